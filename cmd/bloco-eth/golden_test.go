@@ -0,0 +1,87 @@
+//go:build unix
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestGoldenOutput runs the binary on deterministic commands and compares what it
+// prints, and its exit code, with testdata/<name>.golden
+func TestGoldenOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+	}{
+		{"stats", "stats --prefix abc --suffix 12"},
+		{"stats_checksum", "stats --prefix AbC --checksum --case-sensitive"},
+		{"preview", "--preview --with-mnemonic"},
+		{"k8s_generate", "k8s generate --prefix dead --agents 4 --pvc keystores"},
+		{"k8s_legacy_pattern", "k8s generate --pattern dead --agents 4 --allow-emptydir"},
+		{"flags_deprecated", "flags --deprecated"},
+		{"version", "version"},
+		{"invalid_prefix", "--prefix xyz --no-keystore"},
+		{"invalid_repeat", "benchmark --repeat 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkGolden(t, tt.name, runMain(t, tt.args))
+		})
+	}
+}
+
+// runMain re-executes the test binary as bloco-eth with args in a clean
+// environment and returns its combined output followed by the exit code
+func runMain(t *testing.T, args string) string {
+	t.Helper()
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Dir = dir
+	// No BLOCO_* settings, locale or terminal from the caller's environment
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH"), "HOME=" + dir, "LANG=C", "NO_COLOR=1", "TERM=dumb",
+		"BLOCO_TEST_MAIN_ARGS=" + args}
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	code := 0
+	if err := cmd.Run(); err != nil {
+		var exit *exec.ExitError
+		if !errors.As(err, &exit) {
+			t.Fatal(err)
+		}
+		code = exit.ExitCode()
+	}
+	return fmt.Sprintf("%s[exit %d]\n", out.String(), code)
+}
+
+// checkGolden compares got with testdata/<name>.golden, rewriting it with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -run %s -update to create it)", err, t.Name())
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s; if the change is intended, rerun with -update\n--- want\n%s--- got\n%s",
+			path, want, strings.TrimPrefix(got, "\n"))
+	}
+}
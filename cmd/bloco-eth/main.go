@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"bloco-eth/internal/cli"
 	"bloco-eth/internal/config"
@@ -33,20 +34,29 @@ func main() {
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
 		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitConfiguration)
 	}
 
 	// Create CLI application
 	app := cli.NewApplication(cfg, Version, GitCommit, BuildTime)
 
 	// Execute with fang for smooth animations and signal handling
-	if err := fang.Execute(
+	err := fang.Execute(
 		ctx,
 		app.GetRootCommand(),
 		fang.WithNotifySignal(os.Interrupt, syscall.SIGTERM),
-	); err != nil {
+	)
+
+	// Export queued trace spans whatever the outcome
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	if traceErr := app.Shutdown(shutdownCtx); traceErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", traceErr)
+	}
+	cancelShutdown()
+
+	if err != nil {
 		handleError(err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
 
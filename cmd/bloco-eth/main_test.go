@@ -0,0 +1,67 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestMain runs main itself when re-executed by TestInterruptLatency
+func TestMain(m *testing.M) {
+	if args := os.Getenv("BLOCO_TEST_MAIN_ARGS"); args != "" {
+		os.Args = append([]string{"bloco-eth"}, strings.Fields(args)...)
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestInterruptLatency(t *testing.T) {
+	tests := []struct {
+		name string
+		args string
+	}{
+		{"throughput-max", "--batch-strategy throughput-max"},
+		{"fixed large batches", "--batch-strategy fixed"},
+		{"mnemonic", "--with-mnemonic"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(os.Args[0], "-test.run=^$")
+			cmd.Dir = t.TempDir()
+			cmd.Env = append(os.Environ(), "BLOCO_BATCH_SIZE=100000000",
+				"BLOCO_TEST_MAIN_ARGS=--prefix ffffffff --threads 2 --no-keystore --no-logging --tui=false --quiet "+tt.args)
+			if err := cmd.Start(); err != nil {
+				t.Fatal(err)
+			}
+			exited := make(chan error, 1)
+			go func() { exited <- cmd.Wait() }()
+
+			// Give batches time to grow before interrupting the search
+			select {
+			case err := <-exited:
+				t.Fatalf("exited before the interrupt: %v", err)
+			case <-time.After(1500 * time.Millisecond):
+			}
+			interrupted := time.Now()
+			if err := cmd.Process.Signal(syscall.SIGINT); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-exited:
+			case <-time.After(10 * time.Second):
+				_ = cmd.Process.Kill()
+				t.Fatal("still running 10s after SIGINT")
+			}
+			// The default 250ms --cancel-latency, plus shutdown on a loaded machine
+			if latency := time.Since(interrupted); latency > 750*time.Millisecond {
+				t.Errorf("exited %v after SIGINT, want well within 750ms", latency)
+			}
+		})
+	}
+}
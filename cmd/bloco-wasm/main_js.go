@@ -0,0 +1,194 @@
+// Command bloco-wasm exposes the matcher and a single-threaded generator to
+// JavaScript for the in-browser demo:
+//
+//	GOOS=js GOARCH=wasm go build -o web/bloco.wasm ./cmd/bloco-wasm
+//
+// It installs a global bloco object with difficulty, matches, generate and cancel,
+// using the same criteria validation, difficulty and matching code as the CLI,
+// then calls the global blocoReady function if the page defined one.
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/generator"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// maxDemoDifficulty keeps the demo to patterns a single browser thread finds in
+// seconds or minutes (six hex characters)
+const maxDemoDifficulty = 1 << 24
+
+// progressInterval is how often generate reports progress
+const progressInterval = 250 * time.Millisecond
+
+// current cancels the running generate call
+var current struct {
+	sync.Mutex
+	cancel context.CancelFunc
+}
+
+func main() {
+	js.Global().Set("bloco", js.ValueOf(map[string]any{
+		"difficulty": js.FuncOf(difficulty),
+		"matches":    js.FuncOf(matches),
+		"generate":   js.FuncOf(generate),
+		"cancel":     js.FuncOf(cancel),
+	}))
+	if ready := js.Global().Get("blocoReady"); ready.Type() == js.TypeFunction {
+		ready.Invoke()
+	}
+	// Keep the exported functions alive
+	select {}
+}
+
+// criteriaFrom reads {prefix, suffix, checksum, network} into validated criteria
+func criteriaFrom(v js.Value) (wallet.GenerationCriteria, error) {
+	var criteria wallet.GenerationCriteria
+	if v.Type() != js.TypeObject {
+		return criteria, fmt.Errorf("criteria must be an object like {prefix: \"abc\"}")
+	}
+	if p := v.Get("prefix"); p.Type() == js.TypeString {
+		criteria.Prefix = p.String()
+	}
+	if s := v.Get("suffix"); s.Type() == js.TypeString {
+		criteria.Suffix = s.String()
+	}
+	if n := v.Get("network"); n.Type() == js.TypeString {
+		criteria.Network = n.String()
+	}
+	criteria.IsChecksum = v.Get("checksum").Truthy()
+	return criteria, criteria.Validate()
+}
+
+// difficulty returns the expected attempts per match of bloco.difficulty(criteria)
+func difficulty(_ js.Value, args []js.Value) any {
+	if len(args) < 1 {
+		return jsError("difficulty(criteria) needs the criteria")
+	}
+	criteria, err := criteriaFrom(args[0])
+	if err != nil {
+		return jsError(err.Error())
+	}
+	return criteria.Difficulty()
+}
+
+// matches reports whether bloco.matches(address, criteria) holds
+func matches(_ js.Value, args []js.Value) any {
+	if len(args) < 2 {
+		return jsError("matches(address, criteria) needs an address and the criteria")
+	}
+	criteria, err := criteriaFrom(args[1])
+	if err != nil {
+		return jsError(err.Error())
+	}
+	return worker.MatchesCriteria(args[0].String(), criteria)
+}
+
+// generate starts bloco.generate(criteria, onProgress) and returns a promise of
+// {address, privateKey, attempts, seconds}. onProgress, if given, receives
+// {attempts, speed, probability} while the search runs. Run it in a Web Worker:
+// the search does not yield to the page's event loop.
+func generate(_ js.Value, args []js.Value) any {
+	var onProgress js.Value
+	if len(args) > 1 && args[1].Type() == js.TypeFunction {
+		onProgress = args[1]
+	}
+	var criteriaArg js.Value
+	if len(args) > 0 {
+		criteriaArg = args[0]
+	}
+
+	return promise(func(resolve, reject func(any)) {
+		criteria, err := criteriaFrom(criteriaArg)
+		if err != nil {
+			reject(jsError(err.Error()))
+			return
+		}
+		d := criteria.Difficulty()
+		if d > maxDemoDifficulty {
+			reject(jsError(fmt.Sprintf("pattern too hard for the browser demo (%.0f expected attempts); use the CLI", d)))
+			return
+		}
+
+		opts := generator.Options{Threads: 1, Count: 1, ProgressInterval: progressInterval}
+		if !onProgress.IsUndefined() {
+			opts.OnProgress = func(p generator.Progress) {
+				onProgress.Invoke(js.ValueOf(map[string]any{
+					"attempts":    p.Attempts,
+					"speed":       p.Speed,
+					"probability": vanitymath.Probability(d, p.Attempts) * 100,
+				}))
+			}
+		}
+		g, err := generator.New(opts)
+		if err != nil {
+			reject(jsError(err.Error()))
+			return
+		}
+
+		ctx, stop := context.WithCancel(context.Background())
+		current.Lock()
+		if current.cancel != nil {
+			current.cancel()
+		}
+		current.cancel = stop
+		current.Unlock()
+		defer stop()
+
+		results, err := g.Generate(ctx, criteria)
+		if err != nil {
+			reject(jsError(err.Error()))
+			return
+		}
+		r, ok := <-results
+		switch {
+		case !ok || ctx.Err() != nil:
+			reject(jsError("cancelled"))
+		case r.Err != nil:
+			reject(jsError(r.Err.Error()))
+		default:
+			resolve(js.ValueOf(map[string]any{
+				"address":    r.Wallet.Address,
+				"privateKey": r.Wallet.PrivateKey,
+				"attempts":   r.Attempts,
+				"seconds":    r.Duration.Seconds(),
+			}))
+		}
+	})
+}
+
+// cancel stops the running bloco.generate call, whose promise is rejected
+func cancel(_ js.Value, _ []js.Value) any {
+	current.Lock()
+	defer current.Unlock()
+	if current.cancel != nil {
+		current.cancel()
+		current.cancel = nil
+	}
+	return nil
+}
+
+// promise returns a JavaScript promise settled by run on a goroutine of its own
+func promise(run func(resolve, reject func(any))) js.Value {
+	var executor js.Func
+	executor = js.FuncOf(func(_ js.Value, args []js.Value) any {
+		resolve, reject := args[0], args[1]
+		go run(func(v any) { resolve.Invoke(v) }, func(v any) { reject.Invoke(v) })
+		return nil
+	})
+	p := js.Global().Get("Promise").New(executor)
+	executor.Release()
+	return p
+}
+
+// jsError returns a JavaScript Error with message
+func jsError(message string) js.Value {
+	return js.Global().Get("Error").New(message)
+}
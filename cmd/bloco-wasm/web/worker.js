@@ -0,0 +1,31 @@
+// Runs bloco.wasm off the page's main thread and relays its calls as messages
+importScripts("wasm_exec.js");
+
+const ready = new Promise((resolve) => {
+  self.blocoReady = resolve;
+  const go = new Go();
+  WebAssembly.instantiateStreaming(fetch("bloco.wasm"), go.importObject)
+    .then((result) => go.run(result.instance));
+});
+
+self.onmessage = async ({ data }) => {
+  await ready;
+  switch (data.type) {
+    case "difficulty": {
+      const d = bloco.difficulty(data.criteria);
+      postMessage(d instanceof Error ? { type: "error", message: d.message } : { type: "difficulty", difficulty: d });
+      break;
+    }
+    case "generate":
+      try {
+        const wallet = await bloco.generate(data.criteria, (progress) => postMessage({ type: "progress", progress }));
+        postMessage({ type: "wallet", wallet });
+      } catch (err) {
+        postMessage({ type: "error", message: err.message });
+      }
+      break;
+    case "cancel":
+      bloco.cancel();
+      break;
+  }
+};
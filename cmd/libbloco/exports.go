@@ -0,0 +1,60 @@
+package main
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import "unsafe"
+
+// bloco_generate starts a search described by a JSON request such as
+// {"prefix":"abc","suffix":"","checksum":false,"network":"ethereum","count":1,"threads":0}
+// and returns its handle. Invalid requests return a handle whose search has failed.
+//
+//export bloco_generate
+func bloco_generate(request *C.char) C.uint64_t {
+	return C.uint64_t(startSearch(C.GoString(request)))
+}
+
+// bloco_cancel stops a search and waits for it to end. It returns 0, or -1 for an
+// unknown handle.
+//
+//export bloco_cancel
+func bloco_cancel(handle C.uint64_t) C.int {
+	if !cancelSearch(uint64(handle)) {
+		return -1
+	}
+	return 0
+}
+
+// bloco_poll_progress returns the state, attempts, speed and found count of a search
+// as JSON, with the wallets found since the previous poll. Free the string with
+// bloco_free. It returns NULL for an unknown handle.
+//
+//export bloco_poll_progress
+func bloco_poll_progress(handle C.uint64_t) *C.char {
+	snapshot, ok := poll(uint64(handle))
+	if !ok {
+		return nil
+	}
+	return C.CString(snapshot)
+}
+
+// bloco_release cancels a search and frees its handle. It returns 0, or -1 for an
+// unknown handle.
+//
+//export bloco_release
+func bloco_release(handle C.uint64_t) C.int {
+	if !releaseSearch(uint64(handle)) {
+		return -1
+	}
+	return 0
+}
+
+// bloco_free frees a string returned by the library
+//
+//export bloco_free
+func bloco_free(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
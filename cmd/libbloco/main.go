@@ -0,0 +1,209 @@
+// Command libbloco builds the generator as a C shared library for Python, Node,
+// Rust and other tooling that drives searches in-process:
+//
+//	go build -buildmode=c-shared -o libbloco.so ./cmd/libbloco
+//
+// The build also writes libbloco.h. Requests, progress and results cross the C
+// ABI as JSON strings; see exports.go for the functions and python/bloco.py for
+// a ctypes binding.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/generator"
+	"bloco-eth/pkg/wallet"
+)
+
+// progressInterval is how often a search's attempts and speed are refreshed
+const progressInterval = 200 * time.Millisecond
+
+// Search states reported by poll
+const (
+	stateRunning   = "running"
+	stateDone      = "done"
+	stateFailed    = "failed"
+	stateCancelled = "cancelled"
+)
+
+// request is the JSON accepted by bloco_generate
+type request struct {
+	Prefix   string `json:"prefix"`
+	Suffix   string `json:"suffix"`
+	Checksum bool   `json:"checksum"`
+	Network  string `json:"network"`
+	// Count is the number of wallets to find (default 1)
+	Count   int `json:"count"`
+	Threads int `json:"threads"`
+}
+
+// foundWallet is a wallet returned by poll
+type foundWallet struct {
+	Address    string `json:"address"`
+	PrivateKey string `json:"private_key"`
+	Attempts   int64  `json:"attempts"`
+}
+
+// snapshot is the JSON returned by bloco_poll_progress
+type snapshot struct {
+	State    string  `json:"state"`
+	Attempts int64   `json:"attempts"`
+	Speed    float64 `json:"speed"`
+	Found    int     `json:"found"`
+	// Wallets are the wallets found since the previous poll
+	Wallets []foundWallet `json:"wallets"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// search is one bloco_generate call
+type search struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu    sync.Mutex
+	state string
+	// attempts are sampled from the workers; resultAttempts add up the found wallets
+	attempts       int64
+	resultAttempts int64
+	speed          float64
+	found          int
+	pending        []foundWallet
+	err            string
+}
+
+// searches holds the running and finished searches by handle
+var searches = struct {
+	sync.Mutex
+	next uint64
+	byID map[uint64]*search
+}{byID: make(map[uint64]*search)}
+
+// startSearch starts the search described by the JSON request and returns its
+// handle. An invalid request yields a failed search, so a handle is always returned.
+func startSearch(requestJSON string) uint64 {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &search{cancel: cancel, done: make(chan struct{}), state: stateRunning}
+
+	searches.Lock()
+	searches.next++
+	id := searches.next
+	searches.byID[id] = s
+	searches.Unlock()
+
+	req := request{Count: 1}
+	if err := json.Unmarshal([]byte(requestJSON), &req); err != nil {
+		s.finish(stateFailed, "invalid request: "+err.Error())
+		return id
+	}
+	g, err := generator.New(generator.Options{
+		Threads:          req.Threads,
+		Count:            req.Count,
+		ProgressInterval: progressInterval,
+		OnProgress:       s.progress,
+	})
+	if err != nil {
+		s.finish(stateFailed, err.Error())
+		return id
+	}
+	results, err := g.Generate(ctx, wallet.GenerationCriteria{
+		Network:    req.Network,
+		Prefix:     req.Prefix,
+		Suffix:     req.Suffix,
+		IsChecksum: req.Checksum,
+	})
+	if err != nil {
+		s.finish(stateFailed, err.Error())
+		return id
+	}
+
+	go func() {
+		state, message := stateDone, ""
+		for r := range results {
+			if r.Err != nil {
+				state, message = stateFailed, r.Err.Error()
+				continue
+			}
+			s.mu.Lock()
+			s.found++
+			s.resultAttempts += r.Attempts
+			s.pending = append(s.pending, foundWallet{Address: r.Wallet.Address, PrivateKey: r.Wallet.PrivateKey, Attempts: r.Attempts})
+			s.mu.Unlock()
+		}
+		if ctx.Err() != nil {
+			state, message = stateCancelled, ""
+		}
+		s.finish(state, message)
+	}()
+	return id
+}
+
+// progress records the attempts and speed of a running search
+func (s *search) progress(p generator.Progress) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts = p.Attempts
+	s.speed = p.Speed
+}
+
+// finish ends the search in state
+func (s *search) finish(state, message string) {
+	s.mu.Lock()
+	s.state, s.err, s.speed = state, message, 0
+	s.mu.Unlock()
+	s.cancel()
+	close(s.done)
+}
+
+// poll returns the search's progress as JSON, handing over the wallets found since
+// the previous poll; ok is false for an unknown handle
+func poll(id uint64) (string, bool) {
+	s := lookup(id)
+	if s == nil {
+		return "", false
+	}
+	s.mu.Lock()
+	snap := snapshot{State: s.state, Attempts: max(s.attempts, s.resultAttempts), Speed: s.speed, Found: s.found, Wallets: s.pending, Error: s.err}
+	if snap.Wallets == nil {
+		snap.Wallets = []foundWallet{}
+	}
+	s.pending = nil
+	s.mu.Unlock()
+
+	data, _ := json.Marshal(snap)
+	return string(data), true
+}
+
+// cancelSearch stops a search and waits for it to end
+func cancelSearch(id uint64) bool {
+	s := lookup(id)
+	if s == nil {
+		return false
+	}
+	s.cancel()
+	<-s.done
+	return true
+}
+
+// releaseSearch cancels a search and forgets its handle
+func releaseSearch(id uint64) bool {
+	if !cancelSearch(id) {
+		return false
+	}
+	searches.Lock()
+	delete(searches.byID, id)
+	searches.Unlock()
+	return true
+}
+
+// lookup returns the search with the handle, or nil
+func lookup(id uint64) *search {
+	searches.Lock()
+	defer searches.Unlock()
+	return searches.byID[id]
+}
+
+// main is required by -buildmode=c-shared and never runs
+func main() {}
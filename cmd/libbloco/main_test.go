@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pollSnapshot decodes a poll of the handle
+func pollSnapshot(t *testing.T, id uint64) snapshot {
+	t.Helper()
+	data, ok := poll(id)
+	if !ok {
+		t.Fatalf("poll(%d) of a known handle failed", id)
+	}
+	var snap snapshot
+	if err := json.Unmarshal([]byte(data), &snap); err != nil {
+		t.Fatalf("poll returned %q: %v", data, err)
+	}
+	return snap
+}
+
+// waitFinished polls until the search is no longer running
+func waitFinished(t *testing.T, id uint64) (snapshot, []foundWallet) {
+	t.Helper()
+	var wallets []foundWallet
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		snap := pollSnapshot(t, id)
+		wallets = append(wallets, snap.Wallets...)
+		if snap.State != stateRunning {
+			return snap, wallets
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("search did not finish")
+	return snapshot{}, nil
+}
+
+func TestSearch(t *testing.T) {
+	id := startSearch(`{"prefix":"ab","count":2,"threads":2}`)
+	defer releaseSearch(id)
+
+	snap, wallets := waitFinished(t, id)
+	if snap.State != stateDone || snap.Found != 2 || snap.Attempts <= 0 || snap.Error != "" {
+		t.Errorf("final poll = %+v", snap)
+	}
+	// Each found wallet is handed over once
+	if len(wallets) != 2 {
+		t.Fatalf("got %d wallets, want 2", len(wallets))
+	}
+	for _, w := range wallets {
+		if !strings.HasPrefix(strings.TrimPrefix(w.Address, "0x"), "ab") || w.PrivateKey == "" || w.Attempts <= 0 {
+			t.Errorf("wallet = %+v", w)
+		}
+	}
+}
+
+func TestSearch_Cancel(t *testing.T) {
+	id := startSearch(`{"prefix":"abcdef1234","threads":1}`)
+	if !cancelSearch(id) {
+		t.Fatal("cancelSearch() of a known handle failed")
+	}
+	if snap := pollSnapshot(t, id); snap.State != stateCancelled || len(snap.Wallets) != 0 {
+		t.Errorf("poll after cancel = %+v", snap)
+	}
+	if !releaseSearch(id) || releaseSearch(id) || cancelSearch(id) {
+		t.Error("a released handle is still known")
+	}
+	if _, ok := poll(id); ok {
+		t.Error("poll() of a released handle succeeded")
+	}
+}
+
+func TestSearch_InvalidRequest(t *testing.T) {
+	for _, req := range []string{`not json`, `{"prefix":"xyz"}`, `{"count":-1}`} {
+		id := startSearch(req)
+		if snap := pollSnapshot(t, id); snap.State != stateFailed || snap.Error == "" {
+			t.Errorf("request %s: poll = %+v", req, snap)
+		}
+		releaseSearch(id)
+	}
+}
@@ -0,0 +1,104 @@
+"""Minimal ctypes binding for libbloco, the bloco-eth C shared library.
+
+Build the library first:
+
+    go build -buildmode=c-shared -o libbloco.so ./cmd/libbloco
+
+Then:
+
+    python3 cmd/libbloco/python/bloco.py --prefix abc --count 2
+"""
+
+import argparse
+import ctypes
+import json
+import os
+import time
+
+
+class Search:
+    """A search running inside the library, identified by its handle."""
+
+    def __init__(self, lib, handle):
+        self._lib = lib
+        self.handle = handle
+
+    def poll(self):
+        """Return the progress as a dict; "wallets" holds the wallets found since the last poll."""
+        ptr = self._lib.bloco_poll_progress(self.handle)
+        if not ptr:
+            raise ValueError("unknown search handle")
+        try:
+            return json.loads(ctypes.string_at(ptr).decode())
+        finally:
+            self._lib.bloco_free(ptr)
+
+    def cancel(self):
+        self._lib.bloco_cancel(self.handle)
+
+    def release(self):
+        self._lib.bloco_release(self.handle)
+
+    def __enter__(self):
+        return self
+
+    def __exit__(self, *exc):
+        self.release()
+
+
+class Bloco:
+    """Loads libbloco and starts searches."""
+
+    def __init__(self, path=None):
+        path = path or os.environ.get("LIBBLOCO", "./libbloco.so")
+        lib = ctypes.CDLL(path)
+        lib.bloco_generate.argtypes = [ctypes.c_char_p]
+        lib.bloco_generate.restype = ctypes.c_uint64
+        lib.bloco_cancel.argtypes = [ctypes.c_uint64]
+        lib.bloco_cancel.restype = ctypes.c_int
+        lib.bloco_poll_progress.argtypes = [ctypes.c_uint64]
+        lib.bloco_poll_progress.restype = ctypes.c_void_p
+        lib.bloco_release.argtypes = [ctypes.c_uint64]
+        lib.bloco_release.restype = ctypes.c_int
+        lib.bloco_free.argtypes = [ctypes.c_void_p]
+        lib.bloco_free.restype = None
+        self._lib = lib
+
+    def generate(self, prefix="", suffix="", checksum=False, network="ethereum", count=1, threads=0):
+        request = json.dumps({
+            "prefix": prefix,
+            "suffix": suffix,
+            "checksum": checksum,
+            "network": network,
+            "count": count,
+            "threads": threads,
+        })
+        return Search(self._lib, self._lib.bloco_generate(request.encode()))
+
+
+def main():
+    parser = argparse.ArgumentParser(description=__doc__.splitlines()[0])
+    parser.add_argument("--prefix", default="abc")
+    parser.add_argument("--suffix", default="")
+    parser.add_argument("--count", type=int, default=1)
+    parser.add_argument("--library", default=None)
+    args = parser.parse_args()
+
+    with Bloco(args.library).generate(args.prefix, args.suffix, count=args.count) as search:
+        try:
+            while True:
+                status = search.poll()
+                for w in status["wallets"]:
+                    print(f"\r{w['address']}  ({w['attempts']} attempts)")
+                if status["state"] != "running":
+                    if status.get("error"):
+                        raise SystemExit(f"search {status['state']}: {status['error']}")
+                    break
+                print(f"  {status['attempts']} attempts, {status['speed']:.0f}/s", end="\r")
+                time.sleep(0.5)
+        except KeyboardInterrupt:
+            search.cancel()
+
+
+if __name__ == "__main__":
+    main()
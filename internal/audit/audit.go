@@ -0,0 +1,188 @@
+// Package audit keeps an append-only, hash-chained record of sensitive operations.
+// Each entry stores the hash of the one before it, so editing, reordering or removing
+// an entry breaks the chain from that point on. Entries never contain key material.
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/errors"
+)
+
+// GenesisHash is the previous hash of the first entry
+var GenesisHash = strings.Repeat("0", 64)
+
+// maxLineSize bounds a single entry when reading a trail
+const maxLineSize = 1 << 20
+
+// Entry is one audit record
+type Entry struct {
+	Seq      int64             `json:"seq"`
+	Time     time.Time         `json:"time"`
+	Event    string            `json:"event"`
+	Fields   map[string]string `json:"fields,omitempty"`
+	PrevHash string            `json:"prev_hash"`
+	Hash     string            `json:"hash"`
+}
+
+// ComputeHash returns the SHA-256 of the entry's JSON encoding with Hash left empty.
+// Map keys are encoded in sorted order, so the encoding is deterministic.
+func (e Entry) ComputeHash() (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", errors.WrapError(err, errors.ErrorTypeValidation, "audit_hash", "failed to encode audit entry")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Log appends entries to an audit trail file. One process should write a trail at a time.
+type Log struct {
+	mu   sync.Mutex
+	file *os.File
+	seq  int64
+	head string
+}
+
+// Open opens or creates the trail at path, verifying the existing chain so new
+// entries extend a valid head
+func Open(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"open_audit_trail", fmt.Sprintf("failed to open audit trail %s", path))
+	}
+	result, err := Verify(file)
+	if err != nil {
+		file.Close()
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"open_audit_trail", fmt.Sprintf("refusing to extend audit trail %s", path))
+	}
+	return &Log{file: file, seq: result.Entries, head: result.Head}, nil
+}
+
+// Record appends an entry for event and syncs it to disk
+func (l *Log) Record(event string, fields map[string]string) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := Entry{
+		Seq:      l.seq + 1,
+		Time:     time.Now().UTC(),
+		Event:    event,
+		Fields:   fields,
+		PrevHash: l.head,
+	}
+	hash, err := entry.ComputeHash()
+	if err != nil {
+		return err
+	}
+	entry.Hash = hash
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "audit_record", "failed to encode audit entry")
+	}
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "audit_record", "failed to append audit entry")
+	}
+	if err := l.file.Sync(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "audit_record", "failed to sync audit trail")
+	}
+	l.seq = entry.Seq
+	l.head = entry.Hash
+	return nil
+}
+
+// Head returns the hash of the last entry
+func (l *Log) Head() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.head
+}
+
+// Close closes the trail file
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// VerifyResult summarizes a verified chain
+type VerifyResult struct {
+	Entries int64
+	Head    string // hash of the last entry, GenesisHash for an empty trail
+	First   time.Time
+	Last    time.Time
+	// Hashes holds every entry hash, to check a previously recorded head is still present
+	Hashes map[string]int64
+}
+
+// Verify reads a trail and checks every entry's hash, link and sequence number.
+// The returned error names the first line that breaks the chain.
+func Verify(r io.Reader) (VerifyResult, error) {
+	result := VerifyResult{Head: GenesisHash, Hashes: make(map[string]int64)}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+
+		var entry Entry
+		decoder := json.NewDecoder(strings.NewReader(scanner.Text()))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entry); err != nil {
+			return result, brokenChain(line, fmt.Sprintf("not a valid audit entry: %v", err))
+		}
+		if entry.Seq != result.Entries+1 {
+			return result, brokenChain(line, fmt.Sprintf("sequence %d follows %d", entry.Seq, result.Entries))
+		}
+		if entry.PrevHash != result.Head {
+			return result, brokenChain(line, "previous hash does not match the preceding entry")
+		}
+		hash, err := entry.ComputeHash()
+		if err != nil {
+			return result, err
+		}
+		if hash != entry.Hash {
+			return result, brokenChain(line, "entry hash does not match its contents")
+		}
+
+		if result.Entries == 0 {
+			result.First = entry.Time
+		}
+		result.Last = entry.Time
+		result.Entries = entry.Seq
+		result.Head = entry.Hash
+		result.Hashes[entry.Hash] = entry.Seq
+	}
+	if err := scanner.Err(); err != nil {
+		return result, errors.WrapError(err, errors.ErrorTypeValidation, "verify_audit_trail",
+			fmt.Sprintf("failed to read audit trail after line %d", line))
+	}
+	return result, nil
+}
+
+// brokenChain reports where verification failed
+func brokenChain(line int, reason string) error {
+	return errors.NewValidationError("verify_audit_trail", fmt.Sprintf("line %d: %s", line, reason)).
+		WithContext("line", line)
+}
@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTrail(t *testing.T, path string, events ...string) {
+	t.Helper()
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer log.Close()
+	for _, event := range events {
+		if err := log.Record(event, map[string]string{"address": "0xabc"}); err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+}
+
+func verifyFile(t *testing.T, path string) (VerifyResult, error) {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	return Verify(file)
+}
+
+func TestTrailChainsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trail.jsonl")
+	writeTrail(t, path, "config", "wallet_found")
+	writeTrail(t, path, "keystore_write")
+
+	result, err := verifyFile(t, path)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if result.Entries != 3 || result.Head == GenesisHash || result.Hashes[result.Head] != 3 {
+		t.Errorf("result = %+v", result)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("trail mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+		want   string
+	}{
+		{"edited entry", func(lines []string) []string {
+			lines[1] = strings.Replace(lines[1], "0xabc", "0xdef", 1)
+			return lines
+		}, "line 2: entry hash"},
+		{"removed entry", func(lines []string) []string {
+			return append(lines[:1], lines[2:]...)
+		}, "line 2: sequence 3 follows 1"},
+		{"reordered entries", func(lines []string) []string {
+			lines[1], lines[2] = lines[2], lines[1]
+			return lines
+		}, "line 2: sequence"},
+		{"garbage line", func(lines []string) []string {
+			return append(lines, "not json")
+		}, "line 4: not a valid audit entry"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "trail.jsonl")
+			writeTrail(t, path, "config", "wallet_found", "keystore_write")
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lines := tt.tamper(strings.Split(strings.TrimSpace(string(data)), "\n"))
+			if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := verifyFile(t, path); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Verify error = %v, want %q", err, tt.want)
+			}
+			if _, err := Open(path); err == nil {
+				t.Error("Open should refuse to extend a broken chain")
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+// Package chain provides a minimal Ethereum JSON-RPC client used to check whether
+// generated addresses are already in use. It is only used when an RPC URL is configured.
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"bloco-eth/pkg/errors"
+)
+
+// ENSRegistry is the ENS registry address on Ethereum mainnet and major testnets
+const ENSRegistry = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+
+// Function selectors used for reverse resolution
+const (
+	selectorResolver = "0178b8bf" // resolver(bytes32)
+	selectorName     = "691f3431" // name(bytes32)
+)
+
+// DefaultTimeout bounds each RPC request
+const DefaultTimeout = 10 * time.Second
+
+// AddressStatus is the on-chain state of an address
+type AddressStatus struct {
+	Address string   `json:"address"`
+	Balance *big.Int `json:"balance"`
+	Nonce   uint64   `json:"nonce"`
+	ENSName string   `json:"ens_name,omitempty"`
+}
+
+// Unused reports whether the address has no balance, no transactions and no reverse ENS record
+func (s *AddressStatus) Unused() bool {
+	return s.Balance.Sign() == 0 && s.Nonce == 0 && s.ENSName == ""
+}
+
+// Client sends JSON-RPC requests to an Ethereum node
+type Client struct {
+	url    string
+	http   *http.Client
+	nextID atomic.Int64
+}
+
+// NewClient creates a client for the node at url
+func NewClient(url string, timeout time.Duration) *Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{url: url, http: &http.Client{Timeout: timeout}}
+}
+
+// rpcRequest is a JSON-RPC 2.0 request
+type rpcRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int64         `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call invokes method and decodes its result into out
+func (c *Client) call(ctx context.Context, out interface{}, method string, params ...interface{}) error {
+	body, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: c.nextID.Add(1), Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, method, "invalid RPC URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, method, "RPC request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewConfigurationError(method, fmt.Sprintf("RPC endpoint returned %s", resp.Status))
+	}
+
+	var decoded rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, method, "invalid RPC response")
+	}
+	if decoded.Error != nil {
+		return errors.NewConfigurationError(method, fmt.Sprintf("RPC error %d: %s", decoded.Error.Code, decoded.Error.Message))
+	}
+	if err := json.Unmarshal(decoded.Result, out); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, method, "invalid RPC result")
+	}
+	return nil
+}
+
+// Balance returns the balance of address in wei
+func (c *Client) Balance(ctx context.Context, address string) (*big.Int, error) {
+	var result string
+	if err := c.call(ctx, &result, "eth_getBalance", address, "latest"); err != nil {
+		return nil, err
+	}
+	balance, ok := new(big.Int).SetString(strings.TrimPrefix(result, "0x"), 16)
+	if !ok {
+		return nil, errors.NewConfigurationError("eth_getBalance", fmt.Sprintf("invalid balance %q", result))
+	}
+	return balance, nil
+}
+
+// Nonce returns the number of transactions sent from address
+func (c *Client) Nonce(ctx context.Context, address string) (uint64, error) {
+	var result string
+	if err := c.call(ctx, &result, "eth_getTransactionCount", address, "latest"); err != nil {
+		return 0, err
+	}
+	nonce, err := strconv.ParseUint(strings.TrimPrefix(result, "0x"), 16, 64)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrorTypeConfiguration, "eth_getTransactionCount", fmt.Sprintf("invalid nonce %q", result))
+	}
+	return nonce, nil
+}
+
+// ReverseENSName returns the name set in the reverse record of address, if any
+func (c *Client) ReverseENSName(ctx context.Context, address string) (string, error) {
+	node := Namehash(strings.ToLower(strings.TrimPrefix(address, "0x")) + ".addr.reverse")
+	nodeHex := hex.EncodeToString(node[:])
+
+	resolverWord, err := c.ethCall(ctx, ENSRegistry, selectorResolver+nodeHex)
+	if err != nil || len(resolverWord) < 32 {
+		// Chains without the ENS registry return empty data
+		return "", err
+	}
+	resolver := resolverWord[12:32]
+	if bytes.Equal(resolver, make([]byte, 20)) {
+		return "", nil
+	}
+
+	data, err := c.ethCall(ctx, "0x"+hex.EncodeToString(resolver), selectorName+nodeHex)
+	if err != nil {
+		return "", err
+	}
+	return decodeABIString(data)
+}
+
+// ethCall runs a read-only contract call and returns the raw result
+func (c *Client) ethCall(ctx context.Context, to, data string) ([]byte, error) {
+	var result string
+	call := map[string]string{"to": to, "data": "0x" + data}
+	if err := c.call(ctx, &result, "eth_call", call, "latest"); err != nil {
+		return nil, err
+	}
+	decoded, err := hex.DecodeString(strings.TrimPrefix(result, "0x"))
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "eth_call", "invalid call result")
+	}
+	return decoded, nil
+}
+
+// CheckAddress returns the balance, nonce and reverse ENS name of address
+func (c *Client) CheckAddress(ctx context.Context, address string) (*AddressStatus, error) {
+	status := &AddressStatus{Address: address}
+	var err error
+	if status.Balance, err = c.Balance(ctx, address); err != nil {
+		return nil, err
+	}
+	if status.Nonce, err = c.Nonce(ctx, address); err != nil {
+		return nil, err
+	}
+	if status.ENSName, err = c.ReverseENSName(ctx, address); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// Namehash computes the ENS namehash of name
+func Namehash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		label := ethcrypto.Keccak256([]byte(labels[i]))
+		copy(node[:], ethcrypto.Keccak256(node[:], label))
+	}
+	return node
+}
+
+// decodeABIString decodes an ABI-encoded dynamic string return value
+func decodeABIString(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", nil
+	}
+	if len(data) < 64 {
+		return "", fmt.Errorf("ABI string too short")
+	}
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsInt64() || offset.Int64()+32 > int64(len(data)) {
+		return "", fmt.Errorf("invalid ABI string offset")
+	}
+	start := offset.Int64()
+	length := new(big.Int).SetBytes(data[start : start+32])
+	if !length.IsInt64() || start+32+length.Int64() > int64(len(data)) {
+		return "", fmt.Errorf("invalid ABI string length")
+	}
+	return string(data[start+32 : start+32+length.Int64()]), nil
+}
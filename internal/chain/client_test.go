@@ -0,0 +1,142 @@
+package chain
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testAddress = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+
+// fakeNode serves canned JSON-RPC results keyed by method (and eth_call target)
+func fakeNode(t *testing.T, results map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int64             `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request: %v", err)
+			return
+		}
+		key := req.Method
+		if req.Method == "eth_call" {
+			var call struct {
+				To string `json:"to"`
+			}
+			_ = json.Unmarshal(req.Params[0], &call)
+			key += ":" + strings.ToLower(call.To)
+		}
+		result, ok := results[key]
+		if !ok {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{"code": -32601, "message": "method not found"},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}))
+}
+
+func abiString(s string) string {
+	word := func(n int) string {
+		b := make([]byte, 32)
+		b[31] = byte(n)
+		return hex.EncodeToString(b)
+	}
+	padded := make([]byte, (len(s)+31)/32*32)
+	copy(padded, s)
+	return "0x" + word(32) + word(len(s)) + hex.EncodeToString(padded)
+}
+
+func TestNamehash(t *testing.T) {
+	tests := map[string]string{
+		"":        "0000000000000000000000000000000000000000000000000000000000000000",
+		"eth":     "93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae",
+		"foo.eth": "de9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f",
+	}
+	for name, want := range tests {
+		node := Namehash(name)
+		if got := hex.EncodeToString(node[:]); got != want {
+			t.Errorf("Namehash(%q) = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestCheckAddressUnused(t *testing.T) {
+	server := fakeNode(t, map[string]string{
+		"eth_getBalance":                           "0x0",
+		"eth_getTransactionCount":                  "0x0",
+		"eth_call:" + strings.ToLower(ENSRegistry): "0x" + strings.Repeat("0", 64),
+	})
+	defer server.Close()
+
+	status, err := NewClient(server.URL, 0).CheckAddress(context.Background(), testAddress)
+	if err != nil {
+		t.Fatalf("CheckAddress failed: %v", err)
+	}
+	if !status.Unused() {
+		t.Errorf("expected unused address, got %+v", status)
+	}
+}
+
+func TestCheckAddressInUse(t *testing.T) {
+	resolver := "0x4976fb03c32e5b8cfe2b6ccb31c09ba78ebaba41"
+	server := fakeNode(t, map[string]string{
+		"eth_getBalance":                           "0xde0b6b3a7640000",
+		"eth_getTransactionCount":                  "0x2a",
+		"eth_call:" + strings.ToLower(ENSRegistry): "0x" + strings.Repeat("0", 24) + resolver[2:],
+		"eth_call:" + resolver:                     abiString("vanity.eth"),
+	})
+	defer server.Close()
+
+	status, err := NewClient(server.URL, 0).CheckAddress(context.Background(), testAddress)
+	if err != nil {
+		t.Fatalf("CheckAddress failed: %v", err)
+	}
+	if status.Balance.String() != "1000000000000000000" {
+		t.Errorf("balance = %s, want 1 ether", status.Balance)
+	}
+	if status.Nonce != 42 {
+		t.Errorf("nonce = %d, want 42", status.Nonce)
+	}
+	if status.ENSName != "vanity.eth" {
+		t.Errorf("ENS name = %q, want vanity.eth", status.ENSName)
+	}
+	if status.Unused() {
+		t.Error("expected address to be reported as in use")
+	}
+}
+
+func TestCheckAddressWithoutENSRegistry(t *testing.T) {
+	server := fakeNode(t, map[string]string{
+		"eth_getBalance":                           "0x0",
+		"eth_getTransactionCount":                  "0x0",
+		"eth_call:" + strings.ToLower(ENSRegistry): "0x",
+	})
+	defer server.Close()
+
+	status, err := NewClient(server.URL, 0).CheckAddress(context.Background(), testAddress)
+	if err != nil {
+		t.Fatalf("CheckAddress failed: %v", err)
+	}
+	if status.ENSName != "" {
+		t.Errorf("expected no ENS name, got %q", status.ENSName)
+	}
+}
+
+func TestCheckAddressRPCError(t *testing.T) {
+	server := fakeNode(t, map[string]string{})
+	defer server.Close()
+
+	if _, err := NewClient(server.URL, 0).CheckAddress(context.Background(), testAddress); err == nil ||
+		!strings.Contains(err.Error(), "method not found") {
+		t.Errorf("expected RPC error, got %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package chain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"bloco-eth/pkg/errors"
+)
+
+// TransferGas is the gas limit of a plain ETH transfer
+const TransferGas = 21000
+
+// dynamicFeeTxType is the EIP-2718 type byte of EIP-1559 transactions
+const dynamicFeeTxType = 0x02
+
+// FundingTx is an EIP-1559 ETH transfer from a hot wallet to a generated address,
+// encoded like an eth_signTransaction request so offline signers can consume it
+type FundingTx struct {
+	Type                 hexutil.Uint64 `json:"type"`
+	ChainID              *hexutil.Big   `json:"chainId"`
+	Nonce                hexutil.Uint64 `json:"nonce"`
+	From                 string         `json:"from,omitempty"`
+	To                   string         `json:"to"`
+	Value                *hexutil.Big   `json:"value"`
+	Gas                  hexutil.Uint64 `json:"gas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	Input                hexutil.Bytes  `json:"input"`
+	AccessList           []struct{}     `json:"accessList"`
+
+	// SigningHash is the EIP-1559 hash an offline signer must sign
+	SigningHash string `json:"signingHash"`
+	// Raw and Hash are set once the transaction is signed
+	Raw  hexutil.Bytes `json:"raw,omitempty"`
+	Hash string        `json:"hash,omitempty"`
+}
+
+// NewFundingTx builds an unsigned transfer of value wei to the given address
+func NewFundingTx(chainID *big.Int, nonce uint64, from, to string, value, maxFee, priorityFee *big.Int) (*FundingTx, error) {
+	if !common.IsHexAddress(to) {
+		return nil, errors.NewValidationError("funding_tx", fmt.Sprintf("invalid recipient address %q", to))
+	}
+	if from != "" && !common.IsHexAddress(from) {
+		return nil, errors.NewValidationError("funding_tx", fmt.Sprintf("invalid sender address %q", from))
+	}
+	if priorityFee.Cmp(maxFee) > 0 {
+		return nil, errors.NewValidationError("funding_tx", "priority fee cannot exceed max fee")
+	}
+	tx := &FundingTx{
+		Type:                 dynamicFeeTxType,
+		ChainID:              (*hexutil.Big)(chainID),
+		Nonce:                hexutil.Uint64(nonce),
+		To:                   common.HexToAddress(to).Hex(),
+		Value:                (*hexutil.Big)(value),
+		Gas:                  TransferGas,
+		MaxFeePerGas:         (*hexutil.Big)(maxFee),
+		MaxPriorityFeePerGas: (*hexutil.Big)(priorityFee),
+		Input:                hexutil.Bytes{},
+		AccessList:           []struct{}{},
+	}
+	if from != "" {
+		tx.From = common.HexToAddress(from).Hex()
+	}
+	hash, err := tx.signingHash()
+	if err != nil {
+		return nil, err
+	}
+	tx.SigningHash = hexutil.Encode(hash)
+	return tx, nil
+}
+
+// fields returns the RLP payload fields shared by the signing hash and the signed envelope
+func (tx *FundingTx) fields() []interface{} {
+	return []interface{}{
+		tx.ChainID.ToInt(),
+		uint64(tx.Nonce),
+		tx.MaxPriorityFeePerGas.ToInt(),
+		tx.MaxFeePerGas.ToInt(),
+		uint64(tx.Gas),
+		common.HexToAddress(tx.To),
+		tx.Value.ToInt(),
+		[]byte(tx.Input),
+		tx.AccessList,
+	}
+}
+
+// encodeTyped returns the typed envelope 0x02 || rlp(fields)
+func encodeTyped(fields []interface{}) ([]byte, error) {
+	payload, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{dynamicFeeTxType}, payload...), nil
+}
+
+// signingHash returns keccak256(0x02 || rlp(fields))
+func (tx *FundingTx) signingHash() ([]byte, error) {
+	encoded, err := encodeTyped(tx.fields())
+	if err != nil {
+		return nil, err
+	}
+	return ethcrypto.Keccak256(encoded), nil
+}
+
+// Sign signs the transaction with key, which must belong to From when From is set
+func (tx *FundingTx) Sign(key *ecdsa.PrivateKey) error {
+	sender := ethcrypto.PubkeyToAddress(key.PublicKey)
+	if tx.From != "" && !strings.EqualFold(tx.From, sender.Hex()) {
+		return errors.NewValidationError("funding_tx",
+			fmt.Sprintf("signing key belongs to %s, not the sender %s", sender.Hex(), tx.From))
+	}
+	hash, err := tx.signingHash()
+	if err != nil {
+		return err
+	}
+	sig, err := ethcrypto.Sign(hash, key)
+	if err != nil {
+		return err
+	}
+
+	fields := append(tx.fields(),
+		uint64(sig[64]),
+		new(big.Int).SetBytes(sig[:32]),
+		new(big.Int).SetBytes(sig[32:64]),
+	)
+	raw, err := encodeTyped(fields)
+	if err != nil {
+		return err
+	}
+	tx.From = sender.Hex()
+	tx.Raw = raw
+	tx.Hash = hexutil.Encode(ethcrypto.Keccak256(raw))
+	return nil
+}
+
+// ParseUnits converts a decimal amount such as "0.05" into base units with the given decimals
+func ParseUnits(amount string, decimals int) (*big.Int, error) {
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	value.Mul(value, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	if !value.IsInt() {
+		return nil, fmt.Errorf("amount %q has more than %d decimal places", amount, decimals)
+	}
+	return value.Num(), nil
+}
+
+// ChainID returns the chain ID of the node
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return c.callQuantity(ctx, "eth_chainId")
+}
+
+// PendingNonce returns the next nonce of address, including pending transactions
+func (c *Client) PendingNonce(ctx context.Context, address string) (uint64, error) {
+	nonce, err := c.callQuantity(ctx, "eth_getTransactionCount", address, "pending")
+	if err != nil {
+		return 0, err
+	}
+	return nonce.Uint64(), nil
+}
+
+// SuggestFees returns a max fee and priority fee per gas based on the node's gas price
+func (c *Client) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	gasPrice, err := c.callQuantity(ctx, "eth_gasPrice")
+	if err != nil {
+		return nil, nil, err
+	}
+	tip, err := c.callQuantity(ctx, "eth_maxPriorityFeePerGas")
+	if err != nil {
+		// Nodes without EIP-1559 fee estimation pay the whole gas price as tip
+		tip = new(big.Int).Set(gasPrice)
+	}
+	// Leave headroom for base fee increases over the next blocks
+	maxFee := new(big.Int).Add(new(big.Int).Mul(gasPrice, big.NewInt(2)), tip)
+	return maxFee, tip, nil
+}
+
+// SendRawTransaction broadcasts a signed transaction and returns its hash
+func (c *Client) SendRawTransaction(ctx context.Context, raw []byte) (string, error) {
+	var hash string
+	if err := c.call(ctx, &hash, "eth_sendRawTransaction", hexutil.Encode(raw)); err != nil {
+		return "", err
+	}
+	return hash, nil
+}
+
+// callQuantity invokes a method returning a hex quantity
+func (c *Client) callQuantity(ctx context.Context, method string, params ...interface{}) (*big.Int, error) {
+	var result hexutil.Big
+	if err := c.call(ctx, &result, method, params...); err != nil {
+		return nil, err
+	}
+	return result.ToInt(), nil
+}
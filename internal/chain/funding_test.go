@@ -0,0 +1,113 @@
+package chain
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const testHotWalletKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+func testFundingTx(t *testing.T, from string) *FundingTx {
+	t.Helper()
+	tx, err := NewFundingTx(big.NewInt(11155111), 7, from, testAddress, big.NewInt(1e16), big.NewInt(30e9), big.NewInt(2e9))
+	if err != nil {
+		t.Fatalf("NewFundingTx failed: %v", err)
+	}
+	return tx
+}
+
+func TestFundingTxSign(t *testing.T) {
+	key, err := ethcrypto.HexToECDSA(testHotWalletKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := testFundingTx(t, "")
+	if tx.SigningHash != "0xb1099bf16aca556db9e7e47201c592471da2fa74eaa4f9a05acbef564a425f88" {
+		t.Errorf("signing hash = %s", tx.SigningHash)
+	}
+	if err := tx.Sign(key); err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if tx.From != "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23" {
+		t.Errorf("from = %s", tx.From)
+	}
+	if tx.Hash != "0xecfbbec65de7c683cd189cbe53b05618cc82d9a721a50679f802f90692bce797" {
+		t.Errorf("hash = %s", tx.Hash)
+	}
+	if len(tx.Raw) == 0 || tx.Raw[0] != 0x02 {
+		t.Errorf("expected an EIP-1559 typed envelope, got %x", []byte(tx.Raw))
+	}
+}
+
+func TestFundingTxSignWrongSender(t *testing.T) {
+	key, _ := ethcrypto.HexToECDSA(testHotWalletKey)
+	tx := testFundingTx(t, "0x0000000000000000000000000000000000000001")
+	if err := tx.Sign(key); err == nil || !strings.Contains(err.Error(), "not the sender") {
+		t.Errorf("expected sender mismatch error, got %v", err)
+	}
+}
+
+func TestNewFundingTxValidation(t *testing.T) {
+	if _, err := NewFundingTx(big.NewInt(1), 0, "", "not-an-address", big.NewInt(1), big.NewInt(2), big.NewInt(1)); err == nil {
+		t.Error("expected invalid recipient error")
+	}
+	if _, err := NewFundingTx(big.NewInt(1), 0, "", testAddress, big.NewInt(1), big.NewInt(1), big.NewInt(2)); err == nil {
+		t.Error("expected priority fee above max fee to be rejected")
+	}
+}
+
+func TestParseUnits(t *testing.T) {
+	tests := []struct {
+		amount  string
+		want    string
+		wantErr bool
+	}{
+		{"1", "1000000000000000000", false},
+		{"0.05", "50000000000000000", false},
+		{"0.000000000000000001", "1", false},
+		{"0.0000000000000000001", "", true},
+		{"-1", "", true},
+		{"abc", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseUnits(tt.amount, 18)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseUnits(%q) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseUnits(%q) = %s, want %s", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestFundingRPCHelpers(t *testing.T) {
+	server := fakeNode(t, map[string]string{
+		"eth_chainId":             "0xaa36a7",
+		"eth_getTransactionCount": "0x3",
+		"eth_gasPrice":            "0x3b9aca00",
+		"eth_sendRawTransaction":  "0xabc",
+	})
+	defer server.Close()
+	client := NewClient(server.URL, 0)
+	ctx := context.Background()
+
+	if chainID, err := client.ChainID(ctx); err != nil || chainID.Int64() != 11155111 {
+		t.Errorf("ChainID = %v, %v", chainID, err)
+	}
+	if nonce, err := client.PendingNonce(ctx, testAddress); err != nil || nonce != 3 {
+		t.Errorf("PendingNonce = %d, %v", nonce, err)
+	}
+	// eth_maxPriorityFeePerGas is missing, so the whole gas price becomes the tip
+	maxFee, tip, err := client.SuggestFees(ctx)
+	if err != nil || tip.Int64() != 1e9 || maxFee.Int64() != 3e9 {
+		t.Errorf("SuggestFees = %v, %v, %v", maxFee, tip, err)
+	}
+	if hash, err := client.SendRawTransaction(ctx, []byte{0x02}); err != nil || hash != "0xabc" {
+		t.Errorf("SendRawTransaction = %s, %v", hash, err)
+	}
+}
@@ -0,0 +1,124 @@
+// Package checkpoint saves the progress of a search to a file so an interrupted run
+// can resume where it stopped. Files are replaced atomically, so a crash while
+// saving leaves the previous checkpoint intact, and may be encrypted with a
+// passphrase or machine keyring key so that they cannot be read or forged.
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/faultfs"
+)
+
+// Version is the current checkpoint format version
+const Version = 1
+
+// Checkpoint is the saved state of a search. The pattern fields identify the search,
+// so a checkpoint is never resumed by a different one.
+type Checkpoint struct {
+	Version   int                   `json:"version"`
+	UpdatedAt time.Time             `json:"updated_at"`
+	Network   string                `json:"network"`
+	Prefix    string                `json:"prefix,omitempty"`
+	Suffix    string                `json:"suffix,omitempty"`
+	Checksum  bool                  `json:"checksum,omitempty"`
+	Found     int                   `json:"found"`
+	KeyRange  *crypto.KeyRangeState `json:"key_range,omitempty"`
+	Seed      string                `json:"master_seed,omitempty"` // fingerprint of the --master-seed-file seed
+}
+
+// SameSearch reports why other describes a different search than c, or nil
+func (c *Checkpoint) SameSearch(other *Checkpoint) error {
+	if c.Network != other.Network || c.Prefix != other.Prefix || c.Suffix != other.Suffix || c.Checksum != other.Checksum {
+		return fmt.Errorf("checkpoint is for network=%s prefix=%q suffix=%q checksum=%t, not network=%s prefix=%q suffix=%q checksum=%t",
+			c.Network, c.Prefix, c.Suffix, c.Checksum, other.Network, other.Prefix, other.Suffix, other.Checksum)
+	}
+	if c.Seed != other.Seed {
+		return fmt.Errorf("checkpoint is for master seed %q, not %q", c.Seed, other.Seed)
+	}
+	return nil
+}
+
+// Load reads a plaintext checkpoint. A missing file returns an error satisfying os.IsNotExist.
+func Load(path string) (*Checkpoint, error) {
+	return LoadWith(path, nil)
+}
+
+// LoadWith reads a checkpoint encrypted with key, or a plaintext one when key is
+// nil. An encrypted checkpoint that fails authentication is refused, and so is a
+// plaintext one when a key is given, since it could have replaced the encrypted file.
+func LoadWith(path string, key *Key) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sealed sealedFile
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("%s is not a checkpoint: %w", path, err)
+	}
+	switch {
+	case sealed.CipherText != "" && key == nil:
+		return nil, fmt.Errorf("%s is encrypted with a %s key; add --checkpoint-key", path, sealed.Key)
+	case sealed.CipherText == "" && key != nil:
+		return nil, fmt.Errorf("%s is not encrypted; refusing it with --checkpoint-key", path)
+	case key != nil:
+		if data, err = key.open(&sealed); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	var c Checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%s is not a checkpoint: %w", path, err)
+	}
+	if c.Version != Version {
+		return nil, fmt.Errorf("%s has unsupported checkpoint version %d", path, c.Version)
+	}
+	return &c, nil
+}
+
+// Save writes c to path with mode 0600, replacing any previous checkpoint atomically
+func Save(path string, c *Checkpoint) error {
+	return SaveWith(path, c, nil)
+}
+
+// SaveWith is Save, encrypting the checkpoint with key unless it is nil
+func SaveWith(path string, c *Checkpoint, key *Key) error {
+	c.Version = Version
+	c.UpdatedAt = time.Now().UTC()
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+	if key != nil {
+		if data, err = key.seal(data); err != nil {
+			return fmt.Errorf("failed to encrypt checkpoint: %w", err)
+		}
+	}
+
+	tmp, err := faultfs.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	defer faultfs.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := faultfs.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace checkpoint %s: %w", path, err)
+	}
+	return nil
+}
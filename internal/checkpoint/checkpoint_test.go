@@ -0,0 +1,97 @@
+package checkpoint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/faultfs"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.checkpoint")
+	if _, err := Load(path); !os.IsNotExist(err) {
+		t.Fatalf("Load() of a missing file = %v, want a not-exist error", err)
+	}
+
+	saved := &Checkpoint{
+		Network: "ethereum",
+		Prefix:  "abc",
+		Found:   2,
+		KeyRange: &crypto.KeyRangeState{
+			Start: "0x1", End: "0xff", Stride: "1", Next: "0x10", Checked: "16",
+		},
+	}
+	if err := Save(path, saved); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("checkpoint mode = %v, %v", info.Mode(), err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Version != Version || loaded.Found != 2 || loaded.KeyRange == nil || loaded.KeyRange.Next != "0x10" {
+		t.Errorf("Load() = %+v", loaded)
+	}
+	if err := loaded.SameSearch(saved); err != nil {
+		t.Errorf("SameSearch() = %v", err)
+	}
+	if err := loaded.SameSearch(&Checkpoint{Network: "ethereum", Prefix: "abd"}); err == nil {
+		t.Error("expected a different prefix to be a different search")
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Save() left %d files behind", len(entries))
+	}
+}
+
+func TestSave_Faults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.checkpoint")
+	if err := Save(path, &Checkpoint{Network: "ethereum", Prefix: "abc", Found: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, fault := range map[string]faultfs.Fault{
+		"disk full":         {Op: faultfs.OpWrite, Err: syscall.ENOSPC},
+		"partial write":     {Op: faultfs.OpWrite, Short: 8, Err: syscall.ENOSPC},
+		"failed sync":       {Op: faultfs.OpSync, Err: syscall.EIO},
+		"permission denied": {Op: faultfs.OpRename, Path: "search.checkpoint", Err: syscall.EACCES},
+	} {
+		restore := faultfs.Inject(fault)
+		err := Save(path, &Checkpoint{Network: "ethereum", Prefix: "abc", Found: 2})
+		restore()
+		if !errors.Is(err, fault.Err) {
+			t.Errorf("%s: Save() error = %v, want %v", name, err, fault.Err)
+		}
+
+		// The previous checkpoint is kept and the temporary file removed
+		loaded, err := Load(path)
+		if err != nil || loaded.Found != 1 {
+			t.Errorf("%s: Load() = %+v, %v, want the previous checkpoint", name, loaded, err)
+		}
+		if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+			t.Errorf("%s: Save() left %d files behind", name, len(entries))
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	garbage := filepath.Join(dir, "garbage")
+	os.WriteFile(garbage, []byte("not json"), 0600)
+	if _, err := Load(garbage); err == nil {
+		t.Error("expected garbage to be rejected")
+	}
+	future := filepath.Join(dir, "future")
+	os.WriteFile(future, []byte(`{"version": 99}`), 0600)
+	if _, err := Load(future); err == nil {
+		t.Error("expected an unknown version to be rejected")
+	}
+}
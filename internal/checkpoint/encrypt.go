@@ -0,0 +1,178 @@
+package checkpoint
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"bloco-eth/internal/crypto"
+)
+
+// Key sources recorded in encrypted checkpoints
+const (
+	KeySourcePassphrase = "passphrase"
+	KeySourceKeyring    = "keyring"
+)
+
+// checkpointAAD binds the ciphertext to the checkpoint format
+const checkpointAAD = "bloco-eth-checkpoint-v1"
+
+// scryptN is the scrypt cost used to stretch checkpoint passphrases
+var scryptN = 1 << 18
+
+// sealedFile is an encrypted checkpoint. Only the key source, KDF and cipher
+// parameters are stored in the clear; AES-256-GCM authenticates everything, so
+// a modified file fails to open instead of resuming a forged search.
+type sealedFile struct {
+	Version    int                  `json:"version"`
+	Key        string               `json:"key"`
+	KDF        string               `json:"kdf,omitempty"`
+	KDFParams  *crypto.ScryptParams `json:"kdfparams,omitempty"`
+	Cipher     string               `json:"cipher"`
+	Nonce      string               `json:"nonce"`
+	CipherText string               `json:"ciphertext"`
+}
+
+// Key encrypts and authenticates checkpoints. A passphrase key is stretched
+// with scrypt once per salt, so saving every interval stays cheap.
+type Key struct {
+	source string
+	secret []byte
+
+	params *crypto.ScryptParams
+	key    []byte
+}
+
+// PassphraseKey returns a key derived from a user passphrase
+func PassphraseKey(passphrase string) (*Key, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("checkpoint passphrase cannot be empty")
+	}
+	return &Key{source: KeySourcePassphrase, secret: []byte(passphrase)}, nil
+}
+
+// RawKey returns a key for a 32-byte secret such as one kept in the machine keyring
+func RawKey(secret []byte) (*Key, error) {
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("checkpoint key must be 32 bytes, got %d", len(secret))
+	}
+	return &Key{source: KeySourceKeyring, secret: secret, key: secret}, nil
+}
+
+// Source returns where the key comes from: passphrase or keyring
+func (k *Key) Source() string {
+	return k.source
+}
+
+// derive returns the AES key for params, deriving it only when the salt changes
+func (k *Key) derive(params *crypto.ScryptParams) ([]byte, error) {
+	if k.source != KeySourcePassphrase {
+		return k.key, nil
+	}
+	if k.key != nil && k.params != nil && *k.params == *params {
+		return k.key, nil
+	}
+	if err := crypto.ValidateScryptParams(params); err != nil {
+		return nil, err
+	}
+	if params.DKLen != 32 {
+		return nil, fmt.Errorf("checkpoint key length must be 32, got %d", params.DKLen)
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt hex: %w", err)
+	}
+	key, err := crypto.DeriveKeyScrypt(k.secret, salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, err
+	}
+	k.params, k.key = params, key
+	return key, nil
+}
+
+// seal encrypts data, reusing the salt of the last checkpoint opened or sealed
+func (k *Key) seal(data []byte) ([]byte, error) {
+	file := sealedFile{Version: Version, Key: k.source, Cipher: "aes-256-gcm"}
+	if k.source == KeySourcePassphrase {
+		params := k.params
+		if params == nil {
+			salt, err := crypto.GenerateRandomBytes(32)
+			if err != nil {
+				return nil, err
+			}
+			params = &crypto.ScryptParams{DKLen: 32, N: scryptN, R: 8, P: 1, Salt: hex.EncodeToString(salt)}
+		}
+		file.KDF, file.KDFParams = "scrypt", params
+	}
+	key, err := k.derive(file.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := crypto.GenerateRandomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+	file.Nonce = hex.EncodeToString(nonce)
+	file.CipherText = hex.EncodeToString(aead.Seal(nil, nonce, data, file.aad()))
+	return json.MarshalIndent(file, "", "  ")
+}
+
+// open authenticates and decrypts a sealed checkpoint
+func (k *Key) open(file *sealedFile) ([]byte, error) {
+	if file.Version != Version || file.Cipher != "aes-256-gcm" {
+		return nil, fmt.Errorf("unsupported encrypted checkpoint (version %d, cipher %q)", file.Version, file.Cipher)
+	}
+	if file.Key != k.source {
+		return nil, fmt.Errorf("checkpoint is encrypted with a %s key, not a %s key", file.Key, k.source)
+	}
+	if k.source == KeySourcePassphrase && (file.KDF != "scrypt" || file.KDFParams == nil) {
+		return nil, fmt.Errorf("passphrase checkpoint has no scrypt parameters")
+	}
+	key, err := k.derive(file.KDFParams)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hex.DecodeString(file.Nonce)
+	if err != nil || len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid checkpoint nonce")
+	}
+	ciphertext, err := hex.DecodeString(file.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint ciphertext hex: %w", err)
+	}
+	data, err := aead.Open(nil, nonce, ciphertext, file.aad())
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint failed authentication: wrong key or tampered file")
+	}
+	return data, nil
+}
+
+// aad covers the clear header, so switching the key source or KDF parameters
+// is detected like a change to the ciphertext
+func (f *sealedFile) aad() []byte {
+	aad := fmt.Sprintf("%s|%d|%s|%s", checkpointAAD, f.Version, f.Key, f.Cipher)
+	if f.KDFParams != nil {
+		p := f.KDFParams
+		aad += fmt.Sprintf("|%s|%d|%d|%d|%d|%s", f.KDF, p.N, p.R, p.P, p.DKLen, p.Salt)
+	}
+	return []byte(aad)
+}
+
+// newAEAD creates the AES-256-GCM cipher for a checkpoint key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,120 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/crypto"
+)
+
+func fastScrypt(t *testing.T) {
+	previous := scryptN
+	scryptN = 1024
+	t.Cleanup(func() { scryptN = previous })
+}
+
+func testCheckpoint() *Checkpoint {
+	return &Checkpoint{
+		Network: "ethereum",
+		Prefix:  "abc",
+		Found:   1,
+		KeyRange: &crypto.KeyRangeState{
+			Start: "0x1", End: "0xff", Stride: "1", Next: "0x10", Checked: "16",
+		},
+	}
+}
+
+func TestSaveWith_Passphrase(t *testing.T) {
+	fastScrypt(t)
+	path := filepath.Join(t.TempDir(), "search.checkpoint")
+	key, err := PassphraseKey("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveWith(path, testCheckpoint(), key); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if bytes.Contains(data, []byte(`"prefix"`)) || bytes.Contains(data, []byte(`"key_range"`)) {
+		t.Error("encrypted checkpoint contains the search state in the clear")
+	}
+
+	// A fresh key for the same passphrase resumes and keeps the salt
+	resumed, _ := PassphraseKey("correct horse")
+	loaded, err := LoadWith(path, resumed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.KeyRange.Next != "0x10" || loaded.Prefix != "abc" {
+		t.Errorf("LoadWith() = %+v", loaded)
+	}
+	if err := SaveWith(path, loaded, resumed); err != nil {
+		t.Fatal(err)
+	}
+	var before, after sealedFile
+	json.Unmarshal(data, &before)
+	resaved, _ := os.ReadFile(path)
+	json.Unmarshal(resaved, &after)
+	if before.KDFParams.Salt != after.KDFParams.Salt || before.Nonce == after.Nonce {
+		t.Error("resaving should keep the salt and draw a fresh nonce")
+	}
+
+	wrong, _ := PassphraseKey("wrong horse")
+	if _, err := LoadWith(path, wrong); err == nil || !strings.Contains(err.Error(), "authentication") {
+		t.Errorf("wrong passphrase: err = %v", err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "encrypted") {
+		t.Errorf("Load() of an encrypted checkpoint: err = %v", err)
+	}
+	if _, err := PassphraseKey(""); err == nil {
+		t.Error("empty passphrase was accepted")
+	}
+}
+
+func TestLoadWith_Tampered(t *testing.T) {
+	fastScrypt(t)
+	path := filepath.Join(t.TempDir(), "search.checkpoint")
+	key, _ := RawKey(bytes.Repeat([]byte{7}, 32))
+	if err := SaveWith(path, testCheckpoint(), key); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	var file sealedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+
+	flipped := []byte(file.CipherText)
+	if flipped[0] == '0' {
+		flipped[0] = '1'
+	} else {
+		flipped[0] = '0'
+	}
+	tampered := file
+	tampered.CipherText = string(flipped)
+	relabeled := file
+	relabeled.Cipher = "aes-256-gcm "
+
+	for name, forged := range map[string]sealedFile{"ciphertext": tampered, "header": relabeled} {
+		out, _ := json.Marshal(forged)
+		os.WriteFile(path, out, 0600)
+		if _, err := LoadWith(path, key); err == nil {
+			t.Errorf("checkpoint with a modified %s was accepted", name)
+		}
+	}
+
+	// A plaintext checkpoint cannot stand in for an encrypted one
+	if err := Save(path, testCheckpoint()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadWith(path, key); err == nil || !strings.Contains(err.Error(), "not encrypted") {
+		t.Errorf("plaintext checkpoint with a key: err = %v", err)
+	}
+	if _, err := RawKey([]byte("short")); err == nil {
+		t.Error("short raw key was accepted")
+	}
+}
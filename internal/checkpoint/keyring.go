@@ -0,0 +1,91 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"bloco-eth/internal/crypto"
+)
+
+// The checkpoint key is stored in the machine keyring under this service and account
+const (
+	keyringService = "bloco-eth"
+	keyringAccount = "checkpoint"
+)
+
+// keyringTool runs a keyring command with input on stdin; tests replace it
+var keyringTool = func(input []byte, name string, args ...string) ([]byte, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("no machine keyring: %s was not found in PATH; use --checkpoint-key file:<path>", name)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return stdout.Bytes(), fmt.Errorf("%s failed: %v: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// KeyringKey returns the checkpoint key kept in the machine keyring: the Secret
+// Service through secret-tool on Linux, the login keychain on macOS. The first
+// call creates a random key and stores it.
+func KeyringKey() (*Key, error) {
+	lookup, store, err := keyringCommands()
+	if err != nil {
+		return nil, err
+	}
+	out, lookupErr := keyringTool(nil, lookup[0], lookup[1:]...)
+	if secret := strings.TrimSpace(string(out)); secret != "" {
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		key, err := hex.DecodeString(secret)
+		if err != nil {
+			return nil, fmt.Errorf("keyring entry %s/%s is not a checkpoint key", keyringService, keyringAccount)
+		}
+		return RawKey(key)
+	}
+	if lookupErr != nil && strings.Contains(lookupErr.Error(), "not found in PATH") {
+		return nil, lookupErr
+	}
+
+	key, err := crypto.GenerateRandomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+	input, args := store(hex.EncodeToString(key))
+	if _, err := keyringTool(input, args[0], args[1:]...); err != nil {
+		return nil, fmt.Errorf("failed to store the checkpoint key in the keyring: %w", err)
+	}
+	return RawKey(key)
+}
+
+// keyringCommands returns the lookup command and a builder for the store
+// command of this platform. The secret is passed on stdin, never as an argument.
+func keyringCommands() (lookup []string, store func(secret string) ([]byte, []string), err error) {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		lookup = []string{"secret-tool", "lookup", "service", keyringService, "account", keyringAccount}
+		store = func(secret string) ([]byte, []string) {
+			return []byte(secret), []string{"secret-tool", "store", "--label", "bloco-eth checkpoint key",
+				"service", keyringService, "account", keyringAccount}
+		}
+	case "darwin":
+		lookup = []string{"security", "find-generic-password", "-s", keyringService, "-a", keyringAccount, "-w"}
+		store = func(secret string) ([]byte, []string) {
+			command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", keyringService, keyringAccount, secret)
+			return []byte(command), []string{"security", "-i"}
+		}
+	default:
+		return nil, nil, fmt.Errorf("no machine keyring support on %s; use --checkpoint-key file:<path>", runtime.GOOS)
+	}
+	return lookup, store, nil
+}
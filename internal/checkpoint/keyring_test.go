@@ -0,0 +1,50 @@
+package checkpoint
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestKeyringKey(t *testing.T) {
+	if _, _, err := keyringCommands(); err != nil {
+		t.Skipf("no keyring on %s", runtime.GOOS)
+	}
+	stored := ""
+	previous := keyringTool
+	keyringTool = func(input []byte, name string, args ...string) ([]byte, error) {
+		if input == nil {
+			if stored == "" {
+				return nil, fmt.Errorf("%s failed: exit status 1", name)
+			}
+			return []byte(stored + "\n"), nil
+		}
+		if runtime.GOOS == "darwin" {
+			fmt.Sscanf(string(input), "add-generic-password -U -s bloco-eth -a checkpoint -w %s", &stored)
+		} else {
+			stored = string(input)
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { keyringTool = previous })
+
+	created, err := KeyringKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored == "" || created.Source() != KeySourceKeyring {
+		t.Fatalf("key was not stored in the keyring (source %s)", created.Source())
+	}
+	loaded, err := KeyringKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(loaded.key) != string(created.key) {
+		t.Error("second lookup returned a different key")
+	}
+
+	stored = "not hex"
+	if _, err := KeyringKey(); err == nil {
+		t.Error("malformed keyring entry was accepted")
+	}
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// plainOutput is set by --accessible: no TUI, progress bars, carriage returns, colors or emoji
+var plainOutput atomic.Bool
+
+// applyAccessibility switches to plain output for screen readers and log files
+func (app *Application) applyAccessibility(cmd *cobra.Command) {
+	accessible, _ := cmd.Flags().GetBool("accessible")
+	plainOutput.Store(accessible)
+	if accessible {
+		app.config.TUI.Enabled = false
+		// Styled help and errors honor NO_COLOR
+		_ = os.Setenv("NO_COLOR", "1")
+	}
+}
+
+// prepareOutput configures language and accessibility before any command runs
+func (app *Application) prepareOutput(cmd *cobra.Command, args []string) error {
+	app.applyAccessibility(cmd)
+	return app.applyLanguage(cmd, args)
+}
+
+// icon returns the emoji followed by a space, or nothing in accessible mode
+func icon(emoji string) string {
+	if plainOutput.Load() {
+		return ""
+	}
+	return emoji + " "
+}
+
+// bullet returns the list marker for indented items
+func bullet() string {
+	if plainOutput.Load() {
+		return "-"
+	}
+	return "•"
+}
+
+// printRule prints the heading underline, which accessible mode leaves out
+func printRule() {
+	if !plainOutput.Load() {
+		fmt.Printf("═══════════════════════════════════════\n")
+	}
+}
+
+// statusLines prints a plain status line on every progress tick; it replaces
+// progress bars in accessible mode
+func statusLines(criteria wallet.GenerationCriteria, wallets int, percents []float64) worker.ProgressSubscriber {
+	difficulty := calculateDifficulty(criteria)
+	targets := utils.CalculateETAPercentiles(difficulty, wallets, percents)
+	return func(e worker.ProgressEvent) {
+		if e.Type != worker.ProgressTick {
+			return
+		}
+		fmt.Println(statusLine(e.Stats.TotalAttempts, e.Stats.TotalSpeed, difficulty,
+			utils.EstimateETAPercentiles(targets, e.Stats.TotalAttempts, e.ETASpeed)))
+	}
+}
+
+// statusLine formats one accessible progress line
+func statusLine(attempts int64, speed, difficulty float64, etas []utils.ETAPercentile) string {
+	probability := vanitymath.Probability(difficulty, attempts) * 100
+	line := fmt.Sprintf("Status: %s attempts, %s addresses per second, %s percent probability",
+		formatLargeNumber(attempts), i18n.FormatDecimal(speed, 0), i18n.FormatDecimal(probability, 1))
+	for _, eta := range etas {
+		switch {
+		case eta.Attempts < 0:
+		case eta.Reached():
+			line += fmt.Sprintf(", %g percent reached", eta.Percent)
+		case eta.Remaining > 0:
+			line += fmt.Sprintf(", %g percent in %s", eta.Percent, formatDuration(eta.Remaining))
+		}
+	}
+	return line
+}
@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/utils"
+)
+
+func TestAccessibleHelpers(t *testing.T) {
+	defer plainOutput.Store(false)
+
+	if icon("✅") != "✅ " || bullet() != "•" {
+		t.Errorf("default output should keep emoji and bullets")
+	}
+	plainOutput.Store(true)
+	if icon("✅") != "" || bullet() != "-" {
+		t.Errorf("accessible output should drop emoji and use plain bullets")
+	}
+}
+
+func TestStatusLine(t *testing.T) {
+	etas := []utils.ETAPercentile{
+		{Percent: 50, Attempts: 100, Remaining: 0},
+		{Percent: 90, Attempts: 300, Remaining: 90 * time.Second},
+		{Percent: 99, Attempts: -1, Remaining: -1},
+	}
+	line := statusLine(150, 1000, 256, etas)
+
+	for _, want := range []string{"Status: 150 attempts", "1000 addresses per second", "percent probability",
+		"50 percent reached", "90 percent in 1.5m"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("status line %q is missing %q", line, want)
+		}
+	}
+	if strings.Contains(line, "99 percent") || strings.ContainsAny(line, "\r\x1b") {
+		t.Errorf("status line %q should skip unknown ETAs and contain no control characters", line)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// statSorts are the --sort-by metrics; wallets sort by them highest first
+var statSorts = map[string]func(crypto.AddressStats) int{
+	"zero-bytes":    func(s crypto.AddressStats) int { return s.ZeroBytes },
+	"leading-zeros": func(s crypto.AddressStats) int { return s.LeadingZeroBytes },
+	"uppercase":     func(s crypto.AddressStats) int { return s.ChecksumUppercase },
+}
+
+// parseSortFlag validates --sort-by, which orders batch results and the account report
+func (app *Application) parseSortFlag(cmd *cobra.Command) error {
+	app.sortBy, _ = cmd.Flags().GetString("sort-by")
+	if app.sortBy == "" {
+		return nil
+	}
+	if _, ok := statSorts[app.sortBy]; !ok {
+		names := make([]string, 0, len(statSorts))
+		for name := range statSorts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return errors.NewValidationError("parse_flags",
+			fmt.Sprintf("unsupported --sort-by %q (use %s)", app.sortBy, strings.Join(names, ", ")))
+	}
+	if network, _ := cmd.Flags().GetString("network"); network != "ethereum" {
+		return errors.NewValidationError("parse_flags", "--sort-by orders Ethereum addresses; it cannot be used with --network "+network)
+	}
+	return nil
+}
+
+// sortWallets orders wallets by the --sort-by metric, keeping the order they were
+// found in among equals; without --sort-by it leaves them as they are
+func sortWallets[T any](app *Application, items []T, walletOf func(T) *wallet.Wallet) {
+	metric, ok := statSorts[app.sortBy]
+	if !ok {
+		return
+	}
+	value := func(item T) int {
+		stats, ok := crypto.NewAddressStats(walletOf(item).Address)
+		if !ok {
+			return -1
+		}
+		return metric(stats)
+	}
+	slices.SortStableFunc(items, func(a, b T) int { return cmp.Compare(value(b), value(a)) })
+}
+
+// printZeroBytes describes how --min-zero-bytes changes the difficulty
+func printZeroBytes(criteria wallet.GenerationCriteria) {
+	if criteria.MinZeroBytes == 0 {
+		return
+	}
+	chance := vanitymath.ZeroByteProbability(strings.ToLower(criteria.Prefix), strings.ToLower(criteria.Suffix), criteria.MinZeroBytes)
+	fmt.Printf("Zero bytes: at least %d; ~%.4g%% of matches have them, and difficulty and estimates include this (x%.6g)\n",
+		criteria.MinZeroBytes, chance*100, 1/chance)
+}
+
+// printAddressStats prints the gas-related stats of an Ethereum wallet's address
+func printAddressStats(w *wallet.Wallet, indent string) {
+	if stats, ok := crypto.NewAddressStats(w.Address); ok {
+		fmt.Printf("%sZero bytes: %d (%d leading), checksum uppercase letters: %d\n",
+			indent, stats.ZeroBytes, stats.LeadingZeroBytes, stats.ChecksumUppercase)
+	}
+}
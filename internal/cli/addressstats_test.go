@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"testing"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestSortWallets(t *testing.T) {
+	wallets := []*wallet.Wallet{
+		{Address: "0xab0fffffffffffffffffffffffffffffffffffff"},
+		{Address: "0x00ab00ffffffffffffffffffffffffffffffffff"},
+		{Address: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT"},
+		{Address: "0xabff00ffffffffffffffffffffffffffffffffff"},
+	}
+	byAddress := func(w *wallet.Wallet) *wallet.Wallet { return w }
+
+	app := &Application{}
+	sortWallets(app, wallets, byAddress)
+	if wallets[0].Address != "0xab0fffffffffffffffffffffffffffffffffffff" {
+		t.Error("sortWallets() reordered wallets without --sort-by")
+	}
+
+	app.sortBy = "zero-bytes"
+	sortWallets(app, wallets, byAddress)
+	want := []string{
+		"0x00ab00ffffffffffffffffffffffffffffffffff",
+		"0xabff00ffffffffffffffffffffffffffffffffff",
+		"0xab0fffffffffffffffffffffffffffffffffffff",
+		"1BoatSLRHtKNngkdXEeobR76b53LETtpyT",
+	}
+	for i, w := range wallets {
+		if w.Address != want[i] {
+			t.Errorf("wallet %d = %s, want %s", i, w.Address, want[i])
+		}
+	}
+
+	app.sortBy = "leading-zeros"
+	sortWallets(app, wallets, byAddress)
+	if wallets[0].Address != "0x00ab00ffffffffffffffffffffffffffffffffff" || wallets[1].Address != "0xabff00ffffffffffffffffffffffffffffffffff" {
+		t.Errorf("sortWallets(leading-zeros) = %s, %s; want the leading zero byte first, then the found order",
+			wallets[0].Address, wallets[1].Address)
+	}
+}
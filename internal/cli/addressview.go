@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/tui"
+	"bloco-eth/pkg/wallet"
+)
+
+// addressMarks marks the characters of a found address that matched criteria and,
+// for Ethereum checksum searches, the letters EIP-55 made uppercase
+func addressMarks(address string, criteria wallet.GenerationCriteria) []tui.AddressMark {
+	checksum := criteria.IsChecksum && (criteria.Network == "ethereum" || criteria.Network == "")
+	return tui.MarkAddress(address, criteria.Prefix, criteria.Suffix, checksum)
+}
+
+// addressStyle returns the style manager that colors found addresses on stdout, or
+// nil when stdout is not a terminal or output is plain
+func addressStyle(criteria wallet.GenerationCriteria) *tui.StyleManager {
+	if !criteria.IsChecksum || plainOutput.Load() || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return nil
+	}
+	return tui.NewStyleManagerWithCapabilities(tui.NewTUIManager().DetectCapabilities())
+}
+
+// printFoundAddress prints the address line of a found wallet. On a terminal the
+// address of a checksum search shows which characters matched the pattern and which
+// letters the checksum made uppercase; elsewhere it is printed as is.
+func printFoundAddress(style *tui.StyleManager, indent, address string, criteria wallet.GenerationCriteria) {
+	marks := addressMarks(address, criteria)
+	if style == nil || !tui.HasMarks(marks) {
+		fmt.Println(indent + i18n.T("result.address", address))
+		return
+	}
+	label := strings.TrimSuffix(i18n.T("result.address", address), address)
+	styled, markers := style.FormatAddress(marks)
+	fmt.Println(indent + label + styled)
+	if markers != "" {
+		fmt.Println(indent + strings.Repeat(" ", lipgloss.Width(label)) + markers)
+	}
+}
+
+// printExplorerLink prints the --explorer link of a found wallet, if it has one
+func (app *Application) printExplorerLink(w *wallet.Wallet, indent string) {
+	if link := app.explorer.URL(w.Network, w.Address); link != "" {
+		fmt.Println(indent + i18n.T("result.explorer", explorerLink(link)))
+	}
+}
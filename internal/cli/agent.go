@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/server"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// leaseRetryDelay is how long an agent waits when every key of its keyspace is leased
+const leaseRetryDelay = 10 * time.Second
+
+// createAgentCommand creates the agent subcommand for distributed keyspace searches
+func (app *Application) createAgentCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "agent",
+		Short:       "Search keyspace leases handed out by a serve coordinator",
+		Annotations: map[string]string{unhardenedAnnotation: "true"},
+		Long: `Search a keyspace created on a running "bloco-eth serve" coordinator.
+
+The agent leases a block of keys, searches it in order and asks for the next
+block until the keyspace has the requested wallets or every key was searched.
+Leases are renewed while the agent searches; if it dies, the coordinator hands
+its keys to another agent once the lease expires. Wallets are saved locally as
+keystore files; only their addresses are reported to the coordinator.`,
+		Example: `  curl -X POST localhost:8080/keyspaces -H 'Content-Type: application/json' -d '{"prefix":"abcdef"}'
+  bloco-eth agent --server http://coordinator:8080 --keyspace <id>`,
+		Args: cobra.NoArgs,
+		RunE: app.runAgent,
+	}
+
+	cmd.Flags().String("server", "http://127.0.0.1:8080", "Base URL of the serve coordinator")
+	cmd.Flags().String("api-key", "", "API key for coordinators started with --api-keys (default $BLOCO_API_KEY)")
+	cmd.Flags().String("keyspace", "", "ID of the keyspace to search")
+	cmd.Flags().String("agent-name", "", "Name reported with leases (default: the host name)")
+
+	return cmd
+}
+
+// runAgent leases and searches keys of a keyspace until the keyspace is finished
+func (app *Application) runAgent(cmd *cobra.Command, args []string) error {
+	if err := app.parseFlags(cmd); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", "failed to parse command flags")
+	}
+	id, _ := cmd.Flags().GetString("keyspace")
+	if id == "" {
+		return errors.NewValidationError("agent", "--keyspace is required")
+	}
+	name, _ := cmd.Flags().GetString("agent-name")
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+
+	defer app.finishScreening()
+
+	ctx := cmd.Context()
+	path := "/keyspaces/" + url.PathEscape(id)
+	ks, err := app.agentKeyspace(ctx, cmd, path)
+	if err != nil {
+		return err
+	}
+	criteria := ks.Request.Criteria()
+	quiet := app.config.CLI.QuietMode
+	if !quiet {
+		fmt.Println(i18n.T("agent.searching", name, ks.ID, ks.Range, criteria.GetPattern(), app.config.Worker.ThreadCount))
+	}
+
+	pool := app.newWorkerPool(criteria.Network)
+	if err := pool.Start(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeWorker, "start_workers", "failed to start worker pool")
+	}
+	defer func() {
+		if err := pool.Shutdown(); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("warn.pool_shutdown", err))
+		}
+	}()
+
+	// Waiting for a lease is not a stall
+	var idle atomic.Bool
+	health := poolHealthProvider(pool, ks.Request.Count)
+	stopHealth, err := app.startHealthServer(cmd, server.HealthProviderFunc(func() server.HealthStatus {
+		status := health.HealthStatus()
+		if idle.Load() {
+			status.BacklogDepth = 0
+		}
+		return status
+	}))
+	if err != nil {
+		return err
+	}
+	defer stopHealth()
+
+	for {
+		var lease server.Lease
+		status, err := serveRequest(ctx, cmd, http.MethodPost, path+"/leases", "acquire_lease",
+			map[string]string{"agent": name}, &lease)
+		switch {
+		case status == http.StatusGone:
+			current, err := app.agentKeyspace(ctx, cmd, path)
+			if err != nil {
+				return err
+			}
+			return app.agentFinished(current)
+		case status == http.StatusConflict:
+			idle.Store(true)
+			select {
+			case <-ctx.Done():
+				return errors.NewCancellationError("agent", "agent stopped")
+			case <-time.After(leaseRetryDelay):
+			}
+			idle.Store(false)
+			continue
+		case err != nil:
+			return err
+		}
+
+		if err := app.searchLease(ctx, cmd, pool, criteria, path, lease, false); err != nil {
+			return err
+		}
+	}
+}
+
+// searchLease searches the keys of one lease, renewing it and reporting wallets as
+// they are found. Losing the lease to another agent is not an error. A donated
+// search keeps nothing: only the addresses found leave this machine.
+func (app *Application) searchLease(ctx context.Context, cmd *cobra.Command, pool *worker.Pool,
+	criteria wallet.GenerationCriteria, keyspacePath string, lease server.Lease, donated bool) error {
+	keyRange, err := crypto.ParseKeyRange(lease.Range, lease.Stride)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "agent", "coordinator sent an invalid lease")
+	}
+	cursor := crypto.NewKeyRangeCursor(keyRange)
+	pool.SetKeyRange(cursor)
+	if !app.config.CLI.QuietMode {
+		fmt.Println(i18n.T("agent.lease", lease.ID, formatLargeNumber(int64(lease.Keys)), lease.Range))
+	}
+
+	leaseCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	leasePath := keyspacePath + "/leases/" + url.PathEscape(lease.ID)
+
+	var mu sync.Mutex
+	var found []string
+	var lost atomic.Bool
+	report := func() server.LeaseReport {
+		mu.Lock()
+		defer mu.Unlock()
+		return server.LeaseReport{Checked: cursor.Checked().Uint64(), Addresses: append([]string(nil), found...)}
+	}
+	renew := func() {
+		status, err := serveRequest(ctx, cmd, http.MethodPost, leasePath+"/renew", "renew_lease", report(), nil)
+		switch {
+		case status == http.StatusGone:
+			// The keyspace is finished or the lease went to another agent
+			lost.Store(true)
+			cancel()
+		case err != nil && ctx.Err() == nil:
+			fmt.Fprintln(os.Stderr, i18n.T("warn.error", err))
+		}
+	}
+
+	interval := time.Duration(lease.TTLSeconds * float64(time.Second) / 3)
+	if interval < time.Second {
+		interval = time.Second
+	}
+	renewDone := make(chan struct{})
+	go func() {
+		defer close(renewDone)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-leaseCtx.Done():
+				return
+			case <-ticker.C:
+				renew()
+			}
+		}
+	}()
+	stopRenewing := func() {
+		cancel()
+		<-renewDone
+	}
+	// Another agent searches the lease from its start
+	abandon := func() {
+		if _, err := serveRequest(context.Background(), cmd, http.MethodDelete, leasePath, "abandon_lease", nil, nil); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("warn.error", err))
+		}
+	}
+
+	for {
+		result, err := pool.GenerateWalletWithContext(leaseCtx, criteria)
+		if stderrors.Is(err, crypto.ErrKeyRangeExhausted) {
+			break
+		}
+		if err != nil {
+			stopRenewing()
+			if lost.Load() {
+				return nil
+			}
+			abandon()
+			return err
+		}
+
+		accepted, err := app.screenWallet(ctx, result.Wallet)
+		if err != nil {
+			stopRenewing()
+			return err
+		}
+		if !accepted {
+			continue
+		}
+		if donated {
+			result.Wallet.PrivateKey = ""
+			fmt.Println(i18n.T("donate.found", result.Wallet.Address, lease.KeyspaceID))
+		} else if err := app.displayWalletResult(result, criteria, false); err != nil {
+			// Without its keystore the wallet is not reported, so its keys are searched again
+			stopRenewing()
+			abandon()
+			return err
+		}
+		mu.Lock()
+		found = append(found, result.Wallet.Address)
+		mu.Unlock()
+		// Report the wallet right away, so the coordinator can finish the keyspace
+		renew()
+		if lost.Load() {
+			stopRenewing()
+			return nil
+		}
+	}
+
+	stopRenewing()
+	var ks server.Keyspace
+	status, err := serveRequest(ctx, cmd, http.MethodPost, leasePath+"/complete", "complete_lease", report(), &ks)
+	switch {
+	case status == http.StatusGone:
+		return nil
+	case err != nil:
+		return err
+	}
+	if !app.config.CLI.QuietMode {
+		fmt.Println(i18n.T("agent.coverage", ks.Checked, ks.Coverage, len(ks.Addresses), ks.Request.Count))
+	}
+	return nil
+}
+
+// agentKeyspace fetches the current state of the agent's keyspace
+func (app *Application) agentKeyspace(ctx context.Context, cmd *cobra.Command, path string) (server.Keyspace, error) {
+	var ks server.Keyspace
+	_, err := serveRequest(ctx, cmd, http.MethodGet, path, "get_keyspace", nil, &ks)
+	return ks, err
+}
+
+// agentFinished reports a finished keyspace. A keyspace searched to the end without
+// the requested wallets exits like a run stopped by a limit.
+func (app *Application) agentFinished(ks server.Keyspace) error {
+	if !app.config.CLI.QuietMode {
+		fmt.Println(i18n.T("agent.finished", ks.ID, ks.State, ks.Checked, ks.Coverage, len(ks.Addresses), ks.Request.Count))
+	}
+	if ks.State == server.KeyspaceExhausted {
+		return errors.NewBlocoError(errors.ErrorTypeTimeout, "agent",
+			fmt.Sprintf("keyspace %s was searched without finding %d wallets", ks.ID, ks.Request.Count))
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/audit"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/publish"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// skipAuditTrailAnnotation marks commands that must not append to the audit trail
+const skipAuditTrailAnnotation = "skip-audit-trail"
+
+// redactedFlagWords mark flags whose values are recorded as [redacted]
+var redactedFlagWords = []string{"password", "key-file", "api-key", "token", "identity"}
+
+// createAuditCommand creates the audit subcommand group
+func (app *Application) createAuditCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Check the audit trail written with --audit-trail",
+		Long: `With --audit-trail <file>, every command appends hash-chained entries for its
+runtime configuration, found wallets (addresses only), keystore writes and
+keystore decrypt/inspect invocations. Each entry includes the SHA-256 of the
+previous one, so any edit, reordering or deletion breaks the chain.`,
+		Annotations: map[string]string{skipAuditTrailAnnotation: "true"},
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <trail.jsonl>",
+		Short: "Verify the hash chain of an audit trail",
+		Long: `Recompute every entry hash and check each entry links to the one before it.
+The command prints the head hash; keep it somewhere else and pass it back with
+--expect-head to also detect entries removed from the end of the trail.`,
+		Example: `  bloco-eth audit verify audit-trail.jsonl
+  bloco-eth audit verify audit-trail.jsonl --expect-head 3f1c...e9`,
+		Args:        cobra.ExactArgs(1),
+		Annotations: map[string]string{skipAuditTrailAnnotation: "true"},
+		RunE:        app.runAuditVerify,
+	}
+	verifyCmd.Flags().String("expect-head", "", "Fail unless this previously recorded head hash is still in the chain")
+
+	cmd.AddCommand(verifyCmd)
+	return cmd
+}
+
+// runAuditVerify checks the chain of an audit trail file
+func (app *Application) runAuditVerify(cmd *cobra.Command, args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation,
+			"verify_audit_trail", fmt.Sprintf("failed to open %s", args[0]))
+	}
+	defer file.Close()
+
+	result, err := audit.Verify(file)
+	if err != nil {
+		return err
+	}
+	if expected, _ := cmd.Flags().GetString("expect-head"); expected != "" {
+		if _, ok := result.Hashes[strings.ToLower(expected)]; !ok {
+			return errors.NewValidationError("verify_audit_trail",
+				fmt.Sprintf("head %s is not in the chain; entries were removed or replaced", expected))
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out, icon("✅")+i18n.T("audit.verified", result.Entries))
+	if result.Entries > 0 {
+		fmt.Fprintln(out, "  "+i18n.T("audit.first", result.First.Format("2006-01-02 15:04:05 MST")))
+		fmt.Fprintln(out, "  "+i18n.T("audit.last", result.Last.Format("2006-01-02 15:04:05 MST")))
+	}
+	fmt.Fprintln(out, "  "+i18n.T("audit.head", result.Head))
+	return nil
+}
+
+// openAuditTrail opens --audit-trail and records the command's runtime configuration
+func (app *Application) openAuditTrail(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("audit-trail")
+	if path == "" || app.auditTrail != nil {
+		return nil
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[skipAuditTrailAnnotation] != "" {
+			return nil
+		}
+	}
+
+	trail, err := audit.Open(path)
+	if err != nil {
+		return err
+	}
+	app.auditTrail = trail
+	return trail.Record("config", runtimeConfigFields(cmd, os.Args[1:], app.version))
+}
+
+// runtimeConfigFields describes the command and the flags set on its command line,
+// redacting secrets
+func runtimeConfigFields(cmd *cobra.Command, args []string, version string) map[string]string {
+	fields := map[string]string{
+		"command": cmd.CommandPath(),
+		"version": version,
+	}
+	if host, err := os.Hostname(); err == nil {
+		fields["host"] = host
+	}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name == "" {
+			continue
+		}
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil && !strings.HasPrefix(arg, "--") {
+			flag = cmd.Flags().ShorthandLookup(name[:1])
+		}
+		if flag == nil || !flag.Changed {
+			continue
+		}
+
+		value := flag.Value.String()
+		if flag.Name == "publish" {
+			// Credentials ride in the URLs
+			targets, _ := cmd.Flags().GetStringArray("publish")
+			for i, target := range targets {
+				targets[i] = publish.Redact(target)
+			}
+			value = strings.Join(targets, ",")
+		}
+		for _, word := range redactedFlagWords {
+			if strings.Contains(flag.Name, word) {
+				value = "[redacted]"
+				break
+			}
+		}
+		fields["flag."+flag.Name] = value
+	}
+	return fields
+}
+
+// audit appends an entry to the audit trail, if one is open. A failed write is
+// reported but does not stop the operation that has already happened.
+func (app *Application) audit(event string, fields map[string]string) {
+	if err := app.auditTrail.Record(event, fields); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("warn.audit_write", err))
+	}
+}
+
+// auditWalletFound records a found wallet by address only
+func (app *Application) auditWalletFound(w *wallet.Wallet) {
+	if app.auditTrail == nil {
+		return
+	}
+	fields := map[string]string{"address": w.Address, "network": walletNetwork(w)}
+	if w.Label != "" {
+		fields["label"] = w.Label
+	}
+	app.audit("wallet_found", fields)
+}
+
+// auditKeystoreWrite records the outcome of saving a wallet's keystore
+func (app *Application) auditKeystoreWrite(w *wallet.Wallet, err error) {
+	if app.auditTrail == nil {
+		return
+	}
+	fields := map[string]string{
+		"address":  w.Address,
+		"network":  walletNetwork(w),
+		"location": app.keystoreLocation(),
+		"kdf":      app.config.KeyStore.KDFAlgorithm,
+		"success":  strconv.FormatBool(err == nil),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	app.audit("keystore_write", fields)
+}
+
+// auditRollback records that an --atomic-output run wrote none of its wallets
+func (app *Application) auditRollback(wallets int) {
+	if app.auditTrail == nil {
+		return
+	}
+	app.audit("output_rollback", map[string]string{
+		"location": app.keystoreLocation(),
+		"wallets":  strconv.Itoa(wallets),
+	})
+}
+
+// auditKeystoreAccess records a keystore inspect or decrypt invocation
+func (app *Application) auditKeystoreAccess(event, path, address string, err error) {
+	if app.auditTrail == nil {
+		return
+	}
+	fields := map[string]string{"file": path, "success": strconv.FormatBool(err == nil)}
+	if address != "" {
+		fields["address"] = address
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	app.audit(event, fields)
+}
+
+// walletNetwork returns the wallet's network, ethereum when unset
+func walletNetwork(w *wallet.Wallet) string {
+	if w.Network == "" {
+		return "ethereum"
+	}
+	return w.Network
+}
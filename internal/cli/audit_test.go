@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRuntimeConfigFields(t *testing.T) {
+	cmd := &cobra.Command{Use: "decrypt", Run: func(*cobra.Command, []string) {}}
+	cmd.Flags().StringP("prefix", "p", "", "")
+	cmd.Flags().String("password-file", "", "")
+	cmd.Flags().String("api-key", "", "")
+	cmd.Flags().StringArray("publish", nil, "")
+	cmd.Flags().Int("threads", 0, "")
+	args := []string{"-p", "abc", "--password-file=/secret/pw", "--api-key", "tok",
+		"--publish", "nats://s3cr3t@nats:4222/bloco", "--publish", "mqtt://broker/bloco", "keystore.json"}
+	if err := cmd.ParseFlags(args); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := runtimeConfigFields(cmd, args, "1.2.3")
+	want := map[string]string{
+		"command":            "decrypt",
+		"version":            "1.2.3",
+		"flag.prefix":        "abc",
+		"flag.password-file": "[redacted]",
+		"flag.api-key":       "[redacted]",
+		"flag.publish":       "nats://xxxxx@nats:4222/bloco,mqtt://broker/bloco",
+	}
+	for key, value := range want {
+		if fields[key] != value {
+			t.Errorf("fields[%q] = %q, want %q", key, fields[key], value)
+		}
+	}
+	if _, ok := fields["flag.threads"]; ok {
+		t.Error("flags left at their default should not be recorded")
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// checkInsPerUpdate is how many adaptive-latency batches each worker completes
+// between two progress updates, so every update reflects fresh attempts
+const checkInsPerUpdate = 10
+
+// maxHeadlessBatch bounds adaptive-latency batches of headless runs, keeping
+// cancellation within a second when nobody watches the progress
+const maxHeadlessBatch = time.Second
+
+// parseBatchStrategy configures the --batch-strategy, --cancel-latency and
+// --checksum-verifiers. adaptive-latency aims for
+// batches a tenth of the progress interval: the TUI refresh rate for interactive
+// runs, where Ctrl+C and a smooth display matter, and --status-interval for
+// headless ones, where larger batches spend less time reporting.
+func (app *Application) parseBatchStrategy(cmd *cobra.Command) error {
+	name, _ := cmd.Flags().GetString("batch-strategy")
+	target := app.statusInterval / checkInsPerUpdate
+	if interactiveRun(app, cmd) {
+		target = app.config.CLI.ProgressUpdateInterval / checkInsPerUpdate
+	}
+	target = min(target, maxHeadlessBatch)
+
+	strategy, err := worker.NewBatchStrategy(name, target, app.config.Worker.MinBatchSize, app.config.Worker.MaxBatchSize)
+	if err != nil {
+		return errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --batch-strategy: %v", err))
+	}
+	app.batchStrategy = strategy
+
+	app.cancelLatency, _ = cmd.Flags().GetDuration("cancel-latency")
+	if app.cancelLatency < time.Millisecond {
+		return errors.NewValidationError("parse_flags", "--cancel-latency must be at least 1ms")
+	}
+
+	app.checksumVerifiers, _ = cmd.Flags().GetInt("checksum-verifiers")
+	if app.checksumVerifiers < 0 {
+		return errors.NewValidationError("parse_flags", "--checksum-verifiers cannot be negative")
+	}
+	return nil
+}
+
+// interactiveRun reports whether someone watches the run's progress on a terminal
+func interactiveRun(app *Application, cmd *cobra.Command) bool {
+	if app.config.CLI.QuietMode || app.progressFormat == "json" {
+		return false
+	}
+	if cmd.Name() == "serve" || cmd.Name() == "agent" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// newWorkerPool creates a worker pool using the configured --batch-strategy,
+// --cancel-latency and --checksum-verifiers
+func (app *Application) newWorkerPool(network string) *worker.Pool {
+	pool := worker.NewPoolWithConfig(app.config.Worker.ThreadCount, app.config, network)
+	pool.SetBatchStrategy(app.batchStrategy)
+	pool.SetCancelLatency(app.cancelLatency)
+	pool.SetChecksumVerifiers(app.checksumVerifiers)
+	return pool
+}
+
+// reportChecksumVerification prints how many of the workers' case-insensitive hits
+// the --checksum-verifiers checked and confirmed
+func (app *Application) reportChecksumVerification(collector *worker.StatsCollector, criteria wallet.GenerationCriteria) {
+	if app.checksumVerifiers == 0 || !criteria.IsCaseSensitive() || criteria.Network != "ethereum" || app.config.CLI.QuietMode {
+		return
+	}
+	candidates, confirmed := collector.GetChecksumVerification()
+	fmt.Fprintf(os.Stderr, "Checksum verifiers: %s case-insensitive candidates, %s confirmed\n",
+		formatLargeNumber(candidates), formatLargeNumber(confirmed))
+}
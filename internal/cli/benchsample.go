@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"bloco-eth/internal/memgov"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+// benchmarkSampler turns the progress ticks of a benchmark into speed samples
+type benchmarkSampler struct {
+	base      int64 // attempts of the warm-up, excluded from every sample
+	last      int64
+	lastTime  time.Time
+	speeds    []float64
+	durations []time.Duration
+}
+
+func newBenchmarkSampler(start time.Time, warm benchmarkWarmup) *benchmarkSampler {
+	return &benchmarkSampler{base: warm.attempts, last: warm.attempts, lastTime: start}
+}
+
+// observe records the speed since the previous sample, reporting false for events
+// that are not ticks and for ticks without new attempts
+func (s *benchmarkSampler) observe(e worker.ProgressEvent) (float64, bool) {
+	attempts := e.Stats.TotalAttempts
+	if e.Type != worker.ProgressTick || attempts <= s.last {
+		return 0, false
+	}
+	elapsed := e.Time.Sub(s.lastTime)
+	if elapsed <= 0 {
+		return 0, false
+	}
+	speed := float64(attempts-s.last) / elapsed.Seconds()
+	s.speeds = append(s.speeds, speed)
+	s.durations = append(s.durations, elapsed)
+	s.last, s.lastTime = attempts, e.Time
+	return speed, true
+}
+
+// attempts returns the attempts made since the warm-up as of the last sample
+func (s *benchmarkSampler) attempts() int64 {
+	return s.last - s.base
+}
+
+// result summarises the samples; attempts and duration are the benchmark's totals
+func (s *benchmarkSampler) result(attempts int64, duration time.Duration, perf worker.PerformanceMetrics) *wallet.BenchmarkResult {
+	result := &wallet.BenchmarkResult{
+		TotalAttempts:         attempts,
+		TotalDuration:         duration,
+		SpeedSamples:          slices.Clone(s.speeds),
+		DurationSamples:       slices.Clone(s.durations),
+		ThreadCount:           perf.WorkerCount,
+		ScalabilityEfficiency: perf.EfficiencyRatio,
+		ThreadBalanceScore:    perf.ThreadBalanceScore,
+		ThreadUtilization:     perf.CPUUtilization,
+		SpeedupVsSingleThread: perf.SpeedupVsSingleThread,
+		SingleThreadSpeed:     perf.EstimatedSingleThreadSpeed,
+	}
+	if len(s.speeds) == 0 {
+		return result
+	}
+	var sum float64
+	result.MinSpeed, result.MaxSpeed = s.speeds[0], s.speeds[0]
+	for _, speed := range s.speeds {
+		sum += speed
+		result.MinSpeed = min(result.MinSpeed, speed)
+		result.MaxSpeed = max(result.MaxSpeed, speed)
+	}
+	result.AverageSpeed = sum / float64(len(s.speeds))
+	return result
+}
+
+// benchmarkPresenter shows a new sample; result holds the samples so far
+type benchmarkPresenter func(result *wallet.BenchmarkResult, speed float64)
+
+// sampleBenchmark keeps the workers busy matching criteria until duration passes
+// or attempts are made, after the warm-up. Samples are taken from the ticks of a
+// ProgressBroker every interval and passed to present.
+func (app *Application) sampleBenchmark(ctx context.Context, workerPool worker.WorkerPool, criteria wallet.GenerationCriteria,
+	attempts int, duration time.Duration, batchSize int, interval time.Duration, present benchmarkPresenter) (*wallet.BenchmarkResult, error) {
+	statsCollector := workerPool.GetStatsCollector()
+	benchmarkCtx, cancel := context.WithTimeout(ctx, app.warmup+duration)
+	defer cancel()
+
+	// Keep all workers busy until the benchmark context is done
+	benchDone := make(chan error, 1)
+	go func() {
+		_, err := workerPool.RunBenchmark(benchmarkCtx, worker.WorkItem{
+			Criteria:  criteria,
+			BatchSize: batchSize,
+			ID:        fmt.Sprintf("bench-%d", time.Now().UnixNano()),
+		})
+		benchDone <- err
+	}()
+
+	// Sampling starts once the pool is warm
+	warm := warmUp(benchmarkCtx, statsCollector, app.warmup)
+	startTime := time.Now()
+	gcStart := memgov.ReadGC()
+	sampler := newBenchmarkSampler(startTime, warm)
+	broker := worker.NewProgressBroker(statsCollector)
+	broker.Subscribe(interval, func(e worker.ProgressEvent) {
+		if speed, ok := sampler.observe(e); ok {
+			present(sampler.result(sampler.attempts(), time.Since(startTime), statsCollector.GetPerformanceMetrics()), speed)
+		}
+		if int(e.Stats.TotalAttempts-warm.attempts) >= attempts {
+			cancel()
+		}
+	})
+
+	<-benchmarkCtx.Done()
+	totalDuration := time.Since(startTime)
+	gc := memgov.ReadGC().Since(gcStart)
+	// Close waits for the subscriber, so the samples are complete below
+	broker.Close(nil)
+	if err := <-benchDone; err != nil {
+		return nil, err
+	}
+
+	totalAttempts := statsCollector.GetAggregatedStats().TotalAttempts - warm.attempts
+	result := sampler.result(totalAttempts, totalDuration, statsCollector.GetPerformanceMetrics())
+	result.WarmupDuration, result.WarmupAttempts = warm.duration, warm.attempts
+	result.GCCycles, result.GCPause = gc.Cycles, gc.Pause
+	return result, nil
+}
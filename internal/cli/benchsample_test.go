@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestBenchmarkSampler(t *testing.T) {
+	start := time.Now()
+	sampler := newBenchmarkSampler(start, benchmarkWarmup{attempts: 1000})
+	tick := func(after time.Duration, attempts int64) worker.ProgressEvent {
+		return worker.ProgressEvent{Type: worker.ProgressTick, Time: start.Add(after), Stats: worker.AggregatedStats{TotalAttempts: attempts}}
+	}
+
+	if speed, ok := sampler.observe(tick(time.Second, 3000)); !ok || speed != 2000 {
+		t.Errorf("observe() = %v, %v, want 2000 addr/s", speed, ok)
+	}
+	if _, ok := sampler.observe(tick(2*time.Second, 3000)); ok {
+		t.Error("a tick without new attempts was sampled")
+	}
+	if _, ok := sampler.observe(worker.ProgressEvent{Type: worker.ProgressDone, Time: start.Add(3 * time.Second)}); ok {
+		t.Error("the done event was sampled")
+	}
+	// The second sample spans the tick without attempts
+	if speed, ok := sampler.observe(tick(3*time.Second, 4000)); !ok || speed != 500 {
+		t.Errorf("observe() = %v, %v, want 500 addr/s", speed, ok)
+	}
+	if sampler.attempts() != 3000 {
+		t.Errorf("attempts() = %d, want 3000", sampler.attempts())
+	}
+
+	result := sampler.result(3000, 3*time.Second, worker.PerformanceMetrics{})
+	if result.AverageSpeed != 1250 || result.MinSpeed != 500 || result.MaxSpeed != 2000 ||
+		len(result.SpeedSamples) != 2 || result.DurationSamples[1] != 2*time.Second {
+		t.Errorf("result() = %+v", result)
+	}
+}
+
+func TestSampleBenchmark(t *testing.T) {
+	app := &Application{config: config.DefaultConfig()}
+	pool := worker.NewPool(1, "ethereum")
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	var presented int
+	result, err := app.sampleBenchmark(context.Background(), pool, wallet.GenerationCriteria{}, 1<<40, 300*time.Millisecond, 100,
+		50*time.Millisecond, func(result *wallet.BenchmarkResult, speed float64) {
+			presented++
+			if len(result.SpeedSamples) != presented || speed <= 0 {
+				t.Errorf("sample %d: %d samples at %v addr/s", presented, len(result.SpeedSamples), speed)
+			}
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if presented == 0 || len(result.SpeedSamples) != presented || result.TotalAttempts == 0 || result.AverageSpeed <= 0 {
+		t.Errorf("%d samples presented, result %+v", presented, result)
+	}
+}
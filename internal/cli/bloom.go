@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/screening"
+	"bloco-eth/pkg/errors"
+)
+
+// createBloomCommand creates the bloom command for --bloom-filter files
+func (app *Application) createBloomCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bloom",
+		Short: "Build and query Bloom filters of existing addresses",
+		Long: `Build a compact Bloom filter of every address an organization already uses, to
+pass to --bloom-filter so new searches never hand one out again. The filter
+answers "possibly present" or "absent": it never misses an added address, and
+the false positive rate only means an occasional unlisted address is discarded
+and searched for again. The filter holds hashes, not the addresses themselves.`,
+	}
+
+	buildCmd := &cobra.Command{
+		Use:   "build <filter> <addresses.csv>...",
+		Short: "Build a Bloom filter from screening-list CSV files",
+		Example: `  bloco-eth bloom build org.bloom deposit-addresses.csv treasury.csv
+  bloco-eth bloom build org.bloom addresses.csv --expected 5000000 --false-positive-rate 0.00001`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: app.runBloomBuild,
+	}
+	buildCmd.Flags().Uint64("expected", 0, "Number of addresses to size the filter for (default: the addresses read)")
+	buildCmd.Flags().Float64("false-positive-rate", 0.0001, "Chance that an address not in the filter is reported anyway")
+
+	checkCmd := &cobra.Command{
+		Use:     "check <filter> <address>...",
+		Short:   "Report whether addresses may be in a Bloom filter",
+		Example: `  bloco-eth bloom check org.bloom 0xabc123...`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE:    app.runBloomCheck,
+	}
+
+	cmd.AddCommand(buildCmd, checkCmd)
+	return cmd
+}
+
+// runBloomBuild writes a Bloom filter of the addresses in the given lists
+func (app *Application) runBloomBuild(cmd *cobra.Command, args []string) error {
+	var addresses []string
+	for _, path := range args[1:] {
+		list, err := screening.LoadList(path)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "bloom_build", "invalid address list")
+		}
+		addresses = append(addresses, list.Addresses()...)
+	}
+
+	expected, _ := cmd.Flags().GetUint64("expected")
+	if expected == 0 {
+		expected = uint64(len(addresses))
+	}
+	rate, _ := cmd.Flags().GetFloat64("false-positive-rate")
+	filter, err := screening.NewBloom(expected, rate)
+	if err != nil {
+		return errors.NewValidationError("bloom_build", err.Error())
+	}
+	for _, address := range addresses {
+		filter.Add(address)
+	}
+	if err := screening.SaveBloom(args[0], filter); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "bloom_build", "failed to save the Bloom filter")
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s: %d address(es), ~%.4g%% false positives\n",
+		args[0], filter.Len(), filter.FalsePositiveRate()*100)
+	return nil
+}
+
+// runBloomCheck prints whether each address may be in the filter
+func (app *Application) runBloomCheck(cmd *cobra.Command, args []string) error {
+	filter, err := screening.LoadBloom(args[0])
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "bloom_check", "invalid Bloom filter")
+	}
+	for _, address := range args[1:] {
+		result := "absent"
+		if filter.Contains(address) {
+			result = "possibly present"
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\t%s\n", address, result)
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// ceremonyConfirmLength is how much of the binary fingerprint each operator types back
+const ceremonyConfirmLength = 8
+
+// networkFlags are options that reach the network, refused by --ceremony and --harden
+var networkFlags = []string{"rpc-url", "fund-broadcast", "otlp-endpoint", "health-addr", "publish", "notify", "status-url"}
+
+// ceremonyConfig is the configuration fingerprinted and recorded in the transcript
+type ceremonyConfig struct {
+	Network            string         `json:"network"`
+	Prefix             string         `json:"prefix,omitempty"`
+	Suffix             string         `json:"suffix,omitempty"`
+	Checksum           bool           `json:"checksum"`
+	CaseSensitive      bool           `json:"case_sensitive"`
+	Mnemonic           bool           `json:"mnemonic"`
+	Wallets            int            `json:"wallets"`
+	Threads            int            `json:"threads"`
+	Entropy            string         `json:"entropy"`
+	KeystoreDir        string         `json:"keystore_dir"`
+	Vault              string         `json:"vault,omitempty"`
+	KDF                string         `json:"kdf"`
+	KDFParams          map[string]any `json:"kdf_params,omitempty"`
+	SecurityLevel      string         `json:"security_level"`
+	PasswordProtection string         `json:"password_protection"`
+	PasswordPolicy     string         `json:"password_policy,omitempty"`
+	SLIP39             string         `json:"slip39,omitempty"`
+	Label              string         `json:"label,omitempty"`
+	Tags               []string       `json:"tags,omitempty"`
+}
+
+// ceremonySession holds a ceremony between its confirmation and its transcript
+type ceremonySession struct {
+	transcript *crypto.CeremonyTranscript
+	key        ed25519.PrivateKey
+	dir        string
+}
+
+// createCeremonyCommand creates the ceremony subcommand group
+func (app *Application) createCeremonyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ceremony",
+		Short: "Check transcripts of --ceremony key generation runs",
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify <ceremony.json>",
+		Short: "Verify the signature of a ceremony transcript",
+		Long: `Check that a ceremony transcript is signed by its public key and that its
+configuration matches the recorded fingerprint. Pass the public key written in
+the ceremony log with --public-key to also check who signed it.`,
+		Example: `  bloco-eth ceremony verify keystores/ceremony-20260101T120000Z.json
+  bloco-eth ceremony verify ceremony.json --public-key 8a88e3dd...`,
+		Args: cobra.ExactArgs(1),
+		RunE: app.runCeremonyVerify,
+	}
+	verifyCmd.Flags().String("public-key", "", "Hex Ed25519 public key the transcript must be signed with")
+
+	cmd.AddCommand(verifyCmd)
+	return cmd
+}
+
+// runCeremonyVerify verifies a ceremony transcript and summarizes it
+func (app *Application) runCeremonyVerify(cmd *cobra.Command, args []string) error {
+	transcript, err := crypto.LoadCeremonyTranscript(args[0])
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "verify_ceremony", "failed to load transcript")
+	}
+	if err := transcript.Verify(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "verify_ceremony",
+			fmt.Sprintf("%s failed verification", args[0]))
+	}
+	if expected, _ := cmd.Flags().GetString("public-key"); expected != "" &&
+		!strings.EqualFold(strings.TrimSpace(expected), transcript.PublicKey) {
+		return errors.NewValidationError("verify_ceremony",
+			fmt.Sprintf("transcript is signed by %s, not the expected key", transcript.PublicKey))
+	}
+
+	out := cmd.OutOrStdout()
+	operators := make([]string, len(transcript.Operators))
+	for i, operator := range transcript.Operators {
+		operators[i] = operator.Name
+	}
+	fmt.Fprintln(out, icon("✅")+i18n.T("ceremony.verified"))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.started", transcript.StartedAt.Format(time.RFC3339)))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.operators", strings.Join(operators, ", ")))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.binary", transcript.BinarySHA256))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.config", transcript.ConfigSHA256))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.offline", transcript.NetworkCheck.Offline))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.wallets", len(transcript.Wallets)))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.outcome", transcript.Outcome))
+	fmt.Fprintln(out, "  "+i18n.T("ceremony.signed_by", transcript.PublicKey))
+	return nil
+}
+
+// beginCeremony enforces offline operation, prints the fingerprints and collects the
+// confirmation of two operators before any key is generated
+func (app *Application) beginCeremony(cmd *cobra.Command, criteria wallet.GenerationCriteria, count int) (*ceremonySession, error) {
+	if !app.config.KeyStore.Enabled {
+		return nil, errors.NewValidationError("ceremony", "--ceremony requires keystore or vault output; remove --no-keystore")
+	}
+	for _, name := range networkFlags {
+		if cmd.Flags().Changed(name) {
+			return nil, errors.NewValidationError("ceremony", fmt.Sprintf("--%s reaches the network and cannot be used with --ceremony", name))
+		}
+	}
+	if app.tracer != nil {
+		return nil, errors.NewValidationError("ceremony", "trace export reaches the network and cannot be used with --ceremony; unset OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	// Prompts cannot share the terminal with the TUI
+	app.config.TUI.Enabled = false
+
+	transcript := &crypto.CeremonyTranscript{
+		Version:   crypto.CeremonyTranscriptVersion,
+		StartedAt: time.Now().UTC(),
+	}
+	transcript.Host, _ = os.Hostname()
+
+	allowNetwork, _ := cmd.Flags().GetBool("ceremony-allow-network")
+	up, err := networkInterfaces()
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "ceremony", "failed to list network interfaces")
+	}
+	transcript.NetworkCheck = crypto.CeremonyNetworkCheck{Offline: len(up) == 0, Overridden: len(up) > 0 && allowNetwork, Interfaces: up}
+	if len(up) > 0 && !allowNetwork {
+		return nil, errors.NewValidationError("ceremony", fmt.Sprintf(
+			"network interfaces are up: %s; disconnect them or pass --ceremony-allow-network", strings.Join(up, ", ")))
+	}
+
+	executable, err := os.Executable()
+	if err == nil {
+		executable, err = filepath.EvalSymlinks(executable)
+	}
+	if err == nil {
+		transcript.BinaryPath = executable
+		transcript.BinarySHA256, err = crypto.FileSHA256(executable)
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "ceremony", "failed to fingerprint the running binary")
+	}
+	if err := transcript.SetConfig(app.ceremonyConfig(cmd, criteria, count)); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "ceremony", "failed to fingerprint the configuration")
+	}
+
+	key, err := app.ceremonyKey(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("\n%s\n", i18n.T("ceremony.title"))
+	printRule()
+	fmt.Println(i18n.T("ceremony.host", transcript.Host))
+	fmt.Println(i18n.T("ceremony.binary_path", transcript.BinaryPath))
+	fmt.Println(i18n.T("ceremony.binary_sha256", transcript.BinarySHA256))
+	fmt.Println(i18n.T("ceremony.config_sha256", transcript.ConfigSHA256))
+	if transcript.NetworkCheck.Offline {
+		fmt.Println(i18n.T("ceremony.network_offline"))
+	} else {
+		fmt.Println(i18n.T("ceremony.network_online", strings.Join(up, ", ")))
+	}
+	fmt.Println(i18n.T("ceremony.signing_key", key.Public().(ed25519.PublicKey)))
+	fmt.Printf("\n%s\n\n", i18n.T("ceremony.record"))
+
+	operators, err := confirmOperators(cmd.InOrStdin(), os.Stdout, transcript.BinarySHA256)
+	if err != nil {
+		return nil, err
+	}
+	transcript.Operators = operators
+	fmt.Println()
+
+	dir := app.config.KeyStore.OutputDir
+	if app.vault != nil {
+		dir = filepath.Dir(app.vault.Path())
+	}
+	return &ceremonySession{transcript: transcript, key: key, dir: dir}, nil
+}
+
+// ceremonyConfig collects the settings that decide what the ceremony generates and how it is stored
+func (app *Application) ceremonyConfig(cmd *cobra.Command, criteria wallet.GenerationCriteria, count int) ceremonyConfig {
+	config := ceremonyConfig{
+		Network:            walletNetwork(&wallet.Wallet{Network: criteria.Network}),
+		Prefix:             criteria.Prefix,
+		Suffix:             criteria.Suffix,
+		Checksum:           criteria.IsChecksum,
+		CaseSensitive:      criteria.CaseSensitive,
+		Mnemonic:           criteria.UseMnemonic,
+		Wallets:            count,
+		Threads:            app.config.Worker.ThreadCount,
+		Entropy:            crypto.EntropySourceName(),
+		KeystoreDir:        app.config.KeyStore.OutputDir,
+		KDF:                app.config.KeyStore.KDFAlgorithm,
+		KDFParams:          app.config.KeyStore.KDFParams,
+		SecurityLevel:      app.config.KeyStore.SecurityLevel,
+		PasswordProtection: app.config.KeyStore.PasswordProtection,
+		PasswordPolicy:     app.config.KeyStore.PasswordPolicy,
+		Label:              app.label,
+		Tags:               app.tags,
+	}
+	if app.vault != nil {
+		config.Vault = app.vault.Path()
+	}
+	config.SLIP39, _ = cmd.Flags().GetString("slip39")
+	return config
+}
+
+// ceremonyKey loads --ceremony-key or creates a one-time signing key
+func (app *Application) ceremonyKey(cmd *cobra.Command) (ed25519.PrivateKey, error) {
+	path, _ := cmd.Flags().GetString("ceremony-key")
+	if path == "" {
+		key, err := crypto.GenerateCeremonyKey()
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeCrypto, "ceremony", "failed to create signing key")
+		}
+		return key, nil
+	}
+	key, err := crypto.LoadCeremonyKey(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "ceremony", "failed to load --ceremony-key")
+	}
+	return key, nil
+}
+
+// confirmOperators asks two different operators for their name and the start of
+// the binary fingerprint. Any mismatch aborts the ceremony.
+func confirmOperators(in io.Reader, out io.Writer, binarySHA256 string) ([]crypto.CeremonyOperator, error) {
+	reader := bufio.NewReader(in)
+	expected := binarySHA256[:ceremonyConfirmLength]
+	var operators []crypto.CeremonyOperator
+
+	for i := 1; i <= 2; i++ {
+		fmt.Fprint(out, i18n.T("ceremony.operator_name", i))
+		name, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return nil, errors.NewValidationError("ceremony", fmt.Sprintf("operator %d did not give a name", i))
+		}
+		if i == 2 && strings.EqualFold(name, operators[0].Name) {
+			return nil, errors.NewValidationError("ceremony", "the two confirmations must come from different operators")
+		}
+
+		fmt.Fprint(out, i18n.T("ceremony.operator_confirm", name, ceremonyConfirmLength))
+		answer, err := readLine(reader)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(answer, expected) {
+			return nil, errors.NewValidationError("ceremony",
+				fmt.Sprintf("operator %s did not confirm the binary fingerprint; ceremony aborted", name))
+		}
+		operators = append(operators, crypto.CeremonyOperator{Name: name, ConfirmedAt: time.Now().UTC()})
+	}
+	return operators, nil
+}
+
+// readLine reads one trimmed line of operator input
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", errors.NewValidationError("ceremony", "confirmation input ended; ceremony aborted")
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// finish signs and saves the transcript with the wallets found, returning err or,
+// if generation succeeded, any failure to write the transcript
+func (s *ceremonySession) finish(app *Application, err error) error {
+	t := s.transcript
+	t.FinishedAt = time.Now().UTC()
+	t.Outcome = "completed"
+	if err != nil {
+		t.Outcome = err.Error()
+	}
+	app.generatedMu.Lock()
+	t.Wallets = make([]crypto.CeremonyWallet, 0, len(app.generated))
+	for _, w := range app.generated {
+		t.Wallets = append(t.Wallets, crypto.CeremonyWallet{Address: w.Address, Network: walletNetwork(w)})
+	}
+	app.generatedMu.Unlock()
+
+	var path string
+	saveErr := t.Sign(s.key)
+	if saveErr == nil {
+		path, saveErr = crypto.SaveCeremonyTranscript(s.dir, t)
+	}
+	if saveErr != nil {
+		saveErr = errors.WrapError(saveErr, errors.ErrorTypeConfiguration, "ceremony", "failed to write the ceremony transcript")
+		if err == nil {
+			return saveErr
+		}
+		fmt.Fprintln(os.Stderr, i18n.T("warn.error", saveErr))
+		return err
+	}
+
+	app.audit("ceremony", map[string]string{
+		"transcript": path,
+		"public_key": t.PublicKey,
+		"signature":  t.Signature,
+		"wallets":    fmt.Sprint(len(t.Wallets)),
+	})
+	fmt.Printf("\n%s\n", i18n.T("ceremony.transcript", path))
+	fmt.Println(i18n.T("ceremony.transcript_signed_by", t.PublicKey))
+	return err
+}
+
+// activeNetworkInterfaces lists non-loopback interfaces that are up and have an address
+func activeNetworkInterfaces() ([]string, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var up []string
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		addrs, err := iface.Addrs()
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+		names := make([]string, len(addrs))
+		for i, addr := range addrs {
+			names[i] = addr.String()
+		}
+		up = append(up, fmt.Sprintf("%s (%s)", iface.Name, strings.Join(names, " ")))
+	}
+	return up, nil
+}
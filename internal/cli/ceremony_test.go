@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConfirmOperators(t *testing.T) {
+	fingerprint := "c52a31a09b539f92cd90496772499cef867c99607b170da9a49439fa92fb69e5"
+
+	operators, err := confirmOperators(strings.NewReader("Alice\nc52a31a0\nBob\nC52A31A0\n"), io.Discard, fingerprint)
+	if err != nil {
+		t.Fatalf("confirmation failed: %v", err)
+	}
+	if len(operators) != 2 || operators[0].Name != "Alice" || operators[1].Name != "Bob" {
+		t.Errorf("unexpected operators %+v", operators)
+	}
+
+	rejected := map[string]string{
+		"same operator":     "Alice\nc52a31a0\nalice\nc52a31a0\n",
+		"wrong fingerprint": "Alice\nc52a31a0\nBob\n00000000\n",
+		"missing name":      "\nc52a31a0\n",
+		"input ends early":  "Alice\nc52a31a0\n",
+	}
+	for name, input := range rejected {
+		if _, err := confirmOperators(strings.NewReader(input), io.Discard, fingerprint); err == nil {
+			t.Errorf("%s: expected the ceremony to be aborted", name)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
+)
+
+// cloudInstance describes a cloud instance type and its expected generation throughput
+type cloudInstance struct {
+	Provider    string  `json:"provider"`
+	Instance    string  `json:"instance"`
+	VCPUs       int     `json:"vcpus"`
+	HourlyUSD   float64 `json:"hourly_usd"`
+	AddrPerVCPU float64 `json:"addr_per_vcpu"`
+}
+
+// cloudCostEstimate holds the time and cost to reach a target probability on one instance
+type cloudCostEstimate struct {
+	Instance cloudInstance
+	Speed    float64
+	Time50   time.Duration
+	Time95   time.Duration
+	Cost50   float64
+	Cost95   float64
+}
+
+// defaultCloudInstances is a snapshot of typical spot prices and single-vCPU benchmark
+// throughput. Prices change frequently; use --cost-table to supply current numbers.
+var defaultCloudInstances = []cloudInstance{
+	{Provider: "aws", Instance: "c7i.large", VCPUs: 2, HourlyUSD: 0.035, AddrPerVCPU: 20000},
+	{Provider: "aws", Instance: "c7i.4xlarge", VCPUs: 16, HourlyUSD: 0.28, AddrPerVCPU: 20000},
+	{Provider: "aws", Instance: "c7g.4xlarge", VCPUs: 16, HourlyUSD: 0.22, AddrPerVCPU: 16000},
+	{Provider: "aws", Instance: "c7i.16xlarge", VCPUs: 64, HourlyUSD: 1.10, AddrPerVCPU: 20000},
+	{Provider: "gcp", Instance: "n2-highcpu-32", VCPUs: 32, HourlyUSD: 0.24, AddrPerVCPU: 17000},
+	{Provider: "gcp", Instance: "c3-highcpu-44", VCPUs: 44, HourlyUSD: 0.45, AddrPerVCPU: 20000},
+	{Provider: "gcp", Instance: "c2d-highcpu-56", VCPUs: 56, HourlyUSD: 0.50, AddrPerVCPU: 19000},
+}
+
+// loadCloudInstances reads an instance table from a JSON file, or returns the defaults
+func loadCloudInstances(path string) ([]cloudInstance, error) {
+	if path == "" {
+		return defaultCloudInstances, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"load_cost_table", fmt.Sprintf("failed to read cost table %s", path))
+	}
+
+	var instances []cloudInstance
+	if err := json.Unmarshal(data, &instances); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"load_cost_table", "cost table must be a JSON array of instances")
+	}
+
+	for i, inst := range instances {
+		if inst.Instance == "" || inst.VCPUs <= 0 || inst.HourlyUSD < 0 || inst.AddrPerVCPU <= 0 {
+			return nil, errors.NewConfigurationError("load_cost_table",
+				fmt.Sprintf("invalid cost table entry %d: instance, vcpus, hourly_usd and addr_per_vcpu are required", i))
+		}
+	}
+
+	if len(instances) == 0 {
+		return nil, errors.NewConfigurationError("load_cost_table", "cost table is empty")
+	}
+
+	return instances, nil
+}
+
+// estimateCloudCost computes the time and cost to reach 50% and 95% probability on one instance
+func estimateCloudCost(inst cloudInstance, difficulty float64) (cloudCostEstimate, bool) {
+	attempts50 := vanitymath.AttemptsForProbability(difficulty, 0.5)
+	attempts95 := vanitymath.AttemptsForProbability(difficulty, 0.95)
+	speed := inst.AddrPerVCPU * float64(inst.VCPUs)
+	if attempts50 < 0 || attempts95 < 0 || speed <= 0 {
+		return cloudCostEstimate{Instance: inst, Speed: speed}, false
+	}
+
+	seconds50 := float64(attempts50) / speed
+	seconds95 := float64(attempts95) / speed
+
+	return cloudCostEstimate{
+		Instance: inst,
+		Speed:    speed,
+		Time50:   secondsToDuration(seconds50),
+		Time95:   secondsToDuration(seconds95),
+		Cost50:   seconds50 / 3600 * inst.HourlyUSD,
+		Cost95:   seconds95 / 3600 * inst.HourlyUSD,
+	}, true
+}
+
+// secondsToDuration converts seconds to a duration, saturating instead of overflowing
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds >= float64(1<<63-1)/float64(time.Second) {
+		return time.Duration(1<<63 - 1)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// formatUSD formats a dollar amount for display
+func formatUSD(amount float64) string {
+	if amount < 0.01 {
+		return "<$0.01"
+	}
+	if amount >= 1e6 {
+		return fmt.Sprintf("$%s", utils.FormatLargeNumber(int64(amount)))
+	}
+	return fmt.Sprintf("$%.2f", amount)
+}
+
+// showCloudCost prints cost and wall-clock estimates for each instance type
+func (app *Application) showCloudCost(difficulty float64, tablePath string) error {
+	instances, err := loadCloudInstances(tablePath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%s\n", i18n.T("cloudcost.title"))
+	fmt.Printf("%-8s %-16s %6s %12s %12s %12s %12s %12s\n",
+		i18n.T("cloudcost.provider"), i18n.T("cloudcost.instance"), i18n.T("cloudcost.vcpus"), i18n.T("cloudcost.speed"),
+		i18n.T("cloudcost.time50"), i18n.T("cloudcost.cost50"), i18n.T("cloudcost.time95"), i18n.T("cloudcost.cost95"))
+
+	for _, inst := range instances {
+		est, ok := estimateCloudCost(inst, difficulty)
+		if !ok {
+			fmt.Printf("%-8s %-16s %6d %12.0f %12s %12s %12s %12s\n",
+				inst.Provider, inst.Instance, inst.VCPUs, est.Speed,
+				i18n.T("duration.impossible"), "-", "-", "-")
+			continue
+		}
+		fmt.Printf("%-8s %-16s %6d %12.0f %12s %12s %12s %12s\n",
+			inst.Provider, inst.Instance, inst.VCPUs, est.Speed,
+			formatDuration(est.Time50), formatUSD(est.Cost50),
+			formatDuration(est.Time95), formatUSD(est.Cost95))
+	}
+
+	fmt.Printf("\n%s\n", i18n.T("cloudcost.scaling"))
+	if tablePath == "" {
+		fmt.Println(i18n.T("cloudcost.prices"))
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEstimateCloudCost(t *testing.T) {
+	inst := cloudInstance{Provider: "aws", Instance: "test", VCPUs: 10, HourlyUSD: 3.6, AddrPerVCPU: 1000}
+
+	// Difficulty of 16^4 for a four character prefix
+	est, ok := estimateCloudCost(inst, 65536)
+	if !ok {
+		t.Fatal("expected estimate for feasible difficulty")
+	}
+
+	if est.Speed != 10000 {
+		t.Errorf("Speed = %v, expected 10000", est.Speed)
+	}
+
+	// ~45426 attempts for 50% at 10k addr/s is ~4.5s, costing $0.001 per second
+	if est.Time50 < 4*time.Second || est.Time50 > 5*time.Second {
+		t.Errorf("Time50 = %v, expected ~4.5s", est.Time50)
+	}
+	if math.Abs(est.Cost50-est.Time50.Seconds()/1000) > 1e-6 {
+		t.Errorf("Cost50 = %v, inconsistent with Time50 %v", est.Cost50, est.Time50)
+	}
+	if est.Time95 <= est.Time50 || est.Cost95 <= est.Cost50 {
+		t.Errorf("95%% estimate should exceed 50%% estimate: %+v", est)
+	}
+}
+
+func TestLoadCloudInstances(t *testing.T) {
+	instances, err := loadCloudInstances("")
+	if err != nil || len(instances) == 0 {
+		t.Fatalf("expected default instances, got %v (err %v)", instances, err)
+	}
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	if err := os.WriteFile(valid, []byte(`[{"provider":"aws","instance":"x","vcpus":4,"hourly_usd":0.1,"addr_per_vcpu":1000}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	instances, err = loadCloudInstances(valid)
+	if err != nil || len(instances) != 1 || instances[0].VCPUs != 4 {
+		t.Errorf("unexpected result loading table: %v (err %v)", instances, err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`[{"provider":"aws","instance":"x","vcpus":0}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCloudInstances(invalid); err == nil {
+		t.Error("expected error for invalid table entry")
+	}
+}
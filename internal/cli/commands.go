@@ -3,25 +3,36 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"math"
 	"os"
-	"runtime"
+	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"bloco-eth/internal/audit"
+	"bloco-eth/internal/chain"
 	"bloco-eth/internal/config"
 	"bloco-eth/internal/crypto"
 	"bloco-eth/internal/crypto/kdf"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/notify"
+	"bloco-eth/internal/retry"
+	"bloco-eth/internal/screening"
+	"bloco-eth/internal/tracing"
 	"bloco-eth/internal/tui"
 	"bloco-eth/internal/validation"
 	"bloco-eth/internal/worker"
 	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/generator"
 	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
 	"bloco-eth/pkg/wallet"
 )
 
@@ -32,6 +43,66 @@ type Application struct {
 	version   string
 	gitCommit string
 	buildTime string
+	vault     *crypto.Vault
+	rpcClient *chain.Client
+	funding   *fundingConfig
+	hardware  *hardwareConfig
+	screening *screeningState
+	watchdog  *worker.WatchdogConfig
+	power     *powerWatch
+	notifier  *notify.Notifier
+	explorer  *explorer
+	retry     retry.Policies
+
+	batchStrategy     worker.BatchStrategy
+	cancelLatency     time.Duration
+	checksumVerifiers int
+	kdfBudget         *kdf.MemoryBudget
+	memoryGoverned    bool
+	warmup            time.Duration
+
+	keystoreWorkers   int
+	deferredKeystores *deferredKeystores
+
+	slip39Threshold int
+	slip39Count     int
+
+	label          string
+	tags           []string
+	labelFilenames bool
+
+	// keystorePath is the --keystore-path-template, rendered with the pattern of
+	// keystoreCriteria
+	keystorePath     *template.Template
+	keystoreCriteria wallet.GenerationCriteria
+
+	etaPercentiles []float64
+	etaCalibration time.Duration
+	histogramPath  string
+	statusInterval time.Duration
+	progressFormat string
+	progressFile   string
+	progressEvents *jsonProgress
+	progress       *worker.ProgressBroker
+	timeout        time.Duration
+	failOnTimeout  bool
+	keepTop        int
+	sortBy         string
+	tracer         *tracing.Tracer
+	traceCtx       context.Context
+	auditTrail     *audit.Log
+	entropyFile    *crypto.FileEntropySource
+	entropyReady   bool
+	constantRate   time.Duration
+	keyFormat      string
+	hideKeys       bool
+	includePubkey  bool
+	qr             *qrOutput
+	paperWallet    *paperWalletOutput
+	keyRange       *keyRangeSearch
+
+	generatedMu sync.Mutex
+	generated   []*wallet.Wallet
 }
 
 // NewApplication creates a new CLI application
@@ -41,6 +112,7 @@ func NewApplication(cfg *config.Config, version, gitCommit, buildTime string) *A
 		version:   version,
 		gitCommit: gitCommit,
 		buildTime: buildTime,
+		retry:     retry.DefaultPolicies(),
 	}
 
 	app.setupCommands()
@@ -52,6 +124,61 @@ func (app *Application) ExecuteContext(ctx context.Context) error {
 	return app.rootCmd.ExecuteContext(ctx)
 }
 
+// Shutdown closes the audit trail and exports any spans still queued; call it once
+// the command has finished
+func (app *Application) Shutdown(ctx context.Context) error {
+	if app.entropyFile != nil {
+		app.entropyFile.Close()
+	}
+	if err := app.auditTrail.Close(); err != nil {
+		return err
+	}
+	if app.tracer == nil {
+		return nil
+	}
+	tracing.SetTracer(nil)
+	return app.tracer.Shutdown(ctx)
+}
+
+// preRun prepares output, tracing and the audit trail before any command runs
+func (app *Application) preRun(cmd *cobra.Command, args []string) error {
+	if err := app.prepareOutput(cmd, args); err != nil {
+		return err
+	}
+	if err := app.loadRetryPolicies(cmd); err != nil {
+		return err
+	}
+	if err := app.startTracing(cmd); err != nil {
+		return err
+	}
+	if err := app.openAuditTrail(cmd); err != nil {
+		return err
+	}
+	if err := app.checkOffline(cmd); err != nil {
+		return err
+	}
+	return app.applyHardening(cmd)
+}
+
+// loadRetryPolicies reads the retry policies of the sinks from --retry-config or
+// the default location
+func (app *Application) loadRetryPolicies(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("retry-config")
+	if path == "" {
+		var err error
+		if path, err = retry.DefaultPath(); err != nil {
+			// Without a config directory there is no default file to read
+			return nil
+		}
+	}
+	policies, err := retry.Load(path)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "retry_config", "failed to load retry policies")
+	}
+	app.retry = policies
+	return nil
+}
+
 // setupCommands sets up all CLI commands
 func (app *Application) setupCommands() {
 	app.rootCmd = &cobra.Command{
@@ -61,10 +188,13 @@ func (app *Application) setupCommands() {
 with custom prefixes and suffixes. It supports EIP-55 checksum validation,
 multi-threaded generation for optimal performance, automatic KeyStore V3
 file generation, and secure logging that never exposes sensitive data.`,
-		Version: fmt.Sprintf("%s (commit: %s, built: %s)", app.version, app.gitCommit, app.buildTime),
-		RunE:    app.generateWallet,
+		Version:           fmt.Sprintf("%s (commit: %s, built: %s)", app.version, app.gitCommit, app.buildTime),
+		RunE:              app.generateWallet,
+		PersistentPreRunE: app.preRun,
 	}
 
+	app.rootCmd.SetFlagErrorFunc(flagError)
+
 	// Add global flags
 	app.addGlobalFlags()
 
@@ -72,6 +202,31 @@ file generation, and secure logging that never exposes sensitive data.`,
 	app.rootCmd.AddCommand(app.createStatsCommand())
 	app.rootCmd.AddCommand(app.createBenchmarkCommand())
 	app.rootCmd.AddCommand(app.createVersionCommand())
+	app.rootCmd.AddCommand(app.createK8sCommand())
+	app.rootCmd.AddCommand(app.createServeCommand())
+	app.rootCmd.AddCommand(app.createJobsCommand())
+	app.rootCmd.AddCommand(app.createAgentCommand())
+	app.rootCmd.AddCommand(app.createDonateCommand())
+	app.rootCmd.AddCommand(app.createKeystoreCommand())
+	app.rootCmd.AddCommand(app.createVaultCommand())
+	app.rootCmd.AddCommand(app.createHardwareCommand())
+	app.rootCmd.AddCommand(app.createBloomCommand())
+	app.rootCmd.AddCommand(app.createSLIP39Command())
+	app.rootCmd.AddCommand(app.createWizardCommand())
+	app.rootCmd.AddCommand(app.createListCommand())
+	app.rootCmd.AddCommand(app.createCreate2Command())
+	app.rootCmd.AddCommand(app.createAuditCommand())
+	app.rootCmd.AddCommand(app.createCeremonyCommand())
+	app.rootCmd.AddCommand(app.createPresetCommand())
+	app.rootCmd.AddCommand(app.createSeedCommand())
+	app.rootCmd.AddCommand(app.createCompatCommand())
+	app.rootCmd.AddCommand(app.createUpdateCommand())
+	app.rootCmd.AddCommand(app.createFlagsCommand())
+	app.rootCmd.AddCommand(app.createDocsCommand())
+
+	// Accept the flag spellings of the earlier CLI, with a deprecation warning
+	applyLegacyFlags(app.rootCmd)
+	app.registerCompletions()
 }
 
 // addGlobalFlags adds global flags to the root command
@@ -84,27 +239,113 @@ func (app *Application) addGlobalFlags() {
 	flags.BoolP("checksum", "c", false, "Enable EIP-55 checksum validation")
 	flags.Bool("case-sensitive", false, "Enable case-sensitive pattern matching (requires --checksum)")
 	flags.IntP("count", "n", 1, "Number of wallets to generate")
+	flags.Bool("stdin-patterns", false, "Read newline-delimited patterns (prefix, prefix:suffix or :suffix) from stdin and write a JSON line per found wallet")
+	flags.Int("count-per-pattern", 1, "Wallets to generate for each --stdin-patterns pattern")
 	flags.Bool("with-mnemonic", false, "Generate wallets using BIP-39 mnemonic phrases")
+	flags.Bool("preview", false, "Show how --with-mnemonic derives addresses on a public test mnemonic, then exit without searching")
+	flags.Float64("until-probability", 0, "Stop after the attempts needed for this % chance of a match (e.g. 95)")
+	flags.Duration("timeout", 0, "Stop generating after this long (e.g. 10m; 0 = no limit)")
+	flags.Int("keep-top", 0, "Best-effort mode: rank the K closest addresses found within --timeout or --until-probability and keep the one you choose")
+	flags.Bool("fail-on-timeout", true, "Exit with code 2 when --timeout or --until-probability stops a run early; false accepts partial results")
 	flags.String("network", "ethereum", "Target network (ethereum, bitcoin, solana)")
+	flags.String("preset", "", "Generate the named pattern preset (see \"preset list\"); explicit pattern flags override it")
+	flags.String("preset-file", "", "Preset registry file (default: $BLOCO_PRESETS or presets.json in the user config directory)")
+	flags.String("retry-config", "", "Retry policies of keystore, notify, vault and checkpoint writes (default: $BLOCO_RETRY_CONFIG or retry.json in the user config directory)")
 
 	// Performance parameters
-	flags.IntP("threads", "t", 0, "Number of worker threads (0 = auto-detect)")
+	flags.IntP("threads", "t", 0, "Number of worker threads (0 = auto-detect, within a container's CPU quota)")
+	flags.String("batch-strategy", worker.BatchAdaptiveLatency, "How workers size batches between progress reports (fixed, adaptive-latency, throughput-max)")
+	flags.Duration("cancel-latency", worker.DefaultCancelLatency, "Stop workers within this long of Ctrl+C or another cancellation, whatever the batch size")
+	flags.Int("checksum-verifiers", 0, "Match --case-sensitive patterns case-insensitively in the workers and check the case of their hits on this many verifiers (0 = in the workers)")
 	flags.Bool("progress", false, "Show progress information")
 	flags.Bool("tui", true, "Use terminal UI (when available)")
+	flags.Bool("accessible", false, "Screen-reader and log friendly output: no TUI, progress bars, colors or emoji")
+	flags.Duration("status-interval", 10*time.Second, "Interval between --accessible status lines, --progress-format json events and --publish progress")
+	flags.String("eta-percentiles", "50,90,99", "Probabilities (%) to show time-to-match estimates for in progress output")
+	flags.Duration("eta-calibration", 10*time.Second, "Measure the speed of the pattern being searched for this long and base ETAs on it (0 uses the workers' reported speed)")
+	flags.String("progress-format", "text", "Progress output format (text, json); json writes one event per line to stderr and disables the TUI")
+	flags.Duration("constant-rate", 0, "Emit --progress-format json lines of fixed size on this fixed schedule, releasing found wallets one per slot (e.g. 1m)")
+	flags.String("progress-file", "", "Write --progress-format json events to this file or named pipe instead of stderr")
 
 	// Output parameters
 	flags.BoolP("verbose", "v", false, "Enable verbose output")
 	flags.BoolP("quiet", "q", false, "Suppress non-essential output")
 	flags.String("output", "", "Output file for results (default: stdout)")
 	flags.String("format", "text", "Output format (text, json, csv)")
+	flags.String("lang", "", "Output language (en, pt-BR, es; default: detected from LC_ALL/LC_MESSAGES/LANG)")
+	flags.String("attempts-histogram", "", "Write the per-wallet attempts histogram of a --count batch as JSON to this file (- for stdout)")
+	flags.String("label", "", "Label stored with generated wallets (e.g. \"treasury hot wallet\")")
+	flags.StringArray("tag", nil, "Tag stored with generated wallets, repeatable (e.g. team:ops)")
+	flags.Bool("label-filenames", false, "Prefix keystore filenames with the label slug (<label>_<address>.json)")
+	flags.String("keystore-path-template", "", "Keystore path inside --keystore-dir as a Go template, e.g. \"{{.Year}}/{{.Pattern}}/{{.Address}}.json\"; fields: Address, Network, Prefix, Suffix, Pattern, Label, Year, Month, Day, Date")
 
 	// KeyStore parameters
 	flags.String("keystore-dir", "./keystores", "Directory to save keystore files")
 	flags.Bool("no-keystore", false, "Disable keystore file generation")
 	flags.String("keystore-kdf", "scrypt", "KDF algorithm for keystore encryption (scrypt, pbkdf2, pbkdf2-sha256, pbkdf2-sha512)")
+	flags.String("keystore-cipher", "aes-128-ctr", "Keystore cipher (aes-128-ctr, aes-256-ctr, or experimental non-standard aes-128-gcm)")
+	flags.Bool("keystore-defer", false, "Hold found keys in locked memory and write all keystores at the end of the run (or on SIGUSR1), with a SHA-256 manifest")
+	app.rootCmd.Flags().Bool("force", false, "Take over the lock of another run writing to the same keystore directory or vault")
+	flags.Bool("atomic-output", false, "Write the run's keystores or vault entries together when all --count wallets are found, and none if it fails or is cancelled")
+	flags.Int("keystore-workers", 2, "Keystores of a --count run encrypted and written in parallel while the search continues (0 writes them after the search)")
 	flags.String("kdf-params", "", "Custom KDF parameters as JSON (e.g., '{\"n\":262144,\"r\":8,\"p\":1,\"dklen\":32}}' for scrypt)")
+	flags.String("kdf-max-memory", "50%", "Memory cap for scrypt derivations, as a share of available RAM (50%) or a size (512MiB); default parameters step N down to fit, explicit --kdf-params fail")
+	flags.String("mem-limit", "auto", "Soft memory limit of the Go runtime: auto (90% of RAM or the cgroup limit), off, a share (80%) or a size (2GiB); GOGC drops when scrypt leaves little room")
 	flags.Bool("kdf-analysis", false, "Show KDF compatibility analysis and security assessment")
 	flags.String("security-level", "medium", "Minimum security level for KDF parameters (low, medium, high, very-high)")
+	flags.String("password-protection", "none", "Encrypt generated .pwd files at rest (none, gpg:<recipient>, age:<recipient>)")
+	flags.String("keystore-password-policy", "random:12", "Keystore password policy: random:<length>[,classes=lower+upper+digits+symbols][,no-ambiguous] or diceware:<words>[,separator=<s>]")
+	flags.String("vault", "", "Store all generated wallets in one encrypted vault file instead of per-address keystores")
+	flags.String("vault-password-file", "", "File holding the vault password (required with --vault)")
+	flags.String("hardware", "", "Move found private keys into secure hardware instead of keystores (tpm2, yubikey-piv; see \"hardware capabilities\")")
+	flags.String("hardware-dir", "./hardware-keys", "Directory for the key handles of --hardware keys")
+	flags.String("hardware-password-file", "", "File holding a password the TPM requires to unseal --hardware keys")
+	flags.String("yubikey-slot", "9c", "PIV slot receiving the key with --hardware yubikey-piv (9a, 9c, 9d, 9e, 82-95)")
+	flags.String("yubikey-serial", "", "Serial number of the YubiKey to use when several are connected")
+	flags.String("yubikey-pin-file", "", "File holding the YubiKey PIV PIN")
+	flags.String("yubikey-management-key-file", "", "File holding the YubiKey PIV management key (default: the factory key)")
+	flags.String("slip39", "", "Back up mnemonics as SLIP-39 Shamir shares instead of a .mnemonic file (e.g. 2-of-3)")
+	flags.String("account-report", "", "Write a CSV or JSON (by extension) account report for hardware wallet and bulk import")
+	flags.String("entropy", "os", "Entropy source for keys and mnemonics (os, hybrid = OS RNG mixed with user entropy, file:<path>)")
+	flags.String("extra-entropy-file", "", "Mix the contents of this file (dice rolls, a passphrase) into key generation via HKDF")
+	flags.String("key-format", "hex", "Private key output format (hex, hex0x, wif, base64); keystores keep hex")
+	flags.Bool("no-key-output", false, "Never print private keys or mnemonics; found keys only go to keystores, the vault or --hardware")
+	flags.Bool("include-pubkey", false, "Include the uncompressed and compressed public keys in wallet results")
+	flags.String("explorer", "", "Link found Ethereum addresses to a block explorer: etherscan[:<baseurl>] or blockscout:<baseurl>")
+	flags.Bool("qr", false, "Print a QR code of each found address in the terminal (disables the TUI)")
+	flags.String("qr-file", "", "Write a QR image of each found address to this directory")
+	flags.String("qr-format", "png", "Image format of --qr-file (png, svg)")
+	flags.Bool("qr-private", false, "Also QR-encode private keys with --qr and --qr-file (requires --i-understand)")
+	flags.Bool("i-understand", false, "Confirm that --qr-private exposes private keys to anyone who sees the QR codes")
+	flags.String("paper-wallet", "", "Print found wallets to this PDF as foldable paper wallets, private keys included")
+	flags.String("paper-wallet-template", "", "JSON template branding --paper-wallet (title, subtitle, footer, instructions, accent_color, page_size, logo)")
+	flags.String("key-range", "", "Search only private keys in this inclusive hex range, in order (start:end, e.g. 0x20000000000000000:0x3ffffffffffffffff)")
+	flags.Uint64("key-range-stride", 1, "Step between the keys searched in --key-range")
+	flags.String("master-seed-file", "", "Derive candidate keys from this master seed (32+ random bytes, raw or hex) and a counter, so the batch can be derived again; found wallets record their counter")
+	flags.String("checkpoint", "", "Save --key-range or --master-seed-file progress to this file and resume from it when it exists")
+	flags.Duration("checkpoint-interval", 30*time.Second, "How often --checkpoint is saved during a run")
+	flags.String("checkpoint-key", "", "Encrypt --checkpoint with a passphrase (file:<path>) or a key kept in the machine keyring (keyring)")
+
+	// Address screening (local lists only)
+	flags.StringArray("screen-list", nil, "CSV file of addresses never to hand out (used, known or sanctioned); listed finds are regenerated, repeatable")
+	flags.String("screen-report", "", "Append one JSON line per screened address to this file")
+	flags.String("bloom-filter", "", "Bloom filter of existing organizational addresses (see \"bloom build\"); possible members are regenerated")
+	flags.String("reject-words", "", "File of words (one per line) that found addresses must not contain anywhere, ignoring case")
+	flags.Int("min-zero-bytes", 0, "Only accept Ethereum addresses with at least this many zero bytes, which are cheaper in calldata")
+	flags.String("sort-by", "", "Order batch results and the account report by zero-bytes, leading-zeros or uppercase, highest first")
+
+	// On-chain verification (opt-in; offline by default)
+	flags.String("rpc-url", "", "Ethereum JSON-RPC endpoint used to confirm found addresses are unused (default: offline)")
+	flags.Duration("rpc-timeout", chain.DefaultTimeout, "Timeout for each on-chain check request")
+	flags.String("fund-amount", "", "Build a funding transaction of this many ETH from a hot wallet to each found address")
+	flags.String("fund-from", "", "Hot wallet address funding found addresses (for unsigned transactions)")
+	flags.String("fund-key-file", "", "File holding the hot wallet's hex private key; signs the funding transactions")
+	flags.Bool("fund-broadcast", false, "Broadcast signed funding transactions via --rpc-url")
+	flags.String("fund-tx-out", "", "Write funding transactions as JSON to this file (default: stdout)")
+	flags.Int64("fund-chain-id", 1, "Chain ID for funding transactions (checked against --rpc-url when set)")
+	flags.Int64("fund-nonce", -1, "Hot wallet nonce for the first funding transaction (-1 = fetch via --rpc-url)")
+	flags.String("fund-max-fee", "", "Max fee per gas in gwei (default: estimated via --rpc-url)")
+	flags.String("fund-priority-fee", "", "Max priority fee per gas in gwei (default: estimated via --rpc-url)")
 
 	// Secure logging parameters (never logs sensitive data)
 	flags.String("log-level", "info", "Logging level (error, warn, info, debug) - secure logging only")
@@ -114,25 +355,108 @@ func (app *Application) addGlobalFlags() {
 	flags.Int64("log-max-size", 10*1024*1024, "Maximum log file size in bytes before rotation")
 	flags.Int("log-max-files", 5, "Maximum number of rotated log files to keep")
 	flags.Int("log-buffer-size", 1000, "Buffer size for async logging")
+
+	// Orchestrator integration
+	flags.String("health-addr", "", "Serve /healthz and /readyz on this address (e.g. :8080)")
+	flags.Duration("health-stall-timeout", 60*time.Second, "Report unhealthy when pending work makes no progress for this long")
+	flags.Duration("watchdog", 0, "Dump goroutine stacks when a search makes no attempts for this long (0 = off)")
+	flags.Bool("watchdog-restart", false, "Cancel and restart a search the --watchdog finds stalled")
+	flags.String("watchdog-dump", "", "Append --watchdog goroutine dumps to this file instead of stderr")
+	flags.Float64("pause-below-battery", 0, "Pause generation while the host runs on battery below this charge in percent, resuming when it recovers (0 = off; default: $BLOCO_PAUSE_BELOW_BATTERY)")
+	flags.Float64("pause-above-temp", 0, "Pause generation while the CPU is hotter than this many °C, resuming when it cools (0 = off; default: $BLOCO_PAUSE_ABOVE_TEMP)")
+	flags.Duration("power-check-interval", 30*time.Second, "How often to read the battery and CPU temperature for --pause-below-battery and --pause-above-temp")
+	flags.StringArray("publish", nil, "Publish progress and found addresses (never keys) as JSON to nats://host:4222/subject or mqtt://host:1883/topic, repeatable")
+	flags.String("status-file", "", "Keep this JSON file updated with the run's attempts, probability, ETA and closest miss (never keys), every --status-interval")
+	flags.String("status-url", "", "Also push the status JSON to this URL with PUT ($BLOCO_STATUS_TOKEN bearer), or to https://api.github.com/gists/<id> with $GITHUB_TOKEN, at most once a minute")
+	flags.String("notify", "", "JSON config of Slack, Discord or Telegram notifiers for run start, 50% probability and found wallets, and SMTP summary mails")
+	flags.String("audit-trail", "", "Append hash-chained audit entries for configuration, found wallets and keystore access to this file")
+	flags.String("otlp-endpoint", "", "Export OpenTelemetry traces to this OTLP/HTTP collector (e.g. http://localhost:4318; default: $OTEL_EXPORTER_OTLP_ENDPOINT)")
+
+	// Key generation ceremony
+	flags.Bool("ceremony", false, "Run as an offline key generation ceremony: refuse network access, require two operators and write a signed transcript")
+	flags.Bool("ceremony-allow-network", false, "Continue a --ceremony even though network interfaces are up (recorded in the transcript)")
+	flags.String("ceremony-key", "", "Ed25519 PKCS#8 PEM key that signs the ceremony transcript (default: a one-time key)")
+
+	// Air-gap verification
+	flags.Bool("require-offline", false, "Refuse to start while any non-loopback network interface is up; the check is recorded in the --audit-trail")
+	flags.Bool("require-offline-warn", false, "Only warn, loudly, when --require-offline finds a network interface up")
+
+	// Process hardening
+	flags.Bool("harden", false, "Lock the process down before handling keys: no network sockets, exec or ptrace, no capabilities (a seccomp allowlist on Linux, best effort elsewhere)")
 }
 
 // createWorkerPool creates an optimized worker pool with secure logging
 func (app *Application) createWorkerPool(poolManager *crypto.PoolManager, validator *validation.AddressValidator, network string) (worker.WorkerPool, error) {
 	// Create worker pool with configuration that includes logging settings
-	pool := worker.NewPoolWithConfig(app.config.Worker.ThreadCount, app.config, network)
-	return pool, nil
+	pool := app.newWorkerPool(network)
+	if cipher := app.config.KeyStore.Cipher; app.config.KeyStore.Enabled && !crypto.IsStandardCipher(cipher) && !app.config.CLI.QuietMode {
+		fmt.Fprintln(os.Stderr, i18n.T("warn.cipher_nonstandard", cipher))
+		if crypto.IsExperimentalCipher(cipher) {
+			fmt.Fprintln(os.Stderr, i18n.T("warn.cipher_experimental"))
+		}
+	}
+	if app.keyRange != nil {
+		pool.SetKeyRange(app.keyRange.cursor)
+		pool.SetMasterSeed(app.keyRange.seed)
+	}
+	return app.screenPool(app.watchdogPool(pool)), nil
+}
+
+// searchWallets runs count searches of pool through the generator library
+func searchWallets(ctx context.Context, pool worker.WorkerPool, criteria wallet.GenerationCriteria, count int) (<-chan generator.Result, error) {
+	g, err := generator.New(generator.Options{Count: count, Searcher: pool})
+	if err != nil {
+		return nil, err
+	}
+	return g.Generate(ctx, criteria)
+}
+
+// searchWallet runs a single search of pool through the generator library
+func searchWallet(ctx context.Context, pool worker.WorkerPool, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	searches, err := searchWallets(ctx, pool, criteria, 1)
+	if err != nil {
+		return nil, err
+	}
+	search, ok := <-searches
+	if !ok {
+		return nil, errors.NewCancellationError("generate_wallet", "generation cancelled")
+	}
+	if search.Err != nil {
+		return nil, search.Err
+	}
+	return search.GenerationResult(), nil
 }
 
 // generateWallet is the main command handler for wallet generation
-func (app *Application) generateWallet(cmd *cobra.Command, args []string) error {
+func (app *Application) generateWallet(cmd *cobra.Command, args []string) (err error) {
 	ctx := cmd.Context()
 
+	if err := applyPreset(cmd); err != nil {
+		return err
+	}
+
 	// Parse flags and update configuration
 	if err := app.parseFlags(cmd); err != nil {
 		return errors.WrapError(err, errors.ErrorTypeConfiguration,
 			"parse_flags", "failed to parse command flags")
 	}
 
+	if app.constantRate > 0 && app.progressFormat != "json" {
+		return errors.NewValidationError("parse_flags", "--constant-rate paces the --progress-format json stream; add --progress-format json")
+	}
+
+	if preview, _ := cmd.Flags().GetBool("preview"); preview {
+		return app.runMnemonicPreview(cmd, cmd.OutOrStdout())
+	}
+	if stdinPatterns, _ := cmd.Flags().GetBool("stdin-patterns"); stdinPatterns {
+		if app.keepTop > 0 {
+			return errors.NewValidationError("parse_flags", "--keep-top cannot be combined with --stdin-patterns")
+		}
+		return app.runStdinPatterns(cmd)
+	} else if cmd.Flags().Changed("count-per-pattern") {
+		return errors.NewValidationError("parse_flags", "--count-per-pattern requires --stdin-patterns")
+	}
+
 	// Get generation parameters
 	criteria, err := app.getGenerationCriteria(cmd)
 	if err != nil {
@@ -140,9 +464,96 @@ func (app *Application) generateWallet(cmd *cobra.Command, args []string) error
 			"get_criteria", "invalid generation criteria")
 	}
 
+	if app.slip39Count > 0 {
+		switch strings.ToLower(criteria.Network) {
+		case "bitcoin":
+		case "", "ethereum":
+			if !criteria.UseMnemonic {
+				return errors.NewValidationError("get_criteria", "--slip39 requires --with-mnemonic")
+			}
+		default:
+			return errors.NewValidationError("get_criteria",
+				fmt.Sprintf("--slip39 is not supported for %s wallets, which have no mnemonic", criteria.Network))
+		}
+	}
+
+	if app.keyRange, err = parseKeyRange(cmd, criteria); err != nil {
+		return err
+	}
+	if app.keyRange != nil && app.keepTop > 0 {
+		return errors.NewValidationError("parse_flags", "--keep-top cannot be combined with --key-range")
+	}
+	app.keystoreCriteria = criteria
+	if app.config.KeyStore.Enabled {
+		force, _ := cmd.Flags().GetBool("force")
+		lock, err := app.lockOutput(cmd.CommandPath(), patternName(criteria), force)
+		if err != nil {
+			return err
+		}
+		defer lock.release()
+	}
+	if app.keyRange != nil {
+		app.keyRange.retry = app.retry.Checkpoint
+	}
+	if criteria.UseMnemonic && !app.config.CLI.QuietMode && (criteria.Network == "" || criteria.Network == "ethereum") {
+		fmt.Fprintln(os.Stderr, i18n.T("generate.mnemonic_path", crypto.EthereumDerivationPath))
+	}
+	if app.keyRange != nil {
+		// Range coverage is reported in the text output
+		app.config.TUI.Enabled = false
+	}
+
 	count, _ := cmd.Flags().GetInt("count")
 	showProgress, _ := cmd.Flags().GetBool("progress")
 
+	atomicOutput, _ := cmd.Flags().GetBool("atomic-output")
+	if atomicOutput && !app.config.KeyStore.Enabled {
+		return errors.NewValidationError("parse_flags",
+			"--atomic-output stages keystore or vault writes; it cannot be combined with --no-keystore or --hardware")
+	}
+	if atomicOutput {
+		app.deferredKeystores = app.newAtomicOutput()
+		defer func() {
+			// Wallets still held here belong to a run that failed before its end
+			if dropped := app.deferredKeystores.discard(); dropped > 0 {
+				app.auditRollback(dropped)
+			}
+			app.deferredKeystores = nil
+		}()
+	} else if deferKeystores, _ := cmd.Flags().GetBool("keystore-defer"); deferKeystores && app.config.KeyStore.Enabled {
+		if app.vault != nil {
+			return errors.NewValidationError("parse_flags", "--keystore-defer writes keystore files; it cannot be combined with --vault")
+		}
+		app.deferredKeystores = app.newDeferredKeystores()
+		defer func() {
+			if flushErr := app.deferredKeystores.finish(); flushErr != nil && err == nil {
+				err = flushErr
+			}
+			app.deferredKeystores = nil
+		}()
+	}
+
+	if ceremonyMode, _ := cmd.Flags().GetBool("ceremony"); ceremonyMode {
+		ceremony, err := app.beginCeremony(cmd, criteria, count)
+		if err != nil {
+			return err
+		}
+		defer func() { err = ceremony.finish(app, err) }()
+	}
+
+	ctx, span := tracing.Start(ctx, "generate",
+		tracing.String("pattern.prefix", criteria.Prefix),
+		tracing.String("pattern.suffix", criteria.Suffix),
+		tracing.Bool("pattern.checksum", criteria.IsChecksum),
+		tracing.String("network", criteria.Network),
+		tracing.Int("wallets", count),
+		tracing.Int("threads", app.config.Worker.ThreadCount))
+	app.traceCtx = ctx
+	defer func() {
+		span.RecordError(err)
+		span.End()
+	}()
+
 	// Create crypto components
 	poolManager := crypto.NewPoolManager(crypto.DefaultPoolConfig())
 	checksumValidator := crypto.NewChecksumValidator(poolManager)
@@ -162,16 +573,124 @@ func (app *Application) generateWallet(cmd *cobra.Command, args []string) error
 	defer func() {
 		if err := workerPool.Shutdown(); err != nil {
 			// Log shutdown error but don't override the main function's return value
-			fmt.Fprintf(os.Stderr, "Warning: failed to shutdown worker pool: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("warn.pool_shutdown", err))
 		}
 	}()
 
+	stopHealth, err := app.startHealthServer(cmd, poolHealthProvider(workerPool, count))
+	if err != nil {
+		return err
+	}
+	defer stopHealth()
+
+	budget, err := parseAttemptBudget(cmd, criteria, count)
+	if err != nil {
+		return err
+	}
+	genCtx := ctx
+	if app.timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		genCtx, cancelTimeout = context.WithTimeout(genCtx, app.timeout)
+		defer cancelTimeout()
+	}
+	if budget != nil {
+		var cancelBudget context.CancelFunc
+		genCtx, cancelBudget = budget.watch(ctx, workerPool.GetStatsCollector())
+		defer cancelBudget()
+		if !app.config.CLI.QuietMode {
+			fmt.Println(i18n.T("generate.attempt_budget", formatLargeNumber(budget.total), budget.probability))
+		}
+	}
+	genCtx, stopPower := app.watchPower(genCtx)
+	defer stopPower()
+	stopCheckpoints := func() {}
+	if app.keyRange != nil {
+		if !app.config.CLI.QuietMode {
+			app.keyRange.describe()
+		}
+		stopCheckpoints = app.keyRange.watch(ctx, func() int {
+			app.generatedMu.Lock()
+			defer app.generatedMu.Unlock()
+			return len(app.generated)
+		})
+	}
+
+	// Every progress display of the run subscribes to one broker
+	app.progress = worker.NewProgressBroker(workerPool.GetStatsCollector())
+	app.progress.CalibrateETA(app.etaCalibration)
+	if app.progressFormat == "json" {
+		out, closer, err := openProgressOutput(app.progressFile)
+		if err != nil {
+			return err
+		}
+		if app.constantRate > 0 {
+			app.progressEvents = startPacedJSONProgress(genCtx, out, closer, count, app.config.Worker.ThreadCount, app.constantRate)
+			app.progress.Subscribe(0, app.progressEvents.handle)
+		} else {
+			app.progressEvents = startJSONProgress(out, closer, workerPool.GetStatsCollector(),
+				criteria, count, app.config.Worker.ThreadCount, app.etaPercentiles)
+			app.progress.Subscribe(app.statusInterval, app.progressEvents.handle)
+		}
+	}
+	if err := app.startPublishing(cmd, workerPool.GetStatsCollector(), criteria, count); err != nil {
+		return err
+	}
+	app.startNotifications(criteria, count)
+	if genCtx, err = app.startStatusReports(genCtx, cmd, criteria, count); err != nil {
+		return err
+	}
+
 	// Generate wallets
-	if count == 1 {
-		return app.generateSingleWallet(ctx, workerPool, criteria, showProgress)
+	switch {
+	case app.keepTop > 0:
+		err = app.generateTopWallets(ctx, genCtx, workerPool, criteria)
+	case count == 1:
+		err = app.generateSingleWallet(genCtx, workerPool, criteria, showProgress)
+	default:
+		err = app.generateMultipleWallets(genCtx, workerPool, criteria, count, showProgress)
+	}
+	app.generatedMu.Lock()
+	found := len(app.generated)
+	app.generatedMu.Unlock()
+	if budget != nil {
+		attempts := workerPool.GetStatsCollector().GetTotalAttempts()
+		if budgetErr := budget.report(app.config.CLI.QuietMode, found, count, attempts); budgetErr != nil {
+			err = budgetErr
+		}
+	}
+	if app.keyRange != nil {
+		stopCheckpoints()
+		app.keyRange.report(app.config.CLI.QuietMode, found, count)
+		if saveErr := app.keyRange.save(found); saveErr != nil && err == nil {
+			err = saveErr
+		}
 	} else {
-		return app.generateMultipleWallets(ctx, workerPool, criteria, count, showProgress)
+		app.reportChecksumVerification(workerPool.GetStatsCollector(), criteria)
 	}
+	err = app.generationOutcome(ctx, genCtx, budget, found, count, err)
+	app.progress.Close(err)
+	app.finishScreening()
+
+	rolledBack, err := app.settleOutput(found, count, err)
+	if err == nil && !rolledBack {
+		err = app.checkWalletsOnChain(ctx)
+	}
+	if err == nil && !rolledBack {
+		err = app.fundWallets(ctx)
+	}
+
+	// Report whatever was found, even if generation stopped early
+	if reportPath, _ := cmd.Flags().GetString("account-report"); reportPath != "" && !rolledBack {
+		if reportErr := app.writeAccountReport(reportPath); reportErr != nil && err == nil {
+			err = reportErr
+		}
+	}
+	if app.paperWallet != nil && !rolledBack {
+		if paperErr := app.writePaperWallet(); paperErr != nil && err == nil {
+			err = paperErr
+		}
+	}
+	return err
 }
 
 // generateSingleWallet generates a single wallet with progress tracking
@@ -199,15 +718,19 @@ func (app *Application) generateSingleWallet(
 	return app.generateSingleWalletText(ctx, workerPool, criteria, showProgress)
 }
 
-// generateSingleWalletTUI generates a single wallet with TUI progress (fixed based on monolithic version)
+// generateSingleWalletTUI generates a single wallet with TUI progress
 func (app *Application) generateSingleWalletTUI(
 	ctx context.Context,
 	workerPool worker.WorkerPool,
 	criteria wallet.GenerationCriteria,
 ) error {
+	// The TUI shows power pauses in place of the stderr notes
+	app.showPowerInTUI()
+
 	// Create TUI statistics
 	difficulty := calculateDifficulty(criteria)
 	probability50 := calculateProbability50(difficulty)
+	etaTargets := utils.CalculateETAPercentiles(difficulty, 1, app.etaPercentiles)
 
 	tuiStats := &wallet.GenerationStats{
 		Difficulty:      difficulty,
@@ -233,18 +756,53 @@ func (app *Application) generateSingleWalletTUI(
 	// Create TUI program (without alt screen for compatibility)
 	program := tea.NewProgram(progressModel)
 
-	// Channel for wallet results (like in monolithic version)
+	// Channel for wallet results
 	walletResultsChan := make(chan tui.WalletResult, 1)
 
 	// Channel to signal shutdown
 	shutdownChan := make(chan struct{})
 	var shutdownOnce sync.Once // Ensure channel is closed only once
 
-	// Start progress updates (like in monolithic version)
-	go func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
+	// Progress updates come from the run's progress broker
+	unsubscribe := app.progress.Subscribe(100*time.Millisecond, func(e worker.ProgressEvent) {
+		if e.Type != worker.ProgressTick {
+			return
+		}
+		stats := e.Stats
+
+		// Calculate probability based on current attempts
+		probability := vanitymath.Probability(difficulty, stats.TotalAttempts) * 100
+
+		// Calculate estimated time at the speed measured for this pattern
+		speed := e.ETASpeed
+		var estimatedTime time.Duration
+		if probability50 > 0 && speed > 0 {
+			remainingAttempts := probability50 - stats.TotalAttempts
+			if remainingAttempts > 0 {
+				estimatedTime = time.Duration(float64(remainingAttempts)/speed) * time.Second
+			}
+		}
+
+		// Send progress update to TUI
+		program.Send(tui.ProgressMsg{
+			Attempts:         stats.TotalAttempts,
+			Speed:            stats.TotalSpeed,
+			Probability:      probability,
+			EstimatedTime:    estimatedTime,
+			ETAPercentiles:   utils.EstimateETAPercentiles(etaTargets, stats.TotalAttempts, speed),
+			Difficulty:       difficulty,
+			Pattern:          criteria.GetPattern(),
+			CompletedWallets: 0, // Single wallet mode
+			TotalWallets:     1,
+			ProgressPercent:  probability,
+			IsComplete:       false,
+			Paused:           app.pausedReason(),
+		})
+	})
+	defer unsubscribe()
 
+	go func() {
+		defer unsubscribe()
 		for {
 			select {
 			case <-shutdownChan:
@@ -252,36 +810,6 @@ func (app *Application) generateSingleWalletTUI(
 				program.Send(tui.SendQuit())
 				return
 
-			case <-ticker.C:
-				// Get current stats and send progress update
-				stats := statsCollector.GetAggregatedStats()
-
-				// Calculate probability based on current attempts
-				probability := utils.CalculateProbability(difficulty, stats.TotalAttempts) * 100
-
-				// Calculate estimated time
-				var estimatedTime time.Duration
-				if probability50 > 0 && stats.TotalSpeed > 0 {
-					remainingAttempts := probability50 - stats.TotalAttempts
-					if remainingAttempts > 0 {
-						estimatedTime = time.Duration(float64(remainingAttempts)/stats.TotalSpeed) * time.Second
-					}
-				}
-
-				// Send progress update to TUI
-				program.Send(tui.ProgressMsg{
-					Attempts:         stats.TotalAttempts,
-					Speed:            stats.TotalSpeed,
-					Probability:      probability,
-					EstimatedTime:    estimatedTime,
-					Difficulty:       difficulty,
-					Pattern:          criteria.GetPattern(),
-					CompletedWallets: 0, // Single wallet mode
-					TotalWallets:     1,
-					ProgressPercent:  probability,
-					IsComplete:       false,
-				})
-
 			case walletResult, ok := <-walletResultsChan:
 				if !ok {
 					// Channel closed, exit
@@ -317,15 +845,15 @@ func (app *Application) generateSingleWalletTUI(
 		}
 	}()
 
-	// Start wallet generation in background (like in monolithic version)
+	// Start wallet generation in background
 	var result *wallet.GenerationResult
-	var genErr error
+	var genErr, keystoreErr error
 
 	go func() {
 		// Small delay to let TUI initialize
 		time.Sleep(200 * time.Millisecond)
 
-		genResult, err := workerPool.GenerateWalletWithContext(ctx, criteria)
+		genResult, err := searchWallet(ctx, workerPool, criteria)
 		if err != nil {
 			genErr = err
 			shutdownOnce.Do(func() { close(shutdownChan) })
@@ -333,12 +861,15 @@ func (app *Application) generateSingleWalletTUI(
 		}
 
 		result = genResult
+		app.recordWallet(genResult.Wallet)
+		app.progress.PublishWallet(genResult)
 
 		// Generate and save keystore files if enabled (silent mode for TUI)
 		if app.config.KeyStore.Enabled {
 			if err := app.generateAndSaveKeystoreWithVerbose(genResult.Wallet, false); err != nil {
+				keystoreErr = err
 				if !app.config.CLI.QuietMode {
-					fmt.Printf("Warning: Failed to generate keystore: %v\n", err)
+					fmt.Println(i18n.T("result.keystore_failed", err))
 				}
 			}
 		}
@@ -348,10 +879,12 @@ func (app *Application) generateSingleWalletTUI(
 		case walletResultsChan <- tui.WalletResult{
 			Index:      1,
 			Address:    genResult.Wallet.Address,
-			PrivateKey: genResult.Wallet.PrivateKey,
+			PrivateKey: app.printedKey(genResult.Wallet),
 			Attempts:   int(genResult.Attempts),
 			Time:       genResult.Duration,
 			Error:      "",
+			Marks:      addressMarks(genResult.Wallet.Address, criteria),
+			Explorer:   app.explorer.URL(genResult.Wallet.Network, genResult.Wallet.Address),
 		}:
 		case <-ctx.Done():
 		}
@@ -362,7 +895,7 @@ func (app *Application) generateSingleWalletTUI(
 
 	// Run the TUI program (this blocks until quit)
 	if _, err := program.Run(); err != nil {
-		fmt.Printf("TUI failed: %v, falling back to text mode\n", err)
+		fmt.Println(i18n.T("warn.tui_failed", err))
 		return app.generateSingleWalletText(ctx, workerPool, criteria, true)
 	}
 
@@ -374,6 +907,9 @@ func (app *Application) generateSingleWalletTUI(
 
 	// Return the result (don't show it again since TUI already showed it)
 	if result != nil {
+		if keystoreErr != nil {
+			return app.lostKeys(keystoreErr)
+		}
 		return nil // Success, TUI already displayed the result
 	}
 
@@ -388,17 +924,25 @@ func (app *Application) generateSingleWalletText(
 	showProgress bool,
 ) error {
 	if showProgress && !app.config.CLI.QuietMode {
-		fmt.Printf("Generating wallet with pattern: %s\n", criteria.GetPattern())
-		fmt.Printf("Difficulty: %s\n", formatLargeNumber(int64(calculateDifficulty(criteria))))
-		fmt.Printf("Using %d worker threads\n\n", app.config.Worker.ThreadCount)
+		fmt.Println(i18n.T("generate.header", criteria.GetPattern()))
+		fmt.Println(i18n.T("generate.difficulty", formatLargeNumber(int64(calculateDifficulty(criteria)))))
+		printRejectWords(criteria)
+		printZeroBytes(criteria)
+		fmt.Printf("%s\n\n", i18n.T("generate.threads", app.config.Worker.ThreadCount))
 	}
 
 	// Completely disable progress manager to avoid deadlocks
 	// The issue is in the progress system, not the worker pool
 	_ = showProgress // Acknowledge parameter but don't use it
 
+	// Accessible mode reports progress as plain periodic lines instead
+	if showProgress && plainOutput.Load() && !app.config.CLI.QuietMode {
+		stopStatus := app.progress.Subscribe(app.statusInterval, statusLines(criteria, 1, app.etaPercentiles))
+		defer stopStatus()
+	}
+
 	// Generate wallet
-	result, err := workerPool.GenerateWalletWithContext(ctx, criteria)
+	result, err := searchWallet(ctx, workerPool, criteria)
 	if err != nil {
 		if showProgress && !app.config.CLI.QuietMode {
 			fmt.Printf("\n")
@@ -408,13 +952,14 @@ func (app *Application) generateSingleWalletText(
 	}
 
 	// Wallet completed successfully
+	app.progress.PublishWallet(result)
 
 	if showProgress && !app.config.CLI.QuietMode {
 		fmt.Printf("\n")
 	}
 
 	// Display result
-	return app.displayWalletResult(result, showProgress)
+	return app.displayWalletResult(result, criteria, showProgress)
 }
 
 // generateMultipleWallets generates multiple wallets with progress tracking
@@ -443,16 +988,20 @@ func (app *Application) generateMultipleWallets(
 	return app.generateMultipleWalletsText(ctx, workerPool, criteria, count, showProgress)
 }
 
-// generateMultipleWalletsTUI generates multiple wallets with TUI (based on monolithic version)
+// generateMultipleWalletsTUI generates multiple wallets with TUI
 func (app *Application) generateMultipleWalletsTUI(
 	ctx context.Context,
 	workerPool worker.WorkerPool,
 	criteria wallet.GenerationCriteria,
 	count int,
 ) error {
+	// The TUI shows power pauses in place of the stderr notes
+	app.showPowerInTUI()
+
 	// Create TUI statistics
 	difficulty := calculateDifficulty(criteria)
 	probability50 := calculateProbability50(difficulty)
+	etaTargets := utils.CalculateETAPercentiles(difficulty, count, app.etaPercentiles)
 
 	tuiStats := &wallet.GenerationStats{
 		Difficulty:      difficulty,
@@ -478,7 +1027,7 @@ func (app *Application) generateMultipleWalletsTUI(
 	// Create TUI program (without alt screen for compatibility)
 	program := tea.NewProgram(progressModel)
 
-	// Channels for communication (like in monolithic version)
+	// Channels for communication
 	walletResultsChan := make(chan tui.WalletResult, count)
 	shutdownChan := make(chan struct{})
 	var shutdownOnce sync.Once // Ensure channel is closed only once
@@ -488,11 +1037,63 @@ func (app *Application) generateMultipleWalletsTUI(
 	var completedMutex sync.Mutex
 	var results []*wallet.GenerationResult
 
-	// Start progress updates (like in monolithic version)
-	go func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
+	// Progress updates come from the run's progress broker
+	unsubscribe := app.progress.Subscribe(100*time.Millisecond, func(e worker.ProgressEvent) {
+		if e.Type != worker.ProgressTick {
+			return
+		}
+		stats := e.Stats
+
+		// Calculate progress as percentage of wallets completed (thread-safe)
+		completedMutex.Lock()
+		currentCompleted := completedWallets
+		completedMutex.Unlock()
+
+		progressPercent := (float64(currentCompleted) / float64(count)) * 100.0
 
+		// Calculate probability based on progress
+		probability := progressPercent
+
+		// Calculate estimated time at the speed measured for this pattern
+		speed := e.ETASpeed
+		var estimatedTime time.Duration
+		if currentCompleted > 0 && speed > 0 {
+			remaining := count - currentCompleted
+			if remaining > 0 {
+				// Estimate remaining attempts based on average so far
+				avgAttemptsPerWallet := float64(stats.TotalAttempts) / float64(currentCompleted)
+				estimatedRemainingAttempts := avgAttemptsPerWallet * float64(remaining)
+				estimatedTime = time.Duration(estimatedRemainingAttempts/speed) * time.Second
+			}
+		} else if speed > 0 && probability50 > 0 {
+			// Use difficulty-based estimation
+			estimatedTotalAttempts := int64(count) * probability50
+			remainingAttempts := estimatedTotalAttempts - stats.TotalAttempts
+			if remainingAttempts > 0 {
+				estimatedTime = time.Duration(float64(remainingAttempts)/speed) * time.Second
+			}
+		}
+
+		// Send progress update to TUI
+		program.Send(tui.ProgressMsg{
+			Attempts:         stats.TotalAttempts,
+			Speed:            stats.TotalSpeed,
+			Probability:      probability,
+			EstimatedTime:    estimatedTime,
+			ETAPercentiles:   utils.EstimateETAPercentiles(etaTargets, stats.TotalAttempts, speed),
+			Difficulty:       difficulty,
+			Pattern:          criteria.GetPattern(),
+			CompletedWallets: currentCompleted,
+			TotalWallets:     count,
+			ProgressPercent:  progressPercent,
+			IsComplete:       currentCompleted >= count,
+			Paused:           app.pausedReason(),
+		})
+	})
+	defer unsubscribe()
+
+	go func() {
+		defer unsubscribe()
 		for {
 			select {
 			case <-shutdownChan:
@@ -500,53 +1101,6 @@ func (app *Application) generateMultipleWalletsTUI(
 				program.Send(tui.SendQuit())
 				return
 
-			case <-ticker.C:
-				// Get current stats and send progress update
-				stats := statsCollector.GetAggregatedStats()
-
-				// Calculate progress as percentage of wallets completed (thread-safe)
-				completedMutex.Lock()
-				currentCompleted := completedWallets
-				completedMutex.Unlock()
-
-				progressPercent := (float64(currentCompleted) / float64(count)) * 100.0
-
-				// Calculate probability based on progress
-				probability := progressPercent
-
-				// Calculate estimated time
-				var estimatedTime time.Duration
-				if currentCompleted > 0 && stats.TotalSpeed > 0 {
-					remaining := count - currentCompleted
-					if remaining > 0 {
-						// Estimate remaining attempts based on average so far
-						avgAttemptsPerWallet := float64(stats.TotalAttempts) / float64(currentCompleted)
-						estimatedRemainingAttempts := avgAttemptsPerWallet * float64(remaining)
-						estimatedTime = time.Duration(estimatedRemainingAttempts/stats.TotalSpeed) * time.Second
-					}
-				} else if stats.TotalSpeed > 0 && probability50 > 0 {
-					// Use difficulty-based estimation
-					estimatedTotalAttempts := int64(count) * probability50
-					remainingAttempts := estimatedTotalAttempts - stats.TotalAttempts
-					if remainingAttempts > 0 {
-						estimatedTime = time.Duration(float64(remainingAttempts)/stats.TotalSpeed) * time.Second
-					}
-				}
-
-				// Send progress update to TUI
-				program.Send(tui.ProgressMsg{
-					Attempts:         stats.TotalAttempts,
-					Speed:            stats.TotalSpeed,
-					Probability:      probability,
-					EstimatedTime:    estimatedTime,
-					Difficulty:       difficulty,
-					Pattern:          criteria.GetPattern(),
-					CompletedWallets: currentCompleted,
-					TotalWallets:     count,
-					ProgressPercent:  progressPercent,
-					IsComplete:       currentCompleted >= count,
-				})
-
 			case walletResult, ok := <-walletResultsChan:
 				if !ok {
 					// Channel closed, exit
@@ -590,31 +1144,39 @@ func (app *Application) generateMultipleWalletsTUI(
 		}
 	}()
 
-	// Start wallet generation in background (like in monolithic version)
+	// Start wallet generation in background
 	var genErr error
+	var keystoreErrs []error
 
 	go func() {
 		// Small delay to let TUI initialize
 		time.Sleep(200 * time.Millisecond)
 
 		results = make([]*wallet.GenerationResult, 0, count)
+		keystores := app.startKeystorePipeline(count)
+		if keystores != nil {
+			defer keystores.Wait()
+		}
 
-		for i := 0; i < count; i++ {
-			select {
-			case <-ctx.Done():
-				genErr = ctx.Err()
-				shutdownOnce.Do(func() { close(shutdownChan) })
-				return
-			default:
-			}
+		searches, err := searchWallets(ctx, workerPool, criteria, count)
+		if err != nil {
+			genErr = err
+			shutdownOnce.Do(func() { close(shutdownChan) })
+			return
+		}
+		for search := range searches {
+			if search.Err != nil {
+				if ctx.Err() != nil {
+					genErr = ctx.Err()
+					shutdownOnce.Do(func() { close(shutdownChan) })
+					return
+				}
 
-			result, err := workerPool.GenerateWalletWithContext(ctx, criteria)
-			if err != nil {
 				// Send error result to TUI
 				select {
 				case walletResultsChan <- tui.WalletResult{
-					Index: i + 1,
-					Error: err.Error(),
+					Index: search.Index,
+					Error: search.Err.Error(),
 				}:
 				case <-ctx.Done():
 					return
@@ -627,13 +1189,19 @@ func (app *Application) generateMultipleWalletsTUI(
 				continue
 			}
 
+			result := search.GenerationResult()
 			results = append(results, result)
+			app.recordWallet(result.Wallet)
+			app.progress.PublishWallet(result)
 
 			// Generate and save keystore files if enabled (silent mode for TUI)
-			if app.config.KeyStore.Enabled {
+			if keystores != nil {
+				keystores.Submit(search.Index, result.Wallet)
+			} else if app.config.KeyStore.Enabled {
 				if err := app.generateAndSaveKeystoreWithVerbose(result.Wallet, false); err != nil {
+					keystoreErrs = append(keystoreErrs, err)
 					if !app.config.CLI.QuietMode {
-						fmt.Printf("Warning: Failed to generate keystore for wallet %d: %v\n", i+1, err)
+						fmt.Println(i18n.T("batch.wallet_keystore_failed", search.Index, err))
 					}
 				}
 			}
@@ -646,18 +1214,37 @@ func (app *Application) generateMultipleWalletsTUI(
 			// Send successful wallet result to TUI
 			select {
 			case walletResultsChan <- tui.WalletResult{
-				Index:      i + 1,
+				Index:      search.Index,
 				Address:    result.Wallet.Address,
-				PrivateKey: result.Wallet.PrivateKey,
+				PrivateKey: app.printedKey(result.Wallet),
 				Attempts:   int(result.Attempts),
 				Time:       result.Duration,
 				Error:      "",
+				Marks:      addressMarks(result.Wallet.Address, criteria),
+				Explorer:   app.explorer.URL(result.Wallet.Network, result.Wallet.Address),
 			}:
 			case <-ctx.Done():
 				return
 			}
 		}
 
+		if ctx.Err() != nil {
+			genErr = ctx.Err()
+			shutdownOnce.Do(func() { close(shutdownChan) })
+			return
+		}
+
+		// Finish the queued keystores before reporting completion
+		if keystores != nil {
+			keystores.Wait()
+			for _, failure := range keystores.Failures() {
+				keystoreErrs = append(keystoreErrs, failure.err)
+				if !app.config.CLI.QuietMode {
+					fmt.Println(i18n.T("batch.wallet_keystore_failed", failure.index, failure.err))
+				}
+			}
+		}
+
 		// Close the wallet results channel when all wallets are generated
 		// This signals completion to the progress goroutine
 		close(walletResultsChan)
@@ -665,7 +1252,7 @@ func (app *Application) generateMultipleWalletsTUI(
 
 	// Run the TUI program (this blocks until quit)
 	if _, err := program.Run(); err != nil {
-		fmt.Printf("TUI failed: %v, falling back to text mode\n", err)
+		fmt.Println(i18n.T("warn.tui_failed", err))
 		return app.generateMultipleWalletsText(ctx, workerPool, criteria, count, true)
 	}
 
@@ -676,7 +1263,7 @@ func (app *Application) generateMultipleWalletsTUI(
 	}
 
 	// Return success (TUI already displayed the results)
-	return nil
+	return app.lostKeys(keystoreErrs...)
 }
 
 // generateMultipleWalletsText generates multiple wallets with text progress (fallback)
@@ -688,9 +1275,11 @@ func (app *Application) generateMultipleWalletsText(
 	showProgress bool,
 ) error {
 	if showProgress && !app.config.CLI.QuietMode {
-		fmt.Printf("Generating %d wallets with pattern: %s\n", count, criteria.GetPattern())
-		fmt.Printf("Difficulty: %s\n", formatLargeNumber(int64(calculateDifficulty(criteria))))
-		fmt.Printf("Using %d worker threads\n\n", app.config.Worker.ThreadCount)
+		fmt.Println(i18n.T("generate.header_batch", count, criteria.GetPattern()))
+		fmt.Println(i18n.T("generate.difficulty", formatLargeNumber(int64(calculateDifficulty(criteria)))))
+		printRejectWords(criteria)
+		printZeroBytes(criteria)
+		fmt.Printf("%s\n\n", i18n.T("generate.threads", app.config.Worker.ThreadCount))
 	}
 
 	results := make([]*wallet.GenerationResult, 0, count)
@@ -700,28 +1289,52 @@ func (app *Application) generateMultipleWalletsText(
 	// Disable progress manager to avoid deadlocks
 	_ = showProgress // Acknowledge parameter but don't use it
 
+	// Accessible mode reports progress as plain periodic lines instead
+	if showProgress && plainOutput.Load() && !app.config.CLI.QuietMode {
+		stopStatus := app.progress.Subscribe(app.statusInterval, statusLines(criteria, count, app.etaPercentiles))
+		defer stopStatus()
+	}
+
+	// Keystores are written while the search continues
+	keystores := app.startKeystorePipeline(count)
+	if keystores != nil {
+		defer keystores.Wait()
+	}
+
 	// Generate wallets with progress tracking
-	for i := range count {
-		result, err := workerPool.GenerateWalletWithContext(ctx, criteria)
-		if err != nil {
+	searches, err := searchWallets(ctx, workerPool, criteria, count)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeGeneration,
+			"generate_multiple_wallets", "failed to start generation")
+	}
+	for search := range searches {
+		if search.Err != nil {
+			if ctx.Err() != nil || stderrors.Is(search.Err, crypto.ErrKeyRangeExhausted) {
+				// Interrupted, out of time or out of keys; the remaining wallets would fail the same way
+				break
+			}
 			if showProgress && !app.config.CLI.QuietMode {
-				fmt.Printf("\nError generating wallet %d: %v\n", i+1, err)
+				fmt.Printf("\n%s\n", i18n.T("generate.batch_error", search.Index, search.Err))
 			}
 
 			// Continue with next wallet instead of failing completely
 			continue
 		}
 
+		result := search.GenerationResult()
 		results = append(results, result)
 		totalAttempts += result.Attempts
+		app.progress.PublishWallet(result)
+		if keystores != nil {
+			keystores.Submit(search.Index, result.Wallet)
+		}
 
 		// Mark wallet as completed
 		// Progress tracking disabled
 
 		// Show individual wallet result if verbose
 		if app.config.CLI.VerboseOutput {
-			fmt.Printf("\nWallet %d: 0x%s (attempts: %s)\n",
-				i+1, result.Wallet.Address, formatLargeNumber(result.Attempts))
+			fmt.Printf("\n%s\n", i18n.T("batch.wallet_verbose", search.Index, result.Wallet.Address, formatLargeNumber(result.Attempts)))
 		}
 	}
 
@@ -730,7 +1343,7 @@ func (app *Application) generateMultipleWalletsText(
 	}
 
 	// Display summary
-	return app.displayMultipleWalletResults(results, totalAttempts, time.Since(startTime), showProgress)
+	return app.displayMultipleWalletResults(results, criteria, totalAttempts, time.Since(startTime), showProgress, keystores)
 }
 
 // createStatsCommand creates the stats subcommand
@@ -746,6 +1359,10 @@ func (app *Application) createStatsCommand() *cobra.Command {
 	cmd.Flags().StringP("prefix", "p", "", "Address prefix to analyze")
 	cmd.Flags().StringP("suffix", "s", "", "Address suffix to analyze")
 	cmd.Flags().BoolP("checksum", "c", false, "Include checksum validation in analysis")
+	cmd.Flags().Bool("cloud-cost", false, "Estimate cloud cost and time to 50%/95% probability on spot instances")
+	cmd.Flags().String("cost-table", "", "JSON file with instance types, prices and throughput for --cloud-cost")
+	cmd.Flags().Bool("empirical", false, "Measure the matcher's observed match rate on random addresses and compare it with the difficulty")
+	cmd.Flags().Float64("samples", 1e7, "Number of random addresses to sample for --empirical (e.g. 1e7)")
 
 	return cmd
 }
@@ -762,6 +1379,27 @@ func (app *Application) showStats(cmd *cobra.Command, args []string) error {
 	difficulty := calculateDifficulty(criteria)
 	probability50 := calculateProbability50(difficulty)
 
+	// Cloud cost estimates are tabular, so they always use text mode
+	if cloudCost, _ := cmd.Flags().GetBool("cloud-cost"); cloudCost {
+		costTable, _ := cmd.Flags().GetString("cost-table")
+		if err := app.showStatsText(criteria, difficulty, probability50); err != nil {
+			return err
+		}
+		return app.showCloudCost(difficulty, costTable)
+	}
+
+	// Empirical validation prints its results after the text statistics
+	if empirical, _ := cmd.Flags().GetBool("empirical"); empirical {
+		if err := app.parseFlags(cmd); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"parse_flags", "failed to parse command flags")
+		}
+		if err := app.showStatsText(criteria, difficulty, probability50); err != nil {
+			return err
+		}
+		return app.runEmpiricalStats(cmd, criteria, difficulty)
+	}
+
 	// Check if TUI should be used
 	tuiManager := tui.NewTUIManager()
 	useTUI, _ := cmd.Flags().GetBool("tui")
@@ -800,7 +1438,7 @@ func (app *Application) showStatsTUI(criteria wallet.GenerationCriteria, difficu
 	// Run the TUI program
 	if _, err := program.Run(); err != nil {
 		// If TUI fails, fallback to text mode
-		fmt.Printf("TUI failed: %v, falling back to text mode\n", err)
+		fmt.Println(i18n.T("warn.tui_failed", err))
 		return app.showStatsText(criteria, difficulty, probability50)
 	}
 
@@ -810,23 +1448,33 @@ func (app *Application) showStatsTUI(criteria wallet.GenerationCriteria, difficu
 // showStatsText displays statistics in text mode (fallback)
 func (app *Application) showStatsText(criteria wallet.GenerationCriteria, difficulty float64, probability50 int64) error {
 	// Display statistics
-	fmt.Printf("Pattern Analysis: %s\n", criteria.GetPattern())
-	fmt.Printf("═══════════════════════════════════════\n\n")
-
-	fmt.Printf("Pattern Length: %d characters\n", criteria.GetPatternLength())
-	fmt.Printf("Checksum Validation: %s\n", formatBool(criteria.IsChecksum))
-	fmt.Printf("Difficulty: %s\n", formatLargeNumber(int64(difficulty)))
-	fmt.Printf("50%% Probability: %s attempts\n", formatLargeNumber(probability50))
+	fmt.Println(i18n.T("stats.title", criteria.GetPattern()))
+	printRule()
+	fmt.Println()
+
+	fmt.Println(i18n.T("stats.length", criteria.GetPatternLength()))
+	fmt.Println(i18n.T("stats.checksum", formatBool(criteria.IsChecksum)))
+	fmt.Println(i18n.T("generate.difficulty", formatLargeNumber(int64(difficulty))))
+	fmt.Println(i18n.T("stats.probability50", formatLargeNumber(probability50)))
+	printRejectWords(criteria)
+	printZeroBytes(criteria)
+
+	breakdown := vanitymath.Breakdown(criteria.Prefix, criteria.Suffix, criteria.IsCaseSensitive())
+	fmt.Printf("\n%s\n", i18n.T("stats.breakdown"))
+	fmt.Println("  " + i18n.T("stats.breakdown_base", criteria.GetPatternLength(), formatLargeNumber(int64(breakdown.Base))))
+	if criteria.IsCaseSensitive() {
+		fmt.Println("  " + i18n.T("stats.breakdown_case", breakdown.CaseSensitiveChars, formatLargeNumber(int64(breakdown.CaseFactor))))
+	} else {
+		fmt.Println("  " + i18n.T("stats.breakdown_no_case"))
+	}
 
 	// Show time estimates at different speeds
-	fmt.Printf("\nTime Estimates:\n")
+	fmt.Printf("\n%s\n", i18n.T("stats.time_estimates"))
 	speeds := []float64{1000, 10000, 50000, 100000}
 	for _, speed := range speeds {
 		if probability50 > 0 {
 			duration := time.Duration(float64(probability50)/speed) * time.Second
-			fmt.Printf("  At %s addr/s: %s\n",
-				formatLargeNumber(int64(speed)),
-				formatDuration(duration))
+			fmt.Println("  " + i18n.T("stats.at_speed", formatLargeNumber(int64(speed)), formatDuration(duration)))
 		}
 	}
 
@@ -845,7 +1493,18 @@ func (app *Application) createBenchmarkCommand() *cobra.Command {
 	// Add benchmark-specific flags
 	cmd.Flags().Int("attempts", 10000, "Number of attempts for benchmark")
 	cmd.Flags().Duration("duration", 30*time.Second, "Benchmark duration")
+	cmd.Flags().Duration("warmup", 5*time.Second, "Warm-up run before sampling starts, excluded from every benchmark figure (0 disables)")
 	cmd.Flags().Bool("detailed", false, "Show detailed per-thread statistics")
+	cmd.Flags().Bool("optimize-efficiency", false, "Sweep thread counts and batch sizes to find the most efficient configuration")
+	cmd.Flags().Duration("sweep-duration", 3*time.Second, "Duration of each configuration in the efficiency sweep")
+	cmd.Flags().Bool("compare-threads", false, "Benchmark 1, 2, 4... up to --threads threads and chart speedup, efficiency and an Amdahl projection")
+	cmd.Flags().Int("repeat", 1, "Run the benchmark this many times and report mean, median and standard deviation")
+	cmd.Flags().Bool("confidence", false, "With --repeat, report the 95% confidence interval of the mean speed")
+	cmd.Flags().String("save", "", "Write the measured speeds to this file for a later --baseline")
+	cmd.Flags().String("baseline", "", "Compare with speeds saved by --save; a verdict is only given when the difference exceeds the noise")
+	cmd.Flags().String("suite", "", "Run the named scenarios of this YAML or JSON file and report them together; an interrupted suite resumes")
+	cmd.Flags().String("suite-report", "", "Write the --suite report as JSON to this file")
+	cmd.Flags().Bool("restart-suite", false, "Run every --suite scenario again instead of resuming from the checkpoint")
 
 	return cmd
 }
@@ -857,17 +1516,83 @@ func (app *Application) runBenchmark(cmd *cobra.Command, args []string) error {
 	attempts, _ := cmd.Flags().GetInt("attempts")
 	duration, _ := cmd.Flags().GetDuration("duration")
 	detailed, _ := cmd.Flags().GetBool("detailed")
+	warmup, _ := cmd.Flags().GetDuration("warmup")
+	var err error
+	if app.warmup, err = parseWarmup(warmup); err != nil {
+		return err
+	}
+
+	if err := app.governMemory(cmd); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", "failed to parse command flags")
+	}
+
+	if suitePath, _ := cmd.Flags().GetString("suite"); suitePath != "" {
+		return app.runBenchmarkSuite(cmd, suitePath, attempts, duration)
+	}
+
+	if optimize, _ := cmd.Flags().GetBool("optimize-efficiency"); optimize {
+		sweepDuration, _ := cmd.Flags().GetDuration("sweep-duration")
+		if sweepDuration <= 0 {
+			return errors.NewValidationError("run_benchmark", "sweep duration must be positive")
+		}
+		return app.runEfficiencySweep(ctx, sweepDuration)
+	}
+
+	if compare, _ := cmd.Flags().GetBool("compare-threads"); compare {
+		if err := app.parseFlags(cmd); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"parse_flags", "failed to parse command flags")
+		}
+		sweepDuration, _ := cmd.Flags().GetDuration("sweep-duration")
+		if sweepDuration <= 0 {
+			return errors.NewValidationError("run_benchmark", "sweep duration must be positive")
+		}
+		if useTUI, _ := cmd.Flags().GetBool("tui"); useTUI && app.config.TUI.Enabled && tui.NewTUIManager().ShouldUseTUI() {
+			return app.runThreadComparisonTUI(ctx, sweepDuration)
+		}
+		return app.runThreadComparisonText(ctx, sweepDuration)
+	}
+
+	// --until-probability replaces --attempts with the budget for the --prefix/--suffix pattern
+	criteria, err := app.getGenerationCriteria(cmd)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation,
+			"run_benchmark", "invalid pattern criteria")
+	}
+	budget, err := parseAttemptBudget(cmd, criteria, 1)
+	if err != nil {
+		return err
+	}
+	if budget != nil {
+		attempts = int(budget.total)
+	}
+
+	repeat, err := parseBenchmarkRepeat(cmd)
+	if err != nil {
+		return err
+	}
+	if repeat != nil {
+		return app.runBenchmarkRepeats(ctx, attempts, duration, repeat)
+	}
 
 	// Check if TUI should be used
 	tuiManager := tui.NewTUIManager()
 	useTUI, _ := cmd.Flags().GetBool("tui")
 
-	if useTUI && tuiManager.ShouldUseTUI() {
+	if useTUI && tuiManager.ShouldUseTUI() && budget == nil {
 		return app.runBenchmarkTUI(ctx, attempts, duration, detailed)
 	}
 
 	// Fallback to text mode
-	return app.runBenchmarkText(ctx, attempts, duration, detailed)
+	result, err := app.runBenchmarkText(ctx, attempts, duration, detailed)
+	if err != nil {
+		return err
+	}
+	if budget != nil {
+		reportBenchmarkBudget(criteria, budget, result)
+	}
+	return nil
 }
 
 // runBenchmarkTUI runs benchmark with TUI interface
@@ -882,7 +1607,7 @@ func (app *Application) runBenchmarkTUI(ctx context.Context, attempts int, durat
 	}
 	defer func() {
 		if err := workerPool.Shutdown(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to shutdown worker pool: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("warn.pool_shutdown", err))
 		}
 	}()
 
@@ -912,43 +1637,56 @@ func (app *Application) runBenchmarkTUI(ctx context.Context, attempts int, durat
 	// Run the TUI program
 	if _, err := program.Run(); err != nil {
 		// If TUI fails, fallback to text mode
-		fmt.Printf("TUI failed: %v, falling back to text mode\n", err)
-		return app.runBenchmarkText(ctx, attempts, duration, detailed)
+		fmt.Println(i18n.T("warn.tui_failed", err))
+		_, err := app.runBenchmarkText(ctx, attempts, duration, detailed)
+		return err
 	}
 
 	return nil
 }
 
 // runBenchmarkText runs benchmark in text mode
-func (app *Application) runBenchmarkText(ctx context.Context, attempts int, duration time.Duration, detailed bool) error {
-	fmt.Printf("Running benchmark...\n")
-	fmt.Printf("Attempts: %s\n", formatLargeNumber(int64(attempts)))
-	fmt.Printf("Duration: %v\n", duration)
-	fmt.Printf("Threads: %d\n\n", app.config.Worker.ThreadCount)
+func (app *Application) runBenchmarkText(ctx context.Context, attempts int, duration time.Duration, detailed bool) (*wallet.BenchmarkResult, error) {
+	fmt.Println(i18n.T("bench.running"))
+	fmt.Println(i18n.T("result.attempts", formatLargeNumber(int64(attempts))))
+	fmt.Println(i18n.T("result.duration", duration))
+	if app.warmup > 0 {
+		fmt.Println(i18n.T("bench.warmup", app.warmup))
+	}
+	fmt.Printf("%s\n\n", i18n.T("bench.threads", app.config.Worker.ThreadCount))
+
+	result, err := app.runBenchmarkOnce(ctx, attempts, duration)
+	if err != nil {
+		return nil, err
+	}
 
+	// Display results
+	return result, app.displayBenchmarkResults(result, detailed)
+}
+
+// runBenchmarkOnce runs one benchmark on a fresh worker pool
+func (app *Application) runBenchmarkOnce(ctx context.Context, attempts int, duration time.Duration) (*wallet.BenchmarkResult, error) {
 	// Create worker pool
 	workerPool := worker.NewPool(app.config.Worker.ThreadCount, "ethereum")
 
 	// Start worker pool
 	if err := workerPool.Start(); err != nil {
-		return errors.WrapError(err, errors.ErrorTypeWorker,
+		return nil, errors.WrapError(err, errors.ErrorTypeWorker,
 			"run_benchmark", "failed to start worker pool")
 	}
 	defer func() {
 		if err := workerPool.Shutdown(); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to shutdown worker pool: %v\n", err)
+			fmt.Fprintln(os.Stderr, i18n.T("warn.pool_shutdown", err))
 		}
 	}()
 
 	// Run benchmark
 	result, err := app.executeBenchmark(ctx, workerPool, attempts, duration)
 	if err != nil {
-		return errors.WrapError(err, errors.ErrorTypeGeneration,
+		return nil, errors.WrapError(err, errors.ErrorTypeGeneration,
 			"run_benchmark", "benchmark execution failed")
 	}
-
-	// Display results
-	return app.displayBenchmarkResults(result, detailed)
+	return result, nil
 }
 
 // createVersionCommand creates the version subcommand
@@ -957,9 +1695,9 @@ func (app *Application) createVersionCommand() *cobra.Command {
 		Use:   "version",
 		Short: "Show version information",
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Printf("Bloco-ETH %s\n", app.version)
-			fmt.Printf("Git Commit: %s\n", app.gitCommit)
-			fmt.Printf("Build Time: %s\n", app.buildTime)
+			fmt.Println(i18n.T("version.name", app.version))
+			fmt.Println(i18n.T("version.commit", app.gitCommit))
+			fmt.Println(i18n.T("version.build_time", app.buildTime))
 		},
 	}
 }
@@ -971,13 +1709,86 @@ func (app *Application) parseFlags(cmd *cobra.Command) error {
 	// Parse thread count
 	if threads, _ := cmd.Flags().GetInt("threads"); threads >= 0 {
 		if threads == 0 {
-			// Auto-detect CPU cores
-			app.config.Worker.ThreadCount = runtime.NumCPU()
+			// Auto-detect CPU cores, within a container's CPU quota
+			app.config.Worker.ThreadCount = config.DetectCPUCount()
 		} else {
 			app.config.Worker.ThreadCount = threads
 		}
 	}
 
+	if err := app.configureEntropy(cmd); err != nil {
+		return err
+	}
+
+	network, _ := cmd.Flags().GetString("network")
+	keyFormat, err := keyFormatFlag(cmd, network)
+	if err != nil {
+		return err
+	}
+	app.keyFormat = keyFormat
+	if app.includePubkey, _ = cmd.Flags().GetBool("include-pubkey"); app.includePubkey {
+		// The TUI results table has no room for 130-character public keys
+		app.config.TUI.Enabled = false
+	}
+
+	app.histogramPath, _ = cmd.Flags().GetString("attempts-histogram")
+	app.statusInterval = 10 * time.Second
+	if interval, err := cmd.Flags().GetDuration("status-interval"); err == nil {
+		if interval <= 0 {
+			return errors.NewValidationError("parse_flags", "--status-interval must be positive")
+		}
+		app.statusInterval = interval
+	}
+
+	app.timeout, _ = cmd.Flags().GetDuration("timeout")
+	if app.timeout < 0 {
+		return errors.NewValidationError("parse_flags", "--timeout must not be negative")
+	}
+	app.failOnTimeout, _ = cmd.Flags().GetBool("fail-on-timeout")
+
+	app.keepTop, _ = cmd.Flags().GetInt("keep-top")
+	switch {
+	case app.keepTop < 0:
+		return errors.NewValidationError("parse_flags", "--keep-top must not be negative")
+	case app.keepTop > 0 && app.timeout == 0 && !cmd.Flags().Changed("until-probability"):
+		return errors.NewValidationError("parse_flags", "--keep-top searches for a time budget; add --timeout or --until-probability")
+	case app.keepTop > 0 && cmd.Flags().Changed("count"):
+		return errors.NewValidationError("parse_flags", "--keep-top keeps the one wallet you choose; it cannot be combined with --count")
+	case app.keepTop > 0:
+		// The ranking and the choice are made in the text output
+		app.config.TUI.Enabled = false
+	}
+
+	app.progressFormat, _ = cmd.Flags().GetString("progress-format")
+	app.progressFile, _ = cmd.Flags().GetString("progress-file")
+	switch app.progressFormat {
+	case "", "text":
+		app.progressFormat = "text"
+		if app.progressFile != "" {
+			return errors.NewValidationError("parse_flags", "--progress-file requires --progress-format json")
+		}
+	case "json":
+		// The TUI would garble the event stream on stderr, and wrappers run headless anyway
+		app.config.TUI.Enabled = false
+	default:
+		return errors.NewValidationError("parse_flags", fmt.Sprintf("unsupported --progress-format %q (use text or json)", app.progressFormat))
+	}
+
+	app.etaPercentiles = utils.DefaultETAPercentiles
+	if value, err := cmd.Flags().GetString("eta-percentiles"); err == nil {
+		percents, err := utils.ParsePercentiles(value)
+		if err != nil {
+			return errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --eta-percentiles: %v", err))
+		}
+		app.etaPercentiles = percents
+	}
+	if calibration, err := cmd.Flags().GetDuration("eta-calibration"); err == nil {
+		if calibration < 0 {
+			return errors.NewValidationError("parse_flags", "--eta-calibration cannot be negative")
+		}
+		app.etaCalibration = calibration
+	}
+
 	// Parse output options
 	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose {
 		app.config.CLI.VerboseOutput = true
@@ -986,6 +1797,9 @@ func (app *Application) parseFlags(cmd *cobra.Command) error {
 	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
 		app.config.CLI.QuietMode = true
 	}
+	if threads, _ := cmd.Flags().GetInt("threads"); threads > 0 && !app.config.CLI.QuietMode {
+		warnCPUQuota(threads)
+	}
 
 	// Parse TUI option
 	if tui, _ := cmd.Flags().GetBool("tui"); !tui {
@@ -996,6 +1810,18 @@ func (app *Application) parseFlags(cmd *cobra.Command) error {
 	if noKeystore, _ := cmd.Flags().GetBool("no-keystore"); noKeystore {
 		app.config.KeyStore.Enabled = false
 	}
+	if err := app.parseHardwareFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parseQRFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parsePaperWalletFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parseHideKeysFlag(cmd); err != nil {
+		return err
+	}
 
 	// Only update keystore directory if the flag was explicitly set by the user
 	if cmd.Flags().Changed("keystore-dir") {
@@ -1011,6 +1837,14 @@ func (app *Application) parseFlags(cmd *cobra.Command) error {
 		}
 	}
 
+	if cmd.Flags().Changed("keystore-cipher") {
+		app.config.KeyStore.Cipher, _ = cmd.Flags().GetString("keystore-cipher")
+	}
+	app.keystoreWorkers, _ = cmd.Flags().GetInt("keystore-workers")
+	if app.keystoreWorkers < 0 {
+		return fmt.Errorf("--keystore-workers must be 0 or more, got %d", app.keystoreWorkers)
+	}
+
 	// Parse KDF parameters if provided
 	if cmd.Flags().Changed("kdf-params") {
 		if kdfParamsStr, _ := cmd.Flags().GetString("kdf-params"); kdfParamsStr != "" {
@@ -1019,6 +1853,12 @@ func (app *Application) parseFlags(cmd *cobra.Command) error {
 			}
 		}
 	}
+	if err := app.parseKDFMemory(cmd); err != nil {
+		return err
+	}
+	if err := app.governMemory(cmd); err != nil {
+		return err
+	}
 
 	// Parse KDF analysis flag
 	if kdfAnalysis, _ := cmd.Flags().GetBool("kdf-analysis"); kdfAnalysis {
@@ -1032,10 +1872,89 @@ func (app *Application) parseFlags(cmd *cobra.Command) error {
 		}
 	}
 
+	// Only update password protection if the flag was explicitly set by the user
+	if cmd.Flags().Changed("password-protection") {
+		protection, _ := cmd.Flags().GetString("password-protection")
+		app.config.KeyStore.PasswordProtection = protection
+	}
+	if cmd.Flags().Changed("keystore-password-policy") {
+		app.config.KeyStore.PasswordPolicy, _ = cmd.Flags().GetString("keystore-password-policy")
+	}
+	if app.config.KeyStore.Enabled {
+		protection, err := crypto.ParsePasswordProtection(app.config.KeyStore.PasswordProtection)
+		if err != nil {
+			return err
+		}
+		if err := protection.CheckAvailable(); err != nil {
+			return err
+		}
+		if _, err := crypto.ParsePasswordPolicy(app.config.KeyStore.PasswordPolicy); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeValidation, "parse_flags", "invalid --keystore-password-policy")
+		}
+	}
+
+	if err := app.parseLabelFlags(cmd); err != nil {
+		return err
+	}
+	if app.keystorePath, err = parseKeystorePathTemplate(cmd); err != nil {
+		return err
+	}
+
+	if err := app.parseRPCFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parseFundingFlags(cmd); err != nil {
+		return err
+	}
+
+	if scheme, _ := cmd.Flags().GetString("slip39"); scheme != "" {
+		threshold, count, err := crypto.ParseSLIP39Scheme(scheme)
+		if err != nil {
+			return errors.NewValidationError("parse_flags", err.Error())
+		}
+		if vaultPath, _ := cmd.Flags().GetString("vault"); vaultPath != "" {
+			return errors.NewValidationError("parse_flags", "--slip39 cannot be combined with --vault")
+		}
+		app.slip39Threshold, app.slip39Count = threshold, count
+	}
+
+	if vaultPath, _ := cmd.Flags().GetString("vault"); vaultPath != "" && app.config.KeyStore.Enabled {
+		vault, err := openVaultFromFlags(cmd, true)
+		if err != nil {
+			return err
+		}
+		vault.SetRetryPolicy(app.retry.Vault)
+		app.vault = vault
+	}
+	if err := app.parseScreeningFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parseWatchdogFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parsePowerFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parseNotifyFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parseExplorerFlags(cmd); err != nil {
+		return err
+	}
+	if err := app.parseSortFlag(cmd); err != nil {
+		return err
+	}
+	if err := app.parseBatchStrategy(cmd); err != nil {
+		return err
+	}
+
 	// Parse logging configuration
 	if err := app.parseLoggingFlags(cmd); err != nil {
 		return fmt.Errorf("failed to parse logging configuration: %w", err)
 	}
+	if err := app.parseConstantRate(cmd); err != nil {
+		return err
+	}
 
 	// Validate configuration after updates
 	return app.config.Validate()
@@ -1094,6 +2013,47 @@ func (app *Application) parseLoggingFlags(cmd *cobra.Command) error {
 	return nil
 }
 
+// warnCPUQuota warns when --threads asks for more threads than a container's CPU
+// quota can run at once
+func warnCPUQuota(threads int) {
+	quota, ok := config.CPUQuota()
+	if !ok || float64(threads) <= math.Ceil(quota) {
+		return
+	}
+	fmt.Fprintln(os.Stderr, i18n.T("warn.cpu_quota", threads, strconv.FormatFloat(quota, 'f', -1, 64), config.DetectCPUCount()))
+}
+
+// parseKDFMemory sets the scrypt memory budget from --kdf-max-memory and fits the
+// keystore scrypt parameters into it before any key is derived
+func (app *Application) parseKDFMemory(cmd *cobra.Command) error {
+	value, _ := cmd.Flags().GetString("kdf-max-memory")
+	limit, err := kdf.ParseMemoryLimit(value, kdf.AvailableMemory())
+	if err != nil {
+		return fmt.Errorf("invalid --kdf-max-memory: %w", err)
+	}
+	app.kdfBudget = kdf.NewMemoryBudget(limit)
+	if !app.config.KeyStore.Enabled || app.config.KeyStore.KDFAlgorithm != "scrypt" {
+		return nil
+	}
+
+	params := app.config.KeyStore.KDFParams
+	explicit := len(params) > 0
+	if !explicit {
+		params, _ = kdf.NewUniversalKDFService().GetDefaultParams("scrypt")
+	}
+	fitted, reduced, err := kdf.FitScryptParams(params, limit, explicit)
+	if err != nil {
+		return err
+	}
+	if reduced {
+		if !app.config.CLI.QuietMode {
+			fmt.Fprintln(os.Stderr, i18n.T("warn.scrypt_reduced", params["n"], kdf.FormatMemory(limit), value, fitted["n"]))
+		}
+		app.config.KeyStore.KDFParams = fitted
+	}
+	return nil
+}
+
 // parseKDFParams parses KDF parameters from JSON string
 func (app *Application) parseKDFParams(kdfParamsStr string) error {
 	var params map[string]interface{}
@@ -1277,30 +2237,30 @@ func (app *Application) displayCompatibilityReport(report *kdf.CompatibilityRepo
 		return
 	}
 
-	fmt.Printf("\nKDF Compatibility Analysis\n")
-	fmt.Printf("═══════════════════════════════════════\n")
+	fmt.Printf("\n%s\n", i18n.T("kdf.title"))
+	printRule()
 
 	// Basic information
-	fmt.Printf("KDF Algorithm: %s", report.KDFType)
 	if report.NormalizedKDF != report.KDFType {
-		fmt.Printf(" (normalized: %s)", report.NormalizedKDF)
+		fmt.Println(i18n.T("kdf.algorithm_normalized", report.KDFType, report.NormalizedKDF))
+	} else {
+		fmt.Println(i18n.T("kdf.algorithm", report.KDFType))
 	}
-	fmt.Printf("\n")
 
 	// Security level with color coding
 	securityLabel := app.getSecurityLevelLabel(report.SecurityLevel)
-	fmt.Printf("Security Level: %s (%s)\n", securityLabel, report.SecurityLevel)
+	fmt.Println(i18n.T("kdf.security_level", securityLabel, report.SecurityLevel))
 
 	// Compatibility status
 	if report.Compatible {
-		fmt.Printf("Status: Compatible\n")
+		fmt.Println(i18n.T("kdf.compatible"))
 	} else {
-		fmt.Printf("Status: Incompatible\n")
+		fmt.Println(i18n.T("kdf.incompatible"))
 	}
 
 	// Display parameters if verbose
 	if verbose && len(report.Parameters) > 0 {
-		fmt.Printf("\nParameters:\n")
+		fmt.Printf("\n%s\n", i18n.T("kdf.parameters"))
 		for key, value := range report.Parameters {
 			fmt.Printf("  %s: %v\n", key, value)
 		}
@@ -1308,25 +2268,25 @@ func (app *Application) displayCompatibilityReport(report *kdf.CompatibilityRepo
 
 	// Display issues
 	if len(report.Issues) > 0 {
-		fmt.Printf("\nIssues:\n")
+		fmt.Printf("\n%s\n", i18n.T("kdf.issues"))
 		for _, issue := range report.Issues {
-			fmt.Printf("  • %s\n", issue)
+			fmt.Printf("  %s %s\n", bullet(), issue)
 		}
 	}
 
 	// Display warnings
 	if len(report.Warnings) > 0 {
-		fmt.Printf("\nWarnings:\n")
+		fmt.Printf("\n%s\n", i18n.T("kdf.warnings"))
 		for _, warning := range report.Warnings {
-			fmt.Printf("  • %s\n", warning)
+			fmt.Printf("  %s %s\n", bullet(), warning)
 		}
 	}
 
 	// Display suggestions
 	if len(report.Suggestions) > 0 {
-		fmt.Printf("\nSuggestions:\n")
+		fmt.Printf("\n%s\n", i18n.T("kdf.suggestions"))
 		for _, suggestion := range report.Suggestions {
-			fmt.Printf("  • %s\n", suggestion)
+			fmt.Printf("  %s %s\n", bullet(), suggestion)
 		}
 	}
 
@@ -1354,63 +2314,101 @@ func (app *Application) getGenerationCriteria(cmd *cobra.Command) (wallet.Genera
 	prefix, _ := cmd.Flags().GetString("prefix")
 	suffix, _ := cmd.Flags().GetString("suffix")
 	checksum, _ := cmd.Flags().GetBool("checksum")
+	caseSensitive, _ := cmd.Flags().GetBool("case-sensitive")
 	useMnemonic, _ := cmd.Flags().GetBool("with-mnemonic")
 	network, _ := cmd.Flags().GetString("network")
 
 	criteria := wallet.GenerationCriteria{
-		Network:     network,
-		Prefix:      prefix,
-		Suffix:      suffix,
-		IsChecksum:  checksum,
-		UseMnemonic: useMnemonic,
+		Network:       network,
+		Prefix:        prefix,
+		Suffix:        suffix,
+		IsChecksum:    checksum,
+		CaseSensitive: caseSensitive,
+		UseMnemonic:   useMnemonic,
+	}
+	if path, _ := cmd.Flags().GetString("reject-words"); path != "" {
+		words, err := screening.LoadWords(path)
+		if err != nil {
+			return criteria, errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "invalid --reject-words")
+		}
+		criteria.RejectWords = words
 	}
-
+	criteria.MinZeroBytes, _ = cmd.Flags().GetInt("min-zero-bytes")
 	return criteria, criteria.Validate()
 }
 
-// Helper functions using utils package
+// Helper functions using the vanitymath and i18n packages
 func calculateDifficulty(criteria wallet.GenerationCriteria) float64 {
-	return utils.CalculateDifficulty(criteria.Prefix, criteria.Suffix, criteria.IsChecksum)
+	return criteria.Difficulty()
 }
 
 func calculateProbability50(difficulty float64) int64 {
-	return utils.CalculateProbability50(difficulty)
+	return vanitymath.Attempts50(difficulty)
 }
 
 func formatLargeNumber(num int64) string {
-	return utils.FormatLargeNumber(num)
+	return i18n.FormatNumber(num)
 }
 
 func formatDuration(d time.Duration) string {
-	return utils.FormatDuration(d)
+	return i18n.FormatDuration(d)
 }
 
 func formatBool(b bool) string {
 	if b {
-		return "Enabled"
+		return i18n.T("bool.enabled")
+	}
+	return i18n.T("bool.disabled")
+}
+
+// batchKeystore returns the pipeline's keystore result for w, writing the
+// keystore now when there is no pipeline or w was never submitted
+func (app *Application) batchKeystore(keystores *keystorePipeline, w *wallet.Wallet) error {
+	if keystores != nil {
+		if outcome, ok := keystores.Outcome(w); ok {
+			return outcome.err
+		}
 	}
-	return "Disabled"
+	return app.generateAndSaveKeystore(w)
 }
 
 // Placeholder implementations for display functions
-func (app *Application) displayWalletResult(result *wallet.GenerationResult, showProgress bool) error {
-	fmt.Printf("Wallet generated successfully!\n")
-	fmt.Printf("Address: %s\n", result.Wallet.Address)
-	fmt.Printf("Private Key: %s\n", result.Wallet.PrivateKey)
-	if result.Wallet.Mnemonic != "" {
-		fmt.Printf("Mnemonic: %s\n", result.Wallet.Mnemonic)
+func (app *Application) displayWalletResult(result *wallet.GenerationResult, criteria wallet.GenerationCriteria, showProgress bool) error {
+	app.recordWallet(result.Wallet)
+
+	fmt.Println(i18n.T("result.success"))
+	style := addressStyle(criteria)
+	printFoundAddress(style, "", result.Wallet.Address, criteria)
+	app.printExplorerLink(result.Wallet, "")
+	if style != nil && tui.HasMarks(addressMarks(result.Wallet.Address, criteria)) {
+		fmt.Println(style.AddressLegend())
+	}
+	if !app.hideKeys {
+		fmt.Println(i18n.T("result.private_key", app.displayKey(result.Wallet)))
+	}
+	app.printPublicKeys(result.Wallet, "")
+	app.printQR(result.Wallet, "", !app.hideKeys)
+	if result.Wallet.Mnemonic != "" && !app.hideKeys {
+		fmt.Println(i18n.T("result.mnemonic", result.Wallet.Mnemonic))
 	}
-	fmt.Printf("Attempts: %s\n", formatLargeNumber(result.Attempts))
-	fmt.Printf("Duration: %v\n", result.Duration)
+	if result.Wallet.SeedCounter != nil {
+		fmt.Println(i18n.T("result.seed_counter", *result.Wallet.SeedCounter, result.Wallet.SeedFingerprint))
+	}
+	fmt.Println(i18n.T("result.attempts", formatLargeNumber(result.Attempts)))
+	fmt.Println(i18n.T("result.duration", result.Duration))
 
 	// Generate keystore if enabled
 	if app.config.KeyStore.Enabled {
 		if err := app.generateAndSaveKeystore(result.Wallet); err != nil {
-			fmt.Printf("Warning: Failed to generate keystore: %v\n", err)
+			fmt.Println(i18n.T("result.keystore_failed", err))
+			return app.lostKeys(err)
+		} else if app.deferredKeystores != nil {
+			fmt.Println(i18n.T("result.keystore_deferred"))
 		} else {
-			fmt.Printf("Keystore saved to: %s\n", app.config.KeyStore.OutputDir)
+			fmt.Println(i18n.T("result.keystore_saved", app.keystoreLocation()))
+			app.printPasswordPolicy(result.Wallet.Network)
 			if result.Wallet.Mnemonic != "" {
-				fmt.Printf("Mnemonic saved to: %s\n", app.config.KeyStore.OutputDir)
+				fmt.Println(i18n.T("result.backup_saved", app.mnemonicBackupName(), app.keystoreLocation()))
 			}
 		}
 	}
@@ -1418,47 +2416,69 @@ func (app *Application) displayWalletResult(result *wallet.GenerationResult, sho
 	return nil
 }
 
-func (app *Application) displayMultipleWalletResults(results []*wallet.GenerationResult, totalAttempts int64, totalDuration time.Duration, showProgress bool) error {
+func (app *Application) displayMultipleWalletResults(results []*wallet.GenerationResult, criteria wallet.GenerationCriteria, totalAttempts int64, totalDuration time.Duration, showProgress bool, keystores *keystorePipeline) error {
 	if len(results) == 0 {
-		fmt.Printf("No wallets were generated successfully\n")
+		fmt.Println(i18n.T("batch.none"))
 		return nil
 	}
 
-	fmt.Printf("Generated %d wallets successfully!\n", len(results))
-	fmt.Printf("Total attempts: %s\n", formatLargeNumber(totalAttempts))
-	fmt.Printf("Total duration: %s\n", formatDuration(totalDuration))
-	fmt.Printf("Average speed: %.0f addr/s\n\n", float64(totalAttempts)/totalDuration.Seconds())
+	fmt.Println(i18n.T("batch.success", len(results)))
+	fmt.Println(i18n.T("batch.total_attempts", formatLargeNumber(totalAttempts)))
+	fmt.Println(i18n.T("batch.total_duration", formatDuration(totalDuration)))
+	fmt.Printf("%s\n\n", i18n.T("batch.average_speed", i18n.FormatDecimal(float64(totalAttempts)/totalDuration.Seconds(), 0)))
+
+	// Keystores written by the pipeline are reported in result order once all are done
+	if keystores != nil {
+		keystores.Wait()
+	}
 
-	// Display individual wallets
+	// Display individual wallets, after the legend of their marked addresses
+	style := addressStyle(criteria)
+	if style != nil && tui.HasMarks(addressMarks(results[0].Wallet.Address, criteria)) {
+		fmt.Printf("%s\n\n", style.AddressLegend())
+	}
+	sortWallets(app, results, func(r *wallet.GenerationResult) *wallet.Wallet { return r.Wallet })
 	var keystoreErrors []error
 	for i, result := range results {
-		fmt.Printf("Wallet %d:\n", i+1)
-		fmt.Printf("  Address: %s\n", result.Wallet.Address)
+		app.recordWallet(result.Wallet)
+		fmt.Println(i18n.T("batch.wallet", i+1))
+		printFoundAddress(style, "  ", result.Wallet.Address, criteria)
+		app.printExplorerLink(result.Wallet, "  ")
+		if app.sortBy != "" || criteria.MinZeroBytes > 0 {
+			printAddressStats(result.Wallet, "  ")
+		}
 
 		// Only show private key if not in quiet mode
-		if !app.config.CLI.QuietMode {
-			fmt.Printf("  Private Key: %s\n", result.Wallet.PrivateKey)
+		if !app.config.CLI.QuietMode && !app.hideKeys {
+			fmt.Println("  " + i18n.T("result.private_key", app.displayKey(result.Wallet)))
 			if result.Wallet.Mnemonic != "" {
-				fmt.Printf("  Mnemonic: %s\n", result.Wallet.Mnemonic)
+				fmt.Println("  " + i18n.T("result.mnemonic", result.Wallet.Mnemonic))
 			}
 		}
+		app.printPublicKeys(result.Wallet, "  ")
+		app.printQR(result.Wallet, "  ", !app.config.CLI.QuietMode && !app.hideKeys)
+		if result.Wallet.SeedCounter != nil {
+			fmt.Println("  " + i18n.T("result.seed_counter", *result.Wallet.SeedCounter, result.Wallet.SeedFingerprint))
+		}
 
-		fmt.Printf("  Attempts: %s\n", formatLargeNumber(result.Attempts))
-		fmt.Printf("  Duration: %s\n", formatDuration(result.Duration))
+		fmt.Println("  " + i18n.T("result.attempts", formatLargeNumber(result.Attempts)))
+		fmt.Println("  " + i18n.T("result.duration", formatDuration(result.Duration)))
 
 		if result.WorkerID > 0 {
-			fmt.Printf("  Worker: #%d\n", result.WorkerID)
+			fmt.Println("  " + i18n.T("result.worker", result.WorkerID))
 		}
 
 		// Generate keystore if enabled
 		if app.config.KeyStore.Enabled {
-			if err := app.generateAndSaveKeystore(result.Wallet); err != nil {
+			if err := app.batchKeystore(keystores, result.Wallet); err != nil {
 				keystoreErrors = append(keystoreErrors, err)
-				fmt.Printf("  Keystore: Failed to generate (%v)\n", err)
+				fmt.Println("  " + i18n.T("batch.keystore_failed", err))
+			} else if app.deferredKeystores != nil {
+				fmt.Println("  " + i18n.T("batch.keystore_deferred"))
 			} else {
-				fmt.Printf("  Keystore: Saved\n")
+				fmt.Println("  " + i18n.T("batch.keystore_saved"))
 				if result.Wallet.Mnemonic != "" {
-					fmt.Printf("  Mnemonic: Saved\n")
+					fmt.Println("  " + i18n.T("batch.backup_saved", app.mnemonicBackupName()))
 				}
 			}
 		}
@@ -1466,14 +2486,18 @@ func (app *Application) displayMultipleWalletResults(results []*wallet.Generatio
 		fmt.Printf("\n")
 	}
 
-	// Show keystore summary
-	if app.config.KeyStore.Enabled {
+	// Show keystore summary; deferred keystores are summarized when written
+	if app.config.KeyStore.Enabled && app.deferredKeystores == nil {
 		successCount := len(results) - len(keystoreErrors)
 		if successCount > 0 {
-			fmt.Printf("Keystores saved: %d/%d to %s\n", successCount, len(results), app.config.KeyStore.OutputDir)
+			fmt.Println(i18n.T("batch.keystores_saved", successCount, len(results), app.keystoreLocation()))
+			app.printPasswordPolicy(results[0].Wallet.Network)
 		}
 		if len(keystoreErrors) > 0 {
-			fmt.Printf("Keystore errors: %d/%d\n", len(keystoreErrors), len(results))
+			fmt.Println(i18n.T("batch.keystore_errors", len(keystoreErrors), len(results)))
+		}
+		if keystores != nil {
+			fmt.Println(i18n.T("batch.keystore_pipeline", keystores.workers, formatDuration(keystores.Tail())))
 		}
 	}
 
@@ -1494,14 +2518,22 @@ func (app *Application) displayMultipleWalletResults(results []*wallet.Generatio
 
 		avgAttempts := totalWalletAttempts / int64(len(results))
 
-		fmt.Printf("Statistics Summary:\n")
-		fmt.Printf("  Average attempts per wallet: %s\n", formatLargeNumber(avgAttempts))
-		fmt.Printf("  Min attempts: %s\n", formatLargeNumber(minAttempts))
-		fmt.Printf("  Max attempts: %s\n", formatLargeNumber(maxAttempts))
-		fmt.Printf("  Success rate: %.2f%%\n", float64(len(results))/float64(totalAttempts)*100)
+		fmt.Println(i18n.T("summary.title"))
+		fmt.Println("  " + i18n.T("summary.average", formatLargeNumber(avgAttempts)))
+		fmt.Println("  " + i18n.T("summary.min", formatLargeNumber(minAttempts)))
+		fmt.Println("  " + i18n.T("summary.max", formatLargeNumber(maxAttempts)))
+		fmt.Println("  " + i18n.T("summary.success_rate", i18n.FormatDecimal(float64(len(results))/float64(totalAttempts)*100, 2)))
+
+		histogram := newAttemptHistogram(results, criteria)
+		histogram.print()
+		if app.histogramPath != "" {
+			if err := writeAttemptHistogram(app.histogramPath, histogram); err != nil {
+				return err
+			}
+		}
 	}
 
-	return nil
+	return app.lostKeys(keystoreErrors...)
 }
 
 // executeBenchmarkWithTUI runs benchmark and sends updates to TUI
@@ -1513,352 +2545,114 @@ func (app *Application) executeBenchmarkWithTUI(ctx context.Context, workerPool
 		IsChecksum: false,
 	}
 
-	startTime := time.Now()
-	var totalAttempts int64
-	var speedSamples []float64
-	var durationSamples []time.Duration
-
-	// Get stats collector for monitoring
-	statsCollector := workerPool.GetStatsCollector()
-
-	// Run benchmark for specified duration or attempts
-	benchmarkCtx, cancel := context.WithTimeout(ctx, duration)
-	defer cancel()
-
-	// Sample performance every 500ms for smoother TUI updates
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	lastAttempts := int64(0)
-	sampleCount := 0
-
-	for {
-		select {
-		case <-benchmarkCtx.Done():
-			// Benchmark completed
-			goto benchmarkComplete
-
-		case <-ticker.C:
-			// Sample current performance
-			currentStats := statsCollector.GetAggregatedStats()
-			currentAttempts := currentStats.TotalAttempts
-
-			if currentAttempts > lastAttempts {
-				speed := float64(currentAttempts-lastAttempts) / 0.5 // Per second
-				speedSamples = append(speedSamples, speed)
-				durationSamples = append(durationSamples, 500*time.Millisecond)
-
-				// Calculate current statistics
-				var avgSpeed, minSpeed, maxSpeed float64
-				if len(speedSamples) > 0 {
-					var sum float64
-					minSpeed = speedSamples[0]
-					maxSpeed = speedSamples[0]
-
-					for _, s := range speedSamples {
-						sum += s
-						if s < minSpeed {
-							minSpeed = s
-						}
-						if s > maxSpeed {
-							maxSpeed = s
-						}
-					}
-					avgSpeed = sum / float64(len(speedSamples))
-				}
-
-				// Calculate estimated time remaining
-				remainingAttempts := int64(attempts) - currentAttempts
-				var estimatedTime time.Duration
-				if avgSpeed > 0 && remainingAttempts > 0 {
-					estimatedTime = time.Duration(float64(remainingAttempts)/avgSpeed) * time.Second
-				}
-
-				// Get performance metrics
-				perfMetrics := statsCollector.GetPerformanceMetrics()
-
-				// Send update to TUI
-				program.Send(tui.BenchmarkUpdateMsg{
-					Running: true,
-					Progress: tui.ProgressMsg{
-						Attempts:      currentAttempts,
-						Speed:         speed,
-						Pattern:       criteria.GetPattern(),
-						Difficulty:    calculateDifficulty(criteria),
-						EstimatedTime: estimatedTime,
-					},
-					Results: &wallet.BenchmarkResult{
-						TotalAttempts:         currentAttempts,
-						TotalDuration:         time.Since(startTime),
-						AverageSpeed:          avgSpeed,
-						MinSpeed:              minSpeed,
-						MaxSpeed:              maxSpeed,
-						SpeedSamples:          speedSamples,
-						DurationSamples:       durationSamples,
-						ThreadCount:           perfMetrics.WorkerCount,
-						ScalabilityEfficiency: perfMetrics.EfficiencyRatio,
-						ThreadBalanceScore:    perfMetrics.ThreadBalanceScore,
-						ThreadUtilization:     perfMetrics.CPUUtilization,
-						SpeedupVsSingleThread: perfMetrics.SpeedupVsSingleThread,
-						SingleThreadSpeed:     perfMetrics.EstimatedSingleThreadSpeed,
-					},
-				})
-
-				lastAttempts = currentAttempts
-				sampleCount++
-			}
-
-			// Check if we've reached the attempt limit
-			if int(currentAttempts) >= attempts {
-				cancel()
-			}
-
-		default:
-			// Submit work continuously to keep workers busy
-			for i := 0; i < app.config.Worker.ThreadCount; i++ {
-				workItem := worker.WorkItem{
-					Criteria:  criteria,
-					BatchSize: 1000, // Smaller batch for more frequent updates
-					ID:        fmt.Sprintf("bench-%d-%d", time.Now().UnixNano(), i),
-				}
-
-				select {
-				case <-benchmarkCtx.Done():
-					goto benchmarkComplete
-				default:
-					// TODO: Implement benchmark with ants pool
-					_ = workItem // Avoid unused variable for now
-				}
+	// Smaller batches and samples every 500ms for smoother TUI updates
+	return app.sampleBenchmark(ctx, workerPool, criteria, attempts, duration, 1000, 500*time.Millisecond,
+		func(result *wallet.BenchmarkResult, speed float64) {
+			// Calculate estimated time remaining
+			var estimatedTime time.Duration
+			if remaining := int64(attempts) - result.TotalAttempts; result.AverageSpeed > 0 && remaining > 0 {
+				estimatedTime = time.Duration(float64(remaining)/result.AverageSpeed) * time.Second
 			}
-
-			// Small delay to prevent busy waiting
-			time.Sleep(10 * time.Millisecond)
-		}
-	}
-
-benchmarkComplete:
-	totalDuration := time.Since(startTime)
-	finalStats := statsCollector.GetAggregatedStats()
-	totalAttempts = finalStats.TotalAttempts
-
-	// Calculate final statistics
-	var avgSpeed, minSpeed, maxSpeed float64
-	if len(speedSamples) > 0 {
-		var sum float64
-		minSpeed = speedSamples[0]
-		maxSpeed = speedSamples[0]
-
-		for _, speed := range speedSamples {
-			sum += speed
-			if speed < minSpeed {
-				minSpeed = speed
-			}
-			if speed > maxSpeed {
-				maxSpeed = speed
-			}
-		}
-		avgSpeed = sum / float64(len(speedSamples))
-	}
-
-	// Get final performance metrics
-	perfMetrics := statsCollector.GetPerformanceMetrics()
-
-	return &wallet.BenchmarkResult{
-		TotalAttempts:         totalAttempts,
-		TotalDuration:         totalDuration,
-		AverageSpeed:          avgSpeed,
-		MinSpeed:              minSpeed,
-		MaxSpeed:              maxSpeed,
-		SpeedSamples:          speedSamples,
-		DurationSamples:       durationSamples,
-		ThreadCount:           perfMetrics.WorkerCount,
-		ScalabilityEfficiency: perfMetrics.EfficiencyRatio,
-		ThreadBalanceScore:    perfMetrics.ThreadBalanceScore,
-		ThreadUtilization:     perfMetrics.CPUUtilization,
-		SpeedupVsSingleThread: perfMetrics.SpeedupVsSingleThread,
-		SingleThreadSpeed:     perfMetrics.EstimatedSingleThreadSpeed,
-	}, nil
+			program.Send(tui.BenchmarkUpdateMsg{
+				Running: true,
+				Progress: tui.ProgressMsg{
+					Attempts:      result.TotalAttempts,
+					Speed:         speed,
+					Pattern:       criteria.GetPattern(),
+					Difficulty:    calculateDifficulty(criteria),
+					EstimatedTime: estimatedTime,
+				},
+				Results: result,
+			})
+		})
 }
 
 func (app *Application) executeBenchmark(ctx context.Context, workerPool worker.WorkerPool, attempts int, duration time.Duration) (*wallet.BenchmarkResult, error) {
-	fmt.Printf("Starting benchmark...\n")
-
 	// Create a simple generation criteria for benchmarking
 	criteria := wallet.GenerationCriteria{
 		Prefix:     "",
 		Suffix:     "",
 		IsChecksum: false,
 	}
+	return app.executeBenchmarkFor(ctx, workerPool, criteria, attempts, duration)
+}
 
-	startTime := time.Now()
-	var totalAttempts int64
-	var speedSamples []float64
-	var durationSamples []time.Duration
-
-	// Get stats collector for monitoring
-	statsCollector := workerPool.GetStatsCollector()
-
-	// Run benchmark for specified duration or attempts
-	benchmarkCtx, cancel := context.WithTimeout(ctx, duration)
-	defer cancel()
-
-	// Sample performance every second
-	ticker := time.NewTicker(time.Second)
-	defer ticker.Stop()
-
-	lastAttempts := int64(0)
-	sampleCount := 0
-
-	for {
-		select {
-		case <-benchmarkCtx.Done():
-			// Benchmark completed
-			goto benchmarkComplete
-
-		case <-ticker.C:
-			// Sample current performance
-			currentStats := statsCollector.GetAggregatedStats()
-			currentAttempts := currentStats.TotalAttempts
-
-			if currentAttempts > lastAttempts {
-				speed := float64(currentAttempts - lastAttempts)
-				speedSamples = append(speedSamples, speed)
-				durationSamples = append(durationSamples, time.Second)
-
-				fmt.Printf("\rSample %d: %.0f addr/s (total: %s attempts)",
-					sampleCount+1, speed, formatLargeNumber(currentAttempts))
-
-				lastAttempts = currentAttempts
-				sampleCount++
-			}
-
-			// Check if we've reached the attempt limit
-			if int(currentAttempts) >= attempts {
-				cancel()
-			}
-
-		default:
-			// Submit work continuously to keep workers busy
-			for i := 0; i < app.config.Worker.ThreadCount; i++ {
-				workItem := worker.WorkItem{
-					Criteria:  criteria,
-					BatchSize: 5000, // Large batch for benchmarking
-					ID:        fmt.Sprintf("bench-%d-%d", time.Now().UnixNano(), i),
-				}
-
-				select {
-				case <-benchmarkCtx.Done():
-					goto benchmarkComplete
-				default:
-					// TODO: Implement benchmark with ants pool
-					_ = workItem // Avoid unused variable for now
-				}
+// executeBenchmarkFor measures the speed of workers matching every address against criteria
+func (app *Application) executeBenchmarkFor(ctx context.Context, workerPool worker.WorkerPool, criteria wallet.GenerationCriteria,
+	attempts int, duration time.Duration) (*wallet.BenchmarkResult, error) {
+	fmt.Println(i18n.T("bench.starting"))
+
+	// Large batches, sampled every second
+	result, err := app.sampleBenchmark(ctx, workerPool, criteria, attempts, duration, 5000, time.Second,
+		func(result *wallet.BenchmarkResult, speed float64) {
+			line := i18n.T("bench.sample_total", len(result.SpeedSamples), speed, formatLargeNumber(result.TotalAttempts))
+			if plainOutput.Load() {
+				fmt.Println(line)
+			} else {
+				fmt.Print("\r" + line)
 			}
-
-			// Small delay to prevent busy waiting
-			time.Sleep(10 * time.Millisecond)
-		}
+		})
+	if err != nil {
+		return nil, err
 	}
-
-benchmarkComplete:
-	totalDuration := time.Since(startTime)
-	finalStats := statsCollector.GetAggregatedStats()
-	totalAttempts = finalStats.TotalAttempts
-
-	fmt.Printf("\nBenchmark completed!\n")
-
-	// Calculate statistics
-	var avgSpeed, minSpeed, maxSpeed float64
-	if len(speedSamples) > 0 {
-		var sum float64
-		minSpeed = speedSamples[0]
-		maxSpeed = speedSamples[0]
-
-		for _, speed := range speedSamples {
-			sum += speed
-			if speed < minSpeed {
-				minSpeed = speed
-			}
-			if speed > maxSpeed {
-				maxSpeed = speed
-			}
-		}
-		avgSpeed = sum / float64(len(speedSamples))
-	}
-
-	// Get performance metrics
-	perfMetrics := statsCollector.GetPerformanceMetrics()
-
-	return &wallet.BenchmarkResult{
-		TotalAttempts:         totalAttempts,
-		TotalDuration:         totalDuration,
-		AverageSpeed:          avgSpeed,
-		MinSpeed:              minSpeed,
-		MaxSpeed:              maxSpeed,
-		SpeedSamples:          speedSamples,
-		DurationSamples:       durationSamples,
-		ThreadCount:           perfMetrics.WorkerCount,
-		ScalabilityEfficiency: perfMetrics.EfficiencyRatio,
-		ThreadBalanceScore:    perfMetrics.ThreadBalanceScore,
-		ThreadUtilization:     perfMetrics.CPUUtilization,
-		SpeedupVsSingleThread: perfMetrics.SpeedupVsSingleThread,
-		SingleThreadSpeed:     perfMetrics.EstimatedSingleThreadSpeed,
-	}, nil
+	fmt.Printf("\n%s\n", i18n.T("bench.completed"))
+	return result, nil
 }
 
 func (app *Application) displayBenchmarkResults(result *wallet.BenchmarkResult, detailed bool) error {
-	fmt.Printf("\nBenchmark Results:\n")
-	fmt.Printf("═══════════════════════════════════════\n")
+	fmt.Printf("\n%s\n", i18n.T("bench.results"))
+	printRule()
 
 	// Basic metrics
-	fmt.Printf("Total Attempts: %s\n", formatLargeNumber(result.TotalAttempts))
-	fmt.Printf("Duration: %s\n", formatDuration(result.TotalDuration))
-	fmt.Printf("Average Speed: %.0f addr/s\n", result.AverageSpeed)
+	fmt.Println(i18n.T("bench.total_attempts", formatLargeNumber(result.TotalAttempts)))
+	fmt.Println(i18n.T("result.duration", formatDuration(result.TotalDuration)))
+	fmt.Println(i18n.T("bench.average_speed", result.AverageSpeed))
+	printWarmup(result)
+	printGCImpact(result)
 
 	if result.MinSpeed > 0 && result.MaxSpeed > 0 {
-		fmt.Printf("Speed Range: %.0f - %.0f addr/s\n", result.MinSpeed, result.MaxSpeed)
+		fmt.Println(i18n.T("bench.speed_range", result.MinSpeed, result.MaxSpeed))
 	}
 
 	// Thread performance
 	if result.ThreadCount > 1 {
-		fmt.Printf("\nMulti-Threading Performance:\n")
-		fmt.Printf("Threads Used: %d\n", result.ThreadCount)
-		fmt.Printf("Thread Efficiency: %.1f%%\n", result.ScalabilityEfficiency*100)
-		fmt.Printf("Thread Balance: %.1f%%\n", result.ThreadBalanceScore*100)
+		fmt.Printf("\n%s\n", i18n.T("bench.threading"))
+		fmt.Println(i18n.T("bench.threads_used", result.ThreadCount))
+		fmt.Println(i18n.T("bench.thread_efficiency", result.ScalabilityEfficiency*100))
+		fmt.Println(i18n.T("bench.thread_balance", result.ThreadBalanceScore*100))
 
 		if result.SingleThreadSpeed > 0 {
-			fmt.Printf("Estimated Single-Thread Speed: %.0f addr/s\n", result.SingleThreadSpeed)
-			fmt.Printf("Multi-Thread Speedup: %.2fx\n", result.SpeedupVsSingleThread)
+			fmt.Println(i18n.T("bench.single_thread", result.SingleThreadSpeed))
+			fmt.Println(i18n.T("bench.speedup", result.SpeedupVsSingleThread))
 
 			idealSpeedup := float64(result.ThreadCount)
 			actualEfficiency := result.SpeedupVsSingleThread / idealSpeedup * 100
-			fmt.Printf("Parallel Efficiency: %.1f%% (%.2fx of %dx ideal)\n",
-				actualEfficiency, result.SpeedupVsSingleThread, result.ThreadCount)
+			fmt.Println(i18n.T("bench.parallel_efficiency", actualEfficiency, result.SpeedupVsSingleThread, result.ThreadCount))
 		}
 	}
 
 	// Detailed statistics
 	if detailed && len(result.SpeedSamples) > 0 {
-		fmt.Printf("\nDetailed Performance Samples:\n")
+		fmt.Printf("\n%s\n", i18n.T("bench.samples"))
 
 		// Show first few and last few samples
 		samplesToShow := 5
 		if len(result.SpeedSamples) <= samplesToShow*2 {
 			// Show all samples if we don't have many
 			for i, speed := range result.SpeedSamples {
-				fmt.Printf("  Sample %d: %.0f addr/s\n", i+1, speed)
+				fmt.Println("  " + i18n.T("bench.sample", i+1, speed))
 			}
 		} else {
 			// Show first few
 			for i := 0; i < samplesToShow; i++ {
-				fmt.Printf("  Sample %d: %.0f addr/s\n", i+1, result.SpeedSamples[i])
+				fmt.Println("  " + i18n.T("bench.sample", i+1, result.SpeedSamples[i]))
 			}
 
-			fmt.Printf("  ... (%d samples omitted) ...\n", len(result.SpeedSamples)-samplesToShow*2)
+			fmt.Println("  " + i18n.T("bench.samples_omitted", len(result.SpeedSamples)-samplesToShow*2))
 
 			// Show last few
 			for i := len(result.SpeedSamples) - samplesToShow; i < len(result.SpeedSamples); i++ {
-				fmt.Printf("  Sample %d: %.0f addr/s\n", i+1, result.SpeedSamples[i])
+				fmt.Println("  " + i18n.T("bench.sample", i+1, result.SpeedSamples[i]))
 			}
 		}
 
@@ -1874,41 +2668,41 @@ func (app *Application) displayBenchmarkResults(result *wallet.BenchmarkResult,
 			variance := (sumSquares - sum*mean) / float64(len(result.SpeedSamples)-1)
 			stdDev := math.Sqrt(variance)
 
-			fmt.Printf("\nSpeed Statistics:\n")
-			fmt.Printf("  Mean: %.0f addr/s\n", mean)
-			fmt.Printf("  Std Dev: %.0f addr/s\n", stdDev)
-			fmt.Printf("  Coefficient of Variation: %.1f%%\n", stdDev/mean*100)
+			fmt.Printf("\n%s\n", i18n.T("bench.speed_stats"))
+			fmt.Println("  " + i18n.T("bench.mean", mean))
+			fmt.Println("  " + i18n.T("bench.std_dev", stdDev))
+			fmt.Println("  " + i18n.T("bench.variation", stdDev/mean*100))
 		}
 	}
 
 	// Performance recommendations
-	fmt.Printf("\nPerformance Analysis:\n")
+	fmt.Printf("\n%s\n", i18n.T("bench.analysis"))
 
 	if result.ThreadCount > 1 {
 		if result.ScalabilityEfficiency > 0.8 {
-			fmt.Printf("  Excellent multi-threading efficiency\n")
+			fmt.Println("  " + i18n.T("bench.mt_excellent"))
 		} else if result.ScalabilityEfficiency > 0.6 {
-			fmt.Printf("  Good multi-threading efficiency\n")
+			fmt.Println("  " + i18n.T("bench.mt_good"))
 		} else {
-			fmt.Printf("  Multi-threading efficiency could be improved\n")
+			fmt.Println("  " + i18n.T("bench.mt_poor"))
 		}
 
 		if result.ThreadBalanceScore > 0.8 {
-			fmt.Printf("  Well-balanced thread utilization\n")
+			fmt.Println("  " + i18n.T("bench.balanced"))
 		} else {
-			fmt.Printf("  Uneven thread utilization detected\n")
+			fmt.Println("  " + i18n.T("bench.unbalanced"))
 		}
 	}
 
 	// Speed assessment
 	if result.AverageSpeed > 100000 {
-		fmt.Printf("  Excellent performance (>100k addr/s)\n")
+		fmt.Println("  " + i18n.T("bench.speed_excellent"))
 	} else if result.AverageSpeed > 50000 {
-		fmt.Printf("  Good performance (>50k addr/s)\n")
+		fmt.Println("  " + i18n.T("bench.speed_good"))
 	} else if result.AverageSpeed > 10000 {
-		fmt.Printf("  Moderate performance (>10k addr/s)\n")
+		fmt.Println("  " + i18n.T("bench.speed_moderate"))
 	} else {
-		fmt.Printf("  Performance could be improved (<10k addr/s)\n")
+		fmt.Println("  " + i18n.T("bench.speed_poor"))
 	}
 
 	return nil
@@ -1945,10 +2739,53 @@ func (app *Application) generateAndSaveKeystore(w *wallet.Wallet) error {
 	return app.generateAndSaveKeystoreWithVerbose(w, app.config.CLI.VerboseOutput)
 }
 
+// printPasswordPolicy prints the password policy of the keystores of network and its
+// entropy; vaults and Bitcoin mnemonic backups have no keystore passwords
+func (app *Application) printPasswordPolicy(network string) {
+	if app.vault != nil || strings.EqualFold(network, "bitcoin") {
+		return
+	}
+	if policy, err := crypto.ParsePasswordPolicy(app.config.KeyStore.PasswordPolicy); err == nil {
+		fmt.Println(i18n.T("result.password_policy", policy, policy.Entropy()))
+	}
+}
+
 // generateAndSaveKeystoreWithVerbose generates and saves a keystore file with verbose control
+func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, verbose bool) error {
+	return app.generateAndSaveKeystoreWithContext(app.traceContext(), w, verbose)
+}
+
+// generateAndSaveKeystoreWithContext generates and saves a keystore file, or with
+// --keystore-defer holds the key until the run writes all keystores at once
+func (app *Application) generateAndSaveKeystoreWithContext(ctx context.Context, w *wallet.Wallet, verbose bool) error {
+	if app.deferredKeystores != nil {
+		return app.deferredKeystores.Add(w)
+	}
+	return app.writeKeystoreFiles(ctx, w, verbose, app.config.KeyStore.OutputDir)
+}
+
+// writeKeystoreFiles generates and saves a keystore file in outputDir, traced as a span of ctx
 // For Bitcoin: only saves mnemonic (no KeyStore V3)
 // For Ethereum and Solana: generates KeyStore V3 or network-specific format
-func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, verbose bool) error {
+func (app *Application) writeKeystoreFiles(ctx context.Context, w *wallet.Wallet, verbose bool, outputDir string) (err error) {
+	ctx, span := tracing.Start(ctx, "keystore.write",
+		tracing.String("network", w.Network),
+		tracing.String("kdf.algorithm", app.config.KeyStore.KDFAlgorithm))
+	defer func() {
+		app.auditKeystoreWrite(w, err)
+		span.RecordError(err)
+		span.End()
+	}()
+
+	// A vault replaces the per-address files with a single encrypted container
+	if app.vault != nil {
+		return app.saveToVault(w)
+	}
+	pathStem, err := app.keystorePathStem(ctx, w)
+	if err != nil {
+		return err
+	}
+
 	// Bitcoin only saves mnemonic, no KeyStore V3
 	if strings.ToLower(w.Network) == "bitcoin" {
 		if w.Mnemonic == "" {
@@ -1958,13 +2795,15 @@ func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, ver
 		// Create keystore service just for saving mnemonic
 		keystoreConfig := crypto.KeyStoreConfig{
 			Enabled:         app.config.KeyStore.Enabled,
-			OutputDirectory: app.config.KeyStore.OutputDir,
+			OutputDirectory: outputDir,
+			FilenameLabel:   app.filenameLabel(w),
+			PathStem:        pathStem,
 		}
 		keystoreService := crypto.NewKeyStoreService(keystoreConfig)
 		keystoreService.SetVerboseMode(verbose)
 
 		// Save only the mnemonic for Bitcoin
-		if err := keystoreService.SaveMnemonicFile(w.Address, w.Mnemonic, w.Network); err != nil {
+		if err := app.saveMnemonicBackup(keystoreService, w); err != nil {
 			if ksErr, ok := err.(*crypto.KeyStoreError); ok {
 				if ksErr.UserMessage != "" {
 					return fmt.Errorf("mnemonic save failed: %s", ksErr.UserMessage)
@@ -1973,7 +2812,7 @@ func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, ver
 			}
 			return fmt.Errorf("failed to save mnemonic file for address %s: %w", w.Address, err)
 		}
-		return nil
+		return app.saveWalletMetadata(keystoreService, w)
 	}
 
 	// For Ethereum and Solana: generate KeyStore V3 or network-specific format
@@ -1983,32 +2822,34 @@ func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, ver
 	// Create compatibility analyzer
 	analyzer := kdf.NewKDFCompatibilityAnalyzer(kdfService)
 
-	// Prepare KDF parameters
-	kdfParams := app.config.KeyStore.KDFParams
-	if len(kdfParams) == 0 {
-		// Use default parameters based on security level
-		securityLevel := app.parseSecurityLevel(app.config.KeyStore.SecurityLevel)
-		defaultParams, err := analyzer.GetOptimizedParams(app.config.KeyStore.KDFAlgorithm, securityLevel, 512) // 512MB max memory
-		if err != nil {
-			return fmt.Errorf("failed to get default KDF parameters: %w", err)
-		}
-		kdfParams = defaultParams
+	protection, err := crypto.ParsePasswordProtection(app.config.KeyStore.PasswordProtection)
+	if err != nil {
+		return err
+	}
+	policy, err := crypto.ParsePasswordPolicy(app.config.KeyStore.PasswordPolicy)
+	if err != nil {
+		return err
 	}
 
 	// Create keystore service configuration with Universal KDF
 	keystoreConfig := crypto.KeyStoreConfig{
-		Enabled:         app.config.KeyStore.Enabled,
-		OutputDirectory: app.config.KeyStore.OutputDir,
-		KDF:             app.config.KeyStore.KDFAlgorithm,
-		KDFParams:       kdfParams,
-		Cipher:          "aes-128-ctr",
-		MaxRetries:      3,
-		RetryDelay:      100, // 100ms
+		Enabled:            app.config.KeyStore.Enabled,
+		OutputDirectory:    outputDir,
+		KDF:                app.config.KeyStore.KDFAlgorithm,
+		KDFParams:          app.config.KeyStore.KDFParams,
+		Cipher:             app.config.KeyStore.Cipher,
+		MemoryBudget:       app.kdfBudget,
+		Retry:              app.retry.Keystore,
+		PasswordProtection: protection,
+		PasswordPolicy:     policy,
+		FilenameLabel:      app.filenameLabel(w),
+		PathStem:           pathStem,
 	}
 
 	// Create keystore service with controlled verbose logging
 	keystoreService := crypto.NewKeyStoreService(keystoreConfig)
 	keystoreService.SetVerboseMode(verbose)
+	keystoreService.SetTraceContext(ctx)
 
 	// Generate keystore first to get complete parameters
 	keystore, password, err := keystoreService.GenerateKeyStore(w.PrivateKey, w.Address, w.Network)
@@ -2029,7 +2870,7 @@ func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, ver
 		report, err := analyzer.AnalyzeKeystore(cryptoParamsComplete)
 		if err != nil {
 			if verbose {
-				fmt.Printf("Warning: Failed to analyze KDF compatibility: %v\n", err)
+				fmt.Println(i18n.T("kdf.analysis_failed", err))
 			}
 		} else {
 			app.displayCompatibilityReport(report, verbose)
@@ -2037,7 +2878,9 @@ func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, ver
 	}
 
 	// Save the generated keystore
-	if err := keystoreService.SaveKeyStoreFilesToDisk(w.Address, keystore, password, w.Network, w.PrivateKey); err != nil {
+	if err := retry.Do(ctx, app.retry.Keystore, func() error {
+		return crypto.RetryableSave(keystoreService.SaveKeyStoreFilesToDisk(w.Address, keystore, password, w.Network, w.PrivateKey))
+	}, nil); err != nil {
 		// Check if it's a KeyStoreError for better error reporting
 		if ksErr, ok := err.(*crypto.KeyStoreError); ok {
 			if ksErr.UserMessage != "" {
@@ -2049,7 +2892,7 @@ func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, ver
 	}
 
 	if w.Mnemonic != "" {
-		if err := keystoreService.SaveMnemonicFile(w.Address, w.Mnemonic, w.Network); err != nil {
+		if err := app.saveMnemonicBackup(keystoreService, w); err != nil {
 			if ksErr, ok := err.(*crypto.KeyStoreError); ok {
 				if ksErr.UserMessage != "" {
 					return fmt.Errorf("mnemonic save failed: %s", ksErr.UserMessage)
@@ -2060,5 +2903,5 @@ func (app *Application) generateAndSaveKeystoreWithVerbose(w *wallet.Wallet, ver
 		}
 	}
 
-	return nil
+	return app.saveWalletMetadata(keystoreService, w)
 }
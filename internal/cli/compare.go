@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"bloco-eth/internal/tui"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+)
+
+// compareBarWidth is the width of the longest bar in the text chart
+const compareBarWidth = 30
+
+// measureThreadCounts benchmarks each thread count for stepDuration and passes every
+// completed run to report
+func (app *Application) measureThreadCounts(ctx context.Context, counts []int, stepDuration time.Duration,
+	report func(threads int, speed float64)) error {
+	for _, threads := range counts {
+		if ctx.Err() != nil {
+			return errors.NewCancellationError("compare_threads", "thread comparison cancelled")
+		}
+		result, err := app.measureEfficiency(ctx, threads, app.config.Worker.MaxBatchSize, stepDuration, nil)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeGeneration,
+				"compare_threads", fmt.Sprintf("benchmark failed for %d threads", threads))
+		}
+		report(threads, result.Speed)
+	}
+	return nil
+}
+
+// runThreadComparisonTUI charts the comparison live, adding each run as it completes
+func (app *Application) runThreadComparisonTUI(ctx context.Context, stepDuration time.Duration) error {
+	counts := sweepThreadCounts(app.config.Worker.ThreadCount)
+	program := tea.NewProgram(tui.NewThreadComparisonModel(counts), tea.WithAltScreen(), tea.WithContext(ctx))
+
+	runCtx, cancel := context.WithCancel(ctx)
+	measured := make(chan struct{})
+	go func() {
+		defer close(measured)
+		err := app.measureThreadCounts(runCtx, counts, stepDuration, func(threads int, speed float64) {
+			program.Send(tui.ThreadRunMsg{Threads: threads, Speed: speed})
+		})
+		program.Send(tui.ThreadComparisonDoneMsg{Err: err})
+	}()
+
+	final, err := program.Run()
+	// Quitting early stops the run in progress; wait for its worker pool to shut down
+	cancel()
+	<-measured
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeTUI, "compare_threads", "thread comparison TUI failed")
+	}
+	// Leave the results on screen after the alternate screen closes
+	printThreadScaling(final.(tui.ThreadComparisonModel).Scaling(), counts[len(counts)-1])
+	return nil
+}
+
+// runThreadComparisonText prints each run as it completes, then the scaling summary
+func (app *Application) runThreadComparisonText(ctx context.Context, stepDuration time.Duration) error {
+	counts := sweepThreadCounts(app.config.Worker.ThreadCount)
+	fmt.Printf("Comparing thread counts %v, %v each...\n", counts, stepDuration)
+
+	var threads []int
+	var speeds []float64
+	err := app.measureThreadCounts(ctx, counts, stepDuration, func(n int, speed float64) {
+		fmt.Printf("  %3d threads: %s addr/s\n", n, formatLargeNumber(int64(speed)))
+		threads = append(threads, n)
+		speeds = append(speeds, speed)
+	})
+	if err != nil {
+		return err
+	}
+	printThreadScaling(utils.CalculateThreadScaling(threads, speeds), counts[len(counts)-1])
+	return nil
+}
+
+// printThreadScaling prints the bar chart, efficiency and Amdahl projection
+func printThreadScaling(scaling []utils.ThreadScaling, maxThreads int) {
+	if len(scaling) == 0 {
+		return
+	}
+	peak := 0.0
+	for _, point := range scaling {
+		peak = math.Max(peak, point.Speed)
+	}
+
+	fmt.Printf("\nThread Comparison:\n")
+	printRule()
+	for _, point := range scaling {
+		bar := ""
+		if !plainOutput.Load() && peak > 0 {
+			filled := int(math.Round(point.Speed / peak * compareBarWidth))
+			bar = strings.Repeat("█", filled) + strings.Repeat("░", compareBarWidth-filled) + " "
+		}
+		fmt.Printf("%3d threads %s%s addr/s, %.2fx speedup, %.0f%% efficiency\n",
+			point.Threads, bar, formatLargeNumber(int64(point.Speed)), point.Speedup, point.Efficiency*100)
+	}
+
+	serial := utils.FitAmdahlSerialFraction(scaling)
+	if serial < 0 {
+		fmt.Printf("\nAmdahl projection needs runs with more than one thread (use --threads)\n")
+		return
+	}
+	fmt.Printf("\nAmdahl's Law (fitted serial fraction %.1f%%):\n", serial*100)
+	for _, n := range []int{2 * maxThreads, 4 * maxThreads} {
+		fmt.Printf("  %3d threads: %.1fx projected speedup\n", n, utils.AmdahlSpeedup(serial, n))
+	}
+	if limit := utils.AmdahlLimit(serial); !math.IsInf(limit, 1) {
+		fmt.Printf("  Limit: %.1fx\n", limit)
+	}
+}
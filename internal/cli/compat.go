@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/compat"
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+)
+
+// createCompatCommand creates the compat command
+func (app *Application) createCompatCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "compat",
+		Short:       "Check a keystore can be imported by other Ethereum wallets",
+		Annotations: map[string]string{unhardenedAnnotation: "true"},
+		Long: `Build a compatibility matrix for a keystore:
+
+  reference  the keystore code decrypts the Web3 Secret Storage test vectors, the
+             file follows the V3 definition, and its password decrypts it to its address
+  analyzer   the KDF parameters are within the limits of geth, besu, anvil, reth
+             and firefly
+  client     installed clients read it for real, each in a temporary directory:
+             geth account list and account import, clef list-accounts and
+             ethkey inspect; clients not on PATH are skipped
+
+The password is read like keystore decrypt does. Without one, the checks that
+decrypt are skipped. geth account import is given the decrypted private key in
+a 0600 temporary file, removed when the check ends. The command exits with an
+error when any check fails.`,
+		Example: `  bloco-eth compat --keystore ./keystores/0x1234...abcd.json
+  bloco-eth compat --keystore wallet.json --password-file wallet.pwd --clients geth
+  bloco-eth compat --keystore wallet.json --format json`,
+		Args: cobra.NoArgs,
+		RunE: app.runCompat,
+	}
+	cmd.Flags().String("keystore", "", "Keystore file to check")
+	cmd.Flags().String("password-file", "", "Password file to use instead of the one next to the keystore")
+	cmd.Flags().String("age-identity", "", "age identity file for .pwd.age password files")
+	cmd.Flags().StringSlice("clients", compat.Clients, "External clients to try")
+	cmd.Flags().Duration("client-timeout", 2*time.Minute, "Time allowed for each client command")
+	_ = cmd.MarkFlagRequired("keystore")
+	return cmd
+}
+
+// runCompat checks a keystore and prints its compatibility matrix
+func (app *Application) runCompat(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("keystore")
+	clients, _ := cmd.Flags().GetStringSlice("clients")
+	timeout, _ := cmd.Flags().GetDuration("client-timeout")
+	format, _ := cmd.Flags().GetString("format")
+
+	keystore, err := readKeystoreFile(path)
+	if err != nil {
+		return err
+	}
+	var password string
+	if passwordPath, found := keystorePasswordPath(cmd, path); found {
+		ageIdentity, _ := cmd.Flags().GetString("age-identity")
+		if password, err = crypto.ReadPasswordFile(passwordPath, ageIdentity); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeValidation,
+				"compat", fmt.Sprintf("failed to read password from %s", passwordPath))
+		}
+	}
+
+	report, err := compat.CheckKeystore(cmd.Context(), path, compat.Options{
+		Password: password,
+		Clients:  clients,
+		Runner: func(ctx context.Context, name string, args ...string) ([]byte, error) {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return compat.ExecRunner(ctx, name, args...)
+		},
+	})
+	app.auditKeystoreAccess("compat", path, keystore.Address, err)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "compat", fmt.Sprintf("failed to check %s", path))
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "compat", "failed to encode report")
+		}
+		fmt.Fprintln(out, string(data))
+	} else if err := printCompatReport(cmd, report); err != nil {
+		return err
+	}
+
+	if !report.OK() {
+		return errors.NewValidationError("compat", fmt.Sprintf("%d compatibility check(s) failed", report.Failed()))
+	}
+	return nil
+}
+
+// printCompatReport writes the compatibility matrix as a table
+func printCompatReport(cmd *cobra.Command, report *compat.Report) error {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Keystore: %s\nAddress:  %s\nKDF:      %s\n\n", report.File, report.Address, report.KDF)
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "CLIENT\tMETHOD\tRESULT\tDETAIL")
+	for _, check := range report.Checks {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", check.Client, check.Method, strings.ToUpper(check.Status), check.Detail)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	if report.OK() {
+		fmt.Fprintf(out, "\n%sNo compatibility problems found\n", icon("✅"))
+	}
+	return nil
+}
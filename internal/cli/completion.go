@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/config"
+)
+
+// flagValuesAnnotation lists the accepted values of a flag, for completion and docs
+const flagValuesAnnotation = "bloco_flag_values"
+
+// registerCompletions adds the dynamic value completions of the global flags;
+// the completion command itself is cobra's default
+func (app *Application) registerCompletions() {
+	completeValues(app.rootCmd, "keystore-kdf", config.KDFAlgorithms...)
+	completeValues(app.rootCmd, "keystore-cipher", config.KeyStoreCiphers...)
+	completeValues(app.rootCmd, "security-level", config.SecurityLevels...)
+	completeValues(app.rootCmd, "format", "text", "json", "csv")
+	_ = app.rootCmd.RegisterFlagCompletionFunc("preset", completePresetNames)
+}
+
+// completeValues completes flag of cmd with a fixed list of values
+func completeValues(cmd *cobra.Command, flag string, values ...string) {
+	f := cmd.Flag(flag)
+	if f == nil {
+		panic(fmt.Sprintf("%s has no --%s flag", cmd.CommandPath(), flag))
+	}
+	if f.Annotations == nil {
+		f.Annotations = map[string][]string{}
+	}
+	f.Annotations[flagValuesAnnotation] = values
+	_ = cmd.RegisterFlagCompletionFunc(flag, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// completePresetNames offers the presets of the registry selected by --preset-file,
+// described by their description or pattern
+func completePresetNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	registry, _, err := loadPresets(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, name := range registry.Names() {
+		if slices.Contains(args, name) {
+			continue
+		}
+		p, _ := registry.Get(name)
+		description := p.Description
+		if description == "" {
+			description = presetArgs(p)
+		}
+		names = append(names, fmt.Sprintf("%s\t%s", name, description))
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePresetArg completes the single preset name argument of preset show and remove
+func completePresetArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completePresetNames(cmd, args, toComplete)
+}
+
+// completePresetExport completes the file of preset export, then the preset names
+func completePresetExport(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return completePresetNames(cmd, args[1:], toComplete)
+}
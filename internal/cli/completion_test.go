@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+)
+
+func TestCompletions(t *testing.T) {
+	registry := filepath.Join(t.TempDir(), "presets.json")
+	data := `{"presets": {"lucky8": {"suffix": "88888888", "description": "Lucky deposits"}, "cafe": {"prefix": "cafe"}}}`
+	if err := os.WriteFile(registry, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"--keystore-kdf", ""}, config.KDFAlgorithms},
+		{[]string{"--security-level", ""}, config.SecurityLevels},
+		{[]string{"stats", "--format", ""}, []string{"text", "json", "csv"}},
+		{[]string{"hardware", "capabilities", "--format", ""}, []string{"text", "json"}},
+		{[]string{"--preset-file", registry, "--preset", ""}, []string{"cafe\t--prefix cafe", "lucky8\tLucky deposits"}},
+		{[]string{"preset", "show", "--preset-file", registry, ""}, []string{"cafe\t--prefix cafe", "lucky8\tLucky deposits"}},
+		{[]string{"preset", "show", "--preset-file", registry, "cafe", ""}, nil},
+		{[]string{"preset", "export", "--preset-file", registry, "out.json", "cafe", ""}, []string{"lucky8\tLucky deposits"}},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+			root := app.GetRootCommand()
+			var out strings.Builder
+			root.SetOut(&out)
+			root.SetArgs(append([]string{"__complete"}, tt.args...))
+			if err := root.Execute(); err != nil {
+				t.Fatal(err)
+			}
+
+			// The last line is the directive
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			got := lines[:len(lines)-1]
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("completions = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
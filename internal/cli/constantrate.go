@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/pkg/errors"
+)
+
+// constantRateLineSize is the length every --constant-rate progress line is padded to
+const constantRateLineSize = 384
+
+// immediateOutputFlags report events as they happen and cannot be paced
+var immediateOutputFlags = []string{"log-file", "audit-trail", "otlp-endpoint", "health-addr", "publish", "notify", "status-file", "status-url"}
+
+// parseConstantRate reads --constant-rate and turns off the secure log, which records
+// wallets as soon as they are found
+func (app *Application) parseConstantRate(cmd *cobra.Command) error {
+	app.constantRate, _ = cmd.Flags().GetDuration("constant-rate")
+	if app.constantRate < 0 {
+		return errors.NewValidationError("parse_flags", "--constant-rate must not be negative")
+	}
+	if app.constantRate == 0 {
+		return nil
+	}
+	for _, name := range immediateOutputFlags {
+		if cmd.Flags().Changed(name) {
+			return errors.NewValidationError("parse_flags",
+				fmt.Sprintf("--%s reports events as they happen and cannot be used with --constant-rate", name))
+		}
+	}
+	if app.tracer != nil {
+		return errors.NewValidationError("parse_flags",
+			"trace export reports events as they happen and cannot be used with --constant-rate; unset OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	app.config.Logging.Enabled = false
+	return nil
+}
+
+// startPacedJSONProgress emits --progress-format json events on a fixed schedule for
+// --constant-rate: one line of constantRateLineSize bytes every rate, timestamped
+// with its slot. Found wallets are queued and released one per slot, and attempts,
+// speed, probability and ETAs are left out, so neither the timing nor the content of
+// the stream depends on how long a wallet took to find.
+func startPacedJSONProgress(ctx context.Context, out io.Writer, closer io.Closer,
+	wallets, threads int, rate time.Duration) *jsonProgress {
+	p := &jsonProgress{
+		out:    out,
+		closer: closer,
+		start:  time.Now(),
+		done:   make(chan struct{}),
+		rate:   rate,
+	}
+	p.emitPaced(progressEvent{Event: "start", Wallets: wallets, Threads: threads}, 0)
+
+	// Cancellation ends generation but not the schedule; queued wallets are still released
+	ctx, p.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(rate)
+		defer ticker.Stop()
+		for slot := int64(1); ; slot++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if p.releaseSlot(slot) {
+					return
+				}
+			}
+		}
+	}()
+	return p
+}
+
+// queue holds a found wallet for the next free slot
+func (p *jsonProgress) queue(address string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queued = append(p.queued, progressEvent{Event: "wallet", Address: address})
+}
+
+// releaseSlot emits the line for a slot: the next queued wallet, the done event once
+// the queue is empty and the run has stopped, or a progress line. It reports whether
+// the stream has ended.
+func (p *jsonProgress) releaseSlot(slot int64) bool {
+	p.mu.Lock()
+	var (
+		e     progressEvent
+		ended bool
+	)
+	switch {
+	case len(p.queued) > 0:
+		e = p.queued[0]
+		p.queued = p.queued[1:]
+		p.released++
+	case p.final != nil:
+		e, ended = *p.final, true
+	default:
+		e = progressEvent{Event: "progress"}
+	}
+	e.Found = p.released
+	p.mu.Unlock()
+
+	p.emitPaced(e, slot)
+	return ended
+}
+
+// drain waits for the queued wallets and the done event to be released on schedule
+func (p *jsonProgress) drain(err error) {
+	done := progressEvent{Event: "done"}
+	if err != nil {
+		done.Error = err.Error()
+	}
+	p.mu.Lock()
+	p.final = &done
+	p.mu.Unlock()
+
+	<-p.done
+	if p.closer != nil {
+		_ = p.closer.Close()
+	}
+}
+
+// emitPaced writes event timestamped with its slot and padded to constantRateLineSize
+func (p *jsonProgress) emitPaced(event progressEvent, slot int64) {
+	offset := time.Duration(slot) * p.rate
+	event.Time = p.start.Add(offset).UTC()
+	event.Elapsed = offset.Seconds()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	if pad := constantRateLineSize - 1 - len(data) - len(`,"pad":""`); pad > 0 {
+		event.Pad = strings.Repeat(" ", pad)
+		if data, err = json.Marshal(event); err != nil {
+			return
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = p.out.Write(append(data, '\n'))
+}
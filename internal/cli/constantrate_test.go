@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestPacedJSONProgress(t *testing.T) {
+	var out bytes.Buffer
+	rate := 20 * time.Millisecond
+	p := startPacedJSONProgress(context.Background(), &out, nil, 2, 4, rate)
+
+	p.walletFound(1, &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: "0xab12"}, Attempts: 300})
+	p.walletFound(2, &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: "0xab34"}, Attempts: 9})
+	p.stop(nil)
+
+	var events []progressEvent
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		if len(scanner.Bytes())+1 != constantRateLineSize {
+			t.Errorf("line is %d bytes, want %d: %s", len(scanner.Bytes())+1, constantRateLineSize, scanner.Text())
+		}
+		var e progressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+
+	want := []string{"start", "wallet", "wallet", "done"}
+	if len(events) != len(want) {
+		t.Fatalf("expected %v events, got %+v", want, events)
+	}
+	for i, e := range events {
+		if e.Event != want[i] {
+			t.Errorf("event %d = %q, want %q", i, e.Event, want[i])
+		}
+		if offset := e.Time.Sub(events[0].Time); offset != time.Duration(i)*rate {
+			t.Errorf("event %d is at %v, want slot %v", i, offset, time.Duration(i)*rate)
+		}
+		if e.Attempts != 0 || e.Speed != 0 || e.Result != nil || e.ETA != nil {
+			t.Errorf("event %d leaks timing metadata: %+v", i, e)
+		}
+	}
+	if events[2].Address != "0xab34" || events[2].Found != 2 || events[3].Found != 2 {
+		t.Errorf("wallets should be released in order with their count, got %+v", events)
+	}
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/validation"
+	"bloco-eth/pkg/errors"
+)
+
+// create2Manifest is the deployment manifest written by the create2 command
+type create2Manifest struct {
+	Deployer      string                 `json:"deployer"`
+	Pattern       string                 `json:"pattern"`
+	CaseSensitive bool                   `json:"caseSensitive"`
+	Contracts     []crypto.CREATE2Result `json:"contracts"`
+}
+
+// createCreate2Command creates the create2 command for batch salt mining
+func (app *Application) createCreate2Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create2",
+		Short: "Mine CREATE2 salts for vanity contract addresses",
+		Long: `Mine a CREATE2 salt for each contract init-code hash so its deployment address
+matches --prefix/--suffix, and write a deployment manifest with the contract name,
+salt and predicted address. All targets are mined together across --threads workers.
+
+Targets come from --init-code-hash NAME=HASH (repeatable) and/or a --targets file
+with one "NAME HASH" pair per line ('#' starts a comment).`,
+		Example: `  bloco-eth create2 --prefix cafe --init-code-hash Token=0x1a2b... --init-code-hash Vault=0x3c4d...
+  bloco-eth create2 --prefix 0000 --targets contracts.txt --manifest deploy/create2.json`,
+		Args: cobra.NoArgs,
+		RunE: app.runCreate2,
+	}
+	cmd.Flags().String("deployer", crypto.DefaultCREATE2Deployer, "CREATE2 factory address that deploys the contracts")
+	cmd.Flags().StringArray("init-code-hash", nil, "Contract to mine as NAME=0xHASH (keccak256 of the init code), repeatable")
+	cmd.Flags().String("targets", "", "File with one \"NAME 0xHASH\" pair per line")
+	cmd.Flags().String("manifest", "", "Write the deployment manifest JSON to this file (default: stdout)")
+	return cmd
+}
+
+// runCreate2 mines salts for all targets and writes the manifest
+func (app *Application) runCreate2(cmd *cobra.Command, args []string) error {
+	if err := app.parseFlags(cmd); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", "failed to parse command flags")
+	}
+	criteria, err := app.getGenerationCriteria(cmd)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation,
+			"get_criteria", "invalid generation criteria")
+	}
+	deployer, _ := cmd.Flags().GetString("deployer")
+	if !common.IsHexAddress(deployer) {
+		return errors.NewValidationError("create2", fmt.Sprintf("invalid --deployer address %q", deployer))
+	}
+	targets, err := readCreate2Targets(cmd)
+	if err != nil {
+		return err
+	}
+
+	quiet := app.config.CLI.QuietMode
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Mining CREATE2 salts for %d contract(s) with pattern %s (difficulty %s each) on %d threads\n",
+			len(targets), criteria.GetPattern(), formatLargeNumber(int64(calculateDifficulty(criteria))), app.config.Worker.ThreadCount)
+	}
+
+	strategy := validation.NewOptimizedStrategy(crypto.NewChecksumValidator(crypto.NewPoolManager(crypto.DefaultPoolConfig())), criteria.IsCaseSensitive())
+	match := func(address string) bool {
+		ok, _ := strategy.Validate(address, criteria.Prefix, criteria.Suffix)
+		return ok
+	}
+
+	start := time.Now()
+	results, mineErr := crypto.MineCREATE2Batch(cmd.Context(), common.HexToAddress(deployer), targets,
+		app.config.Worker.ThreadCount, match, func(result crypto.CREATE2Result) {
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "  %s%s: %s (salt %s, %s attempts, %s)\n", icon("✅"), result.Name, result.Address, result.Salt,
+					formatLargeNumber(result.Attempts), formatDuration(time.Since(start)))
+			}
+		})
+
+	// Write whatever was mined, even if interrupted
+	manifest := create2Manifest{
+		Deployer:      common.HexToAddress(deployer).Hex(),
+		Pattern:       criteria.GetPattern(),
+		CaseSensitive: criteria.IsCaseSensitive(),
+		Contracts:     results,
+	}
+	if manifest.Contracts == nil {
+		manifest.Contracts = []crypto.CREATE2Result{}
+	}
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+	if err := writeCreate2Manifest(manifestPath, manifest); err != nil {
+		return err
+	}
+
+	if mineErr != nil {
+		return errors.WrapError(mineErr, errors.ErrorTypeWorker, "create2",
+			fmt.Sprintf("mining stopped after %d of %d contracts", len(results), len(targets)))
+	}
+	return nil
+}
+
+// readCreate2Targets collects targets from --init-code-hash and --targets
+func readCreate2Targets(cmd *cobra.Command) ([]crypto.CREATE2Target, error) {
+	var specs []string
+	pairs, _ := cmd.Flags().GetStringArray("init-code-hash")
+	for _, pair := range pairs {
+		specs = append(specs, strings.Replace(pair, "=", " ", 1))
+	}
+
+	if path, _ := cmd.Flags().GetString("targets"); path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "create2", fmt.Sprintf("failed to open %s", path))
+		}
+		defer file.Close()
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(strings.SplitN(scanner.Text(), "#", 2)[0])
+			if line != "" {
+				specs = append(specs, line)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "create2", fmt.Sprintf("failed to read %s", path))
+		}
+	}
+
+	var targets []crypto.CREATE2Target
+	seen := make(map[string]bool)
+	for _, spec := range specs {
+		fields := strings.Fields(strings.ReplaceAll(spec, ",", " "))
+		if len(fields) != 2 {
+			return nil, errors.NewValidationError("create2", fmt.Sprintf("invalid target %q: expected NAME and init-code hash", spec))
+		}
+		if seen[fields[0]] {
+			return nil, errors.NewValidationError("create2", fmt.Sprintf("duplicate contract name %q", fields[0]))
+		}
+		hash, err := crypto.ParseInitCodeHash(fields[1])
+		if err != nil {
+			return nil, errors.NewValidationError("create2", fmt.Sprintf("%s: %v", fields[0], err))
+		}
+		seen[fields[0]] = true
+		targets = append(targets, crypto.CREATE2Target{Name: fields[0], InitCodeHash: hash})
+	}
+	if len(targets) == 0 {
+		return nil, errors.NewValidationError("create2", "no targets: use --init-code-hash or --targets")
+	}
+	return targets, nil
+}
+
+// writeCreate2Manifest writes the manifest to path, or to stdout when path is empty
+func writeCreate2Manifest(path string, manifest create2Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "create2", "failed to encode manifest")
+	}
+	if path == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "create2", fmt.Sprintf("failed to write %s", path))
+	}
+	fmt.Fprintf(os.Stderr, "Deployment manifest saved to: %s (%d contracts)\n", path, len(manifest.Contracts))
+	return nil
+}
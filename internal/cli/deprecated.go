@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"bloco-eth/pkg/errors"
+)
+
+// legacyFlag maps a flag spelling of the earlier CLI to its replacement
+type legacyFlag struct {
+	Command     string `json:"command"`
+	Flag        string `json:"flag"`
+	Replacement string `json:"replacement"`
+	Accepted    bool   `json:"accepted"`
+	Note        string `json:"note,omitempty"`
+}
+
+// legacyFlags lists every renamed flag. Accepted ones still work and print a
+// deprecation warning; the others now mean something else and cannot be aliased.
+var legacyFlags = []legacyFlag{
+	{Command: "benchmark", Flag: "--pattern", Replacement: "--prefix", Accepted: true},
+	{Command: "benchmark", Flag: "-a", Replacement: "--attempts", Accepted: true},
+	{Command: "k8s generate", Flag: "--pattern", Replacement: "--prefix", Accepted: true},
+	{Command: "", Flag: "-c", Replacement: "-n, --count", Note: "-c is now the shorthand of --checksum"},
+}
+
+// aliasValue forwards a legacy flag to its replacement, so the replacement is
+// marked as changed just as if it had been given
+type aliasValue struct {
+	cmd    *cobra.Command
+	target *pflag.Flag
+}
+
+func (a aliasValue) Set(s string) error { return a.cmd.Flags().Set(a.target.Name, s) }
+func (a aliasValue) String() string     { return a.target.Value.String() }
+func (a aliasValue) Type() string       { return a.target.Value.Type() }
+
+// applyLegacyFlags registers the accepted legacy flags on the command tree
+func applyLegacyFlags(root *cobra.Command) {
+	for _, legacy := range legacyFlags {
+		if !legacy.Accepted {
+			continue
+		}
+		cmd, _, err := root.Find(strings.Fields(legacy.Command))
+		if err != nil {
+			panic(fmt.Sprintf("legacy flag %s: %v", legacy.Flag, err))
+		}
+		target := cmd.Flags().Lookup(strings.TrimPrefix(legacy.Replacement, "--"))
+		if target == nil {
+			target = cmd.InheritedFlags().Lookup(strings.TrimPrefix(legacy.Replacement, "--"))
+		}
+		if target == nil {
+			panic(fmt.Sprintf("legacy flag %s: %s has no %s flag", legacy.Flag, cmd.CommandPath(), legacy.Replacement))
+		}
+		message := fmt.Sprintf("use %s instead", legacy.Replacement)
+
+		alias := &pflag.Flag{
+			Name:        strings.TrimPrefix(legacy.Flag, "--"),
+			Usage:       fmt.Sprintf("Deprecated alias for %s", legacy.Replacement),
+			Value:       aliasValue{cmd: cmd, target: target},
+			DefValue:    target.DefValue,
+			NoOptDefVal: target.NoOptDefVal,
+			Hidden:      true,
+		}
+		// A legacy shorthand gets a hidden long name of its own, since help lists
+		// the shorthand of a flag even when it is deprecated
+		if !strings.HasPrefix(legacy.Flag, "--") {
+			alias.Name, alias.Shorthand = "legacy"+legacy.Flag, strings.TrimPrefix(legacy.Flag, "-")
+			alias.ShorthandDeprecated = message
+		} else {
+			alias.Deprecated = message
+		}
+		cmd.Flags().AddFlag(alias)
+	}
+}
+
+// createFlagsCommand creates the flags command
+func (app *Application) createFlagsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "flags",
+		Short: "List the flags of every command, or the legacy flags they replace",
+		Long: `List the flags of every command. With --deprecated, list the flags of the
+earlier CLI and their replacements instead. Accepted legacy flags still work and
+print a deprecation warning; the others now mean something else.`,
+		Example: `  bloco-eth flags
+  bloco-eth flags --deprecated
+  bloco-eth flags --deprecated --format json`,
+		Args: cobra.NoArgs,
+		RunE: app.runFlags,
+	}
+	cmd.Flags().Bool("deprecated", false, "List legacy flags and their replacements")
+	return cmd
+}
+
+// runFlags prints the flag list or the legacy flag mapping
+func (app *Application) runFlags(cmd *cobra.Command, args []string) error {
+	deprecated, _ := cmd.Flags().GetBool("deprecated")
+	format, _ := cmd.Flags().GetString("format")
+	out := cmd.OutOrStdout()
+
+	if format == "json" {
+		var data []byte
+		var err error
+		if deprecated {
+			data, err = json.MarshalIndent(legacyFlags, "", "  ")
+		} else {
+			data, err = json.MarshalIndent(currentFlags(app.rootCmd), "", "  ")
+		}
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "flags", "failed to encode flags")
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if deprecated {
+		fmt.Fprintln(table, "COMMAND\tLEGACY\tREPLACEMENT\tSTATUS")
+		for _, legacy := range legacyFlags {
+			status := "accepted, warns"
+			if !legacy.Accepted {
+				status = "removed: " + legacy.Note
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", strings.TrimSpace(app.rootCmd.Name()+" "+legacy.Command), legacy.Flag, legacy.Replacement, status)
+		}
+	} else {
+		fmt.Fprintln(table, "COMMAND\tFLAG\tTYPE\tDEFAULT")
+		for _, flag := range currentFlags(app.rootCmd) {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", flag.Command, flag.Flag, flag.Type, flag.Default)
+		}
+	}
+	return table.Flush()
+}
+
+// commandFlag is one visible flag of a command
+type commandFlag struct {
+	Command string `json:"command"`
+	Flag    string `json:"flag"`
+	Type    string `json:"type"`
+	Default string `json:"default"`
+}
+
+// currentFlags lists the visible flags each command declares, walking the tree from root
+func currentFlags(root *cobra.Command) []commandFlag {
+	var flags []commandFlag
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		visibleFlags(cmd.NonInheritedFlags(), func(f *pflag.Flag) {
+			name := "--" + f.Name
+			if f.Shorthand != "" && f.ShorthandDeprecated == "" {
+				name = "-" + f.Shorthand + ", " + name
+			}
+			flags = append(flags, commandFlag{Command: cmd.CommandPath(), Flag: name, Type: f.Value.Type(), Default: f.DefValue})
+		})
+		for _, sub := range cmd.Commands() {
+			if !sub.Hidden {
+				walk(sub)
+			}
+		}
+	}
+	walk(root)
+	return flags
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+)
+
+func TestLegacyFlags(t *testing.T) {
+	tests := []struct {
+		command []string
+		args    []string
+		target  string
+		want    string
+	}{
+		{[]string{"benchmark"}, []string{"--pattern", "fff"}, "prefix", "fff"},
+		{[]string{"benchmark"}, []string{"-a", "500"}, "attempts", "500"},
+		{[]string{"k8s", "generate"}, []string{"--pattern=dead"}, "prefix", "dead"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(append(tt.command, tt.args...), " "), func(t *testing.T) {
+			app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+			cmd, _, err := app.GetRootCommand().Find(tt.command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var warning strings.Builder
+			cmd.SetOut(&warning)
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags(%v) returned error: %v", tt.args, err)
+			}
+
+			target := cmd.Flags().Lookup(tt.target)
+			if target.Value.String() != tt.want || !cmd.Flags().Changed(tt.target) {
+				t.Errorf("--%s = %q (changed %v), want %q set by the legacy flag", tt.target, target.Value, target.Changed, tt.want)
+			}
+			if !strings.Contains(warning.String(), "deprecated, use --"+tt.target+" instead") {
+				t.Errorf("warning = %q, want a deprecation pointing at --%s", warning.String(), tt.target)
+			}
+		})
+	}
+}
@@ -0,0 +1,308 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"bloco-eth/pkg/errors"
+)
+
+// cliSchema describes the command tree for packagers and GUI front-ends
+type cliSchema struct {
+	Name        string          `json:"name"`
+	Version     string          `json:"version"`
+	Commands    []commandSchema `json:"commands"`
+	LegacyFlags []legacyFlag    `json:"legacy_flags"`
+}
+
+// commandSchema is one command of the tree; Flags are the flags it declares, global
+// ones on the root with persistent set
+type commandSchema struct {
+	Path        string       `json:"path"`
+	Use         string       `json:"use"`
+	Short       string       `json:"short"`
+	Long        string       `json:"long,omitempty"`
+	Example     string       `json:"example,omitempty"`
+	Aliases     []string     `json:"aliases,omitempty"`
+	Runnable    bool         `json:"runnable"`
+	Subcommands []string     `json:"subcommands,omitempty"`
+	Flags       []flagSchema `json:"flags,omitempty"`
+}
+
+// flagSchema is one flag of a command
+type flagSchema struct {
+	Name       string   `json:"name"`
+	Shorthand  string   `json:"shorthand,omitempty"`
+	Type       string   `json:"type"`
+	Default    string   `json:"default"`
+	Usage      string   `json:"usage"`
+	Values     []string `json:"values,omitempty"`
+	Persistent bool     `json:"persistent,omitempty"`
+	Required   bool     `json:"required,omitempty"`
+}
+
+// createDocsCommand creates the hidden docs command
+func (app *Application) createDocsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate man pages or a JSON schema of every command and flag",
+		Long: `Generate documentation from the command tree of this binary, so packages and
+front-ends stay in sync with the CLI. --format man writes one page per command,
+bloco-eth.1, bloco-eth-benchmark.1 and so on, to --dir. --format json prints a
+schema of every command and flag, or writes it to --dir as bloco-eth.json.`,
+		Example: `  bloco-eth docs --format man --dir /usr/share/man/man1
+  bloco-eth docs --format json > bloco-eth.json`,
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE:   app.runDocs,
+	}
+	cmd.Flags().String("format", "man", "Documentation format (man, json)")
+	cmd.Flags().String("dir", "", "Directory to write the files to (json: default stdout)")
+	completeValues(cmd, "format", "man", "json")
+	return cmd
+}
+
+// runDocs generates the documentation in the requested format
+func (app *Application) runDocs(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	dir, _ := cmd.Flags().GetString("dir")
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(app.cliSchema(), "", "  ")
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "docs", "failed to encode schema")
+		}
+		data = append(data, '\n')
+		if dir == "" {
+			_, err = cmd.OutOrStdout().Write(data)
+			return err
+		}
+		return writeDocs(cmd.ErrOrStderr(), dir, map[string][]byte{app.rootCmd.Name() + ".json": data})
+	case "man":
+		if dir == "" {
+			return errors.NewValidationError("docs", "--format man writes one page per command; set --dir")
+		}
+		pages := map[string][]byte{}
+		for _, c := range documentedCommands(app.rootCmd) {
+			var page bytes.Buffer
+			app.writeManPage(&page, c)
+			pages[manPageName(c)+".1"] = page.Bytes()
+		}
+		return writeDocs(cmd.ErrOrStderr(), dir, pages)
+	default:
+		return errors.NewValidationError("docs", fmt.Sprintf("unknown format %q (man, json)", format))
+	}
+}
+
+// writeDocs writes the named files to dir, creating it if needed, and reports it on log
+func writeDocs(log io.Writer, dir string, files map[string][]byte) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "docs", fmt.Sprintf("failed to create %s", dir))
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "docs", fmt.Sprintf("failed to write %s", path))
+		}
+	}
+	fmt.Fprintf(log, "Wrote %d file(s) to %s\n", len(files), dir)
+	return nil
+}
+
+// documentedCommands lists root and every available command below it, depth first
+func documentedCommands(root *cobra.Command) []*cobra.Command {
+	commands := []*cobra.Command{root}
+	for _, sub := range root.Commands() {
+		if sub.IsAvailableCommand() && !sub.IsAdditionalHelpTopicCommand() {
+			commands = append(commands, documentedCommands(sub)...)
+		}
+	}
+	return commands
+}
+
+// cliSchema describes every documented command and its visible flags
+func (app *Application) cliSchema() cliSchema {
+	schema := cliSchema{Name: app.rootCmd.Name(), Version: app.version, LegacyFlags: legacyFlags}
+	for _, c := range documentedCommands(app.rootCmd) {
+		command := commandSchema{
+			Path:     c.CommandPath(),
+			Use:      c.Use,
+			Short:    c.Short,
+			Long:     c.Long,
+			Example:  c.Example,
+			Aliases:  c.Aliases,
+			Runnable: c.Runnable(),
+		}
+		for _, sub := range c.Commands() {
+			if sub.IsAvailableCommand() && !sub.IsAdditionalHelpTopicCommand() {
+				command.Subcommands = append(command.Subcommands, sub.Name())
+			}
+		}
+		persistent := c.PersistentFlags()
+		visibleFlags(c.NonInheritedFlags(), func(f *pflag.Flag) {
+			_, required := f.Annotations[cobra.BashCompOneRequiredFlag]
+			command.Flags = append(command.Flags, flagSchema{
+				Name:       f.Name,
+				Shorthand:  f.Shorthand,
+				Type:       f.Value.Type(),
+				Default:    f.DefValue,
+				Usage:      f.Usage,
+				Values:     f.Annotations[flagValuesAnnotation],
+				Persistent: persistent.Lookup(f.Name) != nil,
+				Required:   required,
+			})
+		})
+		schema.Commands = append(schema.Commands, command)
+	}
+	return schema
+}
+
+// visibleFlags calls fn for the flags of set that are neither hidden nor deprecated
+func visibleFlags(set *pflag.FlagSet, fn func(f *pflag.Flag)) {
+	set.VisitAll(func(f *pflag.Flag) {
+		if !f.Hidden && f.Deprecated == "" {
+			fn(f)
+		}
+	})
+}
+
+// manPageName is the page name of a command, its path joined by dashes
+func manPageName(c *cobra.Command) string {
+	return strings.ReplaceAll(c.CommandPath(), " ", "-")
+}
+
+// writeManPage renders the roff man page of a command. Global flags are only
+// listed on the root page, which every other page refers to.
+func (app *Application) writeManPage(w io.Writer, c *cobra.Command) {
+	name := manPageName(c)
+	fmt.Fprintf(w, ".TH %q 1 \"\" %q \"%s Manual\"\n", strings.ToUpper(name), app.rootCmd.Name()+" "+app.version, app.rootCmd.Name())
+	fmt.Fprintf(w, ".SH NAME\n%s \\- %s\n", name, roffEscape(c.Short))
+
+	fmt.Fprintf(w, ".SH SYNOPSIS\n.B %s\n", roffEscape(c.CommandPath()))
+	if c.HasAvailableSubCommands() && !c.Runnable() {
+		fmt.Fprint(w, "\\fIcommand\\fR\n")
+	}
+	if c.HasAvailableFlags() {
+		fmt.Fprint(w, "[\\fIflags\\fR]\n")
+	}
+	if _, use, ok := strings.Cut(c.Use, " "); ok {
+		fmt.Fprintf(w, "%s\n", roffEscape(use))
+	}
+
+	description := c.Long
+	if description == "" {
+		description = c.Short
+	}
+	fmt.Fprint(w, ".SH DESCRIPTION\n")
+	writeRoffText(w, description)
+
+	if local := c.NonInheritedFlags(); local.HasAvailableFlags() {
+		fmt.Fprint(w, ".SH OPTIONS\n")
+		var flags []*pflag.Flag
+		visibleFlags(local, func(f *pflag.Flag) { flags = append(flags, f) })
+		sort.SliceStable(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+		for _, f := range flags {
+			writeManFlag(w, f)
+		}
+	}
+	if c.HasParent() && c.HasAvailableInheritedFlags() {
+		fmt.Fprintf(w, ".PP\nThe global options are described in \\fB%s\\fR(1).\n", roffEscape(app.rootCmd.Name()))
+	}
+
+	if c.Example != "" {
+		fmt.Fprint(w, ".SH EXAMPLES\n.nf\n")
+		for _, line := range strings.Split(strings.TrimRight(c.Example, "\n"), "\n") {
+			fmt.Fprintln(w, roffLine(line))
+		}
+		fmt.Fprint(w, ".fi\n")
+	}
+
+	var seeAlso []string
+	if c.HasParent() {
+		seeAlso = append(seeAlso, manPageName(c.Parent()))
+	}
+	for _, sub := range c.Commands() {
+		if sub.IsAvailableCommand() && !sub.IsAdditionalHelpTopicCommand() {
+			seeAlso = append(seeAlso, manPageName(sub))
+		}
+	}
+	if len(seeAlso) > 0 {
+		refs := make([]string, len(seeAlso))
+		for i, page := range seeAlso {
+			refs[i] = fmt.Sprintf("\\fB%s\\fR(1)", roffEscape(page))
+		}
+		fmt.Fprintf(w, ".SH SEE ALSO\n%s\n", strings.Join(refs, ", "))
+	}
+}
+
+// writeManFlag renders one flag as a tagged paragraph
+func writeManFlag(w io.Writer, f *pflag.Flag) {
+	tag := "\\fB\\-\\-" + roffEscape(f.Name) + "\\fR"
+	if f.Shorthand != "" && f.ShorthandDeprecated == "" {
+		tag = "\\fB\\-" + roffEscape(f.Shorthand) + "\\fR, " + tag
+	}
+	if f.Value.Type() != "bool" {
+		tag += "=\\fI" + roffEscape(f.Value.Type()) + "\\fR"
+	}
+	usage := f.Usage
+	if values := f.Annotations[flagValuesAnnotation]; len(values) > 0 {
+		usage += "; one of " + strings.Join(values, ", ")
+	}
+	if f.DefValue != "" && f.DefValue != "false" && f.DefValue != "0" && f.DefValue != "[]" {
+		usage += fmt.Sprintf(" (default %s)", f.DefValue)
+	}
+	fmt.Fprintf(w, ".TP\n%s\n%s\n", tag, roffLine(usage))
+}
+
+// writeRoffText renders text with blank lines as paragraph breaks and indented
+// lines, such as lists and tables, kept as they are
+func writeRoffText(w io.Writer, text string) {
+	verbatim := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		indented := strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		switch {
+		case strings.TrimSpace(line) == "":
+			if verbatim {
+				fmt.Fprint(w, ".fi\n.RE\n")
+				verbatim = false
+			}
+			fmt.Fprint(w, ".PP\n")
+			continue
+		case indented && !verbatim:
+			fmt.Fprint(w, ".RS\n.nf\n")
+			verbatim = true
+		case !indented && verbatim:
+			fmt.Fprint(w, ".fi\n.RE\n")
+			verbatim = false
+		}
+		fmt.Fprintln(w, roffLine(strings.TrimRight(line, " ")))
+	}
+	if verbatim {
+		fmt.Fprint(w, ".fi\n.RE\n")
+	}
+}
+
+// roffEscape escapes backslashes and dashes in text
+func roffEscape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+}
+
+// roffLine escapes a line of text, guarding a leading dot or quote that roff
+// would read as a request
+func roffLine(s string) string {
+	s = roffEscape(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+)
+
+func TestCLISchema(t *testing.T) {
+	app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+	schema := app.cliSchema()
+
+	commands := map[string]commandSchema{}
+	for _, c := range schema.Commands {
+		commands[c.Path] = c
+	}
+	for _, path := range []string{"bloco-eth", "bloco-eth benchmark", "bloco-eth k8s generate", "bloco-eth preset show"} {
+		if _, ok := commands[path]; !ok {
+			t.Errorf("schema has no %q command", path)
+		}
+	}
+	if _, ok := commands["bloco-eth docs"]; ok {
+		t.Error("schema lists the hidden docs command")
+	}
+
+	flags := map[string]flagSchema{}
+	for _, f := range commands["bloco-eth"].Flags {
+		flags[f.Name] = f
+	}
+	if f := flags["security-level"]; !f.Persistent || strings.Join(f.Values, ",") != strings.Join(config.SecurityLevels, ",") {
+		t.Errorf("--security-level = %+v, want a persistent flag with the security levels as values", f)
+	}
+	for _, f := range commands["bloco-eth benchmark"].Flags {
+		if f.Name == "pattern" || f.Name == "legacy-a" {
+			t.Errorf("schema lists the deprecated --%s flag", f.Name)
+		}
+	}
+}
+
+func TestDocsManPages(t *testing.T) {
+	app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+	root := app.GetRootCommand()
+	dir := t.TempDir()
+	root.SetArgs([]string{"docs", "--format", "man", "--dir", dir})
+	root.SetErr(&strings.Builder{})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "bloco-eth-benchmark.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`.TH "BLOCO-ETH-BENCHMARK" 1`,
+		`bloco-eth-benchmark \- Run performance benchmarks`,
+		`\fB\-\-attempts\fR=\fIint\fR`,
+		`The global options are described in \fBbloco\-eth\fR(1).`,
+		`\fBbloco\-eth\fR(1)`,
+	} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("benchmark man page does not contain %q:\n%s", want, page)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "bloco-eth-docs.1")); err == nil {
+		t.Error("man page written for the hidden docs command")
+	}
+}
+
+func TestRoffLine(t *testing.T) {
+	tests := map[string]string{
+		"plain text":       "plain text",
+		"--prefix":         `\-\-prefix`,
+		`C:\keys`:          `C:\ekeys`,
+		".hidden file":     `\&.hidden file`,
+		"'quoted' request": `\&'quoted' request`,
+	}
+	for in, want := range tests {
+		if got := roffLine(in); got != want {
+			t.Errorf("roffLine(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
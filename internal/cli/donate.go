@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/server"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+)
+
+// cpuSampleInterval is how long the CPU is watched before each donated lease
+const cpuSampleInterval = time.Second
+
+// createDonateCommand creates the donate subcommand, which lends idle capacity to
+// shared keyspaces
+func (app *Application) createDonateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "donate",
+		Short:       "Donate idle CPU to the shared keyspaces of a serve coordinator",
+		Annotations: map[string]string{unhardenedAnnotation: "true"},
+		Long: `Lend idle capacity to the shared keyspaces of a "bloco-eth serve" coordinator,
+such as the search pool of a team.
+
+The donor runs at the lowest CPU priority, lists the keyspaces created with
+"shared": true, and searches leases of the oldest one through the same lease
+protocol as "bloco-eth agent", moving on when it is finished or fully leased.
+Before each lease it waits while other programs keep more than --max-cpu-busy
+percent of the CPU busy (Linux only).
+
+Only public results leave the machine: the addresses found are reported to
+the coordinator, and their private keys are discarded, never printed or saved.
+The keyspace owner finds each key again by searching the lease range the
+coordinator records for its address with --key-range. The donor runs until it
+is stopped with Ctrl+C.`,
+		Example: `  curl -X POST coordinator:8080/keyspaces -H 'Content-Type: application/json' -d '{"prefix":"abcdef","shared":true}'
+  bloco-eth donate --server http://coordinator:8080 --threads 2`,
+		Args: cobra.NoArgs,
+		RunE: app.runDonate,
+	}
+
+	cmd.Flags().String("server", "http://127.0.0.1:8080", "Base URL of the serve coordinator")
+	cmd.Flags().String("api-key", "", "API key for coordinators started with --api-keys (default $BLOCO_API_KEY)")
+	cmd.Flags().String("agent-name", "", "Name reported with leases (default: the host name)")
+	cmd.Flags().Float64("max-cpu-busy", 50, "Wait before each lease while other programs keep more than this percentage of the CPU busy (0 = never wait)")
+
+	return cmd
+}
+
+// runDonate searches leases of shared keyspaces until it is stopped
+func (app *Application) runDonate(cmd *cobra.Command, args []string) error {
+	if err := app.parseFlags(cmd); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", "failed to parse command flags")
+	}
+	maxBusy, _ := cmd.Flags().GetFloat64("max-cpu-busy")
+	if maxBusy < 0 || maxBusy > 100 {
+		return errors.NewValidationError("donate", "--max-cpu-busy must be between 0 and 100")
+	}
+	name, _ := cmd.Flags().GetString("agent-name")
+	if name == "" {
+		name, _ = os.Hostname()
+	}
+
+	quiet := app.config.CLI.QuietMode
+	if err := lowerPriority(); err != nil {
+		fmt.Fprintln(os.Stderr, i18n.T("warn.lower_priority", err))
+	}
+	defer app.finishScreening()
+
+	ctx := cmd.Context()
+	if !quiet {
+		base, _ := cmd.Flags().GetString("server")
+		fmt.Println(i18n.T("donate.started", app.config.Worker.ThreadCount, base))
+	}
+
+	// Stopping is the way a donation ends, not a failure
+	for ctx.Err() == nil {
+		var shared []server.Keyspace
+		if _, err := serveRequest(ctx, cmd, http.MethodGet, "/keyspaces?shared=true", "list_keyspaces", nil, &shared); err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return err
+		}
+
+		searched := false
+		for _, ks := range shared {
+			worked, err := app.donateTo(ctx, cmd, name, ks, maxBusy)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			// Start over from the oldest keyspace after each one searched
+			if worked {
+				searched = true
+				break
+			}
+		}
+		if searched {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(leaseRetryDelay):
+		}
+	}
+	return nil
+}
+
+// donateTo searches leases of one shared keyspace until it is finished or every key
+// is leased, reporting whether any lease was searched
+func (app *Application) donateTo(ctx context.Context, cmd *cobra.Command, name string, ks server.Keyspace, maxBusy float64) (bool, error) {
+	path := "/keyspaces/" + url.PathEscape(ks.ID)
+	criteria := ks.Request.Criteria()
+
+	var pool *worker.Pool
+	defer func() {
+		if pool == nil {
+			return
+		}
+		if err := pool.Shutdown(); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("warn.pool_shutdown", err))
+		}
+	}()
+
+	for {
+		if err := app.waitForIdleCPU(ctx, maxBusy); err != nil {
+			return pool != nil, err
+		}
+		var lease server.Lease
+		status, err := serveRequest(ctx, cmd, http.MethodPost, path+"/leases", "acquire_lease",
+			map[string]string{"agent": name}, &lease)
+		switch {
+		case status == http.StatusGone || status == http.StatusConflict:
+			return pool != nil, nil
+		case err != nil:
+			return pool != nil, err
+		}
+
+		if pool == nil {
+			if !app.config.CLI.QuietMode {
+				fmt.Println(i18n.T("donate.searching", ks.ID, criteria.GetPattern()))
+			}
+			pool = app.newWorkerPool(criteria.Network)
+			if err := pool.Start(); err != nil {
+				pool = nil
+				return false, errors.WrapError(err, errors.ErrorTypeWorker, "start_workers", "failed to start worker pool")
+			}
+		}
+		if err := app.searchLease(ctx, cmd, pool, criteria, path, lease, true); err != nil {
+			return true, err
+		}
+	}
+}
+
+// waitForIdleCPU waits while other programs keep more than maxBusy percent of the
+// CPU busy. It is called between leases, so the donor's own work is not counted.
+func (app *Application) waitForIdleCPU(ctx context.Context, maxBusy float64) error {
+	waiting := false
+	for maxBusy > 0 {
+		busy, ok := cpuBusy(ctx, cpuSampleInterval)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !ok || busy <= maxBusy {
+			return nil
+		}
+		if !waiting && !app.config.CLI.QuietMode {
+			fmt.Println(i18n.T("donate.waiting", busy))
+		}
+		waiting = true
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(leaseRetryDelay):
+		}
+	}
+	return nil
+}
+
+// cpuBusy measures the percentage of CPU time spent busy over interval, from
+// /proc/stat. It reports false where /proc/stat is not available.
+func cpuBusy(ctx context.Context, interval time.Duration) (float64, bool) {
+	busyBefore, totalBefore, ok := readCPUTimes()
+	if !ok {
+		return 0, false
+	}
+	select {
+	case <-ctx.Done():
+		return 0, false
+	case <-time.After(interval):
+	}
+	busyAfter, totalAfter, ok := readCPUTimes()
+	if !ok || totalAfter <= totalBefore {
+		return 0, false
+	}
+	return float64(busyAfter-busyBefore) * 100 / float64(totalAfter-totalBefore), true
+}
+
+// readCPUTimes returns the busy and total CPU time of the "cpu" line of /proc/stat
+func readCPUTimes() (busy, total uint64, ok bool) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return 0, 0, false
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	if !scanner.Scan() {
+		return 0, 0, false
+	}
+	fields := strings.Fields(scanner.Text())
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return 0, 0, false
+	}
+	for i, field := range fields[1:] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+		// Guest time is already counted in user time
+		if i >= 8 {
+			break
+		}
+		total += value
+		// idle and iowait
+		if i != 3 && i != 4 {
+			busy += value
+		}
+	}
+	return busy, total, true
+}
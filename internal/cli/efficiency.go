@@ -0,0 +1,257 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// raplEnergyPath is the Linux powercap interface for the package-level energy counter
+const raplEnergyPath = "/sys/class/powercap/intel-rapl:0"
+
+// efficiencyResult holds the measurement of a single thread/batch configuration
+type efficiencyResult struct {
+	Threads        int
+	BatchSize      int
+	Attempts       int64
+	Duration       time.Duration
+	Speed          float64
+	SpeedPerThread float64
+	EnergyJoules   float64
+	AddrPerJoule   float64
+}
+
+// energyMeter reads a monotonically increasing energy counter in microjoules
+type energyMeter struct {
+	energyFile string
+	maxRange   uint64
+}
+
+// newEnergyMeter returns a RAPL-backed meter, or nil when energy counters are unavailable
+func newEnergyMeter() *energyMeter {
+	meter := &energyMeter{energyFile: raplEnergyPath + "/energy_uj"}
+	if _, err := meter.read(); err != nil {
+		return nil
+	}
+
+	if data, err := os.ReadFile(raplEnergyPath + "/max_energy_range_uj"); err == nil {
+		if val, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			meter.maxRange = val
+		}
+	}
+
+	return meter
+}
+
+// read returns the current counter value in microjoules
+func (m *energyMeter) read() (uint64, error) {
+	data, err := os.ReadFile(m.energyFile)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// joulesBetween converts two counter readings into joules, handling counter wrap-around
+func (m *energyMeter) joulesBetween(start, end uint64) float64 {
+	if end >= start {
+		return float64(end-start) / 1e6
+	}
+	if m.maxRange == 0 {
+		return 0
+	}
+	return float64(m.maxRange-start+end) / 1e6
+}
+
+// sweepThreadCounts returns powers of two up to maxThreads, always including maxThreads
+func sweepThreadCounts(maxThreads int) []int {
+	if maxThreads < 1 {
+		maxThreads = 1
+	}
+
+	var counts []int
+	for threads := 1; threads < maxThreads; threads *= 2 {
+		counts = append(counts, threads)
+	}
+	return append(counts, maxThreads)
+}
+
+// sweepBatchSizes returns batch sizes from minBatch to maxBatch in steps of 10x
+func sweepBatchSizes(minBatch, maxBatch int) []int {
+	if minBatch < 1 {
+		minBatch = 1
+	}
+	if maxBatch < minBatch {
+		maxBatch = minBatch
+	}
+
+	var sizes []int
+	for batch := minBatch; batch < maxBatch; batch *= 10 {
+		sizes = append(sizes, batch)
+	}
+	return append(sizes, maxBatch)
+}
+
+// selectMostEfficient picks the configuration with the highest addr/J when energy data is
+// available, falling back to addr/s per thread. Ties are broken by raw throughput.
+func selectMostEfficient(results []efficiencyResult, useEnergy bool) (efficiencyResult, bool) {
+	if len(results) == 0 {
+		return efficiencyResult{}, false
+	}
+
+	score := func(r efficiencyResult) float64 {
+		if useEnergy {
+			return r.AddrPerJoule
+		}
+		return r.SpeedPerThread
+	}
+
+	best := results[0]
+	for _, r := range results[1:] {
+		if score(r) > score(best) || (score(r) == score(best) && r.Speed > best.Speed) {
+			best = r
+		}
+	}
+	return best, true
+}
+
+// measureEfficiency runs a single configuration of the sweep for the given duration
+func (app *Application) measureEfficiency(ctx context.Context, threads, batchSize int, duration time.Duration, meter *energyMeter) (efficiencyResult, error) {
+	workerPool := worker.NewPool(threads, "ethereum")
+	if err := workerPool.Start(); err != nil {
+		return efficiencyResult{}, errors.WrapError(err, errors.ErrorTypeWorker,
+			"measure_efficiency", "failed to start worker pool")
+	}
+	defer func() {
+		if err := workerPool.Shutdown(); err != nil {
+			fmt.Fprintln(os.Stderr, i18n.T("warn.pool_shutdown", err))
+		}
+	}()
+
+	stepCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	var startEnergy uint64
+	if meter != nil {
+		startEnergy, _ = meter.read()
+	}
+
+	startTime := time.Now()
+	attempts, err := workerPool.RunBenchmark(stepCtx, worker.WorkItem{
+		Criteria:  wallet.GenerationCriteria{},
+		BatchSize: batchSize,
+		ID:        fmt.Sprintf("efficiency-%d-%d", threads, batchSize),
+	})
+	elapsed := time.Since(startTime)
+	if err != nil {
+		return efficiencyResult{}, err
+	}
+
+	result := efficiencyResult{
+		Threads:   threads,
+		BatchSize: batchSize,
+		Attempts:  attempts,
+		Duration:  elapsed,
+	}
+
+	if elapsed > 0 {
+		result.Speed = float64(attempts) / elapsed.Seconds()
+		result.SpeedPerThread = result.Speed / float64(threads)
+	}
+
+	if meter != nil {
+		if endEnergy, err := meter.read(); err == nil {
+			result.EnergyJoules = meter.joulesBetween(startEnergy, endEnergy)
+			if result.EnergyJoules > 0 {
+				result.AddrPerJoule = float64(attempts) / result.EnergyJoules
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// runEfficiencySweep benchmarks every thread/batch combination and reports the most efficient one
+func (app *Application) runEfficiencySweep(ctx context.Context, stepDuration time.Duration) error {
+	threadCounts := sweepThreadCounts(app.config.Worker.ThreadCount)
+	batchSizes := sweepBatchSizes(app.config.Worker.MinBatchSize, app.config.Worker.MaxBatchSize)
+	meter := newEnergyMeter()
+
+	fmt.Println(i18n.T("efficiency.running"))
+	fmt.Println(i18n.T("efficiency.thread_counts", threadCounts))
+	fmt.Println(i18n.T("efficiency.batch_sizes", batchSizes))
+	fmt.Println(i18n.T("efficiency.step_duration", stepDuration))
+	if meter != nil {
+		fmt.Printf("%s\n\n", i18n.T("efficiency.energy_rapl", raplEnergyPath))
+	} else {
+		fmt.Printf("%s\n\n", i18n.T("efficiency.energy_none"))
+	}
+
+	var results []efficiencyResult
+	for _, threads := range threadCounts {
+		for _, batchSize := range batchSizes {
+			if ctx.Err() != nil {
+				return errors.NewCancellationError("efficiency_sweep", "efficiency sweep cancelled")
+			}
+
+			result, err := app.measureEfficiency(ctx, threads, batchSize, stepDuration, meter)
+			if err != nil {
+				return errors.WrapError(err, errors.ErrorTypeGeneration,
+					"efficiency_sweep", fmt.Sprintf("benchmark failed for %d threads, batch %d", threads, batchSize))
+			}
+
+			if meter != nil {
+				fmt.Println(i18n.T("efficiency.row_energy",
+					result.Threads, result.BatchSize, result.Speed, result.SpeedPerThread, result.AddrPerJoule))
+			} else {
+				fmt.Println(i18n.T("efficiency.row",
+					result.Threads, result.BatchSize, result.Speed, result.SpeedPerThread))
+			}
+			results = append(results, result)
+		}
+	}
+
+	best, ok := selectMostEfficient(results, meter != nil)
+	if !ok {
+		return errors.NewGenerationError("efficiency_sweep", "no configurations were measured", nil)
+	}
+	fastest, _ := selectFastest(results)
+
+	fmt.Printf("\n%s\n", i18n.T("efficiency.best"))
+	printRule()
+	fmt.Println(i18n.T("bench.threads", best.Threads))
+	fmt.Println(i18n.T("efficiency.batch_size", best.BatchSize))
+	fmt.Println(i18n.T("efficiency.speed", best.Speed, best.SpeedPerThread))
+	if meter != nil {
+		fmt.Println(i18n.T("efficiency.energy", best.AddrPerJoule))
+	}
+
+	if fastest.Threads != best.Threads || fastest.BatchSize != best.BatchSize {
+		fmt.Printf("\n%s\n", i18n.T("efficiency.fastest", fastest.Threads, fastest.BatchSize, fastest.Speed))
+	}
+
+	return nil
+}
+
+// selectFastest picks the configuration with the highest raw throughput
+func selectFastest(results []efficiencyResult) (efficiencyResult, bool) {
+	if len(results) == 0 {
+		return efficiencyResult{}, false
+	}
+
+	best := results[0]
+	for _, r := range results[1:] {
+		if r.Speed > best.Speed {
+			best = r
+		}
+	}
+	return best, true
+}
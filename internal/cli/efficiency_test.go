@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSweepThreadCounts(t *testing.T) {
+	tests := []struct {
+		maxThreads int
+		expected   []int
+	}{
+		{maxThreads: 0, expected: []int{1}},
+		{maxThreads: 1, expected: []int{1}},
+		{maxThreads: 4, expected: []int{1, 2, 4}},
+		{maxThreads: 6, expected: []int{1, 2, 4, 6}},
+	}
+
+	for _, tt := range tests {
+		if got := sweepThreadCounts(tt.maxThreads); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("sweepThreadCounts(%d) = %v, expected %v", tt.maxThreads, got, tt.expected)
+		}
+	}
+}
+
+func TestSweepBatchSizes(t *testing.T) {
+	tests := []struct {
+		minBatch, maxBatch int
+		expected           []int
+	}{
+		{minBatch: 100, maxBatch: 10000, expected: []int{100, 1000, 10000}},
+		{minBatch: 100, maxBatch: 5000, expected: []int{100, 1000, 5000}},
+		{minBatch: 500, maxBatch: 100, expected: []int{500}},
+	}
+
+	for _, tt := range tests {
+		if got := sweepBatchSizes(tt.minBatch, tt.maxBatch); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("sweepBatchSizes(%d, %d) = %v, expected %v", tt.minBatch, tt.maxBatch, got, tt.expected)
+		}
+	}
+}
+
+func TestSelectMostEfficient(t *testing.T) {
+	results := []efficiencyResult{
+		{Threads: 1, BatchSize: 100, Speed: 1000, SpeedPerThread: 1000, AddrPerJoule: 50},
+		{Threads: 4, BatchSize: 100, Speed: 3000, SpeedPerThread: 750, AddrPerJoule: 80},
+		{Threads: 1, BatchSize: 1000, Speed: 1100, SpeedPerThread: 1100, AddrPerJoule: 60},
+	}
+
+	best, ok := selectMostEfficient(results, false)
+	if !ok || best.Threads != 1 || best.BatchSize != 1000 {
+		t.Errorf("expected 1 thread/batch 1000 by addr/s per thread, got %+v", best)
+	}
+
+	best, ok = selectMostEfficient(results, true)
+	if !ok || best.Threads != 4 {
+		t.Errorf("expected 4 threads by addr/J, got %+v", best)
+	}
+
+	if _, ok := selectMostEfficient(nil, false); ok {
+		t.Error("expected no result for empty input")
+	}
+}
+
+func TestEnergyMeterWrapAround(t *testing.T) {
+	meter := &energyMeter{maxRange: 1000000}
+
+	if got := meter.joulesBetween(100, 2000100); got != 2 {
+		t.Errorf("joulesBetween() = %v, expected 2", got)
+	}
+	if got := meter.joulesBetween(900000, 100000); got != 0.2 {
+		t.Errorf("joulesBetween() with wrap = %v, expected 0.2", got)
+	}
+}
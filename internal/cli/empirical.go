@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	mrand "math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/wallet"
+)
+
+const (
+	// empiricalMaxZScore is how many standard deviations the observed match count may
+	// drift from the expected count before it is reported as a discrepancy
+	empiricalMaxZScore = 4.0
+	// empiricalMinExpected is the expected match count needed for a meaningful comparison
+	empiricalMinExpected = 25.0
+)
+
+// empiricalResult compares an observed match rate against the theoretical difficulty
+type empiricalResult struct {
+	Samples     int64
+	Matches     int64
+	Difficulty  float64
+	Expected    float64
+	ZScore      float64
+	Duration    time.Duration
+	Discrepancy bool
+	TooFew      bool
+}
+
+// ObservedDifficulty is the difficulty implied by the observed match rate
+func (r empiricalResult) ObservedDifficulty() float64 {
+	if r.Matches == 0 {
+		return math.Inf(1)
+	}
+	return float64(r.Samples) / float64(r.Matches)
+}
+
+// parseEmpiricalSamples reads --samples, which accepts scientific notation such as 1e7
+func parseEmpiricalSamples(cmd *cobra.Command) (int64, error) {
+	samples, _ := cmd.Flags().GetFloat64("samples")
+	if samples < 1 || samples > math.MaxInt64/2 || samples != math.Trunc(samples) {
+		return 0, errors.NewValidationError("parse_flags", fmt.Sprintf("--samples must be a positive whole number, got %g", samples))
+	}
+	return int64(samples), nil
+}
+
+// sampleMatches checks samples random Ethereum addresses against match across workers
+// and returns how many matched and how many were checked before ctx was cancelled
+func sampleMatches(ctx context.Context, samples int64, workers int, match func(string) bool) (matches, checked int64) {
+	if workers < 1 {
+		workers = 1
+	}
+	var matched, done atomic.Int64
+	var next atomic.Int64
+	const chunk = 4096
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Sample addresses only need to be uniform, not secret, so a seeded ChaCha8 is enough
+			var seed [32]byte
+			_, _ = rand.Read(seed[:])
+			rng := mrand.NewChaCha8(seed)
+
+			var raw [20]byte
+			var address [40]byte
+			for ctx.Err() == nil {
+				start := next.Add(chunk) - chunk
+				if start >= samples {
+					return
+				}
+				n := min(int64(chunk), samples-start)
+				var found int64
+				for j := int64(0); j < n; j++ {
+					_, _ = rng.Read(raw[:])
+					hex.Encode(address[:], raw[:])
+					if match(string(address[:])) {
+						found++
+					}
+				}
+				matched.Add(found)
+				done.Add(n)
+			}
+		}()
+	}
+	wg.Wait()
+	return matched.Load(), done.Load()
+}
+
+// evaluateEmpirical compares matches out of samples with the theoretical difficulty
+func evaluateEmpirical(matches, samples int64, difficulty float64) empiricalResult {
+	p := 1 / difficulty
+	expected := float64(samples) * p
+	result := empiricalResult{
+		Samples:    samples,
+		Matches:    matches,
+		Difficulty: difficulty,
+		Expected:   expected,
+		TooFew:     expected < empiricalMinExpected,
+	}
+	if stddev := math.Sqrt(expected * (1 - p)); stddev > 0 {
+		result.ZScore = (float64(matches) - expected) / stddev
+	}
+	// Matches far beyond the expectation are a bug even when the sample is small
+	result.Discrepancy = math.Abs(result.ZScore) > empiricalMaxZScore && (!result.TooFew || result.ZScore > 0)
+	return result
+}
+
+// runEmpiricalStats measures the matcher's real match rate for criteria and flags
+// disagreement with the theoretical difficulty
+func (app *Application) runEmpiricalStats(cmd *cobra.Command, criteria wallet.GenerationCriteria, difficulty float64) error {
+	if criteria.Network != "" && criteria.Network != "ethereum" {
+		return errors.NewValidationError("show_stats", "--empirical supports only the ethereum network")
+	}
+	if criteria.Prefix == "" && criteria.Suffix == "" {
+		return errors.NewValidationError("show_stats", "--empirical requires --prefix or --suffix")
+	}
+	samples, err := parseEmpiricalSamples(cmd)
+	if err != nil {
+		return err
+	}
+
+	threads := app.config.Worker.ThreadCount
+	fmt.Printf("\n%s\n", i18n.T("empirical.title"))
+	fmt.Println("  " + i18n.T("empirical.sampling", formatLargeNumber(samples), threads))
+
+	start := time.Now()
+	matcher := worker.NewMatcher(criteria)
+	matches, checked := sampleMatches(cmd.Context(), samples, threads, matcher.Matches)
+	if checked < samples {
+		return errors.NewGenerationError("show_stats",
+			fmt.Sprintf("sampling interrupted after %s of %s addresses", formatLargeNumber(checked), formatLargeNumber(samples)), cmd.Context().Err())
+	}
+	result := evaluateEmpirical(matches, samples, difficulty)
+	result.Duration = time.Since(start)
+
+	fmt.Println("  " + i18n.T("empirical.matches",
+		formatLargeNumber(result.Matches), result.Expected, formatLargeNumber(int64(difficulty))))
+	if result.Matches > 0 {
+		fmt.Println("  " + i18n.T("empirical.observed", formatLargeNumber(int64(math.Round(result.ObservedDifficulty())))))
+	}
+	fmt.Println("  " + i18n.T("empirical.deviation",
+		result.ZScore, utils.FormatSpeed(float64(samples)/result.Duration.Seconds()), formatDuration(result.Duration)))
+
+	if result.TooFew {
+		fmt.Println("  " + icon("⚠️ ") + i18n.T("empirical.too_few", result.Expected, math.Ceil(empiricalMinExpected*difficulty)))
+	}
+	if result.Discrepancy {
+		return errors.NewValidationError("show_stats",
+			fmt.Sprintf("observed match rate differs from the theoretical difficulty by %.1f standard deviations; the matcher or difficulty calculation may be wrong", result.ZScore))
+	}
+	if !result.TooFew {
+		fmt.Println("  " + icon("✅") + i18n.T("empirical.consistent"))
+	}
+	return nil
+}
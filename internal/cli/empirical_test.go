@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestEmpiricalMatchesDifficulty(t *testing.T) {
+	criteria := wallet.GenerationCriteria{Network: "ethereum", Prefix: "ab", IsChecksum: true}
+	match := func(address string) bool { return worker.MatchesCriteria(address, criteria) }
+
+	const samples = 200000
+	matches, checked := sampleMatches(context.Background(), samples, 2, match)
+	if checked != samples {
+		t.Fatalf("checked %d addresses, want %d", checked, samples)
+	}
+
+	difficulty := vanitymath.Difficulty(criteria.Prefix, criteria.Suffix, criteria.IsCaseSensitive())
+	if result := evaluateEmpirical(matches, samples, difficulty); result.Discrepancy || result.TooFew {
+		t.Errorf("matcher disagrees with difficulty %v: %+v", difficulty, result)
+	}
+
+	// Applying the case factor to a case-insensitive matcher must be caught
+	if result := evaluateEmpirical(matches, samples, difficulty*4); !result.Discrepancy {
+		t.Errorf("expected a discrepancy against a 4x difficulty: %+v", result)
+	}
+}
+
+func TestEvaluateEmpiricalSmallSample(t *testing.T) {
+	// Zero matches when 0.5 were expected is normal
+	if result := evaluateEmpirical(0, 1000, 2000); result.Discrepancy || !result.TooFew {
+		t.Errorf("small sample misreported: %+v", result)
+	}
+	// Far too many matches is a bug at any sample size
+	if result := evaluateEmpirical(50, 1000, 2000); !result.Discrepancy {
+		t.Errorf("expected a discrepancy: %+v", result)
+	}
+}
+
+func TestSampleMatchesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, checked := sampleMatches(ctx, 1000000, 2, func(string) bool { return false }); checked != 0 {
+		t.Errorf("checked %d addresses after cancellation", checked)
+	}
+}
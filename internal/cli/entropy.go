@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+)
+
+const (
+	// maxUserEntropy bounds user entropy read from a file or stdin
+	maxUserEntropy = 1 << 20
+	// userEntropyTarget is how many estimated bits the interactive prompt asks for
+	userEntropyTarget = 256
+	// keyboardBitsPerChar is the entropy credited to each mashed keystroke
+	keyboardBitsPerChar = 1.0
+)
+
+// configureEntropy selects the --entropy source for key generation and runs the
+// startup health tests on it. Sources are os, hybrid (OS RNG mixed with user
+// entropy) and file:<path>; --extra-entropy-file mixes user entropy into any of them.
+func (app *Application) configureEntropy(cmd *cobra.Command) error {
+	if app.entropyReady {
+		return nil
+	}
+	spec, _ := cmd.Flags().GetString("entropy")
+	extraPath, _ := cmd.Flags().GetString("extra-entropy-file")
+
+	var (
+		name = spec
+		base io.Reader
+		err  error
+	)
+	switch {
+	case spec == "" || spec == "os":
+		name = "os"
+		base, err = crypto.NewHealthCheckedSource(rand.Reader)
+	case spec == "hybrid":
+		base, err = crypto.NewHealthCheckedSource(rand.Reader)
+	case strings.HasPrefix(spec, "file:"):
+		path := strings.TrimPrefix(spec, "file:")
+		if path == "" {
+			return errors.NewValidationError("parse_flags", "--entropy file: needs a path, e.g. file:/dev/hwrng")
+		}
+		file, openErr := crypto.NewFileEntropySource(path)
+		if openErr != nil {
+			return errors.WrapError(openErr, errors.ErrorTypeConfiguration, "parse_flags", "invalid --entropy source")
+		}
+		app.entropyFile = file
+		base, err = crypto.NewHealthCheckedSource(file)
+	default:
+		return errors.NewValidationError("parse_flags",
+			fmt.Sprintf("invalid --entropy %q; use os, hybrid or file:<path>", spec))
+	}
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeCrypto, "entropy_health", "entropy source rejected").
+			WithContext("source", name)
+	}
+
+	source := base
+	if spec == "hybrid" || extraPath != "" {
+		userEntropy, err := readUserEntropy(cmd.InOrStdin(), extraPath)
+		if err != nil {
+			return err
+		}
+		source, err = crypto.NewHybridEntropySource(base, userEntropy)
+		crypto.ClearSensitiveData(userEntropy)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeValidation, "parse_flags", "invalid user entropy")
+		}
+		if spec != "hybrid" {
+			name += "+extra"
+		}
+	}
+
+	crypto.SetEntropySource(name, source)
+	app.entropyReady = true
+	return nil
+}
+
+// readUserEntropy reads the entropy mixed into key generation from --extra-entropy-file,
+// from stdin when it is piped, or from the interactive prompt on a terminal
+func readUserEntropy(stdin io.Reader, path string) ([]byte, error) {
+	var (
+		userEntropy []byte
+		err         error
+	)
+	switch file, ok := stdin.(*os.File); {
+	case path != "":
+		if userEntropy, err = readLimited(path); err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "failed to read --extra-entropy-file")
+		}
+	case ok && term.IsTerminal(int(file.Fd())):
+		return promptUserEntropy(stdin, os.Stderr)
+	default:
+		if userEntropy, err = io.ReadAll(io.LimitReader(stdin, maxUserEntropy)); err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "failed to read user entropy from stdin")
+		}
+	}
+	if len(userEntropy) == 0 {
+		return nil, errors.NewValidationError("parse_flags", "no user entropy given; the extra entropy input is empty")
+	}
+	return userEntropy, nil
+}
+
+// readLimited reads a file of at most maxUserEntropy bytes
+func readLimited(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxUserEntropy+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxUserEntropy {
+		return nil, fmt.Errorf("%s is larger than %d bytes", path, maxUserEntropy)
+	}
+	return data, nil
+}
+
+// promptUserEntropy collects dice rolls or keyboard mashing line by line until the
+// estimate reaches userEntropyTarget bits or the user enters an empty line. The
+// arrival time of each line is mixed in as well.
+func promptUserEntropy(in io.Reader, out io.Writer) ([]byte, error) {
+	fmt.Fprintf(out, "Enter dice rolls (1-6) or mash the keyboard, then press Enter.\n")
+	fmt.Fprintf(out, "Continue until %d bits are collected, or enter an empty line to finish early.\n", userEntropyTarget)
+
+	reader := bufio.NewReader(in)
+	var (
+		collected []byte
+		bits      float64
+	)
+	for bits < userEntropyTarget {
+		fmt.Fprintf(out, "[%3.0f/%d bits] > ", bits, userEntropyTarget)
+		line, err := reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if err != nil && err != io.EOF {
+				return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "failed to read user entropy")
+			}
+			break
+		}
+		collected = append(collected, line...)
+		collected = binary.BigEndian.AppendUint64(collected, uint64(time.Now().UnixNano()))
+		bits += estimateEntropyBits(line)
+		if err != nil {
+			break
+		}
+	}
+
+	if len(collected) == 0 {
+		return nil, errors.NewValidationError("parse_flags", "no user entropy entered")
+	}
+	if bits < userEntropyTarget {
+		fmt.Fprintf(out, "Warning: only about %.0f bits of user entropy; keys still rely on the OS RNG for the rest.\n", bits)
+	}
+	return collected, nil
+}
+
+// estimateEntropyBits gives a conservative estimate of the entropy in a line of input:
+// log2(6) bits per die roll for lines of dice digits, otherwise one bit per keystroke
+func estimateEntropyBits(line string) float64 {
+	rolls := 0
+	for _, r := range line {
+		switch {
+		case r >= '1' && r <= '6':
+			rolls++
+		case r == ' ' || r == ',' || r == '\t':
+		default:
+			return float64(utf8.RuneCountInString(line)) * keyboardBitsPerChar
+		}
+	}
+	return float64(rolls) * math.Log2(6)
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"io"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEstimateEntropyBits(t *testing.T) {
+	tests := []struct {
+		line string
+		want float64
+	}{
+		{"1 2 3 4 5 6", 6 * math.Log2(6)},
+		{"3,3,1", 3 * math.Log2(6)},
+		{"qwerty", 6 * keyboardBitsPerChar},
+		{"1 2 7", 5 * keyboardBitsPerChar},
+	}
+	for _, tt := range tests {
+		if got := estimateEntropyBits(tt.line); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("estimateEntropyBits(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestPromptUserEntropy(t *testing.T) {
+	// 100 rolls carry about 258 bits, so the prompt stops before the trailing line
+	rolls := strings.Repeat("4", 100)
+	entropy, err := promptUserEntropy(strings.NewReader(rolls+"\nunread\n"), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(entropy), rolls) || strings.Contains(string(entropy), "unread") {
+		t.Error("prompt should stop reading once the target is reached")
+	}
+
+	entropy, err = promptUserEntropy(strings.NewReader("asdf\n\nignored\n"), io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(entropy), "asdf") || strings.Contains(string(entropy), "ignored") {
+		t.Error("an empty line should finish the prompt early")
+	}
+	if len(entropy) != len("asdf")+8 {
+		t.Errorf("each line should be followed by its 8-byte arrival time, got %d bytes", len(entropy))
+	}
+
+	if _, err := promptUserEntropy(strings.NewReader("\n"), io.Discard); err == nil {
+		t.Error("expected an error when no entropy is entered")
+	}
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+)
+
+// Exit codes of the bloco-eth binary
+const (
+	ExitSuccess       = 0 // every requested wallet was found
+	ExitPartial       = 2 // --timeout, --until-probability or the end of --key-range stopped the run early
+	ExitCancelled     = 3 // interrupted by a signal
+	ExitConfiguration = 4 // invalid flags, configuration or input
+	ExitInternal      = 5 // any other failure
+)
+
+// ExitCode maps the error returned by a command to its exit code
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+	if stderrors.Is(err, context.Canceled) {
+		return ExitCancelled
+	}
+	var blocoErr *errors.BlocoError
+	if stderrors.As(err, &blocoErr) {
+		switch blocoErr.Type {
+		case errors.ErrorTypeTimeout:
+			return ExitPartial
+		case errors.ErrorTypeCancellation:
+			return ExitCancelled
+		case errors.ErrorTypeValidation, errors.ErrorTypeConfiguration:
+			return ExitConfiguration
+		}
+	}
+	return ExitInternal
+}
+
+// flagError marks cobra's unknown or malformed flag errors as configuration errors
+func flagError(cmd *cobra.Command, err error) error {
+	return errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "invalid command line")
+}
+
+// generationOutcome replaces err when the run stopped before finding count wallets:
+// a signal cancels it, while --timeout and --until-probability mark it partial unless
+// --fail-on-timeout=false accepts the wallets found so far
+func (app *Application) generationOutcome(ctx, genCtx context.Context, budget *attemptBudget, found, count int, err error) error {
+	if found >= count {
+		return err
+	}
+	if ctx.Err() != nil {
+		return errors.NewCancellationError("generate_wallet", fmt.Sprintf("cancelled after finding %d of %d wallet(s)", found, count))
+	}
+
+	// limit names what stopped the run in errors; shown names it in the output language
+	var limit, shown string
+	switch {
+	case budget != nil && budget.exhausted.Load():
+		limit = fmt.Sprintf("the %.4g%% probability budget", budget.probability)
+		shown = i18n.T("exit.limit_budget", budget.probability)
+	case app.keyRange != nil && app.keyRange.cursor.Exhausted():
+		limit = "the end of --key-range"
+		shown = i18n.T("exit.limit_key_range")
+	case stderrors.Is(genCtx.Err(), context.DeadlineExceeded):
+		limit = fmt.Sprintf("--timeout %s", app.timeout)
+		shown = limit
+	default:
+		return err
+	}
+	if !app.failOnTimeout && found > 0 {
+		if !app.config.CLI.QuietMode {
+			fmt.Fprintln(os.Stderr, i18n.T("exit.accepted_partial", shown, found, count))
+		}
+		return nil
+	}
+	return errors.NewBlocoError(errors.ErrorTypeTimeout, "generate_wallet",
+		fmt.Sprintf("stopped by %s after finding %d of %d wallet(s)", limit, found, count))
+}
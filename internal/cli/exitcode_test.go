@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/pkg/errors"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"success", nil, ExitSuccess},
+		{"limit", errors.NewBlocoError(errors.ErrorTypeTimeout, "generate_wallet", "stopped"), ExitPartial},
+		{"cancelled", errors.NewCancellationError("generate_wallet", "cancelled"), ExitCancelled},
+		{"context cancelled", fmt.Errorf("generate: %w", context.Canceled), ExitCancelled},
+		{"validation", errors.NewValidationError("parse_flags", "bad prefix"), ExitConfiguration},
+		{"wrapped configuration", errors.WrapError(stderrors.New("bad"), errors.ErrorTypeConfiguration, "parse_flags", "bad flags"), ExitConfiguration},
+		{"generation", errors.NewGenerationError("generate_wallet", "failed", nil), ExitInternal},
+		{"plain", stderrors.New("boom"), ExitInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerationOutcome(t *testing.T) {
+	app := &Application{config: config.DefaultConfig(), timeout: time.Millisecond, failOnTimeout: true}
+	app.config.CLI.QuietMode = true
+	ctx := context.Background()
+	expired, cancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+
+	if err := app.generationOutcome(ctx, expired, nil, 3, 3, nil); err != nil {
+		t.Errorf("all wallets found should succeed, got %v", err)
+	}
+	if code := ExitCode(app.generationOutcome(ctx, expired, nil, 1, 3, nil)); code != ExitPartial {
+		t.Errorf("timeout with partial results: exit code %d, want %d", code, ExitPartial)
+	}
+	budget := &attemptBudget{probability: 50}
+	budget.exhausted.Store(true)
+	if code := ExitCode(app.generationOutcome(ctx, ctx, budget, 0, 1, errors.NewGenerationError("generate_wallet", "budget", nil))); code != ExitPartial {
+		t.Errorf("exhausted budget: exit code %d, want %d", code, ExitPartial)
+	}
+
+	cancelled, cancelRun := context.WithCancel(ctx)
+	cancelRun()
+	if code := ExitCode(app.generationOutcome(cancelled, cancelled, nil, 1, 3, nil)); code != ExitCancelled {
+		t.Errorf("signal: exit code %d, want %d", code, ExitCancelled)
+	}
+
+	app.failOnTimeout = false
+	if err := app.generationOutcome(ctx, expired, nil, 1, 3, nil); err != nil {
+		t.Errorf("--fail-on-timeout=false should accept partial results, got %v", err)
+	}
+	if code := ExitCode(app.generationOutcome(ctx, expired, nil, 0, 3, nil)); code != ExitPartial {
+		t.Errorf("--fail-on-timeout=false with nothing found: exit code %d, want %d", code, ExitPartial)
+	}
+}
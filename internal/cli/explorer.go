@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/tui"
+	"bloco-eth/pkg/errors"
+)
+
+// defaultEtherscanURL is the explorer of --explorer etherscan without a base URL
+const defaultEtherscanURL = "https://etherscan.io"
+
+// explorer links found addresses to a block explorer
+type explorer struct {
+	base string
+}
+
+// parseExplorer parses an --explorer value: etherscan[:<baseurl>] or
+// blockscout:<baseurl>
+func parseExplorer(spec string) (*explorer, error) {
+	kind, base, hasBase := strings.Cut(spec, ":")
+	switch strings.ToLower(kind) {
+	case "etherscan":
+		if !hasBase {
+			base = defaultEtherscanURL
+		}
+	case "blockscout":
+		if !hasBase || base == "" {
+			return nil, errors.NewValidationError("parse_flags", "--explorer blockscout needs a base URL (blockscout:<baseurl>)")
+		}
+	default:
+		return nil, errors.NewValidationError("parse_flags", "--explorer must be etherscan[:<baseurl>] or blockscout:<baseurl>")
+	}
+	u, err := url.Parse(base)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.NewValidationError("parse_flags", "--explorer base URL must be an http or https URL")
+	}
+	return &explorer{base: strings.TrimRight(base, "/")}, nil
+}
+
+// URL returns the explorer page of an address on network, or "" without an
+// explorer or for networks other than Ethereum
+func (e *explorer) URL(network, address string) string {
+	if e == nil || (network != "" && !strings.EqualFold(network, "ethereum")) {
+		return ""
+	}
+	return e.base + "/address/" + address
+}
+
+// parseExplorerFlags applies --explorer; without it no links are printed
+func (app *Application) parseExplorerFlags(cmd *cobra.Command) error {
+	app.explorer = nil
+	spec, _ := cmd.Flags().GetString("explorer")
+	if spec == "" {
+		return nil
+	}
+	e, err := parseExplorer(spec)
+	if err != nil {
+		return err
+	}
+	app.explorer = e
+	return nil
+}
+
+// explorerLink returns link as an OSC 8 hyperlink when the terminal on stdout
+// renders them, and as the plain URL otherwise
+func explorerLink(link string) string {
+	if link == "" || plainOutput.Load() || !tui.SupportsHyperlinks() {
+		return link
+	}
+	return tui.Hyperlink(link, link)
+}
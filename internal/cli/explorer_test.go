@@ -0,0 +1,50 @@
+package cli
+
+import "testing"
+
+func TestParseExplorer(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr bool
+	}{
+		{spec: "etherscan", want: "https://etherscan.io/address/0xabc"},
+		{spec: "etherscan:https://sepolia.etherscan.io/", want: "https://sepolia.etherscan.io/address/0xabc"},
+		{spec: "blockscout:https://eth.blockscout.com", want: "https://eth.blockscout.com/address/0xabc"},
+		{spec: "blockscout", wantErr: true},
+		{spec: "blockscout:ftp://eth.blockscout.com", wantErr: true},
+		{spec: "etherscan:sepolia", wantErr: true},
+		{spec: "mempool", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			e, err := parseExplorer(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseExplorer(%q) succeeded, want an error", tt.spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.URL("ethereum", "0xabc"); got != tt.want {
+				t.Errorf("URL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplorerURL_OtherNetworks(t *testing.T) {
+	e, err := parseExplorer("etherscan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := e.URL("bitcoin", "bc1qabc"); got != "" {
+		t.Errorf("URL() of a bitcoin address = %q, want none", got)
+	}
+	var none *explorer
+	if got := none.URL("ethereum", "0xabc"); got != "" {
+		t.Errorf("URL() without --explorer = %q, want none", got)
+	}
+}
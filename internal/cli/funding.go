@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/chain"
+	"bloco-eth/pkg/errors"
+)
+
+// fundingConfig holds the validated --fund-* flags
+type fundingConfig struct {
+	amount      *big.Int
+	from        string
+	key         *ecdsa.PrivateKey
+	broadcast   bool
+	outPath     string
+	chainID     *big.Int
+	chainIDSet  bool
+	nonce       int64
+	maxFee      *big.Int
+	priorityFee *big.Int
+}
+
+// fundingFlags lists the flags that only apply with --fund-amount
+var fundingFlags = []string{"fund-from", "fund-key-file", "fund-broadcast", "fund-tx-out", "fund-chain-id", "fund-nonce", "fund-max-fee", "fund-priority-fee"}
+
+// parseFundingFlags reads and validates the --fund-* flags; must run after parseRPCFlags
+func (app *Application) parseFundingFlags(cmd *cobra.Command) error {
+	app.funding = nil
+	amount, _ := cmd.Flags().GetString("fund-amount")
+	if amount == "" {
+		for _, name := range fundingFlags {
+			if cmd.Flags().Changed(name) {
+				return errors.NewValidationError("parse_flags", fmt.Sprintf("--%s requires --fund-amount", name))
+			}
+		}
+		return nil
+	}
+
+	cfg := &fundingConfig{}
+	var err error
+	if cfg.amount, err = chain.ParseUnits(amount, 18); err != nil || cfg.amount.Sign() == 0 {
+		return errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --fund-amount %q: must be a positive ETH amount", amount))
+	}
+
+	cfg.from, _ = cmd.Flags().GetString("fund-from")
+	if cfg.from != "" && !common.IsHexAddress(cfg.from) {
+		return errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --fund-from address %q", cfg.from))
+	}
+	if keyFile, _ := cmd.Flags().GetString("fund-key-file"); keyFile != "" {
+		if cfg.key, err = readHotWalletKey(keyFile); err != nil {
+			return err
+		}
+		sender := ethcrypto.PubkeyToAddress(cfg.key.PublicKey).Hex()
+		if cfg.from != "" && !strings.EqualFold(cfg.from, sender) {
+			return errors.NewValidationError("parse_flags",
+				fmt.Sprintf("--fund-key-file belongs to %s, not --fund-from %s", sender, cfg.from))
+		}
+		cfg.from = sender
+	}
+	if cfg.from == "" {
+		return errors.NewValidationError("parse_flags", "--fund-amount requires --fund-from or --fund-key-file")
+	}
+
+	cfg.broadcast, _ = cmd.Flags().GetBool("fund-broadcast")
+	if cfg.broadcast && (cfg.key == nil || app.rpcClient == nil) {
+		return errors.NewValidationError("parse_flags", "--fund-broadcast requires --fund-key-file and --rpc-url")
+	}
+	cfg.outPath, _ = cmd.Flags().GetString("fund-tx-out")
+
+	chainID, _ := cmd.Flags().GetInt64("fund-chain-id")
+	if chainID <= 0 {
+		return errors.NewValidationError("parse_flags", "--fund-chain-id must be positive")
+	}
+	cfg.chainID, cfg.chainIDSet = big.NewInt(chainID), cmd.Flags().Changed("fund-chain-id")
+	cfg.nonce, _ = cmd.Flags().GetInt64("fund-nonce")
+
+	maxFee, _ := cmd.Flags().GetString("fund-max-fee")
+	priorityFee, _ := cmd.Flags().GetString("fund-priority-fee")
+	if (maxFee == "") != (priorityFee == "") {
+		return errors.NewValidationError("parse_flags", "--fund-max-fee and --fund-priority-fee must be set together")
+	}
+	if maxFee != "" {
+		if cfg.maxFee, err = chain.ParseUnits(maxFee, 9); err != nil {
+			return errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --fund-max-fee: %v", err))
+		}
+		if cfg.priorityFee, err = chain.ParseUnits(priorityFee, 9); err != nil {
+			return errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --fund-priority-fee: %v", err))
+		}
+	}
+
+	if app.rpcClient == nil && (cfg.nonce < 0 || cfg.maxFee == nil) {
+		return errors.NewValidationError("parse_flags",
+			"without --rpc-url, funding requires --fund-nonce, --fund-max-fee and --fund-priority-fee")
+	}
+
+	app.funding = cfg
+	return nil
+}
+
+// readHotWalletKey reads a hex private key from path
+func readHotWalletKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags",
+			fmt.Sprintf("failed to read --fund-key-file %s", path))
+	}
+	key, err := ethcrypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, errors.NewValidationError("parse_flags", "--fund-key-file must contain a hex private key")
+	}
+	return key, nil
+}
+
+// fundWallets builds a funding transaction for every Ethereum wallet generated in this
+// run, optionally signing and broadcasting it, and writes the transactions as JSON
+func (app *Application) fundWallets(ctx context.Context) error {
+	cfg := app.funding
+	if cfg == nil {
+		return nil
+	}
+	app.generatedMu.Lock()
+	wallets := app.generated
+	app.generatedMu.Unlock()
+
+	var recipients []string
+	for _, w := range wallets {
+		if network := strings.ToLower(w.Network); network == "" || network == "ethereum" {
+			recipients = append(recipients, w.Address)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	chainID, nonce, maxFee, priorityFee := cfg.chainID, uint64(cfg.nonce), cfg.maxFee, cfg.priorityFee
+	if app.rpcClient != nil {
+		nodeChainID, err := app.rpcClient.ChainID(ctx)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "fund_wallets", "failed to read chain ID")
+		}
+		if cfg.chainIDSet && nodeChainID.Cmp(cfg.chainID) != 0 {
+			return errors.NewValidationError("fund_wallets",
+				fmt.Sprintf("--fund-chain-id %s does not match the RPC node's chain ID %s", cfg.chainID, nodeChainID))
+		}
+		chainID = nodeChainID
+		if cfg.nonce < 0 {
+			if nonce, err = app.rpcClient.PendingNonce(ctx, cfg.from); err != nil {
+				return errors.WrapError(err, errors.ErrorTypeConfiguration, "fund_wallets", "failed to read hot wallet nonce")
+			}
+		}
+		if maxFee == nil {
+			if maxFee, priorityFee, err = app.rpcClient.SuggestFees(ctx); err != nil {
+				return errors.WrapError(err, errors.ErrorTypeConfiguration, "fund_wallets", "failed to estimate fees")
+			}
+		}
+	}
+
+	txs := make([]*chain.FundingTx, 0, len(recipients))
+	for i, to := range recipients {
+		tx, err := chain.NewFundingTx(chainID, nonce+uint64(i), cfg.from, to, cfg.amount, maxFee, priorityFee)
+		if err != nil {
+			return err
+		}
+		if cfg.key != nil {
+			if err := tx.Sign(cfg.key); err != nil {
+				return err
+			}
+		}
+		txs = append(txs, tx)
+	}
+
+	if cfg.outPath != "" || !cfg.broadcast {
+		if err := writeFundingTxs(cfg.outPath, txs); err != nil {
+			return err
+		}
+	}
+
+	if !cfg.broadcast {
+		return nil
+	}
+	for _, tx := range txs {
+		hash, err := app.rpcClient.SendRawTransaction(ctx, tx.Raw)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "fund_wallets",
+				fmt.Sprintf("failed to broadcast funding transaction to %s", tx.To))
+		}
+		if !app.config.CLI.QuietMode {
+			fmt.Printf("%sFunded %s: %s\n", icon("💸"), tx.To, hash)
+		}
+	}
+	return nil
+}
+
+// writeFundingTxs writes txs as a JSON array to path, or to stdout when path is empty
+func writeFundingTxs(path string, txs []*chain.FundingTx) error {
+	data, err := json.MarshalIndent(txs, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "fund_wallets", "failed to encode funding transactions")
+	}
+	if path == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "fund_wallets", fmt.Sprintf("failed to write %s", path))
+	}
+	fmt.Printf("Funding transactions saved to: %s (%d)\n", path, len(txs))
+	return nil
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"bloco-eth/internal/config"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// checkGolden compares got with testdata/<name>.golden, rewriting it with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run go test -run %s -update to create it)", err, t.Name())
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from %s; if the change is intended, rerun with -update\n%s", t.Name(), path, lineDiff(string(want), got))
+	}
+}
+
+// lineDiff lists the lines only in want (-) or only in got (+)
+func lineDiff(want, got string) string {
+	count := map[string]int{}
+	for _, line := range strings.Split(got, "\n") {
+		count[line]++
+	}
+	var diff strings.Builder
+	for _, line := range strings.Split(want, "\n") {
+		if count[line] > 0 {
+			count[line]--
+		} else {
+			fmt.Fprintf(&diff, "- %s\n", line)
+		}
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if count[line] > 0 {
+			count[line]--
+			fmt.Fprintf(&diff, "+ %s\n", line)
+		}
+	}
+	return diff.String()
+}
+
+// TestGoldenCommandTree pins every command and the names, types and defaults of
+// its flags, so a flag cannot be renamed, dropped or given a new default unnoticed
+func TestGoldenCommandTree(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Worker.ThreadCount = 0 // machine dependent
+	app := NewApplication(cfg, "dev", "unknown", "unknown")
+
+	var out strings.Builder
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		fmt.Fprintf(&out, "%s\n", cmd.CommandPath())
+		cmd.NonInheritedFlags().VisitAll(func(f *pflag.Flag) {
+			name := "--" + f.Name
+			if f.Shorthand != "" {
+				name += ", -" + f.Shorthand
+			}
+			hidden := ""
+			if f.Hidden {
+				hidden = " (hidden)"
+			}
+			fmt.Fprintf(&out, "  %s %s = %q%s\n", name, f.Value.Type(), f.DefValue, hidden)
+		})
+		for _, sub := range cmd.Commands() {
+			walk(sub)
+		}
+	}
+	walk(app.GetRootCommand())
+	checkGolden(t, "command_tree", out.String())
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/harden"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+)
+
+// unhardenedAnnotation marks commands that reach the network or run other programs,
+// which --harden forbids
+const unhardenedAnnotation = "unhardened"
+
+// applyHardening locks the process down for --harden once the options it cannot
+// work with are ruled out
+func (app *Application) applyHardening(cmd *cobra.Command) error {
+	if enabled, _ := cmd.Flags().GetBool("harden"); !enabled {
+		return nil
+	}
+	if err := app.hardenConflict(cmd); err != nil {
+		return err
+	}
+
+	report, err := harden.Apply()
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "harden", "failed to harden the process")
+	}
+	if app.auditTrail != nil {
+		app.audit("process_hardened", map[string]string{
+			"applied": strings.Join(report.Applied, "; "),
+			"skipped": strings.Join(report.Skipped, "; "),
+		})
+	}
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		return nil
+	}
+	for _, measure := range report.Applied {
+		fmt.Fprintln(os.Stderr, icon("🔒")+i18n.T("harden.applied", measure))
+	}
+	for _, measure := range report.Skipped {
+		fmt.Fprintln(os.Stderr, icon("⚠️ ")+i18n.T("harden.skipped", measure))
+	}
+	return nil
+}
+
+// hardenConflict reports the first option of cmd that --harden would break
+func (app *Application) hardenConflict(cmd *cobra.Command) error {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[unhardenedAnnotation] != "" {
+			return errors.NewValidationError("harden", fmt.Sprintf("%q reaches the network or runs other programs and cannot be used with --harden", c.CommandPath()))
+		}
+	}
+	for _, name := range networkFlags {
+		if cmd.Flags().Changed(name) {
+			return errors.NewValidationError("harden", fmt.Sprintf("--%s reaches the network and cannot be used with --harden", name))
+		}
+	}
+	if app.tracer != nil {
+		return errors.NewValidationError("harden", "trace export reaches the network and cannot be used with --harden; unset OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+
+	protection := app.config.KeyStore.PasswordProtection
+	if cmd.Flags().Changed("password-protection") {
+		protection, _ = cmd.Flags().GetString("password-protection")
+	}
+	hardware, _ := cmd.Flags().GetString("hardware")
+	checkpointKey, _ := cmd.Flags().GetString("checkpoint-key")
+	switch {
+	case protection != "" && protection != "none":
+		return errors.NewValidationError("harden", "--password-protection runs gpg or age and cannot be used with --harden")
+	case hardware == "yubikey-piv":
+		return errors.NewValidationError("harden", "--hardware yubikey-piv runs ykman and cannot be used with --harden")
+	case checkpointKey == "keyring":
+		return errors.NewValidationError("harden", "--checkpoint-key keyring runs the keyring tool and cannot be used with --harden; use file:<path>")
+	}
+	return nil
+}
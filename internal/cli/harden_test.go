@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+)
+
+// Only refused combinations are run: a test that got past the checks would
+// harden the test binary itself
+func TestHardenConflicts(t *testing.T) {
+	tests := map[string][]string{
+		"serve":                  {"--harden", "serve"},
+		"jobs":                   {"--harden", "jobs", "list"},
+		"--rpc-url":              {"--harden", "--prefix", "ab", "--rpc-url", "http://127.0.0.1:8545"},
+		"--publish":              {"--harden", "--prefix", "ab", "--publish", "nats://127.0.0.1:4222/found"},
+		"--password-protection":  {"--harden", "--prefix", "ab", "--password-protection", "gpg:ops@example.com"},
+		"--hardware yubikey-piv": {"--harden", "--prefix", "ab", "--hardware", "yubikey-piv"},
+		"--checkpoint-key":       {"--harden", "--key-range", "0x1:0xff", "--checkpoint-key", "keyring"},
+	}
+	for want, args := range tests {
+		t.Run(want, func(t *testing.T) {
+			app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+			root := app.GetRootCommand()
+			root.SetArgs(args)
+			root.SetOut(&strings.Builder{})
+			root.SetErr(&strings.Builder{})
+			err := root.Execute()
+			if ExitCode(err) != ExitConfiguration || !strings.Contains(err.Error(), "--harden") {
+				t.Fatalf("Execute(%q) = %v, want a usage error naming --harden", args, err)
+			}
+		})
+	}
+}
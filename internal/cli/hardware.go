@@ -0,0 +1,405 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/hardware"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// hardwareConfig holds the validated --hardware flags
+type hardwareConfig struct {
+	backend  string
+	dir      string
+	password []byte
+	yubikey  hardware.YubiKeyOptions
+	slot     string
+	// The TPM device and the YubiKey serve one client at a time
+	mu sync.Mutex
+}
+
+// yubikeyFlags lists the flags that only apply with --hardware yubikey-piv
+var yubikeyFlags = []string{"yubikey-slot", "yubikey-serial", "yubikey-pin-file", "yubikey-management-key-file"}
+
+// parseHardwareFlags reads and validates the --hardware flags. Keys held by hardware
+// replace keystores, so keystore output is turned off.
+func (app *Application) parseHardwareFlags(cmd *cobra.Command) error {
+	app.hardware = nil
+	backend, _ := cmd.Flags().GetString("hardware")
+	if backend != hardware.BackendYubiKeyPIV {
+		for _, name := range yubikeyFlags {
+			if cmd.Flags().Changed(name) {
+				return errors.NewValidationError("parse_flags", fmt.Sprintf("--%s requires --hardware yubikey-piv", name))
+			}
+		}
+	}
+	if backend == "" {
+		return nil
+	}
+	for _, name := range []string{"with-mnemonic", "slip39", "vault"} {
+		if cmd.Flags().Changed(name) {
+			return errors.NewValidationError("parse_flags",
+				fmt.Sprintf("--hardware cannot be combined with --%s; the key would leave the hardware", name))
+		}
+	}
+
+	cfg := &hardwareConfig{backend: backend}
+	cfg.dir, _ = cmd.Flags().GetString("hardware-dir")
+	if cfg.dir == "" {
+		return errors.NewValidationError("parse_flags", "--hardware-dir must not be empty")
+	}
+	var err error
+	switch backend {
+	case hardware.BackendTPM2:
+		err = cfg.parseTPMFlags(cmd)
+	case hardware.BackendYubiKeyPIV:
+		err = cfg.parseYubiKeyFlags(cmd)
+	default:
+		err = errors.NewValidationError("parse_flags", fmt.Sprintf("unsupported --hardware %q (use tpm2 or yubikey-piv)", backend))
+	}
+	if err != nil {
+		return err
+	}
+
+	app.hardware = cfg
+	app.config.KeyStore.Enabled = false
+	app.config.TUI.Enabled = false
+	return nil
+}
+
+// parseTPMFlags checks a TPM 2.0 is available and reads its password
+func (cfg *hardwareConfig) parseTPMFlags(cmd *cobra.Command) error {
+	detected := hardware.Detect()
+	if !detected.Supported {
+		return errors.NewConfigurationError("parse_flags",
+			fmt.Sprintf("--hardware tpm2 is not supported on %s (%s); see \"bloco-eth hardware capabilities\"", runtime.GOOS, detected.Notes))
+	}
+	if !detected.Available {
+		return errors.NewConfigurationError("parse_flags",
+			fmt.Sprintf("no TPM 2.0 device found (%s)", strings.Join(hardware.TPMDevices, ", ")))
+	}
+	password, err := hardwarePassword(cmd, "hardware-password-file")
+	if err != nil {
+		return err
+	}
+	cfg.password = password
+	return nil
+}
+
+// parseYubiKeyFlags checks the YubiKey and its slot can take an Ed25519 key. Each
+// key needs its own slot, and a slot that already holds a key is never overwritten.
+func (cfg *hardwareConfig) parseYubiKeyFlags(cmd *cobra.Command) error {
+	if network, _ := cmd.Flags().GetString("network"); !strings.EqualFold(network, "solana") {
+		return errors.NewValidationError("parse_flags",
+			"YubiKey PIV cannot hold secp256k1 keys; --hardware yubikey-piv needs --network solana (Ed25519, firmware 5.7+)")
+	}
+	if count, _ := cmd.Flags().GetInt("count"); count > 1 {
+		return errors.NewValidationError("parse_flags", "a PIV slot holds one key; use --count 1 with --hardware yubikey-piv")
+	}
+	if cmd.Flags().Changed("hardware-password-file") {
+		return errors.NewValidationError("parse_flags", "--hardware-password-file applies to tpm2; use --yubikey-pin-file")
+	}
+	cfg.slot, _ = cmd.Flags().GetString("yubikey-slot")
+	cfg.slot = strings.ToLower(cfg.slot)
+	if err := hardware.ValidatePIVSlot(cfg.slot); err != nil {
+		return errors.NewValidationError("parse_flags", "invalid --yubikey-slot: "+err.Error())
+	}
+
+	cfg.yubikey.Serial, _ = cmd.Flags().GetString("yubikey-serial")
+	pin, err := hardwarePassword(cmd, "yubikey-pin-file")
+	if err != nil {
+		return err
+	}
+	managementKey, err := hardwarePassword(cmd, "yubikey-management-key-file")
+	if err != nil {
+		return err
+	}
+	cfg.yubikey.PIN, cfg.yubikey.ManagementKey = string(pin), string(managementKey)
+
+	yk, err := hardware.OpenYubiKey(cfg.yubikey)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "YubiKey not available")
+	}
+	if yk.SlotHasKey(cfg.slot) {
+		return errors.NewConfigurationError("parse_flags",
+			fmt.Sprintf("PIV slot %s of YubiKey %s already holds a key; choose another --yubikey-slot", cfg.slot, yk.Serial))
+	}
+	cfg.yubikey.Serial = yk.Serial
+	return nil
+}
+
+// hardwarePassword reads a PIN or password file flag, if set
+func hardwarePassword(cmd *cobra.Command, flag string) ([]byte, error) {
+	path, _ := cmd.Flags().GetString(flag)
+	if path == "" {
+		return nil, nil
+	}
+	password, err := crypto.ReadPasswordFile(path, "")
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", fmt.Sprintf("failed to read --%s %s", flag, path))
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		return nil, errors.NewValidationError("parse_flags", fmt.Sprintf("%s is empty", path))
+	}
+	return []byte(password), nil
+}
+
+// sealWallet moves a found wallet's private key into the --hardware device and
+// replaces the key with its handle. A key the device does not take is kept, so it
+// is still shown rather than lost.
+func (app *Application) sealWallet(w *wallet.Wallet) {
+	if app.hardware == nil || w.KeyHandle != "" {
+		return
+	}
+	var path string
+	var err error
+	if app.hardware.backend == hardware.BackendYubiKeyPIV {
+		path, err = app.hardware.importYubiKey(w)
+	} else {
+		path, err = app.hardware.seal(w)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to move the key of %s into the %s, showing it instead: %v\n",
+			w.Address, app.hardware.deviceName(), err)
+		return
+	}
+	w.PrivateKey = ""
+	w.KeyHandle = path
+}
+
+// deviceName names the --hardware device in messages
+func (cfg *hardwareConfig) deviceName() string {
+	if cfg.backend == hardware.BackendYubiKeyPIV {
+		return "YubiKey " + cfg.yubikey.Serial
+	}
+	return "TPM"
+}
+
+// sealedKeyText describes where a key moved by sealWallet is kept
+func (cfg *hardwareConfig) sealedKeyText(handle string) string {
+	if cfg.backend == hardware.BackendYubiKeyPIV {
+		return i18n.T("result.key_yubikey", cfg.yubikey.Serial, cfg.slot, handle)
+	}
+	return i18n.T("result.key_sealed", handle)
+}
+
+// seal seals the key of w, checks it unseals to the same key and saves its handle
+func (cfg *hardwareConfig) seal(w *wallet.Wallet) (string, error) {
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+
+	tpm, err := hardware.OpenTPM()
+	if err != nil {
+		return "", err
+	}
+	defer tpm.Close()
+
+	secret := []byte(w.PrivateKey)
+	blob, err := tpm.Seal(secret, cfg.password)
+	if err != nil {
+		return "", err
+	}
+	unsealed, err := tpm.Unseal(blob, cfg.password)
+	if err != nil {
+		return "", fmt.Errorf("failed to verify the sealed key: %w", err)
+	}
+	defer crypto.ClearSensitiveData(unsealed)
+	if !bytes.Equal(unsealed, secret) {
+		return "", fmt.Errorf("the sealed key does not unseal to the generated key")
+	}
+
+	network := w.Network
+	if network == "" {
+		network = "ethereum"
+	}
+	return hardware.SaveHandle(cfg.dir, hardware.NewTPMHandle(w.Address, network, blob, len(cfg.password) > 0))
+}
+
+// importYubiKey imports the Ed25519 key of w into the PIV slot, then saves a handle
+// with the device attestation certificate
+func (cfg *hardwareConfig) importYubiKey(w *wallet.Wallet) (string, error) {
+	cfg.mu.Lock()
+	defer cfg.mu.Unlock()
+
+	key, err := hex.DecodeString(w.PrivateKey)
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return "", fmt.Errorf("not an Ed25519 private key")
+	}
+	defer crypto.ClearSensitiveData(key)
+
+	yk, err := hardware.OpenYubiKey(cfg.yubikey)
+	if err != nil {
+		return "", err
+	}
+	// Another key may have been found and imported since the flags were checked
+	if yk.SlotHasKey(cfg.slot) {
+		return "", fmt.Errorf("PIV slot %s already holds a key", cfg.slot)
+	}
+	if err := yk.ImportEd25519(cfg.slot, ed25519.PrivateKey(key)); err != nil {
+		return "", err
+	}
+	attestation, err := yk.DeviceAttestation()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: no attestation certificate recorded for %s: %v\n", w.Address, err)
+	}
+	return hardware.SaveHandle(cfg.dir, hardware.NewYubiKeyHandle(w.Address, "solana", yk.Serial, cfg.slot, attestation))
+}
+
+// createHardwareCommand creates the hardware subcommand group
+func (app *Application) createHardwareCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hardware",
+		Short: "Inspect secure hardware support and unseal hardware-held keys",
+		Long: `Keys found with --hardware tpm2 are sealed into this machine's TPM 2.0 and
+replaced by a key handle file in --hardware-dir. The handle holds no usable key
+material: only the TPM that sealed it can recover the key.
+
+With --hardware yubikey-piv, a Solana (Ed25519) key is imported into a YubiKey
+PIV slot through ykman and can never be read back; its handle records the
+YubiKey serial, the slot and the device attestation certificate. PIV has no
+secp256k1 keys, so Ethereum and Bitcoin keys cannot be imported.
+
+Vanity searches use ordinary in-memory candidate keys; only the accepted key is
+moved into the hardware. No TPM or Secure Enclave can create secp256k1 or
+Ed25519 keys itself, so the key is sealed rather than generated on the chip.`,
+	}
+
+	capabilitiesCmd := &cobra.Command{
+		Use:   "capabilities",
+		Short: "Show the secure hardware capability matrix and what this system has",
+		Args:  cobra.NoArgs,
+		RunE:  app.runHardwareCapabilities,
+	}
+	capabilitiesCmd.Flags().String("format", "text", "Output format (text, json)")
+	completeValues(capabilitiesCmd, "format", "text", "json")
+
+	unsealCmd := &cobra.Command{
+		Use:   "unseal <handle.json>",
+		Short: "Unseal a key held by the TPM and print it",
+		Long: `Unseal the private key of a key handle with the TPM that sealed it and print it
+to stdout in --key-format. Keys sealed with a password need the same
+--hardware-password-file.`,
+		Example: `  bloco-eth hardware unseal hardware-keys/0xabc....tpm2.json --hardware-password-file tpm.pwd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    app.runHardwareUnseal,
+	}
+
+	cmd.AddCommand(capabilitiesCmd, unsealCmd)
+	return cmd
+}
+
+// runHardwareCapabilities prints the capability matrix and the detected hardware
+func (app *Application) runHardwareCapabilities(cmd *cobra.Command, args []string) error {
+	detected := hardware.Detect()
+	if format, _ := cmd.Flags().GetString("format"); format == "json" {
+		data, err := json.MarshalIndent(map[string]interface{}{
+			"platforms": hardware.Capabilities,
+			"detected":  detected,
+		}, "", "  ")
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "hardware_capabilities", "failed to encode capabilities")
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+	out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(out, "OS\tHARDWARE\tSECP256K1\tED25519\tSEAL\tIMPORT\tSUPPORTED\tNOTES")
+	for _, c := range hardware.Capabilities {
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.OS, c.Hardware,
+			yesNo(c.NativeSecp256k1), yesNo(c.NativeEd25519), yesNo(c.Seal), yesNo(c.Import), yesNo(c.Supported), c.Notes)
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+
+	switch {
+	case detected.Available:
+		fmt.Fprintf(cmd.OutOrStdout(), "\nThis system: %s at %s (use --hardware %s)\n", detected.Hardware, detected.Device, detected.Backend)
+	case detected.Supported:
+		fmt.Fprintf(cmd.OutOrStdout(), "\nThis system: no %s device found\n", detected.Hardware)
+	default:
+		fmt.Fprintf(cmd.OutOrStdout(), "\nThis system: %s has no supported secure hardware\n", runtime.GOOS)
+	}
+	if serials, err := hardware.ListYubiKeys(); err != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "YubiKeys: %v\n", err)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "YubiKeys: %d connected %v (use --hardware yubikey-piv)\n", len(serials), serials)
+	}
+	return nil
+}
+
+// runHardwareUnseal unseals the key of a handle and prints it
+func (app *Application) runHardwareUnseal(cmd *cobra.Command, args []string) error {
+	handle, err := hardware.LoadHandle(args[0])
+	if err != nil {
+		app.auditKeystoreAccess("hardware_unseal", args[0], "", err)
+		return errors.WrapError(err, errors.ErrorTypeValidation, "hardware_unseal", "invalid key handle")
+	}
+	format, err := keyFormatFlag(cmd, handle.Network)
+	if err != nil {
+		return err
+	}
+	if handle.Backend == hardware.BackendYubiKeyPIV {
+		return errors.NewValidationError("hardware_unseal",
+			fmt.Sprintf("keys in a YubiKey PIV slot cannot be exported; %s stays in slot %s of YubiKey %s", handle.Address, handle.Slot, handle.Serial))
+	}
+	password, err := hardwarePassword(cmd, "hardware-password-file")
+	if err != nil {
+		return err
+	}
+	if handle.PasswordProtected && password == nil {
+		return errors.NewValidationError("hardware_unseal", "this key was sealed with a password; use --hardware-password-file")
+	}
+
+	secret, err := unsealHandle(handle, password)
+	// Record the unsealing before the key is printed
+	app.auditKeystoreAccess("hardware_unseal", args[0], handle.Address, err)
+	if err != nil {
+		return errors.NewCryptoError("hardware_unseal", "failed to unseal the key", err)
+	}
+	defer crypto.ClearSensitiveData(secret)
+
+	key, err := crypto.FormatPrivateKeyHex(string(secret), format)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "hardware_unseal", "invalid --key-format for this key")
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), key)
+	return nil
+}
+
+// unsealHandle recovers the key of a handle from the TPM
+func unsealHandle(handle *hardware.KeyHandle, password []byte) ([]byte, error) {
+	blob, err := handle.Blob()
+	if err != nil {
+		return nil, err
+	}
+	tpm, err := hardware.OpenTPM()
+	if err != nil {
+		return nil, err
+	}
+	defer tpm.Close()
+	return tpm.Unseal(blob, password)
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/server"
+	"bloco-eth/internal/worker"
+)
+
+// poolHealthProvider reports worker pool state for a run expected to find totalWallets wallets
+func poolHealthProvider(workerPool worker.WorkerPool, totalWallets int) server.HealthProvider {
+	return server.HealthProviderFunc(func() server.HealthStatus {
+		collector := workerPool.GetStatsCollector()
+		stats := collector.GetAggregatedStats()
+
+		backlog := totalWallets - int(collector.GetWalletsFound())
+		if backlog < 0 {
+			backlog = 0
+		}
+
+		return server.HealthStatus{
+			PoolRunning:    workerPool.IsRunning(),
+			ActiveWorkers:  stats.ActiveWorkers,
+			HealthyWorkers: stats.HealthyWorkers,
+			WorkerRestarts: collector.GetWorkerRestarts(),
+			BacklogDepth:   backlog,
+			TotalAttempts:  stats.TotalAttempts,
+			LastProgress:   collector.GetLastProgress(),
+		}
+	})
+}
+
+// startHealthServer starts the /healthz and /readyz endpoints when --health-addr is set.
+// The returned stop function is always safe to call.
+func (app *Application) startHealthServer(cmd *cobra.Command, provider server.HealthProvider) (func(), error) {
+	addr, _ := cmd.Flags().GetString("health-addr")
+	if addr == "" {
+		return func() {}, nil
+	}
+
+	stallTimeout, _ := cmd.Flags().GetDuration("health-stall-timeout")
+	healthServer := server.NewHealthServer(addr, provider, stallTimeout)
+	if err := healthServer.Start(); err != nil {
+		return func() {}, err
+	}
+
+	if app.config.CLI.VerboseOutput {
+		fmt.Printf("Health endpoints listening on %s (/healthz, /readyz)\n", healthServer.Addr())
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := healthServer.Shutdown(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to shutdown health server: %v\n", err)
+		}
+	}, nil
+}
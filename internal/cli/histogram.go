@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+const (
+	// histogramBuckets is the maximum number of buckets in the attempts histogram
+	histogramBuckets = 10
+	// histogramMaxZScore is the deviation of the mean beyond which a batch is flagged
+	histogramMaxZScore = 3.0
+)
+
+// sparkBlocks are the bar heights used by the text sparkline
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// histogramBucket counts wallets needing between Min and Max attempts (inclusive)
+type histogramBucket struct {
+	Min   int64 `json:"min"`
+	Max   int64 `json:"max"`
+	Count int   `json:"count"`
+}
+
+// attemptHistogram summarizes attempts per wallet in a batch against the theoretical
+// geometric distribution, whose mean is the difficulty
+type attemptHistogram struct {
+	Pattern      string            `json:"pattern"`
+	Difficulty   float64           `json:"difficulty"`
+	Wallets      int               `json:"wallets"`
+	Mean         float64           `json:"mean"`
+	ExpectedMean float64           `json:"expected_mean"`
+	ZScore       float64           `json:"z_score"`
+	Unlikely     bool              `json:"unlikely"`
+	Buckets      []histogramBucket `json:"buckets"`
+}
+
+// newAttemptHistogram buckets the attempts of each result and compares their mean with
+// the difficulty; the mean of n geometric samples has standard deviation
+// sqrt(1-p)/p/sqrt(n), with p = 1/difficulty
+func newAttemptHistogram(results []*wallet.GenerationResult, criteria wallet.GenerationCriteria) *attemptHistogram {
+	difficulty := calculateDifficulty(criteria)
+	h := &attemptHistogram{
+		Pattern:      criteria.GetPattern(),
+		Difficulty:   difficulty,
+		Wallets:      len(results),
+		ExpectedMean: difficulty,
+		Buckets:      []histogramBucket{},
+	}
+	if len(results) == 0 {
+		return h
+	}
+
+	maxAttempts := int64(1)
+	var total float64
+	for _, result := range results {
+		total += float64(result.Attempts)
+		maxAttempts = max(maxAttempts, result.Attempts)
+	}
+	h.Mean = total / float64(len(results))
+
+	p := 1 / difficulty
+	if stddev := math.Sqrt(1-p) / p / math.Sqrt(float64(len(results))); stddev > 0 {
+		h.ZScore = (h.Mean - h.ExpectedMean) / stddev
+	}
+	h.Unlikely = math.Abs(h.ZScore) > histogramMaxZScore
+
+	buckets := int64(min(histogramBuckets, len(results)))
+	width := (maxAttempts + buckets - 1) / buckets
+	for i := int64(0); i < buckets; i++ {
+		h.Buckets = append(h.Buckets, histogramBucket{Min: i*width + 1, Max: (i + 1) * width})
+	}
+	for _, result := range results {
+		index := max(0, min((result.Attempts-1)/width, buckets-1))
+		h.Buckets[index].Count++
+	}
+	return h
+}
+
+// sparkline renders the bucket counts as a line of block characters
+func (h *attemptHistogram) sparkline() string {
+	peak := 0
+	for _, bucket := range h.Buckets {
+		peak = max(peak, bucket.Count)
+	}
+	var line strings.Builder
+	for _, bucket := range h.Buckets {
+		if bucket.Count == 0 {
+			line.WriteRune(' ')
+			continue
+		}
+		line.WriteRune(sparkBlocks[(bucket.Count*(len(sparkBlocks)-1)+peak-1)/peak])
+	}
+	return line.String()
+}
+
+// print writes the text histogram and the comparison with the expected mean
+func (h *attemptHistogram) print() {
+	if len(h.Buckets) == 0 {
+		return
+	}
+	fmt.Printf("\n%s\n", i18n.T("histogram.title", h.Wallets))
+	if plainOutput.Load() {
+		for _, bucket := range h.Buckets {
+			fmt.Println("  " + i18n.T("histogram.bucket", formatLargeNumber(bucket.Min), formatLargeNumber(bucket.Max), bucket.Count))
+		}
+	} else {
+		fmt.Println("  " + h.sparkline() + "  " + i18n.T("histogram.range",
+			formatLargeNumber(h.Buckets[0].Min), formatLargeNumber(h.Buckets[len(h.Buckets)-1].Max)))
+	}
+	fmt.Println("  " + i18n.T("histogram.mean",
+		formatLargeNumber(int64(math.Round(h.Mean))), formatLargeNumber(int64(h.ExpectedMean)), h.ZScore))
+	if h.Unlikely {
+		fmt.Println("  " + icon("⚠️ ") + i18n.T("histogram.unlikely", histogramMaxZScore))
+	}
+}
+
+// writeAttemptHistogram writes the histogram as JSON to path, or to stdout for "-"
+func writeAttemptHistogram(path string, h *attemptHistogram) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "attempts_histogram", "failed to encode histogram")
+	}
+	if path == "-" {
+		fmt.Println(string(data))
+		return nil
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "attempts_histogram", fmt.Sprintf("failed to write %s", path))
+	}
+	fmt.Println(i18n.T("histogram.saved", path))
+	return nil
+}
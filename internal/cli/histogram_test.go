@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func histogramResults(attempts ...int64) []*wallet.GenerationResult {
+	results := make([]*wallet.GenerationResult, len(attempts))
+	for i, a := range attempts {
+		results[i] = &wallet.GenerationResult{Attempts: a}
+	}
+	return results
+}
+
+func TestAttemptHistogram(t *testing.T) {
+	criteria := wallet.GenerationCriteria{Prefix: "ab"} // difficulty 256
+	h := newAttemptHistogram(histogramResults(100, 200, 300, 400, 1000), criteria)
+
+	if h.ExpectedMean != 256 || h.Mean != 400 {
+		t.Errorf("mean = %v, expected mean = %v", h.Mean, h.ExpectedMean)
+	}
+	if len(h.Buckets) != 5 {
+		t.Fatalf("expected one bucket per wallet for small batches, got %d", len(h.Buckets))
+	}
+	var total int
+	for _, bucket := range h.Buckets {
+		total += bucket.Count
+	}
+	if total != 5 || h.Buckets[0].Count != 2 || h.Buckets[4].Count != 1 || h.Buckets[4].Max != 1000 {
+		t.Errorf("unexpected buckets: %+v", h.Buckets)
+	}
+	if h.Unlikely {
+		t.Errorf("a small batch near the mean should not be flagged: z = %v", h.ZScore)
+	}
+	if got := utf8.RuneCountInString(h.sparkline()); got != len(h.Buckets) {
+		t.Errorf("sparkline has %d characters, want %d", got, len(h.Buckets))
+	}
+}
+
+func TestAttemptHistogramUnlikely(t *testing.T) {
+	attempts := make([]int64, 50)
+	for i := range attempts {
+		attempts[i] = 1024 // four times the expected mean, every time
+	}
+	h := newAttemptHistogram(histogramResults(attempts...), wallet.GenerationCriteria{Prefix: "ab"})
+	if !h.Unlikely || h.ZScore < histogramMaxZScore {
+		t.Errorf("expected the batch to be flagged, z = %v", h.ZScore)
+	}
+	if len(h.Buckets) != histogramBuckets {
+		t.Errorf("expected %d buckets, got %d", histogramBuckets, len(h.Buckets))
+	}
+}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/server"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+)
+
+// jobsClientTimeout bounds each request to the serve API
+const jobsClientTimeout = 10 * time.Second
+
+// createJobsCommand creates the jobs subcommand group for a running serve instance
+func (app *Application) createJobsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "jobs",
+		Short:       "Manage jobs on a running serve instance",
+		Annotations: map[string]string{unhardenedAnnotation: "true"},
+		Long:        "List, cancel and retry generation jobs queued on a running \"bloco-eth serve\" instance.",
+	}
+	cmd.PersistentFlags().String("server", "http://127.0.0.1:8080", "Base URL of the serve API")
+	cmd.PersistentFlags().String("api-key", "", "API key for servers started with --api-keys (default $BLOCO_API_KEY)")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List jobs",
+		Args:  cobra.NoArgs,
+		RunE:  app.listJobs,
+	}
+	listCmd.Flags().String("state", "", "Only show jobs in this state (queued, running, paused, completed, failed, cancelled)")
+
+	cancelCmd := &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel a queued, running or paused job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.jobAction(cmd, http.MethodDelete, "/jobs/"+url.PathEscape(args[0]), "cancel_job")
+		},
+	}
+
+	retryCmd := &cobra.Command{
+		Use:   "retry <id>",
+		Short: "Retry a failed or cancelled job",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return app.jobAction(cmd, http.MethodPost, "/jobs/"+url.PathEscape(args[0])+"/retry", "retry_job")
+		},
+	}
+
+	cmd.AddCommand(listCmd, cancelCmd, retryCmd)
+	return cmd
+}
+
+// listJobs prints the jobs known to the server
+func (app *Application) listJobs(cmd *cobra.Command, args []string) error {
+	var jobs []server.Job
+	if err := jobsRequest(cmd, http.MethodGet, "/jobs", "list_jobs", &jobs); err != nil {
+		return err
+	}
+
+	state, _ := cmd.Flags().GetString("state")
+	out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(out, "ID\tSTATE\tPRIORITY\tPATTERN\tWALLETS\tATTEMPTS\tRETRIES\tCREATED")
+	for _, job := range jobs {
+		if state != "" && string(job.State) != state {
+			continue
+		}
+		criteria := job.Request.Criteria()
+		priority, _ := worker.ParsePriority(job.Request.Priority)
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%d/%d\t%s\t%d\t%s\n",
+			job.ID, job.State, priority, criteria.GetPattern(), len(job.Addresses), job.Request.Count,
+			utils.FormatLargeNumber(job.Attempts), job.Retries, job.CreatedAt.Local().Format(time.DateTime))
+	}
+	return out.Flush()
+}
+
+// jobAction applies a state change to a job and prints the result
+func (app *Application) jobAction(cmd *cobra.Command, method, path, operation string) error {
+	var job server.Job
+	if err := jobsRequest(cmd, method, path, operation, &job); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Job %s is now %s\n", job.ID, job.State)
+	return nil
+}
+
+// jobsRequest sends a request to the serve API and decodes the JSON response
+func jobsRequest(cmd *cobra.Command, method, path, operation string, out interface{}) error {
+	var in interface{}
+	if method != http.MethodGet {
+		in = struct{}{}
+	}
+	_, err := serveRequest(cmd.Context(), cmd, method, path, operation, in, out)
+	return err
+}
+
+// serveRequest sends in as the JSON body of a request to the serve API, decodes the
+// JSON response into out and returns the response status. Error statuses are
+// returned along with an error carrying the API's message.
+func serveRequest(ctx context.Context, cmd *cobra.Command, method, path, operation string, in, out interface{}) (int, error) {
+	base, _ := cmd.Flags().GetString("server")
+	base = strings.TrimRight(base, "/")
+
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return 0, errors.WrapError(err, errors.ErrorTypeValidation, operation, "failed to encode request")
+		}
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, base+path, body)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrorTypeValidation, operation, "invalid server URL")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	apiKey, _ := cmd.Flags().GetString("api-key")
+	if apiKey == "" {
+		apiKey = os.Getenv("BLOCO_API_KEY")
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	client := &http.Client{Timeout: jobsClientTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, errors.WrapError(err, errors.ErrorTypeConfiguration, operation,
+			fmt.Sprintf("failed to reach serve API at %s", base))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) != nil || apiErr.Error == "" {
+			apiErr.Error = resp.Status
+		}
+		return resp.StatusCode, errors.NewValidationError(operation, apiErr.Error)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return resp.StatusCode, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, errors.WrapError(err, errors.ErrorTypeConfiguration, operation, "invalid response from serve API")
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/pkg/errors"
+)
+
+// k8sNamePattern matches valid Kubernetes resource names (RFC 1123 label)
+var k8sNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// k8sQuantityPattern matches Kubernetes memory quantities such as 512Mi or 2G
+var k8sQuantityPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?(Ki|Mi|Gi|Ti|k|M|G|T)?$`)
+
+// k8sManifestOptions holds the values rendered into the Job manifest
+type k8sManifestOptions struct {
+	Name      string
+	Namespace string
+	Image     string
+	Agents    int
+	CPU       int
+	Memory    string
+	PVC       string
+	EmptyDir  bool // --allow-emptydir: without a PVC the results die with the pods
+	Deadline  int64
+	Args      []string
+	// Coordinator and Keyspace make the agents search leases of a serve keyspace
+	Coordinator  string
+	Keyspace     string
+	APIKeySecret string
+}
+
+// k8sJobTemplate renders an Indexed Job whose success policy completes the Job
+// (and stops the remaining agents) as soon as any agent finds a match.
+var k8sJobTemplate = template.Must(template.New("job").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`# Generated by bloco-eth k8s generate
+{{- if .Coordinator }}
+# Agents lease blocks of keyspace {{ .Keyspace }} from the coordinator at {{ .Coordinator }},
+# so no two agents search the same keys; the Job completes once the keyspace is
+# finished. Results are written to /data/keystores.
+{{- else }}
+# Each agent searches an independent random keyspace; the first agent to find a
+# match completes the Job. Results are written to /data/keystores.
+{{- end }}
+# Agents run with --no-key-output, so private keys never reach the pod logs.
+{{- if not .PVC }}
+# The keystore volume is an emptyDir (--allow-emptydir): copy the results out
+# before the pods are deleted, or they are lost with them.
+{{- end }}
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ .Name }}
+  namespace: {{ .Namespace }}
+  labels:
+    app.kubernetes.io/name: bloco-eth
+    app.kubernetes.io/instance: {{ .Name }}
+spec:
+  completionMode: Indexed
+  completions: {{ .Agents }}
+  parallelism: {{ .Agents }}
+  successPolicy:
+    rules:
+      - succeededCount: 1
+  backoffLimitPerIndex: 2
+{{- if gt .Deadline 0 }}
+  activeDeadlineSeconds: {{ .Deadline }}
+{{- end }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: bloco-eth
+        app.kubernetes.io/instance: {{ .Name }}
+    spec:
+      restartPolicy: Never
+      securityContext:
+        runAsNonRoot: true
+        runAsUser: 1001
+        fsGroup: 1001
+      containers:
+        - name: agent
+          image: {{ .Image }}
+          args:
+{{- range .Args }}
+            - {{ quote . }}
+{{- end }}
+{{- if .APIKeySecret }}
+          env:
+            - name: BLOCO_API_KEY
+              valueFrom:
+                secretKeyRef:
+                  name: {{ .APIKeySecret }}
+                  key: token
+{{- end }}
+          ports:
+            - name: health
+              containerPort: 8080
+          livenessProbe:
+            httpGet:
+              path: /healthz
+              port: health
+            initialDelaySeconds: 10
+            periodSeconds: 20
+          readinessProbe:
+            httpGet:
+              path: /readyz
+              port: health
+            periodSeconds: 10
+          resources:
+            requests:
+              cpu: "{{ .CPU }}"
+              memory: {{ .Memory }}
+            limits:
+              cpu: "{{ .CPU }}"
+              memory: {{ .Memory }}
+          securityContext:
+            allowPrivilegeEscalation: false
+            readOnlyRootFilesystem: true
+          volumeMounts:
+            - name: keystores
+              mountPath: /data/keystores
+      volumes:
+        - name: keystores
+{{- if .PVC }}
+          persistentVolumeClaim:
+            claimName: {{ .PVC }}
+{{- else }}
+          emptyDir: {}
+{{- end }}
+`))
+
+// createK8sCommand creates the k8s subcommand group
+func (app *Application) createK8sCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "k8s",
+		Short: "Kubernetes helpers for cluster-wide searches",
+		Long:  "Generate Kubernetes manifests that run bloco-eth agents in a cluster.",
+	}
+
+	generateCmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate a Job manifest for a pattern search",
+		Long: `Generate a ready-to-apply Kubernetes Job that runs multiple agents searching
+for the same pattern. The Job completes as soon as one agent finds a match.
+
+With --coordinator and --keyspace, the agents search leases of a keyspace
+created on a "bloco-eth serve" coordinator instead of random keys, so no two
+agents repeat work; the pattern comes from the keyspace.`,
+		Example: `  bloco-eth k8s generate --prefix dead --agents 20 --pvc bloco-keystores > job.yaml
+  bloco-eth k8s generate --prefix abc --suffix 99 --agents 8 --allow-emptydir | kubectl apply -f -
+  bloco-eth k8s generate --coordinator http://bloco-serve:8080 --keyspace <id> --agents 50 --pvc bloco-keystores`,
+		RunE: app.generateK8sManifest,
+	}
+
+	generateCmd.Flags().Int("agents", 4, "Number of agent pods to run in parallel")
+	generateCmd.Flags().String("name", "bloco-search", "Job name")
+	generateCmd.Flags().String("namespace", "default", "Kubernetes namespace")
+	generateCmd.Flags().String("image", "ghcr.io/italoag/bloco-eth:latest", "Container image")
+	generateCmd.Flags().Int("cpu", 2, "CPU cores per agent (also used as the agent thread count)")
+	generateCmd.Flags().String("memory", "512Mi", "Memory per agent")
+	generateCmd.Flags().String("pvc", "", "PersistentVolumeClaim for keystore output (required unless --allow-emptydir)")
+	generateCmd.Flags().Bool("allow-emptydir", false, "Write keystores to an emptyDir volume, which is deleted with the pods, when no --pvc is given")
+	generateCmd.Flags().Int64("deadline", 0, "Job active deadline in seconds (0 = no deadline)")
+	generateCmd.Flags().String("manifest-output", "", "Write manifest to file instead of stdout")
+	generateCmd.Flags().String("coordinator", "", "Base URL of a serve coordinator handing out keyspace leases")
+	generateCmd.Flags().String("keyspace", "", "ID of the coordinator keyspace the agents search")
+	generateCmd.Flags().String("api-key-secret", "", "Secret whose \"token\" key holds the coordinator API key")
+
+	cmd.AddCommand(generateCmd)
+	return cmd
+}
+
+// generateK8sManifest renders the Job manifest for the requested search
+func (app *Application) generateK8sManifest(cmd *cobra.Command, args []string) error {
+	coordinator, _ := cmd.Flags().GetString("coordinator")
+	criteria, err := app.getGenerationCriteria(cmd)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation,
+			"k8s_generate", "invalid pattern criteria")
+	}
+	hasPattern := criteria.Prefix != "" || criteria.Suffix != ""
+	switch {
+	case coordinator != "" && hasPattern:
+		return errors.NewValidationError("k8s_generate", "coordinator agents search the pattern of their --keyspace; remove the pattern flags")
+	case coordinator == "" && !hasPattern:
+		return errors.NewValidationError("k8s_generate", "a --prefix or --suffix is required")
+	}
+
+	opts := k8sManifestOptions{}
+	opts.Name, _ = cmd.Flags().GetString("name")
+	opts.Namespace, _ = cmd.Flags().GetString("namespace")
+	opts.Image, _ = cmd.Flags().GetString("image")
+	opts.Agents, _ = cmd.Flags().GetInt("agents")
+	opts.CPU, _ = cmd.Flags().GetInt("cpu")
+	opts.Memory, _ = cmd.Flags().GetString("memory")
+	opts.PVC, _ = cmd.Flags().GetString("pvc")
+	opts.EmptyDir, _ = cmd.Flags().GetBool("allow-emptydir")
+	opts.Deadline, _ = cmd.Flags().GetInt64("deadline")
+	opts.Coordinator = coordinator
+	opts.Keyspace, _ = cmd.Flags().GetString("keyspace")
+	opts.APIKeySecret, _ = cmd.Flags().GetString("api-key-secret")
+
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	count, _ := cmd.Flags().GetInt("count")
+	network, _ := cmd.Flags().GetString("network")
+	useMnemonic, _ := cmd.Flags().GetBool("with-mnemonic")
+
+	if opts.Coordinator != "" {
+		opts.Args = []string{"agent", "--server", opts.Coordinator, "--keyspace", opts.Keyspace,
+			"--threads", strconv.Itoa(opts.CPU), "--keystore-dir", "/data/keystores", "--no-key-output", "--health-addr", ":8080"}
+		return app.writeK8sManifest(cmd, opts)
+	}
+
+	opts.Args = []string{"--tui=false", "--threads", strconv.Itoa(opts.CPU), "--keystore-dir", "/data/keystores", "--no-key-output", "--health-addr", ":8080"}
+	if criteria.Prefix != "" {
+		opts.Args = append(opts.Args, "--prefix", criteria.Prefix)
+	}
+	if criteria.Suffix != "" {
+		opts.Args = append(opts.Args, "--suffix", criteria.Suffix)
+	}
+	if criteria.IsChecksum {
+		opts.Args = append(opts.Args, "--checksum")
+	}
+	if criteria.CaseSensitive {
+		opts.Args = append(opts.Args, "--case-sensitive")
+	}
+	if count > 1 {
+		opts.Args = append(opts.Args, "--count", strconv.Itoa(count))
+	}
+	if network != "" && network != "ethereum" {
+		opts.Args = append(opts.Args, "--network", network)
+	}
+	if useMnemonic {
+		opts.Args = append(opts.Args, "--with-mnemonic")
+	}
+
+	return app.writeK8sManifest(cmd, opts)
+}
+
+// writeK8sManifest renders the manifest to stdout or --manifest-output
+func (app *Application) writeK8sManifest(cmd *cobra.Command, opts k8sManifestOptions) error {
+	var out io.Writer = cmd.OutOrStdout()
+	if path, _ := cmd.Flags().GetString("manifest-output"); path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"k8s_generate", fmt.Sprintf("failed to create %s", path))
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return renderK8sManifest(out, opts)
+}
+
+// validate checks manifest options before rendering
+func (o k8sManifestOptions) validate() error {
+	if !k8sNamePattern.MatchString(o.Name) || len(o.Name) > 63 {
+		return errors.NewValidationError("k8s_generate",
+			fmt.Sprintf("invalid job name %q: must be a lowercase RFC 1123 label", o.Name))
+	}
+	if !k8sNamePattern.MatchString(o.Namespace) {
+		return errors.NewValidationError("k8s_generate",
+			fmt.Sprintf("invalid namespace %q", o.Namespace))
+	}
+	if o.PVC != "" && !k8sNamePattern.MatchString(o.PVC) {
+		return errors.NewValidationError("k8s_generate",
+			fmt.Sprintf("invalid PVC name %q", o.PVC))
+	}
+	if o.PVC == "" && !o.EmptyDir {
+		return errors.NewValidationError("k8s_generate",
+			"keystores need a --pvc to outlive the pods; pass --allow-emptydir to keep them on an emptyDir volume anyway")
+	}
+	if o.Agents < 1 || o.Agents > 1000 {
+		return errors.NewValidationError("k8s_generate",
+			fmt.Sprintf("agents must be between 1 and 1000, got %d", o.Agents))
+	}
+	if o.CPU < 1 || o.CPU > 128 {
+		return errors.NewValidationError("k8s_generate",
+			fmt.Sprintf("cpu must be between 1 and 128, got %d", o.CPU))
+	}
+	if o.Image == "" || strings.ContainsAny(o.Image, " \n\t") {
+		return errors.NewValidationError("k8s_generate", "invalid container image")
+	}
+	if !k8sQuantityPattern.MatchString(o.Memory) {
+		return errors.NewValidationError("k8s_generate", "invalid memory quantity")
+	}
+	if o.Deadline < 0 {
+		return errors.NewValidationError("k8s_generate", "deadline cannot be negative")
+	}
+	if o.Coordinator == "" {
+		if o.Keyspace != "" || o.APIKeySecret != "" {
+			return errors.NewValidationError("k8s_generate", "--keyspace and --api-key-secret need --coordinator")
+		}
+		return nil
+	}
+	if u, err := url.Parse(o.Coordinator); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.NewValidationError("k8s_generate", fmt.Sprintf("invalid coordinator URL %q", o.Coordinator))
+	}
+	if o.Keyspace == "" || strings.ContainsAny(o.Keyspace, " \n\t/") {
+		return errors.NewValidationError("k8s_generate", "--coordinator needs the --keyspace to search")
+	}
+	if o.APIKeySecret != "" && !k8sNamePattern.MatchString(o.APIKeySecret) {
+		return errors.NewValidationError("k8s_generate",
+			fmt.Sprintf("invalid secret name %q", o.APIKeySecret))
+	}
+	return nil
+}
+
+// renderK8sManifest writes the Job manifest to the given writer
+func renderK8sManifest(w io.Writer, opts k8sManifestOptions) error {
+	if err := k8sJobTemplate.Execute(w, opts); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"k8s_generate", "failed to render manifest")
+	}
+	return nil
+}
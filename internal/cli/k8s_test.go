@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderK8sManifest(t *testing.T) {
+	opts := k8sManifestOptions{
+		Name:      "bloco-search",
+		Namespace: "default",
+		Image:     "ghcr.io/italoag/bloco-eth:latest",
+		Agents:    20,
+		CPU:       2,
+		Memory:    "512Mi",
+		PVC:       "keystores",
+		Deadline:  3600,
+		Args:      []string{"--tui=false", "--prefix", "dead"},
+	}
+
+	if err := opts.validate(); err != nil {
+		t.Fatalf("validate() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderK8sManifest(&buf, opts); err != nil {
+		t.Fatalf("renderK8sManifest() returned error: %v", err)
+	}
+
+	manifest := buf.String()
+	expected := []string{
+		"kind: Job",
+		"parallelism: 20",
+		"succeededCount: 1",
+		"activeDeadlineSeconds: 3600",
+		`- "--prefix"`,
+		`- "dead"`,
+		"claimName: keystores",
+		"path: /healthz",
+		"path: /readyz",
+	}
+	for _, want := range expected {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("manifest missing %q", want)
+		}
+	}
+	if strings.Contains(manifest, "emptyDir") {
+		t.Error("manifest should not use emptyDir when a PVC is set")
+	}
+}
+
+func TestRenderK8sManifest_Coordinator(t *testing.T) {
+	opts := k8sManifestOptions{
+		Name:         "bloco-search",
+		Namespace:    "default",
+		Image:        "img",
+		Agents:       8,
+		CPU:          4,
+		Memory:       "1Gi",
+		EmptyDir:     true,
+		Coordinator:  "http://bloco-serve:8080",
+		Keyspace:     "0123abcd",
+		APIKeySecret: "bloco-api-key",
+		Args:         []string{"agent", "--server", "http://bloco-serve:8080", "--keyspace", "0123abcd"},
+	}
+	if err := opts.validate(); err != nil {
+		t.Fatalf("validate() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := renderK8sManifest(&buf, opts); err != nil {
+		t.Fatalf("renderK8sManifest() returned error: %v", err)
+	}
+	manifest := buf.String()
+	for _, want := range []string{"keyspace 0123abcd from the coordinator", `- "agent"`, "name: BLOCO_API_KEY", "name: bloco-api-key", "emptyDir: {}", "--allow-emptydir"} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("manifest missing %q", want)
+		}
+	}
+	if strings.Contains(manifest, "independent random keyspace") {
+		t.Error("coordinator manifest should not describe random keyspaces")
+	}
+}
+
+func TestK8sManifestOptionsValidate(t *testing.T) {
+	valid := k8sManifestOptions{Name: "job", Namespace: "default", Image: "img", Agents: 1, CPU: 1, Memory: "1Gi", PVC: "keystores"}
+
+	tests := []struct {
+		name   string
+		modify func(*k8sManifestOptions)
+	}{
+		{"uppercase name", func(o *k8sManifestOptions) { o.Name = "Job" }},
+		{"zero agents", func(o *k8sManifestOptions) { o.Agents = 0 }},
+		{"zero cpu", func(o *k8sManifestOptions) { o.CPU = 0 }},
+		{"invalid pvc", func(o *k8sManifestOptions) { o.PVC = "bad_name" }},
+		{"emptyDir without opt-out", func(o *k8sManifestOptions) { o.PVC = "" }},
+		{"image with spaces", func(o *k8sManifestOptions) { o.Image = "img\nkind: Pod" }},
+		{"keyspace without coordinator", func(o *k8sManifestOptions) { o.Keyspace = "abc" }},
+		{"coordinator without keyspace", func(o *k8sManifestOptions) { o.Coordinator = "http://coordinator:8080" }},
+		{"coordinator not a URL", func(o *k8sManifestOptions) { o.Coordinator, o.Keyspace = "coordinator:8080", "abc" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.modify(&opts)
+			if err := opts.validate(); err == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
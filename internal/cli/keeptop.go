@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// generateTopWallets runs a --keep-top best-effort search. Searches repeat until the
+// time budget ends or K exact matches turn up, each ending at the first wallet that
+// ranks among the best so far. The ranking is then shown and the wallet the user
+// chooses is kept like a found wallet; the others are discarded.
+func (app *Application) generateTopWallets(ctx, genCtx context.Context, pool worker.WorkerPool, criteria wallet.GenerationCriteria) error {
+	quiet := app.config.CLI.QuietMode
+	if !quiet {
+		fmt.Println(i18n.T("generate.header", criteria.GetPattern()))
+		fmt.Printf("Best-effort search: ranking the %d closest addresses until the time budget ends\n\n", app.keepTop)
+		if plainOutput.Load() {
+			stopStatus := app.progress.Subscribe(app.statusInterval, statusLines(criteria, 1, app.etaPercentiles))
+			defer stopStatus()
+		}
+	}
+
+	ranking := worker.NewRanking(app.keepTop, criteria)
+	searchCtx := worker.WithRanking(genCtx, ranking)
+	start := time.Now()
+	for !ranking.Complete() {
+		result, err := searchWallet(searchCtx, pool, criteria)
+		if err != nil {
+			if genCtx.Err() != nil {
+				break
+			}
+			return errors.WrapError(err, errors.ErrorTypeGeneration,
+				"generate_wallet", "failed to generate wallet")
+		}
+		ranking.Add(result)
+	}
+	// An interrupted run keeps nothing; generationOutcome reports the cancellation
+	candidates := ranking.Candidates()
+	if ctx.Err() != nil || len(candidates) == 0 {
+		return nil
+	}
+
+	attempts := pool.GetStatsCollector().GetTotalAttempts()
+	choice := 0
+	if !quiet {
+		fmt.Printf("Best %d addresses of %s attempts in %s:\n", len(candidates), formatLargeNumber(attempts), formatDuration(time.Since(start)))
+		printCandidates(os.Stdout, candidates)
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			choice = chooseCandidate(os.Stdin, os.Stdout, len(candidates))
+		} else {
+			fmt.Println("Keeping #1; run on a terminal to choose another")
+		}
+		fmt.Println()
+	}
+
+	chosen := candidates[choice].Result
+	chosen.Attempts, chosen.Duration = attempts, time.Since(start)
+	app.progress.PublishWallet(chosen)
+	return app.displayWalletResult(chosen, criteria, true)
+}
+
+// printCandidates lists a ranking, best first, without any key material
+func printCandidates(out io.Writer, candidates []worker.Candidate) {
+	for i, c := range candidates {
+		match := ""
+		if c.Exact {
+			match = " (exact match)"
+		}
+		fmt.Fprintf(out, "  %d. %s  %d/%d pattern characters%s\n", i+1, c.Result.Wallet.Address, c.Matched, c.Length, match)
+	}
+}
+
+// chooseCandidate asks which of n ranked wallets to keep until it reads a valid
+// answer, and returns its index. An empty answer or the end of in picks the first.
+func chooseCandidate(in io.Reader, out io.Writer, n int) int {
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Fprintf(out, "Keep which wallet? [1-%d, default 1]: ", n)
+		line, err := reader.ReadString('\n')
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			if err != nil {
+				fmt.Fprintln(out)
+			}
+			return 0
+		}
+		if choice, convErr := strconv.Atoi(answer); convErr == nil && choice >= 1 && choice <= n {
+			return choice - 1
+		}
+		if err != nil {
+			fmt.Fprintln(out)
+			return 0
+		}
+		fmt.Fprintf(out, "Enter a number from 1 to %d\n", n)
+	}
+}
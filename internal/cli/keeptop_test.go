@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestChooseCandidate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"2\n", 1},
+		{"\n", 0},
+		{"", 0},
+		{"7\nx\n3\n", 2},
+		{"3", 2},
+		{"x", 0},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		if got := chooseCandidate(strings.NewReader(tt.input), &out, 3); got != tt.want {
+			t.Errorf("chooseCandidate(%q) = %d, want %d (output %q)", tt.input, got, tt.want, out.String())
+		}
+	}
+}
+
+func TestPrintCandidates(t *testing.T) {
+	candidates := []worker.Candidate{
+		{Result: &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: "0xabcd", PrivateKey: "secret"}}, Matched: 4, Length: 4, Exact: true},
+		{Result: &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: "0xab00", PrivateKey: "secret"}}, Matched: 2, Length: 4},
+	}
+	var out bytes.Buffer
+	printCandidates(&out, candidates)
+	want := "  1. 0xabcd  4/4 pattern characters (exact match)\n  2. 0xab00  2/4 pattern characters\n"
+	if out.String() != want {
+		t.Errorf("printCandidates() = %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	stderrors "errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// keyFormatFlag returns --key-format after checking it suits keys of network
+func keyFormatFlag(cmd *cobra.Command, network string) (string, error) {
+	format, _ := cmd.Flags().GetString("key-format")
+	if format == "" {
+		format = crypto.KeyFormatHex
+	}
+	if err := crypto.ValidateKeyFormat(format, network); err != nil {
+		return "", errors.NewValidationError("parse_flags", "invalid --key-format: "+err.Error())
+	}
+	return format, nil
+}
+
+// parseHideKeysFlag reads --no-key-output, which needs somewhere else to put the keys
+func (app *Application) parseHideKeysFlag(cmd *cobra.Command) error {
+	app.hideKeys, _ = cmd.Flags().GetBool("no-key-output")
+	if app.hideKeys && !app.config.KeyStore.Enabled && app.hardware == nil {
+		return errors.NewValidationError("parse_flags",
+			"--no-key-output would lose every key found; it cannot be combined with --no-keystore")
+	}
+	return nil
+}
+
+// printedKey is displayKey for output read by people and logs, or "" with --no-key-output
+func (app *Application) printedKey(w *wallet.Wallet) string {
+	if app.hideKeys {
+		return ""
+	}
+	return app.displayKey(w)
+}
+
+// lostKeys fails a run whose keystores were not written with --no-key-output, as
+// the keystore was the only copy of those keys; with printed keys it returns nil
+func (app *Application) lostKeys(errs ...error) error {
+	if !app.hideKeys || len(errs) == 0 {
+		return nil
+	}
+	return errors.WrapError(stderrors.Join(errs...), errors.ErrorTypeGeneration, "write_keystore",
+		fmt.Sprintf("%d keystore(s) were not written and --no-key-output printed no keys; those keys are lost", len(errs)))
+}
+
+// displayKey returns a wallet's private key in the --key-format chosen for output.
+// Keystores and the vault always hold the canonical hex form; keys moved into
+// --hardware show their handle instead.
+func (app *Application) displayKey(w *wallet.Wallet) string {
+	if w.KeyHandle != "" && app.hardware != nil {
+		return app.hardware.sealedKeyText(w.KeyHandle)
+	}
+	key, err := crypto.FormatPrivateKeyHex(w.PrivateKey, app.keyFormat)
+	if err != nil {
+		// parseFlags rejected formats the network cannot use, so only a malformed key gets here
+		return w.PrivateKey
+	}
+	return key
+}
+
+// attachPublicKeys fills in a wallet's public keys when --include-pubkey is set
+func (app *Application) attachPublicKeys(w *wallet.Wallet) {
+	if !app.includePubkey || w.PublicKeyCompressed != "" {
+		return
+	}
+	uncompressed, compressed, err := crypto.PublicKeys(w.PrivateKey, w.Network)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to derive the public key of %s: %v\n", w.Address, err)
+		return
+	}
+	w.PublicKey, w.PublicKeyCompressed = uncompressed, compressed
+}
+
+// printPublicKeys prints the public keys of a wallet result when --include-pubkey is set
+func (app *Application) printPublicKeys(w *wallet.Wallet, indent string) {
+	if !app.includePubkey || w.PublicKey == "" {
+		return
+	}
+	fmt.Println(indent + i18n.T("result.public_key", w.PublicKey))
+	if w.PublicKeyCompressed != "" {
+		fmt.Println(indent + i18n.T("result.public_key_comp", w.PublicKeyCompressed))
+	}
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/server"
+	"bloco-eth/pkg/errors"
+)
+
+func TestParseHideKeysFlag(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("no-key-output", false, "")
+	if err := cmd.Flags().Parse([]string{"--no-key-output"}); err != nil {
+		t.Fatal(err)
+	}
+	w := testPipelineWallet(t)
+
+	app := &Application{config: config.DefaultConfig(), keyFormat: "hex"}
+	if err := app.parseHideKeysFlag(cmd); err != nil || !app.hideKeys {
+		t.Fatalf("parseHideKeysFlag() = %v, hideKeys %v", err, app.hideKeys)
+	}
+	if key := app.printedKey(w); key != "" {
+		t.Errorf("printedKey() = %q with --no-key-output", key)
+	}
+	if app.displayKey(w) == "" {
+		t.Error("--no-key-output cleared the key written to files")
+	}
+
+	app = &Application{config: config.DefaultConfig()}
+	app.config.KeyStore.Enabled = false
+	if err := app.parseHideKeysFlag(cmd); ExitCode(err) != ExitConfiguration {
+		t.Errorf("parseHideKeysFlag() with --no-keystore = %v, want a validation error", err)
+	}
+}
+
+func TestNoKeyOutput_KeystoreFailure(t *testing.T) {
+	// Keystore paths through a file are unwritable even for root, while the run lock
+	// still goes in the keystore dir
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "blocked"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) error {
+		app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+		root := app.GetRootCommand()
+		root.SetArgs(append(args, "--no-key-output", "--keystore-dir", dir,
+			"--keystore-path-template", "blocked/{{.Address}}.json", "--quiet", "--threads", "1"))
+		root.SetOut(io.Discard)
+		root.SetErr(io.Discard)
+		return root.Execute()
+	}
+
+	for _, args := range [][]string{{"--prefix", "a"}, {"--prefix", "a", "--count", "2"}} {
+		if err := run(args...); !errors.IsErrorType(err, errors.ErrorTypeGeneration) {
+			t.Errorf("run %v with an unwritable keystore = %v, want a lost keys error", args, err)
+		}
+	}
+
+	coordinator := server.NewKeyspaceCoordinator(time.Minute)
+	api := server.NewAPIServer("127.0.0.1:0", nil, time.Minute)
+	api.SetKeyspaceCoordinator(coordinator)
+	ts := httptest.NewServer(api.Handler())
+	defer ts.Close()
+	ks, err := coordinator.Create("", server.KeyspaceRequest{JobRequest: server.JobRequest{Prefix: "a", Count: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := run("agent", "--server", ts.URL, "--keyspace", ks.ID); !errors.IsErrorType(err, errors.ErrorTypeGeneration) {
+		t.Errorf("agent with an unwritable keystore = %v, want a lost keys error", err)
+	}
+	current, _ := coordinator.Get(ks.ID)
+	if len(current.Addresses) != 0 || len(current.Leases) != 0 {
+		t.Errorf("coordinator recorded addresses %v and leases %v of a lost key", current.Addresses, current.Leases)
+	}
+}
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/checkpoint"
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/retry"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// keyRangeSearch is a --key-range or --master-seed-file search and its
+// --checkpoint bookkeeping
+type keyRangeSearch struct {
+	cursor   *crypto.KeyRangeCursor
+	seed     *crypto.MasterSeed // set for --master-seed-file, whose range holds counters
+	path     string
+	interval time.Duration
+	key      *checkpoint.Key
+	retry    retry.Policy
+	search   checkpoint.Checkpoint
+	resumed  bool
+}
+
+// parseKeyRange reads --key-range and --key-range-stride, or --master-seed-file,
+// and --checkpoint and --checkpoint-key, resuming from the checkpoint when it
+// exists; it returns nil when neither --key-range nor --master-seed-file is set
+func parseKeyRange(cmd *cobra.Command, criteria wallet.GenerationCriteria) (*keyRangeSearch, error) {
+	spec, _ := cmd.Flags().GetString("key-range")
+	seedPath, _ := cmd.Flags().GetString("master-seed-file")
+	path, _ := cmd.Flags().GetString("checkpoint")
+	keySpec, _ := cmd.Flags().GetString("checkpoint-key")
+	if spec == "" && seedPath == "" {
+		if path != "" {
+			return nil, errors.NewValidationError("parse_flags", "--checkpoint records --key-range progress; add --key-range or --master-seed-file")
+		}
+		return nil, nil
+	}
+	if spec != "" && seedPath != "" {
+		return nil, errors.NewValidationError("parse_flags", "--key-range and --master-seed-file both choose the keys searched; use one")
+	}
+	if keySpec != "" && path == "" {
+		return nil, errors.NewValidationError("parse_flags", "--checkpoint-key encrypts --checkpoint; add --checkpoint")
+	}
+
+	mode := "--key-range"
+	if seedPath != "" {
+		mode = "--master-seed-file"
+	}
+	network := strings.ToLower(criteria.Network)
+	switch {
+	case network == "solana":
+		return nil, errors.NewValidationError("parse_flags", mode+" searches secp256k1 scalars; solana keys are Ed25519 seeds")
+	case criteria.UseMnemonic:
+		return nil, errors.NewValidationError("parse_flags", mode+" keys are not derived from a mnemonic; remove --with-mnemonic")
+	}
+	if slip39, _ := cmd.Flags().GetString("slip39"); slip39 != "" {
+		return nil, errors.NewValidationError("parse_flags", "--slip39 backs up a mnemonic, and "+mode+" wallets have none")
+	}
+
+	var keyRange *crypto.KeyRange
+	var seed *crypto.MasterSeed
+	var err error
+	if seedPath != "" {
+		for _, name := range []string{"entropy", "extra-entropy-file", "key-range-stride"} {
+			if cmd.Flags().Changed(name) {
+				return nil, errors.NewValidationError("parse_flags", fmt.Sprintf("--master-seed-file derives every key from the seed; remove --%s", name))
+			}
+		}
+		if seed, err = crypto.ReadMasterSeed(seedPath); err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeValidation, "master_seed", "invalid --master-seed-file")
+		}
+		keyRange = crypto.MasterSeedCounters
+	} else {
+		stride, _ := cmd.Flags().GetUint64("key-range-stride")
+		if keyRange, err = crypto.ParseKeyRange(spec, stride); err != nil {
+			return nil, errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --key-range: %v", err))
+		}
+	}
+	interval, _ := cmd.Flags().GetDuration("checkpoint-interval")
+	if interval <= 0 {
+		return nil, errors.NewValidationError("parse_flags", "--checkpoint-interval must be positive")
+	}
+	if network == "" {
+		network = "ethereum"
+	}
+
+	s := &keyRangeSearch{
+		cursor:   crypto.NewKeyRangeCursor(keyRange),
+		seed:     seed,
+		path:     path,
+		interval: interval,
+		retry:    retry.DefaultFilePolicy,
+		search: checkpoint.Checkpoint{
+			Network:  network,
+			Prefix:   criteria.Prefix,
+			Suffix:   criteria.Suffix,
+			Checksum: criteria.IsChecksum,
+		},
+	}
+	if seed != nil {
+		s.search.Seed = seed.Fingerprint()
+	}
+	if path == "" {
+		return s, nil
+	}
+	if keySpec != "" {
+		if s.key, err = parseCheckpointKey(keySpec); err != nil {
+			return nil, err
+		}
+	}
+
+	saved, err := checkpoint.LoadWith(path, s.key)
+	switch {
+	case os.IsNotExist(err):
+		return s, nil
+	case err != nil:
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "load_checkpoint", "failed to read --checkpoint")
+	case saved.KeyRange == nil:
+		return nil, errors.NewValidationError("load_checkpoint", fmt.Sprintf("%s holds no key range progress", path))
+	}
+	if err := saved.SameSearch(&s.search); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeValidation, "load_checkpoint", "cannot resume --checkpoint")
+	}
+	if err := s.cursor.Restore(*saved.KeyRange); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeValidation, "load_checkpoint", "cannot resume --checkpoint")
+	}
+	s.search.Found = saved.Found
+	s.resumed = true
+	return s, nil
+}
+
+// parseCheckpointKey reads --checkpoint-key: file:<path> holds a passphrase,
+// keyring uses the key kept in the machine keyring
+func parseCheckpointKey(spec string) (*checkpoint.Key, error) {
+	if spec == "keyring" {
+		key, err := checkpoint.KeyringKey()
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "checkpoint_key", "failed to load the checkpoint key")
+		}
+		return key, nil
+	}
+	path, ok := strings.CutPrefix(spec, "file:")
+	if !ok || path == "" {
+		return nil, errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --checkpoint-key %q: use file:<path> or keyring", spec))
+	}
+	passphrase, err := crypto.ReadPasswordFile(path, "")
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "checkpoint_key",
+			fmt.Sprintf("failed to read the checkpoint passphrase from %s", path))
+	}
+	key, err := checkpoint.PassphraseKey(strings.TrimRight(passphrase, "\r\n"))
+	if err != nil {
+		return nil, errors.NewValidationError("parse_flags", err.Error())
+	}
+	return key, nil
+}
+
+// describe prints the range being searched and, when resuming, how far it got
+func (s *keyRangeSearch) describe() {
+	r := s.cursor.Range()
+	if s.seed != nil {
+		fmt.Printf("Master seed: %s (keys derived at counters 0, 1, 2, ...)\n", s.seed.Fingerprint())
+	} else {
+		fmt.Printf("Key range: %s (%s keys, stride %s)\n", r, r.Size(), r.Stride)
+	}
+	if s.resumed {
+		fmt.Printf("Resuming from %s: %s keys checked (%.4g%%)\n", s.path, s.cursor.Checked(), s.cursor.Coverage())
+	}
+}
+
+// watch saves the checkpoint every interval until the returned stop is called
+func (s *keyRangeSearch) watch(ctx context.Context, found func() int) (stop func()) {
+	if s.path == "" {
+		return func() {}
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.save(found()); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// save writes the checkpoint with the wallets found by this run so far
+func (s *keyRangeSearch) save(found int) error {
+	if s.path == "" {
+		return nil
+	}
+	c := s.search
+	c.Found += found
+	state := s.cursor.State()
+	c.KeyRange = &state
+	if err := retry.Do(context.Background(), s.retry, func() error { return checkpoint.SaveWith(s.path, &c, s.key) }, nil); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "save_checkpoint", "failed to save --checkpoint")
+	}
+	return nil
+}
+
+// report prints the range coverage at the end of a run
+func (s *keyRangeSearch) report(quiet bool, found, count int) {
+	if quiet && found >= count {
+		return
+	}
+	coverage := fmt.Sprintf("Key range coverage: %s of %s keys checked (%.4g%%)",
+		s.cursor.Checked(), s.cursor.Range().Size(), s.cursor.Coverage())
+	if s.seed != nil {
+		coverage = fmt.Sprintf("Master seed counters checked: %s", s.cursor.Checked())
+	}
+	if s.cursor.Exhausted() {
+		coverage += ", range exhausted"
+	}
+	if found < count {
+		fmt.Fprintln(os.Stderr, coverage)
+		return
+	}
+	fmt.Println(coverage)
+}
@@ -0,0 +1,408 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+)
+
+// createKeystoreCommand creates the keystore subcommand group
+func (app *Application) createKeystoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keystore",
+		Short: "Inspect and maintain keystore directories",
+		Long:  "Tools for checking keystore files written by bloco-eth.",
+	}
+
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Check the integrity of every keystore in a directory",
+		Long: `Check every JSON keystore in a directory:
+
+  schema         the file is a valid KeyStore V3 document
+  mac            the MAC verifies with the password from the matching password file
+  address        the decrypted private key belongs to the keystore address
+  permissions    keystore, password and mnemonic files are mode 0600
+  filename       the filename matches the keystore address
+  password_file  every keystore has a .pwd, .pwd.gpg or .pwd.age file
+
+Password, mnemonic and key files without a keystore are reported as orphans.
+Wrapped password files are decrypted with gpg, or with age and --age-identity.
+The command exits with an error when any check fails.`,
+		Example: `  bloco-eth keystore audit --dir ./keystores
+  bloco-eth keystore audit --dir ./keystores --format json > audit.json
+  bloco-eth keystore audit --no-verify --report audit.json`,
+		Args: cobra.NoArgs,
+		RunE: app.runKeystoreAudit,
+	}
+	auditCmd.Flags().String("dir", "", "Keystore directory to audit (default: --keystore-dir)")
+	auditCmd.Flags().Bool("no-verify", false, "Skip MAC verification (avoids running the KDF for each keystore)")
+	auditCmd.Flags().String("report", "", "Also write the JSON report to this file")
+	auditCmd.Flags().String("age-identity", "", "age identity file for .pwd.age password files")
+
+	inspectCmd := &cobra.Command{
+		Use:   "inspect <keystore.json>",
+		Short: "Show keystore parameters and verify its password",
+		Long: `Show the address, cipher and KDF parameters of a keystore and where its
+password is stored. With --verify the password is read (decrypting .pwd.gpg and
+.pwd.age files) and checked against the keystore MAC. No key material is printed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: app.runKeystoreInspect,
+	}
+	inspectCmd.Flags().Bool("verify", false, "Verify the password against the keystore MAC")
+	inspectCmd.Flags().String("password-file", "", "Password file to use instead of the one next to the keystore")
+	inspectCmd.Flags().String("age-identity", "", "age identity file for .pwd.age password files")
+
+	decryptCmd := &cobra.Command{
+		Use:   "decrypt <keystore.json>",
+		Short: "Decrypt a keystore and print its private key",
+		Long: `Decrypt a keystore with the password stored next to it (plain, gpg or age
+wrapped) or with --password-file, and print the hex private key to stdout.`,
+		Args: cobra.ExactArgs(1),
+		RunE: app.runKeystoreDecrypt,
+	}
+	decryptCmd.Flags().String("password-file", "", "Password file to use instead of the one next to the keystore")
+	decryptCmd.Flags().String("age-identity", "", "age identity file for .pwd.age password files")
+
+	rotateCmd := &cobra.Command{
+		Use:   "rotate-passwords",
+		Short: "Regenerate weak keystore passwords and re-encrypt their keystores",
+		Long: `Estimate the entropy of the password of every keystore in a directory and,
+for passwords below --min-entropy, generate a new random password and re-encrypt
+the keystore with the same cipher and KDF parameters.
+
+Each keystore and its new password file are staged next to the originals and
+renamed into place, keystore first; a run interrupted by a crash is finished or
+undone by the next one. New password files are wrapped with --password-protection,
+and gpg or age wrapped password files are only rotated when it is set.
+
+New passwords follow --keystore-password-policy when it is set, and are otherwise
+--length random characters. The report maps every keystore to its old and new
+password file and entropy; it never contains passwords.`,
+		Example: `  bloco-eth keystore rotate-passwords --dir ./keystores --dry-run
+  bloco-eth keystore rotate-passwords --dir ./keystores --report rotation.json
+  bloco-eth keystore rotate-passwords --min-entropy 80 --length 24 --password-protection age:age1...`,
+		Args: cobra.NoArgs,
+		RunE: app.runKeystoreRotatePasswords,
+	}
+	rotateCmd.Flags().String("dir", "", "Keystore directory to rotate (default: --keystore-dir)")
+	rotateCmd.Flags().Float64("min-entropy", crypto.DefaultMinPasswordEntropy, "Regenerate passwords with fewer bits of entropy than this")
+	rotateCmd.Flags().Int("length", crypto.DefaultRotatedPasswordLength, "Length of the regenerated random passwords (ignored with --keystore-password-policy)")
+	rotateCmd.Flags().Bool("dry-run", false, "Report the weak passwords without changing any file")
+	rotateCmd.Flags().String("report", "", "Also write the JSON mapping report to this file")
+	rotateCmd.Flags().String("age-identity", "", "age identity file for .pwd.age password files")
+
+	cmd.AddCommand(auditCmd, inspectCmd, decryptCmd, rotateCmd)
+	return cmd
+}
+
+// runKeystoreAudit audits a keystore directory and prints the report
+func (app *Application) runKeystoreAudit(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	if dir == "" {
+		dir, _ = cmd.Flags().GetString("keystore-dir")
+	}
+	noVerify, _ := cmd.Flags().GetBool("no-verify")
+	format, _ := cmd.Flags().GetString("format")
+	reportPath, _ := cmd.Flags().GetString("report")
+	ageIdentity, _ := cmd.Flags().GetString("age-identity")
+
+	report, err := crypto.AuditKeystoreDirectory(dir, crypto.KeystoreAuditOptions{
+		VerifyMAC:   !noVerify,
+		AgeIdentity: ageIdentity,
+	})
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"keystore_audit", fmt.Sprintf("failed to audit %s", dir))
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "keystore_audit", "failed to encode report")
+	}
+	if reportPath != "" {
+		if err := os.WriteFile(reportPath, append(data, '\n'), 0600); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"keystore_audit", fmt.Sprintf("failed to write report %s", reportPath))
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		fmt.Fprintln(out, string(data))
+	} else {
+		printKeystoreAudit(out, report)
+	}
+
+	if !report.OK() {
+		return errors.NewValidationError("keystore_audit",
+			fmt.Sprintf("%d keystore(s) failed and %d orphaned file(s) found", report.Failed, len(report.Orphans)))
+	}
+	return nil
+}
+
+// printKeystoreAudit writes a human-readable audit summary
+func printKeystoreAudit(out io.Writer, report *crypto.KeystoreAuditReport) {
+	fmt.Fprintf(out, "Keystore audit: %s\n", report.Directory)
+	if !report.MACVerified {
+		fmt.Fprintln(out, "MAC verification skipped (--no-verify)")
+	}
+
+	for _, result := range report.Results {
+		if result.OK || result.Skipped != "" {
+			continue
+		}
+		fmt.Fprintf(out, "  FAIL %s\n", result.File)
+		for _, issue := range result.Issues {
+			fmt.Fprintf(out, "       %-13s %s: %s\n", issue.Check, issue.File, issue.Message)
+		}
+	}
+	for _, orphan := range report.Orphans {
+		fmt.Fprintf(out, "  ORPHAN %s: %s\n", orphan.File, orphan.Message)
+	}
+
+	fmt.Fprintf(out, "\nKeystores: %d  Passed: %d  Failed: %d  Skipped: %d  Orphans: %d\n",
+		report.Keystores, report.Passed, report.Failed, report.Skipped, len(report.Orphans))
+}
+
+// runKeystoreRotatePasswords regenerates the weak passwords of a keystore directory
+// and prints the mapping report
+func (app *Application) runKeystoreRotatePasswords(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("dir")
+	if dir == "" {
+		dir, _ = cmd.Flags().GetString("keystore-dir")
+	}
+	minEntropy, _ := cmd.Flags().GetFloat64("min-entropy")
+	length, _ := cmd.Flags().GetInt("length")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	format, _ := cmd.Flags().GetString("format")
+	reportPath, _ := cmd.Flags().GetString("report")
+	ageIdentity, _ := cmd.Flags().GetString("age-identity")
+	if minEntropy <= 0 || length <= 0 {
+		return errors.NewValidationError("keystore_rotate", "--min-entropy and --length must be positive")
+	}
+
+	spec := app.config.KeyStore.PasswordProtection
+	if cmd.Flags().Changed("password-protection") {
+		spec, _ = cmd.Flags().GetString("password-protection")
+	}
+	protection, err := crypto.ParsePasswordProtection(spec)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "keystore_rotate", "invalid --password-protection")
+	}
+	if !dryRun {
+		if err := protection.CheckAvailable(); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "keystore_rotate", "password protection is unavailable")
+		}
+	}
+
+	var policy crypto.PasswordPolicy
+	if cmd.Flags().Changed("keystore-password-policy") {
+		if cmd.Flags().Changed("length") {
+			return errors.NewValidationError("keystore_rotate", "--length cannot be combined with --keystore-password-policy")
+		}
+		spec, _ := cmd.Flags().GetString("keystore-password-policy")
+		if policy, err = crypto.ParsePasswordPolicy(spec); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeValidation, "keystore_rotate", "invalid --keystore-password-policy")
+		}
+	}
+
+	report, err := crypto.RotatePasswords(dir, crypto.PasswordRotationOptions{
+		MinEntropy:  minEntropy,
+		Length:      length,
+		Policy:      policy,
+		Protection:  protection,
+		AgeIdentity: ageIdentity,
+		DryRun:      dryRun,
+	})
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation,
+			"keystore_rotate", fmt.Sprintf("failed to rotate passwords in %s", dir))
+	}
+	for _, result := range report.Results {
+		if result.Action == crypto.RotationRotated || result.Action == crypto.RotationFailed {
+			var resultErr error
+			if result.Action == crypto.RotationFailed {
+				resultErr = fmt.Errorf("%s", result.Message)
+			}
+			app.auditKeystoreAccess("keystore_rotate_password", filepath.Join(dir, result.File), result.Address, resultErr)
+		}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "keystore_rotate", "failed to encode report")
+	}
+	if reportPath != "" {
+		if err := os.WriteFile(reportPath, append(data, '\n'), 0600); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"keystore_rotate", fmt.Sprintf("failed to write report %s", reportPath))
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	if format == "json" {
+		fmt.Fprintln(out, string(data))
+	} else {
+		printPasswordRotation(out, report)
+	}
+
+	if report.Failed > 0 {
+		return errors.NewValidationError("keystore_rotate", fmt.Sprintf("%d keystore(s) could not be rotated", report.Failed))
+	}
+	return nil
+}
+
+// printPasswordRotation writes a human-readable rotation summary
+func printPasswordRotation(out io.Writer, report *crypto.PasswordRotationReport) {
+	fmt.Fprintf(out, "Password rotation: %s (minimum %.0f bits)\n", report.Directory, report.MinEntropy)
+	fmt.Fprintf(out, "New passwords: %s (%.0f bits of entropy each)\n", report.Policy, report.PolicyEntropy)
+	if report.DryRun {
+		fmt.Fprintln(out, "Dry run: no files were changed")
+	}
+
+	for _, result := range report.Results {
+		switch result.Action {
+		case crypto.RotationKept:
+			continue
+		case crypto.RotationRotated, crypto.RotationWould:
+			fmt.Fprintf(out, "  %-12s %s: %s (%.1f bits) -> %s\n", strings.ToUpper(result.Action), result.File,
+				result.PasswordFile, result.Entropy, result.NewPasswordFile)
+		default:
+			fmt.Fprintf(out, "  %-12s %s: %s\n", strings.ToUpper(result.Action), result.File, result.Message)
+		}
+	}
+
+	fmt.Fprintf(out, "\nKeystores: %d  Rotated: %d  Kept: %d  Skipped: %d  Failed: %d\n",
+		report.Keystores, report.Rotated, report.Kept, report.Skipped, report.Failed)
+}
+
+// runKeystoreInspect prints keystore parameters and optionally verifies its password
+func (app *Application) runKeystoreInspect(cmd *cobra.Command, args []string) (err error) {
+	keystore, err := readKeystoreFile(args[0])
+	if err != nil {
+		app.auditKeystoreAccess("keystore_inspect", args[0], "", err)
+		return err
+	}
+	defer func() {
+		app.auditKeystoreAccess("keystore_inspect", args[0], keystore.Address, err)
+	}()
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "File:     %s\n", args[0])
+	fmt.Fprintf(out, "Address:  0x%s\n", strings.TrimPrefix(keystore.Address, "0x"))
+	fmt.Fprintf(out, "Version:  %d\n", keystore.Version)
+	fmt.Fprintf(out, "Cipher:   %s\n", keystore.Crypto.Cipher)
+	fmt.Fprintf(out, "KDF:      %s\n", keystore.Crypto.KDF)
+	if params, ok := keystore.Crypto.KDFParams.(map[string]interface{}); ok {
+		keys := make([]string, 0, len(params))
+		for key := range params {
+			if key != "salt" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(out, "  %-6s  %v\n", key, params[key])
+		}
+	}
+
+	passwordPath, found := keystorePasswordPath(cmd, args[0])
+	if !found {
+		fmt.Fprintln(out, "Password: not found")
+	} else {
+		fmt.Fprintf(out, "Password: %s (%s)\n", passwordPath, crypto.PasswordFileProtection(passwordPath))
+	}
+
+	if verify, _ := cmd.Flags().GetBool("verify"); verify {
+		if _, err := decryptKeystore(cmd, args[0], keystore); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "MAC:      verified")
+	}
+	return nil
+}
+
+// runKeystoreDecrypt prints the private key held in a keystore
+func (app *Application) runKeystoreDecrypt(cmd *cobra.Command, args []string) error {
+	keystore, err := readKeystoreFile(args[0])
+	if err != nil {
+		app.auditKeystoreAccess("keystore_decrypt", args[0], "", err)
+		return err
+	}
+
+	privateKey, err := decryptKeystore(cmd, args[0], keystore)
+	// Record the decryption before the key is printed
+	app.auditKeystoreAccess("keystore_decrypt", args[0], keystore.Address, err)
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearSensitiveData(privateKey)
+
+	format, err := keyFormatFlag(cmd, "")
+	if err != nil {
+		return err
+	}
+	key, err := crypto.FormatPrivateKey(privateKey, format)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "keystore_decrypt", "invalid --key-format for this key")
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), key)
+	return nil
+}
+
+// readKeystoreFile loads and validates a KeyStore V3 file
+func readKeystoreFile(path string) (*crypto.KeyStoreV3, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeValidation,
+			"read_keystore", fmt.Sprintf("failed to read %s", path))
+	}
+	keystore, err := crypto.FromJSON(data)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeValidation,
+			"read_keystore", fmt.Sprintf("%s is not a valid keystore", path))
+	}
+	return keystore, nil
+}
+
+// keystorePasswordPath returns --password-file or the password file next to the keystore
+func keystorePasswordPath(cmd *cobra.Command, keystorePath string) (string, bool) {
+	if path, _ := cmd.Flags().GetString("password-file"); path != "" {
+		return path, true
+	}
+	return crypto.FindPasswordFile(filepath.Dir(keystorePath),
+		strings.TrimSuffix(filepath.Base(keystorePath), filepath.Ext(keystorePath)))
+}
+
+// decryptKeystore reads the keystore password, unwrapping it if needed, and decrypts the key
+func decryptKeystore(cmd *cobra.Command, keystorePath string, keystore *crypto.KeyStoreV3) ([]byte, error) {
+	passwordPath, found := keystorePasswordPath(cmd, keystorePath)
+	if !found {
+		return nil, errors.NewValidationError("decrypt_keystore",
+			fmt.Sprintf("no password file found for %s; use --password-file", keystorePath))
+	}
+
+	ageIdentity, _ := cmd.Flags().GetString("age-identity")
+	password, err := crypto.ReadPasswordFile(passwordPath, ageIdentity)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeValidation,
+			"decrypt_keystore", fmt.Sprintf("failed to read password from %s", passwordPath))
+	}
+
+	privateKey, err := crypto.DecryptPrivateKey(keystore, password)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeValidation,
+			"decrypt_keystore", fmt.Sprintf("failed to decrypt %s", keystorePath))
+	}
+	return privateKey, nil
+}
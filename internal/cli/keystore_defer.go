@@ -0,0 +1,344 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/faultfs"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// KeystoreManifestName is the manifest --keystore-defer writes next to the keystores
+const KeystoreManifestName = "keystore-manifest.json"
+
+// KeystoreManifest lists the files a --keystore-defer run wrote, for integrity checks
+type KeystoreManifest struct {
+	Version   int            `json:"version"`
+	CreatedAt time.Time      `json:"created_at"`
+	Files     []ManifestFile `json:"files"`
+}
+
+// ManifestFile is one written file with its SHA-256 checksum
+type ManifestFile struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+	SHA256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+}
+
+// heldWallet is a found wallet whose secrets wait in locked memory
+type heldWallet struct {
+	wallet     wallet.Wallet
+	privateKey *crypto.LockedBuffer
+	mnemonic   *crypto.LockedBuffer
+}
+
+func (h *heldWallet) destroy() {
+	h.privateKey.Destroy()
+	h.mnemonic.Destroy()
+}
+
+// deferredKeystores holds the keys found by a --keystore-defer run and writes all
+// their keystores in one phase: at the end of the run, or when SIGUSR1 asks for
+// it. Each phase encrypts into a staging directory, moves the files into place
+// together and rewrites the manifest of every file the run has written.
+//
+// With --atomic-output the run's wallets are written in a single phase at its end,
+// all or none: a failed write removes the files already moved, and a failed or
+// cancelled run discards the held keys without writing anything.
+type deferredKeystores struct {
+	app      *Application
+	atomic   bool
+	mu       sync.Mutex
+	held     []*heldWallet
+	unlocked int
+	files    []ManifestFile
+	finished bool
+	stop     func()
+}
+
+// newDeferredKeystores starts holding keys, flushing them on SIGUSR1 where supported
+func (app *Application) newDeferredKeystores() *deferredKeystores {
+	d := &deferredKeystores{app: app}
+	d.stop = notifyFlush(func() {
+		if err := d.flush(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: deferred keystore write failed: %v\n", err)
+		}
+	})
+	return d
+}
+
+// newAtomicOutput holds the wallets of an --atomic-output run; they are only
+// written when the run completes, never on SIGUSR1
+func (app *Application) newAtomicOutput() *deferredKeystores {
+	return &deferredKeystores{app: app, atomic: true, stop: func() {}}
+}
+
+// Add copies the wallet's secrets into locked memory until the next flush
+func (d *deferredKeystores) Add(w *wallet.Wallet) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.finished {
+		return d.app.writeKeystoreFiles(d.app.traceContext(), w, false, d.app.config.KeyStore.OutputDir)
+	}
+
+	h := &heldWallet{wallet: *w}
+	h.wallet.PrivateKey, h.wallet.Mnemonic = "", ""
+	var err error
+	if h.privateKey, err = crypto.NewLockedBuffer([]byte(w.PrivateKey)); err != nil {
+		return err
+	}
+	if h.mnemonic, err = crypto.NewLockedBuffer([]byte(w.Mnemonic)); err != nil {
+		h.privateKey.Destroy()
+		return err
+	}
+	if !h.privateKey.Locked() {
+		d.unlocked++
+	}
+	d.held = append(d.held, h)
+	return nil
+}
+
+// flush writes the keystores of every held wallet and updates the manifest
+func (d *deferredKeystores) flush() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.flushLocked()
+}
+
+func (d *deferredKeystores) flushLocked() error {
+	if len(d.held) == 0 {
+		return nil
+	}
+	held := d.held
+	d.held = nil
+	defer func() {
+		for _, h := range held {
+			h.destroy()
+		}
+	}()
+	if d.app.vault != nil {
+		return d.flushToVault(held)
+	}
+	label := "Deferred keystores"
+	if d.atomic {
+		label = "Atomic output"
+	}
+
+	outputDir := d.app.config.KeyStore.OutputDir
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "keystore_defer", "failed to create the keystore directory")
+	}
+	staging, err := os.MkdirTemp(outputDir, ".keystore-defer-*")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "keystore_defer", "failed to create the staging directory")
+	}
+	defer os.RemoveAll(staging)
+
+	// Encrypt everything before moving anything, so the files appear together
+	var failures []error
+	written, failed := 0, 0
+	for i, h := range held {
+		w := h.wallet
+		w.PrivateKey, w.Mnemonic = string(h.privateKey.Bytes()), string(h.mnemonic.Bytes())
+		if err := d.app.writeKeystoreFiles(d.app.traceContext(), &w, false, filepath.Join(staging, strconv.Itoa(i))); err != nil {
+			failures = append(failures, err)
+			failed++
+		}
+	}
+	if d.atomic && failed > 0 {
+		d.app.auditRollback(len(held))
+		return errors.WrapError(stderrors.Join(failures...), errors.ErrorTypeCrypto, "atomic_output",
+			fmt.Sprintf("%d of %d wallets could not be written; none were saved", failed, len(held)))
+	}
+
+	kept := len(d.files)
+	var moved []string
+	for i, h := range held {
+		dir := filepath.Join(staging, strconv.Itoa(i))
+		// Files sit in subdirectories with --keystore-path-template
+		_ = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || !entry.Type().IsRegular() {
+				return nil // nothing was written for a wallet that failed early
+			}
+			name, _ := filepath.Rel(dir, path)
+			file, err := checksumFile(path)
+			if err != nil {
+				failures = append(failures, err)
+				return nil
+			}
+			file.Name, file.Address = filepath.ToSlash(name), h.wallet.Address
+			target := filepath.Join(outputDir, name)
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				failures = append(failures, fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err))
+				return nil
+			}
+			if err := faultfs.Rename(path, target); err != nil {
+				failures = append(failures, fmt.Errorf("failed to move %s into %s: %w", name, outputDir, err))
+				return nil
+			}
+			d.files = append(d.files, file)
+			moved = append(moved, target)
+			written++
+			return nil
+		})
+	}
+
+	var manifestPath string
+	if !d.atomic || len(failures) == 0 {
+		if manifestPath, err = d.writeManifest(outputDir); err != nil {
+			failures = append(failures, err)
+		}
+	}
+	if d.atomic && len(failures) > 0 {
+		for _, target := range moved {
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				failures = append(failures, fmt.Errorf("failed to roll back %s: %w", target, err))
+			}
+		}
+		d.files = d.files[:kept]
+		d.app.auditRollback(len(held))
+		return errors.WrapError(stderrors.Join(failures...), errors.ErrorTypeCrypto, "atomic_output",
+			fmt.Sprintf("failed to write the run's %d wallets; the %d files already moved were removed", len(held), len(moved)))
+	}
+	if !d.app.config.CLI.QuietMode {
+		fmt.Printf("%s: wrote %d files for %d wallets to %s (manifest %s)\n",
+			label, written, len(held)-failed, outputDir, manifestPath)
+		if d.unlocked > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d keys could not be locked in memory and may have been swapped; raise the locked memory limit (ulimit -l)\n", d.unlocked)
+			d.unlocked = 0
+		}
+	}
+	if len(failures) > 0 {
+		return errors.WrapError(stderrors.Join(failures...), errors.ErrorTypeCrypto, "keystore_defer",
+			fmt.Sprintf("%d deferred keystore writes failed", len(failures)))
+	}
+	return nil
+}
+
+// flushToVault stores every held wallet in the vault with a single write
+func (d *deferredKeystores) flushToVault(held []*heldWallet) error {
+	entries := make([]crypto.VaultEntry, len(held))
+	for i, h := range held {
+		w := h.wallet
+		w.PrivateKey, w.Mnemonic = string(h.privateKey.Bytes()), string(h.mnemonic.Bytes())
+		entries[i] = vaultEntry(&w)
+	}
+	err := d.app.vault.AddAll(entries)
+	for i := range held {
+		d.app.auditKeystoreWrite(&held[i].wallet, err)
+	}
+	if err != nil {
+		d.app.auditRollback(len(held))
+		return errors.WrapError(err, errors.ErrorTypeCrypto, "atomic_output",
+			fmt.Sprintf("failed to store the run's %d wallets in the vault; none were saved", len(held)))
+	}
+	if !d.app.config.CLI.QuietMode {
+		fmt.Printf("Atomic output: stored %d wallets in %s\n", len(held), d.app.vault.Path())
+	}
+	return nil
+}
+
+// discard drops every held wallet without writing it and stops holding new keys,
+// returning how many were dropped
+func (d *deferredKeystores) discard() int {
+	d.stop()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	dropped := len(d.held)
+	for _, h := range d.held {
+		h.destroy()
+	}
+	d.held = nil
+	d.finished = true
+	return dropped
+}
+
+// finish writes the remaining keystores and stops holding new keys; later
+// wallets are written directly
+func (d *deferredKeystores) finish() error {
+	d.stop()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	err := d.flushLocked()
+	d.finished = true
+	return err
+}
+
+// settleOutput writes or discards the held keys once generation has stopped. Found
+// keys are written even when the run stopped early, unless --atomic-output asks for
+// all of them or none; a rollback always fails the run with a partial result, even
+// with --fail-on-timeout=false, so nothing downstream acts on the discarded keys.
+func (app *Application) settleOutput(found, count int, err error) (bool, error) {
+	d := app.deferredKeystores
+	if d == nil {
+		return false, err
+	}
+	if !d.atomic || (err == nil && found >= count) {
+		if flushErr := d.finish(); flushErr != nil {
+			if err == nil {
+				err = flushErr
+			}
+			return d.atomic, err
+		}
+		return false, err
+	}
+
+	dropped := d.discard()
+	app.auditRollback(dropped)
+	if !app.config.CLI.QuietMode {
+		fmt.Fprintf(os.Stderr, "Atomic output: the run stopped after %d of %d wallets; discarded them without writing anything\n", found, count)
+	}
+	if err == nil {
+		err = errors.NewBlocoError(errors.ErrorTypeTimeout, "atomic_output",
+			fmt.Sprintf("the run stopped after %d of %d wallets and --atomic-output discarded them", found, count))
+	}
+	return true, err
+}
+
+// writeManifest atomically replaces the manifest with every file written so far
+func (d *deferredKeystores) writeManifest(outputDir string) (string, error) {
+	sort.Slice(d.files, func(i, j int) bool { return d.files[i].Name < d.files[j].Name })
+	data, err := json.MarshalIndent(KeystoreManifest{Version: 1, CreatedAt: time.Now().UTC(), Files: d.files}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(outputDir, KeystoreManifestName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return "", fmt.Errorf("failed to write keystore manifest: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("failed to write keystore manifest: %w", err)
+	}
+	return path, nil
+}
+
+// checksumFile returns the manifest entry of the file at path
+func checksumFile(path string) (ManifestFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, f)
+	if err != nil {
+		return ManifestFile{}, fmt.Errorf("failed to checksum %s: %w", path, err)
+	}
+	return ManifestFile{Name: filepath.Base(path), SHA256: hex.EncodeToString(hash.Sum(nil)), Size: size}, nil
+}
@@ -0,0 +1,8 @@
+//go:build !unix
+
+package cli
+
+// notifyFlush has no on-demand signal here; keystores are written at the end of the run
+func notifyFlush(func()) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/faultfs"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestDeferredKeystores(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.Enabled = true
+	cfg.KeyStore.OutputDir = t.TempDir()
+	cfg.KeyStore.KDFAlgorithm = "pbkdf2"
+	cfg.KeyStore.KDFParams = map[string]interface{}{"c": 100000, "prf": "hmac-sha256", "dklen": 32}
+	cfg.CLI.QuietMode = true
+	app := &Application{config: cfg}
+
+	deferred := app.newDeferredKeystores()
+	wallets := []*wallet.Wallet{testPipelineWallet(t), testPipelineWallet(t)}
+	for _, w := range wallets {
+		if err := deferred.Add(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w := wallets[0]; w.PrivateKey == "" {
+		t.Error("Add cleared the caller's wallet")
+	}
+	if entries, _ := os.ReadDir(cfg.KeyStore.OutputDir); len(entries) != 0 {
+		t.Fatalf("%d files written before the flush", len(entries))
+	}
+
+	if err := deferred.finish(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(cfg.KeyStore.OutputDir, KeystoreManifestName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest KeystoreManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if len(manifest.Files) != 4 {
+		t.Fatalf("manifest lists %d files, want a keystore and password per wallet: %+v", len(manifest.Files), manifest.Files)
+	}
+	for _, file := range manifest.Files {
+		content, err := os.ReadFile(filepath.Join(cfg.KeyStore.OutputDir, file.Name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != file.SHA256 || int64(len(content)) != file.Size {
+			t.Errorf("%s does not match its manifest entry", file.Name)
+		}
+		if !strings.HasPrefix(file.Name, file.Address) {
+			t.Errorf("%s is attributed to %s", file.Name, file.Address)
+		}
+	}
+	entries, _ := os.ReadDir(cfg.KeyStore.OutputDir)
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".keystore-defer-") {
+			t.Errorf("staging directory %s was left behind", entry.Name())
+		}
+	}
+
+	// After the final phase wallets are written directly
+	late := testPipelineWallet(t)
+	if err := deferred.Add(late); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.KeyStore.OutputDir, late.Address+".json")); err != nil {
+		t.Error(err)
+	}
+	if err := deferred.finish(); err != nil {
+		t.Errorf("second finish: %v", err)
+	}
+}
+
+func TestAtomicOutput(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.Enabled = true
+	cfg.KeyStore.OutputDir = t.TempDir()
+	cfg.KeyStore.KDFAlgorithm = "pbkdf2"
+	cfg.KeyStore.KDFParams = map[string]interface{}{"c": 100000, "prf": "hmac-sha256", "dklen": 32}
+	cfg.CLI.QuietMode = true
+	app := &Application{config: cfg}
+	wallets := []*wallet.Wallet{testPipelineWallet(t), testPipelineWallet(t)}
+
+	// A cancelled run writes nothing
+	atomic := app.newAtomicOutput()
+	for _, w := range wallets {
+		if err := atomic.Add(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dropped := atomic.discard(); dropped != 2 {
+		t.Errorf("discard() = %d, want 2", dropped)
+	}
+	if entries, _ := os.ReadDir(cfg.KeyStore.OutputDir); len(entries) != 0 {
+		t.Fatalf("%d files written by a discarded run", len(entries))
+	}
+
+	// A failed move removes the files moved before it
+	atomic = app.newAtomicOutput()
+	for _, w := range wallets {
+		if err := atomic.Add(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	restore := faultfs.Inject(faultfs.Fault{Op: faultfs.OpRename, Path: filepath.Join(cfg.KeyStore.OutputDir, wallets[1].Address+".json"), Err: syscall.ENOSPC})
+	err := atomic.finish()
+	restore()
+	if err == nil {
+		t.Fatal("finish() succeeded despite a failed move")
+	}
+	if entries, _ := os.ReadDir(cfg.KeyStore.OutputDir); len(entries) != 0 {
+		t.Errorf("%d files left after the rollback", len(entries))
+	}
+
+	// A complete run writes every wallet
+	atomic = app.newAtomicOutput()
+	for _, w := range wallets {
+		if err := atomic.Add(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := atomic.finish(); err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range wallets {
+		if _, err := os.Stat(filepath.Join(cfg.KeyStore.OutputDir, w.Address+".json")); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestAtomicOutput_Vault(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.Enabled = true
+	cfg.CLI.QuietMode = true
+	vault, err := crypto.CreateVault(filepath.Join(t.TempDir(), "wallets.vault"), "correct horse battery staple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &Application{config: cfg, vault: vault}
+
+	atomic := app.newAtomicOutput()
+	held := []*wallet.Wallet{testPipelineWallet(t), testPipelineWallet(t)}
+	for _, w := range held {
+		if err := atomic.Add(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(vault.Entries()) != 0 {
+		t.Fatal("wallets stored before the run completed")
+	}
+	if err := atomic.finish(); err != nil {
+		t.Fatal(err)
+	}
+	entries := vault.Entries()
+	if len(entries) != 2 || entries[1].Address != held[1].Address || entries[1].PrivateKey != held[1].PrivateKey {
+		t.Errorf("vault entries = %+v", entries)
+	}
+}
+
+func TestSettleOutput_AtomicWithoutFailOnTimeout(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.Enabled = true
+	cfg.KeyStore.OutputDir = t.TempDir()
+	cfg.CLI.QuietMode = true
+	app := &Application{config: cfg, timeout: time.Millisecond, failOnTimeout: false}
+	app.deferredKeystores = app.newAtomicOutput()
+	if err := app.deferredKeystores.Add(testPipelineWallet(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	// --fail-on-timeout=false accepts the partial run, but the rollback must not
+	ctx := context.Background()
+	expired, cancel := context.WithTimeout(ctx, time.Nanosecond)
+	defer cancel()
+	<-expired.Done()
+	err := app.generationOutcome(ctx, expired, nil, 1, 2, nil)
+	if err != nil {
+		t.Fatalf("generationOutcome() = %v, want the partial run accepted", err)
+	}
+	rolledBack, err := app.settleOutput(1, 2, err)
+	if !rolledBack {
+		t.Error("settleOutput() kept the keys of a partial atomic run")
+	}
+	if code := ExitCode(err); code != ExitPartial {
+		t.Errorf("rolled back run: exit code %d (%v), want %d", code, err, ExitPartial)
+	}
+	if entries, _ := os.ReadDir(cfg.KeyStore.OutputDir); len(entries) != 0 {
+		t.Errorf("%d files written by a rolled back run", len(entries))
+	}
+}
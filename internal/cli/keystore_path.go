@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/server"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// keystorePathFields are the fields of --keystore-path-template. Every value is
+// safe as a path element: addresses, hex or base58 patterns, digits and label slugs.
+type keystorePathFields struct {
+	Address string // as in default filenames: 0x<lowercase hex> for Ethereum
+	Network string
+	Prefix  string
+	Suffix  string
+	Pattern string // prefix and suffix joined by "-", or "any"
+	Label   string // the label slug, or "unlabeled"
+	Year    string
+	Month   string
+	Day     string
+	Date    string // YYYY-MM-DD
+}
+
+// newKeystorePathFields returns the template fields of w found for criteria
+func newKeystorePathFields(w *wallet.Wallet, criteria wallet.GenerationCriteria) keystorePathFields {
+	network := strings.ToLower(w.Network)
+	if network == "" {
+		network = "ethereum"
+	}
+	createdAt := w.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	createdAt = createdAt.UTC()
+
+	label := crypto.LabelSlug(w.Label)
+	if label == "" {
+		label = "unlabeled"
+	}
+	return keystorePathFields{
+		Address: crypto.FormatAddressForFilename(w.Address, network),
+		Network: network,
+		Prefix:  criteria.Prefix,
+		Suffix:  criteria.Suffix,
+		Pattern: patternName(criteria),
+		Label:   label,
+		Year:    createdAt.Format("2006"),
+		Month:   createdAt.Format("01"),
+		Day:     createdAt.Format("02"),
+		Date:    createdAt.Format("2006-01-02"),
+	}
+}
+
+// patternName returns the prefix and suffix of criteria joined by "-", or "any"
+func patternName(criteria wallet.GenerationCriteria) string {
+	var parts []string
+	for _, part := range []string{criteria.Prefix, criteria.Suffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "any"
+	}
+	return strings.Join(parts, "-")
+}
+
+// parseKeystorePathTemplate reads --keystore-path-template, checking it against a
+// sample wallet so that a bad template fails before the search starts
+func parseKeystorePathTemplate(cmd *cobra.Command) (*template.Template, error) {
+	spec, _ := cmd.Flags().GetString("keystore-path-template")
+	if spec == "" {
+		return nil, nil
+	}
+	if labelFilenames, _ := cmd.Flags().GetBool("label-filenames"); labelFilenames {
+		return nil, errors.NewValidationError("parse_flags",
+			"--label-filenames and --keystore-path-template both name keystore files; use {{.Label}} in the template")
+	}
+	tmpl, err := template.New("keystore-path").Option("missingkey=error").Parse(spec)
+	if err != nil {
+		return nil, errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --keystore-path-template: %v", err))
+	}
+	sample := &wallet.Wallet{Address: "0x" + strings.Repeat("ab", 20), Label: "sample"}
+	if _, err := renderKeystorePath(tmpl, newKeystorePathFields(sample, wallet.GenerationCriteria{Prefix: "ab"})); err != nil {
+		return nil, errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --keystore-path-template: %v", err))
+	}
+	return tmpl, nil
+}
+
+// renderKeystorePath renders the keystore path of a wallet and returns it without
+// the .json extension, as the stem its other files share
+func renderKeystorePath(tmpl *template.Template, fields keystorePathFields) (string, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, fields); err != nil {
+		return "", err
+	}
+	path := b.String()
+	stem, ok := strings.CutSuffix(path, ".json")
+	switch {
+	case !ok:
+		return "", fmt.Errorf("%q must end in .json", path)
+	case strings.Contains(path, `\`):
+		return "", fmt.Errorf("%q must separate directories with /", path)
+	case !filepath.IsLocal(filepath.FromSlash(path)) || filepath.Clean(filepath.FromSlash(path)) != filepath.FromSlash(path):
+		return "", fmt.Errorf("%q must be a clean path inside --keystore-dir", path)
+	}
+	// Audits and imports find a keystore's address in its name
+	if name := stem[strings.LastIndex(stem, "/")+1:]; name != fields.Address && !strings.HasSuffix(name, "_"+fields.Address) {
+		return "", fmt.Errorf("the file name in %q must be {{.Address}}.json or end in _{{.Address}}.json", path)
+	}
+	return stem, nil
+}
+
+// keystorePathStem returns the --keystore-path-template stem of w, or "" without a
+// template. Wallets of serve jobs use their job's pattern, others the run's.
+func (app *Application) keystorePathStem(ctx context.Context, w *wallet.Wallet) (string, error) {
+	if app.keystorePath == nil {
+		return "", nil
+	}
+	criteria := app.keystoreCriteria
+	if job, ok := server.JobCriteria(ctx); ok {
+		criteria = job
+	}
+	stem, err := renderKeystorePath(app.keystorePath, newKeystorePathFields(w, criteria))
+	if err != nil {
+		return "", errors.WrapError(err, errors.ErrorTypeConfiguration, "keystore_path", "invalid --keystore-path-template")
+	}
+	return stem, nil
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/wallet"
+)
+
+// keystorePathCommand returns a command with the keystore path flags set to args
+func keystorePathCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().String("keystore-path-template", "", "")
+	cmd.Flags().Bool("label-filenames", false, "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestParseKeystorePathTemplate(t *testing.T) {
+	tmpl, err := parseKeystorePathTemplate(keystorePathCommand(t, "--keystore-path-template", "{{.Year}}/{{.Pattern}}/{{.Label}}_{{.Address}}.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := &wallet.Wallet{Address: "0xCAFE000000000000000000000000000000000001", Label: "Treasury Hot", CreatedAt: time.Date(2026, 3, 9, 23, 0, 0, 0, time.UTC)}
+	stem, err := renderKeystorePath(tmpl, newKeystorePathFields(w, wallet.GenerationCriteria{Prefix: "cafe", Suffix: "01"}))
+	if want := "2026/cafe-01/treasury-hot_0xcafe000000000000000000000000000000000001"; stem != want || err != nil {
+		t.Errorf("renderKeystorePath() = %q, %v, want %q", stem, err, want)
+	}
+
+	for name, args := range map[string][]string{
+		"syntax":            {"--keystore-path-template", "{{.Year}/{{.Address}}.json"},
+		"unknown field":     {"--keystore-path-template", "{{.Owner}}/{{.Address}}.json"},
+		"no extension":      {"--keystore-path-template", "{{.Date}}/{{.Address}}"},
+		"no address":        {"--keystore-path-template", "{{.Date}}/{{.Pattern}}.json"},
+		"address in dir":    {"--keystore-path-template", "{{.Address}}/keystore.json"},
+		"parent directory":  {"--keystore-path-template", "../{{.Address}}.json"},
+		"absolute":          {"--keystore-path-template", "/srv/{{.Address}}.json"},
+		"empty element":     {"--keystore-path-template", "{{.Year}}//{{.Address}}.json"},
+		"backslash":         {"--keystore-path-template", `{{.Year}}\{{.Address}}.json`},
+		"with label prefix": {"--keystore-path-template", "{{.Address}}.json", "--label-filenames"},
+	} {
+		_, err := parseKeystorePathTemplate(keystorePathCommand(t, args...))
+		if ExitCode(err) != ExitConfiguration {
+			t.Errorf("%s: parseKeystorePathTemplate() = %v, want a validation error", name, err)
+		}
+	}
+}
+
+func TestKeystorePathTemplate(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.Enabled = true
+	cfg.KeyStore.OutputDir = t.TempDir()
+	cfg.KeyStore.KDFAlgorithm = "pbkdf2"
+	cfg.KeyStore.KDFParams = map[string]interface{}{"c": 100000, "prf": "hmac-sha256", "dklen": 32}
+	cfg.CLI.QuietMode = true
+	tmpl, err := parseKeystorePathTemplate(keystorePathCommand(t, "--keystore-path-template", "{{.Date}}/{{.Pattern}}/{{.Address}}.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &Application{config: cfg, keystorePath: tmpl, keystoreCriteria: wallet.GenerationCriteria{Prefix: "ab"}}
+
+	direct := testPipelineWallet(t)
+	direct.Label = "batch"
+	if err := app.writeKeystoreFiles(context.Background(), direct, false, cfg.KeyStore.OutputDir); err != nil {
+		t.Fatal(err)
+	}
+	deferred := app.newDeferredKeystores()
+	held := testPipelineWallet(t)
+	if err := deferred.Add(held); err != nil {
+		t.Fatal(err)
+	}
+	if err := deferred.finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	date := time.Now().UTC().Format("2006-01-02")
+	for _, w := range []*wallet.Wallet{direct, held} {
+		for _, ext := range []string{".json", ".pwd"} {
+			if _, err := os.Stat(filepath.Join(cfg.KeyStore.OutputDir, date, "ab", w.Address+ext)); err != nil {
+				t.Error(err)
+			}
+		}
+	}
+
+	// Audits and listings look into the template's directories
+	if err := os.Remove(filepath.Join(cfg.KeyStore.OutputDir, KeystoreManifestName)); err != nil {
+		t.Fatal(err)
+	}
+	report, err := crypto.AuditKeystoreDirectory(cfg.KeyStore.OutputDir, crypto.KeystoreAuditOptions{VerifyMAC: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Keystores != 2 || !report.OK() {
+		t.Errorf("audit = %+v", report)
+	}
+	metas, err := crypto.LoadWalletMetadata(cfg.KeyStore.OutputDir)
+	if err != nil || len(metas) != 1 || metas[0].Label != "batch" || !strings.HasPrefix(metas[0].File, filepath.Join(date, "ab")) {
+		t.Errorf("LoadWalletMetadata() = %+v, %v", metas, err)
+	}
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// keystoreOutcome is the result of writing one wallet's keystore
+type keystoreOutcome struct {
+	index int
+	err   error
+}
+
+// keystoreJob is a found wallet waiting for its keystore
+type keystoreJob struct {
+	index  int
+	wallet *wallet.Wallet
+}
+
+// keystorePipeline encrypts and writes keystores on its own bounded worker pool,
+// so the search keeps running while the KDF derives each key. Outcomes are kept
+// per wallet, so the summary lists them in result order whatever order they finish.
+type keystorePipeline struct {
+	app     *Application
+	workers int
+	jobs    chan keystoreJob
+
+	// submitMu orders Submit against the queue being closed
+	submitMu sync.Mutex
+	closed   bool
+	wg       sync.WaitGroup
+	waitOnce sync.Once
+
+	mu            sync.Mutex
+	outcomes      map[*wallet.Wallet]keystoreOutcome
+	lastSubmitted time.Time
+	finished      time.Time
+}
+
+// startKeystorePipeline starts --keystore-workers encryption workers for up to
+// capacity wallets, or returns nil when keystores are disabled or written inline
+func (app *Application) startKeystorePipeline(capacity int) *keystorePipeline {
+	if !app.config.KeyStore.Enabled || app.keystoreWorkers <= 0 {
+		return nil
+	}
+	p := &keystorePipeline{
+		app:      app,
+		workers:  min(app.keystoreWorkers, max(capacity, 1)),
+		jobs:     make(chan keystoreJob, max(capacity, 1)),
+		outcomes: make(map[*wallet.Wallet]keystoreOutcome),
+	}
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.run()
+	}
+	return p
+}
+
+func (p *keystorePipeline) run() {
+	defer p.wg.Done()
+	for job := range p.jobs {
+		p.record(job, p.app.generateAndSaveKeystoreWithVerbose(job.wallet, false))
+	}
+}
+
+func (p *keystorePipeline) record(job keystoreJob, err error) {
+	p.mu.Lock()
+	p.outcomes[job.wallet] = keystoreOutcome{index: job.index, err: err}
+	p.mu.Unlock()
+}
+
+// Submit queues the keystore of the index-th wallet. The queue holds every
+// wallet of the run, so the search never waits on it; a wallet submitted after
+// Wait is written inline.
+func (p *keystorePipeline) Submit(index int, w *wallet.Wallet) {
+	job := keystoreJob{index: index, wallet: w}
+	p.submitMu.Lock()
+	if p.closed {
+		p.submitMu.Unlock()
+		p.record(job, p.app.generateAndSaveKeystoreWithVerbose(w, false))
+		return
+	}
+	p.mu.Lock()
+	p.lastSubmitted = time.Now()
+	p.mu.Unlock()
+	select {
+	case p.jobs <- job:
+		p.submitMu.Unlock()
+	default:
+		// More wallets than the run announced: write this one inline
+		p.submitMu.Unlock()
+		p.record(job, p.app.generateAndSaveKeystoreWithVerbose(w, false))
+	}
+}
+
+// Wait closes the queue and blocks until every queued keystore is written; it
+// may be called more than once
+func (p *keystorePipeline) Wait() {
+	p.waitOnce.Do(func() {
+		p.submitMu.Lock()
+		p.closed = true
+		close(p.jobs)
+		p.submitMu.Unlock()
+		p.wg.Wait()
+
+		p.mu.Lock()
+		p.finished = time.Now()
+		p.mu.Unlock()
+	})
+}
+
+// Outcome returns the keystore result of a submitted wallet once Wait returned
+func (p *keystorePipeline) Outcome(w *wallet.Wallet) (keystoreOutcome, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	outcome, ok := p.outcomes[w]
+	return outcome, ok
+}
+
+// Failures returns the failed keystores ordered by wallet index
+func (p *keystorePipeline) Failures() []keystoreOutcome {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var failures []keystoreOutcome
+	for _, outcome := range p.outcomes {
+		if outcome.err != nil {
+			failures = append(failures, outcome)
+		}
+	}
+	sort.Slice(failures, func(i, j int) bool { return failures[i].index < failures[j].index })
+	return failures
+}
+
+// Tail is how long keystores were still being written after the last wallet
+// was found, the part of the encryption the search could not hide
+func (p *keystorePipeline) Tail() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.lastSubmitted.IsZero() || p.finished.Before(p.lastSubmitted) {
+		return 0
+	}
+	return p.finished.Sub(p.lastSubmitted)
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/pkg/wallet"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func testPipelineWallet(t *testing.T) *wallet.Wallet {
+	t.Helper()
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &wallet.Wallet{
+		Address:    strings.ToLower(ethcrypto.PubkeyToAddress(key.PublicKey).Hex()),
+		PrivateKey: hex.EncodeToString(ethcrypto.FromECDSA(key)),
+		Network:    "ethereum",
+	}
+}
+
+func TestKeystorePipeline(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.Enabled = true
+	cfg.KeyStore.OutputDir = t.TempDir()
+	cfg.KeyStore.KDFAlgorithm = "pbkdf2"
+	cfg.KeyStore.KDFParams = map[string]interface{}{"c": 100000, "prf": "hmac-sha256", "dklen": 32}
+	app := &Application{config: cfg, keystoreWorkers: 2}
+
+	keystores := app.startKeystorePipeline(3)
+	if keystores == nil {
+		t.Fatal("pipeline was not started")
+	}
+	wallets := []*wallet.Wallet{testPipelineWallet(t), {Address: "0xbad", PrivateKey: "zz", Network: "ethereum"}, testPipelineWallet(t)}
+	for i, w := range wallets {
+		keystores.Submit(i+1, w)
+	}
+	keystores.Wait()
+	keystores.Wait()
+
+	for i, w := range wallets {
+		outcome, ok := keystores.Outcome(w)
+		if !ok || outcome.index != i+1 {
+			t.Fatalf("wallet %d: outcome %+v, recorded %v", i+1, outcome, ok)
+		}
+		if (outcome.err != nil) != (i == 1) {
+			t.Errorf("wallet %d: err = %v", i+1, outcome.err)
+		}
+	}
+	if failures := keystores.Failures(); len(failures) != 1 || failures[0].index != 2 {
+		t.Errorf("failures = %+v, want wallet 2", failures)
+	}
+	for _, w := range []*wallet.Wallet{wallets[0], wallets[2]} {
+		if _, err := os.Stat(filepath.Join(cfg.KeyStore.OutputDir, w.Address+".json")); err != nil {
+			t.Errorf("keystore for %s: %v", w.Address, err)
+		}
+	}
+
+	// Wallets submitted after Wait are written inline
+	late := testPipelineWallet(t)
+	keystores.Submit(4, late)
+	if outcome, ok := keystores.Outcome(late); !ok || outcome.err != nil {
+		t.Errorf("late wallet: %+v, recorded %v", outcome, ok)
+	}
+}
+
+func TestKeystorePipeline_Disabled(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.Enabled = true
+	if (&Application{config: cfg}).startKeystorePipeline(3) != nil {
+		t.Error("pipeline started with --keystore-workers 0")
+	}
+	cfg.KeyStore.Enabled = false
+	if (&Application{config: cfg, keystoreWorkers: 2}).startKeystorePipeline(3) != nil {
+		t.Error("pipeline started with keystores disabled")
+	}
+}
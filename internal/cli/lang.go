@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/errors"
+)
+
+// applyLanguage selects the output language from --lang, or from the locale environment
+func (app *Application) applyLanguage(cmd *cobra.Command, args []string) error {
+	lang := i18n.Detect()
+	if cmd.Flags().Changed("lang") {
+		value, _ := cmd.Flags().GetString("lang")
+		parsed, ok := i18n.Parse(value)
+		if !ok {
+			return errors.NewValidationError("parse_flags",
+				fmt.Sprintf("unsupported --lang %q (supported: %v)", value, i18n.Supported))
+		}
+		lang = parsed
+	}
+	i18n.SetLanguage(lang)
+	return nil
+}
@@ -0,0 +1,171 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// createListCommand creates the list command for labelled wallets
+func (app *Application) createListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored wallets with their labels and tags",
+		Long: `List wallets saved with --label or --tag. Wallets are read from the metadata
+files in the keystore directory, or from the vault when --vault is given.
+--tag may be repeated and every tag must match; --label matches the label
+exactly, ignoring case. No key material is printed.`,
+		Example: `  bloco-eth list --tag team:ops
+  bloco-eth list --keystore-dir ./keystores --label "treasury hot wallet" --format csv
+  bloco-eth list --vault wallets.vault --vault-password-file vault.pwd --tag env:prod`,
+		Args: cobra.NoArgs,
+		RunE: app.runList,
+	}
+	return cmd
+}
+
+// listedWallet is a wallet as shown by the list commands
+type listedWallet struct {
+	Address   string    `json:"address"`
+	Network   string    `json:"network"`
+	Label     string    `json:"label,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// runList prints stored wallets matching --label and --tag
+func (app *Application) runList(cmd *cobra.Command, args []string) error {
+	label, _ := cmd.Flags().GetString("label")
+	tags, _ := cmd.Flags().GetStringArray("tag")
+
+	var wallets []listedWallet
+	if vaultPath, _ := cmd.Flags().GetString("vault"); vaultPath != "" {
+		vault, err := openVaultFromFlags(cmd, false)
+		if err != nil {
+			return err
+		}
+		for _, entry := range vault.Entries() {
+			wallets = append(wallets, listedWallet{entry.Address, entry.Network, entry.Label, entry.Tags, entry.CreatedAt})
+		}
+	} else {
+		dir, _ := cmd.Flags().GetString("keystore-dir")
+		metas, err := crypto.LoadWalletMetadata(dir)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"list_wallets", fmt.Sprintf("failed to read wallet metadata from %s", dir))
+		}
+		for _, meta := range metas {
+			wallets = append(wallets, listedWallet{meta.Address, meta.Network, meta.Label, meta.Tags, meta.CreatedAt})
+		}
+	}
+
+	matched := wallets[:0]
+	for _, w := range wallets {
+		if (label == "" || strings.EqualFold(w.Label, label)) && crypto.HasAllTags(w.Tags, tags) {
+			matched = append(matched, w)
+		}
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	return printListedWallets(cmd, format, matched)
+}
+
+// printListedWallets writes wallets as text, json or csv
+func printListedWallets(cmd *cobra.Command, format string, wallets []listedWallet) error {
+	out := cmd.OutOrStdout()
+	switch format {
+	case "json":
+		if wallets == nil {
+			wallets = []listedWallet{}
+		}
+		data, err := json.MarshalIndent(wallets, "", "  ")
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "list_wallets", "failed to encode wallets")
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	case "csv":
+		writer := csv.NewWriter(out)
+		_ = writer.Write([]string{"address", "network", "label", "tags", "created_at"})
+		for _, w := range wallets {
+			_ = writer.Write([]string{w.Address, w.Network, w.Label, strings.Join(w.Tags, ";"), w.CreatedAt.UTC().Format(time.RFC3339)})
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "ADDRESS\tNETWORK\tLABEL\tTAGS\tCREATED")
+		for _, w := range wallets {
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n",
+				w.Address, w.Network, w.Label, strings.Join(w.Tags, ","), w.CreatedAt.Local().Format(time.DateTime))
+		}
+		return table.Flush()
+	}
+}
+
+// parseLabelFlags reads and validates --label, --tag and --label-filenames
+func (app *Application) parseLabelFlags(cmd *cobra.Command) error {
+	app.label, _ = cmd.Flags().GetString("label")
+	app.label = strings.TrimSpace(app.label)
+	app.labelFilenames, _ = cmd.Flags().GetBool("label-filenames")
+	if app.labelFilenames && crypto.LabelSlug(app.label) == "" {
+		return errors.NewValidationError("parse_flags", "--label-filenames requires a --label with letters or digits")
+	}
+
+	tags, _ := cmd.Flags().GetStringArray("tag")
+	app.tags = nil
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || strings.ContainsAny(tag, ",;") {
+			return errors.NewValidationError("parse_flags",
+				fmt.Sprintf("invalid tag %q: tags must be non-empty and cannot contain ',' or ';'", tag))
+		}
+		app.tags = append(app.tags, tag)
+	}
+	return nil
+}
+
+// filenameLabel returns the label to prefix keystore filenames with, if enabled
+func (app *Application) filenameLabel(w *wallet.Wallet) string {
+	if !app.labelFilenames {
+		return ""
+	}
+	return w.Label
+}
+
+// saveWalletMetadata writes the .meta file for wallets that have a label, tags or a
+// master seed counter
+func (app *Application) saveWalletMetadata(keystoreService *crypto.KeyStoreService, w *wallet.Wallet) error {
+	if w.Label == "" && len(w.Tags) == 0 && w.SeedCounter == nil {
+		return nil
+	}
+	network := strings.ToLower(w.Network)
+	if network == "" {
+		network = "ethereum"
+	}
+	createdAt := w.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now().UTC()
+	}
+	if err := keystoreService.SaveMetadataFile(crypto.WalletMetadata{
+		Address:         w.Address,
+		Network:         network,
+		Label:           w.Label,
+		Tags:            w.Tags,
+		CreatedAt:       createdAt,
+		SeedCounter:     w.SeedCounter,
+		SeedFingerprint: w.SeedFingerprint,
+	}); err != nil {
+		return fmt.Errorf("failed to save metadata for address %s: %w", w.Address, err)
+	}
+	return nil
+}
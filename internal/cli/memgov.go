@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto/kdf"
+	"bloco-eth/internal/memgov"
+	"bloco-eth/pkg/wallet"
+)
+
+// governMemory sets the runtime's soft memory limit from --mem-limit and lowers
+// GOGC when the scrypt derivations of the run leave the heap little room to grow.
+// GOMEMLIMIT and GOGC from the environment are kept unless --mem-limit is set.
+func (app *Application) governMemory(cmd *cobra.Command) error {
+	value, _ := cmd.Flags().GetString("mem-limit")
+	value = strings.ToLower(strings.TrimSpace(value))
+	explicit := cmd.Flags().Changed("mem-limit")
+	if value == "off" || (!explicit && os.Getenv("GOMEMLIMIT") != "" && os.Getenv("GOGC") != "") {
+		return nil
+	}
+
+	total, source := kdf.TotalMemory()
+	var limit int64
+	switch {
+	case value == "auto":
+		limit = int64(float64(total) * memgov.DefaultShare)
+	default:
+		var err error
+		if limit, err = kdf.ParseMemoryLimit(value, total); err != nil {
+			return fmt.Errorf("invalid --mem-limit: %w", err)
+		}
+	}
+	settings := memgov.Plan(limit, app.scryptWorkingSet())
+	if !explicit && os.Getenv("GOMEMLIMIT") != "" {
+		settings.Limit = 0
+	}
+	if !explicit && os.Getenv("GOGC") != "" {
+		settings.GCPercent = 0
+	}
+	memgov.Apply(settings)
+
+	if verbose, _ := cmd.Flags().GetBool("verbose"); verbose && !app.memoryGoverned && settings.Limit > 0 {
+		fmt.Fprintf(os.Stderr, "Memory governor: %s soft limit (--mem-limit %s of the %s %s memory)",
+			kdf.FormatMemory(settings.Limit), value, kdf.FormatMemory(total), source)
+		if settings.GCPercent > 0 {
+			fmt.Fprintf(os.Stderr, ", GOGC %d", settings.GCPercent)
+		}
+		fmt.Fprintln(os.Stderr)
+	}
+	app.memoryGoverned = true
+	return nil
+}
+
+// scryptWorkingSet returns the memory the run's concurrent scrypt derivations keep
+// live: one per keystore worker, within the --kdf-max-memory budget
+func (app *Application) scryptWorkingSet() int64 {
+	if !app.config.KeyStore.Enabled || app.config.KeyStore.KDFAlgorithm != "scrypt" {
+		return 0
+	}
+	params := app.config.KeyStore.KDFParams
+	if len(params) == 0 {
+		params, _ = kdf.NewUniversalKDFService().GetDefaultParams("scrypt")
+	}
+	working := kdf.ScryptParamsMemory(params) * int64(max(app.keystoreWorkers, 1))
+	if app.kdfBudget != nil && app.kdfBudget.Limit() > 0 && app.kdfBudget.Limit() < working {
+		working = app.kdfBudget.Limit()
+	}
+	return working
+}
+
+// printGCImpact prints how long the garbage collector stopped the benchmark's workers
+func printGCImpact(result *wallet.BenchmarkResult) {
+	if result.TotalDuration <= 0 {
+		return
+	}
+	fmt.Printf("GC: %d cycles, %v paused (%.2f%% of the run)\n", result.GCCycles, result.GCPause.Round(time.Microsecond),
+		gcPausePercent(result))
+}
+
+// gcPausePercent returns the share of the benchmark the collector's pauses took
+func gcPausePercent(result *wallet.BenchmarkResult) float64 {
+	if result.TotalDuration <= 0 {
+		return 0
+	}
+	return float64(result.GCPause) / float64(result.TotalDuration) * 100
+}
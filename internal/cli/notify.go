@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/notify"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// notifyTickInterval is how often attempts are checked against the halfway mark
+const notifyTickInterval = time.Second
+
+// parseNotifyFlags loads the --notify config and its secrets up front, so a broken
+// config fails before any work starts
+func (app *Application) parseNotifyFlags(cmd *cobra.Command) error {
+	app.notifier = nil
+	path, _ := cmd.Flags().GetString("notify")
+	if path == "" {
+		return nil
+	}
+	cfg, err := notify.LoadConfig(path)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "notify", "failed to load --notify config")
+	}
+	notifier, err := notify.New(cfg, nil)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "notify", "invalid --notify config")
+	}
+	notifier.SetRetryPolicy(app.retry.Notify)
+	app.notifier = notifier
+	return nil
+}
+
+// startNotifications subscribes the --notify backends to the run's progress broker,
+// mailing the run summary when it ends. Posting happens on the subscriber's
+// goroutine, so a slow chat or mail service never holds up the search.
+func (app *Application) startNotifications(criteria wallet.GenerationCriteria, count int) {
+	if app.notifier == nil {
+		return
+	}
+	host, _ := os.Hostname()
+	difficulty := calculateDifficulty(criteria)
+	halfway := vanitymath.AttemptsForWallets(difficulty, count, 0.5)
+	base := notify.Message{
+		Host:       host,
+		Pattern:    criteria.GetPattern(),
+		Difficulty: formatDifficulty(difficulty),
+		Wallets:    count,
+	}
+
+	started, passedHalfway := false, false
+	startTime := time.Now()
+	var foundAttempts int64
+	var addresses []notify.SummaryAddress
+	send := func(msg notify.Message) {
+		if !app.notifier.Wants(msg.Event) {
+			return
+		}
+		if err := app.notifier.Send(context.Background(), msg); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+	app.progress.Subscribe(notifyTickInterval, func(e worker.ProgressEvent) {
+		if !started {
+			started = true
+			msg := base
+			msg.Event = notify.EventStart
+			send(msg)
+		}
+
+		msg := base
+		msg.Found = e.Found
+		msg.Speed = formatLargeNumber(int64(e.Stats.TotalSpeed))
+		msg.Elapsed = formatDuration(e.Elapsed.Round(time.Second))
+		if e.Type == worker.ProgressWalletFound && e.Result != nil {
+			foundAttempts += e.Result.Attempts
+		}
+		attempts := max(e.Stats.TotalAttempts, foundAttempts)
+		msg.Attempts = formatLargeNumber(attempts)
+
+		switch e.Type {
+		case worker.ProgressWalletFound:
+			if e.Result == nil || e.Result.Wallet == nil {
+				return
+			}
+			msg.Event = notify.EventWallet
+			msg.Address = e.Result.Wallet.Address
+			msg.Attempts = formatLargeNumber(e.Result.Attempts)
+			label := e.Result.Wallet.Label
+			if label == "" {
+				label = app.label
+			}
+			addresses = append(addresses, notify.SummaryAddress{Address: msg.Address, Label: label, Attempts: msg.Attempts})
+			send(msg)
+		case worker.ProgressDone:
+			msg.Event = notify.EventDone
+			if e.Err != nil {
+				msg.Error = e.Err.Error()
+			}
+			send(msg)
+			if app.notifier.WantsSummary() {
+				network := criteria.Network
+				if network == "" {
+					network = "ethereum"
+				}
+				summary := notify.Summary{
+					Host:       host,
+					Pattern:    base.Pattern,
+					Network:    network,
+					Difficulty: base.Difficulty,
+					Wallets:    count,
+					Found:      e.Found,
+					Attempts:   msg.Attempts,
+					Speed:      formatLargeNumber(int64(float64(attempts) / max(e.Elapsed.Seconds(), 1e-9))),
+					Started:    startTime,
+					Duration:   msg.Elapsed,
+					Error:      msg.Error,
+					Addresses:  addresses,
+				}
+				if err := app.notifier.SendSummary(context.Background(), summary); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+				}
+			}
+			return
+		}
+		if !passedHalfway && halfway > 0 && attempts >= halfway && e.Found < count {
+			passedHalfway = true
+			msg.Event = notify.EventHalfway
+			msg.Address = ""
+			msg.Attempts = formatLargeNumber(attempts)
+			send(msg)
+		}
+	})
+}
+
+// formatDifficulty formats the expected attempts of a pattern for messages
+func formatDifficulty(difficulty float64) string {
+	if difficulty >= math.MaxInt64 {
+		return fmt.Sprintf("%.3g", difficulty)
+	}
+	return formatLargeNumber(int64(difficulty))
+}
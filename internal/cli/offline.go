@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/pkg/errors"
+)
+
+// networkInterfaces lists the non-loopback interfaces that are up; tests replace it
+var networkInterfaces = activeNetworkInterfaces
+
+// checkOffline enforces --require-offline: it refuses to start, or with
+// --require-offline-warn warns, when a network interface is up, and records the
+// result in the audit trail
+func (app *Application) checkOffline(cmd *cobra.Command) error {
+	if required, _ := cmd.Flags().GetBool("require-offline"); !required {
+		return nil
+	}
+	warnOnly, _ := cmd.Flags().GetBool("require-offline-warn")
+	up, err := networkInterfaces()
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "require_offline", "failed to list network interfaces")
+	}
+
+	result := "offline"
+	switch {
+	case len(up) > 0 && warnOnly:
+		result = "warned"
+	case len(up) > 0:
+		result = "refused"
+	}
+	if app.auditTrail != nil {
+		app.audit("offline_check", map[string]string{
+			"offline":    strconv.FormatBool(len(up) == 0),
+			"result":     result,
+			"interfaces": strings.Join(up, ", "),
+		})
+	}
+
+	switch result {
+	case "refused":
+		return errors.NewValidationError("require_offline", fmt.Sprintf(
+			"network interfaces are up: %s; disconnect them, or pass --require-offline-warn to continue anyway", strings.Join(up, ", ")))
+	case "warned":
+		// Printed even with --quiet: the operator has to see it
+		fmt.Fprintf(os.Stderr, "\n%sWARNING: this machine is NOT offline. Network interfaces are up:\n", icon("🚨"))
+		for _, iface := range up {
+			fmt.Fprintf(os.Stderr, "  %s %s\n", bullet(), iface)
+		}
+		fmt.Fprintf(os.Stderr, "Keys generated now must not be treated as cold keys.\n\n")
+	default:
+		if quiet, _ := cmd.Flags().GetBool("quiet"); !quiet {
+			fmt.Fprintf(os.Stderr, "%sOffline check passed: no network interface is up\n", icon("✅"))
+		}
+	}
+	return nil
+}
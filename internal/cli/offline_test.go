@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+)
+
+func TestRequireOffline(t *testing.T) {
+	defer func(list func() ([]string, error)) { networkInterfaces = list }(networkInterfaces)
+
+	tests := []struct {
+		name    string
+		up      []string
+		args    []string
+		wantErr bool
+		audited string
+	}{
+		{"offline", nil, nil, false, `"result":"offline"`},
+		{"online", []string{"eth0 (192.0.2.2/24)"}, nil, true, `"result":"refused"`},
+		{"online warned", []string{"eth0 (192.0.2.2/24)"}, []string{"--require-offline-warn"}, false, `"result":"warned"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			networkInterfaces = func() ([]string, error) { return tt.up, nil }
+			trail := filepath.Join(t.TempDir(), "trail.jsonl")
+
+			app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+			root := app.GetRootCommand()
+			root.SetArgs(append([]string{"version", "--require-offline", "--quiet", "--audit-trail", trail}, tt.args...))
+			root.SetOut(&strings.Builder{})
+			root.SetErr(&strings.Builder{})
+			err := root.Execute()
+			switch {
+			case tt.wantErr && ExitCode(err) != ExitConfiguration:
+				t.Fatalf("Execute() = %v, want a configuration error", err)
+			case !tt.wantErr && err != nil:
+				t.Fatalf("Execute() returned error: %v", err)
+			}
+
+			data, err := os.ReadFile(trail)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(string(data), `"event":"offline_check"`) || !strings.Contains(string(data), tt.audited) {
+				t.Errorf("audit trail does not record the check with %s:\n%s", tt.audited, data)
+			}
+		})
+	}
+}
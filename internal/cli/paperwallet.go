@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/paperwallet"
+	"bloco-eth/pkg/errors"
+)
+
+// paperWalletOutput is the PDF --paper-wallet prints found wallets to
+type paperWalletOutput struct {
+	path     string
+	template paperwallet.Template
+}
+
+// parsePaperWalletFlags reads and validates --paper-wallet and --paper-wallet-template
+func (app *Application) parsePaperWalletFlags(cmd *cobra.Command) error {
+	app.paperWallet = nil
+	path, _ := cmd.Flags().GetString("paper-wallet")
+	templatePath, _ := cmd.Flags().GetString("paper-wallet-template")
+	switch {
+	case path == "" && templatePath != "":
+		return errors.NewValidationError("parse_flags", "--paper-wallet-template requires --paper-wallet")
+	case path == "":
+		return nil
+	case app.hardware != nil:
+		return errors.NewValidationError("parse_flags", "--paper-wallet cannot be combined with --hardware; the key never leaves the hardware")
+	}
+
+	tmpl := paperwallet.DefaultTemplate()
+	if templatePath != "" {
+		var err error
+		if tmpl, err = paperwallet.LoadTemplate(templatePath); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeValidation, "parse_flags", "failed to load --paper-wallet-template")
+		}
+	}
+	app.paperWallet = &paperWalletOutput{path: path, template: tmpl}
+	return nil
+}
+
+// writePaperWallet prints the wallets found so far to the --paper-wallet PDF
+func (app *Application) writePaperWallet() error {
+	app.generatedMu.Lock()
+	found := make([]paperwallet.Wallet, 0, len(app.generated))
+	for _, w := range app.generated {
+		found = append(found, paperwallet.Wallet{
+			Address:   w.Address,
+			Key:       app.displayKey(w),
+			Mnemonic:  w.Mnemonic,
+			Network:   w.Network,
+			Label:     w.Label,
+			CreatedAt: w.CreatedAt,
+		})
+	}
+	app.generatedMu.Unlock()
+	if len(found) == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: no wallets were found; %s was not written\n", app.paperWallet.path)
+		return nil
+	}
+
+	file, err := os.OpenFile(app.paperWallet.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"paper_wallet", fmt.Sprintf("failed to create %s", app.paperWallet.path))
+	}
+	if err := paperwallet.Write(file, app.paperWallet.template, found); err != nil {
+		file.Close()
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"paper_wallet", fmt.Sprintf("failed to write %s", app.paperWallet.path))
+	}
+	if err := file.Close(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"paper_wallet", fmt.Sprintf("failed to write %s", app.paperWallet.path))
+	}
+
+	if !app.config.CLI.QuietMode {
+		fmt.Printf("Paper wallet saved to: %s (%d wallets)\n", app.paperWallet.path, len(found))
+		fmt.Fprintf(os.Stderr, "Warning: %s holds private keys; print it offline and then delete it securely\n", app.paperWallet.path)
+	}
+	return nil
+}
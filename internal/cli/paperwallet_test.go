@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/pkg/wallet"
+)
+
+// paperWalletCommand returns a command with the paper wallet flags set to args
+func paperWalletCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().String("paper-wallet", "", "")
+	cmd.Flags().String("paper-wallet-template", "", "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestParsePaperWalletFlags(t *testing.T) {
+	dir := t.TempDir()
+	broken := filepath.Join(dir, "broken.json")
+	os.WriteFile(broken, []byte(`{"page_size": "a3"}`), 0644)
+	for name, tt := range map[string]struct {
+		app  *Application
+		args []string
+	}{
+		"template alone": {&Application{}, []string{"--paper-wallet-template", broken}},
+		"bad template":   {&Application{}, []string{"--paper-wallet", "out.pdf", "--paper-wallet-template", broken}},
+		"hardware":       {&Application{hardware: &hardwareConfig{}}, []string{"--paper-wallet", "out.pdf"}},
+	} {
+		tt.app.config = config.DefaultConfig()
+		if err := tt.app.parsePaperWalletFlags(paperWalletCommand(t, tt.args...)); ExitCode(err) != ExitConfiguration {
+			t.Errorf("%s: parsePaperWalletFlags() = %v, want a validation error", name, err)
+		}
+	}
+}
+
+func TestWritePaperWallet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wallets.pdf")
+	app := &Application{config: config.DefaultConfig(), keyFormat: "hex0x"}
+	app.config.CLI.QuietMode = true
+	if err := app.parsePaperWalletFlags(paperWalletCommand(t, "--paper-wallet", path)); err != nil || app.paperWallet == nil {
+		t.Fatalf("parsePaperWalletFlags() = %v", err)
+	}
+
+	// Nothing found, nothing written
+	if err := app.writePaperWallet(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected no PDF without wallets, got %v", err)
+	}
+
+	app.generated = []*wallet.Wallet{testPipelineWallet(t), testPipelineWallet(t)}
+	if err := app.writePaperWallet(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("paper wallet has mode %o, want 600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	if !bytes.HasPrefix(data, []byte("%PDF-")) || !bytes.Contains(data, []byte("/Count 2")) {
+		t.Error("expected a PDF page per wallet")
+	}
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/power"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+)
+
+// powerWatch is the battery and thermal monitor of a run
+type powerWatch struct {
+	gate   *worker.Gate
+	reason atomic.Pointer[string] // why the run is paused, nil while it runs
+	// notes prints pauses and resumes to stderr; off while the TUI shows them
+	notes atomic.Bool
+}
+
+// parsePowerFlags applies the --pause-below-battery, --pause-above-temp and
+// --power-check-interval flags over the configured thresholds
+func (app *Application) parsePowerFlags(cmd *cobra.Command) error {
+	cfg := &app.config.Power
+	if cmd.Flags().Changed("pause-below-battery") {
+		cfg.PauseBelowBattery, _ = cmd.Flags().GetFloat64("pause-below-battery")
+	}
+	if cmd.Flags().Changed("pause-above-temp") {
+		cfg.PauseAboveTemp, _ = cmd.Flags().GetFloat64("pause-above-temp")
+	}
+	if cmd.Flags().Changed("power-check-interval") {
+		cfg.CheckInterval, _ = cmd.Flags().GetDuration("power-check-interval")
+	}
+	if cfg.PauseBelowBattery < 0 || cfg.PauseBelowBattery >= 100 {
+		return errors.NewValidationError("parse_flags", "--pause-below-battery must be between 0 and 100")
+	}
+	if cfg.PauseAboveTemp < 0 {
+		return errors.NewValidationError("parse_flags", "--pause-above-temp cannot be negative")
+	}
+	if cfg.CheckInterval < 0 {
+		return errors.NewValidationError("parse_flags", "--power-check-interval cannot be negative")
+	}
+	return nil
+}
+
+// watchPower pauses the searches under the returned context while the battery or
+// CPU temperature crosses its threshold, until stop is called. Without thresholds
+// it returns ctx unchanged.
+func (app *Application) watchPower(ctx context.Context) (context.Context, func()) {
+	cfg := app.config.Power
+	thresholds := power.Thresholds{MinBattery: cfg.PauseBelowBattery, MaxTemperature: cfg.PauseAboveTemp}
+	if !thresholds.Enabled() {
+		return ctx, func() {}
+	}
+
+	quiet := app.config.CLI.QuietMode
+	if reading := power.Read(); !quiet {
+		if thresholds.MinBattery > 0 && !reading.HasBattery {
+			fmt.Fprintln(os.Stderr, "Warning: no battery found; --pause-below-battery has no effect")
+		}
+		if thresholds.MaxTemperature > 0 && !reading.HasTemperature {
+			fmt.Fprintln(os.Stderr, "Warning: no CPU temperature sensor found; --pause-above-temp has no effect")
+		}
+	}
+
+	watch := &powerWatch{gate: worker.NewGate()}
+	watch.notes.Store(!quiet)
+	app.power = watch
+	monitorCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	monitor := &power.Monitor{
+		Thresholds: thresholds,
+		Interval:   cfg.CheckInterval,
+		OnChange:   watch.change,
+	}
+	go func() {
+		defer close(done)
+		monitor.Run(monitorCtx)
+	}()
+	return worker.WithGate(ctx, watch.gate), func() {
+		cancel()
+		<-done
+		watch.gate.Resume()
+	}
+}
+
+// change pauses or resumes the run's searches for the power monitor
+func (w *powerWatch) change(paused bool, reason string) {
+	if paused {
+		w.reason.Store(&reason)
+		w.gate.Pause()
+		if w.notes.Load() {
+			fmt.Fprintf(os.Stderr, "Paused: %s; resuming when it recovers\n", reason)
+		}
+		return
+	}
+	w.reason.Store(nil)
+	w.gate.Resume()
+	if w.notes.Load() {
+		fmt.Fprintln(os.Stderr, "Resumed: power and temperature are back within limits")
+	}
+}
+
+// pausedReason returns why the power monitor paused the run, or "" while it runs
+func (app *Application) pausedReason() string {
+	if app.power == nil {
+		return ""
+	}
+	if reason := app.power.reason.Load(); reason != nil {
+		return *reason
+	}
+	return ""
+}
+
+// showPowerInTUI leaves pauses to the TUI's status line instead of stderr
+func (app *Application) showPowerInTUI() {
+	if app.power != nil {
+		app.power.notes.Store(false)
+	}
+}
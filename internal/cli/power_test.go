@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"testing"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/worker"
+)
+
+func TestPowerWatch_Change(t *testing.T) {
+	app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+	if reason := app.pausedReason(); reason != "" {
+		t.Fatalf("pausedReason() = %q without a monitor", reason)
+	}
+
+	app.power = &powerWatch{gate: worker.NewGate()}
+	app.power.change(true, "CPU at 95°C (pausing above 90°C)")
+	if !app.power.gate.Paused() || app.pausedReason() != "CPU at 95°C (pausing above 90°C)" {
+		t.Errorf("after a pause: gate paused = %v, reason = %q", app.power.gate.Paused(), app.pausedReason())
+	}
+	app.power.change(false, "")
+	if app.power.gate.Paused() || app.pausedReason() != "" {
+		t.Errorf("after a resume: gate paused = %v, reason = %q", app.power.gate.Paused(), app.pausedReason())
+	}
+}
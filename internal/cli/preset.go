@@ -0,0 +1,336 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/preset"
+	"bloco-eth/pkg/errors"
+)
+
+// createPresetCommand creates the preset subcommand group for --preset
+func (app *Application) createPresetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "preset",
+		Short: "Manage named pattern presets used with --preset",
+		Long: `Presets name a pattern (prefix, suffix, checksum, case sensitivity and network)
+so it can be generated with --preset <name> instead of repeating its flags.
+Flags given on the command line override the preset's values.
+
+Presets live in a JSON registry, {"presets": {"lucky8": {"suffix": "88888888"}}},
+read from --preset-file, $BLOCO_PRESETS or presets.json in the user config
+directory (e.g. ~/.config/bloco-eth). Export a set of presets to a file and
+import it elsewhere to share a team's naming scheme.`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the presets in the registry",
+		Args:  cobra.NoArgs,
+		RunE:  app.runPresetList,
+	}
+
+	showCmd := &cobra.Command{
+		Use:               "show <name>",
+		Short:             "Show a preset, its flags and its difficulty",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePresetArg,
+		RunE:              app.runPresetShow,
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Save a pattern given with --prefix, --suffix, --checksum, --case-sensitive and --network",
+		Example: `  bloco-eth preset add lucky8 --suffix 88888888 --description "Lucky deposit addresses"
+  bloco-eth preset add treasury --prefix cafe --checksum --case-sensitive`,
+		Args: cobra.ExactArgs(1),
+		RunE: app.runPresetAdd,
+	}
+	addCmd.Flags().String("description", "", "What the preset is for")
+	addCmd.Flags().Bool("force", false, "Replace an existing preset of the same name")
+
+	removeCmd := &cobra.Command{
+		Use:               "remove <name>",
+		Short:             "Delete a preset",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completePresetArg,
+		RunE:              app.runPresetRemove,
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export <file> [name]...",
+		Short: "Write presets (default: all) to a shareable file (- for stdout)",
+		Example: `  bloco-eth preset export team-presets.json
+  bloco-eth preset export - lucky8 treasury`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completePresetExport,
+		RunE:              app.runPresetExport,
+	}
+
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Add the presets of an exported file (- for stdin) to the registry",
+		Args:  cobra.ExactArgs(1),
+		RunE:  app.runPresetImport,
+	}
+	importCmd.Flags().Bool("force", false, "Replace existing presets whose pattern differs")
+
+	cmd.AddCommand(listCmd, showCmd, addCmd, removeCmd, exportCmd, importCmd)
+	return cmd
+}
+
+// presetPath returns the registry file selected by --preset-file or the default location
+func presetPath(cmd *cobra.Command) (string, error) {
+	if path, _ := cmd.Flags().GetString("preset-file"); path != "" {
+		return path, nil
+	}
+	path, err := preset.DefaultPath()
+	if err != nil {
+		return "", errors.WrapError(err, errors.ErrorTypeConfiguration, "preset", "no preset registry")
+	}
+	return path, nil
+}
+
+// loadPresets reads the registry and returns it with its path
+func loadPresets(cmd *cobra.Command) (*preset.Registry, string, error) {
+	path, err := presetPath(cmd)
+	if err != nil {
+		return nil, "", err
+	}
+	registry, err := preset.Load(path)
+	if err != nil {
+		return nil, "", errors.WrapError(err, errors.ErrorTypeConfiguration, "preset", "failed to load presets")
+	}
+	return registry, path, nil
+}
+
+// savePresets writes the registry back to path
+func savePresets(registry *preset.Registry, path string) error {
+	if err := registry.Save(path); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "preset", "failed to save presets")
+	}
+	return nil
+}
+
+// applyPreset sets the pattern flags of the --preset named on cmd that were not given
+// explicitly, so the command line always overrides the preset
+func applyPreset(cmd *cobra.Command) error {
+	name, _ := cmd.Flags().GetString("preset")
+	if name == "" {
+		return nil
+	}
+	registry, path, err := loadPresets(cmd)
+	if err != nil {
+		return err
+	}
+	p, ok := registry.Get(name)
+	if !ok {
+		return errors.NewConfigurationError("preset", fmt.Sprintf("unknown preset %q in %s (see \"preset list\")", name, path))
+	}
+	for flag, value := range presetFlags(p) {
+		if cmd.Flags().Changed(flag) {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, value); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "preset", fmt.Sprintf("failed to set --%s", flag))
+		}
+	}
+	return nil
+}
+
+// presetFlags returns the flag values a preset stands for
+func presetFlags(p preset.Preset) map[string]string {
+	values := make(map[string]string)
+	if p.Prefix != "" {
+		values["prefix"] = p.Prefix
+	}
+	if p.Suffix != "" {
+		values["suffix"] = p.Suffix
+	}
+	if p.Checksum {
+		values["checksum"] = "true"
+	}
+	if p.CaseSensitive {
+		values["case-sensitive"] = "true"
+	}
+	if p.Network != "" {
+		values["network"] = p.Network
+	}
+	return values
+}
+
+// presetArgs returns the command line equivalent to a preset
+func presetArgs(p preset.Preset) string {
+	var args []string
+	if p.Prefix != "" {
+		args = append(args, "--prefix", p.Prefix)
+	}
+	if p.Suffix != "" {
+		args = append(args, "--suffix", p.Suffix)
+	}
+	if p.Checksum {
+		args = append(args, "--checksum")
+	}
+	if p.CaseSensitive {
+		args = append(args, "--case-sensitive")
+	}
+	if p.Network != "" {
+		args = append(args, "--network", p.Network)
+	}
+	return strings.Join(args, " ")
+}
+
+// runPresetList prints the presets of the registry
+func (app *Application) runPresetList(cmd *cobra.Command, args []string) error {
+	registry, path, err := loadPresets(cmd)
+	if err != nil {
+		return err
+	}
+	if len(registry.Presets) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "No presets in %s (add one with \"preset add\")\n", path)
+		return nil
+	}
+	out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(out, "NAME\tFLAGS\tDIFFICULTY\tDESCRIPTION")
+	for _, name := range registry.Names() {
+		p, _ := registry.Get(name)
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", name, presetArgs(p), formatDifficulty(calculateDifficulty(p.Criteria())), p.Description)
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "\n%d preset(s) in %s\n", len(registry.Presets), path)
+	return nil
+}
+
+// runPresetShow prints one preset
+func (app *Application) runPresetShow(cmd *cobra.Command, args []string) error {
+	registry, _, err := loadPresets(cmd)
+	if err != nil {
+		return err
+	}
+	p, ok := registry.Get(args[0])
+	if !ok {
+		return errors.NewValidationError("preset_show", fmt.Sprintf("unknown preset %q", args[0]))
+	}
+	criteria := p.Criteria()
+	difficulty := calculateDifficulty(criteria)
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Name:        %s\n", args[0])
+	if p.Description != "" {
+		fmt.Fprintf(out, "Description: %s\n", p.Description)
+	}
+	fmt.Fprintf(out, "Pattern:     %s (%s)\n", criteria.GetPattern(), criteria.Network)
+	fmt.Fprintf(out, "Flags:       %s\n", presetArgs(p))
+	fmt.Fprintf(out, "Difficulty:  %s\n", formatDifficulty(difficulty))
+	fmt.Fprintf(out, "50%% chance:  %s attempts\n", formatDifficulty(float64(calculateProbability50(difficulty))))
+	fmt.Fprintf(out, "\nGenerate with: bloco-eth --preset %s\n", args[0])
+	return nil
+}
+
+// runPresetAdd saves the pattern flags of the command line as a preset
+func (app *Application) runPresetAdd(cmd *cobra.Command, args []string) error {
+	registry, path, err := loadPresets(cmd)
+	if err != nil {
+		return err
+	}
+	p := preset.Preset{}
+	p.Prefix, _ = cmd.Flags().GetString("prefix")
+	p.Suffix, _ = cmd.Flags().GetString("suffix")
+	p.Checksum, _ = cmd.Flags().GetBool("checksum")
+	p.CaseSensitive, _ = cmd.Flags().GetBool("case-sensitive")
+	if cmd.Flags().Changed("network") {
+		p.Network, _ = cmd.Flags().GetString("network")
+	}
+	p.Description, _ = cmd.Flags().GetString("description")
+	force, _ := cmd.Flags().GetBool("force")
+	if err := registry.Add(args[0], p, force); err != nil {
+		return errors.NewValidationError("preset_add", err.Error()+" (use --force to replace it)")
+	}
+	if err := savePresets(registry, path); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Saved preset %s (%s) to %s\n", args[0], presetArgs(p), path)
+	return nil
+}
+
+// runPresetRemove deletes a preset
+func (app *Application) runPresetRemove(cmd *cobra.Command, args []string) error {
+	registry, path, err := loadPresets(cmd)
+	if err != nil {
+		return err
+	}
+	if !registry.Remove(args[0]) {
+		return errors.NewValidationError("preset_remove", fmt.Sprintf("unknown preset %q", args[0]))
+	}
+	if err := savePresets(registry, path); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Removed preset %s from %s\n", args[0], path)
+	return nil
+}
+
+// runPresetExport writes the selected presets to a file or stdout
+func (app *Application) runPresetExport(cmd *cobra.Command, args []string) error {
+	registry, _, err := loadPresets(cmd)
+	if err != nil {
+		return err
+	}
+	subset, err := registry.Subset(args[1:])
+	if err != nil {
+		return errors.NewValidationError("preset_export", err.Error())
+	}
+	if args[0] == "-" {
+		data, err := subset.Marshal()
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := savePresets(subset, args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Exported %d preset(s) to %s\n", len(subset.Presets), args[0])
+	return nil
+}
+
+// runPresetImport merges an exported preset file into the registry
+func (app *Application) runPresetImport(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "preset_import", "failed to read presets")
+	}
+	imported, err := preset.Parse(data)
+	if err != nil {
+		return errors.NewValidationError("preset_import", fmt.Sprintf("invalid preset file %s: %v", args[0], err))
+	}
+
+	registry, path, err := loadPresets(cmd)
+	if err != nil {
+		return err
+	}
+	force, _ := cmd.Flags().GetBool("force")
+	added, replaced, err := registry.Merge(imported, force)
+	if err != nil {
+		return errors.NewValidationError("preset_import", err.Error()+" (use --force to replace them)")
+	}
+	if err := savePresets(registry, path); err != nil {
+		return err
+	}
+	unchanged := len(imported.Presets) - added - replaced
+	fmt.Fprintf(cmd.OutOrStdout(), "Imported %s into %s: %d added, %d replaced, %d unchanged\n",
+		args[0], path, added, replaced, unchanged)
+	return nil
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/preset"
+)
+
+func TestApplyPreset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "presets.json")
+	registry := &preset.Registry{Presets: map[string]preset.Preset{
+		"treasury": {Prefix: "cafe", Suffix: "88", Checksum: true},
+	}}
+	if err := registry.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("prefix", "", "")
+	cmd.Flags().String("suffix", "", "")
+	cmd.Flags().Bool("checksum", false, "")
+	cmd.Flags().Bool("case-sensitive", false, "")
+	cmd.Flags().String("network", "ethereum", "")
+	cmd.Flags().String("preset", "", "")
+	cmd.Flags().String("preset-file", "", "")
+	if err := cmd.Flags().Parse([]string{"--preset", "treasury", "--preset-file", path, "--suffix", "99"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyPreset(cmd); err != nil {
+		t.Fatalf("applyPreset() error = %v", err)
+	}
+
+	prefix, _ := cmd.Flags().GetString("prefix")
+	suffix, _ := cmd.Flags().GetString("suffix")
+	checksum, _ := cmd.Flags().GetBool("checksum")
+	network, _ := cmd.Flags().GetString("network")
+	if prefix != "cafe" || !checksum || network != "ethereum" {
+		t.Errorf("preset values not applied: prefix %q, checksum %v, network %q", prefix, checksum, network)
+	}
+	if suffix != "99" {
+		t.Errorf("suffix = %q, want the explicit --suffix to override the preset", suffix)
+	}
+
+	_ = cmd.Flags().Set("preset", "missing")
+	if err := applyPreset(cmd); err == nil {
+		t.Error("an unknown preset should fail")
+	}
+}
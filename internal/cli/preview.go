@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+)
+
+// runMnemonicPreview prints how --with-mnemonic wallets are derived, using a public
+// test mnemonic, so the derivation can be checked against a wallet before a long run
+func (app *Application) runMnemonicPreview(cmd *cobra.Command, out io.Writer) error {
+	network, _ := cmd.Flags().GetString("network")
+	useMnemonic, _ := cmd.Flags().GetBool("with-mnemonic")
+	switch {
+	case strings.ToLower(network) != "ethereum" && network != "":
+		return errors.NewValidationError("preview", fmt.Sprintf("--preview shows the Ethereum mnemonic derivation; %s keys are not derived from their mnemonic", network))
+	case !useMnemonic:
+		return errors.NewValidationError("preview", "--preview shows the --with-mnemonic derivation; add --with-mnemonic")
+	}
+
+	sample, err := crypto.PreviewEthereumDerivation(crypto.PreviewMnemonic, crypto.EthereumDerivationPath)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeCrypto, "preview", "failed to derive the sample address")
+	}
+	legacy, err := crypto.PreviewEthereumDerivation(crypto.PreviewMnemonic, crypto.LedgerLegacyDerivationPath)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeCrypto, "preview", "failed to derive the sample address")
+	}
+
+	fmt.Fprintln(out, "Mnemonic derivation used by --with-mnemonic")
+	fmt.Fprintln(out, "  Mnemonic:    BIP-39, 12 English words (128-bit entropy), no passphrase")
+	fmt.Fprintln(out, "  Seed:        PBKDF2-HMAC-SHA512 of the mnemonic, 2048 rounds")
+	fmt.Fprintf(out, "  Path:        %s (BIP-44 Ethereum: account 0, first address)\n", crypto.EthereumDerivationPath)
+	fmt.Fprintln(out, "  Wallets:     the first account of MetaMask, Ledger Live, Trezor Suite and MyEtherWallet's default path")
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Sample derivation of the public BIP-39 test mnemonic (never fund these addresses):")
+	fmt.Fprintf(out, "  Mnemonic:    %s\n", sample.Mnemonic)
+	fmt.Fprintf(out, "  Seed:        %s\n", sample.Seed)
+	for _, step := range sample.Steps {
+		fmt.Fprintf(out, "  %-18s public key %s\n", step.Path, step.PublicKey)
+	}
+	fmt.Fprintf(out, "  Private key: %s\n", sample.PrivateKey)
+	fmt.Fprintf(out, "  Address:     %s\n", sample.Address)
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "Restoring the test mnemonic in your wallet should show %s as its first account.\n", sample.Address)
+	fmt.Fprintf(out, "If it shows %s, the wallet uses Ledger's legacy path %s and will not find\n", legacy.Address, crypto.LedgerLegacyDerivationPath)
+	fmt.Fprintln(out, "generated wallets from their mnemonic; import the private key or keystore instead.")
+	fmt.Fprintln(out, "A BIP-39 passphrase (\"25th word\") also changes every address: leave it empty when restoring.")
+	return nil
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+package cli
+
+import (
+	"os"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// lowestPriority is the nice value of the lowest CPU priority
+const lowestPriority = 19
+
+// lowerPriority runs the process at the lowest CPU priority. Linux keeps a nice
+// value per thread, so every thread is changed; threads started later inherit it
+// from the thread that starts them.
+func lowerPriority() error {
+	// Repeat until a pass finds no thread left to change, in case one was started
+	// by a thread not changed yet
+	for changed := true; changed; {
+		changed = false
+		tasks, err := os.ReadDir("/proc/self/task")
+		if err != nil {
+			return unix.Setpriority(unix.PRIO_PROCESS, 0, lowestPriority)
+		}
+		for _, task := range tasks {
+			tid, err := strconv.Atoi(task.Name())
+			if err != nil {
+				continue
+			}
+			// getpriority returns 20 - nice
+			if current, err := unix.Getpriority(unix.PRIO_PROCESS, tid); err == nil && 20-current == lowestPriority {
+				continue
+			}
+			if err := unix.Setpriority(unix.PRIO_PROCESS, tid, lowestPriority); err != nil && err != unix.ESRCH {
+				return err
+			}
+			changed = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package cli
+
+import "fmt"
+
+// lowerPriority is not available on this platform
+func lowerPriority() error {
+	return fmt.Errorf("process priority cannot be changed on this platform")
+}
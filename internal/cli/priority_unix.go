@@ -0,0 +1,10 @@
+//go:build unix && !linux
+
+package cli
+
+import "syscall"
+
+// lowerPriority runs the process at the lowest CPU priority
+func lowerPriority() error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, 0, 19) // the lowest CPU priority
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package cli
+
+import "golang.org/x/sys/windows"
+
+// lowerPriority runs the process in the idle priority class
+func lowerPriority() error {
+	return windows.SetPriorityClass(windows.CurrentProcess(), windows.IDLE_PRIORITY_CLASS)
+}
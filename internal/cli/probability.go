@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// attemptBudget is the attempt limit derived from --until-probability
+type attemptBudget struct {
+	probability float64 // percent, 0 < p < 100
+	perWallet   int64
+	total       int64
+	exhausted   atomic.Bool
+}
+
+// parseAttemptBudget converts --until-probability into an attempt budget for count wallets
+// matching criteria; it returns nil when the flag is not set
+func parseAttemptBudget(cmd *cobra.Command, criteria wallet.GenerationCriteria, count int) (*attemptBudget, error) {
+	probability, _ := cmd.Flags().GetFloat64("until-probability")
+	if probability == 0 {
+		return nil, nil
+	}
+	if probability < 0 || probability >= 100 {
+		return nil, errors.NewValidationError("parse_flags", "--until-probability must be greater than 0 and less than 100")
+	}
+	if criteria.Prefix == "" && criteria.Suffix == "" {
+		return nil, errors.NewValidationError("parse_flags", "--until-probability requires --prefix or --suffix")
+	}
+
+	difficulty := calculateDifficulty(criteria)
+	perWallet := vanitymath.AttemptsForProbability(difficulty, probability/100)
+	if perWallet < 0 {
+		return nil, errors.NewValidationError("parse_flags",
+			fmt.Sprintf("pattern %s is too difficult to reach %.4g%% probability", criteria.GetPattern(), probability))
+	}
+	return &attemptBudget{probability: probability, perWallet: perWallet, total: perWallet * int64(count)}, nil
+}
+
+// watch cancels the returned context once the pool has used the whole budget
+func (b *attemptBudget) watch(ctx context.Context, stats *worker.StatsCollector) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		ticker := time.NewTicker(50 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if stats.GetTotalAttempts() >= b.total {
+					b.exhausted.Store(true)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	return ctx, cancel
+}
+
+// report prints whether the requested wallets were found within the budget and returns
+// an error when the budget ran out first
+func (b *attemptBudget) report(quiet bool, found, count int, attempts int64) error {
+	if found >= count {
+		if !quiet {
+			fmt.Printf("%sFound %d of %d wallet(s) within the %.4g%% probability budget of %s attempts\n",
+				icon("🎯"), found, count, b.probability, formatLargeNumber(b.total))
+		}
+		return nil
+	}
+	if !b.exhausted.Load() {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Attempt budget exhausted: found %d of %d wallet(s) in %s attempts (%.4g%% probability budget)\n",
+		found, count, formatLargeNumber(attempts), b.probability)
+	return errors.NewGenerationError("generate_wallet",
+		fmt.Sprintf("no match within the %.4g%% probability budget of %s attempts", b.probability, formatLargeNumber(b.total)), nil)
+}
+
+// reportBenchmarkBudget shows how much of the probability budget the benchmark covered
+func reportBenchmarkBudget(criteria wallet.GenerationCriteria, budget *attemptBudget, result *wallet.BenchmarkResult) {
+	difficulty := calculateDifficulty(criteria)
+	reached := vanitymath.Probability(difficulty, result.TotalAttempts) * 100
+
+	fmt.Printf("\nProbability Budget (%s):\n", criteria.GetPattern())
+	fmt.Printf("  Target: %.4g%% in %s attempts\n", budget.probability, formatLargeNumber(budget.total))
+	fmt.Printf("  Reached: %s in %s attempts\n", utils.FormatPercentage(reached), formatLargeNumber(result.TotalAttempts))
+	if result.TotalAttempts >= budget.total {
+		fmt.Printf("  Budget completed in %s\n", formatDuration(result.TotalDuration))
+	} else if result.AverageSpeed > 0 {
+		remaining := float64(budget.total-result.TotalAttempts) / result.AverageSpeed
+		fmt.Printf("  Budget not completed; about %s more at %s\n",
+			formatDuration(secondsToDuration(remaining)), utils.FormatSpeed(result.AverageSpeed))
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func budgetCommand(t *testing.T, probability string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().Float64("until-probability", 0, "")
+	if probability != "" {
+		if err := cmd.Flags().Set("until-probability", probability); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cmd
+}
+
+func TestParseAttemptBudget(t *testing.T) {
+	criteria := wallet.GenerationCriteria{Prefix: "abcd"}
+
+	budget, err := parseAttemptBudget(budgetCommand(t, ""), criteria, 1)
+	if err != nil || budget != nil {
+		t.Fatalf("expected no budget without the flag, got %+v, %v", budget, err)
+	}
+
+	// 16^4 difficulty needs ~45426 attempts for 50% and ~196327 for 95%
+	budget, err = parseAttemptBudget(budgetCommand(t, "50"), criteria, 1)
+	if err != nil {
+		t.Fatalf("parseAttemptBudget failed: %v", err)
+	}
+	if budget.perWallet < 45400 || budget.perWallet > 45450 {
+		t.Errorf("50%% budget = %d, expected ~45426", budget.perWallet)
+	}
+
+	budget, err = parseAttemptBudget(budgetCommand(t, "95"), criteria, 3)
+	if err != nil {
+		t.Fatalf("parseAttemptBudget failed: %v", err)
+	}
+	if budget.perWallet < 196300 || budget.perWallet > 196350 {
+		t.Errorf("95%% budget = %d, expected ~196327", budget.perWallet)
+	}
+	if budget.total != budget.perWallet*3 {
+		t.Errorf("total = %d, expected three wallets' budget", budget.total)
+	}
+}
+
+func TestParseAttemptBudgetValidation(t *testing.T) {
+	for _, probability := range []string{"-5", "100", "150"} {
+		if _, err := parseAttemptBudget(budgetCommand(t, probability), wallet.GenerationCriteria{Prefix: "ab"}, 1); err == nil {
+			t.Errorf("expected --until-probability %s to be rejected", probability)
+		}
+	}
+	if _, err := parseAttemptBudget(budgetCommand(t, "95"), wallet.GenerationCriteria{}, 1); err == nil {
+		t.Error("expected an error without a pattern")
+	}
+}
+
+func TestAttemptBudgetReport(t *testing.T) {
+	budget := &attemptBudget{probability: 95, perWallet: 100, total: 200}
+
+	if err := budget.report(true, 2, 2, 150); err != nil {
+		t.Errorf("all wallets found should not fail: %v", err)
+	}
+	// Generation stopped for another reason, such as Ctrl+C
+	if err := budget.report(true, 1, 2, 150); err != nil {
+		t.Errorf("unexhausted budget should not fail: %v", err)
+	}
+	budget.exhausted.Store(true)
+	if err := budget.report(true, 1, 2, 200); err == nil {
+		t.Error("expected an error when the budget ran out")
+	}
+}
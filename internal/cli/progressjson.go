@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// progressEvent is one JSON line of the --progress-format json protocol
+type progressEvent struct {
+	Event       string                `json:"event"`
+	Time        time.Time             `json:"time"`
+	Host        string                `json:"host,omitempty"`
+	Pattern     string                `json:"pattern,omitempty"`
+	Difficulty  float64               `json:"difficulty,omitempty"`
+	Wallets     int                   `json:"wallets,omitempty"`
+	Threads     int                   `json:"threads,omitempty"`
+	Found       int64                 `json:"found"`
+	Attempts    int64                 `json:"attempts"`
+	Speed       float64               `json:"speed"`
+	Probability float64               `json:"probability"`
+	ETA         []progressEventETA    `json:"eta,omitempty"`
+	Address     string                `json:"address,omitempty"`
+	Stats       *crypto.AddressStats  `json:"address_stats,omitempty"`
+	Elapsed     float64               `json:"elapsed_seconds"`
+	Error       string                `json:"error,omitempty"`
+	Result      *progressEventAttempt `json:"result,omitempty"`
+	// Pad brings --constant-rate lines to the same length
+	Pad string `json:"pad,omitempty"`
+}
+
+// progressEventETA is the time until a probability of success is reached;
+// Seconds is 0 once reached and omitted while the speed is unknown
+type progressEventETA struct {
+	Percent float64  `json:"percent"`
+	Seconds *float64 `json:"seconds,omitempty"`
+}
+
+// progressEventAttempt reports the attempts a found wallet took
+type progressEventAttempt struct {
+	Index    int   `json:"index"`
+	Attempts int64 `json:"attempts"`
+}
+
+// jsonProgress writes structured progress events for wrappers such as CI jobs and GUIs
+type jsonProgress struct {
+	mu      sync.Mutex
+	out     io.Writer
+	closer  io.Closer
+	stats   *worker.StatsCollector
+	targets []utils.ETAPercentile
+	// host tells the machines of a fleet apart in --publish events
+	host string
+
+	difficulty float64
+	start      time.Time
+	found      atomic.Int64
+	// attempts counts the attempts of found wallets, which the stats collector may not have sampled yet
+	attempts atomic.Int64
+	// etaSpeed holds the float64 bits of the broker's latest ETASpeed
+	etaSpeed atomic.Uint64
+
+	// cancel and done stop the --constant-rate schedule
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// rate, queued and final drive --constant-rate output, see constantrate.go
+	rate     time.Duration
+	queued   []progressEvent
+	released int64
+	final    *progressEvent
+}
+
+// openProgressOutput opens --progress-file for appending, or returns stderr when unset;
+// opening a named pipe blocks until a reader attaches
+func openProgressOutput(path string) (io.Writer, io.Closer, error) {
+	if path == "" {
+		return os.Stderr, nil, nil
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "progress_output",
+			fmt.Sprintf("failed to open progress file %s", path))
+	}
+	return file, file, nil
+}
+
+// startJSONProgress emits a start event; subscribe handle to a progress broker for
+// the progress, wallet and done events
+func startJSONProgress(out io.Writer, closer io.Closer, stats *worker.StatsCollector,
+	criteria wallet.GenerationCriteria, wallets, threads int, percents []float64) *jsonProgress {
+	return startHostJSONProgress(out, closer, "", stats, criteria, wallets, threads, percents)
+}
+
+// startHostJSONProgress is startJSONProgress with every event naming host
+func startHostJSONProgress(out io.Writer, closer io.Closer, host string, stats *worker.StatsCollector,
+	criteria wallet.GenerationCriteria, wallets, threads int, percents []float64) *jsonProgress {
+	p := &jsonProgress{
+		out:        out,
+		closer:     closer,
+		stats:      stats,
+		host:       host,
+		difficulty: calculateDifficulty(criteria),
+		start:      time.Now(),
+	}
+	p.targets = utils.CalculateETAPercentiles(p.difficulty, wallets, percents)
+	p.emit(progressEvent{
+		Event:      "start",
+		Pattern:    criteria.GetPattern(),
+		Difficulty: p.difficulty,
+		Wallets:    wallets,
+		Threads:    threads,
+	})
+	return p
+}
+
+// handle writes the events of a progress broker; paced output ignores ticks and
+// keeps its own schedule
+func (p *jsonProgress) handle(e worker.ProgressEvent) {
+	p.etaSpeed.Store(math.Float64bits(e.ETASpeed))
+	switch e.Type {
+	case worker.ProgressTick:
+		if p.rate == 0 {
+			p.emit(p.snapshot("progress"))
+		}
+	case worker.ProgressWalletFound:
+		p.walletFound(e.Found, e.Result)
+	case worker.ProgressDone:
+		p.stop(e.Err)
+	}
+}
+
+// snapshot fills an event with the current attempts, speed, probability and ETAs
+func (p *jsonProgress) snapshot(event string) progressEvent {
+	current := p.stats.GetAggregatedStats()
+	attempts := max(current.TotalAttempts, p.attempts.Load())
+	e := progressEvent{
+		Event:       event,
+		Found:       p.found.Load(),
+		Attempts:    attempts,
+		Speed:       current.TotalSpeed,
+		Probability: vanitymath.Probability(p.difficulty, attempts) * 100,
+	}
+	etaSpeed := math.Float64frombits(p.etaSpeed.Load())
+	if etaSpeed == 0 {
+		etaSpeed = current.TotalSpeed
+	}
+	for _, eta := range utils.EstimateETAPercentiles(p.targets, attempts, etaSpeed) {
+		item := progressEventETA{Percent: eta.Percent}
+		if eta.Remaining >= 0 {
+			seconds := eta.Remaining.Seconds()
+			item.Seconds = &seconds
+		}
+		e.ETA = append(e.ETA, item)
+	}
+	return e
+}
+
+// walletFound emits a wallet event for the index-th wallet (from 1) of the run
+func (p *jsonProgress) walletFound(index int, result *wallet.GenerationResult) {
+	if p == nil || result == nil || result.Wallet == nil {
+		return
+	}
+	if p.rate > 0 {
+		p.queue(result.Wallet.Address)
+		return
+	}
+	p.found.Add(1)
+	p.attempts.Add(result.Attempts)
+	e := p.snapshot("wallet")
+	e.Address = result.Wallet.Address
+	if stats, ok := crypto.NewAddressStats(e.Address); ok && (result.Wallet.Network == "" || result.Wallet.Network == "ethereum") {
+		e.Stats = &stats
+	}
+	e.Result = &progressEventAttempt{Index: index, Attempts: result.Attempts}
+	p.emit(e)
+}
+
+// stop emits a final done event carrying err, if any
+func (p *jsonProgress) stop(err error) {
+	if p == nil {
+		return
+	}
+	if p.rate > 0 {
+		p.drain(err)
+		return
+	}
+	e := p.snapshot("done")
+	if err != nil {
+		e.Error = err.Error()
+	}
+	p.emit(e)
+	if p.closer != nil {
+		_ = p.closer.Close()
+	}
+}
+
+// emit writes event as a single JSON line; write errors are ignored so a detached
+// reader never interrupts generation
+func (p *jsonProgress) emit(event progressEvent) {
+	event.Time = time.Now().UTC()
+	event.Host = p.host
+	event.Elapsed = time.Since(p.start).Seconds()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, _ = p.out.Write(append(data, '\n'))
+}
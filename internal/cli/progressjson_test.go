@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestJSONProgressEvents(t *testing.T) {
+	var out bytes.Buffer
+	criteria := wallet.GenerationCriteria{Prefix: "ab"}
+	stats := worker.NewStatsCollector()
+	p := startJSONProgress(&out, nil, stats, criteria, 2, 4, []float64{50, 90})
+	broker := worker.NewProgressBroker(stats)
+	broker.Subscribe(time.Hour, p.handle)
+
+	broker.PublishWallet(&wallet.GenerationResult{Wallet: &wallet.Wallet{Address: "0xab12"}, Attempts: 300})
+	broker.Close(errors.New("interrupted"))
+
+	var events []progressEvent
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var e progressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("line %q is not JSON: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 3 || events[0].Event != "start" || events[1].Event != "wallet" || events[2].Event != "done" {
+		t.Fatalf("expected start, wallet and done events, got %+v", events)
+	}
+	if events[0].Difficulty != 256 || events[0].Wallets != 2 || events[0].Threads != 4 {
+		t.Errorf("start event = %+v", events[0])
+	}
+	found := events[1]
+	if found.Address != "0xab12" || found.Result == nil || found.Result.Attempts != 300 || found.Found != 1 {
+		t.Errorf("wallet event = %+v", found)
+	}
+	if found.Attempts != 300 || found.Probability <= 0 || len(found.ETA) != 2 {
+		t.Errorf("wallet event should count the found wallet's attempts: %+v", found)
+	}
+	if events[2].Error != "interrupted" || events[2].Found != 1 {
+		t.Errorf("done event = %+v", events[2])
+	}
+}
+
+func TestJSONProgressNil(t *testing.T) {
+	var p *jsonProgress
+	p.walletFound(1, &wallet.GenerationResult{Wallet: &wallet.Wallet{}})
+	p.stop(nil)
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/publish"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// startPublishing connects every --publish target and subscribes it to the run's
+// progress broker. Targets receive the --progress-format json events, which carry
+// found addresses but never keys, each as one message.
+func (app *Application) startPublishing(cmd *cobra.Command, stats *worker.StatsCollector,
+	criteria wallet.GenerationCriteria, count int) error {
+	targets, _ := cmd.Flags().GetStringArray("publish")
+	if len(targets) == 0 {
+		return nil
+	}
+	host, _ := os.Hostname()
+	for _, target := range targets {
+		publisher, err := publish.Dial(target, "bloco-eth")
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "publish",
+				fmt.Sprintf("failed to connect --publish %s", publish.Redact(target)))
+		}
+		out := &publishWriter{publisher: publisher, target: publish.Redact(target)}
+		events := startHostJSONProgress(out, publisher, host, stats,
+			criteria, count, app.config.Worker.ThreadCount, app.etaPercentiles)
+		app.progress.Subscribe(app.statusInterval, events.handle)
+		if app.auditTrail != nil {
+			app.audit("publish_started", map[string]string{"target": out.target, "topic": publisher.Topic()})
+		}
+	}
+	return nil
+}
+
+// publishWriter publishes each JSON line as a message. A broken connection is
+// reported once and never interrupts generation.
+type publishWriter struct {
+	publisher publish.Publisher
+	target    string
+	warnOnce  sync.Once
+}
+
+// Write publishes p without its trailing newline
+func (w *publishWriter) Write(p []byte) (int, error) {
+	if err := w.publisher.Publish(bytes.TrimSuffix(p, []byte("\n"))); err != nil {
+		w.warnOnce.Do(func() {
+			fmt.Fprintf(os.Stderr, "Warning: publishing to %s stopped: %v\n", w.target, err)
+		})
+	}
+	return len(p), nil
+}
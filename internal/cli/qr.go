@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/qr"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// qrOutput is where --qr and --qr-file put the QR codes of found wallets
+type qrOutput struct {
+	terminal bool
+	dir      string
+	format   string // png or svg
+	// private also encodes private keys, with --qr-private --i-understand
+	private bool
+}
+
+// parseQRFlags reads and validates --qr, --qr-file, --qr-format and --qr-private
+func (app *Application) parseQRFlags(cmd *cobra.Command) error {
+	app.qr = nil
+	terminal, _ := cmd.Flags().GetBool("qr")
+	dir, _ := cmd.Flags().GetString("qr-file")
+	format, _ := cmd.Flags().GetString("qr-format")
+	private, _ := cmd.Flags().GetBool("qr-private")
+	understood, _ := cmd.Flags().GetBool("i-understand")
+
+	switch {
+	case format != "png" && format != "svg":
+		return errors.NewValidationError("parse_flags", fmt.Sprintf("unsupported --qr-format %q (use png or svg)", format))
+	case cmd.Flags().Changed("qr-format") && dir == "":
+		return errors.NewValidationError("parse_flags", "--qr-format requires --qr-file")
+	case understood && !private:
+		return errors.NewValidationError("parse_flags", "--i-understand only confirms --qr-private")
+	case private && !terminal && dir == "":
+		return errors.NewValidationError("parse_flags", "--qr-private requires --qr or --qr-file")
+	case private && !understood:
+		return errors.NewValidationError("parse_flags",
+			"--qr-private puts private keys in QR codes that any camera can read; add --i-understand to confirm")
+	case private && app.hardware != nil:
+		return errors.NewValidationError("parse_flags", "--qr-private cannot be combined with --hardware; the key never leaves the hardware")
+	}
+	if !terminal && dir == "" {
+		return nil
+	}
+
+	app.qr = &qrOutput{terminal: terminal, dir: dir, format: format, private: private}
+	if terminal {
+		// QR codes are printed with the text results, not in the TUI
+		app.config.TUI.Enabled = false
+	}
+	return nil
+}
+
+// printQR prints the QR codes of a wallet result when --qr is set. Private keys are
+// only printed where the result shows them.
+func (app *Application) printQR(w *wallet.Wallet, indent string, showKey bool) {
+	if app.qr == nil || !app.qr.terminal {
+		return
+	}
+	app.printQRCode(indent+i18n.T("result.address_qr"), w.Address, indent)
+	if app.qr.private && showKey && w.PrivateKey != "" {
+		app.printQRCode(indent+i18n.T("result.private_key_qr"), app.displayKey(w), indent)
+	}
+}
+
+func (app *Application) printQRCode(title, text, indent string) {
+	code, err := qr.Encode(text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to encode a QR code: %v\n", err)
+		return
+	}
+	fmt.Println(title)
+	_ = code.Terminal(os.Stdout, indent)
+}
+
+// writeQRFiles writes the QR images of a found wallet to --qr-file as
+// <address>.png, and with --qr-private <address>.private.png
+func (app *Application) writeQRFiles(w *wallet.Wallet) {
+	if app.qr == nil || app.qr.dir == "" {
+		return
+	}
+	name := crypto.FormatAddressForFilename(w.Address, strings.ToLower(w.Network))
+	if err := app.qr.writeFile(name, w.Address, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write the QR code of %s: %v\n", w.Address, err)
+	}
+	if app.qr.private && w.PrivateKey != "" {
+		if err := app.qr.writeFile(name+".private", app.displayKey(w), 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write the private key QR code of %s: %v\n", w.Address, err)
+		}
+	}
+}
+
+// writeFile writes the QR image of text to the directory as name plus the format's
+// extension
+func (q *qrOutput) writeFile(name, text string, perm os.FileMode) error {
+	code, err := qr.Encode(text)
+	if err != nil {
+		return err
+	}
+	var image bytes.Buffer
+	if q.format == "svg" {
+		err = code.SVG(&image, 8)
+	} else {
+		err = code.PNG(&image, 8)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(q.dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(q.dir, name+"."+q.format), image.Bytes(), perm)
+}
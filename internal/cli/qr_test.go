@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/config"
+)
+
+// qrCommand returns a command with the QR flags set to args
+func qrCommand(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("qr", false, "")
+	cmd.Flags().String("qr-file", "", "")
+	cmd.Flags().String("qr-format", "png", "")
+	cmd.Flags().Bool("qr-private", false, "")
+	cmd.Flags().Bool("i-understand", false, "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestParseQRFlags(t *testing.T) {
+	for name, args := range map[string][]string{
+		"format":             {"--qr-file", "qr", "--qr-format", "jpeg"},
+		"format without dir": {"--qr", "--qr-format", "svg"},
+		"private only":       {"--qr-private", "--i-understand"},
+		"unconfirmed":        {"--qr", "--qr-private"},
+		"confirmation alone": {"--qr", "--i-understand"},
+	} {
+		app := &Application{config: config.DefaultConfig()}
+		if err := app.parseQRFlags(qrCommand(t, args...)); ExitCode(err) != ExitConfiguration {
+			t.Errorf("%s: parseQRFlags() = %v, want a validation error", name, err)
+		}
+	}
+
+	app := &Application{config: config.DefaultConfig()}
+	if err := app.parseQRFlags(qrCommand(t, "--qr", "--qr-private", "--i-understand")); err != nil || app.qr == nil || !app.qr.private || app.config.TUI.Enabled {
+		t.Errorf("parseQRFlags() = %v, %+v, TUI %v", err, app.qr, app.config.TUI.Enabled)
+	}
+}
+
+func TestWriteQRFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "qr")
+	w := testPipelineWallet(t)
+	for _, tt := range []struct {
+		args  []string
+		files map[string]os.FileMode
+	}{
+		{args: []string{"--qr-file", dir}, files: map[string]os.FileMode{w.Address + ".png": 0644}},
+		{args: []string{"--qr-file", dir, "--qr-format", "svg", "--qr-private", "--i-understand"},
+			files: map[string]os.FileMode{w.Address + ".svg": 0644, w.Address + ".private.svg": 0600}},
+	} {
+		os.RemoveAll(dir)
+		app := &Application{config: config.DefaultConfig(), keyFormat: "hex"}
+		if err := app.parseQRFlags(qrCommand(t, tt.args...)); err != nil {
+			t.Fatal(err)
+		}
+		app.writeQRFiles(w)
+		entries, _ := os.ReadDir(dir)
+		if len(entries) != len(tt.files) {
+			t.Errorf("%v: wrote %d files, want %v", tt.args, len(entries), tt.files)
+		}
+		for name, perm := range tt.files {
+			info, err := os.Stat(filepath.Join(dir, name))
+			if err != nil {
+				t.Error(err)
+			} else if info.Mode().Perm() != perm {
+				t.Errorf("%s has mode %o, want %o", name, info.Mode().Perm(), perm)
+			}
+		}
+	}
+}
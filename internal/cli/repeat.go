@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+)
+
+// benchmarkRepeat holds the --repeat, --confidence, --save and --baseline flags
+type benchmarkRepeat struct {
+	runs       int
+	confidence bool
+	save       string
+	baseline   *benchmarkRecord
+	baseFile   string
+}
+
+// benchmarkRecord is the file --save writes and --baseline reads
+type benchmarkRecord struct {
+	Version   int              `json:"version"`
+	CreatedAt time.Time        `json:"created_at"`
+	Threads   int              `json:"threads"`
+	Speeds    []float64        `json:"speeds"`
+	Summary   utils.RunSummary `json:"summary"`
+}
+
+// parseBenchmarkRepeat reads the repeat flags; it returns nil for a plain single run
+func parseBenchmarkRepeat(cmd *cobra.Command) (*benchmarkRepeat, error) {
+	r := &benchmarkRepeat{}
+	r.runs, _ = cmd.Flags().GetInt("repeat")
+	r.confidence, _ = cmd.Flags().GetBool("confidence")
+	r.save, _ = cmd.Flags().GetString("save")
+	r.baseFile, _ = cmd.Flags().GetString("baseline")
+
+	if r.runs < 1 {
+		return nil, errors.NewValidationError("run_benchmark", "--repeat must be at least 1")
+	}
+	if r.confidence && r.runs < 2 {
+		return nil, errors.NewValidationError("run_benchmark", "--confidence needs --repeat 2 or more")
+	}
+	if r.baseFile != "" {
+		baseline, err := readBenchmarkRecord(r.baseFile)
+		if err != nil {
+			return nil, err
+		}
+		r.baseline = baseline
+	}
+	if r.runs == 1 && r.save == "" && r.baseline == nil {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// readBenchmarkRecord loads a --baseline file
+func readBenchmarkRecord(path string) (*benchmarkRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", fmt.Sprintf("failed to read baseline %s", path))
+	}
+	var record benchmarkRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", fmt.Sprintf("invalid baseline %s", path))
+	}
+	if record.Version != 1 || len(record.Speeds) == 0 {
+		return nil, errors.NewValidationError("run_benchmark", fmt.Sprintf("%s is not a benchmark --save file", path))
+	}
+	// The speeds are authoritative; the stored summary is for readers
+	record.Summary = utils.SummarizeRuns(record.Speeds)
+	return &record, nil
+}
+
+// runBenchmarkRepeats runs the benchmark r.runs times on fresh worker pools and
+// summarizes the average speed of each run
+func (app *Application) runBenchmarkRepeats(ctx context.Context, attempts int, duration time.Duration, r *benchmarkRepeat) error {
+	fmt.Printf("Running benchmark %d times...\n", r.runs)
+	fmt.Printf("Attempts: %s per run\n", formatLargeNumber(int64(attempts)))
+	fmt.Printf("Duration: %v per run\n", duration)
+	if app.warmup > 0 {
+		fmt.Printf("Warm-up: %v per run (excluded)\n", app.warmup)
+	}
+	fmt.Printf("Threads: %d\n\n", app.config.Worker.ThreadCount)
+
+	speeds := make([]float64, 0, r.runs)
+	for i := 1; i <= r.runs; i++ {
+		if ctx.Err() != nil {
+			return errors.NewCancellationError("run_benchmark", "benchmark cancelled")
+		}
+		result, err := app.runBenchmarkOnce(ctx, attempts, duration)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Run %d/%d: %s addr/s\n", i, r.runs, formatLargeNumber(int64(result.AverageSpeed)))
+		speeds = append(speeds, result.AverageSpeed)
+	}
+
+	record := benchmarkRecord{
+		Version:   1,
+		CreatedAt: time.Now().UTC(),
+		Threads:   app.config.Worker.ThreadCount,
+		Speeds:    speeds,
+		Summary:   utils.SummarizeRuns(speeds),
+	}
+	printRunSummary(record.Summary, r.confidence)
+	if r.baseline != nil {
+		if r.baseline.Threads != record.Threads {
+			fmt.Fprintf(os.Stderr, "Warning: the baseline ran %d threads, this benchmark %d\n", r.baseline.Threads, record.Threads)
+		}
+		printBaselineVerdict(r.baseFile, r.baseline.Summary, record.Summary)
+	}
+	if r.save != "" {
+		return writeBenchmarkRecord(r.save, record)
+	}
+	return nil
+}
+
+// printRunSummary prints the spread of the repeated runs
+func printRunSummary(s utils.RunSummary, confidence bool) {
+	fmt.Printf("\nRepeated Benchmark (%d runs):\n", s.Runs)
+	fmt.Println("═══════════════════════════════════════")
+	fmt.Printf("Mean: %s addr/s\n", formatLargeNumber(int64(s.Mean)))
+	fmt.Printf("Median: %s addr/s\n", formatLargeNumber(int64(s.Median)))
+	if s.Runs < 2 {
+		return
+	}
+	cv := 0.0
+	if s.Mean > 0 {
+		cv = s.StdDev / s.Mean * 100
+	}
+	fmt.Printf("Std Dev: %s addr/s (%.1f%% of the mean)\n", formatLargeNumber(int64(s.StdDev)), cv)
+	if confidence {
+		fmt.Printf("95%% Confidence Interval: %s - %s addr/s\n", formatLargeNumber(int64(s.Low)), formatLargeNumber(int64(s.High)))
+	}
+}
+
+// printBaselineVerdict compares with the baseline, naming a winner only when the
+// difference is larger than the run-to-run noise of both benchmarks
+func printBaselineVerdict(path string, baseline, current utils.RunSummary) {
+	fmt.Printf("\nBaseline (%s, %d runs): %s addr/s\n", path, baseline.Runs, formatLargeNumber(int64(baseline.Mean)))
+	c := utils.CompareRuns(baseline, current)
+	percent := func(v float64) float64 { return v / baseline.Mean * 100 }
+	switch {
+	case baseline.Runs < 2 || current.Runs < 2:
+		fmt.Printf("No verdict: the noise is unknown; benchmark both with --repeat 2 or more (difference %+.1f%%)\n", percent(c.Difference))
+	case !c.Significant:
+		fmt.Printf("No verdict: the difference of %+.1f%% is within the noise (95%% CI %+.1f%% to %+.1f%%)\n",
+			percent(c.Difference), percent(c.Low), percent(c.High))
+	case c.Difference > 0:
+		fmt.Printf("Verdict: %.1f%% faster than the baseline (95%% CI %+.1f%% to %+.1f%%)\n",
+			percent(c.Difference), percent(c.Low), percent(c.High))
+	default:
+		fmt.Printf("Verdict: %.1f%% slower than the baseline (95%% CI %+.1f%% to %+.1f%%)\n",
+			-percent(c.Difference), percent(c.Low), percent(c.High))
+	}
+}
+
+// writeBenchmarkRecord writes the --save file
+func writeBenchmarkRecord(path string, record benchmarkRecord) error {
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", "failed to encode benchmark results")
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", fmt.Sprintf("failed to write %s", path))
+	}
+	fmt.Printf("Benchmark speeds saved to: %s\n", path)
+	return nil
+}
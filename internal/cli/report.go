@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// recordWallet applies --label, --tag, --include-pubkey and --qr-file to a generated wallet and remembers it for the account report
+func (app *Application) recordWallet(w *wallet.Wallet) {
+	if w == nil {
+		return
+	}
+	if w.Label == "" {
+		w.Label = app.label
+	}
+	if len(w.Tags) == 0 && len(app.tags) > 0 {
+		w.Tags = append([]string(nil), app.tags...)
+	}
+	app.attachPublicKeys(w)
+	app.sealWallet(w)
+	app.writeQRFiles(w)
+	app.generatedMu.Lock()
+	app.generated = append(app.generated, w)
+	app.generatedMu.Unlock()
+	app.auditWalletFound(w)
+}
+
+// writeAccountReport writes the wallets generated in this run to path as CSV,
+// or as JSON when path ends in .json
+func (app *Application) writeAccountReport(path string) error {
+	app.generatedMu.Lock()
+	wallets := append([]*wallet.Wallet(nil), app.generated...)
+	app.generatedMu.Unlock()
+	sortWallets(app, wallets, func(w *wallet.Wallet) *wallet.Wallet { return w })
+
+	format := "csv"
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		format = "json"
+	}
+
+	entries := make([]crypto.AccountReportEntry, 0, len(wallets))
+	unverified := 0
+	for i, w := range wallets {
+		label := w.Label
+		if label == "" {
+			label = fmt.Sprintf("wallet-%d", i+1)
+		}
+		entry := crypto.NewAccountReportEntry(w, label)
+		entry.ExplorerURL = app.explorer.URL(w.Network, w.Address)
+		if w.Mnemonic != "" && !entry.MnemonicVerified {
+			unverified++
+		}
+		entries = append(entries, entry)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"account_report", fmt.Sprintf("failed to create %s", path))
+	}
+	if err := crypto.WriteAccountReport(file, format, entries); err != nil {
+		file.Close()
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"account_report", fmt.Sprintf("failed to write %s", path))
+	}
+	if err := file.Close(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"account_report", fmt.Sprintf("failed to write %s", path))
+	}
+
+	if !app.config.CLI.QuietMode {
+		fmt.Printf("Account report saved to: %s (%d wallets)\n", path, len(entries))
+	}
+	if unverified > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d mnemonic(s) do not derive their address on the standard path; see the report notes\n", unverified)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/chain"
+	"bloco-eth/pkg/errors"
+)
+
+// parseRPCFlags reads --rpc-url and --rpc-timeout; without --rpc-url no network requests are made
+func (app *Application) parseRPCFlags(cmd *cobra.Command) error {
+	app.rpcClient = nil
+	rpcURL, _ := cmd.Flags().GetString("rpc-url")
+	if rpcURL == "" {
+		return nil
+	}
+	parsed, err := url.Parse(rpcURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.NewValidationError("parse_flags", fmt.Sprintf("invalid --rpc-url %q: must be an http or https URL", rpcURL))
+	}
+	timeout, _ := cmd.Flags().GetDuration("rpc-timeout")
+	app.rpcClient = chain.NewClient(rpcURL, timeout)
+	return nil
+}
+
+// checkWalletsOnChain confirms that the Ethereum wallets generated in this run
+// have no balance, no transactions and no reverse ENS record
+func (app *Application) checkWalletsOnChain(ctx context.Context) error {
+	if app.rpcClient == nil {
+		return nil
+	}
+	app.generatedMu.Lock()
+	wallets := app.generated
+	app.generatedMu.Unlock()
+	if len(wallets) == 0 {
+		return nil
+	}
+
+	quiet := app.config.CLI.QuietMode
+	if !quiet {
+		fmt.Printf("\n%sChecking found addresses on-chain...\n", icon("🔎"))
+	}
+
+	var inUse []string
+	for _, w := range wallets {
+		network := strings.ToLower(w.Network)
+		if network != "" && network != "ethereum" {
+			if !quiet {
+				fmt.Printf("  %s: skipped (on-chain check only supports Ethereum)\n", w.Address)
+			}
+			continue
+		}
+
+		status, err := app.rpcClient.CheckAddress(ctx, w.Address)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"rpc_check", fmt.Sprintf("failed to check %s on-chain", w.Address))
+		}
+		if status.Unused() {
+			if !quiet {
+				fmt.Printf("  %s: unused (balance 0, nonce 0, no ENS name)\n", w.Address)
+			}
+			continue
+		}
+
+		detail := fmt.Sprintf("balance %s wei, nonce %d", status.Balance, status.Nonce)
+		if status.ENSName != "" {
+			detail += fmt.Sprintf(", ENS name %s", status.ENSName)
+		}
+		fmt.Fprintf(os.Stderr, "  %s: IN USE (%s)\n", w.Address, detail)
+		inUse = append(inUse, w.Address)
+	}
+
+	if len(inUse) > 0 {
+		return errors.NewValidationError("rpc_check", fmt.Sprintf(
+			"%d generated address(es) already have on-chain activity: %s; do not use these keys and check the system's random number generator",
+			len(inUse), strings.Join(inUse, ", ")))
+	}
+	return nil
+}
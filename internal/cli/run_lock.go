@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/errors"
+)
+
+// RunLockName is the lock file a run holds in its keystore directory
+const RunLockName = ".bloco.lock"
+
+// Runs refresh their lock every runLockHeartbeat; a lock not refreshed for
+// runLockStale belongs to a run that is gone
+var (
+	runLockHeartbeat = 30 * time.Second
+	runLockStale     = 2 * time.Minute
+)
+
+// RunLock identifies the run writing to a keystore directory or vault
+type RunLock struct {
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	Command   string    `json:"command"`
+	Pattern   string    `json:"pattern"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// heldRunLock is a lock file this process holds until release
+type heldRunLock struct {
+	path string
+	lock RunLock
+	stop chan struct{}
+	once sync.Once
+}
+
+// runLockPath returns the lock file guarding where the run writes wallets
+func (app *Application) runLockPath() string {
+	if app.vault != nil {
+		return app.vault.Path() + ".lock"
+	}
+	return filepath.Join(app.config.KeyStore.OutputDir, RunLockName)
+}
+
+// lockOutput keeps other runs from writing to this run's keystore directory or
+// vault. The lock of a run that is gone is taken over with a warning, and force
+// takes over any lock.
+func (app *Application) lockOutput(command, pattern string, force bool) (*heldRunLock, error) {
+	path := app.runLockPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "run_lock", "failed to create the keystore directory")
+	}
+	host, _ := os.Hostname()
+	held := &heldRunLock{
+		path: path,
+		lock: RunLock{PID: os.Getpid(), Host: host, Command: command, Pattern: pattern, StartedAt: time.Now().UTC()},
+		stop: make(chan struct{}),
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := held.create()
+		if err == nil {
+			break
+		}
+		if !os.IsExist(err) || attempt > 0 {
+			return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "run_lock", fmt.Sprintf("failed to lock %s", path))
+		}
+		other, stale := readRunLock(path, host)
+		switch {
+		case stale:
+			fmt.Fprintf(os.Stderr, "Warning: taking over the stale lock %s of %s\n", path, other.describe())
+		case force:
+			fmt.Fprintf(os.Stderr, "Warning: --force takes over the lock %s of %s\n", path, other.describe())
+		default:
+			return nil, errors.NewConfigurationError("run_lock", fmt.Sprintf(
+				"%s is in use by %s; wait for it to finish, choose another output, or pass --force if that run is not writing there",
+				filepath.Dir(path), other.describe()))
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "run_lock", fmt.Sprintf("failed to remove %s", path))
+		}
+	}
+
+	go held.heartbeat()
+	return held, nil
+}
+
+// create writes the lock file, failing if it exists
+func (h *heldRunLock) create() error {
+	data, err := json.Marshal(h.lock)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(h.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		os.Remove(h.path)
+		return err
+	}
+	return file.Close()
+}
+
+// heartbeat refreshes the lock's modification time until release
+func (h *heldRunLock) heartbeat() {
+	ticker := time.NewTicker(runLockHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			now := time.Now()
+			_ = os.Chtimes(h.path, now, now)
+		case <-h.stop:
+			return
+		}
+	}
+}
+
+// release removes the lock file, unless another run has taken it over
+func (h *heldRunLock) release() {
+	if h == nil {
+		return
+	}
+	h.once.Do(func() {
+		close(h.stop)
+		if current, err := loadRunLock(h.path); err == nil && current == h.lock {
+			_ = os.Remove(h.path)
+		}
+	})
+}
+
+// readRunLock reads the lock at path and reports whether its run is gone: a run
+// on this host whose process has exited, or any run that stopped refreshing it
+func readRunLock(path, host string) (RunLock, bool) {
+	lock, err := loadRunLock(path)
+	info, statErr := os.Stat(path)
+	if statErr != nil {
+		return lock, true
+	}
+	if err != nil {
+		// A run that died while writing the lock leaves it unreadable
+		return lock, time.Since(info.ModTime()) > runLockStale
+	}
+	if lock.Host == host && !processAlive(lock.PID) {
+		return lock, true
+	}
+	return lock, time.Since(info.ModTime()) > runLockStale
+}
+
+// loadRunLock reads a lock file
+func loadRunLock(path string) (RunLock, error) {
+	var lock RunLock
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return lock, err
+	}
+	err = json.Unmarshal(data, &lock)
+	return lock, err
+}
+
+// describe names the run holding a lock
+func (l RunLock) describe() string {
+	if l.PID == 0 {
+		return "an unknown run"
+	}
+	return fmt.Sprintf("%q (pattern %s, pid %d on %s, started %s)",
+		l.Command, l.Pattern, l.PID, l.Host, l.StartedAt.Local().Format(time.RFC3339))
+}
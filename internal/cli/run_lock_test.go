@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/config"
+)
+
+// writeRunLock writes a lock of another run to path, last refreshed at modified
+func writeRunLock(t *testing.T, path string, lock RunLock, modified time.Time) {
+	t.Helper()
+	data, _ := json.Marshal(lock)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLockOutput(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.KeyStore.OutputDir = filepath.Join(t.TempDir(), "keystores")
+	app := &Application{config: cfg}
+	path := filepath.Join(cfg.KeyStore.OutputDir, RunLockName)
+
+	held, err := app.lockOutput("bloco-eth", "abc", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A second run is refused while the first holds the lock
+	if _, err := app.lockOutput("bloco-eth", "abc", false); ExitCode(err) != ExitConfiguration {
+		t.Fatalf("second lockOutput() = %v, want a configuration error", err)
+	}
+	held.release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("lock left after release: %v", err)
+	}
+
+	host, _ := os.Hostname()
+	exited := exec.Command("go", "version")
+	if err := exited.Run(); err != nil {
+		t.Fatal(err)
+	}
+	for name, tt := range map[string]struct {
+		lock     RunLock
+		modified time.Time
+		force    bool
+		taken    bool
+	}{
+		"running elsewhere":     {lock: RunLock{PID: 1, Host: "build-02"}, modified: time.Now()},
+		"exited on this host":   {lock: RunLock{PID: exited.Process.Pid, Host: host}, modified: time.Now(), taken: true},
+		"heartbeat stopped":     {lock: RunLock{PID: 1, Host: "build-02"}, modified: time.Now().Add(-time.Hour), taken: true},
+		"unreadable and recent": {modified: time.Now()},
+		"forced":                {lock: RunLock{PID: 1, Host: "build-02"}, modified: time.Now(), force: true, taken: true},
+	} {
+		if tt.lock.PID == 0 {
+			if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			writeRunLock(t, path, tt.lock, tt.modified)
+		}
+		held, err := app.lockOutput("bloco-eth", "abc", tt.force)
+		if (err == nil) != tt.taken {
+			t.Errorf("%s: lockOutput() = %v, want taken %v", name, err, tt.taken)
+		}
+		held.release()
+		os.Remove(path)
+	}
+
+	// A run whose lock was taken over leaves the new lock in place
+	held, err = app.lockOutput("bloco-eth", "abc", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeRunLock(t, path, RunLock{PID: 1, Host: "build-02"}, time.Now())
+	held.release()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("release removed another run's lock: %v", err)
+	}
+}
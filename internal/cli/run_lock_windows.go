@@ -0,0 +1,23 @@
+//go:build windows
+
+package cli
+
+import "golang.org/x/sys/windows"
+
+// stillActive is the exit code of a process that has not exited
+const stillActive = 259
+
+// processAlive reports whether a process with this pid is running on this host
+func processAlive(pid int) bool {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		// A process we may not open still exists
+		return err == windows.ERROR_ACCESS_DENIED
+	}
+	defer windows.CloseHandle(handle)
+	var code uint32
+	if err := windows.GetExitCodeProcess(handle, &code); err != nil {
+		return true
+	}
+	return code == stillActive
+}
@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/screening"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// screeningState holds the --screen-list screener and what it screened this run
+type screeningState struct {
+	screener screening.Screener
+	lists    int
+	report   *os.File
+
+	mu       sync.Mutex
+	screened int
+	rejected int
+}
+
+// screeningEntry is one line of the --screen-report file
+type screeningEntry struct {
+	Time    time.Time        `json:"time"`
+	Address string           `json:"address"`
+	Listed  bool             `json:"listed"`
+	Match   *screening.Match `json:"match,omitempty"`
+}
+
+// parseScreeningFlags loads the --screen-list files and the --bloom-filter, and
+// screens against the open --vault; without them nothing is screened
+func (app *Application) parseScreeningFlags(cmd *cobra.Command) error {
+	app.screening = nil
+	paths, _ := cmd.Flags().GetStringArray("screen-list")
+	bloomPath, _ := cmd.Flags().GetString("bloom-filter")
+	reportPath, _ := cmd.Flags().GetString("screen-report")
+	if len(paths) == 0 && bloomPath == "" && app.vault == nil {
+		if reportPath != "" {
+			return errors.NewValidationError("parse_flags", "--screen-report requires --screen-list, --bloom-filter or --vault")
+		}
+		return nil
+	}
+
+	var chain screening.Chain
+	var notes []string
+	listed := 0
+	for _, path := range paths {
+		list, err := screening.LoadList(path)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "invalid --screen-list")
+		}
+		chain = append(chain, list)
+		listed += list.Len()
+	}
+	if len(paths) > 0 {
+		notes = append(notes, fmt.Sprintf("Screening found addresses against %d list(s) with %d address(es)", len(paths), listed))
+	}
+	if bloomPath != "" {
+		filter, err := screening.LoadBloom(bloomPath)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "parse_flags", "invalid --bloom-filter")
+		}
+		chain = append(chain, filter)
+		notes = append(notes, fmt.Sprintf("Rejecting addresses possibly in the Bloom filter %s (%d address(es), ~%.4g%% false positives)",
+			filter.Name(), filter.Len(), filter.FalsePositiveRate()*100))
+	}
+	if app.vault != nil {
+		chain = append(chain, vaultScreener{vault: app.vault})
+		notes = append(notes, fmt.Sprintf("Rejecting addresses already stored in the vault %s (%d wallet(s))",
+			app.vault.Path(), len(app.vault.Entries())))
+	}
+
+	state := &screeningState{screener: chain, lists: len(chain)}
+	if reportPath != "" {
+		file, err := os.OpenFile(reportPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration,
+				"parse_flags", fmt.Sprintf("failed to open --screen-report %s", reportPath))
+		}
+		state.report = file
+	}
+	app.screening = state
+	if !app.config.CLI.QuietMode {
+		for _, note := range notes {
+			fmt.Println(note)
+		}
+	}
+	return nil
+}
+
+// vaultScreener rejects addresses already stored in the vault, so a vault never
+// holds the same address twice across runs
+type vaultScreener struct {
+	vault *crypto.Vault
+}
+
+// Name returns the vault file name
+func (s vaultScreener) Name() string {
+	return filepath.Base(s.vault.Path())
+}
+
+// Screen reports addresses the vault already holds
+func (s vaultScreener) Screen(_ context.Context, address string) (*screening.Match, error) {
+	entry, ok := s.vault.Find(address)
+	if !ok {
+		return nil, nil
+	}
+	label := "already stored"
+	if entry.Label != "" {
+		label += " as " + entry.Label
+	}
+	return &screening.Match{List: s.Name(), Label: label}, nil
+}
+
+// screenPool makes pool regenerate wallets whose address is on a --screen-list
+func (app *Application) screenPool(pool worker.WorkerPool) worker.WorkerPool {
+	if app.screening == nil {
+		return pool
+	}
+	return &screenedPool{WorkerPool: pool, app: app}
+}
+
+// screenedPool is a worker pool that keeps searching past listed addresses
+type screenedPool struct {
+	worker.WorkerPool
+	app *Application
+}
+
+// GenerateWalletWithContext returns the first wallet whose address is not listed.
+// The attempts and time spent on listed wallets count towards the result.
+func (p *screenedPool) GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	var attempts int64
+	var duration time.Duration
+	for {
+		result, err := p.WorkerPool.GenerateWalletWithContext(ctx, criteria)
+		if err != nil {
+			return nil, err
+		}
+		attempts += result.Attempts
+		duration += result.Duration
+		accepted, err := p.app.screenWallet(ctx, result.Wallet)
+		if err != nil {
+			return nil, err
+		}
+		if accepted {
+			result.Attempts, result.Duration = attempts, duration
+			return result, nil
+		}
+	}
+}
+
+// screenWallet screens a found wallet and records the result, reporting whether
+// the wallet may be used
+func (app *Application) screenWallet(ctx context.Context, w *wallet.Wallet) (bool, error) {
+	s := app.screening
+	if s == nil || w == nil {
+		return true, nil
+	}
+	match, err := s.screener.Screen(ctx, w.Address)
+	if err != nil {
+		return false, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"screening", fmt.Sprintf("failed to screen %s", w.Address))
+	}
+
+	s.mu.Lock()
+	s.screened++
+	if match != nil {
+		s.rejected++
+	}
+	if s.report != nil {
+		line, _ := json.Marshal(screeningEntry{Time: time.Now().UTC(), Address: w.Address, Listed: match != nil, Match: match})
+		if _, err := s.report.Write(append(line, '\n')); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write screening report: %v\n", err)
+		}
+	}
+	s.mu.Unlock()
+
+	if match == nil {
+		return true, nil
+	}
+	detail := match.List
+	if match.Label != "" {
+		detail += ": " + match.Label
+	}
+	fmt.Fprintf(os.Stderr, "Screening: %s is listed (%s); discarding it and searching again\n", w.Address, detail)
+	if app.auditTrail != nil {
+		app.audit("wallet_screened_out", map[string]string{"address": w.Address, "list": match.List, "label": match.Label})
+	}
+	return false, nil
+}
+
+// finishScreening prints a summary of the screening and closes the report
+func (app *Application) finishScreening() {
+	s := app.screening
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !app.config.CLI.QuietMode {
+		fmt.Printf("Screening: %d address(es) checked against %d list(s), %d listed and regenerated\n",
+			s.screened, s.lists, s.rejected)
+	}
+	if s.report != nil {
+		if err := s.report.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to write screening report: %v\n", err)
+		}
+		s.report = nil
+	}
+}
+
+// printRejectWords describes how --reject-words changes the difficulty
+func printRejectWords(criteria wallet.GenerationCriteria) {
+	if len(criteria.RejectWords) == 0 {
+		return
+	}
+	alphabet, _ := utils.AddressShape(criteria.Network)
+	impossible := 0
+	for _, word := range criteria.RejectWords {
+		if vanitymath.RejectionProbability([]string{word}, len(word), alphabet) == 0 {
+			impossible++
+		}
+	}
+	rate := criteria.RejectionRate()
+	fmt.Printf("Reject words: %d word(s) reject ~%.4f%% of matches; difficulty and estimates include this (x%.6f)\n",
+		len(criteria.RejectWords), rate*100, 1/(1-rate))
+	if impossible > 0 {
+		network := criteria.Network
+		if network == "" {
+			network = "ethereum"
+		}
+		fmt.Printf("Reject words: %d word(s) use characters that never appear in %s addresses\n", impossible, network)
+	}
+}
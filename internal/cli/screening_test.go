@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/screening"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+// sequencePool returns the given addresses in order
+type sequencePool struct {
+	worker.WorkerPool
+	addresses []string
+}
+
+func (p *sequencePool) GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	address := p.addresses[0]
+	p.addresses = p.addresses[1:]
+	return &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: address}, Attempts: 10, Duration: time.Second}, nil
+}
+
+func TestScreenedPool(t *testing.T) {
+	list, err := screening.ReadList("used", strings.NewReader("0xaaaa\n0xAAAB\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := &Application{config: config.DefaultConfig()}
+	app.screening = &screeningState{screener: screening.Chain{list}, lists: 1}
+
+	pool := app.screenPool(&sequencePool{addresses: []string{"0xaaaa", "0xaaab", "0xaaac"}})
+	result, err := pool.GenerateWalletWithContext(context.Background(), wallet.GenerationCriteria{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Wallet.Address != "0xaaac" || result.Attempts != 30 || result.Duration != 3*time.Second {
+		t.Errorf("result = %s after %d attempts in %v", result.Wallet.Address, result.Attempts, result.Duration)
+	}
+	if app.screening.screened != 3 || app.screening.rejected != 2 {
+		t.Errorf("screened %d, rejected %d", app.screening.screened, app.screening.rejected)
+	}
+
+	app.screening = nil
+	inner := &sequencePool{}
+	if app.screenPool(inner) != worker.WorkerPool(inner) {
+		t.Error("pool wrapped without --screen-list")
+	}
+}
+
+func TestCalculateDifficulty_RejectWords(t *testing.T) {
+	criteria := wallet.GenerationCriteria{Network: "ethereum", Prefix: "abc"}
+	plain := calculateDifficulty(criteria)
+
+	criteria.RejectWords = []string{"dead", "b00b"}
+	rate := criteria.RejectionRate()
+	if rate <= 0 || rate > 0.01 {
+		t.Fatalf("RejectionRate() = %v", rate)
+	}
+	if got := calculateDifficulty(criteria); math.Abs(got-plain/(1-rate)) > 1e-9 {
+		t.Errorf("difficulty = %v, want %v", got, plain/(1-rate))
+	}
+
+	criteria.RejectWords = []string{"xyz"}
+	if rate := criteria.RejectionRate(); rate != 0 {
+		t.Errorf("RejectionRate() of a non-hex word = %v", rate)
+	}
+}
+
+func TestVaultScreener(t *testing.T) {
+	vault, err := crypto.CreateVault(filepath.Join(t.TempDir(), "wallets.vault"), "correct horse battery staple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vault.Add(crypto.VaultEntry{Address: "0xAbC0000000000000000000000000000000000001", PrivateKey: "01", Label: "treasury"}); err != nil {
+		t.Fatal(err)
+	}
+
+	s := vaultScreener{vault: vault}
+	match, err := s.Screen(context.Background(), "0xabc0000000000000000000000000000000000001")
+	if err != nil || match == nil || match.List != "wallets.vault" || match.Label != "already stored as treasury" {
+		t.Errorf("Screen() of a stored address = %+v, %v", match, err)
+	}
+	if match, _ := s.Screen(context.Background(), "0xabc0000000000000000000000000000000000002"); match != nil {
+		t.Errorf("Screen() of a new address = %+v", match)
+	}
+}
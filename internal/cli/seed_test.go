@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+)
+
+func runSeedCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+	root := app.GetRootCommand()
+	root.SetArgs(append([]string{"seed"}, args...))
+	out := &strings.Builder{}
+	root.SetOut(out)
+	root.SetErr(&strings.Builder{})
+	err := root.Execute()
+	return out.String(), err
+}
+
+func TestSeedDerive(t *testing.T) {
+	seed := make([]byte, 32)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	path := filepath.Join(t.TempDir(), "master.seed")
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(seed)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runSeedCommand(t, "derive", "--master-seed-file", path, "--format", "json", "0")
+	if err != nil {
+		t.Fatalf("seed derive error = %v", err)
+	}
+	var keys []seedKey
+	if err := json.Unmarshal([]byte(out), &keys); err != nil {
+		t.Fatalf("seed derive output is not JSON: %v\n%s", err, out)
+	}
+	if len(keys) != 1 || keys[0].PrivateKey != "639d5ef2b449c656ab1755a28496ba3cc661ef10f35023936c491516e95848a8" {
+		t.Errorf("seed derive = %+v, want the key of counter 0", keys)
+	}
+	if keys[0].Fingerprint != "1ced0eca1f435621" {
+		t.Errorf("fingerprint = %s, want 1ced0eca1f435621", keys[0].Fingerprint)
+	}
+
+	if _, err := runSeedCommand(t, "derive", "--master-seed-file", path, "--network", "solana", "0"); ExitCode(err) != ExitConfiguration {
+		t.Errorf("seed derive --network solana = %v, want a configuration error", err)
+	}
+	if _, err := runSeedCommand(t, "derive", "--master-seed-file", path, "-1"); ExitCode(err) != ExitConfiguration {
+		t.Errorf("seed derive -1 = %v, want a configuration error", err)
+	}
+}
+
+func TestSeedNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "master.seed")
+	if _, err := runSeedCommand(t, "new", path); err != nil {
+		t.Fatalf("seed new error = %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("seed file mode = %v, want 0600", info.Mode().Perm())
+	}
+	if _, err := runSeedCommand(t, "derive", "--master-seed-file", path, "0"); err != nil {
+		t.Errorf("the new seed cannot be derived from: %v", err)
+	}
+	if _, err := runSeedCommand(t, "new", path); err == nil {
+		t.Error("seed new should refuse to overwrite an existing seed")
+	}
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/server"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// createServeCommand creates the serve subcommand
+func (app *Application) createServeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "serve",
+		Short:       "Run an HTTP API for submitting and monitoring generation jobs",
+		Annotations: map[string]string{unhardenedAnnotation: "true"},
+		Long: `Run an HTTP API that accepts generation jobs and streams their progress.
+
+Endpoints:
+  POST   /jobs              Submit a job: {"prefix":"abc","suffix":"","checksum":false,"count":1}
+  GET    /jobs              List jobs
+  GET    /jobs/{id}         Get a job (addresses only, private keys are never returned)
+  DELETE /jobs/{id}         Cancel a job
+  POST   /jobs/{id}/pause   Pause a job
+  POST   /jobs/{id}/resume  Resume a paused job
+  POST   /jobs/{id}/retry   Retry a failed or cancelled job
+  GET    /ws/jobs/{id}      WebSocket stream of JSON progress events
+  POST   /keyspaces         Start a distributed search: {"prefix":"abc","keys":1099511627776,"lease_keys":16777216}
+  GET    /keyspaces         List keyspaces (?shared=true: shared keyspaces being searched)
+  GET    /keyspaces/{id}    Get a keyspace with its coverage, live leases and found addresses
+  POST   /keyspaces/{id}/leases                   Lease the next keys to an agent
+  POST   /keyspaces/{id}/leases/{lease}/renew     Renew a lease and report progress
+  POST   /keyspaces/{id}/leases/{lease}/complete  Mark a lease searched
+  DELETE /keyspaces/{id}/leases/{lease}           Give a lease back
+  GET    /patterns          Get the pattern set with its combined difficulty and ETA
+  POST   /patterns          Add patterns: {"patterns":[{"prefix":"abc"},{"suffix":"def"}]}
+  DELETE /patterns/{pattern}  Remove a pattern (prefix, prefix:suffix or :suffix)
+  GET    /healthz           Liveness probe
+  GET    /readyz            Readiness probe
+
+POST requests must use Content-Type: application/json. With --ui, a web
+dashboard is served at / showing jobs, live throughput and found addresses.
+
+Jobs are persisted to --job-store and resumed after a restart. Failed jobs
+are retried automatically up to --job-retries times; finished jobs are pruned
+after --job-retention. Use "bloco-eth jobs" to manage jobs from the command line.
+
+With --api-keys, every /jobs, /keyspaces and /ws request needs an "Authorization: Bearer
+<token>" header (browsers may pass ?access_token= on the WebSocket route).
+The keys file is a JSON array of {"name","token_sha256","admin",
+"max_concurrent_jobs","max_difficulty","cpu_seconds"} entries; non-admin keys
+only see their own jobs and keyspaces. --audit-log appends one JSON line per submission and
+state change, recording which key acted on which pattern.
+
+A keyspace is a range of private keys derived from a seed and searched by
+"bloco-eth agent" processes. Each agent leases a block of keys, so no two
+agents search the same keys; a lease not renewed within --lease-ttl is handed
+to the next agent. Agents keep the wallets they find and report only the
+addresses. A keyspace created with "shared": true is visible to every API key,
+without its seed or ranges, so "bloco-eth donate" agents can search it.
+Keyspaces are held in memory.
+
+The pattern set is searched in the background at low priority, matching all of
+its patterns at once, and each pattern is retired once a wallet is found for it.
+--pattern-file seeds it with one pattern per line (prefix, prefix:suffix or
+:suffix) and is read again on SIGHUP; patterns can also be added and removed
+through /patterns, which needs an admin key with --api-keys. Either way the
+workers take up the change without restarting. Patterns added through the API
+are held in memory.
+
+Generated wallets are saved as keystore files in --keystore-dir.`,
+		Example: `  bloco-eth serve --listen 127.0.0.1:8080 --ui
+  curl -X POST localhost:8080/jobs -H 'Content-Type: application/json' -d '{"prefix":"abc"}'
+  bloco-eth serve --api-keys keys.json --audit-log audit.jsonl
+  bloco-eth serve --pattern-file targets.txt && kill -HUP <pid>
+  websocat ws://localhost:8080/ws/jobs/<id>`,
+		RunE: app.runServe,
+	}
+
+	cmd.Flags().String("listen", "127.0.0.1:8080", "Address to listen on")
+	cmd.Flags().Int("max-concurrent-jobs", 1, "Maximum number of jobs running at once")
+	cmd.Flags().Bool("ui", false, "Serve the web dashboard at /")
+	cmd.Flags().String("job-store", "./bloco-jobs.json", "File used to persist jobs across restarts (empty = in-memory only)")
+	cmd.Flags().Int("job-retries", 2, "Automatic retries for a failed job")
+	cmd.Flags().Duration("job-retry-backoff", 5*time.Second, "Delay before retrying a failed job")
+	cmd.Flags().Duration("job-retention", 7*24*time.Hour, "Prune finished jobs older than this (0 = keep forever)")
+	cmd.Flags().String("api-keys", "", "JSON file of API keys and quotas (empty = no authentication)")
+	cmd.Flags().String("audit-log", "", "Append an audit entry per job submission and state change to this file")
+	cmd.Flags().Bool("force", false, "Take over the lock of another run writing to the same keystore directory or vault")
+	cmd.Flags().Duration("lease-ttl", 2*time.Minute, "Hand a keyspace lease to another agent when it is not renewed for this long")
+	cmd.Flags().String("pattern-file", "", "Search the patterns of this file, one per line, in the background; read again on SIGHUP")
+
+	return cmd
+}
+
+// runServe starts the API server and blocks until the context is cancelled
+func (app *Application) runServe(cmd *cobra.Command, args []string) error {
+	if err := app.parseFlags(cmd); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", "failed to parse command flags")
+	}
+	if app.constantRate > 0 {
+		return errors.NewValidationError("serve", "--constant-rate is not supported by serve; job results are visible as soon as they are found")
+	}
+
+	if app.hardware != nil {
+		return errors.NewValidationError("serve", "--hardware is not supported by serve; jobs save their wallets as keystores")
+	}
+	if !app.config.KeyStore.Enabled {
+		return errors.NewConfigurationError("serve",
+			"serve mode requires keystore output; private keys are not returned by the API")
+	}
+
+	force, _ := cmd.Flags().GetBool("force")
+	lock, err := app.lockOutput(cmd.CommandPath(), "per job", force)
+	if err != nil {
+		return err
+	}
+	defer lock.release()
+
+	listen, _ := cmd.Flags().GetString("listen")
+	maxConcurrent, _ := cmd.Flags().GetInt("max-concurrent-jobs")
+	stallTimeout, _ := cmd.Flags().GetDuration("health-stall-timeout")
+	uiEnabled, _ := cmd.Flags().GetBool("ui")
+	if maxConcurrent < 1 {
+		return errors.NewValidationError("serve", "max-concurrent-jobs must be at least 1")
+	}
+	leaseTTL, _ := cmd.Flags().GetDuration("lease-ttl")
+	if leaseTTL <= 0 {
+		return errors.NewValidationError("serve", "--lease-ttl must be positive")
+	}
+
+	if cmd.Flags().Changed("prefix") || cmd.Flags().Changed("suffix") {
+		return errors.NewValidationError("serve", "--prefix and --suffix are not supported by serve; submit jobs or use --pattern-file")
+	}
+	patternBase, err := app.getGenerationCriteria(cmd)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "get_criteria", "invalid generation criteria")
+	}
+	patternFile, _ := cmd.Flags().GetString("pattern-file")
+	var filePatterns []server.PatternSpec
+	if patternFile != "" {
+		if filePatterns, err = loadPatternFile(patternFile); err != nil {
+			return err
+		}
+	}
+
+	newPool := func(network string) (worker.WorkerPool, error) {
+		return app.screenPool(app.watchdogPool(app.newWorkerPool(network))), nil
+	}
+	sink := func(ctx context.Context, w *wallet.Wallet) error {
+		app.auditWalletFound(w)
+		return app.generateAndSaveKeystoreWithContext(ctx, w, false)
+	}
+
+	jobConfig := server.DefaultJobManagerConfig()
+	jobConfig.MaxConcurrent = maxConcurrent
+	jobConfig.MaxRetries, _ = cmd.Flags().GetInt("job-retries")
+	jobConfig.RetryBackoff, _ = cmd.Flags().GetDuration("job-retry-backoff")
+	jobConfig.Retention, _ = cmd.Flags().GetDuration("job-retention")
+	// Concurrent jobs share the --threads workers by priority
+	jobConfig.Scheduler = worker.NewScheduler(app.config.Worker.ThreadCount)
+	if jobConfig.MaxRetries < 0 || jobConfig.RetryBackoff < 0 || jobConfig.Retention < 0 {
+		return errors.NewValidationError("serve", "job retries, backoff and retention cannot be negative")
+	}
+	storePath, _ := cmd.Flags().GetString("job-store")
+	if storePath != "" {
+		jobConfig.Store = server.NewFileJobStore(storePath)
+	}
+
+	var keyring *server.Keyring
+	if keysPath, _ := cmd.Flags().GetString("api-keys"); keysPath != "" {
+		var err error
+		if keyring, err = server.LoadKeyring(keysPath); err != nil {
+			return err
+		}
+		jobConfig.Quotas = keyring.QuotaFor
+	}
+
+	patterns := server.NewPatternRunner(patternBase, newPool, sink, jobConfig.Scheduler)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := patterns.Shutdown(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}()
+	if _, _, err := patterns.Reload(filePatterns); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "serve", "invalid --pattern-file "+patternFile)
+	}
+	if patternFile != "" {
+		stop := notifyReload(func() { app.reloadPatternFile(patterns, patternFile) })
+		defer stop()
+	}
+
+	manager := server.NewJobManagerWithConfig(newPool, sink, jobConfig)
+	apiServer := server.NewAPIServer(listen, manager, stallTimeout)
+	apiServer.SetUIEnabled(uiEnabled)
+	apiServer.SetKeyspaceCoordinator(server.NewKeyspaceCoordinator(leaseTTL))
+	apiServer.SetPatternRunner(patterns)
+	if keyring != nil {
+		apiServer.SetKeyring(keyring)
+	}
+	if auditPath, _ := cmd.Flags().GetString("audit-log"); auditPath != "" {
+		auditLog, err := server.OpenAuditLog(auditPath)
+		if err != nil {
+			return err
+		}
+		defer auditLog.Close()
+		apiServer.SetAuditLog(auditLog)
+	}
+	if err := apiServer.Start(); err != nil {
+		return err
+	}
+
+	requeued, err := manager.Restore()
+	if err != nil {
+		_ = apiServer.Shutdown(context.Background())
+		return err
+	}
+
+	if !app.config.CLI.QuietMode {
+		fmt.Printf("Serving job API on http://%s (keystores: %s)\n", apiServer.Addr(), app.keystoreLocation())
+		if uiEnabled {
+			fmt.Printf("Dashboard available at http://%s/\n", apiServer.Addr())
+		}
+		if requeued > 0 {
+			fmt.Printf("Resumed %d job(s) from %s\n", requeued, storePath)
+		}
+		if len(filePatterns) > 0 {
+			fmt.Printf("Searching %d pattern(s) from %s\n", len(filePatterns), patternFile)
+		}
+	}
+
+	<-cmd.Context().Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := manager.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := apiServer.Shutdown(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to shutdown api server: %v\n", err)
+	}
+
+	return nil
+}
+
+// loadPatternFile reads a --pattern-file: one prefix, prefix:suffix or :suffix per line
+func loadPatternFile(path string) ([]server.PatternSpec, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "load_patterns", "failed to open --pattern-file")
+	}
+	defer file.Close()
+
+	var patterns []server.PatternSpec
+	err = readPatternLines(file, func(number int, line string) error {
+		prefix, suffix, err := parsePatternLine(line)
+		if err != nil {
+			return errors.NewConfigurationError("load_patterns", fmt.Sprintf("%s:%d: %v", path, number, err))
+		}
+		patterns = append(patterns, server.PatternSpec{Prefix: prefix, Suffix: suffix})
+		return nil
+	})
+	return patterns, err
+}
+
+// reloadPatternFile makes the pattern file's patterns those of the pattern set,
+// keeping the current ones if the file is invalid
+func (app *Application) reloadPatternFile(patterns *server.PatternRunner, path string) {
+	specs, err := loadPatternFile(path)
+	if err == nil {
+		var added, removed int
+		if added, removed, err = patterns.Reload(specs); err == nil {
+			if !app.config.CLI.QuietMode {
+				fmt.Printf("Reloaded %s: %d pattern(s) added, %d removed\n", path, added, removed)
+			}
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Warning: kept the current patterns, reloading %s failed: %v\n", path, err)
+}
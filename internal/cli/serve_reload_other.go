@@ -0,0 +1,9 @@
+//go:build !unix
+
+package cli
+
+// notifyReload has no reload signal here; the pattern file is read at startup and
+// patterns change through the API
+func notifyReload(func()) (stop func()) {
+	return func() {}
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// createSLIP39Command creates the slip39 subcommand group
+func (app *Application) createSLIP39Command() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "slip39",
+		Short: "Recover mnemonics backed up as SLIP-39 shares",
+		Long: `Work with SLIP-39 Shamir shares written by --slip39. The shares hold the
+BIP-39 entropy of a generated wallet, so combining enough of them restores the
+original BIP-39 mnemonic.`,
+	}
+
+	recoverCmd := &cobra.Command{
+		Use:   "recover <share-file>...",
+		Short: "Combine SLIP-39 share files back into the BIP-39 mnemonic",
+		Long: `Combine SLIP-39 share files back into the BIP-39 mnemonic. Each file holds one
+share; lines starting with # and blank lines are ignored. Pass "-" to read
+shares from stdin, one per line. The Ethereum address derived from the mnemonic
+on m/44'/60'/0'/0/0 is printed so it can be checked against the wallet.`,
+		Example: `  bloco-eth slip39 recover keystores/0xabc....slip39-1 keystores/0xabc....slip39-3
+  cat share-a.txt share-b.txt | bloco-eth slip39 recover -`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: app.runSLIP39Recover,
+	}
+
+	cmd.AddCommand(recoverCmd)
+	return cmd
+}
+
+// runSLIP39Recover combines share files and prints the mnemonic
+func (app *Application) runSLIP39Recover(cmd *cobra.Command, args []string) error {
+	var shares []string
+	for _, path := range args {
+		read, err := readSLIP39Shares(cmd, path)
+		if err != nil {
+			return err
+		}
+		shares = append(shares, read...)
+	}
+
+	mnemonic, err := crypto.SLIP39SharesToMnemonic(shares)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "slip39_recover", "failed to combine shares")
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Mnemonic: %s\n", mnemonic)
+	if address, _, err := crypto.DeriveEthereumAccount(mnemonic); err == nil {
+		fmt.Fprintf(out, "Ethereum address (%s): %s\n", crypto.EthereumDerivationPath, address)
+	}
+	return nil
+}
+
+// readSLIP39Shares reads one share per non-comment line from a file or stdin
+func readSLIP39Shares(cmd *cobra.Command, path string) ([]string, error) {
+	file := cmd.InOrStdin()
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeValidation,
+				"slip39_recover", fmt.Sprintf("failed to read %s", path))
+		}
+		defer f.Close()
+		file = f
+	}
+
+	var shares []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			shares = append(shares, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeValidation,
+			"slip39_recover", fmt.Sprintf("failed to read %s", path))
+	}
+	return shares, nil
+}
+
+// saveMnemonicBackup writes the mnemonic file, or SLIP-39 share files when --slip39 is set
+func (app *Application) saveMnemonicBackup(keystoreService *crypto.KeyStoreService, w *wallet.Wallet) error {
+	if app.slip39Count == 0 {
+		return keystoreService.SaveMnemonicFile(w.Address, w.Mnemonic, w.Network)
+	}
+
+	shares, err := crypto.MnemonicToSLIP39Shares(w.Mnemonic, app.slip39Threshold, app.slip39Count)
+	if err != nil {
+		return err
+	}
+	return keystoreService.SaveSLIP39ShareFiles(w.Address, shares, w.Network)
+}
+
+// mnemonicBackupName describes how mnemonics are saved
+func (app *Application) mnemonicBackupName() string {
+	if app.slip39Count > 0 {
+		return fmt.Sprintf("SLIP-39 shares (%d-of-%d)", app.slip39Threshold, app.slip39Count)
+	}
+	return "Mnemonic"
+}
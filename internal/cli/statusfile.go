@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/retry"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+const (
+	// statusPushInterval is the least time between two --status-url pushes of progress
+	statusPushInterval = time.Minute
+	// statusPushTimeout bounds one --status-url request
+	statusPushTimeout = 15 * time.Second
+	// statusGistFile is the file of a gist that --status-url updates
+	statusGistFile = "bloco-eth-status.json"
+)
+
+// runStatus is the --status-file and --status-url document: the progress of a run,
+// its found addresses and closest miss, and never any key material
+type runStatus struct {
+	Version     int                `json:"version"`
+	State       string             `json:"state"` // running, paused, done or failed
+	Host        string             `json:"host,omitempty"`
+	Pattern     string             `json:"pattern"`
+	Difficulty  float64            `json:"difficulty"`
+	Wallets     int                `json:"wallets"`
+	Found       int                `json:"found"`
+	Addresses   []string           `json:"addresses,omitempty"`
+	Attempts    int64              `json:"attempts"`
+	Speed       float64            `json:"speed"`
+	Probability float64            `json:"probability"`
+	ETA         []progressEventETA `json:"eta,omitempty"`
+	NearMiss    *worker.NearMiss   `json:"best_near_miss,omitempty"`
+	Paused      string             `json:"paused,omitempty"`
+	Error       string             `json:"error,omitempty"`
+	Started     time.Time          `json:"started"`
+	Updated     time.Time          `json:"updated"`
+	Elapsed     float64            `json:"elapsed_seconds"`
+}
+
+// statusReport keeps the runStatus of a run current for --status-file and --status-url
+type statusReport struct {
+	app     *Application
+	path    string
+	push    *statusPusher
+	targets []utils.ETAPercentile
+	misses  *worker.NearMissTracker
+
+	mu       sync.Mutex
+	status   runStatus
+	lastPush time.Time
+	warnOnce sync.Once
+}
+
+// startStatusReports subscribes --status-file and --status-url to the run's progress
+// broker. The returned context lets the searches under it report their closest miss.
+func (app *Application) startStatusReports(ctx context.Context, cmd *cobra.Command,
+	criteria wallet.GenerationCriteria, count int) (context.Context, error) {
+	path, _ := cmd.Flags().GetString("status-file")
+	target, _ := cmd.Flags().GetString("status-url")
+	if path == "" && target == "" {
+		return ctx, nil
+	}
+	r := &statusReport{app: app, path: path, misses: worker.NewNearMissTracker()}
+	if target != "" {
+		push, err := newStatusPusher(target, app.retry.Notify)
+		if err != nil {
+			return ctx, err
+		}
+		r.push = push
+	}
+	host, _ := os.Hostname()
+	difficulty := calculateDifficulty(criteria)
+	r.targets = utils.CalculateETAPercentiles(difficulty, count, app.etaPercentiles)
+	r.status = runStatus{
+		Version:    1,
+		State:      "running",
+		Host:       host,
+		Pattern:    criteria.GetPattern(),
+		Difficulty: difficulty,
+		Wallets:    count,
+		Started:    time.Now().UTC(),
+	}
+	if err := r.write(); err != nil {
+		return ctx, err
+	}
+	app.progress.Subscribe(app.statusInterval, r.handle)
+	return worker.WithNearMiss(ctx, r.misses), nil
+}
+
+// handle updates the status from a progress broker event
+func (r *statusReport) handle(e worker.ProgressEvent) {
+	r.mu.Lock()
+	s := &r.status
+	s.Attempts = max(s.Attempts, e.Stats.TotalAttempts)
+	s.Speed = e.Stats.TotalSpeed
+	final := false
+	switch e.Type {
+	case worker.ProgressWalletFound:
+		s.Found = e.Found
+		if e.Result != nil && e.Result.Wallet != nil {
+			s.Addresses = append(s.Addresses, e.Result.Wallet.Address)
+		}
+	case worker.ProgressDone:
+		final = true
+		s.State, s.Paused = "done", ""
+		if e.Err != nil {
+			s.State, s.Error = "failed", e.Err.Error()
+		}
+	}
+	s.Probability = vanitymath.Probability(s.Difficulty, s.Attempts) * 100
+	// A finished run has nothing left to estimate
+	s.ETA = nil
+	if !final {
+		s.State, s.Paused = "running", r.app.pausedReason()
+		if s.Paused != "" {
+			s.State = "paused"
+		}
+		for _, eta := range utils.EstimateETAPercentiles(r.targets, s.Attempts, e.ETASpeed) {
+			item := progressEventETA{Percent: eta.Percent}
+			if eta.Remaining >= 0 {
+				seconds := eta.Remaining.Seconds()
+				item.Seconds = &seconds
+			}
+			s.ETA = append(s.ETA, item)
+		}
+	}
+	if miss, ok := r.misses.Best(); ok {
+		s.NearMiss = &miss
+	}
+	s.Updated = time.Now().UTC()
+	s.Elapsed = s.Updated.Sub(s.Started).Seconds()
+	pushDue := e.Type != worker.ProgressTick || time.Since(r.lastPush) >= statusPushInterval
+	r.mu.Unlock()
+
+	if err := r.write(); err != nil {
+		r.warnOnce.Do(func() { fmt.Fprintf(os.Stderr, "Warning: %v; the run goes on\n", err) })
+	}
+	if r.push != nil && pushDue {
+		r.mu.Lock()
+		r.lastPush = time.Now()
+		r.mu.Unlock()
+		r.push.send(r.document(), final)
+	}
+}
+
+// document returns the status as indented JSON
+func (r *statusReport) document() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, _ := json.MarshalIndent(r.status, "", "  ")
+	return append(data, '\n')
+}
+
+// write replaces the --status-file atomically, so readers never see half a document
+func (r *statusReport) write() error {
+	if r.path == "" {
+		return nil
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, r.document(), 0644); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "status_file",
+			fmt.Sprintf("failed to write status file %s", r.path))
+	}
+	if err := os.Rename(tmp, r.path); err != nil {
+		_ = os.Remove(tmp)
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "status_file",
+			fmt.Sprintf("failed to write status file %s", r.path))
+	}
+	return nil
+}
+
+// statusPusher uploads the status to a paste endpoint with PUT, or to a GitHub gist
+type statusPusher struct {
+	endpoint string
+	gist     bool
+	token    string
+	client   *http.Client
+	policy   retry.Policy
+	warnOnce sync.Once
+}
+
+// newStatusPusher prepares pushes to target. https://api.github.com/gists/<id> is
+// updated through the gist API with $GITHUB_TOKEN; any other URL receives the
+// status with PUT, authorized by $BLOCO_STATUS_TOKEN when set.
+func newStatusPusher(target string, policy retry.Policy) (*statusPusher, error) {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, errors.NewValidationError("parse_flags", "--status-url must be an http or https URL")
+	}
+	p := &statusPusher{endpoint: target, client: &http.Client{Timeout: statusPushTimeout}, policy: policy}
+	if u.Host == "api.github.com" && strings.HasPrefix(u.Path, "/gists/") {
+		p.gist, p.token = true, os.Getenv("GITHUB_TOKEN")
+		if p.token == "" {
+			return nil, errors.NewConfigurationError("parse_flags", "--status-url to a gist needs a token with gist scope in $GITHUB_TOKEN")
+		}
+	} else {
+		p.token = os.Getenv("BLOCO_STATUS_TOKEN")
+	}
+	return p, nil
+}
+
+// send uploads doc, retrying the final status of the run. Failures are reported
+// once and never interrupt generation.
+func (p *statusPusher) send(doc []byte, final bool) {
+	attempt := func() error { return p.put(doc) }
+	var err error
+	if final {
+		err = retry.Do(context.Background(), p.policy, attempt, nil)
+	} else {
+		err = attempt()
+	}
+	if err != nil {
+		p.warnOnce.Do(func() {
+			fmt.Fprintf(os.Stderr, "Warning: failed to push the status to --status-url: %v\n", err)
+		})
+	}
+}
+
+// put makes one upload; client errors other than timeouts and rate limits are
+// permanent. Errors never include the URL, which may hold a secret.
+func (p *statusPusher) put(doc []byte) error {
+	method, body, contentType := http.MethodPut, doc, "application/json"
+	if p.gist {
+		method = http.MethodPatch
+		body, _ = json.Marshal(map[string]any{
+			"files": map[string]any{statusGistFile: map[string]string{"content": string(doc)}},
+		})
+		contentType = "application/vnd.github+json"
+	}
+	req, err := http.NewRequest(method, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("invalid URL"))
+	}
+	req.Header.Set("Content-Type", contentType)
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if stderrors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+		if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return retry.Permanent(err)
+		}
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/retry"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestStatusReport_Handle(t *testing.T) {
+	var pushed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("push = %s with %q, want a PUT with the token", r.Method, r.Header.Get("Authorization"))
+		}
+		pushed = append(pushed, string(body))
+	}))
+	defer server.Close()
+	t.Setenv("BLOCO_STATUS_TOKEN", "secret")
+	push, err := newStatusPusher(server.URL, retry.Policy{Attempts: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "status.json")
+	app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+	r := &statusReport{
+		app:     app,
+		path:    path,
+		push:    push,
+		targets: utils.CalculateETAPercentiles(256, 1, []float64{50}),
+		misses:  worker.NewNearMissTracker(),
+		status:  runStatus{Version: 1, State: "running", Pattern: "ab", Difficulty: 256, Wallets: 1},
+	}
+	read := func() runStatus {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s runStatus
+		if err := json.Unmarshal(data, &s); err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	r.handle(worker.ProgressEvent{Type: worker.ProgressTick, Stats: worker.AggregatedStats{TotalAttempts: 100, TotalSpeed: 50}, ETASpeed: 50})
+	if s := read(); s.State != "running" || s.Attempts != 100 || len(s.ETA) != 1 || s.ETA[0].Seconds == nil {
+		t.Errorf("status after a tick = %+v", s)
+	}
+
+	found := &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: "0xab12", PrivateKey: "deadbeef"}}
+	r.handle(worker.ProgressEvent{Type: worker.ProgressWalletFound, Found: 1, Result: found, Stats: worker.AggregatedStats{TotalAttempts: 200}})
+	r.handle(worker.ProgressEvent{Type: worker.ProgressDone, Stats: worker.AggregatedStats{TotalAttempts: 200}})
+	if s := read(); s.State != "done" || s.Found != 1 || len(s.Addresses) != 1 || s.ETA != nil {
+		t.Errorf("final status = %+v", s)
+	}
+
+	// The tick, the find and the end are pushed; key material never is
+	if len(pushed) != 3 {
+		t.Fatalf("pushed %d documents, want 3", len(pushed))
+	}
+	for _, doc := range pushed {
+		if strings.Contains(doc, "deadbeef") {
+			t.Fatalf("pushed status contains the private key: %s", doc)
+		}
+	}
+}
+
+func TestNewStatusPusher(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	for _, target := range []string{"ftp://example.com/status", "not a url", "https://api.github.com/gists/abc"} {
+		if _, err := newStatusPusher(target, retry.Policy{}); err == nil {
+			t.Errorf("newStatusPusher(%q) expected error, got nil", target)
+		}
+	}
+	t.Setenv("GITHUB_TOKEN", "token")
+	push, err := newStatusPusher("https://api.github.com/gists/abc", retry.Policy{})
+	if err != nil || !push.gist {
+		t.Errorf("newStatusPusher(gist) = %+v, %v", push, err)
+	}
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// patternResult is the JSON line --stdin-patterns writes for each found wallet, or
+// for a pattern that failed
+type patternResult struct {
+	Line       int     `json:"line"`
+	Pattern    string  `json:"pattern"`
+	Address    string  `json:"address,omitempty"`
+	PrivateKey string  `json:"private_key,omitempty"`
+	Mnemonic   string  `json:"mnemonic,omitempty"`
+	Attempts   int64   `json:"attempts,omitempty"`
+	Elapsed    float64 `json:"elapsed_seconds,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// errPatternTimeout is why a pattern found no match within --timeout
+var errPatternTimeout = stderrors.New("no match within --timeout")
+
+// parsePatternLine reads a --stdin-patterns line: a prefix, prefix:suffix or :suffix
+func parsePatternLine(line string) (prefix, suffix string, err error) {
+	prefix, suffix, _ = strings.Cut(line, ":")
+	prefix, suffix = strings.TrimSpace(prefix), strings.TrimSpace(suffix)
+	if prefix == "" && suffix == "" {
+		return "", "", fmt.Errorf("empty pattern")
+	}
+	if strings.Contains(suffix, ":") {
+		return "", "", fmt.Errorf("expected prefix, prefix:suffix or :suffix")
+	}
+	return prefix, suffix, nil
+}
+
+// runStdinPatterns generates --count-per-pattern wallets for every pattern read from
+// stdin, writing one JSON line per result as soon as it is found. Invalid and failed
+// patterns get an error line and the stream goes on; the exit code reports the worst
+// of them.
+func (app *Application) runStdinPatterns(cmd *cobra.Command) error {
+	for _, flag := range []string{"prefix", "suffix", "count", "key-range", "master-seed-file", "ceremony", "atomic-output"} {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError("parse_flags",
+				fmt.Sprintf("--%s cannot be combined with --stdin-patterns, which reads the patterns from stdin", flag))
+		}
+	}
+	perPattern, _ := cmd.Flags().GetInt("count-per-pattern")
+	if perPattern < 1 {
+		return errors.NewValidationError("parse_flags", "--count-per-pattern must be at least 1")
+	}
+	base, err := app.getGenerationCriteria(cmd)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "get_criteria", "invalid generation criteria")
+	}
+
+	if app.config.KeyStore.Enabled {
+		force, _ := cmd.Flags().GetBool("force")
+		lock, err := app.lockOutput(cmd.CommandPath(), "from stdin", force)
+		if err != nil {
+			return err
+		}
+		defer lock.release()
+	}
+
+	pool := app.screenPool(app.watchdogPool(app.newWorkerPool(base.Network)))
+	if err := pool.Start(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeWorker, "start_workers", "failed to start worker pool")
+	}
+	defer func() {
+		if err := pool.Shutdown(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to shutdown worker pool: %v\n", err)
+		}
+	}()
+	defer app.finishScreening()
+
+	ctx, stopPower := app.watchPower(cmd.Context())
+	defer stopPower()
+	out := json.NewEncoder(cmd.OutOrStdout())
+	patterns, found := 0, 0
+	invalid, timedOut, failed := 0, 0, 0
+	err = readPatternLines(cmd.InOrStdin(), func(number int, line string) error {
+		patterns++
+		prefix, suffix, err := parsePatternLine(line)
+		criteria := base
+		criteria.Prefix, criteria.Suffix = prefix, suffix
+		if err == nil {
+			err = criteria.Validate()
+		}
+		if err != nil {
+			invalid++
+		}
+		var writeErr error
+		if err == nil {
+			var n int
+			n, err = app.searchPattern(ctx, pool, criteria, perPattern, func(r patternResult) {
+				r.Line, r.Pattern = number, line
+				if writeErr == nil {
+					writeErr = out.Encode(r)
+				}
+			})
+			found += n
+			if stderrors.Is(err, errPatternTimeout) {
+				timedOut++
+			} else if err != nil {
+				failed++
+			}
+		}
+		if err != nil && writeErr == nil && ctx.Err() == nil {
+			writeErr = out.Encode(patternResult{Line: number, Pattern: line, Error: err.Error()})
+		}
+		switch {
+		case writeErr != nil:
+			return errors.WrapError(writeErr, errors.ErrorTypeConfiguration, "stdin_patterns", "failed to write results")
+		case ctx.Err() != nil:
+			return errors.NewCancellationError("stdin_patterns", "generation cancelled")
+		}
+		return nil
+	})
+	if !app.config.CLI.QuietMode {
+		fmt.Fprintf(os.Stderr, "Stdin patterns: %d wallets for %d patterns (%d invalid, %d timed out, %d failed)\n",
+			found, patterns, invalid, timedOut, failed)
+	}
+
+	if reportPath, _ := cmd.Flags().GetString("account-report"); reportPath != "" {
+		if reportErr := app.writeAccountReport(reportPath); reportErr != nil && err == nil {
+			err = reportErr
+		}
+	}
+	if app.paperWallet != nil {
+		if paperErr := app.writePaperWallet(); paperErr != nil && err == nil {
+			err = paperErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case failed > 0:
+		return errors.NewGenerationError("stdin_patterns", fmt.Sprintf("%d of %d patterns failed; see their error lines", failed, patterns), nil)
+	case invalid > 0:
+		return errors.NewValidationError("stdin_patterns", fmt.Sprintf("%d of %d patterns are invalid; see their error lines", invalid, patterns))
+	case timedOut > 0 && app.failOnTimeout:
+		return errors.NewBlocoError(errors.ErrorTypeTimeout, "stdin_patterns",
+			fmt.Sprintf("%d of %d patterns found no match within --timeout %s", timedOut, patterns, app.timeout))
+	}
+	return nil
+}
+
+// readPatternLines calls handle with each pattern line of r and its line number,
+// skipping blank lines and # comments
+func readPatternLines(r io.Reader, handle func(number int, line string) error) error {
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := handle(number, line); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "read_patterns", "failed to read patterns")
+	}
+	return nil
+}
+
+// searchPattern finds count wallets for criteria within --timeout, recording and
+// emitting each one, and returns how many it found
+func (app *Application) searchPattern(ctx context.Context, pool worker.WorkerPool, criteria wallet.GenerationCriteria,
+	count int, emit func(patternResult)) (int, error) {
+	if app.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, app.timeout)
+		defer cancel()
+	}
+	app.keystoreCriteria = criteria
+	for found := 0; found < count; found++ {
+		result, err := pool.GenerateWalletWithContext(ctx, criteria)
+		if err != nil {
+			if ctx.Err() == context.DeadlineExceeded {
+				err = fmt.Errorf("%w %v", errPatternTimeout, app.timeout)
+			}
+			return found, err
+		}
+		w := result.Wallet
+		app.recordWallet(w)
+		if app.config.KeyStore.Enabled {
+			if err := app.generateAndSaveKeystore(w); err != nil {
+				return found, fmt.Errorf("failed to save the keystore of %s: %w", w.Address, err)
+			}
+		}
+		mnemonic := w.Mnemonic
+		if app.hideKeys {
+			mnemonic = ""
+		}
+		emit(patternResult{
+			Address:    w.Address,
+			PrivateKey: app.printedKey(w),
+			Mnemonic:   mnemonic,
+			Attempts:   result.Attempts,
+			Elapsed:    result.Duration.Seconds(),
+		})
+	}
+	return count, nil
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/config"
+)
+
+func TestParsePatternLine(t *testing.T) {
+	for line, want := range map[string][2]string{"cafe": {"cafe", ""}, "ca:fe": {"ca", "fe"}, ":beef": {"", "beef"}, "ab :": {"ab", ""}} {
+		if prefix, suffix, err := parsePatternLine(line); err != nil || prefix != want[0] || suffix != want[1] {
+			t.Errorf("parsePatternLine(%q) = %q, %q, %v", line, prefix, suffix, err)
+		}
+	}
+	for _, line := range []string{":", " : ", "a:b:c"} {
+		if _, _, err := parsePatternLine(line); err == nil {
+			t.Errorf("parsePatternLine(%q) should fail", line)
+		}
+	}
+}
+
+// runStdinPatternsCommand runs the root command with --stdin-patterns reading input
+func runStdinPatternsCommand(t *testing.T, input string, args ...string) ([]patternResult, error) {
+	t.Helper()
+	app := NewApplication(config.DefaultConfig(), "dev", "unknown", "unknown")
+	root := app.GetRootCommand()
+	root.SetArgs(append([]string{"--stdin-patterns", "--no-keystore", "--quiet", "--threads", "2"}, args...))
+	root.SetIn(strings.NewReader(input))
+	out := &strings.Builder{}
+	root.SetOut(out)
+	root.SetErr(&strings.Builder{})
+	err := root.Execute()
+
+	var results []patternResult
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	for scanner.Scan() {
+		// Cobra prints the usage of a failed command to the same writer
+		if !strings.HasPrefix(scanner.Text(), "{") {
+			continue
+		}
+		var r patternResult
+		if jsonErr := json.Unmarshal(scanner.Bytes(), &r); jsonErr != nil {
+			t.Fatalf("output line %q is not JSON: %v", scanner.Text(), jsonErr)
+		}
+		results = append(results, r)
+	}
+	return results, err
+}
+
+func TestStdinPatterns(t *testing.T) {
+	results, err := runStdinPatternsCommand(t, "a\n# comment\n\n:b\n", "--count-per-pattern", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("got %d results, want 4: %+v", len(results), results)
+	}
+	for i, r := range results {
+		wantLine, wantPattern := 1, "a"
+		if i >= 2 {
+			wantLine, wantPattern = 4, ":b"
+		}
+		if r.Line != wantLine || r.Pattern != wantPattern || r.PrivateKey == "" || r.Error != "" {
+			t.Errorf("result %d = %+v", i, r)
+		}
+		if (wantPattern == "a" && !strings.HasPrefix(r.Address, "0xa")) || (wantPattern == ":b" && !strings.HasSuffix(r.Address, "b")) {
+			t.Errorf("%s does not match %s", r.Address, wantPattern)
+		}
+	}
+
+	// An invalid pattern gets an error line and the stream goes on
+	results, err = runStdinPatternsCommand(t, "zz\nc\n")
+	if ExitCode(err) != ExitConfiguration {
+		t.Errorf("Execute() = %v, want a validation error", err)
+	}
+	if len(results) != 2 || results[0].Error == "" || results[0].Line != 1 || results[1].Address == "" {
+		t.Errorf("results = %+v", results)
+	}
+
+	for _, args := range [][]string{{"--prefix", "a"}, {"--count", "2"}, {"--count-per-pattern", "0"}} {
+		if _, err := runStdinPatternsCommand(t, "a\n", args...); ExitCode(err) != ExitConfiguration {
+			t.Errorf("%v: Execute() = %v, want a validation error", args, err)
+		}
+	}
+}
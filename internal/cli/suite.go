@@ -0,0 +1,417 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// benchmarkScenario is one named run of a --suite file
+type benchmarkScenario struct {
+	Name     string        `json:"name"`
+	Network  string        `json:"network,omitempty"`
+	Prefix   string        `json:"prefix,omitempty"`
+	Suffix   string        `json:"suffix,omitempty"`
+	Checksum bool          `json:"checksum,omitempty"`
+	Threads  int           `json:"threads"`
+	Duration time.Duration `json:"duration"`
+}
+
+// scenarioResult is the outcome of one scenario in the suite report and checkpoint
+type scenarioResult struct {
+	benchmarkScenario
+	Attempts       int64     `json:"attempts"`
+	AverageSpeed   float64   `json:"average_speed"`
+	MinSpeed       float64   `json:"min_speed"`
+	MaxSpeed       float64   `json:"max_speed"`
+	Difficulty     float64   `json:"difficulty,omitempty"`
+	ETA50Seconds   float64   `json:"eta50_seconds,omitempty"`
+	CompletedAt    time.Time `json:"completed_at"`
+	ThreadBalance  float64   `json:"thread_balance"`
+	WarmupAttempts int64     `json:"warmup_attempts,omitempty"`
+	GCCycles       uint32    `json:"gc_cycles"`
+	GCPausePercent float64   `json:"gc_pause_percent"`
+}
+
+// suiteReport is the consolidated --suite-report, and the checkpoint of a suite
+// still running
+type suiteReport struct {
+	Version int    `json:"version"`
+	Suite   string `json:"suite"`
+	// SuiteSHA256 ties a checkpoint to the suite file it was written for
+	SuiteSHA256 string           `json:"suite_sha256"`
+	Results     []scenarioResult `json:"results"`
+}
+
+// suiteKeys are the keys a scenario may set
+var suiteKeys = map[string]bool{
+	"name": true, "network": true, "prefix": true, "suffix": true, "checksum": true, "threads": true, "duration": true,
+}
+
+// runBenchmarkSuite runs the scenarios of a --suite file one after another, saving
+// a checkpoint after each so an interrupted suite picks up at the first scenario
+// it did not finish
+func (app *Application) runBenchmarkSuite(cmd *cobra.Command, path string, attempts int, duration time.Duration) error {
+	for _, flag := range []string{"optimize-efficiency", "compare-threads", "repeat", "save", "baseline", "prefix", "suffix", "until-probability"} {
+		if cmd.Flags().Changed(flag) {
+			return errors.NewValidationError("run_benchmark", fmt.Sprintf("--%s cannot be combined with --suite, whose scenarios set the runs", flag))
+		}
+	}
+	// --threads is the default of scenarios that set none
+	if err := app.parseFlags(cmd); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", "failed to parse command flags")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", fmt.Sprintf("failed to read suite %s", path))
+	}
+	scenarios, err := parseSuite(data, app.config.Worker.ThreadCount, duration)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "run_benchmark", fmt.Sprintf("invalid suite %s", path))
+	}
+	// Scenarios run for their duration unless --attempts ends them first
+	if !cmd.Flags().Changed("attempts") {
+		attempts = math.MaxInt
+	}
+	sum := sha256.Sum256(data)
+	report := suiteReport{Version: 1, Suite: path, SuiteSHA256: hex.EncodeToString(sum[:])}
+
+	checkpoint := path + ".checkpoint"
+	if restart, _ := cmd.Flags().GetBool("restart-suite"); !restart {
+		if report.Results, err = readSuiteCheckpoint(checkpoint, report.SuiteSHA256, scenarios); err != nil {
+			return err
+		}
+	}
+	if done := len(report.Results); done > 0 {
+		fmt.Printf("Resuming suite %s: %d of %d scenarios already done\n", path, done, len(scenarios))
+	} else {
+		fmt.Printf("Running suite %s: %d scenarios\n", path, len(scenarios))
+	}
+
+	ctx := cmd.Context()
+	for i := len(report.Results); i < len(scenarios); i++ {
+		scenario := scenarios[i]
+		fmt.Printf("\nScenario %d/%d: %s (%s, %d threads, %v)\n", i+1, len(scenarios), scenario.Name,
+			describeScenarioPattern(scenario), scenario.Threads, scenario.Duration)
+		result, err := app.runScenario(ctx, scenario, attempts)
+		if err != nil {
+			return err
+		}
+		// A scenario cut short is not recorded, so a resumed suite runs it in full
+		if ctx.Err() != nil {
+			return errors.NewCancellationError("run_benchmark",
+				fmt.Sprintf("suite interrupted during %s; run it again to resume", scenario.Name))
+		}
+		report.Results = append(report.Results, result)
+		if err := writeSuiteReport(checkpoint, report); err != nil {
+			return err
+		}
+	}
+
+	printSuiteReport(report)
+	if reportPath, _ := cmd.Flags().GetString("suite-report"); reportPath != "" {
+		if err := writeSuiteReport(reportPath, report); err != nil {
+			return err
+		}
+		fmt.Printf("Suite report saved to: %s\n", reportPath)
+	}
+	if err := os.Remove(checkpoint); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Warning: failed to remove checkpoint %s: %v\n", checkpoint, err)
+	}
+	return nil
+}
+
+// runScenario benchmarks one scenario on a fresh worker pool
+func (app *Application) runScenario(ctx context.Context, scenario benchmarkScenario, attempts int) (scenarioResult, error) {
+	criteria := scenarioCriteria(scenario)
+	workerPool := worker.NewPool(scenario.Threads, criteria.Network)
+	if err := workerPool.Start(); err != nil {
+		return scenarioResult{}, errors.WrapError(err, errors.ErrorTypeWorker, "run_benchmark", "failed to start worker pool")
+	}
+	defer func() {
+		if err := workerPool.Shutdown(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to shutdown worker pool: %v\n", err)
+		}
+	}()
+
+	result, err := app.executeBenchmarkFor(ctx, workerPool, criteria, attempts, scenario.Duration)
+	if err != nil {
+		return scenarioResult{}, errors.WrapError(err, errors.ErrorTypeGeneration,
+			"run_benchmark", fmt.Sprintf("scenario %s failed", scenario.Name))
+	}
+	r := scenarioResult{
+		benchmarkScenario: scenario,
+		Attempts:          result.TotalAttempts,
+		AverageSpeed:      result.AverageSpeed,
+		MinSpeed:          result.MinSpeed,
+		MaxSpeed:          result.MaxSpeed,
+		CompletedAt:       time.Now().UTC(),
+		ThreadBalance:     result.ThreadBalanceScore,
+		WarmupAttempts:    result.WarmupAttempts,
+		GCCycles:          result.GCCycles,
+		GCPausePercent:    gcPausePercent(result),
+	}
+	if criteria.Prefix != "" || criteria.Suffix != "" {
+		r.Difficulty = criteria.Difficulty()
+		if r.AverageSpeed > 0 {
+			r.ETA50Seconds = float64(vanitymath.Attempts50(r.Difficulty)) / r.AverageSpeed
+		}
+	}
+	return r, nil
+}
+
+// scenarioCriteria returns the criteria the workers of a scenario match
+func scenarioCriteria(s benchmarkScenario) wallet.GenerationCriteria {
+	return wallet.GenerationCriteria{Network: s.Network, Prefix: s.Prefix, Suffix: s.Suffix, IsChecksum: s.Checksum}
+}
+
+// describeScenarioPattern names the pattern of a scenario for the report
+func describeScenarioPattern(s benchmarkScenario) string {
+	pattern := "no pattern"
+	switch {
+	case s.Prefix != "" && s.Suffix != "":
+		pattern = s.Prefix + "..." + s.Suffix
+	case s.Prefix != "":
+		pattern = s.Prefix + "..."
+	case s.Suffix != "":
+		pattern = "..." + s.Suffix
+	}
+	if s.Checksum {
+		pattern += ", checksum"
+	}
+	return pattern
+}
+
+// parseSuite reads the scenarios of a suite file, either JSON or the YAML form
+//
+//	scenarios:
+//	  - name: plain
+//	    threads: 4
+//	  - name: checksum
+//	    prefix: abc
+//	    checksum: true
+//	    duration: 10s
+//
+// Threads and duration default to threads and duration.
+func parseSuite(data []byte, threads int, duration time.Duration) ([]benchmarkScenario, error) {
+	var fields []map[string]string
+	var err error
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		fields, err = parseSuiteJSON(trimmed)
+	} else {
+		fields, err = parseSuiteYAML(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no scenarios")
+	}
+
+	scenarios := make([]benchmarkScenario, 0, len(fields))
+	names := make(map[string]bool, len(fields))
+	for i, f := range fields {
+		s := benchmarkScenario{Name: f["name"], Network: strings.ToLower(f["network"]), Prefix: f["prefix"], Suffix: f["suffix"],
+			Threads: threads, Duration: duration}
+		if s.Name == "" {
+			return nil, fmt.Errorf("scenario %d has no name", i+1)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("scenario %s is defined twice", s.Name)
+		}
+		names[s.Name] = true
+		if v, ok := f["checksum"]; ok {
+			if s.Checksum, err = strconv.ParseBool(v); err != nil {
+				return nil, fmt.Errorf("scenario %s: invalid checksum %q", s.Name, v)
+			}
+		}
+		if v, ok := f["threads"]; ok {
+			if s.Threads, err = strconv.Atoi(v); err != nil || s.Threads < 1 {
+				return nil, fmt.Errorf("scenario %s: threads must be a positive number, got %q", s.Name, v)
+			}
+		}
+		if v, ok := f["duration"]; ok {
+			if s.Duration, err = time.ParseDuration(v); err != nil || s.Duration <= 0 {
+				return nil, fmt.Errorf("scenario %s: duration must be positive, like 10s, got %q", s.Name, v)
+			}
+		}
+		criteria := scenarioCriteria(s)
+		if err := criteria.Validate(); err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", s.Name, err)
+		}
+		scenarios = append(scenarios, s)
+	}
+	return scenarios, nil
+}
+
+// parseSuiteJSON reads {"scenarios": [{...}]} into the same fields as the YAML form
+func parseSuiteJSON(data []byte) ([]map[string]string, error) {
+	var suite struct {
+		Scenarios []map[string]interface{} `json:"scenarios"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&suite); err != nil {
+		return nil, err
+	}
+	fields := make([]map[string]string, 0, len(suite.Scenarios))
+	for i, scenario := range suite.Scenarios {
+		f := make(map[string]string, len(scenario))
+		for key, value := range scenario {
+			if !suiteKeys[key] {
+				return nil, fmt.Errorf("scenario %d: unknown key %q", i+1, key)
+			}
+			f[key] = fmt.Sprint(value)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// parseSuiteYAML reads the YAML form: a scenarios key holding a list of flat maps
+// of scalars. Comments, blank lines and quoted values are allowed.
+func parseSuiteYAML(data []byte) ([]map[string]string, error) {
+	var fields []map[string]string
+	inScenarios := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for number := 1; scanner.Scan(); number++ {
+		line := stripYAMLComment(scanner.Text())
+		if strings.TrimSpace(line) == "" || strings.TrimSpace(line) == "---" {
+			continue
+		}
+		indented := line[0] == ' ' || line[0] == '\t'
+		item := strings.TrimSpace(line)
+		switch {
+		case !indented && item == "scenarios:":
+			inScenarios = true
+			continue
+		case !indented || !inScenarios:
+			return nil, fmt.Errorf("line %d: expected a scenarios list", number)
+		}
+
+		if rest, ok := strings.CutPrefix(item, "-"); ok {
+			fields = append(fields, map[string]string{})
+			item = strings.TrimSpace(rest)
+			if item == "" {
+				continue
+			}
+		} else if len(fields) == 0 {
+			return nil, fmt.Errorf("line %d: expected a scenario starting with -", number)
+		}
+		key, value, ok := strings.Cut(item, ":")
+		key = strings.TrimSpace(key)
+		if !ok || !suiteKeys[key] {
+			return nil, fmt.Errorf("line %d: unknown scenario key %q", number, key)
+		}
+		current := fields[len(fields)-1]
+		if _, dup := current[key]; dup {
+			return nil, fmt.Errorf("line %d: %s is set twice", number, key)
+		}
+		current[key] = unquoteYAML(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// stripYAMLComment drops a # comment that is not inside quotes
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
+
+// unquoteYAML removes the quotes around a scalar
+func unquoteYAML(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// readSuiteCheckpoint returns the results of a previous run of the same suite. A
+// checkpoint of another version of the suite, or of the same one run with other
+// default threads or duration, is ignored with a warning.
+func readSuiteCheckpoint(path, suiteSHA256 string, scenarios []benchmarkScenario) ([]scenarioResult, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", fmt.Sprintf("failed to read checkpoint %s", path))
+	}
+	var checkpoint suiteReport
+	if err := json.Unmarshal(data, &checkpoint); err != nil || checkpoint.Version != 1 {
+		return nil, errors.NewConfigurationError("run_benchmark",
+			fmt.Sprintf("%s is not a suite checkpoint; remove it or pass --restart-suite", path))
+	}
+	changed := checkpoint.SuiteSHA256 != suiteSHA256 || len(checkpoint.Results) > len(scenarios)
+	for i := 0; !changed && i < len(checkpoint.Results); i++ {
+		changed = checkpoint.Results[i].benchmarkScenario != scenarios[i]
+	}
+	if changed {
+		fmt.Fprintf(os.Stderr, "Warning: the suite changed since checkpoint %s was written; running every scenario\n", path)
+		return nil, nil
+	}
+	return checkpoint.Results, nil
+}
+
+// writeSuiteReport writes a suite report or checkpoint, replacing the file at once
+func writeSuiteReport(path string, report suiteReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", "failed to encode suite report")
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", fmt.Sprintf("failed to write %s", path))
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "run_benchmark", fmt.Sprintf("failed to write %s", path))
+	}
+	return nil
+}
+
+// printSuiteReport prints one row per scenario
+func printSuiteReport(report suiteReport) {
+	fmt.Printf("\nBenchmark Suite (%s, %d scenarios):\n", report.Suite, len(report.Results))
+	printRule()
+	fmt.Printf("%-20s %-22s %7s %9s %14s %14s %8s\n", "SCENARIO", "PATTERN", "THREADS", "DURATION", "SPEED", "50% ETA", "GC")
+	for _, r := range report.Results {
+		eta := "-"
+		if r.ETA50Seconds > 0 {
+			eta = formatDuration(secondsToDuration(r.ETA50Seconds))
+		}
+		fmt.Printf("%-20s %-22s %7d %9v %14s %14s %7.2f%%\n", r.Name, describeScenarioPattern(r.benchmarkScenario), r.Threads,
+			r.Duration, formatLargeNumber(int64(r.AverageSpeed))+" addr/s", eta, r.GCPausePercent)
+	}
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSuite(t *testing.T) {
+	yaml := `# nightly
+scenarios:
+  - name: plain
+    threads: 4
+  -
+    name: "checksum # abc"   # the name keeps its hash
+    prefix: abc
+    checksum: true
+    duration: 10s
+`
+	scenarios, err := parseSuite([]byte(yaml), 2, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []benchmarkScenario{
+		{Name: "plain", Threads: 4, Duration: time.Minute},
+		{Name: "checksum # abc", Prefix: "abc", Checksum: true, Threads: 2, Duration: 10 * time.Second},
+	}
+	if len(scenarios) != len(want) {
+		t.Fatalf("parseSuite() = %+v", scenarios)
+	}
+	for i := range want {
+		if scenarios[i] != want[i] {
+			t.Errorf("scenario %d = %+v, want %+v", i, scenarios[i], want[i])
+		}
+	}
+
+	json := `{"scenarios": [{"name": "plain", "threads": 4}, {"name": "checksum # abc", "prefix": "abc", "checksum": true, "duration": "10s"}]}`
+	fromJSON, err := parseSuite([]byte(json), 2, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if fromJSON[i] != want[i] {
+			t.Errorf("JSON scenario %d = %+v, want %+v", i, fromJSON[i], want[i])
+		}
+	}
+}
+
+func TestParseSuite_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"no list":        "name: plain\n",
+		"unknown key":    "scenarios:\n  - name: a\n    pattern: abc\n",
+		"no name":        "scenarios:\n  - prefix: abc\n",
+		"duplicate name": "scenarios:\n  - name: a\n  - name: a\n",
+		"key set twice":  "scenarios:\n  - name: a\n    threads: 1\n    threads: 2\n",
+		"bad threads":    "scenarios:\n  - name: a\n    threads: 0\n",
+		"bad duration":   "scenarios:\n  - name: a\n    duration: soon\n",
+		"bad pattern":    "scenarios:\n  - name: a\n    prefix: xyz\n",
+		"json unknown":   `{"scenarios": [{"name": "a", "pattern": "abc"}]}`,
+	}
+	for name, suite := range tests {
+		if _, err := parseSuite([]byte(suite), 1, time.Second); err == nil {
+			t.Errorf("%s: parseSuite() accepted %q", name, suite)
+		}
+	}
+}
+
+func TestReadSuiteCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "suite.yaml.checkpoint")
+	scenarios := []benchmarkScenario{{Name: "a", Threads: 1, Duration: time.Second}, {Name: "b", Threads: 1, Duration: time.Second}}
+
+	if results, err := readSuiteCheckpoint(path, "sum", scenarios); err != nil || results != nil {
+		t.Fatalf("missing checkpoint = %v, %v", results, err)
+	}
+
+	report := suiteReport{Version: 1, SuiteSHA256: "sum", Results: []scenarioResult{{benchmarkScenario: scenarios[0], AverageSpeed: 100}}}
+	if err := writeSuiteReport(path, report); err != nil {
+		t.Fatal(err)
+	}
+	if results, err := readSuiteCheckpoint(path, "sum", scenarios); err != nil || len(results) != 1 || results[0].AverageSpeed != 100 {
+		t.Errorf("readSuiteCheckpoint() = %+v, %v", results, err)
+	}
+	// Another suite file, or other defaults, start over
+	if results, _ := readSuiteCheckpoint(path, "other", scenarios); results != nil {
+		t.Errorf("checkpoint of another suite kept %+v", results)
+	}
+	changed := []benchmarkScenario{{Name: "a", Threads: 8, Duration: time.Second}, scenarios[1]}
+	if results, _ := readSuiteCheckpoint(path, "sum", changed); results != nil {
+		t.Errorf("checkpoint of other threads kept %+v", results)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readSuiteCheckpoint(path, "sum", scenarios); err == nil || !strings.Contains(err.Error(), "--restart-suite") {
+		t.Errorf("corrupt checkpoint error = %v", err)
+	}
+}
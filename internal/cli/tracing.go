@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/tracing"
+)
+
+// startTracing installs an OTLP exporter when --otlp-endpoint or the standard OTEL_*
+// variables name a collector. A TRACEPARENT variable, set by whatever launched this
+// process, parents the command's spans so agents on different nodes share one trace.
+func (app *Application) startTracing(cmd *cobra.Command) error {
+	if app.tracer != nil {
+		return nil
+	}
+	if traceparent := os.Getenv("TRACEPARENT"); traceparent != "" {
+		cmd.SetContext(tracing.ContextWithTraceparent(cmd.Context(), traceparent))
+	}
+
+	endpoint, _ := cmd.Flags().GetString("otlp-endpoint")
+	if endpoint == "" {
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	}
+	if endpoint == "" {
+		endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if endpoint == "" {
+		return nil
+	}
+
+	attributes := []tracing.Attribute{}
+	if host, err := os.Hostname(); err == nil {
+		attributes = append(attributes, tracing.String("host.name", host))
+	}
+	tracer, err := tracing.NewTracer(tracing.Config{
+		Endpoint:       endpoint,
+		ServiceName:    os.Getenv("OTEL_SERVICE_NAME"),
+		ServiceVersion: app.version,
+		Attributes:     attributes,
+		Headers:        parseOTLPHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")),
+	})
+	if err != nil {
+		return err
+	}
+	app.tracer = tracer
+	tracing.SetTracer(tracer)
+	return nil
+}
+
+// traceContext returns the context of the running generate span, for code paths that
+// do not receive one
+func (app *Application) traceContext() context.Context {
+	if app.traceCtx == nil {
+		return context.Background()
+	}
+	return app.traceCtx
+}
+
+// parseOTLPHeaders parses OTEL_EXPORTER_OTLP_HEADERS: comma separated key=value pairs
+// with URL-encoded values
+func parseOTLPHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(val)); err == nil {
+			val = decoded
+		}
+		headers[key] = val
+	}
+	return headers
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/internal/update"
+	"bloco-eth/pkg/errors"
+)
+
+// updateCheck is the result of update --check-only
+type updateCheck struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	UpdateAvailable bool   `json:"update_available"`
+	URL             string `json:"url"`
+	ManagedBy       string `json:"managed_by,omitempty"`
+	UpgradeCommand  string `json:"upgrade_command,omitempty"`
+}
+
+// createUpdateCommand creates the update command
+func (app *Application) createUpdateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "update",
+		Short:       "Replace this binary with the latest release",
+		Annotations: map[string]string{unhardenedAnnotation: "true"},
+		Long: `Check the GitHub releases for a newer bloco-eth and install it in place of the
+running binary. The archive for this platform must match its SHA-256 in
+checksums.txt, and checksums.txt must carry a valid signature of the release key
+built into this binary. Builds without a release key, such as those made from
+source, refuse to install without --allow-unsigned.
+
+Binaries installed by Homebrew or Scoop are left to the package manager: the
+command prints the upgrade command to run instead.`,
+		Example: `  bloco-eth update --check-only
+  bloco-eth update
+  bloco-eth update --release v1.4.2`,
+		Args: cobra.NoArgs,
+		RunE: app.runUpdate,
+	}
+	cmd.Flags().Bool("check-only", false, "Only report whether a newer release exists")
+	cmd.Flags().String("release", "", "Install this release tag instead of the latest, even if it is older")
+	cmd.Flags().Bool("allow-unsigned", false, "Trust checksums.txt without a signature when this build has no release key")
+	cmd.Flags().String("api-url", "https://api.github.com", "GitHub API root, for mirrors")
+	cmd.Flags().Duration("http-timeout", 2*time.Minute, "Time allowed for each request and download")
+	return cmd
+}
+
+// runUpdate checks for a newer release and installs it
+func (app *Application) runUpdate(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	checkOnly, _ := cmd.Flags().GetBool("check-only")
+	tag, _ := cmd.Flags().GetString("release")
+	allowUnsigned, _ := cmd.Flags().GetBool("allow-unsigned")
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	timeout, _ := cmd.Flags().GetDuration("http-timeout")
+	format, _ := cmd.Flags().GetString("format")
+	out := cmd.OutOrStdout()
+
+	exe, err := os.Executable()
+	if err == nil {
+		exe, err = filepath.EvalSymlinks(exe)
+	}
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "update", "cannot locate the running binary")
+	}
+
+	client := update.NewClient(timeout)
+	client.BaseURL = apiURL
+	release, err := client.Release(ctx, tag)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "update", "failed to read the release")
+	}
+
+	check := updateCheck{
+		Current:         app.version,
+		Latest:          release.Tag,
+		UpdateAvailable: update.Compare(app.version, release.Tag) < 0,
+		URL:             release.URL,
+		ManagedBy:       update.ManagedBy(exe),
+	}
+	check.UpgradeCommand = update.UpgradeCommand(check.ManagedBy)
+
+	if checkOnly && format == "json" {
+		data, err := json.MarshalIndent(check, "", "  ")
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "update", "failed to encode result")
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+	// --release installs the tag given even when it is not newer
+	if !check.UpdateAvailable && (checkOnly || tag == "") {
+		fmt.Fprintln(out, i18n.T("update.up_to_date", check.Current, check.Latest))
+		return nil
+	}
+	if checkOnly {
+		fmt.Fprintln(out, i18n.T("update.available", check.Current, check.Latest))
+		fmt.Fprintln(out, check.URL)
+		if check.UpgradeCommand != "" {
+			fmt.Fprintln(out, i18n.T("update.managed", check.ManagedBy, check.UpgradeCommand))
+		}
+		return nil
+	}
+	if check.ManagedBy != "" {
+		return errors.NewConfigurationError("update",
+			fmt.Sprintf("%s is managed by %s; upgrade it with: %s", exe, check.ManagedBy, check.UpgradeCommand))
+	}
+	if update.ReleaseKey == "" && !allowUnsigned {
+		return errors.NewConfigurationError("update",
+			"this build has no release key to verify signatures with; install a release binary or pass --allow-unsigned")
+	}
+
+	key := update.ReleaseKey
+	if key == "" {
+		fmt.Fprintln(cmd.ErrOrStderr(), icon("⚠️ ")+i18n.T("update.unsigned"))
+	}
+	fmt.Fprintln(cmd.ErrOrStderr(), i18n.T("update.installing", release.Tag, runtime.GOOS, runtime.GOARCH))
+	if err := client.Install(ctx, release, exe, runtime.GOOS, runtime.GOARCH, key); err != nil {
+		if stderrors.Is(err, update.ErrVerification) {
+			return errors.WrapError(err, errors.ErrorTypeCrypto, "update", "refusing to install an unverified release")
+		}
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "update", fmt.Sprintf("failed to install %s", release.Tag))
+	}
+
+	fmt.Fprintln(out, icon("✅")+i18n.T("update.updated", exe, check.Current, release.Tag))
+	return nil
+}
@@ -0,0 +1,185 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// createVaultCommand creates the vault subcommand group
+func (app *Application) createVaultCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vault",
+		Short: "Read wallets stored in an encrypted vault file",
+		Long: `Read wallets written with --vault. A vault is a single AES-256-GCM encrypted
+JSON file (scrypt key derivation) that holds every generated wallet, including
+private keys and mnemonics, instead of one keystore per address.`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the wallets stored in a vault",
+		Example: `  bloco-eth vault list --vault wallets.vault --vault-password-file vault.pwd
+  bloco-eth vault list --vault wallets.vault --vault-password-file vault.pwd --format json`,
+		Args: cobra.NoArgs,
+		RunE: app.runVaultList,
+	}
+
+	exportCmd := &cobra.Command{
+		Use:     "export <address>",
+		Short:   "Print one wallet from a vault as JSON, including its private key",
+		Example: `  bloco-eth vault export 0xabc123... --vault wallets.vault --vault-password-file vault.pwd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    app.runVaultExport,
+	}
+
+	cmd.AddCommand(listCmd, exportCmd)
+	return cmd
+}
+
+// runVaultList prints the wallets in a vault without key material
+func (app *Application) runVaultList(cmd *cobra.Command, args []string) error {
+	vault, err := openVaultFromFlags(cmd, false)
+	if err != nil {
+		return err
+	}
+	entries := vault.Entries()
+
+	if format, _ := cmd.Flags().GetString("format"); format == "json" {
+		type vaultListing struct {
+			listedWallet
+			HasMnemonic bool `json:"has_mnemonic"`
+		}
+		listed := make([]vaultListing, 0, len(entries))
+		for _, entry := range entries {
+			listed = append(listed, vaultListing{
+				listedWallet{entry.Address, entry.Network, entry.Label, entry.Tags, entry.CreatedAt},
+				entry.Mnemonic != "",
+			})
+		}
+		data, err := json.MarshalIndent(listed, "", "  ")
+		if err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "vault_list", "failed to encode wallets")
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	out := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(out, "ADDRESS\tNETWORK\tLABEL\tMNEMONIC\tCREATED")
+	for _, entry := range entries {
+		mnemonic := "no"
+		if entry.Mnemonic != "" {
+			mnemonic = "yes"
+		}
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\t%s\n", entry.Address, entry.Network, entry.Label, mnemonic, entry.CreatedAt.Local().Format(time.DateTime))
+	}
+	if err := out.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "\n%d wallet(s) in %s\n", len(entries), vault.Path())
+	return nil
+}
+
+// runVaultExport prints a single vault wallet as JSON
+func (app *Application) runVaultExport(cmd *cobra.Command, args []string) error {
+	vault, err := openVaultFromFlags(cmd, false)
+	if err != nil {
+		return err
+	}
+
+	entry, ok := vault.Find(args[0])
+	if !ok {
+		return errors.NewValidationError("vault_export",
+			fmt.Sprintf("wallet %s not found in %s", args[0], vault.Path()))
+	}
+
+	format, err := keyFormatFlag(cmd, entry.Network)
+	if err != nil {
+		return err
+	}
+	if entry.PrivateKey, err = crypto.FormatPrivateKeyHex(entry.PrivateKey, format); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "vault_export", "failed to format private key")
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "vault_export", "failed to encode wallet")
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return nil
+}
+
+// openVaultFromFlags opens the vault named by --vault, creating it when create is set
+func openVaultFromFlags(cmd *cobra.Command, create bool) (*crypto.Vault, error) {
+	path, _ := cmd.Flags().GetString("vault")
+	if path == "" {
+		return nil, errors.NewValidationError("open_vault", "--vault is required")
+	}
+	passwordFile, _ := cmd.Flags().GetString("vault-password-file")
+	if passwordFile == "" {
+		return nil, errors.NewValidationError("open_vault", "--vault-password-file is required with --vault")
+	}
+
+	password, err := crypto.ReadPasswordFile(passwordFile, "")
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"open_vault", fmt.Sprintf("failed to read vault password from %s", passwordFile))
+	}
+	password = strings.TrimRight(password, "\r\n")
+
+	var vault *crypto.Vault
+	if create {
+		vault, err = crypto.OpenOrCreateVault(path, password)
+	} else {
+		vault, err = crypto.OpenVault(path, password)
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"open_vault", fmt.Sprintf("failed to open vault %s", path))
+	}
+	return vault, nil
+}
+
+// saveToVault stores a generated wallet in the open vault
+func (app *Application) saveToVault(w *wallet.Wallet) error {
+	if err := app.vault.Add(vaultEntry(w)); err != nil {
+		return fmt.Errorf("failed to store wallet %s in vault: %w", w.Address, err)
+	}
+	return nil
+}
+
+// vaultEntry returns the vault entry of a generated wallet
+func vaultEntry(w *wallet.Wallet) crypto.VaultEntry {
+	network := strings.ToLower(w.Network)
+	if network == "" {
+		network = "ethereum"
+	}
+	return crypto.VaultEntry{
+		Address:             w.Address,
+		PrivateKey:          w.PrivateKey,
+		PublicKey:           w.PublicKey,
+		PublicKeyCompressed: w.PublicKeyCompressed,
+		Mnemonic:            w.Mnemonic,
+		Network:             network,
+		CreatedAt:           w.CreatedAt,
+		Label:               w.Label,
+		Tags:                w.Tags,
+	}
+}
+
+// keystoreLocation returns where generated wallets are written
+func (app *Application) keystoreLocation() string {
+	if app.vault != nil {
+		return app.vault.Path() + " (vault)"
+	}
+	return app.config.KeyStore.OutputDir
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// benchmarkWarmup is the attempts and time of the --warmup phase, which the
+// benchmark subtracts so that only steady-state throughput is reported
+type benchmarkWarmup struct {
+	attempts int64
+	duration time.Duration
+}
+
+// parseWarmup reads --warmup
+func parseWarmup(value time.Duration) (time.Duration, error) {
+	if value < 0 {
+		return 0, errors.NewValidationError("run_benchmark", "--warmup cannot be negative")
+	}
+	return value, nil
+}
+
+// warmUp lets the running pool work for the warm-up period before sampling
+// starts: goroutine start-up, first allocations of the pools and caches and CPU
+// frequency ramp-up land here instead of in the first speed samples
+func warmUp(ctx context.Context, stats *worker.StatsCollector, warmup time.Duration) benchmarkWarmup {
+	if warmup <= 0 {
+		return benchmarkWarmup{}
+	}
+	start := time.Now()
+	timer := time.NewTimer(warmup)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return benchmarkWarmup{
+		attempts: stats.GetAggregatedStats().TotalAttempts,
+		duration: time.Since(start),
+	}
+}
+
+// printWarmup reports the excluded warm-up phase of a benchmark
+func printWarmup(result *wallet.BenchmarkResult) {
+	if result.WarmupDuration <= 0 {
+		return
+	}
+	fmt.Printf("Warm-up: %s excluded (%s attempts, %.0f addr/s)\n", formatDuration(result.WarmupDuration),
+		formatLargeNumber(result.WarmupAttempts), float64(result.WarmupAttempts)/result.WarmupDuration.Seconds())
+}
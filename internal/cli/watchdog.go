@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+)
+
+// parseWatchdogFlags configures the --watchdog; without it searches are not watched
+func (app *Application) parseWatchdogFlags(cmd *cobra.Command) error {
+	app.watchdog = nil
+	timeout, _ := cmd.Flags().GetDuration("watchdog")
+	restart, _ := cmd.Flags().GetBool("watchdog-restart")
+	dumpPath, _ := cmd.Flags().GetString("watchdog-dump")
+	if timeout < 0 {
+		return errors.NewValidationError("parse_flags", "--watchdog cannot be negative")
+	}
+	if timeout == 0 {
+		if restart || dumpPath != "" {
+			return errors.NewValidationError("parse_flags", "--watchdog-restart and --watchdog-dump require --watchdog")
+		}
+		return nil
+	}
+	if timeout < time.Second {
+		return errors.NewValidationError("parse_flags", "--watchdog must be at least 1s; workers report progress after every batch, up to about 1s apart")
+	}
+
+	cfg := &worker.WatchdogConfig{Timeout: timeout, Restart: restart, OnStall: app.recordStall}
+	if dumpPath != "" {
+		cfg.Dump = dumpFile(dumpPath)
+	}
+	app.watchdog = cfg
+	return nil
+}
+
+// watchdogPool watches the searches of pool when --watchdog is set
+func (app *Application) watchdogPool(pool worker.WorkerPool) worker.WorkerPool {
+	if app.watchdog == nil {
+		return pool
+	}
+	return worker.NewWatchdogPool(pool, *app.watchdog)
+}
+
+// recordStall reports a stall found by the --watchdog
+func (app *Application) recordStall(stall worker.Stall) {
+	action := "goroutine stacks dumped"
+	if stall.Restarting {
+		action += "; restarting the search"
+	}
+	fmt.Fprintf(os.Stderr, "Warning: no attempts for %v (stuck at %d); %s\n",
+		stall.Stalled.Round(time.Second), stall.Attempts, action)
+	if app.auditTrail != nil {
+		app.audit("pipeline_stall", map[string]string{
+			"attempts":   strconv.FormatInt(stall.Attempts, 10),
+			"stalled":    stall.Stalled.Round(time.Second).String(),
+			"goroutines": strconv.Itoa(stall.Goroutines),
+			"restarting": strconv.FormatBool(stall.Restarting),
+		})
+	}
+}
+
+// dumpFile appends every write to a file, opening it only when a stall is dumped
+type dumpFile string
+
+// Write appends p to the file
+func (d dumpFile) Write(p []byte) (int, error) {
+	file, err := os.OpenFile(string(d), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return 0, err
+	}
+	n, err := file.Write(p)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return n, err
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"bloco-eth/internal/tui"
+	"bloco-eth/pkg/errors"
+)
+
+// wizardCalibration is how long the wizard benchmarks the machine for its ETA
+const wizardCalibration = time.Second
+
+// createWizardCommand creates the wizard subcommand
+func (app *Application) createWizardCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "wizard",
+		Short: "Interactively choose a pattern and options, then run or print the command",
+		Long: `Walk through choosing a prefix and suffix, checksum and KeyStore options in a
+terminal UI. Difficulty and ETA update while typing, using the speed measured on
+this machine when the wizard starts. Finish by running the generation right away
+or by printing the equivalent command line and environment settings.`,
+		Example: `  bloco-eth wizard
+  bloco-eth wizard --prefix cafe --threads 4`,
+		Args: cobra.NoArgs,
+		RunE: app.runWizard,
+	}
+}
+
+// runWizard shows the wizard and runs or prints the chosen job
+func (app *Application) runWizard(cmd *cobra.Command, args []string) error {
+	if err := app.parseFlags(cmd); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"parse_flags", "failed to parse command flags")
+	}
+	// The wizard reads keys, so unlike progress output it needs a terminal on stdin too
+	if !app.config.TUI.Enabled || !term.IsTerminal(int(os.Stdin.Fd())) || !tui.NewTUIManager().ShouldUseTUI() {
+		return errors.NewValidationError("wizard", "the wizard needs an interactive terminal; pass --prefix/--suffix to the main command instead")
+	}
+
+	defaults := tui.WizardChoices{Keystore: app.config.KeyStore.Enabled, KDF: app.config.KeyStore.KDFAlgorithm}
+	defaults.Prefix, _ = cmd.Flags().GetString("prefix")
+	defaults.Suffix, _ = cmd.Flags().GetString("suffix")
+	defaults.Checksum, _ = cmd.Flags().GetBool("checksum")
+	defaults.CaseSensitive, _ = cmd.Flags().GetBool("case-sensitive")
+	defaults.Count, _ = cmd.Flags().GetInt("count")
+
+	ctx := cmd.Context()
+	threads, batchSize := app.config.Worker.ThreadCount, app.config.Worker.MaxBatchSize
+	calibrate := func() tea.Msg {
+		result, err := app.measureEfficiency(ctx, threads, batchSize, wizardCalibration, nil)
+		if err != nil {
+			return tui.WizardSpeedMsg{}
+		}
+		return tui.WizardSpeedMsg{Speed: result.Speed}
+	}
+
+	final, err := tea.NewProgram(tui.NewWizardModel(defaults, calibrate), tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeTUI, "wizard", "wizard failed")
+	}
+	choices, action := final.(tui.WizardModel).Result()
+
+	switch action {
+	case tui.WizardPrint:
+		fmt.Print(wizardSummary(choices, threads))
+		return nil
+	case tui.WizardRun:
+		if err := applyWizardChoices(cmd, choices); err != nil {
+			return err
+		}
+		return app.generateWallet(cmd, args)
+	}
+	return nil
+}
+
+// wizardArgs converts the wizard choices into command-line arguments
+func wizardArgs(choices tui.WizardChoices) []string {
+	var args []string
+	if choices.Prefix != "" {
+		args = append(args, "--prefix", choices.Prefix)
+	}
+	if choices.Suffix != "" {
+		args = append(args, "--suffix", choices.Suffix)
+	}
+	if choices.Checksum {
+		args = append(args, "--checksum")
+		if choices.CaseSensitive {
+			args = append(args, "--case-sensitive")
+		}
+	}
+	if choices.Count > 1 {
+		args = append(args, "--count", strconv.Itoa(choices.Count))
+	}
+	if !choices.Keystore {
+		args = append(args, "--no-keystore")
+	} else if choices.KDF != "" && choices.KDF != "scrypt" {
+		args = append(args, "--keystore-kdf", choices.KDF)
+	}
+	return append(args, "--progress")
+}
+
+// wizardSummary renders the equivalent command line and environment settings
+func wizardSummary(choices tui.WizardChoices, threads int) string {
+	var summary strings.Builder
+	summary.WriteString("Command:\n")
+	summary.WriteString("  bloco-eth " + strings.Join(wizardArgs(choices), " ") + "\n\n")
+	summary.WriteString("Environment (e.g. in a .env file):\n")
+	fmt.Fprintf(&summary, "  BLOCO_THREADS=%d\n", threads)
+	fmt.Fprintf(&summary, "  BLOCO_KEYSTORE_ENABLED=%t\n", choices.Keystore)
+	if choices.Keystore && choices.KDF != "" {
+		fmt.Fprintf(&summary, "  BLOCO_KEYSTORE_KDF=%s\n", choices.KDF)
+	}
+	return summary.String()
+}
+
+// applyWizardChoices sets the flags of cmd as if the wizard's command line had been typed
+func applyWizardChoices(cmd *cobra.Command, choices tui.WizardChoices) error {
+	values := map[string]string{
+		"prefix":         choices.Prefix,
+		"suffix":         choices.Suffix,
+		"checksum":       strconv.FormatBool(choices.Checksum),
+		"case-sensitive": strconv.FormatBool(choices.Checksum && choices.CaseSensitive),
+		"count":          strconv.Itoa(choices.Count),
+		"no-keystore":    strconv.FormatBool(!choices.Keystore),
+		"progress":       "true",
+	}
+	if choices.Keystore && choices.KDF != "" {
+		values["keystore-kdf"] = choices.KDF
+	}
+	for name, value := range values {
+		if err := cmd.Flags().Set(name, value); err != nil {
+			return errors.WrapError(err, errors.ErrorTypeConfiguration, "wizard", fmt.Sprintf("failed to set --%s", name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"bloco-eth/internal/tui"
+)
+
+func TestWizardSummary(t *testing.T) {
+	choices := tui.WizardChoices{Prefix: "CAFE", Checksum: true, CaseSensitive: true, Count: 3, Keystore: true, KDF: "pbkdf2"}
+	summary := wizardSummary(choices, 8)
+
+	for _, want := range []string{
+		"bloco-eth --prefix CAFE --checksum --case-sensitive --count 3 --keystore-kdf pbkdf2 --progress",
+		"BLOCO_THREADS=8",
+		"BLOCO_KEYSTORE_ENABLED=true",
+		"BLOCO_KEYSTORE_KDF=pbkdf2",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary is missing %q:\n%s", want, summary)
+		}
+	}
+
+	args := strings.Join(wizardArgs(tui.WizardChoices{Suffix: "beef", CaseSensitive: true, Count: 1}), " ")
+	if args != "--suffix beef --no-keystore --progress" {
+		t.Errorf("case sensitivity without checksum and a count of 1 should be left out, got %q", args)
+	}
+}
@@ -0,0 +1,160 @@
+package compat
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"bloco-eth/internal/crypto"
+)
+
+// Clients are the external clients CheckKeystore tries, in matrix order
+var Clients = []string{"geth", "clef", "ethkey"}
+
+// ErrNotInstalled is returned by ExecRunner when the client binary is not on PATH
+var ErrNotInstalled = errors.New("not installed")
+
+// Runner runs a client command and returns its combined output
+type Runner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+// ExecRunner runs the client binary found on PATH
+func ExecRunner(ctx context.Context, name string, args ...string) ([]byte, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, ErrNotInstalled
+	}
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return out.Bytes(), fmt.Errorf("%s %s failed: %v: %s", name, args[0], err, lastLine(out.String()))
+	}
+	return out.Bytes(), nil
+}
+
+// clientProbe tries a keystore with one client and returns the check status and detail
+type clientProbe func(ctx context.Context, run Runner, workDir, path string, keystore *crypto.KeyStoreV3, password, address string) (string, string)
+
+// clientProbes maps each of Clients to its probe
+var clientProbes = map[string]clientProbe{
+	"geth":   probeGeth,
+	"clef":   probeClef,
+	"ethkey": probeEthkey,
+}
+
+// runProbe gives probe a temporary directory, removed with everything written to it
+func runProbe(ctx context.Context, client string, run Runner, path string, keystore *crypto.KeyStoreV3, password, address string) (string, string) {
+	workDir, err := os.MkdirTemp("", "bloco-compat-"+client+"-")
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	defer os.RemoveAll(workDir)
+	return clientProbes[client](ctx, run, workDir, path, keystore, password, address)
+}
+
+// probeGeth lists the keystore in a temporary datadir, then imports its key into
+// another one and checks geth derives the same address
+func probeGeth(ctx context.Context, run Runner, workDir, path string, keystore *crypto.KeyStoreV3, password, address string) (string, string) {
+	datadir := filepath.Join(workDir, "datadir")
+	if err := copyKeystore(path, filepath.Join(datadir, "keystore")); err != nil {
+		return StatusFail, err.Error()
+	}
+	out, err := run(ctx, "geth", "account", "list", "--datadir", datadir)
+	if status, detail, done := clientResult(out, err, address, "account list"); done {
+		return status, detail
+	}
+	if password == "" {
+		return StatusPass, "account list reads the keystore (no password: import not tried)"
+	}
+
+	privateKey, err := crypto.DecryptPrivateKey(keystore, password)
+	if err != nil {
+		return StatusFail, err.Error()
+	}
+	keyHex := []byte(hex.EncodeToString(privateKey))
+	crypto.ClearSensitiveData(privateKey)
+	defer crypto.ClearSensitiveData(keyHex)
+	keyFile := filepath.Join(workDir, "key")
+	passwordFile := filepath.Join(workDir, "password")
+	if err := os.WriteFile(keyFile, keyHex, 0600); err != nil {
+		return StatusFail, err.Error()
+	}
+	if err := os.WriteFile(passwordFile, []byte(password), 0600); err != nil {
+		return StatusFail, err.Error()
+	}
+	out, err = run(ctx, "geth", "account", "import", "--lightkdf",
+		"--datadir", filepath.Join(workDir, "import"), "--password", passwordFile, keyFile)
+	if status, detail, done := clientResult(out, err, address, "account import"); done {
+		return status, detail
+	}
+	return StatusPass, "account list reads the keystore and account import derives its address"
+}
+
+// probeClef lists the keystore directory with clef
+func probeClef(ctx context.Context, run Runner, workDir, path string, keystore *crypto.KeyStoreV3, password, address string) (string, string) {
+	keystoreDir := filepath.Join(workDir, "keystore")
+	if err := copyKeystore(path, keystoreDir); err != nil {
+		return StatusFail, err.Error()
+	}
+	out, err := run(ctx, "clef", "--keystore", keystoreDir, "--configdir", filepath.Join(workDir, "clef"),
+		"--suppress-bootwarn", "--nousb", "list-accounts")
+	if status, detail, done := clientResult(out, err, address, "list-accounts"); done {
+		return status, detail
+	}
+	return StatusPass, "list-accounts reads the keystore"
+}
+
+// probeEthkey decrypts the keystore with ethkey inspect
+func probeEthkey(ctx context.Context, run Runner, workDir, path string, keystore *crypto.KeyStoreV3, password, address string) (string, string) {
+	if password == "" {
+		return StatusSkipped, "no password"
+	}
+	passwordFile := filepath.Join(workDir, "password")
+	if err := os.WriteFile(passwordFile, []byte(password), 0600); err != nil {
+		return StatusFail, err.Error()
+	}
+	out, err := run(ctx, "ethkey", "inspect", "--passwordfile", passwordFile, path)
+	if status, detail, done := clientResult(out, err, address, "inspect"); done {
+		return status, detail
+	}
+	return StatusPass, "inspect decrypts the keystore to its address"
+}
+
+// clientResult turns a client command's outcome into a final status, unless it
+// succeeded and printed address, in which case done is false
+func clientResult(out []byte, err error, address, command string) (status, detail string, done bool) {
+	switch {
+	case errors.Is(err, ErrNotInstalled):
+		return StatusSkipped, "not installed", true
+	case err != nil:
+		return StatusFail, err.Error(), true
+	case !strings.Contains(strings.ToLower(string(out)), strings.TrimPrefix(address, "0x")):
+		return StatusFail, fmt.Sprintf("%s did not show %s: %s", command, address, lastLine(string(out))), true
+	}
+	return "", "", false
+}
+
+// copyKeystore copies the keystore at path into dir
+func copyKeystore(path, dir string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, filepath.Base(path)), data, 0600)
+}
+
+// lastLine returns the last non-empty line of a command's output
+func lastLine(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,230 @@
+// Package compat checks that a keystore written by bloco-eth can be used by
+// other Ethereum wallets: against the Web3 Secret Storage reference vectors, the
+// KDF compatibility analyzer and, when they are installed, real clients
+package compat
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/crypto/kdf"
+)
+
+// Check results
+const (
+	StatusPass    = "pass"
+	StatusFail    = "fail"
+	StatusSkipped = "skipped"
+)
+
+// Check methods, from the cheapest to the most realistic
+const (
+	MethodReference = "reference"
+	MethodAnalyzer  = "analyzer"
+	MethodClient    = "client"
+)
+
+// Check is one row of the compatibility matrix
+type Check struct {
+	Client string `json:"client"`
+	Method string `json:"method"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Report is the compatibility matrix of one keystore
+type Report struct {
+	File    string  `json:"file"`
+	Address string  `json:"address"`
+	KDF     string  `json:"kdf"`
+	Checks  []Check `json:"checks"`
+}
+
+// OK reports whether no check failed
+func (r *Report) OK() bool {
+	return r.Failed() == 0
+}
+
+// Failed counts the failed checks
+func (r *Report) Failed() int {
+	failed := 0
+	for _, check := range r.Checks {
+		if check.Status == StatusFail {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Options controls a compatibility check
+type Options struct {
+	// Password decrypts the keystore; without it the checks that decrypt are skipped
+	Password string
+	// Clients are the external clients to try (default: all of Clients)
+	Clients []string
+	// Runner runs client commands (default: the installed binaries)
+	Runner Runner
+}
+
+// CheckKeystore builds the compatibility matrix of the keystore at path
+func CheckKeystore(ctx context.Context, path string, opts Options) (*Report, error) {
+	clients := opts.Clients
+	if len(clients) == 0 {
+		clients = Clients
+	}
+	for _, client := range clients {
+		if _, ok := clientProbes[client]; !ok {
+			return nil, fmt.Errorf("unknown client %q (use %s)", client, strings.Join(Clients, ", "))
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keystore, err := crypto.FromJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid keystore: %w", path, err)
+	}
+	address := "0x" + strings.ToLower(strings.TrimPrefix(keystore.Address, "0x"))
+	report := &Report{File: path, Address: address, KDF: keystore.Crypto.KDF}
+	add := func(client, method, status, detail string) {
+		report.Checks = append(report.Checks, Check{Client: client, Method: method, Status: status, Detail: detail})
+	}
+
+	if err := CheckReferenceVectors(); err != nil {
+		add("bloco-eth", MethodReference, StatusFail, err.Error())
+	} else {
+		add("bloco-eth", MethodReference, StatusPass, "decrypts the Web3 Secret Storage scrypt and pbkdf2 test vectors")
+	}
+	if issues := specIssues(keystore); len(issues) > 0 {
+		add("web3-secret-storage", MethodReference, StatusFail, strings.Join(issues, "; "))
+	} else {
+		add("web3-secret-storage", MethodReference, StatusPass, "fields and sizes follow the V3 definition")
+	}
+	if opts.Password == "" {
+		add("decrypt", MethodReference, StatusSkipped, "no password")
+	} else if err := verifyKeystore(keystore, opts.Password, address); err != nil {
+		add("decrypt", MethodReference, StatusFail, err.Error())
+	} else {
+		add("decrypt", MethodReference, StatusPass, "MAC verifies and the key derives the keystore address")
+	}
+
+	report.Checks = append(report.Checks, analyzerChecks(keystore)...)
+
+	runner := opts.Runner
+	if runner == nil {
+		runner = ExecRunner
+	}
+	for _, client := range clients {
+		status, detail := runProbe(ctx, client, runner, path, keystore, opts.Password, address)
+		add(client, MethodClient, status, detail)
+	}
+	return report, nil
+}
+
+// specIssues lists where a keystore departs from the Web3 Secret Storage V3 definition
+func specIssues(keystore *crypto.KeyStoreV3) []string {
+	if !crypto.IsStandardCipher(keystore.Crypto.Cipher) {
+		return []string{fmt.Sprintf("cipher %s is not part of the V3 definition (use --keystore-cipher aes-128-ctr)", keystore.Crypto.Cipher)}
+	}
+	var issues []string
+	sizes := []struct {
+		name  string
+		value string
+		bytes int
+	}{
+		{"iv", keystore.Crypto.CipherParams.IV, 16},
+		{"ciphertext", keystore.Crypto.CipherText, 32},
+		{"mac", keystore.Crypto.MAC, 32},
+	}
+	for _, size := range sizes {
+		decoded, err := hex.DecodeString(size.value)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("%s is not hex", size.name))
+		} else if len(decoded) != size.bytes {
+			issues = append(issues, fmt.Sprintf("%s is %d bytes, want %d", size.name, len(decoded), size.bytes))
+		}
+	}
+
+	switch keystore.Crypto.KDF {
+	case "scrypt":
+		params, err := keystore.GetScryptParams()
+		if err != nil {
+			issues = append(issues, err.Error())
+		} else if params.DKLen != 32 {
+			issues = append(issues, fmt.Sprintf("dklen is %d, want 32", params.DKLen))
+		}
+	case "pbkdf2":
+		params, err := keystore.GetPBKDF2Params()
+		if err != nil {
+			issues = append(issues, err.Error())
+		} else {
+			if params.DKLen != 32 {
+				issues = append(issues, fmt.Sprintf("dklen is %d, want 32", params.DKLen))
+			}
+			if params.PRF != "hmac-sha256" {
+				issues = append(issues, fmt.Sprintf("prf is %q, want hmac-sha256", params.PRF))
+			}
+		}
+	}
+	return issues
+}
+
+// verifyKeystore decrypts the keystore and checks the key belongs to address
+func verifyKeystore(keystore *crypto.KeyStoreV3, password, address string) error {
+	privateKey, err := crypto.DecryptPrivateKey(keystore, password)
+	if err != nil {
+		return err
+	}
+	defer crypto.ClearSensitiveData(privateKey)
+	key, err := ethcrypto.ToECDSA(privateKey)
+	if err != nil {
+		return fmt.Errorf("decrypted private key is invalid: %w", err)
+	}
+	if derived := strings.ToLower(ethcrypto.PubkeyToAddress(key.PublicKey).Hex()); derived != address {
+		return fmt.Errorf("private key belongs to %s, not %s", derived, address)
+	}
+	return nil
+}
+
+// analyzerChecks turns the client predictions of the KDF compatibility analyzer into checks
+func analyzerChecks(keystore *crypto.KeyStoreV3) []Check {
+	service := kdf.NewUniversalKDFService()
+	analyzer := kdf.NewKDFCompatibilityAnalyzer(service)
+	params, err := keystore.ToKDFCryptoParams()
+	if err != nil {
+		return []Check{{Client: "kdf", Method: MethodAnalyzer, Status: StatusFail, Detail: err.Error()}}
+	}
+	report, err := analyzer.AnalyzeKeystore(params)
+	if err != nil {
+		return []Check{{Client: "kdf", Method: MethodAnalyzer, Status: StatusFail, Detail: err.Error()}}
+	}
+	if !report.Compatible {
+		return []Check{{Client: "kdf", Method: MethodAnalyzer, Status: StatusFail, Detail: strings.Join(report.Issues, "; ")}}
+	}
+
+	support := analyzer.ClientCompatibility(report.NormalizedKDF, report.Parameters)
+	clients := make([]string, 0, len(support))
+	for client := range support {
+		clients = append(clients, client)
+	}
+	sort.Strings(clients)
+	checks := make([]Check, 0, len(clients))
+	for _, client := range clients {
+		check := Check{Client: client, Method: MethodAnalyzer, Status: StatusPass,
+			Detail: fmt.Sprintf("%s parameters within the client's limits (security %s)", report.NormalizedKDF, report.SecurityLevel)}
+		if !support[client] {
+			check.Status = StatusFail
+			check.Detail = fmt.Sprintf("%s parameters outside the client's limits", report.NormalizedKDF)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
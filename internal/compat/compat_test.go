@@ -0,0 +1,138 @@
+package compat
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const vectorAddress = "0x008aeeda4d805471df9b2a5b0f38a0c3bcba786b"
+
+// writeVector writes the pbkdf2 reference vector as a keystore file
+func writeVector(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "UTC--vector.json")
+	if err := os.WriteFile(path, []byte(referenceVectors[1].keystore), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// fakeClients answers like the real clients would for the vector keystore
+func fakeClients(installed map[string]string) Runner {
+	return func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		out, ok := installed[name]
+		if !ok {
+			return nil, ErrNotInstalled
+		}
+		return []byte(out), nil
+	}
+}
+
+func statuses(report *Report) map[string]string {
+	result := make(map[string]string)
+	for _, check := range report.Checks {
+		result[check.Client+"/"+check.Method] = check.Status
+	}
+	return result
+}
+
+func TestCheckReferenceVectors(t *testing.T) {
+	if err := CheckReferenceVectors(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckKeystore(t *testing.T) {
+	path := writeVector(t)
+	var keyFile string
+	runner := func(ctx context.Context, name string, args ...string) ([]byte, error) {
+		switch {
+		case name == "geth" && args[1] == "list":
+			return []byte("Account #0: {008aeeda4d805471df9b2a5b0f38a0c3bcba786b} keystore:///tmp/x\n"), nil
+		case name == "geth" && args[1] == "import":
+			keyFile = args[len(args)-1]
+			key, err := os.ReadFile(keyFile)
+			if err != nil || string(key) != referenceVectors[1].privateKey {
+				t.Errorf("geth import got key %q, %v", key, err)
+			}
+			return []byte("Address: {008aeeda4d805471df9b2a5b0f38a0c3bcba786b}\n"), nil
+		case name == "ethkey":
+			return []byte("Address:        0x008AEeda4D805471dF9b2A5B0f38A0C3bCBA786b\n"), nil
+		}
+		return nil, ErrNotInstalled
+	}
+
+	report, err := CheckKeystore(context.Background(), path, Options{Password: "testpassword", Runner: runner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := statuses(report)
+	want := map[string]string{
+		"bloco-eth/reference":           StatusPass,
+		"web3-secret-storage/reference": StatusPass,
+		"decrypt/reference":             StatusPass,
+		"geth/analyzer":                 StatusPass,
+		"geth/client":                   StatusPass,
+		"clef/client":                   StatusSkipped,
+		"ethkey/client":                 StatusPass,
+	}
+	for key, status := range want {
+		if got[key] != status {
+			t.Errorf("%s = %q, want %q (%+v)", key, got[key], status, report.Checks)
+		}
+	}
+	if !report.OK() || report.Address != vectorAddress {
+		t.Errorf("report OK=%v address=%s", report.OK(), report.Address)
+	}
+	if _, err := os.Stat(keyFile); !os.IsNotExist(err) {
+		t.Errorf("decrypted key file %s was not removed", keyFile)
+	}
+}
+
+func TestCheckKeystore_Failures(t *testing.T) {
+	path := writeVector(t)
+
+	report, err := CheckKeystore(context.Background(), path, Options{
+		Password: "wrong password",
+		Clients:  []string{"ethkey"},
+		Runner:   fakeClients(map[string]string{"ethkey": "Address: 0x0000000000000000000000000000000000000001\n"}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := statuses(report)
+	if got["decrypt/reference"] != StatusFail || got["ethkey/client"] != StatusFail {
+		t.Errorf("checks = %v, want decrypt and ethkey failures", got)
+	}
+	if report.OK() || report.Failed() != 2 {
+		t.Errorf("Failed() = %d, want 2", report.Failed())
+	}
+	if _, ok := got["geth/client"]; ok {
+		t.Error("geth was checked although only ethkey was selected")
+	}
+}
+
+func TestCheckKeystore_NoPassword(t *testing.T) {
+	path := writeVector(t)
+	report, err := CheckKeystore(context.Background(), path, Options{
+		Clients: []string{"geth", "ethkey"},
+		Runner:  fakeClients(map[string]string{"geth": "Account #0: {008aeeda4d805471df9b2a5b0f38a0c3bcba786b}\n", "ethkey": ""}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := statuses(report)
+	if got["decrypt/reference"] != StatusSkipped || got["ethkey/client"] != StatusSkipped || got["geth/client"] != StatusPass {
+		t.Errorf("checks = %v", got)
+	}
+}
+
+func TestCheckKeystore_UnknownClient(t *testing.T) {
+	_, err := CheckKeystore(context.Background(), writeVector(t), Options{Clients: []string{"parity"}})
+	if err == nil || !strings.Contains(err.Error(), "unknown client") {
+		t.Errorf("err = %v, want unknown client", err)
+	}
+}
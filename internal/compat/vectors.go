@@ -0,0 +1,68 @@
+package compat
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"bloco-eth/internal/crypto"
+)
+
+// referenceVector is a keystore from the Web3 Secret Storage definition with its key
+type referenceVector struct {
+	name       string
+	keystore   string
+	password   string
+	privateKey string
+}
+
+// referenceVectors are the scrypt and pbkdf2 test vectors of the Web3 Secret
+// Storage definition, which geth, ethers and web3.js also test against
+var referenceVectors = []referenceVector{
+	{
+		name: "scrypt",
+		keystore: `{"address": "008aeeda4d805471df9b2a5b0f38a0c3bcba786b", "crypto": {
+			"cipher": "aes-128-ctr", "cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt", "kdfparams": {"dklen": 32, "n": 262144, "r": 1, "p": 8,
+				"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},
+			"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6", "version": 3}`,
+		password:   "testpassword",
+		privateKey: "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d",
+	},
+	{
+		name: "pbkdf2",
+		keystore: `{"address": "008aeeda4d805471df9b2a5b0f38a0c3bcba786b", "crypto": {
+			"cipher": "aes-128-ctr", "cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2", "kdfparams": {"c": 262144, "dklen": 32, "prf": "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},
+			"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6", "version": 3}`,
+		password:   "testpassword",
+		privateKey: "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d",
+	},
+}
+
+// CheckReferenceVectors confirms the keystore code decrypts the reference vectors,
+// so that a keystore it accepts is read the way other wallets read it. The KDFs
+// are slow and the answer cannot change, so the vectors are only decrypted once.
+var CheckReferenceVectors = sync.OnceValue(checkReferenceVectors)
+
+func checkReferenceVectors() error {
+	for _, vector := range referenceVectors {
+		keystore, err := crypto.FromJSON([]byte(vector.keystore))
+		if err != nil {
+			return fmt.Errorf("%s vector: %w", vector.name, err)
+		}
+		privateKey, err := crypto.DecryptPrivateKey(keystore, vector.password)
+		if err != nil {
+			return fmt.Errorf("%s vector: %w", vector.name, err)
+		}
+		if got := hex.EncodeToString(privateKey); got != vector.privateKey {
+			return fmt.Errorf("%s vector: decrypted %s, want %s", vector.name, got, vector.privateKey)
+		}
+	}
+	return nil
+}
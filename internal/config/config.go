@@ -3,8 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
-	"runtime"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,6 +16,7 @@ type Config struct {
 	CLI      CLIConfig      `yaml:"cli"`
 	KeyStore KeyStoreConfig `yaml:"keystore"`
 	Logging  LoggingConfig  `yaml:"logging"`
+	Power    PowerConfig    `yaml:"power"`
 }
 
 // WorkerConfig contains worker-related configuration
@@ -55,14 +56,17 @@ type CLIConfig struct {
 
 // KeyStoreConfig contains keystore generation configuration
 type KeyStoreConfig struct {
-	Enabled       bool                   `yaml:"enabled"`
-	OutputDir     string                 `yaml:"output_dir"`
-	KDFAlgorithm  string                 `yaml:"kdf_algorithm"`
-	KDFParams     map[string]interface{} `yaml:"kdf_params"`
-	CreateDirs    bool                   `yaml:"create_dirs"`
-	FileMode      int                    `yaml:"file_mode"`
-	ShowAnalysis  bool                   `yaml:"show_analysis"`
-	SecurityLevel string                 `yaml:"security_level"`
+	Enabled            bool                   `yaml:"enabled"`
+	OutputDir          string                 `yaml:"output_dir"`
+	KDFAlgorithm       string                 `yaml:"kdf_algorithm"`
+	Cipher             string                 `yaml:"cipher"`
+	KDFParams          map[string]interface{} `yaml:"kdf_params"`
+	CreateDirs         bool                   `yaml:"create_dirs"`
+	FileMode           int                    `yaml:"file_mode"`
+	ShowAnalysis       bool                   `yaml:"show_analysis"`
+	SecurityLevel      string                 `yaml:"security_level"`
+	PasswordProtection string                 `yaml:"password_protection"`
+	PasswordPolicy     string                 `yaml:"password_policy"`
 }
 
 // LoggingConfig contains logging configuration
@@ -76,11 +80,25 @@ type LoggingConfig struct {
 	BufferSize  int    `yaml:"buffer_size"`
 }
 
+// PowerConfig contains the thresholds that pause generation on laptops
+type PowerConfig struct {
+	PauseBelowBattery float64       `yaml:"pause_below_battery"` // percent, 0 = off
+	PauseAboveTemp    float64       `yaml:"pause_above_temp"`    // °C, 0 = off
+	CheckInterval     time.Duration `yaml:"check_interval"`      // 0 = every 30s
+}
+
+// Accepted values of the keystore settings, also offered by shell completion
+var (
+	KDFAlgorithms   = []string{"scrypt", "pbkdf2", "pbkdf2-sha256", "pbkdf2-sha512"}
+	KeyStoreCiphers = []string{"aes-128-ctr", "aes-256-ctr", "aes-128-gcm"}
+	SecurityLevels  = []string{"low", "medium", "high", "very-high"}
+)
+
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
 		Worker: WorkerConfig{
-			ThreadCount:       runtime.NumCPU(),
+			ThreadCount:       DetectCPUCount(),
 			MinBatchSize:      100,
 			MaxBatchSize:      10000,
 			UpdateInterval:    100 * time.Millisecond,
@@ -96,7 +114,7 @@ func DefaultConfig() *Config {
 			UnicodeSupport:   "auto",
 		},
 		Crypto: CryptoConfig{
-			PoolSize:         runtime.NumCPU() * 2,
+			PoolSize:         DetectCPUCount() * 2,
 			SecureRandom:     true,
 			OptimizedHashing: true,
 			MemoryClearing:   true,
@@ -107,14 +125,17 @@ func DefaultConfig() *Config {
 			QuietMode:              false,
 		},
 		KeyStore: KeyStoreConfig{
-			Enabled:       true,
-			OutputDir:     "./keystores",
-			KDFAlgorithm:  "scrypt",
-			KDFParams:     make(map[string]interface{}),
-			CreateDirs:    true,
-			FileMode:      0600,
-			ShowAnalysis:  false,
-			SecurityLevel: "medium",
+			Enabled:            true,
+			OutputDir:          "./keystores",
+			KDFAlgorithm:       "scrypt",
+			Cipher:             "aes-128-ctr",
+			KDFParams:          make(map[string]interface{}),
+			CreateDirs:         true,
+			FileMode:           0600,
+			ShowAnalysis:       false,
+			SecurityLevel:      "medium",
+			PasswordProtection: "none",
+			PasswordPolicy:     "random:12",
 		},
 		Logging: LoggingConfig{
 			Enabled:     true,
@@ -125,6 +146,9 @@ func DefaultConfig() *Config {
 			MaxFiles:    5,
 			BufferSize:  1000,
 		},
+		Power: PowerConfig{
+			CheckInterval: 30 * time.Second,
+		},
 	}
 }
 
@@ -143,6 +167,19 @@ func (c *Config) LoadFromEnvironment() {
 		}
 	}
 
+	// Power thresholds
+	if battery := os.Getenv("BLOCO_PAUSE_BELOW_BATTERY"); battery != "" {
+		if val, err := strconv.ParseFloat(battery, 64); err == nil {
+			c.Power.PauseBelowBattery = val
+		}
+	}
+
+	if temp := os.Getenv("BLOCO_PAUSE_ABOVE_TEMP"); temp != "" {
+		if val, err := strconv.ParseFloat(temp, 64); err == nil {
+			c.Power.PauseAboveTemp = val
+		}
+	}
+
 	// TUI configuration
 	if tuiEnabled := os.Getenv("BLOCO_TUI"); tuiEnabled != "" {
 		c.TUI.Enabled = parseBoolEnv(tuiEnabled, c.TUI.Enabled)
@@ -179,6 +216,10 @@ func (c *Config) LoadFromEnvironment() {
 		c.KeyStore.KDFAlgorithm = keystoreKDF
 	}
 
+	if keystoreCipher := os.Getenv("BLOCO_KEYSTORE_CIPHER"); keystoreCipher != "" {
+		c.KeyStore.Cipher = keystoreCipher
+	}
+
 	if showAnalysis := os.Getenv("BLOCO_KDF_ANALYSIS"); showAnalysis != "" {
 		c.KeyStore.ShowAnalysis = parseBoolEnv(showAnalysis, c.KeyStore.ShowAnalysis)
 	}
@@ -187,6 +228,14 @@ func (c *Config) LoadFromEnvironment() {
 		c.KeyStore.SecurityLevel = securityLevel
 	}
 
+	if protection := os.Getenv("BLOCO_PASSWORD_PROTECTION"); protection != "" {
+		c.KeyStore.PasswordProtection = protection
+	}
+
+	if policy := os.Getenv("BLOCO_KEYSTORE_PASSWORD_POLICY"); policy != "" {
+		c.KeyStore.PasswordPolicy = policy
+	}
+
 	// Logging configuration
 	if loggingEnabled := os.Getenv("BLOCO_LOGGING_ENABLED"); loggingEnabled != "" {
 		c.Logging.Enabled = parseBoolEnv(loggingEnabled, c.Logging.Enabled)
@@ -263,16 +312,31 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("keystore output directory cannot be empty")
 	}
 
-	validKDFAlgorithms := []string{"scrypt", "pbkdf2", "pbkdf2-sha256", "pbkdf2-sha512"}
-	if !contains(validKDFAlgorithms, c.KeyStore.KDFAlgorithm) {
+	if !contains(KDFAlgorithms, c.KeyStore.KDFAlgorithm) {
 		return fmt.Errorf("invalid KDF algorithm: %s (valid: %v)",
-			c.KeyStore.KDFAlgorithm, validKDFAlgorithms)
+			c.KeyStore.KDFAlgorithm, KDFAlgorithms)
 	}
 
-	validSecurityLevels := []string{"low", "medium", "high", "very-high"}
-	if !contains(validSecurityLevels, c.KeyStore.SecurityLevel) {
+	if !contains(KeyStoreCiphers, c.KeyStore.Cipher) {
+		return fmt.Errorf("invalid keystore cipher: %s (valid: %v)",
+			c.KeyStore.Cipher, KeyStoreCiphers)
+	}
+
+	if !contains(SecurityLevels, c.KeyStore.SecurityLevel) {
 		return fmt.Errorf("invalid security level: %s (valid: %v)",
-			c.KeyStore.SecurityLevel, validSecurityLevels)
+			c.KeyStore.SecurityLevel, SecurityLevels)
+	}
+
+	if !validPasswordProtection(c.KeyStore.PasswordProtection) {
+		return fmt.Errorf("invalid password protection: %s (valid: none, gpg:<recipient>, age:<recipient>)",
+			c.KeyStore.PasswordProtection)
+	}
+
+	if mode, _, _ := strings.Cut(c.KeyStore.PasswordPolicy, ":"); mode != "" {
+		if mode, _, _ = strings.Cut(mode, ","); mode != "random" && mode != "diceware" {
+			return fmt.Errorf("invalid keystore password policy: %s (valid: random[:<length>], diceware[:<words>])",
+				c.KeyStore.PasswordPolicy)
+		}
 	}
 
 	if c.KeyStore.FileMode < 0 || c.KeyStore.FileMode > 0777 {
@@ -304,6 +368,19 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("log buffer size must be non-negative, got %d", c.Logging.BufferSize)
 	}
 
+	// Validate power thresholds
+	if c.Power.PauseBelowBattery < 0 || c.Power.PauseBelowBattery >= 100 {
+		return fmt.Errorf("battery pause threshold must be between 0 and 100%%, got %g", c.Power.PauseBelowBattery)
+	}
+
+	if c.Power.PauseAboveTemp < 0 {
+		return fmt.Errorf("temperature pause threshold must be non-negative, got %g", c.Power.PauseAboveTemp)
+	}
+
+	if c.Power.CheckInterval < 0 {
+		return fmt.Errorf("power check interval must be non-negative, got %v", c.Power.CheckInterval)
+	}
+
 	return nil
 }
 
@@ -406,9 +483,18 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// validPasswordProtection reports whether spec is "none", "gpg:<recipient>" or "age:<recipient>"
+func validPasswordProtection(spec string) bool {
+	if spec == "" || spec == "none" {
+		return true
+	}
+	method, recipient, ok := strings.Cut(spec, ":")
+	return ok && recipient != "" && (method == "gpg" || method == "age")
+}
+
 // GetEffectiveThreadCount returns the effective thread count considering system limits
 func (c *Config) GetEffectiveThreadCount() int {
-	maxRecommended := runtime.NumCPU() * 2
+	maxRecommended := DetectCPUCount() * 2
 	if c.Worker.ThreadCount > maxRecommended {
 		return maxRecommended
 	}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfig_LoggingConfig(t *testing.T) {
@@ -272,3 +273,42 @@ func TestConfig_ApplyOverrides_LoggingConfig(t *testing.T) {
 		t.Errorf("OutputFile = %v, want %v", cfg.Logging.OutputFile, file)
 	}
 }
+
+func TestConfig_PowerConfig(t *testing.T) {
+	t.Setenv("BLOCO_PAUSE_BELOW_BATTERY", "15")
+	t.Setenv("BLOCO_PAUSE_ABOVE_TEMP", "90.5")
+	cfg := DefaultConfig()
+	cfg.LoadFromEnvironment()
+	if cfg.Power.PauseBelowBattery != 15 || cfg.Power.PauseAboveTemp != 90.5 {
+		t.Errorf("Power = %+v, want 15%% and 90.5°C", cfg.Power)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	for _, power := range []PowerConfig{{PauseBelowBattery: 100}, {PauseBelowBattery: -1}, {PauseAboveTemp: -5}, {CheckInterval: -time.Second}} {
+		cfg := DefaultConfig()
+		cfg.Power = power
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected error, got nil", power)
+		}
+	}
+}
+
+func TestConfig_PasswordPolicy(t *testing.T) {
+	t.Setenv("BLOCO_KEYSTORE_PASSWORD_POLICY", "diceware:6")
+	cfg := DefaultConfig()
+	cfg.LoadFromEnvironment()
+	if cfg.KeyStore.PasswordPolicy != "diceware:6" {
+		t.Errorf("PasswordPolicy = %q, want diceware:6", cfg.KeyStore.PasswordPolicy)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg = DefaultConfig()
+	cfg.KeyStore.PasswordPolicy = "words:6"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() expected an error for an unknown policy")
+	}
+}
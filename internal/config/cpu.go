@@ -0,0 +1,42 @@
+package config
+
+import (
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// DetectCPUCount returns the CPUs the process can keep busy: runtime.NumCPU, which
+// honours CPU affinity and cpusets, lowered to a container's CPU quota rounded up.
+// It is the thread count used when --threads is 0.
+func DetectCPUCount() int {
+	count := runtime.NumCPU()
+	if quota, ok := CPUQuota(); ok && int(math.Ceil(quota)) < count {
+		count = max(int(math.Ceil(quota)), 1)
+	}
+	return count
+}
+
+// parseCPUMax parses a cgroup v2 cpu.max file, "<quota> <period>" or "max <period>"
+func parseCPUMax(data string) (float64, bool) {
+	fields := strings.Fields(data)
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0, false
+	}
+	return quotaRatio(fields[0], fields[1])
+}
+
+// quotaRatio divides a CFS quota by its period, both in microseconds; a negative
+// quota means none
+func quotaRatio(quota, period string) (float64, bool) {
+	q, err := strconv.ParseInt(strings.TrimSpace(quota), 10, 64)
+	if err != nil || q <= 0 {
+		return 0, false
+	}
+	p, err := strconv.ParseInt(strings.TrimSpace(period), 10, 64)
+	if err != nil || p <= 0 {
+		return 0, false
+	}
+	return float64(q) / float64(p), true
+}
@@ -0,0 +1,23 @@
+package config
+
+import "os"
+
+// CPUQuota returns the CPUs a cgroup v2 or v1 CPU quota allows this process, such
+// as 1.5 for "--cpus 1.5", and whether there is a quota
+func CPUQuota() (float64, bool) {
+	if data, err := os.ReadFile("/sys/fs/cgroup/cpu.max"); err == nil {
+		return parseCPUMax(string(data))
+	}
+	for _, dir := range []string{"/sys/fs/cgroup/cpu", "/sys/fs/cgroup/cpu,cpuacct"} {
+		quota, err := os.ReadFile(dir + "/cpu.cfs_quota_us")
+		if err != nil {
+			continue
+		}
+		period, err := os.ReadFile(dir + "/cpu.cfs_period_us")
+		if err != nil {
+			continue
+		}
+		return quotaRatio(string(quota), string(period))
+	}
+	return 0, false
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package config
+
+// CPUQuota reports no quota, as CPU quotas are only read from Linux cgroups
+func CPUQuota() (float64, bool) {
+	return 0, false
+}
@@ -0,0 +1,45 @@
+package config
+
+import "testing"
+
+func TestParseCPUMax(t *testing.T) {
+	tests := []struct {
+		data   string
+		want   float64
+		wantOK bool
+	}{
+		{"200000 100000\n", 2, true},
+		{"150000 100000", 1.5, true},
+		{"50000 100000", 0.5, true},
+		{"max 100000\n", 0, false},
+		{"", 0, false},
+		{"abc 100000", 0, false},
+		{"100000 0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseCPUMax(tt.data)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseCPUMax(%q) = %v, %v; want %v, %v", tt.data, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestQuotaRatio(t *testing.T) {
+	// cgroup v1 reports no quota as -1
+	if _, ok := quotaRatio("-1\n", "100000\n"); ok {
+		t.Error("quotaRatio(-1) reported a quota")
+	}
+	if got, ok := quotaRatio("300000\n", "100000\n"); !ok || got != 3 {
+		t.Errorf("quotaRatio() = %v, %v; want 3", got, ok)
+	}
+}
+
+func TestDetectCPUCount(t *testing.T) {
+	count := DetectCPUCount()
+	if count < 1 {
+		t.Fatalf("DetectCPUCount() = %d", count)
+	}
+	if quota, ok := CPUQuota(); ok && float64(count) > quota+1 {
+		t.Errorf("DetectCPUCount() = %d above the %v CPU quota", count, quota)
+	}
+}
@@ -0,0 +1,195 @@
+package crypto
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// Standard BIP-44 derivation paths used by hardware wallets for the first account
+const (
+	EthereumDerivationPath = "m/44'/60'/0'/0/0"
+	BitcoinDerivationPath  = "m/44'/0'/0'/0/0"
+)
+
+// AccountReportEntry is one row of an account export report
+type AccountReportEntry struct {
+	Label            string   `json:"label"`
+	Tags             []string `json:"tags,omitempty"`
+	Network          string   `json:"network"`
+	Address          string   `json:"address"`
+	DerivationPath   string   `json:"derivation_path,omitempty"`
+	XPub             string   `json:"xpub,omitempty"`
+	MnemonicVerified bool     `json:"mnemonic_verified"`
+	Note             string   `json:"note,omitempty"`
+	// Stats are set for Ethereum addresses
+	Stats       *AddressStats `json:"address_stats,omitempty"`
+	ExplorerURL string        `json:"explorer_url,omitempty"`
+}
+
+// NewAccountReportEntry describes how a generated wallet can be imported. For
+// mnemonic wallets the address is re-derived from the mnemonic on the standard
+// path so the report only lists a derivation path a hardware wallet can restore.
+func NewAccountReportEntry(w *wallet.Wallet, label string) AccountReportEntry {
+	network := strings.ToLower(w.Network)
+	if network == "" {
+		network = "ethereum"
+	}
+	entry := AccountReportEntry{Label: label, Tags: w.Tags, Network: network, Address: w.Address}
+	if stats, ok := NewAddressStats(w.Address); ok && network == "ethereum" {
+		entry.Stats = &stats
+	}
+
+	if w.Mnemonic == "" {
+		entry.Note = "no mnemonic; import the private key or keystore"
+		return entry
+	}
+
+	var (
+		path    string
+		address string
+		xpub    string
+		err     error
+	)
+	switch network {
+	case "ethereum":
+		path = EthereumDerivationPath
+		address, xpub, err = DeriveEthereumAccount(w.Mnemonic)
+	case "bitcoin":
+		path = BitcoinDerivationPath
+		address, xpub, err = DeriveBitcoinAccount(w.Mnemonic)
+	default:
+		entry.Note = fmt.Sprintf("mnemonic derivation is not supported for %s", network)
+		return entry
+	}
+	if err != nil {
+		entry.Note = fmt.Sprintf("mnemonic derivation failed: %v", err)
+		return entry
+	}
+
+	if !strings.EqualFold(address, w.Address) {
+		entry.Note = fmt.Sprintf("mnemonic derives %s on %s, not this address; import the private key or keystore", address, path)
+		return entry
+	}
+	entry.DerivationPath = path
+	entry.XPub = xpub
+	entry.MnemonicVerified = true
+	return entry
+}
+
+// DeriveEthereumAccount returns the address at m/44'/60'/0'/0/0 and the account xpub (m/44'/60'/0')
+func DeriveEthereumAccount(mnemonic string) (string, string, error) {
+	account, key, err := deriveBIP44(mnemonic, 60)
+	if err != nil {
+		return "", "", err
+	}
+	privateKey, err := ethcrypto.ToECDSA(key.Key)
+	if err != nil {
+		return "", "", err
+	}
+	return ethcrypto.PubkeyToAddress(privateKey.PublicKey).Hex(), account.PublicKey().String(), nil
+}
+
+// DeriveBitcoinAccount returns the P2PKH address at m/44'/0'/0'/0/0 and the account xpub (m/44'/0'/0')
+func DeriveBitcoinAccount(mnemonic string) (string, string, error) {
+	account, key, err := deriveBIP44(mnemonic, 0)
+	if err != nil {
+		return "", "", err
+	}
+	_, pubKey := btcec.PrivKeyFromBytes(key.Key)
+	address, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(pubKey.SerializeCompressed()), &chaincfg.MainNetParams)
+	if err != nil {
+		return "", "", err
+	}
+	return address.EncodeAddress(), account.PublicKey().String(), nil
+}
+
+// deriveBIP44 derives the account key m/44'/coin'/0' and the first receive key below it
+func deriveBIP44(mnemonic string, coinType uint32) (*bip32.Key, *bip32.Key, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, nil, fmt.Errorf("invalid BIP-39 mnemonic")
+	}
+	master, err := bip32.NewMasterKey(bip39.NewSeed(mnemonic, ""))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	account := master
+	for _, child := range []uint32{bip32.FirstHardenedChild + 44, bip32.FirstHardenedChild + coinType, bip32.FirstHardenedChild} {
+		if account, err = account.NewChildKey(child); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	key := account
+	for _, child := range []uint32{0, 0} {
+		if key, err = key.NewChildKey(child); err != nil {
+			return nil, nil, err
+		}
+	}
+	return account, key, nil
+}
+
+// WriteAccountReport writes entries as "csv" or "json"
+func WriteAccountReport(out io.Writer, format string, entries []AccountReportEntry) error {
+	switch format {
+	case "json":
+		if entries == nil {
+			entries = []AccountReportEntry{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case "csv":
+		writer := csv.NewWriter(out)
+		header := []string{"label", "tags", "network", "address", "derivation_path", "xpub", "mnemonic_verified", "note"}
+		// The stats and explorer_url columns are only added when some entry has
+		// them, so reports of other networks or without --explorer keep their columns
+		stats := slices.ContainsFunc(entries, func(e AccountReportEntry) bool { return e.Stats != nil })
+		if stats {
+			header = append(header, "zero_bytes", "leading_zero_bytes", "checksum_uppercase")
+		}
+		explorer := slices.ContainsFunc(entries, func(e AccountReportEntry) bool { return e.ExplorerURL != "" })
+		if explorer {
+			header = append(header, "explorer_url")
+		}
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			record := []string{
+				entry.Label, strings.Join(entry.Tags, ";"), entry.Network, entry.Address, entry.DerivationPath, entry.XPub,
+				strconv.FormatBool(entry.MnemonicVerified), entry.Note,
+			}
+			if stats && entry.Stats != nil {
+				record = append(record, strconv.Itoa(entry.Stats.ZeroBytes), strconv.Itoa(entry.Stats.LeadingZeroBytes),
+					strconv.Itoa(entry.Stats.ChecksumUppercase))
+			} else if stats {
+				record = append(record, "", "", "")
+			}
+			if explorer {
+				record = append(record, entry.ExplorerURL)
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported account report format %q (use csv or json)", format)
+	}
+}
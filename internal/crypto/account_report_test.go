@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"bloco-eth/pkg/wallet"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestDeriveAccounts_KnownVectors(t *testing.T) {
+	address, xpub, err := DeriveEthereumAccount(testMnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" || !strings.HasPrefix(xpub, "xpub") {
+		t.Errorf("DeriveEthereumAccount() = %s, %s", address, xpub)
+	}
+
+	address, _, err = DeriveBitcoinAccount(testMnemonic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != "1LqBGSKuX5yYUonjxT5qGfpUsXKYYWeabA" {
+		t.Errorf("DeriveBitcoinAccount() = %s", address)
+	}
+
+	if _, _, err := DeriveEthereumAccount("not a mnemonic"); err == nil {
+		t.Error("expected error for an invalid mnemonic")
+	}
+}
+
+func TestNewAccountReportEntry(t *testing.T) {
+	verified := NewAccountReportEntry(&wallet.Wallet{
+		Address:  "0x9858effd232b4033e47d90003d41ec34ecaeda94",
+		Mnemonic: testMnemonic,
+		Network:  "ethereum",
+	}, "treasury")
+	if !verified.MnemonicVerified || verified.DerivationPath != EthereumDerivationPath || verified.XPub == "" || verified.Label != "treasury" {
+		t.Errorf("expected a verified entry, got %+v", verified)
+	}
+	if verified.Stats == nil || verified.Stats.ZeroBytes != 1 {
+		t.Errorf("expected the address stats of the entry, got %+v", verified.Stats)
+	}
+
+	mismatch := NewAccountReportEntry(&wallet.Wallet{Address: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Mnemonic: testMnemonic, Network: "bitcoin"}, "")
+	if mismatch.MnemonicVerified || mismatch.DerivationPath != "" || !strings.Contains(mismatch.Note, "not this address") {
+		t.Errorf("expected an unverified entry, got %+v", mismatch)
+	}
+	if mismatch.Stats != nil {
+		t.Errorf("expected no address stats for a bitcoin entry, got %+v", mismatch.Stats)
+	}
+
+	keyOnly := NewAccountReportEntry(&wallet.Wallet{Address: "0xabc"}, "")
+	if keyOnly.MnemonicVerified || keyOnly.Network != "ethereum" || keyOnly.Note == "" {
+		t.Errorf("unexpected private key entry %+v", keyOnly)
+	}
+}
+
+func TestWriteAccountReport(t *testing.T) {
+	entries := []AccountReportEntry{{Label: "a, b", Tags: []string{"team:ops", "hot"}, Network: "ethereum", Address: "0xabc", Note: "no mnemonic"}}
+
+	var csvOut bytes.Buffer
+	if err := WriteAccountReport(&csvOut, "csv", entries); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 2 || lines[0] != "label,tags,network,address,derivation_path,xpub,mnemonic_verified,note" ||
+		lines[1] != `"a, b",team:ops;hot,ethereum,0xabc,,,false,no mnemonic` {
+		t.Errorf("unexpected csv output:\n%s", csvOut.String())
+	}
+
+	var jsonOut bytes.Buffer
+	if err := WriteAccountReport(&jsonOut, "json", entries); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []AccountReportEntry
+	if err := json.Unmarshal(jsonOut.Bytes(), &decoded); err != nil || len(decoded) != 1 || decoded[0].Label != "a, b" {
+		t.Errorf("unexpected json output %s (%v)", jsonOut.String(), err)
+	}
+
+	if err := WriteAccountReport(&jsonOut, "xml", entries); err == nil {
+		t.Error("expected error for an unsupported format")
+	}
+
+	entries[0].Stats = &AddressStats{ZeroBytes: 3, LeadingZeroBytes: 1, ChecksumUppercase: 12}
+	entries[0].ExplorerURL = "https://etherscan.io/address/0xabc"
+	csvOut.Reset()
+	if err := WriteAccountReport(&csvOut, "csv", entries); err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[0], ",note,zero_bytes,leading_zero_bytes,checksum_uppercase,explorer_url") ||
+		!strings.HasSuffix(lines[1], ",no mnemonic,3,1,12,https://etherscan.io/address/0xabc") {
+		t.Errorf("unexpected csv output with stats and explorer links:\n%s", csvOut.String())
+	}
+}
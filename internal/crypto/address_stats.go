@@ -0,0 +1,71 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AddressStats are the properties of an Ethereum address that matter for gas. Zero
+// bytes are cheaper in calldata (4 gas instead of 16), and leading zero bytes can be
+// dropped by contracts that pack addresses.
+type AddressStats struct {
+	ZeroBytes        int `json:"zero_bytes"`
+	LeadingZeroBytes int `json:"leading_zero_bytes"`
+	// ChecksumUppercase counts the letters EIP-55 makes uppercase
+	ChecksumUppercase int `json:"checksum_uppercase"`
+}
+
+// NewAddressStats computes the stats of an Ethereum address, and returns false for
+// anything else
+func NewAddressStats(address string) (AddressStats, bool) {
+	raw, ok := addressBytes(address)
+	if !ok {
+		return AddressStats{}, false
+	}
+	var stats AddressStats
+	leading := true
+	for _, b := range raw {
+		if b != 0 {
+			leading = false
+			continue
+		}
+		stats.ZeroBytes++
+		if leading {
+			stats.LeadingZeroBytes++
+		}
+	}
+	for _, char := range common.BytesToAddress(raw).Hex()[2:] {
+		if char >= 'A' && char <= 'F' {
+			stats.ChecksumUppercase++
+		}
+	}
+	return stats, true
+}
+
+// ZeroBytes counts the zero bytes of an Ethereum address, or returns -1 for
+// anything else. It is cheap enough to run on every candidate of a search.
+func ZeroBytes(address string) int {
+	body := strings.TrimPrefix(address, "0x")
+	if len(body) != 40 {
+		return -1
+	}
+	zeros := 0
+	for i := 0; i < len(body); i += 2 {
+		if body[i] == '0' && body[i+1] == '0' {
+			zeros++
+		}
+	}
+	return zeros
+}
+
+// addressBytes decodes a 0x-prefixed or bare 40-digit hex address
+func addressBytes(address string) ([]byte, bool) {
+	body := strings.TrimPrefix(address, "0x")
+	if len(body) != 40 {
+		return nil, false
+	}
+	raw, err := hex.DecodeString(body)
+	return raw, err == nil
+}
@@ -0,0 +1,28 @@
+package crypto
+
+import "testing"
+
+func TestNewAddressStats(t *testing.T) {
+	// EIP-55 writes this address 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed
+	stats, ok := NewAddressStats("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	if !ok || stats != (AddressStats{ChecksumUppercase: 9}) {
+		t.Errorf("NewAddressStats() = %+v, %v", stats, ok)
+	}
+
+	stats, ok = NewAddressStats("0x0000ab00000000000000000000000000000000ff")
+	if !ok || stats.ZeroBytes != 18 || stats.LeadingZeroBytes != 2 {
+		t.Errorf("NewAddressStats() = %+v, %v", stats, ok)
+	}
+	if ZeroBytes("0x0000ab00000000000000000000000000000000ff") != 18 {
+		t.Error("ZeroBytes() disagrees with NewAddressStats()")
+	}
+
+	for _, address := range []string{"0xabc", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "0x" + "zz" + "00000000000000000000000000000000000000"} {
+		if _, ok := NewAddressStats(address); ok {
+			t.Errorf("NewAddressStats(%q) reported stats", address)
+		}
+	}
+	if ZeroBytes("0xabc") != -1 {
+		t.Error("ZeroBytes() of a short address is not -1")
+	}
+}
@@ -1,7 +1,6 @@
 package crypto
 
 import (
-	"crypto/rand"
 	"encoding/hex"
 
 	"bloco-eth/pkg/errors"
@@ -35,7 +34,7 @@ func (bg *BitcoinGenerator) GenerateWallet() (*wallet.Wallet, error) {
 	defer cryptoPool.PutPrivateKeyBuffer(privateKey)
 
 	// Generate 32 random bytes for private key
-	_, err := rand.Read(privateKey)
+	err := ReadEntropy(privateKey)
 	if err != nil {
 		return nil, errors.NewCryptoError("generate_wallet",
 			"failed to generate random private key", err)
@@ -84,8 +83,8 @@ func (bg *BitcoinGenerator) GenerateAddressFromPrivateKey(privateKey []byte) (st
 // generateBIP39Mnemonic generates a 12-word BIP-39 mnemonic phrase
 func generateBIP39Mnemonic() (string, error) {
 	// Generate 128 bits of entropy for a 12-word mnemonic
-	entropy, err := bip39.NewEntropy(128)
-	if err != nil {
+	entropy := make([]byte, 16)
+	if err := ReadEntropy(entropy); err != nil {
 		return "", err
 	}
 
@@ -0,0 +1,201 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CeremonyTranscriptVersion is the current transcript format version
+const CeremonyTranscriptVersion = 1
+
+// CeremonyTranscript records a key generation ceremony. Signature is an Ed25519
+// signature by PublicKey over the JSON encoding of every other field.
+type CeremonyTranscript struct {
+	Version      int                  `json:"version"`
+	StartedAt    time.Time            `json:"started_at"`
+	FinishedAt   time.Time            `json:"finished_at"`
+	Host         string               `json:"host"`
+	BinaryPath   string               `json:"binary_path"`
+	BinarySHA256 string               `json:"binary_sha256"`
+	Config       json.RawMessage      `json:"config"`
+	ConfigSHA256 string               `json:"config_sha256"`
+	NetworkCheck CeremonyNetworkCheck `json:"network_check"`
+	Operators    []CeremonyOperator   `json:"operators"`
+	Wallets      []CeremonyWallet     `json:"wallets"`
+	Outcome      string               `json:"outcome"`
+	PublicKey    string               `json:"public_key"`
+	Signature    string               `json:"signature"`
+}
+
+// CeremonyNetworkCheck records the offline check made before generation
+type CeremonyNetworkCheck struct {
+	Offline    bool     `json:"offline"`
+	Overridden bool     `json:"overridden"`
+	Interfaces []string `json:"interfaces,omitempty"` // interfaces found up, with their addresses
+}
+
+// CeremonyOperator is one of the people who confirmed the ceremony
+type CeremonyOperator struct {
+	Name        string    `json:"name"`
+	ConfirmedAt time.Time `json:"confirmed_at"`
+}
+
+// CeremonyWallet is a wallet generated during the ceremony, by address only
+type CeremonyWallet struct {
+	Address string `json:"address"`
+	Network string `json:"network"`
+}
+
+// FileSHA256 returns the hex SHA-256 of a file, used to fingerprint the running binary
+func FileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// SetConfig stores the ceremony configuration and its fingerprint
+func (t *CeremonyTranscript) SetConfig(config any) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode ceremony configuration: %w", err)
+	}
+	t.Config = data
+	t.ConfigSHA256 = configFingerprint(data)
+	return nil
+}
+
+// configFingerprint hashes the compact form of the configuration, so re-indenting
+// the transcript does not change it
+func configFingerprint(config json.RawMessage) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, config); err != nil {
+		compact.Reset()
+		compact.Write(config)
+	}
+	sum := sha256.Sum256(compact.Bytes())
+	return hex.EncodeToString(sum[:])
+}
+
+// signedBytes is the transcript encoding covered by the signature
+func (t *CeremonyTranscript) signedBytes() ([]byte, error) {
+	unsigned := *t
+	unsigned.Signature = ""
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ceremony transcript: %w", err)
+	}
+	return data, nil
+}
+
+// Sign sets PublicKey and signs the transcript with key
+func (t *CeremonyTranscript) Sign(key ed25519.PrivateKey) error {
+	t.PublicKey = hex.EncodeToString(key.Public().(ed25519.PublicKey))
+	data, err := t.signedBytes()
+	if err != nil {
+		return err
+	}
+	t.Signature = hex.EncodeToString(ed25519.Sign(key, data))
+	return nil
+}
+
+// Verify checks the configuration fingerprint and the signature by the embedded public key
+func (t *CeremonyTranscript) Verify() error {
+	if t.ConfigSHA256 != configFingerprint(t.Config) {
+		return fmt.Errorf("configuration does not match its fingerprint")
+	}
+	publicKey, err := hex.DecodeString(t.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid transcript public key")
+	}
+	signature, err := hex.DecodeString(t.Signature)
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid transcript signature")
+	}
+	data, err := t.signedBytes()
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, data, signature) {
+		return fmt.Errorf("signature does not match the transcript")
+	}
+	return nil
+}
+
+// SaveCeremonyTranscript writes the transcript to dir as ceremony-<UTC start time>.json
+func SaveCeremonyTranscript(dir string, t *CeremonyTranscript) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode ceremony transcript: %w", err)
+	}
+	path := filepath.Join(dir, "ceremony-"+t.StartedAt.UTC().Format("20060102T150405Z")+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// LoadCeremonyTranscript reads a transcript written by SaveCeremonyTranscript
+func LoadCeremonyTranscript(path string) (*CeremonyTranscript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var t CeremonyTranscript
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("%s is not a ceremony transcript: %w", path, err)
+	}
+	return &t, nil
+}
+
+// LoadCeremonyKey reads an Ed25519 private key in PKCS#8 PEM form, as written by
+// "openssl genpkey -algorithm ed25519"
+func LoadCeremonyKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ceremony key %s: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, fmt.Errorf("%s is not a PEM encoded PKCS#8 private key", path)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ceremony key %s: %w", path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("ceremony key %s is not an Ed25519 key", path)
+	}
+	return key, nil
+}
+
+// GenerateCeremonyKey creates a one-time Ed25519 signing key for a ceremony
+func GenerateCeremonyKey() (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ceremony key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,92 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCeremonyTranscriptSignVerify(t *testing.T) {
+	key, err := GenerateCeremonyKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	transcript := &CeremonyTranscript{
+		Version:      CeremonyTranscriptVersion,
+		StartedAt:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		BinarySHA256: "c52a31a0",
+		Operators:    []CeremonyOperator{{Name: "Alice"}, {Name: "Bob"}},
+		Wallets:      []CeremonyWallet{{Address: "0xabc", Network: "ethereum"}},
+		Outcome:      "completed",
+	}
+	if err := transcript.SetConfig(map[string]any{"prefix": "abc", "wallets": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := transcript.Sign(key); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	path, err := SaveCeremonyTranscript(dir, transcript)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "ceremony-20260102T030405Z.json" {
+		t.Errorf("unexpected transcript name %s", path)
+	}
+	loaded, err := LoadCeremonyTranscript(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.Verify(); err != nil {
+		t.Fatalf("saved transcript failed verification: %v", err)
+	}
+
+	tampered := *loaded
+	tampered.Wallets = append([]CeremonyWallet{}, loaded.Wallets...)
+	tampered.Wallets[0].Address = "0xdef"
+	if tampered.Verify() == nil {
+		t.Error("changed wallet address should fail verification")
+	}
+
+	tampered = *loaded
+	tampered.Config = []byte(`{"prefix":"abd","wallets":1}`)
+	if tampered.Verify() == nil {
+		t.Error("changed configuration should fail verification")
+	}
+}
+
+func TestLoadCeremonyKey(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadCeremonyKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Equal(key) {
+		t.Error("loaded key does not match")
+	}
+
+	if err := os.WriteFile(path, []byte("not a key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCeremonyKey(path); err == nil {
+		t.Error("expected an error for a file without a PEM key")
+	}
+}
@@ -0,0 +1,169 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultCREATE2Deployer is the deterministic deployment proxy used by Foundry and Hardhat
+const DefaultCREATE2Deployer = "0x4e59b44847b379578588920cA78FbF26c0B4956C"
+
+// create2ChunkSize is how many salts a worker tries on one target before rescheduling
+const create2ChunkSize = 4096
+
+// CREATE2Address returns keccak256(0xff ++ deployer ++ salt ++ initCodeHash)[12:]
+func CREATE2Address(deployer common.Address, salt, initCodeHash [32]byte) common.Address {
+	hash := ethcrypto.Keccak256([]byte{0xff}, deployer[:], salt[:], initCodeHash[:])
+	return common.BytesToAddress(hash[12:])
+}
+
+// CREATE2Target is a contract whose deployment address should match a pattern
+type CREATE2Target struct {
+	Name         string
+	InitCodeHash [32]byte
+}
+
+// CREATE2Result is a mined salt for one target
+type CREATE2Result struct {
+	Name         string `json:"name"`
+	InitCodeHash string `json:"initCodeHash"`
+	Salt         string `json:"salt"`
+	Address      string `json:"address"`
+	Attempts     int64  `json:"attempts"`
+}
+
+// ParseInitCodeHash parses a 32-byte hex init-code hash
+func ParseInitCodeHash(s string) ([32]byte, error) {
+	var hash [32]byte
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	if err != nil || len(raw) != 32 {
+		return hash, fmt.Errorf("invalid init-code hash %q: must be 32 bytes of hex", s)
+	}
+	copy(hash[:], raw)
+	return hash, nil
+}
+
+// MineCREATE2Batch mines a salt for every target. Workers rotate over the unsolved
+// targets in chunks, so all targets progress together and solved targets drop out.
+// match receives the 40-character hex address without 0x.
+func MineCREATE2Batch(
+	ctx context.Context,
+	deployer common.Address,
+	targets []CREATE2Target,
+	workers int,
+	match func(address string) bool,
+	onFound func(CREATE2Result),
+) ([]CREATE2Result, error) {
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]CREATE2Result, len(targets))
+	solved := make([]atomic.Bool, len(targets))
+	attempts := make([]atomic.Int64, len(targets))
+	var (
+		remaining atomic.Int64
+		next      atomic.Uint64
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		errOnce   sync.Once
+		mineErr   error
+	)
+	remaining.Store(int64(len(targets)))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Each worker counts up from its own random salt, so workers never overlap
+			var salt [32]byte
+			if _, err := rand.Read(salt[:]); err != nil {
+				errOnce.Do(func() { mineErr = err })
+				cancel()
+				return
+			}
+			counter := binary.BigEndian.Uint64(salt[24:])
+			var hexAddr [40]byte
+
+			for remaining.Load() > 0 && ctx.Err() == nil {
+				// Pick the next unsolved target in round-robin order
+				idx := -1
+				for range targets {
+					i := int(next.Add(1) % uint64(len(targets)))
+					if !solved[i].Load() {
+						idx = i
+						break
+					}
+				}
+				if idx < 0 {
+					return
+				}
+				target := &targets[idx]
+
+				tried := int64(0)
+				for tried < create2ChunkSize && !solved[idx].Load() {
+					tried++
+					counter++
+					binary.BigEndian.PutUint64(salt[24:], counter)
+					address := CREATE2Address(deployer, salt, target.InitCodeHash)
+					hex.Encode(hexAddr[:], address[:])
+					if !match(string(hexAddr[:])) {
+						continue
+					}
+
+					attempts[idx].Add(tried)
+					tried = 0
+					mu.Lock()
+					if !solved[idx].Load() {
+						solved[idx].Store(true)
+						results[idx] = CREATE2Result{
+							Name:         target.Name,
+							InitCodeHash: "0x" + hex.EncodeToString(target.InitCodeHash[:]),
+							Salt:         "0x" + hex.EncodeToString(salt[:]),
+							Address:      address.Hex(),
+							Attempts:     attempts[idx].Load(),
+						}
+						remaining.Add(-1)
+						if onFound != nil {
+							onFound(results[idx])
+						}
+					}
+					mu.Unlock()
+					break
+				}
+				attempts[idx].Add(tried)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if mineErr != nil {
+		return nil, mineErr
+	}
+	found := make([]CREATE2Result, 0, len(targets))
+	for i := range results {
+		if solved[i].Load() {
+			found = append(found, results[i])
+		}
+	}
+	if len(found) < len(targets) {
+		return found, ctx.Err()
+	}
+	return found, nil
+}
@@ -0,0 +1,93 @@
+package crypto
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestCREATE2Address(t *testing.T) {
+	// Examples from EIP-1014
+	tests := []struct {
+		deployer string
+		salt     string
+		initCode []byte
+		want     string
+	}{
+		{"0x0000000000000000000000000000000000000000", "0x00", []byte{0x00}, "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38"},
+		{"0xdeadbeef00000000000000000000000000000000", "0x00", []byte{0x00}, "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3"},
+		{"0x00000000000000000000000000000000deadbeef", "0xcafebabe", common.FromHex("0xdeadbeef"), "0x60f3f640a8508fC6a86d45DF051962668E1e8AC7"},
+	}
+	for _, tt := range tests {
+		var initCodeHash [32]byte
+		copy(initCodeHash[:], ethcrypto.Keccak256(tt.initCode))
+		salt := common.BytesToHash(common.FromHex(tt.salt))
+
+		got := CREATE2Address(common.HexToAddress(tt.deployer), salt, initCodeHash)
+		if got.Hex() != tt.want {
+			t.Errorf("CREATE2Address(%s, %s) = %s, want %s", tt.deployer, tt.salt, got.Hex(), tt.want)
+		}
+	}
+}
+
+func TestParseInitCodeHash(t *testing.T) {
+	if _, err := ParseInitCodeHash("0x" + strings.Repeat("ab", 32)); err != nil {
+		t.Errorf("valid hash rejected: %v", err)
+	}
+	for _, bad := range []string{"", "0x1234", strings.Repeat("zz", 32)} {
+		if _, err := ParseInitCodeHash(bad); err == nil {
+			t.Errorf("ParseInitCodeHash(%q) should fail", bad)
+		}
+	}
+}
+
+func TestMineCREATE2Batch(t *testing.T) {
+	deployer := common.HexToAddress(DefaultCREATE2Deployer)
+	targets := []CREATE2Target{{Name: "Token"}, {Name: "Vault"}, {Name: "Router"}}
+	for i := range targets {
+		copy(targets[i].InitCodeHash[:], ethcrypto.Keccak256([]byte(targets[i].Name)))
+	}
+
+	var found int
+	results, err := MineCREATE2Batch(context.Background(), deployer, targets, 4,
+		func(address string) bool { return strings.HasPrefix(address, "ab") },
+		func(CREATE2Result) { found++ })
+	if err != nil {
+		t.Fatalf("MineCREATE2Batch failed: %v", err)
+	}
+	if len(results) != len(targets) || found != len(targets) {
+		t.Fatalf("expected %d results and callbacks, got %d and %d", len(targets), len(results), found)
+	}
+
+	for i, result := range results {
+		if result.Name != targets[i].Name {
+			t.Errorf("result %d is %s, want %s", i, result.Name, targets[i].Name)
+		}
+		salt := common.HexToHash(result.Salt)
+		address := CREATE2Address(deployer, salt, targets[i].InitCodeHash)
+		if address.Hex() != result.Address {
+			t.Errorf("%s: salt produces %s, manifest says %s", result.Name, address.Hex(), result.Address)
+		}
+		if !strings.HasPrefix(strings.ToLower(result.Address), "0xab") {
+			t.Errorf("%s: address %s does not match the pattern", result.Name, result.Address)
+		}
+		if result.Attempts < 1 {
+			t.Errorf("%s: attempts = %d", result.Name, result.Attempts)
+		}
+	}
+}
+
+func TestMineCREATE2BatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	targets := []CREATE2Target{{Name: "Impossible"}}
+	results, err := MineCREATE2Batch(ctx, common.Address{}, targets, 2,
+		func(string) bool { return false }, nil)
+	if err == nil || len(results) != 0 {
+		t.Errorf("expected cancellation error and no results, got %v, %v", results, err)
+	}
+}
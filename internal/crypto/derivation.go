@@ -0,0 +1,102 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip32"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// PreviewMnemonic is the public BIP-39 test mnemonic that derivation previews use.
+// Anyone can derive its keys, so its addresses must never be funded.
+const PreviewMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// LedgerLegacyDerivationPath is the first address of Ledger's legacy (MEW) Ethereum layout
+const LedgerLegacyDerivationPath = "m/44'/60'/0'/0"
+
+// DerivationStep is one BIP-32 level of a derivation preview
+type DerivationStep struct {
+	Path      string
+	PublicKey string
+}
+
+// DerivationPreview shows how a mnemonic derives an Ethereum address on a path
+type DerivationPreview struct {
+	Mnemonic   string
+	Seed       string
+	Path       string
+	Steps      []DerivationStep
+	PrivateKey string
+	Address    string
+}
+
+// ParseDerivationPath parses a BIP-32 path such as m/44'/60'/0'/0/0 into child indexes
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: want m/<index>/...", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		number := strings.TrimRight(part, "'h")
+		index, err := strconv.ParseUint(number, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q: bad index %q", path, part)
+		}
+		child := uint32(index)
+		if hardened {
+			child += bip32.FirstHardenedChild
+		}
+		indexes = append(indexes, child)
+	}
+	return indexes, nil
+}
+
+// MustParseDerivationPath is ParseDerivationPath for constant paths
+func MustParseDerivationPath(path string) []uint32 {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		panic(err)
+	}
+	return indexes
+}
+
+// PreviewEthereumDerivation derives the Ethereum address of mnemonic on path,
+// recording the BIP-39 seed and the public key at every level
+func PreviewEthereumDerivation(mnemonic, path string) (*DerivationPreview, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, fmt.Errorf("invalid BIP-39 mnemonic")
+	}
+	seed := bip39.NewSeed(mnemonic, "")
+	key, err := bip32.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &DerivationPreview{Mnemonic: mnemonic, Seed: hex.EncodeToString(seed), Path: path}
+	levels := strings.Split(path, "/")
+	for i, index := range indexes {
+		if key, err = key.NewChildKey(index); err != nil {
+			return nil, err
+		}
+		current := strings.Join(levels[:i+2], "/")
+		preview.Steps = append(preview.Steps, DerivationStep{Path: current, PublicKey: hex.EncodeToString(key.PublicKey().Key)})
+	}
+
+	privateKey, err := ethcrypto.ToECDSA(key.Key)
+	if err != nil {
+		return nil, err
+	}
+	preview.PrivateKey = hex.EncodeToString(key.Key)
+	preview.Address = ethcrypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	return preview, nil
+}
@@ -0,0 +1,65 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip32"
+)
+
+func TestParseDerivationPath(t *testing.T) {
+	indexes, err := ParseDerivationPath("m/44'/60'/0'/0/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []uint32{bip32.FirstHardenedChild + 44, bip32.FirstHardenedChild + 60, bip32.FirstHardenedChild, 0, 7}
+	if len(indexes) != len(want) {
+		t.Fatalf("ParseDerivationPath() = %v, want %v", indexes, want)
+	}
+	for i := range want {
+		if indexes[i] != want[i] {
+			t.Errorf("index %d = %d, want %d", i, indexes[i], want[i])
+		}
+	}
+	if hardened, _ := ParseDerivationPath("m/44h/60h"); len(hardened) != 2 || hardened[1] != bip32.FirstHardenedChild+60 {
+		t.Errorf("h suffix should mark hardened indexes, got %v", hardened)
+	}
+
+	for _, path := range []string{"", "m", "44'/60'", "m/x", "m/2147483648", "m/-1"} {
+		if _, err := ParseDerivationPath(path); err == nil {
+			t.Errorf("ParseDerivationPath(%q) should fail", path)
+		}
+	}
+}
+
+func TestPreviewEthereumDerivation(t *testing.T) {
+	preview, err := PreviewEthereumDerivation(PreviewMnemonic, EthereumDerivationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// BIP-39 test vector seed and the widely published address of the test mnemonic
+	if !strings.HasPrefix(preview.Seed, "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc1") {
+		t.Errorf("Seed = %s", preview.Seed)
+	}
+	if preview.Address != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("Address = %s", preview.Address)
+	}
+	if len(preview.Steps) != 5 || preview.Steps[0].Path != "m/44'" || preview.Steps[4].Path != EthereumDerivationPath {
+		t.Errorf("Steps = %+v", preview.Steps)
+	}
+	if address, _, _ := DeriveEthereumAccount(PreviewMnemonic); address != preview.Address {
+		t.Errorf("preview derives %s, account report %s", preview.Address, address)
+	}
+
+	legacy, err := PreviewEthereumDerivation(PreviewMnemonic, LedgerLegacyDerivationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if legacy.Address == preview.Address || len(legacy.Steps) != 4 {
+		t.Errorf("the Ledger legacy path should derive another address, got %s", legacy.Address)
+	}
+
+	if _, err := PreviewEthereumDerivation("not a mnemonic", EthereumDerivationPath); err == nil {
+		t.Error("expected an error for an invalid mnemonic")
+	}
+}
@@ -0,0 +1,256 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrEntropyFailure marks errors after which the entropy source must not be used again
+var ErrEntropyFailure = errors.New("entropy source failure")
+
+// Health test parameters (NIST SP 800-90B section 4.4). Samples are bytes assumed to
+// carry full entropy, and alpha = 2^-40 keeps false alarms negligible on long runs.
+const (
+	healthSampleEntropy  = 8.0
+	healthAlphaExponent  = 40
+	healthStartupSamples = 1024
+	healthAPTWindow      = 512
+)
+
+var (
+	rctCutoff = 1 + int(math.Ceil(healthAlphaExponent/healthSampleEntropy))
+	aptCutoff = 1 + critBinom(healthAPTWindow, math.Pow(2, -healthSampleEntropy), healthAlphaExponent)
+)
+
+// hybridEntropyInfo separates hybrid entropy output from any other use of the same inputs
+const hybridEntropyInfo = "bloco-eth hybrid entropy v1"
+
+// entropySource is the reader key material is drawn from, nil for crypto/rand
+type entropySource struct {
+	name   string
+	reader io.Reader
+}
+
+var currentEntropy atomic.Pointer[entropySource]
+
+// SetEntropySource makes r the source of private keys and mnemonics
+func SetEntropySource(name string, r io.Reader) {
+	currentEntropy.Store(&entropySource{name: name, reader: r})
+}
+
+// ResetEntropySource restores the operating system RNG as the entropy source
+func ResetEntropySource() {
+	currentEntropy.Store(nil)
+}
+
+// EntropySourceName describes the current entropy source
+func EntropySourceName() string {
+	if source := currentEntropy.Load(); source != nil {
+		return source.name
+	}
+	return "os"
+}
+
+// EntropyReader returns the current entropy source
+func EntropyReader() io.Reader {
+	if source := currentEntropy.Load(); source != nil {
+		return source.reader
+	}
+	return rand.Reader
+}
+
+// ReadEntropy fills buf from the current entropy source
+func ReadEntropy(buf []byte) error {
+	_, err := io.ReadFull(EntropyReader(), buf)
+	return err
+}
+
+// FileEntropySource reads entropy from a file or device such as /dev/hwrng. Bytes are
+// used once; the source fails when the file is exhausted.
+type FileEntropySource struct {
+	mu   sync.Mutex
+	file *os.File
+	path string
+}
+
+// NewFileEntropySource opens path as an entropy source
+func NewFileEntropySource(path string) (*FileEntropySource, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open entropy file %s: %w", path, err)
+	}
+	return &FileEntropySource{file: file, path: path}, nil
+}
+
+// Read fills p entirely or fails
+func (s *FileEntropySource) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := io.ReadFull(s.file, p)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return n, fmt.Errorf("%w: entropy file %s is exhausted", ErrEntropyFailure, s.path)
+	}
+	if err != nil {
+		return n, fmt.Errorf("%w: failed to read entropy file %s: %v", ErrEntropyFailure, s.path, err)
+	}
+	return n, nil
+}
+
+// Close closes the entropy file
+func (s *FileEntropySource) Close() error {
+	return s.file.Close()
+}
+
+// hybridEntropy mixes a base source with user-provided entropy
+type hybridEntropy struct {
+	base    io.Reader
+	userPRK []byte
+	counter atomic.Uint64
+}
+
+// NewHybridEntropySource mixes base (normally the OS RNG) with user-provided entropy.
+// Each read draws fresh base bytes and returns
+//
+//	HKDF-SHA256(ikm = base bytes, salt = HKDF-Extract(user entropy), info = label || counter)
+//
+// so the output stays unpredictable as long as either input is.
+func NewHybridEntropySource(base io.Reader, userEntropy []byte) (io.Reader, error) {
+	if len(userEntropy) == 0 {
+		return nil, fmt.Errorf("hybrid entropy requires user-provided entropy")
+	}
+	return &hybridEntropy{
+		base:    base,
+		userPRK: hkdf.Extract(sha256.New, userEntropy, []byte(hybridEntropyInfo)),
+	}, nil
+}
+
+// Read fills p with mixed entropy
+func (h *hybridEntropy) Read(p []byte) (int, error) {
+	const chunk = 32 * 255 // HKDF-SHA256 output limit
+	for done := 0; done < len(p); {
+		size := min(len(p)-done, chunk)
+		ikm := make([]byte, max(size, sha256.Size))
+		if _, err := io.ReadFull(h.base, ikm); err != nil {
+			return done, err
+		}
+		info := binary.BigEndian.AppendUint64([]byte(hybridEntropyInfo), h.counter.Add(1))
+		if _, err := io.ReadFull(hkdf.New(sha256.New, ikm, h.userPRK, info), p[done:done+size]); err != nil {
+			return done, fmt.Errorf("%w: hybrid entropy expansion failed: %v", ErrEntropyFailure, err)
+		}
+		clear(ikm)
+		done += size
+	}
+	return len(p), nil
+}
+
+// HealthTests runs the SP 800-90B repetition count and adaptive proportion tests on
+// a stream of byte samples
+type HealthTests struct {
+	rctValue byte
+	rctCount int
+	aptValue byte
+	aptCount int
+	aptSeen  int
+	started  bool
+}
+
+// Feed tests the next samples, failing at the first one that trips a test
+func (t *HealthTests) Feed(samples []byte) error {
+	for _, sample := range samples {
+		if !t.started || sample != t.rctValue {
+			t.rctValue, t.rctCount = sample, 1
+		} else if t.rctCount++; t.rctCount >= rctCutoff {
+			return fmt.Errorf("%w: repetition count test failed: byte 0x%02x repeated %d times", ErrEntropyFailure, sample, t.rctCount)
+		}
+
+		if !t.started || t.aptSeen == healthAPTWindow {
+			t.aptValue, t.aptCount, t.aptSeen = sample, 1, 1
+		} else {
+			t.aptSeen++
+			if sample == t.aptValue {
+				if t.aptCount++; t.aptCount >= aptCutoff {
+					return fmt.Errorf("%w: adaptive proportion test failed: byte 0x%02x seen %d times in %d samples",
+						ErrEntropyFailure, sample, t.aptCount, t.aptSeen)
+				}
+			}
+		}
+		t.started = true
+	}
+	return nil
+}
+
+// HealthCheckedSource runs the health tests continuously on a source that passed the
+// startup tests. After a failure every read fails.
+type HealthCheckedSource struct {
+	source io.Reader
+	mu     sync.Mutex
+	tests  HealthTests
+	err    error
+}
+
+// NewHealthCheckedSource runs the startup health tests on the first 1024 samples of
+// source, which are discarded, and returns the source with continuous tests
+func NewHealthCheckedSource(source io.Reader) (*HealthCheckedSource, error) {
+	s := &HealthCheckedSource{source: source}
+	startup := make([]byte, healthStartupSamples)
+	if _, err := s.Read(startup); err != nil {
+		return nil, fmt.Errorf("entropy startup health tests failed: %w", err)
+	}
+	clear(startup)
+	return s, nil
+}
+
+// Read fills p from the source and tests it. Only the tests hold the lock, so
+// concurrent workers do not wait on each other's reads.
+func (s *HealthCheckedSource) Read(p []byte) (int, error) {
+	_, readErr := io.ReadFull(s.source, p)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		clear(p)
+		return 0, s.err
+	}
+	if readErr != nil {
+		if !errors.Is(readErr, ErrEntropyFailure) {
+			readErr = fmt.Errorf("%w: %v", ErrEntropyFailure, readErr)
+		}
+		s.err = readErr
+		return 0, readErr
+	}
+	if err := s.tests.Feed(p); err != nil {
+		clear(p)
+		s.err = err
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// critBinom returns the smallest k with P(X > k) <= 2^-alphaExponent for X ~ B(n, p)
+func critBinom(n int, p float64, alphaExponent int) int {
+	alpha := math.Pow(2, -float64(alphaExponent))
+	logPMF := func(k int) float64 {
+		lgN, _ := math.Lgamma(float64(n + 1))
+		lgK, _ := math.Lgamma(float64(k + 1))
+		lgNK, _ := math.Lgamma(float64(n - k + 1))
+		return lgN - lgK - lgNK + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p)
+	}
+	tail := 0.0
+	for k := n; k > 0; k-- {
+		tail += math.Exp(logPMF(k))
+		if tail > alpha {
+			return k
+		}
+	}
+	return 0
+}
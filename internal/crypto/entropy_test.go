@@ -0,0 +1,183 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+func TestHealthTestCutoffs(t *testing.T) {
+	if rctCutoff != 6 {
+		t.Errorf("repetition count cutoff = %d, want 6", rctCutoff)
+	}
+	// SP 800-90B table 2: W = 512, H = 8, alpha = 2^-20
+	if got := 1 + critBinom(512, 1.0/256, 20); got != 13 {
+		t.Errorf("adaptive proportion cutoff at alpha 2^-20 = %d, want 13", got)
+	}
+	if aptCutoff <= 13 {
+		t.Errorf("adaptive proportion cutoff at alpha 2^-40 = %d, must exceed 13", aptCutoff)
+	}
+}
+
+func TestHealthTests(t *testing.T) {
+	random := make([]byte, 1<<16)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+	var tests HealthTests
+	if err := tests.Feed(random); err != nil {
+		t.Fatalf("random samples failed the health tests: %v", err)
+	}
+
+	var repetition HealthTests
+	if err := repetition.Feed(bytes.Repeat([]byte{0x42}, rctCutoff)); !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("expected the repetition count test to fail, got %v", err)
+	}
+
+	// Alternate a stuck value with a counter so no run is long enough for the RCT
+	biased := make([]byte, healthAPTWindow)
+	for i := range biased {
+		if i%2 == 0 {
+			biased[i] = 0x42
+		} else {
+			biased[i] = byte(i)
+		}
+	}
+	var proportion HealthTests
+	if err := proportion.Feed(biased); err == nil || !bytes.Contains([]byte(err.Error()), []byte("adaptive proportion")) {
+		t.Errorf("expected the adaptive proportion test to fail, got %v", err)
+	}
+}
+
+func TestHealthCheckedSource(t *testing.T) {
+	if _, err := NewHealthCheckedSource(bytes.NewReader(make([]byte, 4096))); !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("all-zero source passed the startup tests: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "entropy.bin")
+	data := make([]byte, healthStartupSamples+64)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	file, err := NewFileEntropySource(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	source, err := NewHealthCheckedSource(file)
+	if err != nil {
+		t.Fatalf("startup tests failed: %v", err)
+	}
+	buf := make([]byte, 64)
+	if _, err := source.Read(buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf, data[healthStartupSamples:]) {
+		t.Error("startup samples should be discarded and the rest returned in order")
+	}
+	if _, err := source.Read(buf); !errors.Is(err, ErrEntropyFailure) {
+		t.Errorf("expected an exhausted file to fail, got %v", err)
+	}
+	if _, err := source.Read(buf); !errors.Is(err, ErrEntropyFailure) {
+		t.Error("a failed source must keep failing")
+	}
+}
+
+func TestHybridEntropySource(t *testing.T) {
+	base := bytes.Repeat([]byte{7}, 1024)
+	read := func(user string) []byte {
+		source, err := NewHybridEntropySource(bytes.NewReader(base), []byte(user))
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make([]byte, 32)
+		if err := readFull(source, out); err != nil {
+			t.Fatal(err)
+		}
+		return out
+	}
+
+	first := read("4 2 6 1 3 5")
+	if bytes.Equal(first, base[:32]) {
+		t.Error("hybrid output must not be the raw base bytes")
+	}
+	if !bytes.Equal(first, read("4 2 6 1 3 5")) {
+		t.Error("the same inputs should give the same output")
+	}
+	if bytes.Equal(first, read("4 2 6 1 3 6")) {
+		t.Error("user entropy must change the output even when the base repeats")
+	}
+
+	source, _ := NewHybridEntropySource(bytes.NewReader(base), []byte("x"))
+	a, b := make([]byte, 32), make([]byte, 32)
+	_ = readFull(source, a)
+	_ = readFull(source, b)
+	if bytes.Equal(a, b) {
+		t.Error("successive reads from a repeating base must differ")
+	}
+
+	if _, err := NewHybridEntropySource(rand.Reader, nil); err == nil {
+		t.Error("expected an error without user entropy")
+	}
+}
+
+func TestSetEntropySource(t *testing.T) {
+	defer ResetEntropySource()
+	SetEntropySource("file:test", bytes.NewReader(bytes.Repeat([]byte{1}, 32)))
+	if EntropySourceName() != "file:test" {
+		t.Errorf("EntropySourceName() = %q", EntropySourceName())
+	}
+	key := make([]byte, 32)
+	if err := ReadEntropy(key); err != nil || key[0] != 1 {
+		t.Fatalf("ReadEntropy did not use the configured source: %v", err)
+	}
+	if err := ReadEntropy(key); err == nil {
+		t.Error("expected an error once the source is exhausted")
+	}
+}
+
+func readFull(r io.Reader, buf []byte) error {
+	_, err := io.ReadFull(r, buf)
+	return err
+}
+
+// TestHybridEntropyConstruction rebuilds the documented mixing step by step
+func TestHybridEntropyConstruction(t *testing.T) {
+	base := make([]byte, 64)
+	for i := range base {
+		base[i] = byte(i)
+	}
+	user := []byte("6 2 5 1 3 4 4 1")
+	source, err := NewHybridEntropySource(bytes.NewReader(base), user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	salt := hkdf.Extract(sha256.New, user, []byte("bloco-eth hybrid entropy v1"))
+	for counter, ikm := range [][]byte{base[:32], base[32:]} {
+		info := binary.BigEndian.AppendUint64([]byte("bloco-eth hybrid entropy v1"), uint64(counter+1))
+		want := make([]byte, 32)
+		if err := readFull(hkdf.New(sha256.New, ikm, salt, info), want); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, 32)
+		if err := readFull(source, got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("read %d = %x, want %x", counter+1, got, want)
+		}
+	}
+}
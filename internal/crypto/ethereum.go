@@ -1,7 +1,6 @@
 package crypto
 
 import (
-	"crypto/rand"
 	"encoding/hex"
 
 	"bloco-eth/pkg/errors"
@@ -31,7 +30,7 @@ func (eg *EthereumGenerator) GenerateWallet() (*wallet.Wallet, error) {
 	defer cryptoPool.PutPrivateKeyBuffer(privateKey)
 
 	// Generate 32 random bytes for private key
-	_, err := rand.Read(privateKey)
+	err := ReadEntropy(privateKey)
 	if err != nil {
 		return nil, errors.NewCryptoError("generate_wallet",
 			"failed to generate random private key", err)
@@ -66,7 +65,7 @@ func (eg *EthereumGenerator) GeneratePrivateKey() ([]byte, error) {
 	privateKey := cryptoPool.GetPrivateKeyBuffer()
 	defer cryptoPool.PutPrivateKeyBuffer(privateKey)
 
-	_, err := rand.Read(privateKey)
+	err := ReadEntropy(privateKey)
 	if err != nil {
 		return nil, errors.NewCryptoError("generate_private_key",
 			"failed to generate random bytes", err)
@@ -218,6 +218,11 @@ func (analyzer *KDFCompatibilityAnalyzer) analyzePBKDF2Security(kdfType string,
 	}
 }
 
+// ClientCompatibility reports, per Ethereum client, whether it accepts the KDF parameters
+func (analyzer *KDFCompatibilityAnalyzer) ClientCompatibility(kdfType string, params map[string]interface{}) map[string]bool {
+	return analyzer.analyzeClientCompatibility(analyzer.service.normalizeKDFName(kdfType), params)
+}
+
 // analyzeClientCompatibility checks compatibility with different Ethereum clients
 func (analyzer *KDFCompatibilityAnalyzer) analyzeClientCompatibility(kdfType string, params map[string]interface{}) map[string]bool {
 	switch kdfType {
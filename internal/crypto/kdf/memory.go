@@ -0,0 +1,160 @@
+package kdf
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MinNegotiatedScryptN is the lowest N a memory cap may step default scrypt
+// parameters down to; below it the derivation fails instead
+const MinNegotiatedScryptN = 16384
+
+// ScryptMemory returns the bytes a scrypt derivation with n, r and p allocates
+func ScryptMemory(n, r, p int) int64 {
+	return 128 * int64(r) * (int64(n) + int64(p))
+}
+
+// ScryptParamsMemory returns the bytes one derivation with params allocates,
+// taking the defaults for missing parameters
+func ScryptParamsMemory(params map[string]interface{}) int64 {
+	handler := &ScryptHandler{}
+	return ScryptMemory(handler.getIntParam(params, []string{"n", "N", "cost"}, 262144),
+		handler.getIntParam(params, []string{"r", "R", "blocksize"}, 8),
+		handler.getIntParam(params, []string{"p", "P", "parallel"}, 1))
+}
+
+// ParseMemoryLimit parses a --kdf-max-memory value: a percentage of the
+// available memory such as "50%", or a size such as "512MiB", "2GB" or "1048576"
+func ParseMemoryLimit(value string, available int64) (int64, error) {
+	value = strings.TrimSpace(value)
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		fraction, err := strconv.ParseFloat(percent, 64)
+		if err != nil || fraction <= 0 || fraction > 100 {
+			return 0, fmt.Errorf("invalid memory percentage %q: want more than 0%% and at most 100%%", value)
+		}
+		if available <= 0 {
+			return 0, nil
+		}
+		return int64(float64(available) * fraction / 100), nil
+	}
+
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+		{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12}, {"B", 1},
+	}
+	number, multiplier := value, 1.0
+	for _, unit := range units {
+		if trimmed, ok := strings.CutSuffix(strings.ToUpper(value), strings.ToUpper(unit.suffix)); ok {
+			number, multiplier = strings.TrimSpace(value[:len(trimmed)]), unit.multiplier
+			break
+		}
+	}
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil || size <= 0 || size*multiplier > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid memory size %q: use a percentage such as 50%% or a size such as 512MiB", value)
+	}
+	return int64(size * multiplier), nil
+}
+
+// FitScryptParams checks that one derivation with params stays within limit
+// bytes. Parameters that were explicitly requested are never weakened: they fail
+// with an error. Otherwise N is halved until it fits, down to
+// MinNegotiatedScryptN, and reduced reports the step down, which the caller
+// should warn about. A zero limit disables the check.
+func FitScryptParams(params map[string]interface{}, limit int64, explicit bool) (fitted map[string]interface{}, reduced bool, err error) {
+	handler := &ScryptHandler{}
+	n := handler.getIntParam(params, []string{"n", "N", "cost"}, 262144)
+	r := handler.getIntParam(params, []string{"r", "R", "blocksize"}, 8)
+	p := handler.getIntParam(params, []string{"p", "P", "parallel"}, 1)
+	if limit <= 0 || ScryptMemory(n, r, p) <= limit {
+		return params, false, nil
+	}
+	if explicit {
+		return nil, false, fmt.Errorf("scrypt n=%d r=%d needs %s per keystore, more than the %s memory cap; lower n in --kdf-params or raise --kdf-max-memory",
+			n, r, FormatMemory(ScryptMemory(n, r, p)), FormatMemory(limit))
+	}
+
+	for n > MinNegotiatedScryptN && ScryptMemory(n, r, p) > limit {
+		n /= 2
+	}
+	if ScryptMemory(n, r, p) > limit {
+		return nil, false, fmt.Errorf("scrypt needs at least %s per keystore (n=%d r=%d), more than the %s memory cap; raise --kdf-max-memory or use --keystore-kdf pbkdf2",
+			FormatMemory(ScryptMemory(n, r, p)), n, r, FormatMemory(limit))
+	}
+	fitted = make(map[string]interface{}, len(params))
+	for key, value := range params {
+		fitted[key] = value
+	}
+	delete(fitted, "N")
+	delete(fitted, "cost")
+	fitted["n"] = n
+	return fitted, true, nil
+}
+
+// MemoryBudget bounds the memory used by concurrent scrypt derivations: each one
+// waits until its memory fits alongside the derivations already running
+type MemoryBudget struct {
+	limit int64
+	mu    sync.Mutex
+	freed *sync.Cond
+	used  int64
+}
+
+// NewMemoryBudget returns a budget of limit bytes; zero means unlimited
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	b := &MemoryBudget{limit: limit}
+	b.freed = sync.NewCond(&b.mu)
+	return b
+}
+
+// Limit returns the budget in bytes, zero when unlimited
+func (b *MemoryBudget) Limit() int64 {
+	return b.limit
+}
+
+// Acquire reserves bytes, waiting for running derivations to release theirs,
+// and returns the function releasing them. A request larger than the whole
+// budget could never run and fails immediately.
+func (b *MemoryBudget) Acquire(bytes int64) (func(), error) {
+	if b == nil || b.limit <= 0 {
+		return func() {}, nil
+	}
+	if bytes > b.limit {
+		return nil, fmt.Errorf("scrypt derivation needs %s, more than the %s memory cap (--kdf-max-memory)",
+			FormatMemory(bytes), FormatMemory(b.limit))
+	}
+	b.mu.Lock()
+	for b.used+bytes > b.limit {
+		b.freed.Wait()
+	}
+	b.used += bytes
+	b.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			b.used -= bytes
+			b.mu.Unlock()
+			b.freed.Broadcast()
+		})
+	}, nil
+}
+
+// FormatMemory renders a byte count in binary units
+func FormatMemory(bytes int64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1f GiB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.0f MiB", float64(bytes)/(1<<20))
+	default:
+		return fmt.Sprintf("%d KiB", bytes>>10)
+	}
+}
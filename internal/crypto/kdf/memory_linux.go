@@ -0,0 +1,96 @@
+package kdf
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AvailableMemory returns the memory this process can still allocate: the
+// kernel's MemAvailable, lowered to the room left under a cgroup limit when the
+// process runs in a container. Zero means it could not be determined.
+func AvailableMemory() int64 {
+	available := memInfoAvailable()
+	if room := cgroupRoom(); room > 0 && (available == 0 || room < available) {
+		available = room
+	}
+	return available
+}
+
+// TotalMemory returns the most memory this process may use, and whether that is
+// the physical memory ("system") or a container's cgroup limit ("cgroup"). Zero
+// means it could not be determined.
+func TotalMemory() (int64, string) {
+	total := memInfo("MemTotal:")
+	if limit := cgroupLimit(); limit > 0 && (total == 0 || limit < total) {
+		return limit, "cgroup"
+	}
+	return total, "system"
+}
+
+// memInfoAvailable reads MemAvailable from /proc/meminfo
+func memInfoAvailable() int64 {
+	return memInfo("MemAvailable:")
+}
+
+// memInfo reads a field of /proc/meminfo in bytes
+func memInfo(field string) int64 {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == field {
+			kib, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil {
+				return 0
+			}
+			return kib << 10
+		}
+	}
+	return 0
+}
+
+// cgroupFiles are the limit and usage files of cgroup v2 and v1
+var cgroupFiles = [][2]string{
+	{"/sys/fs/cgroup/memory.max", "/sys/fs/cgroup/memory.current"},
+	{"/sys/fs/cgroup/memory/memory.limit_in_bytes", "/sys/fs/cgroup/memory/memory.usage_in_bytes"},
+}
+
+// cgroupRoom returns the cgroup v2 or v1 memory limit minus current usage
+func cgroupRoom() int64 {
+	for _, files := range cgroupFiles {
+		limit, ok := readBytesFile(files[0])
+		// v1 reports "no limit" as a huge page-aligned number
+		if !ok || limit >= 1<<60 {
+			continue
+		}
+		usage, _ := readBytesFile(files[1])
+		return max(limit-usage, 1)
+	}
+	return 0
+}
+
+// cgroupLimit returns the cgroup v2 or v1 memory limit, or zero without one
+func cgroupLimit() int64 {
+	for _, files := range cgroupFiles {
+		if limit, ok := readBytesFile(files[0]); ok && limit < 1<<60 {
+			return limit
+		}
+	}
+	return 0
+}
+
+// readBytesFile reads a cgroup file holding a byte count ("max" means unlimited)
+func readBytesFile(path string) (int64, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	return value, err == nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package kdf
+
+// AvailableMemory returns zero, as available memory is only detected on Linux;
+// percentage caps are then skipped and only absolute --kdf-max-memory sizes apply
+func AvailableMemory() int64 {
+	return 0
+}
+
+// TotalMemory returns zero, as the memory ceiling is only detected on Linux
+func TotalMemory() (int64, string) {
+	return 0, "system"
+}
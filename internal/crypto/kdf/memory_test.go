@@ -0,0 +1,112 @@
+package kdf
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMemoryLimit(t *testing.T) {
+	tests := []struct {
+		value     string
+		available int64
+		want      int64
+		wantErr   bool
+	}{
+		{"50%", 1 << 30, 512 << 20, false},
+		{"100%", 1 << 30, 1 << 30, false},
+		{"50%", 0, 0, false},
+		{"512MiB", 0, 512 << 20, false},
+		{"2GB", 0, 2e9, false},
+		{"1.5 GiB", 0, 3 << 29, false},
+		{"64kib", 0, 64 << 10, false},
+		{"1048576", 0, 1 << 20, false},
+		{"0%", 1 << 30, 0, true},
+		{"150%", 1 << 30, 0, true},
+		{"lots", 0, 0, true},
+		{"-1MiB", 0, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMemoryLimit(tt.value, tt.available)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMemoryLimit(%q, %d) = %d, %v; want %d, error %v", tt.value, tt.available, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestScryptParamsMemory(t *testing.T) {
+	if got, want := ScryptParamsMemory(map[string]interface{}{"n": 16384, "r": 8, "p": 1}), ScryptMemory(16384, 8, 1); got != want {
+		t.Errorf("ScryptParamsMemory() = %d, want %d", got, want)
+	}
+	// Missing parameters take the defaults, 256 MiB for n=262144 r=8
+	if got := ScryptParamsMemory(nil); got != ScryptMemory(262144, 8, 1) {
+		t.Errorf("ScryptParamsMemory(nil) = %d", got)
+	}
+}
+
+func TestFitScryptParams(t *testing.T) {
+	defaults := map[string]interface{}{"n": 262144, "r": 8, "p": 1, "dklen": 32}
+
+	fitted, reduced, err := FitScryptParams(defaults, 512<<20, false)
+	if err != nil || reduced || fitted["n"] != 262144 {
+		t.Errorf("fits: n=%v reduced=%v err=%v", fitted["n"], reduced, err)
+	}
+
+	fitted, reduced, err = FitScryptParams(defaults, 100<<20, false)
+	if err != nil || !reduced || fitted["n"] != 65536 || fitted["dklen"] != 32 {
+		t.Errorf("step down: %v reduced=%v err=%v", fitted, reduced, err)
+	}
+	if defaults["n"] != 262144 {
+		t.Error("step down modified the caller's parameters")
+	}
+
+	if _, _, err := FitScryptParams(defaults, 100<<20, true); err == nil || !strings.Contains(err.Error(), "--kdf-params") {
+		t.Errorf("explicit: err = %v, want a --kdf-params error", err)
+	}
+	if _, _, err := FitScryptParams(defaults, 8<<20, false); err == nil || !strings.Contains(err.Error(), "pbkdf2") {
+		t.Errorf("below floor: err = %v, want an error", err)
+	}
+	if _, reduced, err := FitScryptParams(defaults, 0, true); err != nil || reduced {
+		t.Errorf("unlimited: reduced=%v err=%v", reduced, err)
+	}
+}
+
+func TestMemoryBudget(t *testing.T) {
+	budget := NewMemoryBudget(100)
+	release, err := budget.Acquire(60)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		second, err := budget.Acquire(60)
+		if err != nil {
+			t.Error(err)
+		} else {
+			second()
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second derivation ran over the budget")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release()
+	release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second derivation did not run after the first released")
+	}
+
+	if _, err := budget.Acquire(101); err == nil {
+		t.Error("request larger than the budget was accepted")
+	}
+	var unlimited *MemoryBudget
+	if _, err := unlimited.Acquire(1 << 40); err != nil {
+		t.Errorf("nil budget: %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// Private key output formats for --key-format
+const (
+	KeyFormatHex    = "hex"    // lowercase hex without a prefix
+	KeyFormatHex0x  = "hex0x"  // lowercase hex with a 0x prefix
+	KeyFormatWIF    = "wif"    // Bitcoin mainnet wallet import format, compressed
+	KeyFormatBase64 = "base64" // standard base64 of the raw key bytes
+)
+
+// KeyFormats lists the supported private key formats
+var KeyFormats = []string{KeyFormatHex, KeyFormatHex0x, KeyFormatWIF, KeyFormatBase64}
+
+// ValidateKeyFormat checks that format is supported for keys of network
+func ValidateKeyFormat(format, network string) error {
+	switch format {
+	case KeyFormatHex, KeyFormatHex0x, KeyFormatBase64:
+		return nil
+	case KeyFormatWIF:
+		if strings.EqualFold(network, "solana") {
+			return fmt.Errorf("wif key format is only defined for secp256k1 keys, not %s", network)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown key format %q (supported: %s)", format, strings.Join(KeyFormats, ", "))
+}
+
+// FormatPrivateKey encodes a raw private key in format. WIF requires a 32-byte secp256k1 key.
+func FormatPrivateKey(key []byte, format string) (string, error) {
+	switch format {
+	case KeyFormatHex, "":
+		return hex.EncodeToString(key), nil
+	case KeyFormatHex0x:
+		return "0x" + hex.EncodeToString(key), nil
+	case KeyFormatBase64:
+		return base64.StdEncoding.EncodeToString(key), nil
+	case KeyFormatWIF:
+		if len(key) != btcec.PrivKeyBytesLen {
+			return "", fmt.Errorf("wif key format needs a %d-byte secp256k1 key, got %d bytes", btcec.PrivKeyBytesLen, len(key))
+		}
+		privateKey, _ := btcec.PrivKeyFromBytes(key)
+		wif, err := btcutil.NewWIF(privateKey, &chaincfg.MainNetParams, true)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode wif key: %w", err)
+		}
+		return wif.String(), nil
+	}
+	return "", fmt.Errorf("unknown key format %q (supported: %s)", format, strings.Join(KeyFormats, ", "))
+}
+
+// FormatPrivateKeyHex re-encodes a hex private key, with or without 0x, in format
+func FormatPrivateKeyHex(hexKey, format string) (string, error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(hexKey, "0x"), "0X"))
+	if err != nil {
+		return "", fmt.Errorf("invalid hex private key: %w", err)
+	}
+	defer ClearSensitiveData(key)
+	return FormatPrivateKey(key, format)
+}
+
+// PublicKeys derives the public key encodings of a hex private key. secp256k1 keys
+// (Ethereum, Bitcoin) give the 65-byte uncompressed and 33-byte compressed SEC1 forms;
+// Ed25519 keys (Solana) have a single 32-byte form, returned as uncompressed.
+func PublicKeys(privateKeyHex, network string) (uncompressed, compressed string, err error) {
+	key, err := hex.DecodeString(strings.TrimPrefix(privateKeyHex, "0x"))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid hex private key: %w", err)
+	}
+	defer ClearSensitiveData(key)
+
+	if strings.EqualFold(network, "solana") {
+		if len(key) != ed25519.PrivateKeySize {
+			return "", "", fmt.Errorf("solana private key must be %d bytes, got %d", ed25519.PrivateKeySize, len(key))
+		}
+		public := ed25519.PrivateKey(key).Public().(ed25519.PublicKey)
+		return hex.EncodeToString(public), "", nil
+	}
+	if len(key) != btcec.PrivKeyBytesLen {
+		return "", "", fmt.Errorf("secp256k1 private key must be %d bytes, got %d", btcec.PrivKeyBytesLen, len(key))
+	}
+	_, public := btcec.PrivKeyFromBytes(key)
+	return hex.EncodeToString(public.SerializeUncompressed()), hex.EncodeToString(public.SerializeCompressed()), nil
+}
@@ -0,0 +1,97 @@
+package crypto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFormatPrivateKey(t *testing.T) {
+	// Private key 1: the WIF encodings are the well-known vectors for it
+	key := make([]byte, 32)
+	key[31] = 1
+	hexKey := strings.Repeat("0", 63) + "1"
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{KeyFormatHex, hexKey},
+		{KeyFormatHex0x, "0x" + hexKey},
+		{KeyFormatWIF, "KwDiBf89QgGbjEhKnhXJuH7LrciVrZi3qYjgd9M7rFU73sVHnoWn"},
+		{KeyFormatBase64, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAE="},
+	}
+	for _, tt := range tests {
+		got, err := FormatPrivateKey(key, tt.format)
+		if err != nil {
+			t.Fatalf("FormatPrivateKey(%s) error: %v", tt.format, err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatPrivateKey(%s) = %s, want %s", tt.format, got, tt.want)
+		}
+		fromHex, err := FormatPrivateKeyHex("0x"+hexKey, tt.format)
+		if err != nil || fromHex != tt.want {
+			t.Errorf("FormatPrivateKeyHex(%s) = %s, %v", tt.format, fromHex, err)
+		}
+	}
+
+	if !bytes.Equal(key[:31], make([]byte, 31)) {
+		t.Error("FormatPrivateKey modified its input")
+	}
+}
+
+func TestFormatPrivateKey_Rejects(t *testing.T) {
+	if _, err := FormatPrivateKey(make([]byte, 64), KeyFormatWIF); err == nil {
+		t.Error("expected WIF to reject a 64-byte Solana key")
+	}
+	if _, err := FormatPrivateKey(make([]byte, 32), "pem"); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+	if _, err := FormatPrivateKeyHex("zz", KeyFormatHex); err == nil {
+		t.Error("expected invalid hex to be rejected")
+	}
+}
+
+func TestValidateKeyFormat(t *testing.T) {
+	for _, format := range KeyFormats {
+		if err := ValidateKeyFormat(format, "ethereum"); err != nil {
+			t.Errorf("ValidateKeyFormat(%s, ethereum) = %v", format, err)
+		}
+	}
+	if err := ValidateKeyFormat(KeyFormatWIF, "solana"); err == nil {
+		t.Error("expected wif to be rejected for solana")
+	}
+	if err := ValidateKeyFormat(KeyFormatBase64, "solana"); err != nil {
+		t.Errorf("ValidateKeyFormat(base64, solana) = %v", err)
+	}
+	if err := ValidateKeyFormat("raw", "ethereum"); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+}
+
+func TestPublicKeys(t *testing.T) {
+	// Private key 1 has the generator point G as its public key
+	const gx = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+	const gy = "483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+	uncompressed, compressed, err := PublicKeys(strings.Repeat("0", 63)+"1", "bitcoin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uncompressed != "04"+gx+gy || compressed != "02"+gx {
+		t.Errorf("PublicKeys() = %s, %s", uncompressed, compressed)
+	}
+
+	g := NewSolanaGenerator(nil)
+	w, err := g.GenerateWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	uncompressed, compressed, err = PublicKeys(w.PrivateKey, "solana")
+	if err != nil || compressed != "" || uncompressed != w.PrivateKey[64:] {
+		t.Errorf("PublicKeys(solana) = %s, %q, %v", uncompressed, compressed, err)
+	}
+
+	if _, _, err := PublicKeys(w.PrivateKey, "ethereum"); err == nil {
+		t.Error("expected a 64-byte key to be rejected for ethereum")
+	}
+}
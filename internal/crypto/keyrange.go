@@ -0,0 +1,331 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrKeyRangeExhausted is returned once every key of a --key-range has been checked
+var ErrKeyRangeExhausted = errors.New("key range exhausted")
+
+// KeyRange is an inclusive range of secp256k1 private key scalars. With a stride s
+// the keys searched are Start, Start+s, Start+2s, ... up to End.
+type KeyRange struct {
+	Start  *big.Int
+	End    *big.Int
+	Stride *big.Int
+}
+
+// ParseKeyRange parses a "start:end" range of hex scalars (0x optional) searched with stride
+func ParseKeyRange(spec string, stride uint64) (*KeyRange, error) {
+	startHex, endHex, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("key range %q must be start:end", spec)
+	}
+	start, err := parseScalarHex(startHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key range start: %w", err)
+	}
+	end, err := parseScalarHex(endHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key range end: %w", err)
+	}
+	if start.Cmp(end) > 0 {
+		return nil, fmt.Errorf("key range start %#x is above its end %#x", start, end)
+	}
+	if stride == 0 {
+		return nil, fmt.Errorf("key range stride must be at least 1")
+	}
+	return &KeyRange{Start: start, End: end, Stride: new(big.Int).SetUint64(stride)}, nil
+}
+
+// SeededKeyRange derives a keyspace of keys scalars, stride apart, from seed. The
+// SHA-256 hash of the seed picks the first key, so a seed always gives the same keyspace.
+func SeededKeyRange(seed []byte, keys, stride uint64) (*KeyRange, error) {
+	if keys == 0 || stride == 0 {
+		return nil, fmt.Errorf("a keyspace needs at least one key and a stride of at least 1")
+	}
+	span := new(big.Int).Mul(new(big.Int).SetUint64(keys-1), new(big.Int).SetUint64(stride))
+	room := new(big.Int).Sub(btcec.S256().N, big.NewInt(1))
+	room.Sub(room, span)
+	if room.Sign() <= 0 {
+		return nil, fmt.Errorf("%d keys with stride %d do not fit in the secp256k1 key space", keys, stride)
+	}
+	hash := sha256.Sum256(seed)
+	start := new(big.Int).SetBytes(hash[:])
+	start.Mod(start, room).Add(start, big.NewInt(1))
+	return &KeyRange{Start: start, End: new(big.Int).Add(start, span), Stride: new(big.Int).SetUint64(stride)}, nil
+}
+
+// parseScalarHex parses a hex private key scalar in [1, n-1]
+func parseScalarHex(s string) (*big.Int, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(s), "0x"), "0X")
+	value, ok := new(big.Int).SetString(s, 16)
+	if s == "" || !ok {
+		return nil, fmt.Errorf("%q is not a hex number", s)
+	}
+	if value.Sign() <= 0 || value.Cmp(btcec.S256().N) >= 0 {
+		return nil, fmt.Errorf("%#x is not a valid secp256k1 private key", value)
+	}
+	return value, nil
+}
+
+// Size returns the number of keys in the range
+func (r *KeyRange) Size() *big.Int {
+	size := new(big.Int).Sub(r.End, r.Start)
+	size.Quo(size, r.Stride)
+	return size.Add(size, big.NewInt(1))
+}
+
+// KeyAt returns the key at offset, Start + offset*Stride
+func (r *KeyRange) KeyAt(offset *big.Int) *big.Int {
+	key := new(big.Int).Mul(offset, r.Stride)
+	return key.Add(key, r.Start)
+}
+
+// String formats the range as start:end
+func (r *KeyRange) String() string {
+	return fmt.Sprintf("%#x:%#x", r.Start, r.End)
+}
+
+// KeyBlock is a run of consecutive range offsets claimed by one worker
+type KeyBlock struct {
+	Offset *big.Int
+	Count  uint64
+}
+
+// KeyRangeCursor hands out a KeyRange to workers in blocks and keeps track of the
+// keys checked. Blocks left unfinished, because another worker found a match or
+// the run stopped, are handed out again first.
+type KeyRangeCursor struct {
+	r    *KeyRange
+	size *big.Int
+
+	mu       sync.Mutex
+	next     *big.Int // first offset never handed out
+	returned []KeyBlock
+	active   map[*KeyBlock]struct{}
+	checked  *big.Int
+}
+
+// NewKeyRangeCursor starts a cursor at the beginning of r
+func NewKeyRangeCursor(r *KeyRange) *KeyRangeCursor {
+	return &KeyRangeCursor{
+		r:       r,
+		size:    r.Size(),
+		next:    new(big.Int),
+		active:  make(map[*KeyBlock]struct{}),
+		checked: new(big.Int),
+	}
+}
+
+// Range returns the range the cursor walks
+func (c *KeyRangeCursor) Range() *KeyRange {
+	return c.r
+}
+
+// Claim hands out up to limit unchecked offsets, or false once none are left
+func (c *KeyRangeCursor) Claim(limit uint64) (*KeyBlock, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var block KeyBlock
+	switch {
+	case len(c.returned) > 0:
+		block = c.returned[len(c.returned)-1]
+		c.returned = c.returned[:len(c.returned)-1]
+		if block.Count > limit {
+			rest := KeyBlock{Offset: new(big.Int).Add(block.Offset, new(big.Int).SetUint64(limit)), Count: block.Count - limit}
+			c.returned = append(c.returned, rest)
+			block.Count = limit
+		}
+	case c.next.Cmp(c.size) < 0:
+		left := new(big.Int).Sub(c.size, c.next)
+		count := limit
+		if left.IsUint64() && left.Uint64() < limit {
+			count = left.Uint64()
+		}
+		block = KeyBlock{Offset: new(big.Int).Set(c.next), Count: count}
+		c.next.Add(c.next, new(big.Int).SetUint64(count))
+	default:
+		return nil, false
+	}
+	c.active[&block] = struct{}{}
+	return &block, true
+}
+
+// Release returns a claimed block after its first checked offsets were searched;
+// the rest is handed out again
+func (c *KeyRangeCursor) Release(block *KeyBlock, checked uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.active, block)
+	c.checked.Add(c.checked, new(big.Int).SetUint64(checked))
+	if checked < block.Count {
+		c.returned = append(c.returned, KeyBlock{
+			Offset: new(big.Int).Add(block.Offset, new(big.Int).SetUint64(checked)),
+			Count:  block.Count - checked,
+		})
+	}
+}
+
+// Checked returns how many keys have been searched
+func (c *KeyRangeCursor) Checked() *big.Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return new(big.Int).Set(c.checked)
+}
+
+// Coverage returns the searched fraction of the range in percent
+func (c *KeyRangeCursor) Coverage() float64 {
+	percent, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(new(big.Int).Mul(c.Checked(), big.NewInt(100))),
+		new(big.Float).SetInt(c.size)).Float64()
+	return percent
+}
+
+// Exhausted reports whether every key of the range has been searched
+func (c *KeyRangeCursor) Exhausted() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.next.Cmp(c.size) >= 0 && len(c.returned) == 0 && len(c.active) == 0
+}
+
+// KeyRangeState is the resumable state of a cursor, stored in checkpoints. Offsets
+// below Next that are not in Pending have been searched.
+type KeyRangeState struct {
+	Start   string             `json:"start"`
+	End     string             `json:"end"`
+	Stride  string             `json:"stride"`
+	Next    string             `json:"next"`
+	Pending []KeyRangeInterval `json:"pending,omitempty"`
+	Checked string             `json:"checked"`
+}
+
+// KeyRangeInterval is a run of offsets still to search
+type KeyRangeInterval struct {
+	Offset string `json:"offset"`
+	Count  uint64 `json:"count"`
+}
+
+// State snapshots the cursor. Blocks being searched are recorded as pending, so a
+// resumed run repeats at most the blocks in flight.
+func (c *KeyRangeCursor) State() KeyRangeState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state := KeyRangeState{
+		Start:   fmt.Sprintf("%#x", c.r.Start),
+		End:     fmt.Sprintf("%#x", c.r.End),
+		Stride:  c.r.Stride.String(),
+		Next:    fmt.Sprintf("%#x", c.next),
+		Checked: c.checked.String(),
+	}
+	for _, block := range c.returned {
+		state.Pending = append(state.Pending, KeyRangeInterval{fmt.Sprintf("%#x", block.Offset), block.Count})
+	}
+	for block := range c.active {
+		state.Pending = append(state.Pending, KeyRangeInterval{fmt.Sprintf("%#x", block.Offset), block.Count})
+	}
+	return state
+}
+
+// Restore continues from a state saved by State for the same range and stride
+func (c *KeyRangeCursor) Restore(state KeyRangeState) error {
+	if state.Start != fmt.Sprintf("%#x", c.r.Start) || state.End != fmt.Sprintf("%#x", c.r.End) ||
+		state.Stride != c.r.Stride.String() {
+		return fmt.Errorf("checkpoint is for key range %s:%s with stride %s, not %s with stride %s",
+			state.Start, state.End, state.Stride, c.r, c.r.Stride)
+	}
+	next, ok := new(big.Int).SetString(state.Next, 0)
+	if !ok || next.Sign() < 0 || next.Cmp(c.size) > 0 {
+		return fmt.Errorf("checkpoint has an invalid key range position %q", state.Next)
+	}
+	checked, ok := new(big.Int).SetString(state.Checked, 10)
+	if !ok || checked.Sign() < 0 {
+		return fmt.Errorf("checkpoint has an invalid checked key count %q", state.Checked)
+	}
+	var returned []KeyBlock
+	for _, interval := range state.Pending {
+		offset, ok := new(big.Int).SetString(interval.Offset, 0)
+		if !ok || offset.Sign() < 0 || interval.Count == 0 ||
+			new(big.Int).Add(offset, new(big.Int).SetUint64(interval.Count)).Cmp(next) > 0 {
+			return fmt.Errorf("checkpoint has an invalid pending interval at %q", interval.Offset)
+		}
+		returned = append(returned, KeyBlock{Offset: offset, Count: interval.Count})
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.next, c.checked, c.returned = next, checked, returned
+	return nil
+}
+
+// KeyWalker steps through consecutive keys of a range. Each public key is derived
+// from the previous one with a single point addition, which is much cheaper than
+// a scalar multiplication per key.
+type KeyWalker struct {
+	key   btcec.ModNScalar
+	step  btcec.ModNScalar
+	point btcec.JacobianPoint // affine (Z = 1), so additions take the fastest path
+	stepG btcec.JacobianPoint
+}
+
+// NewKeyWalker starts a walk at key, advancing by stride
+func NewKeyWalker(key, stride *big.Int) *KeyWalker {
+	w := &KeyWalker{}
+	var buf [32]byte
+	w.key.SetByteSlice(key.FillBytes(buf[:]))
+	w.step.SetByteSlice(new(big.Int).Mod(stride, btcec.S256().N).FillBytes(buf[:]))
+	btcec.ScalarBaseMultNonConst(&w.key, &w.point)
+	btcec.ScalarBaseMultNonConst(&w.step, &w.stepG)
+	w.point.ToAffine()
+	w.stepG.ToAffine()
+	return w
+}
+
+// Next advances to the following key
+func (w *KeyWalker) Next() {
+	var sum btcec.JacobianPoint
+	w.key.Add(&w.step)
+	btcec.AddNonConst(&w.point, &w.stepG, &sum)
+	sum.ToAffine()
+	w.point = sum
+}
+
+// PrivateKey returns the current key as 32 big-endian bytes
+func (w *KeyWalker) PrivateKey() []byte {
+	key := w.key.Bytes()
+	return key[:]
+}
+
+// PublicKey returns the public key of the current key
+func (w *KeyWalker) PublicKey() *btcec.PublicKey {
+	return btcec.NewPublicKey(&w.point.X, &w.point.Y)
+}
+
+// AddressFromPublicKey returns the address of a secp256k1 public key: a lowercase
+// 0x-prefixed Ethereum address, or a compressed P2PKH Bitcoin address
+func AddressFromPublicKey(network string, publicKey *btcec.PublicKey) (string, error) {
+	switch strings.ToLower(network) {
+	case "", "ethereum":
+		hash := ethcrypto.Keccak256(publicKey.SerializeUncompressed()[1:])
+		return "0x" + hex.EncodeToString(hash[12:]), nil
+	case "bitcoin":
+		address, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(publicKey.SerializeCompressed()), &chaincfg.MainNetParams)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode bitcoin address: %w", err)
+		}
+		return address.EncodeAddress(), nil
+	}
+	return "", fmt.Errorf("%s keys are not secp256k1 scalars and cannot be searched by range", network)
+}
@@ -0,0 +1,183 @@
+package crypto
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+func TestParseKeyRange(t *testing.T) {
+	r, err := ParseKeyRange("0x80000:FFFFF", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.String() != "0x80000:0xfffff" || r.Size().Int64() != 174763 {
+		t.Errorf("ParseKeyRange() = %s with %s keys", r, r.Size())
+	}
+	if got := r.KeyAt(big.NewInt(2)).Int64(); got != 0x80006 {
+		t.Errorf("KeyAt(2) = %#x, want 0x80006", got)
+	}
+
+	n := btcec.S256().N
+	for _, spec := range []string{"", "0x10", "0:10", "0x20:0x10", "xyz:0x10", "1:" + n.Text(16)} {
+		if _, err := ParseKeyRange(spec, 1); err == nil {
+			t.Errorf("ParseKeyRange(%q) succeeded", spec)
+		}
+	}
+	if _, err := ParseKeyRange("1:2", 0); err == nil {
+		t.Error("expected a zero stride to be rejected")
+	}
+}
+
+func TestKeyWalker(t *testing.T) {
+	for _, stride := range []int64{1, 7} {
+		start := big.NewInt(0xd2c50)
+		walker := NewKeyWalker(start, big.NewInt(stride))
+		for i := int64(0); i < 20; i++ {
+			if i > 0 {
+				walker.Next()
+			}
+			want := new(big.Int).Add(start, big.NewInt(i*stride)).FillBytes(make([]byte, 32))
+			if !bytes.Equal(walker.PrivateKey(), want) {
+				t.Fatalf("stride %d step %d: key %x, want %x", stride, i, walker.PrivateKey(), want)
+			}
+			_, public := btcec.PrivKeyFromBytes(want)
+			if !walker.PublicKey().IsEqual(public) {
+				t.Fatalf("stride %d step %d: public key does not match the key", stride, i)
+			}
+		}
+	}
+
+	// Stepping onto the stride itself doubles the step point
+	walker := NewKeyWalker(big.NewInt(1), big.NewInt(1))
+	walker.Next()
+	_, two := btcec.PrivKeyFromBytes(big.NewInt(2).FillBytes(make([]byte, 32)))
+	if !walker.PublicKey().IsEqual(two) {
+		t.Error("walking from 1 to 2 gave the wrong public key")
+	}
+}
+
+func TestAddressFromPublicKey(t *testing.T) {
+	key := big.NewInt(0xd2c55).FillBytes(make([]byte, 32))
+	_, public := btcec.PrivKeyFromBytes(key)
+
+	address, err := AddressFromPublicKey("ethereum", public)
+	if err != nil || address != "0x5abfc823a8b0691ebada0a41cd6fab2f15a0a628" {
+		t.Errorf("ethereum address = %s, %v", address, err)
+	}
+	// Bitcoin puzzle #20 has this key
+	address, err = AddressFromPublicKey("bitcoin", public)
+	if err != nil || address != "1HsMJxNiV7TLxmoF6uJNkydxPFDog4NQum" {
+		t.Errorf("bitcoin address = %s, %v", address, err)
+	}
+	if _, err := AddressFromPublicKey("solana", public); err == nil {
+		t.Error("expected solana to be rejected")
+	}
+}
+
+func TestKeyRangeCursor(t *testing.T) {
+	r, err := ParseKeyRange("1:0x64", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewKeyRangeCursor(r)
+
+	first, _ := c.Claim(40)
+	second, _ := c.Claim(40)
+	c.Release(first, 10) // interrupted after 10 keys
+	c.Release(second, second.Count)
+
+	retried, ok := c.Claim(100)
+	if !ok || retried.Offset.Int64() != 10 || retried.Count != 30 {
+		t.Fatalf("Claim() after an interrupted block = %+v, want offset 10 count 30", retried)
+	}
+	c.Release(retried, retried.Count)
+	last, _ := c.Claim(100)
+	if last.Offset.Int64() != 80 || last.Count != 20 {
+		t.Fatalf("Claim() at the end of the range = %+v, want offset 80 count 20", last)
+	}
+	if c.Exhausted() {
+		t.Error("range exhausted while a block is still being searched")
+	}
+	c.Release(last, last.Count)
+
+	if _, ok := c.Claim(1); ok || !c.Exhausted() {
+		t.Error("expected the range to be exhausted")
+	}
+	if c.Checked().Int64() != 100 || c.Coverage() != 100 {
+		t.Errorf("Checked() = %s, Coverage() = %v", c.Checked(), c.Coverage())
+	}
+}
+
+func TestKeyRangeCursor_StateRestore(t *testing.T) {
+	r, _ := ParseKeyRange("1:0x3e8", 1)
+	c := NewKeyRangeCursor(r)
+	done, _ := c.Claim(100)
+	c.Release(done, done.Count)
+	partial, _ := c.Claim(100)
+	c.Release(partial, 25)
+	// Still being searched: the 75 keys left over from partial in two blocks, then keys 200-299
+	inFlight, _ := c.Claim(50)
+	c.Claim(100)
+	c.Claim(100)
+
+	state := c.State()
+	if len(state.Pending) != 3 || state.Checked != "125" || state.Next != "0x12c" {
+		t.Fatalf("State() = %+v", state)
+	}
+
+	resumed := NewKeyRangeCursor(r)
+	if err := resumed.Restore(state); err != nil {
+		t.Fatal(err)
+	}
+	searched := make(map[int64]bool)
+	for {
+		block, ok := resumed.Claim(1000)
+		if !ok {
+			break
+		}
+		for i := uint64(0); i < block.Count; i++ {
+			searched[block.Offset.Int64()+int64(i)] = true
+		}
+		resumed.Release(block, block.Count)
+	}
+	// Everything not searched before the snapshot, including the blocks in flight, is searched once
+	if len(searched) != 875 || searched[124] || !searched[inFlight.Offset.Int64()] || !searched[999] {
+		t.Errorf("resumed run searched %d offsets", len(searched))
+	}
+	if resumed.Checked().Int64() != 1000 {
+		t.Errorf("resumed Checked() = %s, want 1000", resumed.Checked())
+	}
+
+	other, _ := ParseKeyRange("1:0x3e8", 2)
+	if err := NewKeyRangeCursor(other).Restore(state); err == nil {
+		t.Error("expected a state for another stride to be rejected")
+	}
+	state.Next = "0x10000"
+	if err := NewKeyRangeCursor(r).Restore(state); err == nil {
+		t.Error("expected a position past the range to be rejected")
+	}
+}
+
+func TestSeededKeyRange(t *testing.T) {
+	r, err := SeededKeyRange([]byte("seed"), 1000, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := SeededKeyRange([]byte("seed"), 1000, 3)
+	other, _ := SeededKeyRange([]byte("other seed"), 1000, 3)
+	if r.String() != again.String() || r.String() == other.String() {
+		t.Errorf("seeded ranges %s, %s, %s", r, again, other)
+	}
+	if r.Size().Int64() != 1000 || r.Start.Sign() <= 0 || r.End.Cmp(btcec.S256().N) >= 0 {
+		t.Errorf("SeededKeyRange() = %s with %s keys", r, r.Size())
+	}
+	if _, err := SeededKeyRange([]byte("seed"), 0, 1); err == nil {
+		t.Error("expected an empty keyspace to be rejected")
+	}
+	if _, err := SeededKeyRange([]byte("seed"), 2, 1<<63); err != nil {
+		t.Errorf("a wide stride that fits was rejected: %v", err)
+	}
+}
@@ -2,12 +2,17 @@ package crypto
 
 import (
 	"bloco-eth/internal/crypto/kdf"
+	"bloco-eth/internal/faultfs"
+	"bloco-eth/internal/retry"
+	"bloco-eth/internal/tracing"
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -680,8 +685,8 @@ func (ks *KeyStoreV3) Validate() error {
 		return fmt.Errorf("ID cannot be empty")
 	}
 
-	if ks.Crypto.Cipher != "aes-128-ctr" {
-		return fmt.Errorf("unsupported cipher: %s", ks.Crypto.Cipher)
+	if err := ValidateKeystoreCipher(ks.Crypto.Cipher); err != nil {
+		return err
 	}
 
 	if ks.Crypto.KDF != "scrypt" && ks.Crypto.KDF != "pbkdf2" {
@@ -970,7 +975,11 @@ func (ks *KeyStoreService) EncryptPrivateKeyWithKDF(privateKeyHex string, passwo
 			"Failed to generate cryptographic salt. This might be due to insufficient system entropy.")
 	}
 
-	iv, err := GenerateRandomBytes(16)
+	cipherName := ks.config.Cipher
+	if err := ValidateKeystoreCipher(cipherName); err != nil {
+		return nil, NewKeyStoreError("encrypt", "cipher", err)
+	}
+	iv, err := GenerateRandomBytes(keystoreCiphers[cipherName].ivLen)
 	if err != nil {
 		return nil, NewRecoverableKeyStoreError("encrypt", "iv", err,
 			"Failed to generate initialization vector. This might be due to insufficient system entropy.")
@@ -985,8 +994,14 @@ func (ks *KeyStoreService) EncryptPrivateKeyWithKDF(privateKeyHex string, passwo
 		return nil, NewKeyStoreError("encrypt", "kdf_params", err)
 	}
 
-	// Add salt to parameters
+	// Requested parameters override the defaults; the salt is always fresh
+	for key, value := range ks.config.KDFParams {
+		defaultParams[key] = value
+	}
+
+	// Add salt to parameters, and derive enough key for the cipher and the MAC
 	defaultParams["salt"] = hex.EncodeToString(salt)
+	defaultParams["dklen"] = CipherDerivedKeyLength(cipherName)
 
 	// Create crypto parameters for KDF service
 	cryptoParams := &kdf.CryptoParams{
@@ -1018,8 +1033,25 @@ func (ks *KeyStoreService) EncryptPrivateKeyWithKDF(privateKeyHex string, passwo
 		ks.logger.LogInfo(fmt.Sprintf("KDF security level: %s", compatReport.SecurityLevel))
 	}
 
+	// Wait for room in the memory budget, so concurrent scrypt derivations cannot exhaust RAM
+	release := func() {}
+	if kdfType == "scrypt" {
+		scryptParams, err := ParseScryptParamsFromMap(defaultParams)
+		if err != nil {
+			return nil, NewKeyStoreError("validate", "kdf_params", err)
+		}
+		release, err = ks.config.MemoryBudget.Acquire(kdf.ScryptMemory(scryptParams.N, scryptParams.R, scryptParams.P))
+		if err != nil {
+			return nil, NewKeyStoreError("derive", "memory", err)
+		}
+	}
+
 	// Derive key using Universal KDF service
+	_, span := tracing.Start(ks.traceCtx, "kdf.derive", tracing.String("kdf.algorithm", kdfType))
 	derivedKey, err := ks.kdfService.DeriveKey(password, cryptoParams)
+	release()
+	span.RecordError(err)
+	span.End()
 	if err != nil {
 		if kdfErr, ok := err.(*kdf.KDFError); ok {
 			return nil, NewKDFKeyStoreError("derive", "key", kdfErr)
@@ -1027,23 +1059,10 @@ func (ks *KeyStoreService) EncryptPrivateKeyWithKDF(privateKeyHex string, passwo
 		return nil, NewKeyStoreError("derive", "key", err)
 	}
 
-	// Use first 16 bytes of derived key for AES encryption
-	if len(derivedKey) < 32 {
-		return nil, NewKeyStoreError("derive", "key",
-			fmt.Errorf("derived key too short: got %d bytes, need at least 32", len(derivedKey)))
-	}
-	encryptionKey := derivedKey[:16]
-
-	// Encrypt private key
-	ciphertext, err := EncryptAES128CTR(privateKeyBytes, encryptionKey, iv)
-	if err != nil {
-		return nil, NewKeyStoreError("encrypt", "aes", fmt.Errorf("AES encryption failed: %w", err))
-	}
-
-	// Generate MAC for integrity
-	mac, err := GenerateMAC(derivedKey, ciphertext)
+	// Encrypt private key with the start of the derived key and MAC it with the rest
+	ciphertext, mac, err := EncryptKeystoreCipher(cipherName, privateKeyBytes, derivedKey, iv)
 	if err != nil {
-		return nil, NewKeyStoreError("encrypt", "mac", fmt.Errorf("MAC generation failed: %w", err))
+		return nil, NewKeyStoreError("encrypt", "aes", fmt.Errorf("%s encryption failed: %w", cipherName, err))
 	}
 
 	// Derive Ethereum address from private key (placeholder - would need actual implementation)
@@ -1054,6 +1073,7 @@ func (ks *KeyStoreService) EncryptPrivateKeyWithKDF(privateKeyHex string, passwo
 	keystore := NewKeyStoreV3(address, "ethereum")
 
 	// Set cipher parameters
+	keystore.Crypto.Cipher = cipherName
 	keystore.SetCipherParams(iv, ciphertext)
 	keystore.SetMAC(mac)
 
@@ -1086,11 +1106,25 @@ func (ks *KeyStoreService) EncryptPrivateKeyWithKDF(privateKeyHex string, passwo
 type KeyStoreConfig struct {
 	OutputDirectory string
 	Enabled         bool
-	Cipher          string                 // "aes-128-ctr"
+	Cipher          string                 // one of KeystoreCiphers, default "aes-128-ctr"
 	KDF             string                 // "scrypt" or "pbkdf2"
 	KDFParams       map[string]interface{} // KDF-specific parameters
-	MaxRetries      int                    // Maximum number of retry attempts for recoverable errors
-	RetryDelay      int                    // Delay between retries in milliseconds
+	MaxRetries      int                    // Attempts for recoverable errors when Retry is unset
+	RetryDelay      int                    // First retry delay in milliseconds when Retry is unset
+	// Retry is the backoff policy of recoverable save errors
+	Retry retry.Policy
+	// PasswordProtection encrypts generated password files for a gpg or age recipient
+	PasswordProtection PasswordProtection
+	// PasswordPolicy generates the keystore passwords; the zero policy is 12 random characters
+	PasswordPolicy PasswordPolicy
+	// FilenameLabel is prepended to generated filenames as "<label>_<address>"
+	FilenameLabel string
+	// PathStem, when set, replaces "<label>_<address>" with a slash-separated path
+	// relative to OutputDirectory, such as "2026/cafe/0x<address>"; its directories
+	// are created as files are written
+	PathStem string
+	// MemoryBudget, shared by services deriving concurrently, bounds scrypt memory
+	MemoryBudget *kdf.MemoryBudget
 }
 
 // FileOperationError represents errors that occur during file operations
@@ -1148,6 +1182,7 @@ type KeyStoreService struct {
 	logger      ProgressLogger
 	kdfService  *kdf.UniversalKDFService
 	analyzer    *kdf.KDFCompatibilityAnalyzer
+	traceCtx    context.Context
 }
 
 // NewKeyStoreService creates a new keystore service with the given configuration
@@ -1168,6 +1203,14 @@ func NewKeyStoreService(config KeyStoreConfig) *KeyStoreService {
 	if config.RetryDelay == 0 {
 		config.RetryDelay = 100 // 100ms default delay
 	}
+	if config.Retry.Attempts == 0 {
+		config.Retry = retry.DefaultFilePolicy
+		config.Retry.Attempts = config.MaxRetries
+		config.Retry.InitialDelay = retry.Duration(time.Duration(config.RetryDelay) * time.Millisecond)
+		if config.Retry.MaxDelay < config.Retry.InitialDelay {
+			config.Retry.MaxDelay = config.Retry.InitialDelay
+		}
+	}
 
 	// Initialize Universal KDF service
 	kdfService := kdf.NewUniversalKDFService()
@@ -1181,6 +1224,7 @@ func NewKeyStoreService(config KeyStoreConfig) *KeyStoreService {
 		logger:      &DefaultProgressLogger{VerboseMode: false},
 		kdfService:  kdfService,
 		analyzer:    analyzer,
+		traceCtx:    context.Background(),
 	}
 }
 
@@ -1203,6 +1247,11 @@ func (ks *KeyStoreService) SetLogger(logger ProgressLogger) {
 	ks.logger = logger
 }
 
+// SetTraceContext parents the service's key derivation spans on the span in ctx
+func (ks *KeyStoreService) SetTraceContext(ctx context.Context) {
+	ks.traceCtx = ctx
+}
+
 // SetVerboseMode enables or disables verbose logging for the default logger
 func (ks *KeyStoreService) SetVerboseMode(verbose bool) {
 	if defaultLogger, ok := ks.logger.(*DefaultProgressLogger); ok {
@@ -1210,46 +1259,55 @@ func (ks *KeyStoreService) SetVerboseMode(verbose bool) {
 	}
 }
 
-// SaveKeyStoreFilesWithRetry saves keystore files with automatic retry for recoverable errors
+// SaveKeyStoreFilesWithRetry saves keystore files, retrying recoverable errors
+// under the configured retry policy
 func (ks *KeyStoreService) SaveKeyStoreFilesWithRetry(privateKeyHex, address, network string) error {
 	if !ks.config.Enabled {
 		ks.logger.LogDebug("Keystore generation is disabled, skipping file creation")
 		return nil
 	}
 
-	var lastErr error
-	for attempt := 1; attempt <= ks.config.MaxRetries; attempt++ {
-		ks.logger.LogDebug(fmt.Sprintf("Keystore save attempt %d/%d for address %s", attempt, ks.config.MaxRetries, address))
+	attempts := 0
+	err := retry.Do(context.Background(), ks.config.Retry, func() error {
+		attempts++
+		ks.logger.LogDebug(fmt.Sprintf("Keystore save attempt %d/%d for address %s", attempts, ks.config.Retry.Attempts, address))
+		return RetryableSave(ks.SaveKeyStoreFiles(privateKeyHex, address, network))
+	}, func(attempt int, err error, delay time.Duration) {
+		ks.logger.LogWarning(fmt.Sprintf("Recoverable error on attempt %d for address %s: %v. Retrying in %s...",
+			attempt, address, err, delay.Round(time.Millisecond)))
+	})
+	switch {
+	case err == nil && attempts > 1:
+		ks.logger.LogInfo(fmt.Sprintf("Keystore files saved successfully for address %s after %d attempts", address, attempts))
+	case err != nil && IsRecoverableError(err):
+		ks.logger.LogError(fmt.Sprintf("Max retries (%d) exceeded for address %s. Last error: %v",
+			ks.config.Retry.Attempts, address, err))
+	case err != nil:
+		ks.logger.LogError(fmt.Sprintf("Non-recoverable error for address %s: %v", address, err))
+	}
+	return err
+}
 
-		err := ks.SaveKeyStoreFiles(privateKeyHex, address, network)
-		if err == nil {
-			if attempt > 1 {
-				ks.logger.LogInfo(fmt.Sprintf("Keystore files saved successfully for address %s after %d attempts", address, attempt))
-			}
-			return nil
-		}
+// generatePassword returns a new password under the configured policy
+func (ks *KeyStoreService) generatePassword() (string, error) {
+	if ks.config.PasswordPolicy.Mode == "" {
+		return ks.passwordGen.GenerateSecurePassword()
+	}
+	return ks.config.PasswordPolicy.Generate()
+}
 
-		lastErr = err
+// IsRecoverableError reports whether err is a KeyStoreError that retrying may fix
+func IsRecoverableError(err error) bool {
+	var ksErr *KeyStoreError
+	return errors.As(err, &ksErr) && ksErr.IsRecoverable()
+}
 
-		// Check if error is recoverable
-		if ksErr, ok := err.(*KeyStoreError); ok && ksErr.IsRecoverable() {
-			if attempt < ks.config.MaxRetries {
-				ks.logger.LogWarning(fmt.Sprintf("Recoverable error on attempt %d for address %s: %v. Retrying in %dms...",
-					attempt, address, err, ks.config.RetryDelay))
-				time.Sleep(time.Duration(ks.config.RetryDelay) * time.Millisecond)
-				continue
-			} else {
-				ks.logger.LogError(fmt.Sprintf("Max retries (%d) exceeded for address %s. Last error: %v",
-					ks.config.MaxRetries, address, err))
-			}
-		} else {
-			// Non-recoverable error, don't retry
-			ks.logger.LogError(fmt.Sprintf("Non-recoverable error for address %s: %v", address, err))
-			break
-		}
+// RetryableSave marks err permanent for retry.Do unless it is recoverable
+func RetryableSave(err error) error {
+	if err == nil || IsRecoverableError(err) {
+		return err
 	}
-
-	return lastErr
+	return retry.Permanent(err)
 }
 
 // GenerateKeyStore creates a keystore for the given private key and address
@@ -1267,7 +1325,7 @@ func (ks *KeyStoreService) GenerateKeyStore(privateKeyHex, address, network stri
 	}
 
 	// Generate secure password
-	password, err := ks.passwordGen.GenerateSecurePassword()
+	password, err := ks.generatePassword()
 	if err != nil {
 		return nil, "", NewRecoverableKeyStoreError("generate", "password", err,
 			"Failed to generate secure password. This might be due to insufficient system entropy. Please try again.")
@@ -1388,9 +1446,9 @@ func validateAddressForNetwork(address, network string) error {
 	}
 }
 
-// formatAddressForFilename formats an address for use in filenames based on the network
+// FormatAddressForFilename formats an address for use in filenames based on the network
 // Ethereum addresses get "0x" prefix, Bitcoin and Solana addresses don't
-func formatAddressForFilename(address, network string) string {
+func FormatAddressForFilename(address, network string) string {
 	cleanAddress := strings.TrimPrefix(address, "0x")
 
 	// Only add 0x prefix for Ethereum
@@ -1402,6 +1460,19 @@ func formatAddressForFilename(address, network string) string {
 	return cleanAddress
 }
 
+// fileBase returns the filename of a wallet's files without extension, relative to
+// the output directory
+func (ks *KeyStoreService) fileBase(address, network string) string {
+	if ks.config.PathStem != "" {
+		return filepath.FromSlash(ks.config.PathStem)
+	}
+	base := FormatAddressForFilename(address, network)
+	if slug := LabelSlug(ks.config.FilenameLabel); slug != "" {
+		return slug + "_" + base
+	}
+	return base
+}
+
 // SaveKeyStoreFilesToDisk saves keystore and password files to disk
 // Network-specific behavior:
 // - Ethereum: saves KeyStore V3 JSON + password file
@@ -1459,11 +1530,11 @@ func (ks *KeyStoreService) saveEthereumKeyStore(address string, keystore *KeySto
 	}
 
 	// Format address with 0x prefix for Ethereum
-	formattedAddress := formatAddressForFilename(address, "ethereum")
+	formattedAddress := ks.fileBase(address, "ethereum")
 
 	// Get file paths
 	keystorePath := filepath.Join(ks.config.OutputDirectory, fmt.Sprintf("%s.json", formattedAddress))
-	passwordPath := filepath.Join(ks.config.OutputDirectory, fmt.Sprintf("%s.pwd", formattedAddress))
+	passwordPath := filepath.Join(ks.config.OutputDirectory, formattedAddress+ks.config.PasswordProtection.Suffix())
 
 	// Check if files already exist and warn (but don't fail)
 	if _, err := ks.FileExists(keystorePath); err != nil {
@@ -1482,30 +1553,34 @@ func (ks *KeyStoreService) saveEthereumKeyStore(address string, keystore *KeySto
 		return NewKeyStoreErrorWithAddress("serialize", "keystore", address, err)
 	}
 
+	// Encrypt the password for the configured recipient before anything is written
+	passwordData, err := ks.config.PasswordProtection.Wrap([]byte(password))
+	if err != nil {
+		return NewKeyStoreErrorWithAddress("encrypt", "password_file", address, err)
+	}
+
 	// Write keystore file atomically with secure permissions (600)
 	ks.logger.LogDebug(fmt.Sprintf("Writing keystore file: %s", keystorePath))
 	if err := ks.writeFileAtomic(keystorePath, keystoreJSON, 0600); err != nil {
 		ks.logger.LogError(fmt.Sprintf("Failed to write keystore file %s: %v", keystorePath, err))
-		return NewRecoverableKeyStoreError("save", "keystore_file", err,
-			fmt.Sprintf("Failed to save keystore file to '%s'. Please check disk space and permissions.", keystorePath))
+		return newWriteError("keystore_file", keystorePath, "keystore file", err)
 	}
 	ks.logger.LogDebug(fmt.Sprintf("Keystore file written successfully: %s", keystorePath))
 
 	// Write password file atomically with secure permissions (600)
 	ks.logger.LogDebug(fmt.Sprintf("Writing password file: %s", passwordPath))
-	if err := ks.writeFileAtomic(passwordPath, []byte(password), 0600); err != nil {
+	if err := ks.writeFileAtomic(passwordPath, passwordData, 0600); err != nil {
 		ks.logger.LogError(fmt.Sprintf("Failed to write password file %s: %v", passwordPath, err))
 		// If password file fails, try to clean up keystore file
 		ks.logger.LogDebug(fmt.Sprintf("Attempting to clean up keystore file: %s", keystorePath))
-		if removeErr := os.Remove(keystorePath); removeErr != nil {
+		if removeErr := faultfs.Remove(longPath(keystorePath)); removeErr != nil {
 			ks.logger.LogError(fmt.Sprintf("Failed to clean up keystore file %s: %v", keystorePath, removeErr))
 			// Return compound error with cleanup failure
 			return NewKeyStoreErrorWithPath("save", "password_file", passwordPath,
 				fmt.Errorf("failed to write password file and cleanup failed: write error: %w, cleanup error: %v", err, removeErr))
 		}
 		ks.logger.LogDebug(fmt.Sprintf("Keystore file cleaned up successfully: %s", keystorePath))
-		return NewRecoverableKeyStoreError("save", "password_file", err,
-			fmt.Sprintf("Failed to save password file to '%s'. Please check disk space and permissions.", passwordPath))
+		return newWriteError("password_file", passwordPath, "password file", err)
 	}
 	ks.logger.LogDebug(fmt.Sprintf("Password file written successfully: %s", passwordPath))
 
@@ -1529,7 +1604,7 @@ func (ks *KeyStoreService) saveSolanaKeypair(address string, keystore *KeyStoreV
 	}
 
 	// Format address without 0x prefix for Solana
-	formattedAddress := formatAddressForFilename(address, "solana")
+	formattedAddress := ks.fileBase(address, "solana")
 
 	// Get file path (no 0x prefix for Solana)
 	keypairPath := filepath.Join(ks.config.OutputDirectory, fmt.Sprintf("%s.json", formattedAddress))
@@ -1585,7 +1660,7 @@ func (ks *KeyStoreService) SaveMnemonicFile(address, mnemonic, network string) e
 	}
 
 	// Format address based on network (0x prefix only for Ethereum)
-	formattedAddress := formatAddressForFilename(address, network)
+	formattedAddress := ks.fileBase(address, network)
 
 	// Construct mnemonic file path
 	mnemonicPath := filepath.Join(ks.config.OutputDirectory, fmt.Sprintf("%s.mnemonic", formattedAddress))
@@ -1613,8 +1688,7 @@ func (ks *KeyStoreService) SaveMnemonicFile(address, mnemonic, network string) e
 	ks.logger.LogDebug(fmt.Sprintf("Writing mnemonic file: %s", mnemonicPath))
 	if err := ks.writeFileAtomic(mnemonicPath, []byte(mnemonic), 0600); err != nil {
 		ks.logger.LogError(fmt.Sprintf("Failed to write mnemonic file %s: %v", mnemonicPath, err))
-		return NewRecoverableKeyStoreError("save", "mnemonic_file", err,
-			fmt.Sprintf("Failed to save mnemonic file to '%s'. Please check disk space and permissions.", mnemonicPath))
+		return newWriteError("mnemonic_file", mnemonicPath, "mnemonic file", err)
 	}
 	ks.logger.LogDebug(fmt.Sprintf("Mnemonic file written successfully: %s", mnemonicPath))
 
@@ -1639,7 +1713,7 @@ func (ks *KeyStoreService) SavePrivateKeyFile(address, privateKeyHex, network st
 	}
 
 	// Format address for filename (no 0x prefix for Solana)
-	formattedAddress := formatAddressForFilename(address, network)
+	formattedAddress := ks.fileBase(address, network)
 
 	// Get file path
 	keyPath := filepath.Join(ks.config.OutputDirectory, fmt.Sprintf("%s.key", formattedAddress))
@@ -1654,6 +1728,34 @@ func (ks *KeyStoreService) SavePrivateKeyFile(address, privateKeyHex, network st
 	return nil
 }
 
+// SaveSLIP39ShareFiles writes each SLIP-39 share to its own <address>.slip39-<n> file
+func (ks *KeyStoreService) SaveSLIP39ShareFiles(address string, shares []string, network string) error {
+	if !ks.config.Enabled {
+		return NewKeyStoreError("save", "service", fmt.Errorf("keystore generation is disabled"))
+	}
+	if len(shares) == 0 {
+		return NewKeyStoreErrorWithAddress("save", "slip39_shares", address, fmt.Errorf("no shares to save"))
+	}
+	if err := validateAddressForNetwork(address, network); err != nil {
+		return NewKeyStoreErrorWithAddress("validate", "address", address, err)
+	}
+	if err := ks.ensureOutputDirectory(); err != nil {
+		return NewRecoverableKeyStoreError("save", "directory", err,
+			fmt.Sprintf("Failed to create keystore directory '%s'. Please check permissions and try again.", ks.config.OutputDirectory))
+	}
+
+	formattedAddress := ks.fileBase(address, network)
+	for i, share := range shares {
+		sharePath := filepath.Join(ks.config.OutputDirectory, fmt.Sprintf("%s.slip39-%d", formattedAddress, i+1))
+		ks.logger.LogDebug(fmt.Sprintf("Writing SLIP-39 share file: %s", sharePath))
+		if err := ks.writeFileAtomic(sharePath, []byte(share+"\n"), 0600); err != nil {
+			ks.logger.LogError(fmt.Sprintf("Failed to write SLIP-39 share file %s: %v", sharePath, err))
+			return newWriteError("slip39_share_file", sharePath, "SLIP-39 share", err)
+		}
+	}
+	return nil
+}
+
 // ensureOutputDirectory creates the output directory if it doesn't exist with proper error handling
 func (ks *KeyStoreService) ensureOutputDirectory() error {
 	// Check if path is empty
@@ -1661,8 +1763,8 @@ func (ks *KeyStoreService) ensureOutputDirectory() error {
 		return fmt.Errorf("output directory cannot be empty")
 	}
 
-	// Clean the path to handle any path traversal issues
-	cleanPath := filepath.Clean(ks.config.OutputDirectory)
+	// Clean the path, in the extended-length form where Windows needs it
+	cleanPath := longPath(ks.config.OutputDirectory)
 
 	// Check if directory already exists
 	info, err := os.Stat(cleanPath)
@@ -1708,8 +1810,8 @@ func (ks *KeyStoreService) writeFileAtomic(filename string, data []byte, perm os
 		return fmt.Errorf("file permissions cannot be zero")
 	}
 
-	// Clean the filename path
-	cleanFilename := filepath.Clean(filename)
+	// Clean the filename path, in the extended-length form where Windows needs it
+	cleanFilename := longPath(filename)
 	dir := filepath.Dir(cleanFilename)
 
 	// Ensure the directory exists
@@ -1725,7 +1827,7 @@ func (ks *KeyStoreService) writeFileAtomic(filename string, data []byte, perm os
 	}
 
 	// Create temporary file in the same directory with a secure pattern
-	tmpFile, err := os.CreateTemp(dir, ".keystore-tmp-*")
+	tmpFile, err := faultfs.CreateTemp(dir, ".keystore-tmp-*")
 	if err != nil {
 		return fmt.Errorf("failed to create temporary file in %s: %w", dir, err)
 	}
@@ -1741,7 +1843,7 @@ func (ks *KeyStoreService) writeFileAtomic(filename string, data []byte, perm os
 		}
 		// Remove temp file if write failed
 		if writeErr != nil {
-			_ = os.Remove(tmpPath)
+			_ = faultfs.Remove(tmpPath)
 		}
 	}()
 
@@ -1781,13 +1883,13 @@ func (ks *KeyStoreService) writeFileAtomic(filename string, data []byte, perm os
 	if info, err := os.Stat(tmpPath); err != nil {
 		writeErr = fmt.Errorf("failed to verify temporary file: %w", err)
 		return writeErr
-	} else if info.Mode().Perm() != perm {
+	} else if unixPermissions && info.Mode().Perm() != perm {
 		writeErr = fmt.Errorf("temporary file permissions incorrect: got %o, expected %o", info.Mode().Perm(), perm)
 		return writeErr
 	}
 
 	// Atomically move temporary file to final location
-	if err := os.Rename(tmpPath, cleanFilename); err != nil {
+	if err := faultfs.Rename(tmpPath, cleanFilename); err != nil {
 		writeErr = fmt.Errorf("failed to move temporary file to final location %s: %w", cleanFilename, err)
 		return writeErr
 	}
@@ -1795,7 +1897,7 @@ func (ks *KeyStoreService) writeFileAtomic(filename string, data []byte, perm os
 	// Verify final file exists and has correct permissions
 	if info, err := os.Stat(cleanFilename); err != nil {
 		return fmt.Errorf("failed to verify final file %s: %w", cleanFilename, err)
-	} else if info.Mode().Perm() != perm {
+	} else if unixPermissions && info.Mode().Perm() != perm {
 		return fmt.Errorf("final file permissions incorrect: got %o, expected %o", info.Mode().Perm(), perm)
 	}
 
@@ -1836,7 +1938,7 @@ func (ks *KeyStoreService) CheckDirectoryPermissions() error {
 		return nil // Skip check if keystore is disabled
 	}
 
-	info, err := os.Stat(ks.config.OutputDirectory)
+	info, err := os.Stat(longPath(ks.config.OutputDirectory))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &FileOperationError{
@@ -1861,7 +1963,7 @@ func (ks *KeyStoreService) CheckDirectoryPermissions() error {
 	}
 
 	// Check if directory is writable by attempting to create a temp file
-	tmpFile, err := os.CreateTemp(ks.config.OutputDirectory, ".permission-test-*")
+	tmpFile, err := os.CreateTemp(longPath(ks.config.OutputDirectory), ".permission-test-*")
 	if err != nil {
 		return &FileOperationError{
 			Operation: "check_permissions",
@@ -1883,7 +1985,7 @@ func (ks *KeyStoreService) FileExists(filename string) (bool, error) {
 		return false, fmt.Errorf("filename cannot be empty")
 	}
 
-	cleanPath := filepath.Clean(filename)
+	cleanPath := longPath(filename)
 	_, err := os.Stat(cleanPath)
 	if err == nil {
 		return true, nil
@@ -1905,7 +2007,7 @@ func (ks *KeyStoreService) GetKeystoreFilePath(address string) (string, error) {
 		return "", fmt.Errorf("address cannot be empty")
 	}
 
-	filename := fmt.Sprintf("0x%s.json", cleanAddress)
+	filename := ks.fileBase(cleanAddress, "ethereum") + ".json"
 	return filepath.Join(ks.config.OutputDirectory, filename), nil
 }
 
@@ -1916,7 +2018,7 @@ func (ks *KeyStoreService) GetPasswordFilePath(address string) (string, error) {
 		return "", fmt.Errorf("address cannot be empty")
 	}
 
-	filename := fmt.Sprintf("0x%s.pwd", cleanAddress)
+	filename := ks.fileBase(cleanAddress, "ethereum") + ks.config.PasswordProtection.Suffix()
 	return filepath.Join(ks.config.OutputDirectory, filename), nil
 }
 
@@ -1927,7 +2029,7 @@ func (ks *KeyStoreService) GetMnemonicFilePath(address string) (string, error) {
 		return "", fmt.Errorf("address cannot be empty")
 	}
 
-	filename := fmt.Sprintf("0x%s.mnemonic", cleanAddress)
+	filename := ks.fileBase(cleanAddress, "ethereum") + ".mnemonic"
 	return filepath.Join(ks.config.OutputDirectory, filename), nil
 }
 
@@ -1951,7 +2053,7 @@ func (ks *KeyStoreService) RemoveKeystoreFiles(address string) error {
 	if exists, err := ks.FileExists(keystorePath); err != nil {
 		return fmt.Errorf("failed to check keystore file: %w", err)
 	} else if exists {
-		if err := os.Remove(keystorePath); err != nil {
+		if err := os.Remove(longPath(keystorePath)); err != nil {
 			return &FileOperationError{
 				Operation: "remove_keystore",
 				Path:      keystorePath,
@@ -1964,7 +2066,7 @@ func (ks *KeyStoreService) RemoveKeystoreFiles(address string) error {
 	if exists, err := ks.FileExists(passwordPath); err != nil {
 		return fmt.Errorf("failed to check password file: %w", err)
 	} else if exists {
-		if err := os.Remove(passwordPath); err != nil {
+		if err := os.Remove(longPath(passwordPath)); err != nil {
 			return &FileOperationError{
 				Operation: "remove_password",
 				Path:      passwordPath,
@@ -1982,7 +2084,7 @@ func (ks *KeyStoreService) ValidateFilePermissions(filename string, expectedPerm
 		return fmt.Errorf("filename cannot be empty")
 	}
 
-	info, err := os.Stat(filename)
+	info, err := os.Stat(longPath(filename))
 	if err != nil {
 		return &FileOperationError{
 			Operation: "validate_permissions",
@@ -1992,7 +2094,7 @@ func (ks *KeyStoreService) ValidateFilePermissions(filename string, expectedPerm
 	}
 
 	actualPerm := info.Mode().Perm()
-	if actualPerm != expectedPerm {
+	if unixPermissions && actualPerm != expectedPerm {
 		return &FileOperationError{
 			Operation: "validate_permissions",
 			Path:      filename,
@@ -2072,20 +2174,10 @@ func DecryptPrivateKey(ks *KeyStoreV3, password string) ([]byte, error) {
 		return nil, fmt.Errorf("unsupported KDF: %s", ks.Crypto.KDF)
 	}
 
-	// Verify MAC
-	valid, err := VerifyMAC(derivedKey, ciphertext, expectedMAC)
-	if err != nil {
-		return nil, fmt.Errorf("MAC verification failed: %w", err)
-	}
-	if !valid {
-		return nil, fmt.Errorf("MAC verification failed: incorrect password or corrupted keystore")
-	}
-
-	// Decrypt private key
-	encryptionKey := derivedKey[:16]
-	privateKeyBytes, err := DecryptAES128CTR(ciphertext, encryptionKey, iv)
+	// Verify MAC and decrypt private key
+	privateKeyBytes, err := DecryptKeystoreCipher(ks.Crypto.Cipher, ciphertext, derivedKey, iv, expectedMAC)
 	if err != nil {
-		return nil, fmt.Errorf("AES decryption failed: %w", err)
+		return nil, err
 	}
 
 	return privateKeyBytes, nil
@@ -0,0 +1,258 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// Keystore audit check names
+const (
+	AuditCheckSchema      = "schema"
+	AuditCheckMAC         = "mac"
+	AuditCheckPermissions = "permissions"
+	AuditCheckFilename    = "filename"
+	AuditCheckPassword    = "password_file"
+	AuditCheckAddress     = "address"
+)
+
+// KeystoreAuditIssue describes one failed check
+type KeystoreAuditIssue struct {
+	Check   string `json:"check"`
+	File    string `json:"file"`
+	Message string `json:"message"`
+}
+
+// KeystoreAuditResult holds the checks performed on one keystore file
+type KeystoreAuditResult struct {
+	File    string               `json:"file"`
+	Address string               `json:"address,omitempty"`
+	OK      bool                 `json:"ok"`
+	Skipped string               `json:"skipped,omitempty"`
+	Issues  []KeystoreAuditIssue `json:"issues,omitempty"`
+}
+
+// KeystoreAuditOptions controls an audit of a keystore directory
+type KeystoreAuditOptions struct {
+	// VerifyMAC decrypts each keystore with its password, running the KDF
+	VerifyMAC bool
+	// AgeIdentity is the identity file used to unwrap age encrypted password files
+	AgeIdentity string
+}
+
+// KeystoreAuditReport summarizes an audit of a keystore directory
+type KeystoreAuditReport struct {
+	Directory   string                `json:"directory"`
+	Keystores   int                   `json:"keystores"`
+	Passed      int                   `json:"passed"`
+	Failed      int                   `json:"failed"`
+	Skipped     int                   `json:"skipped"`
+	MACVerified bool                  `json:"mac_verified"`
+	Results     []KeystoreAuditResult `json:"results"`
+	Orphans     []KeystoreAuditIssue  `json:"orphans"`
+}
+
+// OK reports whether every keystore passed and no orphaned files were found
+func (r *KeystoreAuditReport) OK() bool {
+	return r.Failed == 0 && len(r.Orphans) == 0
+}
+
+// AuditKeystoreDirectory checks every JSON keystore in dir and its subdirectories
+// for schema validity, MAC verification against its password file, 0600
+// permissions and address/filename consistency, and reports password, mnemonic and
+// key files without a keystore.
+func AuditKeystoreDirectory(dir string, opts KeystoreAuditOptions) (*KeystoreAuditReport, error) {
+	names, err := walkWalletFiles(dir)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("audit", "directory", dir, err)
+	}
+
+	report := &KeystoreAuditReport{
+		Directory:   dir,
+		MACVerified: opts.VerifyMAC,
+		Results:     []KeystoreAuditResult{},
+		Orphans:     []KeystoreAuditIssue{},
+	}
+
+	files := make(map[string]bool)
+	for _, name := range names {
+		files[name] = true
+	}
+
+	for _, name := range names {
+		ext := filepath.Ext(name)
+		base := strings.TrimSuffix(name, ext)
+		if trimmed, ok := TrimPasswordFileSuffix(name); ok {
+			base, ext = trimmed, ".pwd"
+		}
+
+		switch ext {
+		case ".json":
+			result := auditKeystoreFile(dir, name, opts)
+			report.Results = append(report.Results, result)
+			switch {
+			case result.Skipped != "":
+				report.Skipped++
+			case result.OK:
+				report.Keystores++
+				report.Passed++
+			default:
+				report.Keystores++
+				report.Failed++
+			}
+		case ".pwd", ".key", ".mnemonic", MetadataFileSuffix:
+			if files[base+".json"] {
+				continue
+			}
+			// Bitcoin wallets are saved as a mnemonic file only
+			if ext == ".mnemonic" && !strings.HasPrefix(filepath.Base(base), "0x") {
+				continue
+			}
+			report.Orphans = append(report.Orphans, KeystoreAuditIssue{
+				Check:   "orphan",
+				File:    name,
+				Message: fmt.Sprintf("no keystore %s.json for this %s file", base, strings.TrimPrefix(ext, ".")),
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// auditKeystoreFile runs every check against a single keystore file
+func auditKeystoreFile(dir, name string, opts KeystoreAuditOptions) KeystoreAuditResult {
+	result := KeystoreAuditResult{File: name}
+	path := filepath.Join(dir, name)
+	base := strings.TrimSuffix(name, ".json")
+
+	addIssue := func(check, file, message string) {
+		result.Issues = append(result.Issues, KeystoreAuditIssue{Check: check, File: file, Message: message})
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		addIssue(AuditCheckSchema, name, fmt.Sprintf("cannot read file: %v", err))
+		return result
+	}
+
+	var header struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(data, &header) == nil && header.Type == "solana-keypair" {
+		result.Skipped = "solana keypair"
+		return result
+	}
+
+	checkPermissions := func(file string) {
+		if runtime.GOOS == "windows" {
+			return
+		}
+		info, err := os.Stat(filepath.Join(dir, file))
+		if err != nil {
+			addIssue(AuditCheckPermissions, file, err.Error())
+			return
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			addIssue(AuditCheckPermissions, file, fmt.Sprintf("permissions are %o, expected 600", perm))
+		}
+	}
+
+	passwordPath, hasPassword := FindPasswordFile(dir, base)
+
+	passwordName := base + ".pwd"
+	if hasPassword {
+		passwordName, _ = filepath.Rel(dir, passwordPath)
+	}
+	checkPermissions(name)
+	if hasPassword {
+		checkPermissions(passwordName)
+	}
+	if _, err := os.Stat(filepath.Join(dir, base+".mnemonic")); err == nil {
+		checkPermissions(base + ".mnemonic")
+	}
+
+	keystore, err := FromJSON(data)
+	if err != nil {
+		addIssue(AuditCheckSchema, name, err.Error())
+		return finishAudit(result)
+	}
+	result.Address = "0x" + strings.ToLower(strings.TrimPrefix(keystore.Address, "0x"))
+
+	// Labelled keystores are named <label>_<address>.json
+	expected := FormatAddressForFilename(keystore.Address, "ethereum") + ".json"
+	if file := filepath.Base(name); !strings.EqualFold(file, expected) && !strings.HasSuffix(strings.ToLower(file), "_"+expected) {
+		addIssue(AuditCheckFilename, name, fmt.Sprintf("keystore address %s does not match filename, expected %s", result.Address, expected))
+	}
+
+	if !hasPassword {
+		addIssue(AuditCheckPassword, passwordName, "password file is missing")
+		return finishAudit(result)
+	}
+
+	if opts.VerifyMAC {
+		password, err := ReadPasswordFile(passwordPath, opts.AgeIdentity)
+		if err != nil {
+			addIssue(AuditCheckPassword, passwordName, fmt.Sprintf("cannot read password: %v", err))
+			return finishAudit(result)
+		}
+
+		privateKey, err := DecryptPrivateKey(keystore, password)
+		if err != nil {
+			addIssue(AuditCheckMAC, name, err.Error())
+			return finishAudit(result)
+		}
+
+		key, err := ethcrypto.ToECDSA(privateKey)
+		if err != nil {
+			addIssue(AuditCheckAddress, name, fmt.Sprintf("decrypted private key is invalid: %v", err))
+			return finishAudit(result)
+		}
+		derived := strings.ToLower(ethcrypto.PubkeyToAddress(key.PublicKey).Hex())
+		if derived != result.Address {
+			addIssue(AuditCheckAddress, name, fmt.Sprintf("private key belongs to %s, not %s", derived, result.Address))
+		}
+	}
+
+	return finishAudit(result)
+}
+
+// walkWalletFiles returns the regular files in dir and its subdirectories, such as
+// those of a keystore path template, as sorted paths relative to dir. Hidden
+// directories, like those staging deferred keystores, are skipped.
+func walkWalletFiles(dir string) ([]string, error) {
+	var names []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			name, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			names = append(names, name)
+		}
+		return nil
+	})
+	sort.Strings(names)
+	return names, err
+}
+
+// finishAudit marks a result as passed when no issues were recorded
+func finishAudit(result KeystoreAuditResult) KeystoreAuditResult {
+	result.OK = len(result.Issues) == 0
+	return result
+}
@@ -0,0 +1,134 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// writeAuditKeystore saves a real keystore and password file into dir and returns its base name
+func writeAuditKeystore(t *testing.T, dir string) string {
+	t.Helper()
+
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := ethcrypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	service := NewKeyStoreService(KeyStoreConfig{OutputDirectory: dir, Enabled: true, KDF: "pbkdf2"})
+	if err := service.SaveKeyStoreFiles(hex.EncodeToString(ethcrypto.FromECDSA(key)), address, "ethereum"); err != nil {
+		t.Fatalf("SaveKeyStoreFiles() error = %v", err)
+	}
+	return "0x" + strings.ToLower(address[2:])
+}
+
+// auditChecks returns the check names reported for a file
+func auditChecks(report *KeystoreAuditReport, file string) []string {
+	var checks []string
+	for _, result := range report.Results {
+		if result.File == file {
+			for _, issue := range result.Issues {
+				checks = append(checks, issue.Check)
+			}
+		}
+	}
+	return checks
+}
+
+func TestAuditKeystoreDirectory_Clean(t *testing.T) {
+	dir := t.TempDir()
+	writeAuditKeystore(t, dir)
+	writeAuditKeystore(t, dir)
+
+	report, err := AuditKeystoreDirectory(dir, KeystoreAuditOptions{VerifyMAC: true})
+	if err != nil {
+		t.Fatalf("AuditKeystoreDirectory() error = %v", err)
+	}
+	if !report.OK() || report.Keystores != 2 || report.Passed != 2 {
+		t.Errorf("expected two passing keystores, got %+v", report)
+	}
+}
+
+func TestAuditKeystoreDirectory_DetectsProblems(t *testing.T) {
+	dir := t.TempDir()
+	wrongPassword := writeAuditKeystore(t, dir)
+	badPerms := writeAuditKeystore(t, dir)
+	renamed := writeAuditKeystore(t, dir)
+	missingPassword := writeAuditKeystore(t, dir)
+
+	if err := os.WriteFile(filepath.Join(dir, wrongPassword+".pwd"), []byte("not-the-password"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(filepath.Join(dir, badPerms+".json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	other := "0x" + strings.Repeat("ab", 20)
+	for _, ext := range []string{".json", ".pwd"} {
+		if err := os.Rename(filepath.Join(dir, renamed+ext), filepath.Join(dir, other+ext)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Remove(filepath.Join(dir, missingPassword+".pwd")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte(`{"version":3}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "0xdead.pwd"), []byte("orphan"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bc1qexample.mnemonic"), []byte("bitcoin words"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := AuditKeystoreDirectory(dir, KeystoreAuditOptions{VerifyMAC: true})
+	if err != nil {
+		t.Fatalf("AuditKeystoreDirectory() error = %v", err)
+	}
+
+	expected := map[string]string{
+		wrongPassword + ".json":   AuditCheckMAC,
+		badPerms + ".json":        AuditCheckPermissions,
+		other + ".json":           AuditCheckFilename,
+		missingPassword + ".json": AuditCheckPassword,
+		"broken.json":             AuditCheckSchema,
+	}
+	for file, check := range expected {
+		checks := auditChecks(report, file)
+		if len(checks) != 1 || checks[0] != check {
+			t.Errorf("%s: checks = %v, expected [%s]", file, checks, check)
+		}
+	}
+
+	if report.Keystores != 5 || report.Failed != 5 || report.Passed != 0 {
+		t.Errorf("unexpected summary: keystores=%d passed=%d failed=%d", report.Keystores, report.Passed, report.Failed)
+	}
+	if len(report.Orphans) != 1 || report.Orphans[0].File != "0xdead.pwd" {
+		t.Errorf("expected only the orphaned password file, got %+v", report.Orphans)
+	}
+}
+
+func TestAuditKeystoreDirectory_SkipMAC(t *testing.T) {
+	dir := t.TempDir()
+	base := writeAuditKeystore(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, base+".pwd"), []byte("wrong"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := AuditKeystoreDirectory(dir, KeystoreAuditOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || report.MACVerified {
+		t.Errorf("MAC should not be verified when disabled: %+v", report)
+	}
+
+	if _, err := AuditKeystoreDirectory(filepath.Join(dir, "missing"), KeystoreAuditOptions{VerifyMAC: true}); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+}
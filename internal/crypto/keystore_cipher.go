@@ -0,0 +1,142 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Keystore ciphers accepted by --keystore-cipher
+const (
+	CipherAES128CTR = "aes-128-ctr"
+	CipherAES256CTR = "aes-256-ctr"
+	CipherAES128GCM = "aes-128-gcm"
+)
+
+// KeystoreCiphers lists the keystore ciphers, the standard one first
+var KeystoreCiphers = []string{CipherAES128CTR, CipherAES256CTR, CipherAES128GCM}
+
+// keystoreCipher describes how a cipher splits the derived key. The encryption
+// key is its first keyLen bytes and the MAC key the 16 bytes after them, which
+// for aes-128-ctr is the Web3 Secret Storage layout.
+type keystoreCipher struct {
+	keyLen int
+	ivLen  int
+	// standard ciphers are part of the Web3 Secret Storage definition
+	standard bool
+	// experimental ciphers are not read by any other wallet
+	experimental bool
+}
+
+var keystoreCiphers = map[string]keystoreCipher{
+	CipherAES128CTR: {keyLen: 16, ivLen: 16, standard: true},
+	CipherAES256CTR: {keyLen: 32, ivLen: 16},
+	CipherAES128GCM: {keyLen: 16, ivLen: 12, experimental: true},
+}
+
+// ValidateKeystoreCipher checks that name is one of KeystoreCiphers
+func ValidateKeystoreCipher(name string) error {
+	if _, ok := keystoreCiphers[name]; !ok {
+		return fmt.Errorf("unsupported cipher: %s (supported: %s)", name, strings.Join(KeystoreCiphers, ", "))
+	}
+	return nil
+}
+
+// IsStandardCipher reports whether keystores using the cipher follow the Web3
+// Secret Storage definition and so open in geth, clef and other wallets
+func IsStandardCipher(name string) bool {
+	return keystoreCiphers[name].standard
+}
+
+// IsExperimentalCipher reports whether the cipher is a non-standard mode that
+// only bloco-eth can decrypt
+func IsExperimentalCipher(name string) bool {
+	return keystoreCiphers[name].experimental
+}
+
+// CipherDerivedKeyLength is the KDF dklen a cipher needs: its key plus the 16-byte MAC key
+func CipherDerivedKeyLength(name string) int {
+	return max(keystoreCiphers[name].keyLen+16, 32)
+}
+
+// cipherParts splits the derived key into the cipher's encryption and MAC keys
+func cipherParts(name string, derivedKey []byte) (c keystoreCipher, encryptionKey, macKey []byte, err error) {
+	c, ok := keystoreCiphers[name]
+	if !ok {
+		return c, nil, nil, ValidateKeystoreCipher(name)
+	}
+	if len(derivedKey) < c.keyLen+16 {
+		return c, nil, nil, fmt.Errorf("derived key too short for %s: got %d bytes, need %d", name, len(derivedKey), c.keyLen+16)
+	}
+	return c, derivedKey[:c.keyLen], derivedKey[c.keyLen : c.keyLen+16], nil
+}
+
+// EncryptKeystoreCipher encrypts plaintext with the named cipher and returns the
+// ciphertext and its Keccak-256 MAC. For aes-128-gcm the ciphertext ends with the
+// GCM tag, and the MAC still covers it so every cipher verifies passwords alike.
+func EncryptKeystoreCipher(name string, plaintext, derivedKey, iv []byte) (ciphertext, mac []byte, err error) {
+	c, encryptionKey, macKey, err := cipherParts(name, derivedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(iv) != c.ivLen {
+		return nil, nil, fmt.Errorf("IV must be %d bytes for %s", c.ivLen, name)
+	}
+	if len(plaintext) == 0 {
+		return nil, nil, fmt.Errorf("plaintext cannot be empty")
+	}
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	if name == CipherAES128GCM {
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create GCM: %w", err)
+		}
+		ciphertext = gcm.Seal(nil, iv, plaintext, nil)
+	} else {
+		ciphertext = make([]byte, len(plaintext))
+		cipher.NewCTR(block, iv).XORKeyStream(ciphertext, plaintext)
+	}
+	return ciphertext, crypto.Keccak256(macKey, ciphertext), nil
+}
+
+// DecryptKeystoreCipher verifies the MAC of ciphertext and decrypts it with the named cipher
+func DecryptKeystoreCipher(name string, ciphertext, derivedKey, iv, mac []byte) ([]byte, error) {
+	c, encryptionKey, macKey, err := cipherParts(name, derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != c.ivLen {
+		return nil, fmt.Errorf("IV must be %d bytes for %s", c.ivLen, name)
+	}
+	if len(ciphertext) == 0 {
+		return nil, fmt.Errorf("ciphertext cannot be empty")
+	}
+	if subtle.ConstantTimeCompare(crypto.Keccak256(macKey, ciphertext), mac) != 1 {
+		return nil, fmt.Errorf("MAC verification failed: incorrect password or corrupted keystore")
+	}
+	block, err := aes.NewCipher(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	if name == CipherAES128GCM {
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCM: %w", err)
+		}
+		plaintext, err := gcm.Open(nil, iv, ciphertext, nil)
+		if err != nil {
+			return nil, fmt.Errorf("GCM authentication failed: corrupted keystore")
+		}
+		return plaintext, nil
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCTR(block, iv).XORKeyStream(plaintext, ciphertext)
+	return plaintext, nil
+}
@@ -0,0 +1,111 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestKeystoreCipher_RoundTrip(t *testing.T) {
+	plaintext := bytes.Repeat([]byte{0x42}, 32)
+	for _, name := range KeystoreCiphers {
+		t.Run(name, func(t *testing.T) {
+			derivedKey := bytes.Repeat([]byte{0x07}, CipherDerivedKeyLength(name))
+			iv := make([]byte, keystoreCiphers[name].ivLen)
+			ciphertext, mac, err := EncryptKeystoreCipher(name, plaintext, derivedKey, iv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := DecryptKeystoreCipher(name, ciphertext, derivedKey, iv, mac)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, plaintext) {
+				t.Errorf("decrypted %x, want %x", got, plaintext)
+			}
+
+			tampered := append([]byte(nil), ciphertext...)
+			tampered[0] ^= 1
+			if _, err := DecryptKeystoreCipher(name, tampered, derivedKey, iv, mac); err == nil || !strings.Contains(err.Error(), "MAC") {
+				t.Errorf("tampered ciphertext: err = %v, want MAC failure", err)
+			}
+			if _, err := DecryptKeystoreCipher(name, ciphertext, derivedKey[:16], iv, mac); err == nil {
+				t.Error("short derived key was accepted")
+			}
+		})
+	}
+}
+
+func TestKeystoreCipher_AES128CTRMatchesV3(t *testing.T) {
+	plaintext := bytes.Repeat([]byte{0x42}, 32)
+	derivedKey := bytes.Repeat([]byte{0x07}, 32)
+	iv := bytes.Repeat([]byte{0x01}, 16)
+
+	ciphertext, mac, err := EncryptKeystoreCipher(CipherAES128CTR, plaintext, derivedKey, iv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, _ := EncryptAES128CTR(plaintext, derivedKey[:16], iv)
+	legacyMAC, _ := GenerateMAC(derivedKey, legacy)
+	if !bytes.Equal(ciphertext, legacy) || !bytes.Equal(mac, legacyMAC) {
+		t.Error("aes-128-ctr does not follow the Web3 Secret Storage layout")
+	}
+}
+
+func TestKeystoreCipher_GCMAuthenticates(t *testing.T) {
+	derivedKey := bytes.Repeat([]byte{0x07}, 32)
+	iv := make([]byte, 12)
+	ciphertext, _, err := EncryptKeystoreCipher(CipherAES128GCM, bytes.Repeat([]byte{0x42}, 32), derivedKey, iv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ciphertext) != 48 {
+		t.Errorf("ciphertext is %d bytes, want 32 plus the 16-byte tag", len(ciphertext))
+	}
+
+	// A forged MAC over a tampered ciphertext still fails the GCM tag
+	ciphertext[0] ^= 1
+	mac := crypto.Keccak256(derivedKey[16:32], ciphertext)
+	if _, err := DecryptKeystoreCipher(CipherAES128GCM, ciphertext, derivedKey, iv, mac); err == nil || !strings.Contains(err.Error(), "GCM") {
+		t.Errorf("err = %v, want GCM authentication failure", err)
+	}
+}
+
+func TestKeyStoreService_Cipher(t *testing.T) {
+	privateKey := "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	for _, name := range KeystoreCiphers {
+		t.Run(name, func(t *testing.T) {
+			service := NewKeyStoreService(KeyStoreConfig{Enabled: true, KDF: "pbkdf2", Cipher: name})
+			keystore, password, err := service.GenerateKeyStore(privateKey, "0x008aeeda4d805471df9b2a5b0f38a0c3bcba786b", "ethereum")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if keystore.Crypto.Cipher != name {
+				t.Errorf("cipher = %s, want %s", keystore.Crypto.Cipher, name)
+			}
+			data, err := keystore.ToJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := FromJSON(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			key, err := DecryptPrivateKey(parsed, password)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if hex.EncodeToString(key) != privateKey {
+				t.Errorf("decrypted %x", key)
+			}
+		})
+	}
+
+	service := NewKeyStoreService(KeyStoreConfig{Enabled: true, KDF: "pbkdf2", Cipher: "des-cbc"})
+	if _, _, err := service.GenerateKeyStore(privateKey, "0x008aeeda4d805471df9b2a5b0f38a0c3bcba786b", "ethereum"); err == nil {
+		t.Error("unsupported cipher was accepted")
+	}
+}
@@ -0,0 +1,142 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/faultfs"
+	"bloco-eth/internal/retry"
+)
+
+// faultAddress is the address saved by the fault injection tests
+const faultAddress = "0x1234567890abcdef1234567890abcdef12345678"
+
+// newFaultKeystore returns a keystore service writing to a new directory and a
+// keystore of faultAddress to save with it
+func newFaultKeystore(t *testing.T) (*KeyStoreService, *KeyStoreV3, string) {
+	t.Helper()
+	service := NewKeyStoreService(KeyStoreConfig{
+		Enabled:         true,
+		OutputDirectory: t.TempDir(),
+		KDF:             "pbkdf2",
+		KDFParams:       map[string]interface{}{"c": 100000},
+	})
+	keystore, password, err := service.GenerateKeyStore(
+		"1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef", faultAddress, "ethereum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service, keystore, password
+}
+
+// outputFiles lists the names in the service's output directory
+func outputFiles(t *testing.T, service *KeyStoreService) []string {
+	t.Helper()
+	entries, err := os.ReadDir(service.GetConfig().OutputDirectory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestSaveKeyStoreFiles_Faults(t *testing.T) {
+	tests := []struct {
+		name        string
+		faults      []faultfs.Fault
+		recoverable bool
+		errorMsg    string
+		left        int // files left behind
+	}{
+		{
+			name:     "disk full writing the keystore",
+			faults:   []faultfs.Fault{{Op: faultfs.OpWrite, Err: syscall.ENOSPC}},
+			errorMsg: "no space left on device",
+		},
+		{
+			name:        "partial keystore write",
+			faults:      []faultfs.Fault{{Op: faultfs.OpWrite, Short: 10}},
+			recoverable: true,
+			errorMsg:    "incomplete write",
+		},
+		{
+			name:     "disk full writing the password removes the keystore",
+			faults:   []faultfs.Fault{{Op: faultfs.OpWrite, After: 1, Err: syscall.EDQUOT}},
+			errorMsg: "disk quota exceeded",
+		},
+		{
+			name:        "permission denied renaming the password file",
+			faults:      []faultfs.Fault{{Op: faultfs.OpRename, Path: "*.pwd", Err: syscall.EACCES}},
+			recoverable: true,
+			errorMsg:    "permission denied",
+		},
+		{
+			name: "failed keystore cleanup",
+			faults: []faultfs.Fault{
+				{Op: faultfs.OpSync, After: 1, Err: syscall.EIO},
+				{Op: faultfs.OpRemove, Path: "*.json", Err: syscall.EACCES},
+			},
+			errorMsg: "cleanup failed",
+			left:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, keystore, password := newFaultKeystore(t)
+			restore := faultfs.Inject(tt.faults...)
+			err := service.SaveKeyStoreFilesToDisk(faultAddress, keystore, password, "ethereum", "")
+			restore()
+
+			if err == nil || !strings.Contains(errors.Unwrap(err).Error(), tt.errorMsg) {
+				t.Fatalf("SaveKeyStoreFilesToDisk() error = %v, want %q", err, tt.errorMsg)
+			}
+			if IsRecoverableError(err) != tt.recoverable {
+				t.Errorf("IsRecoverableError() = %v, want %v", !tt.recoverable, tt.recoverable)
+			}
+			// A full disk is not recoverable, and named as the cause
+			if IsDiskFull(err) && (tt.recoverable || !strings.Contains(err.Error(), "the disk is full")) {
+				t.Errorf("error = %q, IsDiskFull() = %v", err, IsDiskFull(err))
+			}
+			// Temporary files are always removed, and so is a keystore without its password
+			if files := outputFiles(t, service); len(files) != tt.left {
+				t.Errorf("files left = %v, want %d", files, tt.left)
+			}
+		})
+	}
+}
+
+func TestSaveKeyStoreFiles_RetryAfterFault(t *testing.T) {
+	service, keystore, password := newFaultKeystore(t)
+	defer faultfs.Inject(faultfs.Fault{Op: faultfs.OpWrite, After: 1, Count: 2, Err: syscall.EIO})()
+
+	attempts := 0
+	policy := retry.Policy{Attempts: 3, InitialDelay: retry.Duration(time.Millisecond), Multiplier: 1}
+	err := retry.Do(context.Background(), policy, func() error {
+		attempts++
+		return RetryableSave(service.SaveKeyStoreFilesToDisk(faultAddress, keystore, password, "ethereum", ""))
+	}, nil)
+	if err != nil || attempts != 3 {
+		t.Fatalf("save = %v after %d attempts, want success on the third", err, attempts)
+	}
+
+	keystorePath, _ := service.GetKeystoreFilePath(faultAddress)
+	passwordPath, _ := service.GetPasswordFilePath(faultAddress)
+	want := []string{filepath.Base(keystorePath), filepath.Base(passwordPath)}
+	if files := outputFiles(t, service); strings.Join(files, " ") != strings.Join(want, " ") {
+		t.Errorf("files = %v, want %v", files, want)
+	}
+	data, err := os.ReadFile(passwordPath)
+	if err != nil || string(data) != password {
+		t.Errorf("password file = %q, %v", data, err)
+	}
+}
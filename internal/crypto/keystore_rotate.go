@@ -0,0 +1,355 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bloco-eth/internal/faultfs"
+)
+
+const (
+	// DefaultMinPasswordEntropy is the entropy, in bits, below which a keystore
+	// password is regenerated
+	DefaultMinPasswordEntropy = 64
+	// DefaultRotatedPasswordLength is the length of regenerated passwords
+	DefaultRotatedPasswordLength = 20
+	// rotatingSuffix marks the staged files of a rotation in progress
+	rotatingSuffix = ".rotating"
+)
+
+// Password rotation actions
+const (
+	RotationKept     = "kept"
+	RotationRotated  = "rotated"
+	RotationWould    = "would_rotate"
+	RotationSkipped  = "skipped"
+	RotationFailed   = "failed"
+	RotationResumed  = "resumed"
+	RotationReverted = "reverted"
+)
+
+// PasswordRotationOptions controls a password rotation of a keystore directory
+type PasswordRotationOptions struct {
+	// MinEntropy is the entropy, in bits, a password needs to be kept
+	MinEntropy float64
+	// Length is the length of regenerated passwords when Policy is unset
+	Length int
+	// Policy generates the new passwords
+	Policy PasswordPolicy
+	// Protection wraps the new password files; wrapped password files are only
+	// rotated when it is set, so a rotation never leaves a password in plain text
+	Protection PasswordProtection
+	// AgeIdentity is the identity file used to unwrap age encrypted password files
+	AgeIdentity string
+	// DryRun reports the weak passwords without changing any file
+	DryRun bool
+}
+
+// PasswordRotationResult is what a rotation did to one keystore. It names the
+// password files, never the passwords.
+type PasswordRotationResult struct {
+	File            string  `json:"file"`
+	Address         string  `json:"address,omitempty"`
+	Action          string  `json:"action"`
+	PasswordFile    string  `json:"password_file,omitempty"`
+	NewPasswordFile string  `json:"new_password_file,omitempty"`
+	Entropy         float64 `json:"entropy_bits,omitempty"`
+	NewEntropy      float64 `json:"new_entropy_bits,omitempty"`
+	Message         string  `json:"message,omitempty"`
+}
+
+// PasswordRotationReport maps every keystore of a directory to its password file
+// before and after a rotation
+type PasswordRotationReport struct {
+	Directory     string                   `json:"directory"`
+	MinEntropy    float64                  `json:"min_entropy_bits"`
+	Policy        string                   `json:"policy"`
+	PolicyEntropy float64                  `json:"policy_entropy_bits"`
+	DryRun        bool                     `json:"dry_run,omitempty"`
+	Keystores     int                      `json:"keystores"`
+	Rotated       int                      `json:"rotated"`
+	Kept          int                      `json:"kept"`
+	Skipped       int                      `json:"skipped"`
+	Failed        int                      `json:"failed"`
+	Results       []PasswordRotationResult `json:"results"`
+}
+
+// RotatePasswords regenerates the weak passwords of the keystores in dir and its
+// subdirectories and re-encrypts those keystores with the same cipher, KDF and
+// KDF parameters. Each keystore and its new password file are staged next to the
+// originals and the keystore is renamed into place first; an interrupted rotation
+// is finished or undone by the next run, so a keystore never stays without the
+// password that opens it.
+func RotatePasswords(dir string, opts PasswordRotationOptions) (*PasswordRotationReport, error) {
+	if opts.MinEntropy <= 0 {
+		opts.MinEntropy = DefaultMinPasswordEntropy
+	}
+	if opts.Policy.Mode == "" {
+		if opts.Length <= 0 {
+			opts.Length = DefaultRotatedPasswordLength
+		}
+		opts.Policy = PasswordPolicy{Mode: PasswordModeRandom, Length: opts.Length, Classes: DefaultPasswordCharset()}
+	}
+	if err := opts.Policy.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Policy.Entropy() < opts.MinEntropy {
+		return nil, fmt.Errorf("%s passwords have %.0f bits of entropy, short of %.0f; use a longer policy",
+			opts.Policy, opts.Policy.Entropy(), opts.MinEntropy)
+	}
+
+	names, err := walkWalletFiles(dir)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("rotate", "directory", dir, err)
+	}
+	report := &PasswordRotationReport{
+		Directory:     dir,
+		MinEntropy:    opts.MinEntropy,
+		Policy:        opts.Policy.String(),
+		PolicyEntropy: math.Round(opts.Policy.Entropy()*10) / 10,
+		DryRun:        opts.DryRun,
+		Results:       []PasswordRotationResult{},
+	}
+	service := NewKeyStoreService(KeyStoreConfig{OutputDirectory: dir})
+	for _, name := range names {
+		if filepath.Ext(name) != ".json" {
+			continue
+		}
+		result := rotateKeystorePassword(service, dir, name, opts)
+		if result.Action == "" {
+			continue
+		}
+		report.Results = append(report.Results, result)
+		report.Keystores++
+		switch result.Action {
+		case RotationRotated, RotationWould, RotationResumed:
+			report.Rotated++
+		case RotationKept, RotationReverted:
+			report.Kept++
+		case RotationSkipped:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// rotateKeystorePassword rotates the password of one keystore if it is weak. It
+// returns an empty action for JSON files that are not keystores.
+func rotateKeystorePassword(service *KeyStoreService, dir, name string, opts PasswordRotationOptions) PasswordRotationResult {
+	result := PasswordRotationResult{File: name}
+	path := filepath.Join(dir, name)
+	base := strings.TrimSuffix(name, ".json")
+	fail := func(format string, args ...any) PasswordRotationResult {
+		result.Action, result.Message = RotationFailed, fmt.Sprintf(format, args...)
+		return result
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fail("cannot read keystore: %v", err)
+	}
+	var header struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal(data, &header) == nil && header.Type == "solana-keypair" {
+		return PasswordRotationResult{}
+	}
+	keystore, err := FromJSON(data)
+	if err != nil {
+		return PasswordRotationResult{}
+	}
+	result.Address = "0x" + strings.ToLower(strings.TrimPrefix(keystore.Address, "0x"))
+
+	if !opts.DryRun {
+		switch action, err := recoverRotation(dir, base); {
+		case err != nil:
+			return fail("cannot finish an interrupted rotation: %v", err)
+		case action != "":
+			result.Action, result.Message = action, "finished by this run after an interrupted rotation"
+			if action == RotationResumed {
+				result.NewPasswordFile, _ = passwordFileName(dir, base)
+				return result
+			}
+		}
+	}
+
+	passwordPath, found := FindPasswordFile(dir, base)
+	if !found {
+		result.Action, result.Message = RotationSkipped, "no password file"
+		return result
+	}
+	result.PasswordFile, _ = filepath.Rel(dir, passwordPath)
+	oldProtection := PasswordFileProtection(passwordPath)
+	password, err := ReadPasswordFile(passwordPath, opts.AgeIdentity)
+	if err != nil {
+		return fail("cannot read password: %v", err)
+	}
+	result.Entropy = math.Round(PasswordEntropy(password)*10) / 10
+	if PasswordEntropy(password) >= opts.MinEntropy {
+		if result.Action == "" {
+			result.Action = RotationKept
+		}
+		return result
+	}
+	if oldProtection != PasswordProtectionNone && opts.Protection.Method == PasswordProtectionNone {
+		result.Action, result.Message = RotationSkipped, fmt.Sprintf("password is %s wrapped; set a %s recipient to rewrap the new one", oldProtection, oldProtection)
+		return result
+	}
+	newPasswordPath := filepath.Join(dir, base+opts.Protection.Suffix())
+	result.NewPasswordFile, _ = filepath.Rel(dir, newPasswordPath)
+	if opts.DryRun {
+		result.Action = RotationWould
+		return result
+	}
+
+	privateKey, err := DecryptPrivateKey(keystore, password)
+	if err != nil {
+		return fail("cannot decrypt with the current password: %v", err)
+	}
+	defer ClearSensitiveData(privateKey)
+
+	newPassword, err := opts.Policy.Generate()
+	if err != nil {
+		return fail("cannot generate a password: %v", err)
+	}
+	rotated, err := reencryptKeystore(keystore, privateKey, newPassword)
+	if err != nil {
+		return fail("cannot re-encrypt: %v", err)
+	}
+	keystoreJSON, err := rotated.ToJSON()
+	if err != nil {
+		return fail("cannot encode keystore: %v", err)
+	}
+	passwordData, err := opts.Protection.Wrap([]byte(newPassword))
+	if err != nil {
+		return fail("cannot wrap the new password: %v", err)
+	}
+
+	// Stage both files, then commit by renaming the keystore into place
+	stagedKeystore, stagedPassword := path+rotatingSuffix, newPasswordPath+rotatingSuffix
+	if err := service.writeFileAtomic(stagedPassword, passwordData, 0600); err != nil {
+		return fail("cannot stage the new password: %v", err)
+	}
+	if err := service.writeFileAtomic(stagedKeystore, keystoreJSON, 0600); err != nil {
+		_ = faultfs.Remove(stagedPassword)
+		return fail("cannot stage the re-encrypted keystore: %v", err)
+	}
+	if err := faultfs.Rename(stagedKeystore, path); err != nil {
+		_ = faultfs.Remove(stagedKeystore)
+		_ = faultfs.Remove(stagedPassword)
+		return fail("cannot replace the keystore: %v", err)
+	}
+	if err := finishRotation(dir, base, newPasswordPath); err != nil {
+		return fail("keystore re-encrypted but the new password is still at %s: %v", stagedPassword, err)
+	}
+	result.Action = RotationRotated
+	result.NewEntropy = math.Round(opts.Policy.Entropy()*10) / 10
+	return result
+}
+
+// reencryptKeystore encrypts privateKey for password with the cipher, KDF and KDF
+// parameters of keystore, keeping its address and ID, and checks the result opens
+func reencryptKeystore(keystore *KeyStoreV3, privateKey []byte, password string) (*KeyStoreV3, error) {
+	var params map[string]interface{}
+	switch keystore.Crypto.KDF {
+	case "scrypt":
+		current, err := keystore.GetScryptParams()
+		if err != nil {
+			return nil, err
+		}
+		params = map[string]interface{}{"n": current.N, "r": current.R, "p": current.P}
+	case "pbkdf2":
+		current, err := keystore.GetPBKDF2Params()
+		if err != nil {
+			return nil, err
+		}
+		params = map[string]interface{}{"c": current.C, "prf": current.PRF}
+	default:
+		return nil, fmt.Errorf("unsupported KDF: %s", keystore.Crypto.KDF)
+	}
+	// The parameters are the keystore's own, so their compatibility warnings add nothing
+	service := NewKeyStoreServiceWithLogger(KeyStoreConfig{Cipher: keystore.Crypto.Cipher, KDF: keystore.Crypto.KDF, KDFParams: params},
+		quietLogger{})
+	rotated, err := service.EncryptPrivateKeyWithKDF(hex.EncodeToString(privateKey), password, keystore.Crypto.KDF, "ethereum")
+	if err != nil {
+		return nil, err
+	}
+	rotated.Address, rotated.ID = keystore.Address, keystore.ID
+
+	check, err := DecryptPrivateKey(rotated, password)
+	if err != nil {
+		return nil, fmt.Errorf("the re-encrypted keystore does not open: %w", err)
+	}
+	defer ClearSensitiveData(check)
+	if hex.EncodeToString(check) != hex.EncodeToString(privateKey) {
+		return nil, fmt.Errorf("the re-encrypted keystore holds a different key")
+	}
+	return rotated, nil
+}
+
+// recoverRotation finishes or undoes a rotation of base interrupted by a crash. A
+// staged keystore means the rotation stopped before its commit and is undone; a
+// staged password without one means the keystore was replaced and the password
+// is moved into place.
+func recoverRotation(dir, base string) (string, error) {
+	stagedKeystore := filepath.Join(dir, base+".json"+rotatingSuffix)
+	if _, err := os.Stat(stagedKeystore); err == nil {
+		if err := faultfs.Remove(stagedKeystore); err != nil {
+			return "", err
+		}
+		for _, suffix := range passwordFileSuffixes {
+			if err := faultfs.Remove(filepath.Join(dir, base+suffix+rotatingSuffix)); err != nil && !os.IsNotExist(err) {
+				return "", err
+			}
+		}
+		return RotationReverted, nil
+	}
+	for _, suffix := range passwordFileSuffixes {
+		target := filepath.Join(dir, base+suffix)
+		if _, err := os.Stat(target + rotatingSuffix); err == nil {
+			return RotationResumed, finishRotation(dir, base, target)
+		}
+	}
+	return "", nil
+}
+
+// finishRotation moves the staged password of base to target and removes the
+// password files it used before
+func finishRotation(dir, base, target string) error {
+	if err := faultfs.Rename(target+rotatingSuffix, target); err != nil {
+		return err
+	}
+	for _, suffix := range passwordFileSuffixes {
+		if old := filepath.Join(dir, base+suffix); old != target {
+			if err := faultfs.Remove(old); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// passwordFileName returns the password file of base relative to dir
+func passwordFileName(dir, base string) (string, bool) {
+	path, found := FindPasswordFile(dir, base)
+	if !found {
+		return "", false
+	}
+	name, err := filepath.Rel(dir, path)
+	return name, err == nil
+}
+
+// quietLogger drops the messages of a KeyStoreService
+type quietLogger struct{}
+
+func (quietLogger) LogInfo(string)    {}
+func (quietLogger) LogWarning(string) {}
+func (quietLogger) LogError(string)   {}
+func (quietLogger) LogDebug(string)   {}
@@ -0,0 +1,169 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// rotationResult returns the result of a rotation for the keystore base
+func rotationResult(t *testing.T, report *PasswordRotationReport, base string) PasswordRotationResult {
+	t.Helper()
+	for _, result := range report.Results {
+		if result.File == base+".json" {
+			return result
+		}
+	}
+	t.Fatalf("no result for %s in %+v", base, report.Results)
+	return PasswordRotationResult{}
+}
+
+// openKeystore decrypts the keystore base of dir with its password file
+func openKeystore(t *testing.T, dir, base string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, base+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keystore, err := FromJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	password, err := ReadPasswordFile(filepath.Join(dir, base+".pwd"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := DecryptPrivateKey(keystore, password)
+	if err != nil {
+		t.Fatalf("keystore %s does not open with its password file: %v", base, err)
+	}
+	return key
+}
+
+func TestRotatePasswords(t *testing.T) {
+	dir := t.TempDir()
+	weak, strong := writeAuditKeystore(t, dir), writeAuditKeystore(t, dir)
+	// Re-encrypt one keystore under a weak password
+	key := openKeystore(t, dir, weak)
+	data, _ := os.ReadFile(filepath.Join(dir, weak+".json"))
+	keystore, _ := FromJSON(data)
+	reencrypted, err := reencryptKeystore(keystore, key, "password123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reencryptedJSON, _ := reencrypted.ToJSON()
+	if err := os.WriteFile(filepath.Join(dir, weak+".json"), reencryptedJSON, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, weak+".pwd"), []byte("password123"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	strongBefore, _ := os.ReadFile(filepath.Join(dir, strong+".json"))
+
+	report, err := RotatePasswords(dir, PasswordRotationOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("RotatePasswords() error = %v", err)
+	}
+	if got := rotationResult(t, report, weak).Action; got != RotationWould {
+		t.Errorf("dry run action = %q, want %q", got, RotationWould)
+	}
+	if pw, _ := os.ReadFile(filepath.Join(dir, weak+".pwd")); string(pw) != "password123" {
+		t.Error("a dry run changed a password file")
+	}
+
+	report, err = RotatePasswords(dir, PasswordRotationOptions{})
+	if err != nil {
+		t.Fatalf("RotatePasswords() error = %v", err)
+	}
+	if report.Keystores != 2 || report.Rotated != 1 || report.Kept != 1 || report.Failed != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	result := rotationResult(t, report, weak)
+	if result.Action != RotationRotated || result.NewEntropy < DefaultMinPasswordEntropy {
+		t.Errorf("weak keystore result = %+v", result)
+	}
+	if got := openKeystore(t, dir, weak); string(got) != string(key) {
+		t.Error("the rotated keystore holds a different key")
+	}
+	if strongAfter, _ := os.ReadFile(filepath.Join(dir, strong+".json")); string(strongAfter) != string(strongBefore) {
+		t.Error("a keystore with a strong password was rewritten")
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*"+rotatingSuffix)); len(matches) != 0 {
+		t.Errorf("staged files left behind: %v", matches)
+	}
+}
+
+func TestRotatePasswords_FinishesInterruptedRotation(t *testing.T) {
+	dir := t.TempDir()
+	base := writeAuditKeystore(t, dir)
+	key := openKeystore(t, dir, base)
+
+	// A crash after the keystore was committed leaves only the staged password
+	data, _ := os.ReadFile(filepath.Join(dir, base+".json"))
+	keystore, _ := FromJSON(data)
+	rotated, err := reencryptKeystore(keystore, key, "Fresh-Password-0123456789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotatedJSON, _ := rotated.ToJSON()
+	if err := os.WriteFile(filepath.Join(dir, base+".json"), rotatedJSON, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, base+".pwd"+rotatingSuffix), []byte("Fresh-Password-0123456789"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := RotatePasswords(dir, PasswordRotationOptions{})
+	if err != nil {
+		t.Fatalf("RotatePasswords() error = %v", err)
+	}
+	if got := rotationResult(t, report, base).Action; got != RotationResumed {
+		t.Errorf("action = %q, want %q", got, RotationResumed)
+	}
+	if got := openKeystore(t, dir, base); string(got) != string(key) {
+		t.Error("the resumed keystore holds a different key")
+	}
+}
+
+func TestRotatePasswords_UndoesUncommittedRotation(t *testing.T) {
+	dir := t.TempDir()
+	base := writeAuditKeystore(t, dir)
+	for _, name := range []string{base + ".json" + rotatingSuffix, base + ".pwd" + rotatingSuffix} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("staged"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := RotatePasswords(dir, PasswordRotationOptions{})
+	if err != nil {
+		t.Fatalf("RotatePasswords() error = %v", err)
+	}
+	if got := rotationResult(t, report, base).Action; got != RotationReverted {
+		t.Errorf("action = %q, want %q", got, RotationReverted)
+	}
+	openKeystore(t, dir, base)
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*"+rotatingSuffix)); len(matches) != 0 {
+		t.Errorf("staged files left behind: %v", matches)
+	}
+}
+
+func TestRotatePasswords_KeepsWrappedPasswordsWithoutRecipient(t *testing.T) {
+	dir := t.TempDir()
+	base := writeAuditKeystore(t, dir)
+	if err := os.Rename(filepath.Join(dir, base+".pwd"), filepath.Join(dir, base+".pwd.gpg")); err != nil {
+		t.Fatal(err)
+	}
+	report, err := RotatePasswords(dir, PasswordRotationOptions{MinEntropy: 1000, Length: 200})
+	if err != nil {
+		t.Fatalf("RotatePasswords() error = %v", err)
+	}
+	if result := rotationResult(t, report, base); result.Action != RotationFailed && result.Action != RotationSkipped {
+		t.Errorf("a wrapped password was rotated without a recipient: %+v", result)
+	}
+}
+
+func TestRotatePasswords_RejectsUnreachableEntropy(t *testing.T) {
+	if _, err := RotatePasswords(t.TempDir(), PasswordRotationOptions{MinEntropy: 128, Length: 12}); err == nil {
+		t.Error("expected an error when the new passwords cannot reach the minimum entropy")
+	}
+}
@@ -0,0 +1,44 @@
+package crypto
+
+import "fmt"
+
+// LockedBuffer holds secret bytes outside the Go heap, locked into RAM where the
+// platform allows it so that they are never written to swap
+type LockedBuffer struct {
+	mapping []byte
+	data    []byte
+	locked  bool
+}
+
+// NewLockedBuffer copies secret into newly allocated memory. When the operating
+// system refuses to lock it (RLIMIT_MEMLOCK), the buffer is still returned and
+// Locked reports false.
+func NewLockedBuffer(secret []byte) (*LockedBuffer, error) {
+	mapping, locked, err := allocLocked(max(len(secret), 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate locked memory: %w", err)
+	}
+	b := &LockedBuffer{mapping: mapping, data: mapping[:len(secret)], locked: locked}
+	copy(b.data, secret)
+	return b, nil
+}
+
+// Bytes returns the secret; it is only valid until Destroy
+func (b *LockedBuffer) Bytes() []byte {
+	return b.data
+}
+
+// Locked reports whether the memory is locked into RAM
+func (b *LockedBuffer) Locked() bool {
+	return b.locked
+}
+
+// Destroy zeroes the secret and releases its memory; it may be called more than once
+func (b *LockedBuffer) Destroy() {
+	if b == nil || b.mapping == nil {
+		return
+	}
+	ClearSensitiveData(b.mapping)
+	freeLocked(b.mapping, b.locked)
+	b.mapping, b.data, b.locked = nil, nil, false
+}
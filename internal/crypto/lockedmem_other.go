@@ -0,0 +1,10 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package crypto
+
+// allocLocked falls back to heap memory where pages cannot be locked
+func allocLocked(size int) ([]byte, bool, error) {
+	return make([]byte, size), false, nil
+}
+
+func freeLocked([]byte, bool) {}
@@ -0,0 +1,34 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLockedBuffer(t *testing.T) {
+	secret := []byte("7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d")
+	b, err := NewLockedBuffer(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b.Bytes(), secret) {
+		t.Errorf("Bytes() = %q", b.Bytes())
+	}
+	secret[0] = 'x'
+	if b.Bytes()[0] != '7' {
+		t.Error("buffer shares memory with its source")
+	}
+	t.Logf("locked: %v", b.Locked())
+
+	b.Destroy()
+	b.Destroy()
+	if b.Bytes() != nil || b.Locked() {
+		t.Error("destroyed buffer still holds its secret")
+	}
+
+	empty, err := NewLockedBuffer(nil)
+	if err != nil || len(empty.Bytes()) != 0 {
+		t.Errorf("empty buffer: %v, %d bytes", err, len(empty.Bytes()))
+	}
+	empty.Destroy()
+}
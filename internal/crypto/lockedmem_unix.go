@@ -0,0 +1,26 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package crypto
+
+import (
+	"os"
+	"syscall"
+)
+
+// allocLocked maps whole anonymous pages for size bytes and tries to mlock them
+func allocLocked(size int) ([]byte, bool, error) {
+	pageSize := os.Getpagesize()
+	length := (size + pageSize - 1) / pageSize * pageSize
+	mapping, err := syscall.Mmap(-1, 0, length, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return nil, false, err
+	}
+	return mapping, syscall.Mlock(mapping) == nil, nil
+}
+
+func freeLocked(mapping []byte, locked bool) {
+	if locked {
+		_ = syscall.Munlock(mapping)
+	}
+	_ = syscall.Munmap(mapping)
+}
@@ -0,0 +1,122 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MetadataFileSuffix is the extension of wallet metadata sidecar files
+const MetadataFileSuffix = ".meta"
+
+// WalletMetadata records why a wallet exists; it never holds key material
+type WalletMetadata struct {
+	Address   string    `json:"address"`
+	Network   string    `json:"network"`
+	Label     string    `json:"label,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// SeedCounter and SeedFingerprint locate a --master-seed-file key for re-derivation
+	SeedCounter     *uint64 `json:"seed_counter,omitempty"`
+	SeedFingerprint string  `json:"seed_fingerprint,omitempty"`
+	// File is the metadata file path relative to the directory it was loaded from
+	File string `json:"-"`
+}
+
+// HasTags reports whether the metadata carries every tag in tags
+func (m WalletMetadata) HasTags(tags []string) bool {
+	return HasAllTags(m.Tags, tags)
+}
+
+// HasAllTags reports whether have contains every tag in want, ignoring case
+func HasAllTags(have, want []string) bool {
+	for _, tag := range want {
+		found := false
+		for _, candidate := range have {
+			if strings.EqualFold(candidate, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// LabelSlug converts a label into a filename-safe prefix such as "treasury-hot-wallet"
+func LabelSlug(label string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(label) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// SaveMetadataFile writes the label and tags of a wallet to <address>.meta
+func (ks *KeyStoreService) SaveMetadataFile(meta WalletMetadata) error {
+	if !ks.config.Enabled {
+		return NewKeyStoreError("save", "service", fmt.Errorf("keystore generation is disabled"))
+	}
+	if err := validateAddressForNetwork(meta.Address, meta.Network); err != nil {
+		return NewKeyStoreErrorWithAddress("validate", "address", meta.Address, err)
+	}
+	if err := ks.ensureOutputDirectory(); err != nil {
+		return NewRecoverableKeyStoreError("save", "directory", err,
+			fmt.Sprintf("Failed to create keystore directory '%s'. Please check permissions and try again.", ks.config.OutputDirectory))
+	}
+
+	data, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return NewKeyStoreErrorWithAddress("save", "metadata", meta.Address, err)
+	}
+	metaPath := filepath.Join(ks.config.OutputDirectory, ks.fileBase(meta.Address, meta.Network)+MetadataFileSuffix)
+	if err := ks.writeFileAtomic(metaPath, append(data, '\n'), 0600); err != nil {
+		return NewKeyStoreErrorWithPath("write", "metadata", metaPath, err)
+	}
+	return nil
+}
+
+// LoadWalletMetadata reads every metadata file in dir and its subdirectories,
+// ordered by creation time
+func LoadWalletMetadata(dir string) ([]WalletMetadata, error) {
+	names, err := walkWalletFiles(dir)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("load", "metadata", dir, err)
+	}
+
+	var metas []WalletMetadata
+	for _, name := range names {
+		if !strings.HasSuffix(name, MetadataFileSuffix) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, NewKeyStoreErrorWithPath("load", "metadata", path, err)
+		}
+		var meta WalletMetadata
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return nil, NewKeyStoreErrorWithPath("load", "metadata", path, fmt.Errorf("invalid metadata file: %w", err))
+		}
+		meta.File = name
+		metas = append(metas, meta)
+	}
+
+	sort.SliceStable(metas, func(i, j int) bool {
+		return metas[i].CreatedAt.Before(metas[j].CreatedAt)
+	})
+	return metas, nil
+}
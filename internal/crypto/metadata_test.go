@@ -0,0 +1,69 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestLabelSlug(t *testing.T) {
+	for label, expected := range map[string]string{
+		"treasury hot wallet": "treasury-hot-wallet",
+		"  Ops / Payroll #2 ": "ops-payroll-2",
+		"***":                 "",
+		"":                    "",
+	} {
+		if got := LabelSlug(label); got != expected {
+			t.Errorf("LabelSlug(%q) = %q, expected %q", label, got, expected)
+		}
+	}
+}
+
+func TestLabelledKeystoreFiles(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := ethcrypto.PubkeyToAddress(key.PublicKey).Hex()
+	dir := t.TempDir()
+
+	service := NewKeyStoreService(KeyStoreConfig{OutputDirectory: dir, Enabled: true, KDF: "pbkdf2", FilenameLabel: "Treasury Hot"})
+	if err := service.SaveKeyStoreFiles(hex.EncodeToString(ethcrypto.FromECDSA(key)), address, "ethereum"); err != nil {
+		t.Fatalf("SaveKeyStoreFiles() error = %v", err)
+	}
+	meta := WalletMetadata{Address: address, Network: "ethereum", Label: "Treasury Hot", Tags: []string{"team:ops"}, CreatedAt: time.Now()}
+	if err := service.SaveMetadataFile(meta); err != nil {
+		t.Fatalf("SaveMetadataFile() error = %v", err)
+	}
+
+	base := "treasury-hot_0x" + strings.ToLower(address[2:])
+	for _, ext := range []string{".json", ".pwd", MetadataFileSuffix} {
+		if _, err := os.Stat(filepath.Join(dir, base+ext)); err != nil {
+			t.Errorf("expected %s%s: %v", base, ext, err)
+		}
+	}
+
+	report, err := AuditKeystoreDirectory(dir, KeystoreAuditOptions{VerifyMAC: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || report.Passed != 1 {
+		t.Errorf("labelled keystores should pass the audit: %+v", report)
+	}
+
+	metas, err := LoadWalletMetadata(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metas) != 1 || metas[0].Label != "Treasury Hot" || metas[0].File != base+MetadataFileSuffix {
+		t.Fatalf("unexpected metadata %+v", metas)
+	}
+	if !metas[0].HasTags([]string{"TEAM:OPS"}) || metas[0].HasTags([]string{"team:ops", "cold"}) {
+		t.Error("HasTags() should require every tag, ignoring case")
+	}
+}
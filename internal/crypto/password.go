@@ -4,7 +4,10 @@ import (
 	"bloco-eth/pkg/errors"
 	"crypto/rand"
 	"fmt"
+	"math"
 	"strings"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
 )
 
 // PasswordCharset defines the character sets used for password generation
@@ -71,32 +74,27 @@ func (pg *PasswordGenerator) GenerateSecurePassword() (string, error) {
 	password := make([]byte, pg.minLength)
 
 	// Ensure at least one character from each required set
-	// Requirement 3.2: at least one lowercase
-	if err := pg.setRandomCharFromSet(password, 0, pg.charset.Lowercase); err != nil {
-		return "", errors.WrapError(err, errors.ErrorTypeCrypto,
-			"generate_secure_password", "failed to add lowercase character")
-	}
-
-	// Requirement 3.3: at least one uppercase
-	if err := pg.setRandomCharFromSet(password, 1, pg.charset.Uppercase); err != nil {
-		return "", errors.WrapError(err, errors.ErrorTypeCrypto,
-			"generate_secure_password", "failed to add uppercase character")
-	}
-
-	// Requirement 3.4: at least one number
-	if err := pg.setRandomCharFromSet(password, 2, pg.charset.Numbers); err != nil {
-		return "", errors.WrapError(err, errors.ErrorTypeCrypto,
-			"generate_secure_password", "failed to add number character")
-	}
-
-	// Requirement 3.5: at least one special character
-	if err := pg.setRandomCharFromSet(password, 3, pg.charset.Special); err != nil {
-		return "", errors.WrapError(err, errors.ErrorTypeCrypto,
-			"generate_secure_password", "failed to add special character")
+	// Requirements 3.2-3.5: at least one lowercase, uppercase, number and special
+	// character; a policy may leave a class empty, which drops its requirement
+	position := 0
+	for _, class := range []struct{ name, chars string }{
+		{"lowercase", pg.charset.Lowercase},
+		{"uppercase", pg.charset.Uppercase},
+		{"number", pg.charset.Numbers},
+		{"special", pg.charset.Special},
+	} {
+		if class.chars == "" {
+			continue
+		}
+		if err := pg.setRandomCharFromSet(password, position, class.chars); err != nil {
+			return "", errors.WrapError(err, errors.ErrorTypeCrypto,
+				"generate_secure_password", fmt.Sprintf("failed to add %s character", class.name))
+		}
+		position++
 	}
 
 	// Fill remaining positions with random characters from all sets
-	for i := 4; i < len(password); i++ {
+	for i := position; i < len(password); i++ {
 		if err := pg.setRandomCharFromSet(password, i, allChars); err != nil {
 			return "", errors.WrapError(err, errors.ErrorTypeCrypto,
 				"generate_secure_password", "failed to add random character")
@@ -213,18 +211,18 @@ func (pg *PasswordGenerator) ValidatePassword(password string) error {
 		}
 	}
 
-	// Collect missing requirements
+	// Collect missing requirements of the classes in the charset
 	var missing []string
-	if !hasLower {
+	if !hasLower && pg.charset.Lowercase != "" {
 		missing = append(missing, "lowercase letter")
 	}
-	if !hasUpper {
+	if !hasUpper && pg.charset.Uppercase != "" {
 		missing = append(missing, "uppercase letter")
 	}
-	if !hasNumber {
+	if !hasNumber && pg.charset.Numbers != "" {
 		missing = append(missing, "number")
 	}
-	if !hasSpecial {
+	if !hasSpecial && pg.charset.Special != "" {
 		missing = append(missing, "special character")
 	}
 
@@ -278,3 +276,49 @@ func (pg *PasswordGenerator) SetCharset(charset PasswordCharset) error {
 	pg.charset = charset
 	return nil
 }
+
+// PasswordEntropy estimates the bits of entropy of a password from the character
+// classes it uses. Characters that repeat or continue a run of their neighbour,
+// as in "aaaa" or "1234", add nothing. A password made of diceware words is
+// scored by its words instead.
+func PasswordEntropy(password string) float64 {
+	if words := dicewareWords(password); words > 0 {
+		return float64(words) * math.Log2(float64(len(wordlists.English)))
+	}
+
+	var lower, upper, digit, other bool
+	effective := 0
+	for i := 0; i < len(password); i++ {
+		c := password[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= '0' && c <= '9':
+			digit = true
+		default:
+			other = true
+		}
+		if i > 0 {
+			if step := int(c) - int(password[i-1]); step >= -1 && step <= 1 {
+				continue
+			}
+		}
+		effective++
+	}
+
+	pool := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{{lower, 26}, {upper, 26}, {digit, 10}, {other, 33}} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(effective) * math.Log2(float64(pool))
+}
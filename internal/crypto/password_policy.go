@@ -0,0 +1,264 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+// Password policy modes
+const (
+	PasswordModeRandom   = "random"
+	PasswordModeDiceware = "diceware"
+)
+
+const (
+	// minDicewareWords keeps word passwords above 50 bits, like the 12-character minimum
+	minDicewareWords     = 5
+	defaultDicewareWords = 6
+	// ambiguousChars are the characters dropped by no-ambiguous
+	ambiguousChars = "Il1|O0o"
+)
+
+// PasswordPolicy describes how keystore passwords are generated: random characters
+// from a set of classes, or diceware words drawn from the 2048-word BIP-39 English
+// list. The zero policy is the default of 12 random characters from every class.
+type PasswordPolicy struct {
+	Mode string
+	// Length is the number of characters, or of words in diceware mode
+	Length int
+	// Classes are the character classes of random passwords; a password holds at
+	// least one character of each non-empty class
+	Classes PasswordCharset
+	// ExcludeAmbiguous drops characters that look alike, such as l, 1 and I
+	ExcludeAmbiguous bool
+	// Separator joins diceware words
+	Separator string
+}
+
+// ParsePasswordPolicy parses a policy spec:
+//
+//	random[:<length>][,classes=lower+upper+digits+symbols][,no-ambiguous]
+//	diceware[:<words>][,separator=<s>]
+func ParsePasswordPolicy(spec string) (PasswordPolicy, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		spec = PasswordModeRandom
+	}
+	head, options, _ := strings.Cut(spec, ",")
+	mode, count, hasCount := strings.Cut(head, ":")
+	policy := PasswordPolicy{Mode: mode}
+	switch mode {
+	case PasswordModeRandom:
+		policy.Length = 12
+		policy.Classes = DefaultPasswordCharset()
+	case PasswordModeDiceware:
+		policy.Length = defaultDicewareWords
+		policy.Separator = "-"
+	default:
+		return PasswordPolicy{}, fmt.Errorf("unknown password policy %q (valid: random[:<length>], diceware[:<words>])", mode)
+	}
+	if hasCount {
+		n, err := strconv.Atoi(count)
+		if err != nil || n <= 0 {
+			return PasswordPolicy{}, fmt.Errorf("invalid password policy length %q", count)
+		}
+		policy.Length = n
+	}
+
+	if options != "" {
+		for _, option := range strings.Split(options, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+			switch {
+			case mode == PasswordModeRandom && key == "no-ambiguous":
+				policy.ExcludeAmbiguous = true
+			case mode == PasswordModeRandom && key == "classes":
+				classes, err := parsePasswordClasses(value)
+				if err != nil {
+					return PasswordPolicy{}, err
+				}
+				policy.Classes = classes
+			case mode == PasswordModeDiceware && key == "separator":
+				policy.Separator = value
+			default:
+				return PasswordPolicy{}, fmt.Errorf("unknown %s password policy option %q", mode, option)
+			}
+		}
+	}
+	return policy, policy.Validate()
+}
+
+// parsePasswordClasses parses a "+" separated list of character classes
+func parsePasswordClasses(list string) (PasswordCharset, error) {
+	all := DefaultPasswordCharset()
+	var classes PasswordCharset
+	for _, class := range strings.Split(list, "+") {
+		switch class {
+		case "lower":
+			classes.Lowercase = all.Lowercase
+		case "upper":
+			classes.Uppercase = all.Uppercase
+		case "digits":
+			classes.Numbers = all.Numbers
+		case "symbols":
+			classes.Special = all.Special
+		default:
+			return PasswordCharset{}, fmt.Errorf("unknown character class %q (valid: lower, upper, digits, symbols)", class)
+		}
+	}
+	return classes, nil
+}
+
+// Validate checks the policy generates passwords of at least 12 characters or 5 words
+func (p PasswordPolicy) Validate() error {
+	switch p.Mode {
+	case "":
+		return nil
+	case PasswordModeRandom:
+		if p.Length < 12 {
+			return fmt.Errorf("random passwords need at least 12 characters, got %d", p.Length)
+		}
+		if len(p.charset()) == 0 {
+			return fmt.Errorf("random passwords need at least one character class")
+		}
+	case PasswordModeDiceware:
+		if p.Length < minDicewareWords {
+			return fmt.Errorf("diceware passwords need at least %d words, got %d", minDicewareWords, p.Length)
+		}
+	default:
+		return fmt.Errorf("unknown password policy %q", p.Mode)
+	}
+	return nil
+}
+
+// resolved returns the policy, or the default one for the zero policy
+func (p PasswordPolicy) resolved() PasswordPolicy {
+	if p.Mode == "" {
+		return PasswordPolicy{Mode: PasswordModeRandom, Length: 12, Classes: DefaultPasswordCharset()}
+	}
+	return p
+}
+
+// String returns the spec of the policy
+func (p PasswordPolicy) String() string {
+	p = p.resolved()
+	if p.Mode == PasswordModeDiceware {
+		spec := fmt.Sprintf("%s:%d", p.Mode, p.Length)
+		if p.Separator != "-" {
+			spec += ",separator=" + p.Separator
+		}
+		return spec
+	}
+	spec := fmt.Sprintf("%s:%d", p.Mode, p.Length)
+	if p.Classes != DefaultPasswordCharset() {
+		var classes []string
+		for _, class := range []struct{ name, chars string }{
+			{"lower", p.Classes.Lowercase}, {"upper", p.Classes.Uppercase},
+			{"digits", p.Classes.Numbers}, {"symbols", p.Classes.Special},
+		} {
+			if class.chars != "" {
+				classes = append(classes, class.name)
+			}
+		}
+		spec += ",classes=" + strings.Join(classes, "+")
+	}
+	if p.ExcludeAmbiguous {
+		spec += ",no-ambiguous"
+	}
+	return spec
+}
+
+// Entropy returns the bits of entropy of a password the policy generates
+func (p PasswordPolicy) Entropy() float64 {
+	p = p.resolved()
+	if p.Mode == PasswordModeDiceware {
+		return float64(p.Length) * math.Log2(float64(len(wordlists.English)))
+	}
+	return float64(p.Length) * math.Log2(float64(len(p.charset())))
+}
+
+// Generate returns a new password under the policy
+func (p PasswordPolicy) Generate() (string, error) {
+	p = p.resolved()
+	if p.Mode == PasswordModeDiceware {
+		return generateDiceware(p.Length, p.Separator)
+	}
+	return NewPasswordGeneratorWithConfig(p.Length, p.classes()).GenerateSecurePassword()
+}
+
+// classes returns the character classes of the policy without the ambiguous characters
+func (p PasswordPolicy) classes() PasswordCharset {
+	if !p.ExcludeAmbiguous {
+		return p.Classes
+	}
+	drop := func(set string) string {
+		return strings.Map(func(r rune) rune {
+			if strings.ContainsRune(ambiguousChars, r) {
+				return -1
+			}
+			return r
+		}, set)
+	}
+	return PasswordCharset{
+		Lowercase: drop(p.Classes.Lowercase),
+		Uppercase: drop(p.Classes.Uppercase),
+		Numbers:   drop(p.Classes.Numbers),
+		Special:   drop(p.Classes.Special),
+	}
+}
+
+// charset returns every character a random password of the policy may hold
+func (p PasswordPolicy) charset() string {
+	classes := p.classes()
+	return classes.Lowercase + classes.Uppercase + classes.Numbers + classes.Special
+}
+
+// dicewareWords returns how many words password has when it is two or more words
+// of the BIP-39 English list joined by one separator, and 0 otherwise
+func dicewareWords(password string) int {
+	i := strings.IndexFunc(password, func(r rune) bool { return r < 'a' || r > 'z' })
+	if i <= 0 {
+		return 0
+	}
+	separator, _ := utf8.DecodeRuneInString(password[i:])
+	words := strings.Split(password, string(separator))
+	if len(words) < 2 {
+		return 0
+	}
+	for _, word := range words {
+		if _, ok := englishWords()[word]; !ok {
+			return 0
+		}
+	}
+	return len(words)
+}
+
+// englishWords indexes the BIP-39 English list
+var englishWords = sync.OnceValue(func() map[string]struct{} {
+	index := make(map[string]struct{}, len(wordlists.English))
+	for _, word := range wordlists.English {
+		index[word] = struct{}{}
+	}
+	return index
+})
+
+// generateDiceware joins n words drawn uniformly from the BIP-39 English list
+func generateDiceware(n int, separator string) (string, error) {
+	words := make([]string, n)
+	buf := make([]byte, 2)
+	for i := range words {
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to generate random bytes: %w", err)
+		}
+		// 2048 divides 65536, so the modulo keeps the draw uniform
+		words[i] = wordlists.English[binary.BigEndian.Uint16(buf)%uint16(len(wordlists.English))]
+	}
+	return strings.Join(words, separator), nil
+}
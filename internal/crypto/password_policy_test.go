@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+func TestParsePasswordPolicy(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		entropy float64
+	}{
+		{"", "random:12", 12 * math.Log2(88)},
+		{"random:20", "random:20", 20 * math.Log2(88)},
+		{"random:16,classes=lower+digits", "random:16,classes=lower+digits", 16 * math.Log2(36)},
+		{"random:16,no-ambiguous", "random:16,no-ambiguous", 16 * math.Log2(81)},
+		{"diceware:6", "diceware:6", 66},
+		{"diceware", "diceware:6", 66},
+		{"diceware:8,separator=.", "diceware:8,separator=.", 88},
+	}
+	for _, tt := range tests {
+		policy, err := ParsePasswordPolicy(tt.spec)
+		if err != nil {
+			t.Errorf("ParsePasswordPolicy(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if got := policy.String(); got != tt.want {
+			t.Errorf("ParsePasswordPolicy(%q).String() = %q, want %q", tt.spec, got, tt.want)
+		}
+		if got := policy.Entropy(); math.Abs(got-tt.entropy) > 0.01 {
+			t.Errorf("ParsePasswordPolicy(%q).Entropy() = %.2f, want %.2f", tt.spec, got, tt.entropy)
+		}
+	}
+
+	for _, spec := range []string{"words:6", "random:8", "diceware:3", "random:x", "random:16,classes=emoji", "diceware:6,no-ambiguous"} {
+		if _, err := ParsePasswordPolicy(spec); err == nil {
+			t.Errorf("ParsePasswordPolicy(%q) expected an error", spec)
+		}
+	}
+}
+
+func TestPasswordPolicy_Generate(t *testing.T) {
+	policy, _ := ParsePasswordPolicy("random:16,classes=lower+digits,no-ambiguous")
+	for i := 0; i < 50; i++ {
+		password, err := policy.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(password) != 16 || strings.ContainsAny(password, ambiguousChars+"ABC!@#") {
+			t.Fatalf("password %q breaks the policy %s", password, policy)
+		}
+		if !strings.ContainsAny(password, "abcdefghijkmnpqrstuvwxyz") || !strings.ContainsAny(password, "23456789") {
+			t.Fatalf("password %q lacks a character class", password)
+		}
+	}
+
+	policy, _ = ParsePasswordPolicy("diceware:6")
+	password, err := policy.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := strings.Split(password, "-")
+	if len(words) != 6 {
+		t.Fatalf("diceware password %q has %d words, want 6", password, len(words))
+	}
+	list := make(map[string]bool, len(wordlists.English))
+	for _, word := range wordlists.English {
+		list[word] = true
+	}
+	for _, word := range words {
+		if !list[word] {
+			t.Errorf("word %q is not in the wordlist", word)
+		}
+	}
+
+	// The zero policy keeps the default generator
+	if password, err := (PasswordPolicy{}).Generate(); err != nil || NewPasswordGenerator().ValidatePassword(password) != nil {
+		t.Errorf("zero policy generated %q, %v", password, err)
+	}
+}
+
+func TestKeyStoreService_PasswordPolicy(t *testing.T) {
+	policy, _ := ParsePasswordPolicy("diceware:5,separator=_")
+	service := NewKeyStoreService(KeyStoreConfig{Enabled: true, KDF: "pbkdf2", PasswordPolicy: policy, OutputDirectory: t.TempDir()})
+	keystore, password, err := service.GenerateKeyStore(
+		"1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef", "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23", "ethereum")
+	if err != nil {
+		t.Fatalf("GenerateKeyStore() error = %v", err)
+	}
+	if words := strings.Split(password, "_"); len(words) != 5 {
+		t.Errorf("password %q does not follow %s", password, policy)
+	}
+	if _, err := DecryptPrivateKey(keystore, password); err != nil {
+		t.Errorf("keystore does not open with its password: %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Password protection methods for generated keystore passwords
+const (
+	PasswordProtectionNone = "none"
+	PasswordProtectionGPG  = "gpg"
+	PasswordProtectionAge  = "age"
+)
+
+// passwordFileSuffixes lists password file suffixes by protection method
+var passwordFileSuffixes = map[string]string{
+	PasswordProtectionNone: ".pwd",
+	PasswordProtectionGPG:  ".pwd.gpg",
+	PasswordProtectionAge:  ".pwd.age",
+}
+
+// PasswordProtection encrypts generated keystore passwords at rest for a recipient
+type PasswordProtection struct {
+	Method    string
+	Recipient string
+}
+
+// ParsePasswordProtection parses "none", "gpg:<recipient>" or "age:<recipient>"
+func ParsePasswordProtection(spec string) (PasswordProtection, error) {
+	if spec == "" || spec == PasswordProtectionNone {
+		return PasswordProtection{Method: PasswordProtectionNone}, nil
+	}
+
+	method, recipient, ok := strings.Cut(spec, ":")
+	if !ok || recipient == "" || (method != PasswordProtectionGPG && method != PasswordProtectionAge) {
+		return PasswordProtection{}, fmt.Errorf("invalid password protection %q (valid: none, gpg:<recipient>, age:<recipient>)", spec)
+	}
+	return PasswordProtection{Method: method, Recipient: recipient}, nil
+}
+
+// String returns the protection in flag syntax
+func (p PasswordProtection) String() string {
+	if p.Method == "" || p.Method == PasswordProtectionNone {
+		return PasswordProtectionNone
+	}
+	return p.Method + ":" + p.Recipient
+}
+
+// Suffix returns the password file suffix for this protection
+func (p PasswordProtection) Suffix() string {
+	if suffix, ok := passwordFileSuffixes[p.Method]; ok {
+		return suffix
+	}
+	return passwordFileSuffixes[PasswordProtectionNone]
+}
+
+// CheckAvailable verifies that the tool needed for this protection is installed
+func (p PasswordProtection) CheckAvailable() error {
+	if p.Method == "" || p.Method == PasswordProtectionNone {
+		return nil
+	}
+	if _, err := exec.LookPath(p.Method); err != nil {
+		return fmt.Errorf("%s is required for --password-protection %s but was not found in PATH", p.Method, p)
+	}
+	return nil
+}
+
+// Wrap encrypts a password for the recipient. With no protection it is returned unchanged.
+func (p PasswordProtection) Wrap(password []byte) ([]byte, error) {
+	switch p.Method {
+	case "", PasswordProtectionNone:
+		return password, nil
+	case PasswordProtectionGPG:
+		return runPasswordTool(password, "gpg", "--batch", "--yes", "--quiet", "--trust-model", "always",
+			"--encrypt", "--recipient", p.Recipient)
+	case PasswordProtectionAge:
+		return runPasswordTool(password, "age", "--encrypt", "--recipient", p.Recipient)
+	default:
+		return nil, fmt.Errorf("unsupported password protection: %s", p.Method)
+	}
+}
+
+// PasswordFileProtection returns the protection method implied by a password file name
+func PasswordFileProtection(path string) string {
+	switch {
+	case strings.HasSuffix(path, passwordFileSuffixes[PasswordProtectionGPG]):
+		return PasswordProtectionGPG
+	case strings.HasSuffix(path, passwordFileSuffixes[PasswordProtectionAge]):
+		return PasswordProtectionAge
+	default:
+		return PasswordProtectionNone
+	}
+}
+
+// FindPasswordFile returns the password file stored next to a keystore with the
+// given base name, trying the plaintext and wrapped variants in turn
+func FindPasswordFile(dir, base string) (string, bool) {
+	for _, method := range []string{PasswordProtectionNone, PasswordProtectionGPG, PasswordProtectionAge} {
+		path := filepath.Join(dir, base+passwordFileSuffixes[method])
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// TrimPasswordFileSuffix strips any password file suffix, reporting whether one was found
+func TrimPasswordFileSuffix(name string) (string, bool) {
+	for _, method := range []string{PasswordProtectionGPG, PasswordProtectionAge, PasswordProtectionNone} {
+		if base, ok := strings.CutSuffix(name, passwordFileSuffixes[method]); ok {
+			return base, true
+		}
+	}
+	return name, false
+}
+
+// ReadPasswordFile reads a password file, decrypting wrapped passwords with gpg
+// or with age and ageIdentity
+func ReadPasswordFile(path, ageIdentity string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	switch PasswordFileProtection(path) {
+	case PasswordProtectionGPG:
+		data, err = runPasswordTool(data, "gpg", "--batch", "--quiet", "--decrypt")
+	case PasswordProtectionAge:
+		if ageIdentity == "" {
+			return "", fmt.Errorf("%s is age encrypted; an age identity file is required", filepath.Base(path))
+		}
+		data, err = runPasswordTool(data, "age", "--decrypt", "--identity", ageIdentity)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// runPasswordTool pipes input through an external encryption tool
+func runPasswordTool(input []byte, name string, args ...string) ([]byte, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s is required for password protection but was not found in PATH", name)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s failed: %v: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,134 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestParsePasswordProtection(t *testing.T) {
+	tests := []struct {
+		spec    string
+		method  string
+		suffix  string
+		wantErr bool
+	}{
+		{spec: "", method: PasswordProtectionNone, suffix: ".pwd"},
+		{spec: "none", method: PasswordProtectionNone, suffix: ".pwd"},
+		{spec: "gpg:ops@example.com", method: PasswordProtectionGPG, suffix: ".pwd.gpg"},
+		{spec: "age:age1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs3290gq", method: PasswordProtectionAge, suffix: ".pwd.age"},
+		{spec: "gpg:", wantErr: true},
+		{spec: "pgp:ops@example.com", wantErr: true},
+		{spec: "age", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			protection, err := ParsePasswordProtection(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePasswordProtection(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if protection.Method != tt.method || protection.Suffix() != tt.suffix {
+				t.Errorf("got method %q suffix %q, expected %q %q", protection.Method, protection.Suffix(), tt.method, tt.suffix)
+			}
+		})
+	}
+}
+
+func TestTrimPasswordFileSuffix(t *testing.T) {
+	for name, expected := range map[string]string{
+		"0xabc.pwd":     "0xabc",
+		"0xabc.pwd.gpg": "0xabc",
+		"0xabc.pwd.age": "0xabc",
+	} {
+		if base, ok := TrimPasswordFileSuffix(name); !ok || base != expected {
+			t.Errorf("TrimPasswordFileSuffix(%q) = %q, %v", name, base, ok)
+		}
+	}
+	if _, ok := TrimPasswordFileSuffix("0xabc.json"); ok {
+		t.Error("keystore files have no password suffix")
+	}
+}
+
+func TestReadPasswordFile_AgeRequiresIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "0xabc.pwd.age")
+	if err := os.WriteFile(path, []byte("ciphertext"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadPasswordFile(path, ""); err == nil || !strings.Contains(err.Error(), "identity") {
+		t.Errorf("expected identity error, got %v", err)
+	}
+}
+
+func TestPasswordProtection_GPGRoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg not installed")
+	}
+
+	home, err := os.MkdirTemp("", "bloco-gpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GNUPGHOME", home)
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--kill", "gpg-agent").Run()
+		_ = os.RemoveAll(home)
+	})
+
+	recipient := "bloco-test@example.invalid"
+	genKey := exec.Command("gpg", "--batch", "--passphrase", "", "--quick-gen-key", recipient, "future-default", "default", "never")
+	if out, err := genKey.CombinedOutput(); err != nil {
+		t.Skipf("cannot create gpg test key: %v: %s", err, out)
+	}
+
+	protection, err := ParsePasswordProtection("gpg:" + recipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ethcrypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := ethcrypto.PubkeyToAddress(key.PublicKey).Hex()
+	dir := t.TempDir()
+	service := NewKeyStoreService(KeyStoreConfig{OutputDirectory: dir, Enabled: true, KDF: "pbkdf2", PasswordProtection: protection})
+	if err := service.SaveKeyStoreFiles(hex.EncodeToString(ethcrypto.FromECDSA(key)), address, "ethereum"); err != nil {
+		t.Fatalf("SaveKeyStoreFiles() error = %v", err)
+	}
+
+	base := "0x" + strings.ToLower(address[2:])
+	if _, err := os.Stat(filepath.Join(dir, base+".pwd")); !os.IsNotExist(err) {
+		t.Error("plaintext password file should not be written")
+	}
+	passwordPath, ok := FindPasswordFile(dir, base)
+	if !ok || PasswordFileProtection(passwordPath) != PasswordProtectionGPG {
+		t.Fatalf("wrapped password file not found: %q", passwordPath)
+	}
+
+	wrapped, _ := os.ReadFile(passwordPath)
+	password, err := ReadPasswordFile(passwordPath, "")
+	if err != nil {
+		t.Fatalf("ReadPasswordFile() error = %v", err)
+	}
+	if password == "" || bytes.Contains(wrapped, []byte(password)) {
+		t.Error("password file must hold the encrypted password")
+	}
+
+	report, err := AuditKeystoreDirectory(dir, KeystoreAuditOptions{VerifyMAC: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.OK() || report.Passed != 1 {
+		t.Errorf("audit should verify keystores with wrapped passwords: %+v", report)
+	}
+}
@@ -519,3 +519,35 @@ func TestPasswordGeneration_EdgeCases(t *testing.T) {
 		t.Errorf("Password missing required special '!': %s", password)
 	}
 }
+
+func TestPasswordEntropy_Estimate(t *testing.T) {
+	weak := []string{"", "password", "aaaaaaaaaaaaaaaa", "abcdefghijklmnop", "12345678"}
+	for _, password := range weak {
+		if bits := PasswordEntropy(password); bits >= 64 {
+			t.Errorf("PasswordEntropy(%q) = %.1f, want under 64 bits", password, bits)
+		}
+	}
+	if PasswordEntropy("aaaa") >= PasswordEntropy("aZ3!") {
+		t.Error("a password with more character classes should score higher")
+	}
+
+	generated, err := NewPasswordGeneratorWithConfig(20, DefaultPasswordCharset()).GenerateSecurePassword()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bits := PasswordEntropy(generated); bits < 64 {
+		t.Errorf("a generated 20-character password scored %.1f bits", bits)
+	}
+}
+
+func TestPasswordEntropy_DicewareWords(t *testing.T) {
+	if bits := PasswordEntropy("language-soon-target-damp-mystery-space"); bits != 66 {
+		t.Errorf("six diceware words scored %.1f bits, want 66", bits)
+	}
+	if bits := PasswordEntropy("abandon ability able about"); bits != 44 {
+		t.Errorf("four diceware words scored %.1f bits, want 44", bits)
+	}
+	if bits := PasswordEntropy("notaword-soon"); bits == 22 {
+		t.Error("a password with a word outside the list was scored as diceware")
+	}
+}
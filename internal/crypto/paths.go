@@ -0,0 +1,57 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxPathLength is the longest Windows path usable without the extended-length
+// prefix; CreateDirectory allows 12 characters fewer than MAX_PATH
+const maxPathLength = 260 - 12
+
+// extendedPath returns the extended-length form of an absolute Windows path, which
+// lifts the MAX_PATH limit: C:\dir becomes \\?\C:\dir and \\server\share\dir
+// becomes \\?\UNC\server\share\dir. Other paths are returned unchanged.
+func extendedPath(path string) string {
+	switch {
+	case strings.HasPrefix(path, `\\?\`), strings.HasPrefix(path, `\\.\`):
+		return path
+	case strings.HasPrefix(path, `\\`):
+		return `\\?\UNC\` + path[2:]
+	case len(path) >= 3 && path[1] == ':' && path[2] == '\\' && isDriveLetter(path[0]):
+		return `\\?\` + path
+	}
+	return path
+}
+
+func isDriveLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// IsDiskFull reports whether err is an out-of-space or disk quota error
+func IsDiskFull(err error) bool {
+	for _, target := range diskFullErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// newWriteError reports a failed write of a keystore output file. A full disk is
+// not recoverable, since retrying cannot free space, and is named as the cause.
+func newWriteError(component, path, what string, err error) *KeyStoreError {
+	if IsDiskFull(err) {
+		return &KeyStoreError{
+			Operation:  "save",
+			Component:  component,
+			Path:       path,
+			Underlying: err,
+			UserMessage: fmt.Sprintf("Failed to save %s to '%s': the disk is full. Free up space or choose another keystore directory.",
+				what, path),
+		}
+	}
+	return NewRecoverableKeyStoreError("save", component, err,
+		fmt.Sprintf("Failed to save %s to '%s'. Please check disk space and permissions.", what, path))
+}
@@ -0,0 +1,35 @@
+package crypto
+
+import (
+	"fmt"
+	"syscall"
+	"testing"
+)
+
+func TestExtendedPath(t *testing.T) {
+	tests := map[string]string{
+		`C:\Users\me\keys`:             `\\?\C:\Users\me\keys`,
+		`d:\keys`:                      `\\?\d:\keys`,
+		`\\fileserver\team\vault\keys`: `\\?\UNC\fileserver\team\vault\keys`,
+		`\\?\C:\already\extended`:      `\\?\C:\already\extended`,
+		`\\?\UNC\fileserver\team\keys`: `\\?\UNC\fileserver\team\keys`,
+		`\\.\PhysicalDrive0`:           `\\.\PhysicalDrive0`,
+		`relative\keys`:                `relative\keys`,
+		`C:relative`:                   `C:relative`,
+		`/home/me/keys`:                `/home/me/keys`,
+	}
+	for path, want := range tests {
+		if got := extendedPath(path); got != want {
+			t.Errorf("extendedPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestIsDiskFull(t *testing.T) {
+	if !IsDiskFull(fmt.Errorf("write: %w", syscall.ENOSPC)) {
+		t.Error("a wrapped ENOSPC should be a full disk")
+	}
+	if IsDiskFull(syscall.EACCES) || IsDiskFull(nil) {
+		t.Error("only out-of-space errors are a full disk")
+	}
+}
@@ -0,0 +1,19 @@
+//go:build unix
+
+package crypto
+
+import (
+	"path/filepath"
+	"syscall"
+)
+
+// unixPermissions reports whether file modes are checked after writing
+const unixPermissions = true
+
+// diskFullErrors are the errors IsDiskFull matches
+var diskFullErrors = []error{syscall.ENOSPC, syscall.EDQUOT}
+
+// longPath returns path cleaned for file system calls
+func longPath(path string) string {
+	return filepath.Clean(path)
+}
@@ -0,0 +1,45 @@
+//go:build windows
+
+package crypto
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// unixPermissions is false: Windows reports only the read-only attribute as
+// permission bits, so file modes are not checked after writing
+const unixPermissions = false
+
+// diskFullErrors are the errors IsDiskFull matches
+var diskFullErrors = []error{
+	windows.ERROR_DISK_FULL,
+	windows.ERROR_HANDLE_DISK_FULL,
+	windows.ERROR_DISK_QUOTA_EXCEEDED,
+	syscall.ENOSPC,
+	syscall.EDQUOT,
+}
+
+// longPath returns path cleaned for file system calls. Paths too long for MAX_PATH,
+// including relative ones, which Windows never extends, are made absolute in their
+// extended-length form; paths given in that form are cleaned after the prefix, as
+// Windows does not resolve "." and ".." in them.
+func longPath(path string) string {
+	for _, prefix := range []string{`\\?\UNC\`, `\\?\`} {
+		if rest, ok := strings.CutPrefix(path, prefix); ok {
+			return prefix + filepath.Clean(rest)
+		}
+	}
+	clean := filepath.Clean(path)
+	if len(clean) < maxPathLength && filepath.IsAbs(clean) {
+		return clean
+	}
+	abs, err := filepath.Abs(clean)
+	if err != nil || len(abs) < maxPathLength {
+		return clean
+	}
+	return extendedPath(abs)
+}
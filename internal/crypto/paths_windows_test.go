@@ -0,0 +1,41 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/sys/windows"
+)
+
+func TestLongPath_Windows(t *testing.T) {
+	if got := longPath(`\\?\C:\keys\.\deep\..\wallets`); got != `\\?\C:\keys\wallets` {
+		t.Errorf("longPath() of an extended path = %q", got)
+	}
+	if got := longPath(`C:\keys\..\wallets`); got != `C:\wallets` {
+		t.Errorf("longPath() of a short path = %q", got)
+	}
+
+	deep := strings.Repeat(`nested-directory\`, 20) + "keys"
+	got := longPath(deep)
+	if !strings.HasPrefix(got, `\\?\`) || !strings.HasSuffix(got, deep) {
+		t.Errorf("longPath() of a long relative path = %q, want its extended absolute form", got)
+	}
+	if !IsDiskFull(windows.ERROR_DISK_FULL) {
+		t.Error("ERROR_DISK_FULL should be a full disk")
+	}
+}
+
+func TestSaveKeyStoreFiles_DeepDirectory(t *testing.T) {
+	service, keystore, password := newFaultKeystore(t)
+	dir := filepath.Join(service.GetConfig().OutputDirectory, strings.Repeat("nested-directory-", 20))
+	service.SetOutputDirectory(dir)
+	if err := service.SaveKeyStoreFilesToDisk(faultAddress, keystore, password, "ethereum", ""); err != nil {
+		t.Fatalf("SaveKeyStoreFilesToDisk() in a %d character directory = %v", len(dir), err)
+	}
+	keystorePath, _ := service.GetKeystoreFilePath(faultAddress)
+	if _, err := os.Stat(longPath(keystorePath)); err != nil {
+		t.Error(err)
+	}
+}
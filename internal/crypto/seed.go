@@ -0,0 +1,170 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/hkdf"
+)
+
+// MinMasterSeedBytes is the shortest master seed accepted, 256 bits
+const MinMasterSeedBytes = 32
+
+// HKDF info strings; the version suffix changes only with the derivation itself
+const (
+	masterSeedKeyInfo         = "bloco-eth master seed key v1"
+	masterSeedFingerprintInfo = "bloco-eth master seed fingerprint v1"
+)
+
+// masterSeedKeyBytes is the HKDF output reduced to a key: 128 bits above the size
+// of the group order make the bias of the reduction negligible
+const masterSeedKeyBytes = 48
+
+// MasterSeedCounters is the keyspace a master seed search walks: every uint64 counter
+var MasterSeedCounters = &KeyRange{
+	Start:  new(big.Int),
+	End:    new(big.Int).SetUint64(math.MaxUint64),
+	Stride: big.NewInt(1),
+}
+
+// MasterSeed derives candidate private keys from a high-entropy seed and a counter,
+// so every key of a batch can be derived again from the seed and its counter alone
+type MasterSeed struct {
+	seed []byte
+}
+
+// NewMasterSeed checks seed is long enough and not an obvious pattern; the seed
+// is copied
+func NewMasterSeed(seed []byte) (*MasterSeed, error) {
+	if len(seed) < MinMasterSeedBytes {
+		return nil, fmt.Errorf("master seed has %d bytes; at least %d are required", len(seed), MinMasterSeedBytes)
+	}
+	if err := ValidateRandomBytes(seed); err != nil {
+		return nil, fmt.Errorf("master seed rejected: %w", err)
+	}
+	return &MasterSeed{seed: append([]byte(nil), seed...)}, nil
+}
+
+// ReadMasterSeed reads a master seed file holding hex (0x optional, surrounding
+// whitespace ignored) or raw binary bytes. Other text is refused: a passphrase is
+// not a high-entropy seed.
+func ReadMasterSeed(path string) (*MasterSeed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer ClearSensitiveData(data)
+	text := strings.TrimPrefix(strings.TrimSpace(string(data)), "0x")
+	if decoded, err := hex.DecodeString(text); err == nil && len(text) > 0 {
+		defer ClearSensitiveData(decoded)
+		return NewMasterSeed(decoded)
+	}
+	if isText(data) {
+		return nil, fmt.Errorf("%s holds text that is not hex; use raw random bytes or their hex encoding", path)
+	}
+	return NewMasterSeed(data)
+}
+
+// isText reports whether data is printable ASCII and whitespace only
+func isText(data []byte) bool {
+	for _, b := range data {
+		if (b < 0x20 || b > 0x7e) && b != '\n' && b != '\r' && b != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// Key derives the private key of counter: HKDF-SHA256 of the seed with the
+// big-endian counter in the info, reduced to a scalar in [1, n-1]
+func (s *MasterSeed) Key(counter uint64) []byte {
+	info := make([]byte, len(masterSeedKeyInfo)+8)
+	copy(info, masterSeedKeyInfo)
+	binary.BigEndian.PutUint64(info[len(masterSeedKeyInfo):], counter)
+
+	// Cleared with clear rather than ClearSensitiveData, which collects garbage on
+	// every call; this runs once per candidate key
+	okm := make([]byte, masterSeedKeyBytes)
+	defer clear(okm)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, s.seed, nil, info), okm); err != nil {
+		panic(fmt.Sprintf("hkdf: %v", err)) // only fails past 255 hash lengths of output
+	}
+	orderMinusOne := new(big.Int).Sub(btcec.S256().N, big.NewInt(1))
+	scalar := new(big.Int).SetBytes(okm)
+	scalar.Mod(scalar, orderMinusOne).Add(scalar, big.NewInt(1))
+	return scalar.FillBytes(make([]byte, 32))
+}
+
+// Fingerprint identifies the seed in records without revealing it
+func (s *MasterSeed) Fingerprint() string {
+	fingerprint := make([]byte, 8)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, s.seed, nil, []byte(masterSeedFingerprintInfo)), fingerprint); err != nil {
+		panic(fmt.Sprintf("hkdf: %v", err))
+	}
+	return hex.EncodeToString(fingerprint)
+}
+
+// Wipe clears the seed from memory
+func (s *MasterSeed) Wipe() {
+	ClearSensitiveData(s.seed)
+}
+
+// SeedWalker steps through consecutive counters of a master seed. Unlike a
+// KeyWalker each key costs a full derivation and scalar multiplication, since the
+// keys are unrelated to each other.
+type SeedWalker struct {
+	seed    *MasterSeed
+	counter uint64
+	key     btcec.ModNScalar
+	point   btcec.JacobianPoint
+}
+
+// Walker starts a walk at counter
+func (s *MasterSeed) Walker(counter uint64) *SeedWalker {
+	w := &SeedWalker{seed: s, counter: counter}
+	w.derive()
+	return w
+}
+
+func (w *SeedWalker) derive() {
+	key := w.seed.Key(w.counter)
+	w.key.SetByteSlice(key)
+	clear(key)
+	btcec.ScalarBaseMultNonConst(&w.key, &w.point)
+	w.point.ToAffine()
+}
+
+// Next advances to the following counter
+func (w *SeedWalker) Next() {
+	w.counter++
+	w.derive()
+}
+
+// Fingerprint identifies the seed being walked
+func (w *SeedWalker) Fingerprint() string {
+	return w.seed.Fingerprint()
+}
+
+// Counter returns the current counter
+func (w *SeedWalker) Counter() uint64 {
+	return w.counter
+}
+
+// PrivateKey returns the current key as 32 big-endian bytes
+func (w *SeedWalker) PrivateKey() []byte {
+	key := w.key.Bytes()
+	return key[:]
+}
+
+// PublicKey returns the public key of the current key
+func (w *SeedWalker) PublicKey() *btcec.PublicKey {
+	return btcec.NewPublicKey(&w.point.X, &w.point.Y)
+}
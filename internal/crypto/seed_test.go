@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testMasterSeed is 0x00, 0x01, ... 0x1f
+func testMasterSeed(t *testing.T) *MasterSeed {
+	t.Helper()
+	seed := make([]byte, 32)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	s, err := NewMasterSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// The vectors were computed with an independent HKDF-SHA256 implementation; a
+// change to any of them breaks every batch derived so far
+func TestMasterSeedKey(t *testing.T) {
+	s := testMasterSeed(t)
+	vectors := map[uint64]string{
+		0:              "639d5ef2b449c656ab1755a28496ba3cc661ef10f35023936c491516e95848a8",
+		1:              "f7d33ff767cd2de947efa24c438af56c7c467864917fda80eac1145b70ef2c38",
+		1 << 32:        "de56859698c837ec6512ef998598dd50c067c5d45758fd18314577eabf514c48",
+		math.MaxUint64: "24d2afd75a3621d358a2ffad3b897538bc317df86670ebecd2e18b87d52cbbc7",
+	}
+	for counter, want := range vectors {
+		if got := hex.EncodeToString(s.Key(counter)); got != want {
+			t.Errorf("Key(%d) = %s, want %s", counter, got, want)
+		}
+	}
+	if got := s.Fingerprint(); got != "1ced0eca1f435621" {
+		t.Errorf("Fingerprint() = %s, want 1ced0eca1f435621", got)
+	}
+}
+
+func TestSeedWalker(t *testing.T) {
+	s := testMasterSeed(t)
+	w := s.Walker(1<<32 - 1)
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			w.Next()
+		}
+		key := s.Key(w.Counter())
+		if !bytes.Equal(w.PrivateKey(), key) {
+			t.Fatalf("counter %d: walker key %x, want %x", w.Counter(), w.PrivateKey(), key)
+		}
+		if !w.PublicKey().IsEqual(NewKeyWalker(new(big.Int).SetBytes(key), big.NewInt(1)).PublicKey()) {
+			t.Fatalf("counter %d: walker public key does not match its private key", w.Counter())
+		}
+	}
+	if w.Counter() != 1<<32+1 {
+		t.Errorf("Counter() = %d after two steps from 2^32-1", w.Counter())
+	}
+}
+
+func TestReadMasterSeed(t *testing.T) {
+	dir := t.TempDir()
+	raw := testMasterSeed(t).seed
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	for name, data := range map[string][]byte{
+		"hex": []byte(hex.EncodeToString(raw) + "\n"),
+		"0x":  []byte("0x" + hex.EncodeToString(raw)),
+		"raw": raw,
+	} {
+		s, err := ReadMasterSeed(write(name, data))
+		if err != nil {
+			t.Errorf("%s seed: %v", name, err)
+			continue
+		}
+		if s.Fingerprint() != "1ced0eca1f435621" {
+			t.Errorf("%s seed read as a different seed", name)
+		}
+	}
+
+	for name, data := range map[string]string{
+		"short":      hex.EncodeToString(raw[:16]),
+		"passphrase": "correct horse battery staple and a few more words",
+		"zeros":      strings.Repeat("00", 32),
+	} {
+		if _, err := ReadMasterSeed(write(name, []byte(data))); err == nil {
+			t.Errorf("%s seed accepted", name)
+		}
+	}
+}
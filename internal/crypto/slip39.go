@@ -0,0 +1,561 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SLIP-39 encoding parameters
+const (
+	slip39RadixBits       = 10
+	slip39ChecksumWords   = 3
+	slip39PrefixWords     = 4
+	slip39MinMnemonicLen  = 20
+	slip39DigestLen       = 4
+	slip39DigestIndex     = 254
+	slip39SecretIndex     = 255
+	slip39BaseIterations  = 10000
+	slip39Rounds          = 4
+	slip39MaxShareCount   = 16
+	slip39MinSecretLength = 16
+
+	slip39Customization           = "shamir"
+	slip39CustomizationExtendable = "shamir_extendable"
+)
+
+// slip39IterationExponent sets the PBKDF2 cost of new shares (10000 << e iterations)
+var slip39IterationExponent = 1
+
+var (
+	slip39Exp [255]byte
+	slip39Log [256]byte
+)
+
+func init() {
+	// GF(256) with the Rijndael polynomial, generated by 3
+	poly := 1
+	for i := 0; i < 255; i++ {
+		slip39Exp[i] = byte(poly)
+		slip39Log[poly] = byte(i)
+		poly = (poly << 1) ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11B
+		}
+	}
+}
+
+// slip39Point is one Shamir share: the x coordinate and the share value
+type slip39Point struct {
+	x byte
+	y []byte
+}
+
+// slip39Share is a decoded SLIP-39 mnemonic
+type slip39Share struct {
+	identifier        int
+	extendable        bool
+	iterationExponent int
+	groupIndex        int
+	groupThreshold    int
+	groupCount        int
+	memberIndex       int
+	memberThreshold   int
+	value             []byte
+}
+
+// ParseSLIP39Scheme parses a "T-of-N" share scheme such as "2-of-3"
+func ParseSLIP39Scheme(spec string) (int, int, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(spec)), "-of-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid SLIP-39 scheme %q, expected T-of-N (e.g. 2-of-3)", spec)
+	}
+	threshold, err1 := strconv.Atoi(parts[0])
+	count, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return 0, 0, fmt.Errorf("invalid SLIP-39 scheme %q, expected T-of-N (e.g. 2-of-3)", spec)
+	}
+	if err := validateSLIP39Scheme(threshold, count); err != nil {
+		return 0, 0, err
+	}
+	return threshold, count, nil
+}
+
+// validateSLIP39Scheme checks a member threshold and share count
+func validateSLIP39Scheme(threshold, count int) error {
+	switch {
+	case count < 1 || count > slip39MaxShareCount:
+		return fmt.Errorf("SLIP-39 share count must be between 1 and %d, got %d", slip39MaxShareCount, count)
+	case threshold < 1 || threshold > count:
+		return fmt.Errorf("SLIP-39 threshold must be between 1 and the share count %d, got %d", count, threshold)
+	case threshold == 1 && count > 1:
+		return fmt.Errorf("SLIP-39 does not allow several shares with threshold 1; use 1-of-1")
+	}
+	return nil
+}
+
+// MnemonicToSLIP39Shares splits the entropy of a BIP-39 mnemonic into SLIP-39 shares.
+// The shares hold the BIP-39 entropy, so SLIP39SharesToMnemonic restores the original
+// mnemonic; they do not recreate the wallet when restored as SLIP-39 on a hardware wallet.
+func MnemonicToSLIP39Shares(mnemonic string, threshold, count int) ([]string, error) {
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BIP-39 mnemonic: %w", err)
+	}
+	return SplitSLIP39(entropy, threshold, count, nil)
+}
+
+// SLIP39SharesToMnemonic combines shares created by MnemonicToSLIP39Shares back into the BIP-39 mnemonic
+func SLIP39SharesToMnemonic(shares []string) (string, error) {
+	entropy, err := CombineSLIP39(shares, nil)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// SplitSLIP39 encrypts masterSecret with passphrase and splits it into count
+// single-group SLIP-39 mnemonics, any threshold of which recover it
+func SplitSLIP39(masterSecret []byte, threshold, count int, passphrase []byte) ([]string, error) {
+	if len(masterSecret) < slip39MinSecretLength || len(masterSecret)%2 != 0 {
+		return nil, fmt.Errorf("SLIP-39 master secret must be an even number of bytes, at least %d", slip39MinSecretLength)
+	}
+	if err := validateSLIP39Scheme(threshold, count); err != nil {
+		return nil, err
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	identifier := int(binary.BigEndian.Uint16(id[:]) >> 1)
+
+	encrypted := slip39Encrypt(masterSecret, passphrase, slip39IterationExponent, identifier, false)
+
+	// A single group with threshold 1 holds the encrypted secret directly
+	groups, err := slip39SplitSecret(1, 1, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	members, err := slip39SplitSecret(threshold, count, groups[0].y)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([]string, 0, count)
+	for _, member := range members {
+		share := slip39Share{
+			identifier:        identifier,
+			iterationExponent: slip39IterationExponent,
+			groupIndex:        int(groups[0].x),
+			groupThreshold:    1,
+			groupCount:        1,
+			memberIndex:       int(member.x),
+			memberThreshold:   threshold,
+			value:             member.y,
+		}
+		mnemonics = append(mnemonics, share.mnemonic())
+	}
+	return mnemonics, nil
+}
+
+// CombineSLIP39 recovers the master secret from SLIP-39 mnemonics
+func CombineSLIP39(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, fmt.Errorf("no SLIP-39 shares provided")
+	}
+
+	shares := make([]slip39Share, 0, len(mnemonics))
+	for i, mnemonic := range mnemonics {
+		share, err := decodeSLIP39Share(mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares = append(shares, share)
+	}
+
+	first := shares[0]
+	groups := make(map[int][]slip39Share)
+	for _, share := range shares {
+		if share.identifier != first.identifier || share.extendable != first.extendable ||
+			share.iterationExponent != first.iterationExponent {
+			return nil, fmt.Errorf("shares belong to different SLIP-39 backups")
+		}
+		if share.groupThreshold != first.groupThreshold || share.groupCount != first.groupCount {
+			return nil, fmt.Errorf("shares have inconsistent group parameters")
+		}
+		if len(share.value) != len(first.value) {
+			return nil, fmt.Errorf("shares have different lengths")
+		}
+		members := groups[share.groupIndex]
+		for _, member := range members {
+			if member.memberThreshold != share.memberThreshold {
+				return nil, fmt.Errorf("shares in group %d have inconsistent thresholds", share.groupIndex+1)
+			}
+			if member.memberIndex == share.memberIndex && !bytes.Equal(member.value, share.value) {
+				return nil, fmt.Errorf("shares in group %d have the same index but different values", share.groupIndex+1)
+			}
+		}
+		groups[share.groupIndex] = append(members, share)
+	}
+
+	indices := make([]int, 0, len(groups))
+	for index := range groups {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	var groupPoints []slip39Point
+	for _, index := range indices {
+		if len(groupPoints) == first.groupThreshold {
+			break
+		}
+		members := uniqueSLIP39Members(groups[index])
+		threshold := members[0].memberThreshold
+		if len(members) < threshold {
+			continue
+		}
+		points := make([]slip39Point, 0, threshold)
+		for _, member := range members[:threshold] {
+			points = append(points, slip39Point{x: byte(member.memberIndex), y: member.value})
+		}
+		secret, err := slip39RecoverSecret(threshold, points)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", index+1, err)
+		}
+		groupPoints = append(groupPoints, slip39Point{x: byte(index), y: secret})
+	}
+	if len(groupPoints) < first.groupThreshold && first.groupCount == 1 {
+		members := uniqueSLIP39Members(groups[first.groupIndex])
+		return nil, fmt.Errorf("not enough shares: %d of %d required", len(members), members[0].memberThreshold)
+	}
+	if len(groupPoints) < first.groupThreshold {
+		return nil, fmt.Errorf("not enough shares: %d of %d required groups are complete", len(groupPoints), first.groupThreshold)
+	}
+
+	encrypted, err := slip39RecoverSecret(first.groupThreshold, groupPoints)
+	if err != nil {
+		return nil, err
+	}
+	return slip39Decrypt(encrypted, passphrase, first.iterationExponent, first.identifier, first.extendable), nil
+}
+
+// uniqueSLIP39Members drops repeated copies of the same member share
+func uniqueSLIP39Members(members []slip39Share) []slip39Share {
+	seen := make(map[int]bool)
+	unique := members[:0:0]
+	for _, member := range members {
+		if !seen[member.memberIndex] {
+			seen[member.memberIndex] = true
+			unique = append(unique, member)
+		}
+	}
+	return unique
+}
+
+// mnemonic encodes the share as words with an RS1024 checksum
+func (s slip39Share) mnemonic() string {
+	ext := 0
+	if s.extendable {
+		ext = 1
+	}
+	prefix := s.identifier<<25 | ext<<24 | s.iterationExponent<<20 | s.groupIndex<<16 |
+		(s.groupThreshold-1)<<12 | (s.groupCount-1)<<8 | s.memberIndex<<4 | (s.memberThreshold - 1)
+
+	values := make([]int, 0, slip39MinMnemonicLen)
+	for i := slip39PrefixWords - 1; i >= 0; i-- {
+		values = append(values, (prefix>>(i*slip39RadixBits))&1023)
+	}
+
+	wordCount := (len(s.value)*8 + slip39RadixBits - 1) / slip39RadixBits
+	n := new(big.Int).SetBytes(s.value)
+	valueWords := make([]int, wordCount)
+	for i := wordCount - 1; i >= 0; i-- {
+		valueWords[i] = int(new(big.Int).And(n, big.NewInt(1023)).Int64())
+		n.Rsh(n, slip39RadixBits)
+	}
+	values = append(values, valueWords...)
+	values = append(values, slip39CreateChecksum(slip39CustomizationFor(s.extendable), values)...)
+
+	words := make([]string, len(values))
+	for i, value := range values {
+		words[i] = slip39Wordlist[value]
+	}
+	return strings.Join(words, " ")
+}
+
+// decodeSLIP39Share parses and checksums a SLIP-39 mnemonic
+func decodeSLIP39Share(mnemonic string) (slip39Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < slip39MinMnemonicLen {
+		return slip39Share{}, fmt.Errorf("SLIP-39 share must have at least %d words, got %d", slip39MinMnemonicLen, len(words))
+	}
+
+	values := make([]int, len(words))
+	for i, word := range words {
+		index, ok := slip39WordIndex(word)
+		if !ok {
+			return slip39Share{}, fmt.Errorf("%q is not a SLIP-39 word", word)
+		}
+		values[i] = index
+	}
+
+	valueWords := len(values) - slip39PrefixWords - slip39ChecksumWords
+	padding := (slip39RadixBits * valueWords) % 16
+	if padding > 8 {
+		return slip39Share{}, fmt.Errorf("invalid SLIP-39 share length")
+	}
+
+	prefix := 0
+	for _, value := range values[:slip39PrefixWords] {
+		prefix = prefix<<slip39RadixBits | value
+	}
+	share := slip39Share{
+		identifier:        prefix >> 25,
+		extendable:        (prefix>>24)&1 == 1,
+		iterationExponent: (prefix >> 20) & 0xF,
+		groupIndex:        (prefix >> 16) & 0xF,
+		groupThreshold:    (prefix>>12)&0xF + 1,
+		groupCount:        (prefix>>8)&0xF + 1,
+		memberIndex:       (prefix >> 4) & 0xF,
+		memberThreshold:   prefix&0xF + 1,
+	}
+
+	if !slip39VerifyChecksum(slip39CustomizationFor(share.extendable), values) {
+		return slip39Share{}, fmt.Errorf("invalid SLIP-39 checksum")
+	}
+	if share.groupThreshold > share.groupCount {
+		return slip39Share{}, fmt.Errorf("group threshold exceeds group count")
+	}
+
+	n := new(big.Int)
+	for _, value := range values[slip39PrefixWords : len(values)-slip39ChecksumWords] {
+		n.Lsh(n, slip39RadixBits)
+		n.Or(n, big.NewInt(int64(value)))
+	}
+	byteCount := (slip39RadixBits*valueWords - padding) / 8
+	if n.BitLen() > byteCount*8 {
+		return slip39Share{}, fmt.Errorf("invalid SLIP-39 share padding")
+	}
+	share.value = n.FillBytes(make([]byte, byteCount))
+	if byteCount < slip39MinSecretLength {
+		return slip39Share{}, fmt.Errorf("SLIP-39 share value is too short")
+	}
+	return share, nil
+}
+
+// slip39WordIndex returns the index of a word, also accepting its unique four-letter prefix
+func slip39WordIndex(word string) (int, bool) {
+	index := sort.SearchStrings(slip39Wordlist[:], word)
+	if index < len(slip39Wordlist) && slip39Wordlist[index] == word {
+		return index, true
+	}
+	if len(word) == 4 && index < len(slip39Wordlist) && strings.HasPrefix(slip39Wordlist[index], word) {
+		return index, true
+	}
+	return 0, false
+}
+
+// slip39CustomizationFor returns the checksum customization string
+func slip39CustomizationFor(extendable bool) string {
+	if extendable {
+		return slip39CustomizationExtendable
+	}
+	return slip39Customization
+}
+
+// slip39Polymod computes the RS1024 checksum polynomial
+func slip39Polymod(values []int) int {
+	gen := [10]int{0xE0E040, 0x1C1C080, 0x3838100, 0x7070200, 0xE0E0009, 0x1C0C2412, 0x38086C24, 0x3090FC48, 0x21B1F890, 0x3F3F120}
+	chk := 1
+	for _, value := range values {
+		b := chk >> 20
+		chk = (chk&0xFFFFF)<<10 ^ value
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+// slip39CreateChecksum returns the three checksum words for data
+func slip39CreateChecksum(customization string, data []int) []int {
+	values := make([]int, 0, len(customization)+len(data)+slip39ChecksumWords)
+	for _, c := range []byte(customization) {
+		values = append(values, int(c))
+	}
+	values = append(values, data...)
+	values = append(values, 0, 0, 0)
+	polymod := slip39Polymod(values) ^ 1
+	return []int{(polymod >> 20) & 1023, (polymod >> 10) & 1023, polymod & 1023}
+}
+
+// slip39VerifyChecksum checks the checksum words at the end of data
+func slip39VerifyChecksum(customization string, data []int) bool {
+	values := make([]int, 0, len(customization)+len(data))
+	for _, c := range []byte(customization) {
+		values = append(values, int(c))
+	}
+	return slip39Polymod(append(values, data...)) == 1
+}
+
+// slip39Interpolate evaluates the polynomial through points at x
+func slip39Interpolate(points []slip39Point, x byte) ([]byte, error) {
+	length := len(points[0].y)
+	for _, point := range points {
+		if len(point.y) != length {
+			return nil, fmt.Errorf("shares have different lengths")
+		}
+		if point.x == x {
+			return append([]byte(nil), point.y...), nil
+		}
+	}
+
+	logProd := 0
+	for _, point := range points {
+		logProd += int(slip39Log[point.x^x])
+	}
+
+	result := make([]byte, length)
+	for i, point := range points {
+		basis := logProd - int(slip39Log[point.x^x])
+		for j, other := range points {
+			if i != j {
+				basis -= int(slip39Log[point.x^other.x])
+			}
+		}
+		basis = ((basis % 255) + 255) % 255
+
+		for k, value := range point.y {
+			if value != 0 {
+				result[k] ^= slip39Exp[(int(slip39Log[value])+basis)%255]
+			}
+		}
+	}
+	return result, nil
+}
+
+// slip39SplitSecret creates count Shamir shares of secret with the SLIP-39 digest share
+func slip39SplitSecret(threshold, count int, secret []byte) ([]slip39Point, error) {
+	if threshold == 1 {
+		points := make([]slip39Point, count)
+		for i := range points {
+			points[i] = slip39Point{x: byte(i), y: append([]byte(nil), secret...)}
+		}
+		return points, nil
+	}
+
+	randomCount := threshold - 2
+	points := make([]slip39Point, 0, count)
+	for i := 0; i < randomCount; i++ {
+		value, err := GenerateRandomBytes(len(secret))
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, slip39Point{x: byte(i), y: value})
+	}
+
+	randomPart, err := GenerateRandomBytes(len(secret) - slip39DigestLen)
+	if err != nil {
+		return nil, err
+	}
+	digest := slip39Digest(randomPart, secret)
+
+	base := append(append([]slip39Point(nil), points...),
+		slip39Point{x: slip39DigestIndex, y: append(digest, randomPart...)},
+		slip39Point{x: slip39SecretIndex, y: secret},
+	)
+	for i := randomCount; i < count; i++ {
+		value, err := slip39Interpolate(base, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, slip39Point{x: byte(i), y: value})
+	}
+	return points, nil
+}
+
+// slip39RecoverSecret interpolates the secret and checks it against the digest share
+func slip39RecoverSecret(threshold int, points []slip39Point) ([]byte, error) {
+	if threshold == 1 {
+		return append([]byte(nil), points[0].y...), nil
+	}
+
+	secret, err := slip39Interpolate(points, slip39SecretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digestShare, err := slip39Interpolate(points, slip39DigestIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(digestShare[:slip39DigestLen], slip39Digest(digestShare[slip39DigestLen:], secret)) {
+		return nil, fmt.Errorf("invalid digest of the shared secret; the shares do not match")
+	}
+	return secret, nil
+}
+
+// slip39Digest returns the first bytes of HMAC-SHA256(randomPart, secret)
+func slip39Digest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:slip39DigestLen]
+}
+
+// slip39Encrypt applies the four-round Feistel cipher to the master secret
+func slip39Encrypt(masterSecret, passphrase []byte, iterationExponent, identifier int, extendable bool) []byte {
+	half := len(masterSecret) / 2
+	l, r := masterSecret[:half], masterSecret[half:]
+	salt := slip39Salt(identifier, extendable)
+	for i := 0; i < slip39Rounds; i++ {
+		l, r = r, slip39XOR(l, slip39RoundFunction(i, passphrase, iterationExponent, salt, r))
+	}
+	return append(append([]byte(nil), r...), l...)
+}
+
+// slip39Decrypt reverses slip39Encrypt
+func slip39Decrypt(encrypted, passphrase []byte, iterationExponent, identifier int, extendable bool) []byte {
+	half := len(encrypted) / 2
+	l, r := encrypted[:half], encrypted[half:]
+	salt := slip39Salt(identifier, extendable)
+	for i := slip39Rounds - 1; i >= 0; i-- {
+		l, r = r, slip39XOR(l, slip39RoundFunction(i, passphrase, iterationExponent, salt, r))
+	}
+	return append(append([]byte(nil), r...), l...)
+}
+
+// slip39Salt returns the Feistel salt prefix for a backup
+func slip39Salt(identifier int, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return append([]byte(slip39Customization), byte(identifier>>8), byte(identifier))
+}
+
+// slip39RoundFunction is the PBKDF2-HMAC-SHA256 Feistel round function
+func slip39RoundFunction(round int, passphrase []byte, iterationExponent int, salt, r []byte) []byte {
+	password := append([]byte{byte(round)}, passphrase...)
+	iterations := (slip39BaseIterations / slip39Rounds) << iterationExponent
+	return pbkdf2.Key(password, append(append([]byte(nil), salt...), r...), iterations, len(r), sha256.New)
+}
+
+// slip39XOR returns a XOR b
+func slip39XOR(a, b []byte) []byte {
+	out := make([]byte, len(a))
+	for i := range a {
+		out[i] = a[i] ^ b[i]
+	}
+	return out
+}
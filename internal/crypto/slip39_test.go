@@ -0,0 +1,112 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSLIP39Wordlist(t *testing.T) {
+	if !sort.StringsAreSorted(slip39Wordlist[:]) {
+		t.Fatal("wordlist must be sorted")
+	}
+	prefixes := make(map[string]bool)
+	for _, word := range slip39Wordlist {
+		if len(word) < 4 || len(word) > 8 || prefixes[word[:4]] {
+			t.Fatalf("word %q breaks the wordlist rules", word)
+		}
+		prefixes[word[:4]] = true
+	}
+}
+
+func TestCombineSLIP39_Vectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		mnemonics []string
+		secret    string
+	}{
+		{
+			name:      "1-of-1 128 bits",
+			mnemonics: []string{"duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"},
+			secret:    "bb54aac4b89dc868ba37d9cc21b2cece",
+		},
+		{
+			name: "2-of-3 128 bits",
+			mnemonics: []string{
+				"shadow pistol academic always adequate wildlife fancy gross oasis cylinder mustang wrist rescue view short owner flip making coding armed",
+				"shadow pistol academic acid actress prayer class unknown daughter sweater depict flip twice unkind craft early superior advocate guest smoking",
+			},
+			secret: "b43ceb7e57a0ea8766221624d01b0864",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := CombineSLIP39(tt.mnemonics, []byte("TREZOR"))
+			if err != nil {
+				t.Fatalf("CombineSLIP39() error = %v", err)
+			}
+			if hex.EncodeToString(secret) != tt.secret {
+				t.Errorf("secret = %x, expected %s", secret, tt.secret)
+			}
+		})
+	}
+}
+
+func TestSLIP39_MnemonicRoundTrip(t *testing.T) {
+	shares, err := MnemonicToSLIP39Shares(testMnemonic, 2, 3)
+	if err != nil {
+		t.Fatalf("MnemonicToSLIP39Shares() error = %v", err)
+	}
+	if len(shares) != 3 || len(strings.Fields(shares[0])) != 20 {
+		t.Fatalf("expected three 20-word shares, got %q", shares)
+	}
+
+	for _, pair := range [][]string{{shares[0], shares[1]}, {shares[2], shares[0]}, {shares[1], shares[2]}} {
+		mnemonic, err := SLIP39SharesToMnemonic(pair)
+		if err != nil {
+			t.Fatalf("SLIP39SharesToMnemonic() error = %v", err)
+		}
+		if mnemonic != testMnemonic {
+			t.Errorf("recovered %q, expected %q", mnemonic, testMnemonic)
+		}
+	}
+
+	if _, err := SLIP39SharesToMnemonic(shares[:1]); err == nil {
+		t.Error("expected error below the threshold")
+	}
+	if _, err := SLIP39SharesToMnemonic([]string{shares[0], shares[0]}); err == nil {
+		t.Error("expected error for a repeated share")
+	}
+
+	words := strings.Fields(shares[1])
+	words[7] = slip39Wordlist[(sort.SearchStrings(slip39Wordlist[:], words[7])+1)%len(slip39Wordlist)]
+	if _, err := SLIP39SharesToMnemonic([]string{shares[0], strings.Join(words, " ")}); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("expected checksum error for a mistyped share, got %v", err)
+	}
+
+	other, err := MnemonicToSLIP39Shares(testMnemonic, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SLIP39SharesToMnemonic([]string{shares[0], other[1]}); err == nil {
+		t.Error("expected error mixing shares from different backups")
+	}
+}
+
+func TestParseSLIP39Scheme(t *testing.T) {
+	for spec, valid := range map[string]bool{
+		"2-of-3":   true,
+		"1-of-1":   true,
+		"16-of-16": true,
+		"1-of-3":   false,
+		"4-of-3":   false,
+		"2-of-17":  false,
+		"2/3":      false,
+	} {
+		if _, _, err := ParseSLIP39Scheme(spec); (err == nil) != valid {
+			t.Errorf("ParseSLIP39Scheme(%q) error = %v, valid %v", spec, err, valid)
+		}
+	}
+}
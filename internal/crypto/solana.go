@@ -2,7 +2,6 @@ package crypto
 
 import (
 	"crypto/ed25519"
-	"crypto/rand"
 	"encoding/hex"
 
 	"bloco-eth/pkg/errors"
@@ -29,11 +28,13 @@ func (sg *SolanaGenerator) GenerateWallet() (*wallet.Wallet, error) {
 	// Solana uses Ed25519, which has 64-byte private keys (32 byte seed + 32 byte pub key)
 	// But standard crypto/ed25519 GenerateKey returns the full 64 bytes.
 
-	pub, priv, err := ed25519.GenerateKey(rand.Reader)
-	if err != nil {
+	seed := make([]byte, ed25519.SeedSize)
+	if err := ReadEntropy(seed); err != nil {
 		return nil, errors.NewCryptoError("generate_wallet",
 			"failed to generate random private key", err)
 	}
+	priv := ed25519.NewKeyFromSeed(seed)
+	pub := priv.Public().(ed25519.PublicKey)
 
 	// Convert to Solana wallet
 	// Solana private keys are usually represented as the 64-byte array
@@ -0,0 +1,314 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"bloco-eth/internal/retry"
+)
+
+// VaultVersion is the current vault file format version
+const VaultVersion = 1
+
+// vaultAAD binds the ciphertext to the vault format
+const vaultAAD = "bloco-eth-vault-v1"
+
+// vaultScryptN is the scrypt cost used for new vaults
+var vaultScryptN = 1 << 18
+
+// VaultEntry is one wallet stored in a vault
+type VaultEntry struct {
+	Address             string    `json:"address"`
+	PrivateKey          string    `json:"private_key"`
+	PublicKey           string    `json:"public_key,omitempty"`
+	PublicKeyCompressed string    `json:"public_key_compressed,omitempty"`
+	Mnemonic            string    `json:"mnemonic,omitempty"`
+	Network             string    `json:"network"`
+	CreatedAt           time.Time `json:"created_at"`
+	Label               string    `json:"label,omitempty"`
+	Tags                []string  `json:"tags,omitempty"`
+}
+
+// vaultFile is the on-disk vault container. Only the KDF and cipher parameters
+// are stored in the clear; the wallets are encrypted with AES-256-GCM.
+type vaultFile struct {
+	Version    int          `json:"version"`
+	KDF        string       `json:"kdf"`
+	KDFParams  ScryptParams `json:"kdfparams"`
+	Cipher     string       `json:"cipher"`
+	Nonce      string       `json:"nonce"`
+	CipherText string       `json:"ciphertext"`
+}
+
+// vaultPayload is the encrypted vault content
+type vaultPayload struct {
+	Wallets []VaultEntry `json:"wallets"`
+}
+
+// Vault is a single encrypted file holding many wallets
+type Vault struct {
+	path   string
+	params ScryptParams
+	key    []byte
+	retry  retry.Policy
+
+	mu      sync.Mutex
+	entries []VaultEntry
+}
+
+// OpenOrCreateVault opens the vault at path, creating an empty one if it does not exist
+func OpenOrCreateVault(path, password string) (*Vault, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return CreateVault(path, password)
+	}
+	return OpenVault(path, password)
+}
+
+// CreateVault writes a new empty vault protected by password
+func CreateVault(path, password string) (*Vault, error) {
+	if password == "" {
+		return nil, NewKeyStoreErrorWithPath("create", "vault", path, fmt.Errorf("vault password cannot be empty"))
+	}
+
+	salt, err := GenerateRandomBytes(32)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("create", "vault", path, err)
+	}
+	params := ScryptParams{DKLen: 32, N: vaultScryptN, R: 8, P: 1, Salt: hex.EncodeToString(salt)}
+
+	key, err := DeriveKeyScrypt([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("create", "vault", path, err)
+	}
+
+	vault := &Vault{path: path, params: params, key: key, retry: retry.DefaultFilePolicy, entries: []VaultEntry{}}
+	if err := vault.save(); err != nil {
+		return nil, err
+	}
+	return vault, nil
+}
+
+// OpenVault decrypts the vault at path
+func OpenVault(path, password string) (*Vault, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, err)
+	}
+
+	var file vaultFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("not a vault file: %w", err))
+	}
+	if file.Version != VaultVersion || file.KDF != "scrypt" || file.Cipher != "aes-256-gcm" {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path,
+			fmt.Errorf("unsupported vault format (version %d, kdf %q, cipher %q)", file.Version, file.KDF, file.Cipher))
+	}
+	if err := ValidateScryptParams(&file.KDFParams); err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, err)
+	}
+	if file.KDFParams.DKLen != 32 {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("vault key length must be 32, got %d", file.KDFParams.DKLen))
+	}
+
+	salt, err := hex.DecodeString(file.KDFParams.Salt)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("invalid salt hex: %w", err))
+	}
+	nonce, err := hex.DecodeString(file.Nonce)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("invalid nonce hex: %w", err))
+	}
+	ciphertext, err := hex.DecodeString(file.CipherText)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("invalid ciphertext hex: %w", err))
+	}
+
+	p := file.KDFParams
+	key, err := DeriveKeyScrypt([]byte(password), salt, p.N, p.R, p.P, p.DKLen)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, err)
+	}
+
+	aead, err := newVaultAEAD(key)
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, err)
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("invalid nonce length %d", len(nonce)))
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(vaultAAD))
+	if err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("incorrect password or corrupted vault"))
+	}
+
+	var payload vaultPayload
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		return nil, NewKeyStoreErrorWithPath("open", "vault", path, fmt.Errorf("invalid vault content: %w", err))
+	}
+	if payload.Wallets == nil {
+		payload.Wallets = []VaultEntry{}
+	}
+
+	return &Vault{path: path, params: file.KDFParams, key: key, retry: retry.DefaultFilePolicy, entries: payload.Wallets}, nil
+}
+
+// SetRetryPolicy sets how failed writes of the vault file are retried
+func (v *Vault) SetRetryPolicy(policy retry.Policy) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.retry = policy
+}
+
+// Path returns the vault file path
+func (v *Vault) Path() string {
+	return v.path
+}
+
+// Add stores a wallet and rewrites the vault
+func (v *Vault) Add(entry VaultEntry) error {
+	return v.AddAll([]VaultEntry{entry})
+}
+
+// AddAll stores wallets with a single rewrite of the vault; if any is invalid or
+// the write fails, none are stored
+func (v *Vault) AddAll(entries []VaultEntry) error {
+	added := make([]VaultEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Address == "" || entry.PrivateKey == "" {
+			return NewKeyStoreErrorWithPath("add", "vault", v.path, fmt.Errorf("wallet address and private key are required"))
+		}
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = time.Now().UTC()
+		}
+		added = append(added, entry)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	stored := len(v.entries)
+	for _, entry := range added {
+		if _, ok := v.findLocked(entry.Address); ok {
+			v.entries = v.entries[:stored]
+			return NewKeyStoreErrorWithAddress("add", "vault", entry.Address, fmt.Errorf("wallet already stored in vault"))
+		}
+		v.entries = append(v.entries, entry)
+	}
+	if err := v.saveLocked(); err != nil {
+		v.entries = v.entries[:stored]
+		return err
+	}
+	return nil
+}
+
+// Entries returns a copy of the stored wallets in insertion order
+func (v *Vault) Entries() []VaultEntry {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]VaultEntry(nil), v.entries...)
+}
+
+// Find returns the wallet with the given address, ignoring case and any 0x prefix
+func (v *Vault) Find(address string) (VaultEntry, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.findLocked(address)
+}
+
+// findLocked looks up an entry. Callers must hold v.mu.
+func (v *Vault) findLocked(address string) (VaultEntry, bool) {
+	needle := strings.TrimPrefix(strings.ToLower(address), "0x")
+	for _, entry := range v.entries {
+		if strings.TrimPrefix(strings.ToLower(entry.Address), "0x") == needle {
+			return entry, true
+		}
+	}
+	return VaultEntry{}, false
+}
+
+// save writes the vault to disk
+func (v *Vault) save() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.saveLocked()
+}
+
+// saveLocked writes the vault file, retrying failed writes. Callers must hold v.mu.
+func (v *Vault) saveLocked() error {
+	return retry.Do(context.Background(), v.retry, v.writeLocked, nil)
+}
+
+// writeLocked encrypts the entries with a fresh nonce and atomically replaces the
+// vault file. Callers must hold v.mu.
+func (v *Vault) writeLocked() error {
+	plaintext, err := json.Marshal(vaultPayload{Wallets: v.entries})
+	if err != nil {
+		return NewKeyStoreErrorWithPath("save", "vault", v.path, err)
+	}
+
+	aead, err := newVaultAEAD(v.key)
+	if err != nil {
+		return NewKeyStoreErrorWithPath("save", "vault", v.path, err)
+	}
+	nonce, err := GenerateRandomBytes(aead.NonceSize())
+	if err != nil {
+		return NewKeyStoreErrorWithPath("save", "vault", v.path, err)
+	}
+
+	data, err := json.MarshalIndent(vaultFile{
+		Version:    VaultVersion,
+		KDF:        "scrypt",
+		KDFParams:  v.params,
+		Cipher:     "aes-256-gcm",
+		Nonce:      hex.EncodeToString(nonce),
+		CipherText: hex.EncodeToString(aead.Seal(nil, nonce, plaintext, []byte(vaultAAD))),
+	}, "", "  ")
+	if err != nil {
+		return NewKeyStoreErrorWithPath("save", "vault", v.path, err)
+	}
+
+	if dir := filepath.Dir(v.path); dir != "." {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return NewKeyStoreErrorWithPath("save", "vault", v.path, err)
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(v.path), ".vault-*.tmp")
+	if err != nil {
+		return NewKeyStoreErrorWithPath("save", "vault", v.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), v.path)
+	}
+	if err != nil {
+		return NewKeyStoreErrorWithPath("save", "vault", v.path, err)
+	}
+	return nil
+}
+
+// newVaultAEAD creates the AES-256-GCM cipher for a vault key
+func newVaultAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,126 @@
+package crypto
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useFastVaultKDF lowers the vault scrypt cost for the duration of a test
+func useFastVaultKDF(t *testing.T) {
+	t.Helper()
+	previous := vaultScryptN
+	vaultScryptN = 1024
+	t.Cleanup(func() { vaultScryptN = previous })
+}
+
+func TestVault_RoundTrip(t *testing.T) {
+	useFastVaultKDF(t)
+	path := filepath.Join(t.TempDir(), "wallets", "vault.json")
+
+	vault, err := OpenOrCreateVault(path, "correct horse")
+	if err != nil {
+		t.Fatalf("OpenOrCreateVault() error = %v", err)
+	}
+	entries := []VaultEntry{
+		{Address: "0xAbC0000000000000000000000000000000000001", PrivateKey: strings.Repeat("11", 32), Network: "ethereum"},
+		{Address: "0xabc0000000000000000000000000000000000002", PrivateKey: strings.Repeat("22", 32), Mnemonic: "abandon ability able", Network: "ethereum"},
+	}
+	for _, entry := range entries {
+		if err := vault.Add(entry); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if err := vault.Add(entries[0]); err == nil {
+		t.Error("expected error adding a duplicate address")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("vault permissions = %o, expected 600", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), entries[0].PrivateKey) || strings.Contains(string(data), "abandon") {
+		t.Error("vault file must not contain plaintext key material")
+	}
+
+	reopened, err := OpenOrCreateVault(path, "correct horse")
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	if got := reopened.Entries(); len(got) != 2 || got[1].Mnemonic != "abandon ability able" || got[0].CreatedAt.IsZero() {
+		t.Errorf("unexpected entries after reopen: %+v", got)
+	}
+	if entry, ok := reopened.Find("abc0000000000000000000000000000000000001"); !ok || entry.PrivateKey != entries[0].PrivateKey {
+		t.Errorf("Find() = %+v, %v", entry, ok)
+	}
+	if _, ok := reopened.Find("0xdead"); ok {
+		t.Error("Find() should not match unknown addresses")
+	}
+}
+
+func TestVault_AddAll(t *testing.T) {
+	useFastVaultKDF(t)
+	path := filepath.Join(t.TempDir(), "vault.json")
+	vault, err := OpenOrCreateVault(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := VaultEntry{Address: "0xabc0000000000000000000000000000000000001", PrivateKey: strings.Repeat("11", 32)}
+	second := VaultEntry{Address: "0xabc0000000000000000000000000000000000002", PrivateKey: strings.Repeat("22", 32)}
+	if err := vault.AddAll([]VaultEntry{first, second}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A batch with one bad wallet stores none of them
+	third := VaultEntry{Address: "0xabc0000000000000000000000000000000000003", PrivateKey: strings.Repeat("33", 32)}
+	for name, batch := range map[string][]VaultEntry{
+		"stored duplicate":    {third, first},
+		"repeated in batch":   {third, third},
+		"missing private key": {third, {Address: "0xabc0000000000000000000000000000000000004"}},
+	} {
+		if err := vault.AddAll(batch); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	reopened, err := OpenVault(path, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reopened.Entries(); len(got) != 2 || len(vault.Entries()) != 2 {
+		t.Errorf("entries = %+v, want the first batch only", got)
+	}
+}
+
+func TestOpenVault_Errors(t *testing.T) {
+	useFastVaultKDF(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vault.json")
+
+	if _, err := CreateVault(path, ""); err == nil {
+		t.Error("expected error for an empty password")
+	}
+	if _, err := CreateVault(path, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenVault(path, "wrong"); err == nil || !strings.Contains(err.Error(), "incorrect password") {
+		t.Errorf("expected incorrect password error, got %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	tampered := strings.Replace(string(data), `"version": 1`, `"version": 2`, 1)
+	if err := os.WriteFile(path, []byte(tampered), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenVault(path, "secret"); err == nil {
+		t.Error("expected error for an unsupported version")
+	}
+
+	if _, err := OpenVault(filepath.Join(dir, "missing.json"), "secret"); err == nil {
+		t.Error("expected error for a missing vault")
+	}
+}
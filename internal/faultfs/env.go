@@ -0,0 +1,26 @@
+//go:build faults
+
+package faultfs
+
+import (
+	"fmt"
+	"os"
+)
+
+// EnvFaults names the environment variable holding faults in Parse's format. Only
+// binaries built with -tags faults read it.
+const EnvFaults = "BLOCO_FAULTS"
+
+func init() {
+	spec := os.Getenv(EnvFaults)
+	if spec == "" {
+		return
+	}
+	parsed, err := Parse(spec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring %s: %v\n", EnvFaults, err)
+		return
+	}
+	Inject(parsed...)
+	fmt.Fprintf(os.Stderr, "Warning: injecting %d file system faults from %s\n", len(parsed), EnvFaults)
+}
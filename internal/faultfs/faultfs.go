@@ -0,0 +1,243 @@
+// Package faultfs wraps the file operations of the keystore, checkpoint and serve
+// job store writers so that tests can make them fail with disk-full, permission
+// and partial-write errors. With no faults injected every call goes straight to package os.
+package faultfs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+// Operations that faults can target
+const (
+	OpCreate = "create" // creating a temporary file
+	OpWrite  = "write"
+	OpSync   = "sync"
+	OpClose  = "close"
+	OpRename = "rename" // matched against the destination
+	OpRemove = "remove"
+)
+
+// Fault makes matching file operations fail
+type Fault struct {
+	// Op is the operation to fail, one of the Op constants
+	Op string
+	// Path is a filepath.Match pattern for the file, matched against the base name,
+	// or the whole path when it holds a separator; empty matches every file
+	Path string
+	// Err is the error returned, wrapped in an *os.PathError; only a write may
+	// leave it nil, to report a Short count without an error
+	Err error
+	// Short writes only this many bytes of a failed write
+	Short int
+	// After lets this many matching operations succeed before failing
+	After int
+	// Count is how many times to fail; 0 fails every time
+	Count int
+
+	seen, failed int
+}
+
+var (
+	active atomic.Bool
+	mu     sync.Mutex
+	faults []*Fault
+)
+
+// Inject adds faults until the returned function is called, which removes them
+func Inject(add ...Fault) (restore func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	added := make([]*Fault, len(add))
+	for i := range add {
+		f := add[i]
+		added[i] = &f
+	}
+	faults = append(faults, added...)
+	active.Store(true)
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		kept := faults[:0]
+		for _, f := range faults {
+			if !contains(added, f) {
+				kept = append(kept, f)
+			}
+		}
+		faults = kept
+		active.Store(len(faults) > 0)
+	}
+}
+
+func contains(list []*Fault, f *Fault) bool {
+	for _, g := range list {
+		if g == f {
+			return true
+		}
+	}
+	return false
+}
+
+// check returns the fault that fails op on path now, if any
+func check(op, path string) *Fault {
+	if !active.Load() {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, f := range faults {
+		if f.Op != op || !f.matches(path) {
+			continue
+		}
+		f.seen++
+		if f.seen <= f.After || (f.Count > 0 && f.failed >= f.Count) {
+			continue
+		}
+		f.failed++
+		return f
+	}
+	return nil
+}
+
+func (f *Fault) matches(path string) bool {
+	if f.Path == "" {
+		return true
+	}
+	name := filepath.Base(path)
+	if strings.ContainsRune(f.Path, filepath.Separator) || strings.ContainsRune(f.Path, '/') {
+		name = path
+	}
+	ok, _ := filepath.Match(f.Path, name)
+	return ok
+}
+
+func (f *Fault) error(op, path string) error {
+	if f.Err == nil {
+		return nil
+	}
+	return &os.PathError{Op: op, Path: path, Err: f.Err}
+}
+
+// File is an *os.File whose writes, syncs and close can be failed
+type File struct {
+	*os.File
+}
+
+// CreateTemp is os.CreateTemp
+func CreateTemp(dir, pattern string) (*File, error) {
+	if f := check(OpCreate, filepath.Join(dir, pattern)); f != nil {
+		return nil, f.error("open", filepath.Join(dir, pattern))
+	}
+	file, err := os.CreateTemp(dir, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &File{file}, nil
+}
+
+// Write is (*os.File).Write
+func (file *File) Write(data []byte) (int, error) {
+	if f := check(OpWrite, file.Name()); f != nil {
+		short := min(max(f.Short, 0), len(data))
+		n, err := file.File.Write(data[:short])
+		if err != nil {
+			return n, err
+		}
+		return n, f.error("write", file.Name())
+	}
+	return file.File.Write(data)
+}
+
+// Sync is (*os.File).Sync
+func (file *File) Sync() error {
+	if f := check(OpSync, file.Name()); f != nil {
+		return f.error("sync", file.Name())
+	}
+	return file.File.Sync()
+}
+
+// Close is (*os.File).Close; a failed close still closes the file
+func (file *File) Close() error {
+	if f := check(OpClose, file.Name()); f != nil {
+		_ = file.File.Close()
+		return f.error("close", file.Name())
+	}
+	return file.File.Close()
+}
+
+// Rename is os.Rename
+func Rename(oldpath, newpath string) error {
+	if f := check(OpRename, newpath); f != nil {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: f.Err}
+	}
+	return os.Rename(oldpath, newpath)
+}
+
+// Remove is os.Remove
+func Remove(name string) error {
+	if f := check(OpRemove, name); f != nil {
+		return f.error("remove", name)
+	}
+	return os.Remove(name)
+}
+
+// errorNames are the errors Parse accepts
+var errorNames = map[string]error{
+	"enospc": syscall.ENOSPC,
+	"eacces": syscall.EACCES,
+	"eperm":  syscall.EPERM,
+	"eio":    syscall.EIO,
+	"erofs":  syscall.EROFS,
+}
+
+// Parse reads faults written as comma-separated op:pattern:error[:count] values,
+// e.g. "write:*.pwd:enospc,rename:*.checkpoint:eacces:1". The error is one of
+// enospc, eacces, eperm, eio and erofs, or short=N for a write that stops after N
+// bytes without an error.
+func Parse(spec string) ([]Fault, error) {
+	var parsed []Fault
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		fields := strings.Split(item, ":")
+		if len(fields) < 3 || len(fields) > 4 {
+			return nil, fmt.Errorf("fault %q is not op:pattern:error[:count]", item)
+		}
+		f := Fault{Op: fields[0], Path: fields[1]}
+		switch f.Op {
+		case OpCreate, OpWrite, OpSync, OpClose, OpRename, OpRemove:
+		default:
+			return nil, fmt.Errorf("fault %q: unknown operation %q", item, f.Op)
+		}
+		if _, err := filepath.Match(f.Path, ""); err != nil {
+			return nil, fmt.Errorf("fault %q: %w", item, err)
+		}
+		if short, ok := strings.CutPrefix(fields[2], "short="); ok {
+			n, err := strconv.Atoi(short)
+			if err != nil || n < 0 || f.Op != OpWrite {
+				return nil, fmt.Errorf("fault %q: short=N takes a byte count and applies to writes", item)
+			}
+			f.Short = n
+		} else if f.Err = errorNames[fields[2]]; f.Err == nil {
+			return nil, fmt.Errorf("fault %q: unknown error %q", item, fields[2])
+		}
+		if len(fields) == 4 {
+			count, err := strconv.Atoi(fields[3])
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("fault %q: count must be a positive number", item)
+			}
+			f.Count = count
+		}
+		parsed = append(parsed, f)
+	}
+	return parsed, nil
+}
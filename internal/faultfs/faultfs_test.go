@@ -0,0 +1,83 @@
+package faultfs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestInject(t *testing.T) {
+	dir := t.TempDir()
+	restore := Inject(
+		Fault{Op: OpRename, Path: "*.json", After: 1, Count: 1, Err: syscall.EACCES},
+		Fault{Op: OpWrite, Short: 3},
+	)
+
+	file, err := CreateTemp(dir, "tmp-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := file.Write([]byte("abcdef")); n != 3 || err != nil {
+		t.Errorf("Write() = %d, %v, want a short write of 3 bytes", n, err)
+	}
+	file.Close()
+
+	target := filepath.Join(dir, "wallet.json")
+	var failed []bool
+	for range 3 {
+		err := Rename(file.Name(), target)
+		failed = append(failed, err != nil)
+		if err != nil && !errors.Is(err, syscall.EACCES) {
+			t.Errorf("Rename() error = %v, want EACCES", err)
+		}
+		if err == nil {
+			if err := os.Rename(target, file.Name()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if failed[0] || !failed[1] || failed[2] {
+		t.Errorf("renames failed = %v, want only the second", failed)
+	}
+	if err := Rename(file.Name(), filepath.Join(dir, "wallet.pwd")); err != nil {
+		t.Errorf("Rename() of an unmatched file = %v", err)
+	}
+
+	restore()
+	file, err = CreateTemp(dir, "tmp-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if n, err := file.Write([]byte("abcdef")); n != 6 || err != nil {
+		t.Errorf("Write() after restore = %d, %v", n, err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	faults, err := Parse("write:*.pwd:enospc, rename:/data/*.checkpoint:eacces:2,write::short=5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Fault{
+		{Op: OpWrite, Path: "*.pwd", Err: syscall.ENOSPC},
+		{Op: OpRename, Path: "/data/*.checkpoint", Err: syscall.EACCES, Count: 2},
+		{Op: OpWrite, Short: 5},
+	}
+	if len(faults) != len(want) {
+		t.Fatalf("Parse() = %+v", faults)
+	}
+	for i := range want {
+		if faults[i] != want[i] {
+			t.Errorf("fault %d = %+v, want %+v", i, faults[i], want[i])
+		}
+	}
+
+	for _, spec := range []string{"write:*", "chmod:*:eacces", "write:*:enoent", "rename:*:short=1", "write:*:eio:0", "write:[:eio"} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) should fail", spec)
+		}
+	}
+}
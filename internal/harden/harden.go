@@ -0,0 +1,25 @@
+// Package harden locks the running process down before it handles private keys:
+// on Linux a seccomp allowlist of the system calls key generation needs, which
+// leaves out network sockets, exec and ptrace, dropped capabilities and
+// no-new-privs; elsewhere the closest equivalents the platform has
+package harden
+
+// Report lists the measures Apply took and those the platform could not provide
+type Report struct {
+	Applied []string
+	Skipped []string
+}
+
+func (r *Report) applied(measure string) { r.Applied = append(r.Applied, measure) }
+
+func (r *Report) skipped(measure string) { r.Skipped = append(r.Skipped, measure) }
+
+// Apply hardens the process. It cannot be undone, and it fails rather than
+// leaving a measure the platform supports half applied.
+func Apply() (*Report, error) {
+	report := &Report{}
+	if err := apply(report); err != nil {
+		return report, err
+	}
+	return report, nil
+}
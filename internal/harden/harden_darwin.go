@@ -0,0 +1,20 @@
+package harden
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+func apply(report *Report) error {
+	if err := unix.Setrlimit(unix.RLIMIT_CORE, &unix.Rlimit{}); err != nil {
+		return fmt.Errorf("disable core dumps: %w", err)
+	}
+	report.applied("core dumps disabled")
+	if err := unix.PtraceDenyAttach(); err != nil {
+		return fmt.Errorf("disable ptrace attach: %w", err)
+	}
+	report.applied("debugger attach denied (PT_DENY_ATTACH)")
+	report.skipped("system call filter: macOS only sandboxes signed applications")
+	return nil
+}
@@ -0,0 +1,105 @@
+//go:build linux
+
+package harden
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+func apply(report *Report) error {
+	// prctl and seccomp act on the calling thread, so they must share one
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := unix.Setrlimit(unix.RLIMIT_CORE, &unix.Rlimit{}); err != nil {
+		return fmt.Errorf("disable core dumps: %w", err)
+	}
+	// Not dumpable also keeps processes of the same user from attaching with ptrace
+	// or reading /proc/<pid>/mem
+	if err := unix.Prctl(unix.PR_SET_DUMPABLE, 0, 0, 0, 0); err != nil {
+		return fmt.Errorf("disable ptrace attach: %w", err)
+	}
+	report.applied("core dumps and ptrace attach disabled")
+
+	if err := dropCapabilities(report); err != nil {
+		return err
+	}
+	err := allThreads(unix.SYS_PRCTL, unix.PR_SET_NO_NEW_PRIVS, 1, 0)
+	if errors.Is(err, unix.ENOTSUP) {
+		// The seccomp filter synchronised below carries it to the other threads
+		err = unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0)
+	}
+	if err != nil {
+		return fmt.Errorf("set no-new-privs: %w", err)
+	}
+	report.applied("no-new-privs set")
+
+	filter, err := seccompFilter()
+	if err != nil {
+		report.skipped(fmt.Sprintf("seccomp filter: %v", err))
+		return nil
+	}
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	if _, _, errno := unix.Syscall(unix.SYS_SECCOMP, unix.SECCOMP_SET_MODE_FILTER,
+		unix.SECCOMP_FILTER_FLAG_TSYNC, uintptr(unsafe.Pointer(&prog))); errno != 0 {
+		return fmt.Errorf("install seccomp filter: %w", errno)
+	}
+	runtime.KeepAlive(filter)
+	report.applied("seccomp allowlist installed (no network sockets, exec, ptrace, mounts or kernel modules)")
+	return nil
+}
+
+// dropCapabilities empties the bounding, ambient and thread capability sets
+func dropCapabilities(report *Report) error {
+	// Only a process holding CAP_SETPCAP may shrink its bounding set; without it
+	// there is nothing to drop and no-new-privs keeps it from gaining any. A cgo
+	// build (ENOTSUP) is caught by the capset check below.
+	for c := uintptr(0); ; c++ {
+		err := allThreads(unix.SYS_PRCTL, unix.PR_CAPBSET_DROP, c, 0)
+		if errors.Is(err, unix.EINVAL) || errors.Is(err, unix.EPERM) || errors.Is(err, unix.ENOTSUP) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("drop bounding capabilities: %w", err)
+		}
+	}
+	err := allThreads(unix.SYS_PRCTL, unix.PR_CAP_AMBIENT, unix.PR_CAP_AMBIENT_CLEAR_ALL, 0)
+	if err != nil && !errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.ENOTSUP) {
+		return fmt.Errorf("clear ambient capabilities: %w", err)
+	}
+
+	header := unix.CapUserHeader{Version: unix.LINUX_CAPABILITY_VERSION_3}
+	var data [2]unix.CapUserData
+	err = allThreads(unix.SYS_CAPSET, uintptr(unsafe.Pointer(&header)), uintptr(unsafe.Pointer(&data[0])), 0)
+	if errors.Is(err, unix.ENOTSUP) {
+		// Cgo builds cannot change every thread at once; that only matters when the
+		// process holds capabilities to begin with
+		if err := unix.Capget(&header, &data[0]); err != nil {
+			return fmt.Errorf("read capabilities: %w", err)
+		}
+		if data[0].Permitted|data[1].Permitted != 0 {
+			return fmt.Errorf("drop capabilities: this build cannot change every thread; run bloco-eth without privileges")
+		}
+		report.applied("no capabilities held")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("drop capabilities: %w", err)
+	}
+	report.applied("capabilities dropped")
+	return nil
+}
+
+// allThreads makes a system call on every thread of the process
+func allThreads(trap, a1, a2, a3 uintptr) error {
+	if _, _, errno := syscall.AllThreadsSyscall(trap, a1, a2, a3); errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package harden
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// pledgePromises keep file access and the terminal; network, exec and ptrace are
+// not promised, so the kernel kills the process if it tries them
+const pledgePromises = "stdio rpath wpath cpath fattr flock tty"
+
+func apply(report *Report) error {
+	if err := unix.Setrlimit(unix.RLIMIT_CORE, &unix.Rlimit{}); err != nil {
+		return fmt.Errorf("disable core dumps: %w", err)
+	}
+	report.applied("core dumps disabled")
+	if err := unix.Pledge(pledgePromises, ""); err != nil {
+		return fmt.Errorf("pledge: %w", err)
+	}
+	report.applied("pledged " + pledgePromises)
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package harden
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func apply(report *Report) error {
+	report.skipped(fmt.Sprintf("process hardening: not available on %s", runtime.GOOS))
+	return nil
+}
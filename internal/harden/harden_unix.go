@@ -0,0 +1,19 @@
+//go:build unix && !linux && !darwin && !openbsd
+
+package harden
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+func apply(report *Report) error {
+	if err := unix.Setrlimit(unix.RLIMIT_CORE, &unix.Rlimit{}); err != nil {
+		return fmt.Errorf("disable core dumps: %w", err)
+	}
+	report.applied("core dumps disabled")
+	report.skipped(fmt.Sprintf("system call filter and ptrace protection: not available on %s", runtime.GOOS))
+	return nil
+}
@@ -0,0 +1,123 @@
+//go:build linux && (amd64 || arm64)
+
+package harden
+
+import (
+	"slices"
+
+	"golang.org/x/sys/unix"
+)
+
+// allowedSyscalls are all the filter lets through: those the Go runtime needs to
+// run threads, timers and the network poller, file and terminal I/O for
+// keystores, checkpoints and the TUI, sockets limited to AF_UNIX and AF_NETLINK,
+// and the process's own identity and limits. Anything else, and whatever a kernel
+// adds later, fails with EPERM: exec, ptrace and process_vm_*, pidfd_getfd,
+// io_uring, bpf, mounts, namespaces, kernel modules, keyrings and prctl, which
+// could make the process dumpable again.
+var allowedSyscalls = []uint32{
+	// Memory
+	unix.SYS_BRK, unix.SYS_MMAP, unix.SYS_MUNMAP, unix.SYS_MREMAP, unix.SYS_MPROTECT,
+	unix.SYS_MADVISE, unix.SYS_MINCORE, unix.SYS_MLOCK, unix.SYS_MUNLOCK, unix.SYS_MEMBARRIER,
+
+	// Threads, signals and exit
+	unix.SYS_CLONE, unix.SYS_CLONE3, unix.SYS_FUTEX, unix.SYS_SET_ROBUST_LIST, unix.SYS_GET_ROBUST_LIST,
+	unix.SYS_SET_TID_ADDRESS, unix.SYS_RSEQ, unix.SYS_SCHED_YIELD, unix.SYS_SCHED_GETAFFINITY,
+	unix.SYS_RT_SIGACTION, unix.SYS_RT_SIGPROCMASK, unix.SYS_RT_SIGRETURN, unix.SYS_SIGALTSTACK,
+	unix.SYS_RT_SIGTIMEDWAIT, unix.SYS_RT_SIGSUSPEND, unix.SYS_TGKILL, unix.SYS_TKILL, unix.SYS_KILL,
+	unix.SYS_EXIT, unix.SYS_EXIT_GROUP,
+
+	// Time
+	unix.SYS_NANOSLEEP, unix.SYS_CLOCK_NANOSLEEP, unix.SYS_CLOCK_GETTIME, unix.SYS_CLOCK_GETRES,
+	unix.SYS_GETTIMEOFDAY, unix.SYS_SETITIMER, unix.SYS_GETITIMER,
+	unix.SYS_TIMER_CREATE, unix.SYS_TIMER_SETTIME, unix.SYS_TIMER_GETTIME, unix.SYS_TIMER_DELETE,
+
+	// Polling and descriptors
+	unix.SYS_EPOLL_CREATE1, unix.SYS_EPOLL_CTL, unix.SYS_EPOLL_PWAIT, unix.SYS_EPOLL_PWAIT2,
+	unix.SYS_PPOLL, unix.SYS_PSELECT6, unix.SYS_EVENTFD2, unix.SYS_PIPE2,
+	unix.SYS_DUP, unix.SYS_DUP3, unix.SYS_FCNTL, unix.SYS_CLOSE, unix.SYS_CLOSE_RANGE,
+
+	// Files
+	unix.SYS_OPENAT, unix.SYS_OPENAT2, unix.SYS_READ, unix.SYS_WRITE, unix.SYS_READV, unix.SYS_WRITEV,
+	unix.SYS_PREAD64, unix.SYS_PWRITE64, unix.SYS_LSEEK, unix.SYS_FSYNC, unix.SYS_FDATASYNC,
+	unix.SYS_FTRUNCATE, unix.SYS_FALLOCATE, unix.SYS_FADVISE64, unix.SYS_FLOCK,
+	unix.SYS_FSTAT, unix.SYS_NEWFSTATAT, unix.SYS_STATX, unix.SYS_FSTATFS, unix.SYS_STATFS,
+	unix.SYS_FACCESSAT, unix.SYS_FACCESSAT2, unix.SYS_GETDENTS64, unix.SYS_READLINKAT,
+	unix.SYS_MKDIRAT, unix.SYS_UNLINKAT, unix.SYS_RENAMEAT, unix.SYS_RENAMEAT2, unix.SYS_LINKAT,
+	unix.SYS_SYMLINKAT, unix.SYS_FCHMOD, unix.SYS_FCHMODAT, unix.SYS_FCHOWN, unix.SYS_FCHOWNAT,
+	unix.SYS_UTIMENSAT, unix.SYS_UMASK, unix.SYS_GETCWD, unix.SYS_CHDIR, unix.SYS_FCHDIR,
+
+	// Local sockets; socket itself is checked before this list
+	unix.SYS_CONNECT, unix.SYS_BIND, unix.SYS_GETSOCKNAME, unix.SYS_GETPEERNAME,
+	unix.SYS_SETSOCKOPT, unix.SYS_GETSOCKOPT, unix.SYS_SENDTO, unix.SYS_RECVFROM,
+	unix.SYS_SENDMSG, unix.SYS_RECVMSG, unix.SYS_SHUTDOWN,
+
+	// Identity and limits
+	unix.SYS_GETPID, unix.SYS_GETPPID, unix.SYS_GETTID, unix.SYS_GETUID, unix.SYS_GETEUID,
+	unix.SYS_GETGID, unix.SYS_GETEGID, unix.SYS_GETGROUPS, unix.SYS_GETRESUID, unix.SYS_GETRESGID,
+	unix.SYS_GETPGID, unix.SYS_GETSID, unix.SYS_UNAME, unix.SYS_SYSINFO, unix.SYS_GETRUSAGE,
+	unix.SYS_GETRLIMIT, unix.SYS_PRLIMIT64, unix.SYS_GETPRIORITY, unix.SYS_SETPRIORITY, unix.SYS_GETRANDOM,
+}
+
+// Offsets of the fields of struct seccomp_data the filter reads; the low half of
+// an argument comes first on these little-endian architectures
+const (
+	offsetNr   = 0
+	offsetArch = 4
+	offsetArg0 = 16
+	offsetArg1 = 24
+)
+
+const retDeny = unix.SECCOMP_RET_ERRNO | uint32(unix.EPERM)
+
+// seccompFilter builds the BPF program of the filter
+func seccompFilter() ([]unix.SockFilter, error) {
+	prog := []unix.SockFilter{
+		load(offsetArch),
+		jumpEq(auditArch, 1, 0),
+		ret(unix.SECCOMP_RET_KILL_PROCESS),
+		load(offsetNr),
+	}
+	if x32SyscallBit != 0 {
+		prog = append(prog,
+			jump(unix.BPF_JGE, x32SyscallBit, 0, 1),
+			ret(unix.SECCOMP_RET_KILL_PROCESS))
+	}
+	// Unix sockets stay open to local daemons such as pcscd for YubiKeys, and
+	// netlink to listing interfaces, which --ceremony checks; without capabilities
+	// neither reaches past the host
+	prog = append(prog,
+		jumpEq(unix.SYS_SOCKET, 0, 5),
+		load(offsetArg0),
+		jumpEq(unix.AF_UNIX, 2, 0),
+		jumpEq(unix.AF_NETLINK, 1, 0),
+		ret(retDeny),
+		ret(unix.SECCOMP_RET_ALLOW))
+	// The terminal keeps its ioctls but for those that type into it, which would
+	// have the shell run commands for the process
+	prog = append(prog,
+		jumpEq(unix.SYS_IOCTL, 0, 5),
+		load(offsetArg1),
+		jumpEq(unix.TIOCSTI, 2, 0),
+		jumpEq(unix.TIOCLINUX, 1, 0),
+		ret(unix.SECCOMP_RET_ALLOW),
+		ret(retDeny))
+	for _, nr := range slices.Concat(allowedSyscalls, archAllowedSyscalls) {
+		prog = append(prog, jumpEq(nr, 0, 1), ret(unix.SECCOMP_RET_ALLOW))
+	}
+	return append(prog, ret(retDeny)), nil
+}
+
+func load(offset uint32) unix.SockFilter {
+	return unix.SockFilter{Code: unix.BPF_LD | unix.BPF_W | unix.BPF_ABS, K: offset}
+}
+
+func jumpEq(k uint32, jt, jf uint8) unix.SockFilter { return jump(unix.BPF_JEQ, k, jt, jf) }
+
+func jump(op uint16, k uint32, jt, jf uint8) unix.SockFilter {
+	return unix.SockFilter{Code: unix.BPF_JMP | op | unix.BPF_K, Jt: jt, Jf: jf, K: k}
+}
+
+func ret(k uint32) unix.SockFilter {
+	return unix.SockFilter{Code: unix.BPF_RET | unix.BPF_K, K: k}
+}
@@ -0,0 +1,17 @@
+package harden
+
+import "golang.org/x/sys/unix"
+
+const auditArch = unix.AUDIT_ARCH_X86_64
+
+// x32SyscallBit marks x32 ABI system calls, which share the x86-64 audit arch
+const x32SyscallBit = 0x40000000
+
+// archAllowedSyscalls are the allowed system calls only this architecture has:
+// the older forms arm64 replaced with their *at and p* counterparts
+var archAllowedSyscalls = []uint32{
+	unix.SYS_ARCH_PRCTL, unix.SYS_OPEN, unix.SYS_STAT, unix.SYS_LSTAT, unix.SYS_ACCESS,
+	unix.SYS_READLINK, unix.SYS_MKDIR, unix.SYS_RMDIR, unix.SYS_RENAME, unix.SYS_UNLINK,
+	unix.SYS_CHMOD, unix.SYS_GETDENTS, unix.SYS_POLL, unix.SYS_SELECT, unix.SYS_PIPE,
+	unix.SYS_DUP2, unix.SYS_EPOLL_CREATE, unix.SYS_EPOLL_WAIT, unix.SYS_TIME, unix.SYS_GETPGRP,
+}
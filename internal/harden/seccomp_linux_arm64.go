@@ -0,0 +1,11 @@
+package harden
+
+import "golang.org/x/sys/unix"
+
+const auditArch = unix.AUDIT_ARCH_AARCH64
+
+// x32SyscallBit is zero: arm64 has no second system call ABI to reject
+const x32SyscallBit = 0
+
+// archAllowedSyscalls is empty: every allowed system call of arm64 is shared
+var archAllowedSyscalls = []uint32{}
@@ -0,0 +1,15 @@
+//go:build linux && !amd64 && !arm64
+
+package harden
+
+import (
+	"fmt"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// seccompFilter is only built for the release architectures
+func seccompFilter() ([]unix.SockFilter, error) {
+	return nil, fmt.Errorf("not available on %s", runtime.GOARCH)
+}
@@ -0,0 +1,95 @@
+//go:build linux && (amd64 || arm64)
+
+package harden
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// runFilter interprets the BPF program on a seccomp_data for a system call
+func runFilter(t *testing.T, prog []unix.SockFilter, arch, nr, arg0, arg1 uint32) uint32 {
+	t.Helper()
+	data := make([]byte, 64)
+	binary.LittleEndian.PutUint32(data[offsetNr:], nr)
+	binary.LittleEndian.PutUint32(data[offsetArch:], arch)
+	binary.LittleEndian.PutUint32(data[offsetArg0:], arg0)
+	binary.LittleEndian.PutUint32(data[offsetArg1:], arg1)
+
+	var acc uint32
+	for pc := 0; pc < len(prog); pc++ {
+		in := prog[pc]
+		switch in.Code {
+		case unix.BPF_LD | unix.BPF_W | unix.BPF_ABS:
+			acc = binary.LittleEndian.Uint32(data[in.K:])
+		case unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K, unix.BPF_JMP | unix.BPF_JGE | unix.BPF_K:
+			taken := acc == in.K
+			if in.Code&0xf0 == unix.BPF_JGE {
+				taken = acc >= in.K
+			}
+			if taken {
+				pc += int(in.Jt)
+			} else {
+				pc += int(in.Jf)
+			}
+		case unix.BPF_RET | unix.BPF_K:
+			return in.K
+		default:
+			t.Fatalf("instruction %d: unexpected opcode %#x", pc, in.Code)
+		}
+	}
+	t.Fatal("filter fell off the end of the program")
+	return 0
+}
+
+func TestSeccompFilter(t *testing.T) {
+	prog, err := seccompFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	type filterCase struct {
+		name                 string
+		arch, nr, arg0, arg1 uint32
+		want                 uint32
+	}
+	tests := []filterCase{
+		{"read", auditArch, unix.SYS_READ, 0, 0, unix.SECCOMP_RET_ALLOW},
+		{"openat", auditArch, unix.SYS_OPENAT, 0, 0, unix.SECCOMP_RET_ALLOW},
+		{"unix socket", auditArch, unix.SYS_SOCKET, unix.AF_UNIX, 0, unix.SECCOMP_RET_ALLOW},
+		{"inet socket", auditArch, unix.SYS_SOCKET, unix.AF_INET, 0, retDeny},
+		{"inet6 socket", auditArch, unix.SYS_SOCKET, unix.AF_INET6, 0, retDeny},
+		{"netlink socket", auditArch, unix.SYS_SOCKET, unix.AF_NETLINK, 0, unix.SECCOMP_RET_ALLOW},
+		{"packet socket", auditArch, unix.SYS_SOCKET, unix.AF_PACKET, 0, retDeny},
+		{"ptrace", auditArch, unix.SYS_PTRACE, 0, 0, retDeny},
+		{"execve", auditArch, unix.SYS_EXECVE, 0, 0, retDeny},
+		{"io_uring", auditArch, unix.SYS_IO_URING_SETUP, 0, 0, retDeny},
+		{"pidfd_getfd", auditArch, unix.SYS_PIDFD_GETFD, 0, 0, retDeny},
+		{"pidfd_open", auditArch, unix.SYS_PIDFD_OPEN, 0, 0, retDeny},
+		{"process_madvise", auditArch, unix.SYS_PROCESS_MADVISE, 0, 0, retDeny},
+		{"open_tree", auditArch, unix.SYS_OPEN_TREE, 0, 0, retDeny},
+		{"move_mount", auditArch, unix.SYS_MOVE_MOUNT, 0, 0, retDeny},
+		{"fsopen", auditArch, unix.SYS_FSOPEN, 0, 0, retDeny},
+		{"fsmount", auditArch, unix.SYS_FSMOUNT, 0, 0, retDeny},
+		{"prctl", auditArch, unix.SYS_PRCTL, unix.PR_SET_DUMPABLE, 0, retDeny},
+		{"unlisted", auditArch, 1023, 0, 0, retDeny},
+		{"terminal ioctl", auditArch, unix.SYS_IOCTL, 0, unix.TCGETS, unix.SECCOMP_RET_ALLOW},
+		{"TIOCSTI ioctl", auditArch, unix.SYS_IOCTL, 0, unix.TIOCSTI, retDeny},
+		{"TIOCLINUX ioctl", auditArch, unix.SYS_IOCTL, 0, unix.TIOCLINUX, retDeny},
+		{"foreign arch", auditArch ^ 1, unix.SYS_READ, 0, 0, unix.SECCOMP_RET_KILL_PROCESS},
+	}
+	if x32SyscallBit != 0 {
+		tests = append(tests, filterCase{"x32 abi", auditArch, x32SyscallBit | unix.SYS_READ, 0, 0, unix.SECCOMP_RET_KILL_PROCESS})
+	}
+	for _, nr := range slices.Concat(allowedSyscalls, archAllowedSyscalls) {
+		tests = append(tests, filterCase{fmt.Sprintf("allowed %d", nr), auditArch, nr, 0, 0, unix.SECCOMP_RET_ALLOW})
+	}
+	for _, tt := range tests {
+		if got := runFilter(t, prog, tt.arch, tt.nr, tt.arg0, tt.arg1); got != tt.want {
+			t.Errorf("%s: filter returned %#x, want %#x", tt.name, got, tt.want)
+		}
+	}
+}
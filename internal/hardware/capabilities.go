@@ -0,0 +1,87 @@
+package hardware
+
+import (
+	"os"
+	"runtime"
+)
+
+// Capability describes what a platform's secure hardware can do with wallet keys
+type Capability struct {
+	OS       string `json:"os"`
+	Hardware string `json:"hardware"`
+	Backend  string `json:"backend,omitempty"`
+	// Native key generation inside the hardware, per wallet curve
+	NativeSecp256k1 bool `json:"native_secp256k1"`
+	NativeEd25519   bool `json:"native_ed25519"`
+	// Sealing an imported key so only this hardware can recover it
+	Seal bool `json:"seal"`
+	// Importing a key the hardware then uses without ever revealing it
+	Import bool `json:"import"`
+	// Implemented by bloco-eth on this platform
+	Supported bool   `json:"supported"`
+	Notes     string `json:"notes"`
+}
+
+// Capabilities is the capability matrix of every platform. TPM 2.0 supports NIST
+// P-256/P-384 and BN-256, the Secure Enclave P-256 only and YubiKey PIV no secp256k1,
+// so the accepted key is generated in software and sealed or imported.
+var Capabilities = []Capability{
+	{
+		OS:        "linux",
+		Hardware:  "TPM 2.0",
+		Backend:   BackendTPM2,
+		Seal:      true,
+		Supported: true,
+		Notes:     "via /dev/tpmrm0 or /dev/tpm0; keys only unseal on the same TPM",
+	},
+	{
+		OS:       "windows",
+		Hardware: "TPM 2.0",
+		Seal:     true,
+		Notes:    "needs the TPM Base Services API, not implemented",
+	},
+	{
+		OS:       "darwin",
+		Hardware: "Secure Enclave",
+		Notes:    "P-256 keys only and no sealing of imported keys; needs a signed, entitled binary",
+	},
+	{
+		OS:            "any",
+		Hardware:      "YubiKey PIV",
+		Backend:       BackendYubiKeyPIV,
+		NativeEd25519: true,
+		Import:        true,
+		Supported:     true,
+		Notes:         "via ykman; Ed25519 (Solana) keys on firmware 5.7+, no secp256k1",
+	},
+}
+
+// Detection is the secure hardware found on this system
+type Detection struct {
+	Capability
+	Device    string `json:"device,omitempty"`
+	Available bool   `json:"available"`
+}
+
+// Detect reports the secure hardware of the current platform
+func Detect() Detection {
+	for _, c := range Capabilities {
+		if c.OS != runtime.GOOS || !c.Supported {
+			continue
+		}
+		d := Detection{Capability: c}
+		for _, path := range TPMDevices {
+			if _, err := os.Stat(path); err == nil {
+				d.Device, d.Available = path, true
+				break
+			}
+		}
+		return d
+	}
+	for _, c := range Capabilities {
+		if c.OS == runtime.GOOS {
+			return Detection{Capability: c}
+		}
+	}
+	return Detection{Capability: Capability{OS: runtime.GOOS, Notes: "no supported secure hardware"}}
+}
@@ -0,0 +1,138 @@
+package hardware
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BackendTPM2 names keys sealed by a TPM 2.0
+const BackendTPM2 = "tpm2"
+
+// handleVersion is the key handle file format version
+const handleVersion = 1
+
+// KeyHandle refers to a private key held by secure hardware. It holds no key
+// material usable without the hardware that holds the key.
+type KeyHandle struct {
+	Version           int       `json:"version"`
+	Backend           string    `json:"backend"`
+	Network           string    `json:"network"`
+	Address           string    `json:"address"`
+	PasswordProtected bool      `json:"password_protected"`
+	TPMPublic         string    `json:"tpm_public,omitempty"`
+	TPMPrivate        string    `json:"tpm_private,omitempty"`
+	Serial            string    `json:"serial,omitempty"`
+	Slot              string    `json:"slot,omitempty"`
+	Attestation       string    `json:"attestation,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// NewTPMHandle describes a key sealed by a TPM
+func NewTPMHandle(address, network string, blob *SealedBlob, passwordProtected bool) *KeyHandle {
+	return &KeyHandle{
+		Version:           handleVersion,
+		Backend:           BackendTPM2,
+		Network:           network,
+		Address:           address,
+		PasswordProtected: passwordProtected,
+		TPMPublic:         hex.EncodeToString(blob.Public),
+		TPMPrivate:        hex.EncodeToString(blob.Private),
+		CreatedAt:         time.Now().UTC(),
+	}
+}
+
+// NewYubiKeyHandle describes a key imported into a YubiKey PIV slot, with the
+// device's attestation certificate
+func NewYubiKeyHandle(address, network, serial, slot, attestation string) *KeyHandle {
+	return &KeyHandle{
+		Version:     handleVersion,
+		Backend:     BackendYubiKeyPIV,
+		Network:     network,
+		Address:     address,
+		Serial:      serial,
+		Slot:        slot,
+		Attestation: attestation,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// Blob returns the sealed TPM object of the handle
+func (h *KeyHandle) Blob() (*SealedBlob, error) {
+	if h.Backend != BackendTPM2 {
+		return nil, fmt.Errorf("unsupported key handle backend %q", h.Backend)
+	}
+	public, err := hex.DecodeString(h.TPMPublic)
+	if err != nil || len(public) == 0 {
+		return nil, fmt.Errorf("invalid tpm_public in key handle")
+	}
+	private, err := hex.DecodeString(h.TPMPrivate)
+	if err != nil || len(private) == 0 {
+		return nil, fmt.Errorf("invalid tpm_private in key handle")
+	}
+	return &SealedBlob{Public: public, Private: private}, nil
+}
+
+// HandleFileName returns the file name of the handle of address. Hex addresses are
+// lowercased; base58 addresses are case-sensitive and kept as they are.
+func HandleFileName(address, backend string) string {
+	if strings.HasPrefix(address, "0x") {
+		address = strings.ToLower(address)
+	}
+	return address + "." + backend + ".json"
+}
+
+// SaveHandle writes a key handle into dir and returns its path. Existing handles
+// are never overwritten.
+func SaveHandle(dir string, h *KeyHandle) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode key handle: %w", err)
+	}
+	path := filepath.Join(dir, HandleFileName(h.Address, h.Backend))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// LoadHandle reads a key handle file
+func LoadHandle(path string) (*KeyHandle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var h KeyHandle
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("invalid key handle %s: %w", path, err)
+	}
+	if h.Version != handleVersion {
+		return nil, fmt.Errorf("unsupported key handle version %d in %s", h.Version, path)
+	}
+	switch h.Backend {
+	case BackendYubiKeyPIV:
+		if h.Serial == "" || ValidatePIVSlot(h.Slot) != nil {
+			return nil, fmt.Errorf("%s: invalid YubiKey serial or slot", path)
+		}
+	default:
+		if _, err := h.Blob(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return &h, nil
+}
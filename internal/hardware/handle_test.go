@@ -0,0 +1,56 @@
+package hardware
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadHandle(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	blob := &SealedBlob{Public: []byte{1, 2}, Private: []byte{3, 4}}
+	h := NewTPMHandle("0xAbC0000000000000000000000000000000000001", "ethereum", blob, true)
+
+	path, err := SaveHandle(dir, h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "0xabc0000000000000000000000000000000000001.tpm2.json" {
+		t.Errorf("handle saved as %s", path)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0600 {
+		t.Errorf("handle mode %v, want 0600", info.Mode().Perm())
+	}
+	if _, err := SaveHandle(dir, h); err == nil {
+		t.Error("expected an error overwriting a handle")
+	}
+
+	loaded, err := LoadHandle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := loaded.Blob()
+	if loaded.Address != h.Address || !loaded.PasswordProtected || !bytes.Equal(got.Private, blob.Private) {
+		t.Errorf("loaded handle %+v", loaded)
+	}
+
+	os.WriteFile(path, []byte(`{"version":1,"backend":"tpm2","tpm_public":"zz"}`), 0600)
+	if _, err := LoadHandle(path); err == nil {
+		t.Error("expected an error loading a corrupt handle")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	for _, c := range Capabilities {
+		if c.NativeSecp256k1 {
+			t.Errorf("%s %s claims native secp256k1 keys", c.OS, c.Hardware)
+		}
+		if c.Supported && c.Backend == "" {
+			t.Errorf("%s %s is supported without a backend", c.OS, c.Hardware)
+		}
+	}
+	if d := Detect(); d.Available && !d.Supported {
+		t.Errorf("Detect() = %+v", d)
+	}
+}
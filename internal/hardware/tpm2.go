@@ -0,0 +1,314 @@
+// Package hardware keeps wallet private keys in secure hardware. Wallet keys are
+// secp256k1 or Ed25519, curves no TPM 2.0 or Secure Enclave can generate keys on,
+// so keys are sealed instead: the hardware encrypts them to a key that never leaves
+// it, and only the same chip can unseal them again.
+package hardware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// TPMDevices are the TPM 2.0 character devices tried in order; the kernel resource
+// manager comes first so other TPM users are not disturbed
+var TPMDevices = []string{"/dev/tpmrm0", "/dev/tpm0"}
+
+// ErrNoTPM is returned when no TPM 2.0 device can be opened
+var ErrNoTPM = errors.New("no TPM 2.0 device found")
+
+// ErrWrongPassword is returned when the TPM rejects the sealed key's password
+var ErrWrongPassword = errors.New("the TPM rejected the password for this key")
+
+// TPM 2.0 structure tags, command codes, handles and algorithms (TPM 2.0 Part 2)
+const (
+	tpmSTNoSessions uint16 = 0x8001
+	tpmSTSessions   uint16 = 0x8002
+
+	tpmCCCreatePrimary uint32 = 0x00000131
+	tpmCCCreate        uint32 = 0x00000153
+	tpmCCLoad          uint32 = 0x00000157
+	tpmCCUnseal        uint32 = 0x0000015e
+	tpmCCFlushContext  uint32 = 0x00000165
+
+	tpmRHOwner uint32 = 0x40000001
+	tpmRSPW    uint32 = 0x40000009
+
+	tpmAlgAES       uint16 = 0x0006
+	tpmAlgKeyedHash uint16 = 0x0008
+	tpmAlgSHA256    uint16 = 0x000b
+	tpmAlgNull      uint16 = 0x0010
+	tpmAlgECC       uint16 = 0x0023
+	tpmAlgCFB       uint16 = 0x0043
+	tpmECCNistP256  uint16 = 0x0003
+
+	tpmAttrFixedTPM            uint32 = 1 << 1
+	tpmAttrFixedParent         uint32 = 1 << 4
+	tpmAttrSensitiveDataOrigin uint32 = 1 << 5
+	tpmAttrUserWithAuth        uint32 = 1 << 6
+	tpmAttrNoDA                uint32 = 1 << 10
+	tpmAttrRestricted          uint32 = 1 << 16
+	tpmAttrDecrypt             uint32 = 1 << 17
+
+	tpmRCLockout uint32 = 0x921
+
+	// maxTPMResponse bounds a response read from the device
+	maxTPMResponse = 4096
+)
+
+// SealedBlob is a key sealed by a TPM: the public area and the private area
+// encrypted to the TPM's storage root key
+type SealedBlob struct {
+	Public  []byte
+	Private []byte
+}
+
+// TPM talks to a TPM 2.0 over its command/response interface
+type TPM struct {
+	rw   io.ReadWriter
+	path string
+}
+
+// OpenTPM opens the first TPM 2.0 device available
+func OpenTPM() (*TPM, error) {
+	for _, path := range TPMDevices {
+		f, err := os.OpenFile(path, os.O_RDWR, 0)
+		if err == nil {
+			return &TPM{rw: f, path: path}, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+	}
+	return nil, ErrNoTPM
+}
+
+// Path returns the device the TPM was opened from
+func (t *TPM) Path() string {
+	return t.path
+}
+
+// Close releases the device
+func (t *TPM) Close() error {
+	if c, ok := t.rw.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Seal encrypts secret to the TPM's owner storage root key. The sealed object can
+// only be unsealed on this TPM, and only with password when one is set.
+func (t *TPM) Seal(secret, password []byte) (*SealedBlob, error) {
+	primary, err := t.createPrimary()
+	if err != nil {
+		return nil, err
+	}
+	defer t.flush(primary)
+
+	var params bytes.Buffer
+	var sensitive bytes.Buffer
+	writeTPM2B(&sensitive, authValue(password))
+	writeTPM2B(&sensitive, secret)
+	writeTPM2B(&params, sensitive.Bytes())
+	writeTPM2B(&params, sealedTemplate())
+	writeTPM2B(&params, nil)                           // outsideInfo
+	binary.Write(&params, binary.BigEndian, uint32(0)) // creationPCR: no PCRs
+
+	_, out, err := t.command(tpmCCCreate, []uint32{primary}, nil, 0, params.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("TPM2_Create failed: %w", err)
+	}
+	r := bytes.NewReader(out)
+	private, err := readTPM2B(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TPM2_Create response: %w", err)
+	}
+	public, err := readTPM2B(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TPM2_Create response: %w", err)
+	}
+	return &SealedBlob{Public: public, Private: private}, nil
+}
+
+// Unseal returns the secret of a blob sealed on this TPM
+func (t *TPM) Unseal(blob *SealedBlob, password []byte) ([]byte, error) {
+	primary, err := t.createPrimary()
+	if err != nil {
+		return nil, err
+	}
+	defer t.flush(primary)
+
+	var params bytes.Buffer
+	writeTPM2B(&params, blob.Private)
+	writeTPM2B(&params, blob.Public)
+	handles, _, err := t.command(tpmCCLoad, []uint32{primary}, nil, 1, params.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("TPM2_Load failed (was the key sealed on another TPM?): %w", err)
+	}
+	defer t.flush(handles[0])
+
+	_, out, err := t.command(tpmCCUnseal, []uint32{handles[0]}, authValue(password), 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("TPM2_Unseal failed: %w", err)
+	}
+	secret, err := readTPM2B(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TPM2_Unseal response: %w", err)
+	}
+	return secret, nil
+}
+
+// createPrimary loads the standard ECC P-256 storage root key of the owner
+// hierarchy. It is derived from the TPM's seed, so it is the same on every call.
+func (t *TPM) createPrimary() (uint32, error) {
+	var params bytes.Buffer
+	writeTPM2B(&params, make([]byte, 4)) // empty userAuth and data
+	writeTPM2B(&params, srkTemplate())
+	writeTPM2B(&params, nil)
+	binary.Write(&params, binary.BigEndian, uint32(0))
+
+	handles, _, err := t.command(tpmCCCreatePrimary, []uint32{tpmRHOwner}, nil, 1, params.Bytes())
+	if err != nil {
+		return 0, fmt.Errorf("TPM2_CreatePrimary failed: %w", err)
+	}
+	return handles[0], nil
+}
+
+// flush unloads a transient object
+func (t *TPM) flush(handle uint32) {
+	params := binary.BigEndian.AppendUint32(nil, handle)
+	_, _ = t.run(tpmSTNoSessions, tpmCCFlushContext, params)
+}
+
+// command runs a command authorized by a password session for its first handle
+// and returns the response handles and parameters
+func (t *TPM) command(code uint32, handles []uint32, password []byte, outHandles int, params []byte) ([]uint32, []byte, error) {
+	var body bytes.Buffer
+	for _, h := range handles {
+		binary.Write(&body, binary.BigEndian, h)
+	}
+	var session bytes.Buffer
+	binary.Write(&session, binary.BigEndian, tpmRSPW)
+	writeTPM2B(&session, nil) // nonce
+	session.WriteByte(0x01)   // continueSession
+	writeTPM2B(&session, password)
+	binary.Write(&body, binary.BigEndian, uint32(session.Len()))
+	body.Write(session.Bytes())
+	body.Write(params)
+
+	resp, err := t.run(tpmSTSessions, code, body.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(resp) < 4*outHandles+4 {
+		return nil, nil, fmt.Errorf("response too short")
+	}
+	out := make([]uint32, outHandles)
+	for i := range out {
+		out[i] = binary.BigEndian.Uint32(resp[4*i:])
+	}
+	resp = resp[4*outHandles:]
+	size := binary.BigEndian.Uint32(resp)
+	if uint32(len(resp)-4) < size {
+		return nil, nil, fmt.Errorf("response parameters truncated")
+	}
+	return out, resp[4 : 4+size], nil
+}
+
+// run sends one command and returns the response after its header
+func (t *TPM) run(tag uint16, code uint32, body []byte) ([]byte, error) {
+	cmd := make([]byte, 10, 10+len(body))
+	binary.BigEndian.PutUint16(cmd, tag)
+	binary.BigEndian.PutUint32(cmd[2:], uint32(10+len(body)))
+	binary.BigEndian.PutUint32(cmd[6:], code)
+	if _, err := t.rw.Write(append(cmd, body...)); err != nil {
+		return nil, fmt.Errorf("failed to send TPM command: %w", err)
+	}
+
+	resp := make([]byte, maxTPMResponse)
+	n, err := t.rw.Read(resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TPM response: %w", err)
+	}
+	if n < 10 || int(binary.BigEndian.Uint32(resp[2:])) != n {
+		return nil, fmt.Errorf("malformed TPM response")
+	}
+	if rc := binary.BigEndian.Uint32(resp[6:]); rc != 0 {
+		return nil, responseError(rc)
+	}
+	return resp[10:n], nil
+}
+
+// responseError describes a TPM response code
+func responseError(rc uint32) error {
+	// Format-one codes carry the failing session or parameter in their upper bits
+	if rc&0x80 != 0 {
+		switch rc & 0x3f {
+		case 0x0e, 0x22: // TPM_RC_AUTH_FAIL, TPM_RC_BAD_AUTH
+			return ErrWrongPassword
+		}
+	}
+	if rc == tpmRCLockout {
+		return fmt.Errorf("the TPM is locked out after too many wrong passwords; wait or clear the lockout")
+	}
+	return fmt.Errorf("TPM response code %#x", rc)
+}
+
+// authValue turns a password into an auth value that fits the SHA-256 name algorithm
+func authValue(password []byte) []byte {
+	if len(password) == 0 {
+		return nil
+	}
+	sum := sha256.Sum256(password)
+	return sum[:]
+}
+
+// srkTemplate is the TCG default ECC P-256 storage root key template
+func srkTemplate() []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, tpmAlgECC)
+	binary.Write(&b, binary.BigEndian, tpmAlgSHA256)
+	binary.Write(&b, binary.BigEndian, tpmAttrFixedTPM|tpmAttrFixedParent|tpmAttrSensitiveDataOrigin|
+		tpmAttrUserWithAuth|tpmAttrNoDA|tpmAttrRestricted|tpmAttrDecrypt)
+	writeTPM2B(&b, nil) // authPolicy
+	binary.Write(&b, binary.BigEndian, []uint16{tpmAlgAES, 128, tpmAlgCFB, tpmAlgNull, tpmECCNistP256, tpmAlgNull})
+	writeTPM2B(&b, make([]byte, 32))
+	writeTPM2B(&b, make([]byte, 32))
+	return b.Bytes()
+}
+
+// sealedTemplate describes a sealed data object. Wrong passwords count towards the
+// TPM's dictionary attack lockout.
+func sealedTemplate() []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, tpmAlgKeyedHash)
+	binary.Write(&b, binary.BigEndian, tpmAlgSHA256)
+	binary.Write(&b, binary.BigEndian, tpmAttrFixedTPM|tpmAttrFixedParent|tpmAttrUserWithAuth)
+	writeTPM2B(&b, nil) // authPolicy
+	binary.Write(&b, binary.BigEndian, tpmAlgNull)
+	writeTPM2B(&b, nil) // unique
+	return b.Bytes()
+}
+
+// writeTPM2B writes a size-prefixed buffer
+func writeTPM2B(b *bytes.Buffer, data []byte) {
+	binary.Write(b, binary.BigEndian, uint16(len(data)))
+	b.Write(data)
+}
+
+// readTPM2B reads a size-prefixed buffer
+func readTPM2B(r *bytes.Reader) ([]byte, error) {
+	var size uint16
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,155 @@
+package hardware
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// fakeTPM answers the commands Seal and Unseal send, keeping sealed objects in memory
+type fakeTPM struct {
+	t       *testing.T
+	resp    []byte
+	objects map[string]fakeObject
+	loaded  map[uint32]fakeObject
+	next    uint32
+}
+
+type fakeObject struct {
+	auth, secret []byte
+}
+
+func newFakeTPM(t *testing.T) *fakeTPM {
+	return &fakeTPM{t: t, objects: map[string]fakeObject{}, loaded: map[uint32]fakeObject{}, next: 0x80000000}
+}
+
+func (f *fakeTPM) Read(p []byte) (int, error) {
+	return copy(p, f.resp), nil
+}
+
+func (f *fakeTPM) Write(p []byte) (int, error) {
+	r := bytes.NewReader(p)
+	var tag uint16
+	var size, code uint32
+	binary.Read(r, binary.BigEndian, &tag)
+	binary.Read(r, binary.BigEndian, &size)
+	binary.Read(r, binary.BigEndian, &code)
+	if int(size) != len(p) {
+		f.t.Fatalf("command size %d, wrote %d bytes", size, len(p))
+	}
+
+	if code == tpmCCFlushContext {
+		var handle uint32
+		binary.Read(r, binary.BigEndian, &handle)
+		delete(f.loaded, handle)
+		f.respond(tpmSTNoSessions, 0, nil)
+		return len(p), nil
+	}
+
+	var handle, sessionSize, sessionHandle uint32
+	binary.Read(r, binary.BigEndian, &handle)
+	binary.Read(r, binary.BigEndian, &sessionSize)
+	binary.Read(r, binary.BigEndian, &sessionHandle)
+	readTPM2B(r) // nonce
+	r.ReadByte()
+	auth, _ := readTPM2B(r)
+	if sessionHandle != tpmRSPW {
+		f.t.Fatalf("session handle %#x", sessionHandle)
+	}
+
+	switch code {
+	case tpmCCCreatePrimary:
+		f.next++
+		f.loaded[f.next] = fakeObject{}
+		f.respond(tpmSTSessions, 0, binary.BigEndian.AppendUint32(nil, f.next), []byte{0, 0})
+	case tpmCCCreate:
+		sensitive, _ := readTPM2B(r)
+		public, _ := readTPM2B(r)
+		s := bytes.NewReader(sensitive)
+		objAuth, _ := readTPM2B(s)
+		secret, _ := readTPM2B(s)
+		private := []byte{byte(len(f.objects))}
+		f.objects[string(private)] = fakeObject{auth: objAuth, secret: secret}
+		f.respond(tpmSTSessions, 0, nil, tpm2b(private), tpm2b(public))
+	case tpmCCLoad:
+		private, _ := readTPM2B(r)
+		obj, ok := f.objects[string(private)]
+		if !ok {
+			f.respond(tpmSTSessions, 0x1df, nil)
+			break
+		}
+		f.next++
+		f.loaded[f.next] = obj
+		f.respond(tpmSTSessions, 0, binary.BigEndian.AppendUint32(nil, f.next))
+	case tpmCCUnseal:
+		obj := f.loaded[handle]
+		if !bytes.Equal(obj.auth, auth) {
+			f.respond(tpmSTSessions, 0x98e, nil) // TPM_RC_AUTH_FAIL for session 1
+			break
+		}
+		f.respond(tpmSTSessions, 0, nil, tpm2b(obj.secret))
+	default:
+		f.t.Fatalf("unexpected command %#x", code)
+	}
+	return len(p), nil
+}
+
+// respond queues a response with handles and parameters
+func (f *fakeTPM) respond(tag uint16, rc uint32, handles []byte, params ...[]byte) {
+	body := append([]byte(nil), handles...)
+	if rc == 0 && tag == tpmSTSessions {
+		joined := bytes.Join(params, nil)
+		body = binary.BigEndian.AppendUint32(body, uint32(len(joined)))
+		body = append(body, joined...)
+	}
+	if rc != 0 {
+		body = nil
+	}
+	resp := binary.BigEndian.AppendUint16(nil, tag)
+	resp = binary.BigEndian.AppendUint32(resp, uint32(10+len(body)))
+	resp = binary.BigEndian.AppendUint32(resp, rc)
+	f.resp = append(resp, body...)
+}
+
+func tpm2b(data []byte) []byte {
+	return append(binary.BigEndian.AppendUint16(nil, uint16(len(data))), data...)
+}
+
+func TestTPM_SealUnseal(t *testing.T) {
+	fake := newFakeTPM(t)
+	tpm := &TPM{rw: fake}
+	secret := []byte("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+
+	blob, err := tpm.Seal(secret, []byte("hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := tpm.Unseal(blob, []byte("hunter2"))
+	if err != nil || !bytes.Equal(got, secret) {
+		t.Fatalf("Unseal() = %q, %v", got, err)
+	}
+	if _, err := tpm.Unseal(blob, []byte("wrong")); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Unseal() with a wrong password = %v", err)
+	}
+	if len(fake.loaded) != 0 {
+		t.Errorf("%d objects left loaded", len(fake.loaded))
+	}
+
+	unprotected, _ := tpm.Seal(secret, nil)
+	if got, err := tpm.Unseal(unprotected, nil); err != nil || !bytes.Equal(got, secret) {
+		t.Errorf("Unseal() without a password = %q, %v", got, err)
+	}
+	if _, err := tpm.Unseal(&SealedBlob{Public: blob.Public, Private: []byte{0xff}}, nil); err == nil {
+		t.Error("expected an error loading a blob from another TPM")
+	}
+}
+
+func TestResponseError(t *testing.T) {
+	if err := responseError(0x9a2); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("TPM_RC_BAD_AUTH = %v", err)
+	}
+	if err := responseError(tpmRCLockout); errors.Is(err, ErrWrongPassword) || err == nil {
+		t.Errorf("TPM_RC_LOCKOUT = %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package hardware
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// BackendYubiKeyPIV names keys imported into a YubiKey PIV slot
+const BackendYubiKeyPIV = "yubikey-piv"
+
+// ErrNoYKMan is returned when the YubiKey Manager CLI is not installed
+var ErrNoYKMan = errors.New("ykman (YubiKey Manager) is required for YubiKey PIV but was not found in PATH")
+
+// PIVSlots are the PIV slots a key can be imported into; 82-95 are the retired key
+// management slots
+var PIVSlots = []string{"9a", "9c", "9d", "9e",
+	"82", "83", "84", "85", "86", "87", "88", "89", "8a", "8b",
+	"8c", "8d", "8e", "8f", "90", "91", "92", "93", "94", "95"}
+
+// ValidatePIVSlot checks a PIV slot name
+func ValidatePIVSlot(slot string) error {
+	for _, s := range PIVSlots {
+		if strings.EqualFold(slot, s) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown PIV slot %q (use 9a, 9c, 9d, 9e or 82-95)", slot)
+}
+
+// YubiKeyOptions carries the secrets PIV needs to import a key
+type YubiKeyOptions struct {
+	Serial        string
+	PIN           string
+	ManagementKey string
+}
+
+// YubiKey drives a YubiKey's PIV application through ykman
+type YubiKey struct {
+	Serial string
+	opts   YubiKeyOptions
+	run    func(stdin []byte, args ...string) ([]byte, error)
+}
+
+// OpenYubiKey selects the YubiKey with opts.Serial, or the only one connected
+func OpenYubiKey(opts YubiKeyOptions) (*YubiKey, error) {
+	run, err := ykman()
+	if err != nil {
+		return nil, err
+	}
+	return openYubiKey(opts, run)
+}
+
+// ListYubiKeys returns the serial numbers of the connected YubiKeys
+func ListYubiKeys() ([]string, error) {
+	run, err := ykman()
+	if err != nil {
+		return nil, err
+	}
+	out, err := run(nil, "list", "--serials")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// ykman returns a function running the YubiKey Manager CLI
+func ykman() (func(stdin []byte, args ...string) ([]byte, error), error) {
+	path, err := exec.LookPath("ykman")
+	if err != nil {
+		return nil, ErrNoYKMan
+	}
+	return func(stdin []byte, args ...string) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(path, args...)
+		cmd.Stdin = bytes.NewReader(stdin)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("ykman %s failed: %v: %s", strings.Join(ykmanCommand(args), " "), err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.Bytes(), nil
+	}, nil
+}
+
+// ykmanCommand returns the subcommand words of ykman arguments, leaving out
+// options that may carry a PIN or management key
+func ykmanCommand(args []string) []string {
+	var words []string
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			if args[i] != "-" {
+				i++ // skip the option value
+			}
+			continue
+		}
+		words = append(words, args[i])
+	}
+	return words
+}
+
+// openYubiKey selects a YubiKey using run to call ykman
+func openYubiKey(opts YubiKeyOptions, run func(stdin []byte, args ...string) ([]byte, error)) (*YubiKey, error) {
+	out, err := run(nil, "list", "--serials")
+	if err != nil {
+		return nil, err
+	}
+	serials := strings.Fields(string(out))
+	switch {
+	case opts.Serial != "":
+		for _, s := range serials {
+			if s == opts.Serial {
+				return &YubiKey{Serial: s, opts: opts, run: run}, nil
+			}
+		}
+		return nil, fmt.Errorf("YubiKey %s is not connected", opts.Serial)
+	case len(serials) == 0:
+		return nil, fmt.Errorf("no YubiKey connected")
+	case len(serials) > 1:
+		return nil, fmt.Errorf("%d YubiKeys connected (%s); choose one by serial", len(serials), strings.Join(serials, ", "))
+	}
+	return &YubiKey{Serial: serials[0], opts: opts, run: run}, nil
+}
+
+// ImportEd25519 imports key into slot and checks the slot then holds its public key.
+// PIV has no secp256k1 keys; Ed25519 needs firmware 5.7 or later.
+func (y *YubiKey) ImportEd25519(slot string, key ed25519.PrivateKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("failed to encode key: %w", err)
+	}
+	block := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	defer clear(der)
+	defer clear(block)
+
+	args := []string{"--device", y.Serial, "piv", "keys", "import"}
+	if y.opts.ManagementKey != "" {
+		args = append(args, "--management-key", y.opts.ManagementKey)
+	}
+	if y.opts.PIN != "" {
+		args = append(args, "--pin", y.opts.PIN)
+	}
+	if _, err := y.run(block, append(args, slot, "-")...); err != nil {
+		return err
+	}
+
+	out, err := y.run(nil, "--device", y.Serial, "piv", "keys", "export", "--format", "PEM", slot, "-")
+	if err != nil {
+		return fmt.Errorf("failed to verify the imported key: %w", err)
+	}
+	pemBlock, _ := pem.Decode(out)
+	if pemBlock == nil {
+		return fmt.Errorf("failed to verify the imported key: no public key in slot %s", slot)
+	}
+	public, err := x509.ParsePKIXPublicKey(pemBlock.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to verify the imported key: %w", err)
+	}
+	if want := key.Public().(ed25519.PublicKey); !want.Equal(public) {
+		return fmt.Errorf("slot %s does not hold the imported key", slot)
+	}
+	return nil
+}
+
+// SlotHasKey reports whether a PIV slot already holds a key
+func (y *YubiKey) SlotHasKey(slot string) bool {
+	out, err := y.run(nil, "--device", y.Serial, "piv", "keys", "export", "--format", "PEM", slot, "-")
+	return err == nil && len(bytes.TrimSpace(out)) > 0
+}
+
+// DeviceAttestation returns the YubiKey's PIV attestation certificate (slot f9) in
+// PEM form. Only keys generated on the device get a per-key attestation, so for an
+// imported key this certifies the device, not the key.
+func (y *YubiKey) DeviceAttestation() (string, error) {
+	out, err := y.run(nil, "--device", y.Serial, "piv", "certificates", "export", "f9", "-")
+	if err != nil {
+		return "", err
+	}
+	if block, _ := pem.Decode(out); block == nil || block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("no attestation certificate in slot f9")
+	}
+	return string(out), nil
+}
@@ -0,0 +1,105 @@
+package hardware
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// fakeYKMan answers ykman commands for one YubiKey PIV application
+type fakeYKMan struct {
+	serials string
+	slots   map[string]ed25519.PublicKey
+	calls   []string
+}
+
+func (f *fakeYKMan) run(stdin []byte, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, strings.Join(args, " "))
+	if args[0] == "list" {
+		return []byte(f.serials), nil
+	}
+	command := strings.Join(ykmanCommand(args), " ")
+	slot := args[len(args)-2]
+	switch {
+	case strings.HasPrefix(command, "piv keys import"):
+		block, _ := pem.Decode(stdin)
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		f.slots[slot] = key.(ed25519.PrivateKey).Public().(ed25519.PublicKey)
+		return nil, nil
+	case strings.HasPrefix(command, "piv keys export"):
+		der, _ := x509.MarshalPKIXPublicKey(f.slots[slot])
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+	case command == "piv certificates export f9":
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{0x30}}), nil
+	}
+	return nil, fmt.Errorf("unexpected ykman %v", args)
+}
+
+func TestYubiKey_ImportEd25519(t *testing.T) {
+	fake := &fakeYKMan{serials: "1234567\n", slots: map[string]ed25519.PublicKey{}}
+	yk, err := openYubiKey(YubiKeyOptions{ManagementKey: "010203"}, fake.run)
+	if err != nil || yk.Serial != "1234567" {
+		t.Fatalf("openYubiKey() = %+v, %v", yk, err)
+	}
+
+	_, key, _ := ed25519.GenerateKey(nil)
+	if err := yk.ImportEd25519("9c", key); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(fake.calls[1], "--device 1234567 piv keys import --management-key 010203 9c -") {
+		t.Errorf("import called as %q", fake.calls[1])
+	}
+	attestation, err := yk.DeviceAttestation()
+	if err != nil || !strings.Contains(attestation, "BEGIN CERTIFICATE") {
+		t.Errorf("DeviceAttestation() = %q, %v", attestation, err)
+	}
+
+	// A slot holding another key fails the check
+	_, other, _ := ed25519.GenerateKey(nil)
+	yk.run = func(stdin []byte, args ...string) ([]byte, error) {
+		if strings.Contains(strings.Join(args, " "), "keys import") {
+			return nil, nil
+		}
+		fake.slots["9a"] = other.Public().(ed25519.PublicKey)
+		return fake.run(stdin, args...)
+	}
+	if err := yk.ImportEd25519("9a", key); err == nil {
+		t.Error("expected an error when the slot does not hold the imported key")
+	}
+}
+
+func TestOpenYubiKey_Selection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		serials, serial string
+		ok              bool
+	}{
+		"none":      {"", "", false},
+		"several":   {"1\n2\n", "", false},
+		"by serial": {"1\n2\n", "2", true},
+		"missing":   {"1\n", "2", false},
+	} {
+		fake := &fakeYKMan{serials: tc.serials}
+		if _, err := openYubiKey(YubiKeyOptions{Serial: tc.serial}, fake.run); (err == nil) != tc.ok {
+			t.Errorf("%s: openYubiKey() error = %v", name, err)
+		}
+	}
+	if err := ValidatePIVSlot("9C"); err != nil {
+		t.Error(err)
+	}
+	if err := ValidatePIVSlot("f9"); err == nil {
+		t.Error("expected the attestation slot to be rejected")
+	}
+}
+
+func TestYKManCommand_HidesSecrets(t *testing.T) {
+	got := strings.Join(ykmanCommand([]string{"--device", "1", "piv", "keys", "import", "--pin", "123456", "9c", "-"}), " ")
+	if got != "piv keys import 9c" {
+		t.Errorf("ykmanCommand() = %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+// Package i18n provides the message catalog and locale-aware formatting for CLI and TUI output
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Lang is a supported output language
+type Lang string
+
+const (
+	English    Lang = "en"
+	Portuguese Lang = "pt-BR"
+	Spanish    Lang = "es"
+)
+
+// Supported lists the languages with a message catalog
+var Supported = []Lang{English, Portuguese, Spanish}
+
+// current holds the active language; English until SetLanguage is called
+var current atomic.Value
+
+func init() {
+	current.Store(English)
+}
+
+// Parse maps a language tag or POSIX locale (pt, pt_BR.UTF-8, es-MX) to a supported language
+func Parse(tag string) (Lang, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, ".@"); i >= 0 {
+		tag = tag[:i]
+	}
+	base, _, _ := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-")
+	switch base {
+	case "en":
+		return English, true
+	case "pt":
+		return Portuguese, true
+	case "es":
+		return Spanish, true
+	}
+	return English, false
+}
+
+// Detect picks the language from LC_ALL, LC_MESSAGES, LANG and LANGUAGE, in POSIX order
+func Detect() Lang {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			// The first set variable wins, even if it names an unsupported locale
+			lang, _ := Parse(value)
+			return lang
+		}
+	}
+	for _, tag := range strings.Split(os.Getenv("LANGUAGE"), ":") {
+		if lang, ok := Parse(tag); ok {
+			return lang
+		}
+	}
+	return English
+}
+
+// SetLanguage sets the language used by T and the formatting helpers
+func SetLanguage(lang Lang) {
+	current.Store(lang)
+}
+
+// Current returns the active language
+func Current() Lang {
+	return current.Load().(Lang)
+}
+
+// T returns the message for key in the active language, formatted with args; missing
+// translations fall back to English, and unknown keys to the key itself
+func T(key string, args ...any) string {
+	format, ok := catalog[Current()][key]
+	if !ok {
+		if format, ok = catalog[English][key]; !ok {
+			format = key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// FormatNumber formats an integer with the locale's thousands separator
+func FormatNumber(num int64) string {
+	separator := " "
+	if Current() != English {
+		separator = "."
+	}
+	str := strconv.FormatInt(num, 10)
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		sign, str = "-", str[1:]
+	}
+	var result strings.Builder
+	for i, char := range str {
+		if i > 0 && (len(str)-i)%3 == 0 {
+			result.WriteString(separator)
+		}
+		result.WriteRune(char)
+	}
+	return sign + result.String()
+}
+
+// FormatDecimal formats a float with the locale's decimal separator
+func FormatDecimal(value float64, precision int) string {
+	str := strconv.FormatFloat(value, 'f', precision, 64)
+	if Current() != English {
+		str = strings.Replace(str, ".", ",", 1)
+	}
+	return str
+}
+
+// FormatDuration formats a duration with one decimal in the largest fitting unit
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return T("duration.impossible")
+	}
+	seconds := d.Seconds()
+	if seconds > 200*365.25*24*3600 {
+		return T("duration.thousands_of_years")
+	}
+
+	value, unit := seconds, "duration.unit.second"
+	switch {
+	case seconds < 60:
+	case seconds < 3600:
+		value, unit = seconds/60, "duration.unit.minute"
+	case seconds < 86400:
+		value, unit = seconds/3600, "duration.unit.hour"
+	case seconds < 31536000:
+		value, unit = seconds/86400, "duration.unit.day"
+	default:
+		value, unit = seconds/31536000, "duration.unit.year"
+	}
+	return FormatDecimal(value, 1) + T(unit)
+}
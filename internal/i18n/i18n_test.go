@@ -0,0 +1,133 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Lang
+		ok   bool
+	}{
+		{"en", English, true},
+		{"en_US.UTF-8", English, true},
+		{"pt-BR", Portuguese, true},
+		{"pt_BR.UTF-8", Portuguese, true},
+		{"pt", Portuguese, true},
+		{"es_MX", Spanish, true},
+		{"ES", Spanish, true},
+		{"de_DE.UTF-8", English, false},
+		{"C", English, false},
+	}
+	for _, tt := range tests {
+		if got, ok := Parse(tt.tag); got != tt.want || ok != tt.ok {
+			t.Errorf("Parse(%q) = %v, %v, want %v, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "pt_BR.UTF-8")
+	t.Setenv("LANGUAGE", "")
+	if got := Detect(); got != Portuguese {
+		t.Errorf("Detect() with LANG=pt_BR = %v", got)
+	}
+	t.Setenv("LC_ALL", "es_ES.UTF-8")
+	if got := Detect(); got != Spanish {
+		t.Errorf("LC_ALL should take precedence over LANG, got %v", got)
+	}
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "")
+	t.Setenv("LANGUAGE", "fr:es")
+	if got := Detect(); got != Spanish {
+		t.Errorf("Detect() with LANGUAGE=fr:es = %v", got)
+	}
+}
+
+func TestCatalogComplete(t *testing.T) {
+	verbs := regexp.MustCompile(`%[-+#0-9.]*[a-zA-Z%]`)
+	for key, english := range catalog[English] {
+		want := verbs.FindAllString(english, -1)
+		for _, lang := range []Lang{Portuguese, Spanish} {
+			message, ok := catalog[lang][key]
+			if !ok {
+				t.Errorf("%s is missing %q", lang, key)
+				continue
+			}
+			if got := verbs.FindAllString(message, -1); len(got) != len(want) {
+				t.Errorf("%s %q has verbs %v, English has %v", lang, key, got, want)
+			}
+		}
+	}
+}
+
+// TestCatalogCoversSources checks that every key passed to T by the CLI and TUI is
+// in the catalog, so no output falls back to printing its key
+func TestCatalogCoversSources(t *testing.T) {
+	keys := regexp.MustCompile(`i18n\.T\("([^"]+)"`)
+	files, err := filepath.Glob("../*/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := 0
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range keys.FindAllSubmatch(source, -1) {
+			used++
+			if _, ok := catalog[English][string(match[1])]; !ok {
+				t.Errorf("%s uses %q, which is not in the catalog", file, match[1])
+			}
+		}
+	}
+	if used == 0 {
+		t.Fatal("found no i18n.T calls in the CLI and TUI sources")
+	}
+}
+
+func TestLocaleFormatting(t *testing.T) {
+	defer SetLanguage(English)
+
+	SetLanguage(English)
+	if got := FormatNumber(1234567); got != "1 234 567" {
+		t.Errorf("English FormatNumber = %q", got)
+	}
+	if got := FormatDuration(90 * time.Second); got != "1.5m" {
+		t.Errorf("English FormatDuration = %q", got)
+	}
+	if got := T("result.attempts", "10"); got != "Attempts: 10" {
+		t.Errorf("English T = %q", got)
+	}
+
+	SetLanguage(Portuguese)
+	if got := FormatNumber(1234567); got != "1.234.567" {
+		t.Errorf("Portuguese FormatNumber = %q", got)
+	}
+	if got := FormatDuration(90 * time.Second); got != "1,5min" {
+		t.Errorf("Portuguese FormatDuration = %q", got)
+	}
+	if got := T("result.attempts", "10"); got != "Tentativas: 10" {
+		t.Errorf("Portuguese T = %q", got)
+	}
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key = %q", got)
+	}
+
+	SetLanguage(Spanish)
+	if got := FormatDuration(-1); got != "Casi imposible" {
+		t.Errorf("Spanish FormatDuration = %q", got)
+	}
+}
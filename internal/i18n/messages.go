@@ -0,0 +1,1133 @@
+package i18n
+
+// catalog maps each language to its messages; English is the fallback for missing keys
+var catalog = map[Lang]map[string]string{
+	English: {
+		"duration.impossible":         "Nearly impossible",
+		"duration.thousands_of_years": "Thousands of years",
+		"duration.unit.second":        "s",
+		"duration.unit.minute":        "m",
+		"duration.unit.hour":          "h",
+		"duration.unit.day":           "d",
+		"duration.unit.year":          "y",
+
+		"bool.enabled":  "Enabled",
+		"bool.disabled": "Disabled",
+
+		"generate.header":       "Generating wallet with pattern: %s",
+		"generate.header_batch": "Generating %d wallets with pattern: %s",
+		"generate.difficulty":   "Difficulty: %s",
+		"generate.threads":      "Using %d worker threads",
+		"generate.batch_error":  "Error generating wallet %d: %v",
+
+		"result.success":           "Wallet generated successfully!",
+		"result.address":           "Address: %s",
+		"result.explorer":          "Explorer: %s",
+		"result.private_key":       "Private Key: %s",
+		"result.key_sealed":        "sealed in TPM 2.0, handle %s",
+		"result.key_yubikey":       "in YubiKey %s PIV slot %s, handle %s",
+		"result.public_key":        "Public Key: %s",
+		"result.public_key_comp":   "Compressed Public Key: %s",
+		"result.mnemonic":          "Mnemonic: %s",
+		"result.attempts":          "Attempts: %s",
+		"result.duration":          "Duration: %s",
+		"result.worker":            "Worker: #%d",
+		"result.seed_counter":      "Seed Counter: %d (master seed %s)",
+		"result.address_qr":        "Address QR code:",
+		"result.private_key_qr":    "Private key QR code (anyone who scans it controls the wallet):",
+		"result.keystore_failed":   "Warning: Failed to generate keystore: %v",
+		"result.keystore_saved":    "Keystore saved to: %s",
+		"result.password_policy":   "Keystore passwords: %s (%.0f bits of entropy each)",
+		"result.backup_saved":      "%s saved to: %s",
+		"result.keystore_deferred": "Keystore: deferred until the end of the run",
+		"batch.none":               "No wallets were generated successfully",
+		"batch.success":            "Generated %d wallets successfully!",
+		"batch.total_attempts":     "Total attempts: %s",
+		"batch.total_duration":     "Total duration: %s",
+		"batch.average_speed":      "Average speed: %s addr/s",
+		"batch.wallet":             "Wallet %d:",
+		"batch.keystore_failed":    "Keystore: Failed to generate (%v)",
+		"batch.keystore_saved":     "Keystore: Saved",
+		"batch.keystore_deferred":  "Keystore: Deferred",
+		"batch.backup_saved":       "%s: Saved",
+		"batch.keystores_saved":    "Keystores saved: %d/%d to %s",
+		"batch.keystore_errors":    "Keystore errors: %d/%d",
+		"batch.keystore_pipeline":  "Keystore encryption: %d workers, finished %s after the last wallet",
+		"summary.title":            "Statistics Summary:",
+		"summary.average":          "Average attempts per wallet: %s",
+		"summary.min":              "Min attempts: %s",
+		"summary.max":              "Max attempts: %s",
+		"summary.success_rate":     "Success rate: %s%%",
+		"stats.title":              "Pattern Analysis: %s",
+		"stats.length":             "Pattern Length: %d characters",
+		"stats.checksum":           "Checksum Validation: %s",
+		"stats.probability50":      "50%% Probability: %s attempts",
+		"stats.breakdown":          "Difficulty Breakdown:",
+		"stats.breakdown_base":     "Base (16^%d, any case): %s",
+		"stats.breakdown_case":     "Case factor (2^%d, letters with a required case): %s",
+		"stats.breakdown_no_case":  "Case factor: 1 (case ignored without --checksum --case-sensitive)",
+		"stats.time_estimates":     "Time Estimates:",
+		"stats.at_speed":           "At %s addr/s: %s",
+
+		"tui.no_stats":          "No statistics available",
+		"tui.title":             "Wallet Generator",
+		"tui.pattern":           "Pattern",
+		"tui.pattern_any":       "any",
+		"tui.checksum_tag":      "(checksum)",
+		"tui.difficulty":        "Difficulty",
+		"tui.wallets_completed": "%d/%d wallets completed (%s%%)",
+		"tui.wallets_generated": "%d wallets generated",
+		"tui.probability":       "%s%% probability",
+		"tui.paused":            "Paused: %s; resuming when it recovers",
+		"tui.last_found":        "Last found",
+		"tui.explorer":          "Explorer",
+		"tui.legend_color":      "Bold: matched pattern; underlined: uppercase from the EIP-55 checksum",
+		"tui.legend_markers":    "%s matched pattern, %s uppercase from the EIP-55 checksum, %s both",
+		"tui.statistics":        "Statistics",
+		"tui.thread_perf":       "Thread Performance",
+		"tui.generated":         "Generated Wallets (%d)",
+		"tui.help_scroll":       "Use ↑↓/j/k to scroll table • Press q to quit • Ctrl+C to exit",
+		"tui.help_quit":         "Press q to quit • Ctrl+C to exit",
+		"tui.attempts":          "Attempts",
+		"tui.speed":             "Speed",
+		"tui.speed_value":       "%s addr/s",
+		"tui.eta":               "ETA",
+		"tui.probability50_at":  "50% at",
+		"tui.attempts_value":    "%s attempts",
+		"tui.threads":           "Threads",
+		"tui.threads_value":     "%d threads",
+		"tui.efficiency":        "Efficiency",
+		"tui.peak_speed":        "Peak Speed",
+		"tui.done_in":           "Done in %s",
+		"tui.calculating":       "Calculating...",
+		"tui.eta_reached":       "reached",
+		"tui.eta_never":         "never",
+		"tui.column_address":    "Address",
+		"tui.column_key":        "Private Key",
+		"tui.column_attempts":   "Attempts",
+		"tui.column_time":       "Time",
+		"tui.error_row":         "Error occurred",
+
+		"tui.column_speed":       "Speed (addr/s)",
+		"tui.column_p50":         "50% Probability",
+		"tui.column_p90":         "90% Probability",
+		"tui.column_probability": "Probability",
+		"tui.column_likelihood":  "Likelihood",
+
+		"tui.stats_title":        "Bloco Address Difficulty Analysis",
+		"tui.stats_detailed":     "Detailed Statistics",
+		"tui.stats_help":         "Use ↑/↓ or j/k to navigate • Press 'q', 'Ctrl+C', or 'Esc' to quit",
+		"tui.checksum":           "Checksum",
+		"tui.checksum_on":        "Enabled (increases difficulty)",
+		"tui.pattern_length":     "Pattern Length",
+		"tui.characters":         "%d characters",
+		"tui.time_estimates":     "Time Estimates (at different speeds)",
+		"tui.probability_ex":     "Probability Examples",
+		"tui.recommendations":    "Recommendations",
+		"tui.likely_extreme":     "Extremely unlikely",
+		"tui.likely_very_un":     "Very unlikely",
+		"tui.likely_unlikely":    "Unlikely",
+		"tui.likely_low":         "Low chance",
+		"tui.likely_moderate":    "Moderate chance",
+		"tui.likely_good":        "Good chance",
+		"tui.likely_very":        "Very likely",
+		"tui.likely_impossible":  "Impossible",
+		"tui.level":              "Difficulty Level",
+		"tui.level_easy":         "Easy",
+		"tui.level_moderate":     "Moderate",
+		"tui.level_hard":         "Hard",
+		"tui.level_extreme":      "Extremely Hard",
+		"tui.recommend":          "Recommendation",
+		"tui.recommend_easy":     "Should generate quickly, suitable for testing",
+		"tui.recommend_moderate": "May take some time, reasonable for production use",
+		"tui.recommend_hard":     "Will take considerable time, plan accordingly",
+		"tui.recommend_extreme":  "May take days/weeks/years, use with extreme caution",
+		"tui.checksum_impact":    "Checksum Impact",
+		"tui.checksum_warning":   "Checksum validation significantly increases difficulty",
+		"tui.perf_tip":           "Performance Tip",
+		"tui.perf_threads":       "Use multiple threads (--threads) for better performance",
+
+		"wizard.title":          "Setup Wizard",
+		"wizard.prefix":         "Prefix",
+		"wizard.suffix":         "Suffix",
+		"wizard.checksum":       "EIP-55 checksum",
+		"wizard.case_sensitive": "Match letter case",
+		"wizard.count":          "Wallets",
+		"wizard.keystore":       "Save KeyStore files",
+		"wizard.kdf":            "KeyStore KDF",
+		"wizard.run":            "Run now",
+		"wizard.print":          "Print command line",
+		"wizard.calibrating":    "measuring speed...",
+		"wizard.empty_pattern":  "Type a prefix or suffix (hex characters)",
+		"wizard.help":           "↑/↓ or Tab to move • Space to toggle • ←/→ to change • Enter to choose • Esc to quit",
+
+		"generate.mnemonic_path":       "Deriving keys from 12-word BIP-39 mnemonics (no passphrase) on %s; check wallet compatibility with --preview",
+		"generate.attempt_budget":      "Attempt budget: %s attempts (%.4g%% probability per wallet)",
+		"batch.wallet_verbose":         "Wallet %d: 0x%s (attempts: %s)",
+		"batch.wallet_keystore_failed": "Warning: Failed to generate keystore for wallet %d: %v",
+
+		"warn.cipher_nonstandard":  "Warning: %s keystores are non-standard; geth, clef and other wallets cannot open them",
+		"warn.cipher_experimental": "Warning: aes-128-gcm is experimental; only bloco-eth keystore decrypt can read these keystores",
+		"warn.pool_shutdown":       "Warning: failed to shutdown worker pool: %v",
+		"warn.tui_failed":          "TUI failed: %v, falling back to text mode",
+		"warn.cpu_quota":           "Warning: --threads %d exceeds the container's CPU quota of %s CPUs; the extra threads only share it (0 auto-detects %d)",
+		"warn.scrypt_reduced":      "Warning: default scrypt n=%v does not fit the %s memory cap (--kdf-max-memory %s); using n=%v",
+
+		"kdf.title":                "KDF Compatibility Analysis",
+		"kdf.algorithm":            "KDF Algorithm: %s",
+		"kdf.algorithm_normalized": "KDF Algorithm: %s (normalized: %s)",
+		"kdf.security_level":       "Security Level: %s (%s)",
+		"kdf.compatible":           "Status: Compatible",
+		"kdf.incompatible":         "Status: Incompatible",
+		"kdf.parameters":           "Parameters:",
+		"kdf.issues":               "Issues:",
+		"kdf.warnings":             "Warnings:",
+		"kdf.suggestions":          "Suggestions:",
+		"kdf.analysis_failed":      "Warning: Failed to analyze KDF compatibility: %v",
+
+		"bench.running":             "Running benchmark...",
+		"bench.warmup":              "Warm-up: %v (excluded)",
+		"bench.threads":             "Threads: %d",
+		"bench.starting":            "Starting benchmark...",
+		"bench.sample_total":        "Sample %d: %.0f addr/s (total: %s attempts)",
+		"bench.completed":           "Benchmark completed!",
+		"bench.results":             "Benchmark Results:",
+		"bench.total_attempts":      "Total Attempts: %s",
+		"bench.average_speed":       "Average Speed: %.0f addr/s",
+		"bench.speed_range":         "Speed Range: %.0f - %.0f addr/s",
+		"bench.threading":           "Multi-Threading Performance:",
+		"bench.threads_used":        "Threads Used: %d",
+		"bench.thread_efficiency":   "Thread Efficiency: %.1f%%",
+		"bench.thread_balance":      "Thread Balance: %.1f%%",
+		"bench.single_thread":       "Estimated Single-Thread Speed: %.0f addr/s",
+		"bench.speedup":             "Multi-Thread Speedup: %.2fx",
+		"bench.parallel_efficiency": "Parallel Efficiency: %.1f%% (%.2fx of %dx ideal)",
+		"bench.samples":             "Detailed Performance Samples:",
+		"bench.sample":              "Sample %d: %.0f addr/s",
+		"bench.samples_omitted":     "... (%d samples omitted) ...",
+		"bench.speed_stats":         "Speed Statistics:",
+		"bench.mean":                "Mean: %.0f addr/s",
+		"bench.std_dev":             "Std Dev: %.0f addr/s",
+		"bench.variation":           "Coefficient of Variation: %.1f%%",
+		"bench.analysis":            "Performance Analysis:",
+		"bench.mt_excellent":        "Excellent multi-threading efficiency",
+		"bench.mt_good":             "Good multi-threading efficiency",
+		"bench.mt_poor":             "Multi-threading efficiency could be improved",
+		"bench.balanced":            "Well-balanced thread utilization",
+		"bench.unbalanced":          "Uneven thread utilization detected",
+		"bench.speed_excellent":     "Excellent performance (>100k addr/s)",
+		"bench.speed_good":          "Good performance (>50k addr/s)",
+		"bench.speed_moderate":      "Moderate performance (>10k addr/s)",
+		"bench.speed_poor":          "Performance could be improved (<10k addr/s)",
+
+		"version.name":       "Bloco-ETH %s",
+		"version.commit":     "Git Commit: %s",
+		"version.build_time": "Build Time: %s",
+
+		"efficiency.running":       "Running efficiency sweep...",
+		"efficiency.thread_counts": "Thread counts: %v",
+		"efficiency.batch_sizes":   "Batch sizes: %v",
+		"efficiency.step_duration": "Duration per configuration: %v",
+		"efficiency.energy_rapl":   "Energy source: RAPL (%s)",
+		"efficiency.energy_none":   "Energy source: unavailable, ranking by addr/s per thread",
+		"efficiency.row":           "  threads=%-3d batch=%-6d %10.0f addr/s %10.0f addr/s/thread",
+		"efficiency.row_energy":    "  threads=%-3d batch=%-6d %10.0f addr/s %10.0f addr/s/thread %10.0f addr/J",
+		"efficiency.best":          "Most Efficient Configuration:",
+		"efficiency.batch_size":    "Batch Size: %d",
+		"efficiency.speed":         "Speed: %.0f addr/s (%.0f addr/s per thread)",
+		"efficiency.energy":        "Energy Efficiency: %.0f addr/J",
+		"efficiency.fastest":       "Fastest configuration for comparison: %d threads, batch %d (%.0f addr/s)",
+
+		"cloudcost.title":    "Cloud Cost Estimates (single spot instance):",
+		"cloudcost.provider": "Provider",
+		"cloudcost.instance": "Instance",
+		"cloudcost.vcpus":    "vCPUs",
+		"cloudcost.speed":    "addr/s",
+		"cloudcost.time50":   "Time 50%",
+		"cloudcost.cost50":   "Cost 50%",
+		"cloudcost.time95":   "Time 95%",
+		"cloudcost.cost95":   "Cost 95%",
+		"cloudcost.scaling":  "Cost scales with total compute: N instances divide wall-clock time by N at the same cost.",
+		"cloudcost.prices":   "Prices are approximate spot rates; pass --cost-table to use current pricing.",
+
+		"empirical.title":      "Empirical Validation:",
+		"empirical.sampling":   "Sampling %s random addresses on %d threads...",
+		"empirical.matches":    "Matches: %s observed, %.1f expected (1 in %s)",
+		"empirical.observed":   "Observed difficulty: 1 in %s",
+		"empirical.deviation":  "Deviation: %+.2f standard deviations (%s in %s)",
+		"empirical.too_few":    "Only %.1f matches expected; use at least --samples %.0f for a reliable comparison",
+		"empirical.consistent": "Observed rate is consistent with the theoretical difficulty",
+
+		"histogram.title":    "Attempts Histogram (%d wallets):",
+		"histogram.bucket":   "%s to %s attempts: %d wallets",
+		"histogram.range":    "%s - %s attempts",
+		"histogram.mean":     "Mean: %s (expected %s, %+.2f standard errors)",
+		"histogram.unlikely": "This mean is statistically unlikely (beyond %.0f standard errors); the difficulty estimate or matcher may be off",
+		"histogram.saved":    "Attempts histogram saved to: %s",
+
+		"tui.compare_title":   "Thread Comparison",
+		"tui.compare_threads": "%3d threads",
+		"tui.compare_speed":   "%s addr/s  %.2fx  %.0f%%",
+		"tui.measuring":       "measuring...",
+		"tui.pending":         "pending",
+		"tui.unbounded":       "unbounded",
+		"tui.amdahl":          "%.1f%% serial · %d threads: %.1fx · %d threads: %.1fx · limit %s",
+		"tui.compare_done":    "Comparison complete",
+
+		"tui.bench_metric":               "Metric",
+		"tui.bench_value":                "Value",
+		"tui.bench_details":              "Details",
+		"tui.bench_running":              "Benchmark Running",
+		"tui.bench_current":              "Current Performance:",
+		"tui.bench_current_speed":        "Current Speed: %s addr/s",
+		"tui.bench_average_speed":        "Average Speed: %s addr/s",
+		"tui.bench_min_max":              "Min/Max Speed: %s/%s addr/s",
+		"tui.bench_speed":                "Speed: %s addr/s",
+		"tui.bench_pattern":              "Pattern: %s",
+		"tui.bench_difficulty":           "Difficulty: %.2f",
+		"tui.bench_eta":                  "Estimated Time: %s",
+		"tui.bench_efficiency":           "Efficiency: %.1f%%",
+		"tui.bench_loading":              "Benchmark Complete - Loading Results...",
+		"tui.bench_preparing":            "Preparing results",
+		"tui.bench_results":              "Benchmark Results",
+		"tui.bench_summary":              "Summary:",
+		"tui.bench_total_duration":       "Total Duration: %s",
+		"tui.bench_scalability":          "Scalability Factor: %.2fx",
+		"tui.bench_help":                 "↑/↓: Navigate • q: Quit • Ctrl+C: Exit",
+		"tui.bench_row_attempts":         "Total Attempts",
+		"tui.bench_row_attempts_info":    "Total addresses generated",
+		"tui.bench_row_duration":         "Duration",
+		"tui.bench_row_duration_info":    "Total benchmark time",
+		"tui.bench_row_average":          "Average Speed",
+		"tui.bench_row_average_info":     "Mean generation rate",
+		"tui.bench_row_min":              "Min Speed",
+		"tui.bench_row_min_info":         "Lowest recorded speed",
+		"tui.bench_row_max":              "Max Speed",
+		"tui.bench_row_max_info":         "Highest recorded speed",
+		"tui.bench_row_threads":          "Thread Count",
+		"tui.bench_row_threads_info":     "Parallel workers used",
+		"tui.bench_row_single":           "Single Thread Est.",
+		"tui.bench_row_single_info":      "Estimated single-thread speed",
+		"tui.bench_row_scalability":      "Scalability",
+		"tui.bench_row_scalability_info": "Multi-threading efficiency",
+		"tui.bench_row_speedup":          "Speedup Factor",
+		"tui.bench_row_speedup_info":     "Performance improvement",
+
+		"debug.tui_no_terminal":      "DEBUG TUI: neither stdout nor stdin are terminals, TERM=%s",
+		"debug.tui_dev_environment":  "DEBUG TUI: development environment detected, allowing TUI",
+		"debug.tui_incapable":        "DEBUG TUI: no capable terminal detected, disabling TUI",
+		"debug.tui_capable":          "DEBUG TUI: capable terminal environment detected, allowing TUI",
+		"debug.tui_too_small":        "DEBUG TUI: terminal too small (%dx%d)",
+		"debug.tui_ci":               "DEBUG TUI: CI environment detected",
+		"debug.tui_redirected":       "DEBUG TUI: output is redirected",
+		"debug.tui_benchmark_update": "DEBUG: TUI received update - attempts: %d, speed: %.2f",
+
+		"exit.limit_budget":     "the %.4g%% probability budget",
+		"exit.limit_key_range":  "the end of --key-range",
+		"exit.accepted_partial": "Stopped by %s with %d of %d wallet(s); accepted because of --fail-on-timeout=false",
+
+		"audit.verified":   "Audit trail verified: %d entries",
+		"audit.first":      "First: %s",
+		"audit.last":       "Last:  %s",
+		"audit.head":       "Head:  %s",
+		"warn.audit_write": "Warning: failed to write audit trail: %v",
+
+		"warn.error": "Warning: %v",
+
+		"ceremony.verified":             "Ceremony transcript verified",
+		"ceremony.started":              "Started:    %s",
+		"ceremony.operators":            "Operators:  %s",
+		"ceremony.binary":               "Binary:     %s",
+		"ceremony.config":               "Config:     %s",
+		"ceremony.offline":              "Offline:    %v",
+		"ceremony.wallets":              "Wallets:    %d",
+		"ceremony.outcome":              "Outcome:    %s",
+		"ceremony.signed_by":            "Signed by:  %s",
+		"ceremony.title":                "Key Generation Ceremony",
+		"ceremony.host":                 "Host:             %s",
+		"ceremony.binary_path":          "Binary:           %s",
+		"ceremony.binary_sha256":        "Binary SHA-256:   %s",
+		"ceremony.config_sha256":        "Config SHA-256:   %s",
+		"ceremony.network_offline":      "Network:          offline",
+		"ceremony.network_online":       "Network:          ONLINE, check overridden (%s)",
+		"ceremony.signing_key":          "Signing key:      %x",
+		"ceremony.record":               "Record both fingerprints and the signing key in the ceremony log.",
+		"ceremony.operator_name":        "Operator %d name: ",
+		"ceremony.operator_confirm":     "%s, type the first %d characters of the binary SHA-256 to confirm: ",
+		"ceremony.transcript":           "Ceremony transcript: %s",
+		"ceremony.transcript_signed_by": "Signed by:           %s",
+
+		"agent.searching": "Agent %s searching keyspace %s (%s) for %s on %d threads",
+		"agent.lease":     "Lease %s: %s keys %s",
+		"agent.coverage":  "Keyspace coverage: %s keys checked (%.4g%%), %d/%d wallets found",
+		"agent.finished":  "Keyspace %s is %s: %s keys checked (%.4g%%), %d/%d wallets found",
+
+		"update.up_to_date": "bloco-eth %s is up to date (latest release: %s)",
+		"update.available":  "Update available: %s -> %s",
+		"update.managed":    "Installed with %s; upgrade with: %s",
+		"update.unsigned":   "Release signature not checked: this build has no release key",
+		"update.installing": "Installing %s for %s/%s...",
+		"update.updated":    "Updated %s from %s to %s",
+
+		"harden.applied": "Hardened: %s",
+		"harden.skipped": "Not hardened: %s",
+
+		"donate.started":      "Donating %d threads at idle priority to the shared keyspaces of %s",
+		"donate.searching":    "Searching shared keyspace %s for %s",
+		"donate.waiting":      "Waiting: other programs keep %.0f%% of the CPU busy",
+		"donate.found":        "Found %s in keyspace %s; reported to the coordinator",
+		"warn.lower_priority": "Warning: failed to lower the process priority: %v",
+	},
+	Portuguese: {
+		"duration.impossible":         "Quase impossível",
+		"duration.thousands_of_years": "Milhares de anos",
+		"duration.unit.second":        "s",
+		"duration.unit.minute":        "min",
+		"duration.unit.hour":          "h",
+		"duration.unit.day":           "d",
+		"duration.unit.year":          "a",
+
+		"bool.enabled":  "Ativada",
+		"bool.disabled": "Desativada",
+
+		"generate.header":       "Gerando carteira com o padrão: %s",
+		"generate.header_batch": "Gerando %d carteiras com o padrão: %s",
+		"generate.difficulty":   "Dificuldade: %s",
+		"generate.threads":      "Usando %d threads de trabalho",
+		"generate.batch_error":  "Erro ao gerar a carteira %d: %v",
+
+		"result.success":           "Carteira gerada com sucesso!",
+		"result.address":           "Endereço: %s",
+		"result.explorer":          "Explorador: %s",
+		"result.private_key":       "Chave privada: %s",
+		"result.key_sealed":        "selada no TPM 2.0, handle %s",
+		"result.key_yubikey":       "na YubiKey %s, slot PIV %s, handle %s",
+		"result.public_key":        "Chave pública: %s",
+		"result.public_key_comp":   "Chave pública comprimida: %s",
+		"result.mnemonic":          "Mnemônico: %s",
+		"result.attempts":          "Tentativas: %s",
+		"result.duration":          "Duração: %s",
+		"result.worker":            "Worker: #%d",
+		"result.seed_counter":      "Contador da semente: %d (semente mestra %s)",
+		"result.address_qr":        "QR code do endereço:",
+		"result.private_key_qr":    "QR code da chave privada (quem o escanear controla a carteira):",
+		"result.keystore_failed":   "Aviso: falha ao gerar o keystore: %v",
+		"result.keystore_saved":    "Keystore salvo em: %s",
+		"result.password_policy":   "Senhas dos keystores: %s (%.0f bits de entropia cada)",
+		"result.backup_saved":      "%s salvo em: %s",
+		"result.keystore_deferred": "Keystore: adiado até o fim da execução",
+		"batch.none":               "Nenhuma carteira foi gerada com sucesso",
+		"batch.success":            "%d carteiras geradas com sucesso!",
+		"batch.total_attempts":     "Total de tentativas: %s",
+		"batch.total_duration":     "Duração total: %s",
+		"batch.average_speed":      "Velocidade média: %s end/s",
+		"batch.wallet":             "Carteira %d:",
+		"batch.keystore_failed":    "Keystore: falha ao gerar (%v)",
+		"batch.keystore_saved":     "Keystore: salvo",
+		"batch.keystore_deferred":  "Keystore: adiado",
+		"batch.backup_saved":       "%s: salvo",
+		"batch.keystores_saved":    "Keystores salvos: %d/%d em %s",
+		"batch.keystore_errors":    "Erros de keystore: %d/%d",
+		"batch.keystore_pipeline":  "Criptografia de keystores: %d workers, concluída %s após a última carteira",
+		"summary.title":            "Resumo estatístico:",
+		"summary.average":          "Média de tentativas por carteira: %s",
+		"summary.min":              "Mínimo de tentativas: %s",
+		"summary.max":              "Máximo de tentativas: %s",
+		"summary.success_rate":     "Taxa de sucesso: %s%%",
+		"stats.title":              "Análise do padrão: %s",
+		"stats.length":             "Tamanho do padrão: %d caracteres",
+		"stats.checksum":           "Validação de checksum: %s",
+		"stats.probability50":      "Probabilidade de 50%%: %s tentativas",
+		"stats.breakdown":          "Composição da dificuldade:",
+		"stats.breakdown_base":     "Base (16^%d, qualquer caixa): %s",
+		"stats.breakdown_case":     "Fator de caixa (2^%d, letras com caixa exigida): %s",
+		"stats.breakdown_no_case":  "Fator de caixa: 1 (caixa ignorada sem --checksum --case-sensitive)",
+		"stats.time_estimates":     "Estimativas de tempo:",
+		"stats.at_speed":           "A %s end/s: %s",
+
+		"tui.no_stats":          "Nenhuma estatística disponível",
+		"tui.title":             "Gerador de Carteiras",
+		"tui.pattern":           "Padrão",
+		"tui.pattern_any":       "qualquer",
+		"tui.checksum_tag":      "(checksum)",
+		"tui.difficulty":        "Dificuldade",
+		"tui.wallets_completed": "%d/%d carteiras concluídas (%s%%)",
+		"tui.wallets_generated": "%d carteiras geradas",
+		"tui.probability":       "%s%% de probabilidade",
+		"tui.paused":            "Pausado: %s; retoma quando se recuperar",
+		"tui.last_found":        "Último encontrado",
+		"tui.explorer":          "Explorador",
+		"tui.legend_color":      "Negrito: padrão encontrado; sublinhado: maiúscula do checksum EIP-55",
+		"tui.legend_markers":    "%s padrão encontrado, %s maiúscula do checksum EIP-55, %s ambos",
+		"tui.statistics":        "Estatísticas",
+		"tui.thread_perf":       "Desempenho das threads",
+		"tui.generated":         "Carteiras geradas (%d)",
+		"tui.help_scroll":       "Use ↑↓/j/k para rolar a tabela • Pressione q para sair • Ctrl+C para encerrar",
+		"tui.help_quit":         "Pressione q para sair • Ctrl+C para encerrar",
+		"tui.attempts":          "Tentativas",
+		"tui.speed":             "Velocidade",
+		"tui.speed_value":       "%s end/s",
+		"tui.eta":               "Tempo restante",
+		"tui.probability50_at":  "50% em",
+		"tui.attempts_value":    "%s tentativas",
+		"tui.threads":           "Threads",
+		"tui.threads_value":     "%d threads",
+		"tui.efficiency":        "Eficiência",
+		"tui.peak_speed":        "Velocidade máxima",
+		"tui.done_in":           "Concluído em %s",
+		"tui.calculating":       "Calculando...",
+		"tui.eta_reached":       "atingido",
+		"tui.eta_never":         "nunca",
+		"tui.column_address":    "Endereço",
+		"tui.column_key":        "Chave privada",
+		"tui.column_attempts":   "Tentativas",
+		"tui.column_time":       "Tempo",
+		"tui.error_row":         "Ocorreu um erro",
+
+		"tui.column_speed":       "Velocidade (end/s)",
+		"tui.column_p50":         "Probabilidade de 50%",
+		"tui.column_p90":         "Probabilidade de 90%",
+		"tui.column_probability": "Probabilidade",
+		"tui.column_likelihood":  "Chance",
+
+		"tui.stats_title":        "Análise de Dificuldade de Endereços Bloco",
+		"tui.stats_detailed":     "Estatísticas detalhadas",
+		"tui.stats_help":         "Use ↑/↓ ou j/k para navegar • Pressione 'q', 'Ctrl+C' ou 'Esc' para sair",
+		"tui.checksum":           "Checksum",
+		"tui.checksum_on":        "Ativado (aumenta a dificuldade)",
+		"tui.pattern_length":     "Tamanho do padrão",
+		"tui.characters":         "%d caracteres",
+		"tui.time_estimates":     "Estimativas de tempo (em diferentes velocidades)",
+		"tui.probability_ex":     "Exemplos de probabilidade",
+		"tui.recommendations":    "Recomendações",
+		"tui.likely_extreme":     "Extremamente improvável",
+		"tui.likely_very_un":     "Muito improvável",
+		"tui.likely_unlikely":    "Improvável",
+		"tui.likely_low":         "Chance baixa",
+		"tui.likely_moderate":    "Chance moderada",
+		"tui.likely_good":        "Boa chance",
+		"tui.likely_very":        "Muito provável",
+		"tui.likely_impossible":  "Impossível",
+		"tui.level":              "Nível de dificuldade",
+		"tui.level_easy":         "Fácil",
+		"tui.level_moderate":     "Moderado",
+		"tui.level_hard":         "Difícil",
+		"tui.level_extreme":      "Extremamente difícil",
+		"tui.recommend":          "Recomendação",
+		"tui.recommend_easy":     "Deve gerar rapidamente, adequado para testes",
+		"tui.recommend_moderate": "Pode levar algum tempo, razoável para uso em produção",
+		"tui.recommend_hard":     "Levará um tempo considerável, planeje-se",
+		"tui.recommend_extreme":  "Pode levar dias/semanas/anos, use com extrema cautela",
+		"tui.checksum_impact":    "Impacto do checksum",
+		"tui.checksum_warning":   "A validação de checksum aumenta muito a dificuldade",
+		"tui.perf_tip":           "Dica de desempenho",
+		"tui.perf_threads":       "Use várias threads (--threads) para melhor desempenho",
+
+		"wizard.title":          "Assistente de Configuração",
+		"wizard.prefix":         "Prefixo",
+		"wizard.suffix":         "Sufixo",
+		"wizard.checksum":       "Checksum EIP-55",
+		"wizard.case_sensitive": "Respeitar maiúsculas e minúsculas",
+		"wizard.count":          "Carteiras",
+		"wizard.keystore":       "Salvar arquivos KeyStore",
+		"wizard.kdf":            "KDF do KeyStore",
+		"wizard.run":            "Executar agora",
+		"wizard.print":          "Mostrar linha de comando",
+		"wizard.calibrating":    "medindo velocidade...",
+		"wizard.empty_pattern":  "Digite um prefixo ou sufixo (caracteres hexadecimais)",
+		"wizard.help":           "↑/↓ ou Tab para mover • Espaço para alternar • ←/→ para mudar • Enter para escolher • Esc para sair",
+
+		"generate.mnemonic_path":       "Derivando chaves de mnemônicos BIP-39 de 12 palavras (sem senha) em %s; verifique a compatibilidade da carteira com --preview",
+		"generate.attempt_budget":      "Orçamento de tentativas: %s tentativas (%.4g%% de probabilidade por carteira)",
+		"batch.wallet_verbose":         "Carteira %d: 0x%s (tentativas: %s)",
+		"batch.wallet_keystore_failed": "Aviso: falha ao gerar o keystore da carteira %d: %v",
+
+		"warn.cipher_nonstandard":  "Aviso: keystores %s não são padrão; geth, clef e outras carteiras não conseguem abri-los",
+		"warn.cipher_experimental": "Aviso: aes-128-gcm é experimental; apenas bloco-eth keystore decrypt consegue ler esses keystores",
+		"warn.pool_shutdown":       "Aviso: falha ao encerrar o pool de workers: %v",
+		"warn.tui_failed":          "A TUI falhou: %v, usando o modo texto",
+		"warn.cpu_quota":           "Aviso: --threads %d excede a cota de CPU do contêiner de %s CPUs; as threads extras apenas a dividem (0 detecta %d automaticamente)",
+		"warn.scrypt_reduced":      "Aviso: o scrypt padrão n=%v não cabe no limite de memória de %s (--kdf-max-memory %s); usando n=%v",
+
+		"kdf.title":                "Análise de Compatibilidade do KDF",
+		"kdf.algorithm":            "Algoritmo KDF: %s",
+		"kdf.algorithm_normalized": "Algoritmo KDF: %s (normalizado: %s)",
+		"kdf.security_level":       "Nível de Segurança: %s (%s)",
+		"kdf.compatible":           "Status: Compatível",
+		"kdf.incompatible":         "Status: Incompatível",
+		"kdf.parameters":           "Parâmetros:",
+		"kdf.issues":               "Problemas:",
+		"kdf.warnings":             "Avisos:",
+		"kdf.suggestions":          "Sugestões:",
+		"kdf.analysis_failed":      "Aviso: falha ao analisar a compatibilidade do KDF: %v",
+
+		"bench.running":             "Executando benchmark...",
+		"bench.warmup":              "Aquecimento: %v (excluído)",
+		"bench.threads":             "Threads: %d",
+		"bench.starting":            "Iniciando benchmark...",
+		"bench.sample_total":        "Amostra %d: %.0f end/s (total: %s tentativas)",
+		"bench.completed":           "Benchmark concluído!",
+		"bench.results":             "Resultados do Benchmark:",
+		"bench.total_attempts":      "Total de Tentativas: %s",
+		"bench.average_speed":       "Velocidade Média: %.0f end/s",
+		"bench.speed_range":         "Faixa de Velocidade: %.0f - %.0f end/s",
+		"bench.threading":           "Desempenho Multi-Thread:",
+		"bench.threads_used":        "Threads Usadas: %d",
+		"bench.thread_efficiency":   "Eficiência das Threads: %.1f%%",
+		"bench.thread_balance":      "Balanceamento das Threads: %.1f%%",
+		"bench.single_thread":       "Velocidade Estimada com Uma Thread: %.0f end/s",
+		"bench.speedup":             "Aceleração Multi-Thread: %.2fx",
+		"bench.parallel_efficiency": "Eficiência Paralela: %.1f%% (%.2fx de %dx ideal)",
+		"bench.samples":             "Amostras Detalhadas de Desempenho:",
+		"bench.sample":              "Amostra %d: %.0f end/s",
+		"bench.samples_omitted":     "... (%d amostras omitidas) ...",
+		"bench.speed_stats":         "Estatísticas de Velocidade:",
+		"bench.mean":                "Média: %.0f end/s",
+		"bench.std_dev":             "Desvio Padrão: %.0f end/s",
+		"bench.variation":           "Coeficiente de Variação: %.1f%%",
+		"bench.analysis":            "Análise de Desempenho:",
+		"bench.mt_excellent":        "Excelente eficiência multi-thread",
+		"bench.mt_good":             "Boa eficiência multi-thread",
+		"bench.mt_poor":             "A eficiência multi-thread pode melhorar",
+		"bench.balanced":            "Uso das threads bem balanceado",
+		"bench.unbalanced":          "Uso desigual das threads detectado",
+		"bench.speed_excellent":     "Desempenho excelente (>100k end/s)",
+		"bench.speed_good":          "Bom desempenho (>50k end/s)",
+		"bench.speed_moderate":      "Desempenho moderado (>10k end/s)",
+		"bench.speed_poor":          "O desempenho pode melhorar (<10k end/s)",
+
+		"version.name":       "Bloco-ETH %s",
+		"version.commit":     "Commit Git: %s",
+		"version.build_time": "Data do Build: %s",
+
+		"efficiency.running":       "Executando a varredura de eficiência...",
+		"efficiency.thread_counts": "Quantidades de threads: %v",
+		"efficiency.batch_sizes":   "Tamanhos de lote: %v",
+		"efficiency.step_duration": "Duração por configuração: %v",
+		"efficiency.energy_rapl":   "Fonte de energia: RAPL (%s)",
+		"efficiency.energy_none":   "Fonte de energia: indisponível, classificando por end/s por thread",
+		"efficiency.row":           "  threads=%-3d lote=%-6d %10.0f end/s %10.0f end/s/thread",
+		"efficiency.row_energy":    "  threads=%-3d lote=%-6d %10.0f end/s %10.0f end/s/thread %10.0f end/J",
+		"efficiency.best":          "Configuração Mais Eficiente:",
+		"efficiency.batch_size":    "Tamanho do Lote: %d",
+		"efficiency.speed":         "Velocidade: %.0f end/s (%.0f end/s por thread)",
+		"efficiency.energy":        "Eficiência Energética: %.0f end/J",
+		"efficiency.fastest":       "Configuração mais rápida para comparação: %d threads, lote %d (%.0f end/s)",
+
+		"cloudcost.title":    "Estimativas de Custo na Nuvem (uma instância spot):",
+		"cloudcost.provider": "Provedor",
+		"cloudcost.instance": "Instância",
+		"cloudcost.vcpus":    "vCPUs",
+		"cloudcost.speed":    "end/s",
+		"cloudcost.time50":   "Tempo 50%",
+		"cloudcost.cost50":   "Custo 50%",
+		"cloudcost.time95":   "Tempo 95%",
+		"cloudcost.cost95":   "Custo 95%",
+		"cloudcost.scaling":  "O custo acompanha a computação total: N instâncias dividem o tempo real por N com o mesmo custo.",
+		"cloudcost.prices":   "Os preços são tarifas spot aproximadas; use --cost-table para preços atuais.",
+
+		"empirical.title":      "Validação Empírica:",
+		"empirical.sampling":   "Amostrando %s endereços aleatórios em %d threads...",
+		"empirical.matches":    "Correspondências: %s observadas, %.1f esperadas (1 em %s)",
+		"empirical.observed":   "Dificuldade observada: 1 em %s",
+		"empirical.deviation":  "Desvio: %+.2f desvios padrão (%s em %s)",
+		"empirical.too_few":    "Apenas %.1f correspondências esperadas; use pelo menos --samples %.0f para uma comparação confiável",
+		"empirical.consistent": "A taxa observada é consistente com a dificuldade teórica",
+
+		"histogram.title":    "Histograma de Tentativas (%d carteiras):",
+		"histogram.bucket":   "%s a %s tentativas: %d carteiras",
+		"histogram.range":    "%s - %s tentativas",
+		"histogram.mean":     "Média: %s (esperada %s, %+.2f erros padrão)",
+		"histogram.unlikely": "Esta média é estatisticamente improvável (além de %.0f erros padrão); a estimativa de dificuldade ou o matcher podem estar errados",
+		"histogram.saved":    "Histograma de tentativas salvo em: %s",
+
+		"tui.compare_title":   "Comparação de Threads",
+		"tui.compare_threads": "%3d threads",
+		"tui.compare_speed":   "%s end/s  %.2fx  %.0f%%",
+		"tui.measuring":       "medindo...",
+		"tui.pending":         "pendente",
+		"tui.unbounded":       "ilimitado",
+		"tui.amdahl":          "%.1f%% serial · %d threads: %.1fx · %d threads: %.1fx · limite %s",
+		"tui.compare_done":    "Comparação concluída",
+
+		"tui.bench_metric":               "Métrica",
+		"tui.bench_value":                "Valor",
+		"tui.bench_details":              "Detalhes",
+		"tui.bench_running":              "Benchmark em Execução",
+		"tui.bench_current":              "Desempenho Atual:",
+		"tui.bench_current_speed":        "Velocidade Atual: %s end/s",
+		"tui.bench_average_speed":        "Velocidade Média: %s end/s",
+		"tui.bench_min_max":              "Velocidade Mín/Máx: %s/%s end/s",
+		"tui.bench_speed":                "Velocidade: %s end/s",
+		"tui.bench_pattern":              "Padrão: %s",
+		"tui.bench_difficulty":           "Dificuldade: %.2f",
+		"tui.bench_eta":                  "Tempo Estimado: %s",
+		"tui.bench_efficiency":           "Eficiência: %.1f%%",
+		"tui.bench_loading":              "Benchmark Concluído - Carregando Resultados...",
+		"tui.bench_preparing":            "Preparando os resultados",
+		"tui.bench_results":              "Resultados do Benchmark",
+		"tui.bench_summary":              "Resumo:",
+		"tui.bench_total_duration":       "Duração Total: %s",
+		"tui.bench_scalability":          "Fator de Escalabilidade: %.2fx",
+		"tui.bench_help":                 "↑/↓: Navegar • q: Sair • Ctrl+C: Encerrar",
+		"tui.bench_row_attempts":         "Total de Tentativas",
+		"tui.bench_row_attempts_info":    "Total de endereços gerados",
+		"tui.bench_row_duration":         "Duração",
+		"tui.bench_row_duration_info":    "Tempo total do benchmark",
+		"tui.bench_row_average":          "Velocidade Média",
+		"tui.bench_row_average_info":     "Taxa média de geração",
+		"tui.bench_row_min":              "Velocidade Mínima",
+		"tui.bench_row_min_info":         "Menor velocidade registrada",
+		"tui.bench_row_max":              "Velocidade Máxima",
+		"tui.bench_row_max_info":         "Maior velocidade registrada",
+		"tui.bench_row_threads":          "Número de Threads",
+		"tui.bench_row_threads_info":     "Workers paralelos usados",
+		"tui.bench_row_single":           "Estimativa com Uma Thread",
+		"tui.bench_row_single_info":      "Velocidade estimada com uma thread",
+		"tui.bench_row_scalability":      "Escalabilidade",
+		"tui.bench_row_scalability_info": "Eficiência multi-thread",
+		"tui.bench_row_speedup":          "Fator de Aceleração",
+		"tui.bench_row_speedup_info":     "Ganho de desempenho",
+
+		"debug.tui_no_terminal":      "DEBUG TUI: nem stdout nem stdin são terminais, TERM=%s",
+		"debug.tui_dev_environment":  "DEBUG TUI: ambiente de desenvolvimento detectado, permitindo a TUI",
+		"debug.tui_incapable":        "DEBUG TUI: nenhum terminal compatível detectado, desativando a TUI",
+		"debug.tui_capable":          "DEBUG TUI: terminal compatível detectado, permitindo a TUI",
+		"debug.tui_too_small":        "DEBUG TUI: terminal pequeno demais (%dx%d)",
+		"debug.tui_ci":               "DEBUG TUI: ambiente de CI detectado",
+		"debug.tui_redirected":       "DEBUG TUI: a saída está redirecionada",
+		"debug.tui_benchmark_update": "DEBUG: a TUI recebeu atualização - tentativas: %d, velocidade: %.2f",
+
+		"exit.limit_budget":     "o orçamento de %.4g%% de probabilidade",
+		"exit.limit_key_range":  "o fim de --key-range",
+		"exit.accepted_partial": "Interrompido por %s com %d de %d carteira(s); aceito por causa de --fail-on-timeout=false",
+
+		"audit.verified":   "Trilha de auditoria verificada: %d entradas",
+		"audit.first":      "Primeira: %s",
+		"audit.last":       "Última:   %s",
+		"audit.head":       "Topo:     %s",
+		"warn.audit_write": "Aviso: falha ao gravar a trilha de auditoria: %v",
+
+		"warn.error": "Aviso: %v",
+
+		"ceremony.verified":             "Transcrição da cerimônia verificada",
+		"ceremony.started":              "Início:       %s",
+		"ceremony.operators":            "Operadores:   %s",
+		"ceremony.binary":               "Binário:      %s",
+		"ceremony.config":               "Configuração: %s",
+		"ceremony.offline":              "Offline:      %v",
+		"ceremony.wallets":              "Carteiras:    %d",
+		"ceremony.outcome":              "Resultado:    %s",
+		"ceremony.signed_by":            "Assinada por: %s",
+		"ceremony.title":                "Cerimônia de Geração de Chaves",
+		"ceremony.host":                 "Host:                %s",
+		"ceremony.binary_path":          "Binário:             %s",
+		"ceremony.binary_sha256":        "SHA-256 do binário:  %s",
+		"ceremony.config_sha256":        "SHA-256 da config:   %s",
+		"ceremony.network_offline":      "Rede:                offline",
+		"ceremony.network_online":       "Rede:                ONLINE, verificação ignorada (%s)",
+		"ceremony.signing_key":          "Chave de assinatura: %x",
+		"ceremony.record":               "Registre as duas impressões digitais e a chave de assinatura no livro da cerimônia.",
+		"ceremony.operator_name":        "Nome do operador %d: ",
+		"ceremony.operator_confirm":     "%s, digite os primeiros %d caracteres do SHA-256 do binário para confirmar: ",
+		"ceremony.transcript":           "Transcrição da cerimônia: %s",
+		"ceremony.transcript_signed_by": "Assinada por:             %s",
+
+		"agent.searching": "Agente %s buscando no keyspace %s (%s) por %s em %d threads",
+		"agent.lease":     "Lease %s: %s chaves %s",
+		"agent.coverage":  "Cobertura do keyspace: %s chaves verificadas (%.4g%%), %d/%d carteiras encontradas",
+		"agent.finished":  "O keyspace %s está %s: %s chaves verificadas (%.4g%%), %d/%d carteiras encontradas",
+
+		"update.up_to_date": "bloco-eth %s está atualizado (última versão: %s)",
+		"update.available":  "Atualização disponível: %s -> %s",
+		"update.managed":    "Instalado com %s; atualize com: %s",
+		"update.unsigned":   "Assinatura da versão não verificada: este build não tem chave de release",
+		"update.installing": "Instalando %s para %s/%s...",
+		"update.updated":    "%s atualizado de %s para %s",
+
+		"harden.applied": "Protegido: %s",
+		"harden.skipped": "Não protegido: %s",
+
+		"donate.started":      "Doando %d threads com prioridade ociosa aos keyspaces compartilhados de %s",
+		"donate.searching":    "Buscando no keyspace compartilhado %s por %s",
+		"donate.waiting":      "Aguardando: outros programas mantêm %.0f%% da CPU ocupada",
+		"donate.found":        "Encontrado %s no keyspace %s; informado ao coordenador",
+		"warn.lower_priority": "Aviso: falha ao reduzir a prioridade do processo: %v",
+	},
+	Spanish: {
+		"duration.impossible":         "Casi imposible",
+		"duration.thousands_of_years": "Miles de años",
+		"duration.unit.second":        "s",
+		"duration.unit.minute":        "min",
+		"duration.unit.hour":          "h",
+		"duration.unit.day":           "d",
+		"duration.unit.year":          "a",
+
+		"bool.enabled":  "Activada",
+		"bool.disabled": "Desactivada",
+
+		"generate.header":       "Generando billetera con el patrón: %s",
+		"generate.header_batch": "Generando %d billeteras con el patrón: %s",
+		"generate.difficulty":   "Dificultad: %s",
+		"generate.threads":      "Usando %d hilos de trabajo",
+		"generate.batch_error":  "Error al generar la billetera %d: %v",
+
+		"result.success":           "¡Billetera generada correctamente!",
+		"result.address":           "Dirección: %s",
+		"result.explorer":          "Explorador: %s",
+		"result.private_key":       "Clave privada: %s",
+		"result.key_sealed":        "sellada en el TPM 2.0, handle %s",
+		"result.key_yubikey":       "en la YubiKey %s, slot PIV %s, handle %s",
+		"result.public_key":        "Clave pública: %s",
+		"result.public_key_comp":   "Clave pública comprimida: %s",
+		"result.mnemonic":          "Mnemónico: %s",
+		"result.attempts":          "Intentos: %s",
+		"result.duration":          "Duración: %s",
+		"result.worker":            "Worker: #%d",
+		"result.seed_counter":      "Contador de semilla: %d (semilla maestra %s)",
+		"result.address_qr":        "Código QR de la dirección:",
+		"result.private_key_qr":    "Código QR de la clave privada (quien lo escanee controla la billetera):",
+		"result.keystore_failed":   "Aviso: no se pudo generar el keystore: %v",
+		"result.keystore_saved":    "Keystore guardado en: %s",
+		"result.password_policy":   "Contraseñas de los keystores: %s (%.0f bits de entropía cada una)",
+		"result.backup_saved":      "%s guardado en: %s",
+		"result.keystore_deferred": "Keystore: aplazado hasta el final de la ejecución",
+		"batch.none":               "No se generó ninguna billetera correctamente",
+		"batch.success":            "¡%d billeteras generadas correctamente!",
+		"batch.total_attempts":     "Intentos totales: %s",
+		"batch.total_duration":     "Duración total: %s",
+		"batch.average_speed":      "Velocidad media: %s dir/s",
+		"batch.wallet":             "Billetera %d:",
+		"batch.keystore_failed":    "Keystore: no se pudo generar (%v)",
+		"batch.keystore_saved":     "Keystore: guardado",
+		"batch.keystore_deferred":  "Keystore: aplazado",
+		"batch.backup_saved":       "%s: guardado",
+		"batch.keystores_saved":    "Keystores guardados: %d/%d en %s",
+		"batch.keystore_errors":    "Errores de keystore: %d/%d",
+		"batch.keystore_pipeline":  "Cifrado de keystores: %d workers, terminado %s después de la última billetera",
+		"summary.title":            "Resumen estadístico:",
+		"summary.average":          "Media de intentos por billetera: %s",
+		"summary.min":              "Mínimo de intentos: %s",
+		"summary.max":              "Máximo de intentos: %s",
+		"summary.success_rate":     "Tasa de éxito: %s%%",
+		"stats.title":              "Análisis del patrón: %s",
+		"stats.length":             "Longitud del patrón: %d caracteres",
+		"stats.checksum":           "Validación de checksum: %s",
+		"stats.probability50":      "Probabilidad del 50%%: %s intentos",
+		"stats.breakdown":          "Desglose de la dificultad:",
+		"stats.breakdown_base":     "Base (16^%d, cualquier caso): %s",
+		"stats.breakdown_case":     "Factor de mayúsculas (2^%d, letras con caso exigido): %s",
+		"stats.breakdown_no_case":  "Factor de mayúsculas: 1 (se ignora el caso sin --checksum --case-sensitive)",
+		"stats.time_estimates":     "Estimaciones de tiempo:",
+		"stats.at_speed":           "A %s dir/s: %s",
+
+		"tui.no_stats":          "No hay estadísticas disponibles",
+		"tui.title":             "Generador de Billeteras",
+		"tui.pattern":           "Patrón",
+		"tui.pattern_any":       "cualquiera",
+		"tui.checksum_tag":      "(checksum)",
+		"tui.difficulty":        "Dificultad",
+		"tui.wallets_completed": "%d/%d billeteras completadas (%s%%)",
+		"tui.wallets_generated": "%d billeteras generadas",
+		"tui.probability":       "%s%% de probabilidad",
+		"tui.paused":            "En pausa: %s; se reanuda cuando se recupere",
+		"tui.last_found":        "Último encontrado",
+		"tui.explorer":          "Explorador",
+		"tui.legend_color":      "Negrita: patrón encontrado; subrayado: mayúscula del checksum EIP-55",
+		"tui.legend_markers":    "%s patrón encontrado, %s mayúscula del checksum EIP-55, %s ambos",
+		"tui.statistics":        "Estadísticas",
+		"tui.thread_perf":       "Rendimiento de los hilos",
+		"tui.generated":         "Billeteras generadas (%d)",
+		"tui.help_scroll":       "Use ↑↓/j/k para desplazar la tabla • Pulse q para salir • Ctrl+C para terminar",
+		"tui.help_quit":         "Pulse q para salir • Ctrl+C para terminar",
+		"tui.attempts":          "Intentos",
+		"tui.speed":             "Velocidad",
+		"tui.speed_value":       "%s dir/s",
+		"tui.eta":               "Tiempo restante",
+		"tui.probability50_at":  "50% en",
+		"tui.attempts_value":    "%s intentos",
+		"tui.threads":           "Hilos",
+		"tui.threads_value":     "%d hilos",
+		"tui.efficiency":        "Eficiencia",
+		"tui.peak_speed":        "Velocidad máxima",
+		"tui.done_in":           "Terminado en %s",
+		"tui.calculating":       "Calculando...",
+		"tui.eta_reached":       "alcanzado",
+		"tui.eta_never":         "nunca",
+		"tui.column_address":    "Dirección",
+		"tui.column_key":        "Clave privada",
+		"tui.column_attempts":   "Intentos",
+		"tui.column_time":       "Tiempo",
+		"tui.error_row":         "Se produjo un error",
+
+		"tui.column_speed":       "Velocidad (dir/s)",
+		"tui.column_p50":         "Probabilidad del 50%",
+		"tui.column_p90":         "Probabilidad del 90%",
+		"tui.column_probability": "Probabilidad",
+		"tui.column_likelihood":  "Posibilidad",
+
+		"tui.stats_title":        "Análisis de Dificultad de Direcciones Bloco",
+		"tui.stats_detailed":     "Estadísticas detalladas",
+		"tui.stats_help":         "Use ↑/↓ o j/k para navegar • Pulse 'q', 'Ctrl+C' o 'Esc' para salir",
+		"tui.checksum":           "Checksum",
+		"tui.checksum_on":        "Activado (aumenta la dificultad)",
+		"tui.pattern_length":     "Longitud del patrón",
+		"tui.characters":         "%d caracteres",
+		"tui.time_estimates":     "Estimaciones de tiempo (a distintas velocidades)",
+		"tui.probability_ex":     "Ejemplos de probabilidad",
+		"tui.recommendations":    "Recomendaciones",
+		"tui.likely_extreme":     "Extremadamente improbable",
+		"tui.likely_very_un":     "Muy improbable",
+		"tui.likely_unlikely":    "Improbable",
+		"tui.likely_low":         "Probabilidad baja",
+		"tui.likely_moderate":    "Probabilidad moderada",
+		"tui.likely_good":        "Buena probabilidad",
+		"tui.likely_very":        "Muy probable",
+		"tui.likely_impossible":  "Imposible",
+		"tui.level":              "Nivel de dificultad",
+		"tui.level_easy":         "Fácil",
+		"tui.level_moderate":     "Moderado",
+		"tui.level_hard":         "Difícil",
+		"tui.level_extreme":      "Extremadamente difícil",
+		"tui.recommend":          "Recomendación",
+		"tui.recommend_easy":     "Debería generarse rápido, adecuado para pruebas",
+		"tui.recommend_moderate": "Puede tardar un tiempo, razonable para producción",
+		"tui.recommend_hard":     "Tardará bastante tiempo, planifíquelo",
+		"tui.recommend_extreme":  "Puede tardar días/semanas/años, úselo con extrema precaución",
+		"tui.checksum_impact":    "Impacto del checksum",
+		"tui.checksum_warning":   "La validación de checksum aumenta mucho la dificultad",
+		"tui.perf_tip":           "Consejo de rendimiento",
+		"tui.perf_threads":       "Use varios hilos (--threads) para un mejor rendimiento",
+
+		"wizard.title":          "Asistente de Configuración",
+		"wizard.prefix":         "Prefijo",
+		"wizard.suffix":         "Sufijo",
+		"wizard.checksum":       "Checksum EIP-55",
+		"wizard.case_sensitive": "Respetar mayúsculas y minúsculas",
+		"wizard.count":          "Carteras",
+		"wizard.keystore":       "Guardar archivos KeyStore",
+		"wizard.kdf":            "KDF del KeyStore",
+		"wizard.run":            "Ejecutar ahora",
+		"wizard.print":          "Mostrar línea de comandos",
+		"wizard.calibrating":    "midiendo velocidad...",
+		"wizard.empty_pattern":  "Escriba un prefijo o sufijo (caracteres hexadecimales)",
+		"wizard.help":           "↑/↓ o Tab para moverse • Espacio para alternar • ←/→ para cambiar • Enter para elegir • Esc para salir",
+
+		"generate.mnemonic_path":       "Derivando claves de mnemónicos BIP-39 de 12 palabras (sin contraseña) en %s; compruebe la compatibilidad de la billetera con --preview",
+		"generate.attempt_budget":      "Presupuesto de intentos: %s intentos (%.4g%% de probabilidad por billetera)",
+		"batch.wallet_verbose":         "Billetera %d: 0x%s (intentos: %s)",
+		"batch.wallet_keystore_failed": "Advertencia: no se pudo generar el keystore de la billetera %d: %v",
+
+		"warn.cipher_nonstandard":  "Advertencia: los keystores %s no son estándar; geth, clef y otras billeteras no pueden abrirlos",
+		"warn.cipher_experimental": "Advertencia: aes-128-gcm es experimental; solo bloco-eth keystore decrypt puede leer estos keystores",
+		"warn.pool_shutdown":       "Advertencia: no se pudo detener el pool de workers: %v",
+		"warn.tui_failed":          "La TUI falló: %v, se usa el modo texto",
+		"warn.cpu_quota":           "Advertencia: --threads %d supera la cuota de CPU del contenedor de %s CPUs; los hilos extra solo la comparten (0 detecta %d automáticamente)",
+		"warn.scrypt_reduced":      "Advertencia: el scrypt predeterminado n=%v no cabe en el límite de memoria de %s (--kdf-max-memory %s); se usa n=%v",
+
+		"kdf.title":                "Análisis de Compatibilidad del KDF",
+		"kdf.algorithm":            "Algoritmo KDF: %s",
+		"kdf.algorithm_normalized": "Algoritmo KDF: %s (normalizado: %s)",
+		"kdf.security_level":       "Nivel de Seguridad: %s (%s)",
+		"kdf.compatible":           "Estado: Compatible",
+		"kdf.incompatible":         "Estado: Incompatible",
+		"kdf.parameters":           "Parámetros:",
+		"kdf.issues":               "Problemas:",
+		"kdf.warnings":             "Advertencias:",
+		"kdf.suggestions":          "Sugerencias:",
+		"kdf.analysis_failed":      "Advertencia: no se pudo analizar la compatibilidad del KDF: %v",
+
+		"bench.running":             "Ejecutando benchmark...",
+		"bench.warmup":              "Calentamiento: %v (excluido)",
+		"bench.threads":             "Hilos: %d",
+		"bench.starting":            "Iniciando benchmark...",
+		"bench.sample_total":        "Muestra %d: %.0f dir/s (total: %s intentos)",
+		"bench.completed":           "¡Benchmark completado!",
+		"bench.results":             "Resultados del Benchmark:",
+		"bench.total_attempts":      "Intentos Totales: %s",
+		"bench.average_speed":       "Velocidad Media: %.0f dir/s",
+		"bench.speed_range":         "Rango de Velocidad: %.0f - %.0f dir/s",
+		"bench.threading":           "Rendimiento Multihilo:",
+		"bench.threads_used":        "Hilos Usados: %d",
+		"bench.thread_efficiency":   "Eficiencia de los Hilos: %.1f%%",
+		"bench.thread_balance":      "Equilibrio de los Hilos: %.1f%%",
+		"bench.single_thread":       "Velocidad Estimada con Un Hilo: %.0f dir/s",
+		"bench.speedup":             "Aceleración Multihilo: %.2fx",
+		"bench.parallel_efficiency": "Eficiencia Paralela: %.1f%% (%.2fx de %dx ideal)",
+		"bench.samples":             "Muestras Detalladas de Rendimiento:",
+		"bench.sample":              "Muestra %d: %.0f dir/s",
+		"bench.samples_omitted":     "... (%d muestras omitidas) ...",
+		"bench.speed_stats":         "Estadísticas de Velocidad:",
+		"bench.mean":                "Media: %.0f dir/s",
+		"bench.std_dev":             "Desviación Estándar: %.0f dir/s",
+		"bench.variation":           "Coeficiente de Variación: %.1f%%",
+		"bench.analysis":            "Análisis de Rendimiento:",
+		"bench.mt_excellent":        "Excelente eficiencia multihilo",
+		"bench.mt_good":             "Buena eficiencia multihilo",
+		"bench.mt_poor":             "La eficiencia multihilo podría mejorar",
+		"bench.balanced":            "Uso de los hilos bien equilibrado",
+		"bench.unbalanced":          "Uso desigual de los hilos detectado",
+		"bench.speed_excellent":     "Rendimiento excelente (>100k dir/s)",
+		"bench.speed_good":          "Buen rendimiento (>50k dir/s)",
+		"bench.speed_moderate":      "Rendimiento moderado (>10k dir/s)",
+		"bench.speed_poor":          "El rendimiento podría mejorar (<10k dir/s)",
+
+		"version.name":       "Bloco-ETH %s",
+		"version.commit":     "Commit de Git: %s",
+		"version.build_time": "Fecha de Compilación: %s",
+
+		"efficiency.running":       "Ejecutando el barrido de eficiencia...",
+		"efficiency.thread_counts": "Cantidades de hilos: %v",
+		"efficiency.batch_sizes":   "Tamaños de lote: %v",
+		"efficiency.step_duration": "Duración por configuración: %v",
+		"efficiency.energy_rapl":   "Fuente de energía: RAPL (%s)",
+		"efficiency.energy_none":   "Fuente de energía: no disponible, se ordena por dir/s por hilo",
+		"efficiency.row":           "  hilos=%-3d lote=%-6d %10.0f dir/s %10.0f dir/s/hilo",
+		"efficiency.row_energy":    "  hilos=%-3d lote=%-6d %10.0f dir/s %10.0f dir/s/hilo %10.0f dir/J",
+		"efficiency.best":          "Configuración Más Eficiente:",
+		"efficiency.batch_size":    "Tamaño del Lote: %d",
+		"efficiency.speed":         "Velocidad: %.0f dir/s (%.0f dir/s por hilo)",
+		"efficiency.energy":        "Eficiencia Energética: %.0f dir/J",
+		"efficiency.fastest":       "Configuración más rápida para comparar: %d hilos, lote %d (%.0f dir/s)",
+
+		"cloudcost.title":    "Estimaciones de Costo en la Nube (una instancia spot):",
+		"cloudcost.provider": "Proveedor",
+		"cloudcost.instance": "Instancia",
+		"cloudcost.vcpus":    "vCPUs",
+		"cloudcost.speed":    "dir/s",
+		"cloudcost.time50":   "Tiempo 50%",
+		"cloudcost.cost50":   "Costo 50%",
+		"cloudcost.time95":   "Tiempo 95%",
+		"cloudcost.cost95":   "Costo 95%",
+		"cloudcost.scaling":  "El costo sigue al cómputo total: N instancias dividen el tiempo real entre N con el mismo costo.",
+		"cloudcost.prices":   "Los precios son tarifas spot aproximadas; use --cost-table para precios actuales.",
+
+		"empirical.title":      "Validación Empírica:",
+		"empirical.sampling":   "Muestreando %s direcciones aleatorias en %d hilos...",
+		"empirical.matches":    "Coincidencias: %s observadas, %.1f esperadas (1 de cada %s)",
+		"empirical.observed":   "Dificultad observada: 1 de cada %s",
+		"empirical.deviation":  "Desviación: %+.2f desviaciones estándar (%s en %s)",
+		"empirical.too_few":    "Solo se esperan %.1f coincidencias; use al menos --samples %.0f para una comparación fiable",
+		"empirical.consistent": "La tasa observada es coherente con la dificultad teórica",
+
+		"histogram.title":    "Histograma de Intentos (%d billeteras):",
+		"histogram.bucket":   "%s a %s intentos: %d billeteras",
+		"histogram.range":    "%s - %s intentos",
+		"histogram.mean":     "Media: %s (esperada %s, %+.2f errores estándar)",
+		"histogram.unlikely": "Esta media es estadísticamente improbable (más de %.0f errores estándar); la estimación de dificultad o el matcher pueden estar mal",
+		"histogram.saved":    "Histograma de intentos guardado en: %s",
+
+		"tui.compare_title":   "Comparación de Hilos",
+		"tui.compare_threads": "%3d hilos",
+		"tui.compare_speed":   "%s dir/s  %.2fx  %.0f%%",
+		"tui.measuring":       "midiendo...",
+		"tui.pending":         "pendiente",
+		"tui.unbounded":       "ilimitado",
+		"tui.amdahl":          "%.1f%% serie · %d hilos: %.1fx · %d hilos: %.1fx · límite %s",
+		"tui.compare_done":    "Comparación completada",
+
+		"tui.bench_metric":               "Métrica",
+		"tui.bench_value":                "Valor",
+		"tui.bench_details":              "Detalles",
+		"tui.bench_running":              "Benchmark en Ejecución",
+		"tui.bench_current":              "Rendimiento Actual:",
+		"tui.bench_current_speed":        "Velocidad Actual: %s dir/s",
+		"tui.bench_average_speed":        "Velocidad Media: %s dir/s",
+		"tui.bench_min_max":              "Velocidad Mín/Máx: %s/%s dir/s",
+		"tui.bench_speed":                "Velocidad: %s dir/s",
+		"tui.bench_pattern":              "Patrón: %s",
+		"tui.bench_difficulty":           "Dificultad: %.2f",
+		"tui.bench_eta":                  "Tiempo Estimado: %s",
+		"tui.bench_efficiency":           "Eficiencia: %.1f%%",
+		"tui.bench_loading":              "Benchmark Completado - Cargando Resultados...",
+		"tui.bench_preparing":            "Preparando los resultados",
+		"tui.bench_results":              "Resultados del Benchmark",
+		"tui.bench_summary":              "Resumen:",
+		"tui.bench_total_duration":       "Duración Total: %s",
+		"tui.bench_scalability":          "Factor de Escalabilidad: %.2fx",
+		"tui.bench_help":                 "↑/↓: Navegar • q: Salir • Ctrl+C: Terminar",
+		"tui.bench_row_attempts":         "Intentos Totales",
+		"tui.bench_row_attempts_info":    "Total de direcciones generadas",
+		"tui.bench_row_duration":         "Duración",
+		"tui.bench_row_duration_info":    "Tiempo total del benchmark",
+		"tui.bench_row_average":          "Velocidad Media",
+		"tui.bench_row_average_info":     "Tasa media de generación",
+		"tui.bench_row_min":              "Velocidad Mínima",
+		"tui.bench_row_min_info":         "Menor velocidad registrada",
+		"tui.bench_row_max":              "Velocidad Máxima",
+		"tui.bench_row_max_info":         "Mayor velocidad registrada",
+		"tui.bench_row_threads":          "Número de Hilos",
+		"tui.bench_row_threads_info":     "Workers paralelos usados",
+		"tui.bench_row_single":           "Estimación con Un Hilo",
+		"tui.bench_row_single_info":      "Velocidad estimada con un hilo",
+		"tui.bench_row_scalability":      "Escalabilidad",
+		"tui.bench_row_scalability_info": "Eficiencia multihilo",
+		"tui.bench_row_speedup":          "Factor de Aceleración",
+		"tui.bench_row_speedup_info":     "Mejora de rendimiento",
+
+		"debug.tui_no_terminal":      "DEBUG TUI: ni stdout ni stdin son terminales, TERM=%s",
+		"debug.tui_dev_environment":  "DEBUG TUI: entorno de desarrollo detectado, se permite la TUI",
+		"debug.tui_incapable":        "DEBUG TUI: no se detectó un terminal compatible, se desactiva la TUI",
+		"debug.tui_capable":          "DEBUG TUI: terminal compatible detectado, se permite la TUI",
+		"debug.tui_too_small":        "DEBUG TUI: terminal demasiado pequeño (%dx%d)",
+		"debug.tui_ci":               "DEBUG TUI: entorno de CI detectado",
+		"debug.tui_redirected":       "DEBUG TUI: la salida está redirigida",
+		"debug.tui_benchmark_update": "DEBUG: la TUI recibió una actualización - intentos: %d, velocidad: %.2f",
+
+		"exit.limit_budget":     "el presupuesto de %.4g%% de probabilidad",
+		"exit.limit_key_range":  "el final de --key-range",
+		"exit.accepted_partial": "Detenido por %s con %d de %d billetera(s); aceptado por --fail-on-timeout=false",
+
+		"audit.verified":   "Registro de auditoría verificado: %d entradas",
+		"audit.first":      "Primera: %s",
+		"audit.last":       "Última:  %s",
+		"audit.head":       "Cabeza:  %s",
+		"warn.audit_write": "Advertencia: no se pudo escribir el registro de auditoría: %v",
+
+		"warn.error": "Advertencia: %v",
+
+		"ceremony.verified":             "Transcripción de la ceremonia verificada",
+		"ceremony.started":              "Inicio:        %s",
+		"ceremony.operators":            "Operadores:    %s",
+		"ceremony.binary":               "Binario:       %s",
+		"ceremony.config":               "Configuración: %s",
+		"ceremony.offline":              "Sin red:       %v",
+		"ceremony.wallets":              "Billeteras:    %d",
+		"ceremony.outcome":              "Resultado:     %s",
+		"ceremony.signed_by":            "Firmada por:   %s",
+		"ceremony.title":                "Ceremonia de Generación de Claves",
+		"ceremony.host":                 "Host:                %s",
+		"ceremony.binary_path":          "Binario:             %s",
+		"ceremony.binary_sha256":        "SHA-256 del binario: %s",
+		"ceremony.config_sha256":        "SHA-256 de config:   %s",
+		"ceremony.network_offline":      "Red:                 sin conexión",
+		"ceremony.network_online":       "Red:                 EN LÍNEA, comprobación omitida (%s)",
+		"ceremony.signing_key":          "Clave de firma:      %x",
+		"ceremony.record":               "Anote ambas huellas y la clave de firma en el registro de la ceremonia.",
+		"ceremony.operator_name":        "Nombre del operador %d: ",
+		"ceremony.operator_confirm":     "%s, escriba los primeros %d caracteres del SHA-256 del binario para confirmar: ",
+		"ceremony.transcript":           "Transcripción de la ceremonia: %s",
+		"ceremony.transcript_signed_by": "Firmada por:                   %s",
+
+		"agent.searching": "Agente %s buscando en el keyspace %s (%s) por %s en %d hilos",
+		"agent.lease":     "Lease %s: %s claves %s",
+		"agent.coverage":  "Cobertura del keyspace: %s claves comprobadas (%.4g%%), %d/%d billeteras encontradas",
+		"agent.finished":  "El keyspace %s está %s: %s claves comprobadas (%.4g%%), %d/%d billeteras encontradas",
+
+		"update.up_to_date": "bloco-eth %s está actualizado (última versión: %s)",
+		"update.available":  "Actualización disponible: %s -> %s",
+		"update.managed":    "Instalado con %s; actualice con: %s",
+		"update.unsigned":   "Firma de la versión no verificada: esta compilación no tiene clave de release",
+		"update.installing": "Instalando %s para %s/%s...",
+		"update.updated":    "%s actualizado de %s a %s",
+
+		"harden.applied": "Protegido: %s",
+		"harden.skipped": "No protegido: %s",
+
+		"donate.started":      "Donando %d hilos con prioridad inactiva a los keyspaces compartidos de %s",
+		"donate.searching":    "Buscando en el keyspace compartido %s por %s",
+		"donate.waiting":      "Esperando: otros programas mantienen ocupada el %.0f%% de la CPU",
+		"donate.found":        "Encontrado %s en el keyspace %s; informado al coordinador",
+		"warn.lower_priority": "Advertencia: no se pudo reducir la prioridad del proceso: %v",
+	},
+}
@@ -0,0 +1,75 @@
+// Package memgov tunes the Go runtime's soft memory limit and GC target to the
+// memory the process may use and the memory its scrypt derivations keep live, so
+// runs in containers collect before the cgroup limit kills them.
+package memgov
+
+import (
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// DefaultShare is the part of the memory ceiling the --mem-limit default gives the
+// Go runtime, leaving the rest for stacks, the kernel and other processes
+const DefaultShare = 0.9
+
+// baseHeap is the live heap assumed besides the scrypt derivations
+const baseHeap = 64 << 20
+
+// MinGCPercent is the lowest GOGC the governor sets; below it the collector runs
+// so often that the search slows down more than the memory it saves is worth
+const MinGCPercent = 25
+
+// Settings are the runtime memory settings the governor applies
+type Settings struct {
+	// Limit is the soft memory limit in bytes; zero leaves the runtime's
+	Limit int64
+	// GCPercent is the GOGC value; zero leaves the runtime's
+	GCPercent int
+}
+
+// Plan returns the settings for a soft limit of limit bytes while working bytes
+// stay live at once, such as concurrent scrypt derivations. GOGC stays 100 while
+// the limit leaves room for the heap to double; below that it drops so the heap
+// only grows into the room left, down to MinGCPercent.
+func Plan(limit, working int64) Settings {
+	s := Settings{Limit: limit}
+	if limit <= 0 {
+		return s
+	}
+	live := working + baseHeap
+	if limit >= 2*live {
+		s.GCPercent = 100
+		return s
+	}
+	s.GCPercent = max(int(float64(limit-live)/float64(live)*100), MinGCPercent)
+	return s
+}
+
+// Apply sets s on the runtime
+func Apply(s Settings) {
+	if s.Limit > 0 {
+		debug.SetMemoryLimit(s.Limit)
+	}
+	if s.GCPercent > 0 {
+		debug.SetGCPercent(s.GCPercent)
+	}
+}
+
+// GCStats are the garbage collector's cycles and stop-the-world pauses so far
+type GCStats struct {
+	Cycles uint32
+	Pause  time.Duration
+}
+
+// ReadGC returns the collector's totals since the process started
+func ReadGC() GCStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return GCStats{Cycles: m.NumGC, Pause: time.Duration(m.PauseTotalNs)}
+}
+
+// Since returns the cycles and pauses between start and s
+func (s GCStats) Since(start GCStats) GCStats {
+	return GCStats{Cycles: s.Cycles - start.Cycles, Pause: s.Pause - start.Pause}
+}
@@ -0,0 +1,34 @@
+package memgov
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	const mib = 1 << 20
+	tests := []struct {
+		name           string
+		limit, working int64
+		want           Settings
+	}{
+		{name: "no limit", limit: 0, working: 512 * mib, want: Settings{}},
+		{name: "room to double", limit: 4096 * mib, working: 512 * mib, want: Settings{Limit: 4096 * mib, GCPercent: 100}},
+		// 576 MiB live under 864 MiB leaves half the live heap to grow into
+		{name: "tight", limit: 864 * mib, working: 512 * mib, want: Settings{Limit: 864 * mib, GCPercent: 50}},
+		{name: "floor", limit: 600 * mib, working: 512 * mib, want: Settings{Limit: 600 * mib, GCPercent: MinGCPercent}},
+	}
+	for _, tt := range tests {
+		if got := Plan(tt.limit, tt.working); got != tt.want {
+			t.Errorf("%s: Plan() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReadGC(t *testing.T) {
+	start := ReadGC()
+	runtime.GC()
+	if got := ReadGC().Since(start); got.Cycles < 1 {
+		t.Errorf("Since() = %+v after a collection", got)
+	}
+}
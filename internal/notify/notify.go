@@ -0,0 +1,343 @@
+// Package notify posts templated chat messages about a run to Slack, Discord and
+// Telegram, and mails run summaries over SMTP
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"bloco-eth/internal/retry"
+)
+
+// Event names a moment of a run that can be notified
+type Event string
+
+const (
+	// EventStart is sent when generation starts
+	EventStart Event = "start"
+	// EventHalfway is sent once the run has made the attempts for a 50% chance of success
+	EventHalfway Event = "halfway"
+	// EventWallet is sent for every found wallet
+	EventWallet Event = "wallet"
+	// EventDone is sent when the run ends
+	EventDone Event = "done"
+)
+
+// defaultEvents are notified when the config lists none
+var defaultEvents = []Event{EventStart, EventHalfway, EventWallet}
+
+// defaultTemplates are the messages of events the config gives no template for
+var defaultTemplates = map[Event]string{
+	EventStart:   `bloco-eth on {{.Host}}: searching for {{.Wallets}} wallet(s) matching {{.Pattern}} (difficulty {{.Difficulty}})`,
+	EventHalfway: `bloco-eth on {{.Host}}: {{.Pattern}} passed 50% probability after {{.Attempts}} attempts ({{.Elapsed}}), {{.Found}}/{{.Wallets}} found`,
+	EventWallet:  `bloco-eth on {{.Host}}: found {{.Address}} ({{.Found}}/{{.Wallets}}) after {{.Attempts}} attempts ({{.Elapsed}})`,
+	EventDone:    `bloco-eth on {{.Host}}: finished {{.Pattern}} with {{.Found}}/{{.Wallets}} wallets after {{.Elapsed}}{{if .Error}}: {{.Error}}{{end}}`,
+}
+
+// requestTimeout bounds each post to a chat service
+const requestTimeout = 10 * time.Second
+
+// Message holds the fields templates can use. It never carries key material.
+type Message struct {
+	Event      Event
+	Host       string
+	Pattern    string
+	Difficulty string
+	Wallets    int
+	Found      int
+	Address    string
+	Attempts   string
+	Speed      string
+	Elapsed    string
+	Error      string
+}
+
+// Config is the JSON notification config file
+type Config struct {
+	Notifiers []NotifierConfig `json:"notifiers"`
+	// Events lists the events to notify (default: start, halfway and wallet)
+	Events []Event `json:"events,omitempty"`
+	// Templates replace the default text/template message of an event
+	Templates map[Event]string `json:"templates,omitempty"`
+}
+
+// NotifierConfig configures one chat backend. String values may reference
+// environment variables as ${NAME} so secrets stay out of the file.
+type NotifierConfig struct {
+	Type string `json:"type"`
+	// WebhookURL is the incoming webhook of a slack or discord notifier
+	WebhookURL string `json:"webhook_url,omitempty"`
+	// BotToken and ChatID address a telegram notifier
+	BotToken string `json:"bot_token,omitempty"`
+	ChatID   string `json:"chat_id,omitempty"`
+	// APIURL overrides the Telegram Bot API base URL
+	APIURL string `json:"api_url,omitempty"`
+
+	// An smtp notifier mails the final summary of a run from From to To, over
+	// TLS set to starttls (default), tls or none
+	SMTPHost   string   `json:"smtp_host,omitempty"`
+	SMTPPort   int      `json:"smtp_port,omitempty"`
+	TLS        string   `json:"tls,omitempty"`
+	Username   string   `json:"username,omitempty"`
+	Password   string   `json:"password,omitempty"`
+	From       string   `json:"from,omitempty"`
+	To         []string `json:"to,omitempty"`
+	AttachHTML bool     `json:"attach_html,omitempty"`
+}
+
+// backend posts a text message to a chat
+type backend interface {
+	name() string
+	post(ctx context.Context, client *http.Client, text string) error
+}
+
+// Notifier renders event messages and posts them to every configured chat
+// backend, and mails run summaries through its smtp backends
+type Notifier struct {
+	backends  []backend
+	mailers   []*smtpBackend
+	events    map[Event]bool
+	templates map[Event]*template.Template
+	client    *http.Client
+	retry     retry.Policy
+}
+
+// LoadConfig reads a notification config file
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid notification config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// New validates cfg, expands its ${NAME} references and parses its templates
+func New(cfg Config, client *http.Client) (*Notifier, error) {
+	if len(cfg.Notifiers) == 0 {
+		return nil, errors.New("notification config has no notifiers")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: requestTimeout}
+	}
+	n := &Notifier{events: make(map[Event]bool), templates: make(map[Event]*template.Template), client: client, retry: retry.DefaultNetworkPolicy}
+
+	for i, nc := range cfg.Notifiers {
+		nc, err := expandNotifier(nc)
+		if err == nil && nc.Type == "smtp" {
+			var m *smtpBackend
+			if m, err = newSMTPBackend(nc); err == nil {
+				n.mailers = append(n.mailers, m)
+			}
+		} else if err == nil {
+			var b backend
+			if b, err = newBackend(nc); err == nil {
+				n.backends = append(n.backends, b)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("notifier %d: %w", i+1, err)
+		}
+	}
+
+	events := cfg.Events
+	if len(events) == 0 {
+		events = defaultEvents
+	}
+	for _, event := range events {
+		if _, ok := defaultTemplates[event]; !ok {
+			return nil, fmt.Errorf("unknown notification event %q (use start, halfway, wallet or done)", event)
+		}
+		n.events[event] = true
+	}
+	for event, text := range defaultTemplates {
+		if custom, ok := cfg.Templates[event]; ok {
+			text = custom
+		}
+		tmpl, err := template.New(string(event)).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s template: %w", event, err)
+		}
+		n.templates[event] = tmpl
+	}
+	for event := range cfg.Templates {
+		if _, ok := defaultTemplates[event]; !ok {
+			return nil, fmt.Errorf("template for unknown notification event %q", event)
+		}
+	}
+	return n, nil
+}
+
+// SetRetryPolicy sets how failed deliveries are retried, per backend
+func (n *Notifier) SetRetryPolicy(policy retry.Policy) {
+	n.retry = policy
+}
+
+// Wants reports whether event is posted to a chat backend
+func (n *Notifier) Wants(event Event) bool {
+	return n != nil && len(n.backends) > 0 && n.events[event]
+}
+
+// WantsSummary reports whether an smtp notifier mails the summary of a run
+func (n *Notifier) WantsSummary() bool {
+	return n != nil && len(n.mailers) > 0
+}
+
+// SendSummary mails s through every smtp backend, returning the failures joined
+func (n *Notifier) SendSummary(ctx context.Context, s Summary) error {
+	if n == nil {
+		return nil
+	}
+	var errs []error
+	for _, m := range n.mailers {
+		if err := retry.Do(ctx, n.retry, func() error { return m.send(ctx, s) }, nil); err != nil {
+			errs = append(errs, fmt.Errorf("%s notification failed: %w", m.name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Send renders msg and posts it to every chat backend, returning the failures joined
+func (n *Notifier) Send(ctx context.Context, msg Message) error {
+	if !n.Wants(msg.Event) {
+		return nil
+	}
+	var text strings.Builder
+	if err := n.templates[msg.Event].Execute(&text, msg); err != nil {
+		return fmt.Errorf("render %s notification: %w", msg.Event, err)
+	}
+	var errs []error
+	for _, b := range n.backends {
+		if err := retry.Do(ctx, n.retry, func() error { return b.post(ctx, n.client, text.String()) }, nil); err != nil {
+			errs = append(errs, fmt.Errorf("%s notification failed: %w", b.name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// expandNotifier replaces the ${NAME} references of a notifier config with
+// environment variables, failing on unset ones
+func expandNotifier(nc NotifierConfig) (NotifierConfig, error) {
+	var missing []string
+	expand := func(value string) string {
+		return os.Expand(value, func(name string) string {
+			v, ok := os.LookupEnv(name)
+			if !ok {
+				missing = append(missing, name)
+			}
+			return v
+		})
+	}
+	for _, field := range []*string{&nc.WebhookURL, &nc.BotToken, &nc.ChatID, &nc.APIURL,
+		&nc.SMTPHost, &nc.Username, &nc.Password, &nc.From} {
+		*field = expand(*field)
+	}
+	nc.To = append([]string(nil), nc.To...)
+	for i := range nc.To {
+		nc.To[i] = expand(nc.To[i])
+	}
+	if len(missing) > 0 {
+		return nc, fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))
+	}
+	return nc, nil
+}
+
+// newBackend builds the chat backend of an expanded notifier config
+func newBackend(nc NotifierConfig) (backend, error) {
+	webhook, token, chat, api := nc.WebhookURL, nc.BotToken, nc.ChatID, nc.APIURL
+	switch nc.Type {
+	case "slack", "discord":
+		if !strings.HasPrefix(webhook, "https://") && !strings.HasPrefix(webhook, "http://") {
+			return nil, fmt.Errorf("%s notifier needs an http(s) webhook_url", nc.Type)
+		}
+		field := "text"
+		if nc.Type == "discord" {
+			field = "content"
+		}
+		return &webhookBackend{kind: nc.Type, url: webhook, field: field}, nil
+	case "telegram":
+		if token == "" || chat == "" {
+			return nil, errors.New("telegram notifier needs bot_token and chat_id")
+		}
+		if api == "" {
+			api = "https://api.telegram.org"
+		}
+		return &telegramBackend{url: strings.TrimSuffix(api, "/") + "/bot" + token + "/sendMessage", chatID: chat}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q (use slack, discord, telegram or smtp)", nc.Type)
+	}
+}
+
+// webhookBackend posts {field: text} to a Slack or Discord incoming webhook
+type webhookBackend struct {
+	kind  string
+	url   string
+	field string
+}
+
+func (b *webhookBackend) name() string { return b.kind }
+
+func (b *webhookBackend) post(ctx context.Context, client *http.Client, text string) error {
+	return postJSON(ctx, client, b.url, map[string]string{b.field: text})
+}
+
+// telegramBackend sends a message through the Telegram Bot API
+type telegramBackend struct {
+	url    string
+	chatID string
+}
+
+func (b *telegramBackend) name() string { return "telegram" }
+
+func (b *telegramBackend) post(ctx context.Context, client *http.Client, text string) error {
+	return postJSON(ctx, client, b.url, map[string]string{"chat_id": b.chatID, "text": text})
+}
+
+// postJSON posts body as JSON and fails on a non-2xx status; client errors other
+// than timeouts and rate limits are permanent. Errors never include the URL, which
+// holds the webhook secret or bot token.
+func postJSON(ctx context.Context, client *http.Client, endpoint string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return retry.Permanent(errors.New("invalid URL"))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+		if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			return retry.Permanent(err)
+		}
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
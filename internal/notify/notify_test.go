@@ -0,0 +1,169 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/retry"
+)
+
+// recorder is a chat service that records the path and JSON body of each post
+type recorder struct {
+	mu    sync.Mutex
+	posts []string
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var body map[string]string
+	_ = json.NewDecoder(req.Body).Decode(&body)
+	if req.URL.Path == "/fail" {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+		return
+	}
+	data, _ := json.Marshal(body)
+	r.mu.Lock()
+	r.posts = append(r.posts, req.URL.Path+" "+string(data))
+	r.mu.Unlock()
+}
+
+func TestNotifier_Send(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+	t.Setenv("TEST_BOT_TOKEN", "123:abc")
+
+	n, err := New(Config{
+		Notifiers: []NotifierConfig{
+			{Type: "slack", WebhookURL: srv.URL + "/slack"},
+			{Type: "discord", WebhookURL: srv.URL + "/discord"},
+			{Type: "telegram", BotToken: "${TEST_BOT_TOKEN}", ChatID: "42", APIURL: srv.URL},
+		},
+		Templates: map[Event]string{EventWallet: "found {{.Address}} on {{.Host}}"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !n.Wants(EventStart) || !n.Wants(EventHalfway) || n.Wants(EventDone) {
+		t.Error("default events should be start, halfway and wallet")
+	}
+
+	if err := n.Send(context.Background(), Message{Event: EventWallet, Host: "rig1", Address: "0xabc"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	// Events not listed are not sent
+	if err := n.Send(context.Background(), Message{Event: EventDone}); err != nil {
+		t.Fatalf("Send(done) error = %v", err)
+	}
+	want := []string{
+		`/slack {"text":"found 0xabc on rig1"}`,
+		`/discord {"content":"found 0xabc on rig1"}`,
+		`/bot123:abc/sendMessage {"chat_id":"42","text":"found 0xabc on rig1"}`,
+	}
+	if strings.Join(rec.posts, "\n") != strings.Join(want, "\n") {
+		t.Errorf("posts =\n%s\nwant\n%s", strings.Join(rec.posts, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNotifier_SendFailure(t *testing.T) {
+	srv := httptest.NewServer(&recorder{})
+	defer srv.Close()
+	n, err := New(Config{Notifiers: []NotifierConfig{{Type: "slack", WebhookURL: srv.URL + "/fail"}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = n.Send(context.Background(), Message{Event: EventStart})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403") || strings.Contains(err.Error(), srv.URL) {
+		t.Errorf("Send() error = %v, want the status without the webhook URL", err)
+	}
+}
+
+func TestNotifier_SendRetry(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		calls[req.URL.Path]++
+		n := calls[req.URL.Path]
+		mu.Unlock()
+		switch {
+		case req.URL.Path == "/forbidden":
+			http.Error(w, "invalid_token", http.StatusForbidden)
+		case n < 3:
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	n, err := New(Config{Notifiers: []NotifierConfig{{Type: "slack", WebhookURL: srv.URL + "/flaky"}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.SetRetryPolicy(retry.Policy{Attempts: 3, InitialDelay: retry.Duration(time.Millisecond), Multiplier: 1})
+	if err := n.Send(context.Background(), Message{Event: EventStart}); err != nil || calls["/flaky"] != 3 {
+		t.Errorf("Send() = %v after %d posts, want success on the third", err, calls["/flaky"])
+	}
+
+	n, err = New(Config{Notifiers: []NotifierConfig{{Type: "slack", WebhookURL: srv.URL + "/forbidden"}}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n.SetRetryPolicy(retry.Policy{Attempts: 3, InitialDelay: retry.Duration(time.Millisecond), Multiplier: 1})
+	if err := n.Send(context.Background(), Message{Event: EventStart}); err == nil || calls["/forbidden"] != 1 {
+		t.Errorf("Send() = %v after %d posts, want a 403 to fail without retrying", err, calls["/forbidden"])
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	slack := []NotifierConfig{{Type: "slack", WebhookURL: "https://hooks.example/x"}}
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"no notifiers", Config{}, "no notifiers"},
+		{"unknown type", Config{Notifiers: []NotifierConfig{{Type: "pager"}}}, "unknown notifier type"},
+		{"missing webhook", Config{Notifiers: []NotifierConfig{{Type: "discord"}}}, "webhook_url"},
+		{"missing chat", Config{Notifiers: []NotifierConfig{{Type: "telegram", BotToken: "t"}}}, "chat_id"},
+		{"unset variable", Config{Notifiers: []NotifierConfig{{Type: "slack", WebhookURL: "${BLOCO_TEST_UNSET_WEBHOOK}"}}}, "BLOCO_TEST_UNSET_WEBHOOK"},
+		{"unknown event", Config{Notifiers: slack, Events: []Event{"50%"}}, "unknown notification event"},
+		{"bad template", Config{Notifiers: slack, Templates: map[Event]string{EventStart: "{{.Nope"}}, "start template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.cfg, nil); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notify.json")
+	data := `{"notifiers":[{"type":"telegram","bot_token":"${TOKEN}","chat_id":"1"}],"events":["wallet","done"]}`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	// Variables are expanded by New, not when loading
+	if cfg.Notifiers[0].BotToken != "${TOKEN}" || len(cfg.Events) != 2 {
+		t.Errorf("LoadConfig() = %+v", cfg)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"notifier":[]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil {
+		t.Error("LoadConfig() accepted an unknown field")
+	}
+}
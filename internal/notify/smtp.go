@@ -0,0 +1,216 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"bloco-eth/internal/retry"
+)
+
+// smtpTimeout bounds a whole mail delivery
+const smtpTimeout = 30 * time.Second
+
+// smtpDefaultPorts are the submission ports of each TLS mode
+var smtpDefaultPorts = map[string]int{"starttls": 587, "tls": 465, "none": 25}
+
+// smtpBackend mails the final Summary of a run
+type smtpBackend struct {
+	host       string
+	port       int
+	mode       string
+	username   string
+	password   string
+	from       string
+	to         []string
+	attachHTML bool
+}
+
+// newSMTPBackend validates an smtp notifier whose fields are already expanded
+func newSMTPBackend(nc NotifierConfig) (*smtpBackend, error) {
+	b := &smtpBackend{
+		host:       nc.SMTPHost,
+		port:       nc.SMTPPort,
+		mode:       nc.TLS,
+		username:   nc.Username,
+		password:   nc.Password,
+		from:       nc.From,
+		to:         nc.To,
+		attachHTML: nc.AttachHTML,
+	}
+	if b.host == "" {
+		return nil, errors.New("smtp notifier needs smtp_host")
+	}
+	if b.mode == "" {
+		b.mode = "starttls"
+	}
+	defaultPort, ok := smtpDefaultPorts[b.mode]
+	if !ok {
+		return nil, fmt.Errorf("unknown smtp tls mode %q (use starttls, tls or none)", b.mode)
+	}
+	if b.port == 0 {
+		b.port = defaultPort
+	}
+	if _, err := mail.ParseAddress(b.from); err != nil {
+		return nil, fmt.Errorf("smtp notifier needs a valid from address: %w", err)
+	}
+	if len(b.to) == 0 {
+		return nil, errors.New("smtp notifier needs at least one to address")
+	}
+	for _, to := range b.to {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return nil, fmt.Errorf("invalid to address %q: %w", to, err)
+		}
+	}
+	return b, nil
+}
+
+func (b *smtpBackend) name() string { return "smtp" }
+
+// send delivers the summary, with its HTML report attached when enabled
+func (b *smtpBackend) send(ctx context.Context, s Summary) error {
+	message, err := b.compose(s)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+
+	deadline := time.Now().Add(smtpTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	addr := net.JoinHostPort(b.host, strconv.Itoa(b.port))
+	dialer := &net.Dialer{Deadline: deadline}
+	var conn net.Conn
+	if b.mode == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: b.host})
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, b.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if b.mode == "starttls" {
+		if err := client.StartTLS(&tls.Config{ServerName: b.host}); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+	if b.username != "" {
+		// PlainAuth refuses to send the password without TLS, except to localhost
+		if err := client.Auth(smtp.PlainAuth("", b.username, b.password, b.host)); err != nil {
+			return retry.Permanent(fmt.Errorf("authentication failed: %w", err))
+		}
+	}
+	if err := client.Mail(address(b.from)); err != nil {
+		return smtpRetryable(err)
+	}
+	for _, to := range b.to {
+		if err := client.Rcpt(address(to)); err != nil {
+			return smtpRetryable(err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return smtpRetryable(err)
+	}
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return smtpRetryable(err)
+	}
+	// The server has accepted the message, so sending it again would mail it twice
+	return retry.Permanent(client.Quit())
+}
+
+// smtpRetryable marks permanent SMTP replies (5xx) so they are not retried
+func smtpRetryable(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return retry.Permanent(err)
+	}
+	return err
+}
+
+// compose builds the MIME message: the text summary, plus the HTML report as a
+// multipart/mixed attachment when enabled
+func (b *smtpBackend) compose(s Summary) ([]byte, error) {
+	var msg bytes.Buffer
+	header := func(name, value string) { fmt.Fprintf(&msg, "%s: %s\r\n", name, value) }
+	header("From", b.from)
+	header("To", strings.Join(b.to, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", s.Subject()))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", randomToken(), b.host))
+	header("MIME-Version", "1.0")
+
+	if !b.attachHTML {
+		header("Content-Type", `text/plain; charset="utf-8"`)
+		header("Content-Transfer-Encoding", "base64")
+		msg.WriteString("\r\n")
+		writeBase64(&msg, []byte(s.Text()))
+		return msg.Bytes(), nil
+	}
+
+	report, err := s.HTML()
+	if err != nil {
+		return nil, err
+	}
+	boundary := "bloco-" + randomToken()
+	header("Content-Type", fmt.Sprintf(`multipart/mixed; boundary="%s"`, boundary))
+	msg.WriteString("\r\n")
+	fmt.Fprintf(&msg, "--%s\r\n", boundary)
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\nContent-Transfer-Encoding: base64\r\n\r\n")
+	writeBase64(&msg, []byte(s.Text()))
+	fmt.Fprintf(&msg, "--%s\r\n", boundary)
+	msg.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\nContent-Transfer-Encoding: base64\r\n")
+	msg.WriteString("Content-Disposition: attachment; filename=\"bloco-report.html\"\r\n\r\n")
+	writeBase64(&msg, []byte(report))
+	fmt.Fprintf(&msg, "--%s--\r\n", boundary)
+	return msg.Bytes(), nil
+}
+
+// address returns the bare address of a "Name <addr>" string for the SMTP envelope
+func address(s string) string {
+	if a, err := mail.ParseAddress(s); err == nil {
+		return a.Address
+	}
+	return s
+}
+
+// writeBase64 writes data base64-encoded in 76-character lines
+func writeBase64(w *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	w.WriteString(encoded + "\r\n")
+}
+
+// randomToken returns 16 random hex characters for message IDs and boundaries
+func randomToken() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,190 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpSession is what the fake server received
+type smtpSession struct {
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP accepts one plain-text session offering AUTH PLAIN and sends what it
+// received to sessions
+func fakeSMTP(t *testing.T, sessions chan<- smtpSession) int {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(line string) { fmt.Fprintf(conn, "%s\r\n", line) }
+		var s smtpSession
+		reply("220 test ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimSpace(line)
+			verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch verb {
+			case "EHLO":
+				reply("250-test")
+				reply("250 AUTH PLAIN")
+			case "AUTH":
+				s.auth = line
+				reply("235 ok")
+			case "MAIL":
+				s.from = line
+				reply("250 ok")
+			case "RCPT":
+				s.to = append(s.to, line)
+				reply("250 ok")
+			case "DATA":
+				reply("354 go ahead")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				s.data = data.String()
+				reply("250 queued")
+			case "QUIT":
+				reply("221 bye")
+				sessions <- s
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+func testSummary() Summary {
+	return Summary{
+		Host: "rig1", Pattern: "abcd", Network: "ethereum", Difficulty: "65,536",
+		Wallets: 2, Found: 1, Attempts: "90,000", Speed: "20,000",
+		Started: time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), Duration: "4.5s",
+		Error: "timeout after 4s",
+		Addresses: []SummaryAddress{
+			{Address: "0xabcd000000000000000000000000000000000001", Label: "<treasury>", Attempts: "60,000"},
+		},
+	}
+}
+
+func TestSMTP_SendSummary(t *testing.T) {
+	sessions := make(chan smtpSession, 1)
+	port := fakeSMTP(t, sessions)
+	t.Setenv("TEST_SMTP_PASSWORD", "hunter2")
+
+	n, err := New(Config{Notifiers: []NotifierConfig{{
+		Type: "smtp", SMTPHost: "127.0.0.1", SMTPPort: port, TLS: "none",
+		Username: "ops", Password: "${TEST_SMTP_PASSWORD}",
+		From: "Bloco <bloco@example.com>", To: []string{"ops@example.com"}, AttachHTML: true,
+	}}}, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !n.WantsSummary() || n.Wants(EventStart) {
+		t.Error("an smtp notifier mails summaries and posts no chat events")
+	}
+	if err := n.SendSummary(context.Background(), testSummary()); err != nil {
+		t.Fatalf("SendSummary() error = %v", err)
+	}
+
+	s := <-sessions
+	wantAuth := "AUTH PLAIN " + base64.StdEncoding.EncodeToString([]byte("\x00ops\x00hunter2"))
+	if s.auth != wantAuth || s.from != "MAIL FROM:<bloco@example.com>" || len(s.to) != 1 || s.to[0] != "RCPT TO:<ops@example.com>" {
+		t.Errorf("session = %+v", s)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(s.data))
+	if err != nil {
+		t.Fatalf("ReadMessage() error = %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "bloco-eth batch failed on rig1: 1/2 wallets for abcd" {
+		t.Errorf("Subject = %q", subject)
+	}
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := multipart.NewReader(msg.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(base64.NewDecoder(base64.StdEncoding, part))
+		bodies = append(bodies, part.Header.Get("Content-Type")+"\n"+string(data))
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("got %d parts, want the text summary and the HTML report", len(bodies))
+	}
+	if !strings.Contains(bodies[0], "Wallets:    1/2 found") || !strings.Contains(bodies[0], "Error:      timeout after 4s") {
+		t.Errorf("text part =\n%s", bodies[0])
+	}
+	if !strings.HasPrefix(bodies[1], "text/html") || !strings.Contains(bodies[1], "<code>0xabcd000000000000000000000000000000000001</code>") ||
+		!strings.Contains(bodies[1], "&lt;treasury&gt;") {
+		t.Errorf("HTML part =\n%s", bodies[1])
+	}
+}
+
+func TestSMTP_Invalid(t *testing.T) {
+	base := NotifierConfig{Type: "smtp", SMTPHost: "mail.example.com", From: "bloco@example.com", To: []string{"ops@example.com"}}
+	tests := []struct {
+		name   string
+		modify func(*NotifierConfig)
+		want   string
+	}{
+		{"no host", func(nc *NotifierConfig) { nc.SMTPHost = "" }, "smtp_host"},
+		{"bad tls", func(nc *NotifierConfig) { nc.TLS = "ssl" }, "tls mode"},
+		{"bad from", func(nc *NotifierConfig) { nc.From = "not an address" }, "from address"},
+		{"no to", func(nc *NotifierConfig) { nc.To = nil }, "to address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nc := base
+			tt.modify(&nc)
+			if _, err := New(Config{Notifiers: []NotifierConfig{nc}}, nil); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	b, err := newSMTPBackend(base)
+	if err != nil || b.port != 587 || b.mode != "starttls" {
+		t.Errorf("defaults = %+v, %v; want starttls on 587", b, err)
+	}
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// Summary is the final report of a run mailed by smtp notifiers. Like Message it
+// holds addresses only, never key material.
+type Summary struct {
+	Host       string
+	Pattern    string
+	Network    string
+	Difficulty string
+	Wallets    int
+	Found      int
+	Attempts   string
+	Speed      string
+	Started    time.Time
+	Duration   string
+	Error      string
+	Addresses  []SummaryAddress
+}
+
+// SummaryAddress is one found wallet of a Summary
+type SummaryAddress struct {
+	Address  string
+	Label    string
+	Attempts string
+}
+
+// Status is "completed" for a run without error and "failed" otherwise
+func (s Summary) Status() string {
+	if s.Error != "" {
+		return "failed"
+	}
+	return "completed"
+}
+
+// Subject is the mail subject of the summary
+func (s Summary) Subject() string {
+	return fmt.Sprintf("bloco-eth batch %s on %s: %d/%d wallets for %s", s.Status(), s.Host, s.Found, s.Wallets, s.Pattern)
+}
+
+// Text renders the summary as the plain-text mail body
+func (s Summary) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "bloco-eth batch %s on %s\n\n", s.Status(), s.Host)
+	fmt.Fprintf(&b, "Pattern:    %s (%s)\n", s.Pattern, s.Network)
+	fmt.Fprintf(&b, "Difficulty: %s\n", s.Difficulty)
+	fmt.Fprintf(&b, "Wallets:    %d/%d found\n", s.Found, s.Wallets)
+	fmt.Fprintf(&b, "Attempts:   %s (%s addr/s)\n", s.Attempts, s.Speed)
+	fmt.Fprintf(&b, "Started:    %s\n", s.Started.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "Duration:   %s\n", s.Duration)
+	if s.Error != "" {
+		fmt.Fprintf(&b, "Error:      %s\n", s.Error)
+	}
+	if len(s.Addresses) > 0 {
+		b.WriteString("\nAddresses:\n")
+		for _, a := range s.Addresses {
+			fmt.Fprintf(&b, "  %s  %s attempts", a.Address, a.Attempts)
+			if a.Label != "" {
+				fmt.Fprintf(&b, "  %s", a.Label)
+			}
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\nPrivate keys are never included in this report.\n")
+	return b.String()
+}
+
+// summaryHTML is the HTML report attached with attach_html
+var summaryHTML = template.Must(template.New("summary").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><title>{{.Subject}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: .25rem 1rem .25rem 0; }
+code { font-family: monospace; }
+.failed { color: #b00020; }
+</style></head>
+<body>
+<h1>bloco-eth batch <span class="{{.Status}}">{{.Status}}</span></h1>
+<table>
+<tr><th>Host</th><td>{{.Host}}</td></tr>
+<tr><th>Pattern</th><td><code>{{.Pattern}}</code> ({{.Network}})</td></tr>
+<tr><th>Difficulty</th><td>{{.Difficulty}}</td></tr>
+<tr><th>Wallets</th><td>{{.Found}}/{{.Wallets}} found</td></tr>
+<tr><th>Attempts</th><td>{{.Attempts}} ({{.Speed}} addr/s)</td></tr>
+<tr><th>Started</th><td>{{.Started.UTC.Format "2006-01-02T15:04:05Z07:00"}}</td></tr>
+<tr><th>Duration</th><td>{{.Duration}}</td></tr>
+{{- if .Error}}
+<tr><th>Error</th><td class="failed">{{.Error}}</td></tr>
+{{- end}}
+</table>
+{{- if .Addresses}}
+<h2>Addresses</h2>
+<table>
+<tr><th>Address</th><th>Attempts</th><th>Label</th></tr>
+{{- range .Addresses}}
+<tr><td><code>{{.Address}}</code></td><td>{{.Attempts}}</td><td>{{.Label}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<p>Private keys are never included in this report.</p>
+</body>
+</html>
+`))
+
+// HTML renders the summary as a standalone HTML report
+func (s Summary) HTML() (string, error) {
+	var b strings.Builder
+	if err := summaryHTML.Execute(&b, s); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,225 @@
+package paperwallet
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"bloco-eth/internal/pdf"
+	"bloco-eth/internal/qr"
+)
+
+// Wallet is what a paper wallet shows. Key is the private key as the user asked to
+// see it; wallets without one get no private section.
+type Wallet struct {
+	Address   string
+	Key       string
+	Mnemonic  string
+	Network   string
+	Label     string
+	CreatedAt time.Time
+}
+
+const margin = 36
+
+// Write renders one page per wallet. Each page is three panels divided by fold
+// lines: the address, the private key and recovery phrase, and a patterned cover.
+func Write(w io.Writer, t Template, wallets []Wallet) error {
+	if len(wallets) == 0 {
+		return fmt.Errorf("no wallets to print")
+	}
+	l, err := t.compile()
+	if err != nil {
+		return err
+	}
+	doc := pdf.New(l.width, l.height)
+	var logo *pdf.Image
+	if l.logo != nil {
+		if logo, err = doc.AddImage(l.logo); err != nil {
+			return fmt.Errorf("logo: %w", err)
+		}
+	}
+	for i, wallet := range wallets {
+		page := doc.AddPage()
+		panel := l.height / 3
+		f := fields(wallet, i+1, len(wallets))
+		if err := l.drawAddress(page, logo, wallet, f, 2*panel, panel); err != nil {
+			return err
+		}
+		if err := l.drawPrivate(page, wallet, panel, panel); err != nil {
+			return err
+		}
+		l.drawCover(page, 0, panel)
+		l.drawFolds(page, panel)
+	}
+	_, err = doc.WriteTo(w)
+	return err
+}
+
+// drawAddress draws the panel to share: branding, the address and its QR code
+func (l *layout) drawAddress(page *pdf.Page, logo *pdf.Image, w Wallet, f Fields, bottom, height float64) error {
+	top := bottom + height
+	page.SetFillColor(l.accent[0], l.accent[1], l.accent[2])
+	page.Rect(0, top-56, l.width, 56)
+	page.SetFillColor(1, 1, 1)
+	page.Text(margin, top-28, pdf.HelveticaBold, 20, execute(l.title, f))
+	page.Text(margin, top-45, pdf.Helvetica, 10, execute(l.subtitle, f))
+	if logo != nil {
+		pw, ph := logo.Size()
+		logoHeight := 40.0
+		logoWidth := logoHeight * float64(pw) / float64(ph)
+		page.Image(logo, l.width-margin-logoWidth, top-48, logoWidth, logoHeight)
+	}
+
+	if err := drawQR(page, w.Address, margin, bottom+24, 140); err != nil {
+		return err
+	}
+	x := float64(margin + 140 + 20)
+	y := top - 84
+	page.SetFillColor(l.accent[0], l.accent[1], l.accent[2])
+	page.Text(x, y, pdf.HelveticaBold, 9, "ADDRESS")
+	page.SetFillColor(0, 0, 0)
+	y = drawWrapped(page, x, y-16, l.width-margin-x, 11, w.Address)
+	y -= 8
+	for _, line := range [][2]string{{"Label", w.Label}, {"Network", w.Network}, {"Created", f.Date}} {
+		if line[1] == "" {
+			continue
+		}
+		page.Text(x, y, pdf.HelveticaBold, 9, line[0])
+		page.Text(x+50, y, pdf.Helvetica, 9, line[1])
+		y -= 14
+	}
+	page.SetFillColor(0.4, 0.4, 0.4)
+	page.Text(x, y-6, pdf.Helvetica, 8, "Share this side to receive funds.")
+	page.Text(margin, bottom+10, pdf.Helvetica, 8, execute(l.footer, f))
+	return nil
+}
+
+// drawPrivate draws the panel the cover hides: the key, its QR code and the
+// numbered recovery phrase
+func (l *layout) drawPrivate(page *pdf.Page, w Wallet, bottom, height float64) error {
+	top := bottom + height
+	page.SetFillColor(0.7, 0.1, 0.1)
+	page.Text(margin, top-30, pdf.HelveticaBold, 12, "PRIVATE - keep this section secret")
+	if w.Key == "" {
+		page.SetFillColor(0, 0, 0)
+		page.Text(margin, top-50, pdf.Helvetica, 10, "This wallet's key is not printed.")
+		return nil
+	}
+	if err := drawQR(page, w.Key, margin, bottom+24, 130); err != nil {
+		return err
+	}
+	x := float64(margin + 130 + 20)
+	y := top - 56
+	page.Text(x, y, pdf.HelveticaBold, 9, "PRIVATE KEY")
+	page.SetFillColor(0, 0, 0)
+	y = drawWrapped(page, x, y-14, l.width-margin-x, 9, w.Key)
+	if w.Mnemonic == "" {
+		return nil
+	}
+	y -= 12
+	page.SetFillColor(0.7, 0.1, 0.1)
+	page.Text(x, y, pdf.HelveticaBold, 9, "RECOVERY PHRASE")
+	page.SetFillColor(0, 0, 0)
+	words := strings.Fields(w.Mnemonic)
+	rows := (len(words) + 2) / 3
+	columnWidth := (l.width - margin - x) / 3
+	for i, word := range words {
+		page.Text(x+float64(i/rows)*columnWidth, y-14-float64(i%rows)*12, pdf.Courier, 9, fmt.Sprintf("%2d. %s", i+1, word))
+	}
+	return nil
+}
+
+// drawCover draws the panel that folds over the private one: a hatch dense enough
+// to keep the key from showing through the paper, under the instructions
+func (l *layout) drawCover(page *pdf.Page, bottom, height float64) {
+	top := bottom + height
+	page.SetStrokeColor(0.75, 0.75, 0.75)
+	page.SetLineWidth(0.8)
+	for _, slope := range []float64{1, -1} {
+		for c := -height; c < l.width+height; c += 5 {
+			// The line x = c + slope*(y-bottom), kept to 0 <= x <= width
+			lo, hi := bottom, top
+			for _, edge := range []float64{0, l.width} {
+				y := bottom + (edge-c)/slope
+				if (slope > 0) == (edge == 0) {
+					lo = max(lo, y)
+				} else {
+					hi = min(hi, y)
+				}
+			}
+			if lo < hi {
+				page.Line(c+slope*(lo-bottom), lo, c+slope*(hi-bottom), hi)
+			}
+		}
+	}
+
+	boxHeight := 44 + 13*float64(len(l.instructions))
+	boxBottom := bottom + (height-boxHeight)/2
+	page.SetFillColor(1, 1, 1)
+	page.Rect(margin, boxBottom, l.width-2*margin, boxHeight)
+	page.SetStrokeColor(l.accent[0], l.accent[1], l.accent[2])
+	page.SetLineWidth(1.5)
+	page.StrokeRect(margin, boxBottom, l.width-2*margin, boxHeight)
+	page.SetFillColor(l.accent[0], l.accent[1], l.accent[2])
+	page.Text(margin+16, boxBottom+boxHeight-24, pdf.HelveticaBold, 14, "PRIVATE - fold this cover over the key")
+	page.SetFillColor(0, 0, 0)
+	for i, line := range l.instructions {
+		page.Text(margin+16, boxBottom+boxHeight-44-13*float64(i), pdf.Helvetica, 9, line)
+	}
+}
+
+// drawFolds draws the dashed lines to fold along
+func (l *layout) drawFolds(page *pdf.Page, panel float64) {
+	page.SetStrokeColor(0.5, 0.5, 0.5)
+	page.SetLineWidth(0.5)
+	page.SetDash(4, 3)
+	for _, y := range []float64{panel, 2 * panel} {
+		page.Line(0, y, l.width, y)
+	}
+	page.SetDash(0, 0)
+	page.SetFillColor(0.5, 0.5, 0.5)
+	page.Text(l.width-margin-24, 2*panel+3, pdf.Helvetica, 6, "fold")
+	page.Text(l.width-margin-24, panel+3, pdf.Helvetica, 6, "fold")
+}
+
+// drawQR draws the QR code of text as a size point square, quiet zone included
+func drawQR(page *pdf.Page, text string, x, y, size float64) error {
+	code, err := qr.Encode(text)
+	if err != nil {
+		return err
+	}
+	module := size / float64(code.Size+2*qr.QuietZone)
+	left, top := x+module*qr.QuietZone, y+size-module*qr.QuietZone
+	page.SetFillColor(0, 0, 0)
+	for row := 0; row < code.Size; row++ {
+		// One rectangle per run of dark modules
+		for col := 0; col < code.Size; {
+			if !code.Dark(col, row) {
+				col++
+				continue
+			}
+			run := col
+			for run < code.Size && code.Dark(run, row) {
+				run++
+			}
+			page.Rect(left+float64(col)*module, top-float64(row+1)*module, float64(run-col)*module, module)
+			col = run
+		}
+	}
+	return nil
+}
+
+// drawWrapped draws text in Courier, broken to fit width, and returns the
+// baseline below it
+func drawWrapped(page *pdf.Page, x, y, width, size float64, text string) float64 {
+	perLine := max(int(width/(size*pdf.CourierWidth)), 1)
+	for len(text) > 0 {
+		line := text[:min(perLine, len(text))]
+		text = text[len(line):]
+		page.Text(x, y, pdf.Courier, size, line)
+		y -= size + 3
+	}
+	return y
+}
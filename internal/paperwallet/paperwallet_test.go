@@ -0,0 +1,123 @@
+package paperwallet
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// pageContents returns the decompressed content streams of a PDF
+func pageContents(t *testing.T, data []byte) []string {
+	t.Helper()
+	var contents []string
+	streams := regexp.MustCompile(`<< /Filter /FlateDecode /Length (\d+) >>\nstream\n`)
+	for _, match := range streams.FindAllSubmatchIndex(data, -1) {
+		length, _ := strconv.Atoi(string(data[match[2]:match[3]]))
+		zr, err := zlib.NewReader(bytes.NewReader(data[match[1] : match[1]+length]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(zr)
+		contents = append(contents, string(content))
+	}
+	return contents
+}
+
+func TestWrite(t *testing.T) {
+	wallets := []Wallet{
+		{
+			Address:   "0xbeef7e5f1c6a0c3d1b0d0f6a6aa0e7b6e2c1f0a9",
+			Key:       "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+			Mnemonic:  "abandon ability able about above absent absorb abstract absurd abuse access accident",
+			Network:   "ethereum",
+			Label:     "cold-1",
+			CreatedAt: time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC),
+		},
+		{Address: "0xbeef000000000000000000000000000000000001", Network: "ethereum"},
+	}
+	var out bytes.Buffer
+	if err := Write(&out, DefaultTemplate(), wallets); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(out.Bytes(), []byte("%PDF-1.4")) || !bytes.Contains(out.Bytes(), []byte("/Count 2")) {
+		t.Fatal("expected a PDF of two pages")
+	}
+
+	pages := pageContents(t, out.Bytes())
+	if len(pages) != 2 {
+		t.Fatalf("got %d content streams, want 2", len(pages))
+	}
+	for _, want := range []string{
+		"(Paper Wallet) Tj", "(ethereum | 2026-03-04) Tj", "(Wallet 1 of 2) Tj", "(cold-1) Tj",
+		"(" + wallets[0].Address + ") Tj", "(" + wallets[0].Key + ") Tj",
+		"( 1. abandon) Tj", "(12. accident) Tj", "[4 3] 0 d",
+	} {
+		if !strings.Contains(pages[0], want) {
+			t.Errorf("first page lacks %q", want)
+		}
+	}
+	if strings.Contains(pages[1], "PRIVATE KEY") || !strings.Contains(pages[1], "(This wallet's key is not printed.) Tj") {
+		t.Error("a wallet without a key should get no private key section")
+	}
+
+	if err := Write(&out, DefaultTemplate(), nil); err == nil {
+		t.Error("expected an error for no wallets")
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	logo, err := os.Create(filepath.Join(dir, "logo.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(logo, image.NewGray(image.Rect(0, 0, 4, 2)))
+	logo.Close()
+
+	path := filepath.Join(dir, "brand.json")
+	os.WriteFile(path, []byte(`{"title": "Acme {{.Label}}", "accent_color": "#ff8800", "page_size": "letter", "logo": "logo.png", "instructions": ["Keep it safe."]}`), 0644)
+	tmpl, err := LoadTemplate(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Title != "Acme {{.Label}}" || tmpl.Footer != DefaultTemplate().Footer || tmpl.Logo != filepath.Join(dir, "logo.png") {
+		t.Errorf("LoadTemplate() = %+v", tmpl)
+	}
+	var out bytes.Buffer
+	if err := Write(&out, tmpl, []Wallet{{Address: "0xbeef000000000000000000000000000000000001", Key: "0x01", Label: "vault"}}); err != nil {
+		t.Fatal(err)
+	}
+	content := pageContents(t, out.Bytes())[0]
+	for _, want := range []string{"(Acme vault) Tj", "1 0.53 0 rg", "(Keep it safe.) Tj", "/Im1 Do"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("branded page lacks %q", want)
+		}
+	}
+	if !bytes.Contains(out.Bytes(), []byte("/MediaBox [0 0 612 792]")) {
+		t.Error("expected letter pages")
+	}
+
+	for name, body := range map[string]string{
+		"syntax":   `{"title": "{{.Label"}`,
+		"field":    `{"footer": "{{.Balance}}"}`,
+		"color":    `{"accent_color": "orange"}`,
+		"page":     `{"page_size": "a3"}`,
+		"logo":     `{"logo": "missing.png"}`,
+		"unknown":  `{"colour": "#ffffff"}`,
+		"not json": `title: x`,
+	} {
+		os.WriteFile(path, []byte(body), 0644)
+		if _, err := LoadTemplate(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package paperwallet lays out wallets as printable PDF paper wallets: an address
+// panel to share, a private panel with the key and recovery phrase, and a cover
+// that folds over the private panel.
+package paperwallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	_ "image/jpeg" // logos may be JPEG
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"bloco-eth/internal/pdf"
+)
+
+// Template brands the paper wallets. Title, Subtitle and Footer are text/template
+// strings over Fields.
+type Template struct {
+	Title        string   `json:"title"`
+	Subtitle     string   `json:"subtitle"`
+	Footer       string   `json:"footer"`
+	Instructions []string `json:"instructions"`
+	AccentColor  string   `json:"accent_color"` // #rrggbb
+	PageSize     string   `json:"page_size"`    // a4 or letter
+	Logo         string   `json:"logo"`         // PNG or JPEG, relative to the template file
+}
+
+// Fields are the values Title, Subtitle and Footer can use
+type Fields struct {
+	Label, Address, Network, Date string
+	Index, Count                  int
+}
+
+// DefaultTemplate returns the unbranded layout
+func DefaultTemplate() Template {
+	return Template{
+		Title:    "Paper Wallet",
+		Subtitle: "{{.Network}} | {{.Date}}",
+		Footer:   "Wallet {{.Index}} of {{.Count}}",
+		Instructions: []string{
+			"Fold the cover up over the private section, then fold the address panel back.",
+			"Anyone who reads the private key or recovery phrase controls the funds.",
+			"Keep this paper dry and out of sight; never photograph or scan it.",
+		},
+		AccentColor: "#1f4e79",
+		PageSize:    "a4",
+	}
+}
+
+// LoadTemplate reads a JSON template, taking unset fields from DefaultTemplate
+func LoadTemplate(path string) (Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Template{}, err
+	}
+	t := DefaultTemplate()
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var custom Template
+	if err := decoder.Decode(&custom); err != nil {
+		return Template{}, fmt.Errorf("invalid template %s: %w", path, err)
+	}
+	for _, field := range []struct{ set, from *string }{
+		{&t.Title, &custom.Title}, {&t.Subtitle, &custom.Subtitle}, {&t.Footer, &custom.Footer},
+		{&t.AccentColor, &custom.AccentColor}, {&t.PageSize, &custom.PageSize},
+	} {
+		if *field.from != "" {
+			*field.set = *field.from
+		}
+	}
+	if custom.Instructions != nil {
+		t.Instructions = custom.Instructions
+	}
+	if custom.Logo != "" {
+		t.Logo = custom.Logo
+		if !filepath.IsAbs(t.Logo) {
+			t.Logo = filepath.Join(filepath.Dir(path), t.Logo)
+		}
+	}
+	if _, err := t.compile(); err != nil {
+		return Template{}, fmt.Errorf("invalid template %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// layout is a Template ready to draw
+type layout struct {
+	title, subtitle, footer *template.Template
+	instructions            []string
+	accent                  [3]float64
+	width, height           float64
+	logo                    image.Image
+}
+
+func (t Template) compile() (*layout, error) {
+	l := &layout{instructions: t.Instructions}
+	var err error
+	for _, text := range []struct {
+		name, text string
+		into       **template.Template
+	}{{"title", t.Title, &l.title}, {"subtitle", t.Subtitle, &l.subtitle}, {"footer", t.Footer, &l.footer}} {
+		if *text.into, err = template.New(text.name).Parse(text.text); err != nil {
+			return nil, err
+		}
+		// Unknown fields only show up when the template runs
+		if err := (*text.into).Execute(&bytes.Buffer{}, Fields{}); err != nil {
+			return nil, err
+		}
+	}
+
+	hex := strings.TrimPrefix(t.AccentColor, "#")
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 6 || err != nil {
+		return nil, fmt.Errorf("accent_color %q is not #rrggbb", t.AccentColor)
+	}
+	l.accent = [3]float64{float64(rgb>>16) / 255, float64(rgb>>8&0xff) / 255, float64(rgb&0xff) / 255}
+
+	switch strings.ToLower(t.PageSize) {
+	case "a4":
+		l.width, l.height = pdf.A4Width, pdf.A4Height
+	case "letter":
+		l.width, l.height = pdf.LetterWidth, pdf.LetterHeight
+	default:
+		return nil, fmt.Errorf("page_size %q is not a4 or letter", t.PageSize)
+	}
+
+	if t.Logo != "" {
+		file, err := os.Open(t.Logo)
+		if err != nil {
+			return nil, fmt.Errorf("logo: %w", err)
+		}
+		defer file.Close()
+		if l.logo, _, err = image.Decode(file); err != nil {
+			return nil, fmt.Errorf("logo %s: %w", t.Logo, err)
+		}
+	}
+	return l, nil
+}
+
+// fields returns the template values of the index-th of count wallets, from 1
+func fields(w Wallet, index, count int) Fields {
+	date := ""
+	if !w.CreatedAt.IsZero() {
+		date = w.CreatedAt.Format(time.DateOnly)
+	}
+	return Fields{Label: w.Label, Address: w.Address, Network: w.Network, Date: date, Index: index, Count: count}
+}
+
+func execute(t *template.Template, f Fields) string {
+	var b strings.Builder
+	if err := t.Execute(&b, f); err != nil {
+		// compile ran every template against Fields already
+		return ""
+	}
+	return b.String()
+}
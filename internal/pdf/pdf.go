@@ -0,0 +1,279 @@
+// Package pdf writes simple PDF documents: pages of text in the standard fonts,
+// filled and stroked shapes and RGB images. Coordinates are in points from the
+// bottom left corner of the page.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"io"
+	"strings"
+)
+
+// Page sizes in points
+const (
+	A4Width      = 595.28
+	A4Height     = 841.89
+	LetterWidth  = 612
+	LetterHeight = 792
+)
+
+// Font is one of the standard Type 1 fonts every PDF reader provides
+type Font string
+
+// Standard fonts
+const (
+	Helvetica     Font = "Helvetica"
+	HelveticaBold Font = "Helvetica-Bold"
+	Courier       Font = "Courier"
+	CourierBold   Font = "Courier-Bold"
+)
+
+var fonts = []Font{Helvetica, HelveticaBold, Courier, CourierBold}
+
+// CourierWidth is the advance of every Courier glyph, as a fraction of the font size
+const CourierWidth = 0.6
+
+// Document is a PDF document under construction
+type Document struct {
+	width, height float64
+	pages         []*Page
+	images        []*Image
+}
+
+// Page is a page of a Document, drawn with its methods in order
+type Page struct {
+	content bytes.Buffer
+	images  map[*Image]bool
+}
+
+// Image is an RGB image added to a Document, drawable on any of its pages
+type Image struct {
+	name          string
+	width, height int
+	data          []byte // zlib-compressed RGB samples
+}
+
+// New returns an empty document whose pages are width by height points
+func New(width, height float64) *Document {
+	return &Document{width: width, height: height}
+}
+
+// AddPage appends a blank page
+func (d *Document) AddPage() *Page {
+	p := &Page{images: make(map[*Image]bool)}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// AddImage adds img to the document, flattening any transparency onto white
+func (d *Document) AddImage(img image.Image) (*Image, error) {
+	bounds := img.Bounds()
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	row := make([]byte, 0, bounds.Dx()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			white := 0xffff - a
+			row = append(row, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	added := &Image{name: fmt.Sprintf("Im%d", len(d.images)+1), width: bounds.Dx(), height: bounds.Dy(), data: compressed.Bytes()}
+	d.images = append(d.images, added)
+	return added, nil
+}
+
+// Size returns the width and height of the image in pixels
+func (img *Image) Size() (int, int) {
+	return img.width, img.height
+}
+
+// SetFillColor sets the color of text and filled shapes, with components from 0 to 1
+func (p *Page) SetFillColor(r, g, b float64) {
+	fmt.Fprintf(&p.content, "%s %s %s rg\n", num(r), num(g), num(b))
+}
+
+// SetStrokeColor sets the color of lines and outlines
+func (p *Page) SetStrokeColor(r, g, b float64) {
+	fmt.Fprintf(&p.content, "%s %s %s RG\n", num(r), num(g), num(b))
+}
+
+// SetLineWidth sets the width of lines and outlines in points
+func (p *Page) SetLineWidth(width float64) {
+	fmt.Fprintf(&p.content, "%s w\n", num(width))
+}
+
+// SetDash makes lines dashed with on and off lengths, or solid when both are zero
+func (p *Page) SetDash(on, off float64) {
+	if on == 0 && off == 0 {
+		p.content.WriteString("[] 0 d\n")
+		return
+	}
+	fmt.Fprintf(&p.content, "[%s %s] 0 d\n", num(on), num(off))
+}
+
+// Text draws s with its baseline starting at x, y. Characters outside Latin-1 are
+// drawn as "?".
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", fontName(font), num(size), num(x), num(y), escape(s))
+}
+
+// Rect fills a rectangle whose bottom left corner is x, y
+func (p *Page) Rect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(y), num(width), num(height))
+}
+
+// StrokeRect outlines a rectangle whose bottom left corner is x, y
+func (p *Page) StrokeRect(x, y, width, height float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re S\n", num(x), num(y), num(width), num(height))
+}
+
+// Line draws a line from x1, y1 to x2, y2
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "%s %s m %s %s l S\n", num(x1), num(y1), num(x2), num(y2))
+}
+
+// Image draws img scaled to width by height, with its bottom left corner at x, y
+func (p *Page) Image(img *Image, x, y, width, height float64) {
+	p.images[img] = true
+	fmt.Fprintf(&p.content, "q %s 0 0 %s %s %s cm /%s Do Q\n", num(width), num(height), num(x), num(y), img.name)
+}
+
+// WriteTo writes the document as a PDF file
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	out := &countingWriter{w: w}
+	var offsets []int64
+	object := func(body string) int {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+		return len(offsets)
+	}
+	stream := func(dict string, data []byte) int {
+		offsets = append(offsets, out.n)
+		fmt.Fprintf(out, "%d 0 obj\n<< %s /Length %d >>\nstream\n", len(offsets), dict, len(data))
+		out.Write(data)
+		out.WriteString("\nendstream\nendobj\n")
+		return len(offsets)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// The catalog and page tree come first so that their numbers are known
+	catalog := object("<< /Type /Catalog /Pages 2 0 R >>")
+	pagesAt := len(offsets)
+	offsets = append(offsets, 0)
+
+	fontRefs := make([]string, len(fonts))
+	for i, font := range fonts {
+		ref := object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font))
+		fontRefs[i] = fmt.Sprintf("/%s %d 0 R", fontName(font), ref)
+	}
+	imageRefs := make(map[*Image]int)
+	for _, img := range d.images {
+		imageRefs[img] = stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode",
+			img.width, img.height), img.data)
+	}
+
+	var kids []string
+	for _, page := range d.pages {
+		var content bytes.Buffer
+		zw := zlib.NewWriter(&content)
+		zw.Write(page.content.Bytes())
+		zw.Close()
+		contentRef := stream("/Filter /FlateDecode", content.Bytes())
+
+		var xobjects []string
+		for _, img := range d.images {
+			if page.images[img] {
+				xobjects = append(xobjects, fmt.Sprintf("/%s %d 0 R", img.name, imageRefs[img]))
+			}
+		}
+		resources := fmt.Sprintf("/Font << %s >>", strings.Join(fontRefs, " "))
+		if len(xobjects) > 0 {
+			resources += fmt.Sprintf(" /XObject << %s >>", strings.Join(xobjects, " "))
+		}
+		ref := object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents %d 0 R >>",
+			num(d.width), num(d.height), resources, contentRef))
+		kids = append(kids, fmt.Sprintf("%d 0 R", ref))
+	}
+
+	offsets[pagesAt] = out.n
+	fmt.Fprintf(out, "%d 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", pagesAt+1, strings.Join(kids, " "), len(kids))
+
+	xref := out.n
+	fmt.Fprintf(out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(out, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, catalog, xref)
+	return out.n, out.err
+}
+
+// fontName is the resource name of a font on every page
+func fontName(font Font) string {
+	for i, f := range fonts {
+		if f == font {
+			return fmt.Sprintf("F%d", i+1)
+		}
+	}
+	return "F1"
+}
+
+// escape encodes s as the contents of a PDF literal string in WinAnsiEncoding
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+// num formats a coordinate with at most two decimals
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+// countingWriter counts the bytes written and keeps the first error
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+func (c *countingWriter) WriteString(s string) {
+	c.Write([]byte(s))
+}
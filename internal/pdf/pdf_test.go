@@ -0,0 +1,77 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	doc := New(A4Width, A4Height)
+	logo := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	logo.Set(0, 0, color.RGBA{R: 255, A: 255})
+	img, err := doc.AddImage(logo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := doc.AddPage()
+	first.SetFillColor(0, 0, 0)
+	first.Text(40, 800, Helvetica, 12, `Wallet (cold) \ café €`)
+	first.Image(img, 40, 700, 20, 10)
+	second := doc.AddPage()
+	second.Rect(10, 10, 5, 5)
+
+	var out bytes.Buffer
+	n, err := doc.WriteTo(&out)
+	if err != nil || n != int64(out.Len()) {
+		t.Fatalf("WriteTo() = %d, %v for %d bytes", n, err, out.Len())
+	}
+	data := out.String()
+	if !strings.HasPrefix(data, "%PDF-1.4\n") || !strings.HasSuffix(data, "%%EOF\n") {
+		t.Fatal("missing PDF header or trailer")
+	}
+
+	// Every cross-reference entry points at its object
+	start, _ := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(data)[1])
+	if !strings.HasPrefix(data[start:], "xref\n") {
+		t.Fatalf("startxref %d does not point at the xref table", start)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(data[start:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(entry[1])
+		if want := strconv.Itoa(i+1) + " 0 obj\n"; !strings.HasPrefix(data[offset:], want) {
+			t.Errorf("xref entry %d points at %q", i+1, data[offset:offset+10])
+		}
+	}
+	if !strings.Contains(data, "/Count 2") || strings.Count(data, "/Type /Page ") != 2 {
+		t.Error("expected a page tree of two pages")
+	}
+
+	// The first page's content draws the escaped text and the image
+	match := regexp.MustCompile(`(?s)<< /Filter /FlateDecode /Length (\d+) >>\nstream\n`).FindStringSubmatchIndex(data)
+	length, _ := strconv.Atoi(data[match[2]:match[3]])
+	zr, err := zlib.NewReader(strings.NewReader(data[match[1] : match[1]+length]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, _ := io.ReadAll(zr)
+	for _, want := range []string{`(Wallet \(cold\) \\ caf\351 ?) Tj`, "/Im1 Do"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("page content %q lacks %q", content, want)
+		}
+	}
+}
+
+func TestNum(t *testing.T) {
+	for v, want := range map[float64]string{0: "0", 10: "10", 0.5: "0.5", 595.28: "595.28", -0.001: "0", 1.005: "1"} {
+		if got := num(v); got != want {
+			t.Errorf("num(%v) = %q, want %q", v, got, want)
+		}
+	}
+}
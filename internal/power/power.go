@@ -0,0 +1,114 @@
+// Package power reads the battery charge and CPU temperature of the host, and
+// decides when a long search should pause for them: while a laptop runs low on
+// battery, or hot, and again once it recovers.
+package power
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultInterval is how often a Monitor reads the battery and temperature
+	DefaultInterval = 30 * time.Second
+	// batteryHysteresis is how far above its threshold the battery must charge
+	// before a search paused for it resumes
+	batteryHysteresis = 5
+	// thermalHysteresis is how far, in °C, below its limit the CPU must cool
+	// before a search paused for it resumes
+	thermalHysteresis = 5
+)
+
+// Reading is one sample of the host's power state
+type Reading struct {
+	// HasBattery reports whether a battery was found
+	HasBattery bool
+	// Battery is the charge of the batteries, in percent
+	Battery float64
+	// Discharging reports whether the host runs on battery
+	Discharging bool
+	// HasTemperature reports whether a CPU temperature sensor was found
+	HasTemperature bool
+	// Temperature is the hottest CPU sensor, in °C
+	Temperature float64
+}
+
+// Thresholds are when a search pauses; zero disables a check
+type Thresholds struct {
+	// MinBattery pauses while the host discharges below this charge, in percent
+	MinBattery float64
+	// MaxTemperature pauses while the CPU is hotter than this, in °C
+	MaxTemperature float64
+}
+
+// Enabled reports whether any check is set
+func (t Thresholds) Enabled() bool {
+	return t.MinBattery > 0 || t.MaxTemperature > 0
+}
+
+// Check returns whether a search should be paused after reading r, and why. A
+// paused search resumes only once the battery charges a few percent above
+// MinBattery, or the host is plugged in, and the CPU cools a few degrees below
+// MaxTemperature, so it does not flap around the thresholds.
+func (t Thresholds) Check(r Reading, paused bool) (bool, string) {
+	if t.MinBattery > 0 && r.HasBattery && r.Discharging {
+		limit := t.MinBattery
+		if paused {
+			limit += batteryHysteresis
+		}
+		if r.Battery < limit {
+			return true, fmt.Sprintf("battery at %.0f%% (pausing below %.0f%%)", r.Battery, t.MinBattery)
+		}
+	}
+	if t.MaxTemperature > 0 && r.HasTemperature {
+		limit := t.MaxTemperature
+		if paused {
+			limit -= thermalHysteresis
+		}
+		if r.Temperature > limit {
+			return true, fmt.Sprintf("CPU at %.0f°C (pausing above %.0f°C)", r.Temperature, t.MaxTemperature)
+		}
+	}
+	return false, ""
+}
+
+// Monitor samples the power state and reports when a search should pause or resume
+type Monitor struct {
+	Thresholds Thresholds
+	// Interval is how often the state is read (default DefaultInterval)
+	Interval time.Duration
+	// Read samples the power state (default Read)
+	Read func() Reading
+	// OnChange is called with paused=true and the reason when a search should
+	// pause, and with paused=false when it may resume
+	OnChange func(paused bool, reason string)
+}
+
+// Run samples the power state until ctx ends, calling OnChange on every change
+// from running to paused and back. The first sample is taken at once.
+func (m *Monitor) Run(ctx context.Context) {
+	interval, read := m.Interval, m.Read
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	if read == nil {
+		read = Read
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	paused := false
+	for {
+		pause, reason := m.Thresholds.Check(read(), paused)
+		if pause != paused {
+			paused = pause
+			m.OnChange(paused, reason)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
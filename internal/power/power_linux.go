@@ -0,0 +1,6 @@
+package power
+
+// Read samples the battery and CPU temperature from /sys/class
+func Read() Reading {
+	return readSysfs("/sys/class")
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package power
+
+// Read reports no battery or temperature, as they are only read from Linux sysfs
+func Read() Reading {
+	return Reading{}
+}
@@ -0,0 +1,107 @@
+package power
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestThresholds_Check(t *testing.T) {
+	th := Thresholds{MinBattery: 20, MaxTemperature: 90}
+	tests := []struct {
+		name    string
+		reading Reading
+		paused  bool
+		want    bool
+	}{
+		{"no sensors", Reading{}, false, false},
+		{"low battery", Reading{HasBattery: true, Battery: 15, Discharging: true}, false, true},
+		{"low battery charging", Reading{HasBattery: true, Battery: 15}, true, false},
+		{"battery recovering", Reading{HasBattery: true, Battery: 22, Discharging: true}, true, true},
+		{"battery recovered", Reading{HasBattery: true, Battery: 25, Discharging: true}, true, false},
+		{"hot", Reading{HasTemperature: true, Temperature: 95}, false, true},
+		{"cooling", Reading{HasTemperature: true, Temperature: 88}, true, true},
+		{"cooled", Reading{HasTemperature: true, Temperature: 85}, true, false},
+		{"warm but running", Reading{HasTemperature: true, Temperature: 88}, false, false},
+	}
+	for _, tt := range tests {
+		if got, reason := th.Check(tt.reading, tt.paused); got != tt.want || got != (reason != "") {
+			t.Errorf("%s: Check() = %v, %q; want %v", tt.name, got, reason, tt.want)
+		}
+	}
+	if (Thresholds{}).Enabled() {
+		t.Error("zero thresholds are enabled")
+	}
+}
+
+func writeAttr(t *testing.T, path, value string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(value+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadSysfs(t *testing.T) {
+	root := t.TempDir()
+	writeAttr(t, filepath.Join(root, "power_supply/AC/type"), "Mains")
+	writeAttr(t, filepath.Join(root, "power_supply/BAT0/type"), "Battery")
+	writeAttr(t, filepath.Join(root, "power_supply/BAT0/capacity"), "30")
+	writeAttr(t, filepath.Join(root, "power_supply/BAT0/status"), "Discharging")
+	writeAttr(t, filepath.Join(root, "power_supply/BAT1/type"), "Battery")
+	writeAttr(t, filepath.Join(root, "power_supply/BAT1/capacity"), "50")
+	writeAttr(t, filepath.Join(root, "power_supply/BAT1/status"), "Idle")
+	writeAttr(t, filepath.Join(root, "thermal/thermal_zone0/type"), "acpitz")
+	writeAttr(t, filepath.Join(root, "thermal/thermal_zone0/temp"), "99000")
+	writeAttr(t, filepath.Join(root, "thermal/thermal_zone1/type"), "x86_pkg_temp")
+	writeAttr(t, filepath.Join(root, "thermal/thermal_zone1/temp"), "71500")
+
+	got := readSysfs(root)
+	want := Reading{HasBattery: true, Battery: 40, Discharging: true, HasTemperature: true, Temperature: 71.5}
+	if got != want {
+		t.Errorf("readSysfs() = %+v; want %+v", got, want)
+	}
+	if got := readSysfs(t.TempDir()); got != (Reading{}) {
+		t.Errorf("readSysfs(empty) = %+v", got)
+	}
+}
+
+func TestMonitor_Run(t *testing.T) {
+	var mu sync.Mutex
+	temps := []float64{60, 95, 92, 80, 80}
+	var changes []bool
+	done := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := &Monitor{
+		Thresholds: Thresholds{MaxTemperature: 90},
+		Interval:   time.Millisecond,
+		Read: func() Reading {
+			mu.Lock()
+			defer mu.Unlock()
+			if len(temps) == 1 {
+				cancel()
+			}
+			temp := temps[0]
+			if len(temps) > 1 {
+				temps = temps[1:]
+			}
+			return Reading{HasTemperature: true, Temperature: temp}
+		},
+		OnChange: func(paused bool, _ string) { changes = append(changes, paused) },
+	}
+	go func() {
+		m.Run(ctx)
+		close(done)
+	}()
+	<-done
+	if len(changes) != 2 || !changes[0] || changes[1] {
+		t.Errorf("changes = %v; want [true false]", changes)
+	}
+}
@@ -0,0 +1,91 @@
+package power
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cpuZoneTypes are substrings of the thermal zone types that measure the CPU
+var cpuZoneTypes = []string{"cpu", "pkg", "core", "soc", "k10temp", "tctl"}
+
+// readSysfs reads the power state from a Linux /sys/class tree at root
+func readSysfs(root string) Reading {
+	var r Reading
+	readBatteries(filepath.Join(root, "power_supply"), &r)
+	readThermalZones(filepath.Join(root, "thermal"), &r)
+	return r
+}
+
+// readBatteries averages the charge of every battery under dir; the host
+// discharges when any of them does
+func readBatteries(dir string, r *Reading) {
+	supplies, _ := filepath.Glob(filepath.Join(dir, "*"))
+	var total float64
+	count := 0
+	for _, supply := range supplies {
+		if readString(filepath.Join(supply, "type")) != "Battery" {
+			continue
+		}
+		capacity, err := strconv.ParseFloat(readString(filepath.Join(supply, "capacity")), 64)
+		if err != nil {
+			continue
+		}
+		total += capacity
+		count++
+		if readString(filepath.Join(supply, "status")) == "Discharging" {
+			r.Discharging = true
+		}
+	}
+	if count > 0 {
+		r.HasBattery, r.Battery = true, total/float64(count)
+	}
+}
+
+// readThermalZones takes the hottest CPU thermal zone under dir, or the hottest
+// zone of any type when none is named after the CPU
+func readThermalZones(dir string, r *Reading) {
+	zones, _ := filepath.Glob(filepath.Join(dir, "thermal_zone*"))
+	var hottest, hottestCPU float64
+	found, foundCPU := false, false
+	for _, zone := range zones {
+		milli, err := strconv.ParseFloat(readString(filepath.Join(zone, "temp")), 64)
+		if err != nil || milli <= 0 {
+			continue
+		}
+		temp := milli / 1000
+		if !found || temp > hottest {
+			hottest, found = temp, true
+		}
+		if isCPUZone(readString(filepath.Join(zone, "type"))) && (!foundCPU || temp > hottestCPU) {
+			hottestCPU, foundCPU = temp, true
+		}
+	}
+	switch {
+	case foundCPU:
+		r.HasTemperature, r.Temperature = true, hottestCPU
+	case found:
+		r.HasTemperature, r.Temperature = true, hottest
+	}
+}
+
+// isCPUZone reports whether a thermal zone type names a CPU sensor
+func isCPUZone(zoneType string) bool {
+	zoneType = strings.ToLower(zoneType)
+	for _, name := range cpuZoneTypes {
+		if strings.Contains(zoneType, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// readString reads a sysfs attribute without its trailing newline, or "" on error
+func readString(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
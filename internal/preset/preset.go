@@ -0,0 +1,231 @@
+// Package preset stores named pattern presets in a JSON registry file that teams
+// can export and import to share naming schemes
+package preset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// EnvPath overrides the registry file location
+const EnvPath = "BLOCO_PRESETS"
+
+// validName matches preset names such as lucky8 or team-deposit_v2
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Preset is a named pattern. Empty fields leave the matching flag at its default.
+type Preset struct {
+	Prefix        string `json:"prefix,omitempty"`
+	Suffix        string `json:"suffix,omitempty"`
+	Checksum      bool   `json:"checksum,omitempty"`
+	CaseSensitive bool   `json:"case_sensitive,omitempty"`
+	Network       string `json:"network,omitempty"`
+	Description   string `json:"description,omitempty"`
+}
+
+// Criteria returns the generation criteria of the preset
+func (p Preset) Criteria() wallet.GenerationCriteria {
+	network := p.Network
+	if network == "" {
+		network = "ethereum"
+	}
+	return wallet.GenerationCriteria{
+		Network:       network,
+		Prefix:        p.Prefix,
+		Suffix:        p.Suffix,
+		IsChecksum:    p.Checksum,
+		CaseSensitive: p.CaseSensitive,
+	}
+}
+
+// Validate checks that the preset describes a searchable pattern
+func (p Preset) Validate() error {
+	if p.Prefix == "" && p.Suffix == "" {
+		return fmt.Errorf("a preset needs a prefix or a suffix")
+	}
+	criteria := p.Criteria()
+	return criteria.Validate()
+}
+
+// Registry is a set of presets by name, stored as {"presets": {...}}
+type Registry struct {
+	Presets map[string]Preset `json:"presets"`
+}
+
+// ValidateName checks that name can identify a preset
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid preset name %q: use lowercase letters, digits, - and _ (at most 64)", name)
+	}
+	return nil
+}
+
+// DefaultPath returns $BLOCO_PRESETS, or presets.json in the user's bloco-eth config directory
+func DefaultPath() (string, error) {
+	if path := os.Getenv(EnvPath); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate the preset registry (set %s): %w", EnvPath, err)
+	}
+	return filepath.Join(dir, "bloco-eth", "presets.json"), nil
+}
+
+// Load reads a registry file; a missing file is an empty registry
+func Load(path string) (*Registry, error) {
+	r := &Registry{Presets: make(map[string]Preset)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.decode(data); err != nil {
+		return nil, fmt.Errorf("invalid preset file %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// decode parses and validates registry JSON
+func (r *Registry) decode(data []byte) error {
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(r); err != nil {
+		return err
+	}
+	if r.Presets == nil {
+		r.Presets = make(map[string]Preset)
+	}
+	for name, p := range r.Presets {
+		if err := ValidateName(name); err != nil {
+			return err
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("preset %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Save writes the registry to path, creating its directory
+func (r *Registry) Save(path string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	data, err := r.Marshal()
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Marshal returns the registry as indented JSON
+func (r *Registry) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Names returns the preset names in order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.Presets))
+	for name := range r.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named preset
+func (r *Registry) Get(name string) (Preset, bool) {
+	p, ok := r.Presets[name]
+	return p, ok
+}
+
+// Add stores p as name, refusing to replace a different preset unless replace is set
+func (r *Registry) Add(name string, p Preset, replace bool) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if existing, ok := r.Presets[name]; ok && existing != p && !replace {
+		return fmt.Errorf("preset %s already exists with a different pattern", name)
+	}
+	r.Presets[name] = p
+	return nil
+}
+
+// Remove deletes the named preset, reporting whether it existed
+func (r *Registry) Remove(name string) bool {
+	_, ok := r.Presets[name]
+	delete(r.Presets, name)
+	return ok
+}
+
+// Subset returns a registry of the named presets, or all of them when names is empty
+func (r *Registry) Subset(names []string) (*Registry, error) {
+	if len(names) == 0 {
+		return r, nil
+	}
+	subset := &Registry{Presets: make(map[string]Preset, len(names))}
+	for _, name := range names {
+		p, ok := r.Presets[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown preset %q", name)
+		}
+		subset.Presets[name] = p
+	}
+	return subset, nil
+}
+
+// Merge adds the presets of other. Presets with the same name and a different
+// pattern are conflicts: they are replaced when replace is set and reported
+// otherwise, in which case nothing is merged.
+func (r *Registry) Merge(other *Registry, replace bool) (added, replaced int, err error) {
+	var conflicts []string
+	for _, name := range other.Names() {
+		if existing, ok := r.Presets[name]; ok && existing != other.Presets[name] {
+			conflicts = append(conflicts, name)
+		}
+	}
+	if len(conflicts) > 0 && !replace {
+		return 0, 0, fmt.Errorf("presets %s already exist with a different pattern", strings.Join(conflicts, ", "))
+	}
+	for name, p := range other.Presets {
+		if _, ok := r.Presets[name]; !ok {
+			added++
+		} else if r.Presets[name] != p {
+			replaced++
+		}
+		r.Presets[name] = p
+	}
+	return added, replaced, nil
+}
+
+// Parse decodes and validates registry JSON, such as an exported preset file
+func Parse(data []byte) (*Registry, error) {
+	r := &Registry{}
+	if err := r.decode(data); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
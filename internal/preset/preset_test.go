@@ -0,0 +1,134 @@
+package preset
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegistry_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bloco-eth", "presets.json")
+	r, err := Load(path)
+	if err != nil || len(r.Presets) != 0 {
+		t.Fatalf("Load() of a missing file = %v, %v; want an empty registry", r, err)
+	}
+	if err := r.Add("lucky8", Preset{Suffix: "88888888", Description: "lucky"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add("treasury", Preset{Prefix: "CAFE", Checksum: true, CaseSensitive: true}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := strings.Join(loaded.Names(), ","); got != "lucky8,treasury" {
+		t.Errorf("Names() = %s", got)
+	}
+	if p, _ := loaded.Get("lucky8"); p.Suffix != "88888888" || p.Description != "lucky" {
+		t.Errorf("lucky8 = %+v", p)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"presets": {`) || !strings.Contains(string(data), `"suffix": "88888888"`) {
+		t.Errorf("file =\n%s", data)
+	}
+}
+
+func TestRegistry_Add(t *testing.T) {
+	r := &Registry{Presets: map[string]Preset{"lucky8": {Suffix: "88888888"}}}
+	tests := []struct {
+		name    string
+		preset  string
+		p       Preset
+		replace bool
+		want    string
+	}{
+		{"bad name", "Lucky 8", Preset{Suffix: "88"}, false, "invalid preset name"},
+		{"no pattern", "empty", Preset{Description: "nothing"}, false, "prefix or a suffix"},
+		{"bad hex", "bad", Preset{Prefix: "xyz"}, false, "invalid hex"},
+		{"case without checksum", "case", Preset{Prefix: "CAFE", CaseSensitive: true}, false, "checksum"},
+		{"conflict", "lucky8", Preset{Suffix: "8888"}, false, "already exists"},
+		{"same pattern", "lucky8", Preset{Suffix: "88888888"}, false, ""},
+		{"replace", "lucky8", Preset{Suffix: "8888"}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Add(tt.preset, tt.p, tt.replace)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Add() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Add() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+	if p, _ := r.Get("lucky8"); p.Suffix != "8888" {
+		t.Errorf("lucky8 = %+v after a replace", p)
+	}
+}
+
+func TestRegistry_ExportImport(t *testing.T) {
+	team := &Registry{Presets: map[string]Preset{
+		"lucky8":   {Suffix: "88888888"},
+		"treasury": {Prefix: "cafe"},
+		"deposit":  {Prefix: "d0"},
+	}}
+	subset, err := team.Subset([]string{"lucky8", "treasury"})
+	if err != nil || len(subset.Presets) != 2 {
+		t.Fatalf("Subset() = %v, %v", subset, err)
+	}
+	if _, err := team.Subset([]string{"missing"}); err == nil {
+		t.Error("Subset() of an unknown preset should fail")
+	}
+	data, err := subset.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exported, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	local := &Registry{Presets: map[string]Preset{"lucky8": {Suffix: "8888"}, "mine": {Prefix: "ab"}}}
+	if _, _, err := local.Merge(exported, false); err == nil || !strings.Contains(err.Error(), "lucky8") {
+		t.Errorf("Merge() error = %v, want a lucky8 conflict", err)
+	}
+	if len(local.Presets) != 2 {
+		t.Error("a conflicting Merge() must not change the registry")
+	}
+	added, replaced, err := local.Merge(exported, true)
+	if err != nil || added != 1 || replaced != 1 {
+		t.Errorf("Merge() = %d added, %d replaced, %v; want 1, 1", added, replaced, err)
+	}
+	if p, _ := local.Get("lucky8"); p.Suffix != "88888888" {
+		t.Errorf("lucky8 = %+v", p)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, data := range []string{
+		`{"presets": {"lucky8": {"sufix": "88"}}}`,
+		`{"presets": {"Bad Name": {"suffix": "88"}}}`,
+		`{"presets": {"empty": {}}}`,
+		`not json`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) should fail", data)
+		}
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv(EnvPath, "/tmp/team-presets.json")
+	if path, err := DefaultPath(); err != nil || path != "/tmp/team-presets.json" {
+		t.Errorf("DefaultPath() = %q, %v; want $%s", path, err, EnvPath)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"bloco-eth/internal/worker"
 	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
 	"bloco-eth/pkg/wallet"
 )
 
@@ -22,6 +23,8 @@ type AggregatedStats struct {
 	Probability   float64       `json:"probability"`
 	EstimatedTime time.Duration `json:"estimated_time"`
 	LastUpdate    time.Time     `json:"last_update"`
+
+	ETAPercentiles []utils.ETAPercentile `json:"eta_percentiles,omitempty"`
 }
 
 // ProgressManager provides thread-safe progress display for multi-threaded operations
@@ -36,6 +39,9 @@ type ProgressManager struct {
 
 	// Thread-safe progress aggregation
 	aggregatedStats AggregatedStats
+
+	// Attempts needed for each ETA percentile
+	etaTargets []utils.ETAPercentile
 }
 
 // NewProgressManager creates a new ProgressManager instance
@@ -50,9 +56,17 @@ func NewProgressManager(stats *wallet.GenerationStats, statsCollector *worker.St
 		aggregatedStats: AggregatedStats{
 			LastUpdate: time.Now(),
 		},
+		etaTargets: utils.CalculateETAPercentiles(stats.Difficulty, 1, utils.DefaultETAPercentiles),
 	}
 }
 
+// SetETAPercentiles sets the probabilities shown as ETAs for finding wallets matches
+func (pm *ProgressManager) SetETAPercentiles(percents []float64, wallets int) {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	pm.etaTargets = utils.CalculateETAPercentiles(pm.stats.Difficulty, wallets, percents)
+}
+
 // Start begins the progress display loop
 func (pm *ProgressManager) Start() {
 	// Use atomic compare-and-swap to ensure only one goroutine starts
@@ -170,7 +184,7 @@ func (pm *ProgressManager) aggregateWorkerData() {
 	aggregated.PeakSpeed = pm.statsCollector.GetPeakSpeed()
 
 	// Calculate probability based on difficulty and attempts
-	aggregated.Probability = utils.CalculateProbability(pm.stats.Difficulty, metrics.TotalAttempts) * 100
+	aggregated.Probability = vanitymath.Probability(pm.stats.Difficulty, metrics.TotalAttempts) * 100
 
 	// Calculate estimated time
 	if pm.stats.Probability50 > 0 && aggregated.TotalSpeed > 0 {
@@ -182,6 +196,8 @@ func (pm *ProgressManager) aggregateWorkerData() {
 		}
 	}
 
+	aggregated.ETAPercentiles = utils.EstimateETAPercentiles(pm.etaTargets, metrics.TotalAttempts, aggregated.TotalSpeed)
+
 	// Update the aggregated stats in one go to minimize race conditions
 	pm.aggregatedStats = aggregated
 }
@@ -237,8 +253,10 @@ func (pm *ProgressManager) displayProgress() {
 		utils.FormatLargeNumber(int64(pm.stats.Difficulty)),
 	)
 
-	// Show estimated time if available
-	if pm.aggregatedStats.EstimatedTime > 0 {
+	// Show estimated times if available
+	if len(pm.aggregatedStats.ETAPercentiles) > 0 {
+		fmt.Printf(" | ETA %s", utils.FormatETAPercentiles(pm.aggregatedStats.ETAPercentiles))
+	} else if pm.aggregatedStats.EstimatedTime > 0 {
 		fmt.Printf(" | ETA: %s", utils.FormatDuration(pm.aggregatedStats.EstimatedTime))
 	}
 
@@ -263,6 +281,8 @@ func (pm *ProgressManager) GetAggregatedStats() AggregatedStats {
 		Probability:   pm.aggregatedStats.Probability,
 		EstimatedTime: pm.aggregatedStats.EstimatedTime,
 		LastUpdate:    pm.aggregatedStats.LastUpdate,
+
+		ETAPercentiles: append([]utils.ETAPercentile(nil), pm.aggregatedStats.ETAPercentiles...),
 	}
 }
 
@@ -297,13 +317,14 @@ func NewManager(
 	// Create generation stats
 	stats := &wallet.GenerationStats{
 		Pattern:       criteria.GetPattern(),
-		Difficulty:    utils.CalculateDifficulty(criteria.Prefix, criteria.Suffix, criteria.IsChecksum),
-		Probability50: utils.CalculateProbability50(utils.CalculateDifficulty(criteria.Prefix, criteria.Suffix, criteria.IsChecksum)),
+		Difficulty:    criteria.Difficulty(),
+		Probability50: vanitymath.Attempts50(criteria.Difficulty()),
 		StartTime:     time.Now(),
 		IsChecksum:    criteria.IsChecksum,
 	}
 
 	progressManager := NewProgressManager(stats, statsCollector)
+	progressManager.SetETAPercentiles(utils.DefaultETAPercentiles, totalWallets)
 
 	return &Manager{
 		progressManager: progressManager,
@@ -0,0 +1,215 @@
+package publish
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// MQTT 3.1.1 control packet types, shifted into the fixed header
+const (
+	mqttConnect    = 0x10
+	mqttConnAck    = 0x20
+	mqttPublish    = 0x30
+	mqttPingReq    = 0xc0
+	mqttDisconnect = 0xe0
+)
+
+// mqttKeepAlive is the keep-alive announced in CONNECT; a PINGREQ is sent at half of it
+const mqttKeepAlive = 60 * time.Second
+
+// mqttConnAckReasons are the CONNACK return codes of MQTT 3.1.1
+var mqttConnAckReasons = map[byte]string{
+	1: "unacceptable protocol version",
+	2: "client identifier rejected",
+	3: "server unavailable",
+	4: "bad user name or password",
+	5: "not authorized",
+}
+
+// mqttPublisher is an MQTT 3.1.1 client that publishes with QoS 0
+type mqttPublisher struct {
+	conn  net.Conn
+	topic string
+
+	mu   sync.Mutex
+	err  error
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newMQTT sends CONNECT on conn, waits for an accepting CONNACK and starts keep-alive
+func newMQTT(conn net.Conn, user *url.Userinfo, name, topic string) (*mqttPublisher, error) {
+	_ = conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	clientID := name + "-" + hex.EncodeToString(suffix)
+
+	var flags byte = 0x02 // clean session
+	payload := mqttString(nil, clientID)
+	if user != nil {
+		flags |= 0x80
+		payload = mqttString(payload, user.Username())
+		if pass, ok := user.Password(); ok {
+			flags |= 0x40
+			payload = mqttString(payload, pass)
+		}
+	}
+	body := mqttString(nil, "MQTT")
+	body = append(body, 4, flags)
+	body = binary.BigEndian.AppendUint16(body, uint16(mqttKeepAlive/time.Second))
+	body = append(body, payload...)
+	if _, err := conn.Write(mqttPacket(mqttConnect, body)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("MQTT handshake failed: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	kind, ack, err := mqttRead(reader)
+	if err != nil || kind != mqttConnAck || len(ack) != 2 {
+		conn.Close()
+		return nil, fmt.Errorf("MQTT broker did not send CONNACK: %v", err)
+	}
+	if ack[1] != 0 {
+		conn.Close()
+		reason, ok := mqttConnAckReasons[ack[1]]
+		if !ok {
+			reason = fmt.Sprintf("return code %d", ack[1])
+		}
+		return nil, fmt.Errorf("MQTT broker rejected the connection: %s", reason)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	p := &mqttPublisher{conn: conn, topic: topic, stop: make(chan struct{}), done: make(chan struct{})}
+	go p.read(reader)
+	go p.keepAlive()
+	return p, nil
+}
+
+// read drains PINGRESP packets and notices a closed connection
+func (p *mqttPublisher) read(reader *bufio.Reader) {
+	defer close(p.done)
+	for {
+		if _, _, err := mqttRead(reader); err != nil {
+			p.fail(fmt.Errorf("MQTT connection lost: %w", err))
+			return
+		}
+	}
+}
+
+// keepAlive pings the broker so it does not drop an idle connection
+func (p *mqttPublisher) keepAlive() {
+	ticker := time.NewTicker(mqttKeepAlive / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			_ = p.write(mqttPacket(mqttPingReq, nil))
+		}
+	}
+}
+
+// fail records the first error, which later publishes return
+func (p *mqttPublisher) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// write sends a packet unless the connection has failed
+func (p *mqttPublisher) write(packet []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	_ = p.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := p.conn.Write(packet); err != nil {
+		p.err = fmt.Errorf("MQTT publish failed: %w", err)
+	}
+	return p.err
+}
+
+// Publish sends payload to the topic with QoS 0
+func (p *mqttPublisher) Publish(payload []byte) error {
+	body := mqttString(make([]byte, 0, len(p.topic)+len(payload)+2), p.topic)
+	return p.write(mqttPacket(mqttPublish, append(body, payload...)))
+}
+
+// Topic returns the topic
+func (p *mqttPublisher) Topic() string {
+	return p.topic
+}
+
+// Close sends DISCONNECT and closes the connection
+func (p *mqttPublisher) Close() error {
+	close(p.stop)
+	err := p.write(mqttPacket(mqttDisconnect, nil))
+	p.conn.Close()
+	<-p.done
+	return err
+}
+
+// mqttString appends s as a length-prefixed UTF-8 string
+func mqttString(b []byte, s string) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// mqttPacket frames body with a fixed header and variable-length remaining length
+func mqttPacket(kind byte, body []byte) []byte {
+	packet := []byte{kind}
+	n := len(body)
+	for {
+		digit := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if n == 0 {
+			break
+		}
+	}
+	return append(packet, body...)
+}
+
+// mqttRead reads one packet, returning its type and body
+func mqttRead(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	length, multiplier := 0, 1
+	for i := 0; ; i++ {
+		digit, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		length += int(digit&0x7f) * multiplier
+		if digit&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, fmt.Errorf("malformed MQTT remaining length")
+		}
+		multiplier *= 128
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return 0, nil, err
+	}
+	return header & 0xf0, body, nil
+}
@@ -0,0 +1,161 @@
+package publish
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsPublisher speaks the NATS client protocol: CONNECT, PUB and PING/PONG
+type natsPublisher struct {
+	conn    net.Conn
+	subject string
+
+	mu   sync.Mutex
+	err  error
+	pong chan struct{}
+	done chan struct{}
+}
+
+// natsConnect is the CONNECT options of a publish-only client
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name,omitempty"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	Protocol  int    `json:"protocol"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+// newNATS performs the handshake on conn: the server's INFO, our CONNECT, then a
+// PING whose PONG confirms the server accepted the connection
+func newNATS(conn net.Conn, user *url.Userinfo, name, subject string) (*natsPublisher, error) {
+	_ = conn.SetDeadline(time.Now().Add(dialTimeout))
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return nil, fmt.Errorf("NATS server did not send INFO: %v", err)
+	}
+
+	options := natsConnect{Name: name, Lang: "go", Version: "1.0.0", Protocol: 1}
+	if user != nil {
+		if pass, ok := user.Password(); ok {
+			options.User, options.Pass = user.Username(), pass
+		} else {
+			options.AuthToken = user.Username()
+		}
+	}
+	data, _ := json.Marshal(options)
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", data); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("NATS handshake failed: %w", err)
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("NATS handshake failed: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return nil, fmt.Errorf("NATS server rejected the connection: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	p := &natsPublisher{conn: conn, subject: subject, pong: make(chan struct{}, 1), done: make(chan struct{})}
+	go p.read(reader)
+	return p, nil
+}
+
+// read answers server PINGs and records errors until the connection closes
+func (p *natsPublisher) read(reader *bufio.Reader) {
+	defer close(p.done)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			p.fail(fmt.Errorf("NATS connection lost: %w", err))
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PING":
+			_ = p.write([]byte("PONG\r\n"))
+		case line == "PONG":
+			select {
+			case p.pong <- struct{}{}:
+			default:
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			p.fail(fmt.Errorf("NATS server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))))
+		}
+	}
+}
+
+// fail records the first error, which later publishes return
+func (p *natsPublisher) fail(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// write sends data unless the connection has failed
+func (p *natsPublisher) write(data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	_ = p.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := p.conn.Write(data); err != nil {
+		p.err = fmt.Errorf("NATS publish failed: %w", err)
+	}
+	return p.err
+}
+
+// Publish sends payload on the subject
+func (p *natsPublisher) Publish(payload []byte) error {
+	msg := make([]byte, 0, len(p.subject)+len(payload)+32)
+	msg = fmt.Appendf(msg, "PUB %s %d\r\n", p.subject, len(payload))
+	msg = append(msg, payload...)
+	msg = append(msg, "\r\n"...)
+	return p.write(msg)
+}
+
+// Topic returns the subject
+func (p *natsPublisher) Topic() string {
+	return p.subject
+}
+
+// Close waits for the server to acknowledge a final PING, so every published
+// message was processed, then closes the connection
+func (p *natsPublisher) Close() error {
+	err := p.write([]byte("PING\r\n"))
+	if err == nil {
+		select {
+		case <-p.pong:
+		case <-p.done:
+		case <-time.After(writeTimeout):
+			err = errors.New("NATS server did not acknowledge the final flush")
+		}
+	}
+	p.conn.Close()
+	<-p.done
+	return err
+}
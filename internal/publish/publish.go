@@ -0,0 +1,92 @@
+// Package publish sends events to a NATS subject or MQTT topic using minimal,
+// publish-only clients of the two protocols
+package publish
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// dialTimeout bounds connecting and the protocol handshake
+	dialTimeout = 10 * time.Second
+	// writeTimeout bounds a single publish
+	writeTimeout = 5 * time.Second
+	// defaultTopic is used when the URL has no path
+	defaultTopic = "bloco"
+)
+
+// Publisher publishes messages to the subject or topic named by its URL
+type Publisher interface {
+	// Publish sends payload with at-most-once delivery
+	Publish(payload []byte) error
+	// Topic is the subject or topic messages go to
+	Topic() string
+	// Close flushes and closes the connection
+	Close() error
+}
+
+// Dial connects to nats://[user:pass@]host[:4222]/subject or
+// mqtt://[user:pass@]host[:1883]/topic/path. A URL without a path publishes to "bloco".
+func Dial(rawURL, clientName string) (Publisher, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("publish URL must look like nats://host:4222/subject or mqtt://host:1883/topic, got %q", Redact(rawURL))
+	}
+	topic := strings.Trim(u.Path, "/")
+	switch u.Scheme {
+	case "nats":
+		if topic == "" {
+			topic = defaultTopic
+		}
+		if strings.ContainsAny(topic, " \t\r\n*>") {
+			return nil, fmt.Errorf("invalid NATS subject %q", topic)
+		}
+		conn, err := dial(u, "4222")
+		if err != nil {
+			return nil, err
+		}
+		return newNATS(conn, u.User, clientName, topic)
+	case "mqtt":
+		if topic == "" {
+			topic = defaultTopic
+		}
+		if strings.ContainsAny(topic, "+#") {
+			return nil, fmt.Errorf("MQTT topic %q must not contain wildcards", topic)
+		}
+		conn, err := dial(u, "1883")
+		if err != nil {
+			return nil, err
+		}
+		return newMQTT(conn, u.User, clientName, topic)
+	default:
+		return nil, fmt.Errorf("unsupported publish scheme %q (use nats or mqtt)", u.Scheme)
+	}
+}
+
+// dial opens a TCP connection to the URL's host, on defaultPort when it has none
+func dial(u *url.URL, defaultPort string) (net.Conn, error) {
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), defaultPort)
+	}
+	conn, err := net.DialTimeout("tcp", host, dialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", u.Host, err)
+	}
+	return conn, nil
+}
+
+// Redact hides the credentials of a publish URL, including a NATS token given as
+// the user name, for messages and logs
+func Redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	u.User = url.User("xxxxx")
+	return u.String()
+}
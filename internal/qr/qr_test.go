@@ -0,0 +1,171 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" at 1-M in alphanumeric mode
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := reedSolomonRemainder(data, reedSolomonDivisor(10)); !bytes.Equal(got, want) {
+		t.Errorf("error correction codewords = %v, want %v", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	for mask, want := range map[int]int{0: 0b101010000010010, 1: 0b101000100100101, 4: 0b100010111111001, 7: 0b100101010100000} {
+		if got := formatBits(mask); got != want {
+			t.Errorf("formatBits(%d) = %015b, want %015b", mask, got, want)
+		}
+	}
+	for version, want := range map[int]int{7: 0x07C94, 8: 0x085BC, 9: 0x09A99, 10: 0x0A4D3} {
+		if got := versionBits(version); got != want {
+			t.Errorf("versionBits(%d) = %018b, want %018b", version, got, want)
+		}
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	raw := []int{26, 44, 70, 100, 134, 172, 196, 242, 292, 346}
+	capacity := []int{14, 26, 42, 62, 84, 106, 122, 152, 180, 213}
+	for v := 1; v <= MaxVersion; v++ {
+		if rawCodewords(v) != raw[v-1] || Capacity(v) != capacity[v-1] {
+			t.Errorf("version %d holds %d codewords and %d bytes, want %d and %d", v, rawCodewords(v), Capacity(v), raw[v-1], capacity[v-1])
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for text, version := range map[string]int{
+		"0x52908400098527886e0f7030069857d2e4169ee7":                         3,
+		"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297":     4,
+		"0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318": 5,
+		strings.Repeat("a", 200):                                             10,
+	} {
+		c, err := Encode(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Version != version || c.Size != version*4+17 {
+			t.Errorf("%q: version %d of size %d, want version %d", text, c.Version, c.Size, version)
+		}
+		if got, err := decode(c); err != nil || got != text {
+			t.Errorf("%q decodes as %q, %v", text, got, err)
+		}
+	}
+	if _, err := Encode(strings.Repeat("a", Capacity(MaxVersion)+1)); err == nil {
+		t.Error("expected an error for text too long for a version 10 symbol")
+	}
+}
+
+// decode reads a symbol back: its format information, unmasked data modules, the
+// error correction of each block and the byte mode segment
+func decode(c *Code) (string, error) {
+	layout := newCode(c.Version)
+	layout.drawFunctionPatterns()
+
+	var format int
+	for i := 0; i <= 5; i++ {
+		format |= b2i(c.modules[i][8]) << i
+	}
+	format |= b2i(c.modules[7][8])<<6 | b2i(c.modules[8][8])<<7 | b2i(c.modules[8][7])<<8
+	for i := 9; i < 15; i++ {
+		format |= b2i(c.modules[8][14-i]) << i
+	}
+	mask := -1
+	for m := 0; m < 8; m++ {
+		if formatBits(m) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		return "", fmt.Errorf("format information %015b is not level M", format)
+	}
+
+	unmasked := &Code{Version: c.Version, Size: c.Size, function: layout.function, modules: make([][]bool, c.Size)}
+	for y := range c.modules {
+		unmasked.modules[y] = append([]bool(nil), c.modules[y]...)
+	}
+	unmasked.applyMask(mask)
+
+	// Read the codewords in placement order
+	codewords := make([]byte, rawCodewords(c.Version))
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !layout.function[y][x] && i < len(codewords)*8 {
+					if unmasked.modules[y][x] {
+						codewords[i>>3] |= 1 << (7 - i&7)
+					}
+					i++
+				}
+			}
+		}
+	}
+
+	// Undo the interleaving and check every block
+	blocks, ecc := eccBlocks[c.Version], eccPerBlock[c.Version]
+	shortBlocks := blocks - len(codewords)%blocks
+	shortData := len(codewords)/blocks - ecc
+	dataBlocks := make([][]byte, blocks)
+	k := 0
+	for i := 0; i <= shortData; i++ {
+		for j := range dataBlocks {
+			if i < shortData || j >= shortBlocks {
+				dataBlocks[j] = append(dataBlocks[j], codewords[k])
+				k++
+			}
+		}
+	}
+	var data []byte
+	for j, block := range dataBlocks {
+		check := make([]byte, ecc)
+		for i := range check {
+			check[i] = codewords[k+i*blocks+j]
+		}
+		if !bytes.Equal(reedSolomonRemainder(block, reedSolomonDivisor(ecc)), check) {
+			return "", fmt.Errorf("block %d fails its error correction", j)
+		}
+		data = append(data, block...)
+	}
+
+	if data[0]>>4 != 0x4 {
+		return "", fmt.Errorf("mode %x is not byte mode", data[0]>>4)
+	}
+	var bits bitBuffer
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	read := func(from, n int) int {
+		v := 0
+		for _, bit := range bits[from : from+n] {
+			v = v<<1 | b2i(bit)
+		}
+		return v
+	}
+	count := read(4, countBits(c.Version))
+	text := make([]byte, count)
+	for i := range text {
+		text[i] = byte(read(4+countBits(c.Version)+i*8, 8))
+	}
+	return string(text), nil
+}
+
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
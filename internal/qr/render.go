@@ -0,0 +1,74 @@
+package qr
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// QuietZone is the light border, in modules, that renderings add around a symbol
+const QuietZone = 4
+
+// Terminal writes the symbol with ANSI colors and half blocks, two module rows per
+// line, so that it scans on dark and light terminal themes alike
+func (c *Code) Terminal(w io.Writer, indent string) error {
+	out := bufio.NewWriter(w)
+	colors := func(dark bool) int {
+		if dark {
+			return 30 // black
+		}
+		return 37 // white
+	}
+	for y := -QuietZone; y < c.Size+QuietZone; y += 2 {
+		out.WriteString(indent)
+		for x := -QuietZone; x < c.Size+QuietZone; x++ {
+			// The upper half block takes the top module's color as its foreground
+			fmt.Fprintf(out, "\x1b[%d;%dm▀", colors(c.Dark(x, y)), colors(c.Dark(x, y+1))+10)
+		}
+		out.WriteString("\x1b[0m\n")
+	}
+	return out.Flush()
+}
+
+// Image returns the symbol with its quiet zone, scale pixels per module
+func (c *Code) Image(scale int) *image.Paletted {
+	scale = max(scale, 1)
+	width := (c.Size + 2*QuietZone) * scale
+	img := image.NewPaletted(image.Rect(0, 0, width, width), color.Palette{color.White, color.Black})
+	for y := 0; y < width; y++ {
+		for x := 0; x < width; x++ {
+			if c.Dark(x/scale-QuietZone, y/scale-QuietZone) {
+				img.SetColorIndex(x, y, 1)
+			}
+		}
+	}
+	return img
+}
+
+// PNG writes the symbol as a PNG image, scale pixels per module
+func (c *Code) PNG(w io.Writer, scale int) error {
+	return png.Encode(w, c.Image(scale))
+}
+
+// SVG writes the symbol as an SVG image, scale user units per module
+func (c *Code) SVG(w io.Writer, scale int) error {
+	scale = max(scale, 1)
+	width := c.Size + 2*QuietZone
+	out := bufio.NewWriter(w)
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width*scale, width*scale, width, width)
+	fmt.Fprintf(out, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, width)
+	out.WriteString(`<path fill="#000000" d="`)
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Dark(x, y) {
+				fmt.Fprintf(out, "M%d %dh1v1h-1z", x+QuietZone, y+QuietZone)
+			}
+		}
+	}
+	out.WriteString("\"/>\n</svg>\n")
+	return out.Flush()
+}
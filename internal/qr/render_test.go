@@ -0,0 +1,59 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	c, err := Encode("0x52908400098527886e0f7030069857d2e4169ee7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dark := 0
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Dark(x, y) {
+				dark++
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	if err := c.PNG(&out, 3); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if width := (c.Size + 2*QuietZone) * 3; img.Bounds().Dx() != width || img.Bounds().Dy() != width {
+		t.Errorf("PNG is %v, want %dx%d", img.Bounds(), width, width)
+	}
+	// The finder pattern's top left module is dark and the quiet zone light
+	for _, pixel := range []struct{ x, y int }{{QuietZone * 3, QuietZone * 3}, {0, 0}} {
+		r, _, _, _ := img.At(pixel.x, pixel.y).RGBA()
+		if want := c.Dark(pixel.x/3-QuietZone, pixel.y/3-QuietZone); (r == 0) != want {
+			t.Errorf("pixel %v dark = %v, want %v", pixel, r == 0, want)
+		}
+	}
+
+	out.Reset()
+	if err := c.SVG(&out, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(out.String(), "h1v1h-1z"); got != dark {
+		t.Errorf("SVG draws %d modules, want %d", got, dark)
+	}
+
+	out.Reset()
+	if err := c.Terminal(&out, "  "); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if want := (c.Size + 2*QuietZone + 1) / 2; len(lines) != want || !strings.HasPrefix(lines[0], "  \x1b[") {
+		t.Errorf("terminal output has %d lines, want %d indented", len(lines), want)
+	}
+}
@@ -0,0 +1,198 @@
+// Package retry runs failing operations again with jittered exponential backoff,
+// under per-sink policies read from a JSON config file
+package retry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// EnvPath names the environment variable overriding the default config path
+const EnvPath = "BLOCO_RETRY_CONFIG"
+
+// Policy bounds the retries of an operation. The delay before retry n is
+// InitialDelay * Multiplier^(n-1), capped at MaxDelay, minus a random share of up
+// to Jitter of it so that clients failing together do not retry together.
+type Policy struct {
+	// Attempts is the total number of tries; 1 never retries
+	Attempts     int      `json:"attempts"`
+	InitialDelay Duration `json:"initial_delay"`
+	MaxDelay     Duration `json:"max_delay"`
+	Multiplier   float64  `json:"multiplier"`
+	Jitter       float64  `json:"jitter"`
+}
+
+// Policies holds the policy of every sink
+type Policies struct {
+	Keystore   Policy `json:"keystore"`
+	Notify     Policy `json:"notify"`
+	Vault      Policy `json:"vault"`
+	Checkpoint Policy `json:"checkpoint"`
+}
+
+// Default policies: local writes retry quickly, network deliveries back off longer
+var (
+	DefaultFilePolicy    = Policy{Attempts: 3, InitialDelay: Duration(100 * time.Millisecond), MaxDelay: Duration(2 * time.Second), Multiplier: 2, Jitter: 0.2}
+	DefaultNetworkPolicy = Policy{Attempts: 4, InitialDelay: Duration(time.Second), MaxDelay: Duration(30 * time.Second), Multiplier: 2, Jitter: 0.5}
+)
+
+// DefaultPolicies returns the policies used when the config file sets none
+func DefaultPolicies() Policies {
+	return Policies{
+		Keystore:   DefaultFilePolicy,
+		Notify:     DefaultNetworkPolicy,
+		Vault:      DefaultFilePolicy,
+		Checkpoint: DefaultFilePolicy,
+	}
+}
+
+// jitter returns a random number in [0, 1); tests replace it
+var jitter = rand.Float64
+
+// Validate checks that the policy can be run
+func (p Policy) Validate() error {
+	switch {
+	case p.Attempts < 1:
+		return fmt.Errorf("attempts must be at least 1, got %d", p.Attempts)
+	case p.InitialDelay < 0 || p.MaxDelay < 0:
+		return errors.New("delays cannot be negative")
+	case p.MaxDelay > 0 && p.MaxDelay < p.InitialDelay:
+		return fmt.Errorf("max_delay %s is shorter than initial_delay %s", p.MaxDelay, p.InitialDelay)
+	case p.Multiplier < 1:
+		return fmt.Errorf("multiplier must be at least 1, got %g", p.Multiplier)
+	case p.Jitter < 0 || p.Jitter > 1:
+		return fmt.Errorf("jitter must be between 0 and 1, got %g", p.Jitter)
+	}
+	return nil
+}
+
+// Backoff returns the jittered delay before retry n, counting from 1
+func (p Policy) Backoff(n int) time.Duration {
+	delay := float64(p.InitialDelay) * math.Pow(p.Multiplier, float64(n-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	return time.Duration(delay * (1 - p.Jitter*jitter()))
+}
+
+// permanentError marks an error that retrying cannot fix
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err so that Do returns it without retrying
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+// Do runs op until it succeeds, returns a Permanent error, the policy runs out of
+// attempts or ctx is done. It returns op's last error, with any Permanent mark
+// removed; onRetry, when set, is told of every failure that is retried.
+func Do(ctx context.Context, p Policy, op func() error, onRetry func(attempt int, err error, delay time.Duration)) error {
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+		if attempt >= p.Attempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := p.Backoff(attempt)
+		if onRetry != nil {
+			onRetry(attempt, err, delay)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// DefaultPath returns $BLOCO_RETRY_CONFIG, or retry.json in the user's bloco-eth
+// config directory
+func DefaultPath() (string, error) {
+	if path := os.Getenv(EnvPath); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate the retry config (set %s): %w", EnvPath, err)
+	}
+	return filepath.Join(dir, "bloco-eth", "retry.json"), nil
+}
+
+// Load reads a retry config file. Sinks and fields it leaves out keep their
+// defaults; a missing file is all defaults.
+func Load(path string) (Policies, error) {
+	policies := DefaultPolicies()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return policies, nil
+	}
+	if err != nil {
+		return policies, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&policies); err != nil {
+		return policies, fmt.Errorf("invalid retry config %s: %w", path, err)
+	}
+	for name, p := range map[string]Policy{
+		"keystore":   policies.Keystore,
+		"notify":     policies.Notify,
+		"vault":      policies.Vault,
+		"checkpoint": policies.Checkpoint,
+	} {
+		if err := p.Validate(); err != nil {
+			return policies, fmt.Errorf("invalid %s retry policy in %s: %w", name, path, err)
+		}
+	}
+	return policies, nil
+}
+
+// Duration is a time.Duration written in JSON as a string such as "250ms"
+type Duration time.Duration
+
+// String formats the duration like time.Duration
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON reads a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("durations are strings such as \"500ms\": %w", err)
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
@@ -0,0 +1,98 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	defer func(f func() float64) { jitter = f }(jitter)
+	jitter = func() float64 { return 0 }
+
+	p := Policy{Attempts: 5, InitialDelay: Duration(100 * time.Millisecond), MaxDelay: Duration(time.Second), Multiplier: 3, Jitter: 0.5}
+	for n, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 300 * time.Millisecond, 3: 900 * time.Millisecond, 4: time.Second} {
+		if got := p.Backoff(n); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", n, got, want)
+		}
+	}
+
+	jitter = func() float64 { return 1 }
+	if got := p.Backoff(2); got != 150*time.Millisecond {
+		t.Errorf("Backoff(2) with full jitter = %s, want 150ms", got)
+	}
+}
+
+func TestDo(t *testing.T) {
+	p := Policy{Attempts: 3, InitialDelay: Duration(time.Millisecond), Multiplier: 2}
+	failure := errors.New("disk busy")
+
+	calls, retries := 0, 0
+	err := Do(context.Background(), p, func() error {
+		calls++
+		if calls < 3 {
+			return failure
+		}
+		return nil
+	}, func(int, error, time.Duration) { retries++ })
+	if err != nil || calls != 3 || retries != 2 {
+		t.Errorf("Do() = %v after %d calls and %d retries, want success on the third call", err, calls, retries)
+	}
+
+	calls = 0
+	if err := Do(context.Background(), p, func() error { calls++; return failure }, nil); err != failure || calls != 3 {
+		t.Errorf("Do() = %v after %d calls, want the last error after 3", err, calls)
+	}
+
+	calls = 0
+	if err := Do(context.Background(), p, func() error { calls++; return Permanent(failure) }, nil); err != failure || calls != 1 {
+		t.Errorf("Do() = %v after %d calls, want a permanent error returned unmarked at once", err, calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	if err := Do(ctx, Policy{Attempts: 5, InitialDelay: Duration(time.Hour), Multiplier: 1}, func() error { calls++; return failure }, nil); err != failure || calls != 1 {
+		t.Errorf("Do() with a cancelled context = %v after %d calls, want no retry", err, calls)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	policies, err := Load(filepath.Join(dir, "missing.json"))
+	if err != nil || policies != DefaultPolicies() {
+		t.Fatalf("Load() of a missing file = %+v, %v, want the defaults", policies, err)
+	}
+
+	path := filepath.Join(dir, "retry.json")
+	if err := os.WriteFile(path, []byte(`{"notify":{"attempts":6,"max_delay":"1m"},"vault":{"attempts":1}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policies, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if policies.Notify.Attempts != 6 || policies.Notify.MaxDelay != Duration(time.Minute) || policies.Notify.InitialDelay != DefaultNetworkPolicy.InitialDelay {
+		t.Errorf("notify policy = %+v, want the file's fields over the defaults", policies.Notify)
+	}
+	if policies.Vault.Attempts != 1 || policies.Keystore != DefaultFilePolicy {
+		t.Errorf("policies = %+v", policies)
+	}
+
+	for name, data := range map[string]string{
+		"unknown sink":  `{"webhook":{"attempts":2}}`,
+		"zero attempts": `{"keystore":{"attempts":0}}`,
+		"jitter":        `{"checkpoint":{"jitter":2}}`,
+		"duration":      `{"vault":{"initial_delay":100}}`,
+	} {
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package screening
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// bloomMagic starts every Bloom filter file
+const bloomMagic = "BLOCOBF1"
+
+// maxBloomBits caps the filter size read from a file (512 MiB of bits)
+const maxBloomBits = 1 << 32
+
+// Bloom is a Bloom filter of addresses. It never misses an added address but may
+// report one that was not added, with the false positive rate it was sized for.
+type Bloom struct {
+	name  string
+	bits  []uint64
+	m     uint64
+	k     uint32
+	count uint64
+}
+
+// NewBloom sizes a filter for expected addresses at the given false positive rate
+func NewBloom(expected uint64, falsePositiveRate float64) (*Bloom, error) {
+	if expected == 0 {
+		return nil, fmt.Errorf("a Bloom filter needs at least one expected address")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, fmt.Errorf("false positive rate must be between 0 and 1, got %g", falsePositiveRate)
+	}
+	m := uint64(math.Ceil(-float64(expected) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	m = (m + 63) &^ 63
+	if m > maxBloomBits {
+		return nil, fmt.Errorf("a filter for %d addresses at %g would need %d bits", expected, falsePositiveRate, m)
+	}
+	k := uint32(math.Round(float64(m) / float64(expected) * math.Ln2))
+	if k < 1 {
+		k = 1
+	}
+	return &Bloom{name: "bloom", bits: make([]uint64, m/64), m: m, k: k}, nil
+}
+
+// Add inserts an address into the filter
+func (b *Bloom) Add(address string) {
+	h1, h2 := bloomHashes(address)
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % b.m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+	b.count++
+}
+
+// Contains reports whether the address may have been added
+func (b *Bloom) Contains(address string) bool {
+	h1, h2 := bloomHashes(address)
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % b.m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes derives the two hashes of the double hashing scheme from the
+// normalized address
+func bloomHashes(address string) (uint64, uint64) {
+	sum := sha256.Sum256([]byte(NormalizeAddress(address)))
+	return binary.LittleEndian.Uint64(sum[:8]), binary.LittleEndian.Uint64(sum[8:16]) | 1
+}
+
+// Len returns the number of addresses added to the filter
+func (b *Bloom) Len() uint64 {
+	return b.count
+}
+
+// FalsePositiveRate estimates the chance that an address that was not added is
+// reported anyway
+func (b *Bloom) FalsePositiveRate() float64 {
+	return math.Pow(1-math.Exp(-float64(b.k)*float64(b.count)/float64(b.m)), float64(b.k))
+}
+
+// Name returns the file name the filter was loaded from
+func (b *Bloom) Name() string {
+	return b.name
+}
+
+// Screen reports addresses the filter may contain
+func (b *Bloom) Screen(_ context.Context, address string) (*Match, error) {
+	if !b.Contains(address) {
+		return nil, nil
+	}
+	return &Match{List: b.name, Label: "possible member (Bloom filter)"}, nil
+}
+
+// WriteTo writes the filter in its file format: the magic, the bit count, the hash
+// count and the address count, followed by the bits
+func (b *Bloom) WriteTo(w io.Writer) (int64, error) {
+	buf := bufio.NewWriter(w)
+	header := make([]byte, 0, len(bloomMagic)+20)
+	header = append(header, bloomMagic...)
+	header = binary.LittleEndian.AppendUint64(header, b.m)
+	header = binary.LittleEndian.AppendUint32(header, b.k)
+	header = binary.LittleEndian.AppendUint64(header, b.count)
+	if _, err := buf.Write(header); err != nil {
+		return 0, err
+	}
+	word := make([]byte, 8)
+	for _, bits := range b.bits {
+		binary.LittleEndian.PutUint64(word, bits)
+		if _, err := buf.Write(word); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(header) + 8*len(b.bits)), buf.Flush()
+}
+
+// SaveBloom writes the filter to path, replacing any existing file
+func SaveBloom(path string, b *Bloom) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create Bloom filter: %w", err)
+	}
+	if _, err := b.WriteTo(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write Bloom filter: %w", err)
+	}
+	return file.Close()
+}
+
+// LoadBloom reads a Bloom filter written by SaveBloom
+func LoadBloom(path string) (*Bloom, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Bloom filter: %w", err)
+	}
+	defer file.Close()
+
+	b, err := ReadBloom(filepath.Base(path), file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+// ReadBloom reads a Bloom filter named name from r
+func ReadBloom(name string, r io.Reader) (*Bloom, error) {
+	reader := bufio.NewReader(r)
+	header := make([]byte, len(bloomMagic)+20)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, fmt.Errorf("invalid Bloom filter: %w", err)
+	}
+	if string(header[:len(bloomMagic)]) != bloomMagic {
+		return nil, fmt.Errorf("not a Bloom filter file")
+	}
+	fields := header[len(bloomMagic):]
+	b := &Bloom{
+		name:  name,
+		m:     binary.LittleEndian.Uint64(fields[:8]),
+		k:     binary.LittleEndian.Uint32(fields[8:12]),
+		count: binary.LittleEndian.Uint64(fields[12:20]),
+	}
+	if b.m == 0 || b.m%64 != 0 || b.m > maxBloomBits || b.k == 0 || b.k > 64 {
+		return nil, fmt.Errorf("invalid Bloom filter parameters (%d bits, %d hashes)", b.m, b.k)
+	}
+
+	b.bits = make([]uint64, b.m/64)
+	word := make([]byte, 8)
+	for i := range b.bits {
+		if _, err := io.ReadFull(reader, word); err != nil {
+			return nil, fmt.Errorf("truncated Bloom filter: %w", err)
+		}
+		b.bits[i] = binary.LittleEndian.Uint64(word)
+	}
+	if _, err := reader.ReadByte(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected data after the Bloom filter")
+	}
+	return b, nil
+}
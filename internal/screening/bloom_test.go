@@ -0,0 +1,76 @@
+package screening
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestBloom(t *testing.T) {
+	b, err := NewBloom(1000, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 1000; i++ {
+		b.Add(fmt.Sprintf("0x%040x", i))
+	}
+	for i := 0; i < 1000; i++ {
+		if !b.Contains(fmt.Sprintf("0x%040X", i)) {
+			t.Fatalf("added address %d is missing", i)
+		}
+	}
+	falsePositives := 0
+	for i := 1000; i < 101000; i++ {
+		if b.Contains(fmt.Sprintf("0x%040x", i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / 100000; rate > 0.003 {
+		t.Errorf("false positive rate %v, sized for 0.001", rate)
+	}
+	if b.Len() != 1000 || b.FalsePositiveRate() > 0.0015 {
+		t.Errorf("Len() = %d, FalsePositiveRate() = %v", b.Len(), b.FalsePositiveRate())
+	}
+
+	if _, err := NewBloom(0, 0.01); err == nil {
+		t.Error("expected an error for an empty filter")
+	}
+	if _, err := NewBloom(10, 1); err == nil {
+		t.Error("expected an error for a false positive rate of 1")
+	}
+}
+
+func TestBloom_SaveLoad(t *testing.T) {
+	b, _ := NewBloom(10, 0.01)
+	b.Add("0x1111111111111111111111111111111111111111")
+	path := filepath.Join(t.TempDir(), "org.bloom")
+	if err := SaveBloom(path, b); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadBloom(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Name() != "org.bloom" || loaded.Len() != 1 {
+		t.Errorf("loaded %s with %d addresses", loaded.Name(), loaded.Len())
+	}
+	match, _ := loaded.Screen(context.Background(), "0x1111111111111111111111111111111111111111")
+	if match == nil || match.List != "org.bloom" {
+		t.Errorf("Screen() = %+v", match)
+	}
+
+	var buf bytes.Buffer
+	b.WriteTo(&buf)
+	if _, err := ReadBloom("short", bytes.NewReader(buf.Bytes()[:buf.Len()-1])); err == nil {
+		t.Error("expected an error for a truncated filter")
+	}
+	if _, err := ReadBloom("long", bytes.NewReader(append(buf.Bytes(), 0))); err == nil {
+		t.Error("expected an error for trailing data")
+	}
+	if _, err := ReadBloom("csv", bytes.NewReader([]byte("address,label\n0x11,a\n"))); err == nil {
+		t.Error("expected an error for a file that is not a filter")
+	}
+}
@@ -0,0 +1,147 @@
+// Package screening checks found addresses against lists of addresses that must not
+// be handed out, such as previously used or sanctioned ones. Screeners are
+// pluggable; the lists shipped here are local files, so screening makes no network
+// requests. Word lists reject addresses that spell offensive words anywhere.
+package screening
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Match describes a listed address
+type Match struct {
+	List  string `json:"list"`
+	Label string `json:"label,omitempty"`
+}
+
+// Screener reports whether an address is listed. It returns nil for an address
+// that is not.
+type Screener interface {
+	Name() string
+	Screen(ctx context.Context, address string) (*Match, error)
+}
+
+// Chain screens an address with each screener in turn and returns the first match
+type Chain []Screener
+
+// Name lists the names of the chained screeners
+func (c Chain) Name() string {
+	names := make([]string, len(c))
+	for i, s := range c {
+		names[i] = s.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// Screen returns the first match of the chained screeners
+func (c Chain) Screen(ctx context.Context, address string) (*Match, error) {
+	for _, s := range c {
+		match, err := s.Screen(ctx, address)
+		if err != nil || match != nil {
+			return match, err
+		}
+	}
+	return nil, nil
+}
+
+// List is an in-memory set of addresses loaded from a CSV file
+type List struct {
+	name      string
+	addresses map[string]string
+}
+
+// NormalizeAddress returns the form addresses are compared in. Hex addresses are
+// case-insensitive (EIP-55 only changes the case); base58 and bech32 addresses are
+// compared as given, apart from bech32's lowercase canonical form.
+func NormalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	lower := strings.ToLower(address)
+	if strings.HasPrefix(lower, "0x") || strings.HasPrefix(lower, "bc1") || strings.HasPrefix(lower, "tb1") {
+		return lower
+	}
+	return address
+}
+
+// LoadList reads a CSV file with an address in the first column and an optional
+// label in the second. Blank lines, lines starting with # and an "address" header
+// row are skipped.
+func LoadList(path string) (*List, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open screening list: %w", err)
+	}
+	defer file.Close()
+
+	list, err := ReadList(filepath.Base(path), file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return list, nil
+}
+
+// ReadList reads a screening list named name from r
+func ReadList(name string, r io.Reader) (*List, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	list := &List{name: name, addresses: make(map[string]string)}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid screening list: %w", err)
+		}
+		address := NormalizeAddress(record[0])
+		if address == "" || (first && strings.EqualFold(address, "address")) {
+			continue
+		}
+		label := ""
+		if len(record) > 1 {
+			label = strings.TrimSpace(record[1])
+		}
+		list.addresses[address] = label
+	}
+	if len(list.addresses) == 0 {
+		return nil, fmt.Errorf("screening list has no addresses")
+	}
+	return list, nil
+}
+
+// Name returns the name of the list
+func (l *List) Name() string {
+	return l.name
+}
+
+// Len returns the number of listed addresses
+func (l *List) Len() int {
+	return len(l.addresses)
+}
+
+// Addresses returns the normalized listed addresses in no particular order
+func (l *List) Addresses() []string {
+	addresses := make([]string, 0, len(l.addresses))
+	for address := range l.addresses {
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// Screen reports whether address is on the list
+func (l *List) Screen(_ context.Context, address string) (*Match, error) {
+	label, ok := l.addresses[NormalizeAddress(address)]
+	if !ok {
+		return nil, nil
+	}
+	return &Match{List: l.name, Label: label}, nil
+}
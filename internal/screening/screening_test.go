@@ -0,0 +1,88 @@
+package screening
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadList(t *testing.T) {
+	list, err := ReadList("sanctions.csv", strings.NewReader(`address,label
+# exported 2026-01-01
+0xAbC0000000000000000000000000000000000001, mixer
+bc1QEXAMPLE0000000000000000000000000000
+9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin
+
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", list.Len())
+	}
+
+	ctx := context.Background()
+	for address, want := range map[string]bool{
+		"0xabc0000000000000000000000000000000000001":   true,
+		"0xABC0000000000000000000000000000000000001":   true,
+		"bc1qexample0000000000000000000000000000":      true,
+		"9xQeWvG816bUx9EPjHmaT23yvVM2ZWbrrpZb9PusVFin": true,
+		"9xqewvg816bux9epjhmat23yvvm2zwbrrpzb9pusvfin": false, // base58 is case-sensitive
+		"0xabc0000000000000000000000000000000000002":   false,
+	} {
+		match, err := list.Screen(ctx, address)
+		if err != nil || (match != nil) != want {
+			t.Errorf("Screen(%s) = %+v, %v; want listed=%t", address, match, err, want)
+		}
+	}
+	if match, _ := list.Screen(ctx, "0xabc0000000000000000000000000000000000001"); match.Label != "mixer" || match.List != "sanctions.csv" {
+		t.Errorf("match = %+v", match)
+	}
+
+	if _, err := ReadList("empty.csv", strings.NewReader("address\n")); err == nil {
+		t.Error("expected an error for a list without addresses")
+	}
+}
+
+func TestChain(t *testing.T) {
+	dir := t.TempDir()
+	used := filepath.Join(dir, "used.csv")
+	os.WriteFile(used, []byte("0x1111111111111111111111111111111111111111\n"), 0600)
+	first, err := LoadList(used)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := ReadList("known", strings.NewReader("0x2222222222222222222222222222222222222222,exchange\n"))
+
+	chain := Chain{first, second}
+	if chain.Name() != "used.csv, known" {
+		t.Errorf("Name() = %q", chain.Name())
+	}
+	if match, _ := chain.Screen(context.Background(), "0x2222222222222222222222222222222222222222"); match == nil || match.List != "known" {
+		t.Errorf("Screen() = %+v", match)
+	}
+	if match, _ := chain.Screen(context.Background(), "0x3333333333333333333333333333333333333333"); match != nil {
+		t.Errorf("Screen() of an unlisted address = %+v", match)
+	}
+	if _, err := LoadList(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("expected an error for a missing list")
+	}
+}
+
+func TestReadWords(t *testing.T) {
+	words, err := ReadWords(strings.NewReader("# hex-spellable words\nDEAD\n  b00b \n\ndead\nf4ck\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(words, ",") != "dead,b00b,f4ck" {
+		t.Errorf("words = %v", words)
+	}
+	if _, err := ReadWords(strings.NewReader("# nothing\n")); err == nil {
+		t.Error("expected an error for a list without words")
+	}
+	if _, err := LoadWords(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing word list")
+	}
+}
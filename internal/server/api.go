@@ -0,0 +1,671 @@
+// Package server runs the serve HTTP API: jobs that generate wallets, keyspaces
+// whose leases agents search, and the web UI.
+//
+// Jobs are persisted by FileJobStore as one JSON file rewritten atomically on
+// each change, rather than in an embedded database such as bbolt, which would be
+// a new dependency of the binary. Jobs hold addresses and counters, not keys, and
+// finished ones are pruned after --job-retention, so the file stays small enough
+// to rewrite whole.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"bloco-eth/internal/tracing"
+	"bloco-eth/pkg/errors"
+)
+
+// maxRequestBody limits the size of job submission bodies
+const maxRequestBody = 64 * 1024
+
+// APIServer exposes the job manager over HTTP and WebSocket
+type APIServer struct {
+	addr      string
+	manager   *JobManager
+	health    *HealthServer
+	uiEnabled bool
+	keyring   *Keyring
+	audit     *AuditLog
+	keyspaces *KeyspaceCoordinator
+	patterns  *PatternRunner
+
+	mu       sync.Mutex
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewAPIServer creates an API server for the job manager. Health endpoints report
+// stalled when running jobs make no progress within stallTimeout.
+func NewAPIServer(addr string, manager *JobManager, stallTimeout time.Duration) *APIServer {
+	return &APIServer{
+		addr:    addr,
+		manager: manager,
+		health:  NewHealthServer(addr, manager, stallTimeout),
+	}
+}
+
+// Handler returns the HTTP handler serving all API routes
+func (s *APIServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs", s.handleSubmitJob)
+	mux.HandleFunc("GET /jobs", s.handleListJobs)
+	mux.HandleFunc("GET /jobs/{id}", s.handleGetJob)
+	mux.HandleFunc("DELETE /jobs/{id}", s.handleCancelJob)
+	mux.HandleFunc("POST /jobs/{id}/pause", s.handlePauseJob)
+	mux.HandleFunc("POST /jobs/{id}/resume", s.handleResumeJob)
+	mux.HandleFunc("POST /jobs/{id}/retry", s.handleRetryJob)
+	mux.HandleFunc("GET /ws/jobs/{id}", s.handleJobProgress)
+	if s.keyspaces != nil {
+		mux.HandleFunc("POST /keyspaces", s.handleCreateKeyspace)
+		mux.HandleFunc("GET /keyspaces", s.handleListKeyspaces)
+		mux.HandleFunc("GET /keyspaces/{id}", s.handleGetKeyspace)
+		mux.HandleFunc("POST /keyspaces/{id}/leases", s.handleAcquireLease)
+		mux.HandleFunc("POST /keyspaces/{id}/leases/{lease}/renew", s.handleRenewLease)
+		mux.HandleFunc("POST /keyspaces/{id}/leases/{lease}/complete", s.handleCompleteLease)
+		mux.HandleFunc("DELETE /keyspaces/{id}/leases/{lease}", s.handleAbandonLease)
+	}
+	if s.patterns != nil {
+		mux.HandleFunc("GET /patterns", s.handlePatternStatus)
+		mux.HandleFunc("POST /patterns", s.handleAddPatterns)
+		mux.HandleFunc("DELETE /patterns/{pattern}", s.handleRemovePattern)
+	}
+	s.health.Register(mux)
+	if s.uiEnabled {
+		registerUI(mux)
+	}
+	if s.keyring != nil {
+		return s.requireAPIKey(mux)
+	}
+	return mux
+}
+
+// SetUIEnabled controls whether the embedded web dashboard is served at /
+func (s *APIServer) SetUIEnabled(enabled bool) {
+	s.uiEnabled = enabled
+}
+
+// SetKeyring requires a valid API key on every job route. Non-admin keys only see
+// their own jobs and are subject to their quota.
+func (s *APIServer) SetKeyring(keyring *Keyring) {
+	s.keyring = keyring
+}
+
+// SetAuditLog records job submissions and state changes to log
+func (s *APIServer) SetAuditLog(log *AuditLog) {
+	s.audit = log
+}
+
+// SetKeyspaceCoordinator serves the keyspace lease routes used by distributed agents
+func (s *APIServer) SetKeyspaceCoordinator(coordinator *KeyspaceCoordinator) {
+	s.keyspaces = coordinator
+}
+
+// SetPatternRunner serves the pattern set routes, which only admin keys may use
+func (s *APIServer) SetPatternRunner(runner *PatternRunner) {
+	s.patterns = runner
+}
+
+// Start begins serving on the configured address
+func (s *APIServer) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.server != nil {
+		return errors.NewWorkerError("api_server_start", "api server already started")
+	}
+
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"api_server_start", "failed to listen on "+s.addr)
+	}
+
+	s.listener = listener
+	s.server = &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+
+	return nil
+}
+
+// Addr returns the address the server is listening on
+func (s *APIServer) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener != nil {
+		return s.listener.Addr().String()
+	}
+	return s.addr
+}
+
+// Shutdown stops accepting requests. Hijacked WebSocket connections are closed
+// when their jobs are cancelled by the job manager.
+func (s *APIServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.server
+	s.server = nil
+	s.listener = nil
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// handleSubmitJob queues a new generation job
+func (s *APIServer) handleSubmitJob(w http.ResponseWriter, r *http.Request) {
+	if !requireJSON(w, r) {
+		return
+	}
+
+	var req JobRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid job request: "+err.Error())
+		return
+	}
+
+	// A traceparent header from the caller joins the job to the caller's trace
+	ctx := tracing.Extract(r.Context(), r.Header)
+	job, err := s.manager.SubmitAsWithContext(ctx, requestOwner(r), req)
+	if err != nil {
+		s.recordAudit(r, "submit", Job{Request: req}, writeManagerError(w, err), err)
+		return
+	}
+
+	s.recordAudit(r, "submit", job, http.StatusAccepted, nil)
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+// handleListJobs returns all jobs visible to the caller
+func (s *APIServer) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	jobs := []Job{}
+	for _, job := range s.manager.List() {
+		if canAccess(r, job) {
+			jobs = append(jobs, job)
+		}
+	}
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+// handleGetJob returns a single job
+func (s *APIServer) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.lookupJob(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// handleCancelJob cancels a queued or running job
+func (s *APIServer) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	current, ok := s.lookupJob(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+
+	job, err := s.manager.Cancel(current.ID)
+	if err != nil {
+		s.recordAudit(r, "cancel", current, writeManagerError(w, err), err)
+		return
+	}
+	s.recordAudit(r, "cancel", job, http.StatusOK, nil)
+	writeJSON(w, http.StatusOK, job)
+}
+
+// handlePauseJob pauses a queued or running job
+func (s *APIServer) handlePauseJob(w http.ResponseWriter, r *http.Request) {
+	s.handleJobAction(w, r, "pause", s.manager.Pause)
+}
+
+// handleResumeJob resumes a paused job
+func (s *APIServer) handleResumeJob(w http.ResponseWriter, r *http.Request) {
+	s.handleJobAction(w, r, "resume", s.manager.Resume)
+}
+
+// handleRetryJob requeues a failed or cancelled job
+func (s *APIServer) handleRetryJob(w http.ResponseWriter, r *http.Request) {
+	s.handleJobAction(w, r, "retry", s.manager.Retry)
+}
+
+// handleJobAction applies a state change to an existing job
+func (s *APIServer) handleJobAction(w http.ResponseWriter, r *http.Request, name string, action func(string) (Job, error)) {
+	if !requireJSON(w, r) {
+		return
+	}
+
+	current, ok := s.lookupJob(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+
+	job, err := action(current.ID)
+	if err != nil {
+		status := http.StatusConflict
+		if IsQuotaError(err) || !errors.IsErrorType(err, errors.ErrorTypeValidation) {
+			status = writeManagerError(w, err)
+		} else {
+			writeError(w, status, err.Error())
+		}
+		s.recordAudit(r, name, current, status, err)
+		return
+	}
+	s.recordAudit(r, name, job, http.StatusOK, nil)
+	writeJSON(w, http.StatusOK, job)
+}
+
+// handleJobProgress streams progress events for a job over a WebSocket.
+// Each text frame holds one JSON encoded ProgressEvent; the server closes the
+// connection with a normal closure after the final event.
+func (s *APIServer) handleJobProgress(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := s.lookupJob(r); !ok {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+
+	if !sameOrigin(r) {
+		writeError(w, http.StatusForbidden, "cross-origin websocket connections are not allowed")
+		return
+	}
+
+	events, unsubscribe, err := s.manager.Subscribe(id)
+	if err != nil {
+		writeManagerError(w, err)
+		return
+	}
+	defer unsubscribe()
+
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	go conn.readLoop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				_ = conn.Close(1000)
+				return
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				_ = conn.Close(1011)
+				return
+			}
+			if err := conn.WriteText(payload); err != nil {
+				_ = conn.shutdown()
+				return
+			}
+		case <-conn.Done():
+			return
+		}
+	}
+}
+
+// handleCreateKeyspace starts a distributed search over a seeded keyspace
+func (s *APIServer) handleCreateKeyspace(w http.ResponseWriter, r *http.Request) {
+	var req KeyspaceRequest
+	if !decodeJSONBody(w, r, &req, "invalid keyspace request") {
+		return
+	}
+	ks, err := s.keyspaces.Create(requestOwner(r), req)
+	if err != nil {
+		writeManagerError(w, err)
+		return
+	}
+	w.Header().Set("Location", "/keyspaces/"+ks.ID)
+	writeJSON(w, http.StatusCreated, ks)
+}
+
+// handleListKeyspaces returns all keyspaces visible to the caller; ?shared=true
+// lists only the shared keyspaces still being searched, for donating agents
+func (s *APIServer) handleListKeyspaces(w http.ResponseWriter, r *http.Request) {
+	sharedOnly := r.URL.Query().Get("shared") == "true"
+	keyspaces := []Keyspace{}
+	for _, ks := range s.keyspaces.List() {
+		if sharedOnly && (!ks.Request.Shared || ks.State != KeyspaceSearching) {
+			continue
+		}
+		if ks, ok := keyspaceView(r, ks); ok {
+			keyspaces = append(keyspaces, ks)
+		}
+	}
+	writeJSON(w, http.StatusOK, keyspaces)
+}
+
+// handleGetKeyspace returns a keyspace with its coverage and live leases
+func (s *APIServer) handleGetKeyspace(w http.ResponseWriter, r *http.Request) {
+	ks, ok := s.lookupKeyspace(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "keyspace not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, ks)
+}
+
+// handleAcquireLease leases the next keys of a keyspace to the calling agent
+func (s *APIServer) handleAcquireLease(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Agent string `json:"agent"`
+	}
+	if !decodeJSONBody(w, r, &req, "invalid lease request") {
+		return
+	}
+	ks, ok := s.lookupKeyspace(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "keyspace not found")
+		return
+	}
+	lease, err := s.keyspaces.Acquire(ks.ID, req.Agent)
+	if err != nil {
+		writeLeaseError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, lease)
+}
+
+// handleRenewLease extends a lease and records the agent's progress
+func (s *APIServer) handleRenewLease(w http.ResponseWriter, r *http.Request) {
+	var report LeaseReport
+	if !decodeJSONBody(w, r, &report, "invalid lease report") {
+		return
+	}
+	ks, ok := s.lookupKeyspace(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "keyspace not found")
+		return
+	}
+	lease, err := s.keyspaces.Renew(ks.ID, r.PathValue("lease"), report)
+	if err != nil {
+		writeLeaseError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, lease)
+}
+
+// handleCompleteLease records a fully searched lease
+func (s *APIServer) handleCompleteLease(w http.ResponseWriter, r *http.Request) {
+	var report LeaseReport
+	if !decodeJSONBody(w, r, &report, "invalid lease report") {
+		return
+	}
+	ks, ok := s.lookupKeyspace(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "keyspace not found")
+		return
+	}
+	ks, err := s.keyspaces.Complete(ks.ID, r.PathValue("lease"), report)
+	if err != nil {
+		writeLeaseError(w, err)
+		return
+	}
+	ks, _ = keyspaceView(r, ks)
+	writeJSON(w, http.StatusOK, ks)
+}
+
+// handleAbandonLease gives a lease back so another agent searches it
+func (s *APIServer) handleAbandonLease(w http.ResponseWriter, r *http.Request) {
+	ks, ok := s.lookupKeyspace(r)
+	if !ok {
+		writeError(w, http.StatusNotFound, "keyspace not found")
+		return
+	}
+	if err := s.keyspaces.Abandon(ks.ID, r.PathValue("lease")); err != nil {
+		writeLeaseError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// patternsRequest is the body of POST /patterns
+type patternsRequest struct {
+	Patterns []PatternSpec `json:"patterns"`
+}
+
+// handlePatternStatus returns the pattern set with its combined difficulty and ETA
+func (s *APIServer) handlePatternStatus(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.patterns.Status())
+}
+
+// handleAddPatterns adds patterns to the running pattern set
+func (s *APIServer) handleAddPatterns(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+	var req patternsRequest
+	if !decodeJSONBody(w, r, &req, "invalid patterns request") {
+		return
+	}
+	if len(req.Patterns) == 0 {
+		writeError(w, http.StatusBadRequest, "no patterns given")
+		return
+	}
+	added, err := s.patterns.Add(PatternSourceAPI, req.Patterns)
+	if err != nil {
+		writeManagerError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, added)
+}
+
+// handleRemovePattern drops a pattern from the pattern set
+func (s *APIServer) handleRemovePattern(w http.ResponseWriter, r *http.Request) {
+	if !requireAdmin(w, r) {
+		return
+	}
+	if !s.patterns.Remove(r.PathValue("pattern")) {
+		writeError(w, http.StatusNotFound, "pattern not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireAdmin rejects callers authenticated with a non-admin key
+func requireAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if key, ok := apiKeyFrom(r.Context()); ok && !key.Admin {
+		writeError(w, http.StatusForbidden, "the pattern set needs an admin API key")
+		return false
+	}
+	return true
+}
+
+// requireAPIKey authenticates every request except health probes and dashboard assets
+func (s *APIServer) requireAPIKey(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/jobs") && !strings.HasPrefix(r.URL.Path, "/ws/") &&
+			!strings.HasPrefix(r.URL.Path, "/keyspaces") && !strings.HasPrefix(r.URL.Path, "/patterns") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key, ok := s.keyring.Authenticate(requestToken(r))
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="bloco-eth"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid API key")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(withAPIKey(r.Context(), key)))
+	})
+}
+
+// lookupJob returns the job named in the path if the caller may access it
+func (s *APIServer) lookupJob(r *http.Request) (Job, bool) {
+	job, ok := s.manager.Get(r.PathValue("id"))
+	if !ok || !canAccess(r, job) {
+		return Job{}, false
+	}
+	return job, true
+}
+
+// lookupKeyspace returns the keyspace named in the path if the caller may access it
+func (s *APIServer) lookupKeyspace(r *http.Request) (Keyspace, bool) {
+	ks, ok := s.keyspaces.Get(r.PathValue("id"))
+	if !ok {
+		return Keyspace{}, false
+	}
+	return keyspaceView(r, ks)
+}
+
+// keyspaceView returns ks as the caller may see it: in full to its owner, as the
+// public view to others when it is shared, and not at all otherwise
+func keyspaceView(r *http.Request, ks Keyspace) (Keyspace, bool) {
+	switch {
+	case ownedByCaller(r, ks.Owner):
+		return ks, true
+	case ks.Request.Shared:
+		return ks.Public(), true
+	}
+	return Keyspace{}, false
+}
+
+// canAccess reports whether the caller may see job. Without authentication every
+// job is visible; admin keys see all jobs and other keys only their own.
+func canAccess(r *http.Request, job Job) bool {
+	return ownedByCaller(r, job.Owner)
+}
+
+// ownedByCaller reports whether the caller may access a resource of owner
+func ownedByCaller(r *http.Request, owner string) bool {
+	key, ok := apiKeyFrom(r.Context())
+	return !ok || key.Admin || owner == key.Name
+}
+
+// requestOwner returns the name of the authenticated key, or "" without authentication
+func requestOwner(r *http.Request) string {
+	key, _ := apiKeyFrom(r.Context())
+	return key.Name
+}
+
+// recordAudit appends an audit entry when an audit log is configured
+func (s *APIServer) recordAudit(r *http.Request, action string, job Job, status int, err error) {
+	if s.audit == nil {
+		return
+	}
+
+	entry := AuditEntry{
+		Key:        requestOwner(r),
+		Action:     action,
+		JobID:      job.ID,
+		Prefix:     job.Request.Prefix,
+		Suffix:     job.Request.Suffix,
+		Checksum:   job.Request.Checksum,
+		Count:      job.Request.Count,
+		RemoteAddr: r.RemoteAddr,
+		Status:     status,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if err := s.audit.Record(entry); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to record audit entry: %v\n", err)
+	}
+}
+
+// requireJSON rejects state-changing requests without a JSON content type. Browsers
+// cannot send such requests cross-origin without a CORS preflight, which the
+// server never approves.
+func requireJSON(w http.ResponseWriter, r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+		return false
+	}
+	return true
+}
+
+// decodeJSONBody decodes a JSON request body into v, writing a 4xx response on failure
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, v interface{}, invalid string) bool {
+	if !requireJSON(w, r) {
+		return false
+	}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, invalid+": "+err.Error())
+		return false
+	}
+	return true
+}
+
+// sameOrigin reports whether a browser request originates from the server's own host
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// writeManagerError maps job manager errors to HTTP status codes and returns the status written
+func writeManagerError(w http.ResponseWriter, err error) int {
+	status := http.StatusInternalServerError
+	switch {
+	case IsQuotaError(err):
+		status = http.StatusTooManyRequests
+		if errors.GetErrorContext(err)[quotaContextKey] == "max_difficulty" {
+			status = http.StatusForbidden
+		}
+	case errors.IsErrorType(err, errors.ErrorTypeValidation):
+		status = http.StatusBadRequest
+	case errors.IsErrorType(err, errors.ErrorTypeCancellation):
+		status = http.StatusServiceUnavailable
+	}
+	writeError(w, status, err.Error())
+	return status
+}
+
+// writeLeaseError maps lease outcomes to HTTP status codes: 410 tells the agent to
+// drop its lease, 409 to ask for one again later
+func writeLeaseError(w http.ResponseWriter, err error) {
+	switch {
+	case IsLeaseGone(err):
+		writeError(w, http.StatusGone, err.Error())
+	case IsLeaseUnavailable(err):
+		w.Header().Set("Retry-After", "10")
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeManagerError(w, err)
+	}
+}
+
+// writeError writes a JSON error body
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
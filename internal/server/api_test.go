@@ -0,0 +1,489 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+// newTestManager creates a job manager using single-thread pools and recording saved wallets
+func newTestManager(t *testing.T) (*JobManager, *[]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	saved := []string{}
+	manager := NewJobManager(func(network string) (worker.WorkerPool, error) {
+		return worker.NewPool(1, network), nil
+	}, func(ctx context.Context, w *wallet.Wallet) error {
+		// Sinks see the criteria of the job that found the wallet
+		if criteria, ok := JobCriteria(ctx); !ok || !strings.HasPrefix(strings.ToLower(w.Address), "0x"+criteria.Prefix) {
+			return fmt.Errorf("wallet %s saved with criteria %+v", w.Address, criteria)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		saved = append(saved, w.Address)
+		return nil
+	}, 1)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = manager.Shutdown(ctx)
+	})
+	return manager, &saved
+}
+
+// waitForState polls until the job reaches a final state
+func waitForState(t *testing.T, manager *JobManager, id string) Job {
+	t.Helper()
+
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		job, ok := manager.Get(id)
+		if !ok {
+			t.Fatalf("job %s not found", id)
+		}
+		if job.State.IsFinal() {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return Job{}
+}
+
+func TestJobRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     JobRequest
+		wantErr bool
+	}{
+		{name: "prefix only", req: JobRequest{Prefix: "abc"}},
+		{name: "defaults count to one", req: JobRequest{Suffix: "99"}},
+		{name: "missing pattern", req: JobRequest{}, wantErr: true},
+		{name: "invalid hex", req: JobRequest{Prefix: "xyz"}, wantErr: true},
+		{name: "count too large", req: JobRequest{Prefix: "a", Count: MaxJobWallets + 1}, wantErr: true},
+		{name: "negative count", req: JobRequest{Prefix: "a", Count: -1}, wantErr: true},
+		{name: "reject words", req: JobRequest{Prefix: "a", RejectWords: []string{"DEAD", "b00b"}}},
+		{name: "pattern spells a reject word", req: JobRequest{Prefix: "dead", RejectWords: []string{"DEAD"}}, wantErr: true},
+		{name: "empty reject word", req: JobRequest{Prefix: "a", RejectWords: []string{""}}, wantErr: true},
+		{name: "priority", req: JobRequest{Prefix: "a", Priority: "HIGH"}},
+		{name: "unknown priority", req: JobRequest{Prefix: "a", Priority: "urgent"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && tt.req.Count != 1 {
+				t.Errorf("expected count to default to 1")
+			}
+		})
+	}
+}
+
+func TestJobManager_CompletesJob(t *testing.T) {
+	manager, saved := newTestManager(t)
+
+	job, err := manager.Submit(JobRequest{Prefix: "a", Count: 2})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if job.State != JobQueued {
+		t.Errorf("initial state = %s, expected queued", job.State)
+	}
+
+	job = waitForState(t, manager, job.ID)
+	if job.State != JobCompleted {
+		t.Fatalf("state = %s (error %q), expected completed", job.State, job.Error)
+	}
+	if len(job.Addresses) != 2 || len(*saved) != 2 {
+		t.Errorf("expected 2 addresses and 2 saved wallets, got %v and %v", job.Addresses, *saved)
+	}
+	for _, addr := range job.Addresses {
+		if !strings.HasPrefix(strings.ToLower(addr), "0xa") {
+			t.Errorf("address %s does not match prefix", addr)
+		}
+	}
+}
+
+func TestJobManager_PriorityQueue(t *testing.T) {
+	manager, saved := newTestManager(t)
+	manager.config.Scheduler = worker.NewScheduler(1)
+
+	blocker, err := manager.Submit(JobRequest{Prefix: "abcdefabcdef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.waitState(blocker.ID, func(s JobState) bool { return s == JobRunning })
+
+	// The high priority job overtakes the low one queued before it
+	low, err := manager.Submit(JobRequest{Prefix: "c", Priority: "low"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	high, err := manager.Submit(JobRequest{Prefix: "d", Priority: "high"})
+	if err != nil || high.Request.Priority != "high" {
+		t.Fatalf("Submit() = %+v, %v", high, err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, err := manager.Cancel(blocker.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	waitForState(t, manager, low.ID)
+	waitForState(t, manager, high.ID)
+	if len(*saved) != 2 || !strings.HasPrefix((*saved)[0], "0xd") {
+		t.Errorf("saved %v, want the high priority wallet first", *saved)
+	}
+}
+
+func TestJobManager_Cancel(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	// Nearly impossible pattern so the job keeps running until cancelled
+	job, err := manager.Submit(JobRequest{Prefix: "abcdefabcdef"})
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	job, err = manager.Cancel(job.ID)
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if job.State != JobCancelled {
+		t.Errorf("state = %s, expected cancelled", job.State)
+	}
+
+	if _, err := manager.Cancel("missing"); err == nil {
+		t.Error("expected error cancelling unknown job")
+	}
+}
+
+func TestAPIServer_JobRoutes(t *testing.T) {
+	manager, _ := newTestManager(t)
+	ts := httptest.NewServer(NewAPIServer("127.0.0.1:0", manager, time.Minute).Handler())
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/jobs", "application/json", strings.NewReader(`{"prefix":"zz"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid pattern status = %d, expected 400", resp.StatusCode)
+	}
+
+	resp, err = http.Post(ts.URL+"/jobs", "application/json", strings.NewReader(`{"prefix":"a"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var job Job
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted || job.ID == "" {
+		t.Fatalf("submit status = %d, job = %+v", resp.StatusCode, job)
+	}
+
+	waitForState(t, manager, job.ID)
+
+	resp, err = http.Get(ts.URL + "/jobs/" + job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if job.State != JobCompleted || len(job.Addresses) != 1 {
+		t.Errorf("unexpected job: %+v", job)
+	}
+
+	resp, err = http.Get(ts.URL + "/jobs/missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing job status = %d, expected 404", resp.StatusCode)
+	}
+}
+
+func TestWebsocketAccept(t *testing.T) {
+	// Example handshake from RFC 6455 section 1.3
+	if got := websocketAccept("dGhlIHNhbXBsZSBub25jZQ=="); got != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("websocketAccept() = %s", got)
+	}
+}
+
+func TestAPIServer_ProgressWebSocket(t *testing.T) {
+	manager, _ := newTestManager(t)
+	ts := httptest.NewServer(NewAPIServer("127.0.0.1:0", manager, time.Minute).Handler())
+	defer ts.Close()
+
+	job, err := manager.Submit(JobRequest{Prefix: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(ts.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(60 * time.Second))
+
+	keyBytes := make([]byte, 16)
+	_, _ = rand.Read(keyBytes)
+	key := base64.StdEncoding.EncodeToString(keyBytes)
+	request := "GET /ws/jobs/" + job.ID + " HTTP/1.1\r\n" +
+		"Host: localhost\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: " + key + "\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"
+	if _, err := conn.Write([]byte(request)); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("handshake status = %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Sec-WebSocket-Accept") != websocketAccept(key) {
+		t.Fatalf("unexpected Sec-WebSocket-Accept %q", resp.Header.Get("Sec-WebSocket-Accept"))
+	}
+
+	var events []ProgressEvent
+	for {
+		opcode, payload := readServerFrame(t, reader)
+		if opcode == opClose {
+			if len(payload) < 2 || binary.BigEndian.Uint16(payload) != 1000 {
+				t.Errorf("unexpected close payload %v", payload)
+			}
+			break
+		}
+		if opcode != opText {
+			t.Fatalf("unexpected opcode %d", opcode)
+		}
+		var event ProgressEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			t.Fatalf("invalid event %s: %v", payload, err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) == 0 {
+		t.Fatal("expected at least one progress event")
+	}
+	final := events[len(events)-1]
+	if !final.IsComplete || final.State != JobCompleted || final.CompletedWallets != 1 {
+		t.Errorf("unexpected final event: %+v", final)
+	}
+	if final.Pattern != "abc" || final.Difficulty != 4096 {
+		t.Errorf("unexpected pattern data: %+v", final)
+	}
+	if final.Attempts == 0 || final.Workers == nil {
+		t.Errorf("expected attempts and worker stats in final event: %+v", final)
+	}
+}
+
+func TestAPIServer_WebSocketRejectsPlainRequest(t *testing.T) {
+	manager, _ := newTestManager(t)
+	handler := NewAPIServer("127.0.0.1:0", manager, time.Minute).Handler()
+
+	job, err := manager.Submit(JobRequest{Prefix: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws/jobs/"+job.ID, nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, expected 400", rec.Code)
+	}
+}
+
+// readServerFrame reads one unmasked frame sent by the server
+func readServerFrame(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	t.Helper()
+
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatalf("failed to read frame header: %v", err)
+	}
+	if header[1]&0x80 != 0 {
+		t.Fatal("server frames must not be masked")
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		_, _ = io.ReadFull(r, ext[:])
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		_, _ = io.ReadFull(r, ext[:])
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatalf("failed to read frame payload: %v", err)
+	}
+	return header[0] & 0x0F, payload
+}
+
+func TestJobManager_PauseResume(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	job, err := manager.Submit(JobRequest{Prefix: "abcdefabcdef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err = manager.Pause(job.ID)
+	if err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if job.State != JobPaused {
+		t.Fatalf("state = %s, expected paused", job.State)
+	}
+	if _, err := manager.Pause(job.ID); err == nil {
+		t.Error("expected error pausing a paused job")
+	}
+
+	job, err = manager.Resume(job.ID)
+	if err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if job.State != JobQueued {
+		t.Errorf("state after resume = %s, expected queued", job.State)
+	}
+
+	job, err = manager.Cancel(job.ID)
+	if err != nil || job.State != JobCancelled {
+		t.Errorf("Cancel() = %s, %v; expected cancelled", job.State, err)
+	}
+	if _, err := manager.Resume(job.ID); err == nil {
+		t.Error("expected error resuming a cancelled job")
+	}
+}
+
+func TestJobManager_CancelPaused(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	job, err := manager.Submit(JobRequest{Prefix: "abcdefabcdef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Pause(job.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	events, unsubscribe, err := manager.Subscribe(job.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unsubscribe()
+
+	job, err = manager.Cancel(job.ID)
+	if err != nil || job.State != JobCancelled {
+		t.Fatalf("Cancel() = %s, %v; expected cancelled", job.State, err)
+	}
+
+	var last ProgressEvent
+	for event := range events {
+		last = event
+	}
+	if last.State != JobCancelled || !last.IsComplete {
+		t.Errorf("expected final cancelled event, got %+v", last)
+	}
+}
+
+func TestAPIServer_RequiresJSONContentType(t *testing.T) {
+	manager, _ := newTestManager(t)
+	handler := NewAPIServer("127.0.0.1:0", manager, time.Minute).Handler()
+
+	req := httptest.NewRequest(http.MethodPost, "/jobs", strings.NewReader(`{"prefix":"a"}`))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, expected 415", rec.Code)
+	}
+	if len(manager.List()) != 0 {
+		t.Error("job should not be submitted without a JSON content type")
+	}
+}
+
+func TestAPIServer_WebSocketRejectsCrossOrigin(t *testing.T) {
+	manager, _ := newTestManager(t)
+	handler := NewAPIServer("127.0.0.1:0", manager, time.Minute).Handler()
+
+	job, err := manager.Submit(JobRequest{Prefix: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/ws/jobs/"+job.ID, nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, expected 403", rec.Code)
+	}
+}
+
+func TestAPIServer_UI(t *testing.T) {
+	manager, _ := newTestManager(t)
+	server := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("dashboard should be disabled by default, got status %d", rec.Code)
+	}
+
+	server.SetUIEnabled(true)
+	handler := server.Handler()
+	for path, contains := range map[string]string{
+		"/":             "Bloco Dashboard",
+		"/ui/app.js":    "/ws/jobs/",
+		"/ui/style.css": "--accent",
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), contains) {
+			t.Errorf("%s: status %d, body missing %q", path, rec.Code, contains)
+		}
+		if rec.Header().Get("Content-Security-Policy") == "" {
+			t.Errorf("%s: missing Content-Security-Policy header", path)
+		}
+	}
+}
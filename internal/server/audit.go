@@ -0,0 +1,68 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/errors"
+)
+
+// AuditEntry records one state-changing API request
+type AuditEntry struct {
+	Time       time.Time `json:"time"`
+	Key        string    `json:"key"`
+	Action     string    `json:"action"`
+	JobID      string    `json:"job_id,omitempty"`
+	Prefix     string    `json:"prefix,omitempty"`
+	Suffix     string    `json:"suffix,omitempty"`
+	Checksum   bool      `json:"checksum,omitempty"`
+	Count      int       `json:"count,omitempty"`
+	RemoteAddr string    `json:"remote_addr"`
+	Status     int       `json:"status"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// AuditLog appends audit entries as JSON lines
+type AuditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// OpenAuditLog opens or creates an append-only audit log
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"open_audit_log", fmt.Sprintf("failed to open audit log %s", path))
+	}
+	return &AuditLog{file: file}, nil
+}
+
+// Record appends an entry and syncs it to disk
+func (a *AuditLog) Record(entry AuditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "write_audit_log", "failed to encode entry")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, err := a.file.Write(append(line, '\n')); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "write_audit_log", "failed to write entry")
+	}
+	return a.file.Sync()
+}
+
+// Close closes the audit log
+func (a *AuditLog) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.file.Close()
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"bloco-eth/pkg/errors"
+)
+
+// APIKey identifies a client of the serve API. Only the SHA-256 of the token is stored.
+type APIKey struct {
+	Name        string `json:"name"`
+	TokenSHA256 string `json:"token_sha256"`
+	Admin       bool   `json:"admin,omitempty"`
+	Quota
+}
+
+// Keyring authenticates bearer tokens against a set of API keys
+type Keyring struct {
+	keys   []APIKey
+	hashes [][]byte
+}
+
+// authContextKey stores the authenticated key in the request context
+type authContextKey struct{}
+
+// HashAPIToken returns the hex SHA-256 digest stored for a token
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewKeyring validates keys and creates a keyring
+func NewKeyring(keys []APIKey) (*Keyring, error) {
+	keyring := &Keyring{}
+	names := make(map[string]bool)
+
+	for i, key := range keys {
+		if key.Name == "" {
+			return nil, errors.NewConfigurationError("load_api_keys", fmt.Sprintf("key %d has no name", i))
+		}
+		if names[key.Name] {
+			return nil, errors.NewConfigurationError("load_api_keys", fmt.Sprintf("duplicate key name %q", key.Name))
+		}
+		hash, err := hex.DecodeString(strings.ToLower(key.TokenSHA256))
+		if err != nil || len(hash) != sha256.Size {
+			return nil, errors.NewConfigurationError("load_api_keys",
+				fmt.Sprintf("key %q must have a hex encoded token_sha256", key.Name))
+		}
+		if key.MaxConcurrentJobs < 0 || key.MaxDifficulty < 0 || key.CPUSeconds < 0 {
+			return nil, errors.NewConfigurationError("load_api_keys",
+				fmt.Sprintf("key %q has a negative quota", key.Name))
+		}
+
+		names[key.Name] = true
+		keyring.keys = append(keyring.keys, key)
+		keyring.hashes = append(keyring.hashes, hash)
+	}
+
+	if len(keyring.keys) == 0 {
+		return nil, errors.NewConfigurationError("load_api_keys", "no API keys defined")
+	}
+	return keyring, nil
+}
+
+// LoadKeyring reads API keys from a JSON array file
+func LoadKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"load_api_keys", fmt.Sprintf("failed to read API keys %s", path))
+	}
+
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"load_api_keys", "API keys file must be a JSON array of keys")
+	}
+	return NewKeyring(keys)
+}
+
+// Authenticate returns the key matching token. Every key is compared so timing
+// does not reveal which key matched.
+func (k *Keyring) Authenticate(token string) (APIKey, bool) {
+	if token == "" {
+		return APIKey{}, false
+	}
+	sum := sha256.Sum256([]byte(token))
+
+	match := -1
+	for i, hash := range k.hashes {
+		if subtle.ConstantTimeCompare(sum[:], hash) == 1 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return APIKey{}, false
+	}
+	return k.keys[match], true
+}
+
+// QuotaFor returns the quota of the named key
+func (k *Keyring) QuotaFor(name string) Quota {
+	for _, key := range k.keys {
+		if key.Name == name {
+			return key.Quota
+		}
+	}
+	return Quota{}
+}
+
+// requestToken extracts the bearer token. WebSocket clients in browsers cannot set
+// headers, so the progress stream also accepts an access_token query parameter.
+func requestToken(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, ok := strings.Cut(header, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	if strings.HasPrefix(r.URL.Path, "/ws/") {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
+
+// withAPIKey stores the authenticated key in the context
+func withAPIKey(ctx context.Context, key APIKey) context.Context {
+	return context.WithValue(ctx, authContextKey{}, key)
+}
+
+// apiKeyFrom returns the authenticated key, if any
+func apiKeyFrom(ctx context.Context) (APIKey, bool) {
+	key, ok := ctx.Value(authContextKey{}).(APIKey)
+	return key, ok
+}
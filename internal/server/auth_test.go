@@ -0,0 +1,262 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testKeyring creates a keyring with an admin key, a quota-limited key and an unlimited key
+func testKeyring(t *testing.T) *Keyring {
+	t.Helper()
+
+	keyring, err := NewKeyring([]APIKey{
+		{Name: "admin", TokenSHA256: HashAPIToken("admin-token"), Admin: true},
+		{Name: "alice", TokenSHA256: HashAPIToken("alice-token"), Quota: Quota{MaxConcurrentJobs: 1, MaxDifficulty: 1e6}},
+		{Name: "bob", TokenSHA256: HashAPIToken("bob-token")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
+
+// authRequest sends a request with an optional bearer token
+func authRequest(t *testing.T, handler http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewKeyring_Validation(t *testing.T) {
+	hash := HashAPIToken("token")
+	tests := []struct {
+		name string
+		keys []APIKey
+	}{
+		{name: "empty", keys: nil},
+		{name: "missing name", keys: []APIKey{{TokenSHA256: hash}}},
+		{name: "duplicate name", keys: []APIKey{{Name: "a", TokenSHA256: hash}, {Name: "a", TokenSHA256: hash}}},
+		{name: "plain token", keys: []APIKey{{Name: "a", TokenSHA256: "token"}}},
+		{name: "negative quota", keys: []APIKey{{Name: "a", TokenSHA256: hash, Quota: Quota{CPUSeconds: -1}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyring(tt.keys); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestLoadKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	data := `[{"name":"ci","token_sha256":"` + strings.ToUpper(HashAPIToken("secret")) + `","max_concurrent_jobs":2,"cpu_seconds":3600}]`
+	if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	keyring, err := LoadKeyring(path)
+	if err != nil {
+		t.Fatalf("LoadKeyring() error = %v", err)
+	}
+	key, ok := keyring.Authenticate("secret")
+	if !ok || key.Name != "ci" {
+		t.Errorf("Authenticate() = %+v, %v", key, ok)
+	}
+	if _, ok := keyring.Authenticate("wrong"); ok {
+		t.Error("wrong token should not authenticate")
+	}
+	if quota := keyring.QuotaFor("ci"); quota.MaxConcurrentJobs != 2 || quota.CPUSeconds != 3600 {
+		t.Errorf("QuotaFor() = %+v", quota)
+	}
+}
+
+func TestAPIServer_RequiresAPIKey(t *testing.T) {
+	manager, _ := newTestManager(t)
+	server := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+	server.SetKeyring(testKeyring(t))
+	handler := server.Handler()
+
+	if rec := authRequest(t, handler, http.MethodGet, "/jobs", "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("missing key status = %d, expected 401", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodGet, "/jobs", "nope", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("invalid key status = %d, expected 401", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodGet, "/healthz", "", ""); rec.Code != http.StatusOK {
+		t.Errorf("health probe status = %d, expected 200 without a key", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodGet, "/ws/jobs/missing?access_token=bob-token", "", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("websocket access_token status = %d, expected 404 for an unknown job", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodGet, "/jobs/missing?access_token=bob-token", "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("access_token outside websocket route status = %d, expected 401", rec.Code)
+	}
+}
+
+func TestAPIServer_JobOwnership(t *testing.T) {
+	manager, _ := newTestManager(t)
+	server := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+	server.SetKeyring(testKeyring(t))
+	handler := server.Handler()
+
+	rec := authRequest(t, handler, http.MethodPost, "/jobs", "alice-token", `{"prefix":"abcdefabcdef"}`)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("submit status = %d: %s", rec.Code, rec.Body.String())
+	}
+	var job Job
+	if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+		t.Fatal(err)
+	}
+	if job.Owner != "alice" {
+		t.Errorf("job owner = %q, expected alice", job.Owner)
+	}
+
+	if rec := authRequest(t, handler, http.MethodGet, "/jobs/"+job.ID, "bob-token", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("other key GET status = %d, expected 404", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodDelete, "/jobs/"+job.ID, "bob-token", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("other key DELETE status = %d, expected 404", rec.Code)
+	}
+
+	var jobs []Job
+	rec = authRequest(t, handler, http.MethodGet, "/jobs", "bob-token", "")
+	if err := json.NewDecoder(rec.Body).Decode(&jobs); err != nil || len(jobs) != 0 {
+		t.Errorf("other key should see no jobs, got %v (%v)", jobs, err)
+	}
+	rec = authRequest(t, handler, http.MethodGet, "/jobs", "admin-token", "")
+	if err := json.NewDecoder(rec.Body).Decode(&jobs); err != nil || len(jobs) != 1 {
+		t.Errorf("admin key should see all jobs, got %v (%v)", jobs, err)
+	}
+
+	if rec := authRequest(t, handler, http.MethodDelete, "/jobs/"+job.ID, "alice-token", ""); rec.Code != http.StatusOK {
+		t.Errorf("owner DELETE status = %d, expected 200", rec.Code)
+	}
+}
+
+func TestAPIServer_QuotaStatus(t *testing.T) {
+	manager, _ := newTestManager(t)
+	keyring := testKeyring(t)
+	manager.config.Quotas = keyring.QuotaFor
+	server := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+	server.SetKeyring(keyring)
+	handler := server.Handler()
+
+	if rec := authRequest(t, handler, http.MethodPost, "/jobs", "alice-token", `{"prefix":"abcdefabcdef"}`); rec.Code != http.StatusForbidden {
+		t.Errorf("difficult pattern status = %d, expected 403", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodPost, "/jobs", "alice-token", `{"prefix":"abcd"}`); rec.Code != http.StatusAccepted {
+		t.Fatalf("first job status = %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := authRequest(t, handler, http.MethodPost, "/jobs", "alice-token", `{"prefix":"abcd"}`); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("second concurrent job status = %d, expected 429", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodPost, "/jobs", "bob-token", `{"prefix":"abcdefabcdef"}`); rec.Code != http.StatusAccepted {
+		t.Errorf("unlimited key status = %d, expected 202", rec.Code)
+	}
+}
+
+func TestJobManager_CPUQuota(t *testing.T) {
+	manager := newStoreManager(t, nil, nil, func(cfg *JobManagerConfig) {
+		cfg.Quotas = func(owner string) Quota { return Quota{CPUSeconds: 1e-9} }
+	})
+
+	job, err := manager.SubmitAs("alice", JobRequest{Prefix: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job = waitForState(t, manager, job.ID)
+	if job.CPUSeconds <= 0 {
+		t.Errorf("CPU-seconds not accounted: %+v", job)
+	}
+
+	_, err = manager.SubmitAs("alice", JobRequest{Prefix: "a"})
+	if !IsQuotaError(err) {
+		t.Errorf("expected CPU quota error, got %v", err)
+	}
+	if _, err := manager.SubmitAs("bob-without-usage", JobRequest{Prefix: "a"}); err != nil {
+		t.Errorf("other owner should not share the quota: %v", err)
+	}
+
+	long, err := manager.SubmitAs("carol", JobRequest{Prefix: "abcdefabcdef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	long = waitForState(t, manager, long.ID)
+	if long.State != JobFailed || !strings.Contains(long.Error, "CPU") {
+		t.Errorf("job exceeding the CPU quota should fail, got %+v", long)
+	}
+}
+
+func TestAPIServer_AuditLog(t *testing.T) {
+	manager, _ := newTestManager(t)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	audit, err := OpenAuditLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer audit.Close()
+
+	server := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+	server.SetKeyring(testKeyring(t))
+	server.SetAuditLog(audit)
+	handler := server.Handler()
+
+	rec := authRequest(t, handler, http.MethodPost, "/jobs", "bob-token", `{"prefix":"abcdefabcdef","count":2}`)
+	var job Job
+	if err := json.NewDecoder(rec.Body).Decode(&job); err != nil {
+		t.Fatal(err)
+	}
+	authRequest(t, handler, http.MethodPost, "/jobs/"+job.ID+"/pause", "bob-token", "{}")
+	authRequest(t, handler, http.MethodPost, "/jobs", "bob-token", `{"prefix":"xyz"}`)
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit line %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 audit entries, got %+v", entries)
+	}
+	if e := entries[0]; e.Key != "bob" || e.Action != "submit" || e.JobID != job.ID || e.Prefix != "abcdefabcdef" || e.Count != 2 || e.Status != http.StatusAccepted {
+		t.Errorf("unexpected submit entry: %+v", e)
+	}
+	if e := entries[1]; e.Action != "pause" || e.JobID != job.ID || e.Status != http.StatusOK {
+		t.Errorf("unexpected pause entry: %+v", e)
+	}
+	if e := entries[2]; e.Status != http.StatusBadRequest || e.Error == "" || e.Prefix != "xyz" {
+		t.Errorf("rejected submissions should be audited: %+v", e)
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("audit log permissions = %v, %v", info.Mode().Perm(), err)
+	}
+}
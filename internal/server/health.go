@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/errors"
+)
+
+// HealthStatus is the snapshot reported by the health and readiness endpoints
+type HealthStatus struct {
+	Status               string    `json:"status"`
+	PoolRunning          bool      `json:"pool_running"`
+	ActiveWorkers        int       `json:"active_workers"`
+	HealthyWorkers       int       `json:"healthy_workers"`
+	WorkerRestarts       int64     `json:"worker_restarts"`
+	BacklogDepth         int       `json:"backlog_depth"`
+	TotalAttempts        int64     `json:"total_attempts"`
+	LastProgress         time.Time `json:"last_progress,omitempty"`
+	SecondsSinceProgress float64   `json:"seconds_since_progress"`
+}
+
+// HealthProvider supplies the current worker pool state to the health server
+type HealthProvider interface {
+	HealthStatus() HealthStatus
+}
+
+// HealthProviderFunc adapts a function to the HealthProvider interface
+type HealthProviderFunc func() HealthStatus
+
+// HealthStatus returns the status produced by the function
+func (f HealthProviderFunc) HealthStatus() HealthStatus {
+	return f()
+}
+
+// HealthServer exposes /healthz and /readyz endpoints for orchestrators
+type HealthServer struct {
+	addr         string
+	provider     HealthProvider
+	stallTimeout time.Duration
+	startTime    time.Time
+
+	mu       sync.Mutex
+	server   *http.Server
+	listener net.Listener
+}
+
+// NewHealthServer creates a health server. A pool with pending work that has not
+// reported progress within stallTimeout is reported as not live.
+func NewHealthServer(addr string, provider HealthProvider, stallTimeout time.Duration) *HealthServer {
+	return &HealthServer{
+		addr:         addr,
+		provider:     provider,
+		stallTimeout: stallTimeout,
+		startTime:    time.Now(),
+	}
+}
+
+// Handler returns the HTTP handler serving the health endpoints
+func (h *HealthServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	h.Register(mux)
+	return mux
+}
+
+// Register adds the health endpoints to an existing mux
+func (h *HealthServer) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET /healthz", h.handleHealthz)
+	mux.HandleFunc("GET /readyz", h.handleReadyz)
+}
+
+// Start begins serving on the configured address
+func (h *HealthServer) Start() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.server != nil {
+		return errors.NewWorkerError("health_server_start", "health server already started")
+	}
+
+	listener, err := net.Listen("tcp", h.addr)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"health_server_start", "failed to listen on "+h.addr)
+	}
+
+	h.listener = listener
+	h.server = &http.Server{
+		Handler:           h.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		_ = h.server.Serve(listener)
+	}()
+
+	return nil
+}
+
+// Addr returns the address the server is listening on
+func (h *HealthServer) Addr() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listener != nil {
+		return h.listener.Addr().String()
+	}
+	return h.addr
+}
+
+// Shutdown stops the server
+func (h *HealthServer) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	server := h.server
+	h.server = nil
+	h.listener = nil
+	h.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// Evaluate returns the current status along with liveness and readiness verdicts
+func (h *HealthServer) Evaluate() (status HealthStatus, live bool, ready bool) {
+	status = h.provider.HealthStatus()
+
+	reference := status.LastProgress
+	if reference.IsZero() {
+		reference = h.startTime
+	}
+	status.SecondsSinceProgress = time.Since(reference).Seconds()
+
+	stalled := status.PoolRunning && status.BacklogDepth > 0 &&
+		h.stallTimeout > 0 && time.Since(reference) > h.stallTimeout
+
+	live = !stalled
+	ready = status.PoolRunning && !stalled
+
+	switch {
+	case stalled:
+		status.Status = "stalled"
+	case !status.PoolRunning:
+		status.Status = "not_running"
+	default:
+		status.Status = "ok"
+	}
+
+	return status, live, ready
+}
+
+// handleHealthz reports liveness: the process is healthy unless work has stalled
+func (h *HealthServer) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	status, live, _ := h.Evaluate()
+	writeHealthResponse(w, status, live)
+}
+
+// handleReadyz reports readiness: the pool is running and making progress
+func (h *HealthServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	status, _, ready := h.Evaluate()
+	writeHealthResponse(w, status, ready)
+}
+
+// writeHealthResponse writes the status as JSON with a 200 or 503 code
+func writeHealthResponse(w http.ResponseWriter, status HealthStatus, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(status)
+}
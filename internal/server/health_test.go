@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthServer_Endpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      HealthStatus
+		expectLive  int
+		expectReady int
+		expectState string
+	}{
+		{
+			name:        "running with recent progress",
+			status:      HealthStatus{PoolRunning: true, BacklogDepth: 1, LastProgress: time.Now()},
+			expectLive:  http.StatusOK,
+			expectReady: http.StatusOK,
+			expectState: "ok",
+		},
+		{
+			name:        "pool not started",
+			status:      HealthStatus{PoolRunning: false},
+			expectLive:  http.StatusOK,
+			expectReady: http.StatusServiceUnavailable,
+			expectState: "not_running",
+		},
+		{
+			name:        "stalled with pending work",
+			status:      HealthStatus{PoolRunning: true, BacklogDepth: 2, LastProgress: time.Now().Add(-time.Minute)},
+			expectLive:  http.StatusServiceUnavailable,
+			expectReady: http.StatusServiceUnavailable,
+			expectState: "stalled",
+		},
+		{
+			name:        "idle without backlog is not stalled",
+			status:      HealthStatus{PoolRunning: true, BacklogDepth: 0, LastProgress: time.Now().Add(-time.Minute)},
+			expectLive:  http.StatusOK,
+			expectReady: http.StatusOK,
+			expectState: "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := tt.status
+			hs := NewHealthServer("127.0.0.1:0", HealthProviderFunc(func() HealthStatus { return status }), 10*time.Second)
+			handler := hs.Handler()
+
+			for path, expected := range map[string]int{"/healthz": tt.expectLive, "/readyz": tt.expectReady} {
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+				if rec.Code != expected {
+					t.Errorf("%s returned %d, expected %d", path, rec.Code, expected)
+				}
+
+				var body HealthStatus
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("%s returned invalid JSON: %v", path, err)
+				}
+				if body.Status != tt.expectState {
+					t.Errorf("%s status = %q, expected %q", path, body.Status, tt.expectState)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthServer_StartShutdown(t *testing.T) {
+	hs := NewHealthServer("127.0.0.1:0", HealthProviderFunc(func() HealthStatus {
+		return HealthStatus{PoolRunning: true}
+	}), time.Minute)
+
+	if err := hs.Start(); err != nil {
+		t.Fatalf("Start() returned error: %v", err)
+	}
+
+	resp, err := http.Get("http://" + hs.Addr() + "/readyz")
+	if err != nil {
+		t.Fatalf("GET /readyz failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /readyz returned %d, expected 200", resp.StatusCode)
+	}
+
+	if err := hs.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() returned error: %v", err)
+	}
+}
@@ -0,0 +1,1039 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bloco-eth/internal/tracing"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// MaxJobWallets is the largest number of wallets a single job may request
+const MaxJobWallets = 1000
+
+// MaxRejectWords is the largest number of reject words a single job may send
+const MaxRejectWords = 10000
+
+// progressInterval is how often running jobs publish progress events
+const progressInterval = 500 * time.Millisecond
+
+// JobState describes where a job is in its lifecycle
+type JobState string
+
+const (
+	JobQueued    JobState = "queued"
+	JobRunning   JobState = "running"
+	JobPaused    JobState = "paused"
+	JobCompleted JobState = "completed"
+	JobFailed    JobState = "failed"
+	JobCancelled JobState = "cancelled"
+)
+
+// IsFinal reports whether the job will not change state again
+func (s JobState) IsFinal() bool {
+	return s == JobCompleted || s == JobFailed || s == JobCancelled
+}
+
+// JobRequest is the body accepted when submitting a generation job
+type JobRequest struct {
+	Prefix        string `json:"prefix,omitempty"`
+	Suffix        string `json:"suffix,omitempty"`
+	Checksum      bool   `json:"checksum,omitempty"`
+	CaseSensitive bool   `json:"case_sensitive,omitempty"`
+	Count         int    `json:"count,omitempty"`
+	Network       string `json:"network,omitempty"`
+	WithMnemonic  bool   `json:"with_mnemonic,omitempty"`
+	// Priority weights the job's share of the threads and its place in the queue:
+	// high, normal (the default) or low
+	Priority string `json:"priority,omitempty"`
+	// RejectWords are substrings found addresses must not contain, ignoring case
+	RejectWords []string `json:"reject_words,omitempty"`
+}
+
+// Criteria converts the request into generation criteria
+func (r JobRequest) Criteria() wallet.GenerationCriteria {
+	network := r.Network
+	if network == "" {
+		network = "ethereum"
+	}
+	var rejectWords []string
+	for _, word := range r.RejectWords {
+		rejectWords = append(rejectWords, strings.ToLower(word))
+	}
+	return wallet.GenerationCriteria{
+		Network:       strings.ToLower(network),
+		Prefix:        r.Prefix,
+		Suffix:        r.Suffix,
+		IsChecksum:    r.Checksum,
+		CaseSensitive: r.CaseSensitive,
+		UseMnemonic:   r.WithMnemonic,
+		RejectWords:   rejectWords,
+	}
+}
+
+// Validate checks the request before it is queued
+func (r *JobRequest) Validate() error {
+	if r.Count == 0 {
+		r.Count = 1
+	}
+	if r.Count < 0 || r.Count > MaxJobWallets {
+		return errors.NewValidationError("submit_job",
+			fmt.Sprintf("count must be between 1 and %d, got %d", MaxJobWallets, r.Count))
+	}
+
+	if len(r.RejectWords) > MaxRejectWords {
+		return errors.NewValidationError("submit_job",
+			fmt.Sprintf("at most %d reject words are allowed, got %d", MaxRejectWords, len(r.RejectWords)))
+	}
+
+	priority, err := worker.ParsePriority(r.Priority)
+	if err != nil {
+		return errors.NewValidationError("submit_job", err.Error())
+	}
+	r.Priority = string(priority)
+
+	criteria := r.Criteria()
+	if criteria.Prefix == "" && criteria.Suffix == "" {
+		return errors.NewValidationError("submit_job", "a prefix or suffix is required")
+	}
+	if err := criteria.Validate(); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeValidation, "submit_job", "invalid generation criteria")
+	}
+	return nil
+}
+
+// Job is a snapshot of a submitted generation job. Private keys are never included.
+type Job struct {
+	ID         string     `json:"id"`
+	Owner      string     `json:"owner,omitempty"`
+	Request    JobRequest `json:"request"`
+	State      JobState   `json:"state"`
+	Addresses  []string   `json:"addresses"`
+	Attempts   int64      `json:"attempts"`
+	CPUSeconds float64    `json:"cpu_seconds"`
+	Retries    int        `json:"retries"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  time.Time  `json:"started_at,omitzero"`
+	FinishedAt time.Time  `json:"finished_at,omitzero"`
+	// TraceParent links every run of the job to the trace of its submission
+	TraceParent string `json:"trace_parent,omitempty"`
+}
+
+// ProgressEvent carries the same progress data the TUI renders, plus per-worker stats
+type ProgressEvent struct {
+	JobID            string               `json:"job_id"`
+	State            JobState             `json:"state"`
+	Attempts         int64                `json:"attempts"`
+	Speed            float64              `json:"speed"`
+	Probability      float64              `json:"probability"`
+	ETASeconds       float64              `json:"eta_seconds"`
+	Difficulty       float64              `json:"difficulty"`
+	Pattern          string               `json:"pattern"`
+	CompletedWallets int                  `json:"completed_wallets"`
+	TotalWallets     int                  `json:"total_wallets"`
+	ProgressPercent  float64              `json:"progress_percent"`
+	IsComplete       bool                 `json:"is_complete"`
+	Workers          []worker.WorkerStats `json:"workers"`
+	Timestamp        time.Time            `json:"timestamp"`
+	// ChecksumCandidates and ChecksumConfirmed count the hits checked by the
+	// pool's checksum verifiers, if it has any
+	ChecksumCandidates int64 `json:"checksum_candidates,omitempty"`
+	ChecksumConfirmed  int64 `json:"checksum_confirmed,omitempty"`
+}
+
+// PoolFactory creates a worker pool for the given network
+type PoolFactory func(network string) (worker.WorkerPool, error)
+
+// ResultSink persists a generated wallet, for example as a keystore file. ctx carries
+// the job's run span and criteria.
+type ResultSink func(ctx context.Context, w *wallet.Wallet) error
+
+// criteriaKey is the context key of the criteria a ResultSink's wallet matched
+type criteriaKey struct{}
+
+// JobCriteria returns the criteria of the job whose wallet a ResultSink is given
+func JobCriteria(ctx context.Context) (wallet.GenerationCriteria, bool) {
+	criteria, ok := ctx.Value(criteriaKey{}).(wallet.GenerationCriteria)
+	return criteria, ok
+}
+
+// job is the manager's mutable record for a submitted job
+type job struct {
+	Job
+	cancel      context.CancelFunc
+	subscribers map[chan ProgressEvent]struct{}
+	last        ProgressEvent
+	progressAt  time.Time
+
+	// baseAttempts and baseCPUSeconds count usage from earlier runs of the job
+	baseAttempts   int64
+	baseCPUSeconds float64
+	runStartedAt   time.Time
+	pauseRequested bool
+	quotaErr       error
+	// share is the run's part of the scheduler's threads
+	share *worker.Share
+}
+
+// JobManagerConfig controls job concurrency, persistence, retries and retention
+type JobManagerConfig struct {
+	MaxConcurrent int
+	Store         JobStore      // nil keeps jobs in memory only
+	MaxRetries    int           // automatic retries for a failed job
+	RetryBackoff  time.Duration // delay before an automatic retry
+	Retention     time.Duration // finished jobs older than this are pruned, 0 keeps them
+	Quotas        QuotaLookup   // per-owner limits, nil means unlimited
+	// Scheduler splits threads between running jobs by priority; nil lets every
+	// job run all of its pool's workers
+	Scheduler *worker.Scheduler
+}
+
+// DefaultJobManagerConfig returns an in-memory configuration running one job at a time
+func DefaultJobManagerConfig() JobManagerConfig {
+	return JobManagerConfig{
+		MaxConcurrent: 1,
+		RetryBackoff:  5 * time.Second,
+	}
+}
+
+// JobManager queues generation jobs, runs them on worker pools and fans out progress
+type JobManager struct {
+	newPool PoolFactory
+	sink    ResultSink
+	config  JobManagerConfig
+
+	mu   sync.Mutex
+	jobs map[string]*job
+	// running counts the jobs holding a slot; waiting are the jobs queued for one,
+	// with when they started waiting
+	running   int
+	waiting   map[*job]time.Time
+	slotsFree chan struct{} // closed and replaced when the queue changes
+	// workerRestarts counts worker panics in finished runs
+	workerRestarts int64
+	ctx            context.Context
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+// NewJobManager creates an in-memory job manager running at most maxConcurrent jobs at once
+func NewJobManager(newPool PoolFactory, sink ResultSink, maxConcurrent int) *JobManager {
+	cfg := DefaultJobManagerConfig()
+	cfg.MaxConcurrent = maxConcurrent
+	return NewJobManagerWithConfig(newPool, sink, cfg)
+}
+
+// NewJobManagerWithConfig creates a job manager with the given configuration.
+// Call Restore to reload jobs from the configured store.
+func NewJobManagerWithConfig(newPool PoolFactory, sink ResultSink, cfg JobManagerConfig) *JobManager {
+	if cfg.MaxConcurrent < 1 {
+		cfg.MaxConcurrent = 1
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &JobManager{
+		newPool:   newPool,
+		sink:      sink,
+		config:    cfg,
+		jobs:      make(map[string]*job),
+		waiting:   make(map[*job]time.Time),
+		slotsFree: make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// Restore loads jobs from the store. Jobs that were queued or running when the
+// server stopped are queued again; paused jobs stay paused. It returns the number
+// of jobs requeued.
+func (m *JobManager) Restore() (int, error) {
+	if m.config.Store == nil {
+		return 0, nil
+	}
+
+	stored, err := m.config.Store.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	requeued := 0
+	for _, saved := range stored {
+		if _, exists := m.jobs[saved.ID]; exists {
+			continue
+		}
+		if saved.Addresses == nil {
+			saved.Addresses = []string{}
+		}
+
+		j := &job{
+			Job:            saved,
+			cancel:         func() {},
+			subscribers:    make(map[chan ProgressEvent]struct{}),
+			baseAttempts:   saved.Attempts,
+			baseCPUSeconds: saved.CPUSeconds,
+		}
+		m.jobs[j.ID] = j
+
+		if j.State == JobQueued || j.State == JobRunning {
+			j.State = JobQueued
+			m.start(j, 0)
+			requeued++
+		}
+		j.last = m.buildEvent(j, nil)
+	}
+
+	m.persistLocked()
+	return requeued, nil
+}
+
+// Submit validates and queues a job without an owner
+func (m *JobManager) Submit(req JobRequest) (Job, error) {
+	return m.SubmitAs("", req)
+}
+
+// SubmitAs validates and queues a job for owner, enforcing the owner's quota
+func (m *JobManager) SubmitAs(owner string, req JobRequest) (Job, error) {
+	return m.SubmitAsWithContext(context.Background(), owner, req)
+}
+
+// SubmitAsWithContext is SubmitAs recording the submission as a span of the trace in ctx
+func (m *JobManager) SubmitAsWithContext(ctx context.Context, owner string, req JobRequest) (Job, error) {
+	ctx, span := tracing.Start(ctx, "job.submit",
+		tracing.String("job.owner", owner),
+		tracing.String("pattern.prefix", req.Prefix),
+		tracing.String("pattern.suffix", req.Suffix),
+		tracing.Int("job.count", req.Count))
+	defer span.End()
+
+	submitted, err := m.submit(ctx, owner, req)
+	span.RecordError(err)
+	span.SetAttributes(tracing.String("job.id", submitted.ID))
+	return submitted, err
+}
+
+// submit validates and queues a job, remembering the trace in ctx for its runs
+func (m *JobManager) submit(ctx context.Context, owner string, req JobRequest) (Job, error) {
+	if err := req.Validate(); err != nil {
+		return Job{}, err
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	j := &job{
+		Job: Job{
+			ID:          id,
+			Owner:       owner,
+			Request:     req,
+			State:       JobQueued,
+			Addresses:   []string{},
+			CreatedAt:   time.Now(),
+			TraceParent: tracing.SpanContextFromContext(ctx).Traceparent(),
+		},
+		subscribers: make(map[chan ProgressEvent]struct{}),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.ctx.Err() != nil {
+		return Job{}, errors.NewCancellationError("submit_job", "job manager is shutting down")
+	}
+	if err := m.checkQuotaLocked("submit_job", owner, req, ""); err != nil {
+		return Job{}, err
+	}
+	m.jobs[id] = j
+	j.last = m.buildEvent(j, nil)
+	m.start(j, 0)
+	m.persistLocked()
+
+	return j.snapshot(), nil
+}
+
+// Get returns a snapshot of the job with the given ID
+func (m *JobManager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return j.snapshot(), true
+}
+
+// List returns snapshots of all jobs, oldest first
+func (m *JobManager) List() []Job {
+	m.mu.Lock()
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		jobs = append(jobs, j.snapshot())
+	}
+	m.mu.Unlock()
+
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].CreatedAt.Before(jobs[k].CreatedAt)
+	})
+	return jobs
+}
+
+// Cancel stops a queued, running or paused job
+func (m *JobManager) Cancel(id string) (Job, error) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return Job{}, errors.NewValidationError("cancel_job", fmt.Sprintf("job %s not found", id))
+	}
+	j.pauseRequested = false
+	paused := j.State == JobPaused
+	cancel := j.cancel
+	m.mu.Unlock()
+
+	if paused {
+		// No run is active, so the job can be finished directly
+		m.finish(j, JobCancelled, nil, nil)
+		return m.waitState(id, JobState.IsFinal), nil
+	}
+
+	cancel()
+	return m.waitState(id, JobState.IsFinal), nil
+}
+
+// Pause stops a queued or running job without discarding found wallets. Because
+// every attempt is independent, resuming loses no search progress.
+func (m *JobManager) Pause(id string) (Job, error) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return Job{}, errors.NewValidationError("pause_job", fmt.Sprintf("job %s not found", id))
+	}
+	if j.State != JobQueued && j.State != JobRunning {
+		state := j.State
+		m.mu.Unlock()
+		return Job{}, errors.NewValidationError("pause_job", fmt.Sprintf("cannot pause %s job", state))
+	}
+	j.pauseRequested = true
+	cancel := j.cancel
+	m.mu.Unlock()
+
+	cancel()
+	return m.waitState(id, func(s JobState) bool { return s == JobPaused || s.IsFinal() }), nil
+}
+
+// Resume queues a paused job to search for its remaining wallets
+func (m *JobManager) Resume(id string) (Job, error) {
+	m.mu.Lock()
+	j, ok := m.jobs[id]
+	if !ok {
+		m.mu.Unlock()
+		return Job{}, errors.NewValidationError("resume_job", fmt.Sprintf("job %s not found", id))
+	}
+	if j.State != JobPaused {
+		state := j.State
+		m.mu.Unlock()
+		return Job{}, errors.NewValidationError("resume_job", fmt.Sprintf("cannot resume %s job", state))
+	}
+	if m.ctx.Err() != nil {
+		m.mu.Unlock()
+		return Job{}, errors.NewCancellationError("resume_job", "job manager is shutting down")
+	}
+	if err := m.checkQuotaLocked("resume_job", j.Owner, j.Request, j.ID); err != nil {
+		m.mu.Unlock()
+		return Job{}, err
+	}
+
+	j.pauseRequested = false
+	j.State = JobQueued
+	m.broadcast(j, nil)
+	m.start(j, 0)
+	m.persistLocked()
+	snapshot := j.snapshot()
+	m.mu.Unlock()
+
+	return snapshot, nil
+}
+
+// Retry queues a failed or cancelled job to search for its remaining wallets
+func (m *JobManager) Retry(id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, errors.NewValidationError("retry_job", fmt.Sprintf("job %s not found", id))
+	}
+	if j.State != JobFailed && j.State != JobCancelled {
+		return Job{}, errors.NewValidationError("retry_job", fmt.Sprintf("cannot retry %s job", j.State))
+	}
+	if m.ctx.Err() != nil {
+		return Job{}, errors.NewCancellationError("retry_job", "job manager is shutting down")
+	}
+	if err := m.checkQuotaLocked("retry_job", j.Owner, j.Request, j.ID); err != nil {
+		return Job{}, err
+	}
+
+	j.State = JobQueued
+	j.Error = ""
+	j.FinishedAt = time.Time{}
+	j.pauseRequested = false
+	j.last = m.buildEvent(j, nil)
+	m.start(j, 0)
+	m.persistLocked()
+
+	return j.snapshot(), nil
+}
+
+// Subscribe returns a channel receiving progress events for a job, starting with the
+// latest event. The channel is closed once the job finishes or unsubscribe is called.
+func (m *JobManager) Subscribe(id string) (<-chan ProgressEvent, func(), error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return nil, nil, errors.NewValidationError("subscribe_job", fmt.Sprintf("job %s not found", id))
+	}
+
+	ch := make(chan ProgressEvent, 8)
+	ch <- j.last
+	if j.State.IsFinal() {
+		close(ch)
+		return ch, func() {}, nil
+	}
+
+	j.subscribers[ch] = struct{}{}
+	unsubscribe := func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, ok := j.subscribers[ch]; ok {
+			delete(j.subscribers, ch)
+			close(ch)
+		}
+	}
+	return ch, unsubscribe, nil
+}
+
+// HealthStatus reports the manager state for the health endpoints. The backlog is
+// the number of unfinished jobs and progress is the latest running job update.
+func (m *JobManager) HealthStatus() HealthStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := HealthStatus{PoolRunning: m.ctx.Err() == nil, WorkerRestarts: m.workerRestarts}
+	for _, j := range m.jobs {
+		if j.State.IsFinal() {
+			continue
+		}
+		status.BacklogDepth++
+		status.TotalAttempts += j.Attempts
+		if j.State == JobRunning {
+			status.ActiveWorkers += len(j.last.Workers)
+			for _, ws := range j.last.Workers {
+				if ws.IsHealthy {
+					status.HealthyWorkers++
+				}
+				if !j.runStartedAt.IsZero() {
+					// Finished runs are already in m.workerRestarts
+					status.WorkerRestarts += int64(ws.Restarts)
+				}
+			}
+			if j.progressAt.After(status.LastProgress) {
+				status.LastProgress = j.progressAt
+			}
+		}
+	}
+	if status.BacklogDepth > 0 && status.LastProgress.IsZero() {
+		// Queued jobs are waiting for a slot, not stalled
+		status.LastProgress = time.Now()
+	}
+	return status
+}
+
+// Shutdown cancels all jobs and waits for them to stop
+func (m *JobManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	m.cancel()
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.NewCancellationError("shutdown_jobs", "timed out waiting for jobs to stop")
+	}
+}
+
+// start launches a run for the job after an optional delay. Callers must hold m.mu.
+func (m *JobManager) start(j *job, delay time.Duration) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	j.cancel = cancel
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				m.stop(j, nil)
+				return
+			}
+		}
+		m.run(ctx, j)
+	}()
+}
+
+// run waits for a free slot and searches for the job's remaining wallets
+func (m *JobManager) run(ctx context.Context, j *job) {
+	if !m.acquireSlot(ctx, j) {
+		m.stop(j, nil)
+		return
+	}
+	defer m.releaseSlot()
+
+	if ctx.Err() != nil {
+		m.stop(j, nil)
+		return
+	}
+
+	m.mu.Lock()
+	ctx, span := tracing.Start(tracing.ContextWithTraceparent(ctx, j.TraceParent), "job.run",
+		tracing.String("job.id", j.ID),
+		tracing.Int("job.retries", j.Retries),
+		tracing.Int("job.remaining", j.Request.Count-len(j.Addresses)))
+	m.mu.Unlock()
+	defer span.End()
+
+	criteria := j.Request.Criteria()
+	workerPool, err := m.newPool(criteria.Network)
+	if err == nil {
+		err = workerPool.Start()
+	}
+	if err != nil {
+		span.RecordError(err)
+		m.fail(j, err, nil)
+		return
+	}
+	defer func() { _ = workerPool.Shutdown() }()
+	collector := workerPool.GetStatsCollector()
+
+	priority, _ := worker.ParsePriority(j.Request.Priority)
+	share := m.config.Scheduler.Join(priority.Weight())
+	defer share.Leave()
+	ctx = worker.WithShare(ctx, share)
+
+	m.mu.Lock()
+	j.State = JobRunning
+	j.share = share
+	if j.StartedAt.IsZero() {
+		j.StartedAt = time.Now()
+	}
+	j.progressAt = time.Now()
+	j.runStartedAt = time.Now()
+	j.quotaErr = nil
+	remaining := j.Request.Count - len(j.Addresses)
+	m.broadcast(j, nil)
+	m.persistLocked()
+	m.mu.Unlock()
+
+	progress := worker.NewProgressBroker(collector)
+	progress.Subscribe(progressInterval, func(e worker.ProgressEvent) {
+		if e.Type == worker.ProgressTick {
+			m.publish(j, collector)
+		}
+	})
+
+	var runErr error
+	var resultAttempts int64
+	for i := 0; i < remaining; i++ {
+		result, err := workerPool.GenerateWalletWithContext(ctx, criteria)
+		if err != nil {
+			if ctx.Err() == nil {
+				runErr = err
+			}
+			break
+		}
+
+		if m.sink != nil {
+			if err := m.sink(context.WithValue(ctx, criteriaKey{}, criteria), result.Wallet); err != nil {
+				runErr = err
+				break
+			}
+		}
+
+		resultAttempts += result.Attempts
+		m.mu.Lock()
+		j.Addresses = append(j.Addresses, result.Wallet.Address)
+		m.persistLocked()
+		m.mu.Unlock()
+	}
+
+	progress.Close(runErr)
+
+	// Worker stats are sampled, so fall back to the attempts reported with each result
+	m.mu.Lock()
+	runAttempts := collector.GetTotalAttempts()
+	if resultAttempts > runAttempts {
+		runAttempts = resultAttempts
+	}
+	j.baseAttempts += runAttempts
+	j.Attempts = j.baseAttempts
+	m.workerRestarts += collector.GetWorkerRestarts()
+	j.baseCPUSeconds = m.runCPUSecondsLocked(j, collector)
+	j.CPUSeconds = j.baseCPUSeconds
+	j.runStartedAt = time.Time{}
+	j.share = nil
+	quotaErr := j.quotaErr
+	m.mu.Unlock()
+
+	span.SetAttributes(tracing.Int64("attempts", runAttempts))
+	if quotaErr != nil {
+		span.RecordError(quotaErr)
+	} else {
+		span.RecordError(runErr)
+	}
+
+	switch {
+	case quotaErr != nil:
+		m.finish(j, JobFailed, quotaErr, collector)
+	case runErr != nil:
+		m.fail(j, runErr, collector)
+	case ctx.Err() != nil:
+		m.stop(j, collector)
+	default:
+		m.finish(j, JobCompleted, nil, collector)
+	}
+}
+
+// priorityAging is the head start of every queued job: its claim to the next slot
+// is its weight times the sum of its wait and priorityAging, so higher priorities go
+// first but a lower one that waits long enough is never passed over
+const priorityAging = time.Minute
+
+// acquireSlot waits for a free slot that no job with a stronger claim is waiting
+// for, and reports false if ctx ends first
+func (m *JobManager) acquireSlot(ctx context.Context, j *job) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.waiting[j] = time.Now()
+	for {
+		if m.running < m.config.MaxConcurrent && m.nextWaitingLocked() == j {
+			delete(m.waiting, j)
+			m.running++
+			m.wakeWaitingLocked()
+			return true
+		}
+		wake := m.slotsFree
+		m.mu.Unlock()
+		select {
+		case <-wake:
+			m.mu.Lock()
+		case <-ctx.Done():
+			m.mu.Lock()
+			delete(m.waiting, j)
+			m.wakeWaitingLocked()
+			return false
+		}
+	}
+}
+
+// releaseSlot frees the slot of a finished run
+func (m *JobManager) releaseSlot() {
+	m.mu.Lock()
+	m.running--
+	m.wakeWaitingLocked()
+	m.mu.Unlock()
+}
+
+// nextWaitingLocked returns the waiting job with the strongest claim, the earliest
+// submitted on a tie. Callers must hold m.mu.
+func (m *JobManager) nextWaitingLocked() *job {
+	now := time.Now()
+	var next *job
+	var best float64
+	for j, since := range m.waiting {
+		priority, _ := worker.ParsePriority(j.Request.Priority)
+		claim := float64(priority.Weight()) * (now.Sub(since) + priorityAging).Seconds()
+		if next == nil || claim > best || (claim == best && j.CreatedAt.Before(next.CreatedAt)) {
+			next, best = j, claim
+		}
+	}
+	return next
+}
+
+// wakeWaitingLocked lets waiting jobs check the queue again. Callers must hold m.mu.
+func (m *JobManager) wakeWaitingLocked() {
+	close(m.slotsFree)
+	m.slotsFree = make(chan struct{})
+}
+
+// stop moves an interrupted job to paused when a pause was requested, or cancelled otherwise
+func (m *JobManager) stop(j *job, collector *worker.StatsCollector) {
+	m.mu.Lock()
+	if m.ctx.Err() != nil {
+		// Shutting down: leave the job queued so Restore picks it up after a restart
+		j.State = JobQueued
+		j.last = m.buildEvent(j, collector)
+		m.closeSubscribers(j)
+		m.persistLocked()
+		m.mu.Unlock()
+		return
+	}
+	if j.pauseRequested {
+		j.State = JobPaused
+		m.broadcast(j, collector)
+		m.persistLocked()
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+
+	m.finish(j, JobCancelled, nil, collector)
+}
+
+// fail retries a failed job while automatic retries remain, or marks it failed
+func (m *JobManager) fail(j *job, err error, collector *worker.StatsCollector) {
+	m.mu.Lock()
+	if j.Retries < m.config.MaxRetries && m.ctx.Err() == nil {
+		j.Retries++
+		j.Error = err.Error()
+		j.State = JobQueued
+		m.broadcast(j, collector)
+		m.start(j, m.config.RetryBackoff)
+		m.persistLocked()
+		m.mu.Unlock()
+		return
+	}
+	m.mu.Unlock()
+
+	m.finish(j, JobFailed, err, collector)
+}
+
+// publish sends a progress event built from the pool statistics to all subscribers
+func (m *JobManager) publish(j *job, collector *worker.StatsCollector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if attempts := j.baseAttempts + collector.GetTotalAttempts(); attempts > j.Attempts {
+		j.Attempts = attempts
+		j.progressAt = time.Now()
+	}
+	j.CPUSeconds = m.runCPUSecondsLocked(j, collector)
+
+	// Stop the job once its owner exhausts the CPU-seconds quota
+	if quota := m.quotaFor(j.Owner); quota.CPUSeconds > 0 && j.quotaErr == nil {
+		if used := m.cpuUsageLocked(j.Owner); used > quota.CPUSeconds {
+			j.quotaErr = newQuotaError("run_job", "cpu_seconds",
+				fmt.Sprintf("CPU-seconds quota of %.0f exhausted", quota.CPUSeconds))
+			j.cancel()
+		}
+	}
+	m.broadcast(j, collector)
+}
+
+// runCPUSecondsLocked estimates the job's CPU-seconds as wall time multiplied by the
+// number of reporting workers. Callers must hold m.mu.
+func (m *JobManager) runCPUSecondsLocked(j *job, collector *worker.StatsCollector) float64 {
+	if j.runStartedAt.IsZero() {
+		return j.baseCPUSeconds
+	}
+	workers := collector.GetWorkerCount()
+	if j.share != nil {
+		// Workers beyond the job's share are parked
+		workers = min(workers, j.share.Threads())
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return j.baseCPUSeconds + time.Since(j.runStartedAt).Seconds()*float64(workers)
+}
+
+// broadcast records the latest event and sends it to subscribers. Callers must hold m.mu.
+func (m *JobManager) broadcast(j *job, collector *worker.StatsCollector) {
+	j.last = m.buildEvent(j, collector)
+	for ch := range j.subscribers {
+		select {
+		case ch <- j.last:
+		default:
+			// Slow subscriber: drop this update, the next one supersedes it
+		}
+	}
+}
+
+// finish records the final state, publishes a last event and closes subscriptions
+func (m *JobManager) finish(j *job, state JobState, err error, collector *worker.StatsCollector) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if j.State.IsFinal() {
+		return
+	}
+
+	j.State = state
+	j.FinishedAt = time.Now()
+	if err != nil {
+		j.Error = err.Error()
+	}
+
+	j.last = m.buildEvent(j, collector)
+	m.closeSubscribers(j)
+	j.cancel()
+	m.persistLocked()
+}
+
+// closeSubscribers delivers the latest event and closes all subscriptions. Callers must hold m.mu.
+func (m *JobManager) closeSubscribers(j *job) {
+	for ch := range j.subscribers {
+		select {
+		case ch <- j.last:
+		default:
+			// Make room so the last event is always delivered
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- j.last
+		}
+		close(ch)
+		delete(j.subscribers, ch)
+	}
+}
+
+// persistLocked prunes expired jobs and saves all jobs to the store. Callers must hold m.mu.
+func (m *JobManager) persistLocked() {
+	if m.config.Store == nil {
+		return
+	}
+
+	if m.config.Retention > 0 {
+		cutoff := time.Now().Add(-m.config.Retention)
+		for id, j := range m.jobs {
+			if j.State.IsFinal() && j.FinishedAt.Before(cutoff) {
+				delete(m.jobs, id)
+			}
+		}
+	}
+
+	jobs := make([]Job, 0, len(m.jobs))
+	for _, j := range m.jobs {
+		jobs = append(jobs, j.snapshot())
+	}
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].CreatedAt.Before(jobs[k].CreatedAt)
+	})
+
+	if err := m.config.Store.Save(jobs); err != nil {
+		// Persistence failures must not interrupt running searches
+		fmt.Fprintf(os.Stderr, "Warning: failed to persist jobs: %v\n", err)
+	}
+}
+
+// waitState waits briefly for a job to reach a state accepted by done
+func (m *JobManager) waitState(id string, done func(JobState) bool) Job {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		snapshot, _ := m.Get(id)
+		if done(snapshot.State) || time.Now().After(deadline) {
+			return snapshot
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// buildEvent computes a progress event for the job. Callers must hold m.mu.
+func (m *JobManager) buildEvent(j *job, collector *worker.StatsCollector) ProgressEvent {
+	criteria := j.Request.Criteria()
+	difficulty := criteria.Difficulty()
+
+	event := ProgressEvent{
+		JobID:            j.ID,
+		State:            j.State,
+		Attempts:         j.Attempts,
+		Difficulty:       difficulty,
+		Pattern:          criteria.GetPattern(),
+		CompletedWallets: len(j.Addresses),
+		TotalWallets:     j.Request.Count,
+		IsComplete:       j.State.IsFinal(),
+		Workers:          []worker.WorkerStats{},
+		Timestamp:        time.Now(),
+	}
+
+	if collector != nil {
+		stats := collector.GetAggregatedStats()
+		event.Speed = stats.TotalSpeed
+		event.ChecksumCandidates, event.ChecksumConfirmed = collector.GetChecksumVerification()
+
+		workerStats := collector.GetWorkerStats()
+		for _, ws := range workerStats {
+			event.Workers = append(event.Workers, ws)
+		}
+		sort.Slice(event.Workers, func(i, k int) bool {
+			return event.Workers[i].WorkerID < event.Workers[k].WorkerID
+		})
+	}
+
+	event.Probability = vanitymath.Probability(difficulty, event.Attempts) * 100
+	if probability50 := vanitymath.Attempts50(difficulty); probability50 > 0 && event.Speed > 0 {
+		if remaining := probability50 - event.Attempts; remaining > 0 {
+			event.ETASeconds = float64(remaining) / event.Speed
+		}
+	}
+
+	if event.TotalWallets > 0 {
+		event.ProgressPercent = float64(event.CompletedWallets) / float64(event.TotalWallets) * 100
+	}
+	if event.TotalWallets == 1 && !event.IsComplete {
+		// Single wallet jobs report probability as progress, matching the TUI
+		event.ProgressPercent = event.Probability
+	}
+	if j.State == JobCompleted {
+		event.ProgressPercent = 100
+	}
+
+	return event
+}
+
+// snapshot copies the public job fields. Callers must hold m.mu.
+func (j *job) snapshot() Job {
+	snapshot := j.Job
+	snapshot.Addresses = append([]string{}, j.Addresses...)
+	return snapshot
+}
+
+// newJobID returns a random identifier for a job
+func newJobID() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.WrapError(err, errors.ErrorTypeCrypto, "submit_job", "failed to generate job id")
+	}
+	return hex.EncodeToString(buf), nil
+}
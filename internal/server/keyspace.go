@@ -0,0 +1,431 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+)
+
+// Keyspace defaults applied when a request leaves them unset
+const (
+	DefaultKeyspaceKeys = 1 << 40
+	DefaultLeaseKeys    = 1 << 24
+)
+
+// leaseContextKey marks errors telling an agent what to do with its lease
+const leaseContextKey = "lease"
+
+// Lease outcomes carried by leaseContextKey
+const (
+	leaseGone        = "gone"        // the lease or keyspace is finished; stop searching it
+	leaseUnavailable = "unavailable" // every key is leased; ask again later
+)
+
+// KeyspaceState describes where a distributed search is in its lifecycle
+type KeyspaceState string
+
+const (
+	KeyspaceSearching KeyspaceState = "searching"
+	KeyspaceCompleted KeyspaceState = "completed"
+	KeyspaceExhausted KeyspaceState = "exhausted"
+)
+
+// KeyspaceRequest is the body accepted when creating a keyspace: a job pattern and
+// the seeded range of keys agents split between them. A shared keyspace is
+// published to every API key, so "bloco-eth donate" agents can search it.
+type KeyspaceRequest struct {
+	JobRequest
+	Seed      string `json:"seed,omitempty"`
+	Keys      uint64 `json:"keys,omitempty"`
+	Stride    uint64 `json:"stride,omitempty"`
+	LeaseKeys uint64 `json:"lease_keys,omitempty"`
+	Shared    bool   `json:"shared,omitempty"`
+}
+
+// Validate checks the request and fills in its defaults, including a random seed
+func (r *KeyspaceRequest) Validate() error {
+	if err := r.JobRequest.Validate(); err != nil {
+		return err
+	}
+	if network := r.Criteria().Network; network != "ethereum" && network != "bitcoin" {
+		return errors.NewValidationError("create_keyspace",
+			fmt.Sprintf("keyspaces hold secp256k1 keys; %s wallets cannot be searched by range", network))
+	}
+	if r.WithMnemonic {
+		return errors.NewValidationError("create_keyspace", "keyspace keys are not derived from a mnemonic")
+	}
+
+	if r.Keys == 0 {
+		r.Keys = DefaultKeyspaceKeys
+	}
+	if r.Stride == 0 {
+		r.Stride = 1
+	}
+	if r.LeaseKeys == 0 {
+		r.LeaseKeys = DefaultLeaseKeys
+	}
+	if r.Seed == "" {
+		seed := make([]byte, 32)
+		if _, err := rand.Read(seed); err != nil {
+			return errors.NewCryptoError("create_keyspace", "failed to generate keyspace seed", err)
+		}
+		r.Seed = hex.EncodeToString(seed)
+	}
+	if _, err := hex.DecodeString(r.Seed); err != nil {
+		return errors.NewValidationError("create_keyspace", "seed must be hex encoded")
+	}
+	return nil
+}
+
+// Keyspace is a snapshot of a distributed search. Private keys are never included;
+// agents keep the wallets they find and report only the addresses. FoundIn maps each
+// address to the lease range it was found in, so the owner can derive its key again
+// from the seed.
+type Keyspace struct {
+	ID         string            `json:"id"`
+	Owner      string            `json:"owner,omitempty"`
+	Request    KeyspaceRequest   `json:"request"`
+	State      KeyspaceState     `json:"state"`
+	Range      string            `json:"range"`
+	Checked    string            `json:"checked"`
+	Coverage   float64           `json:"coverage"`
+	Leases     []Lease           `json:"leases"`
+	Reassigned int               `json:"reassigned"`
+	Addresses  []string          `json:"addresses"`
+	FoundIn    map[string]string `json:"found_in,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	FinishedAt time.Time         `json:"finished_at,omitzero"`
+}
+
+// Lease grants an agent the keys Range, Stride apart, until ExpiresAt. Agents renew
+// it while searching; an expired lease is handed to another agent from its start.
+type Lease struct {
+	ID         string    `json:"id"`
+	KeyspaceID string    `json:"keyspace_id"`
+	Agent      string    `json:"agent,omitempty"`
+	Range      string    `json:"range"`
+	Stride     uint64    `json:"stride"`
+	Keys       uint64    `json:"keys"`
+	Checked    uint64    `json:"checked"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	TTLSeconds float64   `json:"ttl_seconds"`
+}
+
+// LeaseReport is an agent's progress on a lease and the addresses it found
+type LeaseReport struct {
+	Checked   uint64   `json:"checked"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// KeyspaceCoordinator splits keyspaces into leases for agents, so no two agents
+// search the same keys, and tracks the coverage of each keyspace
+type KeyspaceCoordinator struct {
+	leaseTTL time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	keyspaces map[string]*keyspace
+}
+
+// keyspace is the coordinator's record of a keyspace
+type keyspace struct {
+	Keyspace
+	cursor *crypto.KeyRangeCursor
+	leases map[string]*lease
+	found  map[string]bool
+}
+
+// lease is a granted lease and the cursor block it covers
+type lease struct {
+	Lease
+	block *crypto.KeyBlock
+}
+
+// NewKeyspaceCoordinator creates a coordinator whose leases expire leaseTTL after
+// they were granted or last renewed
+func NewKeyspaceCoordinator(leaseTTL time.Duration) *KeyspaceCoordinator {
+	return &KeyspaceCoordinator{
+		leaseTTL:  leaseTTL,
+		now:       time.Now,
+		keyspaces: make(map[string]*keyspace),
+	}
+}
+
+// IsLeaseGone reports whether err means the lease or its keyspace is finished
+func IsLeaseGone(err error) bool {
+	return errors.GetErrorContext(err)[leaseContextKey] == leaseGone
+}
+
+// IsLeaseUnavailable reports whether err means every key is leased for now
+func IsLeaseUnavailable(err error) bool {
+	return errors.GetErrorContext(err)[leaseContextKey] == leaseUnavailable
+}
+
+// newLeaseError creates a validation error tagged with a lease outcome
+func newLeaseError(operation, outcome, message string) error {
+	return errors.NewValidationError(operation, message).WithContext(leaseContextKey, outcome)
+}
+
+// Create starts a keyspace derived from the request's seed
+func (c *KeyspaceCoordinator) Create(owner string, req KeyspaceRequest) (Keyspace, error) {
+	if err := req.Validate(); err != nil {
+		return Keyspace{}, err
+	}
+	seed, _ := hex.DecodeString(req.Seed)
+	keyRange, err := crypto.SeededKeyRange(seed, req.Keys, req.Stride)
+	if err != nil {
+		return Keyspace{}, errors.WrapError(err, errors.ErrorTypeValidation, "create_keyspace", "invalid keyspace")
+	}
+	id, err := newJobID()
+	if err != nil {
+		return Keyspace{}, err
+	}
+
+	ks := &keyspace{
+		Keyspace: Keyspace{
+			ID:        id,
+			Owner:     owner,
+			Request:   req,
+			State:     KeyspaceSearching,
+			Range:     keyRange.String(),
+			CreatedAt: c.now(),
+		},
+		cursor: crypto.NewKeyRangeCursor(keyRange),
+		leases: make(map[string]*lease),
+		found:  make(map[string]bool),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.keyspaces[id] = ks
+	return ks.snapshot(), nil
+}
+
+// Get returns a snapshot of a keyspace
+func (c *KeyspaceCoordinator) Get(id string) (Keyspace, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ks, ok := c.keyspaces[id]
+	if !ok {
+		return Keyspace{}, false
+	}
+	c.expireLocked(ks)
+	return ks.snapshot(), true
+}
+
+// List returns all keyspaces, oldest first
+func (c *KeyspaceCoordinator) List() []Keyspace {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keyspaces := make([]Keyspace, 0, len(c.keyspaces))
+	for _, ks := range c.keyspaces {
+		c.expireLocked(ks)
+		keyspaces = append(keyspaces, ks.snapshot())
+	}
+	sort.Slice(keyspaces, func(i, j int) bool {
+		return keyspaces[i].CreatedAt.Before(keyspaces[j].CreatedAt)
+	})
+	return keyspaces
+}
+
+// Acquire leases the next unsearched keys of a keyspace to agent. Keys of leases
+// that expired are handed out again before new ones.
+func (c *KeyspaceCoordinator) Acquire(id, agent string) (Lease, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ks, err := c.searchingLocked("acquire_lease", id)
+	if err != nil {
+		return Lease{}, err
+	}
+
+	block, ok := ks.cursor.Claim(ks.Request.LeaseKeys)
+	if !ok {
+		return Lease{}, newLeaseError("acquire_lease", leaseUnavailable,
+			fmt.Sprintf("every remaining key of keyspace %s is leased; retry later", id))
+	}
+	leaseID, err := newJobID()
+	if err != nil {
+		ks.cursor.Release(block, 0)
+		return Lease{}, err
+	}
+
+	keyRange := ks.cursor.Range()
+	last := new(big.Int).Add(block.Offset, new(big.Int).SetUint64(block.Count-1))
+	l := &lease{
+		Lease: Lease{
+			ID:         leaseID,
+			KeyspaceID: id,
+			Agent:      agent,
+			Range:      fmt.Sprintf("%#x:%#x", keyRange.KeyAt(block.Offset), keyRange.KeyAt(last)),
+			Stride:     ks.Request.Stride,
+			Keys:       block.Count,
+			TTLSeconds: c.leaseTTL.Seconds(),
+		},
+		block: block,
+	}
+	c.renewLocked(l)
+	ks.leases[leaseID] = l
+	return l.Lease, nil
+}
+
+// Renew extends a lease and records the agent's progress and any addresses it found
+func (c *KeyspaceCoordinator) Renew(id, leaseID string, report LeaseReport) (Lease, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ks, l, err := c.leaseLocked("renew_lease", id, leaseID)
+	if err != nil {
+		return Lease{}, err
+	}
+	l.Checked = min(report.Checked, l.Keys)
+	c.renewLocked(l)
+	if ks.recordAddresses(l, report.Addresses) {
+		ks.finish(KeyspaceCompleted, c.now())
+		return Lease{}, newLeaseError("renew_lease", leaseGone, fmt.Sprintf("keyspace %s is %s", id, ks.State))
+	}
+	return l.Lease, nil
+}
+
+// Complete marks every key of a lease as searched and records the addresses found
+func (c *KeyspaceCoordinator) Complete(id, leaseID string, report LeaseReport) (Keyspace, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ks, l, err := c.leaseLocked("complete_lease", id, leaseID)
+	if err != nil {
+		return Keyspace{}, err
+	}
+	delete(ks.leases, leaseID)
+	ks.cursor.Release(l.block, l.block.Count)
+	switch {
+	case ks.recordAddresses(l, report.Addresses):
+		ks.finish(KeyspaceCompleted, c.now())
+	case ks.cursor.Exhausted():
+		ks.finish(KeyspaceExhausted, c.now())
+	}
+	return ks.snapshot(), nil
+}
+
+// Abandon gives a lease back unsearched, for an agent that stops early
+func (c *KeyspaceCoordinator) Abandon(id, leaseID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ks, l, err := c.leaseLocked("abandon_lease", id, leaseID)
+	if err != nil {
+		return err
+	}
+	delete(ks.leases, leaseID)
+	ks.cursor.Release(l.block, 0)
+	return nil
+}
+
+// searchingLocked returns a keyspace that is still being searched
+func (c *KeyspaceCoordinator) searchingLocked(operation, id string) (*keyspace, error) {
+	ks, ok := c.keyspaces[id]
+	if !ok {
+		return nil, errors.NewValidationError(operation, fmt.Sprintf("keyspace %s not found", id))
+	}
+	c.expireLocked(ks)
+	if ks.State != KeyspaceSearching {
+		return nil, newLeaseError(operation, leaseGone, fmt.Sprintf("keyspace %s is %s", id, ks.State))
+	}
+	return ks, nil
+}
+
+// leaseLocked returns a live lease of a keyspace that is still being searched
+func (c *KeyspaceCoordinator) leaseLocked(operation, id, leaseID string) (*keyspace, *lease, error) {
+	ks, err := c.searchingLocked(operation, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	l, ok := ks.leases[leaseID]
+	if !ok {
+		return nil, nil, newLeaseError(operation, leaseGone,
+			fmt.Sprintf("lease %s expired or was never granted", leaseID))
+	}
+	return ks, l, nil
+}
+
+// renewLocked moves a lease's expiry one TTL ahead
+func (c *KeyspaceCoordinator) renewLocked(l *lease) {
+	l.ExpiresAt = c.now().Add(c.leaseTTL)
+}
+
+// expireLocked returns the keys of expired leases to the keyspace, so the next
+// agent to ask is given them from the start
+func (c *KeyspaceCoordinator) expireLocked(ks *keyspace) {
+	if ks.State != KeyspaceSearching {
+		return
+	}
+	now := c.now()
+	for id, l := range ks.leases {
+		if now.After(l.ExpiresAt) {
+			delete(ks.leases, id)
+			ks.cursor.Release(l.block, 0)
+			ks.Reassigned++
+		}
+	}
+}
+
+// recordAddresses adds the addresses newly found in lease l and reports whether
+// enough were found
+func (ks *keyspace) recordAddresses(l *lease, addresses []string) bool {
+	for _, address := range addresses {
+		if address != "" && !ks.found[address] {
+			ks.found[address] = true
+			ks.Addresses = append(ks.Addresses, address)
+			if ks.FoundIn == nil {
+				ks.FoundIn = make(map[string]string)
+			}
+			ks.FoundIn[address] = l.Range
+		}
+	}
+	return len(ks.Addresses) >= ks.Request.Count
+}
+
+// finish ends the search; outstanding leases are gone on their next renewal
+func (ks *keyspace) finish(state KeyspaceState, now time.Time) {
+	ks.State = state
+	ks.FinishedAt = now
+}
+
+// snapshot copies the keyspace, counting the progress reported on live leases
+func (ks *keyspace) snapshot() Keyspace {
+	snapshot := ks.Keyspace
+	snapshot.Addresses = append([]string{}, ks.Addresses...)
+	snapshot.FoundIn = maps.Clone(ks.FoundIn)
+	snapshot.Leases = []Lease{}
+
+	checked := ks.cursor.Checked()
+	for _, l := range ks.leases {
+		checked.Add(checked, new(big.Int).SetUint64(l.Checked))
+		if ks.State == KeyspaceSearching {
+			snapshot.Leases = append(snapshot.Leases, l.Lease)
+		}
+	}
+	sort.Slice(snapshot.Leases, func(i, j int) bool {
+		return ks.leases[snapshot.Leases[i].ID].block.Offset.Cmp(ks.leases[snapshot.Leases[j].ID].block.Offset) < 0
+	})
+	snapshot.Checked = checked.String()
+	snapshot.Coverage, _ = new(big.Float).Quo(
+		new(big.Float).SetInt(checked.Mul(checked, big.NewInt(100))),
+		new(big.Float).SetInt(ks.cursor.Range().Size())).Float64()
+	return snapshot
+}
+
+// Public returns the view of a shared keyspace given to callers that do not own it:
+// its pattern, progress and found addresses, without the seed or any key range
+func (ks Keyspace) Public() Keyspace {
+	ks.Owner = ""
+	ks.Request.Seed = ""
+	ks.Range = ""
+	ks.Leases = []Lease{}
+	ks.FoundIn = nil
+	return ks
+}
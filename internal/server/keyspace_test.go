@@ -0,0 +1,216 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestCoordinator creates a coordinator with a controllable clock
+func newTestCoordinator() (*KeyspaceCoordinator, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	coordinator := NewKeyspaceCoordinator(time.Minute)
+	coordinator.now = func() time.Time { return now }
+	return coordinator, &now
+}
+
+func TestKeyspaceRequest_Validate(t *testing.T) {
+	req := KeyspaceRequest{JobRequest: JobRequest{Prefix: "abc"}}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Seed) != 64 || req.Keys != DefaultKeyspaceKeys || req.Stride != 1 || req.LeaseKeys != DefaultLeaseKeys {
+		t.Errorf("defaults not applied: %+v", req)
+	}
+
+	for name, req := range map[string]KeyspaceRequest{
+		"solana":   {JobRequest: JobRequest{Prefix: "abc", Network: "solana"}},
+		"mnemonic": {JobRequest: JobRequest{Prefix: "abc", WithMnemonic: true}},
+		"seed":     {JobRequest: JobRequest{Prefix: "abc"}, Seed: "not hex"},
+		"pattern":  {},
+	} {
+		if err := req.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestKeyspaceCoordinator_Leases(t *testing.T) {
+	coordinator, now := newTestCoordinator()
+	ks, err := coordinator.Create("", KeyspaceRequest{
+		JobRequest: JobRequest{Prefix: "abc", Count: 2},
+		Seed:       "00",
+		Keys:       100,
+		LeaseKeys:  40,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := coordinator.Create("", KeyspaceRequest{JobRequest: JobRequest{Prefix: "abc"}, Seed: "00", Keys: 100})
+	if ks.Range != again.Range {
+		t.Errorf("the same seed gave keyspaces %s and %s", ks.Range, again.Range)
+	}
+
+	first, _ := coordinator.Acquire(ks.ID, "agent-1")
+	second, _ := coordinator.Acquire(ks.ID, "agent-2")
+	third, _ := coordinator.Acquire(ks.ID, "agent-3")
+	if first.Keys != 40 || second.Keys != 40 || third.Keys != 20 || first.Range == second.Range {
+		t.Fatalf("leases %+v, %+v, %+v", first, second, third)
+	}
+	if _, err := coordinator.Acquire(ks.ID, "agent-4"); !IsLeaseUnavailable(err) {
+		t.Fatalf("Acquire() with every key leased = %v", err)
+	}
+
+	// agent-1 keeps renewing; the other two stop and their leases expire
+	*now = now.Add(40 * time.Second)
+	if _, err := coordinator.Renew(ks.ID, first.ID, LeaseReport{Checked: 10}); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(30 * time.Second)
+	if _, err := coordinator.Renew(ks.ID, second.ID, LeaseReport{}); !IsLeaseGone(err) {
+		t.Fatalf("Renew() of an expired lease = %v", err)
+	}
+	snapshot, _ := coordinator.Get(ks.ID)
+	if snapshot.Reassigned != 2 || len(snapshot.Leases) != 1 || snapshot.Checked != "10" {
+		t.Fatalf("after expiry: %+v", snapshot)
+	}
+
+	// The expired keys are handed out again
+	reassigned, err := coordinator.Acquire(ks.ID, "agent-4")
+	if err != nil || (reassigned.Range != second.Range && reassigned.Range != third.Range) {
+		t.Fatalf("Acquire() after expiry = %+v, %v", reassigned, err)
+	}
+	snapshot, err = coordinator.Complete(ks.ID, first.ID, LeaseReport{Checked: 40, Addresses: []string{"0xabc1"}})
+	if err != nil || snapshot.Coverage != 40 || snapshot.State != KeyspaceSearching {
+		t.Fatalf("Complete() = %+v, %v", snapshot, err)
+	}
+
+	// The second address completes the keyspace; the remaining lease is gone
+	if _, err := coordinator.Renew(ks.ID, reassigned.ID, LeaseReport{Checked: 5, Addresses: []string{"0xabc1", "0xabc2"}}); !IsLeaseGone(err) {
+		t.Fatalf("Renew() completing the keyspace = %v", err)
+	}
+	snapshot, _ = coordinator.Get(ks.ID)
+	if snapshot.State != KeyspaceCompleted || len(snapshot.Addresses) != 2 || len(snapshot.Leases) != 0 {
+		t.Errorf("completed keyspace: %+v", snapshot)
+	}
+	if _, err := coordinator.Acquire(ks.ID, "agent-5"); !IsLeaseGone(err) {
+		t.Errorf("Acquire() on a completed keyspace = %v", err)
+	}
+}
+
+func TestKeyspaceCoordinator_Exhausted(t *testing.T) {
+	coordinator, _ := newTestCoordinator()
+	ks, _ := coordinator.Create("", KeyspaceRequest{JobRequest: JobRequest{Prefix: "abc"}, Keys: 50, LeaseKeys: 30})
+
+	first, _ := coordinator.Acquire(ks.ID, "")
+	second, _ := coordinator.Acquire(ks.ID, "")
+	if err := coordinator.Abandon(ks.ID, second.ID); err != nil {
+		t.Fatal(err)
+	}
+	retried, _ := coordinator.Acquire(ks.ID, "")
+	if retried.Range != second.Range {
+		t.Errorf("abandoned lease %s was not handed out again, got %s", second.Range, retried.Range)
+	}
+	coordinator.Complete(ks.ID, first.ID, LeaseReport{Checked: first.Keys})
+	snapshot, _ := coordinator.Complete(ks.ID, retried.ID, LeaseReport{Checked: retried.Keys})
+	if snapshot.State != KeyspaceExhausted || snapshot.Coverage != 100 {
+		t.Errorf("exhausted keyspace: %+v", snapshot)
+	}
+}
+
+func TestAPIServer_KeyspaceLeases(t *testing.T) {
+	manager, _ := newTestManager(t)
+	api := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+	api.SetKeyspaceCoordinator(NewKeyspaceCoordinator(time.Minute))
+	srv := httptest.NewServer(api.Handler())
+	defer srv.Close()
+
+	post := func(path, body string, out interface{}) int {
+		t.Helper()
+		resp, err := http.Post(srv.URL+path, "application/json", bytes.NewBufferString(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		if out != nil {
+			_ = json.NewDecoder(resp.Body).Decode(out)
+		}
+		return resp.StatusCode
+	}
+
+	var ks Keyspace
+	if status := post("/keyspaces", `{"prefix":"abc","keys":10,"lease_keys":10}`, &ks); status != http.StatusCreated {
+		t.Fatalf("create keyspace: status %d", status)
+	}
+	var lease Lease
+	if status := post("/keyspaces/"+ks.ID+"/leases", `{"agent":"pod-0"}`, &lease); status != http.StatusCreated || lease.Keys != 10 {
+		t.Fatalf("acquire lease: status %d, %+v", status, lease)
+	}
+	if status := post("/keyspaces/"+ks.ID+"/leases", `{"agent":"pod-1"}`, nil); status != http.StatusConflict {
+		t.Errorf("acquire with every key leased: status %d, want 409", status)
+	}
+	if status := post("/keyspaces/"+ks.ID+"/leases/"+lease.ID+"/complete", `{"checked":10}`, &ks); status != http.StatusOK || ks.State != KeyspaceExhausted {
+		t.Errorf("complete lease: status %d, state %s", status, ks.State)
+	}
+	if status := post("/keyspaces/"+ks.ID+"/leases", `{"agent":"pod-1"}`, nil); status != http.StatusGone {
+		t.Errorf("acquire on an exhausted keyspace: status %d, want 410", status)
+	}
+	if status := post("/keyspaces/missing/leases", `{}`, nil); status != http.StatusNotFound {
+		t.Errorf("acquire on a missing keyspace: status %d, want 404", status)
+	}
+}
+
+func TestAPIServer_SharedKeyspaces(t *testing.T) {
+	manager, _ := newTestManager(t)
+	api := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+	api.SetKeyspaceCoordinator(NewKeyspaceCoordinator(time.Minute))
+	api.SetKeyring(testKeyring(t))
+	handler := api.Handler()
+
+	var shared, private Keyspace
+	rec := authRequest(t, handler, http.MethodPost, "/keyspaces", "alice-token", `{"prefix":"abc","keys":100,"lease_keys":10,"count":2,"shared":true}`)
+	if rec.Code != http.StatusCreated || json.Unmarshal(rec.Body.Bytes(), &shared) != nil {
+		t.Fatalf("create shared keyspace: status %d", rec.Code)
+	}
+	rec = authRequest(t, handler, http.MethodPost, "/keyspaces", "alice-token", `{"prefix":"abc","keys":100}`)
+	if rec.Code != http.StatusCreated || json.Unmarshal(rec.Body.Bytes(), &private) != nil {
+		t.Fatalf("create keyspace: status %d", rec.Code)
+	}
+
+	// Another key sees only the shared keyspace, without its seed or ranges
+	var listed []Keyspace
+	rec = authRequest(t, handler, http.MethodGet, "/keyspaces?shared=true", "bob-token", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &listed); err != nil || len(listed) != 1 || listed[0].ID != shared.ID {
+		t.Fatalf("shared keyspaces listed for bob: %s", rec.Body)
+	}
+	if listed[0].Request.Seed != "" || listed[0].Range != "" || listed[0].Owner != "" {
+		t.Errorf("public view leaks the keyspace: %+v", listed[0])
+	}
+	if rec := authRequest(t, handler, http.MethodGet, "/keyspaces/"+private.ID, "bob-token", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("get a keyspace that is not shared: status %d, want 404", rec.Code)
+	}
+
+	var lease Lease
+	rec = authRequest(t, handler, http.MethodPost, "/keyspaces/"+shared.ID+"/leases", "bob-token", `{"agent":"donor"}`)
+	if rec.Code != http.StatusCreated || json.Unmarshal(rec.Body.Bytes(), &lease) != nil {
+		t.Fatalf("donor acquire lease: status %d", rec.Code)
+	}
+	var completed Keyspace
+	rec = authRequest(t, handler, http.MethodPost, "/keyspaces/"+shared.ID+"/leases/"+lease.ID+"/complete", "bob-token", `{"checked":10,"addresses":["0xabc1"]}`)
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &completed) != nil {
+		t.Fatalf("donor complete lease: status %d", rec.Code)
+	}
+	if completed.FoundIn != nil || len(completed.Addresses) != 1 {
+		t.Errorf("donor sees %+v, want the address without its lease range", completed)
+	}
+
+	// The owner learns which lease the address was found in
+	var owned Keyspace
+	rec = authRequest(t, handler, http.MethodGet, "/keyspaces/"+shared.ID, "alice-token", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &owned); err != nil || owned.FoundIn["0xabc1"] != lease.Range {
+		t.Errorf("owner view found_in = %v, want 0xabc1 in %s", owned.FoundIn, lease.Range)
+	}
+}
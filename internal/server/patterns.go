@@ -0,0 +1,374 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/vanitymath"
+	"bloco-eth/pkg/wallet"
+)
+
+// MaxPatterns is the largest number of patterns the pattern set may hold
+const MaxPatterns = 10000
+
+// patternRetryDelay is how long the pattern set waits after a failed search
+const patternRetryDelay = 5 * time.Second
+
+// Sources of pattern set entries
+const (
+	PatternSourceAPI  = "api"
+	PatternSourceFile = "file"
+)
+
+// PatternSpec is a pattern of the pattern set: a prefix, a suffix or both
+type PatternSpec struct {
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// String names the pattern as prefix, prefix:suffix or :suffix
+func (p PatternSpec) String() string {
+	if p.Suffix == "" {
+		return p.Prefix
+	}
+	return p.Prefix + ":" + p.Suffix
+}
+
+// PatternEntry is one pattern of the set. Once a wallet is found for it, the
+// pattern is retired and keeps the address.
+type PatternEntry struct {
+	Pattern    string    `json:"pattern"`
+	Prefix     string    `json:"prefix,omitempty"`
+	Suffix     string    `json:"suffix,omitempty"`
+	Source     string    `json:"source"`
+	Difficulty float64   `json:"difficulty"`
+	AddedAt    time.Time `json:"added_at"`
+	Address    string    `json:"address,omitempty"`
+	FoundAt    time.Time `json:"found_at,omitzero"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// PatternSetStatus is the pattern set with the search's combined difficulty and ETA
+type PatternSetStatus struct {
+	Active     int     `json:"active"`
+	Difficulty float64 `json:"difficulty"`
+	Speed      float64 `json:"speed"`
+	// ETASeconds is the time to a 50% chance of the next match at the current speed
+	ETASeconds float64        `json:"eta_seconds,omitempty"`
+	Error      string         `json:"error,omitempty"`
+	Patterns   []PatternEntry `json:"patterns"`
+}
+
+// PatternRunner searches for wallets matching any pattern of a set that can change
+// while it runs. The workers pick up every change without restarting, and each
+// pattern is retired once a wallet is found for it.
+type PatternRunner struct {
+	base      wallet.GenerationCriteria
+	newPool   PoolFactory
+	sink      ResultSink
+	scheduler *worker.Scheduler
+	set       *worker.PatternSet
+
+	mu      sync.Mutex
+	entries map[string]*PatternEntry
+	changed chan struct{} // closed and replaced when the active patterns change
+	// cancelSearch stops the running search once no pattern is left
+	cancelSearch context.CancelFunc
+	pool         worker.WorkerPool
+	lastErr      error
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPatternRunner returns a runner applying base, without its prefix and suffix,
+// to every pattern. Wallets are passed to sink; scheduler, when not nil, gives the
+// search a low priority share of its threads.
+func NewPatternRunner(base wallet.GenerationCriteria, newPool PoolFactory, sink ResultSink, scheduler *worker.Scheduler) *PatternRunner {
+	base.Prefix, base.Suffix = "", ""
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &PatternRunner{
+		base:      base,
+		newPool:   newPool,
+		sink:      sink,
+		scheduler: scheduler,
+		set:       worker.NewPatternSet(nil),
+		entries:   make(map[string]*PatternEntry),
+		changed:   make(chan struct{}),
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Add adds patterns to the set, or reactivates them if they were retired. Either
+// every pattern is valid and added, or none is.
+func (r *PatternRunner) Add(source string, patterns []PatternSpec) ([]PatternEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries, err := r.newEntriesLocked("add_patterns", source, patterns)
+	if err != nil {
+		return nil, err
+	}
+	added := make([]PatternEntry, 0, len(entries))
+	for _, entry := range entries {
+		if existing, ok := r.entries[entry.Pattern]; ok && existing.Address == "" {
+			added = append(added, *existing)
+			continue
+		}
+		r.entries[entry.Pattern] = entry
+		added = append(added, *entry)
+	}
+	r.compileLocked()
+	return added, nil
+}
+
+// Remove drops a pattern, active or retired, and reports whether it was in the set
+func (r *PatternRunner) Remove(pattern string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.entries[pattern]; !ok {
+		return false
+	}
+	delete(r.entries, pattern)
+	r.compileLocked()
+	return true
+}
+
+// Reload makes the file's patterns those of patterns: it adds the new ones and
+// drops the active ones no longer listed. Patterns added through the API and
+// retired patterns are kept. Nothing changes if a pattern is invalid.
+func (r *PatternRunner) Reload(patterns []PatternSpec) (added, removed int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries, err := r.newEntriesLocked("reload_patterns", PatternSourceFile, patterns)
+	if err != nil {
+		return 0, 0, err
+	}
+	listed := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		listed[entry.Pattern] = true
+		if _, ok := r.entries[entry.Pattern]; !ok {
+			r.entries[entry.Pattern] = entry
+			added++
+		}
+	}
+	for pattern, entry := range r.entries {
+		if entry.Source == PatternSourceFile && entry.Address == "" && !listed[pattern] {
+			delete(r.entries, pattern)
+			removed++
+		}
+	}
+	r.compileLocked()
+	return added, removed, nil
+}
+
+// Status returns the patterns, oldest first, with the combined difficulty of the
+// active ones and the ETA to the next match
+func (r *PatternRunner) Status() PatternSetStatus {
+	r.mu.Lock()
+	status := PatternSetStatus{Active: r.set.Len(), Difficulty: r.set.Difficulty(), Patterns: []PatternEntry{}}
+	for _, entry := range r.entries {
+		status.Patterns = append(status.Patterns, *entry)
+	}
+	if r.lastErr != nil {
+		status.Error = r.lastErr.Error()
+	}
+	pool := r.pool
+	r.mu.Unlock()
+
+	sort.Slice(status.Patterns, func(i, k int) bool {
+		a, b := status.Patterns[i], status.Patterns[k]
+		if !a.AddedAt.Equal(b.AddedAt) {
+			return a.AddedAt.Before(b.AddedAt)
+		}
+		return a.Pattern < b.Pattern
+	})
+	if pool != nil && status.Active > 0 {
+		status.Speed = pool.GetStatsCollector().GetAggregatedStats().TotalSpeed
+		if status.Speed > 0 {
+			status.ETASeconds = float64(vanitymath.Attempts50(status.Difficulty)) / status.Speed
+		}
+	}
+	return status
+}
+
+// Shutdown stops the search and waits for it to end
+func (r *PatternRunner) Shutdown(ctx context.Context) error {
+	r.cancel()
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return errors.NewCancellationError("shutdown_patterns", "timed out waiting for the pattern search to stop")
+	}
+}
+
+// newEntriesLocked validates patterns and returns an entry for each of them
+func (r *PatternRunner) newEntriesLocked(operation, source string, patterns []PatternSpec) ([]*PatternEntry, error) {
+	// A reload replaces the file's patterns, so only its own size counts
+	if len(patterns) > MaxPatterns || (source == PatternSourceAPI && len(r.entries)+len(patterns) > MaxPatterns) {
+		return nil, errors.NewValidationError(operation,
+			fmt.Sprintf("the pattern set holds at most %d patterns", MaxPatterns))
+	}
+	now := time.Now()
+	entries := make([]*PatternEntry, 0, len(patterns))
+	for _, p := range patterns {
+		p.Prefix, p.Suffix = strings.TrimSpace(p.Prefix), strings.TrimSpace(p.Suffix)
+		criteria := r.criteria(p)
+		if p.Prefix == "" && p.Suffix == "" {
+			return nil, errors.NewValidationError(operation, "every pattern needs a prefix or suffix")
+		}
+		if err := criteria.Validate(); err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeValidation, operation, "invalid pattern "+p.String())
+		}
+		entries = append(entries, &PatternEntry{
+			Pattern:    p.String(),
+			Prefix:     p.Prefix,
+			Suffix:     p.Suffix,
+			Source:     source,
+			Difficulty: criteria.Difficulty(),
+			AddedAt:    now,
+		})
+	}
+	return entries, nil
+}
+
+// criteria returns the criteria of a pattern
+func (r *PatternRunner) criteria(p PatternSpec) wallet.GenerationCriteria {
+	criteria := r.base
+	criteria.Prefix, criteria.Suffix = p.Prefix, p.Suffix
+	return criteria
+}
+
+// compileLocked swaps the active patterns into the set and wakes the search.
+// Callers must hold r.mu.
+func (r *PatternRunner) compileLocked() {
+	var active []wallet.GenerationCriteria
+	for _, entry := range r.entries {
+		if entry.Address == "" {
+			active = append(active, r.criteria(PatternSpec{Prefix: entry.Prefix, Suffix: entry.Suffix}))
+		}
+	}
+	r.set.Set(active)
+	if len(active) == 0 && r.cancelSearch != nil {
+		r.cancelSearch()
+	}
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// run searches the set until Shutdown, idling while it is empty
+func (r *PatternRunner) run() {
+	defer close(r.done)
+	defer func() {
+		if r.pool != nil {
+			_ = r.pool.Shutdown()
+		}
+	}()
+	for {
+		r.mu.Lock()
+		active, changed := r.set.Len(), r.changed
+		r.mu.Unlock()
+		if active == 0 {
+			select {
+			case <-changed:
+				continue
+			case <-r.ctx.Done():
+				return
+			}
+		}
+
+		result, err := r.search()
+		switch {
+		case r.ctx.Err() != nil:
+			return
+		case errors.IsErrorType(err, errors.ErrorTypeCancellation):
+			// The last pattern was removed
+		case err != nil:
+			r.setError(err)
+			select {
+			case <-time.After(patternRetryDelay):
+			case <-r.ctx.Done():
+				return
+			}
+		default:
+			r.found(result)
+		}
+	}
+}
+
+// search finds the next wallet matching the set
+func (r *PatternRunner) search() (*wallet.GenerationResult, error) {
+	r.mu.Lock()
+	if r.pool == nil {
+		pool, err := r.newPool(r.base.Network)
+		if err == nil {
+			err = pool.Start()
+		}
+		if err != nil {
+			r.mu.Unlock()
+			return nil, errors.WrapError(err, errors.ErrorTypeWorker, "search_patterns", "failed to start worker pool")
+		}
+		r.pool = pool
+	}
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+	r.cancelSearch = cancel
+	pool := r.pool
+	// The set may have emptied since run looked
+	empty := r.set.Len() == 0
+	r.mu.Unlock()
+	if empty {
+		return nil, errors.NewCancellationError("search_patterns", "no patterns left")
+	}
+
+	share := r.scheduler.Join(worker.PriorityLow.Weight())
+	defer share.Leave()
+	ctx = worker.WithShare(worker.WithPatternSet(ctx, r.set), share)
+	return pool.GenerateWalletWithContext(ctx, r.base)
+}
+
+// found saves the wallet of a match and retires its pattern. A wallet whose pattern
+// was removed meanwhile is saved all the same.
+func (r *PatternRunner) found(result *wallet.GenerationResult) {
+	criteria, matched := r.set.Match(result.Wallet.Address)
+	if !matched {
+		criteria = r.base
+	}
+	err := r.sink(context.WithValue(r.ctx, criteriaKey{}, criteria), result.Wallet)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[PatternSpec{Prefix: criteria.Prefix, Suffix: criteria.Suffix}.String()]
+	if err != nil {
+		// The pattern stays active, so the next match is saved instead
+		r.lastErr = err
+		if ok {
+			entry.Error = err.Error()
+		}
+		return
+	}
+	r.lastErr = nil
+	if !matched || !ok {
+		return
+	}
+	entry.Address, entry.FoundAt, entry.Error = result.Wallet.Address, time.Now(), ""
+	r.compileLocked()
+}
+
+// setError records why the last search failed
+func (r *PatternRunner) setError(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastErr = err
+}
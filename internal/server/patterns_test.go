@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+// newTestRunner creates a pattern runner using single-thread pools and recording saved wallets
+func newTestRunner(t *testing.T) (*PatternRunner, func() []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var saved []string
+	runner := NewPatternRunner(wallet.GenerationCriteria{Network: "ethereum"}, func(network string) (worker.WorkerPool, error) {
+		return worker.NewPool(1, network), nil
+	}, func(ctx context.Context, w *wallet.Wallet) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved = append(saved, w.Address)
+		return nil
+	}, nil)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = runner.Shutdown(ctx)
+	})
+	return runner, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), saved...)
+	}
+}
+
+func TestPatternRunner_AddRemoveReload(t *testing.T) {
+	runner, _ := newTestRunner(t)
+	hard := []PatternSpec{{Prefix: "ffffffffff"}, {Prefix: "eeeeeeeeee", Suffix: "dd"}}
+
+	if _, err := runner.Add(PatternSourceAPI, []PatternSpec{{Prefix: "ffffffffff"}, {Prefix: "xyz"}}); err == nil {
+		t.Fatal("Add() accepted an invalid pattern")
+	}
+	if status := runner.Status(); len(status.Patterns) != 0 {
+		t.Fatalf("a rejected Add() left %d patterns", len(status.Patterns))
+	}
+
+	added, err := runner.Add(PatternSourceAPI, hard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(added) != 2 || added[1].Pattern != "eeeeeeeeee:dd" {
+		t.Fatalf("Add() = %+v", added)
+	}
+	status := runner.Status()
+	if status.Active != 2 || status.Difficulty >= added[0].Difficulty {
+		t.Errorf("status = %d active of difficulty %v, want 2 of less than %v", status.Active, status.Difficulty, added[0].Difficulty)
+	}
+
+	// Reload only touches the file's patterns
+	if n, m, err := runner.Reload([]PatternSpec{{Suffix: "cccccccccc"}, {Prefix: "ffffffffff"}}); err != nil || n != 1 || m != 0 {
+		t.Fatalf("Reload() = %d added, %d removed, %v", n, m, err)
+	}
+	if n, m, err := runner.Reload(nil); err != nil || n != 0 || m != 1 {
+		t.Fatalf("Reload(nil) = %d added, %d removed, %v", n, m, err)
+	}
+	if _, _, err := runner.Reload([]PatternSpec{{Prefix: "zz"}}); err == nil {
+		t.Fatal("Reload() accepted an invalid pattern")
+	}
+
+	if !runner.Remove("eeeeeeeeee:dd") || runner.Remove("eeeeeeeeee:dd") {
+		t.Error("Remove() did not report the pattern once")
+	}
+	if status := runner.Status(); status.Active != 1 || status.Patterns[0].Pattern != "ffffffffff" {
+		t.Errorf("status = %+v, want ffffffffff alone", status)
+	}
+}
+
+func TestPatternRunner_RetiresFoundPatterns(t *testing.T) {
+	runner, saved := newTestRunner(t)
+	if _, err := runner.Add(PatternSourceAPI, []PatternSpec{{Prefix: "a"}, {Suffix: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(30 * time.Second)
+	for runner.Status().Active > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("patterns still active: %+v", runner.Status())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, entry := range runner.Status().Patterns {
+		address := strings.ToLower(entry.Address)
+		if !strings.HasPrefix(address, "0x"+entry.Prefix) || !strings.HasSuffix(address, entry.Suffix) || entry.FoundAt.IsZero() {
+			t.Errorf("pattern %s retired with %q", entry.Pattern, entry.Address)
+		}
+	}
+	if got := saved(); len(got) != 2 {
+		t.Errorf("saved %d wallets, want 2", len(got))
+	}
+
+	// A found pattern can be searched for again
+	if _, err := runner.Add(PatternSourceAPI, []PatternSpec{{Prefix: "ffffffffff"}, {Prefix: "a"}}); err != nil {
+		t.Fatal(err)
+	}
+	if status := runner.Status(); status.Active != 2 {
+		t.Errorf("Active = %d after re-adding a retired pattern, want 2", status.Active)
+	}
+}
+
+func TestAPIServer_PatternRoutes(t *testing.T) {
+	manager, _ := newTestManager(t)
+	runner, _ := newTestRunner(t)
+	api := NewAPIServer("127.0.0.1:0", manager, time.Minute)
+	api.SetKeyring(testKeyring(t))
+	api.SetPatternRunner(runner)
+	handler := api.Handler()
+
+	if rec := authRequest(t, handler, http.MethodGet, "/patterns", "", ""); rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous GET /patterns = %d, want 401", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodGet, "/patterns", "bob-token", ""); rec.Code != http.StatusForbidden {
+		t.Errorf("non-admin GET /patterns = %d, want 403", rec.Code)
+	}
+	body := `{"patterns":[{"prefix":"ffffffffff"}]}`
+	if rec := authRequest(t, handler, http.MethodPost, "/patterns", "admin-token", body); rec.Code != http.StatusCreated {
+		t.Fatalf("POST /patterns = %d: %s", rec.Code, rec.Body)
+	}
+	if rec := authRequest(t, handler, http.MethodPost, "/patterns", "admin-token", `{"patterns":[{"prefix":"xyz"}]}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("POST of an invalid pattern = %d, want 400", rec.Code)
+	}
+
+	rec := authRequest(t, handler, http.MethodGet, "/patterns", "admin-token", "")
+	var status PatternSetStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Active != 1 || status.Difficulty <= 0 || status.Patterns[0].Source != PatternSourceAPI {
+		t.Errorf("GET /patterns = %+v", status)
+	}
+
+	if rec := authRequest(t, handler, http.MethodDelete, "/patterns/ffffffffff", "admin-token", ""); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE /patterns/ffffffffff = %d, want 204", rec.Code)
+	}
+	if rec := authRequest(t, handler, http.MethodDelete, "/patterns/ffffffffff", "admin-token", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("second DELETE = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"fmt"
+
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/utils"
+)
+
+// quotaContextKey marks errors caused by an exhausted quota
+const quotaContextKey = "quota"
+
+// Quota limits the jobs an owner may run. Zero values mean unlimited.
+type Quota struct {
+	MaxConcurrentJobs int     `json:"max_concurrent_jobs,omitempty"`
+	MaxDifficulty     float64 `json:"max_difficulty,omitempty"`
+	CPUSeconds        float64 `json:"cpu_seconds,omitempty"`
+}
+
+// QuotaLookup returns the quota for a job owner
+type QuotaLookup func(owner string) Quota
+
+// IsQuotaError reports whether err was caused by an exhausted quota
+func IsQuotaError(err error) bool {
+	_, ok := errors.GetErrorContext(err)[quotaContextKey]
+	return ok
+}
+
+// newQuotaError creates a validation error tagged with the exhausted quota
+func newQuotaError(operation, quota, message string) error {
+	return errors.NewValidationError(operation, message).WithContext(quotaContextKey, quota)
+}
+
+// quotaFor returns the quota applying to owner
+func (m *JobManager) quotaFor(owner string) Quota {
+	if m.config.Quotas == nil || owner == "" {
+		return Quota{}
+	}
+	return m.config.Quotas(owner)
+}
+
+// checkQuotaLocked verifies that owner may start another run of req, ignoring the
+// job being resumed or retried. Callers must hold m.mu.
+func (m *JobManager) checkQuotaLocked(operation, owner string, req JobRequest, exclude string) error {
+	quota := m.quotaFor(owner)
+
+	if quota.MaxDifficulty > 0 {
+		criteria := req.Criteria()
+		difficulty := criteria.Difficulty()
+		if difficulty > quota.MaxDifficulty {
+			return newQuotaError(operation, "max_difficulty", fmt.Sprintf(
+				"pattern difficulty %s exceeds the limit of %s for this key",
+				utils.FormatDifficulty(difficulty), utils.FormatDifficulty(quota.MaxDifficulty)))
+		}
+	}
+
+	if quota.MaxConcurrentJobs > 0 {
+		active := 0
+		for _, j := range m.jobs {
+			if j.Owner == owner && j.ID != exclude && (j.State == JobQueued || j.State == JobRunning) {
+				active++
+			}
+		}
+		if active >= quota.MaxConcurrentJobs {
+			return newQuotaError(operation, "max_concurrent_jobs", fmt.Sprintf(
+				"key already has %d active jobs (limit %d)", active, quota.MaxConcurrentJobs))
+		}
+	}
+
+	if quota.CPUSeconds > 0 {
+		if used := m.cpuUsageLocked(owner); used >= quota.CPUSeconds {
+			return newQuotaError(operation, "cpu_seconds", fmt.Sprintf(
+				"key has used %.0f of %.0f CPU-seconds", used, quota.CPUSeconds))
+		}
+	}
+
+	return nil
+}
+
+// cpuUsageLocked sums the CPU-seconds of an owner's retained jobs. Callers must hold m.mu.
+func (m *JobManager) cpuUsageLocked(owner string) float64 {
+	var used float64
+	for _, j := range m.jobs {
+		if j.Owner == owner {
+			used += j.CPUSeconds
+		}
+	}
+	return used
+}
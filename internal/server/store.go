@@ -0,0 +1,129 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"bloco-eth/internal/faultfs"
+	"bloco-eth/pkg/errors"
+)
+
+// jobStoreVersion is the current on-disk format of the job store
+const jobStoreVersion = 1
+
+// JobStore persists jobs so they survive server restarts
+type JobStore interface {
+	// Load returns all stored jobs
+	Load() ([]Job, error)
+
+	// Save replaces the stored jobs
+	Save(jobs []Job) error
+}
+
+// jobStoreFile is the on-disk layout of a FileJobStore
+type jobStoreFile struct {
+	Version int   `json:"version"`
+	Jobs    []Job `json:"jobs"`
+}
+
+// FileJobStore keeps jobs in a JSON file that is replaced atomically on each save:
+// the new file is written and synced beside the old one, then renamed over it, so
+// a crash leaves either the previous jobs or the new ones, never a truncated file.
+// Only addresses are stored; private keys live in the keystore files.
+type FileJobStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileJobStore creates a store backed by the file at path
+func NewFileJobStore(path string) *FileJobStore {
+	return &FileJobStore{path: path}
+}
+
+// Path returns the file backing the store
+func (s *FileJobStore) Path() string {
+	return s.path
+}
+
+// Load reads jobs from the file. A missing file is an empty store.
+func (s *FileJobStore) Load() ([]Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"load_jobs", fmt.Sprintf("failed to read job store %s", s.path))
+	}
+
+	var file jobStoreFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"load_jobs", fmt.Sprintf("job store %s is corrupted", s.path))
+	}
+	if file.Version != jobStoreVersion {
+		return nil, errors.NewConfigurationError("load_jobs",
+			fmt.Sprintf("unsupported job store version %d", file.Version))
+	}
+
+	return file.Jobs, nil
+}
+
+// Save writes jobs to a temporary file, syncs it and renames it over the store
+func (s *FileJobStore) Save(jobs []Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(jobStoreFile{Version: jobStoreVersion, Jobs: jobs}, "", "  ")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "save_jobs", "failed to encode jobs")
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"save_jobs", fmt.Sprintf("failed to create directory %s", dir))
+	}
+
+	tmp, err := faultfs.CreateTemp(dir, "."+filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "save_jobs", "failed to create temporary file")
+	}
+	tmpPath := tmp.Name()
+	defer faultfs.Remove(tmpPath)
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "save_jobs", "failed to write job store")
+	}
+
+	if err := faultfs.Rename(tmpPath, s.path); err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration,
+			"save_jobs", fmt.Sprintf("failed to replace job store %s", s.path))
+	}
+	syncDir(dir)
+	return nil
+}
+
+// syncDir flushes a rename in dir to disk. Errors are ignored: some platforms,
+// such as Windows, cannot sync a directory, and the rename itself has succeeded.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
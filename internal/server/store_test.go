@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/faultfs"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestFileJobStore_RoundTrip(t *testing.T) {
+	store := NewFileJobStore(filepath.Join(t.TempDir(), "state", "jobs.json"))
+
+	jobs, err := store.Load()
+	if err != nil || len(jobs) != 0 {
+		t.Fatalf("Load() on missing file = %v, %v; expected empty store", jobs, err)
+	}
+
+	saved := []Job{
+		{ID: "one", Request: JobRequest{Prefix: "abc", Count: 2}, State: JobRunning, Addresses: []string{"0xabc1"}, Attempts: 42},
+		{ID: "two", Request: JobRequest{Suffix: "ff", Count: 1}, State: JobFailed, Addresses: []string{}, Error: "boom", Retries: 2},
+	}
+	if err := store.Save(saved); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	info, err := os.Stat(store.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("store permissions = %v, expected 0600", info.Mode().Perm())
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].ID != "one" || loaded[0].Attempts != 42 || loaded[1].Retries != 2 {
+		t.Errorf("unexpected jobs after round trip: %+v", loaded)
+	}
+
+	entries, _ := os.ReadDir(filepath.Dir(store.Path()))
+	if len(entries) != 1 {
+		t.Errorf("expected only the store file, found %d entries", len(entries))
+	}
+}
+
+func TestFileJobStore_Corrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileJobStore(path).Load(); err == nil {
+		t.Error("expected error loading corrupted store")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version":99,"jobs":[]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileJobStore(path).Load(); err == nil {
+		t.Error("expected error loading unsupported version")
+	}
+}
+
+func TestFileJobStore_SaveFaults(t *testing.T) {
+	store := NewFileJobStore(filepath.Join(t.TempDir(), "jobs.json"))
+	if err := store.Save([]Job{{ID: "kept", State: JobCompleted, Addresses: []string{"0xabc1"}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, fault := range map[string]faultfs.Fault{
+		"disk full":         {Op: faultfs.OpWrite, Err: syscall.ENOSPC},
+		"partial write":     {Op: faultfs.OpWrite, Short: 8, Err: syscall.ENOSPC},
+		"failed sync":       {Op: faultfs.OpSync, Err: syscall.EIO},
+		"permission denied": {Op: faultfs.OpRename, Path: "jobs.json", Err: syscall.EACCES},
+	} {
+		restore := faultfs.Inject(fault)
+		err := store.Save([]Job{{ID: "lost", State: JobRunning}})
+		restore()
+		if !stderrors.Is(err, fault.Err) {
+			t.Errorf("%s: Save() error = %v, want %v", name, err, fault.Err)
+		}
+
+		// The previous jobs are kept and the temporary file removed
+		loaded, err := store.Load()
+		if err != nil || len(loaded) != 1 || loaded[0].ID != "kept" {
+			t.Errorf("%s: Load() = %+v, %v, want the previous jobs", name, loaded, err)
+		}
+		if entries, _ := os.ReadDir(filepath.Dir(store.Path())); len(entries) != 1 {
+			t.Errorf("%s: Save() left %d files behind", name, len(entries))
+		}
+	}
+}
+
+// newStoreManager creates a job manager persisting to store
+func newStoreManager(t *testing.T, store JobStore, sink ResultSink, configure func(*JobManagerConfig)) *JobManager {
+	t.Helper()
+
+	cfg := DefaultJobManagerConfig()
+	cfg.Store = store
+	cfg.RetryBackoff = 0
+	if configure != nil {
+		configure(&cfg)
+	}
+
+	manager := NewJobManagerWithConfig(func(network string) (worker.WorkerPool, error) {
+		return worker.NewPool(1, network), nil
+	}, sink, cfg)
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = manager.Shutdown(ctx)
+	})
+	return manager
+}
+
+func TestJobManager_RestoreRequeuesUnfinishedJobs(t *testing.T) {
+	store := NewFileJobStore(filepath.Join(t.TempDir(), "jobs.json"))
+	if err := store.Save([]Job{
+		{ID: "interrupted", Request: JobRequest{Prefix: "a", Count: 2}, State: JobRunning, Addresses: []string{"0xa000000000000000000000000000000000000001"}, Attempts: 10, CreatedAt: time.Now()},
+		{ID: "paused", Request: JobRequest{Prefix: "abcdefabcdef", Count: 1}, State: JobPaused, CreatedAt: time.Now()},
+		{ID: "done", Request: JobRequest{Prefix: "a", Count: 1}, State: JobCompleted, Addresses: []string{"0xa1"}, CreatedAt: time.Now(), FinishedAt: time.Now()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := newStoreManager(t, store, nil, nil)
+	requeued, err := manager.Restore()
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if requeued != 1 {
+		t.Errorf("requeued = %d, expected 1", requeued)
+	}
+
+	job := waitForState(t, manager, "interrupted")
+	if job.State != JobCompleted || len(job.Addresses) != 2 {
+		t.Errorf("interrupted job should finish its remaining wallet: %+v", job)
+	}
+	if job.Attempts <= 10 {
+		t.Errorf("attempts should accumulate across restarts, got %d", job.Attempts)
+	}
+
+	if job, _ := manager.Get("paused"); job.State != JobPaused {
+		t.Errorf("paused job state = %s, expected paused", job.State)
+	}
+
+	stored, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, job := range stored {
+		if job.ID == "interrupted" && job.State != JobCompleted {
+			t.Errorf("store not updated after completion: %+v", job)
+		}
+	}
+}
+
+func TestJobManager_ShutdownKeepsJobsQueued(t *testing.T) {
+	store := NewFileJobStore(filepath.Join(t.TempDir(), "jobs.json"))
+	manager := newStoreManager(t, store, nil, nil)
+
+	job, err := manager.Submit(JobRequest{Prefix: "abcdefabcdef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := manager.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := store.Load()
+	if err != nil || len(stored) != 1 {
+		t.Fatalf("Load() = %v, %v", stored, err)
+	}
+	if stored[0].ID != job.ID || stored[0].State != JobQueued {
+		t.Errorf("interrupted job should be stored as queued, got %+v", stored[0])
+	}
+}
+
+func TestJobManager_RetryPolicy(t *testing.T) {
+	var calls atomic.Int32
+	failingSink := func(ctx context.Context, w *wallet.Wallet) error {
+		calls.Add(1)
+		return fmt.Errorf("disk full")
+	}
+
+	manager := newStoreManager(t, nil, failingSink, func(cfg *JobManagerConfig) {
+		cfg.MaxRetries = 2
+	})
+
+	job, err := manager.Submit(JobRequest{Prefix: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job = waitForState(t, manager, job.ID)
+	if job.State != JobFailed || job.Retries != 2 || job.Error == "" {
+		t.Errorf("expected failed job after 2 retries, got %+v", job)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("sink called %d times, expected 3", calls.Load())
+	}
+
+	job, err = manager.Retry(job.ID)
+	if err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+	if job.State != JobQueued || job.Error != "" {
+		t.Errorf("unexpected job after manual retry: %+v", job)
+	}
+	waitForState(t, manager, job.ID)
+
+	if _, err := manager.Retry("missing"); err == nil {
+		t.Error("expected error retrying unknown job")
+	}
+}
+
+func TestJobManager_RetryRejectsActiveJob(t *testing.T) {
+	manager := newStoreManager(t, nil, nil, nil)
+
+	job, err := manager.Submit(JobRequest{Prefix: "abcdefabcdef"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Retry(job.ID); err == nil {
+		t.Error("expected error retrying a queued or running job")
+	}
+}
+
+func TestJobManager_Retention(t *testing.T) {
+	store := NewFileJobStore(filepath.Join(t.TempDir(), "jobs.json"))
+	old := time.Now().Add(-48 * time.Hour)
+	if err := store.Save([]Job{
+		{ID: "expired", Request: JobRequest{Prefix: "a", Count: 1}, State: JobCompleted, CreatedAt: old, FinishedAt: old},
+		{ID: "recent", Request: JobRequest{Prefix: "a", Count: 1}, State: JobFailed, CreatedAt: time.Now(), FinishedAt: time.Now()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := newStoreManager(t, store, nil, func(cfg *JobManagerConfig) {
+		cfg.Retention = 24 * time.Hour
+	})
+	if _, err := manager.Restore(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := manager.Get("expired"); ok {
+		t.Error("expired job should be pruned")
+	}
+	if _, ok := manager.Get("recent"); !ok {
+		t.Error("recent job should be kept")
+	}
+
+	stored, _ := store.Load()
+	if len(stored) != 1 || stored[0].ID != "recent" {
+		t.Errorf("store should only contain the recent job, got %+v", stored)
+	}
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webAssets holds the embedded dashboard served by serve --ui
+//
+//go:embed web
+var webAssets embed.FS
+
+// registerUI serves the embedded dashboard at the root path
+func registerUI(mux *http.ServeMux) {
+	assets, err := fs.Sub(webAssets, "web")
+	if err != nil {
+		// The embedded directory is fixed at build time
+		panic(err)
+	}
+
+	files := http.FileServer(http.FS(assets))
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		setUIHeaders(w)
+		files.ServeHTTP(w, r)
+	})
+	mux.HandleFunc("GET /ui/", func(w http.ResponseWriter, r *http.Request) {
+		setUIHeaders(w)
+		http.StripPrefix("/ui", files).ServeHTTP(w, r)
+	})
+}
+
+// setUIHeaders restricts the dashboard to its own origin
+func setUIHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Security-Policy",
+		"default-src 'self'; connect-src 'self' ws: wss:; img-src 'self' data:; frame-ancestors 'none'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+}
@@ -0,0 +1,255 @@
+// Bloco dashboard: polls /jobs for the job list and streams live progress
+// for unfinished jobs over /ws/jobs/{id}.
+(function () {
+  "use strict";
+
+  const HISTORY = 120;
+  const FINAL = new Set(["completed", "failed", "cancelled"]);
+
+  const streams = new Map(); // job id -> WebSocket
+  const progress = new Map(); // job id -> latest ProgressEvent
+  const history = new Map(); // job id -> speed samples
+  let jobs = [];
+  let selected = null;
+
+  const $ = (id) => document.getElementById(id);
+
+  function el(tag, text, className) {
+    const node = document.createElement(tag);
+    if (text !== undefined) node.textContent = text;
+    if (className) node.className = className;
+    return node;
+  }
+
+  function formatNumber(n) {
+    if (!isFinite(n)) return "-";
+    if (n >= 1e9) return (n / 1e9).toFixed(2) + "B";
+    if (n >= 1e6) return (n / 1e6).toFixed(2) + "M";
+    if (n >= 1e3) return (n / 1e3).toFixed(1) + "K";
+    return String(Math.round(n));
+  }
+
+  function formatETA(seconds) {
+    if (!seconds || seconds <= 0) return "-";
+    if (seconds < 60) return Math.round(seconds) + "s";
+    if (seconds < 3600) return Math.round(seconds / 60) + "m";
+    if (seconds < 86400) return (seconds / 3600).toFixed(1) + "h";
+    return (seconds / 86400).toFixed(1) + "d";
+  }
+
+  function pattern(req) {
+    return (req.prefix || "") + "…" + (req.suffix || "");
+  }
+
+  // API key for servers started with --api-keys, kept for the browser session only.
+  function token() {
+    return sessionStorage.getItem("bloco-api-key") || "";
+  }
+
+  async function request(method, path, body) {
+    const options = { method, headers: {} };
+    if (method !== "GET") {
+      options.headers["Content-Type"] = "application/json";
+      options.body = JSON.stringify(body || {});
+    }
+    if (token()) options.headers["Authorization"] = "Bearer " + token();
+    const resp = await fetch(path, options);
+    if (resp.status === 401) {
+      const key = window.prompt("API key");
+      if (key) {
+        sessionStorage.setItem("bloco-api-key", key.trim());
+        return request(method, path, body);
+      }
+    }
+    const data = await resp.json().catch(() => ({}));
+    if (!resp.ok) throw new Error(data.error || resp.statusText);
+    return data;
+  }
+
+  function stream(id) {
+    if (streams.has(id)) return;
+    const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+    let url = scheme + "//" + location.host + "/ws/jobs/" + encodeURIComponent(id);
+    if (token()) url += "?access_token=" + encodeURIComponent(token());
+    const ws = new WebSocket(url);
+    streams.set(id, ws);
+
+    ws.onmessage = (msg) => {
+      const event = JSON.parse(msg.data);
+      progress.set(id, event);
+      if (event.state === "running") {
+        const samples = history.get(id) || [];
+        samples.push(event.speed);
+        if (samples.length > HISTORY) samples.shift();
+        history.set(id, samples);
+      }
+      render();
+    };
+    ws.onclose = () => {
+      streams.delete(id);
+    };
+  }
+
+  async function refresh() {
+    try {
+      jobs = await request("GET", "/jobs");
+      const health = await fetch("/healthz").then((r) => r.json());
+      const badge = $("health");
+      badge.textContent = health.status;
+      badge.className = "badge " + (health.status === "ok" ? "ok" : "warn");
+    } catch (err) {
+      $("health").textContent = "offline";
+      $("health").className = "badge err";
+      return;
+    }
+
+    for (const job of jobs) {
+      if (!FINAL.has(job.state)) stream(job.id);
+    }
+    if (!selected && jobs.length) selected = jobs[jobs.length - 1].id;
+    render();
+  }
+
+  function actionButton(label, handler) {
+    const button = el("button", label);
+    button.addEventListener("click", async (e) => {
+      e.stopPropagation();
+      try {
+        await handler();
+      } catch (err) {
+        alert(err.message);
+      }
+      refresh();
+    });
+    return button;
+  }
+
+  function renderJobs() {
+    const body = $("jobs");
+    body.replaceChildren();
+
+    for (const job of jobs.slice().reverse()) {
+      const event = progress.get(job.id) || {};
+      const state = event.state || job.state;
+      const row = el("tr");
+      if (job.id === selected) row.className = "selected";
+      row.addEventListener("click", () => {
+        selected = job.id;
+        render();
+      });
+
+      row.append(
+        el("td", job.id.slice(0, 8)),
+        el("td", pattern(job.request)),
+        el("td", state, "state-" + state),
+        el("td", (event.completed_wallets ?? job.addresses.length) + "/" + job.request.count),
+        el("td", formatNumber(event.attempts ?? job.attempts)),
+        el("td", state === "running" ? formatNumber(event.speed || 0) + "/s" : "-"),
+        el("td", event.probability !== undefined ? event.probability.toFixed(2) + "%" : "-"),
+        el("td", state === "running" ? formatETA(event.eta_seconds) : "-")
+      );
+
+      const actions = el("td", undefined, "actions");
+      if (state === "running" || state === "queued") {
+        actions.append(actionButton("Pause", () => request("POST", "/jobs/" + job.id + "/pause")));
+      }
+      if (state === "paused") {
+        actions.append(actionButton("Resume", () => request("POST", "/jobs/" + job.id + "/resume")));
+      }
+      if (!FINAL.has(state)) {
+        actions.append(actionButton("Cancel", () => request("DELETE", "/jobs/" + job.id)));
+      }
+      row.append(actions);
+      body.append(row);
+    }
+  }
+
+  function renderChart() {
+    const canvas = $("chart");
+    const ctx = canvas.getContext("2d");
+    const samples = history.get(selected) || [];
+    const width = canvas.width;
+    const height = canvas.height;
+
+    ctx.clearRect(0, 0, width, height);
+    $("chart-job").textContent = selected ? selected.slice(0, 8) : "";
+
+    const max = Math.max(1, ...samples) * 1.1;
+    ctx.strokeStyle = "#262a36";
+    ctx.fillStyle = "#8b90a0";
+    ctx.font = "12px monospace";
+    for (let i = 0; i <= 4; i++) {
+      const y = height - (i / 4) * (height - 20) - 10;
+      ctx.beginPath();
+      ctx.moveTo(50, y);
+      ctx.lineTo(width, y);
+      ctx.stroke();
+      ctx.fillText(formatNumber((max * i) / 4), 4, y + 4);
+    }
+
+    if (samples.length < 2) return;
+    ctx.strokeStyle = "#7c5cff";
+    ctx.lineWidth = 2;
+    ctx.beginPath();
+    samples.forEach((speed, i) => {
+      const x = 50 + (i / (HISTORY - 1)) * (width - 50);
+      const y = height - (speed / max) * (height - 20) - 10;
+      if (i === 0) ctx.moveTo(x, y);
+      else ctx.lineTo(x, y);
+    });
+    ctx.stroke();
+    ctx.lineWidth = 1;
+  }
+
+  function renderWorkers() {
+    const container = $("workers");
+    container.replaceChildren();
+    const event = progress.get(selected);
+    if (!event || !event.workers) return;
+
+    for (const worker of event.workers) {
+      const text = "#" + worker.worker_id + " " + formatNumber(worker.speed) + "/s";
+      container.append(el("span", text, "worker" + (worker.is_healthy ? "" : " unhealthy")));
+    }
+  }
+
+  function renderWallets() {
+    const list = $("wallets");
+    list.replaceChildren();
+    for (const job of jobs) {
+      for (const address of job.addresses) {
+        list.append(el("li", address + "  (" + pattern(job.request) + ")"));
+      }
+    }
+  }
+
+  function render() {
+    renderJobs();
+    renderChart();
+    renderWorkers();
+    renderWallets();
+  }
+
+  $("job-form").addEventListener("submit", async (e) => {
+    e.preventDefault();
+    const form = e.target;
+    $("form-error").textContent = "";
+    try {
+      const job = await request("POST", "/jobs", {
+        prefix: form.prefix.value.trim(),
+        suffix: form.suffix.value.trim(),
+        count: parseInt(form.count.value, 10) || 1,
+        checksum: form.checksum.checked,
+        priority: form.priority.value,
+      });
+      selected = job.id;
+      form.reset();
+    } catch (err) {
+      $("form-error").textContent = err.message;
+    }
+    refresh();
+  });
+
+  refresh();
+  setInterval(refresh, 2000);
+})();
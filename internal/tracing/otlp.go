@@ -0,0 +1,316 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/errors"
+)
+
+const (
+	// tracesPath is the OTLP/HTTP path for trace export
+	tracesPath = "/v1/traces"
+	// maxBatchSpans flushes early once this many spans are waiting
+	maxBatchSpans = 512
+	// maxQueuedSpans drops new spans while the collector cannot keep up
+	maxQueuedSpans = 8192
+)
+
+// Config configures the OTLP/HTTP exporter
+type Config struct {
+	// Endpoint is the collector base URL (e.g. http://localhost:4318); /v1/traces is
+	// appended unless the URL already ends with it
+	Endpoint       string
+	ServiceName    string
+	ServiceVersion string
+	// Attributes are added to the resource, e.g. host.name to tell agents apart
+	Attributes    []Attribute
+	Headers       map[string]string
+	FlushInterval time.Duration
+	Client        *http.Client
+}
+
+// Tracer batches ended spans and posts them to an OTLP/HTTP collector as JSON
+type Tracer struct {
+	url      string
+	config   Config
+	client   *http.Client
+	resource []otlpKeyValue
+
+	mu      sync.Mutex
+	queue   []*Span
+	dropped int
+	lastErr error
+
+	flush chan struct{}
+	stop  chan struct{}
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewTracer creates a tracer exporting to cfg.Endpoint and starts its flush loop
+func NewTracer(cfg Config) (*Tracer, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, errors.NewConfigurationError("otlp_endpoint",
+			fmt.Sprintf("OTLP endpoint must be an http(s) URL, got %q", cfg.Endpoint))
+	}
+	if !strings.HasSuffix(endpoint.Path, tracesPath) {
+		endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + tracesPath
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "bloco-eth"
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = 5 * time.Second
+	}
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resource := []Attribute{String("service.name", cfg.ServiceName)}
+	if cfg.ServiceVersion != "" {
+		resource = append(resource, String("service.version", cfg.ServiceVersion))
+	}
+	resource = append(resource, cfg.Attributes...)
+
+	t := &Tracer{
+		url:      endpoint.String(),
+		config:   cfg,
+		client:   client,
+		resource: toKeyValues(resource),
+		flush:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go t.loop()
+	return t, nil
+}
+
+// Shutdown exports the remaining spans and stops the flush loop. It returns the last
+// export error, if any, so a misconfigured collector is reported once at exit.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.once.Do(func() { close(t.stop) })
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return errors.NewCancellationError("shutdown_tracing", "timed out exporting spans")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.lastErr != nil {
+		return t.lastErr
+	}
+	if t.dropped > 0 {
+		return errors.NewBlocoError(errors.ErrorTypeConfiguration, "export_spans",
+			fmt.Sprintf("dropped %d spans because the export queue was full", t.dropped))
+	}
+	return nil
+}
+
+// enqueue queues an ended span, asking for an early flush when a batch is full
+func (t *Tracer) enqueue(s *Span) {
+	t.mu.Lock()
+	if len(t.queue) >= maxQueuedSpans {
+		t.dropped++
+		t.mu.Unlock()
+		return
+	}
+	t.queue = append(t.queue, s)
+	full := len(t.queue) >= maxBatchSpans
+	t.mu.Unlock()
+
+	if full {
+		select {
+		case t.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// loop exports queued spans every flush interval, when a batch fills and at shutdown
+func (t *Tracer) loop() {
+	defer close(t.done)
+	ticker := time.NewTicker(t.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-t.flush:
+		case <-t.stop:
+			t.exportQueued()
+			return
+		}
+		t.exportQueued()
+	}
+}
+
+// exportQueued sends every queued span in batches of maxBatchSpans
+func (t *Tracer) exportQueued() {
+	for {
+		t.mu.Lock()
+		n := min(len(t.queue), maxBatchSpans)
+		batch := t.queue[:n:n]
+		t.queue = t.queue[n:]
+		t.mu.Unlock()
+		if n == 0 {
+			return
+		}
+
+		if err := t.export(batch); err != nil {
+			t.mu.Lock()
+			t.lastErr = err
+			t.mu.Unlock()
+		}
+	}
+}
+
+// export posts one batch of spans
+func (t *Tracer) export(spans []*Span) error {
+	body, err := json.Marshal(t.request(spans))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "export_spans", "failed to encode spans")
+	}
+	req, err := http.NewRequest(http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "export_spans", "failed to build export request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return errors.WrapError(err, errors.ErrorTypeConfiguration, "export_spans",
+			fmt.Sprintf("failed to export spans to %s", t.url))
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.NewBlocoError(errors.ErrorTypeConfiguration, "export_spans",
+			fmt.Sprintf("collector %s rejected spans: %s", t.url, resp.Status))
+	}
+	return nil
+}
+
+// OTLP JSON encoding of ExportTraceServiceRequest. IDs are hex and 64-bit integers are
+// strings, as the OTLP/HTTP JSON mapping requires.
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+// OTLP span kind and status codes
+const (
+	spanKindInternal = 1
+	statusUnset      = 0
+	statusError      = 2
+)
+
+// request converts spans to an export request
+func (t *Tracer) request(spans []*Span) otlpRequest {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           s.sc.TraceID.String(),
+			SpanID:            s.sc.SpanID.String(),
+			Name:              s.name,
+			Kind:              spanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        toKeyValues(s.attrs),
+			Status:            otlpStatus{Code: statusUnset},
+		}
+		if s.parent != (SpanID{}) {
+			span.ParentSpanID = s.parent.String()
+		}
+		if s.errMsg != "" {
+			span.Status = otlpStatus{Code: statusError, Message: s.errMsg}
+		}
+		s.mu.Unlock()
+		encoded = append(encoded, span)
+	}
+
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: t.resource},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "bloco-eth", Version: t.config.ServiceVersion},
+			Spans: encoded,
+		}},
+	}}}
+}
+
+// toKeyValues encodes attributes as OTLP AnyValue key/value pairs
+func toKeyValues(attrs []Attribute) []otlpKeyValue {
+	values := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		var value map[string]any
+		switch v := attr.Value.(type) {
+		case string:
+			value = map[string]any{"stringValue": v}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]any{"doubleValue": v}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		values = append(values, otlpKeyValue{Key: attr.Key, Value: value})
+	}
+	return values
+}
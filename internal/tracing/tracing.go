@@ -0,0 +1,261 @@
+// Package tracing records spans compatible with OpenTelemetry and exports them over OTLP/HTTP.
+// Tracing is off until SetTracer installs a tracer; Start then returns a nil span and
+// every Span method is a no-op, so instrumented code costs almost nothing by default.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bloco-eth/pkg/errors"
+)
+
+// TraceparentHeader is the W3C Trace Context header carrying the parent span
+const TraceparentHeader = "traceparent"
+
+// TraceID identifies a trace across processes
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+// String returns the lowercase hex form used by OTLP and traceparent
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// String returns the lowercase hex form used by OTLP and traceparent
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// SpanContext is the part of a span that propagates to children and other nodes
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid reports whether both IDs are set
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Traceparent formats the span context as a W3C traceparent value, or "" if invalid
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%s-%s-%s", sc.TraceID, sc.SpanID, flags)
+}
+
+// ParseTraceparent parses a W3C traceparent value such as
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func ParseTraceparent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" ||
+		len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, errors.NewValidationError("parse_traceparent",
+			fmt.Sprintf("invalid traceparent %q", value))
+	}
+	// Version 00 has exactly four fields; later versions may append more
+	if parts[0] == "00" && len(parts) != 4 {
+		return SpanContext{}, errors.NewValidationError("parse_traceparent",
+			fmt.Sprintf("invalid traceparent %q", value))
+	}
+
+	var sc SpanContext
+	var flags [1]byte
+	if _, err := hex.Decode(sc.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, errors.WrapError(err, errors.ErrorTypeValidation, "parse_traceparent", "invalid trace ID")
+	}
+	if _, err := hex.Decode(sc.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, errors.WrapError(err, errors.ErrorTypeValidation, "parse_traceparent", "invalid span ID")
+	}
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return SpanContext{}, errors.WrapError(err, errors.ErrorTypeValidation, "parse_traceparent", "invalid trace flags")
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, errors.NewValidationError("parse_traceparent", "trace and span IDs must not be zero")
+	}
+	sc.Sampled = flags[0]&1 == 1
+	return sc, nil
+}
+
+// Attribute is a key/value pair recorded on a span
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String creates a string attribute
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int creates an integer attribute
+func Int(key string, value int) Attribute { return Attribute{Key: key, Value: int64(value)} }
+
+// Int64 creates an integer attribute
+func Int64(key string, value int64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Float64 creates a floating point attribute
+func Float64(key string, value float64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Bool creates a boolean attribute
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// Span times one operation. A nil *Span is valid and records nothing.
+type Span struct {
+	tracer *Tracer
+	name   string
+	sc     SpanContext
+	parent SpanID
+	start  time.Time
+
+	mu     sync.Mutex
+	end    time.Time
+	attrs  []Attribute
+	errMsg string
+	ended  bool
+}
+
+// SpanContext returns the span's identifiers, or an invalid context for a nil span
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.attrs = append(s.attrs, attrs...)
+	s.mu.Unlock()
+}
+
+// RecordError marks the span as failed with err; nil errors are ignored
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.errMsg = err.Error()
+	s.mu.Unlock()
+}
+
+// End finishes the span and queues it for export. Only the first call has an effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+	s.tracer.enqueue(s)
+}
+
+type spanContextKey struct{}
+
+// global is the tracer used by Start, nil while tracing is off
+var global atomic.Pointer[Tracer]
+
+// SetTracer installs the tracer used by Start; nil turns tracing off
+func SetTracer(t *Tracer) {
+	global.Store(t)
+}
+
+// Enabled reports whether a tracer is installed
+func Enabled() bool {
+	return global.Load() != nil
+}
+
+// ContextWithSpanContext returns a context whose next span is a child of sc, typically
+// a parent received from another node
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// SpanContextFromContext returns the current span context, invalid if there is none
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	sc, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc
+}
+
+// ContextWithTraceparent parents the next span on a W3C traceparent value; empty or
+// malformed values leave ctx unchanged
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	sc, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// Start begins a span that is a child of the span in ctx, or the root of a new trace.
+// It returns ctx unchanged and a nil span when tracing is off or the parent is not sampled.
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	tracer := global.Load()
+	if tracer == nil {
+		return ctx, nil
+	}
+	parent := SpanContextFromContext(ctx)
+	if parent.IsValid() && !parent.Sampled {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer: tracer,
+		name:   name,
+		start:  time.Now(),
+		attrs:  attrs,
+	}
+	span.sc = SpanContext{TraceID: parent.TraceID, SpanID: newSpanID(), Sampled: true}
+	if parent.IsValid() {
+		span.parent = parent.SpanID
+	} else {
+		_, _ = rand.Read(span.sc.TraceID[:])
+	}
+	return context.WithValue(ctx, spanContextKey{}, span.sc), span
+}
+
+// Inject writes the current span context to an outgoing request's headers
+func Inject(ctx context.Context, header http.Header) {
+	if traceparent := SpanContextFromContext(ctx).Traceparent(); traceparent != "" {
+		header.Set(TraceparentHeader, traceparent)
+	}
+}
+
+// Extract returns ctx parented on the traceparent header of an incoming request
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return ContextWithTraceparent(ctx, header.Get(TraceparentHeader))
+}
+
+// newSpanID returns a random non-zero span ID
+func newSpanID() SpanID {
+	var id SpanID
+	for id == (SpanID{}) {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
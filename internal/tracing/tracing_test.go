@@ -0,0 +1,155 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	const value = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(value)
+	if err != nil {
+		t.Fatalf("ParseTraceparent: %v", err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" || !sc.Sampled {
+		t.Errorf("parsed %+v", sc)
+	}
+	if got := sc.Traceparent(); got != value {
+		t.Errorf("Traceparent() = %q, want %q", got, value)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceparent(invalid); err == nil {
+			t.Errorf("ParseTraceparent(%q) should fail", invalid)
+		}
+	}
+}
+
+func TestStartWithoutTracer(t *testing.T) {
+	SetTracer(nil)
+	ctx, span := Start(context.Background(), "noop")
+	if span != nil || SpanContextFromContext(ctx).IsValid() {
+		t.Fatal("Start should not record spans while tracing is off")
+	}
+	// Methods on the nil span are no-ops
+	span.SetAttributes(String("k", "v"))
+	span.RecordError(errors.New("ignored"))
+	span.End()
+}
+
+func TestTracerExport(t *testing.T) {
+	var mu sync.Mutex
+	var received []otlpRequest
+	var contentType string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			t.Errorf("export path = %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req otlpRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("invalid export body: %v", err)
+		}
+		mu.Lock()
+		received = append(received, req)
+		contentType = r.Header.Get("Content-Type")
+		mu.Unlock()
+	}))
+	defer collector.Close()
+
+	tracer, err := NewTracer(Config{Endpoint: collector.URL, ServiceVersion: "test", FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewTracer: %v", err)
+	}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	remote, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx, parent := Start(ContextWithSpanContext(context.Background(), remote), "job.run", String("job.id", "abc"))
+	_, child := Start(ctx, "kdf.derive", Int("n", 3))
+	child.RecordError(errors.New("derive failed"))
+	child.End()
+	parent.End()
+	parent.End() // a second End must not export the span twice
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if contentType != "application/json" || len(received) != 1 {
+		t.Fatalf("expected one JSON export, got %d (%s)", len(received), contentType)
+	}
+	resource := received[0].ResourceSpans[0]
+	if resource.Resource.Attributes[0].Value["stringValue"] != "bloco-eth" {
+		t.Errorf("resource attributes = %+v", resource.Resource.Attributes)
+	}
+	spans := resource.ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(spans))
+	}
+	kdf, run := spans[0], spans[1]
+	if run.TraceID != remote.TraceID.String() || run.ParentSpanID != remote.SpanID.String() {
+		t.Errorf("job.run should continue the remote trace: %+v", run)
+	}
+	if kdf.TraceID != run.TraceID || kdf.ParentSpanID != run.SpanID {
+		t.Errorf("kdf.derive should be a child of job.run: %+v", kdf)
+	}
+	if kdf.Status.Code != statusError || kdf.Status.Message != "derive failed" {
+		t.Errorf("kdf.derive status = %+v", kdf.Status)
+	}
+	if kdf.Attributes[0].Value["intValue"] != "3" || run.Attributes[0].Value["stringValue"] != "abc" {
+		t.Errorf("attributes = %+v / %+v", kdf.Attributes, run.Attributes)
+	}
+}
+
+func TestTracerReportsExportFailure(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer collector.Close()
+
+	tracer, err := NewTracer(Config{Endpoint: collector.URL + "/v1/traces", FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("NewTracer: %v", err)
+	}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	_, span := Start(context.Background(), "generate")
+	span.End()
+	if err := tracer.Shutdown(context.Background()); err == nil {
+		t.Error("Shutdown should report the rejected export")
+	}
+
+	if _, err := NewTracer(Config{Endpoint: "localhost:4318"}); err == nil {
+		t.Error("an endpoint without an http(s) scheme should be rejected")
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	remote, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	header := http.Header{}
+	Inject(ContextWithSpanContext(context.Background(), remote), header)
+	if got := SpanContextFromContext(Extract(context.Background(), header)); got != remote {
+		t.Errorf("Extract = %+v, want %+v", got, remote)
+	}
+	if Extract(context.Background(), http.Header{TraceparentHeader: {"garbage"}}) != context.Background() {
+		t.Error("a malformed traceparent should be ignored")
+	}
+}
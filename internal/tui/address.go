@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"bloco-eth/internal/i18n"
+)
+
+// Markers of the character line printed under an address when colors are off
+const (
+	patternMarker  = '^'
+	checksumMarker = '*'
+	bothMarker     = '#'
+)
+
+// AddressMark says why one character of a found address stands out
+type AddressMark struct {
+	Char rune
+	// Pattern is set for the characters that matched the prefix or suffix
+	Pattern bool
+	// Checksum is set for the letters the EIP-55 checksum made uppercase
+	Checksum bool
+}
+
+// MarkAddress marks the characters of address that matched prefix and suffix and,
+// when checksum is set, the letters its EIP-55 checksum made uppercase. A leading
+// 0x is never marked, and pattern characters the address does not match are left
+// unmarked.
+func MarkAddress(address, prefix, suffix string, checksum bool) []AddressMark {
+	marks := make([]AddressMark, 0, len(address))
+	for _, r := range address {
+		marks = append(marks, AddressMark{Char: r})
+	}
+	body := marks
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		body = marks[2:]
+	}
+
+	markRun := func(run []AddressMark, pattern []rune) {
+		for i, r := range pattern {
+			if unicode.ToLower(run[i].Char) != unicode.ToLower(r) {
+				return
+			}
+		}
+		for i := range pattern {
+			run[i].Pattern = true
+		}
+	}
+	if p := []rune(prefix); len(p) <= len(body) {
+		markRun(body[:len(p)], p)
+	}
+	if s := []rune(suffix); len(s) <= len(body) {
+		markRun(body[len(body)-len(s):], s)
+	}
+	if checksum {
+		for i := range body {
+			body[i].Checksum = unicode.IsUpper(body[i].Char)
+		}
+	}
+	return marks
+}
+
+// HasMarks reports whether any character of marks stands out
+func HasMarks(marks []AddressMark) bool {
+	for _, mark := range marks {
+		if mark.Pattern || mark.Checksum {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatAddress renders an address marked by MarkAddress: the pattern characters in
+// bold accent, the checksum uppercase letters underlined. Without color support the
+// address is plain and markers is a line to print under it, with a marker under each
+// of those characters, padded to the display width of every character above it.
+func (sm *StyleManager) FormatAddress(marks []AddressMark) (address, markers string) {
+	var text strings.Builder
+	if !sm.monochrome() {
+		pattern := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(AccentColor))
+		upper := lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color(WarningColor))
+		both := pattern.Underline(true)
+		for _, mark := range marks {
+			char := string(mark.Char)
+			switch {
+			case mark.Pattern && mark.Checksum:
+				char = both.Render(char)
+			case mark.Pattern:
+				char = pattern.Render(char)
+			case mark.Checksum:
+				char = upper.Render(char)
+			}
+			text.WriteString(char)
+		}
+		return text.String(), ""
+	}
+
+	var line strings.Builder
+	for _, mark := range marks {
+		char := string(mark.Char)
+		text.WriteString(char)
+		marker := ' '
+		switch {
+		case mark.Pattern && mark.Checksum:
+			marker = bothMarker
+		case mark.Pattern:
+			marker = patternMarker
+		case mark.Checksum:
+			marker = checksumMarker
+		}
+		line.WriteRune(marker)
+		if width := lipgloss.Width(char); width > 1 {
+			line.WriteString(strings.Repeat(" ", width-1))
+		}
+	}
+	return text.String(), strings.TrimRight(line.String(), " ")
+}
+
+// AddressLegend explains the marks of FormatAddress
+func (sm *StyleManager) AddressLegend() string {
+	if !sm.monochrome() {
+		return sm.helpStyle.Render(i18n.T("tui.legend_color"))
+	}
+	return i18n.T("tui.legend_markers", string(patternMarker), string(checksumMarker), string(bothMarker))
+}
+
+// monochrome reports whether the terminal was detected without color support; a
+// style manager without detected capabilities uses colors
+func (sm *StyleManager) monochrome() bool {
+	return sm.capabilities != (TUICapabilities{}) && !sm.capabilities.SupportsColor
+}
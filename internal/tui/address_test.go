@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+// markString writes p for pattern, c for checksum, b for both and . otherwise
+func markString(marks []AddressMark) string {
+	var s strings.Builder
+	for _, mark := range marks {
+		switch {
+		case mark.Pattern && mark.Checksum:
+			s.WriteByte('b')
+		case mark.Pattern:
+			s.WriteByte('p')
+		case mark.Checksum:
+			s.WriteByte('c')
+		default:
+			s.WriteByte('.')
+		}
+	}
+	return s.String()
+}
+
+func TestMarkAddress(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		prefix   string
+		suffix   string
+		checksum bool
+		want     string
+	}{
+		{"prefix and suffix", "0xabc123def", "abc", "ef", false, "..ppp....pp"},
+		{"checksum case", "0xAbC123dEf", "abc", "", true, "..bpb....c."},
+		{"checksum off", "0xAbC123dEf", "", "", false, "..........."},
+		{"pattern not matched", "0xabc123def", "abd", "", false, "..........."},
+		{"no 0x", "Sol1Abc", "sol", "", false, "ppp...."},
+		{"pattern longer than address", "0xab", "abcdef", "", false, "...."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markString(MarkAddress(tt.address, tt.prefix, tt.suffix, tt.checksum)); got != tt.want {
+				t.Errorf("MarkAddress() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatAddress_Monochrome(t *testing.T) {
+	sm := NewStyleManagerWithCapabilities(TUICapabilities{SupportsColor: false, TerminalWidth: 80})
+	address, markers := sm.FormatAddress(MarkAddress("0xAbC123dEf", "abc", "", true))
+	if address != "0xAbC123dEf" {
+		t.Errorf("address = %q, want it unstyled", address)
+	}
+	if markers != "  #^#    *" {
+		t.Errorf("markers = %q, want %q", markers, "  #^#    *")
+	}
+
+	// A wide character keeps the markers after it aligned
+	_, markers = sm.FormatAddress([]AddressMark{{Char: '界'}, {Char: 'a', Pattern: true}})
+	if markers != "  ^" {
+		t.Errorf("markers after a wide character = %q, want %q", markers, "  ^")
+	}
+}
+
+func TestFormatAddress_Color(t *testing.T) {
+	sm := NewStyleManager()
+	address, markers := sm.FormatAddress(MarkAddress("0xAbC123dEf", "abc", "", true))
+	if markers != "" {
+		t.Errorf("markers = %q, want none with colors", markers)
+	}
+	if !strings.Contains(address, "123") || !strings.HasPrefix(address, "0x") {
+		t.Errorf("address = %q lost its plain characters", address)
+	}
+	if HasMarks(MarkAddress("0xabc", "", "", true)) {
+		t.Error("an all-lowercase address without a pattern has no marks")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"bloco-eth/internal/i18n"
 	"bloco-eth/pkg/wallet"
 )
 
@@ -58,9 +59,9 @@ func NewBenchmarkModel() BenchmarkModel {
 
 	// Create table for results display
 	columns := []table.Column{
-		{Title: "Metric", Width: 25},
-		{Title: "Value", Width: 20},
-		{Title: "Details", Width: 30},
+		{Title: i18n.T("tui.bench_metric"), Width: 25},
+		{Title: i18n.T("tui.bench_value"), Width: 20},
+		{Title: i18n.T("tui.bench_details"), Width: 30},
 	}
 
 	t := table.New(
@@ -128,7 +129,9 @@ func (m BenchmarkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case BenchmarkUpdateMsg:
-		fmt.Fprintf(os.Stderr, "DEBUG: TUI received update - attempts: %d, speed: %.2f\n", msg.Progress.Attempts, msg.Progress.Speed)
+		if os.Getenv("BLOCO_DEBUG") != "" {
+			fmt.Fprintln(os.Stderr, i18n.T("debug.tui_benchmark_update", msg.Progress.Attempts, msg.Progress.Speed))
+		}
 		m.progressMsg = msg.Progress
 		m.running = msg.Running
 		m.lastUpdate = time.Now()
@@ -201,7 +204,7 @@ func (m BenchmarkModel) renderProgressView() string {
 	b.WriteString("\n")
 
 	// Header
-	header := m.styleManager.FormatHeader("Benchmark Running")
+	header := m.styleManager.FormatHeader(i18n.T("tui.bench_running"))
 	b.WriteString(header + "\n\n")
 
 	// Progress bar
@@ -239,46 +242,32 @@ func (m BenchmarkModel) renderProgressView() string {
 
 	var metrics string
 	if m.results != nil {
-		metrics = fmt.Sprintf(
-			"Current Performance:\n"+
-				"   Attempts: %s\n"+
-				"   Current Speed: %s addr/s\n"+
-				"   Average Speed: %s addr/s\n"+
-				"   Min/Max Speed: %s/%s addr/s\n"+
-				"   Pattern: %s\n"+
-				"   Difficulty: %.2f\n"+
-				"   Estimated Time: %s\n"+
-				"   Efficiency: %.1f%%",
-			formatLargeNumber(m.progressMsg.Attempts),
-			formatSpeed(m.progressMsg.Speed),
-			formatSpeed(m.results.AverageSpeed),
-			formatSpeed(m.results.MinSpeed),
-			formatSpeed(m.results.MaxSpeed),
-			m.progressMsg.Pattern,
-			m.progressMsg.Difficulty,
-			formatDuration(m.progressMsg.EstimatedTime),
-			m.results.ScalabilityEfficiency*100,
-		)
+		metrics = strings.Join([]string{
+			i18n.T("tui.bench_current"),
+			"   " + i18n.T("result.attempts", formatLargeNumber(m.progressMsg.Attempts)),
+			"   " + i18n.T("tui.bench_current_speed", formatSpeed(m.progressMsg.Speed)),
+			"   " + i18n.T("tui.bench_average_speed", formatSpeed(m.results.AverageSpeed)),
+			"   " + i18n.T("tui.bench_min_max", formatSpeed(m.results.MinSpeed), formatSpeed(m.results.MaxSpeed)),
+			"   " + i18n.T("tui.bench_pattern", m.progressMsg.Pattern),
+			"   " + i18n.T("tui.bench_difficulty", m.progressMsg.Difficulty),
+			"   " + i18n.T("tui.bench_eta", formatDuration(m.progressMsg.EstimatedTime)),
+			"   " + i18n.T("tui.bench_efficiency", m.results.ScalabilityEfficiency*100),
+		}, "\n")
 	} else {
-		metrics = fmt.Sprintf(
-			"Current Performance:\n"+
-				"   Attempts: %s\n"+
-				"   Speed: %s addr/s\n"+
-				"   Pattern: %s\n"+
-				"   Difficulty: %.2f\n"+
-				"   Estimated Time: %s",
-			formatLargeNumber(m.progressMsg.Attempts),
-			formatSpeed(m.progressMsg.Speed),
-			m.progressMsg.Pattern,
-			m.progressMsg.Difficulty,
-			formatDuration(m.progressMsg.EstimatedTime),
-		)
+		metrics = strings.Join([]string{
+			i18n.T("tui.bench_current"),
+			"   " + i18n.T("result.attempts", formatLargeNumber(m.progressMsg.Attempts)),
+			"   " + i18n.T("tui.bench_speed", formatSpeed(m.progressMsg.Speed)),
+			"   " + i18n.T("tui.bench_pattern", m.progressMsg.Pattern),
+			"   " + i18n.T("tui.bench_difficulty", m.progressMsg.Difficulty),
+			"   " + i18n.T("tui.bench_eta", formatDuration(m.progressMsg.EstimatedTime)),
+		}, "\n")
 	}
 
 	b.WriteString(metricsStyle.Render(metrics) + "\n\n")
 
 	// Help text
-	helpText := helpStyle("Press q to quit • Ctrl+C to exit")
+	helpText := helpStyle(i18n.T("tui.help_quit"))
 	b.WriteString(helpText)
 
 	return b.String()
@@ -288,12 +277,12 @@ func (m BenchmarkModel) renderProgressView() string {
 func (m BenchmarkModel) renderTransitionView() string {
 	var b strings.Builder
 
-	header := m.styleManager.FormatHeader("Benchmark Complete - Loading Results...")
+	header := m.styleManager.FormatHeader(i18n.T("tui.bench_loading"))
 	b.WriteString(header + "\n\n")
 
 	// Simple loading animation
 	dots := strings.Repeat(".", int(time.Since(m.transitionTime)/100*time.Millisecond)%4)
-	loading := fmt.Sprintf("Preparing results%s", dots)
+	loading := i18n.T("tui.bench_preparing") + dots
 	b.WriteString(loading + "\n\n")
 
 	return b.String()
@@ -308,7 +297,7 @@ func (m BenchmarkModel) renderResultsView() string {
 	b.WriteString("\n")
 
 	// Header
-	header := m.styleManager.FormatHeader("Benchmark Results")
+	header := m.styleManager.FormatHeader(i18n.T("tui.bench_results"))
 	b.WriteString(header + "\n\n")
 
 	// Results summary
@@ -318,17 +307,13 @@ func (m BenchmarkModel) renderResultsView() string {
 			BorderForeground(lipgloss.Color(SuccessColor)).
 			Padding(1, 2)
 
-		summary := fmt.Sprintf(
-			"Summary:\n"+
-				"   Total Duration: %s\n"+
-				"   Average Speed: %.0f addr/s\n"+
-				"   Thread Efficiency: %.1f%%\n"+
-				"   Scalability Factor: %.2fx",
-			formatDuration(m.results.TotalDuration),
-			m.results.AverageSpeed,
-			m.results.ScalabilityEfficiency*100,
-			float64(m.results.ThreadCount)*m.results.ScalabilityEfficiency,
-		)
+		summary := strings.Join([]string{
+			i18n.T("tui.bench_summary"),
+			"   " + i18n.T("tui.bench_total_duration", formatDuration(m.results.TotalDuration)),
+			"   " + i18n.T("bench.average_speed", m.results.AverageSpeed),
+			"   " + i18n.T("bench.thread_efficiency", m.results.ScalabilityEfficiency*100),
+			"   " + i18n.T("tui.bench_scalability", float64(m.results.ThreadCount)*m.results.ScalabilityEfficiency),
+		}, "\n")
 
 		b.WriteString(summaryStyle.Render(summary) + "\n\n")
 	}
@@ -341,7 +326,7 @@ func (m BenchmarkModel) renderResultsView() string {
 	b.WriteString(tableStyle.Render(m.table.View()) + "\n\n")
 
 	// Help text
-	helpText := helpStyle("↑/↓: Navigate • q: Quit • Ctrl+C: Exit")
+	helpText := helpStyle(i18n.T("tui.bench_help"))
 	b.WriteString(helpText)
 
 	return b.String()
@@ -354,20 +339,25 @@ func (m BenchmarkModel) generateResultsRows() []table.Row {
 	}
 
 	rows := []table.Row{
-		{"Total Attempts", formatLargeNumber(m.results.TotalAttempts), "Total addresses generated"},
-		{"Duration", formatDuration(m.results.TotalDuration), "Total benchmark time"},
-		{"Average Speed", fmt.Sprintf("%.0f addr/s", m.results.AverageSpeed), "Mean generation rate"},
-		{"Min Speed", fmt.Sprintf("%.0f addr/s", m.results.MinSpeed), "Lowest recorded speed"},
-		{"Max Speed", fmt.Sprintf("%.0f addr/s", m.results.MaxSpeed), "Highest recorded speed"},
-		{"Thread Count", fmt.Sprintf("%d", m.results.ThreadCount), "Parallel workers used"},
-		{"Single Thread Est.", fmt.Sprintf("%.0f addr/s", m.results.SingleThreadSpeed), "Estimated single-thread speed"},
-		{"Scalability", fmt.Sprintf("%.1f%%", m.results.ScalabilityEfficiency*100), "Multi-threading efficiency"},
-		{"Speedup Factor", fmt.Sprintf("%.2fx", float64(m.results.ThreadCount)*m.results.ScalabilityEfficiency), "Performance improvement"},
+		{i18n.T("tui.bench_row_attempts"), formatLargeNumber(m.results.TotalAttempts), i18n.T("tui.bench_row_attempts_info")},
+		{i18n.T("tui.bench_row_duration"), formatDuration(m.results.TotalDuration), i18n.T("tui.bench_row_duration_info")},
+		{i18n.T("tui.bench_row_average"), speedValue(m.results.AverageSpeed), i18n.T("tui.bench_row_average_info")},
+		{i18n.T("tui.bench_row_min"), speedValue(m.results.MinSpeed), i18n.T("tui.bench_row_min_info")},
+		{i18n.T("tui.bench_row_max"), speedValue(m.results.MaxSpeed), i18n.T("tui.bench_row_max_info")},
+		{i18n.T("tui.bench_row_threads"), fmt.Sprintf("%d", m.results.ThreadCount), i18n.T("tui.bench_row_threads_info")},
+		{i18n.T("tui.bench_row_single"), speedValue(m.results.SingleThreadSpeed), i18n.T("tui.bench_row_single_info")},
+		{i18n.T("tui.bench_row_scalability"), fmt.Sprintf("%.1f%%", m.results.ScalabilityEfficiency*100), i18n.T("tui.bench_row_scalability_info")},
+		{i18n.T("tui.bench_row_speedup"), fmt.Sprintf("%.2fx", float64(m.results.ThreadCount)*m.results.ScalabilityEfficiency), i18n.T("tui.bench_row_speedup_info")},
 	}
 
 	return rows
 }
 
+// speedValue formats a speed in whole addresses per second with its unit
+func speedValue(speed float64) string {
+	return i18n.T("tui.speed_value", fmt.Sprintf("%.0f", speed))
+}
+
 // tickCmd returns a command that ticks every 100ms for smooth animations
 func tickCmd() tea.Cmd {
 	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
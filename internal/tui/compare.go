@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/utils"
+)
+
+// compareBarWidth is the width of the longest speed bar
+const compareBarWidth = 30
+
+// ThreadRunMsg delivers the speed measured for one thread count
+type ThreadRunMsg struct {
+	Threads int
+	Speed   float64
+}
+
+// ThreadComparisonDoneMsg ends the comparison, with Err set if a run failed
+type ThreadComparisonDoneMsg struct {
+	Err error
+}
+
+// ThreadComparisonModel charts addr/s per thread count as each benchmark run completes
+type ThreadComparisonModel struct {
+	planned      []int
+	speeds       map[int]float64
+	done         bool
+	err          error
+	styleManager *StyleManager
+}
+
+// NewThreadComparisonModel creates the comparison view for the planned thread counts
+func NewThreadComparisonModel(planned []int) ThreadComparisonModel {
+	capabilities := NewTUIManager().DetectCapabilities()
+	return ThreadComparisonModel{
+		planned:      planned,
+		speeds:       make(map[int]float64),
+		styleManager: NewStyleManagerWithCapabilities(capabilities),
+	}
+}
+
+// Init implements tea.Model
+func (m ThreadComparisonModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update records runs as they complete
+func (m ThreadComparisonModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ThreadRunMsg:
+		speeds := make(map[int]float64, len(m.speeds)+1)
+		for threads, speed := range m.speeds {
+			speeds[threads] = speed
+		}
+		speeds[msg.Threads] = msg.Speed
+		m.speeds = speeds
+	case ThreadComparisonDoneMsg:
+		m.done = true
+		m.err = msg.Err
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// Scaling returns the scaling of the runs completed so far, in planned order
+func (m ThreadComparisonModel) Scaling() []utils.ThreadScaling {
+	var threads []int
+	var speeds []float64
+	for _, n := range m.planned {
+		if speed, ok := m.speeds[n]; ok {
+			threads = append(threads, n)
+			speeds = append(speeds, speed)
+		}
+	}
+	return utils.CalculateThreadScaling(threads, speeds)
+}
+
+// View renders the bar chart, efficiency curve and Amdahl projection
+func (m ThreadComparisonModel) View() string {
+	pad := strings.Repeat(" ", 2)
+	var content strings.Builder
+
+	content.WriteString("\n")
+	content.WriteString(pad)
+	content.WriteString(m.styleManager.FormatTitle(i18n.T("tui.compare_title")))
+	content.WriteString("\n\n")
+
+	scaling := m.Scaling()
+	peak := 0.0
+	for _, point := range scaling {
+		peak = math.Max(peak, point.Speed)
+	}
+
+	byThreads := make(map[int]utils.ThreadScaling, len(scaling))
+	for _, point := range scaling {
+		byThreads[point.Threads] = point
+	}
+	measuring := true
+	for _, n := range m.planned {
+		point, ok := byThreads[n]
+		content.WriteString(pad + i18n.T("tui.compare_threads", n) + " ")
+		switch {
+		case ok:
+			content.WriteString(m.styleManager.FormatInfo(m.bar(point.Speed, peak)))
+			content.WriteString(" " + i18n.T("tui.compare_speed",
+				formatLargeNumber(int64(point.Speed)), point.Speedup, point.Efficiency*100))
+		case measuring && !m.done:
+			content.WriteString(m.styleManager.FormatWarning(i18n.T("tui.measuring")))
+			measuring = false
+		default:
+			content.WriteString(helpStyle(i18n.T("tui.pending")))
+		}
+		content.WriteString("\n")
+	}
+
+	if len(scaling) > 1 {
+		content.WriteString("\n")
+		content.WriteString(pad)
+		content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.efficiency"), m.efficiencyCurve(scaling)))
+		content.WriteString("\n")
+	}
+
+	if serial := utils.FitAmdahlSerialFraction(scaling); serial >= 0 {
+		maxThreads := m.planned[len(m.planned)-1]
+		limit := i18n.T("tui.unbounded")
+		if l := utils.AmdahlLimit(serial); !math.IsInf(l, 1) {
+			limit = fmt.Sprintf("%.1fx", l)
+		}
+		content.WriteString(pad)
+		content.WriteString(m.styleManager.FormatKeyValue("Amdahl",
+			i18n.T("tui.amdahl", serial*100,
+				2*maxThreads, utils.AmdahlSpeedup(serial, 2*maxThreads),
+				4*maxThreads, utils.AmdahlSpeedup(serial, 4*maxThreads), limit)))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(pad)
+	switch {
+	case m.err != nil:
+		content.WriteString(m.styleManager.FormatError(m.err.Error()))
+		content.WriteString("\n\n")
+		content.WriteString(pad)
+	case m.done:
+		content.WriteString(m.styleManager.FormatSuccess(i18n.T("tui.compare_done")))
+		content.WriteString("\n\n")
+		content.WriteString(pad)
+	}
+	content.WriteString(helpStyle(i18n.T("tui.help_quit")))
+	content.WriteString("\n")
+	return content.String()
+}
+
+// bar draws speed as a bar scaled to peak
+func (m ThreadComparisonModel) bar(speed, peak float64) string {
+	full, empty := "█", "░"
+	if !m.styleManager.IsUnicodeSupported() {
+		full, empty = "#", "."
+	}
+	filled := 0
+	if peak > 0 {
+		filled = int(math.Round(speed / peak * compareBarWidth))
+	}
+	return strings.Repeat(full, filled) + strings.Repeat(empty, compareBarWidth-filled)
+}
+
+// efficiencyCurve renders efficiency per thread count as a sparkline followed by the values
+func (m ThreadComparisonModel) efficiencyCurve(scaling []utils.ThreadScaling) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	var curve strings.Builder
+	values := make([]string, len(scaling))
+	for i, point := range scaling {
+		if m.styleManager.IsUnicodeSupported() {
+			level := int(math.Round(math.Max(0, math.Min(1, point.Efficiency)) * float64(len(blocks)-1)))
+			curve.WriteRune(blocks[level])
+		}
+		values[i] = fmt.Sprintf("%.0f%%", point.Efficiency*100)
+	}
+	if curve.Len() > 0 {
+		curve.WriteString("  ")
+	}
+	curve.WriteString(strings.Join(values, " → "))
+	return curve.String()
+}
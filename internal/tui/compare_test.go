@@ -0,0 +1,26 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestThreadComparisonModel(t *testing.T) {
+	var model any = NewThreadComparisonModel([]int{1, 2, 4, 8})
+	for _, msg := range []any{ThreadRunMsg{Threads: 1, Speed: 1000}, ThreadRunMsg{Threads: 2, Speed: 1800}} {
+		model, _ = model.(ThreadComparisonModel).Update(msg)
+	}
+	m := model.(ThreadComparisonModel)
+
+	view := m.View()
+	for _, want := range []string{"1.80x", "90%", "measuring...", "pending", "Amdahl"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view is missing %q:\n%s", want, view)
+		}
+	}
+
+	next, _ := m.Update(ThreadComparisonDoneMsg{})
+	if view := next.(ThreadComparisonModel).View(); !strings.Contains(view, "Comparison complete") || strings.Contains(view, "measuring") {
+		t.Errorf("finished view should drop the pending run:\n%s", view)
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Hyperlink returns text linked to url with an OSC 8 escape sequence; terminals
+// without OSC 8 support show text alone
+func Hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// SupportsHyperlinks reports whether stdout is a terminal known to render OSC 8
+// hyperlinks. FORCE_HYPERLINK=1 or 0 overrides the detection.
+func SupportsHyperlinks() bool {
+	if force, ok := os.LookupEnv("FORCE_HYPERLINK"); ok {
+		enabled, err := strconv.ParseBool(force)
+		return err == nil && enabled
+	}
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return false
+	}
+	return hyperlinkTerminal(os.Getenv)
+}
+
+// hyperlinkTerminal recognises the terminals that render OSC 8 from their
+// environment variables
+func hyperlinkTerminal(getenv func(string) string) bool {
+	if getenv("TERM") == "dumb" {
+		return false
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	}
+	// VTE terminals (GNOME Terminal, Tilix, ...) render OSC 8 since 0.50
+	if vte, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && vte >= 5000 {
+		return true
+	}
+	for _, name := range []string{"WT_SESSION", "KONSOLE_VERSION", "KITTY_WINDOW_ID"} {
+		if getenv(name) != "" {
+			return true
+		}
+	}
+	return strings.HasPrefix(getenv("TERM"), "xterm-kitty")
+}
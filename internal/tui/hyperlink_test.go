@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHyperlink(t *testing.T) {
+	link := Hyperlink("https://etherscan.io/address/0xabc", "0xabc")
+	if link != "\x1b]8;;https://etherscan.io/address/0xabc\x1b\\0xabc\x1b]8;;\x1b\\" {
+		t.Errorf("Hyperlink() = %q", link)
+	}
+	if width := lipgloss.Width(link); width != len("0xabc") {
+		t.Errorf("width of hyperlink = %d, want %d", width, len("0xabc"))
+	}
+}
+
+func TestHyperlinkTerminal(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"iterm", map[string]string{"TERM_PROGRAM": "iTerm.app"}, true},
+		{"vte 0.52", map[string]string{"VTE_VERSION": "5202"}, true},
+		{"vte 0.46", map[string]string{"VTE_VERSION": "4601"}, false},
+		{"windows terminal", map[string]string{"WT_SESSION": "1"}, true},
+		{"kitty", map[string]string{"TERM": "xterm-kitty"}, true},
+		{"dumb", map[string]string{"TERM": "dumb", "WT_SESSION": "1"}, false},
+		{"plain xterm", map[string]string{"TERM": "xterm-256color"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(name string) string { return tt.env[name] }
+			if got := hyperlinkTerminal(getenv); got != tt.want {
+				t.Errorf("hyperlinkTerminal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"golang.org/x/term"
 
+	"bloco-eth/internal/i18n"
 	"bloco-eth/pkg/wallet"
 )
 
@@ -198,7 +199,7 @@ func (tm *TUIManager) ShouldUseTUI() bool {
 	// In development environments, be more lenient with TUI detection
 	if !isStdoutTerminal && !isStdinTerminal {
 		if os.Getenv("BLOCO_DEBUG") != "" {
-			fmt.Printf("DEBUG TUI: neither stdout nor stdin are terminals, TERM=%s\n", termType)
+			fmt.Println(i18n.T("debug.tui_no_terminal", termType))
 		}
 
 		// If we have a capable terminal environment OR we're in a development setup, allow TUI
@@ -207,17 +208,17 @@ func (tm *TUIManager) ShouldUseTUI() bool {
 			if os.Getenv("VSCODE_INJECTION") != "" || os.Getenv("TERM_PROGRAM") != "" ||
 				os.Getenv("COLORTERM") != "" || len(os.Getenv("TERM")) > 0 {
 				if os.Getenv("BLOCO_DEBUG") != "" {
-					fmt.Printf("DEBUG TUI: development environment detected, allowing TUI\n")
+					fmt.Println(i18n.T("debug.tui_dev_environment"))
 				}
 			} else {
 				if os.Getenv("BLOCO_DEBUG") != "" {
-					fmt.Printf("DEBUG TUI: no capable terminal detected, disabling TUI\n")
+					fmt.Println(i18n.T("debug.tui_incapable"))
 				}
 				return false
 			}
 		} else {
 			if os.Getenv("BLOCO_DEBUG") != "" {
-				fmt.Printf("DEBUG TUI: capable terminal environment detected, allowing TUI\n")
+				fmt.Println(i18n.T("debug.tui_capable"))
 			}
 		}
 	}
@@ -228,7 +229,7 @@ func (tm *TUIManager) ShouldUseTUI() bool {
 	// Require minimum terminal size for usable TUI
 	if capabilities.TerminalWidth < 40 || capabilities.TerminalHeight < 10 {
 		if os.Getenv("BLOCO_DEBUG") != "" {
-			fmt.Printf("DEBUG TUI: terminal too small (%dx%d)\n", capabilities.TerminalWidth, capabilities.TerminalHeight)
+			fmt.Println(i18n.T("debug.tui_too_small", capabilities.TerminalWidth, capabilities.TerminalHeight))
 		}
 		return false
 	}
@@ -238,7 +239,7 @@ func (tm *TUIManager) ShouldUseTUI() bool {
 	// Check if we're running in a CI environment
 	if tm.isInCIEnvironment() {
 		if os.Getenv("BLOCO_DEBUG") != "" {
-			fmt.Printf("DEBUG TUI: CI environment detected\n")
+			fmt.Println(i18n.T("debug.tui_ci"))
 		}
 		return false
 	}
@@ -246,7 +247,7 @@ func (tm *TUIManager) ShouldUseTUI() bool {
 	// Check if output is being redirected
 	if tm.isOutputRedirected() {
 		if os.Getenv("BLOCO_DEBUG") != "" {
-			fmt.Printf("DEBUG TUI: output is redirected\n")
+			fmt.Println(i18n.T("debug.tui_redirected"))
 		}
 		return false
 	}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
+)
+
+// DifficultyLevel is the traffic-light rating of a pattern
+type DifficultyLevel int
+
+const (
+	LevelEasy DifficultyLevel = iota
+	LevelModerate
+	LevelHard
+	LevelExtreme
+)
+
+// Time to a 50% chance at which a pattern moves to the next level
+var levelETAs = []time.Duration{time.Minute, time.Hour, 7 * 24 * time.Hour}
+
+// Difficulties used instead while no speed is known; they match the stats view's
+// pattern-length levels (3, 5 and 7 characters)
+var levelDifficulties = []float64{vanitymath.BaseDifficulty(3), vanitymath.BaseDifficulty(5), vanitymath.BaseDifficulty(7)}
+
+// PatternPreview estimates how hard a pattern is while it is being typed
+type PatternPreview struct {
+	Prefix        string
+	Suffix        string
+	CaseSensitive bool
+	Wallets       int
+	Speed         float64 // calibrated addresses per second, 0 if unknown
+}
+
+// Empty reports whether there is no pattern to preview
+func (p PatternPreview) Empty() bool {
+	return p.Prefix == "" && p.Suffix == ""
+}
+
+// Difficulty is the expected number of attempts per match
+func (p PatternPreview) Difficulty() float64 {
+	return vanitymath.Difficulty(p.Prefix, p.Suffix, p.CaseSensitive)
+}
+
+// Attempts50 is the number of attempts for a 50% chance of finding every wallet
+func (p PatternPreview) Attempts50() int64 {
+	return vanitymath.AttemptsForWallets(p.Difficulty(), max(p.Wallets, 1), 0.5)
+}
+
+// ETAs estimates the time to a 50% and 90% chance at the calibrated speed
+func (p PatternPreview) ETAs() []utils.ETAPercentile {
+	targets := utils.CalculateETAPercentiles(p.Difficulty(), max(p.Wallets, 1), []float64{50, 90})
+	return utils.EstimateETAPercentiles(targets, 0, p.Speed)
+}
+
+// Level rates the pattern by its time to a 50% chance, or by difficulty until the speed is known
+func (p PatternPreview) Level() DifficultyLevel {
+	if p.Speed > 0 {
+		attempts := p.Attempts50()
+		if attempts < 0 {
+			return LevelExtreme
+		}
+		// EstimateETAPercentiles caps what a plain conversion would overflow
+		eta := p.ETAs()[0].Remaining
+		for i, limit := range levelETAs {
+			if eta < limit {
+				return DifficultyLevel(i)
+			}
+		}
+		return LevelExtreme
+	}
+	difficulty := p.Difficulty()
+	for i, limit := range levelDifficulties {
+		if difficulty <= limit {
+			return DifficultyLevel(i)
+		}
+	}
+	return LevelExtreme
+}
+
+// Render draws the preview: difficulty, 50% attempts, ETA and the traffic light
+func (p PatternPreview) Render(sm *StyleManager, pad string) string {
+	if p.Empty() {
+		return pad + sm.FormatInfo(i18n.T("wizard.empty_pattern")) + "\n"
+	}
+	var content strings.Builder
+
+	content.WriteString(pad)
+	content.WriteString(p.renderLevel(sm))
+	content.WriteString("\n")
+
+	content.WriteString(pad)
+	content.WriteString(sm.FormatKeyValue(i18n.T("tui.difficulty"), "1 / "+formatLargeNumber(int64(p.Difficulty()))))
+	content.WriteString("\n")
+
+	attempts := i18n.T("tui.eta_never")
+	if a := p.Attempts50(); a >= 0 {
+		attempts = i18n.T("tui.attempts_value", formatLargeNumber(a))
+	}
+	content.WriteString(pad)
+	content.WriteString(sm.FormatKeyValue(i18n.T("tui.probability50_at"), attempts))
+	content.WriteString("\n")
+
+	eta := i18n.T("wizard.calibrating")
+	if p.Speed > 0 {
+		eta = formatETAPercentiles(p.ETAs()) + " (" + i18n.T("tui.speed_value", formatLargeNumber(int64(p.Speed))) + ")"
+	}
+	content.WriteString(pad)
+	content.WriteString(sm.FormatKeyValue(i18n.T("tui.eta"), eta))
+	content.WriteString("\n")
+	return content.String()
+}
+
+// renderLevel draws the colored traffic light and level name
+func (p PatternPreview) renderLevel(sm *StyleManager) string {
+	light := "●"
+	if !sm.IsUnicodeSupported() {
+		light = "*"
+	}
+	switch p.Level() {
+	case LevelEasy:
+		return sm.FormatSuccess(light + " " + i18n.T("tui.level_easy"))
+	case LevelModerate:
+		return sm.FormatWarning(light + " " + i18n.T("tui.level_moderate"))
+	case LevelHard:
+		return sm.FormatError(light + " " + i18n.T("tui.level_hard"))
+	default:
+		return sm.FormatError(light + light + " " + i18n.T("tui.level_extreme"))
+	}
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPatternPreviewLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		preview PatternPreview
+		want    DifficultyLevel
+	}{
+		{"short pattern without speed", PatternPreview{Prefix: "abc"}, LevelEasy},
+		{"long pattern without speed", PatternPreview{Prefix: "abcdef01"}, LevelExtreme},
+		{"seconds at speed", PatternPreview{Prefix: "abcd", Speed: 100000}, LevelEasy},
+		{"minutes at speed", PatternPreview{Prefix: "abcdef", Speed: 100000}, LevelModerate},
+		{"days at speed", PatternPreview{Prefix: "abcdef01", Speed: 100000}, LevelHard},
+		{"years at speed", PatternPreview{Prefix: "abcdef0123", Speed: 100000}, LevelExtreme},
+		{"longer than a Duration at speed", PatternPreview{Prefix: "abcdef0123456", Suffix: "12", Speed: 50000}, LevelExtreme},
+		{"batch of wallets", PatternPreview{Prefix: "abcd", Wallets: 1000, Speed: 100000}, LevelModerate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.preview.Level(); got != tt.want {
+				t.Errorf("Level() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatternPreviewRender(t *testing.T) {
+	sm := NewStyleManager()
+	p := PatternPreview{Prefix: "ab"}
+	if out := p.Render(sm, ""); !strings.Contains(out, "256") || !strings.Contains(out, "178") || !strings.Contains(out, "measuring") {
+		t.Errorf("preview without speed should show difficulty and 50%% attempts:\n%s", out)
+	}
+	p.Speed = 100
+	if out := p.Render(sm, ""); !strings.Contains(out, "90%") || !strings.Contains(out, "Easy") {
+		t.Errorf("preview with speed should show the ETA and level:\n%s", out)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/utils"
 	"bloco-eth/pkg/wallet"
 )
 
@@ -34,6 +36,8 @@ type ProgressModel struct {
 	completedWallets int  // Number of wallets completed
 	totalWallets     int  // Total wallets requested
 	isComplete       bool // Indicates if generation is complete
+	etaPercentiles   []utils.ETAPercentile
+	paused           string // why generation is paused, "" while it runs
 }
 
 // ProgressMsg represents a progress update message
@@ -42,12 +46,14 @@ type ProgressMsg struct {
 	Speed            float64
 	Probability      float64
 	EstimatedTime    time.Duration
+	ETAPercentiles   []utils.ETAPercentile // Time to each probability of finding all wallets
 	Difficulty       float64
 	Pattern          string
 	CompletedWallets int     // Number of wallets successfully generated
 	TotalWallets     int     // Total wallets requested
 	ProgressPercent  float64 // Progress as percentage (0-100) for progress bar
 	IsComplete       bool    // Indicates if generation is complete
+	Paused           string  // Why generation is paused, "" while it runs
 }
 
 // TickMsg represents a timer tick for smooth animations
@@ -64,6 +70,10 @@ type WalletResult struct {
 	Attempts   int
 	Time       time.Duration
 	Error      string
+	// Marks, when set, show the pattern and checksum case of the address
+	Marks []AddressMark
+	// Explorer, when set, is the block explorer page of the address
+	Explorer string
 }
 
 // WalletResultMsg represents a wallet generation result message
@@ -86,10 +96,10 @@ func NewProgressModel(stats *wallet.GenerationStats, statsManager StatsManager)
 	// Create results table with columns for wallet information
 	columns := []table.Column{
 		{Title: "№", Width: 3},
-		{Title: "Address", Width: 42},
-		{Title: "Private Key", Width: 64},
-		{Title: "Attempts", Width: 10},
-		{Title: "Time", Width: 10},
+		{Title: i18n.T("tui.column_address"), Width: 42},
+		{Title: i18n.T("tui.column_key"), Width: 64},
+		{Title: i18n.T("tui.column_attempts"), Width: 10},
+		{Title: i18n.T("tui.column_time"), Width: 10},
 	}
 
 	t := table.New(
@@ -181,6 +191,9 @@ func (m ProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.stats.Speed = msg.Speed
 			m.stats.Probability = msg.Probability
 			m.stats.EstimatedTime = msg.EstimatedTime
+			if msg.ETAPercentiles != nil {
+				m.etaPercentiles = msg.ETAPercentiles
+			}
 			m.stats.Difficulty = msg.Difficulty
 			m.stats.Pattern = msg.Pattern
 			m.stats.LastUpdate = time.Now()
@@ -189,6 +202,7 @@ func (m ProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.completedWallets = msg.CompletedWallets
 			m.totalWallets = msg.TotalWallets
 			m.isComplete = msg.IsComplete // Update completion status
+			m.paused = msg.Paused
 
 			// Update progress bar with correct percentage (wallets completed vs total)
 			progressPercent := msg.ProgressPercent / 100.0
@@ -261,7 +275,7 @@ func (m ProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the progress display
 func (m ProgressModel) View() string {
 	if m.stats == nil {
-		return m.styleManager.FormatError("No statistics available")
+		return m.styleManager.FormatError(i18n.T("tui.no_stats"))
 	}
 
 	pad := strings.Repeat(" ", padding)
@@ -272,7 +286,7 @@ func (m ProgressModel) View() string {
 	content.WriteString(renderBlocoLogo(pad))
 	content.WriteString("\n")
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatTitle("Wallet Generator"))
+	content.WriteString(m.styleManager.FormatTitle(i18n.T("tui.title")))
 	content.WriteString("\n")
 
 	// ALWAYS show progress information first (pattern, difficulty, progress bar, stats)
@@ -281,11 +295,11 @@ func (m ProgressModel) View() string {
 	content.WriteString(pad)
 	pattern := m.stats.Pattern
 	if len(pattern) == 0 {
-		pattern = "any"
+		pattern = i18n.T("tui.pattern_any")
 	}
-	patternInfo := m.styleManager.FormatKeyValue("Pattern", pattern)
+	patternInfo := m.styleManager.FormatKeyValue(i18n.T("tui.pattern"), pattern)
 	if m.stats.IsChecksum {
-		patternInfo += " " + m.styleManager.FormatHighlight("(checksum)")
+		patternInfo += " " + m.styleManager.FormatHighlight(i18n.T("tui.checksum_tag"))
 	}
 	content.WriteString(patternInfo)
 	content.WriteString("\n")
@@ -293,7 +307,7 @@ func (m ProgressModel) View() string {
 	// Difficulty information
 	content.WriteString(pad)
 	difficultyStr := formatLargeNumber(int64(m.stats.Difficulty))
-	content.WriteString(m.styleManager.FormatKeyValue("Difficulty", difficultyStr))
+	content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.difficulty"), difficultyStr))
 	content.WriteString("\n")
 
 	// Progress bar - following Bubbletea pattern
@@ -306,23 +320,29 @@ func (m ProgressModel) View() string {
 	var progressText string
 	if m.totalWallets > 0 {
 		// Show wallets completed vs total when we know the total
-		progressText = fmt.Sprintf("%d/%d wallets completed (%.1f%%)",
+		progressText = i18n.T("tui.wallets_completed",
 			m.completedWallets,
 			m.totalWallets,
-			(float64(m.completedWallets)/float64(m.totalWallets))*100.0)
+			i18n.FormatDecimal((float64(m.completedWallets)/float64(m.totalWallets))*100.0, 1))
 	} else if len(m.walletResults) > 0 {
 		// Fallback to wallet results count
-		progressText = fmt.Sprintf("%d wallets generated", len(m.walletResults))
+		progressText = i18n.T("tui.wallets_generated", len(m.walletResults))
 	} else {
 		// Show probability when no results yet
-		progressText = fmt.Sprintf("%.2f%% probability", m.stats.Probability)
+		progressText = i18n.T("tui.probability", i18n.FormatDecimal(m.stats.Probability, 2))
 	}
 	content.WriteString(m.styleManager.FormatHighlight(progressText))
-	content.WriteString("\n\n")
+	content.WriteString("\n")
+	if m.paused != "" {
+		content.WriteString(pad)
+		content.WriteString(m.styleManager.FormatWarning(i18n.T("tui.paused", m.paused)))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
 
 	// Statistics section using Bubbletea table-like display
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatSubtitle("Statistics"))
+	content.WriteString(m.styleManager.FormatSubtitle(i18n.T("tui.statistics")))
 	content.WriteString("\n")
 
 	// Create formatted statistics display
@@ -335,7 +355,7 @@ func (m ProgressModel) View() string {
 		if metrics.ThreadCount > 1 {
 			content.WriteString("\n")
 			content.WriteString(pad)
-			content.WriteString(m.styleManager.FormatSubtitle("Thread Performance"))
+			content.WriteString(m.styleManager.FormatSubtitle(i18n.T("tui.thread_perf")))
 			content.WriteString("\n")
 
 			threadDisplay := m.renderThreadStats(metrics)
@@ -347,25 +367,58 @@ func (m ProgressModel) View() string {
 	if m.showResults && len(m.walletResults) > 0 {
 		content.WriteString("\n")
 		content.WriteString(pad)
-		content.WriteString(m.styleManager.FormatSubtitle(fmt.Sprintf("Generated Wallets (%d)", len(m.walletResults))))
+		content.WriteString(m.styleManager.FormatSubtitle(i18n.T("tui.generated", len(m.walletResults))))
 		content.WriteString("\n")
 		content.WriteString(pad)
 		content.WriteString(m.resultsTable.View())
 		content.WriteString("\n")
+		content.WriteString(m.renderLastFound(pad))
 	}
 
 	// Help text
 	content.WriteString("\n")
 	content.WriteString(pad)
 	if m.showResults && len(m.walletResults) > 8 {
-		content.WriteString(helpStyle("Use ↑↓/j/k to scroll table • Press q to quit • Ctrl+C to exit"))
+		content.WriteString(helpStyle(i18n.T("tui.help_scroll")))
 	} else {
-		content.WriteString(helpStyle("Press q to quit • Ctrl+C to exit"))
+		content.WriteString(helpStyle(i18n.T("tui.help_quit")))
 	}
 
 	return content.String()
 }
 
+// renderLastFound renders the marks and explorer link of the latest address, or ""
+// when it has neither
+func (m ProgressModel) renderLastFound(pad string) string {
+	last := m.walletResults[len(m.walletResults)-1]
+	marked := HasMarks(last.Marks)
+	if last.Error != "" || (!marked && last.Explorer == "") {
+		return ""
+	}
+	label := m.styleManager.labelStyle.Render(i18n.T("tui.last_found") + ": ")
+	var content strings.Builder
+	if !marked {
+		content.WriteString(pad + label + last.Address + "\n")
+	} else {
+		address, markers := m.styleManager.FormatAddress(last.Marks)
+		content.WriteString(pad + label + address + "\n")
+		if markers != "" {
+			content.WriteString(pad + strings.Repeat(" ", lipgloss.Width(label)) + markers + "\n")
+		}
+	}
+	if last.Explorer != "" {
+		link := last.Explorer
+		if SupportsHyperlinks() {
+			link = Hyperlink(link, link)
+		}
+		content.WriteString(pad + m.styleManager.labelStyle.Render(i18n.T("tui.explorer")+": ") + link + "\n")
+	}
+	if marked {
+		content.WriteString(pad + m.styleManager.AddressLegend() + "\n")
+	}
+	return content.String()
+}
+
 func (m ProgressModel) Quitting() bool {
 	return m.quitting
 }
@@ -387,10 +440,10 @@ func (m ProgressModel) renderStatsTable() string {
 		label string
 		value string
 	}{
-		{"Attempts", formatLargeNumber(m.stats.CurrentAttempts)},
-		{"Speed", fmt.Sprintf("%.0f addr/s", m.stats.Speed)},
-		{"ETA", m.formatETA()},
-		{"50% at", m.format50Probability()},
+		{i18n.T("tui.attempts"), formatLargeNumber(m.stats.CurrentAttempts)},
+		{i18n.T("tui.speed"), i18n.T("tui.speed_value", i18n.FormatDecimal(m.stats.Speed, 0))},
+		{i18n.T("tui.eta"), m.formatETA()},
+		{i18n.T("tui.probability50_at"), m.format50Probability()},
 	}
 
 	// Render each stat row
@@ -412,9 +465,9 @@ func (m ProgressModel) renderThreadStats(metrics ThreadMetrics) string {
 		label string
 		value string
 	}{
-		{"Threads", fmt.Sprintf("%d threads", metrics.ThreadCount)},
-		{"Efficiency", fmt.Sprintf("%.1f%%", metrics.EfficiencyRatio*100)},
-		{"Peak Speed", fmt.Sprintf("%.0f addr/s", m.statsManager.GetPeakSpeed())},
+		{i18n.T("tui.threads"), i18n.T("tui.threads_value", metrics.ThreadCount)},
+		{i18n.T("tui.efficiency"), i18n.FormatDecimal(metrics.EfficiencyRatio*100, 1) + "%"},
+		{i18n.T("tui.peak_speed"), i18n.T("tui.speed_value", i18n.FormatDecimal(m.statsManager.GetPeakSpeed(), 0))},
 	}
 
 	for _, stat := range threadStats {
@@ -430,20 +483,23 @@ func (m ProgressModel) renderThreadStats(metrics ThreadMetrics) string {
 func (m ProgressModel) formatETA() string {
 	if m.isComplete {
 		totalTime := time.Since(m.stats.StartTime)
-		return fmt.Sprintf("Done in %s", formatDuration(totalTime))
+		return i18n.T("tui.done_in", formatDuration(totalTime))
+	}
+	if len(m.etaPercentiles) > 0 {
+		return formatETAPercentiles(m.etaPercentiles)
 	}
 	if m.stats.EstimatedTime > 0 {
 		return formatDuration(m.stats.EstimatedTime)
 	}
-	return "Calculating..."
+	return i18n.T("tui.calculating")
 }
 
 // format50Probability formats the 50% probability attempts
 func (m ProgressModel) format50Probability() string {
 	if m.stats.Probability50 > 0 {
-		return formatLargeNumber(m.stats.Probability50) + " attempts"
+		return i18n.T("tui.attempts_value", formatLargeNumber(m.stats.Probability50))
 	}
-	return "Nearly impossible"
+	return i18n.T("duration.impossible")
 }
 
 // UpdateProgress sends a progress update message to the model
@@ -492,7 +548,7 @@ func (m *ProgressModel) updateResultsTable() {
 			// Error row
 			rows = append(rows, table.Row{
 				fmt.Sprintf("%d", result.Index),
-				"Error occurred",
+				i18n.T("tui.error_row"),
 				result.Error,
 				"-",
 				m.formatDuration(result.Time),
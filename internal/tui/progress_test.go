@@ -0,0 +1,30 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestProgressModelETAPercentiles(t *testing.T) {
+	stats := &wallet.GenerationStats{Difficulty: 65536, StartTime: time.Now()}
+	model := NewProgressModel(stats, nil)
+
+	targets := utils.CalculateETAPercentiles(stats.Difficulty, 1, utils.DefaultETAPercentiles)
+	updated, _ := model.Update(ProgressMsg{
+		Attempts:       targets[0].Attempts,
+		Speed:          1000,
+		ETAPercentiles: utils.EstimateETAPercentiles(targets, targets[0].Attempts, 1000),
+		TotalWallets:   1,
+	})
+
+	eta := updated.(ProgressModel).formatETA()
+	for _, want := range []string{"50%: reached", "90%: ", "99%: "} {
+		if !strings.Contains(eta, want) {
+			t.Errorf("ETA %q does not contain %q", eta, want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package tui
 
 import (
 	"fmt"
-	"math"
 	"strings"
 	"time"
 
@@ -10,6 +9,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/vanitymath"
 	"bloco-eth/pkg/wallet"
 )
 
@@ -149,7 +150,7 @@ func (m StatsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the statistics display
 func (m StatsModel) View() string {
 	if m.stats == nil {
-		return m.styleManager.FormatError("No statistics available")
+		return m.styleManager.FormatError(i18n.T("tui.no_stats"))
 	}
 
 	pad := strings.Repeat(" ", 2)
@@ -160,7 +161,7 @@ func (m StatsModel) View() string {
 	content.WriteString(renderBlocoLogo(pad))
 	content.WriteString("\n")
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatTitle("Bloco Address Difficulty Analysis"))
+	content.WriteString(m.styleManager.FormatTitle(i18n.T("tui.stats_title")))
 	content.WriteString("\n\n")
 
 	// Pattern overview section
@@ -169,7 +170,7 @@ func (m StatsModel) View() string {
 
 	// Main statistics table
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatSubtitle("Detailed Statistics"))
+	content.WriteString(m.styleManager.FormatSubtitle(i18n.T("tui.stats_detailed")))
 	content.WriteString("\n")
 	content.WriteString(m.table.View())
 	content.WriteString("\n")
@@ -188,7 +189,7 @@ func (m StatsModel) View() string {
 
 	// Help text
 	content.WriteString(pad)
-	helpText := "Use ↑/↓ or j/k to navigate • Press 'q', 'Ctrl+C', or 'Esc' to quit"
+	helpText := i18n.T("tui.stats_help")
 	content.WriteString(helpStyle(helpText))
 
 	return content.String()
@@ -202,22 +203,22 @@ func (m StatsModel) renderPatternOverview() string {
 	// Create pattern visualization
 	pattern := m.getPatternVisualization()
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatKeyValue("Pattern", pattern))
+	content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.pattern"), pattern))
 	content.WriteString("\n")
 
 	// Checksum status
-	checksumStatus := "Disabled"
+	checksumStatus := i18n.T("bool.disabled")
 	if m.stats.IsChecksum {
-		checksumStatus = m.styleManager.FormatHighlight("Enabled (increases difficulty)")
+		checksumStatus = m.styleManager.FormatHighlight(i18n.T("tui.checksum_on"))
 	}
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatKeyValue("Checksum", checksumStatus))
+	content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.checksum"), checksumStatus))
 	content.WriteString("\n")
 
 	// Pattern length
 	patternLength := len(m.stats.Pattern)
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatKeyValue("Pattern Length", fmt.Sprintf("%d characters", patternLength)))
+	content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.pattern_length"), i18n.T("tui.characters", patternLength)))
 
 	return content.String()
 }
@@ -228,14 +229,14 @@ func (m StatsModel) renderTimeEstimates() string {
 	var content strings.Builder
 
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatSubtitle("Time Estimates (at different speeds)"))
+	content.WriteString(m.styleManager.FormatSubtitle(i18n.T("tui.time_estimates")))
 	content.WriteString("\n")
 
 	// Create time estimates table
 	timeColumns := []table.Column{
-		{Title: "Speed (addr/s)", Width: 15},
-		{Title: "50% Probability", Width: 20},
-		{Title: "90% Probability", Width: 20},
+		{Title: i18n.T("tui.column_speed"), Width: 15},
+		{Title: i18n.T("tui.column_p50"), Width: 20},
+		{Title: i18n.T("tui.column_p90"), Width: 20},
 	}
 
 	timeTable := table.New(
@@ -278,8 +279,8 @@ func (m StatsModel) renderTimeEstimates() string {
 			time90 := time.Duration(float64(m.stats.Probability50)*2.3/speed) * time.Second
 			time90Str = formatDuration(time90)
 		} else {
-			time50Str = "Nearly impossible"
-			time90Str = "Nearly impossible"
+			time50Str = i18n.T("duration.impossible")
+			time90Str = i18n.T("duration.impossible")
 		}
 
 		timeRows = append(timeRows, table.Row{speedStr, time50Str, time90Str})
@@ -297,14 +298,14 @@ func (m StatsModel) renderProbabilityExamples() string {
 	var content strings.Builder
 
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatSubtitle("Probability Examples"))
+	content.WriteString(m.styleManager.FormatSubtitle(i18n.T("tui.probability_ex")))
 	content.WriteString("\n")
 
 	// Create probability table
 	probColumns := []table.Column{
-		{Title: "Attempts", Width: 15},
-		{Title: "Probability", Width: 15},
-		{Title: "Likelihood", Width: 25},
+		{Title: i18n.T("tui.column_attempts"), Width: 15},
+		{Title: i18n.T("tui.column_probability"), Width: 15},
+		{Title: i18n.T("tui.column_likelihood"), Width: 25},
 	}
 
 	probTable := table.New(
@@ -340,28 +341,28 @@ func (m StatsModel) renderProbabilityExamples() string {
 
 		var probStr, likelihoodStr string
 		if m.stats.Difficulty > 0 {
-			prob := computeProbability(m.stats.Difficulty, attempts) * 100
-			probStr = fmt.Sprintf("%.4f%%", prob)
+			prob := vanitymath.Probability(m.stats.Difficulty, attempts) * 100
+			probStr = i18n.FormatDecimal(prob, 4) + "%"
 
 			// Provide intuitive likelihood descriptions
 			if prob < 0.001 {
-				likelihoodStr = "Extremely unlikely"
+				likelihoodStr = i18n.T("tui.likely_extreme")
 			} else if prob < 0.1 {
-				likelihoodStr = "Very unlikely"
+				likelihoodStr = i18n.T("tui.likely_very_un")
 			} else if prob < 1 {
-				likelihoodStr = "Unlikely"
+				likelihoodStr = i18n.T("tui.likely_unlikely")
 			} else if prob < 10 {
-				likelihoodStr = "Low chance"
+				likelihoodStr = i18n.T("tui.likely_low")
 			} else if prob < 50 {
-				likelihoodStr = "Moderate chance"
+				likelihoodStr = i18n.T("tui.likely_moderate")
 			} else if prob < 90 {
-				likelihoodStr = "Good chance"
+				likelihoodStr = i18n.T("tui.likely_good")
 			} else {
-				likelihoodStr = "Very likely"
+				likelihoodStr = i18n.T("tui.likely_very")
 			}
 		} else {
 			probStr = "0%"
-			likelihoodStr = "Impossible"
+			likelihoodStr = i18n.T("tui.likely_impossible")
 		}
 
 		probRows = append(probRows, table.Row{attemptsStr, probStr, likelihoodStr})
@@ -379,7 +380,7 @@ func (m StatsModel) renderRecommendations() string {
 	var content strings.Builder
 
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatSubtitle("Recommendations"))
+	content.WriteString(m.styleManager.FormatSubtitle(i18n.T("tui.recommendations")))
 	content.WriteString("\n")
 
 	patternLength := len(m.stats.Pattern)
@@ -387,38 +388,38 @@ func (m StatsModel) renderRecommendations() string {
 	// Difficulty assessment
 	var difficultyLevel, recommendation string
 	if patternLength <= 3 {
-		difficultyLevel = m.styleManager.FormatSuccess("Easy")
-		recommendation = "Should generate quickly, suitable for testing"
+		difficultyLevel = m.styleManager.FormatSuccess(i18n.T("tui.level_easy"))
+		recommendation = i18n.T("tui.recommend_easy")
 	} else if patternLength <= 5 {
-		difficultyLevel = m.styleManager.FormatWarning("Moderate")
-		recommendation = "May take some time, reasonable for production use"
+		difficultyLevel = m.styleManager.FormatWarning(i18n.T("tui.level_moderate"))
+		recommendation = i18n.T("tui.recommend_moderate")
 	} else if patternLength <= 7 {
-		difficultyLevel = m.styleManager.FormatError("Hard")
-		recommendation = "Will take considerable time, plan accordingly"
+		difficultyLevel = m.styleManager.FormatError(i18n.T("tui.level_hard"))
+		recommendation = i18n.T("tui.recommend_hard")
 	} else {
-		difficultyLevel = m.styleManager.FormatError("Extremely Hard")
-		recommendation = "May take days/weeks/years, use with extreme caution"
+		difficultyLevel = m.styleManager.FormatError(i18n.T("tui.level_extreme"))
+		recommendation = i18n.T("tui.recommend_extreme")
 	}
 
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatKeyValue("Difficulty Level", difficultyLevel))
+	content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.level"), difficultyLevel))
 	content.WriteString("\n")
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatKeyValue("Recommendation", recommendation))
+	content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.recommend"), recommendation))
 	content.WriteString("\n")
 
 	// Checksum impact
 	if m.stats.IsChecksum {
-		checksumImpact := "Checksum validation significantly increases difficulty"
+		checksumImpact := i18n.T("tui.checksum_warning")
 		content.WriteString(pad)
-		content.WriteString(m.styleManager.FormatKeyValue("Checksum Impact", m.styleManager.FormatWarning(checksumImpact)))
+		content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.checksum_impact"), m.styleManager.FormatWarning(checksumImpact)))
 		content.WriteString("\n")
 	}
 
 	// Thread recommendation
-	threadRec := "Use multiple threads (--threads) for better performance"
+	threadRec := i18n.T("tui.perf_threads")
 	content.WriteString(pad)
-	content.WriteString(m.styleManager.FormatKeyValue("Performance Tip", m.styleManager.FormatInfo(threadRec)))
+	content.WriteString(m.styleManager.FormatKeyValue(i18n.T("tui.perf_tip"), m.styleManager.FormatInfo(threadRec)))
 
 	return content.String()
 }
@@ -457,7 +458,7 @@ func (m StatsModel) generateStatisticsRows() []table.Row {
 	})
 
 	// Base difficulty (without checksum)
-	baseDifficulty := math.Pow(16, float64(patternLength))
+	baseDifficulty := vanitymath.BaseDifficulty(patternLength)
 	rows = append(rows, table.Row{
 		"Base Difficulty",
 		formatLargeNumber(int64(baseDifficulty)),
@@ -592,13 +593,3 @@ func UpdateStats(stats *wallet.GenerationStats) tea.Cmd {
 		return StatsUpdateMsg{Stats: stats}
 	}
 }
-
-// Helper functions for statistical calculations
-
-// computeProbability calculates the probability of finding an address after N attempts
-func computeProbability(difficulty float64, attempts int64) float64 {
-	if difficulty <= 0 {
-		return 0
-	}
-	return 1 - math.Pow(1-1/difficulty, float64(attempts))
-}
@@ -1,52 +1,38 @@
 package tui
 
 import (
-	"fmt"
 	"strconv"
+	"strings"
 	"time"
+
+	"bloco-eth/internal/i18n"
+	"bloco-eth/pkg/utils"
 )
 
-// formatLargeNumber formats large numbers with space separators
+// formatLargeNumber formats large numbers with the locale's thousands separator
 func formatLargeNumber(num int64) string {
-	if num == 0 {
-		return "0"
-	}
-
-	str := strconv.FormatInt(num, 10)
-	result := ""
-
-	for i, char := range str {
-		if i > 0 && (len(str)-i)%3 == 0 {
-			result += " "
-		}
-		result += string(char)
-	}
-
-	return result
+	return i18n.FormatNumber(num)
 }
 
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
-	if d < 0 {
-		return "Nearly impossible"
-	}
-
-	seconds := d.Seconds()
-
-	// If more than 200 years, return "Thousands of years"
-	if seconds > 200*365.25*24*3600 {
-		return "Thousands of years"
-	}
+	return i18n.FormatDuration(d)
+}
 
-	if seconds < 60 {
-		return fmt.Sprintf("%.1fs", seconds)
-	} else if seconds < 3600 {
-		return fmt.Sprintf("%.1fm", seconds/60)
-	} else if seconds < 86400 {
-		return fmt.Sprintf("%.1fh", seconds/3600)
-	} else if seconds < 31536000 {
-		return fmt.Sprintf("%.1fd", seconds/86400)
-	} else {
-		return fmt.Sprintf("%.1fy", seconds/31536000)
+// formatETAPercentiles formats ETA percentiles as "50%: 2.1m · 90%: 6.8m · 99%: 13.6m"
+func formatETAPercentiles(etas []utils.ETAPercentile) string {
+	parts := make([]string, len(etas))
+	for i, eta := range etas {
+		value := "?"
+		switch {
+		case eta.Attempts < 0:
+			value = i18n.T("tui.eta_never")
+		case eta.Reached():
+			value = i18n.T("tui.eta_reached")
+		case eta.Remaining > 0:
+			value = formatDuration(eta.Remaining)
+		}
+		parts[i] = strconv.FormatFloat(eta.Percent, 'f', -1, 64) + "%: " + value
 	}
+	return strings.Join(parts, " · ")
 }
@@ -0,0 +1,363 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"bloco-eth/internal/i18n"
+)
+
+// WizardChoices holds the options picked in the setup wizard
+type WizardChoices struct {
+	Prefix        string
+	Suffix        string
+	Checksum      bool
+	CaseSensitive bool
+	Count         int
+	Keystore      bool
+	KDF           string
+}
+
+// WizardAction is how the wizard was left
+type WizardAction int
+
+const (
+	WizardCancel WizardAction = iota
+	WizardRun
+	WizardPrint
+)
+
+// wizardField is a focusable row of the wizard
+type wizardField int
+
+const (
+	fieldPrefix wizardField = iota
+	fieldSuffix
+	fieldChecksum
+	fieldCaseSensitive
+	fieldCount
+	fieldKeystore
+	fieldKDF
+	fieldRun
+	fieldPrint
+	fieldTotal
+)
+
+// wizardKDFs are the KeyStore KDFs offered by the wizard
+var wizardKDFs = []string{"scrypt", "pbkdf2"}
+
+// maxWizardCount caps the wallet count field
+const maxWizardCount = 1000000
+
+// WizardSpeedMsg reports the calibrated generation speed in addresses per second
+type WizardSpeedMsg struct {
+	Speed float64
+}
+
+// WizardModel walks new users through choosing a pattern and generation options
+type WizardModel struct {
+	choices      WizardChoices
+	countText    string
+	focus        wizardField
+	speed        float64
+	calibrate    tea.Cmd
+	action       WizardAction
+	problem      string
+	styleManager *StyleManager
+}
+
+// NewWizardModel creates a wizard starting from defaults; calibrate, if not nil,
+// measures the speed used for the ETA and should return a WizardSpeedMsg
+func NewWizardModel(defaults WizardChoices, calibrate tea.Cmd) WizardModel {
+	if defaults.Count < 1 {
+		defaults.Count = 1
+	}
+	if defaults.KDF == "" {
+		defaults.KDF = wizardKDFs[0]
+	}
+	capabilities := NewTUIManager().DetectCapabilities()
+	return WizardModel{
+		choices:      defaults,
+		countText:    strconv.Itoa(defaults.Count),
+		calibrate:    calibrate,
+		styleManager: NewStyleManagerWithCapabilities(capabilities),
+	}
+}
+
+// Result returns the choices and how the wizard was left
+func (m WizardModel) Result() (WizardChoices, WizardAction) {
+	return m.choices, m.action
+}
+
+// Init starts the speed calibration
+func (m WizardModel) Init() tea.Cmd {
+	return m.calibrate
+}
+
+// Update handles key presses and the calibration result
+func (m WizardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case WizardSpeedMsg:
+		m.speed = msg.Speed
+		return m, nil
+
+	case tea.KeyMsg:
+		m.problem = ""
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.action = WizardCancel
+			return m, tea.Quit
+		case "up", "shift+tab":
+			m.focus = m.nextField(-1)
+			return m, nil
+		case "down", "tab":
+			m.focus = m.nextField(1)
+			return m, nil
+		case "left", "right":
+			if m.focus == fieldKDF {
+				m.choices.KDF = cycleKDF(m.choices.KDF)
+			}
+			return m, nil
+		case "backspace":
+			m.deleteRune()
+			return m, nil
+		case " ", "enter":
+			return m.activate(msg.String() == "enter")
+		}
+		if msg.Type == tea.KeyRunes {
+			for _, r := range msg.Runes {
+				m.insertRune(r)
+			}
+		}
+	}
+	return m, nil
+}
+
+// nextField moves the focus by step, skipping rows that do not apply
+func (m WizardModel) nextField(step int) wizardField {
+	field := m.focus
+	for {
+		field = (field + wizardField(step) + fieldTotal) % fieldTotal
+		if m.fieldEnabled(field) {
+			return field
+		}
+	}
+}
+
+// fieldEnabled reports whether a row currently applies
+func (m WizardModel) fieldEnabled(field wizardField) bool {
+	switch field {
+	case fieldCaseSensitive:
+		return m.choices.Checksum
+	case fieldKDF:
+		return m.choices.Keystore
+	}
+	return true
+}
+
+// activate toggles the focused option, or finishes on the run and print rows
+func (m WizardModel) activate(enter bool) (tea.Model, tea.Cmd) {
+	switch m.focus {
+	case fieldChecksum:
+		m.choices.Checksum = !m.choices.Checksum
+		if !m.choices.Checksum {
+			m.choices.CaseSensitive = false
+		}
+		m.normalizeCase()
+	case fieldCaseSensitive:
+		m.choices.CaseSensitive = !m.choices.CaseSensitive
+		m.normalizeCase()
+	case fieldKeystore:
+		m.choices.Keystore = !m.choices.Keystore
+	case fieldKDF:
+		m.choices.KDF = cycleKDF(m.choices.KDF)
+	case fieldRun, fieldPrint:
+		if m.choices.Prefix == "" && m.choices.Suffix == "" {
+			m.problem = i18n.T("wizard.empty_pattern")
+			return m, nil
+		}
+		m.action = WizardRun
+		if m.focus == fieldPrint {
+			m.action = WizardPrint
+		}
+		return m, tea.Quit
+	default:
+		if enter {
+			m.focus = m.nextField(1)
+		}
+	}
+	return m, nil
+}
+
+// insertRune types into the focused text field, accepting hex digits for the
+// pattern and decimal digits for the count
+func (m *WizardModel) insertRune(r rune) {
+	switch m.focus {
+	case fieldPrefix, fieldSuffix:
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return
+		}
+		if len(m.choices.Prefix)+len(m.choices.Suffix) >= 40 {
+			return
+		}
+		if !m.caseSensitive() {
+			r = unicode.ToLower(r)
+		}
+		pattern := m.pattern()
+		*pattern += string(r)
+	case fieldCount:
+		if r < '0' || r > '9' {
+			return
+		}
+		if count, err := strconv.Atoi(m.countText + string(r)); err == nil && count <= maxWizardCount {
+			m.countText = strings.TrimLeft(m.countText+string(r), "0")
+			m.choices.Count = max(count, 1)
+		}
+	}
+}
+
+// deleteRune removes the last character of the focused text field
+func (m *WizardModel) deleteRune() {
+	switch m.focus {
+	case fieldPrefix, fieldSuffix:
+		if pattern := m.pattern(); *pattern != "" {
+			*pattern = (*pattern)[:len(*pattern)-1]
+		}
+	case fieldCount:
+		if m.countText != "" {
+			m.countText = m.countText[:len(m.countText)-1]
+		}
+		count, _ := strconv.Atoi(m.countText)
+		m.choices.Count = max(count, 1)
+	}
+}
+
+// caseSensitive reports whether the pattern's letter case is enforced
+func (m WizardModel) caseSensitive() bool {
+	return m.choices.Checksum && m.choices.CaseSensitive
+}
+
+// normalizeCase lowercases the pattern when its case is not enforced
+func (m *WizardModel) normalizeCase() {
+	if !m.caseSensitive() {
+		m.choices.Prefix = strings.ToLower(m.choices.Prefix)
+		m.choices.Suffix = strings.ToLower(m.choices.Suffix)
+	}
+}
+
+// pattern returns the focused pattern field
+func (m *WizardModel) pattern() *string {
+	if m.focus == fieldSuffix {
+		return &m.choices.Suffix
+	}
+	return &m.choices.Prefix
+}
+
+// cycleKDF returns the KDF after current
+func cycleKDF(current string) string {
+	for i, kdf := range wizardKDFs {
+		if kdf == current {
+			return wizardKDFs[(i+1)%len(wizardKDFs)]
+		}
+	}
+	return wizardKDFs[0]
+}
+
+// View renders the wizard form and the live difficulty preview
+func (m WizardModel) View() string {
+	pad := strings.Repeat(" ", 2)
+	var content strings.Builder
+
+	content.WriteString("\n")
+	content.WriteString(renderBlocoLogo(pad))
+	content.WriteString("\n")
+	content.WriteString(pad)
+	content.WriteString(m.styleManager.FormatTitle(i18n.T("wizard.title")))
+	content.WriteString("\n\n")
+
+	rows := []struct {
+		field wizardField
+		label string
+		value string
+	}{
+		{fieldPrefix, i18n.T("wizard.prefix"), m.choices.Prefix},
+		{fieldSuffix, i18n.T("wizard.suffix"), m.choices.Suffix},
+		{fieldChecksum, i18n.T("wizard.checksum"), checkbox(m.choices.Checksum)},
+		{fieldCaseSensitive, i18n.T("wizard.case_sensitive"), checkbox(m.choices.CaseSensitive)},
+		{fieldCount, i18n.T("wizard.count"), m.countText},
+		{fieldKeystore, i18n.T("wizard.keystore"), checkbox(m.choices.Keystore)},
+		{fieldKDF, i18n.T("wizard.kdf"), "< " + m.choices.KDF + " >"},
+	}
+	for _, row := range rows {
+		if !m.fieldEnabled(row.field) {
+			continue
+		}
+		value := row.value
+		if row.field == m.focus && (row.field == fieldPrefix || row.field == fieldSuffix || row.field == fieldCount) {
+			value += "_"
+		}
+		content.WriteString(m.cursor(row.field))
+		content.WriteString(m.styleManager.FormatKeyValue(row.label, value))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(m.preview().Render(m.styleManager, pad))
+	content.WriteString("\n")
+
+	for _, field := range []wizardField{fieldRun, fieldPrint} {
+		label := i18n.T("wizard.run")
+		if field == fieldPrint {
+			label = i18n.T("wizard.print")
+		}
+		content.WriteString(m.cursor(field))
+		if field == m.focus {
+			label = m.styleManager.FormatHighlight(label)
+		}
+		content.WriteString(label)
+		content.WriteString("\n")
+	}
+
+	if m.problem != "" {
+		content.WriteString("\n")
+		content.WriteString(pad)
+		content.WriteString(m.styleManager.FormatError(m.problem))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(pad)
+	content.WriteString(helpStyle(i18n.T("wizard.help")))
+	content.WriteString("\n")
+	return content.String()
+}
+
+// cursor marks the focused row
+func (m WizardModel) cursor(field wizardField) string {
+	if field == m.focus {
+		return "> "
+	}
+	return "  "
+}
+
+// preview describes the pattern being typed with the calibrated speed
+func (m WizardModel) preview() PatternPreview {
+	return PatternPreview{
+		Prefix:        m.choices.Prefix,
+		Suffix:        m.choices.Suffix,
+		CaseSensitive: m.caseSensitive(),
+		Wallets:       m.choices.Count,
+		Speed:         m.speed,
+	}
+}
+
+// checkbox renders a boolean option
+func checkbox(on bool) string {
+	if on {
+		return "[x]"
+	}
+	return "[ ]"
+}
@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func wizardKeys(m WizardModel, msgs ...tea.Msg) WizardModel {
+	for _, msg := range msgs {
+		next, _ := m.Update(msg)
+		m = next.(WizardModel)
+	}
+	return m
+}
+
+func runes(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}
+}
+
+func TestWizardModelTyping(t *testing.T) {
+	m := NewWizardModel(WizardChoices{Keystore: true}, nil)
+	m = wizardKeys(m, runes("AbXz1"), tea.KeyMsg{Type: tea.KeyBackspace})
+
+	choices, _ := m.Result()
+	if choices.Prefix != "ab" {
+		t.Errorf("prefix should keep lowercased hex characters only, got %q", choices.Prefix)
+	}
+
+	m = wizardKeys(m, WizardSpeedMsg{Speed: 1000})
+	view := m.View()
+	if !strings.Contains(view, "256") || !strings.Contains(view, "50%") {
+		t.Errorf("preview should show the difficulty and ETA:\n%s", view)
+	}
+}
+
+func TestWizardModelOptions(t *testing.T) {
+	m := NewWizardModel(WizardChoices{}, nil)
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	space := tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+
+	// The case-sensitive row only appears once checksum is on
+	m = wizardKeys(m, runes("cafe"), down, down)
+	if m.focus != fieldChecksum {
+		t.Fatalf("focus = %v, want checksum", m.focus)
+	}
+	m = wizardKeys(m, down)
+	if m.focus != fieldCount {
+		t.Errorf("case-sensitive row should be skipped while checksum is off, focus = %v", m.focus)
+	}
+	m = wizardKeys(m, tea.KeyMsg{Type: tea.KeyUp}, space, down, space, down, tea.KeyMsg{Type: tea.KeyBackspace}, runes("3"))
+
+	choices, _ := m.Result()
+	if !choices.Checksum || !choices.CaseSensitive || choices.Count != 3 {
+		t.Errorf("choices = %+v", choices)
+	}
+}
+
+func TestWizardModelFinish(t *testing.T) {
+	m := NewWizardModel(WizardChoices{}, nil)
+	m.focus = fieldRun
+	m = wizardKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if _, action := m.Result(); action != WizardCancel || m.problem == "" {
+		t.Errorf("running without a pattern should be refused, action = %v", action)
+	}
+
+	m.choices.Suffix = "beef"
+	m.focus = fieldPrint
+	next, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, action := next.(WizardModel).Result(); action != WizardPrint || cmd == nil {
+		t.Errorf("enter on print should quit with WizardPrint, got %v", action)
+	}
+}
@@ -0,0 +1,361 @@
+// Package update finds the latest GitHub release of bloco-eth, verifies its archive
+// against the signed checksums file and replaces the running binary with it
+package update
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Repository is the GitHub repository releases are published to
+const Repository = "italoag/bloco-wallet-generator"
+
+// BinaryName is the executable inside each release archive
+const BinaryName = "bloco-eth"
+
+// ChecksumsFile and SignatureFile are the release assets the archives are verified with
+const (
+	ChecksumsFile = "checksums.txt"
+	SignatureFile = "checksums.txt.sig"
+)
+
+// ReleaseKey is the base64 Ed25519 public key that signs checksums.txt, set by
+// release builds with -ldflags "-X bloco-eth/internal/update.ReleaseKey=..."
+var ReleaseKey = ""
+
+// maxDownload bounds every download, well above the size of a release archive
+const maxDownload = 256 << 20
+
+// Asset is a file attached to a release
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a published GitHub release
+type Release struct {
+	Tag        string  `json:"tag_name"`
+	URL        string  `json:"html_url"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
+}
+
+// Asset returns the release asset called name
+func (r *Release) Asset(name string) (Asset, bool) {
+	for _, asset := range r.Assets {
+		if asset.Name == name {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
+// Client talks to the GitHub releases API
+type Client struct {
+	BaseURL string // API root, https://api.github.com unless testing
+	HTTP    *http.Client
+}
+
+// NewClient creates a client for the public GitHub API
+func NewClient(timeout time.Duration) *Client {
+	return &Client{BaseURL: "https://api.github.com", HTTP: &http.Client{Timeout: timeout}}
+}
+
+// Release returns the release tagged tag, or the latest one when tag is empty
+func (c *Client) Release(ctx context.Context, tag string) (*Release, error) {
+	path := "/repos/" + Repository + "/releases/latest"
+	if tag != "" {
+		path = "/repos/" + Repository + "/releases/tags/" + tag
+	}
+	data, err := c.get(ctx, c.BaseURL+path, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+	var release Release
+	if err := json.Unmarshal(data, &release); err != nil {
+		return nil, fmt.Errorf("invalid release response: %w", err)
+	}
+	if release.Tag == "" {
+		return nil, fmt.Errorf("release response has no tag")
+	}
+	return &release, nil
+}
+
+// Download returns the content of a release asset
+func (c *Client) Download(ctx context.Context, asset Asset) ([]byte, error) {
+	return c.get(ctx, asset.URL, "application/octet-stream")
+}
+
+func (c *Client) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", BinaryName+"-update")
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownload+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDownload {
+		return nil, fmt.Errorf("GET %s: larger than %d bytes", url, maxDownload)
+	}
+	return data, nil
+}
+
+// ErrVerification marks a release that failed its signature or checksum check
+var ErrVerification = errors.New("release verification failed")
+
+// Install downloads the archive of release for goos/goarch, checks it against the
+// checksums file signed with key and replaces the executable at exe with its
+// binary. An empty key skips the signature check.
+func (c *Client) Install(ctx context.Context, release *Release, exe, goos, goarch, key string) error {
+	archiveName := ArchiveName(release.Tag, goos, goarch)
+	archiveAsset, ok := release.Asset(archiveName)
+	if !ok {
+		return fmt.Errorf("release %s has no %s for %s/%s", release.Tag, archiveName, goos, goarch)
+	}
+	checksumsAsset, ok := release.Asset(ChecksumsFile)
+	if !ok {
+		return fmt.Errorf("release %s has no %s", release.Tag, ChecksumsFile)
+	}
+	checksums, err := c.Download(ctx, checksumsAsset)
+	if err != nil {
+		return err
+	}
+	if key != "" {
+		signatureAsset, ok := release.Asset(SignatureFile)
+		if !ok {
+			return fmt.Errorf("%w: release %s has no %s", ErrVerification, release.Tag, SignatureFile)
+		}
+		signature, err := c.Download(ctx, signatureAsset)
+		if err != nil {
+			return err
+		}
+		if err := VerifySignature(checksums, signature, key); err != nil {
+			return fmt.Errorf("%w: %w", ErrVerification, err)
+		}
+	}
+
+	archive, err := c.Download(ctx, archiveAsset)
+	if err != nil {
+		return err
+	}
+	if err := VerifyChecksum(checksums, archive, archiveName); err != nil {
+		return fmt.Errorf("%w: %w", ErrVerification, err)
+	}
+	binary, err := ExtractBinary(archive)
+	if err != nil {
+		return err
+	}
+	return Replace(exe, binary)
+}
+
+// ArchiveName is the release asset holding the binary for a platform
+func ArchiveName(tag, goos, goarch string) string {
+	return fmt.Sprintf("%s-%s-%s-%s.tar.gz", BinaryName, tag, goos, goarch)
+}
+
+// VerifySignature checks the Ed25519 signature of the checksums file with the
+// base64 public key
+func VerifySignature(checksums, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid release public key")
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), checksums, signature) {
+		return fmt.Errorf("%s signature does not match the release key", ChecksumsFile)
+	}
+	return nil
+}
+
+// VerifyChecksum checks data against its line in a sha256sum checksums file
+func VerifyChecksum(checksums, data []byte, name string) error {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(fields[0], hex.EncodeToString(sum[:])) {
+			return fmt.Errorf("%s: SHA-256 %x does not match %s", name, sum, fields[0])
+		}
+		return nil
+	}
+	return fmt.Errorf("%s is not listed in %s", name, ChecksumsFile)
+}
+
+// ExtractBinary returns the executable from a release archive
+func ExtractBinary(archive []byte) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("archive has no %s binary", BinaryName)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == BinaryName {
+			return io.ReadAll(io.LimitReader(tr, maxDownload))
+		}
+	}
+}
+
+// Replace swaps the executable at path for binary. The new file is written next to
+// it and renamed over it, so a failed write leaves the old binary in place.
+func Replace(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".new-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm() | 0111); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if runtime.GOOS != "windows" {
+		return os.Rename(tmp.Name(), path)
+	}
+	// Windows cannot replace a running executable, but it can rename it; the old
+	// one is removed by the next update
+	old := path + ".old"
+	_ = os.Remove(old)
+	if err := os.Rename(path, old); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Rename(old, path)
+		return err
+	}
+	return nil
+}
+
+// ManagedBy names the package manager that installed the executable at path, or ""
+func ManagedBy(path string) string {
+	slashed := strings.ReplaceAll(strings.ToLower(path), `\`, "/")
+	switch {
+	case strings.Contains(slashed, "/cellar/") || strings.Contains(slashed, "/homebrew/") || strings.Contains(slashed, "/linuxbrew/"):
+		return "Homebrew"
+	case strings.Contains(slashed, "/scoop/apps/") || strings.Contains(slashed, "/scoop/shims/"):
+		return "Scoop"
+	}
+	return ""
+}
+
+// UpgradeCommand is the command that upgrades an install of the package manager
+func UpgradeCommand(manager string) string {
+	switch manager {
+	case "Homebrew":
+		return "brew upgrade " + BinaryName
+	case "Scoop":
+		return "scoop update " + BinaryName
+	}
+	return ""
+}
+
+// Compare orders two versions such as v1.4.0 and 1.5.0-rc.1: -1, 0 or 1. A
+// pre-release sorts before its release; a version that does not parse, such as dev,
+// sorts before every release.
+func Compare(a, b string) int {
+	pa, oka := parseVersion(a)
+	pb, okb := parseVersion(b)
+	if !oka || !okb {
+		return boolCompare(oka, okb)
+	}
+	for i := range pa.numbers {
+		if pa.numbers[i] != pb.numbers[i] {
+			if pa.numbers[i] < pb.numbers[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case pa.pre == pb.pre:
+		return 0
+	case pa.pre == "":
+		return 1
+	case pb.pre == "":
+		return -1
+	case pa.pre < pb.pre:
+		return -1
+	}
+	return 1
+}
+
+type version struct {
+	numbers [3]int
+	pre     string
+}
+
+func parseVersion(s string) (version, bool) {
+	var v version
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	s, _, _ = strings.Cut(s, "+")
+	s, v.pre, _ = strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v.numbers[i] = n
+	}
+	return v, true
+}
+
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
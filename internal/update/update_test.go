@@ -0,0 +1,161 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRelease serves a release of tag with assets at name, as the GitHub API would
+func fakeRelease(t *testing.T, tag string, assets map[string][]byte) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	release := Release{Tag: tag, URL: server.URL + "/releases/" + tag}
+	for name, data := range assets {
+		release.Assets = append(release.Assets, Asset{Name: name, URL: server.URL + "/download/" + name})
+		mux.HandleFunc("/download/"+name, func(w http.ResponseWriter, r *http.Request) { w.Write(data) })
+	}
+	mux.HandleFunc("/repos/"+Repository+"/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(release)
+	})
+	client := NewClient(10 * time.Second)
+	client.BaseURL = server.URL
+	return client
+}
+
+// archive packs binary as a release archive
+func archive(t *testing.T, binary []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: BinaryName, Mode: 0755, Size: int64(len(binary)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(binary)
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
+
+func TestInstall(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := base64.StdEncoding.EncodeToString(public)
+	name := ArchiveName("v1.5.0", "linux", "amd64")
+	release := archive(t, []byte("new binary"))
+	sum := sha256.Sum256(release)
+	checksums := []byte(fmt.Sprintf("%x  %s\n%x  other.tar.gz\n", sum, name, sha256.Sum256(nil)))
+
+	tests := []struct {
+		name    string
+		assets  map[string][]byte
+		key     string
+		wantErr error
+	}{
+		{"signed", map[string][]byte{name: release, ChecksumsFile: checksums, SignatureFile: ed25519.Sign(private, checksums)}, key, nil},
+		{"no key", map[string][]byte{name: release, ChecksumsFile: checksums}, "", nil},
+		{"unsigned", map[string][]byte{name: release, ChecksumsFile: checksums}, key, ErrVerification},
+		{"forged signature", map[string][]byte{name: release, ChecksumsFile: checksums, SignatureFile: ed25519.Sign(private, []byte("other"))}, key, ErrVerification},
+		{"tampered archive", map[string][]byte{name: archive(t, []byte("evil")), ChecksumsFile: checksums, SignatureFile: ed25519.Sign(private, checksums)}, key, ErrVerification},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fakeRelease(t, "v1.5.0", tt.assets)
+			exe := filepath.Join(t.TempDir(), BinaryName)
+			if err := os.WriteFile(exe, []byte("old binary"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := client.Release(context.Background(), "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = client.Install(context.Background(), r, exe, "linux", "amd64", tt.key)
+			data, _ := os.ReadFile(exe)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("Install() returned error: %v", err)
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+				t.Fatalf("Install() error = %v, want %v", err, tt.wantErr)
+			case tt.wantErr == nil && string(data) != "new binary":
+				t.Errorf("binary = %q after the update", data)
+			case tt.wantErr != nil && string(data) != "old binary":
+				t.Errorf("binary = %q after a failed update, want it untouched", data)
+			}
+			if info, err := os.Stat(exe); err != nil || info.Mode().Perm()&0100 == 0 {
+				t.Errorf("binary is not executable after the update: %v", err)
+			}
+		})
+	}
+}
+
+func TestInstall_NoArchiveForPlatform(t *testing.T) {
+	client := fakeRelease(t, "v1.5.0", map[string][]byte{ChecksumsFile: nil})
+	r, err := client.Release(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = client.Install(context.Background(), r, filepath.Join(t.TempDir(), BinaryName), "plan9", "386", "")
+	if err == nil || !strings.Contains(err.Error(), "plan9/386") {
+		t.Errorf("Install() error = %v, want a missing archive for plan9/386", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.4.0", "v1.4.0", 0},
+		{"1.4.0", "v1.4.0", 0},
+		{"v1.4.0", "v1.5.0", -1},
+		{"v1.10.0", "v1.9.3", 1},
+		{"v2", "v1.9.9", 1},
+		{"v1.5.0-rc.1", "v1.5.0", -1},
+		{"v1.5.0-rc.1", "v1.5.0-rc.2", -1},
+		{"v1.5.0+build.7", "v1.5.0", 0},
+		{"dev", "v0.0.1", -1},
+		{"dev", "dev", 0},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestManagedBy(t *testing.T) {
+	tests := map[string]string{
+		"/opt/homebrew/Cellar/bloco-eth/1.4.0/bin/bloco-eth":     "Homebrew",
+		"/home/linuxbrew/.linuxbrew/bin/bloco-eth":               "Homebrew",
+		`C:\Users\op\scoop\apps\bloco-eth\current\bloco-eth.exe`: "Scoop",
+		"/usr/local/bin/bloco-eth":                               "",
+		"/home/op/go/bin/bloco-eth":                              "",
+	}
+	for path, want := range tests {
+		if got := ManagedBy(path); got != want {
+			t.Errorf("ManagedBy(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
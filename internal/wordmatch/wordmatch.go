@@ -0,0 +1,120 @@
+// Package wordmatch finds any of a set of words in a string with an Aho-Corasick
+// automaton, in one pass over the string however many words the set holds. Matching
+// ignores ASCII case.
+package wordmatch
+
+// Matcher finds the words of a set in strings. It is safe for concurrent use, and a
+// nil Matcher has no words.
+type Matcher struct {
+	// class maps a byte to its column in next, or -1 for bytes in no word
+	class [256]int16
+	width int
+	// next is the transition table, width columns per state; state 0 is the root
+	next []int32
+	// found is the index of a word ending at each state, directly or through its
+	// suffixes, or -1
+	found []int32
+	words []string
+}
+
+// New builds a matcher for words; empty words are ignored
+func New(words []string) *Matcher {
+	m := &Matcher{words: words}
+	for i := range m.class {
+		m.class[i] = -1
+	}
+	for _, word := range words {
+		for i := 0; i < len(word); i++ {
+			if c := fold(word[i]); m.class[c] < 0 {
+				m.class[c] = int16(m.width)
+				m.width++
+			}
+		}
+	}
+	for c := 'A'; c <= 'Z'; c++ {
+		m.class[c] = m.class[c+'a'-'A']
+	}
+
+	// The trie of the words, with 0 for missing edges
+	m.addState()
+	for index, word := range words {
+		state := int32(0)
+		for i := 0; i < len(word); i++ {
+			column := int(state)*m.width + int(m.class[word[i]])
+			if m.next[column] == 0 {
+				// addState may move next, so it runs before the assignment
+				child := m.addState()
+				m.next[column] = child
+			}
+			state = m.next[column]
+		}
+		if state != 0 && m.found[state] < 0 {
+			m.found[state] = int32(index)
+		}
+	}
+
+	// Breadth first, every missing edge takes the edge of the longest proper
+	// suffix, which makes the trie a DFA
+	fail := make([]int32, len(m.found))
+	queue := make([]int32, 0, len(m.found))
+	for c := 0; c < m.width; c++ {
+		if child := m.next[c]; child != 0 {
+			queue = append(queue, child)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		if m.found[state] < 0 {
+			m.found[state] = m.found[fail[state]]
+		}
+		row, failRow := int(state)*m.width, int(fail[state])*m.width
+		for c := 0; c < m.width; c++ {
+			child := m.next[row+c]
+			if child == 0 {
+				m.next[row+c] = m.next[failRow+c]
+				continue
+			}
+			fail[child] = m.next[failRow+c]
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+func (m *Matcher) addState() int32 {
+	for c := 0; c < m.width; c++ {
+		m.next = append(m.next, 0)
+	}
+	m.found = append(m.found, -1)
+	return int32(len(m.found) - 1)
+}
+
+// Find returns the longest word of the set that ends first in s, or "" when s
+// contains none
+func (m *Matcher) Find(s string) string {
+	if m == nil || len(m.found) == 1 {
+		return ""
+	}
+	state := int32(0)
+	for i := 0; i < len(s); i++ {
+		c := m.class[s[i]]
+		if c < 0 {
+			state = 0
+			continue
+		}
+		state = m.next[int(state)*m.width+int(c)]
+		if word := m.found[state]; word >= 0 {
+			return m.words[word]
+		}
+	}
+	return ""
+}
+
+// fold lowercases an ASCII letter
+func fold(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
@@ -0,0 +1,103 @@
+package wordmatch
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	m := New([]string{"dead", "beef", "eefa", "", "c0ffee", "ad"})
+	tests := []struct {
+		s, want string
+	}{
+		{"0000dead0000", "dead"},
+		{"0000xad", "ad"},
+		{"00BEEF00", "beef"},
+		{"xbeefa", "beef"},
+		{"bee0fa", ""},
+		{"c0c0ffee", "c0ffee"},
+		{"", ""},
+		{"zzzz", ""},
+	}
+	for _, tt := range tests {
+		if got := m.Find(tt.s); got != tt.want {
+			t.Errorf("Find(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+
+	var none *Matcher
+	if got := none.Find("dead"); got != "" {
+		t.Errorf("nil Find() = %q", got)
+	}
+	if got := New(nil).Find("dead"); got != "" {
+		t.Errorf("empty Find() = %q", got)
+	}
+}
+
+// TestFind_Naive compares the automaton with a strings.Contains loop over random
+// words and addresses
+func TestFind_Naive(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		words := randomWords(r, 1+r.Intn(200), 2, 5)
+		m := New(words)
+		for i := 0; i < 200; i++ {
+			address := randomHex(r, 40)
+			got := m.Find(address)
+			want := naiveFind(words, address)
+			if (got == "") != (want == "") || (got != "" && !strings.Contains(address, got)) {
+				t.Fatalf("Find(%q) = %q, naive loop found %q", address, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkFind(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	addresses := make([]string, 1024)
+	for i := range addresses {
+		addresses[i] = randomHex(r, 40)
+	}
+	for _, count := range []int{10, 100, 1000, 10000} {
+		words := randomWords(r, count, 6, 8)
+		m := New(words)
+		b.Run(fmt.Sprintf("Automaton/%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m.Find(addresses[i%len(addresses)])
+			}
+		})
+		b.Run(fmt.Sprintf("Naive/%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				naiveFind(words, addresses[i%len(addresses)])
+			}
+		})
+	}
+}
+
+func naiveFind(words []string, s string) string {
+	for _, word := range words {
+		if word != "" && strings.Contains(s, word) {
+			return word
+		}
+	}
+	return ""
+}
+
+func randomWords(r *rand.Rand, count, minLength, maxLength int) []string {
+	words := make([]string, count)
+	for i := range words {
+		words[i] = randomHex(r, minLength+r.Intn(maxLength-minLength+1))
+	}
+	return words
+}
+
+func randomHex(r *rand.Rand, length int) string {
+	const digits = "0123456789abcdef"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = digits[r.Intn(len(digits))]
+	}
+	return string(b)
+}
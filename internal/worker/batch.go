@@ -0,0 +1,194 @@
+package worker
+
+import (
+	"fmt"
+	"time"
+)
+
+// Batch strategy names accepted by NewBatchStrategy
+const (
+	BatchFixed           = "fixed"
+	BatchAdaptiveLatency = "adaptive-latency"
+	BatchThroughputMax   = "throughput-max"
+)
+
+// BatchStrategies lists the strategy names in the order shown to users
+var BatchStrategies = []string{BatchFixed, BatchAdaptiveLatency, BatchThroughputMax}
+
+// DefaultCancelLatency is how soon workers stop after their search is cancelled
+const DefaultCancelLatency = 250 * time.Millisecond
+
+// cancelChecksPerBound is how many cancellation checks a worker makes per cancel
+// latency bound, leaving room for the attempt in progress to finish
+const cancelChecksPerBound = 10
+
+// throughputMaxCeiling bounds how long a throughput-max batch may run
+const throughputMaxCeiling = time.Second
+
+// BatchStrategy sizes the batches of attempts a worker makes between check-ins.
+// At each check-in the worker reports its stats, so small batches mean smooth
+// progress and large batches less overhead. Cancellation is checked separately,
+// within the pool's cancel latency whatever the batch size.
+type BatchStrategy interface {
+	// Name is the --batch-strategy value of the strategy
+	Name() string
+	// Initial is the size of a worker's first batch
+	Initial() int
+	// Next is the size of the batch following one of size attempts that took elapsed
+	Next(size int, elapsed time.Duration) int
+}
+
+// FixedBatch always uses the same batch size
+type FixedBatch struct {
+	Size int
+}
+
+func (b FixedBatch) Name() string                { return BatchFixed }
+func (b FixedBatch) Initial() int                { return b.Size }
+func (b FixedBatch) Next(int, time.Duration) int { return b.Size }
+
+// AdaptiveLatencyBatch resizes batches so each takes about Target, keeping
+// check-ins evenly spaced however fast the pattern is to test. A batch changes
+// by at most a factor of two at a time, so progress does not jitter.
+type AdaptiveLatencyBatch struct {
+	Target   time.Duration
+	Min, Max int
+}
+
+func (b AdaptiveLatencyBatch) Name() string { return BatchAdaptiveLatency }
+func (b AdaptiveLatencyBatch) Initial() int { return b.Min }
+
+func (b AdaptiveLatencyBatch) Next(size int, elapsed time.Duration) int {
+	next := size * 2
+	if elapsed > 0 {
+		next = int(float64(size) * float64(b.Target) / float64(elapsed))
+	}
+	return clampBatch(next, max(size/2, b.Min), min(size*2, b.Max))
+}
+
+// ThroughputMaxBatch doubles batches while they finish within half the ceiling,
+// paying as little check-in overhead as possible, and halves one that overruns it
+type ThroughputMaxBatch struct {
+	Min     int
+	Ceiling time.Duration
+}
+
+func (b ThroughputMaxBatch) Name() string { return BatchThroughputMax }
+func (b ThroughputMaxBatch) Initial() int { return b.Min }
+
+func (b ThroughputMaxBatch) Next(size int, elapsed time.Duration) int {
+	switch {
+	case elapsed > b.Ceiling:
+		return max(size/2, b.Min)
+	case elapsed < b.Ceiling/2:
+		return size * 2
+	default:
+		return size
+	}
+}
+
+// clampBatch limits size to [lo, hi]
+func clampBatch(size, lo, hi int) int {
+	return max(lo, min(size, hi))
+}
+
+// NewBatchStrategy returns the named strategy. fixed uses maxSize; the adaptive
+// strategies start at minSize, and adaptive-latency aims for batches of target.
+func NewBatchStrategy(name string, target time.Duration, minSize, maxSize int) (BatchStrategy, error) {
+	if minSize <= 0 || maxSize < minSize {
+		return nil, fmt.Errorf("invalid batch size bounds %d-%d", minSize, maxSize)
+	}
+	switch name {
+	case BatchFixed:
+		return FixedBatch{Size: maxSize}, nil
+	case BatchAdaptiveLatency:
+		if target <= 0 {
+			return nil, fmt.Errorf("adaptive-latency needs a positive target batch duration")
+		}
+		return AdaptiveLatencyBatch{Target: target, Min: minSize, Max: maxSize}, nil
+	case BatchThroughputMax:
+		return ThroughputMaxBatch{Min: minSize, Ceiling: throughputMaxCeiling}, nil
+	default:
+		return nil, fmt.Errorf("unknown batch strategy %q (use fixed, adaptive-latency or throughput-max)", name)
+	}
+}
+
+// defaultBatchStrategy checks in about as often as workers have always reported stats
+func defaultBatchStrategy() BatchStrategy {
+	return AdaptiveLatencyBatch{Target: statsUpdateInterval, Min: 100, Max: 10 * statsUpdateAttempts}
+}
+
+// SetBatchStrategy sets how workers size their batches; nil restores the default
+func (p *Pool) SetBatchStrategy(strategy BatchStrategy) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.batchStrategy = strategy
+}
+
+// SetCancelLatency bounds how long workers keep running after their search is
+// cancelled; zero restores DefaultCancelLatency
+func (p *Pool) SetCancelLatency(bound time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cancelLatency = bound
+}
+
+// cancelLatencyOrDefault returns the cancel latency bound searches use
+func (p *Pool) cancelLatencyOrDefault() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.cancelLatency <= 0 {
+		return DefaultCancelLatency
+	}
+	return p.cancelLatency
+}
+
+// batchStrategyOrDefault returns the strategy searches use
+func (p *Pool) batchStrategyOrDefault() BatchStrategy {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.batchStrategy == nil {
+		return defaultBatchStrategy()
+	}
+	return p.batchStrategy
+}
+
+// batchClock tracks one worker's batches and tells it when to check in. It also
+// spaces the worker's cancellation checks, which are independent of the batch
+// size, so that they come at least cancelChecksPerBound times per bound.
+type batchClock struct {
+	strategy BatchStrategy
+	bound    time.Duration
+	size     int
+	done     int
+	stride   int
+	started  time.Time
+}
+
+func newBatchClock(strategy BatchStrategy, bound time.Duration, now time.Time) *batchClock {
+	return &batchClock{strategy: strategy, bound: bound, size: max(strategy.Initial(), 1), stride: 1, started: now}
+}
+
+// cancelDue reports whether the worker should check for cancellation before its next attempt
+func (c *batchClock) cancelDue() bool {
+	return c.done%c.stride == 0
+}
+
+// tick counts one attempt and reports whether the batch is complete
+func (c *batchClock) tick() bool {
+	c.done++
+	return c.done >= c.size
+}
+
+// checkIn sizes the next batch, and the attempts between cancellation checks,
+// from how long this one took. Until the first check-in every attempt is checked.
+func (c *batchClock) checkIn(now time.Time) {
+	elapsed := now.Sub(c.started)
+	if c.bound > 0 && elapsed > 0 {
+		perCheck := float64(c.bound / cancelChecksPerBound)
+		c.stride = max(int(perCheck*float64(c.done)/float64(elapsed)), 1)
+	}
+	c.size = max(c.strategy.Next(c.size, elapsed), 1)
+	c.done = 0
+	c.started = now
+}
@@ -0,0 +1,145 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestAdaptiveLatencyBatch_Next(t *testing.T) {
+	b := AdaptiveLatencyBatch{Target: 100 * time.Millisecond, Min: 100, Max: 10000}
+	tests := []struct {
+		name    string
+		size    int
+		elapsed time.Duration
+		want    int
+	}{
+		{"on target", 1000, 100 * time.Millisecond, 1000},
+		{"fast batches grow at most twofold", 1000, time.Millisecond, 2000},
+		{"slow batches shrink at most by half", 1000, time.Second, 500},
+		{"close to target", 1000, 80 * time.Millisecond, 1250},
+		{"no measurement", 1000, 0, 2000},
+		{"capped", 8000, time.Millisecond, 10000},
+		{"floored", 150, time.Second, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Next(tt.size, tt.elapsed); got != tt.want {
+				t.Errorf("Next(%d, %v) = %d, want %d", tt.size, tt.elapsed, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThroughputMaxBatch_Next(t *testing.T) {
+	b := ThroughputMaxBatch{Min: 100, Ceiling: time.Second}
+	if got := b.Next(4000, 100*time.Millisecond); got != 8000 {
+		t.Errorf("a short batch should double, got %d", got)
+	}
+	if got := b.Next(4000, 700*time.Millisecond); got != 4000 {
+		t.Errorf("a batch within the ceiling should hold, got %d", got)
+	}
+	if got := b.Next(4000, 2*time.Second); got != 2000 {
+		t.Errorf("a batch over the ceiling should halve, got %d", got)
+	}
+	if got := b.Next(150, 2*time.Second); got != 100 {
+		t.Errorf("batches should not shrink below Min, got %d", got)
+	}
+}
+
+func TestNewBatchStrategy(t *testing.T) {
+	for _, name := range BatchStrategies {
+		s, err := NewBatchStrategy(name, 50*time.Millisecond, 100, 10000)
+		if err != nil || s.Name() != name {
+			t.Errorf("NewBatchStrategy(%q) = %v, %v", name, s, err)
+		}
+	}
+	if s, _ := NewBatchStrategy(BatchFixed, 0, 100, 5000); s.Initial() != 5000 || s.Next(5000, time.Hour) != 5000 {
+		t.Errorf("fixed batches should always be the max size, got %+v", s)
+	}
+	for _, tt := range []struct {
+		name     string
+		target   time.Duration
+		min, max int
+		want     string
+	}{
+		{"greedy", time.Second, 100, 1000, "unknown batch strategy"},
+		{BatchAdaptiveLatency, 0, 100, 1000, "positive target"},
+		{BatchFixed, time.Second, 0, 1000, "bounds"},
+		{BatchFixed, time.Second, 1000, 100, "bounds"},
+	} {
+		if _, err := NewBatchStrategy(tt.name, tt.target, tt.min, tt.max); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("NewBatchStrategy(%q, %v, %d, %d) error = %v, want %q", tt.name, tt.target, tt.min, tt.max, err, tt.want)
+		}
+	}
+}
+
+func TestPool_BatchStrategy(t *testing.T) {
+	pool := NewPool(1, "ethereum")
+	pool.SetBatchStrategy(FixedBatch{Size: 50})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := pool.GenerateWalletWithContext(ctx, wallet.GenerationCriteria{Prefix: "ffffffff"})
+		done <- err
+	}()
+
+	// Stats are reported once per batch
+	for want := int64(50); want <= 100; want += 50 {
+		select {
+		case stats := <-pool.statsChan:
+			if stats.Attempts != want {
+				t.Errorf("stats after %d attempts, want %d", stats.Attempts, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("no stats reported")
+		}
+	}
+	cancel()
+	if err := <-done; err == nil {
+		t.Error("expected a cancellation error")
+	}
+}
+
+func TestPool_CancelLatency(t *testing.T) {
+	const bound = 50 * time.Millisecond
+	tests := []struct {
+		name     string
+		strategy BatchStrategy
+		criteria wallet.GenerationCriteria
+		run      time.Duration
+	}{
+		{"huge fixed batches", FixedBatch{Size: 1 << 30}, wallet.GenerationCriteria{Prefix: "ffffffff"}, 200 * time.Millisecond},
+		{"grown throughput-max batches", ThroughputMaxBatch{Min: 100, Ceiling: time.Second}, wallet.GenerationCriteria{Prefix: "ffffffff"}, time.Second},
+		{"slow mnemonic attempts", FixedBatch{Size: 1 << 30}, wallet.GenerationCriteria{Prefix: "ffffffff", UseMnemonic: true}, 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := NewPool(2, "ethereum")
+			pool.SetBatchStrategy(tt.strategy)
+			pool.SetCancelLatency(bound)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				_, err := pool.GenerateWalletWithContext(ctx, tt.criteria)
+				done <- err
+			}()
+			time.Sleep(tt.run)
+
+			cancelled := time.Now()
+			cancel()
+			if err := <-done; err == nil {
+				t.Fatal("expected a cancellation error")
+			}
+			// The search returns once every worker has stopped; allow for a loaded machine
+			if latency := time.Since(cancelled); latency > bound+100*time.Millisecond {
+				t.Errorf("workers stopped %v after cancellation, want within %v", latency, bound)
+			}
+		})
+	}
+}
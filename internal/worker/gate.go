@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+// Gate pauses the workers of every search under it at the end of their current
+// batch, and lets them go on where they stopped once it is resumed. A nil Gate is
+// always open.
+type Gate struct {
+	mu      sync.Mutex
+	paused  bool
+	changed chan struct{} // closed and replaced on every resume
+}
+
+// NewGate returns an open gate
+func NewGate() *Gate {
+	return &Gate{changed: make(chan struct{})}
+}
+
+// Pause parks the workers under the gate
+func (g *Gate) Pause() {
+	g.mu.Lock()
+	g.paused = true
+	g.mu.Unlock()
+}
+
+// Resume wakes the workers parked by Pause
+func (g *Gate) Resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.paused {
+		return
+	}
+	g.paused = false
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// Paused reports whether the gate holds its workers
+func (g *Gate) Paused() bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
+
+// wait blocks while the gate is paused, and reports false if ctx ends first
+func (g *Gate) wait(ctx context.Context) bool {
+	if g == nil {
+		return true
+	}
+	for {
+		g.mu.Lock()
+		paused, changed := g.paused, g.changed
+		g.mu.Unlock()
+		if !paused {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// gateKey is the context key of the Gate a search runs under
+type gateKey struct{}
+
+// WithGate returns a context whose searches pause while gate is paused
+func WithGate(ctx context.Context, gate *Gate) context.Context {
+	return context.WithValue(ctx, gateKey{}, gate)
+}
+
+// gateFrom returns the Gate of ctx, or nil
+func gateFrom(ctx context.Context) *Gate {
+	gate, _ := ctx.Value(gateKey{}).(*Gate)
+	return gate
+}
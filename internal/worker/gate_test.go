@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestGate_PauseResume(t *testing.T) {
+	gate := NewGate()
+	if !gate.wait(context.Background()) {
+		t.Fatal("wait() = false on an open gate")
+	}
+
+	gate.Pause()
+	waited := make(chan bool)
+	go func() { waited <- gate.wait(context.Background()) }()
+	select {
+	case <-waited:
+		t.Fatal("a worker passed a paused gate")
+	case <-time.After(20 * time.Millisecond):
+	}
+	gate.Resume()
+	select {
+	case ok := <-waited:
+		if !ok {
+			t.Error("wait() = false, want true")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the worker stayed parked after Resume")
+	}
+
+	gate.Pause()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if gate.wait(ctx) {
+		t.Error("wait() = true on a paused gate after cancellation")
+	}
+
+	var none *Gate
+	if none.Paused() || !none.wait(ctx) {
+		t.Error("a nil Gate is not open")
+	}
+}
+
+func TestPool_GenerateWalletWithContext_Gate(t *testing.T) {
+	pool := NewPool(2, "ethereum")
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	gate := NewGate()
+	gate.Pause()
+	ctx, cancel := context.WithTimeout(WithGate(context.Background(), gate), 10*time.Second)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := pool.GenerateWalletWithContext(ctx, wallet.GenerationCriteria{Prefix: "ab"})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("the search ran behind a paused gate: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if attempts := pool.GetStatsCollector().GetTotalAttempts(); attempts != 0 {
+		t.Errorf("attempts = %d while paused, want 0", attempts)
+	}
+
+	gate.Resume()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
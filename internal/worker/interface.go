@@ -16,6 +16,12 @@ type WorkerPool interface {
 	// GenerateWalletWithContext generates a single wallet with the given criteria
 	GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error)
 
+	// RunBenchmark generates addresses on all workers until the context is done
+	RunBenchmark(ctx context.Context, item WorkItem) (int64, error)
+
+	// IsRunning reports whether the pool is started
+	IsRunning() bool
+
 	// GetStatsCollector returns the statistics collector
 	GetStatsCollector() *StatsCollector
 }
@@ -0,0 +1,248 @@
+package worker
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/tracing"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// keyRangeBlockSize is the number of keys a worker claims from a key range at a time
+const keyRangeBlockSize = 4096
+
+// SetKeyRange makes the pool search the keys of cursor in order instead of random
+// keys. Only secp256k1 networks (Ethereum, Bitcoin) can be searched by range.
+func (p *Pool) SetKeyRange(cursor *crypto.KeyRangeCursor) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keyRange = cursor
+}
+
+// SetMasterSeed makes the offsets of the pool's key range counters of seed: the
+// key searched at each offset is derived from the seed instead of added to the
+// range start. Set the range with SetKeyRange(crypto.NewKeyRangeCursor(crypto.MasterSeedCounters)).
+func (p *Pool) SetMasterSeed(seed *crypto.MasterSeed) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.masterSeed = seed
+}
+
+// keyWalker steps through the keys of a claimed block
+type keyWalker interface {
+	Next()
+	PrivateKey() []byte
+	PublicKey() *btcec.PublicKey
+}
+
+// newKeyWalker starts a walk at offset of the cursor's range, or at that counter
+// of seed when one is set
+func newKeyWalker(cursor *crypto.KeyRangeCursor, seed *crypto.MasterSeed, offset *big.Int) keyWalker {
+	if seed != nil {
+		return seed.Walker(offset.Uint64())
+	}
+	return crypto.NewKeyWalker(cursor.Range().KeyAt(offset), cursor.Range().Stride)
+}
+
+// searchKeyRange finds the next key of the pool's key range matching criteria.
+// Workers claim blocks of the range and walk each block with point additions, or
+// with a derivation per key from a master seed.
+func (p *Pool) searchKeyRange(ctx context.Context, cursor *crypto.KeyRangeCursor, seed *crypto.MasterSeed, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	ctx, span := tracing.Start(ctx, "wallet.generate",
+		tracing.String("pattern.prefix", criteria.Prefix),
+		tracing.String("pattern.suffix", criteria.Suffix),
+		tracing.Bool("pattern.checksum", criteria.IsChecksum),
+		tracing.String("network", criteria.Network),
+		tracing.String("key_range", cursor.Range().String()),
+		tracing.Int("threads", p.threadCount))
+	defer span.End()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resultCh := make(chan *wallet.GenerationResult, 1)
+	errorCh := make(chan error, 1)
+	strategy, bound := p.batchStrategyOrDefault(), p.cancelLatencyOrDefault()
+	matcher := NewMatcher(criteria)
+	var wg sync.WaitGroup
+	for i := 0; i < p.threadCount; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			attempts := int64(0)
+			startTime := time.Now()
+			clock := newBatchClock(strategy, bound, startTime)
+
+			// The claimed block goes back to the cursor however the worker stops, so a
+			// worker that panics hands the keys it did not check to the next claim
+			var block *crypto.KeyBlock
+			var checked uint64
+			release := func(done uint64) {
+				if block != nil {
+					cursor.Release(block, done)
+					block = nil
+				}
+			}
+
+			p.superviseWorker(ctx, workerID, nil, errorCh, func() { release(checked) }, func() {
+				for {
+					claimed, ok := cursor.Claim(keyRangeBlockSize)
+					if !ok {
+						return
+					}
+					block = claimed
+					walker := newKeyWalker(cursor, seed, block.Offset)
+					for checked = 0; checked < claimed.Count; checked++ {
+						if clock.cancelDue() {
+							select {
+							case <-ctx.Done():
+								release(checked)
+								return
+							default:
+							}
+						}
+						if checked > 0 {
+							walker.Next()
+						}
+
+						address, err := crypto.AddressFromPublicKey(criteria.Network, walker.PublicKey())
+						if err != nil {
+							release(checked)
+							select {
+							case errorCh <- errors.NewGenerationError("search_key_range", "failed to derive address", err):
+							default:
+							}
+							return
+						}
+						attempts++
+						if clock.tick() {
+							now := time.Now()
+							p.sendWorkerStats(workerID, attempts, startTime, now)
+							clock.checkIn(now)
+							yieldWorker()
+						}
+						if !matcher.Matches(address) {
+							continue
+						}
+
+						release(checked + 1)
+						select {
+						case resultCh <- p.keyRangeResult(walker, address, criteria, attempts, startTime, workerID):
+						case <-ctx.Done():
+						}
+						return
+					}
+					release(claimed.Count)
+				}
+			})
+		}(i)
+	}
+
+	// Every worker releases its block before the search returns, so the cursor is
+	// settled for the next search and for checkpoints
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	stop := func() {
+		cancel()
+		<-finished
+	}
+
+	select {
+	case result := <-resultCh:
+		stop()
+		return p.keyRangeFound(span, result), nil
+	case err := <-errorCh:
+		stop()
+		span.RecordError(err)
+		return nil, err
+	case <-finished:
+		// The last block may have held a match
+		select {
+		case result := <-resultCh:
+			return p.keyRangeFound(span, result), nil
+		default:
+		}
+		err := errors.NewBlocoErrorWithCause(errors.ErrorTypeGeneration, "search_key_range",
+			fmt.Sprintf("no match left in key range %s", cursor.Range()), crypto.ErrKeyRangeExhausted)
+		span.RecordError(err)
+		return nil, err
+	case <-ctx.Done():
+		stop()
+		err := errors.NewCancellationError("generate_wallet", "generation cancelled")
+		span.RecordError(err)
+		return nil, err
+	}
+}
+
+// keyRangeResult builds the result for the walker's current key
+func (p *Pool) keyRangeResult(walker keyWalker, address string, criteria wallet.GenerationCriteria,
+	attempts int64, startTime time.Time, workerID int) *wallet.GenerationResult {
+	privateKey := walker.PrivateKey()
+	w := &wallet.Wallet{
+		Address:    address,
+		PrivateKey: hex.EncodeToString(privateKey),
+		Network:    criteria.Network,
+		CreatedAt:  time.Now(),
+	}
+	crypto.ClearSensitiveData(privateKey)
+	if seedWalker, ok := walker.(*crypto.SeedWalker); ok {
+		counter := seedWalker.Counter()
+		w.SeedCounter = &counter
+		w.SeedFingerprint = seedWalker.Fingerprint()
+	}
+	if criteria.Network == "ethereum" || criteria.Network == "" {
+		w.PublicKey = hex.EncodeToString(walker.PublicKey().SerializeUncompressed())
+		if criteria.IsChecksum {
+			w.Address = toChecksumAddress(address)
+		}
+	}
+	return &wallet.GenerationResult{
+		Wallet:   w,
+		Attempts: attempts,
+		Duration: time.Since(startTime),
+		WorkerID: workerID,
+	}
+}
+
+// keyRangeFound records a key range match
+func (p *Pool) keyRangeFound(span *tracing.Span, result *wallet.GenerationResult) *wallet.GenerationResult {
+	if p.statsCollector != nil {
+		p.statsCollector.RecordWalletFound()
+	}
+	span.SetAttributes(tracing.Int64("attempts", result.Attempts), tracing.Int("worker.id", result.WorkerID))
+	if p.logger != nil {
+		if err := p.logger.LogWalletGenerated(result.Wallet.Address, int(result.Attempts), result.Duration, result.WorkerID); err != nil {
+			fmt.Printf("Warning: Failed to log wallet: %v\n", err)
+		}
+	}
+	return result
+}
+
+// sendWorkerStats reports a worker's attempts to the stats collector without blocking
+func (p *Pool) sendWorkerStats(workerID int, attempts int64, startTime, now time.Time) {
+	var speed float64
+	if elapsed := now.Sub(startTime).Seconds(); elapsed > 0 {
+		speed = float64(attempts) / elapsed
+	}
+	select {
+	case p.statsChan <- WorkerStats{
+		WorkerID:   workerID,
+		Attempts:   attempts,
+		Speed:      speed,
+		LastUpdate: now,
+		IsHealthy:  true,
+	}:
+	default:
+	}
+}
@@ -0,0 +1,47 @@
+package worker
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestPool_SearchKeyRange(t *testing.T) {
+	// 0xd2c55 is the only key of the range with this address prefix
+	keyRange, err := crypto.ParseKeyRange("0xd0000:0xd4fff", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor := crypto.NewKeyRangeCursor(keyRange)
+	pool := NewPool(2, "ethereum")
+	pool.SetKeyRange(cursor)
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+	criteria := wallet.GenerationCriteria{Network: "ethereum", Prefix: "5abfc8"}
+
+	result, err := pool.GenerateWalletWithContext(ctx, criteria)
+	if err != nil {
+		t.Fatalf("GenerateWalletWithContext() error: %v", err)
+	}
+	if result.Wallet.Address != "0x5abfc823a8b0691ebada0a41cd6fab2f15a0a628" ||
+		result.Wallet.PrivateKey != "00000000000000000000000000000000000000000000000000000000000d2c55" {
+		t.Errorf("found %s with key %s", result.Wallet.Address, result.Wallet.PrivateKey)
+	}
+
+	// The rest of the range holds no other match
+	if _, err := pool.GenerateWalletWithContext(ctx, criteria); !stderrors.Is(err, crypto.ErrKeyRangeExhausted) {
+		t.Fatalf("second search error = %v, want ErrKeyRangeExhausted", err)
+	}
+	if !cursor.Exhausted() || cursor.Checked().Int64() != keyRange.Size().Int64() {
+		t.Errorf("checked %s of %s keys", cursor.Checked(), keyRange.Size())
+	}
+}
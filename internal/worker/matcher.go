@@ -0,0 +1,189 @@
+package worker
+
+import (
+	"strings"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/wordmatch"
+	"bloco-eth/pkg/wallet"
+)
+
+// addressMatcher decides which addresses a search's workers return
+type addressMatcher interface {
+	Matches(address string) bool
+}
+
+// Matcher checks addresses against criteria the way the pool's workers do. It finds
+// reject words with an automaton built once, so each address costs the same however
+// many words the criteria reject.
+type Matcher struct {
+	criteria wallet.GenerationCriteria
+	rejects  *wordmatch.Matcher
+}
+
+// NewMatcher prepares criteria for checking many addresses
+func NewMatcher(criteria wallet.GenerationCriteria) *Matcher {
+	m := &Matcher{criteria: criteria}
+	if len(criteria.RejectWords) > 0 {
+		m.rejects = wordmatch.New(criteria.RejectWords)
+	}
+	return m
+}
+
+// Matches checks an address against the criteria, additionally requiring the EIP-55
+// case of every pattern letter when the criteria are case-sensitive and rejecting
+// addresses that contain a reject word or have too few zero bytes
+func (m *Matcher) Matches(address string) bool {
+	criteria := &m.criteria
+	if !matchesCriteria(address, criteria.Prefix, criteria.Suffix, criteria.IsChecksum, criteria.Network) {
+		return false
+	}
+	if m.rejectedWord(address) != "" {
+		return false
+	}
+	if criteria.MinZeroBytes > 0 && crypto.ZeroBytes(address) < criteria.MinZeroBytes {
+		return false
+	}
+	if !criteria.IsCaseSensitive() || (criteria.Network != "ethereum" && criteria.Network != "") {
+		return true
+	}
+	checksumAddr := toChecksumAddress(address)[2:]
+	return strings.HasPrefix(checksumAddr, criteria.Prefix) && strings.HasSuffix(checksumAddr, criteria.Suffix)
+}
+
+// rejectedWord returns a reject word address contains, or ""
+func (m *Matcher) rejectedWord(address string) string {
+	if m.rejects == nil {
+		return m.criteria.RejectedWord(address)
+	}
+	return m.rejects.Find(strings.TrimPrefix(address, "0x"))
+}
+
+// MultiMatcher matches addresses against many criteria at once. Prefixes are a trie
+// walked from the start of the address, and the suffixes of the patterns sharing a
+// prefix are a trie hanging from its node, walked from the end; so an address costs
+// the same however many patterns there are, and only the patterns whose letters
+// match are checked in full.
+type MultiMatcher struct {
+	criteria []wallet.GenerationCriteria
+	matchers []*Matcher
+	// class maps a byte to its column in next, or -1 for bytes in no pattern;
+	// letters share a column whatever their case
+	class [256]int16
+	width int
+	// next is the transition table, width columns per node, with 0 for missing
+	// edges; node 0 is the root of the prefixes
+	next []int32
+	// suffixes is the root of the suffix trie below a prefix node, or 0
+	suffixes []int32
+	// ends lists the patterns whose suffix ends at a suffix node
+	ends [][]int32
+}
+
+// NewMultiMatcher prepares criteria for checking many addresses
+func NewMultiMatcher(criteria []wallet.GenerationCriteria) *MultiMatcher {
+	m := &MultiMatcher{criteria: criteria, matchers: make([]*Matcher, len(criteria))}
+	for i := range m.class {
+		m.class[i] = -1
+	}
+	for _, c := range criteria {
+		for _, pattern := range []string{c.Prefix, c.Suffix} {
+			for i := 0; i < len(pattern); i++ {
+				if b := fold(pattern[i]); m.class[b] < 0 {
+					m.class[b] = int16(m.width)
+					m.width++
+				}
+			}
+		}
+	}
+	for c := 'A'; c <= 'Z'; c++ {
+		m.class[c] = m.class[c+'a'-'A']
+	}
+
+	m.addNode()
+	for index, c := range criteria {
+		m.matchers[index] = NewMatcher(c)
+		node := int32(0)
+		for i := 0; i < len(c.Prefix); i++ {
+			node = m.child(node, c.Prefix[i])
+		}
+		if m.suffixes[node] == 0 {
+			// addNode may move suffixes, so it runs before the assignment
+			root := m.addNode()
+			m.suffixes[node] = root
+		}
+		node = m.suffixes[node]
+		for i := len(c.Suffix) - 1; i >= 0; i-- {
+			node = m.child(node, c.Suffix[i])
+		}
+		m.ends[node] = append(m.ends[node], int32(index))
+	}
+	return m
+}
+
+func (m *MultiMatcher) addNode() int32 {
+	for c := 0; c < m.width; c++ {
+		m.next = append(m.next, 0)
+	}
+	m.suffixes = append(m.suffixes, 0)
+	m.ends = append(m.ends, nil)
+	return int32(len(m.ends) - 1)
+}
+
+// child returns the node below node for b, adding it if needed
+func (m *MultiMatcher) child(node int32, b byte) int32 {
+	column := int(node)*m.width + int(m.class[b])
+	if m.next[column] == 0 {
+		child := m.addNode()
+		m.next[column] = child
+	}
+	return m.next[column]
+}
+
+// Match returns the index of the first criteria address matches
+func (m *MultiMatcher) Match(address string) (int, bool) {
+	body := strings.TrimPrefix(address, "0x")
+	best := -1
+	node := int32(0)
+	for depth := 0; ; depth++ {
+		if root := m.suffixes[node]; root != 0 {
+			best = m.matchSuffixes(address, body, root, best)
+		}
+		if depth == len(body) {
+			break
+		}
+		if node = m.step(node, body[depth]); node == 0 {
+			break
+		}
+	}
+	return best, best >= 0
+}
+
+// matchSuffixes walks the end of body down the suffix trie at root, checking in
+// full the patterns whose suffix it passes, and returns the first that matches,
+// or best if none comes before it
+func (m *MultiMatcher) matchSuffixes(address, body string, root int32, best int) int {
+	node := root
+	for i := len(body); ; i-- {
+		for _, index := range m.ends[node] {
+			if (best < 0 || int(index) < best) && m.matchers[index].Matches(address) {
+				best = int(index)
+			}
+		}
+		if i == 0 {
+			return best
+		}
+		if node = m.step(node, body[i-1]); node == 0 {
+			return best
+		}
+	}
+}
+
+// step follows the edge of node for b, returning 0 if there is none
+func (m *MultiMatcher) step(node int32, b byte) int32 {
+	c := m.class[b]
+	if c < 0 {
+		return 0
+	}
+	return m.next[int(node)*m.width+int(c)]
+}
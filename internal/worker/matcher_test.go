@@ -0,0 +1,181 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestMatcher_RejectWords(t *testing.T) {
+	criteria := wallet.GenerationCriteria{Prefix: "ab", Network: "ethereum", RejectWords: []string{"dead", "beef"}}
+	matcher := NewMatcher(criteria)
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"0xab00000000000000000000000000000000000000", true},
+		{"0xab000000000000000000DEAD0000000000000000", false},
+		{"0xab0000000000000000000000000000000000beef", false},
+		{"0xcd00000000000000000000000000000000000000", false},
+	}
+	for _, tt := range tests {
+		if got := matcher.Matches(tt.address); got != tt.want {
+			t.Errorf("Matches(%s) = %v, want %v", tt.address, got, tt.want)
+		}
+		if got := MatchesCriteria(tt.address, criteria); got != tt.want {
+			t.Errorf("MatchesCriteria(%s) = %v, want %v", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestMatcher_MinZeroBytes(t *testing.T) {
+	criteria := wallet.GenerationCriteria{Prefix: "ab", Network: "ethereum", MinZeroBytes: 2}
+	matcher := NewMatcher(criteria)
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"0xab00ff00ffffffffffffffffffffffffffffffff", true},
+		{"0xab0fff00ffffffffffffffffffffffffffffffff", false},
+		{"0xabf00fffffffffffffffffffffffffffffffffff", false},
+	}
+	for _, tt := range tests {
+		if got := matcher.Matches(tt.address); got != tt.want {
+			t.Errorf("Matches(%s) = %v, want %v", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestMultiMatcher(t *testing.T) {
+	criteria := []wallet.GenerationCriteria{
+		{Prefix: "dead", Network: "ethereum"},
+		{Prefix: "de", Suffix: "ff", Network: "ethereum"},
+		{Suffix: "beef", Network: "ethereum"},
+		{Prefix: "5aAe", Network: "ethereum", IsChecksum: true, CaseSensitive: true},
+		{Prefix: "ab", Network: "ethereum", RejectWords: []string{"bad"}},
+	}
+	m := NewMultiMatcher(criteria)
+	tests := []struct {
+		address string
+		want    int
+	}{
+		{"0xdead0000000000000000000000000000000000ff", 0},
+		{"0xde000000000000000000000000000000000000ff", 1},
+		{"0xDE000000000000000000000000000000000000FF", 1},
+		{"0x000000000000000000000000000000000000BEEF", 2},
+		{"0xdead00000000000000000000000000000000beef", 0},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", 3},
+		{"0xab00000000000000000000000000000000000000", 4},
+		{"0xab0000000000000000000000000000000000bad0", -1},
+		{"0xde00000000000000000000000000000000000000", -1},
+		{"0x0000000000000000000000000000000000000000", -1},
+	}
+	for _, tt := range tests {
+		got, ok := m.Match(tt.address)
+		if !ok {
+			got = -1
+		}
+		if got != tt.want {
+			t.Errorf("Match(%s) = %d, want %d", tt.address, got, tt.want)
+		}
+	}
+
+	if _, ok := NewMultiMatcher(nil).Match("0xdead0000000000000000000000000000000000ff"); ok {
+		t.Error("an empty MultiMatcher matched")
+	}
+	bitcoin := NewMultiMatcher([]wallet.GenerationCriteria{{Prefix: "1Boat", Network: "bitcoin"}})
+	if _, ok := bitcoin.Match("1boatSLRHtKNngkdXEeobR76b53LETtpyT"); ok {
+		t.Error("Bitcoin patterns matched with the wrong case")
+	}
+	if _, ok := bitcoin.Match("1BoatSLRHtKNngkdXEeobR76b53LETtpyT"); !ok {
+		t.Error("Bitcoin pattern did not match")
+	}
+}
+
+// TestMultiMatcher_Naive compares the tries with a loop over the patterns'
+// matchers on random patterns and addresses
+func TestMultiMatcher_Naive(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		criteria := randomCriteria(r, 1+r.Intn(300), 1, 3)
+		m, naive := NewMultiMatcher(criteria), naiveMatchers(criteria)
+		for i := 0; i < 500; i++ {
+			address := "0x" + randomHex(r, 40)
+			got, ok := m.Match(address)
+			if want := naiveMatch(naive, address); (ok && got != want) || (!ok && want >= 0) {
+				t.Fatalf("Match(%s) = %d, %v, naive loop found %d", address, got, ok, want)
+			}
+		}
+	}
+}
+
+func BenchmarkMultiMatcher(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	addresses := make([]string, 1024)
+	for i := range addresses {
+		addresses[i] = "0x" + randomHex(r, 40)
+	}
+	for _, count := range []int{10, 100, 1000, 10000} {
+		criteria := randomCriteria(r, count, 4, 6)
+		m, naive := NewMultiMatcher(criteria), naiveMatchers(criteria)
+		b.Run(fmt.Sprintf("Trie/%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				m.Match(addresses[i%len(addresses)])
+			}
+		})
+		b.Run(fmt.Sprintf("Naive/%d", count), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				naiveMatch(naive, addresses[i%len(addresses)])
+			}
+		})
+	}
+}
+
+func naiveMatchers(criteria []wallet.GenerationCriteria) []*Matcher {
+	matchers := make([]*Matcher, len(criteria))
+	for i, c := range criteria {
+		matchers[i] = NewMatcher(c)
+	}
+	return matchers
+}
+
+func naiveMatch(matchers []*Matcher, address string) int {
+	for i, m := range matchers {
+		if m.Matches(address) {
+			return i
+		}
+	}
+	return -1
+}
+
+// randomCriteria returns prefixes, suffixes and both, of minLength to maxLength
+// characters in all
+func randomCriteria(r *rand.Rand, count, minLength, maxLength int) []wallet.GenerationCriteria {
+	criteria := make([]wallet.GenerationCriteria, count)
+	for i := range criteria {
+		c := wallet.GenerationCriteria{Network: "ethereum"}
+		length := minLength + r.Intn(maxLength-minLength+1)
+		switch r.Intn(3) {
+		case 0:
+			c.Prefix = randomHex(r, length)
+		case 1:
+			c.Suffix = randomHex(r, length)
+		default:
+			split := r.Intn(length + 1)
+			c.Prefix, c.Suffix = randomHex(r, split), randomHex(r, length-split)
+		}
+		criteria[i] = c
+	}
+	return criteria
+}
+
+func randomHex(r *rand.Rand, length int) string {
+	const digits = "0123456789abcdefABCDEF"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = digits[r.Intn(len(digits))]
+	}
+	return string(b)
+}
@@ -0,0 +1,116 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// NearMiss is the address that came closest to a search's pattern without matching it
+type NearMiss struct {
+	Address string `json:"address"`
+	// Matched counts the pattern characters the address has in place, of Length
+	Matched int `json:"matched"`
+	Length  int `json:"length"`
+}
+
+// NearMissTracker keeps the closest miss of the searches under it. Workers only
+// take its lock when they beat the best so far, which soon becomes rare.
+type NearMissTracker struct {
+	best atomic.Int64 // Matched of miss
+
+	mu   sync.Mutex
+	miss NearMiss
+}
+
+// NewNearMissTracker returns a tracker that has seen no address
+func NewNearMissTracker() *NearMissTracker {
+	return &NearMissTracker{}
+}
+
+// Best returns the closest miss so far, and false before any address shared a
+// character with the pattern
+func (t *NearMissTracker) Best() (NearMiss, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.miss, t.miss.Matched > 0
+}
+
+// observe scores an address that missed criteria
+func (t *NearMissTracker) observe(address string, criteria *wallet.GenerationCriteria) {
+	matched := patternMatched(address, criteria)
+	if int64(matched) <= t.best.Load() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if matched > t.miss.Matched {
+		t.miss = NearMiss{Address: address, Matched: matched, Length: len(criteria.Prefix) + len(criteria.Suffix)}
+		t.best.Store(int64(matched))
+	}
+}
+
+// patternMatched scores an address by the prefix characters of criteria it starts
+// with and the suffix characters it ends with
+func patternMatched(address string, criteria *wallet.GenerationCriteria) int {
+	body := strings.TrimPrefix(address, "0x")
+	caseSensitive := criteria.Network == "bitcoin" || criteria.Network == "solana"
+	matched := 0
+	for i := 0; i < len(criteria.Prefix) && i < len(body) && sameChar(body[i], criteria.Prefix[i], caseSensitive); i++ {
+		matched++
+	}
+	for i := 1; i <= len(criteria.Suffix) && i <= len(body) &&
+		sameChar(body[len(body)-i], criteria.Suffix[len(criteria.Suffix)-i], caseSensitive); i++ {
+		matched++
+	}
+	return matched
+}
+
+// sameChar compares two address characters, ignoring ASCII case unless caseSensitive
+func sameChar(a, b byte, caseSensitive bool) bool {
+	if caseSensitive {
+		return a == b
+	}
+	return fold(a) == fold(b)
+}
+
+// fold lowercases an ASCII letter
+func fold(c byte) byte {
+	if c >= 'A' && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// nearMissMatcher reports the addresses its matcher rejects to a tracker
+type nearMissMatcher struct {
+	addressMatcher
+	tracker  *NearMissTracker
+	criteria wallet.GenerationCriteria
+}
+
+// Matches checks address, scoring it when it misses
+func (m *nearMissMatcher) Matches(address string) bool {
+	if m.addressMatcher.Matches(address) {
+		return true
+	}
+	m.tracker.observe(address, &m.criteria)
+	return false
+}
+
+// nearMissKey is the context key of the NearMissTracker of a search
+type nearMissKey struct{}
+
+// WithNearMiss returns a context whose searches report their closest misses to tracker
+func WithNearMiss(ctx context.Context, tracker *NearMissTracker) context.Context {
+	return context.WithValue(ctx, nearMissKey{}, tracker)
+}
+
+// nearMissFrom returns the NearMissTracker of ctx, or nil
+func nearMissFrom(ctx context.Context) *NearMissTracker {
+	tracker, _ := ctx.Value(nearMissKey{}).(*NearMissTracker)
+	return tracker
+}
@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestNearMissTracker_Observe(t *testing.T) {
+	tracker := NewNearMissTracker()
+	if _, ok := tracker.Best(); ok {
+		t.Fatal("Best() reported a miss before any address")
+	}
+	criteria := wallet.GenerationCriteria{Prefix: "abcd", Suffix: "ef"}
+	tracker.observe("0x1bcd000000000000000000000000000000000000", &criteria)
+	tracker.observe("0xAB00000000000000000000000000000000000eF", &criteria)
+	tracker.observe("0xa000000000000000000000000000000000000000", &criteria)
+
+	miss, ok := tracker.Best()
+	want := NearMiss{Address: "0xAB00000000000000000000000000000000000eF", Matched: 4, Length: 6}
+	if !ok || miss != want {
+		t.Errorf("Best() = %+v, %v; want %+v", miss, ok, want)
+	}
+
+	// Base58 addresses compare case-sensitively
+	solana := wallet.GenerationCriteria{Prefix: "Ab", Network: "solana"}
+	sensitive := NewNearMissTracker()
+	sensitive.observe("aB1111", &solana)
+	if _, ok := sensitive.Best(); ok {
+		t.Error("a solana miss matched letters of the wrong case")
+	}
+}
+
+func TestPool_GenerateWalletWithContext_NearMiss(t *testing.T) {
+	pool := NewPool(2, "ethereum")
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	tracker := NewNearMissTracker()
+	ctx, cancel := context.WithTimeout(WithNearMiss(context.Background(), tracker), 10*time.Second)
+	defer cancel()
+	result, err := pool.GenerateWalletWithContext(ctx, wallet.GenerationCriteria{Prefix: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	miss, ok := tracker.Best()
+	if !ok || miss.Length != 3 || miss.Matched < 1 || miss.Matched > 3 || !strings.HasPrefix(strings.ToLower(miss.Address), "0xa") {
+		t.Errorf("Best() = %+v, %v after finding %s", miss, ok, result.Wallet.Address)
+	}
+}
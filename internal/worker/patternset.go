@@ -0,0 +1,86 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// PatternSet is the patterns a long-running search matches all at once. Set swaps
+// them atomically, so a search under the set takes up new patterns, and drops old
+// ones, without restarting its workers. Each version is compiled into one
+// MultiMatcher, so an address costs the same however many patterns the set holds.
+type PatternSet struct {
+	current atomic.Pointer[compiledPatterns]
+}
+
+// compiledPatterns is one version of a PatternSet
+type compiledPatterns struct {
+	criteria   []wallet.GenerationCriteria
+	matcher    *MultiMatcher
+	difficulty float64
+}
+
+// NewPatternSet returns a set matching any of criteria
+func NewPatternSet(criteria []wallet.GenerationCriteria) *PatternSet {
+	s := &PatternSet{}
+	s.Set(criteria)
+	return s
+}
+
+// Set replaces the patterns of the set
+func (s *PatternSet) Set(criteria []wallet.GenerationCriteria) {
+	compiled := &compiledPatterns{criteria: criteria, matcher: NewMultiMatcher(criteria)}
+	// Each attempt matches one pattern or another, so the chances add up
+	var chance float64
+	for _, c := range criteria {
+		chance += 1 / c.Difficulty()
+	}
+	if chance > 0 {
+		compiled.difficulty = 1 / chance
+	}
+	s.current.Store(compiled)
+}
+
+// Match returns the first pattern of the set that address matches
+func (s *PatternSet) Match(address string) (wallet.GenerationCriteria, bool) {
+	compiled := s.current.Load()
+	if i, ok := compiled.matcher.Match(address); ok {
+		return compiled.criteria[i], true
+	}
+	return wallet.GenerationCriteria{}, false
+}
+
+// Matches reports whether address matches any pattern of the set
+func (s *PatternSet) Matches(address string) bool {
+	_, ok := s.Match(address)
+	return ok
+}
+
+// Len returns the number of patterns in the set
+func (s *PatternSet) Len() int {
+	return len(s.current.Load().criteria)
+}
+
+// Difficulty returns the expected attempts until an address matches one of the
+// patterns, or 0 for an empty set
+func (s *PatternSet) Difficulty() float64 {
+	return s.current.Load().difficulty
+}
+
+// patternSetKey is the context key of the PatternSet a search matches
+type patternSetKey struct{}
+
+// WithPatternSet returns a context whose searches match the patterns of set instead
+// of the prefix and suffix of their criteria; the rest of the criteria still apply
+// to every wallet
+func WithPatternSet(ctx context.Context, set *PatternSet) context.Context {
+	return context.WithValue(ctx, patternSetKey{}, set)
+}
+
+// patternSetFrom returns the PatternSet of ctx, or nil
+func patternSetFrom(ctx context.Context) *PatternSet {
+	set, _ := ctx.Value(patternSetKey{}).(*PatternSet)
+	return set
+}
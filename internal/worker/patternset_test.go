@@ -0,0 +1,67 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestPatternSet(t *testing.T) {
+	set := NewPatternSet(nil)
+	if set.Len() != 0 || set.Difficulty() != 0 || set.Matches("0xabc") {
+		t.Fatalf("empty set: Len() = %d, Difficulty() = %v", set.Len(), set.Difficulty())
+	}
+
+	set.Set([]wallet.GenerationCriteria{{Prefix: "ab"}, {Suffix: "cd"}})
+	if set.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", set.Len())
+	}
+	// Two patterns of difficulty 256 each match one address in 128
+	if got := set.Difficulty(); got != 128 {
+		t.Errorf("Difficulty() = %v, want 128", got)
+	}
+	if criteria, ok := set.Match("0x00000000000000000000000000000000000000cd"); !ok || criteria.Suffix != "cd" {
+		t.Errorf("Match() = %+v, %v, want the cd suffix", criteria, ok)
+	}
+	if set.Matches("0x0000000000000000000000000000000000000000") {
+		t.Error("Matches() = true for an address matching no pattern")
+	}
+
+	// The first pattern an address matches wins, in the order of the latest Set
+	set.Set([]wallet.GenerationCriteria{{Suffix: "cd"}, {Prefix: "ab"}})
+	if criteria, ok := set.Match("0xab000000000000000000000000000000000000cd"); !ok || criteria.Suffix != "cd" {
+		t.Errorf("Match() = %+v, %v, want the cd suffix", criteria, ok)
+	}
+	set.Set([]wallet.GenerationCriteria{{Prefix: "ab"}})
+	if criteria, ok := set.Match("0xab000000000000000000000000000000000000cd"); !ok || criteria.Prefix != "ab" {
+		t.Errorf("Match() after Set = %+v, %v, want the ab prefix", criteria, ok)
+	}
+}
+
+func TestPool_GenerateWalletWithContext_PatternSet(t *testing.T) {
+	pool := NewPool(2, "ethereum")
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	// The search starts on a pattern it will not find and takes up the new one
+	set := NewPatternSet([]wallet.GenerationCriteria{{Prefix: "ffffffffff"}})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		set.Set([]wallet.GenerationCriteria{{Prefix: "a"}})
+	}()
+	ctx, cancel := context.WithTimeout(WithPatternSet(context.Background(), set), 10*time.Second)
+	defer cancel()
+
+	result, err := pool.GenerateWalletWithContext(ctx, wallet.GenerationCriteria{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(strings.ToLower(result.Wallet.Address), "0xa") {
+		t.Errorf("address %s does not match the swapped-in pattern", result.Wallet.Address)
+	}
+}
@@ -3,7 +3,7 @@ package worker
 import (
 	"context"
 	"crypto/ecdsa"
-	"crypto/rand"
+	stderrors "errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -18,12 +18,13 @@ import (
 
 	"bloco-eth/internal/config"
 	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/tracing"
 	"bloco-eth/pkg/errors"
 	"bloco-eth/pkg/logging"
 	"bloco-eth/pkg/wallet"
 )
 
-// Pool is a minimal implementation that mimics the working monolithic version
+// Pool is the worker pool that runs searches and benchmarks
 type Pool struct {
 	threadCount    int
 	mu             sync.RWMutex
@@ -35,13 +36,21 @@ type Pool struct {
 	statsCancel    context.CancelFunc
 	poolManager    *crypto.PoolManager
 	generator      crypto.Generator
+	keyRange       *crypto.KeyRangeCursor
+	masterSeed     *crypto.MasterSeed
+	batchStrategy  BatchStrategy
+	cancelLatency  time.Duration
+	// checksumVerifiers checks the case of case-sensitive hits off the workers
+	checksumVerifiers int
 }
 
 const (
-	// statsUpdateInterval is the frequency of stats updates from workers
+	// statsUpdateInterval is the default duration of a worker batch, after which it reports stats
 	statsUpdateInterval = 100 * time.Millisecond
-	// statsUpdateAttempts is the number of attempts between stats updates
+	// statsUpdateAttempts is the default benchmark batch size
 	statsUpdateAttempts = 1000
+	// benchmarkCancelStride is how many benchmark attempts pass between cancellation checks
+	benchmarkCancelStride = 256
 )
 
 // NewPool creates a new worker pool
@@ -149,6 +158,13 @@ func (p *Pool) Shutdown() error {
 	return nil
 }
 
+// IsRunning reports whether the pool has been started and not yet shut down
+func (p *Pool) IsRunning() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.isRunning
+}
+
 // GetStatsCollector returns the stats collector
 func (p *Pool) GetStatsCollector() *StatsCollector {
 	return p.statsCollector
@@ -156,6 +172,13 @@ func (p *Pool) GetStatsCollector() *StatsCollector {
 
 // GenerateWalletWithContext generates a wallet using the worker pool
 func (p *Pool) GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	p.mu.RLock()
+	keyRange, seed := p.keyRange, p.masterSeed
+	p.mu.RUnlock()
+	if keyRange != nil {
+		return p.searchKeyRange(ctx, keyRange, seed, criteria)
+	}
+
 	// Log operation start
 	if p.logger != nil {
 		params := map[string]interface{}{
@@ -170,11 +193,40 @@ func (p *Pool) GenerateWalletWithContext(ctx context.Context, criteria wallet.Ge
 		}
 	}
 
+	ctx, span := tracing.Start(ctx, "wallet.generate",
+		tracing.String("pattern.prefix", criteria.Prefix),
+		tracing.String("pattern.suffix", criteria.Suffix),
+		tracing.Bool("pattern.checksum", criteria.IsChecksum),
+		tracing.String("network", criteria.Network),
+		tracing.Int("threads", p.threadCount))
+	defer span.End()
+
+	// Stop the remaining workers, and end their batch spans, once this search returns
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	resultCh := make(chan *wallet.GenerationResult, 1)
 	errorCh := make(chan error, 1)
+	strategy, bound := p.batchStrategyOrDefault(), p.cancelLatencyOrDefault()
+	share, gate := shareFrom(ctx), gateFrom(ctx)
 
-	// Start workers similar to monolithic version
+	// Start workers
 	var wg sync.WaitGroup
+	var screen addressMatcher = NewMatcher(criteria)
+	hits := chan<- *wallet.GenerationResult(nil)
+	if set := patternSetFrom(ctx); set != nil {
+		// The set's patterns may change while the workers run
+		screen = set
+	} else if ranking := rankingFrom(ctx); ranking != nil {
+		// Best-effort searches also end at near matches, which checksum verifiers
+		// would drop, so the ranking checks the checksum itself
+		screen = ranking
+	} else if verifiers := p.verifiersFor(criteria); verifiers > 0 {
+		screen, hits = NewMatcher(caseInsensitive(criteria)), p.startVerifiers(ctx, &wg, verifiers, criteria, resultCh)
+	}
+	if tracker := nearMissFrom(ctx); tracker != nil && patternSetFrom(ctx) == nil && criteria.GetPattern() != "" {
+		screen = &nearMissMatcher{screen, tracker, criteria}
+	}
 	for i := 0; i < p.threadCount; i++ {
 		wg.Add(1)
 		go func(workerID int) {
@@ -183,275 +235,327 @@ func (p *Pool) GenerateWalletWithContext(ctx context.Context, criteria wallet.Ge
 			// Worker loop
 			attempts := int64(0)
 			startTime := time.Now()
-			lastStatsUpdate := startTime
-
-			for {
-				select {
-				case <-ctx.Done():
+			clock := newBatchClock(strategy, bound, startTime)
+
+			// Each worker's search is one batch span, ended with its attempt count
+			_, batch := tracing.Start(ctx, "worker.batch", tracing.Int("worker.id", workerID))
+			defer func() {
+				batch.SetAttributes(tracing.Int64("worker.attempts", attempts))
+				batch.End()
+			}()
+
+			buffers := newWorkerBuffers(p.poolManager.GetCryptoPool())
+			p.superviseWorker(ctx, workerID, buffers, errorCh, nil, func() {
+				// Workers beyond the search's share wait until it grows, and all
+				// of them while the gate is paused
+				if !share.admit(ctx, workerID) || !gate.wait(ctx) {
 					return
-				default:
 				}
-
-				attempts++
-
-				// Send stats update every 100ms or 1000 attempts
-				now := time.Now()
-				if now.Sub(lastStatsUpdate) >= statsUpdateInterval || attempts%statsUpdateAttempts == 0 {
-					elapsed := now.Sub(startTime)
-					var speed float64
-					if elapsed.Seconds() > 0 {
-						speed = float64(attempts) / elapsed.Seconds()
+				for {
+					// Cancellation is checked often enough to stop within the cancel latency
+					if clock.cancelDue() {
+						select {
+						case <-ctx.Done():
+							return
+						default:
+						}
 					}
 
-					// Send stats to collector
-					select {
-					case p.statsChan <- WorkerStats{
-						WorkerID:   workerID,
-						Attempts:   attempts,
-						Speed:      speed,
-						LastUpdate: now,
-						IsHealthy:  true,
-						ErrorCount: 0,
-					}:
-					default:
-						// Non-blocking send
-					}
-					lastStatsUpdate = now
-				}
+					attempts++
+
+					// Send stats at the end of each batch
+					if clock.tick() {
+						now := time.Now()
+						elapsed := now.Sub(startTime)
+						var speed float64
+						if elapsed.Seconds() > 0 {
+							speed = float64(attempts) / elapsed.Seconds()
+						}
 
-				// Generate private key material based on generation strategy
-				var (
-					privateKey         *ecdsa.PrivateKey
-					mnemonic           string
-					err                error
-					addressStr         string
-					rawPrivateKeyBytes []byte // Added this variable as it's used later in the original code
-				)
-
-				// Mnemonic generation is only supported for Ethereum
-				// Bitcoin and Solana use different key derivation schemes (secp256k1 and Ed25519)
-				if criteria.UseMnemonic && criteria.Network != "ethereum" && criteria.Network != "" {
-					if p.logger != nil {
-						context := map[string]interface{}{
-							"worker_id": workerID,
-							"network":   criteria.Network,
+						// Send stats to collector
+						select {
+						case p.statsChan <- WorkerStats{
+							WorkerID:   workerID,
+							Attempts:   attempts,
+							Speed:      speed,
+							LastUpdate: now,
+							IsHealthy:  true,
+							ErrorCount: 0,
+						}:
+						default:
+							// Non-blocking send
 						}
-						if logErr := p.logger.LogError("unsupported_mnemonic_network",
-							fmt.Errorf("mnemonic generation is only supported for Ethereum network"), context); logErr != nil {
-							_ = logErr
+						clock.checkIn(now)
+						yieldWorker()
+						if share != nil || gate != nil {
+							if !share.admit(ctx, workerID) || !gate.wait(ctx) {
+								return
+							}
+							// Time spent parked is not part of the next batch
+							clock.started = time.Now()
 						}
 					}
-					// Skip mnemonic generation for non-Ethereum networks
-					criteria.UseMnemonic = false
-				}
 
-				// For non-Ethereum networks, use the generator directly
-				if criteria.Network != "ethereum" && criteria.Network != "" {
-					// Use the generator to create a complete wallet
-					genWallet, err := p.generator.GenerateWallet()
-					if err != nil {
+					// Generate private key material based on generation strategy
+					var (
+						privateKey         *ecdsa.PrivateKey
+						mnemonic           string
+						err                error
+						addressStr         string
+						rawPrivateKeyBytes []byte // Added this variable as it's used later in the original code
+					)
+
+					// Mnemonic generation is only supported for Ethereum
+					// Bitcoin and Solana use different key derivation schemes (secp256k1 and Ed25519)
+					if criteria.UseMnemonic && criteria.Network != "ethereum" && criteria.Network != "" {
 						if p.logger != nil {
 							context := map[string]interface{}{
 								"worker_id": workerID,
-								"attempts":  attempts,
 								"network":   criteria.Network,
 							}
-							if logErr := p.logger.LogError("wallet_generation", err, context); logErr != nil {
+							if logErr := p.logger.LogError("unsupported_mnemonic_network",
+								fmt.Errorf("mnemonic generation is only supported for Ethereum network"), context); logErr != nil {
 								_ = logErr
 							}
 						}
-						continue
+						// Skip mnemonic generation for non-Ethereum networks
+						criteria.UseMnemonic = false
 					}
 
-					addressStr = genWallet.Address
+					// For non-Ethereum networks, use the generator directly
+					if criteria.Network != "ethereum" && criteria.Network != "" {
+						// Use the generator to create a complete wallet
+						genWallet, err := p.generator.GenerateWallet()
+						if err != nil {
+							if reportEntropyFailure(err, errorCh) {
+								return
+							}
+							if p.logger != nil {
+								context := map[string]interface{}{
+									"worker_id": workerID,
+									"attempts":  attempts,
+									"network":   criteria.Network,
+								}
+								if logErr := p.logger.LogError("wallet_generation", err, context); logErr != nil {
+									_ = logErr
+								}
+							}
+							continue
+						}
 
-					// Check if address matches criteria
-					if !matchesCriteria(addressStr, criteria.Prefix, criteria.Suffix, criteria.IsChecksum, criteria.Network) {
-						continue
-					}
+						addressStr = genWallet.Address
 
-					// Found a match! Create result directly from generated wallet
-					result := &wallet.GenerationResult{
-						Wallet: &wallet.Wallet{
-							Address:    addressStr,
-							PublicKey:  genWallet.PublicKey,
-							PrivateKey: genWallet.PrivateKey,
-							Mnemonic:   genWallet.Mnemonic, // Include mnemonic from generator
-							Network:    criteria.Network,
-							CreatedAt:  time.Now(),
-						},
-						Attempts: attempts,
-						Duration: time.Since(startTime),
-						WorkerID: workerID,
-					}
+						// Check if address matches criteria
+						if !screen.Matches(addressStr) {
+							continue
+						}
 
-					select {
-					case resultCh <- result:
-					case <-ctx.Done():
-					}
-					return
-				}
+						// Found a match! Create result directly from generated wallet
+						result := &wallet.GenerationResult{
+							Wallet: &wallet.Wallet{
+								Address:    addressStr,
+								PublicKey:  genWallet.PublicKey,
+								PrivateKey: genWallet.PrivateKey,
+								Mnemonic:   genWallet.Mnemonic, // Include mnemonic from generator
+								Network:    criteria.Network,
+								CreatedAt:  time.Now(),
+							},
+							Attempts: attempts,
+							Duration: time.Since(startTime),
+							WorkerID: workerID,
+						}
 
-				if criteria.UseMnemonic {
-					mnemonic, privateKey, err = generateMnemonicPrivateKey()
-					if err != nil {
-						if p.logger != nil {
-							context := map[string]interface{}{
-								"worker_id":      workerID,
-								"attempts":       attempts,
-								"use_mnemonic":   true,
-								"error_category": "mnemonic_generation",
-							}
-							if logErr := p.logger.LogError("wallet_material_generation", err, context); logErr != nil {
-								_ = logErr
-							}
+						select {
+						case resultCh <- result:
+						case <-ctx.Done():
 						}
-						continue
+						return
 					}
 
-					// Use network-specific generator to create address from private key
-					privateKeyBytes := ethcrypto.FromECDSA(privateKey)
-					addressStr, err = p.generator.GenerateAddressFromPrivateKey(privateKeyBytes)
-					if err != nil {
-						if p.logger != nil {
-							context := map[string]interface{}{
-								"worker_id": workerID,
-								"attempts":  attempts,
+					if criteria.UseMnemonic {
+						mnemonic, privateKey, err = generateMnemonicPrivateKey()
+						if err != nil {
+							if reportEntropyFailure(err, errorCh) {
+								return
 							}
-							if logErr := p.logger.LogError("address_generation", err, context); logErr != nil {
-								_ = logErr
+							if p.logger != nil {
+								context := map[string]interface{}{
+									"worker_id":      workerID,
+									"attempts":       attempts,
+									"use_mnemonic":   true,
+									"error_category": "mnemonic_generation",
+								}
+								if logErr := p.logger.LogError("wallet_material_generation", err, context); logErr != nil {
+									_ = logErr
+								}
 							}
+							continue
 						}
-						continue
-					}
 
-				} else {
-					// Optimized path using AddressGenerator and object pooling
-					// Get private key buffer from pool
-					cryptoPool := p.poolManager.GetCryptoPool()
-					privateKeyBytes := cryptoPool.GetPrivateKeyBuffer()
+						// Use network-specific generator to create address from private key
+						privateKeyBytes := ethcrypto.FromECDSA(privateKey)
+						addressStr, err = p.generator.GenerateAddressFromPrivateKey(privateKeyBytes)
+						if err != nil {
+							if p.logger != nil {
+								context := map[string]interface{}{
+									"worker_id": workerID,
+									"attempts":  attempts,
+								}
+								if logErr := p.logger.LogError("address_generation", err, context); logErr != nil {
+									_ = logErr
+								}
+							}
+							continue
+						}
 
-					// Generate random private key
-					_, err := rand.Read(privateKeyBytes)
-					if err != nil {
-						p.poolManager.GetCryptoPool().PutPrivateKeyBuffer(privateKeyBytes)
-						if p.logger != nil {
-							context := map[string]interface{}{
-								"worker_id": workerID,
-								"attempts":  attempts,
+					} else {
+						// Optimized path using AddressGenerator and object pooling
+						// Get private key buffer from pool
+						privateKeyBytes := buffers.get()
+
+						// Generate random private key
+						err := crypto.ReadEntropy(privateKeyBytes)
+						if err != nil {
+							buffers.put(privateKeyBytes)
+							if reportEntropyFailure(err, errorCh) {
+								return
 							}
-							if logErr := p.logger.LogError("crypto_key_generation", err, context); logErr != nil {
-								_ = logErr
+							if p.logger != nil {
+								context := map[string]interface{}{
+									"worker_id": workerID,
+									"attempts":  attempts,
+								}
+								if logErr := p.logger.LogError("crypto_key_generation", err, context); logErr != nil {
+									_ = logErr
+								}
 							}
+							continue
 						}
-						continue
-					}
 
-					// Generate address using generator
-					addressStr, err = p.generator.GenerateAddressFromPrivateKey(privateKeyBytes)
-					if err != nil {
-						p.poolManager.GetCryptoPool().PutPrivateKeyBuffer(privateKeyBytes)
-						if p.logger != nil {
-							context := map[string]interface{}{
-								"worker_id": workerID,
-								"attempts":  attempts,
-							}
-							if logErr := p.logger.LogError("address_generation", err, context); logErr != nil {
-								_ = logErr
+						// Generate address using generator
+						addressStr, err = p.generator.GenerateAddressFromPrivateKey(privateKeyBytes)
+						if err != nil {
+							buffers.put(privateKeyBytes)
+							if p.logger != nil {
+								context := map[string]interface{}{
+									"worker_id": workerID,
+									"attempts":  attempts,
+								}
+								if logErr := p.logger.LogError("address_generation", err, context); logErr != nil {
+									_ = logErr
+								}
 							}
+							continue
 						}
-						continue
-					}
 
-					// If we found a match, we need to reconstruct the full private key object for the result
-					// Otherwise we just return the buffer to the pool
-					if matchesCriteria(addressStr, criteria.Prefix, criteria.Suffix, criteria.IsChecksum, criteria.Network) {
-						// Only reconstruct ECDSA private key for Ethereum
-						// For Solana and Bitcoin, we'll use the raw bytes directly
-						if criteria.Network == "ethereum" || criteria.Network == "" {
-							// Reconstruct private key for result (Ethereum only)
-							privateKey = new(ecdsa.PrivateKey)
-							privateKey.PublicKey.Curve = ethcrypto.S256()
-							privateKey.D = new(big.Int).SetBytes(privateKeyBytes)
-							privateKey.PublicKey.X, privateKey.PublicKey.Y = ethcrypto.S256().ScalarBaseMult(privateKeyBytes)
+						// If we found a match, we need to reconstruct the full private key object for the result
+						// Otherwise we just return the buffer to the pool
+						if screen.Matches(addressStr) {
+							// Only reconstruct ECDSA private key for Ethereum
+							// For Solana and Bitcoin, we'll use the raw bytes directly
+							if criteria.Network == "ethereum" || criteria.Network == "" {
+								// Reconstruct private key for result (Ethereum only)
+								privateKey = new(ecdsa.PrivateKey)
+								privateKey.PublicKey.Curve = ethcrypto.S256()
+								privateKey.D = new(big.Int).SetBytes(privateKeyBytes)
+								privateKey.PublicKey.X, privateKey.PublicKey.Y = ethcrypto.S256().ScalarBaseMult(privateKeyBytes)
+							} else {
+								// For non-Ethereum, store the raw bytes
+								rawPrivateKeyBytes = make([]byte, len(privateKeyBytes))
+								copy(rawPrivateKeyBytes, privateKeyBytes)
+							}
+
+							// We can return the buffer now as we've copied it to big.Int (or we don't need it for non-Ethereum)
+							buffers.put(privateKeyBytes)
 						} else {
-							// For non-Ethereum, store the raw bytes
-							rawPrivateKeyBytes = make([]byte, len(privateKeyBytes))
-							copy(rawPrivateKeyBytes, privateKeyBytes)
+							// No match, return buffer and continue
+							buffers.put(privateKeyBytes)
+							continue
 						}
-
-						// We can return the buffer now as we've copied it to big.Int (or we don't need it for non-Ethereum)
-						p.poolManager.GetCryptoPool().PutPrivateKeyBuffer(privateKeyBytes)
-					} else {
-						// No match, return buffer and continue
-						p.poolManager.GetCryptoPool().PutPrivateKeyBuffer(privateKeyBytes)
-						continue
 					}
-				}
 
-				// Check if address matches criteria (re-check for mnemonic path, or use result from optimized path)
-				// For optimized path, we already checked inside the block above to know if we should reconstruct keys
-				// But we need a unified flow here.
+					// Check if address matches criteria (re-check for mnemonic path, or use result from optimized path)
+					// For optimized path, we already checked inside the block above to know if we should reconstruct keys
+					// But we need a unified flow here.
 
-				// Let's simplify:
-				// The optimized path above does the check inside to avoid reconstruction.
-				// If we are here from optimized path, it means we found a match.
-				// If we are here from mnemonic path, we haven't checked yet.
+					// Let's simplify:
+					// The optimized path above does the check inside to avoid reconstruction.
+					// If we are here from optimized path, it means we found a match.
+					// If we are here from mnemonic path, we haven't checked yet.
 
-				// Double check match (just in case)
-				if !matchesCriteria(addressStr, criteria.Prefix, criteria.Suffix, criteria.IsChecksum, criteria.Network) {
-					continue
-				}
+					// Double check match (just in case)
+					if !screen.Matches(addressStr) {
+						continue
+					}
 
-				// Found a match!
-				// Get private key hex - handle different networks
-				var privateKeyHex string
-				var publicKeyHex string
-
-				if criteria.Network == "ethereum" || criteria.Network == "" {
-					// Ethereum: use ECDSA keys
-					privateKeyBytes := ethcrypto.FromECDSA(privateKey)
-					privateKeyHex = fmt.Sprintf("%x", privateKeyBytes)
-
-					// Get public key hex
-					publicKey := privateKey.Public()
-					publicKeyECDSA, _ := publicKey.(*ecdsa.PublicKey)
-					publicKeyBytes := ethcrypto.FromECDSAPub(publicKeyECDSA)
-					publicKeyHex = fmt.Sprintf("%x", publicKeyBytes)
-				}
+					// Found a match!
+					// Get private key hex - handle different networks
+					var privateKeyHex string
+					var publicKeyHex string
+
+					if criteria.Network == "ethereum" || criteria.Network == "" {
+						// Ethereum: use ECDSA keys
+						privateKeyBytes := ethcrypto.FromECDSA(privateKey)
+						privateKeyHex = fmt.Sprintf("%x", privateKeyBytes)
+
+						// Get public key hex
+						publicKey := privateKey.Public()
+						publicKeyECDSA, _ := publicKey.(*ecdsa.PublicKey)
+						publicKeyBytes := ethcrypto.FromECDSAPub(publicKeyECDSA)
+						publicKeyHex = fmt.Sprintf("%x", publicKeyBytes)
+					}
 
-				// Use checksum address if checksum is required (Ethereum only)
-				finalAddress := addressStr
-				if criteria.IsChecksum && (criteria.Network == "ethereum" || criteria.Network == "") {
-					finalAddress = toChecksumAddress(addressStr)
-				}
+					// Use checksum address if checksum is required (Ethereum only)
+					finalAddress := addressStr
+					if hits == nil && criteria.IsChecksum && (criteria.Network == "ethereum" || criteria.Network == "") {
+						finalAddress = toChecksumAddress(addressStr)
+					}
 
-				result := &wallet.GenerationResult{
-					Wallet: &wallet.Wallet{
-						Address:    finalAddress,
-						PublicKey:  publicKeyHex,
-						PrivateKey: privateKeyHex,
-						Mnemonic:   mnemonic,
-						Network:    criteria.Network,
-						CreatedAt:  time.Now(),
-					},
-					Attempts: attempts,
-					Duration: time.Since(startTime),
-					WorkerID: workerID,
-				}
+					result := &wallet.GenerationResult{
+						Wallet: &wallet.Wallet{
+							Address:    finalAddress,
+							PublicKey:  publicKeyHex,
+							PrivateKey: privateKeyHex,
+							Mnemonic:   mnemonic,
+							Network:    criteria.Network,
+							CreatedAt:  time.Now(),
+						},
+						Attempts: attempts,
+						Duration: time.Since(startTime),
+						WorkerID: workerID,
+					}
 
-				select {
-				case resultCh <- result:
-				case <-ctx.Done():
+					// A verifier checks the case of the hit while this worker searches on
+					if hits != nil {
+						if p.statsCollector != nil {
+							p.statsCollector.RecordChecksumCandidate()
+						}
+						select {
+						case hits <- result:
+							continue
+						case <-ctx.Done():
+							return
+						}
+					}
+
+					select {
+					case resultCh <- result:
+					case <-ctx.Done():
+					}
+					return
 				}
-				return
-			}
+			})
 		}(i)
 	}
 
 	// Wait for result or cancellation
 	select {
 	case result := <-resultCh:
+		if p.statsCollector != nil {
+			p.statsCollector.RecordWalletFound()
+		}
+		span.SetAttributes(tracing.Int64("attempts", result.Attempts), tracing.Int("worker.id", result.WorkerID))
+
 		// Log the wallet generation and operation completion
 		if p.logger != nil {
 			// Log the specific wallet generated
@@ -478,6 +582,7 @@ func (p *Pool) GenerateWalletWithContext(ctx context.Context, criteria wallet.Ge
 		}
 		return result, nil
 	case err := <-errorCh:
+		span.RecordError(err)
 		// Log the error using secure logging
 		if p.logger != nil {
 			context := map[string]interface{}{
@@ -489,7 +594,10 @@ func (p *Pool) GenerateWalletWithContext(ctx context.Context, criteria wallet.Ge
 		}
 		return nil, err
 	case <-ctx.Done():
+		// Return once the workers have stopped, which the cancel latency bounds
+		wg.Wait()
 		cancellationErr := errors.NewCancellationError("generate_wallet", "generation cancelled")
+		span.RecordError(cancellationErr)
 		// Log the cancellation as an error
 		if p.logger != nil {
 			context := map[string]interface{}{
@@ -504,11 +612,141 @@ func (p *Pool) GenerateWalletWithContext(ctx context.Context, criteria wallet.Ge
 	}
 }
 
+// RunBenchmark keeps every worker generating addresses in batches of item.BatchSize
+// until the context is done, and returns the total number of attempts made.
+// Matches are not collected; the criteria only determine the matching cost.
+func (p *Pool) RunBenchmark(ctx context.Context, item WorkItem) (int64, error) {
+	batchSize := item.BatchSize
+	if batchSize <= 0 {
+		batchSize = statsUpdateAttempts
+	}
+	matcher := NewMatcher(item.Criteria)
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		total      int64
+		failed     int
+		entropyErr error
+	)
+	panicCh := make(chan error, 1)
+
+	for i := 0; i < p.threadCount; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+
+			buffers := newWorkerBuffers(p.poolManager.GetCryptoPool())
+			attempts := int64(0)
+			errorCount := 0
+			startTime := time.Now()
+
+			defer func() {
+				mu.Lock()
+				total += attempts
+				failed += errorCount
+				mu.Unlock()
+			}()
+
+			p.superviseWorker(ctx, workerID, buffers, panicCh, nil, func() {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					default:
+					}
+
+					for j := 0; j < batchSize; j++ {
+						// Large batches must not delay the end of a timed benchmark
+						if j%benchmarkCancelStride == 0 && j > 0 && ctx.Err() != nil {
+							return
+						}
+						privateKeyBytes := buffers.get()
+						if err := crypto.ReadEntropy(privateKeyBytes); err != nil {
+							buffers.put(privateKeyBytes)
+							if stderrors.Is(err, crypto.ErrEntropyFailure) {
+								mu.Lock()
+								entropyErr = err
+								mu.Unlock()
+								return
+							}
+							errorCount++
+							continue
+						}
+
+						addressStr, err := p.generator.GenerateAddressFromPrivateKey(privateKeyBytes)
+						buffers.put(privateKeyBytes)
+						if err != nil {
+							errorCount++
+							continue
+						}
+
+						attempts++
+						matcher.Matches(addressStr)
+					}
+
+					now := time.Now()
+					var speed float64
+					if elapsed := now.Sub(startTime).Seconds(); elapsed > 0 {
+						speed = float64(attempts) / elapsed
+					}
+
+					select {
+					case p.statsChan <- WorkerStats{
+						WorkerID:   workerID,
+						Attempts:   attempts,
+						Speed:      speed,
+						LastUpdate: now,
+						IsHealthy:  true,
+						ErrorCount: errorCount,
+					}:
+					default:
+						// Non-blocking send
+					}
+				}
+			})
+		}(i)
+	}
+
+	wg.Wait()
+
+	select {
+	case err := <-panicCh:
+		return total, err
+	default:
+	}
+	if entropyErr != nil {
+		return total, errors.NewCryptoError("run_benchmark", "entropy source failed", entropyErr)
+	}
+	if total == 0 && failed > 0 {
+		return 0, errors.NewWorkerError("run_benchmark",
+			fmt.Sprintf("all %d benchmark attempts failed", failed))
+	}
+
+	return total, nil
+}
+
+// reportEntropyFailure passes a failed entropy source to the search, which cannot
+// continue without one
+func reportEntropyFailure(err error, errorCh chan<- error) bool {
+	if !stderrors.Is(err, crypto.ErrEntropyFailure) {
+		return false
+	}
+	select {
+	case errorCh <- errors.NewCryptoError("generate_wallet", "entropy source failed", err):
+	default:
+	}
+	return true
+}
+
+// ethereumDerivationPath is where mnemonic searches derive their key, as shown by --preview
+var ethereumDerivationPath = crypto.MustParseDerivationPath(crypto.EthereumDerivationPath)
+
 // generateMnemonicPrivateKey creates a new mnemonic phrase and derives the corresponding private key
 func generateMnemonicPrivateKey() (string, *ecdsa.PrivateKey, error) {
 	// Generate 128 bits of entropy for a 12-word mnemonic to balance security and performance
-	entropy, err := bip39.NewEntropy(128)
-	if err != nil {
+	entropy := make([]byte, 16)
+	if err := crypto.ReadEntropy(entropy); err != nil {
 		return "", nil, err
 	}
 
@@ -523,16 +761,8 @@ func generateMnemonicPrivateKey() (string, *ecdsa.PrivateKey, error) {
 		return "", nil, err
 	}
 
-	derivationPath := []uint32{
-		bip32.FirstHardenedChild + 44,
-		bip32.FirstHardenedChild + 60,
-		bip32.FirstHardenedChild + 0,
-		0,
-		0,
-	}
-
 	key := masterKey
-	for _, child := range derivationPath {
+	for _, child := range ethereumDerivationPath {
 		key, err = key.NewChildKey(child)
 		if err != nil {
 			return "", nil, err
@@ -547,8 +777,17 @@ func generateMnemonicPrivateKey() (string, *ecdsa.PrivateKey, error) {
 	return mnemonic, privateKey, nil
 }
 
-// matchesCriteria checks if an address matches the given prefix and suffix criteria
-// It performs a fast string check first, and only calculates checksum if necessary
+// MatchesCriteria reports whether address satisfies criteria using the same matcher as the pool
+func MatchesCriteria(address string, criteria wallet.GenerationCriteria) bool {
+	return matchesWalletCriteria(address, criteria)
+}
+
+// matchesWalletCriteria checks one address against criteria; searches check many
+// with a Matcher
+func matchesWalletCriteria(address string, criteria wallet.GenerationCriteria) bool {
+	return (&Matcher{criteria: criteria}).Matches(address)
+}
+
 // matchesCriteria checks if an address matches the given prefix and suffix criteria
 // It performs a fast string check first, and only calculates checksum if necessary
 func matchesCriteria(address, prefix, suffix string, isChecksum bool, network string) bool {
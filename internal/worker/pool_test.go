@@ -206,7 +206,7 @@ func TestPool_GenerateWalletWithContext_Cancellation(t *testing.T) {
 
 	// Use a very difficult pattern that would take a long time
 	criteria := wallet.GenerationCriteria{
-		Prefix:     "aaaa", // This should take a while to find
+		Prefix:     "aaaaaaaa", // This should take a while to find
 		Suffix:     "",
 		IsChecksum: false,
 	}
@@ -0,0 +1,232 @@
+package worker
+
+import (
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// progressQueueSize is how many events a subscriber may fall behind before
+// publishing waits for it
+const progressQueueSize = 64
+
+// ProgressEventType identifies what a ProgressEvent reports
+type ProgressEventType int
+
+const (
+	// ProgressTick is a periodic sample of the statistics
+	ProgressTick ProgressEventType = iota
+	// ProgressWalletFound reports a found wallet
+	ProgressWalletFound
+	// ProgressDone is the last event of a run
+	ProgressDone
+)
+
+// String returns the event type name
+func (t ProgressEventType) String() string {
+	switch t {
+	case ProgressTick:
+		return "tick"
+	case ProgressWalletFound:
+		return "wallet"
+	case ProgressDone:
+		return "done"
+	default:
+		return "unknown"
+	}
+}
+
+// ProgressEvent is one progress update delivered to a subscriber
+type ProgressEvent struct {
+	Type    ProgressEventType
+	Time    time.Time
+	Elapsed time.Duration
+	// Stats are the collector's statistics when the event is delivered
+	Stats AggregatedStats
+	// ETASpeed is the speed ETAs should use: the throughput measured for the
+	// running pattern once the broker calibrates ETAs, else Stats.TotalSpeed
+	ETASpeed float64
+	// Found counts the wallets found up to this event
+	Found int
+	// Result is the wallet of a ProgressWalletFound event
+	Result *wallet.GenerationResult
+	// Err is why the run ended, for a ProgressDone event
+	Err error
+}
+
+// ProgressSubscriber receives the events of a ProgressBroker on a goroutine of its own
+type ProgressSubscriber func(ProgressEvent)
+
+// ProgressBroker fans the progress of a run out to any number of subscribers (TUI,
+// text and JSON printers, the API). Every subscriber receives ticks at its own
+// interval and each found wallet and the final done event in publish order; ticks
+// are skipped while it is behind, and nothing follows the done event.
+type ProgressBroker struct {
+	stats      *StatsCollector
+	start      time.Time
+	throughput *ThroughputEstimator
+
+	mu     sync.Mutex
+	subs   []*progressSubscription
+	found  int
+	closed bool
+}
+
+// progressSubscription delivers events to one subscriber
+type progressSubscription struct {
+	fn       ProgressSubscriber
+	interval time.Duration
+	events   chan ProgressEvent
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewProgressBroker creates a broker sampling stats, which may be nil
+func NewProgressBroker(stats *StatsCollector) *ProgressBroker {
+	return &ProgressBroker{stats: stats, start: time.Now()}
+}
+
+// CalibrateETA makes ETASpeed the throughput measured over the run, bootstrapped
+// from its first window; it must be called before the first Subscribe
+func (b *ProgressBroker) CalibrateETA(window time.Duration) {
+	if b != nil && window > 0 {
+		b.throughput = NewThroughputEstimator(window)
+	}
+}
+
+// Subscribe delivers the broker's events to fn, with a tick every interval (none
+// when interval is 0). The returned function unsubscribes and waits for fn to
+// return; it must not be called from fn.
+func (b *ProgressBroker) Subscribe(interval time.Duration, fn ProgressSubscriber) func() {
+	if b == nil {
+		return func() {}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return func() {}
+	}
+
+	s := &progressSubscription{
+		fn:       fn,
+		interval: interval,
+		events:   make(chan ProgressEvent, progressQueueSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	b.subs = append(b.subs, s)
+	go b.deliver(s, b.found)
+
+	return func() {
+		s.stopOnce.Do(func() { close(s.stop) })
+		b.mu.Lock()
+		for i, sub := range b.subs {
+			if sub == s {
+				b.subs = append(b.subs[:i], b.subs[i+1:]...)
+				break
+			}
+		}
+		b.mu.Unlock()
+		<-s.done
+	}
+}
+
+// PublishWallet sends a found wallet to every subscriber
+func (b *ProgressBroker) PublishWallet(result *wallet.GenerationResult) {
+	if b == nil || result == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.found++
+	b.sendLocked(ProgressEvent{Type: ProgressWalletFound, Time: time.Now(), Found: b.found, Result: result})
+}
+
+// Close sends the done event carrying err to every subscriber and waits for them
+// to return. Later calls do nothing.
+func (b *ProgressBroker) Close(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	b.sendLocked(ProgressEvent{Type: ProgressDone, Time: time.Now(), Found: b.found, Err: err})
+	subs := b.subs
+	b.subs = nil
+	for _, s := range subs {
+		close(s.events)
+	}
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		<-s.done
+	}
+}
+
+// sendLocked queues e for every subscriber; publishing under the lock keeps every
+// subscriber's events in the same order
+func (b *ProgressBroker) sendLocked(e ProgressEvent) {
+	for _, s := range b.subs {
+		select {
+		case s.events <- e:
+		case <-s.stop:
+		}
+	}
+}
+
+// deliver runs a subscriber until it is unsubscribed or receives the done event
+func (b *ProgressBroker) deliver(s *progressSubscription, found int) {
+	defer close(s.done)
+	var ticks <-chan time.Time
+	if s.interval > 0 {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case e, ok := <-s.events:
+			if !ok {
+				return
+			}
+			found = e.Found
+			s.fn(b.fill(e))
+			if e.Type == ProgressDone {
+				return
+			}
+		case now := <-ticks:
+			// A tick never reports ahead of the wallets still queued
+			if len(s.events) > 0 {
+				continue
+			}
+			s.fn(b.fill(ProgressEvent{Type: ProgressTick, Time: now, Found: found}))
+		}
+	}
+}
+
+// fill adds the elapsed time and the current statistics to e
+func (b *ProgressBroker) fill(e ProgressEvent) ProgressEvent {
+	e.Elapsed = e.Time.Sub(b.start)
+	if b.stats != nil {
+		e.Stats = b.stats.GetAggregatedStats()
+	}
+	e.ETASpeed = e.Stats.TotalSpeed
+	if b.throughput != nil {
+		if speed := b.throughput.Observe(time.Now(), e.Stats.TotalAttempts); speed > 0 {
+			e.ETASpeed = speed
+		}
+	}
+	return e
+}
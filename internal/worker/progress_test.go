@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// eventLog records the events a subscriber received
+type eventLog struct {
+	mu     sync.Mutex
+	events []ProgressEvent
+}
+
+func (l *eventLog) record(e ProgressEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, e)
+}
+
+func (l *eventLog) snapshot() []ProgressEvent {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]ProgressEvent(nil), l.events...)
+}
+
+func TestProgressBroker_DeliversInOrder(t *testing.T) {
+	broker := NewProgressBroker(NewStatsCollector())
+	logs := []*eventLog{{}, {}, {}}
+	for i, log := range logs {
+		// One slow subscriber must not reorder or lose the others' events
+		slow := i == 0
+		broker.Subscribe(time.Millisecond, func(e ProgressEvent) {
+			if slow {
+				time.Sleep(100 * time.Microsecond)
+			}
+			log.record(e)
+		})
+	}
+
+	const wallets = 200
+	for i := 1; i <= wallets; i++ {
+		broker.PublishWallet(&wallet.GenerationResult{Attempts: int64(i)})
+	}
+	broker.Close(errors.New("interrupted"))
+
+	for i, log := range logs {
+		found := 0
+		events := log.snapshot()
+		for j, e := range events {
+			switch e.Type {
+			case ProgressWalletFound:
+				if e.Found != found+1 || e.Result.Attempts != int64(e.Found) {
+					t.Fatalf("subscriber %d: wallet %d arrived after wallet %d", i, e.Found, found)
+				}
+				found = e.Found
+			case ProgressTick:
+				if e.Found != found {
+					t.Fatalf("subscriber %d: tick reports %d wallets after %d", i, e.Found, found)
+				}
+			case ProgressDone:
+				if j != len(events)-1 {
+					t.Fatalf("subscriber %d: %d events after done", i, len(events)-1-j)
+				}
+				if e.Found != wallets || e.Err == nil || e.Err.Error() != "interrupted" {
+					t.Errorf("subscriber %d: done = %+v", i, e)
+				}
+			}
+		}
+		if found != wallets || events[len(events)-1].Type != ProgressDone {
+			t.Errorf("subscriber %d: %d wallets, last event %v", i, found, events[len(events)-1].Type)
+		}
+	}
+}
+
+func TestProgressBroker_Ticks(t *testing.T) {
+	stats := NewStatsCollector()
+	stats.UpdateWorkerStats(WorkerStats{WorkerID: 0, Attempts: 1234})
+	broker := NewProgressBroker(stats)
+	ticks := make(chan ProgressEvent, 100)
+	broker.Subscribe(5*time.Millisecond, func(e ProgressEvent) {
+		if e.Type == ProgressTick {
+			ticks <- e
+		}
+	})
+	// A subscriber without an interval only gets wallets and done
+	var quiet eventLog
+	broker.Subscribe(0, quiet.record)
+
+	select {
+	case e := <-ticks:
+		if e.Stats.TotalAttempts != 1234 || e.Elapsed <= 0 {
+			t.Errorf("tick = %+v", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no tick delivered")
+	}
+	broker.Close(nil)
+	if events := quiet.snapshot(); len(events) != 1 || events[0].Type != ProgressDone || events[0].Err != nil {
+		t.Errorf("interval 0 subscriber got %+v", events)
+	}
+}
+
+func TestProgressBroker_Shutdown(t *testing.T) {
+	broker := NewProgressBroker(nil)
+	var kept, dropped eventLog
+	broker.Subscribe(time.Millisecond, kept.record)
+	unsubscribe := broker.Subscribe(time.Millisecond, dropped.record)
+
+	broker.PublishWallet(&wallet.GenerationResult{})
+	unsubscribe()
+	unsubscribe()
+	afterUnsubscribe := len(dropped.snapshot())
+	broker.PublishWallet(&wallet.GenerationResult{})
+
+	// Close waits for the subscribers, and nothing is delivered after it
+	broker.Close(nil)
+	delivered := len(kept.snapshot())
+	broker.Close(errors.New("twice"))
+	broker.PublishWallet(&wallet.GenerationResult{})
+	broker.Subscribe(time.Millisecond, func(ProgressEvent) { t.Error("event delivered after Close") })()
+	time.Sleep(10 * time.Millisecond)
+
+	events := kept.snapshot()
+	if len(events) != delivered || events[len(events)-1].Type != ProgressDone || events[len(events)-1].Err != nil {
+		t.Errorf("events after Close: %d, then %d; last %+v", delivered, len(events), events[len(events)-1])
+	}
+	if len(dropped.snapshot()) != afterUnsubscribe {
+		t.Error("an unsubscribed subscriber still received events")
+	}
+
+	// A nil broker is a no-op so callers need not check
+	var none *ProgressBroker
+	none.Subscribe(time.Millisecond, func(ProgressEvent) {})()
+	none.PublishWallet(&wallet.GenerationResult{})
+	none.Close(nil)
+}
@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// Candidate is a wallet a best-effort search kept, scored like a near miss
+type Candidate struct {
+	Result *wallet.GenerationResult
+	// Matched counts the pattern characters the address has in place, of Length
+	Matched int
+	Length  int
+	// Exact is set when the address meets the criteria in full
+	Exact bool
+}
+
+// rank orders candidates by pattern characters in place, exact matches first among
+// equals
+func (c Candidate) rank() int64 {
+	rank := int64(c.Matched) * 2
+	if c.Exact {
+		rank++
+	}
+	return rank
+}
+
+// Ranking keeps the K highest-scoring wallets of best-effort searches. Under
+// WithRanking a search returns the first wallet that would enter the ranking rather
+// than only an exact match, so repeating searches until a deadline leaves the best
+// addresses found in it.
+type Ranking struct {
+	k        int
+	criteria wallet.GenerationCriteria
+	matcher  *Matcher
+	// floor is the rank a wallet must beat to enter; 0 until the ranking is full
+	floor atomic.Int64
+
+	mu         sync.Mutex
+	candidates []Candidate // best first
+}
+
+// NewRanking returns an empty ranking of the k best wallets for criteria
+func NewRanking(k int, criteria wallet.GenerationCriteria) *Ranking {
+	return &Ranking{k: k, criteria: criteria, matcher: NewMatcher(criteria)}
+}
+
+// score scores address against the criteria of the ranking
+func (r *Ranking) score(address string) Candidate {
+	c := Candidate{
+		Matched: patternMatched(address, &r.criteria),
+		Length:  len(r.criteria.Prefix) + len(r.criteria.Suffix),
+	}
+	// Only a full case-insensitive match can meet the criteria, and only then is the
+	// checksum worth computing
+	c.Exact = c.Matched == c.Length && r.matcher.Matches(address)
+	return c
+}
+
+// Matches reports whether address would enter the ranking; addresses with a reject
+// word never do. Workers call it for every address, so it only takes the lock in Add.
+func (r *Ranking) Matches(address string) bool {
+	if r.score(address).rank() <= r.floor.Load() {
+		return false
+	}
+	return r.matcher.rejectedWord(address) == ""
+}
+
+// Add scores the wallet of result and keeps it if it ranks among the best k,
+// reporting whether it did
+func (r *Ranking) Add(result *wallet.GenerationResult) bool {
+	c := r.score(result.Wallet.Address)
+	c.Result = result
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c.rank() <= r.floor.Load() {
+		return false
+	}
+	for _, kept := range r.candidates {
+		if kept.Result.Wallet.Address == result.Wallet.Address {
+			return false
+		}
+	}
+	// Ties keep the wallet found first ahead
+	i, _ := slices.BinarySearchFunc(r.candidates, c.rank(), func(kept Candidate, rank int64) int {
+		if kept.rank() >= rank {
+			return -1
+		}
+		return 1
+	})
+	r.candidates = slices.Insert(r.candidates, i, c)
+	if len(r.candidates) > r.k {
+		r.candidates = r.candidates[:r.k]
+	}
+	if len(r.candidates) == r.k {
+		r.floor.Store(r.candidates[r.k-1].rank())
+	}
+	return true
+}
+
+// Candidates returns the kept wallets, best first
+func (r *Ranking) Candidates() []Candidate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.candidates)
+}
+
+// Complete reports whether the ranking holds k exact matches, which no later wallet
+// can beat
+func (r *Ranking) Complete() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.candidates) == r.k && r.candidates[r.k-1].Exact
+}
+
+// rankingKey is the context key of the Ranking of a best-effort search
+type rankingKey struct{}
+
+// WithRanking returns a context whose searches end at the first wallet that would
+// enter ranking instead of the first exact match
+func WithRanking(ctx context.Context, ranking *Ranking) context.Context {
+	return context.WithValue(ctx, rankingKey{}, ranking)
+}
+
+// rankingFrom returns the Ranking of ctx, or nil
+func rankingFrom(ctx context.Context) *Ranking {
+	ranking, _ := ctx.Value(rankingKey{}).(*Ranking)
+	return ranking
+}
@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func rankedResult(address string) *wallet.GenerationResult {
+	return &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: address}}
+}
+
+func TestRanking_Add(t *testing.T) {
+	criteria := wallet.GenerationCriteria{Prefix: "abcd", RejectWords: []string{"dead"}}
+	ranking := NewRanking(2, criteria)
+
+	if ranking.Matches("0x0000000000000000000000000000000000000000") {
+		t.Error("an address without pattern characters would enter the ranking")
+	}
+	if ranking.Matches("0xab0000000000000000000000000000000000dead") {
+		t.Error("an address with a reject word would enter the ranking")
+	}
+	for _, address := range []string{
+		"0xa000000000000000000000000000000000000000",
+		"0xabc0000000000000000000000000000000000000",
+		"0xab00000000000000000000000000000000000000",
+	} {
+		ranking.Add(rankedResult(address))
+	}
+
+	got := ranking.Candidates()
+	if len(got) != 2 || got[0].Matched != 3 || got[1].Matched != 2 || got[0].Length != 4 {
+		t.Fatalf("Candidates() = %+v", got)
+	}
+	if ranking.Matches("0xa100000000000000000000000000000000000000") {
+		t.Error("an address scoring below the full ranking would enter it")
+	}
+	if !ranking.Matches("0xabcd000000000000000000000000000000000000") {
+		t.Error("an exact match would not enter the ranking")
+	}
+	if ranking.Complete() {
+		t.Error("Complete() with near matches only")
+	}
+
+	ranking.Add(rankedResult("0xabcd000000000000000000000000000000000000"))
+	ranking.Add(rankedResult("0xabcd000000000000000000000000000000000001"))
+	if got := ranking.Candidates(); !got[0].Exact || !got[1].Exact || !ranking.Complete() {
+		t.Errorf("Candidates() = %+v after two exact matches", got)
+	}
+}
+
+func TestRanking_Checksum(t *testing.T) {
+	// The EIP-55 checksum of this address is 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed
+	const address = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+	ranking := NewRanking(1, wallet.GenerationCriteria{Prefix: "5AAe", IsChecksum: true, CaseSensitive: true})
+	ranking.Add(rankedResult(address))
+	if got := ranking.Candidates(); len(got) != 1 || got[0].Exact || got[0].Matched != 4 {
+		t.Errorf("Candidates() = %+v; want a near match of the wrong case", got)
+	}
+	exact := NewRanking(1, wallet.GenerationCriteria{Prefix: "5aAe", Suffix: "Aed", IsChecksum: true, CaseSensitive: true})
+	if exact.Add(rankedResult(address)); !exact.Complete() {
+		t.Errorf("Candidates() = %+v; want the checksum match", exact.Candidates())
+	}
+}
+
+func TestPool_GenerateWalletWithContext_Ranking(t *testing.T) {
+	pool := NewPool(2, "ethereum")
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	// A pattern far too long to find still ranks the closest addresses
+	criteria := wallet.GenerationCriteria{Prefix: "abcdefabcdef"}
+	ranking := NewRanking(3, criteria)
+	ctx, cancel := context.WithTimeout(WithRanking(context.Background(), ranking), 10*time.Second)
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		result, err := pool.GenerateWalletWithContext(ctx, criteria)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ranking.Add(result) {
+			t.Errorf("search returned %s, which does not enter the ranking", result.Wallet.Address)
+		}
+	}
+	if got := ranking.Candidates(); len(got) != 3 || got[2].Matched < 1 {
+		t.Errorf("Candidates() = %+v", got)
+	}
+}
@@ -0,0 +1,171 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Priority is a search's claim on the threads that concurrent searches share
+type Priority string
+
+// Priorities, weighted 4, 2 and 1
+const (
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+// ParsePriority reads a priority name, where "" is normal
+func ParsePriority(name string) (Priority, error) {
+	switch p := Priority(strings.ToLower(name)); p {
+	case "":
+		return PriorityNormal, nil
+	case PriorityHigh, PriorityNormal, PriorityLow:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown priority %q (use high, normal or low)", name)
+}
+
+// Weight is the priority's share of the threads relative to other searches
+func (p Priority) Weight() int {
+	switch p {
+	case PriorityHigh:
+		return 4
+	case PriorityLow:
+		return 1
+	}
+	return 2
+}
+
+// Scheduler splits a fixed number of threads between the searches running at once,
+// in proportion to their weights. Each search gets at least one thread, so hard
+// patterns cannot starve easy ones, and the split is redone whenever a search
+// joins or leaves.
+type Scheduler struct {
+	threads int
+
+	mu      sync.Mutex
+	shares  []*Share
+	changed chan struct{} // closed and replaced on every reallocation
+}
+
+// Share is one search's part of a Scheduler's threads
+type Share struct {
+	scheduler *Scheduler
+	weight    int
+	active    int // workers below this ID may run; guarded by scheduler.mu
+}
+
+// NewScheduler returns a scheduler dividing threads between its shares
+func NewScheduler(threads int) *Scheduler {
+	return &Scheduler{threads: max(threads, 1), changed: make(chan struct{})}
+}
+
+// Join adds a search of weight and reallocates the threads. A nil Scheduler
+// returns a nil Share, which never limits its workers.
+func (s *Scheduler) Join(weight int) *Share {
+	if s == nil {
+		return nil
+	}
+	share := &Share{scheduler: s, weight: max(weight, 1)}
+	s.mu.Lock()
+	s.shares = append(s.shares, share)
+	s.reallocateLocked()
+	s.mu.Unlock()
+	return share
+}
+
+// Leave removes the share, giving its threads to the searches still running
+func (sh *Share) Leave() {
+	if sh == nil {
+		return
+	}
+	s := sh.scheduler
+	s.mu.Lock()
+	for i, other := range s.shares {
+		if other == sh {
+			s.shares = append(s.shares[:i], s.shares[i+1:]...)
+			break
+		}
+	}
+	s.reallocateLocked()
+	s.mu.Unlock()
+}
+
+// Threads returns how many workers of the share may run now
+func (sh *Share) Threads() int {
+	if sh == nil {
+		return 0
+	}
+	sh.scheduler.mu.Lock()
+	defer sh.scheduler.mu.Unlock()
+	return sh.active
+}
+
+// admit blocks worker workerID until the share lets it run, and reports false if
+// ctx ends first
+func (sh *Share) admit(ctx context.Context, workerID int) bool {
+	if sh == nil {
+		return true
+	}
+	for {
+		sh.scheduler.mu.Lock()
+		allowed, changed := workerID < sh.active, sh.scheduler.changed
+		sh.scheduler.mu.Unlock()
+		if allowed {
+			return true
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// reallocateLocked splits the threads by weight, largest remainders first, and
+// wakes parked workers. Callers must hold s.mu.
+func (s *Scheduler) reallocateLocked() {
+	total := 0
+	for _, share := range s.shares {
+		total += share.weight
+	}
+	type remainder struct {
+		share *Share
+		rest  int
+	}
+	spare := s.threads
+	rests := make([]remainder, 0, len(s.shares))
+	for _, share := range s.shares {
+		share.active = s.threads * share.weight / total
+		rests = append(rests, remainder{share, s.threads * share.weight % total})
+		spare -= share.active
+	}
+	sort.SliceStable(rests, func(i, k int) bool { return rests[i].rest > rests[k].rest })
+	for i := 0; spare > 0 && i < len(rests); i++ {
+		rests[i].share.active++
+		spare--
+	}
+	for _, share := range s.shares {
+		share.active = max(share.active, 1)
+	}
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// shareKey is the context key of the Share a search runs under
+type shareKey struct{}
+
+// WithShare returns a context whose searches run only the workers share allows
+func WithShare(ctx context.Context, share *Share) context.Context {
+	return context.WithValue(ctx, shareKey{}, share)
+}
+
+// shareFrom returns the Share of ctx, or nil
+func shareFrom(ctx context.Context) *Share {
+	share, _ := ctx.Value(shareKey{}).(*Share)
+	return share
+}
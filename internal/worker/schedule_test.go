@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Priority
+		weight  int
+		wantErr bool
+	}{
+		{name: "", want: PriorityNormal, weight: 2},
+		{name: "HIGH", want: PriorityHigh, weight: 4},
+		{name: "normal", want: PriorityNormal, weight: 2},
+		{name: "low", want: PriorityLow, weight: 1},
+		{name: "urgent", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParsePriority(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParsePriority(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if err == nil && (got != tt.want || got.Weight() != tt.weight) {
+			t.Errorf("ParsePriority(%q) = %s weighing %d, want %s weighing %d", tt.name, got, got.Weight(), tt.want, tt.weight)
+		}
+	}
+}
+
+func TestScheduler_Allocation(t *testing.T) {
+	s := NewScheduler(8)
+	high, normal, low := s.Join(4), s.Join(2), s.Join(1)
+	// 8*4/7, 8*2/7 and 8*1/7 round to 5, 2 and 1 by largest remainder
+	if got := []int{high.Threads(), normal.Threads(), low.Threads()}; got[0] != 5 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("threads = %v, want [5 2 1]", got)
+	}
+
+	high.Leave()
+	if got := []int{normal.Threads(), low.Threads()}; got[0] != 5 || got[1] != 3 {
+		t.Errorf("threads after a leave = %v, want [5 3]", got)
+	}
+
+	// Every share keeps a thread even when there are more shares than threads
+	crowded := NewScheduler(1)
+	a, b := crowded.Join(4), crowded.Join(1)
+	if a.Threads() != 1 || b.Threads() != 1 {
+		t.Errorf("threads = %d, %d, want 1 each", a.Threads(), b.Threads())
+	}
+
+	var none *Scheduler
+	if share := none.Join(4); share != nil || share.Threads() != 0 {
+		t.Errorf("nil Scheduler Join() = %v", share)
+	}
+}
+
+func TestShare_AdmitReallocates(t *testing.T) {
+	s := NewScheduler(4)
+	first, second := s.Join(1), s.Join(1)
+
+	admitted := make(chan bool)
+	go func() { admitted <- second.admit(context.Background(), 3) }()
+	select {
+	case <-admitted:
+		t.Fatal("worker 3 ran beyond its share of 2 threads")
+	case <-time.After(20 * time.Millisecond):
+	}
+	first.Leave()
+	select {
+	case ok := <-admitted:
+		if !ok {
+			t.Error("admit() = false, want true")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("worker 3 stayed parked after the other share left")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if s.Join(8).admit(ctx, 7) {
+		t.Error("admit() = true for a parked worker after cancellation")
+	}
+}
+
+func TestPool_GenerateWalletWithContext_Share(t *testing.T) {
+	pool := NewPool(4, "ethereum")
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	share := NewScheduler(1).Join(1)
+	defer share.Leave()
+	ctx, cancel := context.WithTimeout(WithShare(context.Background(), share), 10*time.Second)
+	defer cancel()
+
+	result, err := pool.GenerateWalletWithContext(ctx, wallet.GenerationCriteria{Prefix: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.WorkerID != 0 {
+		t.Errorf("WorkerID = %d, want only worker 0 to run", result.WorkerID)
+	}
+}
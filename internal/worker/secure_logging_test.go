@@ -147,7 +147,7 @@ func TestPool_SecureLoggingErrorHandling(t *testing.T) {
 	defer cancel()
 
 	criteria := wallet.GenerationCriteria{
-		Prefix:     "aaaa", // Very difficult pattern to force timeout
+		Prefix:     "aaaaaaaa", // Very difficult pattern to force timeout
 		Suffix:     "",
 		IsChecksum: false,
 	}
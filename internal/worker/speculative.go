@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"bloco-eth/pkg/wallet"
+)
+
+// verifierQueue is how many hits may wait for a verifier before workers block
+const verifierQueue = 64
+
+// SetChecksumVerifiers makes case-sensitive Ethereum searches match case-insensitively
+// in the workers and leave the EIP-55 case check to n verifier goroutines, which only
+// see the workers' hits; zero checks the case in the workers
+func (p *Pool) SetChecksumVerifiers(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checksumVerifiers = max(n, 0)
+}
+
+// verifiersFor returns how many verifiers a search for criteria gets, which is zero
+// unless the pattern's case has to match the checksum
+func (p *Pool) verifiersFor(criteria wallet.GenerationCriteria) int {
+	if !criteria.IsCaseSensitive() || (criteria.Network != "ethereum" && criteria.Network != "") {
+		return 0
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.checksumVerifiers
+}
+
+// startVerifiers runs n verifiers until ctx ends. They check the case-insensitive
+// hits sent on the returned channel against criteria, checksum the addresses of
+// those that match and send them to resultCh.
+func (p *Pool) startVerifiers(ctx context.Context, wg *sync.WaitGroup, n int, criteria wallet.GenerationCriteria,
+	resultCh chan<- *wallet.GenerationResult) chan<- *wallet.GenerationResult {
+	hits := make(chan *wallet.GenerationResult, verifierQueue)
+	matcher := NewMatcher(criteria)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case result := <-hits:
+					if !matcher.Matches(result.Wallet.Address) {
+						continue
+					}
+					if p.statsCollector != nil {
+						p.statsCollector.RecordChecksumConfirmed()
+					}
+					result.Wallet.Address = toChecksumAddress(result.Wallet.Address)
+					select {
+					case resultCh <- result:
+					case <-ctx.Done():
+					}
+					return
+				}
+			}
+		}()
+	}
+	return hits
+}
+
+// caseInsensitive returns the criteria workers screen addresses with before a
+// verifier checks their case
+func caseInsensitive(criteria wallet.GenerationCriteria) wallet.GenerationCriteria {
+	criteria.IsChecksum, criteria.CaseSensitive = false, false
+	return criteria
+}
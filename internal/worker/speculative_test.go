@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/wallet"
+)
+
+func TestPool_ChecksumVerifiers(t *testing.T) {
+	pool := NewPool(2, "ethereum")
+	pool.SetChecksumVerifiers(1)
+	if err := pool.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = pool.Shutdown() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	criteria := wallet.GenerationCriteria{Prefix: "Ab", IsChecksum: true, CaseSensitive: true, Network: "ethereum"}
+	result, err := pool.GenerateWalletWithContext(ctx, criteria)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address := strings.TrimPrefix(result.Wallet.Address, "0x"); !strings.HasPrefix(address, "Ab") {
+		t.Errorf("address %s does not start with Ab in its checksum case", result.Wallet.Address)
+	}
+	candidates, confirmed := pool.GetStatsCollector().GetChecksumVerification()
+	if confirmed != 1 || candidates < confirmed {
+		t.Errorf("GetChecksumVerification() = %d candidates, %d confirmed, want 1 confirmed", candidates, confirmed)
+	}
+}
+
+func TestPool_VerifiersFor(t *testing.T) {
+	pool := NewPool(1, "ethereum")
+	pool.SetChecksumVerifiers(2)
+	tests := []struct {
+		name     string
+		criteria wallet.GenerationCriteria
+		want     int
+	}{
+		{"case-sensitive", wallet.GenerationCriteria{Prefix: "Ab", IsChecksum: true, CaseSensitive: true}, 2},
+		{"checksum only", wallet.GenerationCriteria{Prefix: "ab", IsChecksum: true}, 0},
+		{"bitcoin", wallet.GenerationCriteria{Prefix: "Ab", IsChecksum: true, CaseSensitive: true, Network: "bitcoin"}, 0},
+	}
+	for _, tt := range tests {
+		if got := pool.verifiersFor(tt.criteria); got != tt.want {
+			t.Errorf("%s: verifiersFor() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
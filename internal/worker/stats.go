@@ -18,6 +18,8 @@ type WorkerStats struct {
 	IsHealthy  bool      `json:"is_healthy"`
 	ErrorCount int       `json:"error_count"`
 	LastError  string    `json:"last_error,omitempty"`
+	// Restarts counts the times the worker panicked and was restarted
+	Restarts int `json:"restarts,omitempty"`
 }
 
 // WorkerHealth represents health information for a worker
@@ -52,25 +54,37 @@ type StatsCollector struct {
 	aggregatedStats AggregatedStats
 	startTime       time.Time
 	lastUpdate      time.Time
-	peakSpeed       float64
-	speedHistory    []SpeedSample
-	maxHistorySize  int
+	lastProgress    time.Time
+	walletsFound    int64
+	workerRestarts  int64
+	// checksumCandidates and checksumConfirmed count the hits sent to checksum
+	// verifiers and those whose case matched
+	checksumCandidates int64
+	checksumConfirmed  int64
+	peakSpeed          float64
+	speedHistory       []SpeedSample
+	maxHistorySize     int
 }
 
 // AggregatedStats holds aggregated statistics from all workers
 type AggregatedStats struct {
-	TotalAttempts    int64         `json:"total_attempts"`
-	TotalSpeed       float64       `json:"total_speed"`
-	AverageSpeed     float64       `json:"average_speed"`
-	PeakSpeed        float64       `json:"peak_speed"`
-	ActiveWorkers    int           `json:"active_workers"`
-	HealthyWorkers   int           `json:"healthy_workers"`
-	TotalErrors      int           `json:"total_errors"`
-	ElapsedTime      time.Duration `json:"elapsed_time"`
-	LastUpdate       time.Time     `json:"last_update"`
-	ThreadEfficiency float64       `json:"thread_efficiency"`
-	ThreadBalance    float64       `json:"thread_balance"`
-	SpeedVariance    float64       `json:"speed_variance"`
+	TotalAttempts  int64   `json:"total_attempts"`
+	TotalSpeed     float64 `json:"total_speed"`
+	AverageSpeed   float64 `json:"average_speed"`
+	PeakSpeed      float64 `json:"peak_speed"`
+	ActiveWorkers  int     `json:"active_workers"`
+	HealthyWorkers int     `json:"healthy_workers"`
+	TotalErrors    int     `json:"total_errors"`
+	WorkerRestarts int64   `json:"worker_restarts"`
+	// ChecksumCandidates and ChecksumConfirmed count the case-insensitive hits
+	// checked by checksum verifiers and the ones that matched the case
+	ChecksumCandidates int64         `json:"checksum_candidates,omitempty"`
+	ChecksumConfirmed  int64         `json:"checksum_confirmed,omitempty"`
+	ElapsedTime        time.Duration `json:"elapsed_time"`
+	LastUpdate         time.Time     `json:"last_update"`
+	ThreadEfficiency   float64       `json:"thread_efficiency"`
+	ThreadBalance      float64       `json:"thread_balance"`
+	SpeedVariance      float64       `json:"speed_variance"`
 }
 
 // SpeedSample represents a speed measurement at a specific time
@@ -133,8 +147,16 @@ func (sc *StatsCollector) UpdateWorkerStats(stats WorkerStats) {
 	sc.mu.Lock()
 	defer sc.mu.Unlock()
 
+	// Workers report their attempts; the restarts are recorded by their supervisor
+	if prev, ok := sc.workerStats[stats.WorkerID]; ok && stats.Restarts == 0 {
+		stats.Restarts = prev.Restarts
+		if stats.LastError == "" {
+			stats.LastError = prev.LastError
+		}
+	}
 	sc.workerStats[stats.WorkerID] = stats
 	sc.lastUpdate = time.Now()
+	sc.lastProgress = sc.lastUpdate
 
 	// Recalculate aggregated stats
 	sc.recalculateAggregatedStatsUnsafe()
@@ -250,6 +272,71 @@ func (sc *StatsCollector) GetWorkerCount() int {
 	return len(sc.workerStats)
 }
 
+// GetLastProgress returns the time of the last stats update received from a worker
+func (sc *StatsCollector) GetLastProgress() time.Time {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.lastProgress
+}
+
+// RecordWalletFound increments the number of wallets found by the pool
+func (sc *StatsCollector) RecordWalletFound() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.walletsFound++
+}
+
+// GetWalletsFound returns the number of wallets found by the pool
+func (sc *StatsCollector) GetWalletsFound() int64 {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.walletsFound
+}
+
+// RecordWorkerRestart counts a worker restarted after panicking with reason
+func (sc *StatsCollector) RecordWorkerRestart(workerID int, reason string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	stats := sc.workerStats[workerID]
+	stats.WorkerID = workerID
+	stats.Restarts++
+	stats.LastError = reason
+	sc.workerStats[workerID] = stats
+	sc.workerRestarts++
+	sc.recalculateAggregatedStatsUnsafe()
+}
+
+// GetWorkerRestarts returns the number of worker restarts after panics
+func (sc *StatsCollector) GetWorkerRestarts() int64 {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.workerRestarts
+}
+
+// RecordChecksumCandidate counts a case-insensitive hit sent to a checksum verifier
+func (sc *StatsCollector) RecordChecksumCandidate() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.checksumCandidates++
+	sc.aggregatedStats.ChecksumCandidates = sc.checksumCandidates
+}
+
+// RecordChecksumConfirmed counts a candidate whose case matched the pattern
+func (sc *StatsCollector) RecordChecksumConfirmed() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.checksumConfirmed++
+	sc.aggregatedStats.ChecksumConfirmed = sc.checksumConfirmed
+}
+
+// GetChecksumVerification returns the candidates sent to checksum verifiers and
+// how many of them were confirmed
+func (sc *StatsCollector) GetChecksumVerification() (candidates, confirmed int64) {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.checksumCandidates, sc.checksumConfirmed
+}
+
 // GetElapsedTime returns the time elapsed since collection started
 func (sc *StatsCollector) GetElapsedTime() time.Duration {
 	return time.Since(sc.startTime)
@@ -263,6 +350,10 @@ func (sc *StatsCollector) Reset() {
 	sc.workerStats = make(map[int]WorkerStats)
 	sc.startTime = time.Now()
 	sc.lastUpdate = time.Now()
+	sc.lastProgress = time.Time{}
+	sc.walletsFound = 0
+	sc.workerRestarts = 0
+	sc.checksumCandidates, sc.checksumConfirmed = 0, 0
 	sc.peakSpeed = 0
 	sc.speedHistory = sc.speedHistory[:0]
 	sc.aggregatedStats = AggregatedStats{
@@ -327,18 +418,21 @@ func (sc *StatsCollector) recalculateAggregatedStatsUnsafe() {
 
 	// Update aggregated stats
 	sc.aggregatedStats = AggregatedStats{
-		TotalAttempts:    totalAttempts,
-		TotalSpeed:       totalSpeed,
-		AverageSpeed:     averageSpeed,
-		PeakSpeed:        sc.peakSpeed,
-		ActiveWorkers:    activeWorkers,
-		HealthyWorkers:   healthyWorkers,
-		TotalErrors:      totalErrors,
-		ElapsedTime:      elapsed,
-		LastUpdate:       now,
-		ThreadEfficiency: threadEfficiency,
-		ThreadBalance:    threadBalance,
-		SpeedVariance:    sc.calculateSpeedVariance(),
+		TotalAttempts:      totalAttempts,
+		TotalSpeed:         totalSpeed,
+		AverageSpeed:       averageSpeed,
+		PeakSpeed:          sc.peakSpeed,
+		ActiveWorkers:      activeWorkers,
+		HealthyWorkers:     healthyWorkers,
+		TotalErrors:        totalErrors,
+		WorkerRestarts:     sc.workerRestarts,
+		ChecksumCandidates: sc.checksumCandidates,
+		ChecksumConfirmed:  sc.checksumConfirmed,
+		ElapsedTime:        elapsed,
+		LastUpdate:         now,
+		ThreadEfficiency:   threadEfficiency,
+		ThreadBalance:      threadBalance,
+		SpeedVariance:      sc.calculateSpeedVariance(),
 	}
 }
 
@@ -0,0 +1,131 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/errors"
+)
+
+const (
+	// maxWorkerRestarts is how often one worker may panic during a search before the
+	// search fails instead of restarting it again
+	maxWorkerRestarts = 10
+	// workerRestartDelay spaces out the restarts of a worker that keeps panicking
+	workerRestartDelay = 10 * time.Millisecond
+)
+
+// superviseWorker runs a worker's loop and restarts it after a panic. Each panic is
+// logged, the buffers the worker held (if tracked) are wiped and dropped instead of
+// going back to the pool, and the restart is counted in the stats. cleanup, when set, runs after
+// every panic to settle other state the worker owned. After maxWorkerRestarts
+// panics the search fails with a worker error on errorCh.
+func (p *Pool) superviseWorker(ctx context.Context, workerID int, buffers *workerBuffers,
+	errorCh chan<- error, cleanup func(), run func()) {
+	for restarts := 1; ; restarts++ {
+		recovered := runRecovered(run)
+		if recovered == nil {
+			return
+		}
+		if buffers != nil {
+			buffers.discard()
+		}
+		if cleanup != nil {
+			cleanup()
+		}
+		giveUp := restarts > maxWorkerRestarts
+		p.recordWorkerPanic(workerID, restarts, recovered, giveUp)
+
+		if giveUp {
+			select {
+			case errorCh <- errors.NewWorkerError("generate_wallet",
+				fmt.Sprintf("worker %d panicked %d times, last: %v", workerID, restarts, recovered)):
+			default:
+			}
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(workerRestartDelay):
+		}
+	}
+}
+
+// runRecovered runs fn and returns the value it panicked with, or nil
+func runRecovered(fn func()) (recovered any) {
+	defer func() {
+		recovered = recover()
+	}()
+	fn()
+	return nil
+}
+
+// recordWorkerPanic logs a worker panic and counts the restart. The stack trace is
+// only printed with BLOCO_DEBUG, since it may show values from the key derivation.
+func (p *Pool) recordWorkerPanic(workerID, restarts int, recovered any, giveUp bool) {
+	reason := fmt.Sprintf("panic: %v", recovered)
+	if p.statsCollector != nil {
+		p.statsCollector.RecordWorkerRestart(workerID, reason)
+	}
+	if p.logger != nil {
+		context := map[string]interface{}{
+			"worker_id": workerID,
+			"restarts":  restarts,
+		}
+		if logErr := p.logger.LogError("worker_panic", fmt.Errorf("%s", reason), context); logErr != nil {
+			_ = logErr
+		}
+	}
+	action := "restarting it"
+	if giveUp {
+		action = fmt.Sprintf("giving up after %d crashes", restarts)
+	}
+	fmt.Fprintf(os.Stderr, "Warning: worker %d crashed (%s); %s\n", workerID, reason, action)
+	if os.Getenv("BLOCO_DEBUG") != "" {
+		fmt.Fprintf(os.Stderr, "DEBUG: worker %d stack:\n%s\n", workerID, debug.Stack())
+	}
+}
+
+// workerBuffers tracks the private key buffers a worker has taken from the crypto
+// pool, so a worker that panics can wipe them rather than return them
+type workerBuffers struct {
+	pool *crypto.CryptoPool
+	held [][]byte
+}
+
+// newWorkerBuffers tracks buffers taken from pool
+func newWorkerBuffers(pool *crypto.CryptoPool) *workerBuffers {
+	return &workerBuffers{pool: pool}
+}
+
+// get takes a private key buffer from the pool
+func (b *workerBuffers) get() []byte {
+	buf := b.pool.GetPrivateKeyBuffer()
+	b.held = append(b.held, buf)
+	return buf
+}
+
+// put returns a buffer taken with get to the pool
+func (b *workerBuffers) put(buf []byte) {
+	for i, held := range b.held {
+		if len(held) > 0 && len(buf) > 0 && &held[0] == &buf[0] {
+			b.held = append(b.held[:i], b.held[i+1:]...)
+			break
+		}
+	}
+	b.pool.PutPrivateKeyBuffer(buf)
+}
+
+// discard wipes the buffers still held and forgets them; the pool allocates
+// fresh ones in their place
+func (b *workerBuffers) discard() {
+	for _, buf := range b.held {
+		clear(buf)
+	}
+	b.held = b.held[:0]
+}
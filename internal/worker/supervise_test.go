@@ -0,0 +1,104 @@
+package worker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/wallet"
+)
+
+// panickingGenerator panics on the first calls and then defers to the real generator
+type panickingGenerator struct {
+	crypto.Generator
+	mu     sync.Mutex
+	panics int
+}
+
+func (g *panickingGenerator) GenerateAddressFromPrivateKey(privateKey []byte) (string, error) {
+	g.mu.Lock()
+	panicNow := g.panics > 0
+	if panicNow {
+		g.panics--
+	}
+	g.mu.Unlock()
+	if panicNow {
+		panic("corrupt pooled object")
+	}
+	return g.Generator.GenerateAddressFromPrivateKey(privateKey)
+}
+
+func TestPool_RestartsPanickingWorkers(t *testing.T) {
+	pool := NewPool(2, "ethereum")
+	pool.Start()
+	defer pool.Shutdown()
+	pool.generator = &panickingGenerator{Generator: pool.generator, panics: 3}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := pool.GenerateWalletWithContext(ctx, wallet.GenerationCriteria{Prefix: "a", Network: "ethereum"})
+	if err != nil {
+		t.Fatalf("search failed after worker panics: %v", err)
+	}
+	if !strings.HasPrefix(strings.TrimPrefix(result.Wallet.Address, "0x"), "a") {
+		t.Errorf("address %s does not match", result.Wallet.Address)
+	}
+
+	collector := pool.GetStatsCollector()
+	if got := collector.GetWorkerRestarts(); got != 3 {
+		t.Errorf("GetWorkerRestarts() = %d, want 3", got)
+	}
+	restarts := 0
+	for _, ws := range collector.GetWorkerStats() {
+		restarts += ws.Restarts
+		if ws.Restarts > 0 && !strings.Contains(ws.LastError, "corrupt pooled object") {
+			t.Errorf("worker %d last error = %q", ws.WorkerID, ws.LastError)
+		}
+	}
+	if restarts != 3 || collector.GetAggregatedStats().WorkerRestarts != 3 {
+		t.Errorf("per-worker restarts = %d, aggregated = %d", restarts, collector.GetAggregatedStats().WorkerRestarts)
+	}
+}
+
+func TestPool_FailsAfterTooManyRestarts(t *testing.T) {
+	pool := NewPool(1, "ethereum")
+	pool.Start()
+	defer pool.Shutdown()
+	pool.generator = &panickingGenerator{Generator: pool.generator, panics: 1 << 30}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := pool.GenerateWalletWithContext(ctx, wallet.GenerationCriteria{Prefix: "a", Network: "ethereum"})
+	if err == nil || !strings.Contains(err.Error(), "panicked") {
+		t.Fatalf("error = %v, want a worker panic error", err)
+	}
+	if got := pool.GetStatsCollector().GetWorkerRestarts(); got != maxWorkerRestarts+1 {
+		t.Errorf("GetWorkerRestarts() = %d, want %d", got, maxWorkerRestarts+1)
+	}
+}
+
+func TestWorkerBuffers_DiscardWipesHeldBuffers(t *testing.T) {
+	buffers := newWorkerBuffers(crypto.NewCryptoPool(crypto.DefaultPoolConfig()))
+	returned := buffers.get()
+	held := buffers.get()
+	for i := range held {
+		held[i] = 0xff
+	}
+	buffers.put(returned)
+	if len(buffers.held) != 1 {
+		t.Fatalf("held %d buffers, want 1", len(buffers.held))
+	}
+
+	buffers.discard()
+	for _, b := range held {
+		if b != 0 {
+			t.Fatal("discard left key material in a held buffer")
+		}
+	}
+	if len(buffers.held) != 0 {
+		t.Errorf("held %d buffers after discard", len(buffers.held))
+	}
+}
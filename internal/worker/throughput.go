@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// ThroughputEstimator measures the speed of the search actually running, for
+// ETAs. The cost of an attempt depends on the pattern (checksum validation,
+// how early a long prefix rejects a candidate), so the estimate is bootstrapped
+// from the first seconds of the run instead of a generic benchmark: during the
+// calibration window it is the average speed since the first observation, then
+// an exponentially weighted average seeded with that value.
+type ThroughputEstimator struct {
+	calibration time.Duration
+
+	mu            sync.Mutex
+	start         time.Time
+	startAttempts int64
+	last          time.Time
+	lastAttempts  int64
+	speed         float64
+	calibrated    bool
+}
+
+// NewThroughputEstimator creates an estimator with the given calibration window
+func NewThroughputEstimator(calibration time.Duration) *ThroughputEstimator {
+	return &ThroughputEstimator{calibration: calibration}
+}
+
+// Observe records the total attempts at t and returns the current estimate,
+// 0 until two observations are apart in time
+func (e *ThroughputEstimator) Observe(t time.Time, attempts int64) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.start.IsZero() {
+		e.start, e.startAttempts = t, attempts
+		e.last, e.lastAttempts = t, attempts
+		return 0
+	}
+	// Subscribers observe concurrently; an older or repeated sample adds nothing
+	if !t.After(e.last) || attempts < e.lastAttempts {
+		return e.speed
+	}
+
+	if !e.calibrated {
+		elapsed := t.Sub(e.start)
+		e.speed = float64(attempts-e.startAttempts) / elapsed.Seconds()
+		e.calibrated = elapsed >= e.calibration
+	} else {
+		dt := t.Sub(e.last)
+		rate := float64(attempts-e.lastAttempts) / dt.Seconds()
+		// The calibration window is also the time constant of the average
+		alpha := 1 - math.Exp(-dt.Seconds()/e.calibration.Seconds())
+		e.speed += alpha * (rate - e.speed)
+	}
+	e.last, e.lastAttempts = t, attempts
+	return e.speed
+}
+
+// Calibrated reports whether the calibration window has been measured
+func (e *ThroughputEstimator) Calibrated() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calibrated
+}
@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestThroughputEstimator(t *testing.T) {
+	e := NewThroughputEstimator(10 * time.Second)
+	start := time.Now()
+	at := func(seconds float64) time.Time { return start.Add(time.Duration(seconds * float64(time.Second))) }
+
+	if speed := e.Observe(start, 0); speed != 0 {
+		t.Errorf("first observation = %v, want 0", speed)
+	}
+	// A slow start: the bootstrap is the average since the first observation
+	if speed := e.Observe(at(1), 500); speed != 500 {
+		t.Errorf("after 1s = %v, want 500", speed)
+	}
+	if speed := e.Observe(at(10), 9500); speed != 950 || !e.Calibrated() {
+		t.Errorf("after the window = %v (calibrated %v), want 950", speed, e.Calibrated())
+	}
+
+	// Out-of-order samples are ignored
+	if speed := e.Observe(at(5), 4000); speed != 950 {
+		t.Errorf("stale sample changed the estimate to %v", speed)
+	}
+
+	// Afterwards the estimate converges on the steady rate of 1000 addr/s
+	attempts := int64(9500)
+	var speed float64
+	for s := 11; s <= 100; s++ {
+		attempts += 1000
+		speed = e.Observe(at(float64(s)), attempts)
+	}
+	if math.Abs(speed-1000) > 1 {
+		t.Errorf("steady state = %v, want about 1000", speed)
+	}
+}
+
+func TestProgressBroker_CalibrateETA(t *testing.T) {
+	stats := NewStatsCollector()
+	broker := NewProgressBroker(stats)
+	broker.CalibrateETA(time.Minute)
+	events := make(chan ProgressEvent, 16)
+	stop := broker.Subscribe(time.Millisecond, func(e ProgressEvent) { events <- e })
+	defer stop()
+
+	stats.UpdateWorkerStats(WorkerStats{WorkerID: 0, Attempts: 1000, Speed: 1e9})
+	for i := 0; i < 2; i++ {
+		<-events
+	}
+	time.Sleep(5 * time.Millisecond)
+	stats.UpdateWorkerStats(WorkerStats{WorkerID: 0, Attempts: 2000, Speed: 1e9})
+	for e := range events {
+		if e.Stats.TotalAttempts < 2000 {
+			continue
+		}
+		// The measured speed replaces the reported one when both are known
+		if e.ETASpeed <= 0 || e.ETASpeed == e.Stats.TotalSpeed {
+			t.Errorf("ETASpeed = %v, reported %v", e.ETASpeed, e.Stats.TotalSpeed)
+		}
+		break
+	}
+}
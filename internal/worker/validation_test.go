@@ -3,6 +3,8 @@ package worker
 import (
 	"strings"
 	"testing"
+
+	"bloco-eth/pkg/wallet"
 )
 
 // TestIsValidBlocoAddress_CaseInsensitive tests suffix validation without checksum
@@ -754,3 +756,61 @@ func TestMatchesCriteria_CaseSensitivity(t *testing.T) {
 		})
 	}
 }
+
+// TestMatchesWalletCriteriaCaseSensitive checks that --case-sensitive enforces the EIP-55 case
+func TestMatchesWalletCriteriaCaseSensitive(t *testing.T) {
+	// EIP-55 test vector: 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed
+	address := "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+
+	tests := []struct {
+		name          string
+		prefix        string
+		suffix        string
+		caseSensitive bool
+		want          bool
+	}{
+		{"any case without case-sensitive", "5aa", "aed", false, true},
+		{"exact checksum case", "5aA", "Aed", true, true},
+		{"wrong prefix case", "5aa", "", true, false},
+		{"wrong suffix case", "", "aed", true, false},
+		{"digits only", "5", "", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria := wallet.GenerationCriteria{
+				Network:       "ethereum",
+				Prefix:        tt.prefix,
+				Suffix:        tt.suffix,
+				IsChecksum:    true,
+				CaseSensitive: tt.caseSensitive,
+			}
+			if got := matchesWalletCriteria(address, criteria); got != tt.want {
+				t.Errorf("matchesWalletCriteria(%q, %q, %q) = %v, want %v", address, tt.prefix, tt.suffix, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMatchesWalletCriteriaRejectWords checks that reject words anywhere in the address fail the match
+func TestMatchesWalletCriteriaRejectWords(t *testing.T) {
+	address := "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+
+	tests := []struct {
+		name  string
+		words []string
+		want  bool
+	}{
+		{"no words", nil, true},
+		{"word absent", []string{"dead", "b00b"}, true},
+		{"word in the middle", []string{"dead", "c9b9"}, false},
+		{"word at the end", []string{"beaed"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			criteria := wallet.GenerationCriteria{Network: "ethereum", Prefix: "5a", RejectWords: tt.words}
+			if got := matchesWalletCriteria("0x"+address, criteria); got != tt.want {
+				t.Errorf("matchesWalletCriteria(%q, %v) = %v, want %v", address, tt.words, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// defaultWatchdogRestarts bounds the restarts of one search when none is configured
+const defaultWatchdogRestarts = 3
+
+// watchdogGrace is how long a cancelled search may take to return before it is
+// abandoned
+var watchdogGrace = 5 * time.Second
+
+// WatchdogConfig configures the stall watchdog of a worker pool
+type WatchdogConfig struct {
+	// Timeout is how long the total attempts may stay unchanged during a search
+	Timeout time.Duration
+	// Interval is how often the attempts are sampled (default Timeout/10)
+	Interval time.Duration
+	// Restart cancels a stalled search and starts it again
+	Restart bool
+	// MaxRestarts bounds the restarts of one search (default 3)
+	MaxRestarts int
+	// Dump receives the goroutine stacks of every stall (default os.Stderr)
+	Dump io.Writer
+	// OnStall is called for every stall detected
+	OnStall func(Stall)
+}
+
+// Stall describes a search whose attempts stopped advancing
+type Stall struct {
+	Attempts   int64         `json:"attempts"`
+	Stalled    time.Duration `json:"stalled"`
+	Goroutines int           `json:"goroutines"`
+	Restarting bool          `json:"restarting"`
+	Time       time.Time     `json:"time"`
+}
+
+// NewWatchdogPool watches every search of pool and reports the ones whose attempts
+// stop advancing for cfg.Timeout, restarting them when cfg.Restart is set. A zero
+// timeout returns pool unchanged.
+func NewWatchdogPool(pool WorkerPool, cfg WatchdogConfig) WorkerPool {
+	if cfg.Timeout <= 0 {
+		return pool
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = cfg.Timeout / 10
+	}
+	if cfg.MaxRestarts <= 0 {
+		cfg.MaxRestarts = defaultWatchdogRestarts
+	}
+	if cfg.Dump == nil {
+		cfg.Dump = os.Stderr
+	}
+	return &watchdogPool{WorkerPool: pool, cfg: cfg}
+}
+
+// watchdogPool is a worker pool whose searches are watched for stalls
+type watchdogPool struct {
+	WorkerPool
+	cfg    WatchdogConfig
+	dumpMu sync.Mutex
+}
+
+// searchOutcome is what a watched search returned
+type searchOutcome struct {
+	result *wallet.GenerationResult
+	err    error
+}
+
+// GenerateWalletWithContext runs the search under the watchdog
+func (p *watchdogPool) GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	for restarts := 0; ; restarts++ {
+		searchCtx, cancel := context.WithCancel(ctx)
+		done := make(chan searchOutcome, 1)
+		go func() {
+			result, err := p.WorkerPool.GenerateWalletWithContext(searchCtx, criteria)
+			done <- searchOutcome{result, err}
+		}()
+
+		outcome, stalled := p.watch(ctx, done, restarts < p.cfg.MaxRestarts)
+		cancel()
+		if !stalled {
+			return outcome.result, outcome.err
+		}
+
+		// A deadlocked search may never return; give it a moment, then move on
+		select {
+		case <-done:
+		case <-time.After(watchdogGrace):
+			fmt.Fprintf(p.cfg.Dump, "Watchdog: the stalled search did not stop within %v; starting a new one anyway\n", watchdogGrace)
+		case <-ctx.Done():
+			return nil, errors.NewCancellationError("generate_wallet", "generation cancelled")
+		}
+	}
+}
+
+// watch waits for the search to finish, sampling the attempts for stalls. It
+// returns stalled=true, without waiting further, when a stall is found and restart
+// is allowed.
+func (p *watchdogPool) watch(ctx context.Context, done <-chan searchOutcome, restart bool) (searchOutcome, bool) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	collector := p.GetStatsCollector()
+	last, lastChange, reported := collector.GetTotalAttempts(), time.Now(), false
+	for {
+		select {
+		case outcome := <-done:
+			return outcome, false
+		case <-ctx.Done():
+			// The search sees the same context and returns on its own, unless it
+			// is deadlocked
+			select {
+			case outcome := <-done:
+				return outcome, false
+			case <-time.After(watchdogGrace):
+				fmt.Fprintf(p.cfg.Dump, "Watchdog: the cancelled search did not stop within %v; abandoning it\n", watchdogGrace)
+				return searchOutcome{err: errors.NewCancellationError("generate_wallet", "generation cancelled")}, false
+			}
+		case now := <-ticker.C:
+			// A paused search makes no attempts without being stalled
+			if attempts := collector.GetTotalAttempts(); attempts != last || gateFrom(ctx).Paused() {
+				last, lastChange, reported = attempts, now, false
+				continue
+			}
+			if reported || now.Sub(lastChange) < p.cfg.Timeout {
+				continue
+			}
+			reported = true
+			restarting := restart && p.cfg.Restart
+			p.reportStall(Stall{
+				Attempts:   last,
+				Stalled:    now.Sub(lastChange),
+				Goroutines: runtime.NumGoroutine(),
+				Restarting: restarting,
+				Time:       now,
+			})
+			if restarting {
+				return searchOutcome{}, true
+			}
+		}
+	}
+}
+
+// reportStall dumps the goroutine stacks of a stall and notifies OnStall
+func (p *watchdogPool) reportStall(stall Stall) {
+	p.dumpMu.Lock()
+	fmt.Fprintf(p.cfg.Dump, "Watchdog: attempts stuck at %d for %v with %d goroutines; goroutine stacks follow\n",
+		stall.Attempts, stall.Stalled.Round(time.Second), stall.Goroutines)
+	if err := pprof.Lookup("goroutine").WriteTo(p.cfg.Dump, 2); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to dump goroutine stacks: %v\n", err)
+	}
+	p.dumpMu.Unlock()
+
+	if p.cfg.OnStall != nil {
+		p.cfg.OnStall(stall)
+	}
+}
@@ -0,0 +1,168 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// stallPool simulates searches whose attempts stop advancing: the first stalls
+// calls make some attempts and then hang until cancelled, or until hang is closed
+// when it is set
+type stallPool struct {
+	WorkerPool
+	stats    *StatsCollector
+	progress time.Duration
+	mu       sync.Mutex
+	calls    int
+	stalls   int
+	hang     chan struct{}
+}
+
+func (p *stallPool) GetStatsCollector() *StatsCollector {
+	return p.stats
+}
+
+func (p *stallPool) GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	p.mu.Lock()
+	p.calls++
+	stall := p.calls <= p.stalls
+	p.mu.Unlock()
+
+	// Each search starts its workers from zero attempts
+	p.stats.UpdateWorkerStats(WorkerStats{WorkerID: 0, Attempts: 100})
+	if stall && p.hang != nil {
+		<-p.hang
+		return nil, ctx.Err()
+	}
+	if stall {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	deadline := time.Now().Add(p.progress)
+	for attempts := int64(200); time.Now().Before(deadline); attempts += 100 {
+		p.stats.UpdateWorkerStats(WorkerStats{WorkerID: 0, Attempts: attempts})
+		time.Sleep(5 * time.Millisecond)
+	}
+	return &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: "0xabc"}, Attempts: 100}, nil
+}
+
+func watchdogTestConfig(dump *bytes.Buffer, stalls *[]Stall) WatchdogConfig {
+	return WatchdogConfig{
+		Timeout:  50 * time.Millisecond,
+		Interval: 5 * time.Millisecond,
+		Dump:     dump,
+		OnStall:  func(s Stall) { *stalls = append(*stalls, s) },
+	}
+}
+
+func TestWatchdog_RestartsStalledSearch(t *testing.T) {
+	inner := &stallPool{stats: NewStatsCollector(), stalls: 2}
+	var dump bytes.Buffer
+	var stalls []Stall
+	cfg := watchdogTestConfig(&dump, &stalls)
+	cfg.Restart = true
+
+	result, err := NewWatchdogPool(inner, cfg).GenerateWalletWithContext(context.Background(), wallet.GenerationCriteria{})
+	if err != nil || result.Wallet.Address != "0xabc" {
+		t.Fatalf("GenerateWalletWithContext() = %+v, %v", result, err)
+	}
+	if inner.calls != 3 || len(stalls) != 2 {
+		t.Fatalf("%d searches and %d stalls, want 3 and 2", inner.calls, len(stalls))
+	}
+	for _, s := range stalls {
+		if !s.Restarting || s.Attempts != 100 || s.Stalled < cfg.Timeout || s.Goroutines == 0 {
+			t.Errorf("stall = %+v", s)
+		}
+	}
+	// The dump holds the stack of the hung search
+	if !strings.Contains(dump.String(), "Watchdog: attempts stuck at 100") ||
+		!strings.Contains(dump.String(), "(*stallPool).GenerateWalletWithContext") {
+		t.Errorf("dump lacks the stalled goroutine:\n%s", dump.String())
+	}
+}
+
+func TestWatchdog_ReportsWithoutRestarting(t *testing.T) {
+	inner := &stallPool{stats: NewStatsCollector(), stalls: 1}
+	var dump bytes.Buffer
+	var stalls []Stall
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := NewWatchdogPool(inner, watchdogTestConfig(&dump, &stalls)).GenerateWalletWithContext(ctx, wallet.GenerationCriteria{})
+	if err == nil {
+		t.Fatal("a stalled search without --watchdog-restart returned a result")
+	}
+	// A stall is reported once, not on every sample
+	if inner.calls != 1 || len(stalls) != 1 || stalls[0].Restarting {
+		t.Errorf("%d searches, stalls %+v", inner.calls, stalls)
+	}
+}
+
+func TestWatchdog_GivesUpRestarting(t *testing.T) {
+	inner := &stallPool{stats: NewStatsCollector(), stalls: 10}
+	var dump bytes.Buffer
+	var stalls []Stall
+	cfg := watchdogTestConfig(&dump, &stalls)
+	cfg.Restart, cfg.MaxRestarts = true, 2
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := NewWatchdogPool(inner, cfg).GenerateWalletWithContext(ctx, wallet.GenerationCriteria{}); err == nil {
+		t.Fatal("an always stalling search returned a result")
+	}
+	if inner.calls != 3 || len(stalls) != 3 || !stalls[1].Restarting || stalls[2].Restarting {
+		t.Errorf("%d searches, stalls %+v", inner.calls, stalls)
+	}
+}
+
+func TestWatchdog_IgnoresAdvancingSearch(t *testing.T) {
+	inner := &stallPool{stats: NewStatsCollector(), progress: 300 * time.Millisecond}
+	var dump bytes.Buffer
+	var stalls []Stall
+	cfg := watchdogTestConfig(&dump, &stalls)
+	cfg.Restart = true
+
+	if _, err := NewWatchdogPool(inner, cfg).GenerateWalletWithContext(context.Background(), wallet.GenerationCriteria{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(stalls) != 0 || dump.Len() != 0 || inner.calls != 1 {
+		t.Errorf("%d stalls in %d searches of an advancing search", len(stalls), inner.calls)
+	}
+
+	if NewWatchdogPool(inner, WatchdogConfig{}) != WorkerPool(inner) {
+		t.Error("pool wrapped without a timeout")
+	}
+}
+
+func TestWatchdog_AbandonsDeadlockedSearchOnCancel(t *testing.T) {
+	defer func(grace time.Duration) { watchdogGrace = grace }(watchdogGrace)
+	watchdogGrace = 20 * time.Millisecond
+
+	inner := &stallPool{stats: NewStatsCollector(), stalls: 1, hang: make(chan struct{})}
+	defer close(inner.hang)
+	var dump bytes.Buffer
+	var stalls []Stall
+	cfg := watchdogTestConfig(&dump, &stalls)
+	cfg.Timeout = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := NewWatchdogPool(inner, cfg).GenerateWalletWithContext(ctx, wallet.GenerationCriteria{})
+	if !errors.IsErrorType(err, errors.ErrorTypeCancellation) {
+		t.Fatalf("GenerateWalletWithContext() = %v, want a cancellation error", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("returned after %v, want about the grace period", elapsed)
+	}
+	if !strings.Contains(dump.String(), "did not stop within") {
+		t.Errorf("dump = %q", dump.String())
+	}
+}
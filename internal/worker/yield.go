@@ -0,0 +1,7 @@
+//go:build !js
+
+package worker
+
+// yieldWorker lets other work run between a worker's stats updates; native
+// builds are preemptively scheduled and need nothing
+func yieldWorker() {}
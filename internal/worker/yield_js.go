@@ -0,0 +1,10 @@
+package worker
+
+import "time"
+
+// yieldWorker parks the worker briefly so the single-threaded js/wasm runtime
+// returns to the JavaScript event loop, letting progress callbacks and cancel
+// messages through
+func yieldWorker() {
+	time.Sleep(time.Millisecond)
+}
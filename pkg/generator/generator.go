@@ -0,0 +1,186 @@
+// Package generator embeds vanity wallet generation in other Go programs:
+//
+//	g, err := generator.New(generator.Options{Count: 3})
+//	if err != nil {
+//		return err
+//	}
+//	results, err := g.Generate(ctx, wallet.GenerationCriteria{Prefix: "abc"})
+//	if err != nil {
+//		return err
+//	}
+//	for r := range results {
+//		if r.Err != nil {
+//			return r.Err
+//		}
+//		fmt.Println(r.Wallet.Address)
+//	}
+//
+// The bloco-eth command line uses the same API for its searches.
+package generator
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"bloco-eth/internal/config"
+	"bloco-eth/internal/crypto"
+	"bloco-eth/internal/worker"
+	"bloco-eth/pkg/errors"
+	"bloco-eth/pkg/wallet"
+)
+
+// defaultProgressInterval is how often OnProgress is called when no interval is set
+const defaultProgressInterval = time.Second
+
+// Searcher finds one wallet matching the criteria
+type Searcher interface {
+	GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error)
+}
+
+// statsSource is a Searcher whose statistics can drive OnProgress
+type statsSource interface {
+	GetStatsCollector() *worker.StatsCollector
+}
+
+// Options configures a Generator
+type Options struct {
+	// Threads is the number of worker goroutines of the default Searcher (default: the number of CPUs)
+	Threads int
+	// Count is the number of searches to run; 0 keeps searching until the context is done
+	Count int
+	// Searcher runs each search (default: a worker pool for the criteria's network)
+	Searcher Searcher
+	// ProgressInterval is how often OnProgress is called (default 1s)
+	ProgressInterval time.Duration
+	// OnProgress receives the progress of a Generate call on a goroutine of its own
+	OnProgress func(Progress)
+}
+
+// Result is the outcome of one search
+type Result struct {
+	// Index numbers the searches of a Generate call from 1
+	Index    int
+	Wallet   *wallet.Wallet
+	Attempts int64
+	Duration time.Duration
+	WorkerID int
+	// Err is why the search failed; Wallet is nil when it is set
+	Err error
+}
+
+// GenerationResult returns the result of a successful search in the wallet package form
+func (r Result) GenerationResult() *wallet.GenerationResult {
+	if r.Wallet == nil {
+		return nil
+	}
+	return &wallet.GenerationResult{Wallet: r.Wallet, Attempts: r.Attempts, Duration: r.Duration, WorkerID: r.WorkerID}
+}
+
+// Progress is a periodic report of a Generate call
+type Progress struct {
+	Attempts int64
+	Speed    float64
+	Found    int
+	Elapsed  time.Duration
+}
+
+// Generator searches for vanity wallets
+type Generator struct {
+	opts Options
+}
+
+// New creates a generator with the given options
+func New(opts Options) (*Generator, error) {
+	if opts.Threads < 0 {
+		return nil, errors.NewValidationError("generator", "threads cannot be negative")
+	}
+	if opts.Count < 0 {
+		return nil, errors.NewValidationError("generator", "count cannot be negative")
+	}
+	if opts.ProgressInterval < 0 {
+		return nil, errors.NewValidationError("generator", "progress interval cannot be negative")
+	}
+	if opts.Threads == 0 {
+		opts.Threads = config.DetectCPUCount()
+	}
+	if opts.ProgressInterval == 0 {
+		opts.ProgressInterval = defaultProgressInterval
+	}
+	return &Generator{opts: opts}, nil
+}
+
+// Generate runs the searches in the background and streams their results. A failed
+// search is delivered with Err and the next one started, except after the context
+// is done or the keyspace is exhausted. The channel is closed when the searches end;
+// read it until then or cancel ctx.
+func (g *Generator) Generate(ctx context.Context, criteria wallet.GenerationCriteria) (<-chan Result, error) {
+	if err := criteria.Validate(); err != nil {
+		return nil, err
+	}
+
+	searcher := g.opts.Searcher
+	var pool *worker.Pool
+	if searcher == nil {
+		cfg := config.DefaultConfig()
+		cfg.Logging.Enabled = false
+		pool = worker.NewPoolWithConfig(g.opts.Threads, cfg, criteria.Network)
+		if err := pool.Start(); err != nil {
+			return nil, errors.WrapError(err, errors.ErrorTypeWorker, "generator", "failed to start worker pool")
+		}
+		searcher = pool
+	}
+
+	var progress *worker.ProgressBroker
+	if g.opts.OnProgress != nil {
+		var stats *worker.StatsCollector
+		if source, ok := searcher.(statsSource); ok {
+			stats = source.GetStatsCollector()
+		}
+		progress = worker.NewProgressBroker(stats)
+		onProgress := g.opts.OnProgress
+		progress.Subscribe(g.opts.ProgressInterval, func(e worker.ProgressEvent) {
+			if e.Type == worker.ProgressTick {
+				onProgress(Progress{Attempts: e.Stats.TotalAttempts, Speed: e.Stats.TotalSpeed, Found: e.Found, Elapsed: e.Elapsed})
+			}
+		})
+	}
+
+	results := make(chan Result, 1)
+	go func() {
+		defer close(results)
+		defer progress.Close(nil)
+		if pool != nil {
+			defer func() { _ = pool.Shutdown() }()
+		}
+
+		for i := 1; g.opts.Count == 0 || i <= g.opts.Count; i++ {
+			if ctx.Err() != nil {
+				return
+			}
+			r := Result{Index: i}
+			found, err := searcher.GenerateWalletWithContext(ctx, criteria)
+			if err != nil {
+				r.Err = err
+			} else {
+				r.Wallet, r.Attempts, r.Duration, r.WorkerID = found.Wallet, found.Attempts, found.Duration, found.WorkerID
+				progress.PublishWallet(found)
+			}
+
+			select {
+			case results <- r:
+			case <-ctx.Done():
+				// Still hand over a wallet found as the context ended when there is room
+				select {
+				case results <- r:
+				default:
+				}
+				return
+			}
+			if err != nil && (ctx.Err() != nil || stderrors.Is(err, crypto.ErrKeyRangeExhausted)) {
+				return
+			}
+		}
+	}()
+	return results, nil
+}
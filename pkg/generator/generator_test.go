@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"bloco-eth/internal/crypto"
+	"bloco-eth/pkg/wallet"
+)
+
+func TestGenerate(t *testing.T) {
+	var mu sync.Mutex
+	var progress []Progress
+	g, err := New(Options{
+		Threads:          2,
+		Count:            3,
+		ProgressInterval: time.Millisecond,
+		OnProgress: func(p Progress) {
+			mu.Lock()
+			progress = append(progress, p)
+			mu.Unlock()
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	results, err := g.Generate(ctx, wallet.GenerationCriteria{Prefix: "ab"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := 0
+	for r := range results {
+		if r.Err != nil {
+			t.Fatalf("search %d: %v", r.Index, r.Err)
+		}
+		found++
+		if r.Index != found || !strings.HasPrefix(strings.TrimPrefix(r.Wallet.Address, "0x"), "ab") || r.Attempts <= 0 {
+			t.Errorf("result = %+v", r)
+		}
+		if gr := r.GenerationResult(); gr.Wallet != r.Wallet || gr.Attempts != r.Attempts {
+			t.Errorf("GenerationResult() = %+v", gr)
+		}
+	}
+	if found != 3 {
+		t.Errorf("found %d wallets, want 3", found)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := 1; i < len(progress); i++ {
+		if progress[i].Found < progress[i-1].Found || progress[i].Elapsed < progress[i-1].Elapsed {
+			t.Errorf("progress went backwards: %+v then %+v", progress[i-1], progress[i])
+		}
+	}
+}
+
+func TestGenerate_Validation(t *testing.T) {
+	if _, err := New(Options{Count: -1}); err == nil {
+		t.Error("New() accepted a negative count")
+	}
+	g, err := New(Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Generate(context.Background(), wallet.GenerationCriteria{Prefix: "xyz"}); err == nil {
+		t.Error("Generate() accepted a non-hex prefix")
+	}
+}
+
+// scriptedSearcher fails the searches listed in fail and finds a wallet otherwise
+type scriptedSearcher struct {
+	calls int
+	fail  map[int]error
+}
+
+func (s *scriptedSearcher) GenerateWalletWithContext(ctx context.Context, criteria wallet.GenerationCriteria) (*wallet.GenerationResult, error) {
+	s.calls++
+	if err := s.fail[s.calls]; err != nil {
+		return nil, err
+	}
+	return &wallet.GenerationResult{Wallet: &wallet.Wallet{Address: fmt.Sprintf("0x%d", s.calls)}, Attempts: 1}, nil
+}
+
+func TestGenerate_Failures(t *testing.T) {
+	searcher := &scriptedSearcher{fail: map[int]error{2: errors.New("transient"), 4: crypto.ErrKeyRangeExhausted}}
+	g, _ := New(Options{Count: 10, Searcher: searcher})
+	results, err := g.Generate(context.Background(), wallet.GenerationCriteria{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for r := range results {
+		if r.Err != nil {
+			got = append(got, r.Err.Error())
+		} else {
+			got = append(got, r.Wallet.Address)
+		}
+	}
+	// A failed search is reported and skipped; an exhausted keyspace ends the stream
+	want := []string{"0x1", "transient", "0x3", crypto.ErrKeyRangeExhausted.Error()}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+}
+
+func TestGenerate_Cancel(t *testing.T) {
+	g, _ := New(Options{Threads: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := g.Generate(ctx, wallet.GenerationCriteria{Prefix: "abcdef01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range results {
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("results not closed after cancellation")
+	}
+}
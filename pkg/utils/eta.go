@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"bloco-eth/pkg/vanitymath"
+)
+
+// DefaultETAPercentiles are the probabilities shown in progress output
+var DefaultETAPercentiles = []float64{50, 90, 99}
+
+// ETAPercentile is the time until the chance of having found every wallet reaches Percent
+type ETAPercentile struct {
+	// Percent is the target probability, 0 < Percent < 100
+	Percent float64 `json:"percent"`
+	// Attempts is the total attempts needed for Percent, or -1 if nearly impossible
+	Attempts int64 `json:"attempts"`
+	// Remaining is the time left at the current speed, 0 once reached, -1 if unknown.
+	// Times longer than a Duration holds are capped at math.MaxInt64.
+	Remaining time.Duration `json:"remaining"`
+}
+
+// Reached reports whether the attempts for this percentile have already been made
+func (e ETAPercentile) Reached() bool {
+	return e.Attempts >= 0 && e.Remaining == 0
+}
+
+// ParsePercentiles parses a comma-separated list of percentages such as "50,90,99"
+func ParsePercentiles(value string) ([]float64, error) {
+	var percents []float64
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(field), "%"))
+		if field == "" {
+			continue
+		}
+		percent, err := strconv.ParseFloat(field, 64)
+		if err != nil || percent <= 0 || percent >= 100 {
+			return nil, fmt.Errorf("invalid percentile %q: must be greater than 0 and less than 100", field)
+		}
+		percents = append(percents, percent)
+	}
+	if len(percents) == 0 {
+		return nil, fmt.Errorf("no percentiles given")
+	}
+	sort.Float64s(percents)
+	return percents, nil
+}
+
+// CalculateAttemptsForWallets calculates the total attempts needed to find wallets
+// matches with the given probability. Returns -1 if nearly impossible.
+//
+// Deprecated: use vanitymath.AttemptsForWallets.
+func CalculateAttemptsForWallets(difficulty float64, wallets int, probability float64) int64 {
+	return vanitymath.AttemptsForWallets(difficulty, wallets, probability)
+}
+
+// CalculateETAPercentiles returns the attempts needed for each percentile, with
+// Remaining left unknown until EstimateETAPercentiles is applied
+func CalculateETAPercentiles(difficulty float64, wallets int, percents []float64) []ETAPercentile {
+	etas := make([]ETAPercentile, len(percents))
+	for i, percent := range percents {
+		etas[i] = ETAPercentile{
+			Percent:   percent,
+			Attempts:  vanitymath.AttemptsForWallets(difficulty, wallets, percent/100),
+			Remaining: -1,
+		}
+	}
+	return etas
+}
+
+// EstimateETAPercentiles fills in the time left for each percentile after attempts at speed
+func EstimateETAPercentiles(targets []ETAPercentile, attempts int64, speed float64) []ETAPercentile {
+	etas := make([]ETAPercentile, len(targets))
+	for i, target := range targets {
+		etas[i] = target
+		switch {
+		case target.Attempts < 0:
+			etas[i].Remaining = -1
+		case attempts >= target.Attempts:
+			etas[i].Remaining = 0
+		case speed > 0:
+			etas[i].Remaining = remainingDuration(float64(target.Attempts-attempts) / speed)
+		default:
+			etas[i].Remaining = -1
+		}
+	}
+	return etas
+}
+
+// remainingDuration converts seconds to a Duration, capped at the longest one, which
+// FormatDuration shows as thousands of years; a plain conversion would overflow
+// for patterns of 12 or more characters
+func remainingDuration(secs float64) time.Duration {
+	if secs >= math.MaxInt64/float64(time.Second) {
+		return math.MaxInt64
+	}
+	return time.Duration(secs * float64(time.Second))
+}
+
+// FormatETAPercentiles formats percentiles as "50%: 2m 3s · 90%: 6m 49s · 99%: 13m 38s"
+func FormatETAPercentiles(etas []ETAPercentile) string {
+	parts := make([]string, len(etas))
+	for i, eta := range etas {
+		value := "?"
+		switch {
+		case eta.Attempts < 0:
+			value = "never"
+		case eta.Reached():
+			value = "reached"
+		case eta.Remaining > 0:
+			value = FormatDuration(eta.Remaining)
+		}
+		parts[i] = fmt.Sprintf("%s%%: %s", strconv.FormatFloat(eta.Percent, 'f', -1, 64), value)
+	}
+	return strings.Join(parts, " · ")
+}
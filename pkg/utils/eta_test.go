@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParsePercentiles(t *testing.T) {
+	got, err := ParsePercentiles("99, 50%,90")
+	if err != nil {
+		t.Fatalf("ParsePercentiles failed: %v", err)
+	}
+	if len(got) != 3 || got[0] != 50 || got[1] != 90 || got[2] != 99 {
+		t.Errorf("got %v, want sorted [50 90 99]", got)
+	}
+	for _, bad := range []string{"", "0", "100", "abc", "50,-1"} {
+		if _, err := ParsePercentiles(bad); err == nil {
+			t.Errorf("ParsePercentiles(%q) should fail", bad)
+		}
+	}
+}
+
+func TestCalculateAttemptsForWallets(t *testing.T) {
+	difficulty := 65536.0
+	if got, want := CalculateAttemptsForWallets(difficulty, 1, 0.5), CalculateAttemptsForProbability(difficulty, 0.5); got != want {
+		t.Errorf("one wallet = %d, want the geometric %d", got, want)
+	}
+	// The median of Gamma(2, 1) is about 1.67835
+	got := float64(CalculateAttemptsForWallets(difficulty, 2, 0.5))
+	if want := 1.67835 * difficulty; math.Abs(got-want)/want > 1e-4 {
+		t.Errorf("two wallets at 50%% = %v, want ~%v", got, want)
+	}
+	// Many wallets concentrate around wallets * difficulty
+	got = float64(CalculateAttemptsForWallets(difficulty, 1000, 0.5))
+	if want := 1000 * difficulty; math.Abs(got-want)/want > 0.01 {
+		t.Errorf("1000 wallets at 50%% = %v, want ~%v", got, want)
+	}
+	if p90, p50 := CalculateAttemptsForWallets(difficulty, 3, 0.9), CalculateAttemptsForWallets(difficulty, 3, 0.5); p90 <= p50 {
+		t.Errorf("90%% (%d) should need more attempts than 50%% (%d)", p90, p50)
+	}
+}
+
+func TestEstimateETAPercentiles(t *testing.T) {
+	targets := CalculateETAPercentiles(4096, 1, DefaultETAPercentiles)
+	etas := EstimateETAPercentiles(targets, targets[0].Attempts, 100)
+	if !etas[0].Reached() {
+		t.Errorf("50%% should be reached: %+v", etas[0])
+	}
+	want := time.Duration(float64(targets[1].Attempts-targets[0].Attempts) / 100 * float64(time.Second))
+	if etas[1].Remaining != want {
+		t.Errorf("90%% remaining = %v, want %v", etas[1].Remaining, want)
+	}
+	if unknown := EstimateETAPercentiles(targets, 0, 0); unknown[2].Remaining != -1 {
+		t.Errorf("remaining without speed = %v, want unknown", unknown[2].Remaining)
+	}
+	if got := FormatETAPercentiles(etas[:1]); got != "50%: reached" {
+		t.Errorf("FormatETAPercentiles = %q", got)
+	}
+}
+
+func TestEstimateETAPercentiles_LongPattern(t *testing.T) {
+	// 12 hex characters at 50k addr/s take longer than a Duration holds at 99%
+	targets := CalculateETAPercentiles(math.Pow(16, 12), 1, DefaultETAPercentiles)
+	etas := EstimateETAPercentiles(targets, 0, 50000)
+	for _, eta := range etas {
+		if eta.Remaining <= 0 {
+			t.Errorf("%v%% remaining = %v, want a positive capped duration", eta.Percent, eta.Remaining)
+		}
+	}
+	if etas[2].Remaining != math.MaxInt64 {
+		t.Errorf("99%% remaining = %v, want the cap", etas[2].Remaining)
+	}
+	want := "50%: 123.7y · 90%: Thousands of years · 99%: Thousands of years"
+	if got := FormatETAPercentiles(etas); got != want {
+		t.Errorf("FormatETAPercentiles = %q, want %q", got, want)
+	}
+}
@@ -2,9 +2,11 @@ package utils
 
 import (
 	"fmt"
-	"math"
 	"strconv"
+	"strings"
 	"time"
+
+	"bloco-eth/pkg/vanitymath"
 )
 
 // FormatLargeNumber formats large numbers with space separators for thousands
@@ -285,44 +287,72 @@ func FormatTable(headers []string, rows [][]string, padding int) string {
 	return result
 }
 
-// CalculateDifficulty calculates the difficulty of finding a bloco address
-func CalculateDifficulty(prefix, suffix string, isChecksum bool) float64 {
-	pattern := prefix + suffix
-	baseDifficulty := math.Pow(16, float64(len(pattern)))
+// DifficultyBreakdown splits pattern difficulty into the hex match and the case requirement
+//
+// Deprecated: use vanitymath.DifficultyBreakdown.
+type DifficultyBreakdown = vanitymath.DifficultyBreakdown
 
-	if !isChecksum {
-		return baseDifficulty
-	}
-
-	// Count letters (a-f, A-F) in the pattern for checksum calculation
-	letterCount := 0
-	for _, char := range pattern {
-		if (char >= 'a' && char <= 'f') || (char >= 'A' && char <= 'F') {
-			letterCount++
-		}
-	}
+// CalculateDifficultyBreakdown computes difficulty per pattern character
+//
+// Deprecated: use vanitymath.Breakdown.
+func CalculateDifficultyBreakdown(prefix, suffix string, caseSensitive bool) DifficultyBreakdown {
+	return vanitymath.Breakdown(prefix, suffix, caseSensitive)
+}
 
-	return baseDifficulty * math.Pow(2, float64(letterCount))
+// CalculateDifficulty calculates the difficulty of finding a bloco address
+//
+// Deprecated: use vanitymath.Difficulty.
+func CalculateDifficulty(prefix, suffix string, caseSensitive bool) float64 {
+	return vanitymath.Difficulty(prefix, suffix, caseSensitive)
 }
 
 // CalculateProbability calculates the probability of finding an address after N attempts
+//
+// Deprecated: use vanitymath.Probability.
 func CalculateProbability(difficulty float64, attempts int64) float64 {
-	if difficulty <= 0 {
-		return 0
-	}
-	return 1 - math.Pow(1-1/difficulty, float64(attempts))
+	return vanitymath.Probability(difficulty, attempts)
 }
 
 // CalculateProbability50 calculates how many attempts are needed for 50% probability
+//
+// Deprecated: use vanitymath.Attempts50.
 func CalculateProbability50(difficulty float64) int64 {
-	if difficulty <= 0 {
-		return 0
-	}
-	result := math.Log(0.5) / math.Log(1-1/difficulty)
-	if math.IsInf(result, 0) || result < 0 {
-		return -1 // Nearly impossible
+	return vanitymath.Attempts50(difficulty)
+}
+
+// CalculateAttemptsForProbability calculates the number of attempts needed to reach
+// the given success probability (0 < probability < 1). Returns -1 if nearly impossible.
+//
+// Deprecated: use vanitymath.AttemptsForProbability.
+func CalculateAttemptsForProbability(difficulty, probability float64) int64 {
+	return vanitymath.AttemptsForProbability(difficulty, probability)
+}
+
+// Address alphabets of the supported networks
+const (
+	HexAlphabet    = "0123456789abcdef"
+	Base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+// AddressShape returns the alphabet and the number of random characters of a
+// network's addresses, leaving out fixed parts such as 0x or Bitcoin's leading 1
+func AddressShape(network string) (alphabet string, length int) {
+	switch strings.ToLower(network) {
+	case "bitcoin":
+		return Base58Alphabet, 33
+	case "solana":
+		return Base58Alphabet, 44
+	default:
+		return HexAlphabet, 40
 	}
-	return int64(math.Floor(result))
+}
+
+// CalculateRejectionProbability estimates the share of random addresses that contain
+// at least one of words
+//
+// Deprecated: use vanitymath.RejectionProbability.
+func CalculateRejectionProbability(words []string, length int, alphabet string) float64 {
+	return vanitymath.RejectionProbability(words, length, alphabet)
 }
 
 // IsValidHex checks if a string contains only valid hex characters
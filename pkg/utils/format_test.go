@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateDifficultyBreakdown(t *testing.T) {
+	tests := []struct {
+		name          string
+		prefix        string
+		suffix        string
+		caseSensitive bool
+		base          float64
+		caseFactor    float64
+		caseChars     int
+	}{
+		{"case ignored", "dead", "", false, 65536, 1, 0},
+		{"uppercase letters", "DEAD", "", true, 65536, 16, 4},
+		{"lowercase letters have a required case too", "dead", "", true, 65536, 16, 4},
+		{"digits have no case", "1234", "", true, 65536, 1, 0},
+		{"mixed prefix and suffix", "De1", "a0", true, 1048576, 8, 3},
+		{"empty pattern", "", "", true, 1, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateDifficultyBreakdown(tt.prefix, tt.suffix, tt.caseSensitive)
+			if got.Base != tt.base || got.CaseFactor != tt.caseFactor || got.CaseSensitiveChars != tt.caseChars {
+				t.Errorf("breakdown = %+v, want base %v, case factor %v, %d case-sensitive chars",
+					got, tt.base, tt.caseFactor, tt.caseChars)
+			}
+			if got.Total != got.Base*got.CaseFactor {
+				t.Errorf("total %v != base * case factor", got.Total)
+			}
+			if CalculateDifficulty(tt.prefix, tt.suffix, tt.caseSensitive) != got.Total {
+				t.Error("CalculateDifficulty disagrees with the breakdown")
+			}
+		})
+	}
+}
+
+func TestCalculateRejectionProbability(t *testing.T) {
+	alphabet, length := AddressShape("ethereum")
+	if alphabet != HexAlphabet || length != 40 {
+		t.Fatalf("AddressShape(ethereum) = %q, %d", alphabet, length)
+	}
+
+	// One 4-character word fits in 37 positions, each matching with probability 1/16^4
+	want := 1 - math.Pow(1-1/65536.0, 37)
+	if got := CalculateRejectionProbability([]string{"dead"}, 40, HexAlphabet); math.Abs(got-want) > 1e-12 {
+		t.Errorf("rejection of dead = %v, want %v", got, want)
+	}
+	if got := CalculateRejectionProbability([]string{"dead", "DEAD"}, 40, HexAlphabet); got <= want {
+		t.Errorf("a second word should raise the rejection rate, got %v", got)
+	}
+	if got := CalculateRejectionProbability([]string{"shit"}, 40, HexAlphabet); got != 0 {
+		t.Errorf("a word outside the hex alphabet should never match, got %v", got)
+	}
+	if got := CalculateRejectionProbability([]string{"deadbeef"}, 4, HexAlphabet); got != 0 {
+		t.Errorf("a word longer than the address should never match, got %v", got)
+	}
+
+	// Base58 has both cases of most letters, so a lowercase word matches either
+	if got, lower := CalculateRejectionProbability([]string{"ab"}, 44, Base58Alphabet), 1-math.Pow(1-4.0/(58*58), 43); math.Abs(got-lower) > 1e-12 {
+		t.Errorf("base58 rejection of ab = %v, want %v", got, lower)
+	}
+}
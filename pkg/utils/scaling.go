@@ -0,0 +1,70 @@
+package utils
+
+import "math"
+
+// ThreadScaling is the measured speed at one thread count, relative to a single thread
+type ThreadScaling struct {
+	Threads int     `json:"threads"`
+	Speed   float64 `json:"speed"`
+	// Speedup is Speed over the single-thread speed
+	Speedup float64 `json:"speedup"`
+	// Efficiency is Speedup per thread, 1 for perfect scaling
+	Efficiency float64 `json:"efficiency"`
+}
+
+// CalculateThreadScaling relates each speed to the single-thread speed, which is the
+// speed measured with 1 thread or, failing that, the best per-thread speed
+func CalculateThreadScaling(threads []int, speeds []float64) []ThreadScaling {
+	base := 0.0
+	for i, n := range threads {
+		if n == 1 {
+			base = speeds[i]
+			break
+		}
+		base = max(base, speeds[i]/float64(n))
+	}
+
+	scaling := make([]ThreadScaling, len(threads))
+	for i, n := range threads {
+		scaling[i] = ThreadScaling{Threads: n, Speed: speeds[i]}
+		if base > 0 && n > 0 {
+			scaling[i].Speedup = speeds[i] / base
+			scaling[i].Efficiency = scaling[i].Speedup / float64(n)
+		}
+	}
+	return scaling
+}
+
+// FitAmdahlSerialFraction fits Amdahl's law, speedup(n) = 1 / (s + (1-s)/n), to the
+// measurements by least squares on 1/speedup and returns the serial fraction s in [0, 1];
+// it returns -1 without a measurement above one thread
+func FitAmdahlSerialFraction(scaling []ThreadScaling) float64 {
+	var sxy, sxx float64
+	for _, point := range scaling {
+		if point.Threads < 2 || point.Speedup <= 0 {
+			continue
+		}
+		// 1/speedup - 1/n = s * (1 - 1/n)
+		x := 1 - 1/float64(point.Threads)
+		y := 1/point.Speedup - 1/float64(point.Threads)
+		sxy += x * y
+		sxx += x * x
+	}
+	if sxx == 0 {
+		return -1
+	}
+	return math.Max(0, math.Min(1, sxy/sxx))
+}
+
+// AmdahlSpeedup is the speedup Amdahl's law predicts on threads with serial fraction s
+func AmdahlSpeedup(serial float64, threads int) float64 {
+	return 1 / (serial + (1-serial)/float64(threads))
+}
+
+// AmdahlLimit is the speedup no thread count can exceed, +Inf for a fully parallel workload
+func AmdahlLimit(serial float64) float64 {
+	if serial <= 0 {
+		return math.Inf(1)
+	}
+	return 1 / serial
+}
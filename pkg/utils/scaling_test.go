@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalculateThreadScaling(t *testing.T) {
+	scaling := CalculateThreadScaling([]int{1, 2, 4}, []float64{1000, 1800, 3000})
+	if scaling[0].Speedup != 1 || scaling[1].Speedup != 1.8 || scaling[2].Efficiency != 0.75 {
+		t.Errorf("scaling = %+v", scaling)
+	}
+
+	// Without a single-thread run, the best per-thread speed stands in for it
+	scaling = CalculateThreadScaling([]int{2, 4}, []float64{2000, 3000})
+	if scaling[0].Speedup != 2 || scaling[1].Speedup != 3 {
+		t.Errorf("scaling without 1 thread = %+v", scaling)
+	}
+}
+
+func TestFitAmdahlSerialFraction(t *testing.T) {
+	const serial = 0.1
+	threads := []int{1, 2, 4, 8}
+	speeds := make([]float64, len(threads))
+	for i, n := range threads {
+		speeds[i] = 1000 * AmdahlSpeedup(serial, n)
+	}
+	got := FitAmdahlSerialFraction(CalculateThreadScaling(threads, speeds))
+	if math.Abs(got-serial) > 1e-9 {
+		t.Errorf("FitAmdahlSerialFraction = %v, want %v", got, serial)
+	}
+	if limit := AmdahlLimit(got); math.Abs(limit-10) > 1e-6 {
+		t.Errorf("AmdahlLimit = %v, want 10", limit)
+	}
+
+	if got := FitAmdahlSerialFraction(CalculateThreadScaling([]int{1}, []float64{1000})); got != -1 {
+		t.Errorf("a single-thread run cannot be fitted, got %v", got)
+	}
+	perfect := CalculateThreadScaling([]int{1, 4}, []float64{1000, 4000})
+	if got := FitAmdahlSerialFraction(perfect); got != 0 || !math.IsInf(AmdahlLimit(got), 1) {
+		t.Errorf("perfect scaling should have no serial fraction, got %v", got)
+	}
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"math"
+	"sort"
+)
+
+// tCritical95 holds the two-sided 95% Student t critical values for 1 to 30 degrees of freedom
+var tCritical95 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// TCritical95 is the two-sided 95% Student t critical value for df degrees of
+// freedom; fractional df, as from Welch's formula, round down to stay conservative
+func TCritical95(df float64) float64 {
+	if df < 1 {
+		return math.Inf(1)
+	}
+	if df <= float64(len(tCritical95)) {
+		return tCritical95[int(df)-1]
+	}
+	// Beyond the table t approaches 1.96 roughly as 1/df
+	return 1.960 + 2.46/df
+}
+
+// RunSummary describes repeated measurements of the same quantity
+type RunSummary struct {
+	Runs   int     `json:"runs"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	StdDev float64 `json:"stddev"`
+	// Low and High bound the 95% confidence interval of the mean; they equal
+	// Mean when fewer than two runs leave no estimate of the noise
+	Low  float64 `json:"ci_low"`
+	High float64 `json:"ci_high"`
+}
+
+// SummarizeRuns returns the mean, median, sample standard deviation and 95%
+// confidence interval of the mean of values
+func SummarizeRuns(values []float64) RunSummary {
+	s := RunSummary{Runs: len(values)}
+	if len(values) == 0 {
+		return s
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	if n := len(sorted); n%2 == 1 {
+		s.Median = sorted[n/2]
+	} else {
+		s.Median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+
+	for _, v := range values {
+		s.Mean += v
+	}
+	s.Mean /= float64(len(values))
+	s.Low, s.High = s.Mean, s.Mean
+	if len(values) < 2 {
+		return s
+	}
+	var squares float64
+	for _, v := range values {
+		squares += (v - s.Mean) * (v - s.Mean)
+	}
+	s.StdDev = math.Sqrt(squares / float64(len(values)-1))
+	half := TCritical95(float64(len(values)-1)) * s.StdDev / math.Sqrt(float64(len(values)))
+	s.Low, s.High = s.Mean-half, s.Mean+half
+	return s
+}
+
+// RunComparison is the difference between two sets of runs, current minus baseline
+type RunComparison struct {
+	Difference float64 `json:"difference"`
+	// Low and High bound the 95% confidence interval of the difference (Welch)
+	Low  float64 `json:"ci_low"`
+	High float64 `json:"ci_high"`
+	// Significant reports whether the interval excludes zero, that is whether
+	// the difference exceeds the run-to-run noise
+	Significant bool `json:"significant"`
+}
+
+// CompareRuns compares current against baseline with Welch's t-test; both need
+// at least two runs, otherwise the difference is never significant
+func CompareRuns(baseline, current RunSummary) RunComparison {
+	c := RunComparison{Difference: current.Mean - baseline.Mean}
+	c.Low, c.High = c.Difference, c.Difference
+	if baseline.Runs < 2 || current.Runs < 2 {
+		return c
+	}
+	vb := baseline.StdDev * baseline.StdDev / float64(baseline.Runs)
+	vc := current.StdDev * current.StdDev / float64(current.Runs)
+	if vb+vc == 0 {
+		c.Significant = c.Difference != 0
+		return c
+	}
+	// Welch-Satterthwaite degrees of freedom
+	df := (vb + vc) * (vb + vc) / (vb*vb/float64(baseline.Runs-1) + vc*vc/float64(current.Runs-1))
+	half := TCritical95(df) * math.Sqrt(vb+vc)
+	c.Low, c.High = c.Difference-half, c.Difference+half
+	c.Significant = c.Low > 0 || c.High < 0
+	return c
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarizeRuns(t *testing.T) {
+	s := SummarizeRuns([]float64{10, 12, 11, 13, 9})
+	if s.Runs != 5 || s.Mean != 11 || s.Median != 11 {
+		t.Errorf("summary = %+v", s)
+	}
+	if math.Abs(s.StdDev-math.Sqrt(2.5)) > 1e-9 {
+		t.Errorf("StdDev = %v, want %v", s.StdDev, math.Sqrt(2.5))
+	}
+	half := 2.776 * math.Sqrt(2.5) / math.Sqrt(5)
+	if math.Abs(s.Low-(11-half)) > 1e-9 || math.Abs(s.High-(11+half)) > 1e-9 {
+		t.Errorf("interval = [%v, %v], want 11 ± %v", s.Low, s.High, half)
+	}
+
+	if s := SummarizeRuns([]float64{4, 1, 3, 2}); s.Median != 2.5 {
+		t.Errorf("even median = %v, want 2.5", s.Median)
+	}
+	if s := SummarizeRuns([]float64{7}); s.StdDev != 0 || s.Low != 7 || s.High != 7 {
+		t.Errorf("single run = %+v", s)
+	}
+}
+
+func TestCompareRuns(t *testing.T) {
+	baseline := SummarizeRuns([]float64{1000, 1010, 990, 1005, 995})
+
+	// A 10% gain is far outside noise of about 1%
+	faster := CompareRuns(baseline, SummarizeRuns([]float64{1100, 1110, 1090, 1105, 1095}))
+	if !faster.Significant || faster.Difference != 100 || faster.Low <= 0 {
+		t.Errorf("faster = %+v", faster)
+	}
+
+	// A 0.5% change is not
+	same := CompareRuns(baseline, SummarizeRuns([]float64{1005, 1015, 995, 1010, 1000}))
+	if same.Significant || same.Low >= 0 || same.High <= 0 {
+		t.Errorf("within noise = %+v", same)
+	}
+
+	// Single runs give no estimate of noise
+	if c := CompareRuns(SummarizeRuns([]float64{1000}), SummarizeRuns([]float64{2000})); c.Significant {
+		t.Errorf("single runs compared as %+v", c)
+	}
+}
+
+func TestTCritical95(t *testing.T) {
+	if TCritical95(4) != 2.776 || TCritical95(4.7) != 2.776 {
+		t.Error("table lookup")
+	}
+	if got := TCritical95(60); math.Abs(got-2.000) > 0.005 {
+		t.Errorf("TCritical95(60) = %v, want about 2.000", got)
+	}
+	if !math.IsInf(TCritical95(0.5), 1) {
+		t.Error("df below 1 must not produce a finite interval")
+	}
+}
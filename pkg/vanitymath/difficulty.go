@@ -0,0 +1,121 @@
+// Package vanitymath holds the difficulty and probability math of vanity address
+// searches. Every command, the TUI, the API server and the library bindings use it,
+// so that a pattern shows the same difficulty, odds and ETAs everywhere.
+package vanitymath
+
+import (
+	"math"
+	"strings"
+)
+
+// BaseDifficulty is the expected attempts to match chars hex characters ignoring case
+func BaseDifficulty(chars int) float64 {
+	return math.Pow(16, float64(chars))
+}
+
+// DifficultyBreakdown splits pattern difficulty into the hex match and the case requirement
+type DifficultyBreakdown struct {
+	// Base is 16^n for the n pattern characters, matched ignoring case
+	Base float64 `json:"base"`
+	// CaseFactor is 2^k for the k letters whose EIP-55 case must match
+	CaseFactor float64 `json:"case_factor"`
+	// CaseSensitiveChars is k, the number of letters with a required case
+	CaseSensitiveChars int `json:"case_sensitive_chars"`
+	// Total is Base * CaseFactor
+	Total float64 `json:"total"`
+}
+
+// Breakdown computes difficulty per pattern character: every hex character matches
+// with probability 1/16, and with case-sensitive matching each letter must also have
+// the requested case, which the checksum hash fixes with probability 1/2. Digits
+// have no case, so they add nothing to the case factor.
+func Breakdown(prefix, suffix string, caseSensitive bool) DifficultyBreakdown {
+	pattern := prefix + suffix
+	breakdown := DifficultyBreakdown{Base: BaseDifficulty(len(pattern)), CaseFactor: 1}
+	if caseSensitive {
+		for _, char := range pattern {
+			if (char >= 'a' && char <= 'f') || (char >= 'A' && char <= 'F') {
+				breakdown.CaseFactor *= 2
+				breakdown.CaseSensitiveChars++
+			}
+		}
+	}
+	breakdown.Total = breakdown.Base * breakdown.CaseFactor
+	return breakdown
+}
+
+// Difficulty is the expected attempts to find an address with prefix and suffix
+func Difficulty(prefix, suffix string, caseSensitive bool) float64 {
+	return Breakdown(prefix, suffix, caseSensitive).Total
+}
+
+// RejectionProbability estimates the share of random addresses with length free
+// characters from alphabet that contain at least one of words, ignoring case. Each
+// word is treated as independent of the others and of the positions it overlaps.
+func RejectionProbability(words []string, length int, alphabet string) float64 {
+	accepted := 1.0
+	for _, word := range words {
+		positions := length - len(word) + 1
+		if positions <= 0 {
+			continue
+		}
+		hit := 1.0
+		for _, char := range word {
+			hit *= float64(countFold(alphabet, char)) / float64(len(alphabet))
+		}
+		accepted *= math.Pow(1-hit, float64(positions))
+	}
+	return 1 - accepted
+}
+
+// countFold counts the symbols of alphabet equal to char ignoring case
+func countFold(alphabet string, char rune) int {
+	count := 0
+	for _, symbol := range alphabet {
+		if strings.EqualFold(string(symbol), string(char)) {
+			count++
+		}
+	}
+	return count
+}
+
+// ZeroByteProbability is the chance that a random Ethereum address with prefix and
+// suffix has at least min zero bytes. A byte whose two hex characters the pattern
+// fixes is zero or not for certain; every free character is 0 with probability 1/16.
+func ZeroByteProbability(prefix, suffix string, min int) float64 {
+	if min <= 0 {
+		return 1
+	}
+	const digits = 40
+	nibble := func(i int) float64 {
+		var char byte
+		switch {
+		case i < len(prefix):
+			char = prefix[i]
+		case i >= digits-len(suffix):
+			char = suffix[i-(digits-len(suffix))]
+		default:
+			return 1.0 / 16
+		}
+		if char == '0' {
+			return 1
+		}
+		return 0
+	}
+	// exactly[n] is the chance of n zero bytes among the bytes seen so far
+	exactly := []float64{1}
+	for b := 0; b < digits/2; b++ {
+		zero := nibble(2*b) * nibble(2*b+1)
+		next := make([]float64, len(exactly)+1)
+		for n, p := range exactly {
+			next[n] += p * (1 - zero)
+			next[n+1] += p * zero
+		}
+		exactly = next
+	}
+	atLeast := 0.0
+	for n := min; n < len(exactly); n++ {
+		atLeast += exactly[n]
+	}
+	return atLeast
+}
@@ -0,0 +1,83 @@
+package vanitymath
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// hexPattern maps arbitrary bytes to a pattern of up to 20 hex characters
+func hexPattern(raw []byte) string {
+	const alphabet = "0123456789abcdefABCDEF"
+	var b strings.Builder
+	for i, c := range raw {
+		if i == 20 {
+			break
+		}
+		b.WriteByte(alphabet[int(c)%len(alphabet)])
+	}
+	return b.String()
+}
+
+func TestBreakdown_Properties(t *testing.T) {
+	check(t, func(rawPrefix, rawSuffix []byte, caseSensitive bool) bool {
+		prefix, suffix := hexPattern(rawPrefix), hexPattern(rawSuffix)
+		b := Breakdown(prefix, suffix, caseSensitive)
+		chars := len(prefix) + len(suffix)
+		return b.Base == BaseDifficulty(chars) &&
+			b.CaseFactor == math.Pow(2, float64(b.CaseSensitiveChars)) &&
+			b.Total == Difficulty(prefix, suffix, caseSensitive) &&
+			b.Total >= b.Base &&
+			(caseSensitive || b.CaseSensitiveChars == 0)
+	})
+}
+
+// A longer pattern is always harder, and case sensitivity never makes it easier
+func TestDifficulty_Monotone(t *testing.T) {
+	check(t, func(raw []byte, extra byte, caseSensitive bool) bool {
+		pattern := hexPattern(raw)
+		if len(pattern) == 20 {
+			pattern = pattern[:19]
+		}
+		longer := pattern + hexPattern([]byte{extra})
+		return Difficulty(longer, "", caseSensitive) > Difficulty(pattern, "", caseSensitive) &&
+			Difficulty(pattern, "", true) >= Difficulty(pattern, "", false) &&
+			Difficulty(pattern, "", caseSensitive) == Difficulty("", pattern, caseSensitive)
+	})
+}
+
+func TestRejectionProbability_Properties(t *testing.T) {
+	check(t, func(raw []byte, length uint8) bool {
+		word := hexPattern(raw)
+		if word == "" {
+			return true
+		}
+		n := int(length % 64)
+		p := RejectionProbability([]string{word}, n, "0123456789abcdef")
+		more := RejectionProbability([]string{word}, n+1, "0123456789abcdef")
+		both := RejectionProbability([]string{word, word + "0"}, n, "0123456789abcdef")
+		return p >= 0 && p < 1 && more >= p && both >= p && (n >= len(word) || p == 0)
+	})
+}
+
+func TestZeroByteProbability(t *testing.T) {
+	if p := ZeroByteProbability("", "", 0); p != 1 {
+		t.Errorf("ZeroByteProbability(0) = %v, want 1", p)
+	}
+	// One of 20 free bytes: 1 - (255/256)^20
+	if p, want := ZeroByteProbability("", "", 1), 1-math.Pow(255.0/256, 20); math.Abs(p-want) > 1e-12 {
+		t.Errorf("ZeroByteProbability(1) = %v, want %v", p, want)
+	}
+	if p := ZeroByteProbability("0000", "", 2); p != 1 {
+		t.Errorf("a pattern with two zero bytes has ZeroByteProbability %v, want 1", p)
+	}
+	if p := ZeroByteProbability("ab", "", 20); p != 0 {
+		t.Errorf("a pattern with a nonzero byte has ZeroByteProbability(20) %v, want 0", p)
+	}
+	check(t, func(raw []byte, min uint8) bool {
+		prefix := hexPattern(raw)
+		n := int(min % 21)
+		p, more := ZeroByteProbability(prefix, "", n), ZeroByteProbability(prefix, "", n+1)
+		return p >= 0 && p <= 1+1e-12 && more <= p
+	})
+}
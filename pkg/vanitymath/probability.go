@@ -0,0 +1,98 @@
+package vanitymath
+
+import "math"
+
+// Probability is the chance of at least one match in attempts tries at difficulty.
+// It is computed in log space, so it stays accurate when 1/difficulty is below
+// the float64 epsilon.
+func Probability(difficulty float64, attempts int64) float64 {
+	switch {
+	case difficulty <= 0 || attempts <= 0:
+		return 0
+	case difficulty <= 1:
+		return 1
+	}
+	return -math.Expm1(float64(attempts) * math.Log1p(-1/difficulty))
+}
+
+// AttemptsForProbability is the number of attempts needed for the given chance of a
+// match (0 < probability < 1); -1 means no realistic number of attempts reaches it
+func AttemptsForProbability(difficulty, probability float64) int64 {
+	switch {
+	case difficulty <= 0 || probability <= 0:
+		return 0
+	case difficulty <= 1:
+		return 1
+	case probability >= 1:
+		return -1
+	}
+	result := math.Log1p(-probability) / math.Log1p(-1/difficulty)
+	if math.IsInf(result, 0) || math.IsNaN(result) || result < 0 || result >= math.MaxInt64 {
+		return -1
+	}
+	return int64(math.Ceil(result))
+}
+
+// Attempts50 is the number of attempts for an even chance of a match, or -1
+func Attempts50(difficulty float64) int64 {
+	return AttemptsForProbability(difficulty, 0.5)
+}
+
+// AttemptsForWallets is the total attempts needed to find wallets matches with the
+// given probability (0 < probability < 1). One wallet follows the geometric
+// distribution; several use its negative binomial sum via the Poisson approximation.
+// It returns -1 if nearly impossible.
+func AttemptsForWallets(difficulty float64, wallets int, probability float64) int64 {
+	if wallets <= 1 {
+		return AttemptsForProbability(difficulty, probability)
+	}
+	if difficulty <= 0 || probability <= 0 {
+		return 0
+	}
+	if probability >= 1 {
+		return -1
+	}
+	result := math.Ceil(difficulty * gammaQuantile(wallets, probability))
+	if math.IsInf(result, 0) || math.IsNaN(result) || result >= math.MaxInt64 {
+		return -1
+	}
+	return int64(result)
+}
+
+// gammaQuantile finds x with P(Poisson(x) >= shape) = probability, the quantile of
+// the Gamma(shape, 1) distribution, by bisection
+func gammaQuantile(shape int, probability float64) float64 {
+	lo, hi := 0.0, float64(shape)
+	for poissonTail(hi, shape) < probability {
+		lo, hi = hi, hi*2
+	}
+	for i := 0; i < 100 && hi-lo > 1e-9*hi; i++ {
+		mid := (lo + hi) / 2
+		if poissonTail(mid, shape) < probability {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// poissonTail returns P(Poisson(lambda) >= k), summing the lower terms in log space
+func poissonTail(lambda float64, k int) float64 {
+	if lambda <= 0 {
+		return 0
+	}
+	logLambda := math.Log(lambda)
+	maxLog := math.Inf(-1)
+	logs := make([]float64, k)
+	for i := range logs {
+		lgamma, _ := math.Lgamma(float64(i + 1))
+		logs[i] = -lambda + float64(i)*logLambda - lgamma
+		maxLog = math.Max(maxLog, logs[i])
+	}
+	var sum float64
+	for _, l := range logs {
+		sum += math.Exp(l - maxLog)
+	}
+	return 1 - math.Min(1, sum*math.Exp(maxLog))
+}
@@ -0,0 +1,152 @@
+package vanitymath
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// difficultyValue draws difficulties from 1 to 16^40, spread evenly in log space
+type difficultyValue float64
+
+func (difficultyValue) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(difficultyValue(math.Pow(16, r.Float64()*40)))
+}
+
+// attemptsValue draws attempt counts from 0 to 2^62, spread evenly in log space
+type attemptsValue int64
+
+func (attemptsValue) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(attemptsValue(math.Pow(2, r.Float64()*62)))
+}
+
+// probabilityValue draws probabilities strictly between 0 and 1
+type probabilityValue float64
+
+func (probabilityValue) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(probabilityValue(0.0001 + r.Float64()*0.9998))
+}
+
+func check(t *testing.T, property any) {
+	t.Helper()
+	if err := quick.Check(property, &quick.Config{MaxCount: 2000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProbability_Bounds(t *testing.T) {
+	check(t, func(d difficultyValue, n attemptsValue) bool {
+		p := Probability(float64(d), int64(n))
+		return p >= 0 && p <= 1 && !math.IsNaN(p)
+	})
+}
+
+func TestProbability_MonotoneInAttempts(t *testing.T) {
+	check(t, func(d difficultyValue, a, b attemptsValue) bool {
+		if a > b {
+			a, b = b, a
+		}
+		return Probability(float64(d), int64(a)) <= Probability(float64(d), int64(b))
+	})
+}
+
+func TestProbability_MonotoneInDifficulty(t *testing.T) {
+	check(t, func(a, b difficultyValue, n attemptsValue) bool {
+		if a > b {
+			a, b = b, a
+		}
+		return Probability(float64(a), int64(n)) >= Probability(float64(b), int64(n))
+	})
+}
+
+func TestAttemptsForProbability_Monotone(t *testing.T) {
+	check(t, func(d difficultyValue, p, q probabilityValue) bool {
+		if p > q {
+			p, q = q, p
+		}
+		a, b := AttemptsForProbability(float64(d), float64(p)), AttemptsForProbability(float64(d), float64(q))
+		// -1 (out of reach) may only follow reachable counts, never precede them
+		return a <= b || b == -1
+	})
+	check(t, func(a, b difficultyValue, p probabilityValue) bool {
+		if a > b {
+			a, b = b, a
+		}
+		x, y := AttemptsForProbability(float64(a), float64(p)), AttemptsForProbability(float64(b), float64(p))
+		return x <= y || y == -1
+	})
+}
+
+// The attempts returned are the smallest count reaching the probability
+func TestAttemptsForProbability_InvertsProbability(t *testing.T) {
+	check(t, func(d difficultyValue, p probabilityValue) bool {
+		n := AttemptsForProbability(float64(d), float64(p))
+		if n < 0 {
+			return float64(d) > 1e18
+		}
+		// Allow for rounding in the last place of the logarithms
+		const tolerance = 1e-9
+		reached := Probability(float64(d), n) >= float64(p)*(1-tolerance)
+		minimal := n <= 1 || Probability(float64(d), n-1) <= float64(p)*(1+tolerance)
+		return reached && minimal
+	})
+}
+
+func TestAttemptsForWallets_Monotone(t *testing.T) {
+	check(t, func(d difficultyValue, p probabilityValue, w uint8) bool {
+		wallets := int(w%50) + 1
+		difficulty := math.Min(float64(d), 1e15)
+		one, more := AttemptsForWallets(difficulty, wallets, float64(p)), AttemptsForWallets(difficulty, wallets+1, float64(p))
+		return one >= 0 && more >= one
+	})
+}
+
+// As difficulty grows without bound the odds vanish smoothly, with no NaN, no
+// overflow into negative counts and no sudden loss of precision
+func TestInfiniteDifficulty(t *testing.T) {
+	for _, d := range []float64{1e15, 1e17, 1e20, 1e30, 1e300, math.MaxFloat64, math.Inf(1)} {
+		p := Probability(d, 1000)
+		if math.IsNaN(p) || p < 0 || p > 1000/d*(1+1e-9) {
+			t.Errorf("Probability(%g, 1000) = %g, want about %g", d, p, 1000/d)
+		}
+		if d < 1e17 && p == 0 {
+			t.Errorf("Probability(%g, 1000) lost all precision", d)
+		}
+		if n := Attempts50(d); n != -1 && (n <= 0 || float64(n) < d*math.Ln2*(1-1e-9)) {
+			t.Errorf("Attempts50(%g) = %d", d, n)
+		}
+		if n := AttemptsForWallets(d, 3, 0.5); n != -1 && n <= 0 {
+			t.Errorf("AttemptsForWallets(%g, 3, 0.5) = %d", d, n)
+		}
+	}
+	// 16^20 is the longest pattern: its count does not fit in an int64
+	if n := Attempts50(BaseDifficulty(20)); n != -1 {
+		t.Errorf("Attempts50(16^20) = %d, want -1", n)
+	}
+}
+
+func TestEdgeCases(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"no attempts", Probability(256, 0), 0},
+		{"negative attempts", Probability(256, -5), 0},
+		{"no difficulty", Probability(0, 100), 0},
+		{"empty pattern", Probability(1, 1), 1},
+		{"one in two", Probability(2, 1), 0.5},
+		{"attempts for certainty", float64(AttemptsForProbability(256, 1)), -1},
+		{"attempts for nothing", float64(AttemptsForProbability(256, 0)), 0},
+		{"attempts for an empty pattern", float64(AttemptsForProbability(1, 0.99)), 1},
+		{"attempts50 of one in two", float64(Attempts50(2)), 1},
+		{"attempts50 of one hex char", float64(Attempts50(16)), 11},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -1,17 +1,30 @@
 package wallet
 
 import (
+	"fmt"
+	"strings"
 	"time"
+
+	"bloco-eth/pkg/utils"
+	"bloco-eth/pkg/vanitymath"
 )
 
 // Wallet represents an Ethereum wallet with address and private key
 type Wallet struct {
-	Address    string    `json:"address"`
-	PublicKey  string    `json:"public_key"`
-	PrivateKey string    `json:"private_key"`
-	Mnemonic   string    `json:"mnemonic,omitempty"`
-	Network    string    `json:"network,omitempty"`
-	CreatedAt  time.Time `json:"created_at"`
+	Address             string    `json:"address"`
+	PublicKey           string    `json:"public_key"`
+	PublicKeyCompressed string    `json:"public_key_compressed,omitempty"`
+	PrivateKey          string    `json:"private_key"`
+	Mnemonic            string    `json:"mnemonic,omitempty"`
+	Network             string    `json:"network,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+	Label               string    `json:"label,omitempty"`
+	Tags                []string  `json:"tags,omitempty"`
+	KeyHandle           string    `json:"key_handle,omitempty"`
+	// SeedCounter and SeedFingerprint identify the key of a --master-seed-file run:
+	// the counter it was derived at, and the seed without revealing it
+	SeedCounter     *uint64 `json:"seed_counter,omitempty"`
+	SeedFingerprint string  `json:"seed_fingerprint,omitempty"`
 }
 
 // GenerationResult represents the result of wallet generation
@@ -23,14 +36,24 @@ type GenerationResult struct {
 	WorkerID int           `json:"worker_id,omitempty"`
 }
 
+// MaxRejectionRate is the estimated share of matches reject words may throw away
+// before the criteria are refused as impractical
+const MaxRejectionRate = 0.99
+
 // GenerationCriteria defines the criteria for wallet generation
 type GenerationCriteria struct {
-	Network     string `json:"network"`
-	Prefix      string `json:"prefix"`
-	Suffix      string `json:"suffix"`
-	IsChecksum  bool   `json:"is_checksum"`
-	UseMnemonic bool   `json:"use_mnemonic,omitempty"`
-	MaxAttempts int64  `json:"max_attempts,omitempty"`
+	Network    string `json:"network"`
+	Prefix     string `json:"prefix"`
+	Suffix     string `json:"suffix"`
+	IsChecksum bool   `json:"is_checksum"`
+	// CaseSensitive requires the EIP-55 case of the pattern letters to match (needs IsChecksum)
+	CaseSensitive bool  `json:"case_sensitive,omitempty"`
+	UseMnemonic   bool  `json:"use_mnemonic,omitempty"`
+	MaxAttempts   int64 `json:"max_attempts,omitempty"`
+	// RejectWords are lowercase substrings a matching address must not contain anywhere
+	RejectWords []string `json:"reject_words,omitempty"`
+	// MinZeroBytes is the fewest zero bytes a matching Ethereum address may have
+	MinZeroBytes int `json:"min_zero_bytes,omitempty"`
 }
 
 // GenerationRequest represents a request for wallet generation
@@ -71,6 +94,12 @@ type BenchmarkResult struct {
 	ThreadUtilization     float64         `json:"thread_utilization"`
 	SpeedupVsSingleThread float64         `json:"speedup_vs_single_thread"`
 	AmdahlsLawLimit       float64         `json:"amdahls_law_limit"`
+	// The warm-up phase is excluded from every other figure
+	WarmupDuration time.Duration `json:"warmup_duration,omitempty"`
+	WarmupAttempts int64         `json:"warmup_attempts,omitempty"`
+	// Garbage collections and their stop-the-world pauses while sampling
+	GCCycles uint32        `json:"gc_cycles"`
+	GCPause  time.Duration `json:"gc_pause"`
 }
 
 // IsValid checks if a wallet is valid
@@ -104,6 +133,46 @@ func (gc *GenerationCriteria) GetPatternLength() int {
 	return len(gc.Prefix) + len(gc.Suffix)
 }
 
+// IsCaseSensitive reports whether pattern letters must match the checksum case exactly
+func (gc *GenerationCriteria) IsCaseSensitive() bool {
+	return gc.IsChecksum && gc.CaseSensitive
+}
+
+// RejectedWord returns the first reject word found in address, case-insensitively,
+// or "" when the address contains none
+func (gc *GenerationCriteria) RejectedWord(address string) string {
+	if len(gc.RejectWords) == 0 {
+		return ""
+	}
+	address = strings.ToLower(strings.TrimPrefix(address, "0x"))
+	for _, word := range gc.RejectWords {
+		if strings.Contains(address, word) {
+			return word
+		}
+	}
+	return ""
+}
+
+// RejectionRate estimates the share of matching addresses that contain a reject word
+func (gc *GenerationCriteria) RejectionRate() float64 {
+	if len(gc.RejectWords) == 0 {
+		return 0
+	}
+	alphabet, length := utils.AddressShape(gc.Network)
+	return vanitymath.RejectionProbability(gc.RejectWords, length-gc.GetPatternLength(), alphabet)
+}
+
+// Difficulty returns the expected attempts per match, including the matches thrown
+// away for containing a reject word or too few zero bytes
+func (gc *GenerationCriteria) Difficulty() float64 {
+	difficulty := vanitymath.Difficulty(gc.Prefix, gc.Suffix, gc.IsCaseSensitive())
+	difficulty /= 1 - gc.RejectionRate()
+	if gc.MinZeroBytes > 0 {
+		difficulty /= vanitymath.ZeroByteProbability(strings.ToLower(gc.Prefix), strings.ToLower(gc.Suffix), gc.MinZeroBytes)
+	}
+	return difficulty
+}
+
 // IsEmpty checks if the criteria has any pattern requirements
 func (gc *GenerationCriteria) IsEmpty() bool {
 	return gc.Prefix == "" && gc.Suffix == ""
@@ -129,6 +198,39 @@ func (gc *GenerationCriteria) Validate() error {
 			"suffix contains invalid hex characters")
 	}
 
+	if gc.CaseSensitive && !gc.IsChecksum {
+		return NewValidationError("criteria_validation",
+			"case-sensitive matching requires checksum validation")
+	}
+
+	// A pattern spelling a reject word could never be found
+	for _, word := range gc.RejectWords {
+		if word == "" {
+			return NewValidationError("criteria_validation", "reject words cannot be empty")
+		}
+		if strings.Contains(strings.ToLower(gc.Prefix), word) || strings.Contains(strings.ToLower(gc.Suffix), word) {
+			return NewValidationError("criteria_validation",
+				fmt.Sprintf("pattern contains the reject word %q", word))
+		}
+	}
+
+	if rate := gc.RejectionRate(); rate >= MaxRejectionRate {
+		return NewValidationError("criteria_validation",
+			fmt.Sprintf("reject words would throw away %.1f%% of matching addresses", rate*100))
+	}
+
+	if gc.MinZeroBytes != 0 {
+		switch {
+		case gc.Network != "" && gc.Network != "ethereum":
+			return NewValidationError("criteria_validation", "a minimum of zero bytes only applies to Ethereum addresses")
+		case gc.MinZeroBytes < 0 || gc.MinZeroBytes > 20:
+			return NewValidationError("criteria_validation", "minimum zero bytes must be between 0 and 20")
+		case vanitymath.ZeroByteProbability(strings.ToLower(gc.Prefix), strings.ToLower(gc.Suffix), gc.MinZeroBytes) == 0:
+			return NewValidationError("criteria_validation",
+				fmt.Sprintf("no address with this pattern has %d zero bytes", gc.MinZeroBytes))
+		}
+	}
+
 	// Max attempts validation
 	if gc.MaxAttempts < 0 {
 		return NewValidationError("criteria_validation",
@@ -141,7 +243,7 @@ func (gc *GenerationCriteria) Validate() error {
 // Update updates the generation stats with new attempt count
 func (gs *GenerationStats) Update(attempts int64) {
 	gs.CurrentAttempts = attempts
-	gs.Probability = computeProbability(gs.Difficulty, attempts) * 100
+	gs.Probability = vanitymath.Probability(gs.Difficulty, attempts) * 100
 
 	now := time.Now()
 	elapsed := now.Sub(gs.StartTime)
@@ -179,26 +281,6 @@ func isValidHex(hex string) bool {
 	return true
 }
 
-// computeProbability calculates the probability of finding an address after N attempts
-func computeProbability(difficulty float64, attempts int64) float64 {
-	if difficulty <= 0 {
-		return 0
-	}
-	return 1 - pow(1-1/difficulty, float64(attempts))
-}
-
-// pow is a simple power function to avoid importing math
-func pow(base, exp float64) float64 {
-	if exp == 0 {
-		return 1
-	}
-	result := 1.0
-	for i := 0; i < int(exp); i++ {
-		result *= base
-	}
-	return result
-}
-
 // Import the validation error from errors package
 // This would normally be imported, but for this example we'll define it locally
 type ValidationError struct {